@@ -29,12 +29,17 @@ type Memory struct {
 	lock  sync.Mutex
 	locks map[ids.ID]*rcLock
 	db    database.Database
+
+	handlersLock sync.RWMutex
+	// chainID -> handlers notified of the values put into its shared memory
+	putHandlers map[ids.ID][]PutHandler
 }
 
 func NewMemory(db database.Database) *Memory {
 	return &Memory{
-		locks: make(map[ids.ID]*rcLock),
-		db:    db,
+		locks:       make(map[ids.ID]*rcLock),
+		db:          db,
+		putHandlers: make(map[ids.ID][]PutHandler),
 	}
 }
 
@@ -0,0 +1,44 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package atomic
+
+import "github.com/ava-labs/avalanchego/ids"
+
+var _ Notifier = (*sharedMemory)(nil)
+
+// PutHandler is called with the elements that [peerChainID] put into the
+// shared memory of the chain the handler was registered by.
+type PutHandler func(peerChainID ids.ID, elems []*Element)
+
+// Notifier is optionally implemented by SharedMemory to notify a chain of the
+// values its peers make available to it.
+type Notifier interface {
+	// RegisterPutHandler registers [handler] to be called every time a peer
+	// chain puts values into this chain's shared memory. The handler is called
+	// after the values have been committed and must not block.
+	RegisterPutHandler(handler PutHandler)
+}
+
+func (sm *sharedMemory) RegisterPutHandler(handler PutHandler) {
+	sm.m.handlersLock.Lock()
+	defer sm.m.handlersLock.Unlock()
+
+	sm.m.putHandlers[sm.thisChainID] = append(sm.m.putHandlers[sm.thisChainID], handler)
+}
+
+// notifyPuts calls the put handlers of the chains that [sourceChainID] put
+// values into.
+func (m *Memory) notifyPuts(sourceChainID ids.ID, requests map[ids.ID]*Requests) {
+	m.handlersLock.RLock()
+	defer m.handlersLock.RUnlock()
+
+	for peerChainID, req := range requests {
+		if len(req.PutRequests) == 0 {
+			continue
+		}
+		for _, handler := range m.putHandlers[peerChainID] {
+			handler(sourceChainID, req.PutRequests)
+		}
+	}
+}
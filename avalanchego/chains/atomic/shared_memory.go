@@ -113,6 +113,17 @@ func (sm *sharedMemory) Indexed(
 }
 
 func (sm *sharedMemory) Apply(requests map[ids.ID]*Requests, batches ...database.Batch) error {
+	if err := sm.apply(requests, batches...); err != nil {
+		return err
+	}
+
+	// The handlers are notified after the shared databases have been released
+	// so that they are able to read the values that were put.
+	sm.m.notifyPuts(sm.thisChainID, requests)
+	return nil
+}
+
+func (sm *sharedMemory) apply(requests map[ids.ID]*Requests, batches ...database.Batch) error {
 	// Sorting here introduces an ordering over the locks to prevent any
 	// deadlocks
 	sharedIDs := make([]ids.ID, 0, len(requests))
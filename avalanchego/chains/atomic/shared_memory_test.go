@@ -6,6 +6,8 @@ package atomic
 import (
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
@@ -29,3 +31,45 @@ func TestSharedMemory(t *testing.T) {
 		test(t, chainID0, chainID1, sm0, sm1, testDB)
 	}
 }
+
+func TestSharedMemoryPutHandler(t *testing.T) {
+	require := require.New(t)
+
+	chainID0 := ids.GenerateTestID()
+	chainID1 := ids.GenerateTestID()
+
+	m := NewMemory(memdb.New())
+	sm0 := m.NewSharedMemory(chainID0)
+	sm1 := m.NewSharedMemory(chainID1)
+
+	var (
+		notifiedPeerChainID ids.ID
+		notifiedElems       []*Element
+	)
+	sm1.(Notifier).RegisterPutHandler(func(peerChainID ids.ID, elems []*Element) {
+		notifiedPeerChainID = peerChainID
+		notifiedElems = elems
+
+		// The values must be readable once the handler is called.
+		values, err := sm1.Get(peerChainID, [][]byte{elems[0].Key})
+		require.NoError(err)
+		require.Equal([][]byte{elems[0].Value}, values)
+	})
+
+	elems := []*Element{{
+		Key:   []byte{0},
+		Value: []byte{1},
+	}}
+	require.NoError(sm0.Apply(map[ids.ID]*Requests{chainID1: {
+		PutRequests: elems,
+	}}))
+	require.Equal(chainID0, notifiedPeerChainID)
+	require.Equal(elems, notifiedElems)
+
+	// Removals don't notify the handler.
+	notifiedElems = nil
+	require.NoError(sm1.Apply(map[ids.ID]*Requests{chainID0: {
+		RemoveRequests: [][]byte{{0}},
+	}}))
+	require.Nil(notifiedElems)
+}
@@ -11,8 +11,10 @@ import (
 	"fmt"
 	"io/fs"
 	"math"
+	"net"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -107,6 +109,7 @@ var (
 	errStakeMaxConsumptionBelowMin            = errors.New("stake max consumption can't be less than min stake consumption")
 	errStakeMintingPeriodBelowMin             = errors.New("stake minting period can't be less than max stake duration")
 	errCannotTrackPrimaryNetwork              = errors.New("cannot track primary network")
+	errInvalidHostname                        = errors.New("invalid hostname")
 	errStakingKeyContentUnset                 = fmt.Errorf("%s key not set but %s set", StakingTLSKeyContentKey, StakingCertContentKey)
 	errStakingCertContentUnset                = fmt.Errorf("%s key set but %s not set", StakingTLSKeyContentKey, StakingCertContentKey)
 	errMissingStakingSigningKeyFile           = errors.New("missing staking signing key file")
@@ -580,6 +583,7 @@ func getStateSyncConfig(v *viper.Viper) (node.StateSyncConfig, error) {
 func getBootstrapConfig(v *viper.Viper, networkID uint32) (node.BootstrapConfig, error) {
 	config := node.BootstrapConfig{
 		BootstrapBeaconConnectionTimeout:        v.GetDuration(BootstrapBeaconConnectionTimeoutKey),
+		BootstrapBeaconResolutionFrequency:      v.GetDuration(BootstrapBeaconResolutionFrequencyKey),
 		BootstrapMaxTimeGetAncestors:            v.GetDuration(BootstrapMaxTimeGetAncestorsKey),
 		BootstrapAncestorsMaxContainersSent:     int(v.GetUint(BootstrapAncestorsMaxContainersSentKey)),
 		BootstrapAncestorsMaxContainersReceived: int(v.GetUint(BootstrapAncestorsMaxContainersReceivedKey)),
@@ -596,8 +600,17 @@ func getBootstrapConfig(v *viper.Viper, networkID uint32) (node.BootstrapConfig,
 		return node.BootstrapConfig{}, fmt.Errorf("set %q but didn't set %q", BootstrapIDsKey, BootstrapIPsKey)
 	}
 
+	if config.BootstrapBeaconResolutionFrequency <= 0 {
+		return node.BootstrapConfig{}, fmt.Errorf("%q must be > 0", BootstrapBeaconResolutionFrequencyKey)
+	}
+
+	// Either the IP or the hostname of every bootstrapper is populated.
+	type bootstrapperAddr struct {
+		ip       ips.IPPort
+		hostname string
+	}
 	bootstrapIPs := strings.Split(v.GetString(BootstrapIPsKey), ",")
-	config.Bootstrappers = make([]genesis.Bootstrapper, 0, len(bootstrapIPs))
+	bootstrapAddrs := make([]bootstrapperAddr, 0, len(bootstrapIPs))
 	for _, bootstrapIP := range bootstrapIPs {
 		ip := strings.TrimSpace(bootstrapIP)
 		if ip == "" {
@@ -605,12 +618,20 @@ func getBootstrapConfig(v *viper.Viper, networkID uint32) (node.BootstrapConfig,
 		}
 
 		addr, err := ips.ToIPPort(ip)
-		if err != nil {
-			return node.BootstrapConfig{}, fmt.Errorf("couldn't parse bootstrap ip %s: %w", ip, err)
+		if err == nil {
+			bootstrapAddrs = append(bootstrapAddrs, bootstrapperAddr{
+				ip: addr,
+			})
+			continue
 		}
-		config.Bootstrappers = append(config.Bootstrappers, genesis.Bootstrapper{
-			// ID is populated below
-			IP: ips.IPDesc(addr),
+
+		hostname, port, hostnameErr := parseHostnamePort(ip)
+		if hostnameErr != nil {
+			return node.BootstrapConfig{}, fmt.Errorf("couldn't parse bootstrap ip %s: %w", ip, hostnameErr)
+		}
+		bootstrapAddrs = append(bootstrapAddrs, bootstrapperAddr{
+			ip:       ips.IPPort{Port: port},
+			hostname: hostname,
 		})
 	}
 
@@ -629,16 +650,44 @@ func getBootstrapConfig(v *viper.Viper, networkID uint32) (node.BootstrapConfig,
 		bootstrapNodeIDs = append(bootstrapNodeIDs, nodeID)
 	}
 
-	if len(config.Bootstrappers) != len(bootstrapNodeIDs) {
-		return node.BootstrapConfig{}, fmt.Errorf("expected the number of bootstrapIPs (%d) to match the number of bootstrapIDs (%d)", len(config.Bootstrappers), len(bootstrapNodeIDs))
+	if len(bootstrapAddrs) != len(bootstrapNodeIDs) {
+		return node.BootstrapConfig{}, fmt.Errorf("expected the number of bootstrapIPs (%d) to match the number of bootstrapIDs (%d)", len(bootstrapAddrs), len(bootstrapNodeIDs))
 	}
-	for i, nodeID := range bootstrapNodeIDs {
-		config.Bootstrappers[i].ID = nodeID
+	config.Bootstrappers = make([]genesis.Bootstrapper, 0, len(bootstrapAddrs))
+	for i, addr := range bootstrapAddrs {
+		if addr.hostname != "" {
+			config.HostnameBootstrappers = append(config.HostnameBootstrappers, genesis.HostnameBootstrapper{
+				ID:       bootstrapNodeIDs[i],
+				Hostname: addr.hostname,
+				Port:     addr.ip.Port,
+			})
+			continue
+		}
+		config.Bootstrappers = append(config.Bootstrappers, genesis.Bootstrapper{
+			ID: bootstrapNodeIDs[i],
+			IP: ips.IPDesc(addr.ip),
+		})
 	}
 
 	return config, nil
 }
 
+// parseHostnamePort parses [str] of the form hostname:port.
+func parseHostnamePort(str string) (string, uint16, error) {
+	hostname, portStr, err := net.SplitHostPort(str)
+	if err != nil {
+		return "", 0, err
+	}
+	port, err := strconv.ParseUint(portStr, 10 /*=base*/, 16 /*=size*/)
+	if err != nil {
+		return "", 0, err
+	}
+	if hostname == "" || strings.ContainsAny(hostname, " /:") {
+		return "", 0, fmt.Errorf("%w: %q", errInvalidHostname, hostname)
+	}
+	return hostname, uint16(port), nil
+}
+
 func getIPConfig(v *viper.Viper) (node.IPConfig, error) {
 	ipConfig := node.IPConfig{
 		PublicIP:                  v.GetString(PublicIPKey),
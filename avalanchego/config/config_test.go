@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net"
 	"os"
 	"path/filepath"
 	"testing"
@@ -17,9 +18,12 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/subnets"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/ips"
 )
 
 func TestGetChainConfigsFromFiles(t *testing.T) {
@@ -237,6 +241,65 @@ func TestGetChainConfigsFromFlags(t *testing.T) {
 	}
 }
 
+func TestGetBootstrapConfig(t *testing.T) {
+	var (
+		nodeID0 = ids.GenerateTestNodeID()
+		nodeID1 = ids.GenerateTestNodeID()
+		nodeID2 = ids.GenerateTestNodeID()
+	)
+	tests := map[string]struct {
+		ips                           string
+		ids                           string
+		expectedBootstrappers         []genesis.Bootstrapper
+		expectedHostnameBootstrappers []genesis.HostnameBootstrapper
+		expectedErr                   error
+	}{
+		"ipv4, ipv6 and hostname": {
+			ips: "127.0.0.1:9651, [::1]:9652, bootstrap.example.com:9653",
+			ids: fmt.Sprintf("%s,%s,%s", nodeID0, nodeID1, nodeID2),
+			expectedBootstrappers: []genesis.Bootstrapper{
+				{
+					ID: nodeID0,
+					IP: ips.IPDesc{IP: net.ParseIP("127.0.0.1"), Port: 9651},
+				},
+				{
+					ID: nodeID1,
+					IP: ips.IPDesc{IP: net.IPv6loopback, Port: 9652},
+				},
+			},
+			expectedHostnameBootstrappers: []genesis.HostnameBootstrapper{
+				{
+					ID:       nodeID2,
+					Hostname: "bootstrap.example.com",
+					Port:     9653,
+				},
+			},
+		},
+		"invalid hostname": {
+			ips:         "bad/host:9651",
+			ids:         nodeID0.String(),
+			expectedErr: errInvalidHostname,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			v := setupViperFlags()
+			v.Set(BootstrapIPsKey, test.ips)
+			v.Set(BootstrapIDsKey, test.ids)
+
+			config, err := getBootstrapConfig(v, constants.LocalID)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+			require.Equal(test.expectedBootstrappers, config.Bootstrappers)
+			require.Equal(test.expectedHostnameBootstrappers, config.HostnameBootstrappers)
+		})
+	}
+}
+
 func TestGetVMAliasesFromFile(t *testing.T) {
 	tests := map[string]struct {
 		givenJSON   string
@@ -297,9 +297,10 @@ func addNodeFlags(fs *pflag.FlagSet) {
 
 	// Bootstrapping
 	// TODO: combine "BootstrapIPsKey" and "BootstrapIDsKey" into one flag
-	fs.String(BootstrapIPsKey, "", "Comma separated list of bootstrap peer ips to connect to. Example: 127.0.0.1:9630,127.0.0.1:9631")
+	fs.String(BootstrapIPsKey, "", "Comma separated list of bootstrap peer ips, or hostnames, to connect to. Example: 127.0.0.1:9630,[::1]:9631,bootstrap.example.com:9651")
 	fs.String(BootstrapIDsKey, "", "Comma separated list of bootstrap peer ids to connect to. Example: NodeID-JR4dVmy6ffUGAKCBDkyCbeZbyHQBeDsET,NodeID-8CrVPQZ4VSqgL8zTdvL14G8HqAfrBr4z")
 	fs.Duration(BootstrapBeaconConnectionTimeoutKey, time.Minute, "Timeout before emitting a warn log when connecting to bootstrapping beacons")
+	fs.Duration(BootstrapBeaconResolutionFrequencyKey, time.Minute, "Frequency at which the hostnames of the bootstrapping beacons this node isn't connected to are resolved again")
 	fs.Duration(BootstrapMaxTimeGetAncestorsKey, 50*time.Millisecond, "Max Time to spend fetching a container and its ancestors when responding to a GetAncestors")
 	fs.Uint(BootstrapAncestorsMaxContainersSentKey, 2000, "Max number of containers in an Ancestors message sent by this node")
 	fs.Uint(BootstrapAncestorsMaxContainersReceivedKey, 2000, "This node reads at most this many containers from an incoming Ancestors message")
@@ -170,6 +170,7 @@ const (
 	HealthCheckAveragerHalflifeKey                     = "health-check-averager-halflife"
 	PluginDirKey                                       = "plugin-dir"
 	BootstrapBeaconConnectionTimeoutKey                = "bootstrap-beacon-connection-timeout"
+	BootstrapBeaconResolutionFrequencyKey              = "bootstrap-beacon-resolution-frequency"
 	BootstrapMaxTimeGetAncestorsKey                    = "bootstrap-max-time-get-ancestors"
 	BootstrapAncestorsMaxContainersSentKey             = "bootstrap-ancestors-max-containers-sent"
 	BootstrapAncestorsMaxContainersReceivedKey         = "bootstrap-ancestors-max-containers-received"
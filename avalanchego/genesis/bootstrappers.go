@@ -35,6 +35,14 @@ type Bootstrapper struct {
 	IP ips.IPDesc `json:"ip"`
 }
 
+// HostnameBootstrapper is a bootstrapper whose IP is resolved from a DNS
+// name, so that its IP can change without changing the config of the node.
+type HostnameBootstrapper struct {
+	ID       ids.NodeID `json:"id"`
+	Hostname string     `json:"hostname"`
+	Port     uint16     `json:"port"`
+}
+
 // GetBootstrappers returns all default bootstrappers for the provided network.
 func GetBootstrappers(networkID uint32) []Bootstrapper {
 	networkName := constants.NetworkIDToNetworkName[networkID]
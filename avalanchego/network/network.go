@@ -76,7 +76,8 @@ type Network interface {
 	Dispatch() error
 
 	// Attempt to connect to this IP. The network will never stop attempting to
-	// connect to this ID.
+	// connect to this ID. If this ID is already tracked with a different IP,
+	// the network attempts to connect to the new IP instead.
 	ManuallyTrack(nodeID ids.NodeID, ip ips.IPPort)
 
 	// PeerInfo returns information about peers. If [nodeIDs] is empty, returns
@@ -593,12 +594,20 @@ func (n *network) ManuallyTrack(nodeID ids.NodeID, ip ips.IPPort) {
 		return
 	}
 
-	_, isTracked := n.trackedIPs[nodeID]
-	if !isTracked {
-		tracked := newTrackedIP(ip)
-		n.trackedIPs[nodeID] = tracked
-		n.dial(nodeID, tracked)
+	tracked, isTracked := n.trackedIPs[nodeID]
+	switch {
+	case !isTracked:
+		tracked = newTrackedIP(ip)
+	case !tracked.ip.Equal(ip):
+		// The new IP is dialed without waiting for the backoff of the old
+		// IP.
+		tracked.stopTracking()
+		tracked = newTrackedIP(ip)
+	default:
+		return
 	}
+	n.trackedIPs[nodeID] = tracked
+	n.dial(nodeID, tracked)
 }
 
 func (n *network) track(ip *ips.ClaimedIPPort) error {
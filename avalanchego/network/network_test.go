@@ -657,6 +657,51 @@ func TestDialContext(t *testing.T) {
 	wg.Wait()
 }
 
+func TestManuallyTrackNewIP(t *testing.T) {
+	require := require.New(t)
+
+	_, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil})
+
+	dialer := newTestDialer()
+	network := networks[0]
+	network.dialer = dialer
+
+	var (
+		nodeID          = ids.GenerateTestNodeID()
+		oldIP, _        = dialer.NewListener()
+		newIP, listener = dialer.NewListener()
+		getTrackedIP    = func() *trackedIP {
+			network.peersLock.RLock()
+			defer network.peersLock.RUnlock()
+
+			return network.trackedIPs[nodeID]
+		}
+	)
+
+	network.ManuallyTrack(nodeID, oldIP.IPPort())
+	oldTrackedIP := getTrackedIP()
+	require.Equal(oldIP.IPPort(), oldTrackedIP.ip)
+
+	// Tracking the same IP again is a no-op.
+	network.ManuallyTrack(nodeID, oldIP.IPPort())
+	require.Same(oldTrackedIP, getTrackedIP())
+
+	network.ManuallyTrack(nodeID, newIP.IPPort())
+	require.Equal(newIP.IPPort(), getTrackedIP().ip)
+	select {
+	case <-oldTrackedIP.onStopTracking:
+	default:
+		require.FailNow("old IP is still tracked")
+	}
+
+	// The new IP is dialed.
+	_, err := listener.Accept()
+	require.NoError(err)
+
+	network.StartClose()
+	wg.Wait()
+}
+
 func TestAllowConnectionAsAValidator(t *testing.T) {
 	require := require.New(t)
 
@@ -52,6 +52,11 @@ type BloomFilter struct {
 
 	metrics *bloom.Metrics
 
+	// Parameters of [bloom]
+	targetElements int
+	numHashes      int
+	numEntries     int
+
 	maxCount int
 	bloom    *bloom.Filter
 	// salt is provided to eventually unblock collisions in Bloom. It's possible
@@ -71,6 +76,18 @@ func (b *BloomFilter) Has(gossipable Gossipable) bool {
 	return bloom.Contains(b.bloom, h[:], b.salt[:])
 }
 
+// TargetElements returns the number of elements that the bloom filter was
+// sized for when it was last reset.
+func (b *BloomFilter) TargetElements() int {
+	return b.targetElements
+}
+
+// FalsePositiveProbability returns the estimated probability of a false
+// positive of the bloom filter.
+func (b *BloomFilter) FalsePositiveProbability() float64 {
+	return bloom.EstimateFalsePositiveProbability(b.numHashes, b.numEntries, b.bloom.Count())
+}
+
 func (b *BloomFilter) Marshal() ([]byte, []byte) {
 	bloomBytes := b.bloom.Marshal()
 	// salt must be copied here to ensure the bytes aren't overwritten if salt
@@ -103,6 +120,22 @@ func ResetBloomFilterIfNeeded(
 	return err == nil, err
 }
 
+// ResizeBloomFilter resets a bloom filter so that it is sized for
+// [targetElements], which is raised to [minTargetElements] if needed. Unlike
+// [ResetBloomFilterIfNeeded], the bloom filter is reset even if it hasn't
+// breached [resetFalsePositiveProbability].
+func ResizeBloomFilter(
+	bloomFilter *BloomFilter,
+	targetElements int,
+) error {
+	return resetBloomFilter(
+		bloomFilter,
+		max(bloomFilter.minTargetElements, targetElements),
+		bloomFilter.targetFalsePositiveProbability,
+		bloomFilter.resetFalsePositiveProbability,
+	)
+}
+
 func resetBloomFilter(
 	bloomFilter *BloomFilter,
 	targetElements int,
@@ -122,6 +155,9 @@ func resetBloomFilter(
 		return err
 	}
 
+	bloomFilter.targetElements = targetElements
+	bloomFilter.numHashes = numHashes
+	bloomFilter.numEntries = numEntries
 	bloomFilter.maxCount = bloom.EstimateCount(numHashes, numEntries, resetFalsePositiveProbability)
 	bloomFilter.bloom = newBloom
 	bloomFilter.salt = newSalt
@@ -106,3 +106,27 @@ func TestBloomFilterRefresh(t *testing.T) {
 		})
 	}
 }
+
+func TestResizeBloomFilter(t *testing.T) {
+	require := require.New(t)
+
+	bloom, err := NewBloomFilter(prometheus.NewRegistry(), "", 10, 0.01, 0.05)
+	require.NoError(err)
+	require.Equal(10, bloom.TargetElements())
+	require.Zero(bloom.FalsePositiveProbability())
+
+	tx := &testTx{id: ids.ID{1}}
+	bloom.Add(tx)
+	require.Positive(bloom.FalsePositiveProbability())
+
+	// Resizing always resets the bloom filter
+	require.NoError(ResizeBloomFilter(bloom, 100))
+	require.Equal(100, bloom.TargetElements())
+	require.False(bloom.Has(tx))
+	require.Zero(bloom.FalsePositiveProbability())
+	require.Equal(float64(2), testutil.ToFloat64(bloom.metrics.ResetCount))
+
+	// The bloom filter is never smaller than the minimum
+	require.NoError(ResizeBloomFilter(bloom, 1))
+	require.Equal(10, bloom.TargetElements())
+}
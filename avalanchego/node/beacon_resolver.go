@@ -0,0 +1,118 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/ips"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const (
+	beaconResolutionTimeout = 10 * time.Second
+	// Delay before the hostnames that failed to resolve are resolved again
+	beaconResolutionRetryDelay = 5 * time.Second
+)
+
+// beaconResolver resolves the hostnames of the bootstrappers, and tracks their
+// IPs. The hostnames of the bootstrappers this node isn't connected to are
+// periodically resolved again, so that bootstrappers can be moved to new IPs
+// by updating their DNS records.
+type beaconResolver struct {
+	log       logging.Logger
+	beacons   []genesis.HostnameBootstrapper
+	frequency time.Duration
+
+	lookup    func(ctx context.Context, hostname string) (net.IP, error)
+	track     func(nodeID ids.NodeID, ip ips.IPPort)
+	connected func(nodeID ids.NodeID) bool
+
+	// nodeID --> last resolved IP
+	resolved map[ids.NodeID]ips.IPPort
+}
+
+func newBeaconResolver(
+	log logging.Logger,
+	beacons []genesis.HostnameBootstrapper,
+	frequency time.Duration,
+	track func(ids.NodeID, ips.IPPort),
+	connected func(ids.NodeID) bool,
+) *beaconResolver {
+	return &beaconResolver{
+		log:       log,
+		beacons:   beacons,
+		frequency: frequency,
+		lookup:    ips.LookupContext,
+		track:     track,
+		connected: connected,
+		resolved:  make(map[ids.NodeID]ips.IPPort),
+	}
+}
+
+// Dispatch resolves the hostnames until [ctx] is cancelled.
+func (r *beaconResolver) Dispatch(ctx context.Context) {
+	for {
+		delay := r.frequency
+		if !r.resolve(ctx) {
+			delay = min(delay, beaconResolutionRetryDelay)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// resolve the hostnames of the bootstrappers that this node isn't connected
+// to, and track their IPs if they changed. Returns false if any hostname
+// failed to resolve.
+func (r *beaconResolver) resolve(ctx context.Context) bool {
+	allResolved := true
+	for _, beacon := range r.beacons {
+		if r.connected(beacon.ID) {
+			continue
+		}
+
+		lookupCtx, cancel := context.WithTimeout(ctx, beaconResolutionTimeout)
+		ip, err := r.lookup(lookupCtx, beacon.Hostname)
+		cancel()
+		if err != nil {
+			r.log.Warn("couldn't resolve bootstrapper hostname",
+				zap.Stringer("nodeID", beacon.ID),
+				zap.String("hostname", beacon.Hostname),
+				zap.Error(err),
+			)
+			allResolved = false
+			continue
+		}
+
+		ipPort := ips.IPPort{
+			IP:   ip,
+			Port: beacon.Port,
+		}
+		if previous, ok := r.resolved[beacon.ID]; ok && previous.Equal(ipPort) {
+			continue
+		}
+		r.resolved[beacon.ID] = ipPort
+
+		r.log.Info("resolved bootstrapper hostname",
+			zap.Stringer("nodeID", beacon.ID),
+			zap.String("hostname", beacon.Hostname),
+			zap.Stringer("ip", ipPort),
+		)
+		r.track(beacon.ID, ipPort)
+	}
+	return allResolved
+}
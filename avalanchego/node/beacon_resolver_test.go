@@ -0,0 +1,84 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/ips"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+var errTestLookup = errors.New("test lookup failure")
+
+func TestBeaconResolverResolve(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		nodeID0 = ids.GenerateTestNodeID()
+		nodeID1 = ids.GenerateTestNodeID()
+
+		hostnameIPs = map[string]net.IP{
+			"a.example.com": net.ParseIP("10.0.0.1"),
+			"b.example.com": net.ParseIP("2001:db8::1"),
+		}
+		connected = set.Set[ids.NodeID]{}
+		tracked   = make(map[ids.NodeID]ips.IPPort)
+		numTracks int
+	)
+	r := newBeaconResolver(
+		logging.NoLog{},
+		[]genesis.HostnameBootstrapper{
+			{ID: nodeID0, Hostname: "a.example.com", Port: 9651},
+			{ID: nodeID1, Hostname: "b.example.com", Port: 9652},
+		},
+		0,
+		func(nodeID ids.NodeID, ip ips.IPPort) {
+			tracked[nodeID] = ip
+			numTracks++
+		},
+		connected.Contains,
+	)
+	r.lookup = func(_ context.Context, hostname string) (net.IP, error) {
+		ip, ok := hostnameIPs[hostname]
+		if !ok {
+			return nil, errTestLookup
+		}
+		return ip, nil
+	}
+
+	require.True(r.resolve(context.Background()))
+	require.Equal(map[ids.NodeID]ips.IPPort{
+		nodeID0: {IP: net.ParseIP("10.0.0.1"), Port: 9651},
+		nodeID1: {IP: net.ParseIP("2001:db8::1"), Port: 9652},
+	}, tracked)
+	require.Equal(2, numTracks)
+
+	// Unchanged IPs aren't tracked again.
+	require.True(r.resolve(context.Background()))
+	require.Equal(2, numTracks)
+
+	// Changed IPs are tracked, unless the beacon is connected.
+	hostnameIPs["a.example.com"] = net.ParseIP("10.0.0.2")
+	hostnameIPs["b.example.com"] = net.ParseIP("2001:db8::2")
+	connected.Add(nodeID1)
+	require.True(r.resolve(context.Background()))
+	require.Equal(ips.IPPort{IP: net.ParseIP("10.0.0.2"), Port: 9651}, tracked[nodeID0])
+	require.Equal(ips.IPPort{IP: net.ParseIP("2001:db8::1"), Port: 9652}, tracked[nodeID1])
+	require.Equal(3, numTracks)
+
+	// Failed resolutions are reported, and keep the previous IP tracked.
+	delete(hostnameIPs, "a.example.com")
+	require.False(r.resolve(context.Background()))
+	require.Equal(ips.IPPort{IP: net.ParseIP("10.0.0.2"), Port: 9651}, tracked[nodeID0])
+	require.Equal(3, numTracks)
+}
@@ -117,6 +117,13 @@ type BootstrapConfig struct {
 	BootstrapMaxTimeGetAncestors time.Duration `json:"bootstrapMaxTimeGetAncestors"`
 
 	Bootstrappers []genesis.Bootstrapper `json:"bootstrappers"`
+
+	// Bootstrappers whose IPs are resolved from their hostnames
+	HostnameBootstrappers []genesis.HostnameBootstrapper `json:"hostnameBootstrappers"`
+
+	// How often the hostnames of the bootstrappers this node isn't connected
+	// to are resolved again
+	BootstrapBeaconResolutionFrequency time.Duration `json:"bootstrapBeaconResolutionFrequency"`
 }
 
 type DatabaseConfig struct {
@@ -689,8 +689,26 @@ func (n *Node) Dispatch() error {
 		n.Net.ManuallyTrack(bootstrapper.ID, ips.IPPort(bootstrapper.IP))
 	}
 
+	// Add the bootstrap nodes with hostnames once their IPs are resolved
+	resolverCtx, cancelResolver := context.WithCancel(context.Background())
+	if len(n.Config.HostnameBootstrappers) != 0 {
+		resolver := newBeaconResolver(
+			n.Log,
+			n.Config.HostnameBootstrappers,
+			n.Config.BootstrapBeaconResolutionFrequency,
+			n.Net.ManuallyTrack,
+			func(nodeID ids.NodeID) bool {
+				return len(n.Net.PeerInfo([]ids.NodeID{nodeID})) != 0
+			},
+		)
+		go n.Log.RecoverAndPanic(func() {
+			resolver.Dispatch(resolverCtx)
+		})
+	}
+
 	// Start P2P connections
 	err := n.Net.Dispatch()
+	cancelResolver()
 
 	// If the P2P server isn't running, shut down the node.
 	// If node is already shutting down, this does nothing.
@@ -826,6 +844,11 @@ func (n *Node) initBootstrappers() error {
 			return err
 		}
 	}
+	for _, bootstrapper := range n.Config.HostnameBootstrappers {
+		if err := n.bootstrappers.AddStaker(constants.PrimaryNetworkID, bootstrapper.ID, nil, ids.Empty, 1); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -1198,40 +1221,38 @@ func (n *Node) initVMs() error {
 		return err
 	}
 
+	platformConfig := platformconfig.Config{
+		Chains:                        n.chainManager,
+		Validators:                    vdrs,
+		UptimeLockedCalculator:        n.uptimeCalculator,
+		SybilProtectionEnabled:        n.Config.SybilProtectionEnabled,
+		PartialSyncPrimaryNetwork:     n.Config.PartialSyncPrimaryNetwork,
+		TrackedSubnets:                n.Config.TrackedSubnets,
+		TxFee:                         n.Config.TxFee,
+		CreateAssetTxFee:              n.Config.CreateAssetTxFee,
+		CreateSubnetTxFee:             n.Config.CreateSubnetTxFee,
+		TransformSubnetTxFee:          n.Config.TransformSubnetTxFee,
+		CreateBlockchainTxFee:         n.Config.CreateBlockchainTxFee,
+		AddPrimaryNetworkValidatorFee: n.Config.AddPrimaryNetworkValidatorFee,
+		AddPrimaryNetworkDelegatorFee: n.Config.AddPrimaryNetworkDelegatorFee,
+		AddSubnetValidatorFee:         n.Config.AddSubnetValidatorFee,
+		AddSubnetDelegatorFee:         n.Config.AddSubnetDelegatorFee,
+		UptimePercentage:              n.Config.UptimeRequirement,
+		MinValidatorStake:             n.Config.MinValidatorStake,
+		MaxValidatorStake:             n.Config.MaxValidatorStake,
+		MinDelegatorStake:             n.Config.MinDelegatorStake,
+		MinDelegationFee:              n.Config.MinDelegationFee,
+		MinStakeDuration:              n.Config.MinStakeDuration,
+		MaxStakeDuration:              n.Config.MaxStakeDuration,
+		RewardConfig:                  n.Config.RewardConfig,
+		UseCurrentHeight:              n.Config.UseCurrentHeight,
+	}
+	platformConfig.SetNetworkUpgrades(n.Config.NetworkID)
+
 	// Register the VMs that Avalanche supports
 	err := utils.Err(
 		n.VMManager.RegisterFactory(context.TODO(), constants.PlatformVMID, &platformvm.Factory{
-			Config: platformconfig.Config{
-				Chains:                        n.chainManager,
-				Validators:                    vdrs,
-				UptimeLockedCalculator:        n.uptimeCalculator,
-				SybilProtectionEnabled:        n.Config.SybilProtectionEnabled,
-				PartialSyncPrimaryNetwork:     n.Config.PartialSyncPrimaryNetwork,
-				TrackedSubnets:                n.Config.TrackedSubnets,
-				TxFee:                         n.Config.TxFee,
-				CreateAssetTxFee:              n.Config.CreateAssetTxFee,
-				CreateSubnetTxFee:             n.Config.CreateSubnetTxFee,
-				TransformSubnetTxFee:          n.Config.TransformSubnetTxFee,
-				CreateBlockchainTxFee:         n.Config.CreateBlockchainTxFee,
-				AddPrimaryNetworkValidatorFee: n.Config.AddPrimaryNetworkValidatorFee,
-				AddPrimaryNetworkDelegatorFee: n.Config.AddPrimaryNetworkDelegatorFee,
-				AddSubnetValidatorFee:         n.Config.AddSubnetValidatorFee,
-				AddSubnetDelegatorFee:         n.Config.AddSubnetDelegatorFee,
-				UptimePercentage:              n.Config.UptimeRequirement,
-				MinValidatorStake:             n.Config.MinValidatorStake,
-				MaxValidatorStake:             n.Config.MaxValidatorStake,
-				MinDelegatorStake:             n.Config.MinDelegatorStake,
-				MinDelegationFee:              n.Config.MinDelegationFee,
-				MinStakeDuration:              n.Config.MinStakeDuration,
-				MaxStakeDuration:              n.Config.MaxStakeDuration,
-				RewardConfig:                  n.Config.RewardConfig,
-				ApricotPhase3Time:             version.GetApricotPhase3Time(n.Config.NetworkID),
-				ApricotPhase5Time:             version.GetApricotPhase5Time(n.Config.NetworkID),
-				BanffTime:                     version.GetBanffTime(n.Config.NetworkID),
-				CortinaTime:                   version.GetCortinaTime(n.Config.NetworkID),
-				DurangoTime:                   durangoTime,
-				UseCurrentHeight:              n.Config.UseCurrentHeight,
-			},
+			Config: platformConfig,
 		}),
 		n.VMManager.RegisterFactory(context.TODO(), constants.AVMID, &avm.Factory{
 			Config: avmconfig.Config{
@@ -10,3 +10,6 @@ source "$AVALANCHE_PATH"/scripts/constants.sh
 # Ensure execution of fixture unit tests under tests/ but exclude ginkgo tests in tests/e2e and tests/upgrade
 # shellcheck disable=SC2046
 go test -shuffle=on -race -timeout="${TIMEOUT:-120s}" -coverprofile="coverage.out" -covermode="atomic" $(go list ./... | grep -v /mocks | grep -v proto | grep -v tests/e2e | grep -v tests/upgrade)
+
+# Run the platformvm tests again with the fault injection points compiled in
+go test -shuffle=on -race -timeout="${TIMEOUT:-120s}" -tags faults ./vms/platformvm/...
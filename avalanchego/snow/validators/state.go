@@ -34,6 +34,17 @@ type State interface {
 	) (map[ids.NodeID]*GetValidatorOutput, error)
 }
 
+// CachedState is an optional extension of State that can serve some of the
+// validator sets it previously generated without requiring its lock.
+type CachedState interface {
+	// GetCachedValidatorSet returns the validators of the provided subnet at
+	// the requested P-chain height, if they are cached.
+	// It is safe to call GetCachedValidatorSet without holding the lock that
+	// guards the State.
+	// The returned map should not be modified.
+	GetCachedValidatorSet(height uint64, subnetID ids.ID) (map[ids.NodeID]*GetValidatorOutput, bool)
+}
+
 type lockedState struct {
 	lock sync.Locker
 	s    State
@@ -72,6 +83,12 @@ func (s *lockedState) GetValidatorSet(
 	height uint64,
 	subnetID ids.ID,
 ) (map[ids.NodeID]*GetValidatorOutput, error) {
+	if cached, ok := s.s.(CachedState); ok {
+		if vdrs, ok := cached.GetCachedValidatorSet(height, subnetID); ok {
+			return vdrs, nil
+		}
+	}
+
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
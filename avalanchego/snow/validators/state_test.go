@@ -0,0 +1,62 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validators
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+type testCachedState struct {
+	*TestState
+
+	cached map[ids.ID]map[ids.NodeID]*GetValidatorOutput
+}
+
+func (s *testCachedState) GetCachedValidatorSet(_ uint64, subnetID ids.ID) (map[ids.NodeID]*GetValidatorOutput, bool) {
+	vdrs, ok := s.cached[subnetID]
+	return vdrs, ok
+}
+
+func TestLockedStateGetCachedValidatorSet(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		cachedSubnetID = ids.GenerateTestID()
+		cachedVdrs     = map[ids.NodeID]*GetValidatorOutput{
+			ids.GenerateTestNodeID(): {Weight: 1},
+		}
+		vdrs = map[ids.NodeID]*GetValidatorOutput{
+			ids.GenerateTestNodeID(): {Weight: 2},
+		}
+		lock sync.Mutex
+	)
+	s := NewLockedState(&lock, &testCachedState{
+		TestState: &TestState{
+			T: t,
+			GetValidatorSetF: func(context.Context, uint64, ids.ID) (map[ids.NodeID]*GetValidatorOutput, error) {
+				return vdrs, nil
+			},
+		},
+		cached: map[ids.ID]map[ids.NodeID]*GetValidatorOutput{
+			cachedSubnetID: cachedVdrs,
+		},
+	})
+
+	// Cached sets are served while the lock is held by someone else.
+	lock.Lock()
+	gotVdrs, err := s.GetValidatorSet(context.Background(), 1, cachedSubnetID)
+	require.NoError(err)
+	require.Equal(cachedVdrs, gotVdrs)
+	lock.Unlock()
+
+	gotVdrs, err = s.GetValidatorSet(context.Background(), 1, ids.GenerateTestID())
+	require.NoError(err)
+	require.Equal(vdrs, gotVdrs)
+}
@@ -109,3 +109,23 @@ func EstimateCount(numHashes, numEntries int, falsePositiveProbability float64)
 	}
 	return int(count)
 }
+
+// EstimateFalsePositiveProbability estimates the probability of a false
+// positive of a bloom filter with [numHashes] and [numEntries] after [count]
+// additions.
+//
+// It is guaranteed to return a value in the range [0, 1].
+//
+// ref: https://en.wikipedia.org/wiki/Bloom_filter
+func EstimateFalsePositiveProbability(numHashes, numEntries, count int) float64 {
+	switch {
+	case numHashes <= 0, count <= 0:
+		return 0
+	case numEntries <= 0:
+		return 1
+	}
+
+	numBits := float64(numEntries) * bitsPerByte
+	exp := -float64(numHashes) * float64(count) / numBits
+	return math.Pow(1-math.Exp(exp), float64(numHashes))
+}
@@ -201,3 +201,22 @@ func FuzzEstimateEntries(f *testing.F) {
 		require.GreaterOrEqual(t, entries, 0)
 	})
 }
+
+func TestEstimateFalsePositiveProbability(t *testing.T) {
+	numHashes, numEntries := OptimalParameters(10_000, .01)
+
+	require.Zero(t, EstimateFalsePositiveProbability(numHashes, numEntries, 0))
+	require.InDelta(t, .01, EstimateFalsePositiveProbability(numHashes, numEntries, 10_000), .001)
+
+	// The estimates are consistent with [EstimateCount]
+	count := EstimateCount(numHashes, numEntries, .05)
+	require.InDelta(t, .05, EstimateFalsePositiveProbability(numHashes, numEntries, count), .001)
+}
+
+func FuzzEstimateFalsePositiveProbability(f *testing.F) {
+	f.Fuzz(func(t *testing.T, numHashes, numEntries, count int) {
+		p := EstimateFalsePositiveProbability(numHashes, numEntries, count)
+		require.GreaterOrEqual(t, p, 0.)
+		require.LessOrEqual(t, p, 1.)
+	})
+}
@@ -0,0 +1,107 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package keychain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+var (
+	_ Keychain     = (*remoteKeychain)(nil)
+	_ Signer       = (*remoteSigner)(nil)
+	_ RemoteSigner = RemoteSignerFunc(nil)
+
+	ErrWrongRemoteSigner = errors.New("remote signature wasn't made by the requested address")
+)
+
+// RemoteSigner signs hashes with keys that are held outside of this process,
+// such as by a KMS, an HSM or a signing service.
+type RemoteSigner interface {
+	// SignHash returns the recoverable secp256k1 signature of [hash] by the key
+	// that controls [addr].
+	SignHash(addr ids.ShortID, hash []byte) ([]byte, error)
+}
+
+// RemoteSignerFunc allows a function to be used as a RemoteSigner.
+type RemoteSignerFunc func(addr ids.ShortID, hash []byte) ([]byte, error)
+
+func (f RemoteSignerFunc) SignHash(addr ids.ShortID, hash []byte) ([]byte, error) {
+	return f(addr, hash)
+}
+
+// remoteKeychain is a keychain whose signatures are made by a RemoteSigner
+type remoteKeychain struct {
+	signer RemoteSigner
+	addrs  set.Set[ids.ShortID]
+}
+
+// remoteSigner signs for a single address with a RemoteSigner
+type remoteSigner struct {
+	signer RemoteSigner
+	addr   ids.ShortID
+}
+
+// NewRemoteKeychain returns a keychain that can sign for [addrs] with
+// [signer].
+//
+// Every signature returned by [signer] is checked to have been made by the
+// requested address, so a misbehaving signer can't produce invalid
+// credentials.
+func NewRemoteKeychain(signer RemoteSigner, addrs ...ids.ShortID) Keychain {
+	return &remoteKeychain{
+		signer: signer,
+		addrs:  set.Of(addrs...),
+	}
+}
+
+func (r *remoteKeychain) Addresses() set.Set[ids.ShortID] {
+	return r.addrs
+}
+
+func (r *remoteKeychain) Get(addr ids.ShortID) (Signer, bool) {
+	if !r.addrs.Contains(addr) {
+		return nil, false
+	}
+	return &remoteSigner{
+		signer: r.signer,
+		addr:   addr,
+	}, true
+}
+
+// expects to receive a hash of the unsigned tx bytes
+func (r *remoteSigner) SignHash(hash []byte) ([]byte, error) {
+	sig, err := r.signer.SignHash(r.addr, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	pk, err := secp256k1.RecoverPublicKeyFromHash(hash, sig)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't recover remote signer: %w", err)
+	}
+	if signerAddr := pk.Address(); signerAddr != r.addr {
+		return nil, fmt.Errorf(
+			"%w: expected %s, got %s",
+			ErrWrongRemoteSigner,
+			r.addr,
+			signerAddr,
+		)
+	}
+	return sig, nil
+}
+
+// expects to receive the unsigned tx bytes
+func (r *remoteSigner) Sign(b []byte) ([]byte, error) {
+	return r.SignHash(hashing.ComputeHash256(b))
+}
+
+func (r *remoteSigner) Address() ids.ShortID {
+	return r.addr
+}
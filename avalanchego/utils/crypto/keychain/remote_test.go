@@ -0,0 +1,66 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package keychain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+func TestRemoteKeychain(t *testing.T) {
+	require := require.New(t)
+
+	key, err := secp256k1.NewPrivateKey()
+	require.NoError(err)
+	otherKey, err := secp256k1.NewPrivateKey()
+	require.NoError(err)
+
+	var (
+		addr      = key.Address()
+		otherAddr = otherKey.Address()
+		keys      = map[ids.ShortID]*secp256k1.PrivateKey{
+			addr: key,
+			// The remote signer is misconfigured and signs for [otherAddr]
+			// with [key].
+			otherAddr: key,
+		}
+	)
+	signer := RemoteSignerFunc(func(addr ids.ShortID, hash []byte) ([]byte, error) {
+		key, ok := keys[addr]
+		if !ok {
+			return nil, errTest
+		}
+		return key.SignHash(hash)
+	})
+
+	kc := NewRemoteKeychain(signer, addr, otherAddr)
+	require.Equal(set.Of(addr, otherAddr), kc.Addresses())
+
+	_, ok := kc.Get(ids.GenerateTestShortID())
+	require.False(ok)
+
+	s, ok := kc.Get(addr)
+	require.True(ok)
+	require.Equal(addr, s.Address())
+
+	msg := []byte("hello")
+	sig, err := s.Sign(msg)
+	require.NoError(err)
+
+	pk, err := secp256k1.RecoverPublicKeyFromHash(hashing.ComputeHash256(msg), sig)
+	require.NoError(err)
+	require.Equal(addr, pk.Address())
+
+	s, ok = kc.Get(otherAddr)
+	require.True(ok)
+
+	_, err = s.Sign(msg)
+	require.ErrorIs(err, ErrWrongRemoteSigner)
+}
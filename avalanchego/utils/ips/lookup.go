@@ -4,6 +4,7 @@
 package ips
 
 import (
+	"context"
 	"errors"
 	"net"
 )
@@ -16,19 +17,24 @@ var errNoIPsFound = errors.New("no IPs found")
 //
 // Note: IPv4 is preferred because `net.Listen` prefers IPv4.
 func Lookup(hostname string) (net.IP, error) {
-	ips, err := net.LookupIP(hostname)
+	return LookupContext(context.Background(), hostname)
+}
+
+// LookupContext is [Lookup] with a context that bounds the resolution.
+func LookupContext(ctx context.Context, hostname string) (net.IP, error) {
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, hostname)
 	if err != nil {
 		return nil, err
 	}
-	if len(ips) == 0 {
+	if len(addrs) == 0 {
 		return nil, errNoIPsFound
 	}
 
-	for _, ip := range ips {
-		ipv4 := ip.To4()
+	for _, addr := range addrs {
+		ipv4 := addr.IP.To4()
 		if ipv4 != nil {
 			return ipv4, nil
 		}
 	}
-	return ips[0], nil
+	return addrs[0].IP, nil
 }
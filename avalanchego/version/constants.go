@@ -11,6 +11,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/units"
 )
 
 const (
@@ -155,8 +156,183 @@ var (
 		constants.SongbirdID: time.Date(2025, time.July, 22, 12, 0, 0, 0, time.UTC),
 		constants.LocalID:    time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
 	}
+
+	// SubnetAllowListTimes are the times after which the allow lists of the
+	// subnets can be modified. The upgrade isn't scheduled on the networks that
+	// aren't listed.
+	SubnetAllowListTimes = map[uint32]time.Time{
+		constants.LocalFlareID: DefaultUpgradeTime,
+	}
+
+	// CappedDelegationTimes are the times after which validators can cap the
+	// stake delegated to them by every rewards owner. The upgrade isn't
+	// scheduled on the networks that aren't listed.
+	CappedDelegationTimes = map[uint32]time.Time{
+		constants.LocalFlareID: DefaultUpgradeTime,
+	}
+
+	// ExitValidatorTimes are the times after which permissionless validators can
+	// be removed before their end time. The upgrade isn't scheduled on the
+	// networks that aren't listed.
+	ExitValidatorTimes = map[uint32]time.Time{
+		constants.LocalFlareID: DefaultUpgradeTime,
+	}
+
+	// SubnetValidatorWeightTimes are the times after which the weight of PoA
+	// subnet validators can be changed. The upgrade isn't scheduled on the
+	// networks that aren't listed.
+	SubnetValidatorWeightTimes = map[uint32]time.Time{
+		constants.LocalFlareID: DefaultUpgradeTime,
+	}
+
+	// ParameterChangeTimes are the times after which the runtime parameters of the
+	// P-chain can be changed by governance. The upgrade isn't scheduled on the
+	// networks that aren't listed.
+	ParameterChangeTimes = map[uint32]time.Time{
+		constants.LocalFlareID: DefaultUpgradeTime,
+	}
+
+	// RekeyValidatorTimes are the times after which the nodeID and BLS key of
+	// primary network validators can be replaced. The upgrade isn't scheduled on
+	// the networks that aren't listed.
+	RekeyValidatorTimes = map[uint32]time.Time{
+		constants.LocalFlareID: DefaultUpgradeTime,
+	}
+
+	// NameRegistryTimes are the times after which validators can register names.
+	// The upgrade isn't scheduled on the networks that aren't listed.
+	NameRegistryTimes = map[uint32]time.Time{
+		constants.LocalFlareID: DefaultUpgradeTime,
+	}
+
+	// NameRegistrationFees are the fees burned by the txs that register or
+	// update names, unless they are changed by governance. Every network that
+	// schedules the name registry upgrade must charge a fee, otherwise names
+	// could be squatted for free.
+	NameRegistrationFees = map[uint32]uint64{
+		constants.LocalFlareID: 10 * units.Avax,
+	}
+
+	// ValidatorMetadataTimes are the times after which validators can be added
+	// with metadata. The upgrade isn't scheduled on the networks that aren't
+	// listed.
+	ValidatorMetadataTimes = map[uint32]time.Time{
+		constants.LocalFlareID: DefaultUpgradeTime,
+	}
+
+	// ClaimableRewardsTimes are the times after which staking rewards accrue
+	// to the claimable balances of their owners. The upgrade isn't scheduled
+	// on the networks that aren't listed.
+	ClaimableRewardsTimes = map[uint32]time.Time{
+		constants.LocalFlareID: DefaultUpgradeTime,
+	}
+
+	// RewardExportTimes are the times after which staking rewards are
+	// exported to the C-chain addresses bound by their owners. The upgrade
+	// isn't scheduled on the networks that aren't listed.
+	RewardExportTimes = map[uint32]time.Time{
+		constants.LocalFlareID: DefaultUpgradeTime,
+	}
+
+	// FeeTreasuries are the fee treasuries of the networks. The fees burned by
+	// P-chain txs are burned in full on the networks that aren't listed.
+	FeeTreasuries = map[uint32]FeeTreasury{}
+
+	// GovernanceOwners are the owners that authorize the changes to the
+	// runtime parameters of the P-chain. Parameters can't be changed on the
+	// networks that aren't listed.
+	GovernanceOwners = map[uint32]GovernanceOwner{}
+
+	// BlockComplexityLimits are the limits on the total complexity of the txs
+	// in a P-chain block. Blocks aren't limited on the networks that aren't
+	// listed.
+	BlockComplexityLimits = map[uint32]BlockComplexityLimit{}
+
+	// ValidatorAllowlists are the allowlists of the nodes that may be added as
+	// primary network validators until the open validator set upgrade. The
+	// validators aren't restricted on the networks that aren't listed.
+	ValidatorAllowlists = map[uint32]ValidatorAllowlist{}
+
+	// SubnetFeeAssetUpgrades are the upgrades after which the txs of subnets
+	// may pay their fees with the fee assets of the subnets. Fees are only paid
+	// with AVAX on the networks that aren't listed.
+	SubnetFeeAssetUpgrades = map[uint32]SubnetFeeAssetUpgrade{}
+
+	// StakerStartHorizons are the bounds on how far ahead of the chain time
+	// the start times of the stakers of a network may be. Start times are only
+	// bounded by the default maximum on the networks that aren't listed.
+	StakerStartHorizons = map[uint32]StakerStartHorizon{}
 )
 
+// ValidatorAllowlist is the allowlist of the nodes that may be added as primary
+// network validators of a network until the open validator set upgrade.
+type ValidatorAllowlist struct {
+	NodeIDs []ids.NodeID
+	// Time of the open validator set upgrade, after which the allowlist is no
+	// longer enforced
+	OpenValidatorSetTime time.Time
+}
+
+// StakerStartHorizon is the bound on how far ahead of the chain time the start
+// times of the stakers of a network may be.
+type StakerStartHorizon struct {
+	// Time after which start times are bounded by [Horizon]
+	Time time.Time
+	// Max duration that a start time may be ahead of the chain time
+	Horizon time.Duration
+}
+
+// SubnetFeeAssetUpgrade is the upgrade after which the txs of the subnets of a
+// network may pay their fees with the fee assets of the subnets.
+type SubnetFeeAssetUpgrade struct {
+	// Time after which fees may be paid with [Assets]
+	Time time.Time
+	// Fee assets, by subnet
+	Assets map[ids.ID]SubnetFeeAsset
+}
+
+// SubnetFeeAsset is an asset that the AddSubnetValidatorTxs and CreateChainTxs
+// of a subnet may pay their fees with.
+type SubnetFeeAsset struct {
+	AssetID ids.ID
+	// Fee, in [AssetID], of an AddSubnetValidatorTx
+	AddSubnetValidatorFee uint64
+	// Fee, in [AssetID], of a CreateChainTx
+	CreateBlockchainTxFee uint64
+	// Address that the fees are swept to. The fees are burned if empty.
+	Treasury ids.ShortID
+}
+
+// BlockComplexityLimit is the limit on the total complexity of the txs in a
+// P-chain block of a network.
+type BlockComplexityLimit struct {
+	// Time after which blocks are limited to [MaxComplexity]
+	Time time.Time
+	// Max total complexity of the txs in a block
+	MaxComplexity uint64
+}
+
+// FeeTreasury is the share of the fees burned by P-chain txs that is sent to
+// the fee treasury of a network.
+type FeeTreasury struct {
+	// Time after which the fees are sent to [Address]
+	Time time.Time
+	// Address of the fee treasury
+	Address ids.ShortID
+	// Share of the burned fees, in units of [reward.PercentDenominator], that
+	// is sent to [Address]
+	Percentage uint64
+}
+
+// GovernanceOwner is the multisig that authorizes the changes to the runtime
+// parameters of the P-chain of a network.
+type GovernanceOwner struct {
+	// Number of [Addrs] that must sign a change
+	Threshold uint32
+	// Sorted addresses of the owner
+	Addrs []ids.ShortID
+}
+
 func init() {
 	var parsedRPCChainVMCompatibility map[uint][]string
 	err := json.Unmarshal(rpcChainVMProtocolCompatibilityBytes, &parsedRPCChainVMCompatibility)
@@ -227,19 +403,140 @@ func GetDurangoTime(networkID uint32) time.Time {
 	return DefaultUpgradeTime
 }
 
-func GetCompatibility(networkID uint32) Compatibility {
+// GetSubnetAllowListTime returns the time of the upgrade on [networkID], or
+// the zero time if the upgrade isn't scheduled on [networkID].
+func GetSubnetAllowListTime(networkID uint32) time.Time {
+	return SubnetAllowListTimes[networkID]
+}
+
+// GetCappedDelegationTime returns the time of the upgrade on [networkID], or
+// the zero time if the upgrade isn't scheduled on [networkID].
+func GetCappedDelegationTime(networkID uint32) time.Time {
+	return CappedDelegationTimes[networkID]
+}
+
+// GetExitValidatorTime returns the time of the upgrade on [networkID], or the
+// zero time if the upgrade isn't scheduled on [networkID].
+func GetExitValidatorTime(networkID uint32) time.Time {
+	return ExitValidatorTimes[networkID]
+}
+
+// GetSubnetValidatorWeightTime returns the time of the upgrade on [networkID],
+// or the zero time if the upgrade isn't scheduled on [networkID].
+func GetSubnetValidatorWeightTime(networkID uint32) time.Time {
+	return SubnetValidatorWeightTimes[networkID]
+}
+
+// GetParameterChangeTime returns the time of the upgrade on [networkID], or the
+// zero time if the upgrade isn't scheduled on [networkID].
+func GetParameterChangeTime(networkID uint32) time.Time {
+	return ParameterChangeTimes[networkID]
+}
+
+// GetRekeyValidatorTime returns the time of the upgrade on [networkID], or the
+// zero time if the upgrade isn't scheduled on [networkID].
+func GetRekeyValidatorTime(networkID uint32) time.Time {
+	return RekeyValidatorTimes[networkID]
+}
+
+// GetNameRegistryTime returns the time of the upgrade on [networkID], or the
+// zero time if the upgrade isn't scheduled on [networkID].
+func GetNameRegistryTime(networkID uint32) time.Time {
+	return NameRegistryTimes[networkID]
+}
+
+// GetNameRegistrationFee returns the name registration fee of [networkID], or
+// 0 if names can't be registered on [networkID].
+func GetNameRegistrationFee(networkID uint32) uint64 {
+	return NameRegistrationFees[networkID]
+}
+
+// GetValidatorMetadataTime returns the time of the upgrade on [networkID], or
+// the zero time if the upgrade isn't scheduled on [networkID].
+func GetValidatorMetadataTime(networkID uint32) time.Time {
+	return ValidatorMetadataTimes[networkID]
+}
+
+// GetClaimableRewardsTime returns the time of the upgrade on [networkID], or
+// the zero time if the upgrade isn't scheduled on [networkID].
+func GetClaimableRewardsTime(networkID uint32) time.Time {
+	return ClaimableRewardsTimes[networkID]
+}
+
+// GetRewardExportTime returns the time of the upgrade on [networkID], or the
+// zero time if the upgrade isn't scheduled on [networkID].
+func GetRewardExportTime(networkID uint32) time.Time {
+	return RewardExportTimes[networkID]
+}
+
+// GetFeeTreasury returns the fee treasury of [networkID]. The zero value,
+// which doesn't redirect any fees, is returned if [networkID] doesn't have a
+// fee treasury.
+func GetFeeTreasury(networkID uint32) FeeTreasury {
+	return FeeTreasuries[networkID]
+}
+
+// GetValidatorAllowlist returns the validator allowlist of [networkID]. False
+// is returned if the validators of [networkID] aren't restricted.
+func GetValidatorAllowlist(networkID uint32) (ValidatorAllowlist, bool) {
+	allowlist, ok := ValidatorAllowlists[networkID]
+	return allowlist, ok
+}
+
+// GetStakerStartHorizon returns the staker start horizon of [networkID]. The
+// zero value, which doesn't bound start times, is returned if [networkID]
+// doesn't have a staker start horizon.
+func GetStakerStartHorizon(networkID uint32) StakerStartHorizon {
+	return StakerStartHorizons[networkID]
+}
+
+// GetBlockComplexityLimit returns the block complexity limit of [networkID].
+// The zero value, which doesn't limit blocks, is returned if [networkID]
+// doesn't have a block complexity limit.
+func GetBlockComplexityLimit(networkID uint32) BlockComplexityLimit {
+	return BlockComplexityLimits[networkID]
+}
+
+// GetSubnetFeeAssetUpgrade returns the subnet fee asset upgrade of
+// [networkID]. The zero value, which never activates, is returned if the
+// upgrade isn't scheduled on [networkID].
+func GetSubnetFeeAssetUpgrade(networkID uint32) SubnetFeeAssetUpgrade {
+	return SubnetFeeAssetUpgrades[networkID]
+}
+
+// GetGovernanceOwner returns the governance owner of [networkID]. False is
+// returned if [networkID] doesn't have a governance owner.
+func GetGovernanceOwner(networkID uint32) (GovernanceOwner, bool) {
+	owner, ok := GovernanceOwners[networkID]
+	return owner, ok
+}
+
+// getVersions returns the version of this node on [networkID], the minimum
+// version of its peers and the minimum version of its peers before the
+// Durango upgrade.
+func getVersions(networkID uint32) (*Application, *Application, *Application) {
 	if networkID == constants.SongbirdID || networkID == constants.CostonID || networkID == constants.LocalID {
-		return NewCompatibility(
-			CurrentSgbApp,
-			MinimumCompatibleSgbVersion,
-			GetDurangoTime(networkID),
-			PrevMinimumCompatibleSgbVersion,
-		)
+		return CurrentSgbApp, MinimumCompatibleSgbVersion, PrevMinimumCompatibleSgbVersion
 	}
+	return CurrentApp, MinimumCompatibleVersion, PrevMinimumCompatibleVersion
+}
+
+func GetCompatibility(networkID uint32) Compatibility {
+	current, minCompatible, prevMinCompatible := getVersions(networkID)
 	return NewCompatibility(
-		CurrentApp,
-		MinimumCompatibleVersion,
+		current,
+		minCompatible,
 		GetDurangoTime(networkID),
-		PrevMinimumCompatibleVersion,
+		prevMinCompatible,
 	)
 }
+
+// GetMinimumCompatibleVersion returns the minimum version of the peers of a
+// node on [networkID] at [now].
+func GetMinimumCompatibleVersion(networkID uint32, now time.Time) *Application {
+	_, minCompatible, prevMinCompatible := getVersions(networkID)
+	if now.Before(GetDurangoTime(networkID)) {
+		return prevMinCompatible
+	}
+	return minCompatible
+}
@@ -5,11 +5,32 @@ package version
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
 )
 
 func TestCurrentRPCChainVMCompatible(t *testing.T) {
 	compatibleVersions := RPCChainVMProtocolCompatibility[RPCChainVMProtocol]
 	require.Contains(t, compatibleVersions, Current)
 }
+
+func TestGetMinimumCompatibleVersion(t *testing.T) {
+	require := require.New(t)
+
+	durangoTime := GetDurangoTime(constants.SongbirdID)
+	require.Equal(PrevMinimumCompatibleSgbVersion, GetMinimumCompatibleVersion(constants.SongbirdID, durangoTime.Add(-time.Second)))
+	require.Equal(MinimumCompatibleSgbVersion, GetMinimumCompatibleVersion(constants.SongbirdID, durangoTime))
+
+	durangoTime = GetDurangoTime(constants.FlareID)
+	require.Equal(PrevMinimumCompatibleVersion, GetMinimumCompatibleVersion(constants.FlareID, durangoTime.Add(-time.Second)))
+	require.Equal(MinimumCompatibleVersion, GetMinimumCompatibleVersion(constants.FlareID, durangoTime))
+}
+
+func TestNameRegistrationFees(t *testing.T) {
+	for networkID := range NameRegistryTimes {
+		require.NotZero(t, GetNameRegistrationFee(networkID), "network %d", networkID)
+	}
+}
@@ -12,7 +12,6 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/utils"
-	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 )
 
@@ -165,12 +164,12 @@ func (in *TransferableInput) Compare(other *TransferableInput) int {
 	return in.UTXOID.Compare(&other.UTXOID)
 }
 
-type innerSortTransferableInputsWithSigners struct {
+type innerSortTransferableInputsWithSigners[S any] struct {
 	ins     []*TransferableInput
-	signers [][]*secp256k1.PrivateKey
+	signers [][]S
 }
 
-func (ins *innerSortTransferableInputsWithSigners) Less(i, j int) bool {
+func (ins *innerSortTransferableInputsWithSigners[_]) Less(i, j int) bool {
 	iID, iIndex := ins.ins[i].InputSource()
 	jID, jIndex := ins.ins[j].InputSource()
 
@@ -184,19 +183,19 @@ func (ins *innerSortTransferableInputsWithSigners) Less(i, j int) bool {
 	}
 }
 
-func (ins *innerSortTransferableInputsWithSigners) Len() int {
+func (ins *innerSortTransferableInputsWithSigners[_]) Len() int {
 	return len(ins.ins)
 }
 
-func (ins *innerSortTransferableInputsWithSigners) Swap(i, j int) {
+func (ins *innerSortTransferableInputsWithSigners[_]) Swap(i, j int) {
 	ins.ins[j], ins.ins[i] = ins.ins[i], ins.ins[j]
 	ins.signers[j], ins.signers[i] = ins.signers[i], ins.signers[j]
 }
 
 // SortTransferableInputsWithSigners sorts the inputs and signers based on the
 // input's utxo ID
-func SortTransferableInputsWithSigners(ins []*TransferableInput, signers [][]*secp256k1.PrivateKey) {
-	sort.Sort(&innerSortTransferableInputsWithSigners{ins: ins, signers: signers})
+func SortTransferableInputsWithSigners[S any](ins []*TransferableInput, signers [][]S) {
+	sort.Sort(&innerSortTransferableInputsWithSigners[S]{ins: ins, signers: signers})
 }
 
 // VerifyTx verifies that the inputs and outputs flowcheck, including a fee.
@@ -4,6 +4,9 @@
 package avax
 
 import (
+	"bytes"
+	"fmt"
+
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/cache"
@@ -292,3 +295,45 @@ func (s *utxoState) updateChecksum(modifiedID ids.ID) {
 
 	s.checksum = s.checksum.XOR(modifiedID)
 }
+
+// CheckUTXOIndex verifies that every UTXO stored in [db] by a UTXOState is
+// stored under its ID and is indexed under each of its addresses. [report] is
+// called with a description of every inconsistency that is found.
+func CheckUTXOIndex(db database.Database, c codec.Manager, report func(string)) error {
+	utxoDB := prefixdb.New(utxoPrefix, db)
+	indexDB := prefixdb.New(indexPrefix, db)
+
+	it := utxoDB.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		utxo := &UTXO{}
+		if _, err := c.Unmarshal(it.Value(), utxo); err != nil {
+			report(fmt.Sprintf("failed to parse UTXO stored under %x: %s", key, err))
+			continue
+		}
+
+		utxoID := utxo.InputID()
+		if !bytes.Equal(key, utxoID[:]) {
+			report(fmt.Sprintf("UTXO %s is stored under %x", utxoID, key))
+			continue
+		}
+
+		addressable, ok := utxo.Out.(Addressable)
+		if !ok {
+			continue
+		}
+		for _, addr := range addressable.Addresses() {
+			indexList := linkeddb.NewDefault(prefixdb.NewNested(addr, indexDB))
+			has, err := indexList.Has(utxoID[:])
+			if err != nil {
+				return err
+			}
+			if !has {
+				report(fmt.Sprintf("UTXO %s isn't indexed under address %x", utxoID, addr))
+			}
+		}
+	}
+	return it.Error()
+}
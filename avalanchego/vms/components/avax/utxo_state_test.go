@@ -12,7 +12,9 @@ import (
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/codec/linearcodec"
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/linkeddb"
 	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
@@ -93,3 +95,48 @@ func TestUTXOState(t *testing.T) {
 	require.NoError(err)
 	require.Equal([]ids.ID{utxoID}, utxoIDs)
 }
+
+func TestCheckUTXOIndex(t *testing.T) {
+	require := require.New(t)
+
+	addr := ids.GenerateTestShortID()
+	utxo := &UTXO{
+		UTXOID: UTXOID{
+			TxID:        ids.GenerateTestID(),
+			OutputIndex: 1,
+		},
+		Asset: Asset{ID: ids.GenerateTestID()},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 12345,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr},
+			},
+		},
+	}
+	utxoID := utxo.InputID()
+
+	c := linearcodec.NewDefault(time.Time{})
+	manager := codec.NewDefaultManager()
+	require.NoError(c.RegisterType(&secp256k1fx.TransferOutput{}))
+	require.NoError(manager.RegisterCodec(codecVersion, c))
+
+	db := memdb.New()
+	s, err := NewUTXOState(db, manager, trackChecksum)
+	require.NoError(err)
+	require.NoError(s.PutUTXO(utxo))
+
+	var reports []string
+	report := func(msg string) {
+		reports = append(reports, msg)
+	}
+	require.NoError(CheckUTXOIndex(db, manager, report))
+	require.Empty(reports)
+
+	// Drop the UTXO from the address index without removing the UTXO.
+	indexList := linkeddb.NewDefault(prefixdb.NewNested(addr[:], prefixdb.New(indexPrefix, db)))
+	require.NoError(indexList.Delete(utxoID[:]))
+
+	require.NoError(CheckUTXOIndex(db, manager, report))
+	require.Len(reports, 1)
+}
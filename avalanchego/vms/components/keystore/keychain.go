@@ -0,0 +1,83 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package keystore
+
+import (
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+var _ keychain.Keychain = (*Keychain)(nil)
+
+// Keychain is a keychain whose keys are held by a keystore user. Unlike the
+// keychain returned by GetKeychain, a key is only read from the user when a
+// signer for its address is requested.
+//
+// The keychain must not be used after [user] is closed.
+type Keychain struct {
+	user User
+
+	// Addrs is the list of addresses that the keychain can sign for, in the
+	// order that the user stored them. It should not be modified externally.
+	Addrs []ids.ShortID
+	addrs set.Set[ids.ShortID]
+
+	lock sync.Mutex
+	keys map[ids.ShortID]*secp256k1.PrivateKey
+}
+
+// NewKeychain returns a new keychain backed by [u].
+// If [addresses] is non-empty the keychain can only sign for the addresses in
+// [addresses] that [u] controls.
+// If [addresses] is empty, then the keychain can sign for every address in the
+// provided [u].
+func NewKeychain(u User, addresses set.Set[ids.ShortID]) (*Keychain, error) {
+	userAddrs, err := u.GetAddresses()
+	if err != nil {
+		return nil, err
+	}
+
+	kc := &Keychain{
+		user: u,
+		keys: make(map[ids.ShortID]*secp256k1.PrivateKey),
+	}
+	for _, addr := range userAddrs {
+		if addresses.Len() != 0 && !addresses.Contains(addr) {
+			continue
+		}
+		if kc.addrs.Contains(addr) {
+			continue
+		}
+		kc.Addrs = append(kc.Addrs, addr)
+		kc.addrs.Add(addr)
+	}
+	return kc, nil
+}
+
+func (kc *Keychain) Get(addr ids.ShortID) (keychain.Signer, bool) {
+	if !kc.addrs.Contains(addr) {
+		return nil, false
+	}
+
+	kc.lock.Lock()
+	defer kc.lock.Unlock()
+
+	if key, ok := kc.keys[addr]; ok {
+		return key, true
+	}
+	key, err := kc.user.GetKey(addr)
+	if err != nil {
+		return nil, false
+	}
+	kc.keys[addr] = key
+	return key, true
+}
+
+func (kc *Keychain) Addresses() set.Set[ids.ShortID] {
+	return kc.addrs
+}
@@ -0,0 +1,60 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package keystore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/encdb"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+func TestKeychain(t *testing.T) {
+	require := require.New(t)
+
+	db, err := encdb.New([]byte(testPassword), memdb.New())
+	require.NoError(err)
+
+	u := NewUserFromDB(db)
+	keys, err := NewKeys(u, 2)
+	require.NoError(err)
+
+	var (
+		addr0        = keys[0].PublicKey().Address()
+		addr1        = keys[1].PublicKey().Address()
+		unknownAddr  = ids.GenerateTestShortID()
+		allAddresses = set.Of(addr0, addr1)
+	)
+
+	kc, err := NewKeychain(u, nil)
+	require.NoError(err)
+	require.Equal([]ids.ShortID{addr0, addr1}, kc.Addrs)
+	require.Equal(allAddresses, kc.Addresses())
+
+	signer, ok := kc.Get(addr1)
+	require.True(ok)
+	require.Equal(addr1, signer.Address())
+
+	_, ok = kc.Get(unknownAddr)
+	require.False(ok)
+
+	// Only the requested addresses that the user controls can be signed for.
+	kc, err = NewKeychain(u, set.Of(addr1, unknownAddr))
+	require.NoError(err)
+	require.Equal([]ids.ShortID{addr1}, kc.Addrs)
+
+	_, ok = kc.Get(addr0)
+	require.False(ok)
+
+	// Keys that were already read remain usable after the user is closed.
+	_, ok = kc.Get(addr1)
+	require.True(ok)
+	require.NoError(u.Close())
+	_, ok = kc.Get(addr1)
+	require.True(ok)
+}
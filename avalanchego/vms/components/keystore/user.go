@@ -16,15 +16,24 @@ import (
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
-// Max number of addresses allowed for a single keystore user
-const maxKeystoreAddresses = 5000
+const (
+	// Max number of addresses allowed for a single keystore user
+	maxKeystoreAddresses = 5000
+	// Max number of bytes of a label
+	maxLabelLen = 64
+)
 
 var (
 	// Key in the database whose corresponding value is the list of addresses
 	// this user controls
 	addressesKey = ids.Empty[:]
+	// Key in the database whose corresponding value is the labels this user
+	// attached to addresses
+	labelsKey = []byte("labels")
 
 	errMaxAddresses = fmt.Errorf("keystore user has reached its limit of %d addresses", maxKeystoreAddresses)
+	errMaxLabels    = fmt.Errorf("keystore user has reached its limit of %d labels", maxKeystoreAddresses)
+	errLabelTooLong = fmt.Errorf("label is longer than %d bytes", maxLabelLen)
 
 	_ User = (*user)(nil)
 )
@@ -40,6 +49,14 @@ type User interface {
 
 	// GetKey returns the private key that controls the given address
 	GetKey(address ids.ShortID) (*secp256k1.PrivateKey, error)
+
+	// GetLabels returns the labels attached to addresses. The addresses
+	// aren't necessarily controlled by this user.
+	GetLabels() (map[ids.ShortID]string, error)
+
+	// SetLabel attaches [label] to [address]. An empty label removes the
+	// label of [address].
+	SetLabel(address ids.ShortID, label string) error
 }
 
 type user struct {
@@ -127,6 +144,45 @@ func (u *user) GetKey(address ids.ShortID) (*secp256k1.PrivateKey, error) {
 	return secp256k1.ToPrivateKey(bytes)
 }
 
+func (u *user) GetLabels() (map[ids.ShortID]string, error) {
+	labelsBytes, err := u.db.Get(labelsKey)
+	if err == database.ErrNotFound {
+		return map[ids.ShortID]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	labels := map[ids.ShortID]string{}
+	_, err = Codec.Unmarshal(labelsBytes, &labels)
+	return labels, err
+}
+
+func (u *user) SetLabel(address ids.ShortID, label string) error {
+	if len(label) > maxLabelLen {
+		return errLabelTooLong
+	}
+
+	labels, err := u.GetLabels()
+	if err != nil {
+		return err
+	}
+	if label == "" {
+		delete(labels, address)
+	} else {
+		if _, ok := labels[address]; !ok && len(labels) >= maxKeystoreAddresses {
+			return errMaxLabels
+		}
+		labels[address] = label
+	}
+
+	labelsBytes, err := Codec.Marshal(CodecVersion, labels)
+	if err != nil {
+		return err
+	}
+	return u.db.Put(labelsKey, labelsBytes)
+}
+
 func (u *user) Close() error {
 	return u.db.Close()
 }
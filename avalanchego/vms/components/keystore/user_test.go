@@ -4,6 +4,7 @@
 package keystore
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -82,3 +83,40 @@ func TestUser(t *testing.T) {
 	require.Len(savedKeychain.Keys, 1, "key should have been added")
 	require.Equal(sk.Bytes(), savedKeychain.Keys[0].Bytes(), "wrong key returned")
 }
+
+func TestUserLabels(t *testing.T) {
+	require := require.New(t)
+
+	db, err := encdb.New([]byte(testPassword), memdb.New())
+	require.NoError(err)
+
+	u := NewUserFromDB(db)
+
+	labels, err := u.GetLabels()
+	require.NoError(err)
+	require.Empty(labels)
+
+	var (
+		addr0 = ids.GenerateTestShortID()
+		addr1 = ids.GenerateTestShortID()
+	)
+	require.NoError(u.SetLabel(addr0, "savings"))
+	require.NoError(u.SetLabel(addr1, "savings"))
+	require.NoError(u.SetLabel(addr1, "staking"))
+
+	labels, err = u.GetLabels()
+	require.NoError(err)
+	require.Equal(map[ids.ShortID]string{
+		addr0: "savings",
+		addr1: "staking",
+	}, labels)
+
+	// An empty label removes the label of the address
+	require.NoError(u.SetLabel(addr0, ""))
+	labels, err = u.GetLabels()
+	require.NoError(err)
+	require.Equal(map[ids.ShortID]string{addr1: "staking"}, labels)
+
+	err = u.SetLabel(addr0, strings.Repeat("a", maxLabelLen+1))
+	require.ErrorIs(err, errLabelTooLong)
+}
@@ -0,0 +1,54 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+
+	avajson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+var _ http.Handler = (*acceptedBlockStream)(nil)
+
+// APIAcceptedBlock is the API representation of an accepted block
+type APIAcceptedBlock struct {
+	Height  avajson.Uint64 `json:"height"`
+	BlockID ids.ID         `json:"blockID"`
+	// Block is the hex encoding of the block bytes.
+	Block string `json:"block"`
+}
+
+// acceptedBlockStream streams the accepted blocks, one [APIAcceptedBlock] per
+// accepted height. Read replicas follow this stream to stay in sync.
+type acceptedBlockStream struct {
+	vm *VM
+}
+
+func (s *acceptedBlockStream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.vm.streamHeights(w, r, "acceptedBlocks", s.getAcceptedBlock)
+}
+
+func (s *acceptedBlockStream) getAcceptedBlock(height uint64) (any, error) {
+	blkID, err := s.vm.state.GetBlockIDAtHeight(height)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get block ID at height %d: %w", height, err)
+	}
+	blk, err := s.vm.manager.GetStatelessBlock(blkID)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get block %s: %w", blkID, err)
+	}
+	blkStr, err := formatting.Encode(formatting.Hex, blk.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("couldn't encode block %s: %w", blkID, err)
+	}
+	return &APIAcceptedBlock{
+		Height:  avajson.Uint64(height),
+		BlockID: blkID,
+		Block:   blkStr,
+	}, nil
+}
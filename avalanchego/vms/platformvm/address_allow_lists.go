@@ -0,0 +1,81 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+const apiTokenPrefix = "Bearer "
+
+var (
+	errMissingAPIToken       = errors.New("missing API token")
+	errUnknownAPIToken       = errors.New("unknown API token")
+	errUnauthorizedAddress   = errors.New("address isn't authorized for the API token")
+	errEmptyAddressAllowList = errors.New("empty address allow list")
+)
+
+// addressAllowLists maps API tokens to the addresses whose UTXOs, balances and
+// stake may be queried with the token. This allows a single node to serve
+// multiple customers without revealing the holdings of one customer to
+// another.
+//
+// If no allow lists are configured, every address may be queried without a
+// token.
+type addressAllowLists map[string]set.Set[ids.ShortID]
+
+func newAddressAllowLists(
+	addrManager avax.AddressManager,
+	allowLists map[string][]string,
+) (addressAllowLists, error) {
+	lists := make(addressAllowLists, len(allowLists))
+	for token, addrStrs := range allowLists {
+		if len(addrStrs) == 0 {
+			return nil, errEmptyAddressAllowList
+		}
+		addrs, err := avax.ParseServiceAddresses(addrManager, addrStrs)
+		if err != nil {
+			return nil, err
+		}
+		lists[token] = addrs
+	}
+	return lists, nil
+}
+
+// authorize returns nil if the API token that [r] was sent with allows
+// querying all of [addrs].
+//
+// The token is read from the Authorization header, which is expected to be of
+// the form "Bearer <token>".
+func (a addressAllowLists) authorize(r *http.Request, addrs set.Set[ids.ShortID]) error {
+	if len(a) == 0 {
+		return nil
+	}
+
+	var header string
+	if r != nil {
+		header = r.Header.Get("Authorization")
+	}
+	token, ok := strings.CutPrefix(header, apiTokenPrefix)
+	if !ok || token == "" {
+		return errMissingAPIToken
+	}
+	allowed, ok := a[token]
+	if !ok {
+		return errUnknownAPIToken
+	}
+	for addr := range addrs {
+		if !allowed.Contains(addr) {
+			return fmt.Errorf("%w: %s", errUnauthorizedAddress, addr)
+		}
+	}
+	return nil
+}
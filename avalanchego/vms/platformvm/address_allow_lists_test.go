@@ -0,0 +1,87 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+)
+
+func TestAddressAllowLists(t *testing.T) {
+	service, _ := defaultService(t)
+
+	allowedAddr, err := service.addrManager.FormatLocalAddress(keys[0].Address())
+	require.NoError(t, err)
+	otherAddr, err := service.addrManager.FormatLocalAddress(keys[1].Address())
+	require.NoError(t, err)
+
+	service.addressAllowLists, err = newAddressAllowLists(service.addrManager, map[string][]string{
+		"token": {allowedAddr},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		header      string
+		addrs       []string
+		expectedErr error
+	}{
+		{
+			name:        "missing token",
+			addrs:       []string{allowedAddr},
+			expectedErr: errMissingAPIToken,
+		},
+		{
+			name:        "unknown token",
+			header:      "Bearer unknown",
+			addrs:       []string{allowedAddr},
+			expectedErr: errUnknownAPIToken,
+		},
+		{
+			name:        "unauthorized address",
+			header:      "Bearer token",
+			addrs:       []string{allowedAddr, otherAddr},
+			expectedErr: errUnauthorizedAddress,
+		},
+		{
+			name:        "authorized",
+			header:      "Bearer token",
+			addrs:       []string{allowedAddr},
+			expectedErr: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			r, err := http.NewRequest(http.MethodPost, "/", nil)
+			require.NoError(err)
+			if test.header != "" {
+				r.Header.Set("Authorization", test.header)
+			}
+
+			err = service.GetUTXOs(r, &api.GetUTXOsArgs{Addresses: test.addrs}, &api.GetUTXOsReply{})
+			require.ErrorIs(err, test.expectedErr)
+
+			err = service.GetBalance(r, &GetBalanceRequest{Addresses: test.addrs}, &GetBalanceResponse{})
+			require.ErrorIs(err, test.expectedErr)
+
+			err = service.GetStake(r, &GetStakeArgs{
+				JSONAddresses: api.JSONAddresses{Addresses: test.addrs},
+				Encoding:      formatting.Hex,
+			}, &GetStakeReply{})
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+
+	_, err = newAddressAllowLists(service.addrManager, map[string][]string{
+		"token": nil,
+	})
+	require.ErrorIs(t, err, errEmptyAddressAllowList)
+}
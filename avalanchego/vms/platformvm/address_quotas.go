@@ -0,0 +1,235 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+const addressUsageLen = wrappers.LongLen + wrappers.IntLen
+
+var (
+	errAddressQuotaExceeded   = errors.New("address quota exceeded")
+	errInvalidAddressUsageLen = errors.New("invalid address usage length")
+)
+
+// addressQuotas limits the number of txs that spend the UTXOs of every address
+// during each period. The period of an address starts with the first tx that
+// is counted against its quota.
+//
+// Usage is persisted, so that restarting the node doesn't reset the quotas.
+//
+// If the limit is 0, or if the quotas are nil, txs are never limited.
+type addressQuotas struct {
+	db     database.Database
+	limit  int
+	period time.Duration
+	clock  *mockable.Clock
+
+	lock  sync.Mutex
+	usage map[ids.ShortID]*addressUsage
+}
+
+type addressUsage struct {
+	windowStart time.Time
+	// Number of txs issued since [windowStart]
+	count uint32
+}
+
+// newAddressQuotas returns the quotas whose usage is persisted in [db]. Usage
+// of periods that already ended is removed from [db].
+func newAddressQuotas(
+	db database.Database,
+	limit int,
+	period time.Duration,
+	clock *mockable.Clock,
+) (*addressQuotas, error) {
+	q := &addressQuotas{
+		db:     db,
+		limit:  limit,
+		period: period,
+		clock:  clock,
+		usage:  make(map[ids.ShortID]*addressUsage),
+	}
+
+	it := db.NewIterator()
+	defer it.Release()
+
+	now := q.clock.Time()
+	for it.Next() {
+		addr, err := ids.ToShortID(it.Key())
+		if err != nil {
+			return nil, err
+		}
+		usage, err := parseAddressUsage(it.Value())
+		if err != nil {
+			return nil, err
+		}
+		if now.Sub(usage.windowStart) >= q.period {
+			if err := db.Delete(it.Key()); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		q.usage[addr] = usage
+	}
+	return q, it.Error()
+}
+
+// consume counts a tx against the quota of each of [addrs]. Returns an error,
+// without counting the tx against any quota, if the quota of any of [addrs] is
+// exhausted.
+func (q *addressQuotas) consume(addrs set.Set[ids.ShortID]) error {
+	if q == nil || q.limit <= 0 {
+		return nil
+	}
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	now := q.clock.Time()
+	for addr := range addrs {
+		usage, ok := q.usage[addr]
+		if !ok || now.Sub(usage.windowStart) >= q.period {
+			continue
+		}
+		if int(usage.count) >= q.limit {
+			return fmt.Errorf("%w: %s issued %d txs in %s",
+				errAddressQuotaExceeded,
+				addr,
+				usage.count,
+				q.period,
+			)
+		}
+	}
+
+	for addr := range addrs {
+		usage, ok := q.usage[addr]
+		if !ok || now.Sub(usage.windowStart) >= q.period {
+			usage = &addressUsage{windowStart: now}
+			q.usage[addr] = usage
+		}
+		usage.count++
+		if err := q.db.Put(addr[:], usage.bytes()); err != nil {
+			return fmt.Errorf("failed to persist usage of %s: %w", addr, err)
+		}
+	}
+	return q.prune(now)
+}
+
+// release removes a tx that was counted against the quota of each of [addrs]
+// by [consume]. Quotas whose period ended since the tx was counted are left
+// untouched.
+func (q *addressQuotas) release(addrs set.Set[ids.ShortID]) error {
+	if q == nil || q.limit <= 0 {
+		return nil
+	}
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	now := q.clock.Time()
+	for addr := range addrs {
+		usage, ok := q.usage[addr]
+		if !ok || now.Sub(usage.windowStart) >= q.period || usage.count == 0 {
+			continue
+		}
+		usage.count--
+		if usage.count == 0 {
+			delete(q.usage, addr)
+			if err := q.db.Delete(addr[:]); err != nil {
+				return fmt.Errorf("failed to delete usage of %s: %w", addr, err)
+			}
+			continue
+		}
+		if err := q.db.Put(addr[:], usage.bytes()); err != nil {
+			return fmt.Errorf("failed to persist usage of %s: %w", addr, err)
+		}
+	}
+	return nil
+}
+
+// prune removes the usage of the periods that ended before [now].
+//
+// Invariant: [q.lock] is held.
+func (q *addressQuotas) prune(now time.Time) error {
+	for addr, usage := range q.usage {
+		if now.Sub(usage.windowStart) < q.period {
+			continue
+		}
+		delete(q.usage, addr)
+		if err := q.db.Delete(addr[:]); err != nil {
+			return fmt.Errorf("failed to delete usage of %s: %w", addr, err)
+		}
+	}
+	return nil
+}
+
+func (u *addressUsage) bytes() []byte {
+	b := make([]byte, addressUsageLen)
+	binary.BigEndian.PutUint64(b, uint64(u.windowStart.Unix()))
+	binary.BigEndian.PutUint32(b[wrappers.LongLen:], u.count)
+	return b
+}
+
+func parseAddressUsage(b []byte) (*addressUsage, error) {
+	if len(b) != addressUsageLen {
+		return nil, fmt.Errorf("%w: expected %d bytes but got %d",
+			errInvalidAddressUsageLen,
+			addressUsageLen,
+			len(b),
+		)
+	}
+	return &addressUsage{
+		windowStart: time.Unix(int64(binary.BigEndian.Uint64(b)), 0),
+		count:       binary.BigEndian.Uint32(b[wrappers.LongLen:]),
+	}, nil
+}
+
+// spenderAddresses returns the addresses that own the UTXOs consumed by [tx].
+// UTXOs imported from other chains aren't in [chainState], so their owners
+// aren't returned.
+func spenderAddresses(chainState state.Chain, tx *txs.Tx) (set.Set[ids.ShortID], error) {
+	var addrs set.Set[ids.ShortID]
+	for inputID := range tx.Unsigned.InputIDs() {
+		utxo, err := chainState.GetUTXO(inputID)
+		if err == database.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get UTXO %s: %w", inputID, err)
+		}
+
+		out := utxo.Out
+		if lockedOut, ok := out.(*stakeable.LockOut); ok {
+			out = lockedOut.TransferableOut
+		}
+		addressable, ok := out.(avax.Addressable)
+		if !ok {
+			continue
+		}
+		for _, addrBytes := range addressable.Addresses() {
+			addr, err := ids.ToShortID(addrBytes)
+			if err != nil {
+				return nil, err
+			}
+			addrs.Add(addr)
+		}
+	}
+	return addrs, nil
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestAddressQuotas(t *testing.T) {
+	require := require.New(t)
+
+	clock := &mockable.Clock{}
+	clock.Set(time.Unix(1_700_000_000, 0))
+	db := memdb.New()
+	q, err := newAddressQuotas(db, 2, time.Hour, clock)
+	require.NoError(err)
+
+	var (
+		addr0 = ids.GenerateTestShortID()
+		addr1 = ids.GenerateTestShortID()
+	)
+	require.NoError(q.consume(set.Of(addr0)))
+	require.NoError(q.consume(set.Of(addr0, addr1)))
+	err = q.consume(set.Of(addr0))
+	require.ErrorIs(err, errAddressQuotaExceeded)
+
+	// A tx is rejected if the quota of any of its addresses is exhausted, in
+	// which case it isn't counted against the other addresses
+	err = q.consume(set.Of(addr0, addr1))
+	require.ErrorIs(err, errAddressQuotaExceeded)
+	require.NoError(q.consume(set.Of(addr1)))
+
+	// Usage is persisted across restarts
+	clock.Set(clock.Time().Add(time.Minute))
+	q, err = newAddressQuotas(db, 2, time.Hour, clock)
+	require.NoError(err)
+	err = q.consume(set.Of(addr0))
+	require.ErrorIs(err, errAddressQuotaExceeded)
+
+	// Quotas are restored once the period of the address ends
+	clock.Set(clock.Time().Add(time.Hour))
+	require.NoError(q.consume(set.Of(addr0)))
+
+	// Usage of the periods that ended is removed
+	has, err := db.Has(addr1[:])
+	require.NoError(err)
+	require.False(has)
+
+	// Released txs no longer count against the quotas
+	require.NoError(q.consume(set.Of(addr0)))
+	err = q.consume(set.Of(addr0))
+	require.ErrorIs(err, errAddressQuotaExceeded)
+	require.NoError(q.release(set.Of(addr0)))
+	require.NoError(q.consume(set.Of(addr0)))
+
+	// Usage is removed once every tx of the period is released
+	require.NoError(q.release(set.Of(addr0)))
+	require.NoError(q.release(set.Of(addr0)))
+	has, err = db.Has(addr0[:])
+	require.NoError(err)
+	require.False(has)
+}
+
+func TestAddressQuotasDisabled(t *testing.T) {
+	require := require.New(t)
+
+	addrs := set.Of(ids.GenerateTestShortID())
+	q, err := newAddressQuotas(memdb.New(), 0, time.Hour, &mockable.Clock{})
+	require.NoError(err)
+	for i := 0; i < 10; i++ {
+		require.NoError(q.consume(addrs))
+	}
+
+	var nilQuotas *addressQuotas
+	require.NoError(nilQuotas.consume(addrs))
+	require.NoError(nilQuotas.release(addrs))
+}
+
+func TestServiceIssueTxAddressQuotas(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+
+	var err error
+	service.vm.issueTxAddressQuotas, err = newAddressQuotas(memdb.New(), 1, time.Hour, &service.vm.clock)
+	require.NoError(err)
+
+	newCreateSubnetTx := func() *txs.Tx {
+		service.vm.ctx.Lock.Lock()
+		defer service.vm.ctx.Lock.Unlock()
+
+		tx, err := service.vm.txBuilder.NewCreateSubnetTx(
+			1, // threshold
+			[]ids.ShortID{ids.GenerateTestShortID()},
+			secp256k1fx.NewKeychain(keys[0]),
+			keys[0].Address(), // change address
+			nil,
+		)
+		require.NoError(err)
+		return tx
+	}
+
+	// Txs that fail to be issued aren't counted against the quotas
+	invalidTx := newCreateSubnetTx()
+	invalidTx.Creds = nil
+	require.NoError(invalidTx.Sign(txs.Codec, [][]*secp256k1.PrivateKey{{keys[1]}}))
+	err = service.issueTx(context.Background(), invalidTx)
+	require.ErrorIs(err, secp256k1fx.ErrWrongSig)
+
+	require.NoError(service.issueTx(context.Background(), newCreateSubnetTx()))
+	err = service.issueTx(context.Background(), newCreateSubnetTx())
+	require.ErrorIs(err, errAddressQuotaExceeded)
+	require.Equal(1, service.vm.Builder.Len())
+}
@@ -0,0 +1,881 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/keystore"
+	"github.com/ava-labs/avalanchego/vms/platformvm/backup"
+
+	avajson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+// Max number of accruals that can be returned by a single call to
+// getRewardAccruals
+const maxRewardAccruals = 1024
+
+// Max number of entries that can be returned by a single call to
+// getAutoImportLog
+const maxAutoImportEntries = 1024
+
+var (
+	errInvalidBackupSink     = errors.New("exactly one of 'path' and 'url' must be provided")
+	errClockOverrideDisabled = errors.New("clock override is disabled")
+	errClockMovedBackwards   = errors.New("clock can't be moved backwards")
+	errAutoImportDisabled    = errors.New("auto-import is disabled")
+	errNoAutoImportKeys      = errors.New("no keys to auto-import the UTXOs of")
+)
+
+// AdminService exposes maintenance operations of the platform chain. It is
+// only served if the admin API is enabled in the execution config.
+type AdminService struct {
+	vm *VM
+}
+
+// Compact triggers a compaction of the cold regions of the database. The
+// compaction is performed asynchronously, even if scheduled compactions are
+// paused.
+func (s *AdminService) Compact(_ *http.Request, _ *struct{}, _ *api.EmptyReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platformAdmin"),
+		zap.String("method", "compact"),
+	)
+
+	s.vm.compactionScheduler.Trigger()
+	return nil
+}
+
+// PauseCompaction stops compactions from being scheduled during the
+// compaction window.
+func (s *AdminService) PauseCompaction(_ *http.Request, _ *struct{}, _ *api.EmptyReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platformAdmin"),
+		zap.String("method", "pauseCompaction"),
+	)
+
+	s.vm.compactionScheduler.Pause()
+	return nil
+}
+
+// ResumeCompaction allows compactions to be scheduled during the compaction
+// window again.
+func (s *AdminService) ResumeCompaction(_ *http.Request, _ *struct{}, _ *api.EmptyReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platformAdmin"),
+		zap.String("method", "resumeCompaction"),
+	)
+
+	s.vm.compactionScheduler.Resume()
+	return nil
+}
+
+// GetCompactionStatusReply is the response from GetCompactionStatus
+type GetCompactionStatusReply struct {
+	Paused bool `json:"paused"`
+	// LastCompaction is nil if the database hasn't been compacted since the
+	// node started.
+	LastCompaction *time.Time `json:"lastCompaction,omitempty"`
+}
+
+// GetCompactionStatus returns the state of the compaction scheduler.
+func (s *AdminService) GetCompactionStatus(_ *http.Request, _ *struct{}, reply *GetCompactionStatusReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platformAdmin"),
+		zap.String("method", "getCompactionStatus"),
+	)
+
+	reply.Paused = s.vm.compactionScheduler.Paused()
+	if lastCompaction := s.vm.compactionScheduler.LastCompaction(); !lastCompaction.IsZero() {
+		reply.LastCompaction = &lastCompaction
+	}
+	return nil
+}
+
+// BackupArgs are the arguments to Backup
+type BackupArgs struct {
+	// Path of the local file to write the backup to.
+	Path string `json:"path"`
+	// URL that the backup is POSTed to.
+	URL string `json:"url"`
+}
+
+// BackupReply is the response from Backup
+type BackupReply struct {
+	LastAccepted ids.ID         `json:"lastAccepted"`
+	Height       avajson.Uint64 `json:"height"`
+}
+
+// Backup writes a zstd compressed tar archive of the database, as of the last
+// accepted block, to a local file or streams it to an HTTP endpoint. The
+// backup can be restored by starting the node with the restore-backup-path
+// execution config.
+func (s *AdminService) Backup(r *http.Request, args *BackupArgs, reply *BackupReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platformAdmin"),
+		zap.String("method", "backup"),
+		zap.String("path", args.Path),
+		zap.String("url", args.URL),
+	)
+
+	var (
+		metadata backup.Metadata
+		err      error
+	)
+	switch {
+	case args.Path != "" && args.URL == "":
+		metadata, err = s.vm.writeBackupToFile(args.Path)
+	case args.Path == "" && args.URL != "":
+		metadata, err = s.vm.writeBackupToURL(r.Context(), args.URL)
+	default:
+		return errInvalidBackupSink
+	}
+	if err != nil {
+		return err
+	}
+
+	reply.LastAccepted = metadata.LastAccepted
+	reply.Height = avajson.Uint64(metadata.Height)
+	return nil
+}
+
+// WatchRewardAddresses adds the addresses to the reward watchlist. Rewards
+// that are paid out to watched addresses are logged and reported by
+// GetRewardAccruals.
+func (s *AdminService) WatchRewardAddresses(_ *http.Request, args *api.JSONAddresses, _ *api.EmptyReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platformAdmin"),
+		zap.String("method", "watchRewardAddresses"),
+		logging.UserStrings("addresses", args.Addresses),
+	)
+
+	addrs, err := avax.ParseServiceAddresses(avax.NewAddressManager(s.vm.ctx), args.Addresses)
+	if err != nil {
+		return err
+	}
+	s.vm.rewardWatchlist.Watch(addrs.List()...)
+	return nil
+}
+
+// UnwatchRewardAddresses removes the addresses from the reward watchlist.
+func (s *AdminService) UnwatchRewardAddresses(_ *http.Request, args *api.JSONAddresses, _ *api.EmptyReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platformAdmin"),
+		zap.String("method", "unwatchRewardAddresses"),
+		logging.UserStrings("addresses", args.Addresses),
+	)
+
+	addrs, err := avax.ParseServiceAddresses(avax.NewAddressManager(s.vm.ctx), args.Addresses)
+	if err != nil {
+		return err
+	}
+	s.vm.rewardWatchlist.Unwatch(addrs.List()...)
+	return nil
+}
+
+// GetRewardWatchlist returns the addresses on the reward watchlist.
+func (s *AdminService) GetRewardWatchlist(_ *http.Request, _ *struct{}, reply *api.JSONAddresses) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platformAdmin"),
+		zap.String("method", "getRewardWatchlist"),
+	)
+
+	addrManager := avax.NewAddressManager(s.vm.ctx)
+	addrs := s.vm.rewardWatchlist.Addresses()
+	utils.Sort(addrs)
+	reply.Addresses = make([]string, len(addrs))
+	for i, addr := range addrs {
+		addrStr, err := addrManager.FormatLocalAddress(addr)
+		if err != nil {
+			return err
+		}
+		reply.Addresses[i] = addrStr
+	}
+	return nil
+}
+
+// GetRewardAccrualsArgs are the arguments to GetRewardAccruals
+type GetRewardAccrualsArgs struct {
+	// Index of the first accrual to return
+	StartIndex avajson.Uint64 `json:"startIndex"`
+	// Max number of accruals to return. Defaults to, and is capped at,
+	// [maxRewardAccruals].
+	Limit avajson.Uint32 `json:"limit"`
+}
+
+// RewardAccrual is a reward output created for a watched address
+type RewardAccrual struct {
+	Index      avajson.Uint64 `json:"index"`
+	BlockID    ids.ID         `json:"blockID"`
+	Height     avajson.Uint64 `json:"height"`
+	StakerTxID ids.ID         `json:"stakerTxID"`
+	NodeID     ids.NodeID     `json:"nodeID"`
+	SubnetID   ids.ID         `json:"subnetID"`
+	UTXOID     ids.ID         `json:"utxoID"`
+	Address    string         `json:"address"`
+	AssetID    ids.ID         `json:"assetID"`
+	Amount     avajson.Uint64 `json:"amount"`
+}
+
+// GetRewardAccrualsReply is the response from GetRewardAccruals
+type GetRewardAccrualsReply struct {
+	Accruals []RewardAccrual `json:"accruals"`
+	// Index to pass as the start index of the next call
+	NextIndex avajson.Uint64 `json:"nextIndex"`
+}
+
+// GetRewardAccruals returns the recent rewards paid out to watched addresses,
+// starting at [args.StartIndex]. Only the most recent accruals are
+// remembered, so a caller that falls too far behind receives the oldest
+// remembered accruals instead.
+func (s *AdminService) GetRewardAccruals(_ *http.Request, args *GetRewardAccrualsArgs, reply *GetRewardAccrualsReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platformAdmin"),
+		zap.String("method", "getRewardAccruals"),
+		zap.Uint64("startIndex", uint64(args.StartIndex)),
+	)
+
+	limit := maxRewardAccruals
+	if args.Limit != 0 && int(args.Limit) < limit {
+		limit = int(args.Limit)
+	}
+	accruals, nextIndex := s.vm.rewardWatchlist.Accruals(uint64(args.StartIndex), limit)
+
+	addrManager := avax.NewAddressManager(s.vm.ctx)
+	reply.Accruals = make([]RewardAccrual, len(accruals))
+	for i, accrual := range accruals {
+		addr, err := addrManager.FormatLocalAddress(accrual.Address)
+		if err != nil {
+			return err
+		}
+		reply.Accruals[i] = RewardAccrual{
+			Index:      avajson.Uint64(accrual.Index),
+			BlockID:    accrual.BlockID,
+			Height:     avajson.Uint64(accrual.Height),
+			StakerTxID: accrual.StakerTxID,
+			NodeID:     accrual.NodeID,
+			SubnetID:   accrual.SubnetID,
+			UTXOID:     accrual.UTXOID,
+			Address:    addr,
+			AssetID:    accrual.AssetID,
+			Amount:     avajson.Uint64(accrual.Amount),
+		}
+	}
+	reply.NextIndex = avajson.Uint64(nextIndex)
+	return nil
+}
+
+// EnableAutoImportArgs are the arguments to EnableAutoImport
+type EnableAutoImportArgs struct {
+	api.UserPass
+	// Addresses of the keys of the user whose UTXOs are imported. Defaults to
+	// every address of the user.
+	Addresses []string `json:"addresses"`
+	// Address that the UTXOs are imported to
+	To string `json:"to"`
+}
+
+// EnableAutoImport starts importing the atomic UTXOs owned by the keys of a
+// keystore user. The keys are only kept in memory, so auto-import must be
+// enabled again after a restart.
+func (s *AdminService) EnableAutoImport(_ *http.Request, args *EnableAutoImportArgs, _ *api.EmptyReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platformAdmin"),
+		zap.String("method", "enableAutoImport"),
+		logging.UserString("username", args.Username),
+	)
+
+	if s.vm.autoImporter == nil {
+		return errAutoImportDisabled
+	}
+
+	addrManager := avax.NewAddressManager(s.vm.ctx)
+	addrs, err := avax.ParseServiceAddresses(addrManager, args.Addresses)
+	if err != nil {
+		return err
+	}
+	to, err := avax.ParseServiceAddress(addrManager, args.To)
+	if err != nil {
+		return fmt.Errorf("couldn't parse 'to' address: %w", err)
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	user, err := keystore.NewUserFromKeystore(s.vm.ctx.Keystore, args.Username, args.Password)
+	if err != nil {
+		return err
+	}
+	defer user.Close()
+
+	kc, err := keystore.GetKeychain(user, addrs)
+	if err != nil {
+		return err
+	}
+	if kc.Addrs.Len() == 0 {
+		return errNoAutoImportKeys
+	}
+
+	s.vm.autoImporter.Enable(kc, to)
+	return user.Close()
+}
+
+// DisableAutoImport stops importing atomic UTXOs and forgets the keys.
+func (s *AdminService) DisableAutoImport(_ *http.Request, _ *struct{}, _ *api.EmptyReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platformAdmin"),
+		zap.String("method", "disableAutoImport"),
+	)
+
+	if s.vm.autoImporter == nil {
+		return errAutoImportDisabled
+	}
+	s.vm.autoImporter.Disable()
+	return nil
+}
+
+// GetAutoImportLogArgs are the arguments to GetAutoImportLog
+type GetAutoImportLogArgs struct {
+	// Index of the first entry to return
+	StartIndex avajson.Uint64 `json:"startIndex"`
+	// Max number of entries to return. Defaults to, and is capped at,
+	// [maxAutoImportEntries].
+	Limit avajson.Uint32 `json:"limit"`
+}
+
+// AutoImportEntry is an ImportTx that was issued by the auto-importer, or that
+// failed to be built or issued
+type AutoImportEntry struct {
+	Index         avajson.Uint64 `json:"index"`
+	Timestamp     avajson.Uint64 `json:"timestamp"`
+	SourceChainID ids.ID         `json:"sourceChainID"`
+	To            string         `json:"to"`
+	TxID          ids.ID         `json:"txID"`
+	NumUTXOs      avajson.Uint32 `json:"numUTXOs"`
+	Error         string         `json:"error,omitempty"`
+}
+
+// GetAutoImportLogReply is the response from GetAutoImportLog
+type GetAutoImportLogReply struct {
+	// True if keys are currently provided to the auto-importer
+	Enabled bool `json:"enabled"`
+	// Addresses of the keys whose UTXOs are imported
+	Addresses []string `json:"addresses"`
+	// Address that the UTXOs are imported to
+	To      string            `json:"to"`
+	Entries []AutoImportEntry `json:"entries"`
+	// Index to pass as the start index of the next call
+	NextIndex avajson.Uint64 `json:"nextIndex"`
+}
+
+// GetAutoImportLog returns the status of the auto-importer and its recent
+// entries, starting at [args.StartIndex]. Only the most recent entries are
+// remembered, so a caller that falls too far behind receives the oldest
+// remembered entries instead.
+func (s *AdminService) GetAutoImportLog(_ *http.Request, args *GetAutoImportLogArgs, reply *GetAutoImportLogReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platformAdmin"),
+		zap.String("method", "getAutoImportLog"),
+		zap.Uint64("startIndex", uint64(args.StartIndex)),
+	)
+
+	if s.vm.autoImporter == nil {
+		return errAutoImportDisabled
+	}
+
+	addrManager := avax.NewAddressManager(s.vm.ctx)
+	addrs, to, enabled := s.vm.autoImporter.Enabled()
+	reply.Enabled = enabled
+	reply.Addresses = make([]string, len(addrs))
+	for i, addr := range addrs {
+		addrStr, err := addrManager.FormatLocalAddress(addr)
+		if err != nil {
+			return err
+		}
+		reply.Addresses[i] = addrStr
+	}
+	if enabled {
+		toStr, err := addrManager.FormatLocalAddress(to)
+		if err != nil {
+			return err
+		}
+		reply.To = toStr
+	}
+
+	limit := maxAutoImportEntries
+	if args.Limit != 0 && int(args.Limit) < limit {
+		limit = int(args.Limit)
+	}
+	entries, nextIndex := s.vm.autoImporter.Entries(uint64(args.StartIndex), limit)
+
+	reply.Entries = make([]AutoImportEntry, len(entries))
+	for i, entry := range entries {
+		toStr, err := addrManager.FormatLocalAddress(entry.To)
+		if err != nil {
+			return err
+		}
+		reply.Entries[i] = AutoImportEntry{
+			Index:         avajson.Uint64(entry.Index),
+			Timestamp:     avajson.Uint64(entry.Timestamp),
+			SourceChainID: entry.SourceChainID,
+			To:            toStr,
+			TxID:          entry.TxID,
+			NumUTXOs:      avajson.Uint32(entry.NumUTXOs),
+			Error:         entry.Error,
+		}
+	}
+	reply.NextIndex = avajson.Uint64(nextIndex)
+	return nil
+}
+
+// ForceAcceptBlockArgs are the arguments to ForceAcceptBlock
+type ForceAcceptBlockArgs struct {
+	// Block to accept
+	Block string `json:"block"`
+	// Signatures of the block by the recovery keys. See
+	// [recoveryMessageHash] for the signed message.
+	Signatures []string            `json:"signatures"`
+	Encoding   formatting.Encoding `json:"encoding"`
+}
+
+// ForceAcceptBlockReply is the response from ForceAcceptBlock
+type ForceAcceptBlockReply struct {
+	BlockID ids.ID         `json:"blockID"`
+	Height  avajson.Uint64 `json:"height"`
+}
+
+// ForceAcceptBlock accepts a block without running consensus on it. It is
+// meant to recover a halted network, by having every node accept a block that
+// was signed offline by a quorum of the recovery keys configured in the
+// execution config.
+func (s *AdminService) ForceAcceptBlock(r *http.Request, args *ForceAcceptBlockArgs, reply *ForceAcceptBlockReply) error {
+	s.vm.ctx.Log.Warn("API called",
+		zap.String("service", "platformAdmin"),
+		zap.String("method", "forceAcceptBlock"),
+		zap.Int("numSignatures", len(args.Signatures)),
+	)
+
+	blkBytes, err := formatting.Decode(args.Encoding, args.Block)
+	if err != nil {
+		return fmt.Errorf("couldn't decode block: %w", err)
+	}
+	sigs := make([][]byte, len(args.Signatures))
+	for i, sigStr := range args.Signatures {
+		sigs[i], err = formatting.Decode(args.Encoding, sigStr)
+		if err != nil {
+			return fmt.Errorf("couldn't decode signature %d: %w", i, err)
+		}
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	blk, err := s.vm.forceAcceptBlock(r.Context(), blkBytes, sigs)
+	if err != nil {
+		return err
+	}
+	reply.BlockID = blk.ID()
+	reply.Height = avajson.Uint64(blk.Height())
+	return nil
+}
+
+// ReconcileSharedMemoryArgs are the arguments to ReconcileSharedMemory
+type ReconcileSharedMemoryArgs struct {
+	StartHeight avajson.Uint64 `json:"startHeight"`
+	EndHeight   avajson.Uint64 `json:"endHeight"`
+	// If true, the missing removals are reported without being applied.
+	DryRun bool `json:"dryRun"`
+}
+
+// MissingRemovals are the shared memory removals of an accepted block that
+// weren't applied to shared memory
+type MissingRemovals struct {
+	BlockID     ids.ID         `json:"blockID"`
+	Height      avajson.Uint64 `json:"height"`
+	PeerChainID ids.ID         `json:"peerChainID"`
+	// Hex encoded keys that are still present in shared memory
+	Keys []string `json:"keys"`
+}
+
+// ReconcileSharedMemoryReply is the response from ReconcileSharedMemory
+type ReconcileSharedMemoryReply struct {
+	Missing []MissingRemovals `json:"missing"`
+	// Repaired is true if the missing removals were applied.
+	Repaired bool `json:"repaired"`
+}
+
+// ReconcileSharedMemory checks shared memory against the requests recorded for
+// the accepted blocks at heights in [args.StartHeight, args.EndHeight], and
+// applies the removals that are missing from it unless [args.DryRun] is set.
+func (s *AdminService) ReconcileSharedMemory(_ *http.Request, args *ReconcileSharedMemoryArgs, reply *ReconcileSharedMemoryReply) error {
+	s.vm.ctx.Log.Warn("API called",
+		zap.String("service", "platformAdmin"),
+		zap.String("method", "reconcileSharedMemory"),
+		zap.Uint64("startHeight", uint64(args.StartHeight)),
+		zap.Uint64("endHeight", uint64(args.EndHeight)),
+		zap.Bool("dryRun", args.DryRun),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	missing, err := s.vm.reconcileSharedMemory(uint64(args.StartHeight), uint64(args.EndHeight), args.DryRun)
+	if err != nil {
+		return err
+	}
+
+	reply.Missing = make([]MissingRemovals, len(missing))
+	for i, m := range missing {
+		keys := make([]string, len(m.keys))
+		for j, key := range m.keys {
+			keys[j], err = formatting.Encode(formatting.Hex, key)
+			if err != nil {
+				return fmt.Errorf("couldn't encode key: %w", err)
+			}
+		}
+		reply.Missing[i] = MissingRemovals{
+			BlockID:     m.blkID,
+			Height:      avajson.Uint64(m.height),
+			PeerChainID: m.peerChainID,
+			Keys:        keys,
+		}
+	}
+	reply.Repaired = !args.DryRun && len(missing) != 0
+	return nil
+}
+
+// PeerBan is a peer whose messages are dropped for gossiping invalid txs
+type PeerBan struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	End    time.Time  `json:"end"`
+}
+
+// GetPeerBansReply is the response from GetPeerBans
+type GetPeerBansReply struct {
+	Bans []PeerBan `json:"bans"`
+}
+
+// GetPeerBans returns the peers that are currently banned for gossiping
+// invalid txs.
+func (s *AdminService) GetPeerBans(_ *http.Request, _ *struct{}, reply *GetPeerBansReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platformAdmin"),
+		zap.String("method", "getPeerBans"),
+	)
+
+	bans := s.vm.peerBans.Bans()
+	reply.Bans = make([]PeerBan, 0, len(bans))
+	for nodeID, end := range bans {
+		reply.Bans = append(reply.Bans, PeerBan{
+			NodeID: nodeID,
+			End:    end,
+		})
+	}
+	slices.SortFunc(reply.Bans, func(a, b PeerBan) int {
+		return a.NodeID.Compare(b.NodeID)
+	})
+	return nil
+}
+
+// GetValidatorAllowlistReply is the response from GetValidatorAllowlist
+type GetValidatorAllowlistReply struct {
+	// NodeIDs that may be added as primary network validators. Empty if no
+	// allowlist is configured.
+	NodeIDs []ids.NodeID `json:"nodeIDs"`
+	// Time after which the allowlist is no longer enforced
+	OpenValidatorSetTime time.Time `json:"openValidatorSetTime"`
+	// Enforced is true if the allowlist restricts the validators that can be
+	// added at the current chain time.
+	Enforced bool `json:"enforced"`
+}
+
+// GetValidatorAllowlist returns the allowlist of the nodes that may be added
+// as primary network validators before the open validator set upgrade.
+func (s *AdminService) GetValidatorAllowlist(_ *http.Request, _ *struct{}, reply *GetValidatorAllowlistReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platformAdmin"),
+		zap.String("method", "getValidatorAllowlist"),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	if s.vm.ValidatorAllowlist == nil {
+		reply.NodeIDs = []ids.NodeID{}
+		return nil
+	}
+
+	reply.NodeIDs = s.vm.ValidatorAllowlist.List()
+	utils.Sort(reply.NodeIDs)
+	reply.OpenValidatorSetTime = s.vm.OpenValidatorSetTime
+	reply.Enforced = !s.vm.IsOpenValidatorSetActivated(s.vm.state.GetTimestamp())
+	return nil
+}
+
+// ClearPeerBanArgs are the arguments to ClearPeerBan
+type ClearPeerBanArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+}
+
+// ClearPeerBan lifts the ban of a peer and resets its score.
+func (s *AdminService) ClearPeerBan(_ *http.Request, args *ClearPeerBanArgs, _ *api.EmptyReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platformAdmin"),
+		zap.String("method", "clearPeerBan"),
+		zap.Stringer("nodeID", args.NodeID),
+	)
+
+	return s.vm.peerBans.Clear(args.NodeID)
+}
+
+// SetClockArgs are the arguments to SetClock
+type SetClockArgs struct {
+	Time time.Time `json:"time"`
+}
+
+// AdvanceClockArgs are the arguments to AdvanceClock
+type AdvanceClockArgs struct {
+	Seconds avajson.Uint64 `json:"seconds"`
+}
+
+// ClockReply is the response from the methods that override the clock
+type ClockReply struct {
+	// Time of the clock after it was overridden
+	Time time.Time `json:"time"`
+}
+
+// SetClock fakes the time of the clock that every subsystem of the chain reads
+// the time from. It is only allowed if the clock override is enabled in the
+// execution config, and is meant to simulate the passage of time, such as
+// staking period transitions, on test networks.
+func (s *AdminService) SetClock(_ *http.Request, args *SetClockArgs, reply *ClockReply) error {
+	s.vm.ctx.Log.Warn("API called",
+		zap.String("service", "platformAdmin"),
+		zap.String("method", "setClock"),
+		zap.Time("time", args.Time),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	return s.setClock(args.Time, reply)
+}
+
+// AdvanceClock moves the clock forward by the provided number of seconds. See
+// SetClock.
+func (s *AdminService) AdvanceClock(_ *http.Request, args *AdvanceClockArgs, reply *ClockReply) error {
+	s.vm.ctx.Log.Warn("API called",
+		zap.String("service", "platformAdmin"),
+		zap.String("method", "advanceClock"),
+		zap.Uint64("seconds", uint64(args.Seconds)),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	duration := time.Duration(args.Seconds) * time.Second
+	return s.setClock(s.vm.clock.Time().Add(duration), reply)
+}
+
+// SyncClock stops faking the time of the clock, so that it follows the wall
+// clock again.
+func (s *AdminService) SyncClock(_ *http.Request, _ *struct{}, reply *ClockReply) error {
+	s.vm.ctx.Log.Warn("API called",
+		zap.String("service", "platformAdmin"),
+		zap.String("method", "syncClock"),
+	)
+
+	if !s.vm.execConfig.ClockOverrideEnabled {
+		return errClockOverrideDisabled
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	s.vm.clock.Sync()
+	s.vm.Builder.ResetBlockTimer()
+	reply.Time = s.vm.clock.Time()
+	return nil
+}
+
+// setClock fakes the time of the clock to [now] and wakes up the block builder,
+// as staker set changes may be due at the new time.
+//
+// Invariant: the context lock is held.
+func (s *AdminService) setClock(now time.Time, reply *ClockReply) error {
+	if !s.vm.execConfig.ClockOverrideEnabled {
+		return errClockOverrideDisabled
+	}
+	if current := s.vm.clock.Time(); now.Before(current) {
+		return fmt.Errorf("%w: %s is before %s", errClockMovedBackwards, now, current)
+	}
+
+	s.vm.clock.Set(now)
+	s.vm.Builder.ResetBlockTimer()
+	reply.Time = now
+	return nil
+}
+
+// ConformanceViolation is an accepted block that breaks one of the timing
+// invariants of the chain
+type ConformanceViolation struct {
+	Height  avajson.Uint64 `json:"height"`
+	BlockID ids.ID         `json:"blockID"`
+	// ID of the tx or of the staker involved, if any
+	TxID   ids.ID `json:"txID"`
+	Reason string `json:"reason"`
+}
+
+// CheckChainConformanceReply is the response from CheckChainConformance
+type CheckChainConformanceReply struct {
+	// Height and ID of the last checked block
+	Height  avajson.Uint64 `json:"height"`
+	BlockID ids.ID         `json:"blockID"`
+	// Chain time after the last checked block
+	ChainTime           time.Time      `json:"chainTime"`
+	NumTimestampChanges avajson.Uint64 `json:"numTimestampChanges"`
+	NumStakerStarts     avajson.Uint64 `json:"numStakerStarts"`
+	NumStakerEnds       avajson.Uint64 `json:"numStakerEnds"`
+	NumStakerExits      avajson.Uint64 `json:"numStakerExits"`
+	// Violations are empty if the chain is conformant
+	Violations []ConformanceViolation `json:"violations"`
+}
+
+// CheckChainConformance walks the accepted chain from genesis and reports the
+// blocks that move the timestamp backwards or that don't transition the
+// stakers exactly at their start and end times. The walk is performed in
+// batches, so that the chain keeps making progress while it is checked.
+func (s *AdminService) CheckChainConformance(r *http.Request, _ *struct{}, reply *CheckChainConformanceReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platformAdmin"),
+		zap.String("method", "checkChainConformance"),
+	)
+
+	report, err := s.vm.checkConformance(r.Context())
+	if err != nil {
+		return err
+	}
+
+	reply.Height = avajson.Uint64(report.height)
+	reply.BlockID = report.blkID
+	reply.ChainTime = report.chainTime
+	reply.NumTimestampChanges = avajson.Uint64(report.numTimestampChanges)
+	reply.NumStakerStarts = avajson.Uint64(report.numStakerStarts)
+	reply.NumStakerEnds = avajson.Uint64(report.numStakerEnds)
+	reply.NumStakerExits = avajson.Uint64(report.numStakerExits)
+	reply.Violations = make([]ConformanceViolation, len(report.violations))
+	for i, v := range report.violations {
+		reply.Violations[i] = ConformanceViolation{
+			Height:  avajson.Uint64(v.height),
+			BlockID: v.blkID,
+			TxID:    v.txID,
+			Reason:  v.err.Error(),
+		}
+	}
+	return nil
+}
+
+// SimulateForkArgs are the arguments for calling SimulateFork
+type SimulateForkArgs struct {
+	// Name of the network upgrade to activate, e.g. "durango". Defaults to
+	// the next scheduled upgrade.
+	Fork      string         `json:"fork"`
+	NumBlocks avajson.Uint32 `json:"numBlocks"`
+}
+
+// SimulatedBlock is a hypothetical block built by SimulateFork
+type SimulatedBlock struct {
+	BlockID   ids.ID         `json:"blockID"`
+	Height    avajson.Uint64 `json:"height"`
+	Timestamp time.Time      `json:"timestamp"`
+	NumTxs    avajson.Uint32 `json:"numTxs"`
+	// ID of the staker rewarded by the block, if any
+	RewardedTxID ids.ID `json:"rewardedTxID"`
+	// Error is the verification error of the block under the rules of the
+	// fork, if any
+	Error string `json:"error,omitempty"`
+}
+
+// SimulatedTxFailure is a mempool tx that fails under the rules of the fork
+type SimulatedTxFailure struct {
+	TxID   ids.ID         `json:"txID"`
+	Height avajson.Uint64 `json:"height"`
+	Error  string         `json:"error"`
+	// PreForkError is the error of the tx under the current rules. It is empty
+	// if the failure is introduced by the fork.
+	PreForkError string `json:"preForkError,omitempty"`
+}
+
+// SimulateForkReply is the response from SimulateFork
+type SimulateForkReply struct {
+	Fork           string               `json:"fork"`
+	ActivationTime time.Time            `json:"activationTime"`
+	Blocks         []SimulatedBlock     `json:"blocks"`
+	FailedTxs      []SimulatedTxFailure `json:"failedTxs"`
+	// Number of mempool txs that weren't included in the simulated blocks
+	NumRemainingTxs avajson.Uint32 `json:"numRemainingTxs"`
+}
+
+// SimulateFork activates a network upgrade at the current chain time on a
+// scratch copy of the state, and builds the next [args.NumBlocks] blocks out
+// of the mempool under its rules. The txs and blocks that would fail to be
+// verified after the activation are reported. Neither the state nor the
+// mempool are modified.
+func (s *AdminService) SimulateFork(_ *http.Request, args *SimulateForkArgs, reply *SimulateForkReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platformAdmin"),
+		zap.String("method", "simulateFork"),
+		zap.String("fork", args.Fork),
+		zap.Uint32("numBlocks", uint32(args.NumBlocks)),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	simulation, err := s.vm.simulateFork(args.Fork, int(args.NumBlocks))
+	if err != nil {
+		return err
+	}
+
+	reply.Fork = simulation.fork
+	reply.ActivationTime = simulation.activationTime
+	reply.Blocks = make([]SimulatedBlock, len(simulation.blocks))
+	for i, blk := range simulation.blocks {
+		reply.Blocks[i] = SimulatedBlock{
+			BlockID:      blk.blkID,
+			Height:       avajson.Uint64(blk.height),
+			Timestamp:    blk.timestamp,
+			NumTxs:       avajson.Uint32(blk.numTxs),
+			RewardedTxID: blk.rewardedTxID,
+		}
+		if blk.err != nil {
+			reply.Blocks[i].Error = blk.err.Error()
+		}
+	}
+	reply.FailedTxs = make([]SimulatedTxFailure, len(simulation.failedTxs))
+	for i, failure := range simulation.failedTxs {
+		reply.FailedTxs[i] = SimulatedTxFailure{
+			TxID:   failure.txID,
+			Height: avajson.Uint64(failure.height),
+			Error:  failure.err.Error(),
+		}
+		if failure.preForkErr != nil {
+			reply.FailedTxs[i].PreForkError = failure.preForkErr.Error()
+		}
+	}
+	reply.NumRemainingTxs = avajson.Uint32(simulation.numRemainingTxs)
+	return nil
+}
@@ -0,0 +1,451 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/chains/atomic"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/message"
+	"github.com/ava-labs/avalanchego/vms/platformvm/backup"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/watchlist"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	avajson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+func requireRestoresTo(t *testing.T, archive []byte, db database.Database) {
+	require := require.New(t)
+
+	restoredDB := memdb.New()
+	_, _, err := backup.Restore(bytes.NewReader(archive), restoredDB)
+	require.NoError(err)
+
+	expectedCount, err := database.Count(db)
+	require.NoError(err)
+	restoredCount, err := database.Count(restoredDB)
+	require.NoError(err)
+	require.Equal(expectedCount, restoredCount)
+}
+
+func TestAdminServiceBackup(t *testing.T) {
+	require := require.New(t)
+
+	vm, db, _ := defaultVM(t, latestFork)
+	service := &AdminService{vm: vm}
+
+	lastAcceptedID := vm.state.GetLastAccepted()
+
+	// A backup must be written to exactly one sink.
+	err := service.Backup(nil, &BackupArgs{}, &BackupReply{})
+	require.ErrorIs(err, errInvalidBackupSink)
+
+	path := filepath.Join(t.TempDir(), "backup.tar.zst")
+	reply := BackupReply{}
+	require.NoError(service.Backup(nil, &BackupArgs{Path: path}, &reply))
+	require.Equal(lastAcceptedID, reply.LastAccepted)
+
+	archive, err := os.ReadFile(path)
+	require.NoError(err)
+	requireRestoresTo(t, archive, db)
+
+	received := &bytes.Buffer{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.Copy(received, r.Body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	require.NoError(service.Backup(req, &BackupArgs{URL: server.URL}, &reply))
+	require.Equal(lastAcceptedID, reply.LastAccepted)
+	requireRestoresTo(t, received.Bytes(), db)
+}
+
+func TestAdminServiceRewardWatchlist(t *testing.T) {
+	require := require.New(t)
+
+	vm, _, _ := defaultVM(t, latestFork)
+	service := &AdminService{vm: vm}
+
+	addrManager := avax.NewAddressManager(vm.ctx)
+	addrs := make([]string, len(keys))
+	for i, key := range keys {
+		addr, err := addrManager.FormatLocalAddress(key.Address())
+		require.NoError(err)
+		addrs[i] = addr
+	}
+
+	require.NoError(service.WatchRewardAddresses(nil, &api.JSONAddresses{Addresses: addrs}, &api.EmptyReply{}))
+	require.NoError(service.UnwatchRewardAddresses(nil, &api.JSONAddresses{Addresses: addrs[1:]}, &api.EmptyReply{}))
+
+	watchlistReply := api.JSONAddresses{}
+	require.NoError(service.GetRewardWatchlist(nil, nil, &watchlistReply))
+	require.Equal(addrs[:1], watchlistReply.Addresses)
+
+	// Pay out a reward to every key.
+	rewardUTXOs := make([]*avax.UTXO, len(keys))
+	for i, key := range keys {
+		rewardUTXOs[i] = &avax.UTXO{
+			UTXOID: avax.UTXOID{OutputIndex: uint32(i)},
+			Asset:  avax.Asset{ID: vm.ctx.AVAXAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: units.Avax,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{key.Address()},
+				},
+			},
+		}
+	}
+	vm.rewardWatchlist.Record(
+		watchlist.Reward{NodeID: genesisNodeIDs[0]},
+		rewardUTXOs,
+	)
+
+	reply := GetRewardAccrualsReply{}
+	require.NoError(service.GetRewardAccruals(nil, &GetRewardAccrualsArgs{}, &reply))
+	require.Equal(avajson.Uint64(1), reply.NextIndex)
+	require.Equal(
+		[]RewardAccrual{{
+			Index:   0,
+			NodeID:  genesisNodeIDs[0],
+			UTXOID:  rewardUTXOs[0].InputID(),
+			Address: addrs[0],
+			AssetID: vm.ctx.AVAXAssetID,
+			Amount:  avajson.Uint64(units.Avax),
+		}},
+		reply.Accruals,
+	)
+
+	require.NoError(service.GetRewardAccruals(nil, &GetRewardAccrualsArgs{StartIndex: reply.NextIndex}, &reply))
+	require.Empty(reply.Accruals)
+}
+
+func TestAdminServiceForceAcceptBlock(t *testing.T) {
+	require := require.New(t)
+
+	vm, _, _ := defaultVM(t, latestFork)
+	service := &AdminService{vm: vm}
+
+	vm.ctx.Lock.Lock()
+	tx, err := vm.txBuilder.NewCreateSubnetTx(
+		1, // threshold
+		[]ids.ShortID{keys[0].Address()},
+		secp256k1fx.NewKeychain(keys[0]),
+		keys[0].Address(), // change addr
+		nil,
+	)
+	require.NoError(err)
+
+	lastAcceptedID := vm.manager.LastAccepted()
+	lastAccepted, err := vm.manager.GetStatelessBlock(lastAcceptedID)
+	require.NoError(err)
+	newBlock := func(parentID ids.ID) block.Block {
+		blk, err := block.NewBanffStandardBlock(
+			vm.state.GetTimestamp(),
+			parentID,
+			lastAccepted.Height()+1,
+			[]*txs.Tx{tx},
+		)
+		require.NoError(err)
+		return blk
+	}
+	vm.ctx.Lock.Unlock()
+
+	newArgs := func(blk block.Block, signers ...*secp256k1.PrivateKey) *ForceAcceptBlockArgs {
+		blkStr, err := formatting.Encode(formatting.Hex, blk.Bytes())
+		require.NoError(err)
+
+		hash := recoveryMessageHash(blk.ID())
+		sigs := make([]string, len(signers))
+		for i, signer := range signers {
+			sig, err := signer.SignHash(hash)
+			require.NoError(err)
+			sigs[i], err = formatting.Encode(formatting.Hex, sig)
+			require.NoError(err)
+		}
+		return &ForceAcceptBlockArgs{
+			Block:      blkStr,
+			Signatures: sigs,
+			Encoding:   formatting.Hex,
+		}
+	}
+
+	var (
+		blk = newBlock(lastAcceptedID)
+		req = httptest.NewRequest(http.MethodPost, "/", nil)
+	)
+	err = service.ForceAcceptBlock(req, newArgs(blk, keys[0], keys[1]), &ForceAcceptBlockReply{})
+	require.ErrorIs(err, errRecoveryDisabled)
+
+	vm.recoveryKeys = &recoveryKeys{
+		addrs:     set.Of(keys[0].Address(), keys[1].Address(), keys[2].Address()),
+		threshold: 2,
+	}
+
+	err = service.ForceAcceptBlock(req, newArgs(blk, keys[0]), &ForceAcceptBlockReply{})
+	require.ErrorIs(err, errInsufficientRecoverySigners)
+
+	err = service.ForceAcceptBlock(req, newArgs(blk, keys[0], keys[0]), &ForceAcceptBlockReply{})
+	require.ErrorIs(err, errDuplicateRecoverySigner)
+
+	err = service.ForceAcceptBlock(req, newArgs(blk, keys[0], keys[4]), &ForceAcceptBlockReply{})
+	require.ErrorIs(err, errUnknownRecoverySigner)
+
+	err = service.ForceAcceptBlock(req, newArgs(newBlock(ids.GenerateTestID()), keys[0], keys[1]), &ForceAcceptBlockReply{})
+	require.ErrorIs(err, errRecoveryBlockNotChild)
+
+	// The block is refused while a conflicting block is processing
+	vm.ctx.Lock.Lock()
+	siblingTx, err := vm.txBuilder.NewCreateSubnetTx(
+		1, // threshold
+		[]ids.ShortID{keys[1].Address()},
+		secp256k1fx.NewKeychain(keys[1]),
+		keys[1].Address(), // change addr
+		nil,
+	)
+	require.NoError(err)
+	siblingBlk, err := block.NewBanffStandardBlock(
+		vm.state.GetTimestamp(),
+		lastAcceptedID,
+		lastAccepted.Height()+1,
+		[]*txs.Tx{siblingTx},
+	)
+	require.NoError(err)
+	sibling := vm.manager.NewBlock(siblingBlk)
+	require.NoError(sibling.Verify(context.Background()))
+	vm.ctx.Lock.Unlock()
+
+	err = service.ForceAcceptBlock(req, newArgs(blk, keys[1], keys[2]), &ForceAcceptBlockReply{})
+	require.ErrorIs(err, errRecoveryConflictingBlock)
+
+	vm.ctx.Lock.Lock()
+	require.NoError(sibling.Reject(context.Background()))
+	vm.ctx.Lock.Unlock()
+
+	reply := ForceAcceptBlockReply{}
+	require.NoError(service.ForceAcceptBlock(req, newArgs(blk, keys[1], keys[2]), &reply))
+	require.Equal(blk.ID(), reply.BlockID)
+	require.Equal(avajson.Uint64(blk.Height()), reply.Height)
+
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	require.Equal(blk.ID(), vm.manager.LastAccepted())
+	_, txStatus, err := vm.state.GetTx(tx.ID())
+	require.NoError(err)
+	require.Equal(status.Committed, txStatus)
+}
+
+func TestAdminServicePeerBans(t *testing.T) {
+	require := require.New(t)
+
+	vm, _, _ := defaultVM(t, latestFork)
+	service := &AdminService{vm: vm}
+
+	reply := GetPeerBansReply{}
+	require.NoError(service.GetPeerBans(nil, nil, &reply))
+	require.Empty(reply.Bans)
+
+	// A peer that keeps gossiping malformed txs is banned.
+	msgBytes, err := message.Build(&message.Tx{
+		Tx: []byte{0x00},
+	})
+	require.NoError(err)
+	nodeID := ids.GenerateTestNodeID()
+	for !vm.peerBans.IsBanned(nodeID) {
+		require.NoError(vm.Network.AppGossip(context.Background(), nodeID, msgBytes))
+	}
+
+	require.NoError(service.GetPeerBans(nil, nil, &reply))
+	require.Len(reply.Bans, 1)
+	require.Equal(nodeID, reply.Bans[0].NodeID)
+
+	require.NoError(service.ClearPeerBan(nil, &ClearPeerBanArgs{NodeID: nodeID}, &api.EmptyReply{}))
+	require.False(vm.peerBans.IsBanned(nodeID))
+	require.NoError(service.GetPeerBans(nil, nil, &reply))
+	require.Empty(reply.Bans)
+}
+
+func TestAdminServiceClock(t *testing.T) {
+	require := require.New(t)
+
+	vm, _, _ := defaultVM(t, latestFork)
+	service := &AdminService{vm: vm}
+
+	reply := ClockReply{}
+	err := service.AdvanceClock(nil, &AdvanceClockArgs{Seconds: 1}, &reply)
+	require.ErrorIs(err, errClockOverrideDisabled)
+
+	vm.execConfig.ClockOverrideEnabled = true
+
+	now := vm.clock.Time()
+	err = service.SetClock(nil, &SetClockArgs{Time: now.Add(-time.Second)}, &reply)
+	require.ErrorIs(err, errClockMovedBackwards)
+
+	// Peer bans end according to the clock of the VM
+	msgBytes, err := message.Build(&message.Tx{
+		Tx: []byte{0x00},
+	})
+	require.NoError(err)
+	nodeID := ids.GenerateTestNodeID()
+	for !vm.peerBans.IsBanned(nodeID) {
+		require.NoError(vm.Network.AppGossip(context.Background(), nodeID, msgBytes))
+	}
+
+	require.NoError(service.AdvanceClock(nil, &AdvanceClockArgs{Seconds: 3600}, &reply))
+	require.Equal(now.Add(time.Hour), reply.Time)
+	require.Equal(now.Add(time.Hour), vm.clock.Time())
+	require.False(vm.peerBans.IsBanned(nodeID))
+
+	require.NoError(service.SyncClock(nil, nil, &reply))
+	require.WithinDuration(time.Now(), vm.clock.Time(), time.Minute)
+}
+
+func TestAdminServiceValidatorAllowlist(t *testing.T) {
+	require := require.New(t)
+
+	vm, _, _ := defaultVM(t, latestFork)
+	service := &AdminService{vm: vm}
+
+	reply := GetValidatorAllowlistReply{}
+	require.NoError(service.GetValidatorAllowlist(nil, nil, &reply))
+	require.Empty(reply.NodeIDs)
+	require.False(reply.Enforced)
+
+	nodeID := ids.GenerateTestNodeID()
+	openTime := vm.state.GetTimestamp().Add(time.Hour)
+	vm.ctx.Lock.Lock()
+	vm.ValidatorAllowlist = set.Of(nodeID)
+	vm.OpenValidatorSetTime = openTime
+	vm.ctx.Lock.Unlock()
+
+	require.NoError(service.GetValidatorAllowlist(nil, nil, &reply))
+	require.Equal([]ids.NodeID{nodeID}, reply.NodeIDs)
+	require.True(openTime.Equal(reply.OpenValidatorSetTime))
+	require.True(reply.Enforced)
+}
+
+func TestAdminServiceReconcileSharedMemory(t *testing.T) {
+	require := require.New(t)
+
+	vm, _, mutableSharedMemory := defaultVM(t, latestFork)
+	service := &AdminService{vm: vm}
+
+	m := atomic.NewMemory(memdb.New())
+	mutableSharedMemory.SharedMemory = m.NewSharedMemory(vm.ctx.ChainID)
+	peerSharedMemory := m.NewSharedMemory(vm.ctx.XChainID)
+
+	removedKey := ids.GenerateTestID()
+	missingKey := ids.GenerateTestID()
+	require.NoError(peerSharedMemory.Apply(map[ids.ID]*atomic.Requests{
+		vm.ctx.ChainID: {
+			PutRequests: []*atomic.Element{
+				{
+					Key:   missingKey[:],
+					Value: []byte{1},
+				},
+			},
+		},
+	}))
+
+	// Record that the last accepted block removed both keys, although only
+	// [removedKey] is absent from shared memory.
+	lastAcceptedID := vm.state.GetLastAccepted()
+	vm.state.AddAtomicRequests(lastAcceptedID, map[ids.ID]*atomic.Requests{
+		vm.ctx.XChainID: {
+			RemoveRequests: [][]byte{removedKey[:], missingKey[:]},
+		},
+	})
+	require.NoError(vm.state.Commit())
+
+	lastAccepted, err := vm.state.GetStatelessBlock(lastAcceptedID)
+	require.NoError(err)
+	height := lastAccepted.Height()
+
+	missingKeyStr, err := formatting.Encode(formatting.Hex, missingKey[:])
+	require.NoError(err)
+	expectedMissing := []MissingRemovals{
+		{
+			BlockID:     lastAcceptedID,
+			Height:      avajson.Uint64(height),
+			PeerChainID: vm.ctx.XChainID,
+			Keys:        []string{missingKeyStr},
+		},
+	}
+
+	// The range must end at or before the last accepted block.
+	err = service.ReconcileSharedMemory(nil, &ReconcileSharedMemoryArgs{
+		StartHeight: avajson.Uint64(height),
+		EndHeight:   avajson.Uint64(height + 1),
+	}, &ReconcileSharedMemoryReply{})
+	require.ErrorIs(err, errInvalidReconciliationRange)
+
+	args := &ReconcileSharedMemoryArgs{
+		StartHeight: avajson.Uint64(height),
+		EndHeight:   avajson.Uint64(height),
+		DryRun:      true,
+	}
+	reply := ReconcileSharedMemoryReply{}
+	require.NoError(service.ReconcileSharedMemory(nil, args, &reply))
+	require.Equal(expectedMissing, reply.Missing)
+	require.False(reply.Repaired)
+
+	_, err = vm.ctx.SharedMemory.Get(vm.ctx.XChainID, [][]byte{missingKey[:]})
+	require.NoError(err)
+
+	args.DryRun = false
+	reply = ReconcileSharedMemoryReply{}
+	require.NoError(service.ReconcileSharedMemory(nil, args, &reply))
+	require.Equal(expectedMissing, reply.Missing)
+	require.True(reply.Repaired)
+
+	_, err = vm.ctx.SharedMemory.Get(vm.ctx.XChainID, [][]byte{missingKey[:]})
+	require.ErrorIs(err, database.ErrNotFound)
+
+	reply = ReconcileSharedMemoryReply{}
+	require.NoError(service.ReconcileSharedMemory(nil, args, &reply))
+	require.Empty(reply.Missing)
+	require.False(reply.Repaired)
+}
+
+func TestAdminServiceCheckChainConformance(t *testing.T) {
+	require := require.New(t)
+
+	vm, _, _ := defaultVM(t, latestFork)
+	service := &AdminService{vm: vm}
+
+	reply := CheckChainConformanceReply{}
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	require.NoError(service.CheckChainConformance(r, nil, &reply))
+	require.Equal(vm.state.GetLastAccepted(), reply.BlockID)
+	require.Equal(vm.state.GetTimestamp(), reply.ChainTime)
+	require.Empty(reply.Violations)
+}
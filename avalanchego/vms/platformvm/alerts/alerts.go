@@ -0,0 +1,249 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package alerts
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+const (
+	// ValidatorExpiry fires when the primary network validator of this node
+	// ends within [Config.ValidatorExpiryWindow].
+	ValidatorExpiry = "validator_expiry"
+	// LowConnectivity fires when this node validates the primary network and
+	// is connected to less than [Config.MinConnectedPercentage] of its stake.
+	LowConnectivity = "low_connectivity"
+	// StaleMempool fires when the oldest tx of the mempool has been waiting
+	// for longer than [Config.MaxMempoolAge].
+	StaleMempool = "stale_mempool"
+)
+
+var rules = []string{
+	ValidatorExpiry,
+	LowConnectivity,
+	StaleMempool,
+}
+
+// Config of the alert rules. A rule with a zero threshold is disabled.
+type Config struct {
+	ValidatorExpiryWindow time.Duration
+	// Percentage, in [0, 100], of the primary network stake
+	MinConnectedPercentage float64
+	MaxMempoolAge          time.Duration
+}
+
+// Status is the state of this node that the rules are evaluated against.
+type Status struct {
+	Now time.Time
+	// End time of the primary network validator of this node. Zero if this
+	// node isn't a primary network validator.
+	ValidatorEndTime time.Time
+	// Percentage, in [0, 100], of the primary network stake that this node is
+	// connected to
+	ConnectedPercentage float64
+	// Oldest tx of the mempool. Empty if the mempool is empty.
+	OldestMempoolTxID ids.ID
+}
+
+// Alert is a rule that is firing.
+type Alert struct {
+	Rule    string    `json:"rule"`
+	Message string    `json:"message"`
+	Since   time.Time `json:"since"`
+}
+
+// Event is reported when an alert starts firing, or once it is resolved.
+type Event struct {
+	Alert
+	Resolved bool      `json:"resolved"`
+	Time     time.Time `json:"time"`
+}
+
+// Handler is called with every event. It is called synchronously by
+// [Evaluator.Evaluate], so it must not block.
+type Handler func(Event)
+
+// Evaluator evaluates the alert rules and reports the alerts that start
+// firing, or that are resolved, to its handlers.
+type Evaluator struct {
+	config Config
+
+	lock     sync.Mutex
+	handlers []Handler
+	// rule --> alert
+	firing map[string]Alert
+
+	// The mempool doesn't record when its txs were added, so the age of the
+	// oldest tx is measured from the first evaluation it was the oldest at.
+	oldestTxID        ids.ID
+	oldestTxFirstSeen time.Time
+
+	firingMetric *prometheus.GaugeVec
+	firedMetric  *prometheus.CounterVec
+}
+
+func New(config Config, registerer prometheus.Registerer) (*Evaluator, error) {
+	e := &Evaluator{
+		config: config,
+		firing: make(map[string]Alert),
+		firingMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "alerts_firing",
+				Help: "1 if the alert rule is firing, 0 otherwise",
+			},
+			[]string{"rule"},
+		),
+		firedMetric: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "alerts_fired",
+				Help: "Number of times the alert rule started firing",
+			},
+			[]string{"rule"},
+		),
+	}
+	for _, rule := range rules {
+		labels := prometheus.Labels{"rule": rule}
+		e.firingMetric.With(labels).Set(0)
+		e.firedMetric.With(labels)
+	}
+
+	err := utils.Err(
+		registerer.Register(e.firingMetric),
+		registerer.Register(e.firedMetric),
+	)
+	return e, err
+}
+
+// RegisterHandler adds [handler] to the handlers called with every event.
+func (e *Evaluator) RegisterHandler(handler Handler) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.handlers = append(e.handlers, handler)
+}
+
+// Evaluate the rules against [status] and return the firing alerts, ordered by
+// rule.
+func (e *Evaluator) Evaluate(status Status) []Alert {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if status.OldestMempoolTxID != e.oldestTxID {
+		e.oldestTxID = status.OldestMempoolTxID
+		e.oldestTxFirstSeen = status.Now
+	}
+
+	e.update(status.Now, ValidatorExpiry, e.validatorExpiry(status))
+	e.update(status.Now, LowConnectivity, e.lowConnectivity(status))
+	e.update(status.Now, StaleMempool, e.staleMempool(status))
+	return e.firingAlerts()
+}
+
+// Firing returns the alerts that were firing as of the last evaluation,
+// ordered by rule.
+func (e *Evaluator) Firing() []Alert {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	return e.firingAlerts()
+}
+
+func (e *Evaluator) validatorExpiry(status Status) string {
+	if e.config.ValidatorExpiryWindow <= 0 || status.ValidatorEndTime.IsZero() {
+		return ""
+	}
+	remaining := status.ValidatorEndTime.Sub(status.Now)
+	if remaining > e.config.ValidatorExpiryWindow {
+		return ""
+	}
+	return fmt.Sprintf("validator ends at %s, in %s",
+		status.ValidatorEndTime.UTC().Format(time.RFC3339),
+		remaining.Truncate(time.Second),
+	)
+}
+
+func (e *Evaluator) lowConnectivity(status Status) string {
+	if e.config.MinConnectedPercentage <= 0 || status.ValidatorEndTime.IsZero() {
+		return ""
+	}
+	if status.ConnectedPercentage >= e.config.MinConnectedPercentage {
+		return ""
+	}
+	return fmt.Sprintf("connected to %.2f%% of the stake, less than %.2f%%",
+		status.ConnectedPercentage,
+		e.config.MinConnectedPercentage,
+	)
+}
+
+func (e *Evaluator) staleMempool(status Status) string {
+	if e.config.MaxMempoolAge <= 0 || status.OldestMempoolTxID == ids.Empty {
+		return ""
+	}
+	age := status.Now.Sub(e.oldestTxFirstSeen)
+	if age <= e.config.MaxMempoolAge {
+		return ""
+	}
+	return fmt.Sprintf("tx %s has been in the mempool for at least %s",
+		status.OldestMempoolTxID,
+		age.Truncate(time.Second),
+	)
+}
+
+// update the state of [rule], which is firing if [message] isn't empty.
+func (e *Evaluator) update(now time.Time, rule string, message string) {
+	alert, wasFiring := e.firing[rule]
+	switch {
+	case message != "" && wasFiring:
+		// Keep the message up to date without reporting a new event
+		alert.Message = message
+		e.firing[rule] = alert
+	case message != "":
+		alert = Alert{
+			Rule:    rule,
+			Message: message,
+			Since:   now,
+		}
+		e.firing[rule] = alert
+		e.firingMetric.WithLabelValues(rule).Set(1)
+		e.firedMetric.WithLabelValues(rule).Inc()
+		e.report(Event{
+			Alert: alert,
+			Time:  now,
+		})
+	case wasFiring:
+		delete(e.firing, rule)
+		e.firingMetric.WithLabelValues(rule).Set(0)
+		e.report(Event{
+			Alert:    alert,
+			Resolved: true,
+			Time:     now,
+		})
+	}
+}
+
+func (e *Evaluator) report(event Event) {
+	for _, handler := range e.handlers {
+		handler(event)
+	}
+}
+
+func (e *Evaluator) firingAlerts() []Alert {
+	alerts := make([]Alert, 0, len(e.firing))
+	for _, alert := range e.firing {
+		alerts = append(alerts, alert)
+	}
+	slices.SortFunc(alerts, func(a, b Alert) int {
+		return strings.Compare(a.Rule, b.Rule)
+	})
+	return alerts
+}
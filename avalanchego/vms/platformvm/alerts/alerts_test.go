@@ -0,0 +1,145 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package alerts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestEvaluatorRules(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	config := Config{
+		ValidatorExpiryWindow:  7 * 24 * time.Hour,
+		MinConnectedPercentage: 80,
+		MaxMempoolAge:          10 * time.Minute,
+	}
+
+	tests := []struct {
+		name          string
+		config        Config
+		status        Status
+		expectedRules []string
+	}{
+		{
+			name:   "healthy validator",
+			config: config,
+			status: Status{
+				Now:                 now,
+				ValidatorEndTime:    now.Add(30 * 24 * time.Hour),
+				ConnectedPercentage: 90,
+			},
+			expectedRules: []string{},
+		},
+		{
+			name:   "expiring validator",
+			config: config,
+			status: Status{
+				Now:                 now,
+				ValidatorEndTime:    now.Add(24 * time.Hour),
+				ConnectedPercentage: 90,
+			},
+			expectedRules: []string{ValidatorExpiry},
+		},
+		{
+			name:   "poorly connected validator",
+			config: config,
+			status: Status{
+				Now:                 now,
+				ValidatorEndTime:    now.Add(30 * 24 * time.Hour),
+				ConnectedPercentage: 79.9,
+			},
+			expectedRules: []string{LowConnectivity},
+		},
+		{
+			name:   "poorly connected non-validator",
+			config: config,
+			status: Status{
+				Now:                 now,
+				ConnectedPercentage: 10,
+			},
+			expectedRules: []string{},
+		},
+		{
+			name: "disabled rules",
+			status: Status{
+				Now:                 now,
+				ValidatorEndTime:    now.Add(time.Hour),
+				ConnectedPercentage: 10,
+			},
+			expectedRules: []string{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			e, err := New(test.config, prometheus.NewRegistry())
+			require.NoError(err)
+
+			rules := []string{}
+			for _, alert := range e.Evaluate(test.status) {
+				rules = append(rules, alert.Rule)
+			}
+			require.Equal(test.expectedRules, rules)
+		})
+	}
+}
+
+func TestEvaluatorEvents(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		now   = time.Unix(1_700_000_000, 0)
+		txID  = ids.GenerateTestID()
+		txID2 = ids.GenerateTestID()
+	)
+	e, err := New(Config{MaxMempoolAge: 10 * time.Minute}, prometheus.NewRegistry())
+	require.NoError(err)
+
+	var events []Event
+	e.RegisterHandler(func(event Event) {
+		events = append(events, event)
+	})
+	firing := func() float64 {
+		return testutil.ToFloat64(e.firingMetric.WithLabelValues(StaleMempool))
+	}
+
+	// The age of the oldest tx is measured from the first evaluation
+	require.Empty(e.Evaluate(Status{Now: now, OldestMempoolTxID: txID}))
+	require.Empty(e.Evaluate(Status{Now: now.Add(10 * time.Minute), OldestMempoolTxID: txID}))
+	require.Empty(events)
+
+	alerts := e.Evaluate(Status{Now: now.Add(11 * time.Minute), OldestMempoolTxID: txID})
+	require.Len(alerts, 1)
+	require.Equal(StaleMempool, alerts[0].Rule)
+	require.Equal(now.Add(11*time.Minute), alerts[0].Since)
+	require.Len(events, 1)
+	require.False(events[0].Resolved)
+	require.Equal(alerts[0], events[0].Alert)
+	require.InDelta(1, firing(), 0)
+
+	// A firing alert is only reported once
+	alerts = e.Evaluate(Status{Now: now.Add(12 * time.Minute), OldestMempoolTxID: txID})
+	require.Len(alerts, 1)
+	require.Equal(now.Add(11*time.Minute), alerts[0].Since)
+	require.Len(events, 1)
+	require.Equal(alerts, e.Firing())
+
+	// A new oldest tx resolves the alert
+	require.Empty(e.Evaluate(Status{Now: now.Add(13 * time.Minute), OldestMempoolTxID: txID2}))
+	require.Len(events, 2)
+	require.True(events[1].Resolved)
+	require.Equal(StaleMempool, events[1].Rule)
+	require.Equal(now.Add(13*time.Minute), events[1].Time)
+	require.Zero(firing())
+	require.InDelta(1, testutil.ToFloat64(e.firedMetric.WithLabelValues(StaleMempool)), 0)
+	require.Empty(e.Firing())
+}
@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const (
+	webhookQueueSize = 64
+	webhookTimeout   = 10 * time.Second
+)
+
+// Dispatcher posts the events to a set of webhooks, as JSON. Events are
+// delivered in order, on a best effort basis: an event that can't be delivered
+// to a webhook is logged and dropped.
+type Dispatcher struct {
+	log    logging.Logger
+	client *http.Client
+	urls   []string
+	events chan Event
+}
+
+func NewDispatcher(log logging.Logger, client *http.Client, urls []string) *Dispatcher {
+	return &Dispatcher{
+		log:    log,
+		client: client,
+		urls:   urls,
+		events: make(chan Event, webhookQueueSize),
+	}
+}
+
+// Handle queues [event] to be posted to the webhooks. It never blocks: if the
+// queue is full, the event is dropped.
+func (d *Dispatcher) Handle(event Event) {
+	select {
+	case d.events <- event:
+	default:
+		d.log.Warn("dropping alert event",
+			zap.String("rule", event.Rule),
+			zap.Bool("resolved", event.Resolved),
+			zap.String("reason", "webhook queue is full"),
+		)
+	}
+}
+
+// Run posts the queued events until [ctx] is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	for {
+		select {
+		case event := <-d.events:
+			d.post(ctx, event)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (d *Dispatcher) post(ctx context.Context, event Event) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		d.log.Error("failed to marshal alert event",
+			zap.String("rule", event.Rule),
+			zap.Error(err),
+		)
+		return
+	}
+
+	for _, url := range d.urls {
+		if err := d.postTo(ctx, url, body); err != nil {
+			d.log.Warn("failed to post alert event",
+				zap.String("url", url),
+				zap.String("rule", event.Rule),
+				zap.Bool("resolved", event.Resolved),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+func (d *Dispatcher) postTo(ctx context.Context, url string, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestDispatcherPostsEvents(t *testing.T) {
+	require := require.New(t)
+
+	received := make(chan Event, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event Event
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- event
+	}))
+	defer server.Close()
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	d := NewDispatcher(
+		logging.NoLog{},
+		server.Client(),
+		[]string{failing.URL, server.URL},
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go d.Run(ctx)
+
+	now := time.Unix(1_700_000_000, 0).UTC()
+	expected := []Event{
+		{
+			Alert: Alert{
+				Rule:    StaleMempool,
+				Message: "stale",
+				Since:   now,
+			},
+			Time: now,
+		},
+		{
+			Alert: Alert{
+				Rule:    StaleMempool,
+				Message: "stale",
+				Since:   now,
+			},
+			Resolved: true,
+			Time:     now.Add(time.Minute),
+		},
+	}
+	for _, event := range expected {
+		d.Handle(event)
+	}
+	for _, event := range expected {
+		require.Equal(event, <-received)
+	}
+}
+
+func TestDispatcherDropsEventsWhenFull(t *testing.T) {
+	d := NewDispatcher(logging.NoLog{}, http.DefaultClient, nil)
+	for i := 0; i < webhookQueueSize+1; i++ {
+		d.Handle(Event{})
+	}
+	require.Len(t, d.events, webhookQueueSize)
+}
@@ -0,0 +1,407 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package attestation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+const (
+	// QuorumNumerator / QuorumDenominator is the share of the validator
+	// weight that must attest to a commitment for it to be certified.
+	QuorumNumerator   = 67
+	QuorumDenominator = 100
+
+	// Signatures are only collected for the most recent heights. Commitments
+	// that didn't reach a quorum before then are dropped.
+	maxPendingHeights = 4
+	// Max number of signatures of a height that are buffered before this node
+	// accepted the block at the height. Signatures received once the buffer is
+	// full are dropped, rather than replacing the buffered signatures.
+	maxEarlySignatures = 1024
+)
+
+var (
+	_ p2p.Handler = (*Attester)(nil)
+
+	errWrongMessage = errors.New("message doesn't attest to the commitment")
+)
+
+// Sender sends the signatures of this node to the other validators.
+type Sender interface {
+	AppGossipSpecific(ctx context.Context, nodeIDs set.Set[ids.NodeID], appGossipBytes []byte) error
+}
+
+type Config struct {
+	// Interval between the heights whose state is attested
+	Interval  uint64
+	NetworkID uint32
+	ChainID   ids.ID
+	NodeID    ids.NodeID
+}
+
+// Attester signs the commitments of the state at every [Config.Interval]
+// heights and gossips the signatures to the other primary network validators.
+// Once the signatures of a quorum of the validators at the height of a
+// commitment were collected, they are aggregated into a certificate that is
+// persisted in the store.
+type Attester struct {
+	p2p.NoOpHandler
+
+	config     Config
+	log        logging.Logger
+	signer     warp.Signer
+	validators validators.State
+	store      *Store
+
+	commitments chan Commitment
+
+	lock sync.Mutex
+	// Height of the last accepted block. Signatures are only collected up to
+	// the first attested height after it.
+	lastAccepted uint64
+	pending      map[uint64]*pendingCommitment
+}
+
+// New returns an attester. [validators] must be safe to call concurrently with
+// the acceptance of blocks.
+func New(
+	config Config,
+	log logging.Logger,
+	signer warp.Signer,
+	validators validators.State,
+	store *Store,
+) *Attester {
+	return &Attester{
+		config:      config,
+		log:         log,
+		signer:      signer,
+		validators:  validators,
+		store:       store,
+		commitments: make(chan Commitment, maxPendingHeights),
+		pending:     make(map[uint64]*pendingCommitment),
+	}
+}
+
+// ShouldAttest returns true if the state at [height] is attested.
+func (a *Attester) ShouldAttest(height uint64) bool {
+	return height%a.config.Interval == 0
+}
+
+// SetLastAccepted records that the block at [height] was accepted.
+func (a *Attester) SetLastAccepted(height uint64) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.lastAccepted = height
+}
+
+// Attest queues [commitment] to be signed. It doesn't block, so it can be
+// called while a block is being accepted.
+func (a *Attester) Attest(commitment Commitment) {
+	select {
+	case a.commitments <- commitment:
+	default:
+		a.log.Warn("dropping state commitment",
+			zap.String("reason", "too many commitments are waiting to be signed"),
+			zap.Uint64("height", commitment.Height),
+		)
+	}
+}
+
+// Run signs the queued commitments and sends the signatures with [sender]
+// until [ctx] is cancelled.
+func (a *Attester) Run(ctx context.Context, sender Sender) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case commitment := <-a.commitments:
+			if err := a.attest(ctx, sender, commitment); err != nil {
+				a.log.Warn("failed to attest to state",
+					zap.Uint64("height", commitment.Height),
+					zap.Error(err),
+				)
+			}
+		}
+	}
+}
+
+func (a *Attester) attest(ctx context.Context, sender Sender, commitment Commitment) error {
+	unsignedMsg, err := commitment.UnsignedMessage(a.config.NetworkID, a.config.ChainID)
+	if err != nil {
+		return err
+	}
+	vdrs, totalWeight, err := warp.GetCanonicalValidatorSet(ctx, a.validators, commitment.Height, constants.PrimaryNetworkID)
+	if err != nil {
+		return err
+	}
+
+	a.lock.Lock()
+	p := a.getPending(commitment.Height)
+	if p == nil {
+		a.lock.Unlock()
+		return nil
+	}
+	early := p.setCommitment(&commitment, unsignedMsg, vdrs, totalWeight)
+	for nodeID, msg := range early {
+		a.addSignature(p, nodeID, msg)
+	}
+	a.lock.Unlock()
+
+	if p.validatorIndex(a.config.NodeID) < 0 {
+		// Only validators attest to the state
+		return nil
+	}
+
+	sigBytes, err := a.signer.Sign(unsignedMsg)
+	if err != nil {
+		return err
+	}
+	msg := &signatureMessage{
+		Commitment: commitment,
+	}
+	copy(msg.Signature[:], sigBytes)
+
+	a.lock.Lock()
+	a.addSignature(p, a.config.NodeID, msg)
+	a.lock.Unlock()
+
+	msgBytes, err := Codec.Marshal(CodecVersion, msg)
+	if err != nil {
+		return err
+	}
+	nodeIDs := set.Set[ids.NodeID]{}
+	for _, vdr := range vdrs {
+		nodeIDs.Add(vdr.NodeIDs...)
+	}
+	nodeIDs.Remove(a.config.NodeID)
+	return sender.AppGossipSpecific(ctx, nodeIDs, msgBytes)
+}
+
+func (a *Attester) AppGossip(_ context.Context, nodeID ids.NodeID, gossipBytes []byte) {
+	msg := &signatureMessage{}
+	if _, err := Codec.Unmarshal(gossipBytes, msg); err != nil {
+		a.log.Debug("dropping invalid state attestation",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+		return
+	}
+	if !a.ShouldAttest(msg.Commitment.Height) {
+		a.log.Debug("dropping state attestation",
+			zap.String("reason", "height isn't attested"),
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint64("height", msg.Commitment.Height),
+		)
+		return
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if p := a.getPending(msg.Commitment.Height); p != nil {
+		a.addSignature(p, nodeID, msg)
+	}
+}
+
+// getPending returns the signatures collected for [height]. If too many
+// heights are pending, the lowest height is dropped. Nil is returned if
+// [height] would be dropped, or if [height] is after the first attested height
+// following the last accepted block. As pending heights are only created up to
+// the next attested height, signatures of far future heights can't cause the
+// heights that are being attested to be dropped.
+//
+// Invariant: [a.lock] is held.
+func (a *Attester) getPending(height uint64) *pendingCommitment {
+	if p, ok := a.pending[height]; ok {
+		return p
+	}
+	if height > a.lastAccepted && height-a.lastAccepted > a.config.Interval {
+		return nil
+	}
+
+	if len(a.pending) >= maxPendingHeights {
+		lowestHeight := height
+		for pendingHeight := range a.pending {
+			lowestHeight = min(lowestHeight, pendingHeight)
+		}
+		if lowestHeight == height {
+			return nil
+		}
+		delete(a.pending, lowestHeight)
+	}
+
+	p := &pendingCommitment{
+		early: make(map[ids.NodeID]*signatureMessage),
+	}
+	a.pending[height] = p
+	return p
+}
+
+// addSignature adds the signature of [nodeID] to [p]. If the signatures reach
+// a quorum, the certificate is built and stored.
+//
+// Invariant: [a.lock] is held.
+func (a *Attester) addSignature(p *pendingCommitment, nodeID ids.NodeID, msg *signatureMessage) {
+	if p.certified {
+		return
+	}
+	if p.commitment == nil {
+		if len(p.early) < maxEarlySignatures {
+			p.early[nodeID] = msg
+		}
+		return
+	}
+	if msg.Commitment != *p.commitment {
+		a.log.Warn("validator attested to a different state",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint64("height", msg.Commitment.Height),
+			zap.Stringer("blockID", msg.Commitment.BlockID),
+			zap.Stringer("stateHash", msg.Commitment.StateHash),
+			zap.Stringer("expectedStateHash", p.commitment.StateHash),
+		)
+		return
+	}
+	if err := p.add(nodeID, msg.Signature[:]); err != nil {
+		a.log.Debug("dropping state attestation",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint64("height", msg.Commitment.Height),
+			zap.Error(err),
+		)
+		return
+	}
+
+	cert, err := p.certificate()
+	if err != nil || cert == nil {
+		return
+	}
+	if err := a.store.Put(cert); err != nil {
+		a.log.Error("failed to store state certificate",
+			zap.Uint64("height", cert.Commitment.Height),
+			zap.Error(err),
+		)
+		return
+	}
+	p.certified = true
+	a.log.Info("certified state",
+		zap.Uint64("height", cert.Commitment.Height),
+		zap.Stringer("blockID", cert.Commitment.BlockID),
+		zap.Stringer("stateHash", cert.Commitment.StateHash),
+	)
+}
+
+// pendingCommitment holds the signatures collected for a height.
+type pendingCommitment struct {
+	// Nil until this node accepted the block at the height
+	commitment  *Commitment
+	unsignedMsg *warp.UnsignedMessage
+	validators  []*warp.Validator
+	totalWeight uint64
+
+	// Signatures received before the commitment was known
+	early map[ids.NodeID]*signatureMessage
+
+	// Indices in [validators] of the validators that signed the commitment
+	signers      set.Bits
+	signatures   []*bls.Signature
+	signedWeight uint64
+	certified    bool
+}
+
+// setCommitment sets the commitment that signatures must attest to and
+// returns the signatures that were received before it was known.
+func (p *pendingCommitment) setCommitment(
+	commitment *Commitment,
+	unsignedMsg *warp.UnsignedMessage,
+	vdrs []*warp.Validator,
+	totalWeight uint64,
+) map[ids.NodeID]*signatureMessage {
+	p.commitment = commitment
+	p.unsignedMsg = unsignedMsg
+	p.validators = vdrs
+	p.totalWeight = totalWeight
+	p.signers = set.NewBits()
+
+	early := p.early
+	p.early = nil
+	return early
+}
+
+// validatorIndex returns the index of the validator with [nodeID], or -1 if
+// [nodeID] isn't a validator with a BLS key.
+func (p *pendingCommitment) validatorIndex(nodeID ids.NodeID) int {
+	for i, vdr := range p.validators {
+		for _, vdrNodeID := range vdr.NodeIDs {
+			if vdrNodeID == nodeID {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func (p *pendingCommitment) add(nodeID ids.NodeID, sigBytes []byte) error {
+	index := p.validatorIndex(nodeID)
+	if index < 0 {
+		return fmt.Errorf("%s isn't a validator", nodeID)
+	}
+	if p.signers.Contains(index) {
+		return nil
+	}
+
+	sig, err := bls.SignatureFromBytes(sigBytes)
+	if err != nil {
+		return err
+	}
+	vdr := p.validators[index]
+	if !bls.Verify(vdr.PublicKey, sig, p.unsignedMsg.Bytes()) {
+		return warp.ErrInvalidSignature
+	}
+
+	p.signers.Add(index)
+	p.signatures = append(p.signatures, sig)
+	p.signedWeight += vdr.Weight // Can't overflow, as the total weight didn't
+	return nil
+}
+
+// certificate returns the certificate of the commitment, or nil if the
+// signatures don't reach a quorum yet.
+func (p *pendingCommitment) certificate() (*Certificate, error) {
+	if warp.VerifyWeight(p.signedWeight, p.totalWeight, QuorumNumerator, QuorumDenominator) != nil {
+		return nil, nil
+	}
+
+	aggSig, err := bls.AggregateSignatures(p.signatures)
+	if err != nil {
+		return nil, err
+	}
+	sig := &warp.BitSetSignature{
+		Signers: p.signers.Bytes(),
+	}
+	copy(sig.Signature[:], bls.SignatureToBytes(aggSig))
+
+	msg, err := warp.NewMessage(p.unsignedMsg, sig)
+	if err != nil {
+		return nil, err
+	}
+	return &Certificate{
+		Commitment: *p.commitment,
+		Message:    msg.Bytes(),
+	}, nil
+}
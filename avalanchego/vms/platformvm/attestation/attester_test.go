@@ -0,0 +1,155 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package attestation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+const networkID = constants.UnitTestID
+
+// testSender delivers the gossip of [nodeID] to the attesters of the other
+// nodes.
+type testSender struct {
+	nodeID    ids.NodeID
+	attesters map[ids.NodeID]*Attester
+}
+
+func (s *testSender) AppGossipSpecific(ctx context.Context, nodeIDs set.Set[ids.NodeID], msg []byte) error {
+	for nodeID := range nodeIDs {
+		if attester, ok := s.attesters[nodeID]; ok {
+			attester.AppGossip(ctx, s.nodeID, msg)
+		}
+	}
+	return nil
+}
+
+func TestAttester(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		chainID   = constants.PlatformChainID
+		nodeIDs   = []ids.NodeID{ids.GenerateTestNodeID(), ids.GenerateTestNodeID(), ids.GenerateTestNodeID()}
+		weights   = []uint64{1, 1, 2}
+		vdrs      = make(map[ids.NodeID]*validators.GetValidatorOutput)
+		attesters = make(map[ids.NodeID]*Attester)
+		senders   = make(map[ids.NodeID]*testSender)
+		stores    = make(map[ids.NodeID]*Store)
+	)
+	vdrState := &validators.TestState{
+		GetSubnetIDF: func(context.Context, ids.ID) (ids.ID, error) {
+			return constants.PrimaryNetworkID, nil
+		},
+		GetValidatorSetF: func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			return vdrs, nil
+		},
+	}
+	for i, nodeID := range nodeIDs {
+		sk, err := bls.NewSecretKey()
+		require.NoError(err)
+		vdrs[nodeID] = &validators.GetValidatorOutput{
+			NodeID:    nodeID,
+			PublicKey: bls.PublicFromSecretKey(sk),
+			Weight:    weights[i],
+		}
+
+		stores[nodeID] = NewStore(memdb.New())
+		attesters[nodeID] = New(
+			Config{
+				Interval:  10,
+				NetworkID: networkID,
+				ChainID:   chainID,
+				NodeID:    nodeID,
+			},
+			logging.NoLog{},
+			warp.NewSigner(sk, networkID, chainID),
+			vdrState,
+			stores[nodeID],
+		)
+		senders[nodeID] = &testSender{
+			nodeID:    nodeID,
+			attesters: attesters,
+		}
+	}
+
+	commitment := Commitment{
+		Height:    10,
+		BlockID:   ids.GenerateTestID(),
+		StateHash: ids.GenerateTestID(),
+	}
+	require.False(attesters[nodeIDs[0]].ShouldAttest(commitment.Height + 1))
+	require.True(attesters[nodeIDs[0]].ShouldAttest(commitment.Height))
+
+	// The first validator doesn't reach a quorum on its own. Its signature is
+	// buffered by the other validators until they accept the block.
+	ctx := context.Background()
+	require.NoError(attesters[nodeIDs[0]].attest(ctx, senders[nodeIDs[0]], commitment))
+	for _, store := range stores {
+		_, err := store.GetLatest()
+		require.ErrorIs(err, database.ErrNotFound)
+	}
+
+	// A validator that attested to a different state isn't counted.
+	differentCommitment := commitment
+	differentCommitment.StateHash = ids.GenerateTestID()
+	require.NoError(attesters[nodeIDs[1]].attest(ctx, senders[nodeIDs[1]], differentCommitment))
+	_, err := stores[nodeIDs[0]].GetLatest()
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// The first and last validators hold 75% of the weight.
+	require.NoError(attesters[nodeIDs[2]].attest(ctx, senders[nodeIDs[2]], commitment))
+	for _, nodeID := range []ids.NodeID{nodeIDs[0], nodeIDs[2]} {
+		cert, err := stores[nodeID].GetLatest()
+		require.NoError(err)
+		require.Equal(commitment, cert.Commitment)
+		require.NoError(cert.Verify(ctx, networkID, chainID, vdrState, QuorumNumerator, QuorumDenominator))
+
+		// The certificate doesn't attest to a different commitment.
+		cert.Commitment = differentCommitment
+		err = cert.Verify(ctx, networkID, chainID, vdrState, QuorumNumerator, QuorumDenominator)
+		require.ErrorIs(err, errWrongMessage)
+	}
+
+	// The signature of the last validator is rejected by the second validator,
+	// which attested to a different state.
+	_, err = stores[nodeIDs[1]].GetLatest()
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestAttesterPendingHeights(t *testing.T) {
+	require := require.New(t)
+
+	a := New(Config{Interval: 1}, logging.NoLog{}, nil, nil, nil)
+	a.SetLastAccepted(maxPendingHeights)
+	for height := uint64(1); height <= maxPendingHeights; height++ {
+		require.NotNil(a.getPending(height))
+	}
+
+	// Heights after the next attested height are ignored, so they don't cause
+	// the pending heights to be dropped.
+	require.Nil(a.getPending(maxPendingHeights + 2))
+	require.Len(a.pending, maxPendingHeights)
+	require.Contains(a.pending, uint64(1))
+
+	// The lowest height is dropped in favor of the next attested height.
+	require.NotNil(a.getPending(maxPendingHeights + 1))
+	require.Len(a.pending, maxPendingHeights)
+	require.NotContains(a.pending, uint64(1))
+
+	// Heights below every pending height are ignored.
+	require.Nil(a.getPending(1))
+}
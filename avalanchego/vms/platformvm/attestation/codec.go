@@ -0,0 +1,24 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package attestation
+
+import (
+	"math"
+	"time"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/codec/linearcodec"
+)
+
+const CodecVersion = 0
+
+var Codec codec.Manager
+
+func init() {
+	Codec = codec.NewManager(math.MaxInt32)
+	lc := linearcodec.NewDefault(time.Time{})
+	if err := Codec.RegisterCodec(CodecVersion, lc); err != nil {
+		panic(err)
+	}
+}
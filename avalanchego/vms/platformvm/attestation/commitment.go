@@ -0,0 +1,94 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package attestation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+)
+
+// Commitment is the state of the chain after the block at [Height] was
+// accepted.
+type Commitment struct {
+	Height    uint64 `serialize:"true" json:"height"`
+	BlockID   ids.ID `serialize:"true" json:"blockID"`
+	StateHash ids.ID `serialize:"true" json:"stateHash"`
+}
+
+// ID returns the hash that validators sign to attest to the commitment.
+func (c *Commitment) ID() ids.ID {
+	p := wrappers.Packer{
+		Bytes: make([]byte, wrappers.LongLen+2*ids.IDLen),
+	}
+	p.PackLong(c.Height)
+	p.PackFixedBytes(c.BlockID[:])
+	p.PackFixedBytes(c.StateHash[:])
+	return hashing.ComputeHash256Array(p.Bytes)
+}
+
+// UnsignedMessage returns the warp message, sent by [chainID], that validators
+// sign to attest to the commitment. Its payload is the hash of the commitment.
+func (c *Commitment) UnsignedMessage(networkID uint32, chainID ids.ID) (*warp.UnsignedMessage, error) {
+	hash, err := payload.NewHash(c.ID())
+	if err != nil {
+		return nil, err
+	}
+	return warp.NewUnsignedMessage(networkID, chainID, hash.Bytes())
+}
+
+// Certificate is a commitment that was attested by a quorum of the primary
+// network validators at the height of the commitment.
+type Certificate struct {
+	Commitment Commitment `serialize:"true" json:"commitment"`
+	// Message is the warp message returned by [Commitment.UnsignedMessage],
+	// signed by the attesting validators.
+	Message []byte `serialize:"true" json:"message"`
+}
+
+// Verify that [c.Message] attests to [c.Commitment] and was signed by at least
+// [quorumNum]/[quorumDen] of the weight of the primary network validators at
+// the height of the commitment.
+func (c *Certificate) Verify(
+	ctx context.Context,
+	networkID uint32,
+	chainID ids.ID,
+	pChainState validators.State,
+	quorumNum uint64,
+	quorumDen uint64,
+) error {
+	msg, err := warp.ParseMessage(c.Message)
+	if err != nil {
+		return err
+	}
+	expectedMsg, err := c.Commitment.UnsignedMessage(networkID, chainID)
+	if err != nil {
+		return err
+	}
+	if msg.UnsignedMessage.ID() != expectedMsg.ID() {
+		return fmt.Errorf("%w: message %s, commitment %s", errWrongMessage, msg.UnsignedMessage.ID(), expectedMsg.ID())
+	}
+	return msg.Signature.Verify(
+		ctx,
+		&msg.UnsignedMessage,
+		networkID,
+		pChainState,
+		c.Commitment.Height,
+		quorumNum,
+		quorumDen,
+	)
+}
+
+// signatureMessage is gossiped by a validator to attest to [Commitment].
+type signatureMessage struct {
+	Commitment Commitment             `serialize:"true"`
+	Signature  [bls.SignatureLen]byte `serialize:"true"`
+}
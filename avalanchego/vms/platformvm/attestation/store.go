@@ -0,0 +1,77 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package attestation
+
+import (
+	"sync"
+
+	"github.com/ava-labs/avalanchego/database"
+)
+
+// latestKey is shorter than the height keys, so it can't collide with them.
+var latestKey = []byte("latest")
+
+// Store persists the certificates built by the attester, keyed by the height
+// of their commitment.
+type Store struct {
+	lock sync.Mutex
+	db   database.Database
+}
+
+func NewStore(db database.Database) *Store {
+	return &Store{db: db}
+}
+
+// Put stores [cert]. If it is the most recent certificate, it becomes the
+// latest certificate.
+func (s *Store) Put(cert *Certificate) error {
+	certBytes, err := Codec.Marshal(CodecVersion, cert)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	height := cert.Commitment.Height
+	latest, err := database.GetUInt64(s.db, latestKey)
+	switch {
+	case err == database.ErrNotFound:
+	case err != nil:
+		return err
+	case latest > height:
+		return s.db.Put(database.PackUInt64(height), certBytes)
+	}
+
+	batch := s.db.NewBatch()
+	if err := batch.Put(database.PackUInt64(height), certBytes); err != nil {
+		return err
+	}
+	if err := database.PutUInt64(batch, latestKey, height); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// Get returns the certificate of the commitment at [height].
+func (s *Store) Get(height uint64) (*Certificate, error) {
+	certBytes, err := s.db.Get(database.PackUInt64(height))
+	if err != nil {
+		return nil, err
+	}
+	cert := &Certificate{}
+	_, err = Codec.Unmarshal(certBytes, cert)
+	return cert, err
+}
+
+// GetLatest returns the certificate with the highest commitment height.
+func (s *Store) GetLatest() (*Certificate, error) {
+	s.lock.Lock()
+	height, err := database.GetUInt64(s.db, latestKey)
+	s.lock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(height)
+}
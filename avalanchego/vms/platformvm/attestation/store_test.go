@@ -0,0 +1,50 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package attestation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestStore(t *testing.T) {
+	require := require.New(t)
+
+	s := NewStore(memdb.New())
+	_, err := s.GetLatest()
+	require.ErrorIs(err, database.ErrNotFound)
+
+	newCertificate := func(height uint64) *Certificate {
+		return &Certificate{
+			Commitment: Commitment{
+				Height:    height,
+				BlockID:   ids.GenerateTestID(),
+				StateHash: ids.GenerateTestID(),
+			},
+			Message: []byte{byte(height)},
+		}
+	}
+	cert20 := newCertificate(20)
+	require.NoError(s.Put(cert20))
+
+	// Storing an older certificate doesn't change the latest certificate.
+	cert10 := newCertificate(10)
+	require.NoError(s.Put(cert10))
+
+	latest, err := s.GetLatest()
+	require.NoError(err)
+	require.Equal(cert20, latest)
+
+	cert, err := s.Get(10)
+	require.NoError(err)
+	require.Equal(cert10, cert)
+
+	_, err = s.Get(30)
+	require.ErrorIs(err, database.ErrNotFound)
+}
@@ -0,0 +1,299 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package autoimport
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/builder"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// Builder builds the ImportTxs that import the UTXOs of the managed keys.
+type Builder interface {
+	NewImportTx(
+		chainID ids.ID,
+		to ids.ShortID,
+		kc keychain.Keychain,
+		changeAddr ids.ShortID,
+		memo []byte,
+	) (*txs.Tx, error)
+}
+
+// Issuer issues the built ImportTxs into the mempool.
+type Issuer func(context.Context, *txs.Tx) error
+
+type Config struct {
+	// Interval between the checks for importable UTXOs
+	Interval time.Duration
+	// Maximum number of ImportTxs issued every interval
+	MaxTxsPerInterval int
+	// Chains that UTXOs are imported from
+	SourceChainIDs []ids.ID
+	// Number of entries kept in the audit log
+	LogSize int
+}
+
+// Entry is an entry of the audit log, recorded for every ImportTx that was
+// issued or that failed to be built or issued.
+type Entry struct {
+	// Index of the entry. Entries are indexed in the order they were recorded,
+	// starting from 0.
+	Index uint64 `json:"index"`
+
+	Timestamp     int64       `json:"timestamp"`
+	SourceChainID ids.ID      `json:"sourceChainID"`
+	To            ids.ShortID `json:"to"`
+	// TxID is empty if the ImportTx couldn't be built
+	TxID     ids.ID `json:"txID"`
+	NumUTXOs int    `json:"numUTXOs"`
+	// Error is empty if the ImportTx was issued
+	Error string `json:"error,omitempty"`
+}
+
+// Importer imports the atomic UTXOs owned by a set of keys that are exported to
+// the P-chain, so that they don't have to be imported manually.
+//
+// The keys are only kept in memory. Importing is disabled until keys are
+// provided with [Importer.Enable] and on restart.
+type Importer struct {
+	config Config
+	log    logging.Logger
+	clock  *mockable.Clock
+
+	// ctxLock is held while the ImportTxs are built
+	ctxLock sync.Locker
+	builder Builder
+	issuer  Issuer
+
+	lock sync.Mutex
+	kc   *secp256k1fx.Keychain
+	to   ids.ShortID
+	// pending are the source chains that may have UTXOs to import
+	pending set.Set[ids.ID]
+	// lastIssued is the last ImportTx issued for every source chain. It is
+	// built again until the imported UTXOs are removed from shared memory.
+	lastIssued map[ids.ID]ids.ID
+
+	// entries is a ring buffer of the most recent entries. The entry with
+	// index i is at position i % len(entries).
+	entries   []Entry
+	nextIndex uint64
+}
+
+// New returns a disabled importer. [ctxLock] must be held to call [builder].
+func New(
+	config Config,
+	log logging.Logger,
+	clock *mockable.Clock,
+	ctxLock sync.Locker,
+	builder Builder,
+	issuer Issuer,
+) *Importer {
+	return &Importer{
+		config:     config,
+		log:        log,
+		clock:      clock,
+		ctxLock:    ctxLock,
+		builder:    builder,
+		issuer:     issuer,
+		lastIssued: make(map[ids.ID]ids.ID),
+		entries:    make([]Entry, max(config.LogSize, 1)),
+	}
+}
+
+// Enable importing the UTXOs owned by the keys of [kc] to [to]. Every source
+// chain is checked for UTXOs on the next interval.
+func (i *Importer) Enable(kc *secp256k1fx.Keychain, to ids.ShortID) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.kc = kc
+	i.to = to
+	i.pending = set.Of(i.config.SourceChainIDs...)
+	clear(i.lastIssued)
+}
+
+// Disable importing and forget the keys.
+func (i *Importer) Disable() {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.kc = nil
+	i.to = ids.ShortEmpty
+	i.pending = nil
+}
+
+// Enabled returns the addresses of the managed keys and the address that their
+// UTXOs are imported to. False is returned if importing is disabled.
+func (i *Importer) Enabled() ([]ids.ShortID, ids.ShortID, bool) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if i.kc == nil {
+		return nil, ids.ShortEmpty, false
+	}
+	return i.kc.Addrs.List(), i.to, true
+}
+
+// Notify the importer that UTXOs were exported to the P-chain by
+// [sourceChainID]. It must not block, as it is called by shared memory.
+func (i *Importer) Notify(sourceChainID ids.ID) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if i.kc == nil {
+		return
+	}
+	for _, chainID := range i.config.SourceChainIDs {
+		if chainID == sourceChainID {
+			i.pending.Add(sourceChainID)
+			return
+		}
+	}
+}
+
+// Run imports the pending UTXOs every interval until [ctx] is cancelled.
+func (i *Importer) Run(ctx context.Context) {
+	ticker := time.NewTicker(i.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			i.importPending(ctx)
+		}
+	}
+}
+
+// importPending issues up to [Config.MaxTxsPerInterval] ImportTxs for the
+// pending source chains. Source chains that are left with nothing to import
+// are no longer pending.
+func (i *Importer) importPending(ctx context.Context) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if i.kc == nil {
+		return
+	}
+
+	numIssued := 0
+	for _, chainID := range i.config.SourceChainIDs {
+		if numIssued >= i.config.MaxTxsPerInterval {
+			return
+		}
+		if !i.pending.Contains(chainID) {
+			continue
+		}
+
+		issued, err := i.importFrom(ctx, chainID)
+		switch {
+		case errors.Is(err, builder.ErrNoFunds):
+			// All the UTXOs of the chain were imported
+			i.pending.Remove(chainID)
+			delete(i.lastIssued, chainID)
+		case err != nil:
+			i.log.Warn("failed to auto-import UTXOs",
+				zap.Stringer("sourceChainID", chainID),
+				zap.Error(err),
+			)
+		case issued:
+			numIssued++
+		}
+	}
+}
+
+// importFrom issues an ImportTx of the UTXOs exported by [chainID]. False is
+// returned if the ImportTx was already issued.
+//
+// Invariant: [i.lock] is held.
+func (i *Importer) importFrom(ctx context.Context, chainID ids.ID) (bool, error) {
+	i.ctxLock.Lock()
+	tx, err := i.builder.NewImportTx(chainID, i.to, i.kc, i.to, nil)
+	i.ctxLock.Unlock()
+	if errors.Is(err, builder.ErrNoFunds) {
+		return false, err
+	}
+
+	entry := Entry{
+		Timestamp:     i.clock.Time().Unix(),
+		SourceChainID: chainID,
+		To:            i.to,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+		i.record(entry)
+		return false, err
+	}
+
+	txID := tx.ID()
+	if i.lastIssued[chainID] == txID {
+		// The imported UTXOs are still in shared memory because the ImportTx
+		// wasn't accepted yet.
+		return false, nil
+	}
+
+	entry.TxID = txID
+	if utx, ok := tx.Unsigned.(*txs.ImportTx); ok {
+		entry.NumUTXOs = len(utx.ImportedInputs)
+	}
+	if err := i.issuer(ctx, tx); err != nil {
+		entry.Error = err.Error()
+		i.record(entry)
+		return false, err
+	}
+
+	i.lastIssued[chainID] = txID
+	i.record(entry)
+	i.log.Info("auto-imported UTXOs",
+		zap.Stringer("sourceChainID", chainID),
+		zap.Stringer("txID", txID),
+		zap.Int("numUTXOs", entry.NumUTXOs),
+	)
+	return true, nil
+}
+
+// Invariant: [i.lock] is held.
+func (i *Importer) record(entry Entry) {
+	entry.Index = i.nextIndex
+	i.entries[i.nextIndex%uint64(len(i.entries))] = entry
+	i.nextIndex++
+}
+
+// Entries returns up to [limit] of the remembered entries of the audit log,
+// starting at [startIndex], along with the index of the next entry to request.
+//
+// If entries starting at [startIndex] have already been forgotten, the
+// returned entries start with the oldest remembered entry.
+func (i *Importer) Entries(startIndex uint64, limit int) ([]Entry, uint64) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	numRemembered := min(i.nextIndex, uint64(len(i.entries)))
+	startIndex = max(startIndex, i.nextIndex-numRemembered)
+	if startIndex >= i.nextIndex {
+		return nil, i.nextIndex
+	}
+
+	numEntries := min(i.nextIndex-startIndex, uint64(max(limit, 0)))
+	entries := make([]Entry, numEntries)
+	for j := range entries {
+		index := startIndex + uint64(j)
+		entries[j] = i.entries[index%uint64(len(i.entries))]
+	}
+	return entries, startIndex + numEntries
+}
@@ -0,0 +1,194 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package autoimport
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/builder"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var errTest = errors.New("non-nil error")
+
+// testBuilder builds an ImportTx of the [utxos] of a source chain, which are
+// only removed once [accept] is called.
+type testBuilder struct {
+	utxos map[ids.ID][]ids.ID
+	err   error
+}
+
+func (b *testBuilder) NewImportTx(
+	chainID ids.ID,
+	_ ids.ShortID,
+	_ keychain.Keychain,
+	_ ids.ShortID,
+	_ []byte,
+) (*txs.Tx, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	utxoIDs := b.utxos[chainID]
+	if len(utxoIDs) == 0 {
+		return nil, builder.ErrNoFunds
+	}
+
+	utx := &txs.ImportTx{
+		SourceChain: chainID,
+	}
+	for _, utxoID := range utxoIDs {
+		utx.ImportedInputs = append(utx.ImportedInputs, &avax.TransferableInput{
+			UTXOID: avax.UTXOID{TxID: utxoID},
+			In:     &secp256k1fx.TransferInput{},
+		})
+	}
+	return txs.NewSigned(utx, txs.Codec, nil)
+}
+
+func (b *testBuilder) accept(chainID ids.ID) {
+	delete(b.utxos, chainID)
+}
+
+func newTestImporter(t *testing.T, b *testBuilder, issued *[]*txs.Tx) *Importer {
+	key, err := secp256k1.NewPrivateKey()
+	require.NoError(t, err)
+
+	i := New(
+		Config{
+			Interval:          time.Second,
+			MaxTxsPerInterval: 1,
+			SourceChainIDs:    []ids.ID{{1}, {2}},
+			LogSize:           2,
+		},
+		logging.NoLog{},
+		&mockable.Clock{},
+		&sync.Mutex{},
+		b,
+		func(_ context.Context, tx *txs.Tx) error {
+			*issued = append(*issued, tx)
+			return nil
+		},
+	)
+	i.Enable(secp256k1fx.NewKeychain(key), ids.ShortID{3})
+	return i
+}
+
+func TestImporterImportPending(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		xChainID = ids.ID{1}
+		cChainID = ids.ID{2}
+		b        = &testBuilder{
+			utxos: map[ids.ID][]ids.ID{
+				xChainID: {{4}},
+				cChainID: {{5}, {6}},
+			},
+		}
+		issued []*txs.Tx
+		i      = newTestImporter(t, b, &issued)
+	)
+
+	// Only a single tx is issued per interval.
+	i.importPending(context.Background())
+	require.Len(issued, 1)
+	require.Equal(xChainID, issued[0].Unsigned.(*txs.ImportTx).SourceChain)
+
+	// The tx of the X-chain isn't issued again until it is accepted.
+	i.importPending(context.Background())
+	require.Len(issued, 2)
+	require.Equal(cChainID, issued[1].Unsigned.(*txs.ImportTx).SourceChain)
+
+	i.importPending(context.Background())
+	require.Len(issued, 2)
+
+	// Chains without UTXOs are no longer pending.
+	b.accept(xChainID)
+	b.accept(cChainID)
+	i.importPending(context.Background())
+	require.Empty(i.pending)
+
+	// Notifications of other chains are ignored.
+	i.Notify(ids.ID{7})
+	require.Empty(i.pending)
+
+	b.utxos[xChainID] = []ids.ID{{8}}
+	i.Notify(xChainID)
+	i.importPending(context.Background())
+	require.Len(issued, 3)
+
+	// Only the most recent entries are remembered.
+	entries, nextIndex := i.Entries(0, 10)
+	require.Equal(uint64(3), nextIndex)
+	require.Len(entries, 2)
+	require.Equal(uint64(1), entries[0].Index)
+	require.Equal(issued[1].ID(), entries[0].TxID)
+	require.Equal(2, entries[0].NumUTXOs)
+	require.Equal(uint64(2), entries[1].Index)
+	require.Equal(issued[2].ID(), entries[1].TxID)
+	require.Equal(ids.ShortID{3}, entries[1].To)
+}
+
+func TestImporterRecordsErrors(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		b      = &testBuilder{err: errTest}
+		issued []*txs.Tx
+		i      = newTestImporter(t, b, &issued)
+	)
+
+	i.importPending(context.Background())
+	require.Empty(issued)
+
+	// Failing chains stay pending, so that they are retried.
+	require.Equal(2, i.pending.Len())
+	entries, nextIndex := i.Entries(0, 10)
+	require.Equal(uint64(2), nextIndex)
+	require.Len(entries, 2)
+	for _, entry := range entries {
+		require.Equal(ids.Empty, entry.TxID)
+		require.Equal(errTest.Error(), entry.Error)
+	}
+}
+
+func TestImporterDisable(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		b = &testBuilder{
+			utxos: map[ids.ID][]ids.ID{
+				{1}: {{4}},
+			},
+		}
+		issued []*txs.Tx
+		i      = newTestImporter(t, b, &issued)
+	)
+
+	addrs, to, enabled := i.Enabled()
+	require.True(enabled)
+	require.Len(addrs, 1)
+	require.Equal(ids.ShortID{3}, to)
+
+	i.Disable()
+	_, _, enabled = i.Enabled()
+	require.False(enabled)
+
+	i.Notify(ids.ID{1})
+	i.importPending(context.Background())
+	require.Empty(issued)
+}
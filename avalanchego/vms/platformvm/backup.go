@@ -0,0 +1,135 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm/backup"
+)
+
+// writeBackup streams a backup of the database, as of the last accepted block,
+// to [w].
+func (vm *VM) writeBackup(w io.Writer) (backup.Metadata, error) {
+	// The iterator is created while holding the context lock so that it
+	// observes a database that only contains fully accepted blocks. The
+	// iteration itself doesn't require the lock.
+	vm.ctx.Lock.Lock()
+	lastAcceptedID := vm.state.GetLastAccepted()
+	lastAccepted, err := vm.manager.GetStatelessBlock(lastAcceptedID)
+	if err != nil {
+		vm.ctx.Lock.Unlock()
+		return backup.Metadata{}, err
+	}
+	metadata := backup.Metadata{
+		LastAccepted: lastAcceptedID,
+		Height:       lastAccepted.Height(),
+		CreatedAt:    vm.clock.Time(),
+	}
+	it := vm.db.NewIterator()
+	vm.ctx.Lock.Unlock()
+	defer it.Release()
+
+	numKeys, err := backup.Write(w, it, metadata)
+	if err != nil {
+		return backup.Metadata{}, err
+	}
+
+	vm.ctx.Log.Info("wrote database backup",
+		zap.Stringer("lastAcceptedID", metadata.LastAccepted),
+		zap.Uint64("height", metadata.Height),
+		zap.Uint64("numKeys", numKeys),
+	)
+	return metadata, nil
+}
+
+// writeBackupToFile atomically writes a backup to [path].
+func (vm *VM) writeBackupToFile(path string) (backup.Metadata, error) {
+	tmpPath := path + ".tmp"
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return backup.Metadata{}, err
+	}
+
+	metadata, err := vm.writeBackup(f)
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmpPath)
+		return backup.Metadata{}, err
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return backup.Metadata{}, err
+	}
+	return metadata, os.Rename(tmpPath, path)
+}
+
+// writeBackupToURL streams a backup to [url] in the body of a POST request.
+func (vm *VM) writeBackupToURL(ctx context.Context, url string) (backup.Metadata, error) {
+	pr, pw := io.Pipe()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, pr)
+	if err != nil {
+		return backup.Metadata{}, err
+	}
+	req.Header.Set("Content-Type", "application/zstd")
+
+	var (
+		metadata backup.Metadata
+		writeErr error
+		done     = make(chan struct{})
+	)
+	go func() {
+		defer close(done)
+
+		metadata, writeErr = vm.writeBackup(pw)
+		_ = pw.CloseWithError(writeErr)
+	}()
+
+	resp, err := http.DefaultClient.Do(req)
+	// Unblock the writer in case the request failed before consuming the
+	// whole body.
+	_ = pr.CloseWithError(io.ErrClosedPipe)
+	<-done
+	if err != nil {
+		return backup.Metadata{}, err
+	}
+	_ = resp.Body.Close()
+
+	if writeErr != nil {
+		return backup.Metadata{}, writeErr
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return backup.Metadata{}, fmt.Errorf("backup sink responded with %s", resp.Status)
+	}
+	return metadata, nil
+}
+
+// restoreBackup populates the, empty, database with the backup at [path].
+func (vm *VM) restoreBackup(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	metadata, numKeys, err := backup.Restore(f, vm.db)
+	if err != nil {
+		return err
+	}
+
+	vm.ctx.Log.Info("restored database backup",
+		zap.String("path", path),
+		zap.Stringer("lastAcceptedID", metadata.LastAccepted),
+		zap.Uint64("height", metadata.Height),
+		zap.Time("createdAt", metadata.CreatedAt),
+		zap.Uint64("numKeys", numKeys),
+	)
+	return nil
+}
@@ -0,0 +1,258 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package backup serializes the platformvm database into a zstd compressed
+// tar archive and restores databases from such archives.
+//
+// The archive contains a [MetadataFile] entry, describing the snapshot,
+// followed by [dataDir] entries holding the key-value pairs of the database.
+// Every key-value pair is encoded as a uvarint key length, the key, a uvarint
+// value length and the value.
+package backup
+
+import (
+	"archive/tar"
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"github.com/DataDog/zstd"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+const (
+	// Version of the archive format.
+	Version = 0
+
+	MetadataFile = "metadata.json"
+
+	dataDir       = "data"
+	dataChunkSize = 16 * 1024 * 1024
+	// restoreBatchSize is the number of bytes written to the database per
+	// batch while restoring.
+	restoreBatchSize = 4 * 1024 * 1024
+)
+
+var (
+	ErrDatabaseNotEmpty = errors.New("database isn't empty")
+
+	errMissingMetadata    = errors.New("missing metadata")
+	errUnsupportedVersion = errors.New("unsupported backup version")
+	errUnexpectedEntry    = errors.New("unexpected archive entry")
+	errTruncatedKeyValue  = errors.New("truncated key-value pair")
+	errMetadataAfterData  = errors.New("metadata must precede the data")
+	errDuplicateMetadata  = errors.New("duplicate metadata")
+)
+
+// Metadata describes the database snapshot contained in a backup.
+type Metadata struct {
+	Version      uint16    `json:"version"`
+	LastAccepted ids.ID    `json:"lastAccepted"`
+	Height       uint64    `json:"height"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// Write streams the key-value pairs of [it] into a backup archive written to
+// [w] and returns the number of key-value pairs that were written. The caller
+// is responsible for releasing [it].
+//
+// To produce a consistent backup, [it] must iterate over a snapshot of the
+// database taken at the block described by [metadata].
+func Write(w io.Writer, it database.Iterator, metadata Metadata) (uint64, error) {
+	zw := zstd.NewWriter(w)
+	tw := tar.NewWriter(zw)
+
+	metadata.Version = Version
+	metadataBytes, err := json.Marshal(metadata)
+	if err != nil {
+		return 0, err
+	}
+	if err := writeEntry(tw, MetadataFile, metadataBytes); err != nil {
+		return 0, err
+	}
+
+	var (
+		numKeys  uint64
+		numChunk int
+		chunk    []byte
+		lenBuf   [binary.MaxVarintLen64]byte
+	)
+	flush := func() error {
+		name := path.Join(dataDir, fmt.Sprintf("%08d", numChunk))
+		if err := writeEntry(tw, name, chunk); err != nil {
+			return err
+		}
+		numChunk++
+		chunk = chunk[:0]
+		return nil
+	}
+	for it.Next() {
+		key := it.Key()
+		value := it.Value()
+
+		n := binary.PutUvarint(lenBuf[:], uint64(len(key)))
+		chunk = append(chunk, lenBuf[:n]...)
+		chunk = append(chunk, key...)
+		n = binary.PutUvarint(lenBuf[:], uint64(len(value)))
+		chunk = append(chunk, lenBuf[:n]...)
+		chunk = append(chunk, value...)
+		numKeys++
+
+		if len(chunk) >= dataChunkSize {
+			if err := flush(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if err := it.Error(); err != nil {
+		return 0, err
+	}
+	if len(chunk) > 0 {
+		if err := flush(); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return 0, err
+	}
+	return numKeys, zw.Close()
+}
+
+func writeEntry(tw *tar.Writer, name string, content []byte) error {
+	err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     int64(len(content)),
+		Mode:     0o600,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = tw.Write(content)
+	return err
+}
+
+// Restore writes the key-value pairs of the backup archive read from [r] into
+// [db], which must be empty. The metadata of the backup and the number of
+// restored key-value pairs are returned. If an error is returned, [db] may
+// have been partially restored.
+func Restore(r io.Reader, db database.Database) (Metadata, uint64, error) {
+	it := db.NewIterator()
+	hasKeys := it.Next()
+	it.Release()
+	if err := it.Error(); err != nil {
+		return Metadata{}, 0, err
+	}
+	if hasKeys {
+		return Metadata{}, 0, ErrDatabaseNotEmpty
+	}
+
+	zr := zstd.NewReader(r)
+	defer zr.Close()
+	tr := tar.NewReader(zr)
+
+	var (
+		metadata    Metadata
+		hasMetadata bool
+		numKeys     uint64
+		batch       = db.NewBatch()
+	)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Metadata{}, 0, err
+		}
+
+		switch {
+		case header.Name == MetadataFile:
+			if hasMetadata {
+				return Metadata{}, 0, errDuplicateMetadata
+			}
+			if err := json.NewDecoder(tr).Decode(&metadata); err != nil {
+				return Metadata{}, 0, fmt.Errorf("failed to parse metadata: %w", err)
+			}
+			if metadata.Version != Version {
+				return Metadata{}, 0, fmt.Errorf("%w: %d", errUnsupportedVersion, metadata.Version)
+			}
+			hasMetadata = true
+		case path.Dir(header.Name) == dataDir:
+			if !hasMetadata {
+				return Metadata{}, 0, errMetadataAfterData
+			}
+			numChunkKeys, err := restoreChunk(bufio.NewReader(tr), header.Size, batch)
+			if err != nil {
+				return Metadata{}, 0, fmt.Errorf("failed to restore %s: %w", header.Name, err)
+			}
+			numKeys += numChunkKeys
+		default:
+			return Metadata{}, 0, fmt.Errorf("%w: %s", errUnexpectedEntry, header.Name)
+		}
+	}
+	if !hasMetadata {
+		return Metadata{}, 0, errMissingMetadata
+	}
+	return metadata, numKeys, batch.Write()
+}
+
+func restoreChunk(r *bufio.Reader, size int64, batch database.Batch) (uint64, error) {
+	var numKeys uint64
+	for {
+		key, err := readBytes(r, size)
+		if err == io.EOF {
+			return numKeys, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		value, err := readBytes(r, size)
+		if err == io.EOF {
+			return 0, errTruncatedKeyValue
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		if err := batch.Put(key, value); err != nil {
+			return 0, err
+		}
+		numKeys++
+
+		if batch.Size() >= restoreBatchSize {
+			if err := batch.Write(); err != nil {
+				return 0, err
+			}
+			batch.Reset()
+		}
+	}
+}
+
+// readBytes reads a uvarint length prefixed byte slice of at most [maxLength]
+// bytes. If [r] is empty, EOF is returned.
+func readBytes(r *bufio.Reader, maxLength int64) ([]byte, error) {
+	length, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if length > uint64(maxLength) {
+		return nil, errTruncatedKeyValue
+	}
+	b := make([]byte, length)
+	if _, err := io.ReadFull(r, b); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return b, nil
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package backup
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func dump(t *testing.T, db database.Iteratee) map[string]string {
+	it := db.NewIterator()
+	defer it.Release()
+
+	kvs := make(map[string]string)
+	for it.Next() {
+		kvs[string(it.Key())] = string(it.Value())
+	}
+	require.NoError(t, it.Error())
+	return kvs
+}
+
+func TestWriteRestore(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	require.NoError(db.Put([]byte{1}, []byte{2}))
+	require.NoError(db.Put([]byte{3}, nil))
+	require.NoError(db.Put([]byte("key"), bytes.Repeat([]byte{4}, 1024)))
+
+	metadata := Metadata{
+		LastAccepted: ids.GenerateTestID(),
+		Height:       5,
+		CreatedAt:    time.Unix(6, 0).UTC(),
+	}
+
+	it := db.NewIterator()
+	defer it.Release()
+
+	archive := &bytes.Buffer{}
+	numKeys, err := Write(archive, it, metadata)
+	require.NoError(err)
+	require.Equal(uint64(3), numKeys)
+
+	restoredDB := memdb.New()
+	restoredMetadata, numKeys, err := Restore(bytes.NewReader(archive.Bytes()), restoredDB)
+	require.NoError(err)
+	require.Equal(uint64(3), numKeys)
+	require.Equal(metadata, restoredMetadata)
+
+	require.Equal(dump(t, db), dump(t, restoredDB))
+
+	// Backups can only be restored into empty databases.
+	_, _, err = Restore(bytes.NewReader(archive.Bytes()), restoredDB)
+	require.ErrorIs(err, ErrDatabaseNotEmpty)
+}
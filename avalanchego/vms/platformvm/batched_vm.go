@@ -0,0 +1,88 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+
+	snowmanblock "github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+var _ snowmanblock.BatchedChainVM = (*VM)(nil)
+
+// GetAncestors isn't batched by the VM, so the engine falls back to fetching
+// the ancestors one at a time.
+func (*VM) GetAncestors(
+	context.Context,
+	ids.ID,
+	int,
+	int,
+	time.Duration,
+) ([][]byte, error) {
+	return nil, snowmanblock.ErrRemoteVMNotImplemented
+}
+
+// BatchedParseBlock parses [blksBytes] in parallel. The txs of the parsed
+// blocks are also syntactically verified in parallel, which allows the blocks
+// to skip syntactic verification when they are executed, in order, by the
+// bootstrapper.
+func (vm *VM) BatchedParseBlock(_ context.Context, blksBytes [][]byte) ([]snowman.Block, error) {
+	var (
+		statelessBlks = make([]block.Block, len(blksBytes))
+		errs          = make([]error, len(blksBytes))
+		numWorkers    = runtime.GOMAXPROCS(0)
+		indices       = make(chan int)
+		wg            sync.WaitGroup
+	)
+	if numWorkers > len(blksBytes) {
+		numWorkers = len(blksBytes)
+	}
+	wg.Add(numWorkers)
+	for i := 0; i < numWorkers; i++ {
+		go func() {
+			defer wg.Done()
+
+			for i := range indices {
+				statelessBlks[i], errs[i] = vm.parseAndVerifyTxs(blksBytes[i])
+			}
+		}()
+	}
+	for i := range blksBytes {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	blks := make([]snowman.Block, len(blksBytes))
+	for i, statelessBlk := range statelessBlks {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		blks[i] = vm.manager.NewBlock(statelessBlk)
+	}
+	return blks, nil
+}
+
+func (vm *VM) parseAndVerifyTxs(blkBytes []byte) (block.Block, error) {
+	// Note: blocks to be parsed are not verified, so we must used blocks.Codec
+	// rather than blocks.GenesisCodec
+	statelessBlk, err := block.Parse(block.Codec, blkBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Txs remember that they were successfully verified. An invalid tx doesn't
+	// fail parsing, it is reported once its block is verified.
+	for _, tx := range statelessBlk.Txs() {
+		_ = tx.SyntacticVerify(vm.ctx)
+	}
+	return statelessBlk, nil
+}
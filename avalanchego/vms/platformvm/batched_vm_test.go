@@ -0,0 +1,82 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	blockexecutor "github.com/ava-labs/avalanchego/vms/platformvm/block/executor"
+	txexecutor "github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+)
+
+func TestBatchedParseBlock(t *testing.T) {
+	require := require.New(t)
+	vm, _, _ := defaultVM(t, latestFork)
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	var (
+		startTime = vm.clock.Time().Add(txexecutor.SyncBound).Add(1 * time.Second)
+		endTime   = startTime.Add(defaultMinStakingDuration)
+	)
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	tx, err := vm.txBuilder.NewAddPermissionlessValidatorTx(
+		vm.MinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		ids.GenerateTestNodeID(),
+		signer.NewProofOfPossession(sk),
+		ids.GenerateTestShortID(),
+		reward.PercentDenominator,
+		secp256k1fx.NewKeychain(keys[0]),
+		ids.ShortEmpty, // change addr
+		nil,
+	)
+	require.NoError(err)
+
+	lastAcceptedID := vm.manager.LastAccepted()
+	lastAccepted, err := vm.manager.GetBlock(lastAcceptedID)
+	require.NoError(err)
+	statelessBlk, err := block.NewBanffStandardBlock(
+		startTime,
+		lastAcceptedID,
+		lastAccepted.Height()+1,
+		[]*txs.Tx{tx},
+	)
+	require.NoError(err)
+
+	blks, err := vm.BatchedParseBlock(context.Background(), [][]byte{
+		lastAccepted.Bytes(),
+		statelessBlk.Bytes(),
+	})
+	require.NoError(err)
+	require.Len(blks, 2)
+	require.Equal(lastAcceptedID, blks[0].ID())
+	require.Equal(statelessBlk.ID(), blks[1].ID())
+
+	// The txs of the parsed block were syntactically verified while parsing.
+	parsedTx := blks[1].(*blockexecutor.Block).Block.Txs()[0]
+	require.True(parsedTx.Unsigned.(*txs.AddPermissionlessValidatorTx).SyntacticallyVerified)
+
+	_, err = vm.BatchedParseBlock(context.Background(), [][]byte{
+		statelessBlk.Bytes(),
+		{1},
+	})
+	require.ErrorIs(err, codec.ErrCantUnpackVersion)
+}
@@ -17,6 +17,8 @@ type Block interface {
 	snow.ContextInitializable
 	ID() ids.ID
 	Parent() ids.ID
+	// Bytes returns the bytes the block was parsed from or marshalled into.
+	// The slice is shared rather than copied, so it must not be modified.
 	Bytes() []byte
 	Height() uint64
 
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"sync"
 	"time"
 
@@ -19,11 +20,13 @@ import (
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/mempool"
 
+	safemath "github.com/ava-labs/avalanchego/utils/math"
 	blockexecutor "github.com/ava-labs/avalanchego/vms/platformvm/block/executor"
 	txexecutor "github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
 )
@@ -251,6 +254,7 @@ func (b *builder) PackBlockTxs(targetBlockSize int) ([]*txs.Tx, error) {
 		b.blkManager,
 		b.txExecutorBackend.Clk.Time(),
 		targetBlockSize,
+		maxBlockComplexity(b.txExecutorBackend.Config, b.txExecutorBackend.Clk.Time()),
 	)
 }
 
@@ -279,6 +283,18 @@ func buildBlock(
 		var blockTxs []*txs.Tx
 		// TODO: Cleanup post-Durango
 		if builder.txExecutorBackend.Config.IsDurangoActivated(timestamp) {
+			rewardComplexity, err := txs.Complexity(rewardValidatorTx)
+			if err != nil {
+				return nil, fmt.Errorf("could not calculate complexity of reward tx: %w", err)
+			}
+			remainingComplexity, err := safemath.Sub(
+				maxBlockComplexity(builder.txExecutorBackend.Config, timestamp),
+				rewardComplexity,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("%w: reward tx", blockexecutor.ErrBlockComplexityExceeded)
+			}
+
 			blockTxs, err = packBlockTxs(
 				parentID,
 				parentState,
@@ -287,6 +303,7 @@ func buildBlock(
 				builder.blkManager,
 				timestamp,
 				targetBlockSize,
+				remainingComplexity,
 			)
 			if err != nil {
 				return nil, fmt.Errorf("failed to pack block txs: %w", err)
@@ -310,6 +327,7 @@ func buildBlock(
 		builder.blkManager,
 		timestamp,
 		targetBlockSize,
+		maxBlockComplexity(builder.txExecutorBackend.Config, timestamp),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to pack block txs: %w", err)
@@ -338,6 +356,7 @@ func packBlockTxs(
 	manager blockexecutor.Manager,
 	timestamp time.Time,
 	remainingSize int,
+	remainingComplexity uint64,
 ) ([]*txs.Tx, error) {
 	stateDiff, err := state.NewDiffOn(parentState)
 	if err != nil {
@@ -362,6 +381,15 @@ func packBlockTxs(
 		if txSize > remainingSize {
 			break
 		}
+		txComplexity, err := txs.Complexity(tx)
+		if err != nil {
+			mempool.Remove(tx)
+			mempool.MarkDropped(tx.ID(), err)
+			continue
+		}
+		if txComplexity > remainingComplexity {
+			break
+		}
 		mempool.Remove(tx)
 
 		// Invariant: [tx] has already been syntactically verified.
@@ -377,7 +405,7 @@ func packBlockTxs(
 			Tx:      tx,
 		}
 
-		err = tx.Unsigned.Visit(executor)
+		err = backend.Execute(txDiff, tx, executor)
 		if err != nil {
 			txID := tx.ID()
 			mempool.MarkDropped(txID, err)
@@ -404,12 +432,22 @@ func packBlockTxs(
 		}
 
 		remainingSize -= txSize
+		remainingComplexity -= txComplexity
 		blockTxs = append(blockTxs, tx)
 	}
 
 	return blockTxs, nil
 }
 
+// maxBlockComplexity returns the max total complexity of the txs in a block
+// with [timestamp].
+func maxBlockComplexity(cfg *config.Config, timestamp time.Time) uint64 {
+	if !cfg.IsBlockComplexityActivated(timestamp) {
+		return math.MaxUint64
+	}
+	return cfg.MaxBlockComplexity
+}
+
 // getNextStakerToReward returns the next staker txID to remove from the staking
 // set with a RewardValidatorTx rather than an AdvanceTimeTx. [chainTimestamp]
 // is the timestamp of the chain at the time this validator would be getting
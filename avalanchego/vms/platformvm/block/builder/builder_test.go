@@ -16,7 +16,6 @@ import (
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
-	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
@@ -24,6 +23,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	blockexecutor "github.com/ava-labs/avalanchego/vms/platformvm/block/executor"
 	txexecutor "github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
@@ -43,7 +43,7 @@ func TestBuildBlockBasic(t *testing.T) {
 		constants.AVMID,
 		nil,
 		"chain name",
-		[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
 		ids.ShortEmpty,
 		nil,
 	)
@@ -89,6 +89,52 @@ func TestBuildBlockDoesNotBuildWithEmptyMempool(t *testing.T) {
 	require.Nil(blk)
 }
 
+func TestBuildBlockMaxComplexity(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment(t, latestFork)
+	env.ctx.Lock.Lock()
+	defer env.ctx.Lock.Unlock()
+
+	tx, err := env.txBuilder.NewCreateChainTx(
+		testSubnet1.ID(),
+		nil,
+		constants.AVMID,
+		nil,
+		"chain name",
+		secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
+		ids.ShortEmpty,
+		nil,
+	)
+	require.NoError(err)
+	txID := tx.ID()
+
+	complexity, err := txs.Complexity(tx)
+	require.NoError(err)
+
+	env.ctx.Lock.Unlock()
+	require.NoError(env.network.IssueTx(context.Background(), tx))
+	env.ctx.Lock.Lock()
+
+	// The tx doesn't fit into a block
+	env.config.MaxBlockComplexity = complexity - 1
+	_, err = env.Builder.BuildBlock(context.Background())
+	require.ErrorIs(err, ErrNoPendingBlocks)
+
+	// The tx should remain in the mempool
+	_, ok := env.mempool.Get(txID)
+	require.True(ok)
+
+	env.config.MaxBlockComplexity = complexity
+	blkIntf, err := env.Builder.BuildBlock(context.Background())
+	require.NoError(err)
+
+	require.IsType(&blockexecutor.Block{}, blkIntf)
+	blk := blkIntf.(*blockexecutor.Block)
+	require.Len(blk.Txs(), 1)
+	require.Equal(txID, blk.Txs()[0].ID())
+}
+
 func TestBuildBlockShouldReward(t *testing.T) {
 	require := require.New(t)
 
@@ -117,7 +163,7 @@ func TestBuildBlockShouldReward(t *testing.T) {
 		signer.NewProofOfPossession(sk),
 		preFundedKeys[0].PublicKey().Address(),
 		reward.PercentDenominator,
-		[]*secp256k1.PrivateKey{preFundedKeys[0]},
+		secp256k1fx.NewKeychain(preFundedKeys[0]),
 		preFundedKeys[0].PublicKey().Address(),
 		nil,
 	)
@@ -238,7 +284,7 @@ func TestBuildBlockForceAdvanceTime(t *testing.T) {
 		constants.AVMID,
 		nil,
 		"chain name",
-		[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
 		ids.ShortEmpty,
 		nil,
 	)
@@ -306,7 +352,7 @@ func TestBuildBlockDropExpiredStakerTxs(t *testing.T) {
 		ids.GenerateTestNodeID(),
 		preFundedKeys[0].PublicKey().Address(),
 		reward.PercentDenominator,
-		[]*secp256k1.PrivateKey{preFundedKeys[0]},
+		secp256k1fx.NewKeychain(preFundedKeys[0]),
 		preFundedKeys[0].PublicKey().Address(),
 		nil,
 	)
@@ -327,7 +373,7 @@ func TestBuildBlockDropExpiredStakerTxs(t *testing.T) {
 		ids.GenerateTestNodeID(),
 		preFundedKeys[1].PublicKey().Address(),
 		reward.PercentDenominator,
-		[]*secp256k1.PrivateKey{preFundedKeys[1]},
+		secp256k1fx.NewKeychain(preFundedKeys[1]),
 		preFundedKeys[1].PublicKey().Address(),
 		nil,
 	)
@@ -348,7 +394,7 @@ func TestBuildBlockDropExpiredStakerTxs(t *testing.T) {
 		ids.GenerateTestNodeID(),
 		preFundedKeys[2].PublicKey().Address(),
 		reward.PercentDenominator,
-		[]*secp256k1.PrivateKey{preFundedKeys[2]},
+		secp256k1fx.NewKeychain(preFundedKeys[2]),
 		preFundedKeys[2].PublicKey().Address(),
 		nil,
 	)
@@ -415,7 +461,7 @@ func TestBuildBlockInvalidStakingDurations(t *testing.T) {
 		signer.NewProofOfPossession(sk),
 		preFundedKeys[0].PublicKey().Address(),
 		reward.PercentDenominator,
-		[]*secp256k1.PrivateKey{preFundedKeys[0]},
+		secp256k1fx.NewKeychain(preFundedKeys[0]),
 		preFundedKeys[0].PublicKey().Address(),
 		nil,
 	)
@@ -439,7 +485,7 @@ func TestBuildBlockInvalidStakingDurations(t *testing.T) {
 		signer.NewProofOfPossession(sk),
 		preFundedKeys[2].PublicKey().Address(),
 		reward.PercentDenominator,
-		[]*secp256k1.PrivateKey{preFundedKeys[2]},
+		secp256k1fx.NewKeychain(preFundedKeys[2]),
 		preFundedKeys[2].PublicKey().Address(),
 		nil,
 	)
@@ -485,7 +531,7 @@ func TestPreviouslyDroppedTxsCannotBeReAddedToMempool(t *testing.T) {
 		constants.AVMID,
 		nil,
 		"chain name",
-		[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
 		ids.ShortEmpty,
 		nil,
 	)
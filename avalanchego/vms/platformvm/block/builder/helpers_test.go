@@ -181,7 +181,7 @@ func newEnvironment(t *testing.T, f fork) *environment { //nolint:unparam
 		return nil
 	}
 
-	metrics, err := metrics.New("", registerer)
+	metrics, err := metrics.New("", registerer, 0)
 	require.NoError(err)
 
 	res.mempool, err = mempool.New("mempool", registerer, nil)
@@ -193,9 +193,18 @@ func newEnvironment(t *testing.T, f fork) *environment { //nolint:unparam
 		res.state,
 		&res.backend,
 		pvalidators.TestManager,
+		0,
+		blockexecutor.Checkpoint{},
+		blockexecutor.InvariantChecks{},
+		false,
+		nil,
+		nil,
+		0,
 	)
 
 	txVerifier := network.NewLockedTxVerifier(&res.ctx.Lock, res.blkManager)
+	peerBans, err := network.NewPeerBans(res.backend.Ctx.Log, memdb.New(), res.backend.Clk, network.DefaultConfig)
+	require.NoError(err)
 	res.network, err = network.New(
 		res.backend.Ctx.Log,
 		res.backend.Ctx.NodeID,
@@ -204,6 +213,8 @@ func newEnvironment(t *testing.T, f fork) *environment { //nolint:unparam
 		txVerifier,
 		res.mempool,
 		res.backend.Config.PartialSyncPrimaryNetwork,
+		res.backend.Config.TrackedSubnets,
+		peerBans,
 		res.sender,
 		registerer,
 		network.DefaultConfig,
@@ -253,7 +264,7 @@ func addSubnet(t *testing.T, env *environment) {
 			preFundedKeys[1].PublicKey().Address(),
 			preFundedKeys[2].PublicKey().Address(),
 		},
-		[]*secp256k1.PrivateKey{preFundedKeys[0]},
+		secp256k1fx.NewKeychain(preFundedKeys[0]),
 		preFundedKeys[0].PublicKey().Address(),
 		nil,
 	)
@@ -350,11 +361,19 @@ func defaultConfig(t *testing.T, f fork) *config.Config {
 			MintingPeriod:      365 * 24 * time.Hour,
 			SupplyCap:          720 * units.MegaAvax,
 		},
-		ApricotPhase3Time: apricotPhase3Time,
-		ApricotPhase5Time: apricotPhase5Time,
-		BanffTime:         banffTime,
-		CortinaTime:       cortinaTime,
-		DurangoTime:       durangoTime,
+		ApricotPhase3Time:         apricotPhase3Time,
+		ApricotPhase5Time:         apricotPhase5Time,
+		BanffTime:                 banffTime,
+		CortinaTime:               cortinaTime,
+		DurangoTime:               durangoTime,
+		SubnetAllowListTime:       durangoTime,
+		CappedDelegationTime:      durangoTime,
+		ExitValidatorTime:         durangoTime,
+		SubnetValidatorWeightTime: durangoTime,
+		ParameterChangeTime:       durangoTime,
+		RekeyValidatorTime:        durangoTime,
+		NameRegistryTime:          durangoTime,
+		ValidatorMetadataTime:     durangoTime,
 	}
 }
 
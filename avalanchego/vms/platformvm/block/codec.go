@@ -41,6 +41,7 @@ func InitCodec(durangoTime time.Time) error {
 			txs.RegisterUnsignedTxsTypes(c),
 			RegisterBanffBlockTypes(c),
 			txs.RegisterDUnsignedTxsTypes(c),
+			txs.RegisterExtensionTypes(c),
 		)
 	}
 
@@ -9,11 +9,23 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/ava-labs/avalanchego/chains/atomic"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/attestation"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/faults"
 	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/validators"
+	"github.com/ava-labs/avalanchego/vms/platformvm/watchlist"
 )
 
 var (
@@ -30,6 +42,31 @@ type acceptor struct {
 	metrics      metrics.Metrics
 	validators   validators.Manager
 	bootstrapped *utils.Atomic[bool]
+
+	// bootstrapCommitInterval is the number of blocks accepted during
+	// bootstrapping between commits of the state. If it is at most 1, the
+	// state is committed after every block.
+	bootstrapCommitInterval int
+	// numUncommittedBlocks is the number of accepted blocks that have been
+	// written to the state without being committed.
+	numUncommittedBlocks int
+
+	// Optional watchlist that is notified of the rewards paid out to the
+	// watched addresses
+	rewardWatchlist *watchlist.Watchlist
+
+	// Optional attester that signs the state at the attested heights
+	stateAttester *attestation.Attester
+
+	invariantChecks InvariantChecks
+	// numUncheckedBlocks is the number of blocks accepted since the
+	// invariants of the state were last checked.
+	numUncheckedBlocks uint64
+
+	// Used to measure the uptimes recorded in reward receipts
+	primaryUptimePercentage float64
+	trackedSubnets          set.Set[ids.ID]
+	uptimes                 uptime.Calculator
 }
 
 func (a *acceptor) BanffAbortBlock(b *block.BanffAbortBlock) error {
@@ -85,23 +122,16 @@ func (a *acceptor) ApricotAtomicBlock(b *block.ApricotAtomicBlock) error {
 	}
 
 	defer a.state.Abort()
-	batch, err := a.state.CommitBatch()
-	if err != nil {
+	if err := a.commit(blkID, blkState.atomicRequests); err != nil {
 		return fmt.Errorf(
-			"failed to commit VM's database for block %s: %w",
-			blkID,
+			"failed to atomically accept tx %s: %w",
+			b.Tx.ID(),
 			err,
 		)
 	}
 
-	// Note that this method writes [batch] to the database.
-	if err := a.ctx.SharedMemory.Apply(blkState.atomicRequests, batch); err != nil {
-		return fmt.Errorf(
-			"failed to atomically accept tx %s in block %s: %w",
-			b.Tx.ID(),
-			blkID,
-			err,
-		)
+	if err := a.checkInvariants(b); err != nil {
+		return err
 	}
 
 	a.ctx.Log.Trace(
@@ -140,33 +170,62 @@ func (a *acceptor) optionBlock(b block.Block, blockType string) error {
 		return err
 	}
 
+	blkState, ok := a.blkIDToState[blkID]
+	if !ok {
+		return fmt.Errorf("%w %s", errMissingBlockState, blkID)
+	}
+
+	// The reward receipt must be created before the staker is removed from
+	// the state.
+	var (
+		receipt      *state.RewardReceipt
+		rewardedNode ids.NodeID
+	)
+	rewardTx, isReward := rewardValidatorTx(parentState.statelessBlock)
+	if isReward {
+		var err error
+		receipt, rewardedNode, err = a.newRewardReceipt(rewardTx)
+		if err != nil {
+			return err
+		}
+	}
+
 	if parentState.onDecisionState != nil {
 		if err := parentState.onDecisionState.Apply(a.state); err != nil {
 			return err
 		}
 	}
 
-	blkState, ok := a.blkIDToState[blkID]
-	if !ok {
-		return fmt.Errorf("%w %s", errMissingBlockState, blkID)
-	}
 	if err := blkState.onAcceptState.Apply(a.state); err != nil {
 		return err
 	}
 
-	defer a.state.Abort()
-	batch, err := a.state.CommitBatch()
-	if err != nil {
-		return fmt.Errorf(
-			"failed to commit VM's database for block %s: %w",
-			blkID,
-			err,
-		)
+	if isReward {
+		if err := a.recordRewards(rewardTx, rewardedNode, b, receipt); err != nil {
+			return err
+		}
 	}
 
-	// Note that this method writes [batch] to the database.
-	if err := a.ctx.SharedMemory.Apply(parentState.atomicRequests, batch); err != nil {
-		return fmt.Errorf("failed to apply vm's state to shared memory: %w", err)
+	// The shared memory requests of the decision txs of the proposal block are
+	// applied along with the requests of the accepted option.
+	atomicRequests := mergeAtomicRequests(parentState.atomicRequests, blkState.atomicRequests)
+	if a.deferCommit(atomicRequests) {
+		if err := a.write(blkID); err != nil {
+			return err
+		}
+	} else {
+		defer a.state.Abort()
+		if err := a.commit(blkID, atomicRequests); err != nil {
+			return err
+		}
+	}
+
+	if err := a.attestState(b); err != nil {
+		return err
+	}
+
+	if err := a.checkInvariants(b); err != nil {
+		return err
 	}
 
 	if onAcceptFunc := parentState.onAcceptFunc; onAcceptFunc != nil {
@@ -185,6 +244,185 @@ func (a *acceptor) optionBlock(b block.Block, blockType string) error {
 	return nil
 }
 
+// newRewardReceipt returns the partially filled receipt of the staker that
+// [rewardTx] decides to reward, along with the nodeID the staker validates
+// with. It must be called before the decision is applied to the state.
+func (a *acceptor) newRewardReceipt(rewardTx *txs.RewardValidatorTx) (*state.RewardReceipt, ids.NodeID, error) {
+	stakerTx, _, err := a.state.GetTx(rewardTx.TxID)
+	if err != nil {
+		return nil, ids.EmptyNodeID, fmt.Errorf("failed to get rewarded staker tx %s: %w", rewardTx.TxID, err)
+	}
+	staker, ok := stakerTx.Unsigned.(txs.Staker)
+	if !ok {
+		return nil, ids.EmptyNodeID, fmt.Errorf("%w: %T", errUnexpectedStakerTxType, stakerTx.Unsigned)
+	}
+
+	currentStaker, err := getCurrentStaker(a.state, rewardTx.TxID, staker)
+	if err != nil {
+		return nil, ids.EmptyNodeID, fmt.Errorf("failed to get rewarded staker %s: %w", rewardTx.TxID, err)
+	}
+
+	o := options{
+		log:                     a.ctx.Log,
+		primaryUptimePercentage: a.primaryUptimePercentage,
+		trackedSubnets:          a.trackedSubnets,
+		uptimes:                 a.uptimes,
+		state:                   a.state,
+	}
+	receipt := &state.RewardReceipt{
+		StakerTxID:      rewardTx.TxID,
+		SubnetID:        currentStaker.SubnetID,
+		PotentialReward: currentStaker.PotentialReward,
+	}
+
+	// The cause is provisionally set to this node's preference. It is revised
+	// once the decision is known.
+	measuredUptime, requiredUptime, err := o.stakerUptime(rewardTx.TxID, staker)
+	switch {
+	case err != nil:
+		// Failing to measure the uptime mirrors the fallback of preferring
+		// commit, so it must not be fatal.
+		a.ctx.Log.Debug("failed to measure uptime of rewarded staker",
+			zap.Stringer("stakerTxID", rewardTx.TxID),
+			zap.Error(err),
+		)
+		receipt.Cause = state.RewardCauseUptimeUnavailable
+	case measuredUptime >= requiredUptime:
+		receipt.Cause = state.RewardCauseUptimeSufficient
+	default:
+		receipt.Cause = state.RewardCauseUptimeInsufficient
+	}
+	receipt.Uptime = uint64(measuredUptime * reward.PercentDenominator)
+	receipt.RequiredUptime = uint64(requiredUptime * reward.PercentDenominator)
+	return receipt, currentStaker.NodeID, nil
+}
+
+// getCurrentStaker returns the current validator or delegator that was added
+// by [stakerTxID].
+func getCurrentStaker(chain state.Chain, stakerTxID ids.ID, staker txs.Staker) (*state.Staker, error) {
+	subnetID := staker.SubnetID()
+	nodeID := staker.NodeID()
+	if _, ok := staker.(txs.ValidatorTx); ok {
+		validator, err := chain.GetCurrentValidator(subnetID, nodeID)
+		switch {
+		case err == nil && validator.TxID == stakerTxID:
+			return validator, nil
+		case err != nil && err != database.ErrNotFound:
+			return nil, err
+		}
+	} else {
+		it, err := chain.GetCurrentDelegatorIterator(subnetID, nodeID)
+		if err != nil {
+			return nil, err
+		}
+		delegator, found := findStaker(it, stakerTxID)
+		if found {
+			return delegator, nil
+		}
+	}
+
+	// The staker was re-keyed away from the nodeID of its tx, which may
+	// have been reused since.
+	it, err := chain.GetCurrentStakerIterator()
+	if err != nil {
+		return nil, err
+	}
+	currentStaker, found := findStaker(it, stakerTxID)
+	if !found {
+		return nil, database.ErrNotFound
+	}
+	return currentStaker, nil
+}
+
+// findStaker returns the staker of [it] that was added by [stakerTxID]. [it]
+// is released.
+func findStaker(it state.StakerIterator, stakerTxID ids.ID) (*state.Staker, bool) {
+	defer it.Release()
+
+	for it.Next() {
+		if staker := it.Value(); staker.TxID == stakerTxID {
+			return staker, true
+		}
+	}
+	return nil, false
+}
+
+// rewardValidatorTx returns the RewardValidatorTx of [proposalBlock], if it has
+// one.
+func rewardValidatorTx(proposalBlock block.Block) (*txs.RewardValidatorTx, bool) {
+	blkTxs := proposalBlock.Txs()
+	if len(blkTxs) == 0 {
+		return nil, false
+	}
+	rewardTx, ok := blkTxs[len(blkTxs)-1].Unsigned.(*txs.RewardValidatorTx)
+	return rewardTx, ok
+}
+
+// recordRewards reports the rewards paid out by the accepted [rewardTx] to the
+// staker of [nodeID] and completes its reward [receipt]. It must be called
+// after the decision, [optionBlock], has been applied to the state.
+func (a *acceptor) recordRewards(rewardTx *txs.RewardValidatorTx, nodeID ids.NodeID, optionBlock block.Block, receipt *state.RewardReceipt) error {
+	stakerTx, _, err := a.state.GetTx(rewardTx.TxID)
+	if err != nil {
+		return fmt.Errorf("failed to get rewarded staker tx %s: %w", rewardTx.TxID, err)
+	}
+	staker, ok := stakerTx.Unsigned.(txs.Staker)
+	if !ok {
+		return fmt.Errorf("%w: %T", errUnexpectedStakerTxType, stakerTx.Unsigned)
+	}
+
+	rewardUTXOs, err := a.state.GetRewardUTXOs(rewardTx.TxID)
+	if err != nil {
+		return fmt.Errorf("failed to get reward UTXOs of %s: %w", rewardTx.TxID, err)
+	}
+	var rewards uint64
+	for _, utxo := range rewardUTXOs {
+		out, ok := utxo.Out.(avax.Amounter)
+		if !ok {
+			continue
+		}
+		rewards, err = math.Add64(rewards, out.Amount())
+		if err != nil {
+			return err
+		}
+	}
+
+	switch optionBlock.(type) {
+	case *block.BanffCommitBlock, *block.ApricotCommitBlock:
+		receipt.Rewarded = true
+	}
+	prefersCommit := receipt.Cause != state.RewardCauseUptimeInsufficient
+	if receipt.Rewarded != prefersCommit {
+		receipt.Cause = state.RewardCauseNetworkDecision
+	}
+	receipt.Height = optionBlock.Height()
+	receipt.Timestamp = uint64(a.state.GetTimestamp().Unix())
+	receipt.Reward = rewards
+	a.state.AddRewardReceipt(nodeID, receipt)
+
+	subnetID := staker.SubnetID()
+	a.metrics.AddRewards(subnetID, rewards)
+	a.ctx.Log.Debug("rewarded staker",
+		zap.Stringer("subnetID", subnetID),
+		zap.Stringer("stakerTxID", rewardTx.TxID),
+		zap.Uint64("rewards", rewards),
+	)
+
+	if a.rewardWatchlist != nil {
+		a.rewardWatchlist.Record(
+			watchlist.Reward{
+				BlockID:    optionBlock.ID(),
+				Height:     optionBlock.Height(),
+				StakerTxID: rewardTx.TxID,
+				NodeID:     nodeID,
+				SubnetID:   subnetID,
+			},
+			rewardUTXOs,
+		)
+	}
+	return nil
+}
+
 func (a *acceptor) proposalBlock(b block.Block, blockType string) {
 	// Note that:
 	//
@@ -233,19 +471,23 @@ func (a *acceptor) standardBlock(b block.Block, blockType string) error {
 		return err
 	}
 
-	defer a.state.Abort()
-	batch, err := a.state.CommitBatch()
-	if err != nil {
-		return fmt.Errorf(
-			"failed to commit VM's database for block %s: %w",
-			blkID,
-			err,
-		)
+	if a.deferCommit(blkState.atomicRequests) {
+		if err := a.write(blkID); err != nil {
+			return err
+		}
+	} else {
+		defer a.state.Abort()
+		if err := a.commit(blkID, blkState.atomicRequests); err != nil {
+			return err
+		}
 	}
 
-	// Note that this method writes [batch] to the database.
-	if err := a.ctx.SharedMemory.Apply(blkState.atomicRequests, batch); err != nil {
-		return fmt.Errorf("failed to apply vm's state to shared memory: %w", err)
+	if err := a.attestState(b); err != nil {
+		return err
+	}
+
+	if err := a.checkInvariants(b); err != nil {
+		return err
 	}
 
 	if onAcceptFunc := blkState.onAcceptFunc; onAcceptFunc != nil {
@@ -264,6 +506,156 @@ func (a *acceptor) standardBlock(b block.Block, blockType string) error {
 	return nil
 }
 
+// deferCommit returns true if the state shouldn't be committed after accepting
+// a block with [atomicRequests].
+//
+// While bootstrapping, blocks that don't modify shared memory are only written
+// to the state, which is committed once every [bootstrapCommitInterval]
+// blocks. Blocks are accepted in order, so a node that stops before the commit
+// restarts from a consistent, if older, last accepted block.
+func (a *acceptor) deferCommit(atomicRequests map[ids.ID]*atomic.Requests) bool {
+	return a.numUncommittedBlocks+1 < a.bootstrapCommitInterval &&
+		len(atomicRequests) == 0 &&
+		!a.bootstrapped.Get()
+}
+
+// write writes the accepted state without committing it.
+func (a *acceptor) write(blkID ids.ID) error {
+	if err := a.state.Write(); err != nil {
+		return fmt.Errorf(
+			"failed to write VM's state for block %s: %w",
+			blkID,
+			err,
+		)
+	}
+	a.numUncommittedBlocks++
+	return nil
+}
+
+// mergeAtomicRequests returns the requests of both [a] and [b], without
+// modifying either of them.
+func mergeAtomicRequests(a, b map[ids.ID]*atomic.Requests) map[ids.ID]*atomic.Requests {
+	switch {
+	case len(a) == 0:
+		return b
+	case len(b) == 0:
+		return a
+	}
+
+	merged := make(map[ids.ID]*atomic.Requests, len(a)+len(b))
+	for _, requests := range []map[ids.ID]*atomic.Requests{a, b} {
+		for chainID, chainRequests := range requests {
+			mergedRequests, exists := merged[chainID]
+			if !exists {
+				mergedRequests = &atomic.Requests{}
+				merged[chainID] = mergedRequests
+			}
+			mergedRequests.PutRequests = append(mergedRequests.PutRequests, chainRequests.PutRequests...)
+			mergedRequests.RemoveRequests = append(mergedRequests.RemoveRequests, chainRequests.RemoveRequests...)
+		}
+	}
+	return merged
+}
+
+// commit commits the accepted state atomically with [atomicRequests]. The
+// caller is responsible for aborting the state afterwards.
+//
+// This is the crash-consistency protocol of acceptance. Every change made by
+// accepting a block, including the last accepted block, is pending in the
+// versioned database of the state until [CommitBatch] collects them into a
+// single batch. [SharedMemory.Apply] writes that batch and [atomicRequests] in
+// one write of the shared database, so a crash leaves either none or all of
+// them on disk. There is no partially applied acceptance to detect: a node
+// that crashes before the write restarts from the previous last accepted block
+// and accepts the block again, and a node that crashes after the write
+// restarts from the block.
+//
+// Commits deferred while bootstrapping by [deferCommit] are safe for the same
+// reason. The blocks they skip are only written to the versioned database, so
+// they are committed in the batch of the next commit or lost together on a
+// crash. Blocks with shared memory requests are never deferred, so the shared
+// memory never runs ahead of the last accepted block.
+//
+// The requests are recorded along with the state, so that requests that were
+// already applied for [blkID] aren't applied again, as shared memory rejects
+// duplicate requests.
+func (a *acceptor) commit(blkID ids.ID, atomicRequests map[ids.ID]*atomic.Requests) error {
+	if len(atomicRequests) != 0 {
+		_, err := a.state.GetAtomicRequests(blkID)
+		switch err {
+		case nil:
+			a.ctx.Log.Warn("skipping already applied atomic requests",
+				zap.Stringer("blkID", blkID),
+			)
+			atomicRequests = nil
+		case database.ErrNotFound:
+			a.state.AddAtomicRequests(blkID, atomicRequests)
+		default:
+			return fmt.Errorf(
+				"failed to get atomic requests of block %s: %w",
+				blkID,
+				err,
+			)
+		}
+	}
+
+	batch, err := a.state.CommitBatch()
+	if err != nil {
+		return fmt.Errorf(
+			"failed to commit VM's database for block %s: %w",
+			blkID,
+			err,
+		)
+	}
+
+	if err := faults.Inject(faults.BeforeSharedMemoryApply); err != nil {
+		return err
+	}
+
+	// Note that this method writes [batch] to the database.
+	if err := a.ctx.SharedMemory.Apply(atomicRequests, batch); err != nil {
+		return fmt.Errorf("failed to apply vm's state to shared memory: %w", err)
+	}
+	if err := faults.Inject(faults.AfterSharedMemoryApply); err != nil {
+		return err
+	}
+	a.numUncommittedBlocks = 0
+	return nil
+}
+
+// attestState queues the commitment of the state after [b] was accepted to be
+// signed, if the height of [b] is attested. The state isn't attested while
+// bootstrapping, as the attestations of past heights were already collected by
+// the other validators.
+//
+// Proposal blocks are accepted together with their option, so their heights
+// are never attested.
+//
+// Invariant: the state was committed after accepting [b].
+func (a *acceptor) attestState(b block.Block) error {
+	if a.stateAttester == nil {
+		return nil
+	}
+
+	height := b.Height()
+	a.stateAttester.SetLastAccepted(height)
+	if !a.bootstrapped.Get() || !a.stateAttester.ShouldAttest(height) {
+		return nil
+	}
+
+	blkID := b.ID()
+	stateHash, err := a.state.Hash()
+	if err != nil {
+		return fmt.Errorf("failed to hash the state after block %s: %w", blkID, err)
+	}
+	a.stateAttester.Attest(attestation.Commitment{
+		Height:    height,
+		BlockID:   blkID,
+		StateHash: stateHash,
+	})
+	return nil
+}
+
 func (a *acceptor) commonAccept(b block.Block) error {
 	blkID := b.ID()
 
@@ -344,6 +344,7 @@ func TestAcceptorVisitCommitBlock(t *testing.T) {
 		s.EXPECT().SetHeight(blk.Height()).Times(1),
 		s.EXPECT().AddStatelessBlock(blk).Times(1),
 
+		parentStatelessBlk.EXPECT().Txs().Return(nil).Times(1),
 		parentOnCommitState.EXPECT().Apply(s).Times(1),
 		s.EXPECT().CommitBatch().Return(batch, nil).Times(1),
 		sharedMemory.EXPECT().Apply(atomicRequests, batch).Return(nil).Times(1),
@@ -454,6 +455,7 @@ func TestAcceptorVisitAbortBlock(t *testing.T) {
 		s.EXPECT().SetHeight(blk.Height()).Times(1),
 		s.EXPECT().AddStatelessBlock(blk).Times(1),
 
+		parentStatelessBlk.EXPECT().Txs().Return(nil).Times(1),
 		parentOnAbortState.EXPECT().Apply(s).Times(1),
 		s.EXPECT().CommitBatch().Return(batch, nil).Times(1),
 		sharedMemory.EXPECT().Apply(atomicRequests, batch).Return(nil).Times(1),
@@ -465,3 +467,170 @@ func TestAcceptorVisitAbortBlock(t *testing.T) {
 	require.True(calledOnAcceptFunc)
 	require.Equal(blk.ID(), acceptor.backend.lastAccepted)
 }
+
+func TestAcceptorBootstrapCommitInterval(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	s := state.NewMockState(ctrl)
+	sharedMemory := atomic.NewMockSharedMemory(ctrl)
+
+	parentID := ids.GenerateTestID()
+	bootstrapped := &utils.Atomic[bool]{}
+	acceptor := &acceptor{
+		backend: &backend{
+			lastAccepted: parentID,
+			blkIDToState: make(map[ids.ID]*blockState),
+			state:        s,
+			ctx: &snow.Context{
+				Log:          logging.NoLog{},
+				SharedMemory: sharedMemory,
+			},
+		},
+		metrics:                 metrics.Noop,
+		validators:              validators.TestManager,
+		bootstrapped:            bootstrapped,
+		bootstrapCommitInterval: 2,
+	}
+
+	accept := func(height uint64, atomicRequests map[ids.ID]*atomic.Requests, commit bool) {
+		blk, err := block.NewBanffStandardBlock(time.Time{}, parentID, height, nil)
+		require.NoError(err)
+		parentID = blk.ID()
+
+		onAcceptState := state.NewMockDiff(ctrl)
+		acceptor.backend.blkIDToState[blk.ID()] = &blockState{
+			onAcceptState:  onAcceptState,
+			atomicRequests: atomicRequests,
+		}
+
+		s.EXPECT().SetLastAccepted(blk.ID()).Times(1)
+		s.EXPECT().SetHeight(height).Times(1)
+		s.EXPECT().AddStatelessBlock(blk).Times(1)
+		onAcceptState.EXPECT().Apply(s).Times(1)
+		if commit {
+			if len(atomicRequests) != 0 {
+				s.EXPECT().GetAtomicRequests(blk.ID()).Return(nil, database.ErrNotFound).Times(1)
+				s.EXPECT().AddAtomicRequests(blk.ID(), atomicRequests).Times(1)
+			}
+			batch := database.NewMockBatch(ctrl)
+			s.EXPECT().CommitBatch().Return(batch, nil).Times(1)
+			s.EXPECT().Abort().Times(1)
+			sharedMemory.EXPECT().Apply(atomicRequests, batch).Return(nil).Times(1)
+		} else {
+			s.EXPECT().Write().Return(nil).Times(1)
+		}
+		s.EXPECT().Checksum().Return(ids.Empty).Times(1)
+
+		require.NoError(acceptor.BanffStandardBlock(blk))
+	}
+
+	// While bootstrapping, the state is committed every other block.
+	accept(1, nil, false)
+	accept(2, nil, true)
+	accept(3, nil, false)
+
+	// Blocks that modify shared memory are always committed.
+	atomicRequests := map[ids.ID]*atomic.Requests{
+		ids.GenerateTestID(): {},
+	}
+	accept(4, atomicRequests, true)
+
+	// Once bootstrapped, the state is committed after every block.
+	bootstrapped.Set(true)
+	accept(5, nil, true)
+	accept(6, nil, true)
+}
+
+func TestAcceptorSkipsAppliedAtomicRequests(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	s := state.NewMockState(ctrl)
+	sharedMemory := atomic.NewMockSharedMemory(ctrl)
+
+	parentID := ids.GenerateTestID()
+	acceptor := &acceptor{
+		backend: &backend{
+			lastAccepted: parentID,
+			blkIDToState: make(map[ids.ID]*blockState),
+			state:        s,
+			ctx: &snow.Context{
+				Log:          logging.NoLog{},
+				SharedMemory: sharedMemory,
+			},
+		},
+		metrics:    metrics.Noop,
+		validators: validators.TestManager,
+	}
+
+	blk, err := block.NewBanffStandardBlock(time.Time{}, parentID, 1, nil)
+	require.NoError(err)
+
+	onAcceptState := state.NewMockDiff(ctrl)
+	atomicRequests := map[ids.ID]*atomic.Requests{
+		ids.GenerateTestID(): {
+			RemoveRequests: [][]byte{{1}},
+		},
+	}
+	acceptor.backend.blkIDToState[blk.ID()] = &blockState{
+		onAcceptState:  onAcceptState,
+		atomicRequests: atomicRequests,
+	}
+
+	// The requests were already applied when the block was accepted, so only
+	// the state is written.
+	s.EXPECT().SetLastAccepted(blk.ID()).Times(1)
+	s.EXPECT().SetHeight(blk.Height()).Times(1)
+	s.EXPECT().AddStatelessBlock(blk).Times(1)
+	onAcceptState.EXPECT().Apply(s).Times(1)
+	s.EXPECT().GetAtomicRequests(blk.ID()).Return(atomicRequests, nil).Times(1)
+	batch := database.NewMockBatch(ctrl)
+	s.EXPECT().CommitBatch().Return(batch, nil).Times(1)
+	s.EXPECT().Abort().Times(1)
+	sharedMemory.EXPECT().Apply(nil, batch).Return(nil).Times(1)
+	s.EXPECT().Checksum().Return(ids.Empty).Times(1)
+
+	require.NoError(acceptor.BanffStandardBlock(blk))
+}
+
+func TestMergeAtomicRequests(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		chainID0 = ids.GenerateTestID()
+		chainID1 = ids.GenerateTestID()
+		decision = map[ids.ID]*atomic.Requests{
+			chainID0: {
+				RemoveRequests: [][]byte{{0}},
+			},
+		}
+		option = map[ids.ID]*atomic.Requests{
+			chainID0: {
+				PutRequests: []*atomic.Element{{Key: []byte{1}}},
+			},
+			chainID1: {
+				PutRequests: []*atomic.Element{{Key: []byte{2}}},
+			},
+		}
+	)
+
+	require.Equal(decision, mergeAtomicRequests(decision, nil))
+	require.Equal(option, mergeAtomicRequests(nil, option))
+	require.Equal(
+		map[ids.ID]*atomic.Requests{
+			chainID0: {
+				RemoveRequests: [][]byte{{0}},
+				PutRequests:    []*atomic.Element{{Key: []byte{1}}},
+			},
+			chainID1: {
+				PutRequests: []*atomic.Element{{Key: []byte{2}}},
+			},
+		},
+		mergeAtomicRequests(decision, option),
+	)
+
+	// The merged requests aren't modified.
+	require.Empty(decision[chainID0].PutRequests)
+	require.Empty(option[chainID0].RemoveRequests)
+}
@@ -5,6 +5,7 @@ package executor
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -15,7 +16,11 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/mempool"
 )
 
-var errConflictingParentTxs = errors.New("block contains a transaction that conflicts with a transaction in a parent block")
+var (
+	errConflictingParentTxs = errors.New("block contains a transaction that conflicts with a transaction in a parent block")
+
+	ErrConflictingProcessingTx = errors.New("transaction conflicts with a transaction in a processing block")
+)
 
 // Shared fields used by visitors.
 type backend struct {
@@ -34,9 +39,25 @@ type backend struct {
 	state        state.State
 
 	ctx *snow.Context
+
+	// checkpoint below which blocks are replayed without verifying their
+	// spends while bootstrapping.
+	checkpoint Checkpoint
+
+	// trace, if non-nil, records all the accesses to the states returned by
+	// this backend.
+	trace *Trace
 }
 
 func (b *backend) GetState(blkID ids.ID) (state.Chain, bool) {
+	chain, ok := b.getState(blkID)
+	if !ok || b.trace == nil {
+		return chain, ok
+	}
+	return state.NewTracedChain(chain, parentStateScope, b.trace.record), true
+}
+
+func (b *backend) getState(blkID ids.ID) (state.Chain, bool) {
 	// If the block is in the map, it is either processing or a proposal block
 	// that was accepted without an accepted child.
 	if state, ok := b.blkIDToState[blkID]; ok {
@@ -77,6 +98,16 @@ func (b *backend) GetBlock(blkID ids.ID) (block.Block, error) {
 	return b.state.GetStatelessBlock(blkID)
 }
 
+func (b *backend) ProcessingChildren(blkID ids.ID) []ids.ID {
+	var children []ids.ID
+	for childID, childState := range b.blkIDToState {
+		if childID != b.lastAccepted && childState.statelessBlock.Parent() == blkID {
+			children = append(children, childID)
+		}
+	}
+	return children
+}
+
 func (b *backend) LastAccepted() ids.ID {
 	return b.lastAccepted
 }
@@ -124,3 +155,35 @@ func (b *backend) verifyUniqueInputs(blkID ids.ID, inputs set.Set[ids.ID]) error
 		blkID = blk.Parent()
 	}
 }
+
+// verifyNoProcessingConflicts returns nil iff no processing block, regardless
+// of whether it is preferred, contains a tx other than [txID] that consumes an
+// input in [inputs].
+func (b *backend) verifyNoProcessingConflicts(txID ids.ID, inputs set.Set[ids.ID]) error {
+	if inputs.Len() == 0 {
+		return nil
+	}
+
+	for blkID, blkState := range b.blkIDToState {
+		// An accepted proposal block may remain in memory until one of its
+		// children is accepted. Its inputs are already consumed on disk.
+		if blkID == b.lastAccepted {
+			continue
+		}
+
+		consumed := blkState.getConsumedInputs()
+		for inputID := range inputs {
+			conflictingTxID, ok := consumed[inputID]
+			if !ok || conflictingTxID == txID {
+				continue
+			}
+			return fmt.Errorf("%w: input %s is consumed by tx %s in block %s",
+				ErrConflictingProcessingTx,
+				inputID,
+				conflictingTxID,
+				blkID,
+			)
+		}
+	}
+	return nil
+}
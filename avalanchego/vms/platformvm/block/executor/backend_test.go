@@ -109,6 +109,36 @@ func TestBackendGetBlock(t *testing.T) {
 	}
 }
 
+func TestBackendProcessingChildren(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	var (
+		lastAcceptedID = ids.GenerateTestID()
+		childID        = ids.GenerateTestID()
+		grandchildID   = ids.GenerateTestID()
+		child          = block.NewMockBlock(ctrl)
+		grandchild     = block.NewMockBlock(ctrl)
+		lastAccepted   = block.NewMockBlock(ctrl)
+		b              = &backend{
+			lastAccepted: lastAcceptedID,
+			blkIDToState: map[ids.ID]*blockState{
+				// An accepted proposal block remains in the map until one of
+				// its children is accepted.
+				lastAcceptedID: {statelessBlock: lastAccepted},
+				childID:        {statelessBlock: child},
+				grandchildID:   {statelessBlock: grandchild},
+			},
+		}
+	)
+	child.EXPECT().Parent().Return(lastAcceptedID).AnyTimes()
+	grandchild.EXPECT().Parent().Return(childID).AnyTimes()
+
+	require.Equal([]ids.ID{childID}, b.ProcessingChildren(lastAcceptedID))
+	require.Equal([]ids.ID{grandchildID}, b.ProcessingChildren(childID))
+	require.Empty(b.ProcessingChildren(grandchildID))
+}
+
 func TestGetTimestamp(t *testing.T) {
 	type test struct {
 		name              string
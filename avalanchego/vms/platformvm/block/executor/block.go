@@ -5,6 +5,7 @@ package executor
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"go.uber.org/zap"
@@ -33,15 +34,32 @@ func (b *Block) Verify(context.Context) error {
 		return nil
 	}
 
-	return b.Visit(b.manager.verifier)
+	// Refusing to verify the block reports the back-pressure to the consensus
+	// engine, which drops the block until it is requested again.
+	maxProcessingBlocks := b.manager.maxProcessingBlocks
+	if maxProcessingBlocks > 0 && len(b.manager.blkIDToState) >= maxProcessingBlocks {
+		b.manager.metrics.IncProcessingBlocksLimitReached()
+		return fmt.Errorf("%w: limit of %d reached", ErrTooManyProcessingBlocks, maxProcessingBlocks)
+	}
+
+	err := b.Visit(b.manager.verifier)
+	if b.manager.shadowExecution && b.manager.txExecutorBackend.Bootstrapped.Get() {
+		b.manager.shadowVerify(b.Block, err)
+	}
+	b.manager.metrics.SetProcessingBlocks(len(b.manager.blkIDToState))
+	return err
 }
 
 func (b *Block) Accept(context.Context) error {
-	return b.Visit(b.manager.acceptor)
+	err := b.Visit(b.manager.acceptor)
+	b.manager.metrics.SetProcessingBlocks(len(b.manager.blkIDToState))
+	return err
 }
 
 func (b *Block) Reject(context.Context) error {
-	return b.Visit(b.manager.rejector)
+	err := b.Visit(b.manager.rejector)
+	b.manager.metrics.SetProcessingBlocks(len(b.manager.blkIDToState))
+	return err
 }
 
 func (b *Block) Status() choices.Status {
@@ -85,6 +103,7 @@ func (b *Block) Options(context.Context) ([2]snowman.Block, error) {
 	options := options{
 		log:                     b.manager.ctx.Log,
 		primaryUptimePercentage: b.manager.txExecutorBackend.Config.UptimePercentage,
+		trackedSubnets:          b.manager.txExecutorBackend.Config.TrackedSubnets,
 		uptimes:                 b.manager.txExecutorBackend.Uptimes,
 		state:                   b.manager.backend.state,
 	}
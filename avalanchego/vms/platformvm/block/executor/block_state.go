@@ -17,6 +17,11 @@ type proposalBlockState struct {
 	onDecisionState state.Diff
 	onCommitState   state.Diff
 	onAbortState    state.Diff
+
+	// Shared memory requests of the proposal tx that only apply if the
+	// proposal is committed or aborted
+	onCommitAtomicRequests map[ids.ID]*atomic.Requests
+	onAbortAtomicRequests  map[ids.ID]*atomic.Requests
 }
 
 // The state of a block.
@@ -31,4 +36,23 @@ type blockState struct {
 	inputs         set.Set[ids.ID]
 	timestamp      time.Time
 	atomicRequests map[ids.ID]*atomic.Requests
+
+	// consumedInputs maps every UTXO consumed by the block's txs to the ID of
+	// the tx consuming it. It is populated lazily by getConsumedInputs.
+	consumedInputs map[ids.ID]ids.ID
+}
+
+func (s *blockState) getConsumedInputs() map[ids.ID]ids.ID {
+	if s.consumedInputs != nil {
+		return s.consumedInputs
+	}
+
+	s.consumedInputs = make(map[ids.ID]ids.ID)
+	for _, tx := range s.statelessBlock.Txs() {
+		txID := tx.ID()
+		for inputID := range tx.Unsigned.InputIDs() {
+			s.consumedInputs[inputID] = txID
+		}
+	}
+	return s.consumedInputs
 }
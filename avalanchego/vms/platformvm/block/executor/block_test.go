@@ -17,8 +17,10 @@ import (
 	"github.com/ava-labs/avalanchego/snow/snowtest"
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
@@ -131,6 +133,33 @@ func TestStatus(t *testing.T) {
 	}
 }
 
+func TestVerifyMaxProcessingBlocks(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	blkID := ids.GenerateTestID()
+	statelessBlk := block.NewMockBlock(ctrl)
+	statelessBlk.EXPECT().ID().Return(blkID)
+
+	manager := &manager{
+		backend: &backend{
+			blkIDToState: map[ids.ID]*blockState{
+				ids.GenerateTestID(): {},
+			},
+		},
+		metrics:             metrics.Noop,
+		maxProcessingBlocks: 1,
+	}
+	blk := &Block{
+		Block:   statelessBlk,
+		manager: manager,
+	}
+
+	err := blk.Verify(context.Background())
+	require.ErrorIs(err, ErrTooManyProcessingBlocks)
+	require.Len(manager.blkIDToState, 1)
+}
+
 func TestBlockOptions(t *testing.T) {
 	type test struct {
 		name                   string
@@ -384,13 +413,15 @@ func TestBlockOptions(t *testing.T) {
 					}
 					primaryNetworkValidatorStartTime = time.Now()
 					staker                           = &state.Staker{
+						TxID:      stakerTxID,
+						NodeID:    nodeID,
 						StartTime: primaryNetworkValidatorStartTime,
 					}
 				)
 
 				state := state.NewMockState(ctrl)
 				state.EXPECT().GetTx(stakerTxID).Return(stakerTx, status.Committed, nil)
-				state.EXPECT().GetCurrentValidator(constants.PrimaryNetworkID, nodeID).Return(staker, nil)
+				state.EXPECT().GetCurrentValidator(constants.PrimaryNetworkID, nodeID).Return(staker, nil).Times(2)
 
 				uptimes := uptime.NewMockCalculator(ctrl)
 				uptimes.EXPECT().CalculateUptimePercentFrom(nodeID, constants.PrimaryNetworkID, primaryNetworkValidatorStartTime).Return(0.0, database.ErrNotFound)
@@ -603,6 +634,74 @@ func TestBlockOptions(t *testing.T) {
 			},
 			expectedPreferenceType: &block.BanffAbortBlock{},
 		},
+		{
+			name: "banff proposal block; prefers abort on tracked subnet uptime",
+			blkF: func(ctrl *gomock.Controller) *Block {
+				var (
+					stakerTxID = ids.GenerateTestID()
+					nodeID     = ids.GenerateTestNodeID()
+					subnetID   = ids.GenerateTestID()
+					stakerTx   = &txs.Tx{
+						Unsigned: &txs.AddPermissionlessValidatorTx{
+							Validator: txs.Validator{
+								NodeID: nodeID,
+							},
+							Subnet: subnetID,
+						},
+					}
+					primaryNetworkValidatorStartTime = time.Now()
+					primaryNetworkStaker             = &state.Staker{
+						StartTime: primaryNetworkValidatorStartTime,
+					}
+					subnetValidatorStartTime = primaryNetworkValidatorStartTime.Add(time.Hour)
+					subnetStaker             = &state.Staker{
+						StartTime: subnetValidatorStartTime,
+					}
+					transformSubnetTx = &txs.Tx{
+						Unsigned: &txs.TransformSubnetTx{
+							UptimeRequirement: .6 * reward.PercentDenominator,
+						},
+					}
+				)
+
+				state := state.NewMockState(ctrl)
+				state.EXPECT().GetTx(stakerTxID).Return(stakerTx, status.Committed, nil)
+				state.EXPECT().GetCurrentValidator(constants.PrimaryNetworkID, nodeID).Return(primaryNetworkStaker, nil)
+				state.EXPECT().GetSubnetTransformation(subnetID).Return(transformSubnetTx, nil)
+				state.EXPECT().GetCurrentValidator(subnetID, nodeID).Return(subnetStaker, nil)
+
+				uptimes := uptime.NewMockCalculator(ctrl)
+				uptimes.EXPECT().CalculateUptimePercentFrom(nodeID, subnetID, subnetValidatorStartTime).Return(.5, nil)
+
+				manager := &manager{
+					backend: &backend{
+						state: state,
+						ctx:   snowtest.Context(t, snowtest.PChainID),
+					},
+					txExecutorBackend: &executor.Backend{
+						Config: &config.Config{
+							UptimePercentage: .4,
+							TrackedSubnets:   set.Of(subnetID),
+						},
+						Uptimes: uptimes,
+					},
+				}
+
+				return &Block{
+					Block: &block.BanffProposalBlock{
+						ApricotProposalBlock: block.ApricotProposalBlock{
+							Tx: &txs.Tx{
+								Unsigned: &txs.RewardValidatorTx{
+									TxID: stakerTxID,
+								},
+							},
+						},
+					},
+					manager: manager,
+				}
+			},
+			expectedPreferenceType: &block.BanffAbortBlock{},
+		},
 	}
 
 	for _, tt := range tests {
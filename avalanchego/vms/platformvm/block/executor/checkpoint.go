@@ -0,0 +1,45 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+)
+
+var errCheckpointMismatch = errors.New("block doesn't match the trusted checkpoint")
+
+// Checkpoint is an accepted block that the operator trusts.
+//
+// While bootstrapping, the txs of the blocks at or below the checkpoint are
+// replayed with only their structure being verified, as the blocks are already
+// known to be valid. The checkpoint block itself must have the configured ID,
+// otherwise its verification fails. A zero checkpoint disables trusted replay.
+type Checkpoint struct {
+	Height  uint64
+	BlockID ids.ID
+}
+
+func (c Checkpoint) trusts(height uint64) bool {
+	return c.Height != 0 && height <= c.Height
+}
+
+// verify that [b] isn't a different block at the height of the checkpoint.
+func (c Checkpoint) verify(b block.Block) error {
+	if c.Height == 0 || b.Height() != c.Height {
+		return nil
+	}
+	if blkID := b.ID(); blkID != c.BlockID {
+		return fmt.Errorf("%w: expected %s at height %d but got %s",
+			errCheckpointMismatch,
+			c.BlockID,
+			c.Height,
+			blkID,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+	"github.com/ava-labs/avalanchego/vms/platformvm/utxo"
+)
+
+func TestCheckpointVerify(t *testing.T) {
+	require := require.New(t)
+
+	checkpointBlk, err := block.NewApricotCommitBlock(ids.GenerateTestID(), 10)
+	require.NoError(err)
+	otherBlk, err := block.NewApricotCommitBlock(ids.GenerateTestID(), 10)
+	require.NoError(err)
+	lowerBlk, err := block.NewApricotCommitBlock(ids.GenerateTestID(), 9)
+	require.NoError(err)
+
+	checkpoint := Checkpoint{
+		Height:  10,
+		BlockID: checkpointBlk.ID(),
+	}
+	require.NoError(checkpoint.verify(checkpointBlk))
+	require.NoError(checkpoint.verify(lowerBlk))
+	err = checkpoint.verify(otherBlk)
+	require.ErrorIs(err, errCheckpointMismatch)
+
+	// A zero checkpoint accepts every block.
+	require.NoError(Checkpoint{}.verify(otherBlk))
+}
+
+func TestVerifierTxBackend(t *testing.T) {
+	require := require.New(t)
+
+	bootstrapped := &utils.Atomic[bool]{}
+	txExecutorBackend := &executor.Backend{
+		FlowChecker:  utxo.NewMockVerifier(nil),
+		Bootstrapped: bootstrapped,
+	}
+	v := &verifier{
+		backend: &backend{
+			checkpoint: Checkpoint{
+				Height:  10,
+				BlockID: ids.GenerateTestID(),
+			},
+		},
+		txExecutorBackend: txExecutorBackend,
+	}
+
+	// Blocks at or below the checkpoint are trusted while bootstrapping.
+	require.Equal(utxo.TrustedVerifier{}, v.txBackend(9).FlowChecker)
+	require.Equal(utxo.TrustedVerifier{}, v.txBackend(10).FlowChecker)
+	require.Equal(txExecutorBackend, v.txBackend(11))
+
+	// Once bootstrapped, every block is verified.
+	bootstrapped.Set(true)
+	require.Equal(txExecutorBackend, v.txBackend(9))
+
+	// Without a checkpoint, no block is trusted.
+	bootstrapped.Set(false)
+	v.checkpoint = Checkpoint{}
+	require.Equal(txExecutorBackend, v.txBackend(9))
+}
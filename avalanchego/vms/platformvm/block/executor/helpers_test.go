@@ -214,6 +214,13 @@ func newEnvironment(t *testing.T, ctrl *gomock.Controller, f fork) *environment
 			res.state,
 			res.backend,
 			pvalidators.TestManager,
+			0,
+			Checkpoint{},
+			InvariantChecks{},
+			false,
+			nil,
+			nil,
+			0,
 		)
 		addSubnet(res)
 	} else {
@@ -223,6 +230,13 @@ func newEnvironment(t *testing.T, ctrl *gomock.Controller, f fork) *environment
 			res.mockedState,
 			res.backend,
 			pvalidators.TestManager,
+			0,
+			Checkpoint{},
+			InvariantChecks{},
+			false,
+			nil,
+			nil,
+			0,
 		)
 		// we do not add any subnet to state, since we can mock
 		// whatever we need
@@ -266,7 +280,7 @@ func addSubnet(env *environment) {
 			preFundedKeys[1].PublicKey().Address(),
 			preFundedKeys[2].PublicKey().Address(),
 		},
-		[]*secp256k1.PrivateKey{preFundedKeys[0]},
+		secp256k1fx.NewKeychain(preFundedKeys[0]),
 		preFundedKeys[0].PublicKey().Address(),
 		nil,
 	)
@@ -374,11 +388,19 @@ func defaultConfig(t *testing.T, f fork) *config.Config {
 			MintingPeriod:      365 * 24 * time.Hour,
 			SupplyCap:          720 * units.MegaAvax,
 		},
-		ApricotPhase3Time: apricotPhase3Time,
-		ApricotPhase5Time: apricotPhase5Time,
-		BanffTime:         banffTime,
-		CortinaTime:       cortinaTime,
-		DurangoTime:       durangoTime,
+		ApricotPhase3Time:         apricotPhase3Time,
+		ApricotPhase5Time:         apricotPhase5Time,
+		BanffTime:                 banffTime,
+		CortinaTime:               cortinaTime,
+		DurangoTime:               durangoTime,
+		SubnetAllowListTime:       durangoTime,
+		CappedDelegationTime:      durangoTime,
+		ExitValidatorTime:         durangoTime,
+		SubnetValidatorWeightTime: durangoTime,
+		ParameterChangeTime:       durangoTime,
+		RekeyValidatorTime:        durangoTime,
+		NameRegistryTime:          durangoTime,
+		ValidatorMetadataTime:     durangoTime,
 	}
 }
 
@@ -506,7 +528,7 @@ func addPendingValidator(
 		nodeID,
 		rewardAddress,
 		reward.PercentDenominator,
-		keys,
+		secp256k1fx.NewKeychain(keys...),
 		ids.ShortEmpty,
 		nil,
 	)
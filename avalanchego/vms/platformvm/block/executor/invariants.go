@@ -0,0 +1,54 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"errors"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+)
+
+// InvariantChecks configures how often the invariants of the state are
+// verified after blocks are accepted. A zero interval disables the checks.
+type InvariantChecks struct {
+	// The invariants are checked once every [Interval] accepted blocks
+	Interval uint64
+	// If true, a violation fails the acceptance of the block. Otherwise it is
+	// only logged and reported in the metrics.
+	Fatal bool
+}
+
+// checkInvariants verifies the invariants of the state after [b] was accepted,
+// if [b] is sampled by the interval of the checks.
+//
+// Invariant: the state was written after accepting [b].
+func (a *acceptor) checkInvariants(b block.Block) error {
+	if a.invariantChecks.Interval == 0 {
+		return nil
+	}
+	a.numUncheckedBlocks++
+	if a.numUncheckedBlocks < a.invariantChecks.Interval {
+		return nil
+	}
+	a.numUncheckedBlocks = 0
+
+	err := a.state.CheckInvariants()
+	if !errors.Is(err, state.ErrInvariantViolated) {
+		return err
+	}
+
+	a.metrics.IncInvariantViolations()
+	a.ctx.Log.Error("state invariant violated",
+		zap.Stringer("blkID", b.ID()),
+		zap.Uint64("height", b.Height()),
+		zap.Error(err),
+	)
+	if a.invariantChecks.Fatal {
+		return err
+	}
+	return nil
+}
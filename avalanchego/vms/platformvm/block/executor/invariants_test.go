@@ -0,0 +1,85 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+)
+
+func TestAcceptorCheckInvariants(t *testing.T) {
+	errViolation := fmt.Errorf("%w: test", state.ErrInvariantViolated)
+	tests := []struct {
+		name        string
+		checks      InvariantChecks
+		numAccepted int
+		checkErr    error
+		numChecks   int
+		expectedErr error
+	}{
+		{
+			name:        "disabled",
+			checks:      InvariantChecks{},
+			numAccepted: 4,
+		},
+		{
+			name:        "sampled",
+			checks:      InvariantChecks{Interval: 2},
+			numAccepted: 4,
+			numChecks:   2,
+		},
+		{
+			name:        "violation isn't fatal",
+			checks:      InvariantChecks{Interval: 1},
+			numAccepted: 1,
+			checkErr:    errViolation,
+			numChecks:   1,
+		},
+		{
+			name:        "fatal violation",
+			checks:      InvariantChecks{Interval: 1, Fatal: true},
+			numAccepted: 1,
+			checkErr:    errViolation,
+			numChecks:   1,
+			expectedErr: state.ErrInvariantViolated,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			s := state.NewMockState(ctrl)
+			s.EXPECT().CheckInvariants().Return(test.checkErr).Times(test.numChecks)
+
+			acceptor := &acceptor{
+				backend: &backend{
+					ctx: &snow.Context{
+						Log: logging.NoLog{},
+					},
+					state: s,
+				},
+				metrics:         metrics.Noop,
+				invariantChecks: test.checks,
+			}
+
+			blk, err := block.NewBanffStandardBlock(defaultGenesisTime, ids.GenerateTestID(), 1, nil)
+			require.NoError(err)
+			for i := 0; i < test.numAccepted; i++ {
+				err := acceptor.checkInvariants(blk)
+				require.ErrorIs(err, test.expectedErr)
+			}
+		})
+	}
+}
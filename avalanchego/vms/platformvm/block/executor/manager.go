@@ -8,7 +8,9 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/attestation"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
@@ -16,12 +18,14 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/mempool"
 	"github.com/ava-labs/avalanchego/vms/platformvm/validators"
+	"github.com/ava-labs/avalanchego/vms/platformvm/watchlist"
 )
 
 var (
 	_ Manager = (*manager)(nil)
 
-	ErrChainNotSynced = errors.New("chain not synced")
+	ErrChainNotSynced          = errors.New("chain not synced")
+	ErrTooManyProcessingBlocks = errors.New("too many processing blocks")
 )
 
 type Manager interface {
@@ -34,16 +38,31 @@ type Manager interface {
 	Preferred() ids.ID
 
 	GetBlock(blkID ids.ID) (snowman.Block, error)
+	// ProcessingChildren returns the IDs of the processing blocks whose parent
+	// is [blkID].
+	ProcessingChildren(blkID ids.ID) []ids.ID
 	GetStatelessBlock(blkID ids.ID) (block.Block, error)
 	NewBlock(block.Block) snowman.Block
 
+	// ParseBlock returns the block whose bytes are [blkBytes]. If the block is
+	// already held in memory, the held block is returned rather than parsing
+	// [blkBytes] again. Otherwise, the returned block retains [blkBytes], which
+	// must not be modified afterwards.
+	ParseBlock(blkBytes []byte) (snowman.Block, error)
+
 	// VerifyTx verifies that the transaction can be issued based on the currently
-	// preferred state. This should *not* be used to verify transactions in a block.
+	// preferred state and that it doesn't conflict with a transaction in any
+	// processing block. This should *not* be used to verify transactions in a
+	// block.
 	VerifyTx(tx *txs.Tx) error
 
 	// VerifyUniqueInputs verifies that the inputs are not duplicated in the
 	// provided blk or any of its ancestors pinned in memory.
 	VerifyUniqueInputs(blkID ids.ID, inputs set.Set[ids.ID]) error
+
+	// TraceVerify re-runs the verification of the provided blk, recording the
+	// state accesses performed. It has no side effects.
+	TraceVerify(blk block.Block) *Trace
 }
 
 func NewManager(
@@ -52,6 +71,13 @@ func NewManager(
 	s state.State,
 	txExecutorBackend *executor.Backend,
 	validatorManager validators.Manager,
+	bootstrapCommitInterval int,
+	checkpoint Checkpoint,
+	invariantChecks InvariantChecks,
+	shadowExecution bool,
+	rewardWatchlist *watchlist.Watchlist,
+	stateAttester *attestation.Attester,
+	maxProcessingBlocks int,
 ) Manager {
 	lastAccepted := s.GetLastAccepted()
 	backend := &backend{
@@ -60,6 +86,7 @@ func NewManager(
 		state:        s,
 		ctx:          txExecutorBackend.Ctx,
 		blkIDToState: map[ids.ID]*blockState{},
+		checkpoint:   checkpoint,
 	}
 
 	return &manager{
@@ -69,17 +96,27 @@ func NewManager(
 			txExecutorBackend: txExecutorBackend,
 		},
 		acceptor: &acceptor{
-			backend:      backend,
-			metrics:      metrics,
-			validators:   validatorManager,
-			bootstrapped: txExecutorBackend.Bootstrapped,
+			backend:                 backend,
+			metrics:                 metrics,
+			validators:              validatorManager,
+			bootstrapped:            txExecutorBackend.Bootstrapped,
+			bootstrapCommitInterval: bootstrapCommitInterval,
+			rewardWatchlist:         rewardWatchlist,
+			stateAttester:           stateAttester,
+			invariantChecks:         invariantChecks,
+			primaryUptimePercentage: txExecutorBackend.Config.UptimePercentage,
+			trackedSubnets:          txExecutorBackend.Config.TrackedSubnets,
+			uptimes:                 txExecutorBackend.Uptimes,
 		},
 		rejector: &rejector{
 			backend:         backend,
 			addTxsToMempool: !txExecutorBackend.Config.PartialSyncPrimaryNetwork,
 		},
-		preferred:         lastAccepted,
-		txExecutorBackend: txExecutorBackend,
+		preferred:           lastAccepted,
+		txExecutorBackend:   txExecutorBackend,
+		metrics:             metrics,
+		shadowExecution:     shadowExecution,
+		maxProcessingBlocks: maxProcessingBlocks,
 	}
 }
 
@@ -91,6 +128,16 @@ type manager struct {
 
 	preferred         ids.ID
 	txExecutorBackend *executor.Backend
+	metrics           metrics.Metrics
+
+	// If true, the verification of every block is also run under the rules of
+	// the next scheduled network upgrade.
+	shadowExecution bool
+
+	// Max number of verified blocks held in memory. Blocks that would exceed
+	// it fail verification until processing blocks are decided. If 0, the
+	// number of processing blocks is unbounded.
+	maxProcessingBlocks int
 }
 
 func (m *manager) GetBlock(blkID ids.ID) (snowman.Block, error) {
@@ -105,6 +152,21 @@ func (m *manager) GetStatelessBlock(blkID ids.ID) (block.Block, error) {
 	return m.backend.GetBlock(blkID)
 }
 
+func (m *manager) ParseBlock(blkBytes []byte) (snowman.Block, error) {
+	blkID := hashing.ComputeHash256Array(blkBytes)
+	if blkState, ok := m.blkIDToState[blkID]; ok {
+		return m.NewBlock(blkState.statelessBlock), nil
+	}
+
+	// Note: blocks to be parsed are not verified, so we must used blocks.Codec
+	// rather than blocks.GenesisCodec
+	statelessBlk, err := block.Parse(block.Codec, blkBytes)
+	if err != nil {
+		return nil, err
+	}
+	return m.NewBlock(statelessBlk), nil
+}
+
 func (m *manager) NewBlock(blk block.Block) snowman.Block {
 	return &Block{
 		manager: m,
@@ -127,6 +189,13 @@ func (m *manager) VerifyTx(tx *txs.Tx) error {
 		return ErrChainNotSynced
 	}
 
+	// Conflicts with transactions in processing blocks are checked first, as
+	// they would otherwise be reported as missing UTXOs or not be reported at
+	// all if the conflicting block isn't preferred.
+	if err := m.verifyNoProcessingConflicts(tx.ID(), tx.Unsigned.InputIDs()); err != nil {
+		return err
+	}
+
 	stateDiff, err := state.NewDiff(m.preferred, m)
 	if err != nil {
 		return err
@@ -142,7 +211,7 @@ func (m *manager) VerifyTx(tx *txs.Tx) error {
 		return err
 	}
 
-	err = tx.Unsigned.Visit(&executor.StandardTxExecutor{
+	err = m.txExecutorBackend.Execute(stateDiff, tx, &executor.StandardTxExecutor{
 		Backend: m.txExecutorBackend,
 		State:   stateDiff,
 		Tx:      tx,
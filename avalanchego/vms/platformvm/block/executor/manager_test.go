@@ -4,15 +4,23 @@
 package executor
 
 import (
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
 func TestGetBlock(t *testing.T) {
@@ -61,6 +69,46 @@ func TestGetBlock(t *testing.T) {
 	}
 }
 
+func TestManagerParseBlock(t *testing.T) {
+	require := require.New(t)
+
+	statelessBlk, err := block.NewBanffCommitBlock(time.Unix(1, 0), ids.GenerateTestID() /*parent*/, 2 /*height*/)
+	require.NoError(err)
+	manager := &manager{
+		backend: &backend{
+			blkIDToState: map[ids.ID]*blockState{},
+		},
+	}
+
+	{
+		// Case: block isn't in memory
+		blkBytes := slices.Clone(statelessBlk.Bytes())
+		gotBlk, err := manager.ParseBlock(blkBytes)
+		require.NoError(err)
+		require.Equal(statelessBlk.ID(), gotBlk.ID())
+		require.IsType(&Block{}, gotBlk)
+		innerBlk := gotBlk.(*Block)
+		require.NotSame(statelessBlk, innerBlk.Block)
+		require.Equal(blkBytes, innerBlk.Bytes())
+	}
+	{
+		// Case: block is in memory, so its bytes are reused
+		manager.backend.blkIDToState[statelessBlk.ID()] = &blockState{
+			statelessBlock: statelessBlk,
+		}
+		gotBlk, err := manager.ParseBlock(slices.Clone(statelessBlk.Bytes()))
+		require.NoError(err)
+		require.IsType(&Block{}, gotBlk)
+		innerBlk := gotBlk.(*Block)
+		require.Same(statelessBlk, innerBlk.Block)
+	}
+	{
+		// Case: invalid bytes
+		_, err := manager.ParseBlock([]byte{1})
+		require.ErrorIs(err, codec.ErrCantUnpackVersion)
+	}
+}
+
 func TestManagerLastAccepted(t *testing.T) {
 	lastAcceptedID := ids.GenerateTestID()
 	manager := &manager{
@@ -86,3 +134,64 @@ func TestManagerSetPreference(t *testing.T) {
 	require.False(manager.SetPreference(newPreference))
 	require.True(manager.SetPreference(initialPreference))
 }
+
+func TestManagerVerifyTxProcessingConflict(t *testing.T) {
+	require := require.New(t)
+
+	newTx := func(utxoID avax.UTXOID, memo string) *txs.Tx {
+		tx := &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{
+			Ins: []*avax.TransferableInput{{
+				UTXOID: utxoID,
+				Asset:  avax.Asset{ID: ids.GenerateTestID()},
+				In: &secp256k1fx.TransferInput{
+					Amt: 1,
+				},
+			}},
+			Memo: []byte(memo),
+		}}}
+		require.NoError(tx.Initialize(txs.Codec))
+		return tx
+	}
+
+	var (
+		utxoID = avax.UTXOID{
+			TxID:        ids.GenerateTestID(),
+			OutputIndex: 1,
+		}
+		processingTx  = newTx(utxoID, "processing")
+		conflictingTx = newTx(utxoID, "conflicting")
+	)
+
+	processingBlk, err := block.NewBanffStandardBlock(
+		time.Unix(0, 0),
+		ids.GenerateTestID(),
+		1,
+		[]*txs.Tx{processingTx},
+	)
+	require.NoError(err)
+
+	bootstrapped := &utils.Atomic[bool]{}
+	bootstrapped.Set(true)
+	manager := &manager{
+		backend: &backend{
+			blkIDToState: map[ids.ID]*blockState{
+				processingBlk.ID(): {
+					statelessBlock: processingBlk,
+				},
+			},
+		},
+		txExecutorBackend: &executor.Backend{
+			Bootstrapped: bootstrapped,
+		},
+	}
+
+	err = manager.VerifyTx(conflictingTx)
+	require.ErrorIs(err, ErrConflictingProcessingTx)
+
+	// A tx doesn't conflict with itself.
+	require.NoError(manager.verifyNoProcessingConflicts(processingTx.ID(), processingTx.Unsigned.InputIDs()))
+
+	// Once the block is accepted, its inputs are consumed on disk.
+	manager.lastAccepted = processingBlk.ID()
+	require.NoError(manager.verifyNoProcessingConflicts(conflictingTx.ID(), conflictingTx.Unsigned.InputIDs()))
+}
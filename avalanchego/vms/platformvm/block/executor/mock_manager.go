@@ -117,6 +117,21 @@ func (mr *MockManagerMockRecorder) NewBlock(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewBlock", reflect.TypeOf((*MockManager)(nil).NewBlock), arg0)
 }
 
+// ParseBlock mocks base method.
+func (m *MockManager) ParseBlock(arg0 []byte) (snowman.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseBlock", arg0)
+	ret0, _ := ret[0].(snowman.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseBlock indicates an expected call of ParseBlock.
+func (mr *MockManagerMockRecorder) ParseBlock(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseBlock", reflect.TypeOf((*MockManager)(nil).ParseBlock), arg0)
+}
+
 // Preferred mocks base method.
 func (m *MockManager) Preferred() ids.ID {
 	m.ctrl.T.Helper()
@@ -131,6 +146,20 @@ func (mr *MockManagerMockRecorder) Preferred() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preferred", reflect.TypeOf((*MockManager)(nil).Preferred))
 }
 
+// ProcessingChildren mocks base method.
+func (m *MockManager) ProcessingChildren(blkID ids.ID) []ids.ID {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessingChildren", blkID)
+	ret0, _ := ret[0].([]ids.ID)
+	return ret0
+}
+
+// ProcessingChildren indicates an expected call of ProcessingChildren.
+func (mr *MockManagerMockRecorder) ProcessingChildren(blkID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessingChildren", reflect.TypeOf((*MockManager)(nil).ProcessingChildren), blkID)
+}
+
 // SetPreference mocks base method.
 func (m *MockManager) SetPreference(blkID ids.ID) bool {
 	m.ctrl.T.Helper()
@@ -145,6 +174,20 @@ func (mr *MockManagerMockRecorder) SetPreference(blkID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPreference", reflect.TypeOf((*MockManager)(nil).SetPreference), blkID)
 }
 
+// TraceVerify mocks base method.
+func (m *MockManager) TraceVerify(blk block.Block) *Trace {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TraceVerify", blk)
+	ret0, _ := ret[0].(*Trace)
+	return ret0
+}
+
+// TraceVerify indicates an expected call of TraceVerify.
+func (mr *MockManagerMockRecorder) TraceVerify(blk any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TraceVerify", reflect.TypeOf((*MockManager)(nil).TraceVerify), blk)
+}
+
 // VerifyTx mocks base method.
 func (m *MockManager) VerifyTx(tx *txs.Tx) error {
 	m.ctrl.T.Helper()
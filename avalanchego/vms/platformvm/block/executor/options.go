@@ -9,10 +9,12 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
@@ -27,6 +29,7 @@ var (
 	errFailedFetchingStakerTx             = errors.New("failed fetching staker transaction")
 	errUnexpectedStakerTxType             = errors.New("unexpected staker transaction type")
 	errFailedFetchingPrimaryStaker        = errors.New("failed fetching primary staker")
+	errFailedFetchingSubnetStaker         = errors.New("failed fetching subnet staker")
 	errFailedFetchingSubnetTransformation = errors.New("failed fetching subnet transformation")
 	errFailedCalculatingUptime            = errors.New("failed calculating uptime")
 )
@@ -36,8 +39,12 @@ type options struct {
 	// inputs populated before calling this struct's methods:
 	log                     logging.Logger
 	primaryUptimePercentage float64
-	uptimes                 uptime.Calculator
-	state                   state.Chain
+	// trackedSubnets are the subnets whose uptimes are being measured by this
+	// node. Subnet stakers of other subnets are judged on their primary
+	// network uptime.
+	trackedSubnets set.Set[ids.ID]
+	uptimes        uptime.Calculator
+	state          state.Chain
 
 	// outputs populated by this struct's methods:
 	preferredBlock block.Block
@@ -156,34 +163,69 @@ func (o *options) prefersCommit(tx *txs.Tx) (bool, error) {
 		return false, fmt.Errorf("%w: %T", errUnexpectedStakerTxType, stakerTx.Unsigned)
 	}
 
+	uptime, expectedUptimePercentage, err := o.stakerUptime(unsignedTx.TxID, staker)
+	if err != nil {
+		return false, err
+	}
+	return uptime >= expectedUptimePercentage, nil
+}
+
+// stakerUptime returns the uptime this node measured for [staker], which was
+// added by [stakerTxID], along with the uptime [staker] must have to be
+// rewarded. [staker] must be current.
+func (o *options) stakerUptime(stakerTxID ids.ID, staker txs.Staker) (float64, float64, error) {
 	nodeID := staker.NodeID()
+	if staker.SubnetID() == constants.PrimaryNetworkID {
+		// Primary network stakers may have been re-keyed to another nodeID.
+		currentStaker, err := getCurrentStaker(o.state, stakerTxID, staker)
+		if err != nil {
+			return 0, 0, fmt.Errorf("%w: %w", errFailedFetchingPrimaryStaker, err)
+		}
+		nodeID = currentStaker.NodeID
+	}
+
 	primaryNetworkValidator, err := o.state.GetCurrentValidator(
 		constants.PrimaryNetworkID,
 		nodeID,
 	)
 	if err != nil {
-		return false, fmt.Errorf("%w: %w", errFailedFetchingPrimaryStaker, err)
+		return 0, 0, fmt.Errorf("%w: %w", errFailedFetchingPrimaryStaker, err)
 	}
 
-	expectedUptimePercentage := o.primaryUptimePercentage
+	var (
+		expectedUptimePercentage = o.primaryUptimePercentage
+		uptimeSubnetID           = constants.PrimaryNetworkID
+		uptimeStartTime          = primaryNetworkValidator.StartTime
+	)
 	if subnetID := staker.SubnetID(); subnetID != constants.PrimaryNetworkID {
+		// Each permissionless subnet specifies its own uptime requirement when
+		// it is transformed.
 		transformSubnet, err := executor.GetTransformSubnetTx(o.state, subnetID)
 		if err != nil {
-			return false, fmt.Errorf("%w: %w", errFailedFetchingSubnetTransformation, err)
+			return 0, 0, fmt.Errorf("%w: %w", errFailedFetchingSubnetTransformation, err)
 		}
 
 		expectedUptimePercentage = float64(transformSubnet.UptimeRequirement) / reward.PercentDenominator
+
+		// Uptimes are only measured for the subnets this node tracks.
+		if o.trackedSubnets.Contains(subnetID) {
+			subnetValidator, err := o.state.GetCurrentValidator(subnetID, nodeID)
+			if err != nil {
+				return 0, 0, fmt.Errorf("%w: %w", errFailedFetchingSubnetStaker, err)
+			}
+
+			uptimeSubnetID = subnetID
+			uptimeStartTime = subnetValidator.StartTime
+		}
 	}
 
-	// TODO: calculate subnet uptimes
 	uptime, err := o.uptimes.CalculateUptimePercentFrom(
 		nodeID,
-		constants.PrimaryNetworkID,
-		primaryNetworkValidator.StartTime,
+		uptimeSubnetID,
+		uptimeStartTime,
 	)
 	if err != nil {
-		return false, fmt.Errorf("%w: %w", errFailedCalculatingUptime, err)
+		return 0, 0, fmt.Errorf("%w: %w", errFailedCalculatingUptime, err)
 	}
-
-	return uptime >= expectedUptimePercentage, nil
+	return uptime, expectedUptimePercentage, nil
 }
@@ -215,6 +215,7 @@ func TestBanffProposalBlockTimeVerification(t *testing.T) {
 	pendingStakersIt.EXPECT().Next().Return(false).AnyTimes() // no pending stakers
 	pendingStakersIt.EXPECT().Release().AnyTimes()
 	onParentAccept.EXPECT().GetPendingStakerIterator().Return(pendingStakersIt, nil).AnyTimes()
+	onParentAccept.EXPECT().GetPendingStakerIteratorUntil(gomock.Any()).Return(pendingStakersIt, nil).AnyTimes()
 
 	env.mockedState.EXPECT().GetUptime(gomock.Any(), gomock.Any()).Return(
 		time.Microsecond, /*upDuration*/
@@ -558,7 +559,7 @@ func TestBanffProposalBlockUpdateStakers(t *testing.T) {
 					staker.nodeID,
 					staker.rewardAddress,
 					reward.PercentDenominator,
-					[]*secp256k1.PrivateKey{preFundedKeys[0]},
+					secp256k1fx.NewKeychain(preFundedKeys[0]),
 					ids.ShortEmpty,
 					nil,
 				)
@@ -582,7 +583,7 @@ func TestBanffProposalBlockUpdateStakers(t *testing.T) {
 					uint64(subStaker.endTime.Unix()),
 					subStaker.nodeID, // validator ID
 					subnetID,         // Subnet ID
-					[]*secp256k1.PrivateKey{preFundedKeys[0], preFundedKeys[1]},
+					secp256k1fx.NewKeychain(preFundedKeys[0], preFundedKeys[1]),
 					ids.ShortEmpty,
 					nil,
 				)
@@ -612,7 +613,7 @@ func TestBanffProposalBlockUpdateStakers(t *testing.T) {
 					staker0.nodeID,
 					staker0.rewardAddress,
 					reward.PercentDenominator,
-					[]*secp256k1.PrivateKey{preFundedKeys[0], preFundedKeys[1]},
+					secp256k1fx.NewKeychain(preFundedKeys[0], preFundedKeys[1]),
 					ids.ShortEmpty,
 					nil,
 				)
@@ -712,7 +713,7 @@ func TestBanffProposalBlockRemoveSubnetValidator(t *testing.T) {
 		uint64(subnetVdr1EndTime.Unix()),   // end time
 		subnetValidatorNodeID,              // Node ID
 		subnetID,                           // Subnet ID
-		[]*secp256k1.PrivateKey{preFundedKeys[0], preFundedKeys[1]},
+		secp256k1fx.NewKeychain(preFundedKeys[0], preFundedKeys[1]),
 		ids.ShortEmpty,
 		nil,
 	)
@@ -741,7 +742,7 @@ func TestBanffProposalBlockRemoveSubnetValidator(t *testing.T) {
 		uint64(subnetVdr1EndTime.Add(time.Second).Add(defaultMinStakingDuration).Unix()), // end time
 		subnetVdr2NodeID, // Node ID
 		subnetID,         // Subnet ID
-		[]*secp256k1.PrivateKey{preFundedKeys[0], preFundedKeys[1]},
+		secp256k1fx.NewKeychain(preFundedKeys[0], preFundedKeys[1]),
 		ids.ShortEmpty,
 		nil,
 	)
@@ -773,7 +774,7 @@ func TestBanffProposalBlockRemoveSubnetValidator(t *testing.T) {
 		ids.GenerateTestNodeID(),
 		ids.GenerateTestShortID(),
 		reward.PercentDenominator,
-		[]*secp256k1.PrivateKey{preFundedKeys[0], preFundedKeys[1]},
+		secp256k1fx.NewKeychain(preFundedKeys[0], preFundedKeys[1]),
 		ids.ShortEmpty,
 		nil,
 	)
@@ -856,7 +857,7 @@ func TestBanffProposalBlockTrackedSubnet(t *testing.T) {
 				uint64(subnetVdr1EndTime.Unix()),   // end time
 				subnetValidatorNodeID,              // Node ID
 				subnetID,                           // Subnet ID
-				[]*secp256k1.PrivateKey{preFundedKeys[0], preFundedKeys[1]},
+				secp256k1fx.NewKeychain(preFundedKeys[0], preFundedKeys[1]),
 				ids.ShortEmpty,
 				nil,
 			)
@@ -886,7 +887,7 @@ func TestBanffProposalBlockTrackedSubnet(t *testing.T) {
 				ids.GenerateTestNodeID(),
 				ids.GenerateTestShortID(),
 				reward.PercentDenominator,
-				[]*secp256k1.PrivateKey{preFundedKeys[0], preFundedKeys[1]},
+				secp256k1fx.NewKeychain(preFundedKeys[0], preFundedKeys[1]),
 				ids.ShortEmpty,
 				nil,
 			)
@@ -972,7 +973,7 @@ func TestBanffProposalBlockDelegatorStakerWeight(t *testing.T) {
 		ids.GenerateTestNodeID(),
 		ids.GenerateTestShortID(),
 		reward.PercentDenominator,
-		[]*secp256k1.PrivateKey{preFundedKeys[0], preFundedKeys[1]},
+		secp256k1fx.NewKeychain(preFundedKeys[0], preFundedKeys[1]),
 		ids.ShortEmpty,
 		nil,
 	)
@@ -1037,11 +1038,11 @@ func TestBanffProposalBlockDelegatorStakerWeight(t *testing.T) {
 		uint64(pendingDelegatorEndTime.Unix()),
 		nodeID,
 		preFundedKeys[0].PublicKey().Address(),
-		[]*secp256k1.PrivateKey{
+		secp256k1fx.NewKeychain(
 			preFundedKeys[0],
 			preFundedKeys[1],
 			preFundedKeys[4],
-		},
+		),
 		ids.ShortEmpty,
 		nil,
 	)
@@ -1068,7 +1069,7 @@ func TestBanffProposalBlockDelegatorStakerWeight(t *testing.T) {
 		ids.GenerateTestNodeID(),
 		ids.GenerateTestShortID(),
 		reward.PercentDenominator,
-		[]*secp256k1.PrivateKey{preFundedKeys[0], preFundedKeys[1]},
+		secp256k1fx.NewKeychain(preFundedKeys[0], preFundedKeys[1]),
 		ids.ShortEmpty,
 		nil,
 	)
@@ -1158,7 +1159,7 @@ func TestBanffProposalBlockDelegatorStakers(t *testing.T) {
 		ids.GenerateTestNodeID(),
 		ids.GenerateTestShortID(),
 		reward.PercentDenominator,
-		[]*secp256k1.PrivateKey{preFundedKeys[0], preFundedKeys[1]},
+		secp256k1fx.NewKeychain(preFundedKeys[0], preFundedKeys[1]),
 		ids.ShortEmpty,
 		nil,
 	)
@@ -1222,11 +1223,11 @@ func TestBanffProposalBlockDelegatorStakers(t *testing.T) {
 		uint64(pendingDelegatorEndTime.Unix()),
 		nodeID,
 		preFundedKeys[0].PublicKey().Address(),
-		[]*secp256k1.PrivateKey{
+		secp256k1fx.NewKeychain(
 			preFundedKeys[0],
 			preFundedKeys[1],
 			preFundedKeys[4],
-		},
+		),
 		ids.ShortEmpty,
 		nil,
 	)
@@ -1253,7 +1254,7 @@ func TestBanffProposalBlockDelegatorStakers(t *testing.T) {
 		ids.GenerateTestNodeID(),
 		ids.GenerateTestShortID(),
 		reward.PercentDenominator,
-		[]*secp256k1.PrivateKey{preFundedKeys[0], preFundedKeys[1]},
+		secp256k1fx.NewKeychain(preFundedKeys[0], preFundedKeys[1]),
 		ids.ShortEmpty,
 		nil,
 	)
@@ -1333,11 +1334,11 @@ func TestAddValidatorProposalBlock(t *testing.T) {
 		signer.NewProofOfPossession(sk),
 		preFundedKeys[0].PublicKey().Address(),
 		10000,
-		[]*secp256k1.PrivateKey{
+		secp256k1fx.NewKeychain(
 			preFundedKeys[0],
 			preFundedKeys[1],
 			preFundedKeys[4],
-		},
+		),
 		ids.ShortEmpty,
 		nil,
 	)
@@ -1409,11 +1410,11 @@ func TestAddValidatorProposalBlock(t *testing.T) {
 		signer.NewProofOfPossession(sk),
 		preFundedKeys[0].PublicKey().Address(),
 		10000,
-		[]*secp256k1.PrivateKey{
+		secp256k1fx.NewKeychain(
 			preFundedKeys[0],
 			preFundedKeys[1],
 			preFundedKeys[4],
-		},
+		),
 		ids.ShortEmpty,
 		nil,
 	)
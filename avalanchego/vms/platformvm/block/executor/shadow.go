@@ -0,0 +1,42 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"slices"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+)
+
+// shadowVerify re-runs the verification of [blk] as if the next scheduled
+// network upgrade was activated at the time of its parent, and reports a
+// divergence if the outcome differs from [err], the outcome of the
+// verification under the current rules. The state produced by the re-run is
+// discarded.
+func (m *manager) shadowVerify(blk block.Block, err error) {
+	parentState, ok := m.GetState(blk.Parent())
+	if !ok {
+		return
+	}
+	fork, nextForkConfig, ok := m.txExecutorBackend.Config.ActivateNextFork(parentState.GetTimestamp())
+	if !ok {
+		return
+	}
+
+	trace := m.traceVerify(blk, nextForkConfig)
+	if (err == nil) == (trace.Err == nil) && slices.Equal(validationRules(err), trace.Rules) {
+		return
+	}
+
+	m.metrics.IncShadowExecutionDivergences(fork)
+	m.ctx.Log.Warn("block execution diverges under the next network upgrade",
+		zap.String("fork", fork),
+		zap.Stringer("blkID", blk.ID()),
+		zap.Uint64("height", blk.Height()),
+		zap.NamedError("currentErr", err),
+		zap.NamedError("nextForkErr", trace.Err),
+	)
+}
@@ -0,0 +1,91 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+type shadowExecutionMetrics struct {
+	metrics.Metrics
+	divergences []string
+}
+
+func (m *shadowExecutionMetrics) IncShadowExecutionDivergences(fork string) {
+	m.divergences = append(m.divergences, fork)
+}
+
+func TestShadowVerify(t *testing.T) {
+	tests := []struct {
+		name                string
+		memo                []byte
+		expectedDivergences []string
+	}{
+		{
+			name: "same outcome",
+		},
+		{
+			// Memos are disallowed once Durango activates
+			name:                "diverges under the next fork",
+			memo:                []byte("memo"),
+			expectedDivergences: []string{"durango"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			env := newEnvironment(t, nil, cortina)
+			// Durango must be the next scheduled fork
+			env.config.ApricotPhase3Time = time.Time{}
+			env.config.ApricotPhase5Time = time.Time{}
+
+			m := env.blkManager.(*manager)
+			m.shadowExecution = true
+			shadowMetrics := &shadowExecutionMetrics{
+				Metrics: metrics.Noop,
+			}
+			m.metrics = shadowMetrics
+
+			tx, err := env.txBuilder.NewCreateSubnetTx(
+				1, // threshold
+				[]ids.ShortID{preFundedKeys[0].Address()},
+				secp256k1fx.NewKeychain(preFundedKeys[0]),
+				preFundedKeys[0].Address(), // change addr
+				test.memo,
+			)
+			require.NoError(err)
+
+			lastAcceptedID := env.state.GetLastAccepted()
+			lastAccepted, err := env.state.GetStatelessBlock(lastAcceptedID)
+			require.NoError(err)
+			statelessBlk, err := block.NewBanffStandardBlock(
+				env.state.GetTimestamp(),
+				lastAcceptedID,
+				lastAccepted.Height()+1,
+				[]*txs.Tx{tx},
+			)
+			require.NoError(err)
+
+			blk := env.blkManager.NewBlock(statelessBlk)
+			require.NoError(blk.Verify(context.Background()))
+			require.Equal(test.expectedDivergences, shadowMetrics.divergences)
+
+			// The shadow execution must not replace the state of the block
+			blkState, ok := m.blkIDToState[blk.ID()]
+			require.True(ok)
+			_, _, err = blkState.onAcceptState.GetTx(tx.ID())
+			require.NoError(err)
+		})
+	}
+}
@@ -131,6 +131,7 @@ func TestBanffStandardBlockTimeVerification(t *testing.T) {
 	pendingIt.EXPECT().Next().Return(false).AnyTimes()
 	pendingIt.EXPECT().Release().Return().AnyTimes()
 	onParentAccept.EXPECT().GetPendingStakerIterator().Return(pendingIt, nil).AnyTimes()
+	onParentAccept.EXPECT().GetPendingStakerIteratorUntil(gomock.Any()).Return(pendingIt, nil).AnyTimes()
 
 	onParentAccept.EXPECT().GetTimestamp().Return(chainTime).AnyTimes()
 
@@ -514,7 +515,7 @@ func TestBanffStandardBlockUpdateStakers(t *testing.T) {
 					uint64(staker.endTime.Unix()),
 					staker.nodeID, // validator ID
 					subnetID,      // Subnet ID
-					[]*secp256k1.PrivateKey{preFundedKeys[0], preFundedKeys[1]},
+					secp256k1fx.NewKeychain(preFundedKeys[0], preFundedKeys[1]),
 					ids.ShortEmpty,
 					nil,
 				)
@@ -604,7 +605,7 @@ func TestBanffStandardBlockRemoveSubnetValidator(t *testing.T) {
 		uint64(subnetVdr1EndTime.Unix()),   // end time
 		subnetValidatorNodeID,              // Node ID
 		subnetID,                           // Subnet ID
-		[]*secp256k1.PrivateKey{preFundedKeys[0], preFundedKeys[1]},
+		secp256k1fx.NewKeychain(preFundedKeys[0], preFundedKeys[1]),
 		ids.ShortEmpty,
 		nil,
 	)
@@ -633,7 +634,7 @@ func TestBanffStandardBlockRemoveSubnetValidator(t *testing.T) {
 		uint64(subnetVdr1EndTime.Add(time.Second).Add(defaultMinStakingDuration).Unix()), // end time
 		subnetVdr2NodeID, // Node ID
 		subnetID,         // Subnet ID
-		[]*secp256k1.PrivateKey{preFundedKeys[0], preFundedKeys[1]},
+		secp256k1fx.NewKeychain(preFundedKeys[0], preFundedKeys[1]),
 		ids.ShortEmpty,
 		nil,
 	)
@@ -703,7 +704,7 @@ func TestBanffStandardBlockTrackedSubnet(t *testing.T) {
 				uint64(subnetVdr1EndTime.Unix()),   // end time
 				subnetValidatorNodeID,              // Node ID
 				subnetID,                           // Subnet ID
-				[]*secp256k1.PrivateKey{preFundedKeys[0], preFundedKeys[1]},
+				secp256k1fx.NewKeychain(preFundedKeys[0], preFundedKeys[1]),
 				ids.ShortEmpty,
 				nil,
 			)
@@ -794,11 +795,11 @@ func TestBanffStandardBlockDelegatorStakerWeight(t *testing.T) {
 		uint64(pendingDelegatorEndTime.Unix()),
 		nodeID,
 		preFundedKeys[0].PublicKey().Address(),
-		[]*secp256k1.PrivateKey{
+		secp256k1fx.NewKeychain(
 			preFundedKeys[0],
 			preFundedKeys[1],
 			preFundedKeys[4],
-		},
+		),
 		ids.ShortEmpty,
 		nil,
 	)
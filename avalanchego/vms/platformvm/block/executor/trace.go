@@ -0,0 +1,128 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"maps"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/mempool"
+)
+
+// Scopes reported in the accesses of a Trace.
+const (
+	parentStateScope     = "parent"
+	onAcceptStateScope   = "onAccept"
+	onDecisionStateScope = "onDecision"
+	onCommitStateScope   = "onCommit"
+	onAbortStateScope    = "onAbort"
+)
+
+var _ mempool.Mempool = (*tracedMempool)(nil)
+
+// Trace records the state accesses and the decisions made while verifying a
+// block. Two versions of the node that verify a block identically produce the
+// same trace.
+type Trace struct {
+	BlockID ids.ID
+	// Accesses are ordered by the time they were performed.
+	Accesses []state.Access
+	// ForkChecks are the checks of the network upgrades that gated the
+	// branches taken, ordered by the time they were performed.
+	ForkChecks []config.ForkCheck
+	// FailedTxID is the ID of the tx that failed execution, if any.
+	FailedTxID ids.ID
+	// Err is the error returned by the verification, if any.
+	Err error
+	// Rules are the validation rules that [Err] reports as violated, in the
+	// order they are wrapped.
+	Rules []string
+}
+
+func (t *Trace) record(access state.Access) {
+	t.Accesses = append(t.Accesses, access)
+}
+
+func (t *Trace) recordForkCheck(check config.ForkCheck) {
+	t.ForkChecks = append(t.ForkChecks, check)
+}
+
+// validationRules returns the messages of the errors wrapped by [err] that
+// don't wrap other errors. Verification errors are built by wrapping sentinel
+// errors, each of which describes a validation rule.
+func validationRules(err error) []string {
+	switch wrapped := err.(type) {
+	case nil:
+		return nil
+	case interface{ Unwrap() error }:
+		if inner := wrapped.Unwrap(); inner != nil {
+			return validationRules(inner)
+		}
+	case interface{ Unwrap() []error }:
+		var rules []string
+		for _, inner := range wrapped.Unwrap() {
+			rules = append(rules, validationRules(inner)...)
+		}
+		return rules
+	}
+	return []string{err.Error()}
+}
+
+// tracedMempool drops all the modifications performed by the verifier so that
+// tracing a block doesn't have side effects.
+type tracedMempool struct {
+	mempool.Mempool
+	trace *Trace
+}
+
+func (*tracedMempool) Remove(...*txs.Tx) {}
+
+func (m *tracedMempool) MarkDropped(txID ids.ID, _ error) {
+	m.trace.FailedTxID = txID
+}
+
+// TraceVerify re-runs the verification of [blk] while recording every state
+// access. Unlike Verify, the processing blocks and the mempool are left
+// untouched.
+func (m *manager) TraceVerify(blk block.Block) *Trace {
+	return m.traceVerify(blk, m.txExecutorBackend.Config)
+}
+
+// traceVerify re-runs the verification of [blk] under the rules of [cfg].
+func (m *manager) traceVerify(blk block.Block, cfg *config.Config) *Trace {
+	trace := &Trace{
+		BlockID: blk.ID(),
+	}
+	// The verifier populates [blkIDToState], so it operates on a copy to
+	// avoid modifying the processing blocks.
+	backend := &backend{
+		Mempool: &tracedMempool{
+			Mempool: m.Mempool,
+			trace:   trace,
+		},
+		lastAccepted: m.lastAccepted,
+		blkIDToState: maps.Clone(m.blkIDToState),
+		state:        m.state,
+		ctx:          m.ctx,
+		checkpoint:   m.checkpoint,
+		trace:        trace,
+	}
+	// The fork checks are observed through a copy of the config so that the
+	// concurrent verification of other blocks isn't traced.
+	txExecutorConfig := *cfg
+	txExecutorConfig.ForkObserver = trace.recordForkCheck
+	txExecutorBackend := *m.txExecutorBackend
+	txExecutorBackend.Config = &txExecutorConfig
+
+	trace.Err = blk.Visit(&verifier{
+		backend:           backend,
+		txExecutorBackend: &txExecutorBackend,
+	})
+	trace.Rules = validationRules(trace.Err)
+	return trace
+}
@@ -15,12 +15,14 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+	"github.com/ava-labs/avalanchego/vms/platformvm/utxo"
 )
 
 var (
 	_ block.Visitor = (*verifier)(nil)
 
-	ErrConflictingBlockTxs = errors.New("block contains conflicting transactions")
+	ErrConflictingBlockTxs     = errors.New("block contains conflicting transactions")
+	ErrBlockComplexityExceeded = errors.New("block complexity exceeds the max")
 
 	errApricotBlockIssuedAfterFork                = errors.New("apricot block issued after fork")
 	errBanffProposalBlockWithMultipleTransactions = errors.New("BanffProposalBlock contains multiple transactions")
@@ -61,7 +63,7 @@ func (v *verifier) BanffProposalBlock(b *block.BanffProposalBlock) error {
 	}
 
 	parentID := b.Parent()
-	onDecisionState, err := state.NewDiff(parentID, v.backend)
+	onDecisionState, err := v.newDiff(parentID, onDecisionStateScope)
 	if err != nil {
 		return err
 	}
@@ -71,17 +73,17 @@ func (v *verifier) BanffProposalBlock(b *block.BanffProposalBlock) error {
 		return err
 	}
 
-	inputs, atomicRequests, onAcceptFunc, err := v.processStandardTxs(b.Transactions, onDecisionState, b.Parent())
+	inputs, atomicRequests, onAcceptFunc, err := v.processStandardTxs(v.txBackend(b.Height()), b.Transactions, onDecisionState, b.Parent())
 	if err != nil {
 		return err
 	}
 
-	onCommitState, err := state.NewDiffOn(onDecisionState)
+	onCommitState, err := v.newDiffOn(onDecisionState, onCommitStateScope)
 	if err != nil {
 		return err
 	}
 
-	onAbortState, err := state.NewDiffOn(onDecisionState)
+	onAbortState, err := v.newDiffOn(onDecisionState, onAbortStateScope)
 	if err != nil {
 		return err
 	}
@@ -103,7 +105,7 @@ func (v *verifier) BanffStandardBlock(b *block.BanffStandardBlock) error {
 	}
 
 	parentID := b.Parent()
-	onAcceptState, err := state.NewDiff(parentID, v.backend)
+	onAcceptState, err := v.newDiff(parentID, onAcceptStateScope)
 	if err != nil {
 		return err
 	}
@@ -147,11 +149,11 @@ func (v *verifier) ApricotProposalBlock(b *block.ApricotProposalBlock) error {
 	}
 
 	parentID := b.Parent()
-	onCommitState, err := state.NewDiff(parentID, v.backend)
+	onCommitState, err := v.newDiff(parentID, onCommitStateScope)
 	if err != nil {
 		return err
 	}
-	onAbortState, err := state.NewDiff(parentID, v.backend)
+	onAbortState, err := v.newDiff(parentID, onAbortStateScope)
 	if err != nil {
 		return err
 	}
@@ -165,7 +167,7 @@ func (v *verifier) ApricotStandardBlock(b *block.ApricotStandardBlock) error {
 	}
 
 	parentID := b.Parent()
-	onAcceptState, err := state.NewDiff(parentID, v)
+	onAcceptState, err := v.newDiff(parentID, onAcceptStateScope)
 	if err != nil {
 		return err
 	}
@@ -193,7 +195,7 @@ func (v *verifier) ApricotAtomicBlock(b *block.ApricotAtomicBlock) error {
 	}
 
 	atomicExecutor := executor.AtomicTxExecutor{
-		Backend:       v.txExecutorBackend,
+		Backend:       v.txBackend(b.Height()),
 		ParentID:      parentID,
 		StateVersions: v,
 		Tx:            b.Tx,
@@ -226,6 +228,25 @@ func (v *verifier) ApricotAtomicBlock(b *block.ApricotAtomicBlock) error {
 	return nil
 }
 
+// newDiff returns a diff on top of the state of [parentID]. If the verification
+// is being traced, the accesses to the diff are recorded under [scope].
+func (v *verifier) newDiff(parentID ids.ID, scope string) (state.Diff, error) {
+	diff, err := state.NewDiff(parentID, v.backend)
+	if err != nil || v.trace == nil {
+		return diff, err
+	}
+	return state.NewTracedDiff(diff, scope, v.trace.record), nil
+}
+
+// newDiffOn is the same as newDiff but builds the diff on top of [parent].
+func (v *verifier) newDiffOn(parent state.Chain, scope string) (state.Diff, error) {
+	diff, err := state.NewDiffOn(parent)
+	if err != nil || v.trace == nil {
+		return diff, err
+	}
+	return state.NewTracedDiff(diff, scope, v.trace.record), nil
+}
+
 func (v *verifier) banffOptionBlock(b block.BanffBlock) error {
 	if err := v.commonBlock(b); err != nil {
 		return err
@@ -318,9 +339,49 @@ func (v *verifier) commonBlock(b block.Block) error {
 			height,
 		)
 	}
+	if err := v.verifyComplexity(b); err != nil {
+		return err
+	}
+	return v.checkpoint.verify(b)
+}
+
+// verifyComplexity verifies that the total complexity of the txs in [b] doesn't
+// exceed [config.Config.MaxBlockComplexity], once the limit is activated.
+func (v *verifier) verifyComplexity(b block.Block) error {
+	// Apricot blocks don't change the chain time, so they are verified
+	// against the timestamp of their parent.
+	timestamp := v.getTimestamp(b.Parent())
+	if banffBlk, ok := b.(block.BanffBlock); ok {
+		timestamp = banffBlk.Timestamp()
+	}
+	config := v.txExecutorBackend.Config
+	if !config.IsBlockComplexityActivated(timestamp) {
+		return nil
+	}
+	maxComplexity := config.MaxBlockComplexity
+
+	complexity, err := txs.TotalComplexity(b.Txs())
+	if err != nil {
+		return err
+	}
+	if complexity > maxComplexity {
+		return fmt.Errorf("%w: %d > %d", ErrBlockComplexityExceeded, complexity, maxComplexity)
+	}
 	return nil
 }
 
+// txBackend returns the backend that the txs of the block at [height] are
+// executed with. While bootstrapping, blocks at or below the trusted
+// checkpoint are executed without verifying their spends.
+func (v *verifier) txBackend(height uint64) *executor.Backend {
+	if !v.checkpoint.trusts(height) || v.txExecutorBackend.Bootstrapped.Get() {
+		return v.txExecutorBackend
+	}
+	txBackend := *v.txExecutorBackend
+	txBackend.FlowChecker = utxo.TrustedVerifier{}
+	return &txBackend
+}
+
 // abortBlock populates the state of this block if [nil] is returned
 func (v *verifier) abortBlock(b block.Block) error {
 	parentID := b.Parent()
@@ -334,6 +395,7 @@ func (v *verifier) abortBlock(b block.Block) error {
 		statelessBlock: b,
 		onAcceptState:  onAbortState,
 		timestamp:      onAbortState.GetTimestamp(),
+		atomicRequests: v.blkIDToState[parentID].onAbortAtomicRequests,
 	}
 	return nil
 }
@@ -351,6 +413,7 @@ func (v *verifier) commitBlock(b block.Block) error {
 		statelessBlock: b,
 		onAcceptState:  onCommitState,
 		timestamp:      onCommitState.GetTimestamp(),
+		atomicRequests: v.blkIDToState[parentID].onCommitAtomicRequests,
 	}
 	return nil
 }
@@ -368,7 +431,7 @@ func (v *verifier) proposalBlock(
 	txExecutor := executor.ProposalTxExecutor{
 		OnCommitState: onCommitState,
 		OnAbortState:  onAbortState,
-		Backend:       v.txExecutorBackend,
+		Backend:       v.txBackend(b.Height()),
 		Tx:            b.Tx,
 	}
 
@@ -386,9 +449,11 @@ func (v *verifier) proposalBlock(
 	blkID := b.ID()
 	v.blkIDToState[blkID] = &blockState{
 		proposalBlockState: proposalBlockState{
-			onDecisionState: onDecisionState,
-			onCommitState:   onCommitState,
-			onAbortState:    onAbortState,
+			onDecisionState:        onDecisionState,
+			onCommitState:          onCommitState,
+			onAbortState:           onAbortState,
+			onCommitAtomicRequests: txExecutor.OnCommitAtomicRequests,
+			onAbortAtomicRequests:  txExecutor.OnAbortAtomicRequests,
 		},
 
 		statelessBlock: b,
@@ -410,7 +475,7 @@ func (v *verifier) standardBlock(
 	b *block.ApricotStandardBlock,
 	onAcceptState state.Diff,
 ) error {
-	inputs, atomicRequests, onAcceptFunc, err := v.processStandardTxs(b.Transactions, onAcceptState, b.Parent())
+	inputs, atomicRequests, onAcceptFunc, err := v.processStandardTxs(v.txBackend(b.Height()), b.Transactions, onAcceptState, b.Parent())
 	if err != nil {
 		return err
 	}
@@ -431,7 +496,7 @@ func (v *verifier) standardBlock(
 	return nil
 }
 
-func (v *verifier) processStandardTxs(txs []*txs.Tx, state state.Diff, parentID ids.ID) (
+func (v *verifier) processStandardTxs(txBackend *executor.Backend, txs []*txs.Tx, state state.Diff, parentID ids.ID) (
 	set.Set[ids.ID],
 	map[ids.ID]*atomic.Requests,
 	func(),
@@ -445,7 +510,7 @@ func (v *verifier) processStandardTxs(txs []*txs.Tx, state state.Diff, parentID
 	)
 	for _, tx := range txs {
 		txExecutor := executor.StandardTxExecutor{
-			Backend: v.txExecutorBackend,
+			Backend: txBackend,
 			State:   state,
 			Tx:      tx,
 		}
@@ -454,6 +519,12 @@ func (v *verifier) processStandardTxs(txs []*txs.Tx, state state.Diff, parentID
 			v.MarkDropped(txID, err) // cache tx as dropped
 			return nil, nil, nil, err
 		}
+		if err := executor.PayFeeTreasury(v.txExecutorBackend, state, tx); err != nil {
+			return nil, nil, nil, err
+		}
+		if err := executor.SweepSubnetFees(v.txExecutorBackend, state, tx); err != nil {
+			return nil, nil, nil, err
+		}
 		// ensure it doesn't overlap with current input batch
 		if inputs.Overlaps(txExecutor.Inputs) {
 			return nil, nil, nil, ErrConflictingBlockTxs
@@ -21,6 +21,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
@@ -67,6 +68,7 @@ func TestVerifierVisitProposalBlock(t *testing.T) {
 	manager := &manager{
 		backend:  backend,
 		verifier: verifier,
+		metrics:  metrics.Noop,
 	}
 
 	blkTx := txs.NewMockUnsignedTx(ctrl)
@@ -152,6 +154,7 @@ func TestVerifierVisitAtomicBlock(t *testing.T) {
 	manager := &manager{
 		backend:  backend,
 		verifier: verifier,
+		metrics:  metrics.Noop,
 	}
 
 	onAccept := state.NewMockDiff(ctrl)
@@ -239,6 +242,7 @@ func TestVerifierVisitStandardBlock(t *testing.T) {
 	manager := &manager{
 		backend:  backend,
 		verifier: verifier,
+		metrics:  metrics.Noop,
 	}
 
 	blkTx := txs.NewMockUnsignedTx(ctrl)
@@ -343,6 +347,7 @@ func TestVerifierVisitCommitBlock(t *testing.T) {
 	manager := &manager{
 		backend:  backend,
 		verifier: verifier,
+		metrics:  metrics.Noop,
 	}
 
 	apricotBlk, err := block.NewApricotCommitBlock(
@@ -414,6 +419,7 @@ func TestVerifierVisitAbortBlock(t *testing.T) {
 	manager := &manager{
 		backend:  backend,
 		verifier: verifier,
+		metrics:  metrics.Noop,
 	}
 
 	apricotBlk, err := block.NewApricotAbortBlock(
@@ -883,6 +889,84 @@ func TestVerifierVisitBanffStandardBlockWithProposalBlockParent(t *testing.T) {
 	require.ErrorIs(err, state.ErrMissingParentState)
 }
 
+func TestVerifierVisitStandardBlockExceedingMaxComplexity(t *testing.T) {
+	// Block timestamps are in seconds.
+	blkTime := time.Unix(time.Now().Unix(), 0)
+
+	tests := []struct {
+		name                string
+		blockComplexityTime time.Time
+		expectedErr         error
+	}{
+		{
+			name:                "limit activated",
+			blockComplexityTime: blkTime,
+			expectedErr:         ErrBlockComplexityExceeded,
+		},
+		{
+			// The block isn't limited, so it fails later on, as its parent
+			// state is missing.
+			name:                "limit not activated",
+			blockComplexityTime: blkTime.Add(time.Second),
+			expectedErr:         state.ErrMissingParentState,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			parentID := ids.GenerateTestID()
+			parentStatelessBlk := block.NewMockBlock(ctrl)
+
+			backend := &backend{
+				blkIDToState: map[ids.ID]*blockState{
+					parentID: {
+						statelessBlock: parentStatelessBlk,
+					},
+				},
+				Mempool: mempool.NewMockMempool(ctrl),
+				state:   state.NewMockState(ctrl),
+				ctx: &snow.Context{
+					Log: logging.NoLog{},
+				},
+			}
+			verifier := &verifier{
+				txExecutorBackend: &executor.Backend{
+					Config: &config.Config{
+						BlockComplexityTime: test.blockComplexityTime,
+						MaxBlockComplexity:  txs.StakerMutationComplexity,
+					},
+					Clk: &mockable.Clock{},
+				},
+				backend: backend,
+			}
+
+			blk, err := block.NewBanffStandardBlock(
+				blkTime,
+				parentID,
+				2,
+				[]*txs.Tx{
+					{
+						Unsigned: &txs.RewardValidatorTx{},
+						Creds:    []verify.Verifiable{},
+					},
+					{
+						Unsigned: &txs.RewardValidatorTx{},
+						Creds:    []verify.Verifiable{},
+					},
+				},
+			)
+			require.NoError(err)
+
+			parentStatelessBlk.EXPECT().Height().Return(uint64(1)).Times(1)
+
+			err = verifier.BanffStandardBlock(blk)
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
 func TestVerifierVisitApricotCommitBlockUnexpectedParentState(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
@@ -5,6 +5,8 @@ package block
 
 import "github.com/ava-labs/avalanchego/codec"
 
+// Parse parses the block [b]. The returned block retains [b] without copying
+// it, so [b] must not be modified afterwards.
 func Parse(c codec.Manager, b []byte) (Block, error) {
 	var blk Block
 	if _, err := c.Unmarshal(b, &blk); err != nil {
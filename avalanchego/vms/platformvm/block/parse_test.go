@@ -330,6 +330,23 @@ func testAtomicTx() (*txs.Tx, error) {
 	return txs.NewSigned(utx, txs.Codec, signers)
 }
 
+func BenchmarkParseStandardBlock(b *testing.B) {
+	decisionTxs, err := testDecisionTxs()
+	require.NoError(b, err)
+
+	blk, err := NewBanffStandardBlock(time.Now(), ids.GenerateTestID(), 2022, decisionTxs)
+	require.NoError(b, err)
+	blkBytes := blk.Bytes()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := Parse(Codec, blkBytes); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 func testDecisionTxs() ([]*txs.Tx, error) {
 	countTxs := 2
 	decisionTxs := make([]*txs.Tx, 0, countTxs)
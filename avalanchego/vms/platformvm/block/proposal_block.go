@@ -27,10 +27,8 @@ func (b *BanffProposalBlock) initialize(bytes []byte) error {
 	if err := b.ApricotProposalBlock.initialize(bytes); err != nil {
 		return err
 	}
-	for _, tx := range b.Transactions {
-		if err := tx.Initialize(txs.Codec); err != nil {
-			return fmt.Errorf("failed to initialize tx: %w", err)
-		}
+	if err := txs.InitializeTxs(txs.Codec, b.Transactions); err != nil {
+		return fmt.Errorf("failed to initialize txs: %w", err)
 	}
 	return nil
 }
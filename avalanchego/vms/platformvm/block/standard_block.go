@@ -56,10 +56,8 @@ type ApricotStandardBlock struct {
 
 func (b *ApricotStandardBlock) initialize(bytes []byte) error {
 	b.CommonBlock.initialize(bytes)
-	for _, tx := range b.Transactions {
-		if err := tx.Initialize(txs.Codec); err != nil {
-			return fmt.Errorf("failed to initialize tx: %w", err)
-		}
+	if err := txs.InitializeTxs(txs.Codec, b.Transactions); err != nil {
+		return fmt.Errorf("failed to initialize txs: %w", err)
 	}
 	return nil
 }
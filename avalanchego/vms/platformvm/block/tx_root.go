@@ -0,0 +1,129 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+// Domain separation of the hashes of the Merkle tree of the txIDs of a block,
+// so that an inner node can't be passed off as a leaf.
+const (
+	txLeafPrefix byte = iota
+	txNodePrefix
+)
+
+var ErrTxIndexOutOfRange = errors.New("tx index out of range")
+
+// TxIDs returns the IDs of the txs of [blk] in the order they are included.
+func TxIDs(blk Block) []ids.ID {
+	blkTxs := blk.Txs()
+	txIDs := make([]ids.ID, len(blkTxs))
+	for i, tx := range blkTxs {
+		txIDs[i] = tx.ID()
+	}
+	return txIDs
+}
+
+// TxRoot returns the root of the Merkle tree of [txIDs].
+//
+// Every leaf is the hash of a txID and every inner node is the hash of its two
+// children. If a level has an odd number of nodes, the last node is promoted to
+// the next level unchanged. The root of no txIDs is [ids.Empty].
+func TxRoot(txIDs []ids.ID) ids.ID {
+	if len(txIDs) == 0 {
+		return ids.Empty
+	}
+	level := txLeaves(txIDs)
+	for len(level) > 1 {
+		level = nextTxLevel(level)
+	}
+	return level[0]
+}
+
+// TxInclusionProof returns the siblings, from the leaves towards the root,
+// that prove the inclusion of the txID at [index] of [txIDs] in [TxRoot].
+func TxInclusionProof(txIDs []ids.ID, index int) ([]ids.ID, error) {
+	if index < 0 || index >= len(txIDs) {
+		return nil, fmt.Errorf("%w: %d not in [0, %d)", ErrTxIndexOutOfRange, index, len(txIDs))
+	}
+
+	var (
+		level = txLeaves(txIDs)
+		proof []ids.ID
+	)
+	for len(level) > 1 {
+		sibling := index ^ 1
+		if sibling < len(level) {
+			proof = append(proof, level[sibling])
+		}
+		level = nextTxLevel(level)
+		index /= 2
+	}
+	return proof, nil
+}
+
+// VerifyTxInclusion returns true if [proof] proves that [txID] is the tx at
+// [index] of the [numTxs] txs whose Merkle tree has [root].
+func VerifyTxInclusion(root ids.ID, txID ids.ID, index int, numTxs int, proof []ids.ID) bool {
+	if index < 0 || index >= numTxs {
+		return false
+	}
+
+	node := txLeaf(txID)
+	for size := numTxs; size > 1; size = (size + 1) / 2 {
+		// The last node of a level with an odd number of nodes has no sibling.
+		if index^1 < size {
+			if len(proof) == 0 {
+				return false
+			}
+			if index%2 == 0 {
+				node = txNode(node, proof[0])
+			} else {
+				node = txNode(proof[0], node)
+			}
+			proof = proof[1:]
+		}
+		index /= 2
+	}
+	return len(proof) == 0 && node == root
+}
+
+func txLeaves(txIDs []ids.ID) []ids.ID {
+	leaves := make([]ids.ID, len(txIDs))
+	for i, txID := range txIDs {
+		leaves[i] = txLeaf(txID)
+	}
+	return leaves
+}
+
+func nextTxLevel(level []ids.ID) []ids.ID {
+	next := make([]ids.ID, 0, (len(level)+1)/2)
+	for i := 0; i+1 < len(level); i += 2 {
+		next = append(next, txNode(level[i], level[i+1]))
+	}
+	if len(level)%2 == 1 {
+		next = append(next, level[len(level)-1])
+	}
+	return next
+}
+
+func txLeaf(txID ids.ID) ids.ID {
+	buf := make([]byte, 1+ids.IDLen)
+	buf[0] = txLeafPrefix
+	copy(buf[1:], txID[:])
+	return hashing.ComputeHash256Array(buf)
+}
+
+func txNode(left, right ids.ID) ids.ID {
+	buf := make([]byte, 1+2*ids.IDLen)
+	buf[0] = txNodePrefix
+	copy(buf[1:], left[:])
+	copy(buf[1+ids.IDLen:], right[:])
+	return hashing.ComputeHash256Array(buf)
+}
@@ -0,0 +1,55 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestTxInclusionProof(t *testing.T) {
+	for numTxs := 1; numTxs <= 9; numTxs++ {
+		txIDs := make([]ids.ID, numTxs)
+		for i := range txIDs {
+			txIDs[i] = ids.GenerateTestID()
+		}
+		root := TxRoot(txIDs)
+
+		for index, txID := range txIDs {
+			require := require.New(t)
+
+			proof, err := TxInclusionProof(txIDs, index)
+			require.NoError(err)
+			require.True(VerifyTxInclusion(root, txID, index, numTxs, proof))
+
+			// The proof doesn't hold for another tx, index or root.
+			require.False(VerifyTxInclusion(root, ids.GenerateTestID(), index, numTxs, proof))
+			if numTxs > 1 {
+				require.False(VerifyTxInclusion(root, txID, (index+1)%numTxs, numTxs, proof))
+			}
+			require.False(VerifyTxInclusion(ids.GenerateTestID(), txID, index, numTxs, proof))
+		}
+	}
+}
+
+func TestTxRootEmpty(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(ids.Empty, TxRoot(nil))
+
+	_, err := TxInclusionProof(nil, 0)
+	require.ErrorIs(err, ErrTxIndexOutOfRange)
+	require.False(VerifyTxInclusion(ids.Empty, ids.Empty, 0, 0, nil))
+}
+
+func TestTxRootSingleTx(t *testing.T) {
+	require := require.New(t)
+
+	txID := ids.GenerateTestID()
+	require.Equal(txLeaf(txID), TxRoot([]ids.ID{txID}))
+	require.NotEqual(txID, TxRoot([]ids.ID{txID}))
+}
@@ -0,0 +1,131 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	blockexecutor "github.com/ava-labs/avalanchego/vms/platformvm/block/executor"
+	txexecutor "github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+)
+
+var blockDictionaryFile = flag.String("block-dictionary", "", "write a block compression dictionary to the given file")
+
+// TestBlockDictionary accepts a block for each of the common P-chain tx types
+// and concatenates the accepted blocks into a zstd raw content dictionary.
+//
+// Stored blocks reference their dictionary by version, so a released
+// dictionary must never be regenerated. A new dictionary version is generated
+// with:
+//
+//	go test ./vms/platformvm -run TestBlockDictionary -block-dictionary state/block_dictionary_v<version>.bin
+func TestBlockDictionary(t *testing.T) {
+	require := require.New(t)
+	vm, _, _ := defaultVM(t, latestFork)
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	var dictionary bytes.Buffer
+	issueAndAccept := func(tx *txs.Tx, err error) {
+		require.NoError(err)
+
+		vm.ctx.Lock.Unlock()
+		require.NoError(vm.issueTx(context.Background(), tx))
+		vm.ctx.Lock.Lock()
+
+		blk, err := vm.Builder.BuildBlock(context.Background())
+		require.NoError(err)
+		require.IsType(&block.BanffStandardBlock{}, blk.(*blockexecutor.Block).Block)
+		accept(require, vm, blk)
+		dictionary.Write(blk.Bytes())
+	}
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	var (
+		startTime     = vm.clock.Time().Add(txexecutor.SyncBound).Add(1)
+		endTime       = startTime.Add(defaultMinStakingDuration)
+		nodeID        = ids.GenerateTestNodeID()
+		recipientAddr = keys[4].Address()
+	)
+	issueAndAccept(vm.txBuilder.NewAddPermissionlessValidatorTx(
+		vm.MinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		nodeID,
+		signer.NewProofOfPossession(sk),
+		recipientAddr,
+		reward.PercentDenominator,
+		secp256k1fx.NewKeychain(keys[0]),
+		keys[0].Address(),
+		nil,
+	))
+	issueAndAccept(vm.txBuilder.NewAddPermissionlessDelegatorTx(
+		vm.MinDelegatorStake,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		nodeID,
+		recipientAddr,
+		secp256k1fx.NewKeychain(keys[1]),
+		keys[1].Address(),
+		nil,
+	))
+	issueAndAccept(vm.txBuilder.NewAddSubnetValidatorTx(
+		defaultWeight,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		nodeID,
+		testSubnet1.ID(),
+		secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
+		keys[0].Address(),
+		nil,
+	))
+	issueAndAccept(vm.txBuilder.NewBaseTx(
+		defaultMinValidatorStake,
+		secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{recipientAddr},
+		},
+		secp256k1fx.NewKeychain(keys[3]),
+		keys[3].Address(),
+		nil,
+	))
+	issueAndAccept(vm.txBuilder.NewExportTx(
+		defaultMinValidatorStake,
+		vm.ctx.XChainID,
+		recipientAddr,
+		secp256k1fx.NewKeychain(keys[2]),
+		keys[2].Address(),
+		nil,
+	))
+	issueAndAccept(vm.txBuilder.NewCreateChainTx(
+		testSubnet1.ID(),
+		nil,
+		ids.ID{'t', 'e', 's', 't', 'v', 'm'},
+		nil,
+		"name",
+		secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
+		keys[0].Address(),
+		nil,
+	))
+
+	if *blockDictionaryFile != "" {
+		require.NoError(os.WriteFile(*blockDictionaryFile, dictionary.Bytes(), 0o600))
+	}
+}
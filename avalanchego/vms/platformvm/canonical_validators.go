@@ -0,0 +1,110 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/platformvm/validatorset"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+const (
+	// Max number of canonical validator sets that are cached
+	canonicalValidatorSetsCacheSize = 64
+
+	// Max number of validator sets that a peer can request per period
+	validatorSetRequestThrottlingPeriod = time.Minute
+	validatorSetRequestThrottlingLimit  = 120
+)
+
+var _ validatorset.State = lockedCanonicalValidators{}
+
+type canonicalValidatorSetKey struct {
+	height   uint64
+	subnetID ids.ID
+}
+
+// canonicalValidatorSet is the validator set of a subnet at a height, in the
+// order that signers of warp messages are indexed in.
+type canonicalValidatorSet struct {
+	// Validators with a BLS key, sorted by their serialized keys
+	validators []*warp.Validator
+	// Compressed keys of [validators], as reported by the API
+	publicKeys  [][]byte
+	totalWeight uint64
+	// Aggregate of the keys of [validators]. Nil if there are none.
+	aggregatePublicKey *bls.PublicKey
+}
+
+// getCanonicalValidatorSet returns the canonical validator set of [subnetID] at
+// [height]. Sets at accepted heights can't change, so they are cached.
+//
+// Invariant: the context lock is held.
+func (vm *VM) getCanonicalValidatorSet(
+	ctx context.Context,
+	height uint64,
+	subnetID ids.ID,
+) (*canonicalValidatorSet, error) {
+	key := canonicalValidatorSetKey{
+		height:   height,
+		subnetID: subnetID,
+	}
+	if vdrSet, ok := vm.canonicalValidatorSets.Get(key); ok {
+		return vdrSet, nil
+	}
+
+	vdrs, totalWeight, err := warp.GetCanonicalValidatorSet(ctx, vm, height, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	vdrSet := &canonicalValidatorSet{
+		validators:  vdrs,
+		publicKeys:  make([][]byte, len(vdrs)),
+		totalWeight: totalWeight,
+	}
+	for i, vdr := range vdrs {
+		vdrSet.publicKeys[i] = bls.PublicKeyToBytes(vdr.PublicKey)
+	}
+	if len(vdrs) > 0 {
+		// The keys are aggregated in a single batch rather than one at a time.
+		vdrSet.aggregatePublicKey, err = warp.AggregatePublicKeys(vdrs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	currentHeight, err := vm.GetCurrentHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if height <= currentHeight {
+		vm.canonicalValidatorSets.Put(key, vdrSet)
+	}
+	return vdrSet, nil
+}
+
+// lockedCanonicalValidators serves the canonical validator sets of the VM to
+// the p2p handlers, which don't hold the context lock.
+type lockedCanonicalValidators struct {
+	vm *VM
+}
+
+func (l lockedCanonicalValidators) GetCanonicalValidatorSet(
+	ctx context.Context,
+	height uint64,
+	subnetID ids.ID,
+) ([]*warp.Validator, uint64, error) {
+	l.vm.ctx.Lock.Lock()
+	defer l.vm.ctx.Lock.Unlock()
+
+	vdrSet, err := l.vm.getCanonicalValidatorSet(ctx, height, subnetID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return vdrSet.validators, vdrSet.totalWeight, nil
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/rpc"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	platformapi "github.com/ava-labs/avalanchego/vms/platformvm/api"
 )
@@ -67,6 +68,15 @@ type Client interface {
 		startUTXOID ids.ID,
 		options ...rpc.Option,
 	) ([][]byte, ids.ShortID, ids.ID, error)
+	// GetAtomicSummary returns the value held by [addrs] on the P-chain along
+	// with the value that [sourceChains] exported to them which wasn't imported
+	// yet. If [sourceChains] is empty, the X-chain and the C-chain are queried.
+	GetAtomicSummary(
+		ctx context.Context,
+		addrs []ids.ShortID,
+		sourceChains []string,
+		options ...rpc.Option,
+	) (*GetAtomicSummaryReply, error)
 	// GetSubnet returns information about the specified subnet
 	GetSubnet(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (GetSubnetClientResponse, error)
 	// GetSubnets returns information about the specified subnets
@@ -204,10 +214,16 @@ type Client interface {
 	GetBlockchains(ctx context.Context, options ...rpc.Option) ([]APIBlockchain, error)
 	// IssueTx issues the transaction and returns its txID
 	IssueTx(ctx context.Context, tx []byte, options ...rpc.Option) (ids.ID, error)
+	// InspectTx returns the type, size, complexity, required signatures and
+	// fee of [tx], which may not be signed yet
+	InspectTx(ctx context.Context, tx []byte, options ...rpc.Option) (*InspectTxReply, error)
 	// GetTx returns the byte representation of the transaction corresponding to [txID]
 	GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error)
 	// GetTxStatus returns the status of the transaction corresponding to [txID]
 	GetTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Option) (*GetTxStatusResponse, error)
+	// GetTxInclusionProof returns the accepted block that includes [txID] and
+	// the proof of its inclusion in the block's tx root
+	GetTxInclusionProof(ctx context.Context, txID ids.ID, options ...rpc.Option) (*GetTxInclusionProofReply, error)
 	// AwaitTxDecided polls [GetTxStatus] until a status is returned that
 	// implies the tx may be decided.
 	// TODO: Move this function off of the Client interface into a utility
@@ -218,6 +234,12 @@ type Client interface {
 		freq time.Duration,
 		options ...rpc.Option,
 	) (*GetTxStatusResponse, error)
+	// GetMempoolGraph returns the dependencies between the txs in the mempool
+	// and, if [tx] is non-empty, the mempool txs that conflict with [tx].
+	GetMempoolGraph(ctx context.Context, tx []byte, options ...rpc.Option) (*GetMempoolGraphReply, error)
+	// CheckImportTx returns the UTXOs imported by the ImportTx [tx] that are
+	// missing from shared memory. The tx isn't issued.
+	CheckImportTx(ctx context.Context, tx []byte, options ...rpc.Option) (*CheckImportTxReply, error)
 	// GetStake returns the amount of nAVAX that [addrs] have cumulatively
 	// staked on the Primary Network.
 	//
@@ -229,6 +251,10 @@ type Client interface {
 		validatorsOnly bool,
 		options ...rpc.Option,
 	) (map[ids.ID]uint64, [][]byte, error)
+	// GetSpendability returns the UTXOs of [addrs] that can be spent at the
+	// current chain time, the UTXOs that unlock later and the outputs of
+	// [addrs] that are bonded in stakes
+	GetSpendability(ctx context.Context, addrs []ids.ShortID, options ...rpc.Option) (*GetSpendabilityReply, error)
 	// GetMinStake returns the minimum staking amount in nAVAX for validators
 	// and delegators respectively
 	GetMinStake(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (uint64, uint64, error)
@@ -251,8 +277,129 @@ type Client interface {
 	//
 	// Deprecated: GetRewardUTXOs should be fetched from a dedicated indexer.
 	GetRewardUTXOs(context.Context, *api.GetTxArgs, ...rpc.Option) ([][]byte, error)
+	// GetDelegationSuggestions returns the primary network validators that can
+	// accept a delegation of [amount] nAVAX for [duration], starting at the
+	// current chain time, from the most to the least preferable. At most
+	// [limit] suggestions are returned, unless [limit] is 0.
+	GetDelegationSuggestions(
+		ctx context.Context,
+		amount uint64,
+		duration time.Duration,
+		limit uint32,
+		options ...rpc.Option,
+	) ([]DelegationSuggestion, error)
+	// GetValidatorStakes returns the self stake, the delegated stake and the
+	// remaining delegation capacity of the current and pending permissionless
+	// validators of [subnetID]. If [nodeIDs] is empty, every such validator is
+	// returned.
+	GetValidatorStakes(
+		ctx context.Context,
+		subnetID ids.ID,
+		nodeIDs []ids.NodeID,
+		options ...rpc.Option,
+	) ([]APIValidatorStake, error)
+	// GetStakeComposition returns the entries hashed into the stake mirror
+	// root of the current primary network validators in [nodeIDs], along with
+	// the roots, at the last accepted height. If [nodeIDs] is empty, every
+	// current primary network validator is returned.
+	GetStakeComposition(
+		ctx context.Context,
+		nodeIDs []ids.NodeID,
+		options ...rpc.Option,
+	) (*GetStakeCompositionReply, error)
+	// GetConnectedValidators returns whether this node is connected to each
+	// current validator of [subnetID], along with the stake-weighted
+	// connectivity of this node.
+	GetConnectedValidators(
+		ctx context.Context,
+		subnetID ids.ID,
+		options ...rpc.Option,
+	) (*GetConnectedValidatorsReply, error)
+	// GetNodeDashboard returns the status of this node that an operator
+	// dashboard displays: its validators and their delegators, its
+	// connectivity, the chain height and time, and the mempool stats.
+	GetNodeDashboard(ctx context.Context, options ...rpc.Option) (*GetNodeDashboardReply, error)
+	// ReserveDelegationCapacity reserves [amount] of the delegation capacity
+	// of [nodeID] on [subnetID] for a delegation rewarded to [rewardAddr] that
+	// ends at [endTime]. [signature] is the signature of the message returned
+	// by [DelegationReservationMessage] made with the key of [rewardAddr]. The
+	// expiry of the reservation is returned.
+	ReserveDelegationCapacity(
+		ctx context.Context,
+		subnetID ids.ID,
+		nodeID ids.NodeID,
+		rewardAddr ids.ShortID,
+		amount uint64,
+		endTime time.Time,
+		signature []byte,
+		options ...rpc.Option,
+	) (time.Time, error)
+	// GetValidatorRewardHistory returns the reward decisions of the stakers of
+	// [nodeID] accepted at heights in [fromHeight, toHeight]. If [toHeight] is
+	// 0, the last accepted height is used.
+	GetValidatorRewardHistory(
+		ctx context.Context,
+		nodeID ids.NodeID,
+		fromHeight uint64,
+		toHeight uint64,
+		options ...rpc.Option,
+	) ([]APIRewardReceipt, error)
+	// GetRewardReceipt returns the reward decision of the staker added by
+	// [stakerTxID] and the nodeID whose uptime it was decided on
+	GetRewardReceipt(ctx context.Context, stakerTxID ids.ID, options ...rpc.Option) (*GetRewardReceiptReply, error)
+	// GetClaimableReward returns the amount of [assetID] that accrued to the
+	// rewards owner [owner] and that it hasn't claimed yet
+	GetClaimableReward(
+		ctx context.Context,
+		owner *secp256k1fx.OutputOwners,
+		assetID ids.ID,
+		options ...rpc.Option,
+	) (uint64, error)
+	// GetRewardAddress returns the C-chain address that the rewards of the
+	// rewards owner [owner] are exported to. [ids.ShortEmpty] is returned if
+	// [owner] isn't bound to an address.
+	GetRewardAddress(
+		ctx context.Context,
+		owner *secp256k1fx.OutputOwners,
+		options ...rpc.Option,
+	) (ids.ShortID, error)
+	// GetStakerTimeline returns the periods [nodeID] validated the primary
+	// network and subnets for, including their rewards, the gaps between
+	// them and the changes of BLS key
+	GetStakerTimeline(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) ([]APIStakingPeriod, error)
+	// GetStateCertificate returns the certificate of the state at [height]
+	// attested by the validators. If [height] is 0, the certificate of the
+	// most recently attested state is returned.
+	GetStateCertificate(ctx context.Context, height uint64, options ...rpc.Option) (*GetStateCertificateReply, error)
+	// GetName returns the validator and the owner that the registered [name]
+	// refers to
+	GetName(ctx context.Context, name string, options ...rpc.Option) (*GetNameReply, error)
+	// VerifyGenesis compares the state created by [genesis] against the state
+	// of the chain. If [genesis] is empty, the genesis the chain was
+	// initialized with is verified.
+	VerifyGenesis(ctx context.Context, genesis []byte, options ...rpc.Option) (*VerifyGenesisReply, error)
+	// GetStakingParameters returns the staking parameters and the fees of the
+	// primary network at the current chain time, along with their scheduled
+	// changes
+	GetStakingParameters(ctx context.Context, options ...rpc.Option) (*GetStakingParametersReply, error)
+	// GetValidatorMetadata returns the metadata committed to by the current
+	// primary network validator [nodeID], along with whether the metadata
+	// fetched by the node matches the committed hash
+	GetValidatorMetadata(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) (*GetValidatorMetadataReply, error)
 	// GetTimestamp returns the current chain timestamp
 	GetTimestamp(ctx context.Context, options ...rpc.Option) (time.Time, error)
+	// GetChainTime returns the chain time and the local time of the node,
+	// along with the range of start times that a new staker may safely use
+	GetChainTime(ctx context.Context, options ...rpc.Option) (*GetChainTimeReply, error)
+	// SimulateChainTime returns the expected staker churn, rewards and supply
+	// for each of the next [days] of chain time.
+	SimulateChainTime(ctx context.Context, days uint64, options ...rpc.Option) (*SimulateChainTimeReply, error)
+	// GetCompatibility returns the versions of the node, its peers and its
+	// codecs along with the fork schedule of the chain
+	GetCompatibility(ctx context.Context, options ...rpc.Option) (*GetCompatibilityReply, error)
+	// GetProfile returns the profile selected with the chain config along
+	// with the fork times, staking parameters and reward config in use
+	GetProfile(ctx context.Context, options ...rpc.Option) (*GetProfileReply, error)
 	// GetValidatorsAt returns the weights of the validator set of a provided
 	// subnet at the specified height.
 	GetValidatorsAt(
@@ -261,10 +408,47 @@ type Client interface {
 		height uint64,
 		options ...rpc.Option,
 	) (map[ids.NodeID]*validators.GetValidatorOutput, error)
+	// GetCanonicalValidatorSet returns the validator set of a provided subnet
+	// at the specified height, in the canonical order used by warp.
+	GetCanonicalValidatorSet(
+		ctx context.Context,
+		subnetID ids.ID,
+		height uint64,
+		options ...rpc.Option,
+	) (*GetCanonicalValidatorSetReply, error)
+	// GetLivePeers returns the validators that recently sent a heartbeat
+	// announcing that they track [subnetID]
+	GetLivePeers(ctx context.Context, subnetID ids.ID, options ...rpc.Option) ([]LivePeer, error)
 	// GetBlock returns the block with the given id.
 	GetBlock(ctx context.Context, blockID ids.ID, options ...rpc.Option) ([]byte, error)
 	// GetBlockByHeight returns the block at the given [height].
 	GetBlockByHeight(ctx context.Context, height uint64, options ...rpc.Option) ([]byte, error)
+	// GetFeesBurned returns the fees burned and redirected to the fee treasury
+	// by the accepted blocks with a height in [startHeight, endHeight].
+	GetFeesBurned(ctx context.Context, startHeight, endHeight uint64, options ...rpc.Option) (*GetFeesBurnedReply, error)
+	// TraceBlockVerification re-runs the verification of [blk] and returns the
+	// state accesses performed along with the verification error, if any.
+	TraceBlockVerification(ctx context.Context, blk []byte, options ...rpc.Option) (*TraceBlockVerificationReply, error)
+	// PrevalidateBlock verifies [blk] on top of the preferred block without
+	// issuing it, and returns whether it can be accepted along with a summary
+	// of the state changes it would perform
+	PrevalidateBlock(ctx context.Context, blk []byte, options ...rpc.Option) (*PrevalidateBlockReply, error)
+	// ConvertAddress returns the encodings of [addr] on the P-chain and the
+	// C-chain. If [publicKey] is given, the EVM address derived from it is
+	// returned too.
+	ConvertAddress(ctx context.Context, addr string, publicKey string, options ...rpc.Option) (*ConvertAddressReply, error)
+	// ValidateAddress returns whether [addr] is a valid bech32 address on a
+	// chain of this network
+	ValidateAddress(ctx context.Context, addr string, options ...rpc.Option) (*ValidateAddressReply, error)
+	// SetAddressLabel attaches [label] to [addr] in [user]'s keystore. An
+	// empty label removes the label of [addr].
+	SetAddressLabel(ctx context.Context, user api.UserPass, addr ids.ShortID, label string, options ...rpc.Option) error
+	// GetAddressLabels returns the labels attached to addresses in [user]'s
+	// keystore
+	GetAddressLabels(ctx context.Context, user api.UserPass, options ...rpc.Option) (map[ids.ShortID]string, error)
+	// GetLabeledBalances returns the balance and stake of the addresses in
+	// [user]'s keystore, grouped by label
+	GetLabeledBalances(ctx context.Context, user api.UserPass, options ...rpc.Option) ([]LabeledBalance, error)
 }
 
 // Client implementation for interacting with the P Chain endpoint
@@ -383,6 +567,20 @@ func (c *client) GetAtomicUTXOs(
 	return utxos, endAddr, endUTXOID, err
 }
 
+func (c *client) GetAtomicSummary(
+	ctx context.Context,
+	addrs []ids.ShortID,
+	sourceChains []string,
+	options ...rpc.Option,
+) (*GetAtomicSummaryReply, error) {
+	res := &GetAtomicSummaryReply{}
+	err := c.requester.SendRequest(ctx, "platform.getAtomicSummary", &GetAtomicSummaryArgs{
+		Addresses:    ids.ShortIDsToStrings(addrs),
+		SourceChains: sourceChains,
+	}, res, options...)
+	return res, err
+}
+
 // GetSubnetClientResponse is the response from calling GetSubnet on the client
 type GetSubnetClientResponse struct {
 	// whether it is permissioned or not
@@ -754,6 +952,20 @@ func (c *client) IssueTx(ctx context.Context, txBytes []byte, options ...rpc.Opt
 	return res.TxID, err
 }
 
+func (c *client) InspectTx(ctx context.Context, txBytes []byte, options ...rpc.Option) (*InspectTxReply, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &InspectTxReply{}
+	err = c.requester.SendRequest(ctx, "platform.inspectTx", &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error) {
 	res := &api.FormattedTx{}
 	err := c.requester.SendRequest(ctx, "platform.getTx", &api.GetTxArgs{
@@ -780,6 +992,44 @@ func (c *client) GetTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Op
 	return res, err
 }
 
+func (c *client) GetTxInclusionProof(ctx context.Context, txID ids.ID, options ...rpc.Option) (*GetTxInclusionProofReply, error) {
+	res := &GetTxInclusionProofReply{}
+	err := c.requester.SendRequest(ctx, "platform.getTxInclusionProof", &GetTxInclusionProofArgs{
+		TxID: txID,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetMempoolGraph(ctx context.Context, tx []byte, options ...rpc.Option) (*GetMempoolGraphReply, error) {
+	args := &GetMempoolGraphArgs{
+		Format:   MempoolGraphFormatJSON,
+		Encoding: formatting.Hex,
+	}
+	if len(tx) != 0 {
+		txStr, err := formatting.Encode(formatting.Hex, tx)
+		if err != nil {
+			return nil, err
+		}
+		args.Tx = txStr
+	}
+	res := &GetMempoolGraphReply{}
+	err := c.requester.SendRequest(ctx, "platform.getMempoolGraph", args, res, options...)
+	return res, err
+}
+
+func (c *client) CheckImportTx(ctx context.Context, tx []byte, options ...rpc.Option) (*CheckImportTxReply, error) {
+	txStr, err := formatting.Encode(formatting.Hex, tx)
+	if err != nil {
+		return nil, err
+	}
+	res := &CheckImportTxReply{}
+	err = c.requester.SendRequest(ctx, "platform.checkImportTx", &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) AwaitTxDecided(ctx context.Context, txID ids.ID, freq time.Duration, options ...rpc.Option) (*GetTxStatusResponse, error) {
 	ticker := time.NewTicker(freq)
 	defer ticker.Stop()
@@ -835,6 +1085,14 @@ func (c *client) GetStake(
 	return staked, outputs, err
 }
 
+func (c *client) GetSpendability(ctx context.Context, addrs []ids.ShortID, options ...rpc.Option) (*GetSpendabilityReply, error) {
+	res := &GetSpendabilityReply{}
+	err := c.requester.SendRequest(ctx, "platform.getSpendability", &api.JSONAddresses{
+		Addresses: ids.ShortIDsToStrings(addrs),
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetMinStake(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (uint64, uint64, error) {
 	res := &GetMinStakeReply{}
 	err := c.requester.SendRequest(ctx, "platform.getMinStake", &GetMinStakeArgs{
@@ -885,12 +1143,241 @@ func (c *client) GetRewardUTXOs(ctx context.Context, args *api.GetTxArgs, option
 	return utxos, err
 }
 
+func (c *client) GetDelegationSuggestions(
+	ctx context.Context,
+	amount uint64,
+	duration time.Duration,
+	limit uint32,
+	options ...rpc.Option,
+) ([]DelegationSuggestion, error) {
+	res := &GetDelegationSuggestionsReply{}
+	err := c.requester.SendRequest(ctx, "platform.getDelegationSuggestions", &GetDelegationSuggestionsArgs{
+		Amount:   json.Uint64(amount),
+		Duration: json.Uint64(duration / time.Second),
+		Limit:    json.Uint32(limit),
+	}, res, options...)
+	return res.Suggestions, err
+}
+
+func (c *client) GetValidatorStakes(
+	ctx context.Context,
+	subnetID ids.ID,
+	nodeIDs []ids.NodeID,
+	options ...rpc.Option,
+) ([]APIValidatorStake, error) {
+	res := &GetValidatorStakesReply{}
+	err := c.requester.SendRequest(ctx, "platform.getValidatorStakes", &GetValidatorStakesArgs{
+		SubnetID: subnetID,
+		NodeIDs:  nodeIDs,
+	}, res, options...)
+	return res.Validators, err
+}
+
+func (c *client) GetStakeComposition(
+	ctx context.Context,
+	nodeIDs []ids.NodeID,
+	options ...rpc.Option,
+) (*GetStakeCompositionReply, error) {
+	res := &GetStakeCompositionReply{}
+	err := c.requester.SendRequest(ctx, "platform.getStakeComposition", &GetStakeCompositionArgs{
+		NodeIDs: nodeIDs,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetConnectedValidators(
+	ctx context.Context,
+	subnetID ids.ID,
+	options ...rpc.Option,
+) (*GetConnectedValidatorsReply, error) {
+	res := &GetConnectedValidatorsReply{}
+	err := c.requester.SendRequest(ctx, "platform.getConnectedValidators", &GetConnectedValidatorsArgs{
+		SubnetID: subnetID,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetNodeDashboard(ctx context.Context, options ...rpc.Option) (*GetNodeDashboardReply, error) {
+	res := &GetNodeDashboardReply{}
+	err := c.requester.SendRequest(ctx, "platform.getNodeDashboard", struct{}{}, res, options...)
+	return res, err
+}
+
+func (c *client) ReserveDelegationCapacity(
+	ctx context.Context,
+	subnetID ids.ID,
+	nodeID ids.NodeID,
+	rewardAddr ids.ShortID,
+	amount uint64,
+	endTime time.Time,
+	signature []byte,
+	options ...rpc.Option,
+) (time.Time, error) {
+	signatureStr, err := formatting.Encode(formatting.Hex, signature)
+	if err != nil {
+		return time.Time{}, err
+	}
+	res := &ReserveDelegationCapacityReply{}
+	err = c.requester.SendRequest(ctx, "platform.reserveDelegationCapacity", &ReserveDelegationCapacityArgs{
+		SubnetID:      subnetID,
+		NodeID:        nodeID,
+		RewardAddress: rewardAddr.String(),
+		Amount:        json.Uint64(amount),
+		EndTime:       json.Uint64(endTime.Unix()),
+		Signature:     signatureStr,
+		Encoding:      formatting.Hex,
+	}, res, options...)
+	return time.Unix(int64(res.Expiry), 0), err
+}
+
+func (c *client) GetValidatorRewardHistory(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	fromHeight uint64,
+	toHeight uint64,
+	options ...rpc.Option,
+) ([]APIRewardReceipt, error) {
+	res := &GetValidatorRewardHistoryReply{}
+	err := c.requester.SendRequest(ctx, "platform.getValidatorRewardHistory", &GetValidatorRewardHistoryArgs{
+		NodeID:     nodeID,
+		FromHeight: json.Uint64(fromHeight),
+		ToHeight:   json.Uint64(toHeight),
+	}, res, options...)
+	return res.Receipts, err
+}
+
+func (c *client) GetRewardReceipt(ctx context.Context, stakerTxID ids.ID, options ...rpc.Option) (*GetRewardReceiptReply, error) {
+	res := &GetRewardReceiptReply{}
+	err := c.requester.SendRequest(ctx, "platform.getRewardReceipt", &GetRewardReceiptArgs{
+		StakerTxID: stakerTxID,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetClaimableReward(
+	ctx context.Context,
+	owner *secp256k1fx.OutputOwners,
+	assetID ids.ID,
+	options ...rpc.Option,
+) (uint64, error) {
+	res := &GetClaimableRewardReply{}
+	err := c.requester.SendRequest(ctx, "platform.getClaimableReward", &GetClaimableRewardArgs{
+		Owner: platformapi.Owner{
+			Locktime:  json.Uint64(owner.Locktime),
+			Threshold: json.Uint32(owner.Threshold),
+			Addresses: ids.ShortIDsToStrings(owner.Addrs),
+		},
+		AssetID: assetID,
+	}, res, options...)
+	return uint64(res.Amount), err
+}
+
+func (c *client) GetRewardAddress(
+	ctx context.Context,
+	owner *secp256k1fx.OutputOwners,
+	options ...rpc.Option,
+) (ids.ShortID, error) {
+	res := &GetRewardAddressReply{}
+	err := c.requester.SendRequest(ctx, "platform.getRewardAddress", &GetRewardAddressArgs{
+		Owner: platformapi.Owner{
+			Locktime:  json.Uint64(owner.Locktime),
+			Threshold: json.Uint32(owner.Threshold),
+			Addresses: ids.ShortIDsToStrings(owner.Addrs),
+		},
+	}, res, options...)
+	if err != nil || res.Address == "" {
+		return ids.ShortEmpty, err
+	}
+	return address.ParseToID(res.Address)
+}
+
+func (c *client) GetStakerTimeline(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) ([]APIStakingPeriod, error) {
+	res := &GetStakerTimelineReply{}
+	err := c.requester.SendRequest(ctx, "platform.getStakerTimeline", &GetStakerTimelineArgs{
+		NodeID: nodeID,
+	}, res, options...)
+	return res.Periods, err
+}
+
+func (c *client) GetStateCertificate(ctx context.Context, height uint64, options ...rpc.Option) (*GetStateCertificateReply, error) {
+	res := &GetStateCertificateReply{}
+	err := c.requester.SendRequest(ctx, "platform.getStateCertificate", &GetStateCertificateArgs{
+		Height:   json.Uint64(height),
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetName(ctx context.Context, name string, options ...rpc.Option) (*GetNameReply, error) {
+	res := &GetNameReply{}
+	err := c.requester.SendRequest(ctx, "platform.getName", &GetNameArgs{
+		Name: name,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) VerifyGenesis(ctx context.Context, genesis []byte, options ...rpc.Option) (*VerifyGenesisReply, error) {
+	args := &VerifyGenesisArgs{
+		Encoding: formatting.Hex,
+	}
+	if len(genesis) != 0 {
+		var err error
+		args.Genesis, err = formatting.Encode(formatting.Hex, genesis)
+		if err != nil {
+			return nil, err
+		}
+	}
+	res := &VerifyGenesisReply{}
+	err := c.requester.SendRequest(ctx, "platform.verifyGenesis", args, res, options...)
+	return res, err
+}
+
+func (c *client) GetStakingParameters(ctx context.Context, options ...rpc.Option) (*GetStakingParametersReply, error) {
+	res := &GetStakingParametersReply{}
+	err := c.requester.SendRequest(ctx, "platform.getStakingParameters", struct{}{}, res, options...)
+	return res, err
+}
+
+func (c *client) GetValidatorMetadata(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) (*GetValidatorMetadataReply, error) {
+	res := &GetValidatorMetadataReply{}
+	err := c.requester.SendRequest(ctx, "platform.getValidatorMetadata", &GetValidatorMetadataArgs{
+		NodeID: nodeID,
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetTimestamp(ctx context.Context, options ...rpc.Option) (time.Time, error) {
 	res := &GetTimestampReply{}
 	err := c.requester.SendRequest(ctx, "platform.getTimestamp", struct{}{}, res, options...)
 	return res.Timestamp, err
 }
 
+func (c *client) GetChainTime(ctx context.Context, options ...rpc.Option) (*GetChainTimeReply, error) {
+	res := &GetChainTimeReply{}
+	err := c.requester.SendRequest(ctx, "platform.getChainTime", struct{}{}, res, options...)
+	return res, err
+}
+
+func (c *client) GetCompatibility(ctx context.Context, options ...rpc.Option) (*GetCompatibilityReply, error) {
+	res := &GetCompatibilityReply{}
+	err := c.requester.SendRequest(ctx, "platform.getCompatibility", struct{}{}, res, options...)
+	return res, err
+}
+
+func (c *client) GetProfile(ctx context.Context, options ...rpc.Option) (*GetProfileReply, error) {
+	res := &GetProfileReply{}
+	err := c.requester.SendRequest(ctx, "platform.getProfile", struct{}{}, res, options...)
+	return res, err
+}
+
+func (c *client) SimulateChainTime(ctx context.Context, days uint64, options ...rpc.Option) (*SimulateChainTimeReply, error) {
+	res := &SimulateChainTimeReply{}
+	err := c.requester.SendRequest(ctx, "platform.simulateChainTime", &SimulateChainTimeArgs{
+		Days: json.Uint64(days),
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetValidatorsAt(
 	ctx context.Context,
 	subnetID ids.ID,
@@ -905,6 +1392,28 @@ func (c *client) GetValidatorsAt(
 	return res.Validators, err
 }
 
+func (c *client) GetCanonicalValidatorSet(
+	ctx context.Context,
+	subnetID ids.ID,
+	height uint64,
+	options ...rpc.Option,
+) (*GetCanonicalValidatorSetReply, error) {
+	res := &GetCanonicalValidatorSetReply{}
+	err := c.requester.SendRequest(ctx, "platform.getCanonicalValidatorSet", &GetCanonicalValidatorSetArgs{
+		SubnetID: subnetID,
+		Height:   json.Uint64(height),
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetLivePeers(ctx context.Context, subnetID ids.ID, options ...rpc.Option) ([]LivePeer, error) {
+	res := &GetLivePeersReply{}
+	err := c.requester.SendRequest(ctx, "platform.getLivePeers", &GetLivePeersArgs{
+		SubnetID: subnetID,
+	}, res, options...)
+	return res.Peers, err
+}
+
 func (c *client) GetBlock(ctx context.Context, blockID ids.ID, options ...rpc.Option) ([]byte, error) {
 	res := &api.FormattedBlock{}
 	if err := c.requester.SendRequest(ctx, "platform.getBlock", &api.GetBlockArgs{
@@ -927,3 +1436,86 @@ func (c *client) GetBlockByHeight(ctx context.Context, height uint64, options ..
 	}
 	return formatting.Decode(res.Encoding, res.Block)
 }
+
+func (c *client) GetFeesBurned(ctx context.Context, startHeight, endHeight uint64, options ...rpc.Option) (*GetFeesBurnedReply, error) {
+	res := &GetFeesBurnedReply{}
+	err := c.requester.SendRequest(ctx, "platform.getFeesBurned", &GetFeesBurnedArgs{
+		StartHeight: json.Uint64(startHeight),
+		EndHeight:   json.Uint64(endHeight),
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) TraceBlockVerification(ctx context.Context, blk []byte, options ...rpc.Option) (*TraceBlockVerificationReply, error) {
+	blkStr, err := formatting.Encode(formatting.Hex, blk)
+	if err != nil {
+		return nil, err
+	}
+	res := &TraceBlockVerificationReply{}
+	err = c.requester.SendRequest(ctx, "platform.traceBlockVerification", &TraceBlockVerificationArgs{
+		Block:    blkStr,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) PrevalidateBlock(ctx context.Context, blk []byte, options ...rpc.Option) (*PrevalidateBlockReply, error) {
+	blkStr, err := formatting.Encode(formatting.Hex, blk)
+	if err != nil {
+		return nil, err
+	}
+	res := &PrevalidateBlockReply{}
+	err = c.requester.SendRequest(ctx, "platform.prevalidateBlock", &PrevalidateBlockArgs{
+		Block:    blkStr,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) ConvertAddress(ctx context.Context, addr string, publicKey string, options ...rpc.Option) (*ConvertAddressReply, error) {
+	res := &ConvertAddressReply{}
+	err := c.requester.SendRequest(ctx, "platform.convertAddress", &ConvertAddressArgs{
+		Address:   addr,
+		PublicKey: publicKey,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) ValidateAddress(ctx context.Context, addr string, options ...rpc.Option) (*ValidateAddressReply, error) {
+	res := &ValidateAddressReply{}
+	err := c.requester.SendRequest(ctx, "platform.validateAddress", &ValidateAddressArgs{
+		Address: addr,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) SetAddressLabel(ctx context.Context, user api.UserPass, addr ids.ShortID, label string, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "platform.setAddressLabel", &SetAddressLabelArgs{
+		UserPass: user,
+		Address:  addr.String(),
+		Label:    label,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) GetAddressLabels(ctx context.Context, user api.UserPass, options ...rpc.Option) (map[ids.ShortID]string, error) {
+	res := &GetAddressLabelsReply{}
+	err := c.requester.SendRequest(ctx, "platform.getAddressLabels", &user, res, options...)
+	if err != nil {
+		return nil, err
+	}
+	labels := make(map[ids.ShortID]string, len(res.Labels))
+	for addrStr, label := range res.Labels {
+		addr, err := address.ParseToID(addrStr)
+		if err != nil {
+			return nil, err
+		}
+		labels[addr] = label
+	}
+	return labels, nil
+}
+
+func (c *client) GetLabeledBalances(ctx context.Context, user api.UserPass, options ...rpc.Option) ([]LabeledBalance, error) {
+	res := &GetLabeledBalancesReply{}
+	err := c.requester.SendRequest(ctx, "platform.getLabeledBalances", &user, res, options...)
+	return res.Groups, err
+}
@@ -0,0 +1,446 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package client provides typed helpers for the P-chain staking flows that are
+// built on the JSON-RPC API of a node.
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/chain/p"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+)
+
+const (
+	DefaultMaxAttempts   = 5
+	DefaultRetryDelay    = time.Second
+	DefaultPollFrequency = time.Second
+
+	// Max number of UTXOs to request per page
+	utxosPageSize = 1024
+)
+
+var (
+	ErrNotCommitted = errors.New("tx wasn't committed")
+
+	errNoKeychain = errors.New("no keychain provided")
+)
+
+// Config configures a Client.
+type Config struct {
+	// URI of the node, such as "http://127.0.0.1:9650"
+	URI string
+	// Keychain signs for the addresses whose funds are staked. Its addresses
+	// also receive change.
+	Keychain keychain.Keychain
+
+	// MaxAttempts is the number of times a request is attempted before its
+	// error is returned. Defaults to DefaultMaxAttempts.
+	MaxAttempts int
+	// RetryDelay is the time waited between attempts of a request. Defaults
+	// to DefaultRetryDelay.
+	RetryDelay time.Duration
+	// PollFrequency is the time waited between tx status requests while
+	// waiting for a tx to be decided. Defaults to DefaultPollFrequency.
+	PollFrequency time.Duration
+}
+
+// Client issues staking txs funded by a keychain and tracks their outcome.
+//
+// Every tx is built from the UTXOs that the node reports at the time the tx is
+// built, so the funds of the keychain may also be spent by other tools.
+type Client struct {
+	config  Config
+	pClient platformvm.Client
+	context p.Context
+}
+
+// New returns a client of the node at [config.URI].
+func New(ctx context.Context, config Config) (*Client, error) {
+	return NewFromClients(
+		ctx,
+		info.NewClient(config.URI),
+		platformvm.NewClient(config.URI),
+		config,
+	)
+}
+
+// NewFromClients returns a client that uses the provided API clients rather
+// than the ones of [config.URI].
+func NewFromClients(
+	ctx context.Context,
+	infoClient info.Client,
+	pClient platformvm.Client,
+	config Config,
+) (*Client, error) {
+	if config.Keychain == nil {
+		return nil, errNoKeychain
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = DefaultMaxAttempts
+	}
+	if config.RetryDelay <= 0 {
+		config.RetryDelay = DefaultRetryDelay
+	}
+	if config.PollFrequency <= 0 {
+		config.PollFrequency = DefaultPollFrequency
+	}
+
+	c := &Client{
+		config:  config,
+		pClient: pClient,
+	}
+
+	var (
+		networkID   uint32
+		avaxAssetID ids.ID
+		txFees      *info.GetTxFeeResponse
+	)
+	err := c.retry(ctx, func(ctx context.Context) error {
+		var err error
+		networkID, err = infoClient.GetNetworkID(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get network ID: %w", err)
+	}
+	err = c.retry(ctx, func(ctx context.Context) error {
+		var err error
+		avaxAssetID, err = pClient.GetStakingAssetID(ctx, constants.PrimaryNetworkID)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get staking asset ID: %w", err)
+	}
+	err = c.retry(ctx, func(ctx context.Context) error {
+		var err error
+		txFees, err = infoClient.GetTxFee(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get tx fees: %w", err)
+	}
+
+	c.context = p.NewContext(
+		networkID,
+		avaxAssetID,
+		uint64(txFees.TxFee),
+		uint64(txFees.CreateSubnetTxFee),
+		uint64(txFees.TransformSubnetTxFee),
+		uint64(txFees.CreateBlockchainTxFee),
+		uint64(txFees.AddPrimaryNetworkValidatorFee),
+		uint64(txFees.AddPrimaryNetworkDelegatorFee),
+		uint64(txFees.AddSubnetValidatorFee),
+		uint64(txFees.AddSubnetDelegatorFee),
+	)
+	return c, nil
+}
+
+// AddValidatorArgs are the arguments of a primary network validator.
+type AddValidatorArgs struct {
+	NodeID    ids.NodeID
+	StartTime time.Time
+	EndTime   time.Time
+	// Weight is the amount of AVAX that is staked
+	Weight uint64
+	// RewardAddress receives the validation and delegation rewards
+	RewardAddress ids.ShortID
+	// DelegationShares is the fraction of the delegator rewards, out of
+	// reward.PercentDenominator, that is paid to the validator
+	DelegationShares uint32
+	// ProofOfPossession of the BLS key of the validator. If nil, the validator
+	// doesn't register a BLS key.
+	ProofOfPossession *signer.ProofOfPossession
+}
+
+// DelegateArgs are the arguments of a primary network delegator.
+type DelegateArgs struct {
+	NodeID    ids.NodeID
+	StartTime time.Time
+	EndTime   time.Time
+	// Weight is the amount of AVAX that is delegated
+	Weight uint64
+	// RewardAddress receives the delegation rewards
+	RewardAddress ids.ShortID
+}
+
+// BuildAddValidatorTx returns an unsigned tx that adds the validator described
+// by [args].
+func (c *Client) BuildAddValidatorTx(ctx context.Context, args *AddValidatorArgs) (txs.UnsignedTx, error) {
+	builder, _, err := c.newBuilder(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.buildAddValidatorTx(ctx, builder, args)
+}
+
+func (c *Client) buildAddValidatorTx(
+	ctx context.Context,
+	builder p.Builder,
+	args *AddValidatorArgs,
+) (txs.UnsignedTx, error) {
+	var vdrSigner signer.Signer = &signer.Empty{}
+	if args.ProofOfPossession != nil {
+		vdrSigner = args.ProofOfPossession
+	}
+	rewardsOwner := &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{args.RewardAddress},
+	}
+	utx, err := builder.NewAddPermissionlessValidatorTx(
+		&txs.SubnetValidator{
+			Validator: txs.Validator{
+				NodeID: args.NodeID,
+				Start:  uint64(args.StartTime.Unix()),
+				End:    uint64(args.EndTime.Unix()),
+				Wght:   args.Weight,
+			},
+			Subnet: constants.PrimaryNetworkID,
+		},
+		vdrSigner,
+		c.context.AVAXAssetID(),
+		rewardsOwner,
+		rewardsOwner,
+		args.DelegationShares,
+		common.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return utx, nil
+}
+
+// BuildDelegateTx returns an unsigned tx that adds the delegator described by
+// [args].
+func (c *Client) BuildDelegateTx(ctx context.Context, args *DelegateArgs) (txs.UnsignedTx, error) {
+	builder, _, err := c.newBuilder(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.buildDelegateTx(ctx, builder, args)
+}
+
+func (c *Client) buildDelegateTx(
+	ctx context.Context,
+	builder p.Builder,
+	args *DelegateArgs,
+) (txs.UnsignedTx, error) {
+	utx, err := builder.NewAddPermissionlessDelegatorTx(
+		&txs.SubnetValidator{
+			Validator: txs.Validator{
+				NodeID: args.NodeID,
+				Start:  uint64(args.StartTime.Unix()),
+				End:    uint64(args.EndTime.Unix()),
+				Wght:   args.Weight,
+			},
+			Subnet: constants.PrimaryNetworkID,
+		},
+		c.context.AVAXAssetID(),
+		&secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{args.RewardAddress},
+		},
+		common.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return utx, nil
+}
+
+// EstimateFee returns the amount of AVAX that [utx] burns.
+func (c *Client) EstimateFee(utx txs.UnsignedTx) (uint64, error) {
+	return txs.Burned(utx, c.context.AVAXAssetID())
+}
+
+// AddValidator signs and issues a tx that adds the validator described by
+// [args]. The returned tx may not have been decided yet, see
+// WaitForAcceptance.
+func (c *Client) AddValidator(ctx context.Context, args *AddValidatorArgs) (*txs.Tx, error) {
+	builder, txSigner, err := c.newBuilder(ctx)
+	if err != nil {
+		return nil, err
+	}
+	utx, err := c.buildAddValidatorTx(ctx, builder, args)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't build tx: %w", err)
+	}
+	return c.signAndIssue(ctx, txSigner, utx)
+}
+
+// Delegate signs and issues a tx that adds the delegator described by [args].
+// The returned tx may not have been decided yet, see WaitForAcceptance.
+func (c *Client) Delegate(ctx context.Context, args *DelegateArgs) (*txs.Tx, error) {
+	builder, txSigner, err := c.newBuilder(ctx)
+	if err != nil {
+		return nil, err
+	}
+	utx, err := c.buildDelegateTx(ctx, builder, args)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't build tx: %w", err)
+	}
+	return c.signAndIssue(ctx, txSigner, utx)
+}
+
+// WaitForAcceptance waits until [txID] is decided and returns an error if it
+// wasn't committed.
+func (c *Client) WaitForAcceptance(ctx context.Context, txID ids.ID) error {
+	res, err := c.pClient.AwaitTxDecided(ctx, txID, c.config.PollFrequency)
+	if err != nil {
+		return err
+	}
+	if res.Status != status.Committed {
+		return fmt.Errorf("%w: %s %s %s", ErrNotCommitted, txID, res.Status, res.Reason)
+	}
+	return nil
+}
+
+// GetRewards returns the UTXOs that were rewarded to the staker added by
+// [txID] when it stopped staking.
+func (c *Client) GetRewards(ctx context.Context, txID ids.ID) ([]*avax.UTXO, error) {
+	var utxosBytes [][]byte
+	err := c.retry(ctx, func(ctx context.Context) error {
+		var err error
+		utxosBytes, err = c.pClient.GetRewardUTXOs(ctx, &api.GetTxArgs{
+			TxID:     txID,
+			Encoding: formatting.Hex,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	utxos := make([]*avax.UTXO, len(utxosBytes))
+	for i, utxoBytes := range utxosBytes {
+		utxos[i] = &avax.UTXO{}
+		if _, err := txs.Codec.Unmarshal(utxoBytes, utxos[i]); err != nil {
+			return nil, fmt.Errorf("couldn't parse reward UTXO: %w", err)
+		}
+	}
+	return utxos, nil
+}
+
+func (c *Client) signAndIssue(
+	ctx context.Context,
+	txSigner p.Signer,
+	utx txs.UnsignedTx,
+) (*txs.Tx, error) {
+	tx, err := p.SignUnsigned(ctx, txSigner, utx)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't sign tx: %w", err)
+	}
+
+	txID := tx.ID()
+	err = c.retry(ctx, func(ctx context.Context) error {
+		_, err := c.pClient.IssueTx(ctx, tx.Bytes())
+		if err == nil {
+			return nil
+		}
+
+		// A previous attempt may have been issued even though its response
+		// was lost.
+		res, statusErr := c.pClient.GetTxStatus(ctx, txID)
+		if statusErr == nil && res.Status != status.Unknown {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't issue tx %s: %w", txID, err)
+	}
+	return tx, nil
+}
+
+// newBuilder returns a builder and a signer of txs that spend the UTXOs the
+// keychain currently owns.
+func (c *Client) newBuilder(ctx context.Context) (p.Builder, p.Signer, error) {
+	addrs := c.config.Keychain.Addresses()
+	utxos := common.NewUTXOs()
+	var (
+		addrList  = addrs.List()
+		startAddr ids.ShortID
+		startUTXO ids.ID
+	)
+	for {
+		var (
+			utxosBytes [][]byte
+			endAddr    ids.ShortID
+			endUTXO    ids.ID
+		)
+		err := c.retry(ctx, func(ctx context.Context) error {
+			var err error
+			utxosBytes, endAddr, endUTXO, err = c.pClient.GetUTXOs(
+				ctx,
+				addrList,
+				utxosPageSize,
+				startAddr,
+				startUTXO,
+			)
+			return err
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("couldn't get UTXOs: %w", err)
+		}
+
+		for _, utxoBytes := range utxosBytes {
+			utxo := &avax.UTXO{}
+			if _, err := txs.Codec.Unmarshal(utxoBytes, utxo); err != nil {
+				return nil, nil, fmt.Errorf("couldn't parse UTXO: %w", err)
+			}
+			err := utxos.AddUTXO(ctx, constants.PlatformChainID, constants.PlatformChainID, utxo)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		if len(utxosBytes) < utxosPageSize {
+			break
+		}
+		startAddr = endAddr
+		startUTXO = endUTXO
+	}
+
+	backend := p.NewBackend(
+		c.context,
+		common.NewChainUTXOs(constants.PlatformChainID, utxos),
+		nil,
+	)
+	return p.NewBuilder(addrs, backend), p.NewSigner(c.config.Keychain, backend), nil
+}
+
+// retry calls [f] until it succeeds, [ctx] is done or the maximum number of
+// attempts was made.
+func (c *Client) retry(ctx context.Context, f func(context.Context) error) error {
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = f(ctx)
+		if err == nil || attempt >= c.config.MaxAttempts {
+			return err
+		}
+
+		timer := time.NewTimer(c.config.RetryDelay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		}
+	}
+}
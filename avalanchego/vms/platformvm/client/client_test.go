@@ -0,0 +1,227 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+const (
+	testNetworkID    = 10
+	testDelegatorFee = 1_000
+)
+
+var (
+	errTest = errors.New("test")
+
+	testAssetID = ids.ID{'a', 'v', 'a', 'x'}
+)
+
+type testInfoClient struct {
+	info.Client
+}
+
+func (*testInfoClient) GetNetworkID(context.Context, ...rpc.Option) (uint32, error) {
+	return testNetworkID, nil
+}
+
+func (*testInfoClient) GetTxFee(context.Context, ...rpc.Option) (*info.GetTxFeeResponse, error) {
+	return &info.GetTxFeeResponse{
+		AddPrimaryNetworkDelegatorFee: testDelegatorFee,
+	}, nil
+}
+
+type testPClient struct {
+	platformvm.Client
+
+	utxos       [][]byte
+	rewardUTXOs [][]byte
+
+	// issueErrs are returned by the first calls to IssueTx
+	issueErrs []error
+	issued    [][]byte
+	status    status.Status
+}
+
+func (*testPClient) GetStakingAssetID(context.Context, ids.ID, ...rpc.Option) (ids.ID, error) {
+	return testAssetID, nil
+}
+
+func (c *testPClient) GetUTXOs(
+	context.Context,
+	[]ids.ShortID,
+	uint32,
+	ids.ShortID,
+	ids.ID,
+	...rpc.Option,
+) ([][]byte, ids.ShortID, ids.ID, error) {
+	return c.utxos, ids.ShortEmpty, ids.Empty, nil
+}
+
+func (c *testPClient) IssueTx(_ context.Context, txBytes []byte, _ ...rpc.Option) (ids.ID, error) {
+	if len(c.issueErrs) > 0 {
+		err := c.issueErrs[0]
+		c.issueErrs = c.issueErrs[1:]
+		return ids.Empty, err
+	}
+	c.issued = append(c.issued, txBytes)
+	return ids.Empty, nil
+}
+
+func (*testPClient) GetTxStatus(context.Context, ids.ID, ...rpc.Option) (*platformvm.GetTxStatusResponse, error) {
+	return &platformvm.GetTxStatusResponse{Status: status.Unknown}, nil
+}
+
+func (c *testPClient) AwaitTxDecided(
+	context.Context,
+	ids.ID,
+	time.Duration,
+	...rpc.Option,
+) (*platformvm.GetTxStatusResponse, error) {
+	return &platformvm.GetTxStatusResponse{Status: c.status}, nil
+}
+
+func (c *testPClient) GetRewardUTXOs(context.Context, *api.GetTxArgs, ...rpc.Option) ([][]byte, error) {
+	return c.rewardUTXOs, nil
+}
+
+func newTestUTXO(t *testing.T, addr ids.ShortID, amount uint64) (*avax.UTXO, []byte) {
+	utxo := &avax.UTXO{
+		UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  avax.Asset{ID: testAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: amount,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr},
+			},
+		},
+	}
+	utxoBytes, err := txs.Codec.Marshal(txs.CodecVersion, utxo)
+	require.NoError(t, err)
+	return utxo, utxoBytes
+}
+
+func TestClientDelegate(t *testing.T) {
+	require := require.New(t)
+
+	key, err := secp256k1.NewPrivateKey()
+	require.NoError(err)
+	addr := key.Address()
+
+	_, utxoBytes := newTestUTXO(t, addr, 10*units.Avax)
+	pClient := &testPClient{
+		utxos:     [][]byte{utxoBytes},
+		issueErrs: []error{errTest},
+		status:    status.Committed,
+	}
+
+	ctx := context.Background()
+	c, err := NewFromClients(ctx, &testInfoClient{}, pClient, Config{
+		Keychain:   secp256k1fx.NewKeychain(key),
+		RetryDelay: time.Millisecond,
+	})
+	require.NoError(err)
+
+	args := &DelegateArgs{
+		NodeID:        ids.GenerateTestNodeID(),
+		StartTime:     time.Unix(1, 0),
+		EndTime:       time.Unix(2, 0),
+		Weight:        5 * units.Avax,
+		RewardAddress: addr,
+	}
+	utx, err := c.BuildDelegateTx(ctx, args)
+	require.NoError(err)
+
+	fee, err := c.EstimateFee(utx)
+	require.NoError(err)
+	require.Equal(uint64(testDelegatorFee), fee)
+
+	// The first attempt to issue the tx fails and is retried.
+	tx, err := c.Delegate(ctx, args)
+	require.NoError(err)
+	require.Equal([][]byte{tx.Bytes()}, pClient.issued)
+	require.Len(tx.Creds, 1)
+
+	issuedTx, err := txs.Parse(txs.Codec, pClient.issued[0])
+	require.NoError(err)
+	require.IsType(&txs.AddPermissionlessDelegatorTx{}, issuedTx.Unsigned)
+	require.Equal(uint32(testNetworkID), issuedTx.Unsigned.(*txs.AddPermissionlessDelegatorTx).NetworkID)
+
+	require.NoError(c.WaitForAcceptance(ctx, tx.ID()))
+
+	pClient.status = status.Aborted
+	err = c.WaitForAcceptance(ctx, tx.ID())
+	require.ErrorIs(err, ErrNotCommitted)
+}
+
+func TestClientIssueRetriesExhausted(t *testing.T) {
+	require := require.New(t)
+
+	key, err := secp256k1.NewPrivateKey()
+	require.NoError(err)
+
+	_, utxoBytes := newTestUTXO(t, key.Address(), 10*units.Avax)
+	pClient := &testPClient{
+		utxos:     [][]byte{utxoBytes},
+		issueErrs: []error{errTest, errTest},
+	}
+
+	ctx := context.Background()
+	c, err := NewFromClients(ctx, &testInfoClient{}, pClient, Config{
+		Keychain:    secp256k1fx.NewKeychain(key),
+		MaxAttempts: 2,
+		RetryDelay:  time.Millisecond,
+	})
+	require.NoError(err)
+
+	_, err = c.Delegate(ctx, &DelegateArgs{
+		NodeID:        ids.GenerateTestNodeID(),
+		StartTime:     time.Unix(1, 0),
+		EndTime:       time.Unix(2, 0),
+		Weight:        units.Avax,
+		RewardAddress: key.Address(),
+	})
+	require.ErrorIs(err, errTest)
+	require.Empty(pClient.issued)
+}
+
+func TestClientGetRewards(t *testing.T) {
+	require := require.New(t)
+
+	addr := ids.GenerateTestShortID()
+	rewardUTXO, rewardUTXOBytes := newTestUTXO(t, addr, units.Avax)
+	pClient := &testPClient{
+		rewardUTXOs: [][]byte{rewardUTXOBytes},
+	}
+
+	ctx := context.Background()
+	c, err := NewFromClients(ctx, &testInfoClient{}, pClient, Config{
+		Keychain: secp256k1fx.NewKeychain(),
+	})
+	require.NoError(err)
+
+	utxos, err := c.GetRewards(ctx, ids.GenerateTestID())
+	require.NoError(err)
+	require.Len(utxos, 1)
+	require.Equal(rewardUTXO.InputID(), utxos[0].InputID())
+	require.Equal(rewardUTXO.Out, utxos[0].Out)
+}
@@ -0,0 +1,117 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+var errIncompatiblePeer = errors.New("peer version is incompatible")
+
+// peerCompatibility tracks the connected peers whose version is incompatible
+// with the version of this node. The messages of incompatible peers are
+// dropped before they are parsed, as they may be encoded with codecs that
+// this node doesn't support.
+type peerCompatibility struct {
+	networkID     uint32
+	compatibility version.Compatibility
+
+	lock         sync.RWMutex
+	incompatible set.Set[ids.NodeID]
+	// nodeID --> version of the connected peers
+	versions map[ids.NodeID]*version.Application
+}
+
+func newPeerCompatibility(networkID uint32) *peerCompatibility {
+	return &peerCompatibility{
+		networkID:     networkID,
+		compatibility: version.GetCompatibility(networkID),
+		versions:      make(map[ids.NodeID]*version.Application),
+	}
+}
+
+// minimumVersion returns the minimum version of the peers at [now].
+func (p *peerCompatibility) minimumVersion(now time.Time) *version.Application {
+	return version.GetMinimumCompatibleVersion(p.networkID, now)
+}
+
+// connect checks the version of [nodeID]. If it's incompatible, the peer is
+// tracked until it disconnects and an error describing the mismatch is
+// returned.
+func (p *peerCompatibility) connect(nodeID ids.NodeID, nodeVersion *version.Application, now time.Time) error {
+	if nodeVersion == nil {
+		return nil
+	}
+
+	p.lock.Lock()
+	p.versions[nodeID] = nodeVersion
+	p.lock.Unlock()
+
+	if err := p.compatibility.Compatible(nodeVersion); err != nil {
+		p.lock.Lock()
+		p.incompatible.Add(nodeID)
+		p.lock.Unlock()
+
+		return fmt.Errorf("%w: %s runs %s but %s requires at least %s: %w",
+			errIncompatiblePeer,
+			nodeID,
+			nodeVersion,
+			p.compatibility.Version(),
+			p.minimumVersion(now),
+			err,
+		)
+	}
+	return nil
+}
+
+func (p *peerCompatibility) disconnect(nodeID ids.NodeID) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.incompatible.Remove(nodeID)
+	delete(p.versions, nodeID)
+}
+
+func (p *peerCompatibility) isIncompatible(nodeID ids.NodeID) bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.incompatible.Contains(nodeID)
+}
+
+// AppGossip drops the gossip of incompatible peers.
+func (vm *VM) AppGossip(ctx context.Context, nodeID ids.NodeID, msg []byte) error {
+	if vm.peerCompatibility.isIncompatible(nodeID) {
+		vm.ctx.Log.Debug("dropping gossip",
+			zap.String("reason", "peer version is incompatible"),
+			zap.Stringer("nodeID", nodeID),
+		)
+		return nil
+	}
+	return vm.Network.AppGossip(ctx, nodeID, msg)
+}
+
+// AppRequest drops the requests of incompatible peers. The requests time out
+// on the peers.
+func (vm *VM) AppRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, deadline time.Time, msg []byte) error {
+	if vm.peerCompatibility.isIncompatible(nodeID) {
+		vm.ctx.Log.Debug("dropping request",
+			zap.String("reason", "peer version is incompatible"),
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+		)
+		return nil
+	}
+	return vm.Network.AppRequest(ctx, nodeID, requestID, deadline, msg)
+}
@@ -4,6 +4,8 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/ava-labs/avalanchego/chains"
@@ -14,8 +16,11 @@ import (
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
+var ErrUnknownFork = errors.New("unknown network upgrade")
+
 // Struct collecting all foundational parameters of PlatformVM
 type Config struct {
 	// The node's chain manager
@@ -68,6 +73,10 @@ type Config struct {
 	// Transaction fee for adding a subnet delegator
 	AddSubnetDelegatorFee uint64
 
+	// Fee that is burned by every name registering or updating transaction,
+	// unless it was changed by governance
+	NameRegistrationFee uint64
+
 	// The minimum amount of tokens one must bond to be a validator
 	MinValidatorStake uint64
 
@@ -107,6 +116,104 @@ type Config struct {
 	// Time of the Durango network upgrade
 	DurangoTime time.Time
 
+	// Time after which [FeeTreasuryPercentage] of the fees burned by txs are
+	// sent to [FeeTreasuryAddress] instead
+	FeeTreasuryTime time.Time
+
+	// Time after which the total complexity of the txs in a block is limited
+	// to [MaxBlockComplexity]
+	BlockComplexityTime time.Time
+
+	// Time after which the primary network validators are no longer
+	// restricted to [ValidatorAllowlist]
+	OpenValidatorSetTime time.Time
+
+	// Time after which the rewards of the stakers accrue to the claimable
+	// balance of their rewards owners, which is claimed with a ClaimRewardTx,
+	// instead of being paid out as UTXOs. Rewards are always paid out as UTXOs
+	// if zero.
+	ClaimableRewardsTime time.Time
+
+	// Time after which the start time of a staker may be at most
+	// [StakerStartHorizon] ahead of the chain time. Staker start times are
+	// only bounded by the default maximum if zero.
+	StakerStartHorizonTime time.Time
+
+	// Maximum duration that the start time of a staker may be ahead of the
+	// chain time once [StakerStartHorizonTime] has passed. It only tightens
+	// the default maximum.
+	StakerStartHorizon time.Duration
+
+	// Time after which the rewards owners that bound a C-chain address with a
+	// BindRewardAddressTx have the rewards paid out to them by
+	// RewardValidatorTxs exported to that address. Rewards are never exported
+	// if zero.
+	RewardExportTime time.Time
+
+	// Time after which the allow lists of the subnets can be modified with
+	// AddSubnetAllowListEntriesTxs and RemoveSubnetAllowListEntriesTxs. The
+	// allow lists can't be modified if zero.
+	SubnetAllowListTime time.Time
+
+	// Time after which validators can be added with an
+	// AddCappedPermissionlessValidatorTx. Capped validators can't be added if
+	// zero.
+	CappedDelegationTime time.Time
+
+	// Time after which permissionless validators can be removed before their end
+	// time with an ExitValidatorTx. Validators can't exit early if zero.
+	ExitValidatorTime time.Time
+
+	// Time after which the weight of PoA subnet validators can be changed with a
+	// SetSubnetValidatorWeightTx. Weights can't be changed if zero.
+	SubnetValidatorWeightTime time.Time
+
+	// Time after which the runtime parameters of the chain can be changed with a
+	// ParameterChangeTx. Parameters can't be changed if zero.
+	ParameterChangeTime time.Time
+
+	// Time after which the nodeID and BLS key of permissionless primary network
+	// validators can be replaced with a RekeyValidatorTx. Validators can't be
+	// re-keyed if zero.
+	RekeyValidatorTime time.Time
+
+	// Time after which names can be registered and updated with RegisterNameTxs
+	// and UpdateNameTxs. Names can't be registered if zero.
+	NameRegistryTime time.Time
+
+	// Time after which validators can be added with the metadata committed to by
+	// an AddPermissionlessValidatorWithMetadataTx. Validators can't be added with
+	// metadata if zero.
+	ValidatorMetadataTime time.Time
+
+	// ValidatorAllowlist, if non-nil, restricts the nodes that may be added as
+	// primary network validators before [OpenValidatorSetTime].
+	ValidatorAllowlist set.Set[ids.NodeID]
+
+	// Address that receives the fees redirected to the fee treasury
+	FeeTreasuryAddress ids.ShortID
+
+	// Share of the burned fees, in units of [reward.PercentDenominator], that
+	// is redirected to the fee treasury. Fees are burned in full if zero.
+	FeeTreasuryPercentage uint64
+
+	// Time after which the txs of the subnets in [SubnetFeeAssets] may pay
+	// their fees with the fee assets of the subnets. Fees are only paid with
+	// AVAX if zero.
+	SubnetFeeAssetTime time.Time
+
+	// Assets that the txs of a subnet may pay their fees with instead of
+	// AVAX, by subnet.
+	SubnetFeeAssets map[ids.ID]SubnetFeeAsset
+
+	// GovernanceOwner authorizes the ParameterChangeTxs that change the
+	// runtime parameters of the chain. Parameters can't be changed if nil.
+	GovernanceOwner *secp256k1fx.OutputOwners
+
+	// Max total complexity, as computed by [txs.Complexity], of the txs in a
+	// block after [BlockComplexityTime]. Blocks aren't limited if zero.
+	MaxBlockComplexity uint64
+
 	// UseCurrentHeight forces [GetMinimumHeight] to return the current height
 	// of the P-Chain instead of the oldest block in the [recentlyAccepted]
 	// window.
@@ -115,26 +222,219 @@ type Config struct {
 	// on recently created subnets (without this, users need to wait for
 	// [recentlyAcceptedWindowTTL] to pass for activation to occur).
 	UseCurrentHeight bool
+
+	// ForkObserver, if non-nil, is called with the result of every check of
+	// the activation of a network upgrade. It is only set to trace the
+	// fork-gated branches taken while verifying a block.
+	ForkObserver func(ForkCheck)
+}
+
+// ForkCheck is the result of checking whether a network upgrade is activated
+// at a timestamp.
+type ForkCheck struct {
+	Fork      string `json:"fork"`
+	Timestamp int64  `json:"timestamp"`
+	Activated bool   `json:"activated"`
+}
+
+// SubnetFeeAsset is an asset that the AddSubnetValidatorTxs and CreateChainTxs
+// of a subnet may pay their fees with.
+type SubnetFeeAsset struct {
+	AssetID ids.ID
+	// Fee, in [AssetID], of an AddSubnetValidatorTx
+	AddSubnetValidatorFee uint64
+	// Fee, in [AssetID], of a CreateChainTx
+	CreateBlockchainTxFee uint64
+	// Address that the fees are swept to. The fees are burned if empty.
+	Treasury ids.ShortID
 }
 
 func (c *Config) IsApricotPhase3Activated(timestamp time.Time) bool {
-	return !timestamp.Before(c.ApricotPhase3Time)
+	return c.observeFork("apricotPhase3", timestamp, !timestamp.Before(c.ApricotPhase3Time))
 }
 
 func (c *Config) IsApricotPhase5Activated(timestamp time.Time) bool {
-	return !timestamp.Before(c.ApricotPhase5Time)
+	return c.observeFork("apricotPhase5", timestamp, !timestamp.Before(c.ApricotPhase5Time))
 }
 
 func (c *Config) IsBanffActivated(timestamp time.Time) bool {
-	return !timestamp.Before(c.BanffTime)
+	return c.observeFork("banff", timestamp, !timestamp.Before(c.BanffTime))
 }
 
 func (c *Config) IsCortinaActivated(timestamp time.Time) bool {
-	return !timestamp.Before(c.CortinaTime)
+	return c.observeFork("cortina", timestamp, !timestamp.Before(c.CortinaTime))
 }
 
 func (c *Config) IsDurangoActivated(timestamp time.Time) bool {
-	return !timestamp.Before(c.DurangoTime)
+	return c.observeFork("durango", timestamp, !timestamp.Before(c.DurangoTime))
+}
+
+func (c *Config) IsFeeTreasuryActivated(timestamp time.Time) bool {
+	return c.observeFork("feeTreasury", timestamp, c.FeeTreasuryPercentage > 0 && !timestamp.Before(c.FeeTreasuryTime))
+}
+
+func (c *Config) IsBlockComplexityActivated(timestamp time.Time) bool {
+	return c.observeFork("blockComplexity", timestamp, c.MaxBlockComplexity > 0 && !timestamp.Before(c.BlockComplexityTime))
+}
+
+func (c *Config) IsOpenValidatorSetActivated(timestamp time.Time) bool {
+	return c.observeFork("openValidatorSet", timestamp, !timestamp.Before(c.OpenValidatorSetTime))
+}
+
+func (c *Config) IsClaimableRewardsActivated(timestamp time.Time) bool {
+	return c.observeFork("claimableRewards", timestamp, !c.ClaimableRewardsTime.IsZero() && !timestamp.Before(c.ClaimableRewardsTime))
+}
+
+func (c *Config) IsStakerStartHorizonActivated(timestamp time.Time) bool {
+	return c.observeFork("stakerStartHorizon", timestamp, !c.StakerStartHorizonTime.IsZero() && !timestamp.Before(c.StakerStartHorizonTime))
+}
+
+func (c *Config) IsRewardExportActivated(timestamp time.Time) bool {
+	return c.observeFork("rewardExport", timestamp, !c.RewardExportTime.IsZero() && !timestamp.Before(c.RewardExportTime))
+}
+
+func (c *Config) IsSubnetFeeAssetActivated(timestamp time.Time) bool {
+	return c.observeFork("subnetFeeAsset", timestamp, !c.SubnetFeeAssetTime.IsZero() && !timestamp.Before(c.SubnetFeeAssetTime))
+}
+
+func (c *Config) IsSubnetAllowListActivated(timestamp time.Time) bool {
+	return c.observeFork("subnetAllowList", timestamp, !c.SubnetAllowListTime.IsZero() && !timestamp.Before(c.SubnetAllowListTime))
+}
+
+func (c *Config) IsCappedDelegationActivated(timestamp time.Time) bool {
+	return c.observeFork("cappedDelegation", timestamp, !c.CappedDelegationTime.IsZero() && !timestamp.Before(c.CappedDelegationTime))
+}
+
+func (c *Config) IsExitValidatorActivated(timestamp time.Time) bool {
+	return c.observeFork("exitValidator", timestamp, !c.ExitValidatorTime.IsZero() && !timestamp.Before(c.ExitValidatorTime))
+}
+
+func (c *Config) IsSubnetValidatorWeightActivated(timestamp time.Time) bool {
+	return c.observeFork("subnetValidatorWeight", timestamp, !c.SubnetValidatorWeightTime.IsZero() && !timestamp.Before(c.SubnetValidatorWeightTime))
+}
+
+func (c *Config) IsParameterChangeActivated(timestamp time.Time) bool {
+	return c.observeFork("parameterChange", timestamp, !c.ParameterChangeTime.IsZero() && !timestamp.Before(c.ParameterChangeTime))
+}
+
+func (c *Config) IsRekeyValidatorActivated(timestamp time.Time) bool {
+	return c.observeFork("rekeyValidator", timestamp, !c.RekeyValidatorTime.IsZero() && !timestamp.Before(c.RekeyValidatorTime))
+}
+
+func (c *Config) IsNameRegistryActivated(timestamp time.Time) bool {
+	return c.observeFork("nameRegistry", timestamp, !c.NameRegistryTime.IsZero() && !timestamp.Before(c.NameRegistryTime))
+}
+
+func (c *Config) IsValidatorMetadataActivated(timestamp time.Time) bool {
+	return c.observeFork("validatorMetadata", timestamp, !c.ValidatorMetadataTime.IsZero() && !timestamp.Before(c.ValidatorMetadataTime))
+}
+
+// NextFork returns the name and the time of the first network upgrade
+// scheduled after [timestamp]. False is returned if every upgrade is activated
+// at [timestamp].
+func (c *Config) NextFork(timestamp time.Time) (string, time.Time, bool) {
+	var (
+		nextFork string
+		nextTime time.Time
+	)
+	for _, fork := range c.forks() {
+		if fork.time.After(timestamp) && (nextFork == "" || fork.time.Before(nextTime)) {
+			nextFork = fork.name
+			nextTime = *fork.time
+		}
+	}
+	return nextFork, nextTime, nextFork != ""
+}
+
+// ActivateNextFork returns the name of the first network upgrade scheduled
+// after [timestamp] and a copy of the config in which it is activated at
+// [timestamp]. Upgrades scheduled at the same time are activated together.
+// False is returned if every upgrade is activated at [timestamp].
+func (c *Config) ActivateNextFork(timestamp time.Time) (string, *Config, bool) {
+	nextFork, nextTime, ok := c.NextFork(timestamp)
+	if !ok {
+		return "", nil, false
+	}
+
+	next := *c
+	for _, fork := range next.forks() {
+		if fork.time.Equal(nextTime) {
+			*fork.time = timestamp
+		}
+	}
+	return nextFork, &next, true
+}
+
+// ActivateFork returns a copy of the config in which the network upgrade named
+// [name], along with every upgrade scheduled before it, is activated at
+// [timestamp]. The upgrades that are activated at [timestamp] already are left
+// unchanged.
+func (c *Config) ActivateFork(name string, timestamp time.Time) (*Config, error) {
+	var forkTime *time.Time
+	for _, fork := range c.forks() {
+		if fork.name == name {
+			forkTime = fork.time
+		}
+	}
+	if forkTime == nil {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFork, name)
+	}
+
+	next := *c
+	activationTime := *forkTime
+	for _, fork := range next.forks() {
+		scheduledBefore := fork.time.After(timestamp) && !fork.time.After(activationTime)
+		// Upgrades without a time are disabled rather than activated at
+		// genesis, so they are only activated if they are named.
+		disabled := fork.name == name && fork.time.IsZero()
+		if scheduledBefore || disabled {
+			*fork.time = timestamp
+		}
+	}
+	return &next, nil
+}
+
+type fork struct {
+	name string
+	time *time.Time
+}
+
+// forks returns the network upgrades of the config. The times of the upgrades
+// point into the config.
+func (c *Config) forks() []fork {
+	return []fork{
+		{name: "apricotPhase3", time: &c.ApricotPhase3Time},
+		{name: "apricotPhase5", time: &c.ApricotPhase5Time},
+		{name: "banff", time: &c.BanffTime},
+		{name: "cortina", time: &c.CortinaTime},
+		{name: "durango", time: &c.DurangoTime},
+		{name: "feeTreasury", time: &c.FeeTreasuryTime},
+		{name: "blockComplexity", time: &c.BlockComplexityTime},
+		{name: "openValidatorSet", time: &c.OpenValidatorSetTime},
+		{name: "claimableRewards", time: &c.ClaimableRewardsTime},
+		{name: "stakerStartHorizon", time: &c.StakerStartHorizonTime},
+		{name: "rewardExport", time: &c.RewardExportTime},
+		{name: "subnetFeeAsset", time: &c.SubnetFeeAssetTime},
+		{name: "subnetAllowList", time: &c.SubnetAllowListTime},
+		{name: "cappedDelegation", time: &c.CappedDelegationTime},
+		{name: "exitValidator", time: &c.ExitValidatorTime},
+		{name: "subnetValidatorWeight", time: &c.SubnetValidatorWeightTime},
+		{name: "parameterChange", time: &c.ParameterChangeTime},
+		{name: "rekeyValidator", time: &c.RekeyValidatorTime},
+		{name: "nameRegistry", time: &c.NameRegistryTime},
+		{name: "validatorMetadata", time: &c.ValidatorMetadataTime},
+	}
+}
+
+func (c *Config) observeFork(fork string, timestamp time.Time, activated bool) bool {
+	if c.ForkObserver != nil {
+		c.ForkObserver(ForkCheck{
+			Fork:      fork,
+			Timestamp: timestamp.Unix(),
+			Activated: activated,
+		})
+	}
+	return activated
 }
 
 func (c *Config) GetCreateBlockchainTxFee(timestamp time.Time) uint64 {
@@ -7,37 +7,130 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/platformvm/network"
 )
 
 var DefaultExecutionConfig = ExecutionConfig{
-	Network:                      network.DefaultConfig,
-	BlockCacheSize:               64 * units.MiB,
-	TxCacheSize:                  128 * units.MiB,
-	TransformedSubnetTxCacheSize: 4 * units.MiB,
-	RewardUTXOsCacheSize:         2048,
-	ChainCacheSize:               2048,
-	ChainDBCacheSize:             2048,
-	BlockIDCacheSize:             8192,
-	FxOwnerCacheSize:             4 * units.MiB,
-	ChecksumsEnabled:             false,
-	MempoolPruneFrequency:        30 * time.Minute,
+	Network:                        network.DefaultConfig,
+	BlockCacheSize:                 64 * units.MiB,
+	TxCacheSize:                    128 * units.MiB,
+	TransformedSubnetTxCacheSize:   4 * units.MiB,
+	RewardUTXOsCacheSize:           2048,
+	ChainCacheSize:                 2048,
+	ChainDBCacheSize:               2048,
+	BlockIDCacheSize:               8192,
+	FxOwnerCacheSize:               4 * units.MiB,
+	ChecksumsEnabled:               false,
+	MempoolPruneFrequency:          30 * time.Minute,
+	ValidatorSetCheckpointInterval: 0,
+	RecentValidatorSetsStoreSize:   0,
+	VerificationTracingEnabled:     false,
+	DisabledTxTypes:                nil,
+	DroppedTxIndexSize:             4096,
+	MaxSubnetMetricLabels:          16,
+	CompactionWindowStart:          0,
+	CompactionWindowLength:         0,
+	AdminAPIEnabled:                false,
+	IntegrityCheckEnabled:          false,
+	RestoreBackupPath:              "",
+	ColdBlockDepth:                 0,
+	ColdBlockDBPath:                "",
+	BlockCompressionEnabled:        false,
+	BootstrapCommitInterval:        128,
+	TrustedCheckpointHeight:        0,
+	TrustedCheckpointBlockID:       ids.Empty,
+	APIAddressAllowLists:           nil,
+	RewardWatchlist:                nil,
+	RewardWatchlistSize:            1024,
+	RecoveryKeys:                   nil,
+	RecoveryThreshold:              0,
+	StateAttestationInterval:       0,
+	HeartbeatInterval:              0,
+	ClockOverrideEnabled:           false,
+	InvariantCheckInterval:         0,
+	InvariantViolationsFatal:       false,
+	ShadowExecutionEnabled:         false,
+	DelegationReservationDuration:  0,
+	ReadReplicaPrimaryURI:          "",
+	BlockPrevalidationEnabled:      false,
+	MaxProcessingBlocks:            0,
+	Profile:                        "",
+	AutoImportInterval:             0,
+	AutoImportMaxTxsPerInterval:    1,
+	AutoImportLogSize:              1024,
+	TxStages:                       nil,
+	DeniedAddresses:                nil,
+	AlertValidatorExpiryWindow:     0,
+	AlertLowConnectivityEnabled:    false,
+	AlertMaxMempoolAge:             0,
+	AlertWebhookURLs:               nil,
+	IssueTxIPQuota:                 0,
+	IssueTxIPQuotaPeriod:           time.Minute,
+	IssueTxAddressQuota:            0,
+	IssueTxAddressQuotaPeriod:      time.Hour,
 }
 
 // ExecutionConfig provides execution parameters of PlatformVM
 type ExecutionConfig struct {
-	Network                      network.Config `json:"network"`
-	BlockCacheSize               int            `json:"block-cache-size"`
-	TxCacheSize                  int            `json:"tx-cache-size"`
-	TransformedSubnetTxCacheSize int            `json:"transformed-subnet-tx-cache-size"`
-	RewardUTXOsCacheSize         int            `json:"reward-utxos-cache-size"`
-	ChainCacheSize               int            `json:"chain-cache-size"`
-	ChainDBCacheSize             int            `json:"chain-db-cache-size"`
-	BlockIDCacheSize             int            `json:"block-id-cache-size"`
-	FxOwnerCacheSize             int            `json:"fx-owner-cache-size"`
-	ChecksumsEnabled             bool           `json:"checksums-enabled"`
-	MempoolPruneFrequency        time.Duration  `json:"mempool-prune-frequency"`
+	Network                        network.Config      `json:"network"`
+	BlockCacheSize                 int                 `json:"block-cache-size"`
+	TxCacheSize                    int                 `json:"tx-cache-size"`
+	TransformedSubnetTxCacheSize   int                 `json:"transformed-subnet-tx-cache-size"`
+	RewardUTXOsCacheSize           int                 `json:"reward-utxos-cache-size"`
+	ChainCacheSize                 int                 `json:"chain-cache-size"`
+	ChainDBCacheSize               int                 `json:"chain-db-cache-size"`
+	BlockIDCacheSize               int                 `json:"block-id-cache-size"`
+	FxOwnerCacheSize               int                 `json:"fx-owner-cache-size"`
+	ChecksumsEnabled               bool                `json:"checksums-enabled"`
+	MempoolPruneFrequency          time.Duration       `json:"mempool-prune-frequency"`
+	ValidatorSetCheckpointInterval uint64              `json:"validator-set-checkpoint-interval"`
+	RecentValidatorSetsStoreSize   int                 `json:"recent-validator-sets-store-size"`
+	VerificationTracingEnabled     bool                `json:"verification-tracing-enabled"`
+	DisabledTxTypes                []string            `json:"disabled-tx-types"`
+	DroppedTxIndexSize             int                 `json:"dropped-tx-index-size"`
+	MaxSubnetMetricLabels          int                 `json:"max-subnet-metric-labels"`
+	CompactionWindowStart          time.Duration       `json:"compaction-window-start"`
+	CompactionWindowLength         time.Duration       `json:"compaction-window-length"`
+	AdminAPIEnabled                bool                `json:"admin-api-enabled"`
+	IntegrityCheckEnabled          bool                `json:"integrity-check-enabled"`
+	RestoreBackupPath              string              `json:"restore-backup-path"`
+	ColdBlockDepth                 uint64              `json:"cold-block-depth"`
+	ColdBlockDBPath                string              `json:"cold-block-db-path"`
+	BlockCompressionEnabled        bool                `json:"block-compression-enabled"`
+	BootstrapCommitInterval        int                 `json:"bootstrap-commit-interval"`
+	TrustedCheckpointHeight        uint64              `json:"trusted-checkpoint-height"`
+	TrustedCheckpointBlockID       ids.ID              `json:"trusted-checkpoint-block-id"`
+	APIAddressAllowLists           map[string][]string `json:"api-address-allow-lists"`
+	RewardWatchlist                []string            `json:"reward-watchlist"`
+	RewardWatchlistSize            int                 `json:"reward-watchlist-size"`
+	RecoveryKeys                   []string            `json:"recovery-keys"`
+	RecoveryThreshold              int                 `json:"recovery-threshold"`
+	StateAttestationInterval       uint64              `json:"state-attestation-interval"`
+	HeartbeatInterval              time.Duration       `json:"heartbeat-interval"`
+	ClockOverrideEnabled           bool                `json:"clock-override-enabled"`
+	InvariantCheckInterval         uint64              `json:"invariant-check-interval"`
+	InvariantViolationsFatal       bool                `json:"invariant-violations-fatal"`
+	ShadowExecutionEnabled         bool                `json:"shadow-execution-enabled"`
+	DelegationReservationDuration  time.Duration       `json:"delegation-reservation-duration"`
+	ReadReplicaPrimaryURI          string              `json:"read-replica-primary-uri"`
+	BlockPrevalidationEnabled      bool                `json:"block-prevalidation-enabled"`
+	MaxProcessingBlocks            int                 `json:"max-processing-blocks"`
+	Profile                        string              `json:"profile"`
+	AutoImportInterval             time.Duration       `json:"auto-import-interval"`
+	AutoImportMaxTxsPerInterval    int                 `json:"auto-import-max-txs-per-interval"`
+	AutoImportLogSize              int                 `json:"auto-import-log-size"`
+	TxStages                       []string            `json:"tx-stages"`
+	DeniedAddresses                []string            `json:"denied-addresses"`
+	AlertValidatorExpiryWindow     time.Duration       `json:"alert-validator-expiry-window"`
+	AlertLowConnectivityEnabled    bool                `json:"alert-low-connectivity-enabled"`
+	AlertMaxMempoolAge             time.Duration       `json:"alert-max-mempool-age"`
+	AlertWebhookURLs               []string            `json:"alert-webhook-urls"`
+	IssueTxIPQuota                 int                 `json:"issue-tx-ip-quota"`
+	IssueTxIPQuotaPeriod           time.Duration       `json:"issue-tx-ip-quota-period"`
+	IssueTxAddressQuota            int                 `json:"issue-tx-address-quota"`
+	IssueTxAddressQuotaPeriod      time.Duration       `json:"issue-tx-address-quota-period"`
 }
 
 // GetExecutionConfig returns an ExecutionConfig
@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/vms/platformvm/network"
 )
 
@@ -41,6 +42,8 @@ func TestExecutionConfigUnmarshal(t *testing.T) {
 
 	t.Run("all values extracted from json", func(t *testing.T) {
 		require := require.New(t)
+		relayNodeID, err := ids.NodeIDFromString("NodeID-7Xhw2mDxuDS44j42TCB6U5579esbSt3Lg")
+		require.NoError(err)
 		b := []byte(`{
 			"network": {
 				"max-validator-set-staleness": 1,
@@ -52,7 +55,12 @@ func TestExecutionConfigUnmarshal(t *testing.T) {
 				"expected-bloom-filter-elements":7,
 				"expected-bloom-filter-false-positive-probability": 8,
 				"max-bloom-filter-false-positive-probability": 9,
-				"legacy-push-gossip-cache-size": 10
+				"legacy-push-gossip-cache-size": 10,
+				"subnet-tx-gossip": true,
+				"ban-score-threshold": 11,
+				"ban-score-half-life": 12,
+				"ban-duration": 13,
+				"relay-node-ids": ["NodeID-7Xhw2mDxuDS44j42TCB6U5579esbSt3Lg"]
 			},
 			"block-cache-size": 1,
 			"tx-cache-size": 2,
@@ -63,7 +71,53 @@ func TestExecutionConfigUnmarshal(t *testing.T) {
 			"block-id-cache-size": 8,
 			"fx-owner-cache-size": 9,
 			"checksums-enabled": true,
-			"mempool-prune-frequency": 60000000000
+			"mempool-prune-frequency": 60000000000,
+			"validator-set-checkpoint-interval": 10,
+			"recent-validator-sets-store-size": 11,
+			"verification-tracing-enabled": true,
+			"disabled-tx-types": ["AddValidatorTx", "CreateChainTx"],
+			"dropped-tx-index-size": 12,
+			"max-subnet-metric-labels": 13,
+			"compaction-window-start": 14,
+			"compaction-window-length": 15,
+			"admin-api-enabled": true,
+			"integrity-check-enabled": true,
+			"restore-backup-path": "backup.tar.zst",
+			"cold-block-depth": 16,
+			"cold-block-db-path": "cold",
+			"block-compression-enabled": true,
+			"bootstrap-commit-interval": 32,
+			"trusted-checkpoint-height": 1000,
+			"trusted-checkpoint-block-id": "SkB7qHwfMsyF2PgrjhMvtFxJKhuR5ZfVoW9VATWRV4P9jV7J",
+			"api-address-allow-lists": {"token": ["P-local18jma8ppw3nhx5r4ap8clazz0dps7rv5u00z96u"]},
+			"reward-watchlist": ["P-local18jma8ppw3nhx5r4ap8clazz0dps7rv5u00z96u"],
+			"reward-watchlist-size": 17,
+			"recovery-keys": ["P-local18jma8ppw3nhx5r4ap8clazz0dps7rv5u00z96u"],
+			"recovery-threshold": 1,
+			"state-attestation-interval": 18,
+			"heartbeat-interval": 19,
+			"clock-override-enabled": true,
+			"invariant-check-interval": 20,
+			"invariant-violations-fatal": true,
+			"shadow-execution-enabled": true,
+			"delegation-reservation-duration": 21,
+			"read-replica-primary-uri": "http://127.0.0.1:9650/ext/bc/P",
+			"block-prevalidation-enabled": true,
+			"max-processing-blocks": 22,
+			"profile": "coston2",
+			"auto-import-interval": 23,
+			"auto-import-max-txs-per-interval": 24,
+			"auto-import-log-size": 25,
+			"tx-stages": ["screening"],
+			"denied-addresses": ["P-local18jma8ppw3nhx5r4ap8clazz0dps7rv5u00z96u"],
+			"alert-validator-expiry-window": 26,
+			"alert-low-connectivity-enabled": true,
+			"alert-max-mempool-age": 27,
+			"alert-webhook-urls": ["http://127.0.0.1:8080/alerts"],
+			"issue-tx-ip-quota": 28,
+			"issue-tx-ip-quota-period": 29,
+			"issue-tx-address-quota": 30,
+			"issue-tx-address-quota-period": 31
 		}`)
 		ec, err := GetExecutionConfig(b)
 		require.NoError(err)
@@ -79,17 +133,70 @@ func TestExecutionConfigUnmarshal(t *testing.T) {
 				ExpectedBloomFilterFalsePositiveProbability: 8,
 				MaxBloomFilterFalsePositiveProbability:      9,
 				LegacyPushGossipCacheSize:                   10,
+				SubnetTxGossip:                              true,
+				BanScoreThreshold:                           11,
+				BanScoreHalfLife:                            12,
+				BanDuration:                                 13,
+				RelayNodeIDs:                                []ids.NodeID{relayNodeID},
 			},
-			BlockCacheSize:               1,
-			TxCacheSize:                  2,
-			TransformedSubnetTxCacheSize: 3,
-			RewardUTXOsCacheSize:         5,
-			ChainCacheSize:               6,
-			ChainDBCacheSize:             7,
-			BlockIDCacheSize:             8,
-			FxOwnerCacheSize:             9,
-			ChecksumsEnabled:             true,
-			MempoolPruneFrequency:        time.Minute,
+			BlockCacheSize:                 1,
+			TxCacheSize:                    2,
+			TransformedSubnetTxCacheSize:   3,
+			RewardUTXOsCacheSize:           5,
+			ChainCacheSize:                 6,
+			ChainDBCacheSize:               7,
+			BlockIDCacheSize:               8,
+			FxOwnerCacheSize:               9,
+			ChecksumsEnabled:               true,
+			MempoolPruneFrequency:          time.Minute,
+			ValidatorSetCheckpointInterval: 10,
+			RecentValidatorSetsStoreSize:   11,
+			VerificationTracingEnabled:     true,
+			DisabledTxTypes:                []string{"AddValidatorTx", "CreateChainTx"},
+			DroppedTxIndexSize:             12,
+			MaxSubnetMetricLabels:          13,
+			CompactionWindowStart:          14,
+			CompactionWindowLength:         15,
+			AdminAPIEnabled:                true,
+			IntegrityCheckEnabled:          true,
+			RestoreBackupPath:              "backup.tar.zst",
+			ColdBlockDepth:                 16,
+			ColdBlockDBPath:                "cold",
+			BlockCompressionEnabled:        true,
+			BootstrapCommitInterval:        32,
+			TrustedCheckpointHeight:        1000,
+			TrustedCheckpointBlockID:       ids.ID{1, 2, 3},
+			APIAddressAllowLists: map[string][]string{
+				"token": {"P-local18jma8ppw3nhx5r4ap8clazz0dps7rv5u00z96u"},
+			},
+			RewardWatchlist:               []string{"P-local18jma8ppw3nhx5r4ap8clazz0dps7rv5u00z96u"},
+			RewardWatchlistSize:           17,
+			RecoveryKeys:                  []string{"P-local18jma8ppw3nhx5r4ap8clazz0dps7rv5u00z96u"},
+			RecoveryThreshold:             1,
+			StateAttestationInterval:      18,
+			HeartbeatInterval:             19,
+			ClockOverrideEnabled:          true,
+			InvariantCheckInterval:        20,
+			InvariantViolationsFatal:      true,
+			ShadowExecutionEnabled:        true,
+			DelegationReservationDuration: 21,
+			ReadReplicaPrimaryURI:         "http://127.0.0.1:9650/ext/bc/P",
+			BlockPrevalidationEnabled:     true,
+			MaxProcessingBlocks:           22,
+			Profile:                       "coston2",
+			AutoImportInterval:            23,
+			AutoImportMaxTxsPerInterval:   24,
+			AutoImportLogSize:             25,
+			TxStages:                      []string{"screening"},
+			DeniedAddresses:               []string{"P-local18jma8ppw3nhx5r4ap8clazz0dps7rv5u00z96u"},
+			AlertValidatorExpiryWindow:    26,
+			AlertLowConnectivityEnabled:   true,
+			AlertMaxMempoolAge:            27,
+			AlertWebhookURLs:              []string{"http://127.0.0.1:8080/alerts"},
+			IssueTxIPQuota:                28,
+			IssueTxIPQuotaPeriod:          29,
+			IssueTxAddressQuota:           30,
+			IssueTxAddressQuotaPeriod:     31,
 		}
 		require.Equal(expected, ec)
 	})
@@ -128,6 +235,9 @@ func TestExecutionConfigUnmarshal(t *testing.T) {
 				ExpectedBloomFilterFalsePositiveProbability: DefaultExecutionConfig.Network.ExpectedBloomFilterFalsePositiveProbability,
 				MaxBloomFilterFalsePositiveProbability:      DefaultExecutionConfig.Network.MaxBloomFilterFalsePositiveProbability,
 				LegacyPushGossipCacheSize:                   DefaultExecutionConfig.Network.LegacyPushGossipCacheSize,
+				BanScoreThreshold:                           DefaultExecutionConfig.Network.BanScoreThreshold,
+				BanScoreHalfLife:                            DefaultExecutionConfig.Network.BanScoreHalfLife,
+				BanDuration:                                 DefaultExecutionConfig.Network.BanDuration,
 			},
 			BlockCacheSize:               1,
 			TxCacheSize:                  2,
@@ -139,6 +249,14 @@ func TestExecutionConfigUnmarshal(t *testing.T) {
 			FxOwnerCacheSize:             9,
 			ChecksumsEnabled:             true,
 			MempoolPruneFrequency:        30 * time.Minute,
+			DroppedTxIndexSize:           DefaultExecutionConfig.DroppedTxIndexSize,
+			MaxSubnetMetricLabels:        DefaultExecutionConfig.MaxSubnetMetricLabels,
+			BootstrapCommitInterval:      DefaultExecutionConfig.BootstrapCommitInterval,
+			RewardWatchlistSize:          DefaultExecutionConfig.RewardWatchlistSize,
+			AutoImportMaxTxsPerInterval:  DefaultExecutionConfig.AutoImportMaxTxsPerInterval,
+			AutoImportLogSize:            DefaultExecutionConfig.AutoImportLogSize,
+			IssueTxIPQuotaPeriod:         DefaultExecutionConfig.IssueTxIPQuotaPeriod,
+			IssueTxAddressQuotaPeriod:    DefaultExecutionConfig.IssueTxAddressQuotaPeriod,
 		}
 		require.Equal(expected, ec)
 	})
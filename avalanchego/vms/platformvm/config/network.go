@@ -0,0 +1,77 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// SetNetworkUpgrades sets the times of the network upgrades of [networkID],
+// along with the parameters that are scheduled with them, as they are defined
+// by the version package. These settings must match across the nodes of a
+// network, so they are never taken from the config of a node.
+func (c *Config) SetNetworkUpgrades(networkID uint32) {
+	c.ApricotPhase3Time = version.GetApricotPhase3Time(networkID)
+	c.ApricotPhase5Time = version.GetApricotPhase5Time(networkID)
+	c.BanffTime = version.GetBanffTime(networkID)
+	c.CortinaTime = version.GetCortinaTime(networkID)
+	c.DurangoTime = version.GetDurangoTime(networkID)
+
+	c.SubnetAllowListTime = version.GetSubnetAllowListTime(networkID)
+	c.ExitValidatorTime = version.GetExitValidatorTime(networkID)
+	c.CappedDelegationTime = version.GetCappedDelegationTime(networkID)
+	c.ParameterChangeTime = version.GetParameterChangeTime(networkID)
+	c.SubnetValidatorWeightTime = version.GetSubnetValidatorWeightTime(networkID)
+	c.RekeyValidatorTime = version.GetRekeyValidatorTime(networkID)
+	c.NameRegistryTime = version.GetNameRegistryTime(networkID)
+	c.NameRegistrationFee = version.GetNameRegistrationFee(networkID)
+	c.ValidatorMetadataTime = version.GetValidatorMetadataTime(networkID)
+	c.ClaimableRewardsTime = version.GetClaimableRewardsTime(networkID)
+	c.RewardExportTime = version.GetRewardExportTime(networkID)
+
+	stakerStartHorizon := version.GetStakerStartHorizon(networkID)
+	c.StakerStartHorizonTime = stakerStartHorizon.Time
+	c.StakerStartHorizon = stakerStartHorizon.Horizon
+
+	subnetFeeAssetUpgrade := version.GetSubnetFeeAssetUpgrade(networkID)
+	c.SubnetFeeAssetTime = subnetFeeAssetUpgrade.Time
+	c.SubnetFeeAssets = make(map[ids.ID]SubnetFeeAsset, len(subnetFeeAssetUpgrade.Assets))
+	for subnetID, feeAsset := range subnetFeeAssetUpgrade.Assets {
+		c.SubnetFeeAssets[subnetID] = SubnetFeeAsset{
+			AssetID:               feeAsset.AssetID,
+			AddSubnetValidatorFee: feeAsset.AddSubnetValidatorFee,
+			CreateBlockchainTxFee: feeAsset.CreateBlockchainTxFee,
+			Treasury:              feeAsset.Treasury,
+		}
+	}
+
+	feeTreasury := version.GetFeeTreasury(networkID)
+	c.FeeTreasuryTime = feeTreasury.Time
+	c.FeeTreasuryAddress = feeTreasury.Address
+	c.FeeTreasuryPercentage = feeTreasury.Percentage
+
+	blockComplexityLimit := version.GetBlockComplexityLimit(networkID)
+	c.BlockComplexityTime = blockComplexityLimit.Time
+	c.MaxBlockComplexity = blockComplexityLimit.MaxComplexity
+
+	c.ValidatorAllowlist = nil
+	c.OpenValidatorSetTime = time.Time{}
+	if allowlist, ok := version.GetValidatorAllowlist(networkID); ok {
+		c.ValidatorAllowlist = set.Of(allowlist.NodeIDs...)
+		c.OpenValidatorSetTime = allowlist.OpenValidatorSetTime
+	}
+
+	c.GovernanceOwner = nil
+	if owner, ok := version.GetGovernanceOwner(networkID); ok {
+		c.GovernanceOwner = &secp256k1fx.OutputOwners{
+			Threshold: owner.Threshold,
+			Addrs:     owner.Addrs,
+		}
+	}
+}
@@ -0,0 +1,499 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/genesis"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+// Number of blocks checked by checkConformance between two acquisitions of the
+// context lock
+const conformanceBatchSize = 1024
+
+var (
+	errTimestampMovedBackwards = errors.New("timestamp moved backwards")
+	errSkippedStakerStart      = errors.New("chain time advanced past the start time of a pending staker")
+	errSkippedStakerEnd        = errors.New("chain time advanced past the end time of a current staker")
+	errStakerStartsInPast      = errors.New("pending staker starts before the chain time")
+	errRewardNotAtEndTime      = errors.New("staker removed at a chain time other than its end time")
+	errRewardedUnknownStaker   = errors.New("removed staker isn't a current staker")
+	errChainTimeMismatch       = errors.New("chain time differs from the state")
+	errStakerSetMismatch       = errors.New("staker set differs from the state")
+)
+
+// conformanceStaker is a staker of the chain, as tracked by the conformance
+// checker.
+type conformanceStaker struct {
+	subnetID     ids.ID
+	nodeID       ids.NodeID
+	validator    bool
+	permissioned bool
+	startTime    time.Time
+	endTime      time.Time
+	current      bool
+	// overdue is true if the chain time advanced past the next transition of
+	// the staker, which was reported already.
+	overdue bool
+}
+
+// nextTime returns the time of the next transition of the staker.
+func (s *conformanceStaker) nextTime() time.Time {
+	if s.current {
+		return s.endTime
+	}
+	return s.startTime
+}
+
+// conformanceViolation is a block of the accepted chain that breaks one of the
+// timing invariants of the chain.
+type conformanceViolation struct {
+	height uint64
+	blkID  ids.ID
+	// ID of the tx or of the staker involved, if any
+	txID ids.ID
+	err  error
+}
+
+// conformanceReport is the result of walking the accepted chain with a
+// conformanceChecker.
+type conformanceReport struct {
+	// Height and ID of the last checked block
+	height uint64
+	blkID  ids.ID
+	// Chain time after the last checked block
+	chainTime time.Time
+
+	numTimestampChanges uint64
+	// Number of stakers that joined the current staker set
+	numStakerStarts uint64
+	// Number of stakers that left the current staker set at their end time
+	numStakerEnds uint64
+	// Number of stakers that left the staker sets before their end time
+	numStakerExits uint64
+
+	violations []conformanceViolation
+}
+
+// conformanceChecker replays the accepted chain, only keeping track of the
+// chain time and of the staker sets, to assert that the timestamps never move
+// backwards and that every staker transition happens exactly at its boundary:
+//
+//   - The chain time never advances past the start time of a pending staker
+//     nor the end time of a current staker.
+//   - Stakers are only rewarded when the chain time is their end time.
+//   - Pending stakers never start before the chain time they were added at.
+//
+// Once the last accepted block is reached, the tracked chain time and staker
+// sets are compared against the state.
+//
+// A conformanceChecker is not safe for concurrent use.
+type conformanceChecker struct {
+	isDurangoActivated func(time.Time) bool
+
+	report     conformanceReport
+	nextHeight uint64
+	stakers    map[ids.ID]*conformanceStaker
+	// proposal is the tx of the last checked block if it is a proposal block
+	proposal *txs.Tx
+
+	// nextChangeTime is cached until the staker sets are modified
+	nextChangeTime      time.Time
+	nextChangeTimeValid bool
+}
+
+// newConformanceChecker returns a checker of the chain created by
+// [genesisBytes], whose forks are activated according to [isDurangoActivated].
+func newConformanceChecker(genesisBytes []byte, isDurangoActivated func(time.Time) bool) (*conformanceChecker, error) {
+	gen, err := genesis.Parse(genesisBytes)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse genesis: %w", err)
+	}
+
+	c := &conformanceChecker{
+		isDurangoActivated: isDurangoActivated,
+		report: conformanceReport{
+			chainTime: time.Unix(int64(gen.Timestamp), 0),
+		},
+		stakers: make(map[ids.ID]*conformanceStaker),
+	}
+	for _, vdrTx := range gen.Validators {
+		staker, ok := vdrTx.Unsigned.(txs.ScheduledStaker)
+		if !ok {
+			return nil, fmt.Errorf("expected a scheduled staker but got %T", vdrTx.Unsigned)
+		}
+		// Genesis validators are immediately added to the current staker set.
+		c.stakers[vdrTx.ID()] = newConformanceStaker(staker, staker.StartTime(), true)
+	}
+	return c, nil
+}
+
+func newConformanceStaker(staker txs.Staker, startTime time.Time, current bool) *conformanceStaker {
+	priority := staker.CurrentPriority()
+	return &conformanceStaker{
+		subnetID:     staker.SubnetID(),
+		nodeID:       staker.NodeID(),
+		validator:    priority.IsValidator(),
+		permissioned: priority.IsPermissionedValidator(),
+		startTime:    startTime,
+		endTime:      staker.EndTime(),
+		current:      current,
+	}
+}
+
+// check checks at most [maxBlocks] accepted blocks, following the blocks that
+// were already checked. Returns true once the last accepted block was checked
+// and the checker was compared against [chainState].
+func (c *conformanceChecker) check(chainState state.State, maxBlocks int) (bool, error) {
+	lastAccepted, err := chainState.GetStatelessBlock(chainState.GetLastAccepted())
+	if err != nil {
+		return false, err
+	}
+
+	lastHeight := lastAccepted.Height()
+	for i := 0; i < maxBlocks && c.nextHeight <= lastHeight; i++ {
+		blkID, err := chainState.GetBlockIDAtHeight(c.nextHeight)
+		if err != nil {
+			return false, fmt.Errorf("failed to get block at height %d: %w", c.nextHeight, err)
+		}
+		blk, err := chainState.GetStatelessBlock(blkID)
+		if err != nil {
+			return false, fmt.Errorf("failed to get block %s: %w", blkID, err)
+		}
+		c.checkBlock(blk)
+		c.nextHeight++
+	}
+	if c.nextHeight <= lastHeight {
+		return false, nil
+	}
+
+	if err := c.checkState(chainState); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// checkBlock applies [blk], the child of the last checked block, to the
+// tracked chain time and staker sets.
+func (c *conformanceChecker) checkBlock(blk block.Block) {
+	c.report.height = blk.Height()
+	c.report.blkID = blk.ID()
+
+	proposal := c.proposal
+	c.proposal = nil
+	switch blk := blk.(type) {
+	case *block.ApricotProposalBlock:
+		c.proposal = blk.Tx
+	case *block.BanffProposalBlock:
+		c.advanceTimeTo(blk.Timestamp())
+		c.acceptTxs(blk.Transactions)
+		c.proposal = blk.Tx
+	case *block.ApricotCommitBlock:
+		c.decide(proposal, true)
+	case *block.BanffCommitBlock:
+		c.advanceTimeTo(blk.Timestamp())
+		c.decide(proposal, true)
+	case *block.ApricotAbortBlock:
+		c.decide(proposal, false)
+	case *block.BanffAbortBlock:
+		c.advanceTimeTo(blk.Timestamp())
+		c.decide(proposal, false)
+	case *block.BanffStandardBlock:
+		c.advanceTimeTo(blk.Timestamp())
+		c.acceptTxs(blk.Transactions)
+	default:
+		// Apricot standard and atomic blocks don't modify the chain time.
+		c.acceptTxs(blk.Txs())
+	}
+}
+
+// decide applies the effects of [proposal], if any, on the commit or the abort
+// of the proposal block.
+func (c *conformanceChecker) decide(proposal *txs.Tx, committed bool) {
+	if proposal == nil {
+		// The genesis block is a commit block without parent.
+		return
+	}
+
+	switch utx := proposal.Unsigned.(type) {
+	case *txs.AdvanceTimeTx:
+		if committed {
+			c.advanceTimeTo(utx.Timestamp())
+		}
+	case *txs.RewardValidatorTx:
+		// The staker is removed whether or not it is rewarded.
+		c.endStaker(utx.TxID)
+	default:
+		// Pre-Banff, stakers were added by proposal txs.
+		if committed {
+			c.acceptTxs([]*txs.Tx{proposal})
+		}
+	}
+}
+
+// advanceTimeTo advances the chain time to [newChainTime], promoting the
+// pending stakers that start at [newChainTime] and removing the permissioned
+// validators that end at [newChainTime].
+func (c *conformanceChecker) advanceTimeTo(newChainTime time.Time) {
+	chainTime := c.report.chainTime
+	if newChainTime.Before(chainTime) {
+		c.violate(ids.Empty, fmt.Errorf("%w: from %s to %s",
+			errTimestampMovedBackwards,
+			chainTime,
+			newChainTime,
+		))
+		return
+	}
+	if !newChainTime.After(chainTime) {
+		return
+	}
+
+	c.report.chainTime = newChainTime
+	c.report.numTimestampChanges++
+	if newChainTime.Before(c.getNextChangeTime()) {
+		return
+	}
+
+	c.nextChangeTimeValid = false
+	for txID, staker := range c.stakers {
+		if staker.overdue {
+			continue
+		}
+
+		nextTime := staker.nextTime()
+		if nextTime.After(newChainTime) {
+			continue
+		}
+		if nextTime.Before(newChainTime) {
+			err := errSkippedStakerStart
+			if staker.current {
+				err = errSkippedStakerEnd
+			}
+			c.violate(txID, fmt.Errorf("%w: %s is before %s", err, nextTime, newChainTime))
+		}
+
+		switch {
+		case !staker.current:
+			staker.current = true
+			c.report.numStakerStarts++
+		case staker.permissioned:
+			// Permissioned validators are removed by advancing the chain time
+			// rather than by a RewardValidatorTx.
+			delete(c.stakers, txID)
+			c.report.numStakerEnds++
+		default:
+			// The staker is kept until it is rewarded, which is reported as
+			// well, but its missed end time is only reported once.
+			staker.overdue = true
+		}
+	}
+}
+
+// getNextChangeTime returns the earliest transition of the stakers that aren't
+// overdue.
+func (c *conformanceChecker) getNextChangeTime() time.Time {
+	if c.nextChangeTimeValid {
+		return c.nextChangeTime
+	}
+
+	c.nextChangeTime = mockable.MaxTime
+	for _, staker := range c.stakers {
+		if nextTime := staker.nextTime(); !staker.overdue && nextTime.Before(c.nextChangeTime) {
+			c.nextChangeTime = nextTime
+		}
+	}
+	c.nextChangeTimeValid = true
+	return c.nextChangeTime
+}
+
+// endStaker removes the current staker added by [txID], which must end at the
+// chain time.
+func (c *conformanceChecker) endStaker(txID ids.ID) {
+	staker, ok := c.stakers[txID]
+	if !ok || !staker.current {
+		c.violate(txID, errRewardedUnknownStaker)
+		return
+	}
+	if !staker.endTime.Equal(c.report.chainTime) {
+		c.violate(txID, fmt.Errorf("%w: ends at %s but removed at %s",
+			errRewardNotAtEndTime,
+			staker.endTime,
+			c.report.chainTime,
+		))
+	}
+
+	delete(c.stakers, txID)
+	c.nextChangeTimeValid = false
+	c.report.numStakerEnds++
+}
+
+// acceptTxs applies the decision txs [decisionTxs] to the staker sets.
+func (c *conformanceChecker) acceptTxs(decisionTxs []*txs.Tx) {
+	for _, tx := range decisionTxs {
+		switch utx := tx.Unsigned.(type) {
+		case *txs.RemoveSubnetValidatorTx:
+			c.exitStakers(utx.Subnet, utx.NodeID, false /*delegators*/)
+		case *txs.ExitValidatorTx:
+			c.exitStakers(utx.Subnet, utx.NodeID, true /*delegators*/)
+		case *txs.RekeyValidatorTx:
+			for _, staker := range c.stakers {
+				if staker.subnetID == constants.PrimaryNetworkID && staker.nodeID == utx.NodeID {
+					staker.nodeID = utx.NewNodeID
+				}
+			}
+		case txs.Staker:
+			c.addStaker(tx.ID(), utx)
+		}
+	}
+}
+
+// addStaker adds the staker added by [txID] at the chain time.
+func (c *conformanceChecker) addStaker(txID ids.ID, staker txs.Staker) {
+	c.nextChangeTimeValid = false
+
+	chainTime := c.report.chainTime
+	if c.isDurangoActivated(chainTime) {
+		// Post-Durango, stakers immediately join the current staker set.
+		c.stakers[txID] = newConformanceStaker(staker, chainTime, true)
+		c.report.numStakerStarts++
+		return
+	}
+
+	scheduledStaker, ok := staker.(txs.ScheduledStaker)
+	if !ok {
+		return
+	}
+	startTime := scheduledStaker.StartTime()
+	if !startTime.After(chainTime) {
+		c.violate(txID, fmt.Errorf("%w: %s is before %s",
+			errStakerStartsInPast,
+			startTime,
+			chainTime,
+		))
+	}
+	c.stakers[txID] = newConformanceStaker(staker, startTime, false)
+}
+
+// exitStakers removes the validator [nodeID] of [subnetID] before its end
+// time, along with its delegators if [delegators] is set.
+func (c *conformanceChecker) exitStakers(subnetID ids.ID, nodeID ids.NodeID, delegators bool) {
+	for txID, staker := range c.stakers {
+		if staker.subnetID != subnetID || staker.nodeID != nodeID {
+			continue
+		}
+		if !staker.validator && !delegators {
+			continue
+		}
+		delete(c.stakers, txID)
+		c.nextChangeTimeValid = false
+		c.report.numStakerExits++
+	}
+}
+
+// checkState compares the tracked chain time and staker sets against
+// [chainState], which must be the state after the last checked block.
+func (c *conformanceChecker) checkState(chainState state.Chain) error {
+	if chainTime := chainState.GetTimestamp(); !chainTime.Equal(c.report.chainTime) {
+		c.violate(ids.Empty, fmt.Errorf("%w: expected %s but found %s",
+			errChainTimeMismatch,
+			c.report.chainTime,
+			chainTime,
+		))
+	}
+
+	seen := make(map[ids.ID]bool, len(c.stakers))
+	for _, current := range []bool{true, false} {
+		var (
+			it  state.StakerIterator
+			err error
+		)
+		if current {
+			it, err = chainState.GetCurrentStakerIterator()
+		} else {
+			it, err = chainState.GetPendingStakerIterator()
+		}
+		if err != nil {
+			return err
+		}
+		for it.Next() {
+			stateStaker := it.Value()
+			seen[stateStaker.TxID] = true
+
+			staker, ok := c.stakers[stateStaker.TxID]
+			switch {
+			case !ok:
+				c.violate(stateStaker.TxID, fmt.Errorf("%w: unexpected staker", errStakerSetMismatch))
+			case staker.current != current:
+				c.violate(stateStaker.TxID, fmt.Errorf("%w: expected current=%t", errStakerSetMismatch, staker.current))
+			case staker.nodeID != stateStaker.NodeID ||
+				!staker.startTime.Equal(stateStaker.StartTime) ||
+				!staker.endTime.Equal(stateStaker.EndTime):
+				c.violate(stateStaker.TxID, fmt.Errorf("%w: expected %s from %s to %s but found %s from %s to %s",
+					errStakerSetMismatch,
+					staker.nodeID,
+					staker.startTime,
+					staker.endTime,
+					stateStaker.NodeID,
+					stateStaker.StartTime,
+					stateStaker.EndTime,
+				))
+			}
+		}
+		it.Release()
+	}
+
+	for txID := range c.stakers {
+		if !seen[txID] {
+			c.violate(txID, fmt.Errorf("%w: missing staker", errStakerSetMismatch))
+		}
+	}
+	return nil
+}
+
+func (c *conformanceChecker) violate(txID ids.ID, err error) {
+	c.report.violations = append(c.report.violations, conformanceViolation{
+		height: c.report.height,
+		blkID:  c.report.blkID,
+		txID:   txID,
+		err:    err,
+	})
+}
+
+// checkConformance walks the accepted chain from genesis with a
+// conformanceChecker. The context lock is only held while a batch of blocks is
+// checked, so that the chain keeps making progress during the walk.
+//
+// Invariant: the context lock isn't held.
+func (vm *VM) checkConformance(ctx context.Context) (*conformanceReport, error) {
+	c, err := newConformanceChecker(vm.genesisBytes, vm.Config.IsDurangoActivated)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		vm.ctx.Lock.Lock()
+		done, err := c.check(vm.state, conformanceBatchSize)
+		vm.ctx.Lock.Unlock()
+		if err != nil {
+			return nil, err
+		}
+		if done {
+			return &c.report, nil
+		}
+	}
+}
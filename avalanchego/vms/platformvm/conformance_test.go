@@ -0,0 +1,137 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/genesis"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+// requireConformance walks the accepted chain of [vm] and requires the
+// timestamps and the staker transitions to conform to the rules of the chain.
+//
+// Invariant: the context lock is held.
+func requireConformance(t *testing.T, vm *VM) *conformanceReport {
+	require := require.New(t)
+
+	c, err := newConformanceChecker(vm.genesisBytes, vm.Config.IsDurangoActivated)
+	require.NoError(err)
+	done, err := c.check(vm.state, math.MaxInt)
+	require.NoError(err)
+	require.True(done)
+	for _, v := range c.report.violations {
+		require.NoError(v.err, "height %d, tx %s", v.height, v.txID)
+	}
+	return &c.report
+}
+
+func TestConformanceRewardedValidators(t *testing.T) {
+	require := require.New(t)
+	vm, _, _ := defaultVM(t, latestFork)
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	gen, err := genesis.Parse(vm.genesisBytes)
+	require.NoError(err)
+
+	// Reward all the genesis validators
+	vm.clock.Set(defaultValidateEndTime)
+	for range gen.Validators {
+		blk, err := vm.Builder.BuildBlock(context.Background())
+		require.NoError(err)
+		require.NoError(blk.Verify(context.Background()))
+
+		options, err := blk.(snowman.OracleBlock).Options(context.Background())
+		require.NoError(err)
+		commit := options[0]
+		require.NoError(commit.Verify(context.Background()))
+
+		require.NoError(blk.Accept(context.Background()))
+		require.NoError(commit.Accept(context.Background()))
+		require.NoError(vm.SetPreference(context.Background(), vm.manager.LastAccepted()))
+	}
+
+	report := requireConformance(t, vm)
+	require.Equal(vm.state.GetLastAccepted(), report.blkID)
+	require.Equal(defaultValidateEndTime.Unix(), report.chainTime.Unix())
+	require.Equal(uint64(len(gen.Validators)), report.numStakerEnds)
+}
+
+func TestConformanceCheckerViolations(t *testing.T) {
+	require := require.New(t)
+	vm, _, _ := defaultVM(t, latestFork)
+
+	gen, err := genesis.Parse(vm.genesisBytes)
+	require.NoError(err)
+	genesisTime := time.Unix(int64(gen.Timestamp), 0)
+
+	c, err := newConformanceChecker(vm.genesisBytes, func(time.Time) bool { return true })
+	require.NoError(err)
+
+	var (
+		parentID = ids.Empty
+		height   uint64
+	)
+	accept := func(blk block.Block, err error) []conformanceViolation {
+		require.NoError(err)
+		numViolations := len(c.report.violations)
+		c.checkBlock(blk)
+		parentID = blk.ID()
+		height++
+		return c.report.violations[numViolations:]
+	}
+	newRewardTx := func(txID ids.ID) *txs.Tx {
+		tx, err := txs.NewSigned(&txs.RewardValidatorTx{TxID: txID}, txs.Codec, nil)
+		require.NoError(err)
+		return tx
+	}
+
+	// Advancing the chain time is valid until a staker transition is due
+	violations := accept(block.NewBanffStandardBlock(genesisTime.Add(time.Hour), parentID, height, nil))
+	require.Empty(violations)
+
+	violations = accept(block.NewBanffStandardBlock(genesisTime, parentID, height, nil))
+	require.Len(violations, 1)
+	require.ErrorIs(violations[0].err, errTimestampMovedBackwards)
+	require.Equal(uint64(1), violations[0].height)
+
+	// The genesis validators can't be rewarded before their end time
+	rewardedTxID := gen.Validators[0].ID()
+	violations = accept(block.NewBanffProposalBlock(genesisTime.Add(time.Hour), parentID, height, newRewardTx(rewardedTxID), nil))
+	require.Empty(violations)
+	violations = accept(block.NewBanffCommitBlock(genesisTime.Add(time.Hour), parentID, height))
+	require.Len(violations, 1)
+	require.ErrorIs(violations[0].err, errRewardNotAtEndTime)
+	require.Equal(rewardedTxID, violations[0].txID)
+
+	// The chain time can't advance past the end time of the other genesis
+	// validators, which are only reported once
+	violations = accept(block.NewBanffStandardBlock(defaultValidateEndTime.Add(time.Second), parentID, height, nil))
+	require.Len(violations, len(gen.Validators)-1)
+	for _, v := range violations {
+		require.ErrorIs(v.err, errSkippedStakerEnd)
+	}
+	violations = accept(block.NewBanffStandardBlock(defaultValidateEndTime.Add(2*time.Second), parentID, height, nil))
+	require.Empty(violations)
+
+	// A staker can only be rewarded once
+	violations = accept(block.NewBanffProposalBlock(defaultValidateEndTime.Add(2*time.Second), parentID, height, newRewardTx(rewardedTxID), nil))
+	require.Empty(violations)
+	violations = accept(block.NewBanffAbortBlock(defaultValidateEndTime.Add(2*time.Second), parentID, height))
+	require.Len(violations, 1)
+	require.ErrorIs(violations[0].err, errRewardedUnknownStaker)
+
+	require.Equal(uint64(3), c.report.numTimestampChanges)
+	require.Equal(uint64(1), c.report.numStakerEnds)
+}
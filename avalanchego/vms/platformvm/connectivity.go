@@ -0,0 +1,101 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+)
+
+// peerConnections tracks since when the compatible peers are connected to
+// this node.
+type peerConnections struct {
+	lock sync.RWMutex
+	// nodeID --> time the peer connected
+	connectedSince map[ids.NodeID]time.Time
+}
+
+func newPeerConnections() *peerConnections {
+	return &peerConnections{
+		connectedSince: make(map[ids.NodeID]time.Time),
+	}
+}
+
+func (p *peerConnections) connect(nodeID ids.NodeID, now time.Time) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.connectedSince[nodeID] = now
+}
+
+func (p *peerConnections) disconnect(nodeID ids.NodeID) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	delete(p.connectedSince, nodeID)
+}
+
+// validatorConnection is the connection of this node to a validator.
+type validatorConnection struct {
+	NodeID    ids.NodeID
+	Weight    uint64
+	Connected bool
+	// Time the validator connected. Zero if it isn't connected.
+	ConnectedSince time.Time
+}
+
+// validatorConnectivity is the connectivity of this node to a validator set.
+type validatorConnectivity struct {
+	// Validators ordered by node ID
+	Validators []validatorConnection
+	// Stake of this node and of the connected validators
+	ConnectedWeight uint64
+	TotalWeight     uint64
+}
+
+// ConnectedPercentage returns the percentage of the stake that this node is
+// connected to.
+func (c *validatorConnectivity) ConnectedPercentage() float64 {
+	if c.TotalWeight == 0 {
+		return 0
+	}
+	return 100 * float64(c.ConnectedWeight) / float64(c.TotalWeight)
+}
+
+// connectivity returns the connections to the validators in [vdrs]. [nodeID],
+// this node, is always counted as connected.
+func (p *peerConnections) connectivity(
+	vdrs map[ids.NodeID]*validators.GetValidatorOutput,
+	nodeID ids.NodeID,
+) validatorConnectivity {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	c := validatorConnectivity{
+		Validators: make([]validatorConnection, 0, len(vdrs)),
+	}
+	for vdrID, vdr := range vdrs {
+		connectedSince, connected := p.connectedSince[vdrID]
+		connected = connected || vdrID == nodeID
+		c.Validators = append(c.Validators, validatorConnection{
+			NodeID:         vdrID,
+			Weight:         vdr.Weight,
+			Connected:      connected,
+			ConnectedSince: connectedSince,
+		})
+
+		c.TotalWeight += vdr.Weight
+		if connected {
+			c.ConnectedWeight += vdr.Weight
+		}
+	}
+	slices.SortFunc(c.Validators, func(a, b validatorConnection) int {
+		return a.NodeID.Compare(b.NodeID)
+	})
+	return c
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+)
+
+func TestPeerConnectionsConnectivity(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		now          = time.Unix(1_700_000_000, 0)
+		nodeID       = ids.GenerateTestNodeID()
+		connectedID  = ids.GenerateTestNodeID()
+		reconnectID  = ids.GenerateTestNodeID()
+		offlineID    = ids.GenerateTestNodeID()
+		nonValidator = ids.GenerateTestNodeID()
+	)
+	vdrs := map[ids.NodeID]*validators.GetValidatorOutput{
+		nodeID:      {NodeID: nodeID, Weight: 10},
+		connectedID: {NodeID: connectedID, Weight: 20},
+		reconnectID: {NodeID: reconnectID, Weight: 30},
+		offlineID:   {NodeID: offlineID, Weight: 40},
+	}
+
+	p := newPeerConnections()
+	p.connect(connectedID, now)
+	p.connect(reconnectID, now)
+	p.connect(offlineID, now)
+	p.connect(nonValidator, now)
+	p.disconnect(offlineID)
+	p.disconnect(reconnectID)
+	p.connect(reconnectID, now.Add(time.Minute))
+
+	c := p.connectivity(vdrs, nodeID)
+	require.Len(c.Validators, 4)
+	for i := 1; i < len(c.Validators); i++ {
+		require.Negative(c.Validators[i-1].NodeID.Compare(c.Validators[i].NodeID))
+	}
+
+	connections := make(map[ids.NodeID]validatorConnection)
+	for _, vdr := range c.Validators {
+		connections[vdr.NodeID] = vdr
+	}
+	require.Equal(validatorConnection{
+		NodeID:    nodeID,
+		Weight:    10,
+		Connected: true,
+	}, connections[nodeID])
+	require.Equal(validatorConnection{
+		NodeID:         connectedID,
+		Weight:         20,
+		Connected:      true,
+		ConnectedSince: now,
+	}, connections[connectedID])
+	require.Equal(validatorConnection{
+		NodeID:         reconnectID,
+		Weight:         30,
+		Connected:      true,
+		ConnectedSince: now.Add(time.Minute),
+	}, connections[reconnectID])
+	require.Equal(validatorConnection{
+		NodeID: offlineID,
+		Weight: 40,
+	}, connections[offlineID])
+
+	require.Equal(uint64(60), c.ConnectedWeight)
+	require.Equal(uint64(100), c.TotalWeight)
+	require.InDelta(60, c.ConnectedPercentage(), 1e-9)
+}
+
+func TestPeerConnectionsNoValidators(t *testing.T) {
+	c := newPeerConnections().connectivity(nil, ids.GenerateTestNodeID())
+	require.Empty(t, c.Validators)
+	require.Zero(t, c.ConnectedPercentage())
+}
@@ -0,0 +1,129 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/network"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	_ network.TxVerifier = (*reservationTxVerifier)(nil)
+
+	errDelegationReservationsDisabled = errors.New("delegation capacity reservations are disabled")
+	errMissingPreferredState          = errors.New("missing preferred state")
+)
+
+// reservationTxVerifier only admits the delegations that fit in the capacity
+// of their validator left by the chain state, the mempool and the
+// reservations of other owners.
+type reservationTxVerifier struct {
+	vm         *VM
+	txVerifier network.TxVerifier
+}
+
+// VerifyTx must be called with the context lock held.
+func (v *reservationTxVerifier) VerifyTx(tx *txs.Tx) error {
+	if err := v.txVerifier.VerifyTx(tx); err != nil {
+		return err
+	}
+
+	delegatorTx, ok := tx.Unsigned.(txs.DelegatorTx)
+	if !ok {
+		return nil
+	}
+
+	capacity, err := v.vm.availableDelegationCapacity(
+		delegatorTx.SubnetID(),
+		delegatorTx.NodeID(),
+		delegatorTx.EndTime(),
+	)
+	if err != nil {
+		return err
+	}
+
+	var owners set.Set[ids.ShortID]
+	if owner, ok := delegatorTx.RewardsOwner().(*secp256k1fx.OutputOwners); ok {
+		owners = set.Of(owner.Addrs...)
+	}
+	err = v.vm.delegationReservations.Admit(
+		delegatorTx.SubnetID(),
+		delegatorTx.NodeID(),
+		owners,
+		delegatorTx.Weight(),
+		capacity,
+	)
+	if err != nil {
+		// Other nodes may not hold the same reservations, so the delegation
+		// isn't invalid.
+		return fmt.Errorf("%w: %w", network.ErrTxRejectedByPolicy, err)
+	}
+	return nil
+}
+
+// DelegationReservationMessage returns the message that the reward address of
+// a delegation signs to reserve [amount] of the delegation capacity of
+// [nodeID] on [subnetID] until [endTime]. The message commits to the
+// reservation, so that its signature can't authorize other reservations.
+func DelegationReservationMessage(subnetID ids.ID, nodeID ids.NodeID, amount uint64, endTime time.Time) string {
+	return fmt.Sprintf(
+		"Reserve %d of the delegation capacity of %s on subnet %s until %d",
+		amount,
+		nodeID,
+		subnetID,
+		endTime.Unix(),
+	)
+}
+
+// availableDelegationCapacity returns the stake that can still be delegated to
+// [nodeID] on [subnetID] from the preferred chain time until [endTime], given
+// the delegations to the validator that are in the mempool.
+//
+// Invariant: the context lock is held.
+func (vm *VM) availableDelegationCapacity(subnetID ids.ID, nodeID ids.NodeID, endTime time.Time) (uint64, error) {
+	preferredID := vm.manager.Preferred()
+	preferredState, ok := vm.manager.GetState(preferredID)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", errMissingPreferredState, preferredID)
+	}
+
+	validator, err := executor.GetValidator(preferredState, subnetID, nodeID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch the validator for %s on %s: %w", nodeID, subnetID, err)
+	}
+
+	startTime := preferredState.GetTimestamp()
+	if startTime.Before(validator.StartTime) {
+		startTime = validator.StartTime
+	}
+	if !startTime.Before(endTime) || endTime.After(validator.EndTime) {
+		return 0, nil
+	}
+
+	backend := &executor.Backend{
+		Config: &vm.Config,
+		Ctx:    vm.ctx,
+	}
+	capacity, err := executor.GetDelegationCapacity(backend, preferredState, validator, startTime, endTime)
+	if err != nil {
+		return 0, err
+	}
+
+	vm.Builder.Iterate(func(tx *txs.Tx) bool {
+		delegatorTx, ok := tx.Unsigned.(txs.DelegatorTx)
+		if ok && delegatorTx.SubnetID() == subnetID && delegatorTx.NodeID() == nodeID {
+			capacity -= min(capacity, delegatorTx.Weight())
+		}
+		return capacity > 0
+	})
+	return capacity, nil
+}
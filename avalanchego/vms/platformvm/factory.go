@@ -7,6 +7,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms"
 	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/network"
 )
 
 var _ vms.Factory = (*Factory)(nil)
@@ -14,9 +15,15 @@ var _ vms.Factory = (*Factory)(nil)
 // Factory can create new instances of the Platform Chain
 type Factory struct {
 	config.Config
+
+	// Optional local policy applied to the txs issued into the mempool
+	AdmissionPolicy network.AdmissionPolicy
 }
 
 // New returns a new instance of the Platform Chain
 func (f *Factory) New(logging.Logger) (interface{}, error) {
-	return &VM{Config: f.Config}, nil
+	return &VM{
+		Config:          f.Config,
+		AdmissionPolicy: f.AdmissionPolicy,
+	}, nil
 }
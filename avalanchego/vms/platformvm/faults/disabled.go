@@ -0,0 +1,12 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build !faults
+
+package faults
+
+// Inject returns the failure injected at [Point]. Faults are disabled in this
+// build, so it always returns nil.
+func Inject(Point) error {
+	return nil
+}
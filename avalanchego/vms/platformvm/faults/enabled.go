@@ -0,0 +1,50 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build faults
+
+package faults
+
+import "sync"
+
+var (
+	lock   sync.Mutex
+	faults = make(map[Point]func() error)
+)
+
+// Set makes [Inject] at [point] return the result of [fault].
+func Set(point Point, fault func() error) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	faults[point] = fault
+}
+
+// Fail makes the next call to [Inject] at [point] return [ErrInjected]. Later
+// calls succeed again.
+func Fail(point Point) {
+	Set(point, func() error {
+		Clear(point)
+		return ErrInjected
+	})
+}
+
+// Clear removes the fault injected at [point].
+func Clear(point Point) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	delete(faults, point)
+}
+
+// Inject returns the failure injected at [point], if any.
+func Inject(point Point) error {
+	lock.Lock()
+	fault, ok := faults[point]
+	lock.Unlock()
+
+	if !ok {
+		return nil
+	}
+	return fault()
+}
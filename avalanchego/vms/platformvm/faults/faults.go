@@ -0,0 +1,33 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package faults defines the points at which failures can be injected into the
+// commits of the platform chain state, to test that the chain recovers
+// consistently after a crash.
+//
+// Faults can only be injected into binaries built with the "faults" build tag.
+// Otherwise [Inject] never fails and is inlined away.
+package faults
+
+import "errors"
+
+// ErrInjected is returned by [Inject] if a fault was injected without a
+// specific error.
+var ErrInjected = errors.New("injected fault")
+
+// Point is a place in the code where a failure can be injected.
+type Point string
+
+const (
+	// PartialStateWrite fails the write of the state after only part of the
+	// pending changes were written.
+	PartialStateWrite Point = "partial-state-write"
+	// BeforeSharedMemoryApply fails the acceptance of a block after the batch
+	// of state changes was built but before it was written atomically with
+	// the shared memory requests.
+	BeforeSharedMemoryApply Point = "before-shared-memory-apply"
+	// AfterSharedMemoryApply fails the acceptance of a block after the state
+	// and shared memory were committed but before the in-memory state was
+	// updated.
+	AfterSharedMemoryApply Point = "after-shared-memory-apply"
+)
@@ -0,0 +1,306 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build faults
+
+package platformvm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/chains/atomic"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/snowtest"
+	"github.com/ava-labs/avalanchego/snow/uptime"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/faults"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// The state is verified against its integrity check on every restart
+var faultTestConfigBytes = []byte(`{"integrity-check-enabled": true}`)
+
+// startFaultTestVM starts a VM on [db] and [m]. The VM isn't shut down when
+// the test ends, as crashed VMs must not commit their state.
+func startFaultTestVM(t *testing.T, db database.Database, m *atomic.Memory, genesisBytes []byte) *VM {
+	require := require.New(t)
+
+	vm := &VM{Config: config.Config{
+		Chains:                 chains.TestManager,
+		UptimeLockedCalculator: uptime.NewLockedCalculator(),
+		SybilProtectionEnabled: true,
+		Validators:             validators.NewManager(),
+		TxFee:                  defaultTxFee,
+		MinValidatorStake:      defaultMinValidatorStake,
+		MaxValidatorStake:      defaultMaxValidatorStake,
+		MinDelegatorStake:      defaultMinDelegatorStake,
+		MinStakeDuration:       defaultMinStakingDuration,
+		MaxStakeDuration:       defaultMaxStakingDuration,
+		RewardConfig:           defaultRewardConfig,
+		ApricotPhase3Time:      latestForkTime,
+		ApricotPhase5Time:      latestForkTime,
+		BanffTime:              latestForkTime,
+		CortinaTime:            latestForkTime,
+		DurangoTime:            latestForkTime,
+	}}
+	vm.clock.Set(latestForkTime)
+
+	ctx := snowtest.Context(t, snowtest.PChainID)
+	ctx.SharedMemory = m.NewSharedMemory(ctx.ChainID)
+
+	ctx.Lock.Lock()
+	defer ctx.Lock.Unlock()
+
+	require.NoError(vm.Initialize(
+		context.Background(),
+		ctx,
+		db,
+		genesisBytes,
+		nil,
+		faultTestConfigBytes,
+		make(chan common.Message, 1),
+		nil,
+		&common.SenderTest{
+			SendAppGossipF: func(context.Context, []byte) error {
+				return nil
+			},
+		},
+	))
+	require.NoError(vm.SetState(context.Background(), snow.NormalOp))
+	return vm
+}
+
+// crash stops the goroutines of [vm] without committing its state.
+func crash(vm *VM) {
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	vm.onShutdownCtxCancel()
+	vm.Builder.ShutdownBlockTimer()
+}
+
+func TestRecoverFromCommitFaults(t *testing.T) {
+	tests := []struct {
+		point faults.Point
+		// True if the block is accepted once the node restarts
+		accepted bool
+	}{
+		{
+			point:    faults.PartialStateWrite,
+			accepted: false,
+		},
+		{
+			point:    faults.BeforeSharedMemoryApply,
+			accepted: false,
+		},
+		{
+			point:    faults.AfterSharedMemoryApply,
+			accepted: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(string(test.point), func(t *testing.T) {
+			require := require.New(t)
+
+			var (
+				baseDB       = memdb.New()
+				chainDB      = prefixdb.New([]byte{0}, baseDB)
+				m            = atomic.NewMemory(prefixdb.New([]byte{1}, baseDB))
+				xChainMemory = m.NewSharedMemory(snowtest.XChainID)
+			)
+			_, genesisBytes := defaultGenesis(t, snowtest.AVAXAssetID)
+
+			vm := startFaultTestVM(t, chainDB, m, genesisBytes)
+			exportTx, err := vm.txBuilder.NewExportTx(
+				defaultTxFee,
+				vm.ctx.XChainID,
+				keys[0].Address(),
+				secp256k1fx.NewKeychain(keys[0]),
+				keys[0].Address(),
+				nil,
+			)
+			require.NoError(err)
+			exportedUTXOID := avax.UTXOID{
+				TxID:        exportTx.ID(),
+				OutputIndex: uint32(len(exportTx.Unsigned.(*txs.ExportTx).Outs)),
+			}
+			exportedInputID := exportedUTXOID.InputID()
+
+			require.NoError(vm.issueTx(context.Background(), exportTx))
+
+			vm.ctx.Lock.Lock()
+			parentID := vm.manager.LastAccepted()
+			blk, err := vm.Builder.BuildBlock(context.Background())
+			require.NoError(err)
+			require.NoError(blk.Verify(context.Background()))
+
+			faults.Fail(test.point)
+			err = blk.Accept(context.Background())
+			vm.ctx.Lock.Unlock()
+			require.ErrorIs(err, faults.ErrInjected)
+			crash(vm)
+
+			// The restarted node must pass its integrity check and agree with
+			// the shared memory on whether the block was accepted.
+			vm = startFaultTestVM(t, chainDB, m, genesisBytes)
+			defer func() {
+				vm.ctx.Lock.Lock()
+				defer vm.ctx.Lock.Unlock()
+
+				require.NoError(vm.Shutdown(context.Background()))
+			}()
+
+			expectedLastAccepted := parentID
+			if test.accepted {
+				expectedLastAccepted = blk.ID()
+			}
+			lastAccepted, err := vm.LastAccepted(context.Background())
+			require.NoError(err)
+			require.Equal(expectedLastAccepted, lastAccepted)
+
+			_, err = xChainMemory.Get(vm.ctx.ChainID, [][]byte{exportedInputID[:]})
+			if test.accepted {
+				require.NoError(err)
+				return
+			}
+			require.ErrorIs(err, database.ErrNotFound)
+
+			// The export can be accepted again after the restart.
+			require.NoError(vm.issueTx(context.Background(), exportTx))
+
+			vm.ctx.Lock.Lock()
+			defer vm.ctx.Lock.Unlock()
+
+			blk, err = vm.Builder.BuildBlock(context.Background())
+			require.NoError(err)
+			require.NoError(blk.Verify(context.Background()))
+			require.NoError(blk.Accept(context.Background()))
+
+			_, err = xChainMemory.Get(vm.ctx.ChainID, [][]byte{exportedInputID[:]})
+			require.NoError(err)
+		})
+	}
+}
+
+// Blocks accepted while bootstrapping are committed together with the next
+// block that modifies shared memory, so a crash while committing them must
+// leave either all or none of the blocks accepted.
+func TestRecoverFromDeferredCommitFaults(t *testing.T) {
+	tests := []struct {
+		point faults.Point
+		// True if the blocks are accepted once the node restarts
+		accepted bool
+	}{
+		{
+			point:    faults.BeforeSharedMemoryApply,
+			accepted: false,
+		},
+		{
+			point:    faults.AfterSharedMemoryApply,
+			accepted: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(string(test.point), func(t *testing.T) {
+			require := require.New(t)
+
+			var (
+				baseDB       = memdb.New()
+				chainDB      = prefixdb.New([]byte{0}, baseDB)
+				m            = atomic.NewMemory(prefixdb.New([]byte{1}, baseDB))
+				xChainMemory = m.NewSharedMemory(snowtest.XChainID)
+			)
+			_, genesisBytes := defaultGenesis(t, snowtest.AVAXAssetID)
+
+			vm := startFaultTestVM(t, chainDB, m, genesisBytes)
+			genesisID := vm.manager.LastAccepted()
+
+			// The first block doesn't modify shared memory, so its commit is
+			// deferred while bootstrapping.
+			createSubnetTx, err := vm.txBuilder.NewCreateSubnetTx(
+				1, // threshold
+				[]ids.ShortID{keys[0].Address()},
+				secp256k1fx.NewKeychain(keys[0]),
+				keys[0].Address(), // change address
+				nil,
+			)
+			require.NoError(err)
+			require.NoError(vm.issueTx(context.Background(), createSubnetTx))
+
+			vm.ctx.Lock.Lock()
+			subnetBlk, err := vm.Builder.BuildBlock(context.Background())
+			require.NoError(err)
+			require.NoError(subnetBlk.Verify(context.Background()))
+			require.NoError(vm.SetPreference(context.Background(), subnetBlk.ID()))
+			vm.ctx.Lock.Unlock()
+
+			exportTx, err := vm.txBuilder.NewExportTx(
+				defaultTxFee,
+				vm.ctx.XChainID,
+				keys[1].Address(),
+				secp256k1fx.NewKeychain(keys[1]),
+				keys[1].Address(),
+				nil,
+			)
+			require.NoError(err)
+			exportedUTXOID := avax.UTXOID{
+				TxID:        exportTx.ID(),
+				OutputIndex: uint32(len(exportTx.Unsigned.(*txs.ExportTx).Outs)),
+			}
+			exportedInputID := exportedUTXOID.InputID()
+			require.NoError(vm.issueTx(context.Background(), exportTx))
+
+			vm.ctx.Lock.Lock()
+			exportBlk, err := vm.Builder.BuildBlock(context.Background())
+			require.NoError(err)
+			require.NoError(exportBlk.Verify(context.Background()))
+
+			require.NoError(vm.SetState(context.Background(), snow.Bootstrapping))
+			require.NoError(subnetBlk.Accept(context.Background()))
+
+			faults.Fail(test.point)
+			err = exportBlk.Accept(context.Background())
+			vm.ctx.Lock.Unlock()
+			require.ErrorIs(err, faults.ErrInjected)
+			crash(vm)
+
+			vm = startFaultTestVM(t, chainDB, m, genesisBytes)
+			defer func() {
+				vm.ctx.Lock.Lock()
+				defer vm.ctx.Lock.Unlock()
+
+				require.NoError(vm.Shutdown(context.Background()))
+			}()
+
+			expectedLastAccepted := genesisID
+			if test.accepted {
+				expectedLastAccepted = exportBlk.ID()
+			}
+			lastAccepted, err := vm.LastAccepted(context.Background())
+			require.NoError(err)
+			require.Equal(expectedLastAccepted, lastAccepted)
+
+			_, _, err = vm.state.GetTx(createSubnetTx.ID())
+			_, sharedErr := xChainMemory.Get(vm.ctx.ChainID, [][]byte{exportedInputID[:]})
+			if test.accepted {
+				require.NoError(err)
+				require.NoError(sharedErr)
+				return
+			}
+			require.ErrorIs(err, database.ErrNotFound)
+			require.ErrorIs(sharedErr, database.ErrNotFound)
+		})
+	}
+}
@@ -0,0 +1,369 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/database/versiondb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
+	"github.com/ava-labs/avalanchego/vms/platformvm/offline"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/utxo"
+
+	blockbuilder "github.com/ava-labs/avalanchego/vms/platformvm/block/builder"
+	txexecutor "github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+)
+
+const (
+	// Max number of blocks that can be simulated by a single call to
+	// simulateFork
+	maxSimulatedBlocks = 64
+
+	// Max number of tx bytes packed into a simulated block, the same as the
+	// blocks built by this node
+	simulatedBlockSize = 128 * units.KiB
+)
+
+var (
+	errInvalidNumSimulatedBlocks = errors.New("invalid number of simulated blocks")
+	errNoForkToSimulate          = errors.New("every network upgrade is activated")
+	errConflictingSimulatedTx    = errors.New("tx conflicts with a tx of the simulated block")
+)
+
+// simulatedBlock is a hypothetical block built by simulateFork.
+type simulatedBlock struct {
+	blkID     ids.ID
+	height    uint64
+	timestamp time.Time
+	numTxs    int
+	// ID of the staker rewarded by the block, if any
+	rewardedTxID ids.ID
+	// err is the verification error of the block under the rules of the fork
+	err error
+}
+
+// simulatedTxFailure is a mempool tx that failed to be executed under the rules
+// of the fork.
+type simulatedTxFailure struct {
+	txID ids.ID
+	// Height of the simulated block the tx failed to be packed into
+	height uint64
+	err    error
+	// preForkErr is the error of the tx under the current rules, executed on
+	// the same state. It is nil if the failure is introduced by the fork.
+	preForkErr error
+}
+
+// forkSimulation is the result of simulateFork.
+type forkSimulation struct {
+	fork           string
+	activationTime time.Time
+	blocks         []simulatedBlock
+	failedTxs      []simulatedTxFailure
+	// Number of mempool txs that weren't packed into the simulated blocks
+	numRemainingTxs int
+}
+
+// simulateFork activates the network upgrade [fork], or the next scheduled one
+// if [fork] is empty, at the chain time of the last accepted block and builds
+// up to [numBlocks] hypothetical blocks on top of it, out of the txs of the
+// mempool. The txs and the blocks are verified under the rules of the fork and
+// the failures are reported.
+//
+// The blocks are accepted into a scratch state, backed by a copy-on-write
+// overlay of the database that is discarded afterwards, so that neither the
+// state nor the mempool of the VM are modified.
+//
+// Invariant: the context lock is held.
+func (vm *VM) simulateFork(fork string, numBlocks int) (*forkSimulation, error) {
+	if numBlocks <= 0 || numBlocks > maxSimulatedBlocks {
+		return nil, fmt.Errorf("%w: %d not in [1, %d]",
+			errInvalidNumSimulatedBlocks,
+			numBlocks,
+			maxSimulatedBlocks,
+		)
+	}
+
+	activationTime := vm.state.GetTimestamp()
+	var (
+		forkCfg *config.Config
+		err     error
+	)
+	if fork == "" {
+		var ok bool
+		fork, forkCfg, ok = vm.Config.ActivateNextFork(activationTime)
+		if !ok {
+			return nil, errNoForkToSimulate
+		}
+	} else {
+		forkCfg, err = vm.Config.ActivateFork(fork, activationTime)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// The fork checks of the simulation aren't observed, and the validator
+	// sets of the scratch state are tracked apart from those of the VM.
+	forkCfg.ForkObserver = nil
+	forkCfg.Validators = validators.NewManager()
+
+	// Blocks are never moved out of the scratch database.
+	execCfg := *vm.execConfig
+	execCfg.ColdBlockDepth = 0
+	scratchState, err := state.New(
+		versiondb.New(vm.db),
+		vm.genesisBytes,
+		prometheus.NewRegistry(),
+		forkCfg,
+		&execCfg,
+		vm.ctx,
+		metrics.Noop,
+		reward.NewCalculator(forkCfg.RewardConfig),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone the state: %w", err)
+	}
+	defer scratchState.Close()
+
+	verifier, err := offline.New(forkCfg, vm.ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := vm.clock.Time()
+	verifier.Clock().Set(now)
+
+	clk := &mockable.Clock{}
+	clk.Set(now)
+	backend := &txexecutor.Backend{
+		Config:       forkCfg,
+		Ctx:          vm.ctx,
+		Clk:          clk,
+		Fx:           vm.fx,
+		FlowChecker:  utxo.NewHandler(vm.ctx, clk, vm.fx),
+		Uptimes:      vm.uptimeManager,
+		Rewards:      reward.NewCalculator(forkCfg.RewardConfig),
+		Bootstrapped: &vm.bootstrapped,
+	}
+	preForkBackend := *backend
+	preForkBackend.Config = &vm.Config
+
+	var mempoolTxs []*txs.Tx
+	vm.Builder.Iterate(func(tx *txs.Tx) bool {
+		mempoolTxs = append(mempoolTxs, tx)
+		return true
+	})
+
+	parent, err := scratchState.GetStatelessBlock(scratchState.GetLastAccepted())
+	if err != nil {
+		return nil, err
+	}
+
+	s := &forkSimulation{
+		fork:           fork,
+		activationTime: activationTime,
+	}
+	for len(s.blocks) < numBlocks {
+		blk, remainingTxs, err := s.buildBlock(scratchState, backend, &preForkBackend, parent, mempoolTxs)
+		if err != nil {
+			return nil, err
+		}
+		if blk == nil {
+			break
+		}
+		mempoolTxs = remainingTxs
+
+		simulated := simulatedBlock{
+			blkID:     blk.ID(),
+			height:    blk.Height(),
+			timestamp: blk.(block.BanffBlock).Timestamp(),
+			numTxs:    len(blk.Txs()),
+		}
+		if proposal, ok := blk.(*block.BanffProposalBlock); ok {
+			simulated.rewardedTxID = proposal.Tx.Unsigned.(*txs.RewardValidatorTx).TxID
+		}
+
+		onAcceptState, err := verifier.VerifyBlock(scratchState, parent, blk)
+		if err != nil {
+			simulated.err = err
+			s.blocks = append(s.blocks, simulated)
+			break
+		}
+		s.blocks = append(s.blocks, simulated)
+
+		if err := onAcceptState.Apply(scratchState); err != nil {
+			return nil, err
+		}
+		scratchState.AddStatelessBlock(blk)
+		scratchState.SetLastAccepted(blk.ID())
+		scratchState.SetHeight(blk.Height())
+		if err := scratchState.Commit(); err != nil {
+			return nil, fmt.Errorf("failed to commit simulated block %s: %w", blk.ID(), err)
+		}
+		parent = blk
+	}
+	s.numRemainingTxs = len(mempoolTxs)
+	return s, nil
+}
+
+// buildBlock builds the child of [parent] out of [mempoolTxs], the way the
+// block builder would. The txs that fail to be executed are reported and
+// dropped. Returns the txs that weren't packed into the block, or a nil block
+// if there is no reason to build one.
+func (s *forkSimulation) buildBlock(
+	parentState state.Chain,
+	backend *txexecutor.Backend,
+	preForkBackend *txexecutor.Backend,
+	parent block.Block,
+	mempoolTxs []*txs.Tx,
+) (block.Block, []*txs.Tx, error) {
+	var (
+		parentID = parent.ID()
+		height   = parent.Height() + 1
+	)
+	timestamp, timeWasCapped, err := txexecutor.NextBlockTime(parentState, backend.Clk)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stakerTxID, shouldReward, err := nextStakerToReward(parentState, timestamp)
+	if err != nil {
+		return nil, nil, err
+	}
+	var rewardTx *txs.Tx
+	if shouldReward {
+		rewardTx, err = blockbuilder.NewRewardValidatorTx(backend.Ctx, stakerTxID)
+		if err != nil {
+			return nil, nil, err
+		}
+		// Pre-Durango, proposal blocks don't include decision txs.
+		if !backend.Config.IsDurangoActivated(timestamp) {
+			blk, err := block.NewBanffProposalBlock(timestamp, parentID, height, rewardTx, nil)
+			return blk, mempoolTxs, err
+		}
+	}
+
+	blockState, err := state.NewDiffOn(parentState)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := txexecutor.AdvanceTimeTo(backend, blockState, timestamp); err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		remainingSize              = simulatedBlockSize
+		remainingComplexity uint64 = math.MaxUint64
+		blockTxs            []*txs.Tx
+		remainingTxs        []*txs.Tx
+		inputs              set.Set[ids.ID]
+	)
+	if backend.Config.IsBlockComplexityActivated(timestamp) {
+		remainingComplexity = backend.Config.MaxBlockComplexity
+	}
+	for i, tx := range mempoolTxs {
+		txSize := len(tx.Bytes())
+		txComplexity, err := txs.Complexity(tx)
+		if err != nil {
+			s.failTx(tx, height, err, err)
+			continue
+		}
+		if txSize > remainingSize || txComplexity > remainingComplexity {
+			remainingTxs = append(remainingTxs, mempoolTxs[i:]...)
+			break
+		}
+
+		txState, err := state.NewDiffOn(blockState)
+		if err != nil {
+			return nil, nil, err
+		}
+		executor := &txexecutor.StandardTxExecutor{
+			Backend: backend,
+			State:   txState,
+			Tx:      tx,
+		}
+		if err := tx.Unsigned.Visit(executor); err != nil {
+			s.failTx(tx, height, err, executeTx(preForkBackend, blockState, tx))
+			continue
+		}
+		if inputs.Overlaps(executor.Inputs) {
+			s.failTx(tx, height, errConflictingSimulatedTx, errConflictingSimulatedTx)
+			continue
+		}
+		inputs.Union(executor.Inputs)
+
+		txState.AddTx(tx, status.Committed)
+		if err := txState.Apply(blockState); err != nil {
+			return nil, nil, err
+		}
+		remainingSize -= txSize
+		remainingComplexity -= txComplexity
+		blockTxs = append(blockTxs, tx)
+	}
+
+	if rewardTx != nil {
+		blk, err := block.NewBanffProposalBlock(timestamp, parentID, height, rewardTx, blockTxs)
+		return blk, remainingTxs, err
+	}
+	if len(blockTxs) == 0 && !timeWasCapped {
+		return nil, remainingTxs, nil
+	}
+	blk, err := block.NewBanffStandardBlock(timestamp, parentID, height, blockTxs)
+	return blk, remainingTxs, err
+}
+
+func (s *forkSimulation) failTx(tx *txs.Tx, height uint64, err error, preForkErr error) {
+	s.failedTxs = append(s.failedTxs, simulatedTxFailure{
+		txID:       tx.ID(),
+		height:     height,
+		err:        err,
+		preForkErr: preForkErr,
+	})
+}
+
+// executeTx returns the error of executing [tx] on top of [parentState] with
+// [backend]. [parentState] isn't modified.
+func executeTx(backend *txexecutor.Backend, parentState state.Chain, tx *txs.Tx) error {
+	txState, err := state.NewDiffOn(parentState)
+	if err != nil {
+		return err
+	}
+	return tx.Unsigned.Visit(&txexecutor.StandardTxExecutor{
+		Backend: backend,
+		State:   txState,
+		Tx:      tx,
+	})
+}
+
+// nextStakerToReward returns the permissionless staker that leaves the current
+// staker set first, and whether it must be rewarded at [timestamp].
+func nextStakerToReward(chainState state.Chain, timestamp time.Time) (ids.ID, bool, error) {
+	it, err := chainState.GetCurrentStakerIterator()
+	if err != nil {
+		return ids.Empty, false, err
+	}
+	defer it.Release()
+
+	for it.Next() {
+		staker := it.Value()
+		if staker.Priority != txs.SubnetPermissionedValidatorCurrentPriority {
+			return staker.TxID, timestamp.Equal(staker.EndTime), nil
+		}
+	}
+	return ids.Empty, false, nil
+}
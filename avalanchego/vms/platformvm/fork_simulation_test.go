@@ -0,0 +1,88 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	txexecutor "github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+)
+
+func TestSimulateFork(t *testing.T) {
+	require := require.New(t)
+	vm, _, _ := defaultVM(t, cortina)
+
+	var (
+		startTime = vm.clock.Time().Add(txexecutor.SyncBound).Add(time.Second)
+		endTime   = startTime.Add(defaultMinStakingDuration)
+	)
+	// AddValidatorTxs are only permitted pre-Durango
+	addValidatorTx, err := vm.txBuilder.NewAddValidatorTx(
+		vm.MinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		ids.GenerateTestNodeID(),
+		ids.GenerateTestShortID(),
+		reward.PercentDenominator,
+		secp256k1fx.NewKeychain(keys[0]),
+		keys[0].Address(), // change address
+		nil,
+	)
+	require.NoError(err)
+	require.NoError(vm.issueTx(context.Background(), addValidatorTx))
+
+	createSubnetTx, err := vm.txBuilder.NewCreateSubnetTx(
+		1, // threshold
+		[]ids.ShortID{keys[1].Address()},
+		secp256k1fx.NewKeychain(keys[1]),
+		keys[1].Address(), // change address
+		nil,
+	)
+	require.NoError(err)
+	require.NoError(vm.issueTx(context.Background(), createSubnetTx))
+
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	lastAcceptedID := vm.state.GetLastAccepted()
+
+	_, err = vm.simulateFork("durango", 0)
+	require.ErrorIs(err, errInvalidNumSimulatedBlocks)
+	_, err = vm.simulateFork("unknown", 1)
+	require.ErrorIs(err, config.ErrUnknownFork)
+
+	// The next scheduled upgrade is Durango
+	s, err := vm.simulateFork("", 4)
+	require.NoError(err)
+	require.Equal("durango", s.fork)
+	require.Equal(vm.state.GetTimestamp(), s.activationTime)
+
+	require.Len(s.failedTxs, 1)
+	failure := s.failedTxs[0]
+	require.Equal(addValidatorTx.ID(), failure.txID)
+	require.ErrorIs(failure.err, txexecutor.ErrAddValidatorTxPostDurango)
+	require.NoError(failure.preForkErr)
+
+	require.Len(s.blocks, 1)
+	blk := s.blocks[0]
+	require.NoError(blk.err)
+	require.Equal(1, blk.numTxs)
+	require.Zero(s.numRemainingTxs)
+
+	// Neither the state nor the mempool were modified
+	require.Equal(lastAcceptedID, vm.state.GetLastAccepted())
+	require.Equal(2, vm.Builder.Len())
+	_, ok := vm.Builder.Get(addValidatorTx.ID())
+	require.True(ok)
+	requireConformance(t, vm)
+}
@@ -0,0 +1,156 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/genesis"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+// genesisVerification is the result of recomputing the genesis state from
+// genesis bytes and comparing it against the state of the chain.
+type genesisVerification struct {
+	// Hash of the verified genesis bytes
+	genesisID ids.ID
+	// ID of the genesis block the verified genesis bytes produce
+	genesisBlockID ids.ID
+	// ID of the genesis block that was accepted by the chain. Empty if the
+	// block isn't indexed by height.
+	acceptedGenesisBlockID ids.ID
+
+	genesis *genesis.Genesis
+
+	// UTXOs created at genesis that are still unspent
+	unspentUTXOs int
+	// UTXOs created at genesis that were spent since
+	spentUTXOs int
+	// UTXOs created at genesis whose content in the state differs from the
+	// genesis
+	mismatchedUTXOs []ids.ID
+
+	// Genesis validators that are still validating
+	currentValidators int
+	// Genesis txs, validators and chains, that aren't committed in the state
+	// with the same content
+	mismatchedTxs []ids.ID
+}
+
+// valid returns true if the genesis produces the accepted genesis block and
+// nothing it created was altered.
+func (v *genesisVerification) valid() bool {
+	return v.genesisBlockID == v.acceptedGenesisBlockID &&
+		len(v.mismatchedUTXOs) == 0 &&
+		len(v.mismatchedTxs) == 0
+}
+
+// verifyGenesis recomputes the UTXOs, the validators and the chains created by
+// [genesisBytes] and compares them against [chainState]. UTXOs created at
+// genesis may have been spent since, but those that remain must be unchanged.
+// Txs created at genesis must be committed unchanged.
+func verifyGenesis(genesisBytes []byte, chainState state.State) (*genesisVerification, error) {
+	gen, err := genesis.Parse(genesisBytes)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse genesis: %w", err)
+	}
+
+	genesisID := hashing.ComputeHash256Array(genesisBytes)
+	genesisBlock, err := block.NewApricotCommitBlock(genesisID, 0 /*height*/)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &genesisVerification{
+		genesisID:      genesisID,
+		genesisBlockID: genesisBlock.ID(),
+		genesis:        gen,
+	}
+	v.acceptedGenesisBlockID, err = chainState.GetBlockIDAtHeight(0)
+	if err != nil && err != database.ErrNotFound {
+		return nil, fmt.Errorf("couldn't get accepted genesis block: %w", err)
+	}
+
+	for _, genesisUTXO := range gen.UTXOs {
+		utxoID := genesisUTXO.InputID()
+		utxo, err := chainState.GetUTXO(utxoID)
+		switch err {
+		case nil:
+		case database.ErrNotFound:
+			v.spentUTXOs++
+			continue
+		default:
+			return nil, fmt.Errorf("couldn't get UTXO %s: %w", utxoID, err)
+		}
+
+		expectedBytes, err := txs.GenesisCodec.Marshal(txs.CodecVersion, &genesisUTXO.UTXO)
+		if err != nil {
+			return nil, err
+		}
+		utxoBytes, err := txs.GenesisCodec.Marshal(txs.CodecVersion, utxo)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(expectedBytes, utxoBytes) {
+			v.mismatchedUTXOs = append(v.mismatchedUTXOs, utxoID)
+			continue
+		}
+		v.unspentUTXOs++
+	}
+
+	for _, vdrTx := range gen.Validators {
+		matches, err := isCommittedUnchanged(chainState, vdrTx)
+		if err != nil {
+			return nil, err
+		}
+		if !matches {
+			v.mismatchedTxs = append(v.mismatchedTxs, vdrTx.ID())
+			continue
+		}
+
+		staker, ok := vdrTx.Unsigned.(txs.Staker)
+		if !ok {
+			return nil, fmt.Errorf("expected a staker but got %T", vdrTx.Unsigned)
+		}
+		vdr, err := chainState.GetCurrentValidator(constants.PrimaryNetworkID, staker.NodeID())
+		switch {
+		case err == nil && vdr.TxID == vdrTx.ID():
+			v.currentValidators++
+		case err != nil && err != database.ErrNotFound:
+			return nil, fmt.Errorf("couldn't get validator %s: %w", staker.NodeID(), err)
+		}
+	}
+
+	for _, chainTx := range gen.Chains {
+		matches, err := isCommittedUnchanged(chainState, chainTx)
+		if err != nil {
+			return nil, err
+		}
+		if !matches {
+			v.mismatchedTxs = append(v.mismatchedTxs, chainTx.ID())
+		}
+	}
+	return v, nil
+}
+
+// isCommittedUnchanged returns true if [tx] is committed in [chainState] with
+// the same bytes.
+func isCommittedUnchanged(chainState state.Chain, tx *txs.Tx) (bool, error) {
+	committedTx, txStatus, err := chainState.GetTx(tx.ID())
+	if err == database.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("couldn't get tx %s: %w", tx.ID(), err)
+	}
+	return txStatus == status.Committed && bytes.Equal(committedTx.Bytes(), tx.Bytes()), nil
+}
@@ -5,21 +5,28 @@ package platformvm
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/platformvm/alerts"
 )
 
+var errAlertsFiring = errors.New("alerts firing")
+
 func (vm *VM) HealthCheck(context.Context) (interface{}, error) {
+	var validatorEndTime time.Time
 	localPrimaryValidator, err := vm.state.GetCurrentValidator(
 		constants.PrimaryNetworkID,
 		vm.ctx.NodeID,
 	)
 	switch err {
 	case nil:
-		vm.metrics.SetTimeUntilUnstake(time.Until(localPrimaryValidator.EndTime))
+		validatorEndTime = localPrimaryValidator.EndTime
+		vm.metrics.SetTimeUntilUnstake(localPrimaryValidator.EndTime.Sub(vm.clock.Time()))
 	case database.ErrNotFound:
 		vm.metrics.SetTimeUntilUnstake(0)
 	default:
@@ -33,12 +40,50 @@ func (vm *VM) HealthCheck(context.Context) (interface{}, error) {
 		)
 		switch err {
 		case nil:
-			vm.metrics.SetTimeUntilSubnetUnstake(subnetID, time.Until(localSubnetValidator.EndTime))
+			vm.metrics.SetTimeUntilSubnetUnstake(subnetID, localSubnetValidator.EndTime.Sub(vm.clock.Time()))
 		case database.ErrNotFound:
 			vm.metrics.SetTimeUntilSubnetUnstake(subnetID, 0)
 		default:
 			return nil, fmt.Errorf("couldn't get current subnet validator of %q: %w", subnetID, err)
 		}
 	}
-	return nil, nil
+
+	now := vm.clock.Time()
+	primaryValidators := vm.Validators.GetMap(constants.PrimaryNetworkID)
+	nextUpgrade, nextUpgradeTime, _ := vm.Config.NextFork(now)
+	readiness := vm.peerCompatibility.readiness(
+		primaryValidators,
+		vm.ctx.NodeID,
+		nextUpgrade,
+		nextUpgradeTime,
+		now,
+	)
+	vm.metrics.SetUpgradeReadiness(readiness.ConnectedWeight, readiness.OutdatedWeight, readiness.NewerWeight)
+
+	connectivity := vm.peerConnections.connectivity(primaryValidators, vm.ctx.NodeID)
+	status := alerts.Status{
+		Now:                 now,
+		ValidatorEndTime:    validatorEndTime,
+		ConnectedPercentage: connectivity.ConnectedPercentage(),
+	}
+	if oldestTx, ok := vm.Builder.Peek(); ok {
+		status.OldestMempoolTxID = oldestTx.ID()
+	}
+	firingAlerts := vm.alertEvaluator.Evaluate(status)
+
+	details := map[string]interface{}{
+		"upgradeReadiness": readiness,
+		"alerts":           firingAlerts,
+	}
+	if err := readiness.Verify(); err != nil {
+		return details, err
+	}
+	if len(firingAlerts) != 0 {
+		rules := make([]string, len(firingAlerts))
+		for i, alert := range firingAlerts {
+			rules[i] = alert.Rule
+		}
+		return details, fmt.Errorf("%w: %s", errAlertsFiring, strings.Join(rules, ", "))
+	}
+	return details, nil
 }
@@ -0,0 +1,44 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package heartbeat
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+)
+
+// Heartbeat is periodically gossiped by a validator to announce that it is
+// online, along with the subnets that it tracks.
+type Heartbeat struct {
+	NodeID ids.NodeID `serialize:"true" json:"nodeID"`
+	// P-chain height accepted by the validator
+	Height uint64 `serialize:"true" json:"height"`
+	// Unix time that the heartbeat was sent at
+	Timestamp      uint64   `serialize:"true" json:"timestamp"`
+	Version        string   `serialize:"true" json:"version"`
+	TrackedSubnets []ids.ID `serialize:"true" json:"trackedSubnets"`
+}
+
+// UnsignedMessage returns the warp message, sent by [chainID], that the
+// validator signs. Its payload is the hash of the heartbeat.
+func (h *Heartbeat) UnsignedMessage(networkID uint32, chainID ids.ID) (*warp.UnsignedMessage, error) {
+	bytes, err := Codec.Marshal(CodecVersion, h)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := payload.NewHash(hashing.ComputeHash256Array(bytes))
+	if err != nil {
+		return nil, err
+	}
+	return warp.NewUnsignedMessage(networkID, chainID, hash.Bytes())
+}
+
+// signedHeartbeat is gossiped by a validator, signed with its BLS key.
+type signedHeartbeat struct {
+	Heartbeat Heartbeat              `serialize:"true"`
+	Signature [bls.SignatureLen]byte `serialize:"true"`
+}
@@ -0,0 +1,234 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package heartbeat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+// A peer is live if its latest heartbeat was sent within [livenessIntervals]
+// heartbeat intervals.
+const livenessIntervals = 3
+
+var (
+	_ p2p.Handler = (*Tracker)(nil)
+
+	errWrongSender       = errors.New("heartbeat wasn't sent by its node")
+	errNotValidator      = errors.New("sender isn't a primary network validator with a BLS key")
+	errInvalidSignature  = errors.New("invalid signature")
+	errFutureHeartbeat   = errors.New("heartbeat is too far in the future")
+	errOutdatedHeartbeat = errors.New("heartbeat is older than the latest heartbeat of the node")
+)
+
+// Sender sends the heartbeats of this node to the other validators.
+type Sender interface {
+	AppGossipSpecific(ctx context.Context, nodeIDs set.Set[ids.NodeID], appGossipBytes []byte) error
+}
+
+// Validators are the current primary network validators, whose heartbeats are
+// tracked.
+type Validators interface {
+	GetValidator(subnetID ids.ID, nodeID ids.NodeID) (*validators.Validator, bool)
+	GetValidatorIDs(subnetID ids.ID) []ids.NodeID
+}
+
+type Config struct {
+	// Interval between the heartbeats sent by this node
+	Interval       time.Duration
+	NetworkID      uint32
+	ChainID        ids.ID
+	NodeID         ids.NodeID
+	Version        string
+	TrackedSubnets set.Set[ids.ID]
+}
+
+// Tracker gossips the heartbeats of this node to the primary network
+// validators and keeps the latest heartbeat of every validator. Heartbeats
+// aren't part of consensus and are only kept in memory.
+type Tracker struct {
+	p2p.NoOpHandler
+
+	config     Config
+	log        logging.Logger
+	signer     warp.Signer
+	validators Validators
+	state      validators.State
+
+	clock *mockable.Clock
+
+	lock       sync.RWMutex
+	heartbeats map[ids.NodeID]Heartbeat
+}
+
+// New returns a tracker. [state] must be safe to call concurrently with the
+// acceptance of blocks. Heartbeats are timestamped and expired according to
+// [clock].
+func New(
+	config Config,
+	log logging.Logger,
+	clock *mockable.Clock,
+	signer warp.Signer,
+	validators Validators,
+	state validators.State,
+) *Tracker {
+	return &Tracker{
+		config:     config,
+		log:        log,
+		clock:      clock,
+		signer:     signer,
+		validators: validators,
+		state:      state,
+		heartbeats: make(map[ids.NodeID]Heartbeat),
+	}
+}
+
+// Run sends a heartbeat with [sender] every interval until [ctx] is
+// cancelled.
+func (t *Tracker) Run(ctx context.Context, sender Sender) {
+	ticker := time.NewTicker(t.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := t.beat(ctx, sender); err != nil {
+			t.log.Warn("failed to send heartbeat",
+				zap.Error(err),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (t *Tracker) beat(ctx context.Context, sender Sender) error {
+	if vdr, ok := t.validators.GetValidator(constants.PrimaryNetworkID, t.config.NodeID); !ok || vdr.PublicKey == nil {
+		// Only validators send heartbeats
+		return nil
+	}
+
+	height, err := t.state.GetCurrentHeight(ctx)
+	if err != nil {
+		return err
+	}
+	heartbeat := Heartbeat{
+		NodeID:         t.config.NodeID,
+		Height:         height,
+		Timestamp:      uint64(t.clock.Unix()),
+		Version:        t.config.Version,
+		TrackedSubnets: t.config.TrackedSubnets.List(),
+	}
+	unsignedMsg, err := heartbeat.UnsignedMessage(t.config.NetworkID, t.config.ChainID)
+	if err != nil {
+		return err
+	}
+	sigBytes, err := t.signer.Sign(unsignedMsg)
+	if err != nil {
+		return err
+	}
+	msg := &signedHeartbeat{
+		Heartbeat: heartbeat,
+	}
+	copy(msg.Signature[:], sigBytes)
+	msgBytes, err := Codec.Marshal(CodecVersion, msg)
+	if err != nil {
+		return err
+	}
+
+	t.lock.Lock()
+	t.heartbeats[t.config.NodeID] = heartbeat
+	t.lock.Unlock()
+
+	nodeIDs := set.Of(t.validators.GetValidatorIDs(constants.PrimaryNetworkID)...)
+	nodeIDs.Remove(t.config.NodeID)
+	return sender.AppGossipSpecific(ctx, nodeIDs, msgBytes)
+}
+
+func (t *Tracker) AppGossip(_ context.Context, nodeID ids.NodeID, gossipBytes []byte) {
+	if err := t.add(nodeID, gossipBytes); err != nil {
+		t.log.Debug("dropping heartbeat",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+	}
+}
+
+func (t *Tracker) add(nodeID ids.NodeID, gossipBytes []byte) error {
+	msg := &signedHeartbeat{}
+	if _, err := Codec.Unmarshal(gossipBytes, msg); err != nil {
+		return err
+	}
+	heartbeat := msg.Heartbeat
+	if heartbeat.NodeID != nodeID {
+		return fmt.Errorf("%w: sent by %s", errWrongSender, nodeID)
+	}
+	maxTimestamp := uint64(t.clock.Time().Add(t.config.Interval).Unix())
+	if heartbeat.Timestamp > maxTimestamp {
+		return fmt.Errorf("%w: %d > %d", errFutureHeartbeat, heartbeat.Timestamp, maxTimestamp)
+	}
+
+	vdr, ok := t.validators.GetValidator(constants.PrimaryNetworkID, nodeID)
+	if !ok || vdr.PublicKey == nil {
+		return errNotValidator
+	}
+	unsignedMsg, err := heartbeat.UnsignedMessage(t.config.NetworkID, t.config.ChainID)
+	if err != nil {
+		return err
+	}
+	sig, err := bls.SignatureFromBytes(msg.Signature[:])
+	if err != nil {
+		return err
+	}
+	if !bls.Verify(vdr.PublicKey, sig, unsignedMsg.Bytes()) {
+		return errInvalidSignature
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if latest, ok := t.heartbeats[nodeID]; ok && latest.Timestamp >= heartbeat.Timestamp {
+		return errOutdatedHeartbeat
+	}
+	t.heartbeats[nodeID] = heartbeat
+	return nil
+}
+
+// Live returns the latest heartbeats of the peers that are live and track
+// [subnetID]. All the live peers track the primary network.
+func (t *Tracker) Live(subnetID ids.ID) []Heartbeat {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	minTimestamp := uint64(t.clock.Time().Add(-livenessIntervals * t.config.Interval).Unix())
+	var live []Heartbeat
+	for _, heartbeat := range t.heartbeats {
+		if heartbeat.Timestamp < minTimestamp {
+			continue
+		}
+		if subnetID != constants.PrimaryNetworkID && !slices.Contains(heartbeat.TrackedSubnets, subnetID) {
+			continue
+		}
+		live = append(live, heartbeat)
+	}
+	return live
+}
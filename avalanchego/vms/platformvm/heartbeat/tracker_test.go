@@ -0,0 +1,195 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package heartbeat
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+const networkID = constants.UnitTestID
+
+// testSender delivers the gossip of [nodeID] to the trackers of the other
+// nodes.
+type testSender struct {
+	nodeID   ids.NodeID
+	trackers map[ids.NodeID]*Tracker
+}
+
+func (s *testSender) AppGossipSpecific(ctx context.Context, nodeIDs set.Set[ids.NodeID], msg []byte) error {
+	for nodeID := range nodeIDs {
+		if tracker, ok := s.trackers[nodeID]; ok {
+			tracker.AppGossip(ctx, s.nodeID, msg)
+		}
+	}
+	return nil
+}
+
+func TestTracker(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		chainID  = constants.PlatformChainID
+		subnetID = ids.GenerateTestID()
+		now      = time.Unix(1_000_000, 0)
+		nodeIDs  = []ids.NodeID{ids.GenerateTestNodeID(), ids.GenerateTestNodeID(), ids.GenerateTestNodeID()}
+		vdrs     = validators.NewManager()
+		trackers = make(map[ids.NodeID]*Tracker)
+		senders  = make(map[ids.NodeID]*testSender)
+		vdrState = &validators.TestState{
+			GetCurrentHeightF: func(context.Context) (uint64, error) {
+				return 10, nil
+			},
+		}
+		clk = &mockable.Clock{}
+	)
+	clk.Set(now)
+	for i, nodeID := range nodeIDs {
+		sk, err := bls.NewSecretKey()
+		require.NoError(err)
+		if i < 2 {
+			// The last node isn't a validator
+			require.NoError(vdrs.AddStaker(constants.PrimaryNetworkID, nodeID, bls.PublicFromSecretKey(sk), ids.Empty, 1))
+		}
+
+		trackedSubnets := set.Set[ids.ID]{}
+		if i == 0 {
+			trackedSubnets.Add(subnetID)
+		}
+		trackers[nodeID] = New(
+			Config{
+				Interval:       time.Minute,
+				NetworkID:      networkID,
+				ChainID:        chainID,
+				NodeID:         nodeID,
+				Version:        "v1.0.0",
+				TrackedSubnets: trackedSubnets,
+			},
+			logging.NoLog{},
+			clk,
+			warp.NewSigner(sk, networkID, chainID),
+			vdrs,
+			vdrState,
+		)
+		senders[nodeID] = &testSender{
+			nodeID:   nodeID,
+			trackers: trackers,
+		}
+	}
+
+	ctx := context.Background()
+	for _, nodeID := range nodeIDs {
+		require.NoError(trackers[nodeID].beat(ctx, senders[nodeID]))
+	}
+
+	tracker := trackers[nodeIDs[1]]
+	live := tracker.Live(constants.PrimaryNetworkID)
+	require.Len(live, 2)
+	require.ElementsMatch(nodeIDs[:2], []ids.NodeID{live[0].NodeID, live[1].NodeID})
+
+	live = tracker.Live(subnetID)
+	require.Equal(
+		[]Heartbeat{{
+			NodeID:         nodeIDs[0],
+			Height:         10,
+			Timestamp:      uint64(now.Unix()),
+			Version:        "v1.0.0",
+			TrackedSubnets: []ids.ID{subnetID},
+		}},
+		live,
+	)
+
+	// Peers are no longer live once they stop sending heartbeats
+	clk.Set(now.Add(livenessIntervals*time.Minute + time.Second))
+	require.Empty(tracker.Live(constants.PrimaryNetworkID))
+}
+
+func TestTrackerAddInvalidHeartbeat(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		chainID = constants.PlatformChainID
+		nodeID  = ids.GenerateTestNodeID()
+		now     = time.Unix(1_000_000, 0)
+		vdrs    = validators.NewManager()
+		clk     = &mockable.Clock{}
+	)
+	clk.Set(now)
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	require.NoError(vdrs.AddStaker(constants.PrimaryNetworkID, nodeID, bls.PublicFromSecretKey(sk), ids.Empty, 1))
+
+	tracker := New(
+		Config{
+			Interval:  time.Minute,
+			NetworkID: networkID,
+			ChainID:   chainID,
+		},
+		logging.NoLog{},
+		clk,
+		nil,
+		vdrs,
+		nil,
+	)
+
+	signer := warp.NewSigner(sk, networkID, chainID)
+	sign := func(heartbeat Heartbeat) []byte {
+		unsignedMsg, err := heartbeat.UnsignedMessage(networkID, chainID)
+		require.NoError(err)
+		sig, err := signer.Sign(unsignedMsg)
+		require.NoError(err)
+		msg := &signedHeartbeat{
+			Heartbeat: heartbeat,
+		}
+		copy(msg.Signature[:], sig)
+		msgBytes, err := Codec.Marshal(CodecVersion, msg)
+		require.NoError(err)
+		return msgBytes
+	}
+
+	heartbeat := Heartbeat{
+		NodeID:    nodeID,
+		Timestamp: uint64(now.Unix()),
+	}
+	err = tracker.add(ids.GenerateTestNodeID(), sign(heartbeat))
+	require.ErrorIs(err, errWrongSender)
+
+	require.NoError(tracker.add(nodeID, sign(heartbeat)))
+	err = tracker.add(nodeID, sign(heartbeat))
+	require.ErrorIs(err, errOutdatedHeartbeat)
+
+	futureHeartbeat := heartbeat
+	futureHeartbeat.Timestamp = uint64(now.Add(2 * time.Minute).Unix())
+	err = tracker.add(nodeID, sign(futureHeartbeat))
+	require.ErrorIs(err, errFutureHeartbeat)
+
+	// The signature doesn't cover a different heartbeat
+	msg := &signedHeartbeat{}
+	_, err = Codec.Unmarshal(sign(heartbeat), msg)
+	require.NoError(err)
+	msg.Heartbeat.Timestamp++
+	msgBytes, err := Codec.Marshal(CodecVersion, msg)
+	require.NoError(err)
+	err = tracker.add(nodeID, msgBytes)
+	require.ErrorIs(err, errInvalidSignature)
+
+	nonValidatorHeartbeat := Heartbeat{
+		NodeID:    ids.GenerateTestNodeID(),
+		Timestamp: uint64(now.Unix()),
+	}
+	err = tracker.add(nonValidatorHeartbeat.NodeID, sign(nonValidatorHeartbeat))
+	require.ErrorIs(err, errNotValidator)
+}
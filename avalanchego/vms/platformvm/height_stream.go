@@ -0,0 +1,130 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// heightStreamPollFrequency is how often a stream that caught up with the last
+// accepted height checks for newly accepted heights.
+const heightStreamPollFrequency = time.Second
+
+// streamHeights writes the message returned by [getMessage] for every accepted
+// height to [w] as newline delimited JSON. The heights are configured with the
+// query parameters of [r]:
+//   - startHeight: the first height to stream, the last accepted height by
+//     default
+//   - endHeight: the last height to stream. If unset, the stream follows the
+//     accepted heights until the client disconnects.
+//
+// [getMessage] is called with the context lock held.
+func (vm *VM) streamHeights(
+	w http.ResponseWriter,
+	r *http.Request,
+	streamName string,
+	getMessage func(height uint64) (any, error),
+) {
+	ctx := r.Context()
+	lastAcceptedHeight, err := vm.lastAcceptedHeight(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	query := r.URL.Query()
+	startHeight, err := parseHeightParam(query.Get("startHeight"), lastAcceptedHeight)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid startHeight: %s", err), http.StatusBadRequest)
+		return
+	}
+	endHeight, err := parseHeightParam(query.Get("endHeight"), math.MaxUint64)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid endHeight: %s", err), http.StatusBadRequest)
+		return
+	}
+	if endHeight < startHeight {
+		http.Error(w, "endHeight is less than startHeight", http.StatusBadRequest)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	vm.ctx.Log.Debug("starting stream",
+		zap.String("stream", streamName),
+		zap.Uint64("startHeight", startHeight),
+		zap.Uint64("endHeight", endHeight),
+	)
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+
+	ticker := time.NewTicker(heightStreamPollFrequency)
+	defer ticker.Stop()
+
+	encoder := json.NewEncoder(w)
+	height := startHeight
+	for {
+		for ; height <= min(lastAcceptedHeight, endHeight); height++ {
+			vm.ctx.Lock.Lock()
+			msg, err := getMessage(height)
+			vm.ctx.Lock.Unlock()
+			if err != nil {
+				vm.ctx.Log.Debug("closing stream",
+					zap.String("stream", streamName),
+					zap.Uint64("height", height),
+					zap.Error(err),
+				)
+				return
+			}
+			if err := encoder.Encode(msg); err != nil {
+				return
+			}
+			if height == endHeight {
+				flusher.Flush()
+				return
+			}
+		}
+		flusher.Flush()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		lastAcceptedHeight, err = vm.lastAcceptedHeight(ctx)
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (vm *VM) lastAcceptedHeight(ctx context.Context) (uint64, error) {
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	return vm.GetCurrentHeight(ctx)
+}
+
+// parseHeightParam parses [heightStr] as a height, returning [defaultHeight]
+// if it is empty.
+func parseHeightParam(heightStr string, defaultHeight uint64) (uint64, error) {
+	if heightStr == "" {
+		return defaultHeight, nil
+	}
+	return strconv.ParseUint(heightStr, 10, 64)
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+var errIPQuotaExceeded = errors.New("IP quota exceeded")
+
+// ipQuotas limits the number of calls that every IP may make during each
+// period. Calls made from behind a proxy are counted against the IP of the
+// proxy.
+//
+// If the limit is 0, or if the quotas are nil, calls are never limited.
+type ipQuotas struct {
+	limit  int
+	period time.Duration
+	clock  *mockable.Clock
+
+	lock        sync.Mutex
+	windowStart time.Time
+	// IP --> number of calls made since [windowStart]
+	calls map[string]int
+}
+
+func newIPQuotas(limit int, period time.Duration, clock *mockable.Clock) *ipQuotas {
+	return &ipQuotas{
+		limit:  limit,
+		period: period,
+		clock:  clock,
+		calls:  make(map[string]int),
+	}
+}
+
+// consume counts a call of [r] against the quota of its IP. Returns an error
+// if the quota of the IP is exhausted.
+func (q *ipQuotas) consume(r *http.Request) error {
+	if q == nil || q.limit <= 0 {
+		return nil
+	}
+	ip := requestIP(r)
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	now := q.clock.Time()
+	if now.Sub(q.windowStart) >= q.period {
+		q.windowStart = now
+		clear(q.calls)
+	}
+	if q.calls[ip] >= q.limit {
+		return fmt.Errorf("%w: %s made %d calls in %s",
+			errIPQuotaExceeded,
+			ip,
+			q.calls[ip],
+			q.period,
+		)
+	}
+	q.calls[ip]++
+	return nil
+}
+
+// requestIP returns the IP that [r] was sent from.
+func requestIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,53 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+func TestIPQuotas(t *testing.T) {
+	require := require.New(t)
+
+	clock := &mockable.Clock{}
+	clock.Set(time.Unix(1_700_000_000, 0))
+	q := newIPQuotas(2, time.Minute, clock)
+
+	var (
+		ipv4 = &http.Request{RemoteAddr: "10.0.0.1:1234"}
+		// The port of the client doesn't matter
+		ipv4OtherPort = &http.Request{RemoteAddr: "10.0.0.1:5678"}
+		ipv6          = &http.Request{RemoteAddr: "[2001:db8::1]:1234"}
+	)
+	require.NoError(q.consume(ipv4))
+	require.NoError(q.consume(ipv4OtherPort))
+	err := q.consume(ipv4)
+	require.ErrorIs(err, errIPQuotaExceeded)
+
+	// Quotas are per IP
+	require.NoError(q.consume(ipv6))
+
+	// Quotas are restored every period
+	clock.Set(clock.Time().Add(time.Minute))
+	require.NoError(q.consume(ipv4))
+}
+
+func TestIPQuotasDisabled(t *testing.T) {
+	require := require.New(t)
+
+	r := &http.Request{RemoteAddr: "10.0.0.1:1234"}
+	q := newIPQuotas(0, time.Minute, &mockable.Clock{})
+	for i := 0; i < 10; i++ {
+		require.NoError(q.consume(r))
+	}
+
+	var nilQuotas *ipQuotas
+	require.NoError(nilQuotas.consume(r))
+}
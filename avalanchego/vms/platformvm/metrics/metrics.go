@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/metric"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 )
 
 var _ Metrics = (*metrics)(nil)
@@ -27,9 +28,14 @@ type Metrics interface {
 	IncValidatorSetsCached()
 	// Mark that we spent the given time computing validator diffs.
 	AddValidatorSetsDuration(time.Duration)
-	// Mark that we computed a validator diff at a height with the given
-	// difference from the top.
-	AddValidatorSetsHeightDiff(uint64)
+	// Mark that we computed a validator diff of [subnetID] at a height with
+	// the given difference from the top.
+	AddValidatorSetsHeightDiff(subnetID ids.ID, heightDiff uint64)
+	// Mark that the number of validators of [subnetID] changed by
+	// [numValidators].
+	AddValidators(subnetID ids.ID, numValidators int)
+	// Mark that this much stake was rewarded to the stakers of [subnetID].
+	AddRewards(subnetID ids.ID, amount uint64)
 	// Mark that this much stake is staked on the node.
 	SetLocalStake(uint64)
 	// Mark that this much stake is staked in the network.
@@ -38,15 +44,36 @@ type Metrics interface {
 	SetTimeUntilUnstake(time.Duration)
 	// Mark when this node will unstake from a subnet.
 	SetTimeUntilSubnetUnstake(subnetID ids.ID, timeUntilUnstake time.Duration)
+	// Mark that [sourceChainID] exported this many UTXOs to this chain.
+	AddImportableUTXOs(sourceChainID ids.ID, numUTXOs int)
+	// Mark that the state violated an invariant after a block was accepted.
+	IncInvariantViolations()
+	// Mark that a block executed differently under the rules of [fork], the
+	// next scheduled network upgrade, than under the current rules.
+	IncShadowExecutionDivergences(fork string)
+	// Mark that a tx was rejected from the mempool for [reason].
+	IncTxRejections(reason string)
+	// Mark that this many verified blocks are held in memory.
+	SetProcessingBlocks(int)
+	// Mark that a block wasn't verified because the maximum number of
+	// processing blocks was reached.
+	IncProcessingBlocksLimitReached()
+	// Mark the stake of the connected validators, and of those whose version
+	// disagrees with the fork schedule of this node.
+	SetUpgradeReadiness(connectedWeight, outdatedWeight, newerWeight uint64)
 }
 
+// New returns the platformvm metrics. At most [maxSubnetLabels] subnets, in
+// addition to the primary network, are given their own subnetID label.
 func New(
 	namespace string,
 	registerer prometheus.Registerer,
+	maxSubnetLabels int,
 ) (Metrics, error) {
 	blockMetrics, err := newBlockMetrics(namespace, registerer)
 	m := &metrics{
 		blockMetrics: blockMetrics,
+		subnetLabels: newSubnetLabels(maxSubnetLabels),
 		timeUntilUnstake: prometheus.NewGauge(prometheus.GaugeOpts{
 			Namespace: namespace,
 			Name:      "time_until_unstake",
@@ -60,6 +87,53 @@ func New(
 			},
 			[]string{"subnetID"},
 		),
+		importableUTXOs: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "importable_utxos",
+				Help:      "Total number of UTXOs exported to this chain by the source chain",
+			},
+			[]string{"sourceChainID"},
+		),
+		invariantViolations: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "invariant_violations",
+			Help:      "Total number of invariant checks of the state that found a violation",
+		}),
+		shadowExecutionDivergences: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "shadow_execution_divergences",
+				Help:      "Total number of blocks whose execution under the next scheduled network upgrade diverged from their execution under the current rules",
+			},
+			[]string{"fork"},
+		),
+		txRejections: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "mempool_tx_rejections",
+				Help:      "Total number of txs that were rejected from the mempool, by reason",
+			},
+			[]string{"reason"},
+		),
+		processingBlocks: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "processing_blocks",
+			Help:      "Number of verified blocks held in memory",
+		}),
+		processingBlocksLimitReached: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "processing_blocks_limit_reached",
+			Help:      "Total number of blocks that weren't verified because the maximum number of processing blocks was reached",
+		}),
+		upgradeReadiness: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "upgrade_readiness_stake",
+				Help:      "Stake of the connected validators (connected), of those running a version that doesn't schedule the next upgrade (outdated) and of those running a newer version (newer)",
+			},
+			[]string{"peers"},
+		),
 		localStake: prometheus.NewGauge(prometheus.GaugeOpts{
 			Namespace: namespace,
 			Name:      "local_staked",
@@ -70,6 +144,29 @@ func New(
 			Name:      "total_staked",
 			Help:      "Amount (in nAVAX) of AVAX staked on the Primary Network",
 		}),
+		validators: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "validators",
+				Help:      "Number of current validators of the subnet",
+			},
+			[]string{"subnetID"},
+		),
+		rewards: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "rewards",
+				Help:      "Amount of the subnet's staking asset rewarded to the subnet's stakers",
+			},
+			[]string{"subnetID"},
+		),
+
+		blockComplexity: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "block_complexity",
+			Help:      "Total complexity of the txs in accepted blocks",
+			Buckets:   prometheus.ExponentialBuckets(1_000, 2, 12),
+		}),
 
 		validatorSetsCached: prometheus.NewCounter(prometheus.CounterOpts{
 			Namespace: namespace,
@@ -81,11 +178,14 @@ func New(
 			Name:      "validator_sets_created",
 			Help:      "Total number of validator sets created from applying difflayers",
 		}),
-		validatorSetsHeightDiff: prometheus.NewGauge(prometheus.GaugeOpts{
-			Namespace: namespace,
-			Name:      "validator_sets_height_diff_sum",
-			Help:      "Total number of validator sets diffs applied for generating validator sets",
-		}),
+		validatorSetsHeightDiff: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "validator_sets_height_diff_sum",
+				Help:      "Total number of validator sets diffs applied for generating validator sets",
+			},
+			[]string{"subnetID"},
+		),
 		validatorSetsDuration: prometheus.NewGauge(prometheus.GaugeOpts{
 			Namespace: namespace,
 			Name:      "validator_sets_duration_sum",
@@ -100,8 +200,18 @@ func New(
 	errs.Add(
 		registerer.Register(m.timeUntilUnstake),
 		registerer.Register(m.timeUntilSubnetUnstake),
+		registerer.Register(m.importableUTXOs),
+		registerer.Register(m.invariantViolations),
+		registerer.Register(m.shadowExecutionDivergences),
+		registerer.Register(m.txRejections),
+		registerer.Register(m.processingBlocks),
+		registerer.Register(m.processingBlocksLimitReached),
+		registerer.Register(m.upgradeReadiness),
 		registerer.Register(m.localStake),
 		registerer.Register(m.totalStake),
+		registerer.Register(m.validators),
+		registerer.Register(m.rewards),
+		registerer.Register(m.blockComplexity),
 
 		registerer.Register(m.validatorSetsCreated),
 		registerer.Register(m.validatorSetsCached),
@@ -116,19 +226,35 @@ type metrics struct {
 	metric.APIInterceptor
 
 	blockMetrics *blockMetrics
+	subnetLabels *subnetLabels
 
-	timeUntilUnstake       prometheus.Gauge
-	timeUntilSubnetUnstake *prometheus.GaugeVec
-	localStake             prometheus.Gauge
-	totalStake             prometheus.Gauge
+	timeUntilUnstake             prometheus.Gauge
+	timeUntilSubnetUnstake       *prometheus.GaugeVec
+	importableUTXOs              *prometheus.CounterVec
+	invariantViolations          prometheus.Counter
+	shadowExecutionDivergences   *prometheus.CounterVec
+	txRejections                 *prometheus.CounterVec
+	processingBlocks             prometheus.Gauge
+	processingBlocksLimitReached prometheus.Counter
+	upgradeReadiness             *prometheus.GaugeVec
+	localStake                   prometheus.Gauge
+	totalStake                   prometheus.Gauge
+	validators                   *prometheus.GaugeVec
+	rewards                      *prometheus.CounterVec
+	blockComplexity              prometheus.Histogram
 
 	validatorSetsCached     prometheus.Counter
 	validatorSetsCreated    prometheus.Counter
-	validatorSetsHeightDiff prometheus.Gauge
+	validatorSetsHeightDiff *prometheus.GaugeVec
 	validatorSetsDuration   prometheus.Gauge
 }
 
 func (m *metrics) MarkAccepted(b block.Block) error {
+	complexity, err := txs.TotalComplexity(b.Txs())
+	if err != nil {
+		return err
+	}
+	m.blockComplexity.Observe(float64(complexity))
 	return b.Visit(m.blockMetrics)
 }
 
@@ -144,8 +270,16 @@ func (m *metrics) AddValidatorSetsDuration(d time.Duration) {
 	m.validatorSetsDuration.Add(float64(d))
 }
 
-func (m *metrics) AddValidatorSetsHeightDiff(d uint64) {
-	m.validatorSetsHeightDiff.Add(float64(d))
+func (m *metrics) AddValidatorSetsHeightDiff(subnetID ids.ID, d uint64) {
+	m.validatorSetsHeightDiff.WithLabelValues(m.subnetLabels.label(subnetID)).Add(float64(d))
+}
+
+func (m *metrics) AddValidators(subnetID ids.ID, numValidators int) {
+	m.validators.WithLabelValues(m.subnetLabels.label(subnetID)).Add(float64(numValidators))
+}
+
+func (m *metrics) AddRewards(subnetID ids.ID, amount uint64) {
+	m.rewards.WithLabelValues(m.subnetLabels.label(subnetID)).Add(float64(amount))
 }
 
 func (m *metrics) SetLocalStake(s uint64) {
@@ -163,3 +297,33 @@ func (m *metrics) SetTimeUntilUnstake(timeUntilUnstake time.Duration) {
 func (m *metrics) SetTimeUntilSubnetUnstake(subnetID ids.ID, timeUntilUnstake time.Duration) {
 	m.timeUntilSubnetUnstake.WithLabelValues(subnetID.String()).Set(float64(timeUntilUnstake))
 }
+
+func (m *metrics) AddImportableUTXOs(sourceChainID ids.ID, numUTXOs int) {
+	m.importableUTXOs.WithLabelValues(sourceChainID.String()).Add(float64(numUTXOs))
+}
+
+func (m *metrics) IncInvariantViolations() {
+	m.invariantViolations.Inc()
+}
+
+func (m *metrics) IncShadowExecutionDivergences(fork string) {
+	m.shadowExecutionDivergences.WithLabelValues(fork).Inc()
+}
+
+func (m *metrics) IncTxRejections(reason string) {
+	m.txRejections.WithLabelValues(reason).Inc()
+}
+
+func (m *metrics) SetProcessingBlocks(numBlocks int) {
+	m.processingBlocks.Set(float64(numBlocks))
+}
+
+func (m *metrics) IncProcessingBlocksLimitReached() {
+	m.processingBlocksLimitReached.Inc()
+}
+
+func (m *metrics) SetUpgradeReadiness(connectedWeight, outdatedWeight, newerWeight uint64) {
+	m.upgradeReadiness.WithLabelValues("connected").Set(float64(connectedWeight))
+	m.upgradeReadiness.WithLabelValues("outdated").Set(float64(outdatedWeight))
+	m.upgradeReadiness.WithLabelValues("newer").Set(float64(newerWeight))
+}
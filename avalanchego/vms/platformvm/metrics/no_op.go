@@ -37,7 +37,11 @@ func (noopMetrics) IncValidatorSetsCached() {}
 
 func (noopMetrics) AddValidatorSetsDuration(time.Duration) {}
 
-func (noopMetrics) AddValidatorSetsHeightDiff(uint64) {}
+func (noopMetrics) AddValidatorSetsHeightDiff(ids.ID, uint64) {}
+
+func (noopMetrics) AddValidators(ids.ID, int) {}
+
+func (noopMetrics) AddRewards(ids.ID, uint64) {}
 
 func (noopMetrics) SetLocalStake(uint64) {}
 
@@ -47,6 +51,20 @@ func (noopMetrics) SetTimeUntilUnstake(time.Duration) {}
 
 func (noopMetrics) SetTimeUntilSubnetUnstake(ids.ID, time.Duration) {}
 
+func (noopMetrics) AddImportableUTXOs(ids.ID, int) {}
+
+func (noopMetrics) IncInvariantViolations() {}
+
+func (noopMetrics) IncShadowExecutionDivergences(string) {}
+
+func (noopMetrics) IncTxRejections(string) {}
+
+func (noopMetrics) SetProcessingBlocks(int) {}
+
+func (noopMetrics) IncProcessingBlocksLimitReached() {}
+
+func (noopMetrics) SetUpgradeReadiness(uint64, uint64, uint64) {}
+
 func (noopMetrics) SetSubnetPercentConnected(ids.ID, float64) {}
 
 func (noopMetrics) SetPercentConnected(float64) {}
@@ -0,0 +1,51 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metrics
+
+import (
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+// OtherSubnetsLabel is the subnetID label shared by the subnets that were
+// observed after the label limit was reached.
+const OtherSubnetsLabel = "other"
+
+// subnetLabels bounds the cardinality of the subnetID label. The primary
+// network is always given its own label, and so are the first [max] other
+// subnets that are observed.
+type subnetLabels struct {
+	lock   sync.Mutex
+	max    int
+	labels map[ids.ID]string
+}
+
+func newSubnetLabels(max int) *subnetLabels {
+	return &subnetLabels{
+		max:    max,
+		labels: make(map[ids.ID]string),
+	}
+}
+
+func (s *subnetLabels) label(subnetID ids.ID) string {
+	if subnetID == constants.PrimaryNetworkID {
+		return subnetID.String()
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if label, ok := s.labels[subnetID]; ok {
+		return label
+	}
+	if len(s.labels) >= s.max {
+		return OtherSubnetsLabel
+	}
+
+	label := subnetID.String()
+	s.labels[subnetID] = label
+	return label
+}
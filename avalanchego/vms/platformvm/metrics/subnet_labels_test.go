@@ -0,0 +1,30 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+func TestSubnetLabels(t *testing.T) {
+	require := require.New(t)
+
+	labels := newSubnetLabels(1)
+
+	subnetID0 := ids.GenerateTestID()
+	subnetID1 := ids.GenerateTestID()
+
+	require.Equal(subnetID0.String(), labels.label(subnetID0))
+	require.Equal(OtherSubnetsLabel, labels.label(subnetID1))
+	require.Equal(subnetID0.String(), labels.label(subnetID0))
+
+	// The primary network doesn't count towards the limit.
+	require.Equal(constants.PrimaryNetworkID.String(), labels.label(constants.PrimaryNetworkID))
+	require.Equal(OtherSubnetsLabel, labels.label(subnetID1))
+}
@@ -12,7 +12,10 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 )
 
-var _ txs.Visitor = (*txMetrics)(nil)
+var (
+	_ txs.Visitor          = (*txMetrics)(nil)
+	_ txs.ExtensionVisitor = (*txMetrics)(nil)
+)
 
 type txMetrics struct {
 	numAddDelegatorTxs,
@@ -29,7 +32,20 @@ type txMetrics struct {
 	numAddPermissionlessValidatorTxs,
 	numAddPermissionlessDelegatorTxs,
 	numTransferSubnetOwnershipTxs,
-	numBaseTxs prometheus.Counter
+	numBaseTxs,
+	numAddSubnetAllowListEntriesTxs,
+	numRemoveSubnetAllowListEntriesTxs,
+	numAddCappedPermissionlessValidatorTxs,
+	numExitValidatorTxs,
+	numSetSubnetValidatorWeightTxs,
+	numParameterChangeTxs,
+	numRekeyValidatorTxs,
+	numRegisterNameTxs,
+	numUpdateNameTxs,
+	numAddPermissionlessValidatorWithMetadataTxs,
+	numClaimRewardTxs,
+	numBindRewardAddressTxs,
+	numExtensionTxs prometheus.Counter
 }
 
 func newTxMetrics(
@@ -38,21 +54,34 @@ func newTxMetrics(
 ) (*txMetrics, error) {
 	errs := wrappers.Errs{}
 	m := &txMetrics{
-		numAddDelegatorTxs:               newTxMetric(namespace, "add_delegator", registerer, &errs),
-		numAddSubnetValidatorTxs:         newTxMetric(namespace, "add_subnet_validator", registerer, &errs),
-		numAddValidatorTxs:               newTxMetric(namespace, "add_validator", registerer, &errs),
-		numAdvanceTimeTxs:                newTxMetric(namespace, "advance_time", registerer, &errs),
-		numCreateChainTxs:                newTxMetric(namespace, "create_chain", registerer, &errs),
-		numCreateSubnetTxs:               newTxMetric(namespace, "create_subnet", registerer, &errs),
-		numExportTxs:                     newTxMetric(namespace, "export", registerer, &errs),
-		numImportTxs:                     newTxMetric(namespace, "import", registerer, &errs),
-		numRewardValidatorTxs:            newTxMetric(namespace, "reward_validator", registerer, &errs),
-		numRemoveSubnetValidatorTxs:      newTxMetric(namespace, "remove_subnet_validator", registerer, &errs),
-		numTransformSubnetTxs:            newTxMetric(namespace, "transform_subnet", registerer, &errs),
-		numAddPermissionlessValidatorTxs: newTxMetric(namespace, "add_permissionless_validator", registerer, &errs),
-		numAddPermissionlessDelegatorTxs: newTxMetric(namespace, "add_permissionless_delegator", registerer, &errs),
-		numTransferSubnetOwnershipTxs:    newTxMetric(namespace, "transfer_subnet_ownership", registerer, &errs),
-		numBaseTxs:                       newTxMetric(namespace, "base", registerer, &errs),
+		numAddDelegatorTxs:                           newTxMetric(namespace, "add_delegator", registerer, &errs),
+		numAddSubnetValidatorTxs:                     newTxMetric(namespace, "add_subnet_validator", registerer, &errs),
+		numAddValidatorTxs:                           newTxMetric(namespace, "add_validator", registerer, &errs),
+		numAdvanceTimeTxs:                            newTxMetric(namespace, "advance_time", registerer, &errs),
+		numCreateChainTxs:                            newTxMetric(namespace, "create_chain", registerer, &errs),
+		numCreateSubnetTxs:                           newTxMetric(namespace, "create_subnet", registerer, &errs),
+		numExportTxs:                                 newTxMetric(namespace, "export", registerer, &errs),
+		numImportTxs:                                 newTxMetric(namespace, "import", registerer, &errs),
+		numRewardValidatorTxs:                        newTxMetric(namespace, "reward_validator", registerer, &errs),
+		numRemoveSubnetValidatorTxs:                  newTxMetric(namespace, "remove_subnet_validator", registerer, &errs),
+		numTransformSubnetTxs:                        newTxMetric(namespace, "transform_subnet", registerer, &errs),
+		numAddPermissionlessValidatorTxs:             newTxMetric(namespace, "add_permissionless_validator", registerer, &errs),
+		numAddPermissionlessDelegatorTxs:             newTxMetric(namespace, "add_permissionless_delegator", registerer, &errs),
+		numTransferSubnetOwnershipTxs:                newTxMetric(namespace, "transfer_subnet_ownership", registerer, &errs),
+		numBaseTxs:                                   newTxMetric(namespace, "base", registerer, &errs),
+		numAddSubnetAllowListEntriesTxs:              newTxMetric(namespace, "add_subnet_allow_list_entries", registerer, &errs),
+		numRemoveSubnetAllowListEntriesTxs:           newTxMetric(namespace, "remove_subnet_allow_list_entries", registerer, &errs),
+		numAddCappedPermissionlessValidatorTxs:       newTxMetric(namespace, "add_capped_permissionless_validator", registerer, &errs),
+		numExitValidatorTxs:                          newTxMetric(namespace, "exit_validator", registerer, &errs),
+		numSetSubnetValidatorWeightTxs:               newTxMetric(namespace, "set_subnet_validator_weight", registerer, &errs),
+		numParameterChangeTxs:                        newTxMetric(namespace, "parameter_change", registerer, &errs),
+		numRekeyValidatorTxs:                         newTxMetric(namespace, "rekey_validator", registerer, &errs),
+		numRegisterNameTxs:                           newTxMetric(namespace, "register_name", registerer, &errs),
+		numUpdateNameTxs:                             newTxMetric(namespace, "update_name", registerer, &errs),
+		numAddPermissionlessValidatorWithMetadataTxs: newTxMetric(namespace, "add_permissionless_validator_with_metadata", registerer, &errs),
+		numClaimRewardTxs:                            newTxMetric(namespace, "claim_reward", registerer, &errs),
+		numBindRewardAddressTxs:                      newTxMetric(namespace, "bind_reward_address", registerer, &errs),
+		numExtensionTxs:                              newTxMetric(namespace, "extension", registerer, &errs),
 	}
 	return m, errs.Err
 }
@@ -146,3 +175,68 @@ func (m *txMetrics) BaseTx(*txs.BaseTx) error {
 	m.numBaseTxs.Inc()
 	return nil
 }
+
+func (m *txMetrics) AddSubnetAllowListEntriesTx(*txs.AddSubnetAllowListEntriesTx) error {
+	m.numAddSubnetAllowListEntriesTxs.Inc()
+	return nil
+}
+
+func (m *txMetrics) RemoveSubnetAllowListEntriesTx(*txs.RemoveSubnetAllowListEntriesTx) error {
+	m.numRemoveSubnetAllowListEntriesTxs.Inc()
+	return nil
+}
+
+func (m *txMetrics) AddCappedPermissionlessValidatorTx(*txs.AddCappedPermissionlessValidatorTx) error {
+	m.numAddCappedPermissionlessValidatorTxs.Inc()
+	return nil
+}
+
+func (m *txMetrics) ExitValidatorTx(*txs.ExitValidatorTx) error {
+	m.numExitValidatorTxs.Inc()
+	return nil
+}
+
+func (m *txMetrics) SetSubnetValidatorWeightTx(*txs.SetSubnetValidatorWeightTx) error {
+	m.numSetSubnetValidatorWeightTxs.Inc()
+	return nil
+}
+
+func (m *txMetrics) ParameterChangeTx(*txs.ParameterChangeTx) error {
+	m.numParameterChangeTxs.Inc()
+	return nil
+}
+
+func (m *txMetrics) RekeyValidatorTx(*txs.RekeyValidatorTx) error {
+	m.numRekeyValidatorTxs.Inc()
+	return nil
+}
+
+func (m *txMetrics) RegisterNameTx(*txs.RegisterNameTx) error {
+	m.numRegisterNameTxs.Inc()
+	return nil
+}
+
+func (m *txMetrics) UpdateNameTx(*txs.UpdateNameTx) error {
+	m.numUpdateNameTxs.Inc()
+	return nil
+}
+
+func (m *txMetrics) AddPermissionlessValidatorWithMetadataTx(*txs.AddPermissionlessValidatorWithMetadataTx) error {
+	m.numAddPermissionlessValidatorWithMetadataTxs.Inc()
+	return nil
+}
+
+func (m *txMetrics) ClaimRewardTx(*txs.ClaimRewardTx) error {
+	m.numClaimRewardTxs.Inc()
+	return nil
+}
+
+func (m *txMetrics) BindRewardAddressTx(*txs.BindRewardAddressTx) error {
+	m.numBindRewardAddressTxs.Inc()
+	return nil
+}
+
+func (m *txMetrics) ExtensionTx(txs.UnsignedTx) error {
+	m.numExtensionTxs.Inc()
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+var (
+	_ AdmissionPolicy = NoOpAdmissionPolicy{}
+	_ TxVerifier      = (*policyTxVerifier)(nil)
+
+	ErrTxRejectedByPolicy = errors.New("tx rejected by admission policy")
+)
+
+// AdmissionPolicy enforces the local policies of a node on the txs that are
+// admitted into its mempool, such as rejecting delegations below a threshold
+// or throttling the txs of an address.
+//
+// The policy is only applied before txs are issued into the mempool. Blocks
+// including txs that the policy would reject are still verified normally.
+type AdmissionPolicy interface {
+	// Admit returns an error if [tx] must not be admitted into the mempool.
+	// It may be called concurrently and without the context lock held.
+	Admit(tx *txs.Tx) error
+}
+
+// NoOpAdmissionPolicy admits all txs.
+type NoOpAdmissionPolicy struct{}
+
+func (NoOpAdmissionPolicy) Admit(*txs.Tx) error {
+	return nil
+}
+
+type policyTxVerifier struct {
+	policy     AdmissionPolicy
+	txVerifier TxVerifier
+}
+
+// NewPolicyTxVerifier returns a TxVerifier that rejects the txs that [policy]
+// doesn't admit and forwards the others to [txVerifier]. If [policy] is nil,
+// [txVerifier] is returned.
+func NewPolicyTxVerifier(policy AdmissionPolicy, txVerifier TxVerifier) TxVerifier {
+	if policy == nil {
+		return txVerifier
+	}
+	return &policyTxVerifier{
+		policy:     policy,
+		txVerifier: txVerifier,
+	}
+}
+
+func (v *policyTxVerifier) VerifyTx(tx *txs.Tx) error {
+	if err := v.policy.Admit(tx); err != nil {
+		return fmt.Errorf("%w: %w", ErrTxRejectedByPolicy, err)
+	}
+	return v.txVerifier.VerifyTx(tx)
+}
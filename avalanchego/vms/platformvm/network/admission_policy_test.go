@@ -0,0 +1,105 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/mempool"
+)
+
+var errDelegationTooSmall = errors.New("delegation too small")
+
+// testAdmissionPolicy rejects delegations below a local threshold
+type testAdmissionPolicy struct {
+	minDelegatorStake uint64
+}
+
+func (p testAdmissionPolicy) Admit(tx *txs.Tx) error {
+	if utx, ok := tx.Unsigned.(*txs.AddPermissionlessDelegatorTx); ok && utx.Weight() < p.minDelegatorStake {
+		return errDelegationTooSmall
+	}
+	return nil
+}
+
+func TestPolicyTxVerifier(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      AdmissionPolicy
+		tx          *txs.Tx
+		expectedErr error
+	}{
+		{
+			name:        "no policy",
+			policy:      nil,
+			tx:          &txs.Tx{Unsigned: &txs.AddPermissionlessDelegatorTx{}},
+			expectedErr: errFoo,
+		},
+		{
+			name:        "no-op policy",
+			policy:      NoOpAdmissionPolicy{},
+			tx:          &txs.Tx{Unsigned: &txs.AddPermissionlessDelegatorTx{}},
+			expectedErr: errFoo,
+		},
+		{
+			name:        "admitted",
+			policy:      testAdmissionPolicy{minDelegatorStake: 1},
+			tx:          &txs.Tx{Unsigned: &txs.CreateChainTx{}},
+			expectedErr: errFoo,
+		},
+		{
+			name:        "rejected",
+			policy:      testAdmissionPolicy{minDelegatorStake: 1},
+			tx:          &txs.Tx{Unsigned: &txs.AddPermissionlessDelegatorTx{}},
+			expectedErr: errDelegationTooSmall,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			verifier := NewPolicyTxVerifier(test.policy, testTxVerifier{err: errFoo})
+			err := verifier.VerifyTx(test.tx)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+// Txs rejected by the local policy aren't considered invalid, so the peers
+// that gossip them aren't penalized
+func TestGossipMempoolAddRejectedByPolicy(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	tx := &txs.Tx{
+		Unsigned: &txs.AddPermissionlessDelegatorTx{},
+		TxID:     ids.GenerateTestID(),
+	}
+
+	mempool := mempool.NewMockMempool(ctrl)
+	mempool.EXPECT().Get(tx.ID()).Return(nil, false)
+	mempool.EXPECT().GetDropReason(tx.ID()).Return(nil)
+	mempool.EXPECT().MarkDropped(tx.ID(), gomock.Any())
+
+	gossipMempool, err := newGossipMempool(
+		mempool,
+		prometheus.NewRegistry(),
+		logging.NoLog{},
+		NewPolicyTxVerifier(testAdmissionPolicy{minDelegatorStake: 1}, testTxVerifier{}),
+		testConfig.ExpectedBloomFilterElements,
+		testConfig.ExpectedBloomFilterFalsePositiveProbability,
+		testConfig.MaxBloomFilterFalsePositiveProbability,
+	)
+	require.NoError(err)
+
+	invalid, err := gossipMempool.add(tx)
+	require.ErrorIs(err, ErrTxRejectedByPolicy)
+	require.False(invalid)
+}
@@ -0,0 +1,250 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/network/p2p/gossip"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+var _ p2p.Handler = (*scoredGossipHandler)(nil)
+
+const (
+	// Penalty of a peer that gossiped a tx that couldn't be parsed
+	malformedTxPenalty = 10
+	// Penalty of a peer that gossiped a tx that failed verification with
+	// [ErrInvalidTx]
+	invalidTxPenalty = 1
+)
+
+// PeerBans scores peers by the invalid txs that they gossip. The score of a
+// peer decays over time, so that honest peers that occasionally gossip a tx
+// that is no longer valid aren't banned. Peers whose score reaches the ban
+// threshold are banned for the ban duration, during which their gossip and
+// requests are dropped without being verified.
+//
+// Bans are persisted, so that restarting the node doesn't lift them.
+type PeerBans struct {
+	log       logging.Logger
+	db        database.Database
+	threshold float64
+	halfLife  time.Duration
+	duration  time.Duration
+
+	clock *mockable.Clock
+
+	lock   sync.Mutex
+	scores map[ids.NodeID]*peerScore
+	// Time that the ban of each banned peer ends at
+	bans map[ids.NodeID]time.Time
+}
+
+type peerScore struct {
+	score float64
+	// Time that [score] was last updated at
+	updated time.Time
+}
+
+// NewPeerBans returns the bans persisted in [db]. Scores decay and bans end
+// according to [clock]. If the ban threshold of [config] is 0, peers are never
+// banned.
+func NewPeerBans(
+	log logging.Logger,
+	db database.Database,
+	clock *mockable.Clock,
+	config Config,
+) (*PeerBans, error) {
+	b := &PeerBans{
+		log:       log,
+		db:        db,
+		clock:     clock,
+		threshold: config.BanScoreThreshold,
+		halfLife:  config.BanScoreHalfLife,
+		duration:  config.BanDuration,
+		scores:    make(map[ids.NodeID]*peerScore),
+		bans:      make(map[ids.NodeID]time.Time),
+	}
+
+	it := db.NewIterator()
+	defer it.Release()
+
+	now := b.clock.Time()
+	for it.Next() {
+		nodeID, err := ids.ToNodeID(it.Key())
+		if err != nil {
+			return nil, err
+		}
+		end, err := database.ParseTimestamp(it.Value())
+		if err != nil {
+			return nil, err
+		}
+		if !end.After(now) {
+			if err := db.Delete(it.Key()); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		b.bans[nodeID] = end
+	}
+	return b, it.Error()
+}
+
+// IsBanned returns true if [nodeID] is currently banned.
+func (b *PeerBans) IsBanned(nodeID ids.NodeID) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	end, ok := b.bans[nodeID]
+	return ok && b.clock.Time().Before(end)
+}
+
+// Bans returns the time that the ban of each banned peer ends at.
+func (b *PeerBans) Bans() map[ids.NodeID]time.Time {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	now := b.clock.Time()
+	bans := make(map[ids.NodeID]time.Time, len(b.bans))
+	for nodeID, end := range b.bans {
+		if now.Before(end) {
+			bans[nodeID] = end
+		}
+	}
+	return bans
+}
+
+// Clear lifts the ban of [nodeID] and resets its score.
+func (b *PeerBans) Clear(nodeID ids.NodeID) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	delete(b.scores, nodeID)
+	delete(b.bans, nodeID)
+	return b.db.Delete(nodeID.Bytes())
+}
+
+// penalize adds [penalty] to the score of [nodeID] and bans it if the score
+// reaches the threshold.
+func (b *PeerBans) penalize(nodeID ids.NodeID, penalty float64) {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	now := b.clock.Time()
+	if end, ok := b.bans[nodeID]; ok && now.Before(end) {
+		return
+	}
+
+	s, ok := b.scores[nodeID]
+	if !ok {
+		s = &peerScore{}
+		b.scores[nodeID] = s
+	}
+	if b.halfLife > 0 {
+		elapsed := now.Sub(s.updated)
+		s.score *= math.Exp2(-float64(elapsed) / float64(b.halfLife))
+	}
+	s.score += penalty
+	s.updated = now
+	if s.score < b.threshold {
+		return
+	}
+
+	end := now.Add(b.duration)
+	delete(b.scores, nodeID)
+	b.bans[nodeID] = end
+	b.log.Info("banning peer",
+		zap.String("reason", "gossiped too many invalid txs"),
+		zap.Stringer("nodeID", nodeID),
+		zap.Time("end", end),
+	)
+	if err := database.PutTimestamp(b.db, nodeID.Bytes(), end); err != nil {
+		b.log.Error("failed to persist peer ban",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+	}
+}
+
+// scoredGossipHandler adds the txs pushed by a peer to the mempool and
+// penalizes the peer for each tx that is malformed or fails verification.
+type scoredGossipHandler struct {
+	p2p.NoOpHandler
+
+	log                logging.Logger
+	accumulator        gossip.Accumulator[*txs.Tx]
+	mempool            *gossipMempool
+	set                gossip.Set[*txs.Tx]
+	metrics            gossip.Metrics
+	targetResponseSize int
+	bans               *PeerBans
+}
+
+func (h *scoredGossipHandler) AppGossip(ctx context.Context, nodeID ids.NodeID, gossipBytes []byte) {
+	// The handler is bound to [nodeID], so that the failures are attributed
+	// to the peer that pushed the txs.
+	handler := gossip.NewHandler[*txs.Tx](
+		h.log,
+		scoredMarshaller{
+			nodeID: nodeID,
+			bans:   h.bans,
+		},
+		h.accumulator,
+		&scoredSet{
+			Set:     h.set,
+			nodeID:  nodeID,
+			mempool: h.mempool,
+			bans:    h.bans,
+		},
+		h.metrics,
+		h.targetResponseSize,
+	)
+	handler.AppGossip(ctx, nodeID, gossipBytes)
+}
+
+type scoredMarshaller struct {
+	txMarshaller
+
+	nodeID ids.NodeID
+	bans   *PeerBans
+}
+
+func (m scoredMarshaller) UnmarshalGossip(bytes []byte) (*txs.Tx, error) {
+	tx, err := m.txMarshaller.UnmarshalGossip(bytes)
+	if err != nil {
+		m.bans.penalize(m.nodeID, malformedTxPenalty)
+	}
+	return tx, err
+}
+
+type scoredSet struct {
+	gossip.Set[*txs.Tx]
+
+	nodeID  ids.NodeID
+	mempool *gossipMempool
+	bans    *PeerBans
+}
+
+func (s *scoredSet) Add(tx *txs.Tx) error {
+	invalid, err := s.mempool.add(tx)
+	if invalid {
+		s.bans.penalize(s.nodeID, invalidTxPenalty)
+	}
+	return err
+}
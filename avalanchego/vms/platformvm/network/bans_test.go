@@ -0,0 +1,107 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+var testBanConfig = Config{
+	BanScoreThreshold: 10,
+	BanScoreHalfLife:  time.Minute,
+	BanDuration:       time.Hour,
+}
+
+func newTestPeerBans(t *testing.T) *PeerBans {
+	bans, err := NewPeerBans(logging.NoLog{}, memdb.New(), &mockable.Clock{}, testBanConfig)
+	require.NoError(t, err)
+	return bans
+}
+
+func TestPeerBans(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		db  = memdb.New()
+		clk = &mockable.Clock{}
+	)
+	bans, err := NewPeerBans(logging.NoLog{}, db, clk, testBanConfig)
+	require.NoError(err)
+
+	now := time.Now()
+	clk.Set(now)
+
+	nodeID := ids.GenerateTestNodeID()
+	for i := 0; i < 9; i++ {
+		bans.penalize(nodeID, invalidTxPenalty)
+	}
+	require.False(bans.IsBanned(nodeID))
+
+	// After a half-life, the score of the peer decayed to 4.5
+	now = now.Add(time.Minute)
+	clk.Set(now)
+	for i := 0; i < 5; i++ {
+		bans.penalize(nodeID, invalidTxPenalty)
+	}
+	require.False(bans.IsBanned(nodeID))
+
+	bans.penalize(nodeID, invalidTxPenalty)
+	require.True(bans.IsBanned(nodeID))
+	require.Equal(map[ids.NodeID]time.Time{nodeID: now.Add(time.Hour)}, bans.Bans())
+
+	// The ban is persisted
+	reloadedBans, err := NewPeerBans(logging.NoLog{}, db, clk, testBanConfig)
+	require.NoError(err)
+	require.True(reloadedBans.IsBanned(nodeID))
+
+	// The ban is lifted once it ends
+	clk.Set(now.Add(time.Hour))
+	require.False(bans.IsBanned(nodeID))
+	require.Empty(bans.Bans())
+	require.False(reloadedBans.IsBanned(nodeID))
+
+	require.NoError(reloadedBans.Clear(nodeID))
+	require.False(reloadedBans.IsBanned(nodeID))
+	has, err := db.Has(nodeID.Bytes())
+	require.NoError(err)
+	require.False(has)
+}
+
+func TestPeerBansDropsEndedBans(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	nodeID := ids.GenerateTestNodeID()
+	require.NoError(database.PutTimestamp(db, nodeID.Bytes(), time.Now().Add(-time.Second)))
+
+	bans, err := NewPeerBans(logging.NoLog{}, db, &mockable.Clock{}, testBanConfig)
+	require.NoError(err)
+	require.False(bans.IsBanned(nodeID))
+
+	has, err := db.Has(nodeID.Bytes())
+	require.NoError(err)
+	require.False(has)
+}
+
+func TestPeerBansDisabled(t *testing.T) {
+	require := require.New(t)
+
+	bans, err := NewPeerBans(logging.NoLog{}, memdb.New(), &mockable.Clock{}, Config{})
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	for i := 0; i < 100; i++ {
+		bans.penalize(nodeID, malformedTxPenalty)
+	}
+	require.False(bans.IsBanned(nodeID))
+}
@@ -6,6 +6,7 @@ package network
 import (
 	"time"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/units"
 )
 
@@ -20,6 +21,9 @@ var DefaultConfig = Config{
 	ExpectedBloomFilterFalsePositiveProbability: .01,
 	MaxBloomFilterFalsePositiveProbability:      .05,
 	LegacyPushGossipCacheSize:                   512,
+	BanScoreThreshold:                           100,
+	BanScoreHalfLife:                            time.Minute,
+	BanDuration:                                 time.Hour,
 }
 
 type Config struct {
@@ -63,4 +67,21 @@ type Config struct {
 	// Deprecated: The legacy push gossip mechanism is deprecated in favor of
 	// the p2p SDK's push gossip mechanism.
 	LegacyPushGossipCacheSize int `json:"legacy-push-gossip-cache-size"`
+	// SubnetTxGossip gossips the txs of each tracked subnet on a topic of its
+	// own, among the validators of the subnet, rather than among all the
+	// primary network validators. Txs of untracked subnets are still gossiped
+	// with the primary network txs.
+	SubnetTxGossip bool `json:"subnet-tx-gossip"`
+	// BanScoreThreshold is the score at which a peer is banned. Peers are
+	// scored by the invalid txs they gossip. If 0, peers are never banned.
+	BanScoreThreshold float64 `json:"ban-score-threshold"`
+	// BanScoreHalfLife is how long it takes for the score of a peer to decay
+	// by half.
+	BanScoreHalfLife time.Duration `json:"ban-score-half-life"`
+	// BanDuration is how long the gossip of a banned peer is dropped for.
+	BanDuration time.Duration `json:"ban-duration"`
+	// RelayNodeIDs, if not empty, are the only nodes that the txs issued
+	// through the API of this node are sent to. This allows running a public
+	// tx submission gateway that only relays txs to a set of validators.
+	RelayNodeIDs []ids.NodeID `json:"relay-node-ids"`
 }
@@ -5,15 +5,18 @@ package network
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/network/p2p"
 	"github.com/ava-labs/avalanchego/network/p2p/gossip"
+	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/mempool"
@@ -25,9 +28,17 @@ var (
 	_ gossip.Gossipable          = (*txs.Tx)(nil)
 )
 
-// bloomChurnMultiplier is the number used to multiply the size of the mempool
-// to determine how large of a bloom filter to create.
-const bloomChurnMultiplier = 3
+const (
+	// bloomChurnMultiplier is the number used to multiply the size of the
+	// mempool to determine how large of a bloom filter to create.
+	bloomChurnMultiplier = 3
+	// bloomGrowthMultiplier is the number used to multiply the size of a bloom
+	// filter that is outgrown by the mempool to determine its new size.
+	bloomGrowthMultiplier = 2
+	// bloomShrinkDivisor is how many times larger than needed a bloom filter
+	// must be before it's shrunk.
+	bloomShrinkDivisor = 4
+)
 
 // txGossipHandler is the handler called when serving gossip messages
 type txGossipHandler struct {
@@ -73,11 +84,20 @@ func newGossipMempool(
 	resetFalsePositiveProbability float64,
 ) (*gossipMempool, error) {
 	bloom, err := gossip.NewBloomFilter(registerer, "mempool_bloom_filter", minTargetElements, targetFalsePositiveProbability, resetFalsePositiveProbability)
+	if err != nil {
+		return nil, err
+	}
+	metrics, err := newBloomMetrics(registerer, "mempool_bloom_filter")
 	return &gossipMempool{
-		Mempool:    mempool,
-		log:        log,
-		txVerifier: txVerifier,
-		bloom:      bloom,
+		Mempool:           mempool,
+		log:               log,
+		txVerifier:        txVerifier,
+		minTargetElements: minTargetElements,
+		bloom:             bloom,
+		subnetBlooms:      make(map[ids.ID]*gossip.BloomFilter),
+		bloomMetrics: map[ids.ID]*bloomMetrics{
+			constants.PrimaryNetworkID: metrics,
+		},
 	}, err
 }
 
@@ -86,14 +106,57 @@ type gossipMempool struct {
 	log        logging.Logger
 	txVerifier TxVerifier
 
-	lock  sync.RWMutex
+	// Minimum number of elements that the bloom filters are sized for
+	minTargetElements int
+
+	lock sync.RWMutex
+	// Bloom filter of the txs gossiped on the primary network topic
 	bloom *gossip.BloomFilter
+	// Bloom filters of the txs gossiped on the topics of subnets. The map is
+	// only modified before gossip starts.
+	subnetBlooms map[ids.ID]*gossip.BloomFilter
+	// Metrics of the bloom filters of every topic
+	bloomMetrics map[ids.ID]*bloomMetrics
+}
+
+// addTopic gossips the txs of [subnetID] on a topic of their own, whose txs
+// are tracked by [bloom].
+func (g *gossipMempool) addTopic(subnetID ids.ID, bloom *gossip.BloomFilter, metrics *bloomMetrics) {
+	g.subnetBlooms[subnetID] = bloom
+	g.bloomMetrics[subnetID] = metrics
+}
+
+// topic returns the subnet whose topic [tx] is gossiped on. Txs of subnets
+// without a topic of their own are gossiped on the primary network topic.
+func (g *gossipMempool) topic(tx *txs.Tx) ids.ID {
+	subnetID := txSubnetID(tx)
+	if _, ok := g.subnetBlooms[subnetID]; ok {
+		return subnetID
+	}
+	return constants.PrimaryNetworkID
+}
+
+// Invariant: [g.lock] is held.
+func (g *gossipMempool) topicBloom(subnetID ids.ID) *gossip.BloomFilter {
+	if subnetID == constants.PrimaryNetworkID {
+		return g.bloom
+	}
+	return g.subnetBlooms[subnetID]
 }
 
 func (g *gossipMempool) Add(tx *txs.Tx) error {
+	_, err := g.add(tx)
+	return err
+}
+
+// add adds [tx] to the mempool. Returns true if [tx] failed verification with
+// [ErrInvalidTx]. Txs that fail verification because of the state of this
+// node, or that are rejected by its local policies, aren't considered invalid,
+// as other nodes may admit them.
+func (g *gossipMempool) add(tx *txs.Tx) (bool, error) {
 	txID := tx.ID()
 	if _, ok := g.Mempool.Get(txID); ok {
-		return fmt.Errorf("tx %s dropped: %w", txID, mempool.ErrDuplicateTx)
+		return false, fmt.Errorf("tx %s dropped: %w", txID, mempool.ErrDuplicateTx)
 	}
 
 	if reason := g.Mempool.GetDropReason(txID); reason != nil {
@@ -101,43 +164,105 @@ func (g *gossipMempool) Add(tx *txs.Tx) error {
 		//
 		// TODO: Should we allow re-verification of the transaction even if it
 		// failed previously?
-		return reason
+		return false, reason
 	}
 
 	if err := g.txVerifier.VerifyTx(tx); err != nil {
 		g.Mempool.MarkDropped(txID, err)
-		return err
+		return errors.Is(err, ErrInvalidTx), err
 	}
 
 	if err := g.Mempool.Add(tx); err != nil {
 		g.Mempool.MarkDropped(txID, err)
-		return err
+		return false, err
 	}
 
 	g.lock.Lock()
 	defer g.lock.Unlock()
 
-	g.bloom.Add(tx)
-	reset, err := gossip.ResetBloomFilterIfNeeded(g.bloom, g.Mempool.Len()*bloomChurnMultiplier)
+	var (
+		topic          = g.topic(tx)
+		bloom          = g.topicBloom(topic)
+		metrics        = g.bloomMetrics[topic]
+		targetElements = g.Mempool.Len() * bloomChurnMultiplier
+	)
+	bloom.Add(tx)
+	reset, err := gossip.ResetBloomFilterIfNeeded(bloom, targetElements)
 	if err != nil {
-		return err
+		return false, err
+	}
+	if !reset {
+		reset, err = g.resizeBloomIfNeeded(bloom, targetElements)
+		if err != nil {
+			return false, err
+		}
+		if reset {
+			metrics.resizeCount.Inc()
+		}
 	}
 
 	if reset {
-		g.log.Debug("resetting bloom filter")
+		g.log.Debug("resetting bloom filter",
+			zap.Stringer("topic", topic),
+		)
 		g.Mempool.Iterate(func(tx *txs.Tx) bool {
-			g.bloom.Add(tx)
+			if g.topic(tx) == topic {
+				bloom.Add(tx)
+			}
 			return true
 		})
 	}
+	metrics.update(bloom)
 
 	g.Mempool.RequestBuildBlock(false)
-	return nil
+	return false, nil
+}
+
+// resizeBloomIfNeeded resets [bloom] if it's too small or much too large for
+// [targetElements]. Bloom filters are grown once the mempool outgrows them,
+// before their false positive probability degrades, and are shrunk once the
+// mempool empties so that pull requests don't carry oversized filters.
+//
+// Returns true if the bloom filter was reset.
+//
+// Invariant: [g.lock] is held.
+func (g *gossipMempool) resizeBloomIfNeeded(bloom *gossip.BloomFilter, targetElements int) (bool, error) {
+	var (
+		currentElements = bloom.TargetElements()
+		newElements     int
+	)
+	switch {
+	case targetElements > currentElements:
+		newElements = targetElements * bloomGrowthMultiplier
+	case targetElements*bloomShrinkDivisor < currentElements && currentElements > g.minTargetElements:
+		newElements = max(targetElements, g.minTargetElements)
+	default:
+		return false, nil
+	}
+	return true, gossip.ResizeBloomFilter(bloom, newElements)
+}
+
+// Iterate iterates over the txs gossiped on the primary network topic.
+func (g *gossipMempool) Iterate(f func(tx *txs.Tx) bool) {
+	g.iterateTopic(constants.PrimaryNetworkID, f)
+}
+
+func (g *gossipMempool) iterateTopic(subnetID ids.ID, f func(tx *txs.Tx) bool) {
+	g.Mempool.Iterate(func(tx *txs.Tx) bool {
+		if g.topic(tx) != subnetID {
+			return true
+		}
+		return f(tx)
+	})
 }
 
 func (g *gossipMempool) GetFilter() (bloom []byte, salt []byte) {
+	return g.getTopicFilter(constants.PrimaryNetworkID)
+}
+
+func (g *gossipMempool) getTopicFilter(subnetID ids.ID) (bloom []byte, salt []byte) {
 	g.lock.RLock()
 	defer g.lock.RUnlock()
 
-	return g.bloom.Marshal()
+	return g.topicBloom(subnetID).Marshal()
 }
@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/network/p2p/gossip"
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+var _ p2p.Handler = (*pullRequestMeter)(nil)
+
+// bloomMetrics reports the efficacy of the bloom filter of a topic, on top of
+// the metrics reported by the bloom filter itself.
+type bloomMetrics struct {
+	falsePositiveProbability prometheus.Gauge
+	resizeCount              prometheus.Counter
+}
+
+func newBloomMetrics(registerer prometheus.Registerer, namespace string) (*bloomMetrics, error) {
+	m := &bloomMetrics{
+		falsePositiveProbability: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "false_positive_probability",
+			Help:      "Estimated probability of a false positive of the bloom",
+		}),
+		resizeCount: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "resize_count",
+			Help:      "Number of times the bloom has been resized to the size of the mempool",
+		}),
+	}
+	err := utils.Err(
+		registerer.Register(m.falsePositiveProbability),
+		registerer.Register(m.resizeCount),
+	)
+	return m, err
+}
+
+func (m *bloomMetrics) update(bloom *gossip.BloomFilter) {
+	m.falsePositiveProbability.Set(bloom.FalsePositiveProbability())
+}
+
+// pullRequestMeter reports the sizes of the pull gossip requests sent by every
+// peer before passing them on to the wrapped handler. It is expected to only
+// be reached by validators, which bounds the number of peer labels.
+type pullRequestMeter struct {
+	p2p.Handler
+
+	numRequests  *prometheus.CounterVec
+	requestBytes *prometheus.CounterVec
+	requestSize  prometheus.Histogram
+}
+
+func newPullRequestMeter(
+	handler p2p.Handler,
+	registerer prometheus.Registerer,
+	namespace string,
+) (*pullRequestMeter, error) {
+	m := &pullRequestMeter{
+		Handler: handler,
+		numRequests: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "pull_requests",
+				Help:      "Number of pull gossip requests received from a peer",
+			},
+			[]string{"nodeID"},
+		),
+		requestBytes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "pull_request_bytes",
+				Help:      "Number of bytes of the pull gossip requests received from a peer",
+			},
+			[]string{"nodeID"},
+		),
+		requestSize: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "pull_request_size",
+			Help:      "Size in bytes of the pull gossip requests",
+			Buckets:   prometheus.ExponentialBuckets(256, 2, 10),
+		}),
+	}
+	err := utils.Err(
+		registerer.Register(m.numRequests),
+		registerer.Register(m.requestBytes),
+		registerer.Register(m.requestSize),
+	)
+	return m, err
+}
+
+func (m *pullRequestMeter) AppRequest(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	deadline time.Time,
+	requestBytes []byte,
+) ([]byte, error) {
+	labels := prometheus.Labels{"nodeID": nodeID.String()}
+	m.numRequests.With(labels).Inc()
+	m.requestBytes.With(labels).Add(float64(len(requestBytes)))
+	m.requestSize.Observe(float64(len(requestBytes)))
+	return m.Handler.AppRequest(ctx, nodeID, deadline, requestBytes)
+}
@@ -4,14 +4,20 @@
 package network
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/network/p2p/gossip"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/mempool"
@@ -21,35 +27,61 @@ var errFoo = errors.New("foo")
 
 // Add should error if verification errors
 func TestGossipMempoolAddVerificationError(t *testing.T) {
-	require := require.New(t)
-	ctrl := gomock.NewController(t)
-
-	txID := ids.GenerateTestID()
-	tx := &txs.Tx{
-		TxID: txID,
+	tests := []struct {
+		name            string
+		err             error
+		expectedInvalid bool
+	}{
+		{
+			name:            "state dependent error",
+			err:             errFoo,
+			expectedInvalid: false,
+		},
+		{
+			name:            "invalid tx",
+			err:             fmt.Errorf("%w: %w", ErrInvalidTx, errFoo),
+			expectedInvalid: true,
+		},
+		{
+			name:            "rejected by policy",
+			err:             fmt.Errorf("%w: %w", ErrTxRejectedByPolicy, errFoo),
+			expectedInvalid: false,
+		},
 	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
 
-	mempool := mempool.NewMockMempool(ctrl)
-	txVerifier := testTxVerifier{err: errFoo}
+			txID := ids.GenerateTestID()
+			tx := &txs.Tx{
+				TxID: txID,
+			}
 
-	mempool.EXPECT().Get(txID).Return(nil, false)
-	mempool.EXPECT().GetDropReason(txID).Return(nil)
-	mempool.EXPECT().MarkDropped(txID, errFoo)
+			mempool := mempool.NewMockMempool(ctrl)
+			txVerifier := testTxVerifier{err: test.err}
 
-	gossipMempool, err := newGossipMempool(
-		mempool,
-		prometheus.NewRegistry(),
-		logging.NoLog{},
-		txVerifier,
-		testConfig.ExpectedBloomFilterElements,
-		testConfig.ExpectedBloomFilterFalsePositiveProbability,
-		testConfig.MaxBloomFilterFalsePositiveProbability,
-	)
-	require.NoError(err)
+			mempool.EXPECT().Get(txID).Return(nil, false)
+			mempool.EXPECT().GetDropReason(txID).Return(nil)
+			mempool.EXPECT().MarkDropped(txID, test.err)
 
-	err = gossipMempool.Add(tx)
-	require.ErrorIs(err, errFoo)
-	require.False(gossipMempool.bloom.Has(tx))
+			gossipMempool, err := newGossipMempool(
+				mempool,
+				prometheus.NewRegistry(),
+				logging.NoLog{},
+				txVerifier,
+				testConfig.ExpectedBloomFilterElements,
+				testConfig.ExpectedBloomFilterFalsePositiveProbability,
+				testConfig.MaxBloomFilterFalsePositiveProbability,
+			)
+			require.NoError(err)
+
+			invalid, err := gossipMempool.add(tx)
+			require.ErrorIs(err, errFoo)
+			require.Equal(test.expectedInvalid, invalid)
+			require.False(gossipMempool.bloom.Has(tx))
+		})
+	}
 }
 
 // Add should error if adding to the mempool errors
@@ -150,3 +182,168 @@ func TestGossipAddBloomFilter(t *testing.T) {
 	require.NoError(gossipMempool.Add(tx))
 	require.True(gossipMempool.bloom.Has(tx))
 }
+
+// Txs of a subnet with a topic should only be tracked by the bloom filter and
+// iterated over by the set of the topic
+func TestGossipMempoolTopics(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	subnetID := ids.GenerateTestID()
+	primaryTx := &txs.Tx{
+		Unsigned: &txs.BaseTx{},
+		TxID:     ids.GenerateTestID(),
+	}
+	subnetTx := &txs.Tx{
+		Unsigned: &txs.CreateChainTx{SubnetID: subnetID},
+		TxID:     ids.GenerateTestID(),
+	}
+	untrackedSubnetTx := &txs.Tx{
+		Unsigned: &txs.CreateChainTx{SubnetID: ids.GenerateTestID()},
+		TxID:     ids.GenerateTestID(),
+	}
+	allTxs := []*txs.Tx{primaryTx, subnetTx, untrackedSubnetTx}
+
+	mempool := mempool.NewMockMempool(ctrl)
+	for _, tx := range allTxs {
+		mempool.EXPECT().Get(tx.ID()).Return(nil, false)
+		mempool.EXPECT().GetDropReason(tx.ID()).Return(nil)
+		mempool.EXPECT().Add(tx).Return(nil)
+	}
+	mempool.EXPECT().Len().Return(0).AnyTimes()
+	mempool.EXPECT().RequestBuildBlock(false).AnyTimes()
+	mempool.EXPECT().Iterate(gomock.Any()).DoAndReturn(func(f func(*txs.Tx) bool) {
+		for _, tx := range allTxs {
+			if !f(tx) {
+				return
+			}
+		}
+	}).AnyTimes()
+
+	gossipMempool, err := newGossipMempool(
+		mempool,
+		prometheus.NewRegistry(),
+		logging.NoLog{},
+		testTxVerifier{},
+		testConfig.ExpectedBloomFilterElements,
+		testConfig.ExpectedBloomFilterFalsePositiveProbability,
+		testConfig.MaxBloomFilterFalsePositiveProbability,
+	)
+	require.NoError(err)
+
+	subnetBloom, err := gossip.NewBloomFilter(
+		prometheus.NewRegistry(),
+		"",
+		testConfig.ExpectedBloomFilterElements,
+		testConfig.ExpectedBloomFilterFalsePositiveProbability,
+		testConfig.MaxBloomFilterFalsePositiveProbability,
+	)
+	require.NoError(err)
+	subnetBloomMetrics, err := newBloomMetrics(prometheus.NewRegistry(), "")
+	require.NoError(err)
+	gossipMempool.addTopic(subnetID, subnetBloom, subnetBloomMetrics)
+
+	for _, tx := range allTxs {
+		require.NoError(gossipMempool.Add(tx))
+	}
+
+	require.True(gossipMempool.bloom.Has(primaryTx))
+	require.True(gossipMempool.bloom.Has(untrackedSubnetTx))
+	require.False(gossipMempool.bloom.Has(subnetTx))
+	require.True(subnetBloom.Has(subnetTx))
+	require.False(subnetBloom.Has(primaryTx))
+
+	var primaryTxs []*txs.Tx
+	gossipMempool.Iterate(func(tx *txs.Tx) bool {
+		primaryTxs = append(primaryTxs, tx)
+		return true
+	})
+	require.Equal([]*txs.Tx{primaryTx, untrackedSubnetTx}, primaryTxs)
+
+	var topicTxs []*txs.Tx
+	set := &topicSet{mempool: gossipMempool, subnetID: subnetID}
+	set.Iterate(func(tx *txs.Tx) bool {
+		topicTxs = append(topicTxs, tx)
+		return true
+	})
+	require.Equal([]*txs.Tx{subnetTx}, topicTxs)
+}
+
+func TestGossipMempoolResizeBloom(t *testing.T) {
+	require := require.New(t)
+
+	const minTargetElements = 100
+	bloom, err := gossip.NewBloomFilter(prometheus.NewRegistry(), "", minTargetElements, .01, .05)
+	require.NoError(err)
+	g := &gossipMempool{
+		minTargetElements: minTargetElements,
+	}
+
+	tests := []struct {
+		name            string
+		targetElements  int
+		expectedResized bool
+		expectedTarget  int
+	}{
+		{
+			name:           "fits",
+			targetElements: minTargetElements,
+			expectedTarget: minTargetElements,
+		},
+		{
+			name:            "outgrown",
+			targetElements:  minTargetElements + 1,
+			expectedResized: true,
+			expectedTarget:  (minTargetElements + 1) * bloomGrowthMultiplier,
+		},
+		{
+			name:           "slightly too large",
+			targetElements: minTargetElements,
+			expectedTarget: (minTargetElements + 1) * bloomGrowthMultiplier,
+		},
+		{
+			name:            "much too large",
+			targetElements:  10,
+			expectedResized: true,
+			expectedTarget:  minTargetElements,
+		},
+		{
+			name:           "minimum size",
+			targetElements: 0,
+			expectedTarget: minTargetElements,
+		},
+	}
+	for _, test := range tests {
+		resized, err := g.resizeBloomIfNeeded(bloom, test.targetElements)
+		require.NoError(err, test.name)
+		require.Equal(test.expectedResized, resized, test.name)
+		require.Equal(test.expectedTarget, bloom.TargetElements(), test.name)
+	}
+}
+
+func TestPullRequestMeter(t *testing.T) {
+	require := require.New(t)
+
+	meter, err := newPullRequestMeter(p2p.NoOpHandler{}, prometheus.NewRegistry(), "")
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	for _, size := range []int{10, 20} {
+		_, err := meter.AppRequest(context.Background(), nodeID, time.Time{}, make([]byte, size))
+		require.NoError(err)
+	}
+
+	labels := prometheus.Labels{"nodeID": nodeID.String()}
+	require.Equal(float64(2), testutil.ToFloat64(meter.numRequests.With(labels)))
+	require.Equal(float64(30), testutil.ToFloat64(meter.requestBytes.With(labels)))
+}
+
+func TestSubnetTxGossipHandlerID(t *testing.T) {
+	require := require.New(t)
+
+	subnetID := ids.GenerateTestID()
+	handlerID := SubnetTxGossipHandlerID(subnetID)
+	require.NotZero(handlerID & subnetTopicHandlerIDBit)
+	require.Equal(handlerID, SubnetTxGossipHandlerID(subnetID))
+	require.NotEqual(handlerID, SubnetTxGossipHandlerID(ids.GenerateTestID()))
+}
@@ -5,6 +5,7 @@ package network
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
@@ -18,20 +19,32 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/components/message"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/mempool"
 )
 
-const TxGossipHandlerID = 0
+const (
+	TxGossipHandlerID = iota
+	StateAttestationHandlerID
+	ValidatorSetHandlerID
+	HeartbeatHandlerID
+)
 
 type Network interface {
 	common.AppHandler
 
+	// AddHandler registers a handler of the p2p messages with [handlerID].
+	AddHandler(handlerID uint64, handler p2p.Handler) error
+	// NewClient returns a client of the handlers registered with [handlerID].
+	NewClient(handlerID uint64, options ...p2p.ClientOption) *p2p.Client
+
 	// Gossip starts gossiping transactions and blocks until it completes.
 	Gossip(ctx context.Context)
 	// IssueTx verifies the transaction at the currently preferred state, adds
-	// it to the mempool, and gossips it to the network.
+	// it to the mempool, and gossips it to the network, or relays it to the
+	// relay nodes if any are configured.
 	IssueTx(context.Context, *txs.Tx) error
 }
 
@@ -48,9 +61,21 @@ type network struct {
 	txPullGossiper    gossip.Gossiper
 	txGossipFrequency time.Duration
 
+	// Topics that the txs of tracked subnets are gossiped on
+	subnetTopics map[ids.ID]*subnetTopic
+
+	// Peers whose messages are dropped for gossiping invalid txs
+	bans *PeerBans
+
 	// gossip related attributes
 	recentTxsLock sync.Mutex
 	recentTxs     *cache.LRU[ids.ID, struct{}]
+
+	// Nodes that the issued txs are relayed to. If empty, the issued txs are
+	// gossiped.
+	relayNodeIDs  set.Set[ids.NodeID]
+	relayedTxs    *cache.LRU[ids.ID, struct{}]
+	numRelayedTxs prometheus.Counter
 }
 
 func New(
@@ -61,6 +86,8 @@ func New(
 	txVerifier TxVerifier,
 	mempool mempool.Mempool,
 	partialSyncPrimaryNetwork bool,
+	trackedSubnets set.Set[ids.ID],
+	bans *PeerBans,
 	appSender common.AppSender,
 	registerer prometheus.Registerer,
 	config Config,
@@ -133,9 +160,14 @@ func New(
 		config.TargetGossipSize,
 	)
 
+	pullRequestMeter, err := newPullRequestMeter(handler, registerer, "tx")
+	if err != nil {
+		return nil, err
+	}
+
 	validatorHandler := p2p.NewValidatorHandler(
 		p2p.NewThrottlerHandler(
-			handler,
+			pullRequestMeter,
 			p2p.NewSlidingWindowThrottler(
 				config.PullGossipThrottlingPeriod,
 				config.PullGossipThrottlingLimit,
@@ -149,7 +181,15 @@ func New(
 	// We allow pushing txs between all peers, but only serve gossip requests
 	// from validators
 	txGossipHandler := txGossipHandler{
-		appGossipHandler:  handler,
+		appGossipHandler: &scoredGossipHandler{
+			log:                log,
+			accumulator:        txPushGossiper,
+			mempool:            gossipMempool,
+			set:                gossipMempool,
+			metrics:            txGossipMetrics,
+			targetResponseSize: config.TargetGossipSize,
+			bans:               bans,
+		},
 		appRequestHandler: validatorHandler,
 	}
 
@@ -157,6 +197,35 @@ func New(
 		return nil, err
 	}
 
+	subnetTopics := make(map[ids.ID]*subnetTopic)
+	if config.SubnetTxGossip {
+		for subnetID := range trackedSubnets {
+			topic, err := newSubnetTopic(
+				log,
+				nodeID,
+				subnetID,
+				vdrs,
+				p2pNetwork,
+				gossipMempool,
+				bans,
+				registerer,
+				config,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create gossip topic of subnet %s: %w", subnetID, err)
+			}
+			subnetTopics[subnetID] = topic
+		}
+	}
+
+	numRelayedTxs := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "relayed_txs",
+		Help: "Number of issued txs relayed to the relay nodes",
+	})
+	if err := registerer.Register(numRelayedTxs); err != nil {
+		return nil, err
+	}
+
 	return &network{
 		Network:                   p2pNetwork,
 		log:                       log,
@@ -167,7 +236,12 @@ func New(
 		txPushGossiper:            txPushGossiper,
 		txPullGossiper:            txPullGossiper,
 		txGossipFrequency:         config.PullGossipFrequency,
+		subnetTopics:              subnetTopics,
+		bans:                      bans,
 		recentTxs:                 &cache.LRU[ids.ID, struct{}]{Size: config.LegacyPushGossipCacheSize},
+		relayNodeIDs:              set.Of(config.RelayNodeIDs...),
+		relayedTxs:                &cache.LRU[ids.ID, struct{}]{Size: config.LegacyPushGossipCacheSize},
+		numRelayedTxs:             numRelayedTxs,
 	}, nil
 }
 
@@ -178,7 +252,16 @@ func (n *network) Gossip(ctx context.Context) {
 		return
 	}
 
+	var wg sync.WaitGroup
+	for _, topic := range n.subnetTopics {
+		wg.Add(1)
+		go func(topic *subnetTopic) {
+			defer wg.Done()
+			gossip.Every(ctx, n.log, topic.pullGossiper, n.txGossipFrequency)
+		}(topic)
+	}
 	gossip.Every(ctx, n.log, n.txPullGossiper, n.txGossipFrequency)
+	wg.Wait()
 }
 
 func (n *network) AppGossip(ctx context.Context, nodeID ids.NodeID, msgBytes []byte) error {
@@ -193,6 +276,13 @@ func (n *network) AppGossip(ctx context.Context, nodeID ids.NodeID, msgBytes []b
 		)
 		return nil
 	}
+	if n.bans.IsBanned(nodeID) {
+		n.log.Debug("dropping AppGossip message",
+			zap.String("reason", "peer is banned"),
+			zap.Stringer("nodeID", nodeID),
+		)
+		return nil
+	}
 
 	msgIntf, err := message.Parse(msgBytes)
 	if err != nil {
@@ -218,21 +308,39 @@ func (n *network) AppGossip(ctx context.Context, nodeID ids.NodeID, msgBytes []b
 			zap.Binary("tx", msg.Tx),
 			zap.Error(err),
 		)
+		n.bans.penalize(nodeID, malformedTxPenalty)
 		return nil
 	}
-	txID := tx.ID()
 
-	if err := n.issueTx(tx); err == nil {
-		n.legacyGossipTx(ctx, txID, msgBytes)
-
-		n.txPushGossiper.Add(tx)
-		return n.txPushGossiper.Gossip(ctx)
+	invalid, err := n.issueTx(tx)
+	if invalid {
+		n.bans.penalize(nodeID, invalidTxPenalty)
+	}
+	if err == nil {
+		return n.gossipTx(ctx, tx, msgBytes)
 	}
 	return nil
 }
 
+func (n *network) AppRequest(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	requestID uint32,
+	deadline time.Time,
+	request []byte,
+) error {
+	if n.bans.IsBanned(nodeID) {
+		n.log.Debug("dropping AppRequest message",
+			zap.String("reason", "peer is banned"),
+			zap.Stringer("nodeID", nodeID),
+		)
+		return nil
+	}
+	return n.Network.AppRequest(ctx, nodeID, requestID, deadline, request)
+}
+
 func (n *network) IssueTx(ctx context.Context, tx *txs.Tx) error {
-	if err := n.issueTx(tx); err != nil {
+	if _, err := n.issueTx(tx); err != nil {
 		return err
 	}
 
@@ -245,30 +353,70 @@ func (n *network) IssueTx(ctx context.Context, tx *txs.Tx) error {
 		return err
 	}
 
-	txID := tx.ID()
-	n.legacyGossipTx(ctx, txID, msgBytes)
+	if n.relayNodeIDs.Len() != 0 {
+		return n.relayTx(ctx, tx.ID(), msgBytes)
+	}
+	return n.gossipTx(ctx, tx, msgBytes)
+}
+
+// relayTx sends [tx] to the relay nodes, unless it was recently relayed. The
+// relay nodes gossip it to the rest of the network once they verified it.
+func (n *network) relayTx(ctx context.Context, txID ids.ID, msgBytes []byte) error {
+	n.recentTxsLock.Lock()
+	_, has := n.relayedTxs.Get(txID)
+	n.relayedTxs.Put(txID, struct{}{})
+	n.recentTxsLock.Unlock()
+
+	if has {
+		return nil
+	}
+
+	n.log.Debug("relaying tx",
+		zap.Stringer("txID", txID),
+		zap.Int("numRelayNodes", n.relayNodeIDs.Len()),
+	)
+
+	if err := n.appSender.SendAppGossipSpecific(ctx, n.relayNodeIDs, msgBytes); err != nil {
+		return fmt.Errorf("failed to relay tx %s: %w", txID, err)
+	}
+	n.numRelayedTxs.Inc()
+	return nil
+}
+
+// gossipTx pushes [tx] to the peers subscribed to its topic. Txs gossiped on
+// the topic of a subnet aren't sent with the legacy push gossip, which would
+// reach all peers.
+func (n *network) gossipTx(ctx context.Context, tx *txs.Tx, msgBytes []byte) error {
+	if topic, ok := n.subnetTopics[n.mempool.topic(tx)]; ok {
+		topic.pushGossiper.Add(tx)
+		return topic.pushGossiper.Gossip(ctx)
+	}
+
+	n.legacyGossipTx(ctx, tx.ID(), msgBytes)
 	n.txPushGossiper.Add(tx)
 	return n.txPushGossiper.Gossip(ctx)
 }
 
-// returns nil if the tx is in the mempool
-func (n *network) issueTx(tx *txs.Tx) error {
+// returns nil if the tx is in the mempool. Also returns true if the tx failed
+// verification.
+func (n *network) issueTx(tx *txs.Tx) (bool, error) {
 	// If we are partially syncing the Primary Network, we should not be
 	// maintaining the transaction mempool locally.
 	if n.partialSyncPrimaryNetwork {
-		return nil
+		return false, nil
 	}
 
-	if err := n.mempool.Add(tx); err != nil {
+	invalid, err := n.mempool.add(tx)
+	if err != nil {
 		n.log.Debug("tx failed to be added to the mempool",
 			zap.Stringer("txID", tx.ID()),
 			zap.Error(err),
 		)
 
-		return err
+		return invalid, err
 	}
 
-	return nil
+	return false, nil
 }
 
 func (n *network) legacyGossipTx(ctx context.Context, txID ids.ID, msgBytes []byte) {
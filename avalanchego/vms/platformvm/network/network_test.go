@@ -17,6 +17,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/snowtest"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/message"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
@@ -190,6 +191,8 @@ func TestNetworkAppGossip(t *testing.T) {
 				testTxVerifier{},
 				tt.mempoolFunc(ctrl),
 				tt.partialSyncPrimaryNetwork,
+				nil,
+				newTestPeerBans(t),
 				tt.appSenderFunc(ctrl),
 				prometheus.NewRegistry(),
 				DefaultConfig,
@@ -323,6 +326,8 @@ func TestNetworkIssueTx(t *testing.T) {
 				tt.txVerifier,
 				tt.mempoolFunc(ctrl),
 				tt.partialSyncPrimaryNetwork,
+				nil,
+				newTestPeerBans(t),
 				tt.appSenderFunc(ctrl),
 				prometheus.NewRegistry(),
 				testConfig,
@@ -335,6 +340,49 @@ func TestNetworkIssueTx(t *testing.T) {
 	}
 }
 
+func TestNetworkRelayTx(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	tx := &txs.Tx{}
+	mpool := mempool.NewMockMempool(ctrl)
+	mpool.EXPECT().Get(gomock.Any()).Return(nil, false).Times(2)
+	mpool.EXPECT().GetDropReason(gomock.Any()).Return(nil).Times(2)
+	mpool.EXPECT().Add(gomock.Any()).Return(nil).Times(2)
+	mpool.EXPECT().Len().Return(0).Times(2)
+	mpool.EXPECT().RequestBuildBlock(false).Times(2)
+
+	relayNodeIDs := []ids.NodeID{
+		ids.GenerateTestNodeID(),
+		ids.GenerateTestNodeID(),
+	}
+	// The tx is only sent to the relay nodes, and only once.
+	appSender := common.NewMockSender(ctrl)
+	appSender.EXPECT().SendAppGossipSpecific(gomock.Any(), set.Of(relayNodeIDs...), gomock.Any()).Return(nil)
+
+	config := testConfig
+	config.RelayNodeIDs = relayNodeIDs
+	snowCtx := snowtest.Context(t, ids.Empty)
+	n, err := New(
+		snowCtx.Log,
+		snowCtx.NodeID,
+		snowCtx.SubnetID,
+		snowCtx.ValidatorState,
+		testTxVerifier{},
+		mpool,
+		false,
+		nil,
+		newTestPeerBans(t),
+		appSender,
+		prometheus.NewRegistry(),
+		config,
+	)
+	require.NoError(err)
+
+	require.NoError(n.IssueTx(context.Background(), tx))
+	require.NoError(n.IssueTx(context.Background(), tx))
+}
+
 func TestNetworkGossipTx(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
@@ -350,6 +398,8 @@ func TestNetworkGossipTx(t *testing.T) {
 		testTxVerifier{},
 		mempool.NewMockMempool(ctrl),
 		false,
+		nil,
+		newTestPeerBans(t),
 		appSender,
 		prometheus.NewRegistry(),
 		testConfig,
@@ -370,3 +420,52 @@ func TestNetworkGossipTx(t *testing.T) {
 	n.legacyGossipTx(context.Background(), ids.GenerateTestID(), msgBytes)
 	// Did make a call to SendAppGossip
 }
+
+// Peers that gossip malformed txs are banned and their gossip is dropped
+func TestNetworkAppGossipBannedPeer(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+
+	testTx := &txs.Tx{
+		Unsigned: &txs.BaseTx{
+			BaseTx: avax.BaseTx{
+				NetworkID:    1,
+				BlockchainID: ids.GenerateTestID(),
+			},
+		},
+	}
+	require.NoError(testTx.Initialize(txs.Codec))
+
+	bans := newTestPeerBans(t)
+	snowCtx := snowtest.Context(t, ids.Empty)
+	n, err := New(
+		logging.NoLog{},
+		ids.EmptyNodeID,
+		ids.Empty,
+		snowCtx.ValidatorState,
+		testTxVerifier{},
+		mempool.NewMockMempool(ctrl), // The txs are never verified
+		false,
+		nil,
+		bans,
+		common.NewMockSender(ctrl),
+		prometheus.NewRegistry(),
+		DefaultConfig,
+	)
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	malformedMsgBytes, err := message.Build(&message.Tx{
+		Tx: []byte{0x00},
+	})
+	require.NoError(err)
+	require.NoError(n.AppGossip(ctx, nodeID, malformedMsgBytes))
+	require.True(bans.IsBanned(nodeID))
+
+	msgBytes, err := message.Build(&message.Tx{
+		Tx: testTx.Bytes(),
+	})
+	require.NoError(err)
+	require.NoError(n.AppGossip(ctx, nodeID, msgBytes))
+}
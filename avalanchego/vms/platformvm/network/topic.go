@@ -0,0 +1,177 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"encoding/binary"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/network/p2p/gossip"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+var _ gossip.Set[*txs.Tx] = (*topicSet)(nil)
+
+// subnetTopicHandlerIDBit is set in the handler IDs of subnet topics, so that
+// they can't collide with the handlers registered with fixed IDs.
+const subnetTopicHandlerIDBit = 1 << 63
+
+// SubnetTxGossipHandlerID returns the ID of the handler that gossips the txs
+// of [subnetID].
+func SubnetTxGossipHandlerID(subnetID ids.ID) uint64 {
+	hash := hashing.ComputeHash256(subnetID[:])
+	return binary.BigEndian.Uint64(hash) | subnetTopicHandlerIDBit
+}
+
+// txSubnetID returns the subnet that [tx] modifies. Txs that don't modify a
+// subnet belong to the primary network.
+func txSubnetID(tx *txs.Tx) ids.ID {
+	switch utx := tx.Unsigned.(type) {
+	case txs.Staker:
+		return utx.SubnetID()
+	case *txs.CreateChainTx:
+		return utx.SubnetID
+	case *txs.RemoveSubnetValidatorTx:
+		return utx.Subnet
+	case *txs.TransformSubnetTx:
+		return utx.Subnet
+	case *txs.TransferSubnetOwnershipTx:
+		return utx.Subnet
+	case *txs.AddSubnetAllowListEntriesTx:
+		return utx.Subnet
+	case *txs.RemoveSubnetAllowListEntriesTx:
+		return utx.Subnet
+	case *txs.ExitValidatorTx:
+		return utx.Subnet
+	case *txs.SetSubnetValidatorWeightTx:
+		return utx.Subnet
+	default:
+		return constants.PrimaryNetworkID
+	}
+}
+
+// topicSet is the set of mempool txs that are gossiped on the topic of a
+// subnet.
+type topicSet struct {
+	mempool  *gossipMempool
+	subnetID ids.ID
+}
+
+func (t *topicSet) Add(tx *txs.Tx) error {
+	return t.mempool.Add(tx)
+}
+
+func (t *topicSet) Iterate(f func(tx *txs.Tx) bool) {
+	t.mempool.iterateTopic(t.subnetID, f)
+}
+
+func (t *topicSet) GetFilter() (bloom []byte, salt []byte) {
+	return t.mempool.getTopicFilter(t.subnetID)
+}
+
+// subnetTopic gossips the txs of a subnet among the validators of the subnet.
+type subnetTopic struct {
+	pushGossiper gossip.Accumulator[*txs.Tx]
+	pullGossiper gossip.Gossiper
+}
+
+// newSubnetTopic registers the handler of the topic of [subnetID] with
+// [p2pNetwork].
+func newSubnetTopic(
+	log logging.Logger,
+	nodeID ids.NodeID,
+	subnetID ids.ID,
+	vdrs validators.State,
+	p2pNetwork *p2p.Network,
+	mempool *gossipMempool,
+	bans *PeerBans,
+	registerer prometheus.Registerer,
+	config Config,
+) (*subnetTopic, error) {
+	// Metrics of all subnet topics share their names and are distinguished by
+	// the subnetID label.
+	registerer = prometheus.WrapRegistererWith(
+		prometheus.Labels{"subnetID": subnetID.String()},
+		registerer,
+	)
+	bloom, err := gossip.NewBloomFilter(
+		registerer,
+		"subnet_mempool_bloom_filter",
+		config.ExpectedBloomFilterElements,
+		config.ExpectedBloomFilterFalsePositiveProbability,
+		config.MaxBloomFilterFalsePositiveProbability,
+	)
+	if err != nil {
+		return nil, err
+	}
+	bloomMetrics, err := newBloomMetrics(registerer, "subnet_mempool_bloom_filter")
+	if err != nil {
+		return nil, err
+	}
+	mempool.addTopic(subnetID, bloom, bloomMetrics)
+
+	metrics, err := gossip.NewMetrics(registerer, "subnet_tx")
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		handlerID     = SubnetTxGossipHandlerID(subnetID)
+		marshaller    = txMarshaller{}
+		set           = &topicSet{mempool: mempool, subnetID: subnetID}
+		subnetVdrs    = p2p.NewValidators(p2pNetwork.Peers, log, subnetID, vdrs, config.MaxValidatorSetStaleness)
+		client        = p2pNetwork.NewClient(handlerID, p2p.WithValidatorSampling(subnetVdrs))
+		pushGossip    = gossip.NewPushGossiper[*txs.Tx](marshaller, client, metrics, config.TargetGossipSize)
+		gossipHandler = gossip.NewHandler[*txs.Tx](log, marshaller, pushGossip, set, metrics, config.TargetGossipSize)
+	)
+
+	pullRequestMeter, err := newPullRequestMeter(gossipHandler, registerer, "subnet_tx")
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the validators of the subnet pull and serve the txs of the topic
+	pullGossip := gossip.ValidatorGossiper{
+		Gossiper:   gossip.NewPullGossiper[*txs.Tx](log, marshaller, set, client, metrics, config.PullGossipPollSize),
+		NodeID:     nodeID,
+		Validators: subnetVdrs,
+	}
+	handler := txGossipHandler{
+		appGossipHandler: &scoredGossipHandler{
+			log:                log,
+			accumulator:        pushGossip,
+			mempool:            mempool,
+			set:                set,
+			metrics:            metrics,
+			targetResponseSize: config.TargetGossipSize,
+			bans:               bans,
+		},
+		appRequestHandler: p2p.NewValidatorHandler(
+			p2p.NewThrottlerHandler(
+				pullRequestMeter,
+				p2p.NewSlidingWindowThrottler(
+					config.PullGossipThrottlingPeriod,
+					config.PullGossipThrottlingLimit,
+				),
+				log,
+			),
+			subnetVdrs,
+			log,
+		),
+	}
+	if err := p2pNetwork.AddHandler(handlerID, handler); err != nil {
+		return nil, err
+	}
+	return &subnetTopic{
+		pushGossiper: pushGossip,
+		pullGossiper: pullGossip,
+	}, nil
+}
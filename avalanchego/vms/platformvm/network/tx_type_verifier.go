@@ -0,0 +1,67 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+var (
+	_ TxVerifier = (*txTypeVerifier)(nil)
+
+	ErrTxTypeDisabled = errors.New("tx type is disabled")
+
+	errUnknownTxType = errors.New("unknown tx type")
+)
+
+// txTypeVerifier rejects the txs whose type was disabled by the node operator.
+//
+// Txs are only filtered out of the mempool, blocks including disabled txs are
+// still verified normally.
+type txTypeVerifier struct {
+	disabled   set.Set[string]
+	txVerifier TxVerifier
+}
+
+// NewTxTypeVerifier returns a TxVerifier that rejects txs whose type is in
+// [disabledTxTypes] and forwards the others to [txVerifier]. Tx types are named
+// after their struct, e.g. "AddValidatorTx".
+func NewTxTypeVerifier(disabledTxTypes []string, txVerifier TxVerifier) (TxVerifier, error) {
+	if len(disabledTxTypes) == 0 {
+		return txVerifier, nil
+	}
+
+	// Every built-in tx type has a corresponding method in the Visitor
+	// interface.
+	visitorType := reflect.TypeOf((*txs.Visitor)(nil)).Elem()
+	extensionTypes := set.Set[string]{}
+	for _, e := range txs.Extensions() {
+		extensionTypes.Add(e.Name())
+	}
+	disabled := set.NewSet[string](len(disabledTxTypes))
+	for _, txType := range disabledTxTypes {
+		_, ok := visitorType.MethodByName(txType)
+		if !ok && !extensionTypes.Contains(txType) {
+			return nil, fmt.Errorf("%w: %q", errUnknownTxType, txType)
+		}
+		disabled.Add(txType)
+	}
+	return &txTypeVerifier{
+		disabled:   disabled,
+		txVerifier: txVerifier,
+	}, nil
+}
+
+func (v *txTypeVerifier) VerifyTx(tx *txs.Tx) error {
+	txType := reflect.TypeOf(tx.Unsigned).Elem().Name()
+	if v.disabled.Contains(txType) {
+		return fmt.Errorf("%w: %s", ErrTxTypeDisabled, txType)
+	}
+	return v.txVerifier.VerifyTx(tx)
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+func TestTxTypeVerifier(t *testing.T) {
+	tests := []struct {
+		name            string
+		disabledTxTypes []string
+		tx              *txs.Tx
+		expectedErr     error
+	}{
+		{
+			name:            "no disabled tx types",
+			disabledTxTypes: nil,
+			tx:              &txs.Tx{Unsigned: &txs.CreateChainTx{}},
+			expectedErr:     errFoo,
+		},
+		{
+			name:            "tx type enabled",
+			disabledTxTypes: []string{"AddValidatorTx"},
+			tx:              &txs.Tx{Unsigned: &txs.CreateChainTx{}},
+			expectedErr:     errFoo,
+		},
+		{
+			name:            "tx type disabled",
+			disabledTxTypes: []string{"AddValidatorTx", "CreateChainTx"},
+			tx:              &txs.Tx{Unsigned: &txs.CreateChainTx{}},
+			expectedErr:     ErrTxTypeDisabled,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			verifier, err := NewTxTypeVerifier(test.disabledTxTypes, testTxVerifier{err: errFoo})
+			require.NoError(err)
+
+			err = verifier.VerifyTx(test.tx)
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+func TestTxTypeVerifierUnknownTxType(t *testing.T) {
+	_, err := NewTxTypeVerifier([]string{"CreateChainTx", "UnknownTx"}, testTxVerifier{})
+	require.ErrorIs(t, err, errUnknownTxType)
+}
@@ -4,15 +4,26 @@
 package network
 
 import (
+	"errors"
 	"sync"
 
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 )
 
-var _ TxVerifier = (*LockedTxVerifier)(nil)
+var (
+	_ TxVerifier = (*LockedTxVerifier)(nil)
+
+	// ErrInvalidTx is wrapped by the verification errors that prove that a
+	// tx is invalid on every node, such as failed syntactic or signature
+	// checks. Other verification errors may depend on the state of the node
+	// verifying the tx.
+	ErrInvalidTx = errors.New("invalid tx")
+)
 
 type TxVerifier interface {
 	// VerifyTx verifies that the transaction should be issued into the mempool.
+	// Peers are only penalized for gossiping txs whose error wraps
+	// [ErrInvalidTx].
 	VerifyTx(tx *txs.Tx) error
 }
 
@@ -0,0 +1,329 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package offline verifies P-chain blocks and transactions against a state
+// provided by the caller, without requiring a running VM or database.
+package offline
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/codec/linearcodec"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+	"github.com/ava-labs/avalanchego/vms/platformvm/utxo"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	blockexecutor "github.com/ava-labs/avalanchego/vms/platformvm/block/executor"
+)
+
+var (
+	_ secp256k1fx.VM = (*fxVM)(nil)
+
+	ErrUnsupportedBlock            = errors.New("unsupported block type")
+	ErrWrongParent                 = errors.New("block doesn't reference the provided parent")
+	ErrIncorrectBlockHeight        = errors.New("incorrect block height")
+	ErrChildBlockEarlierThanParent = errors.New("proposed timestamp before current chain time")
+	ErrProposalBlockWithMultipleTx = errors.New("BanffProposalBlock contains multiple transactions")
+	ErrStandardBlockWithoutChanges = errors.New("BanffStandardBlock performs no state changes")
+)
+
+// Verifier verifies blocks and txs against caller provided states.
+//
+// A Verifier is not safe for concurrent use.
+type Verifier struct {
+	fxVM    *fxVM
+	backend *executor.Backend
+}
+
+// New returns a Verifier of the chain described by [cfg] and [ctx].
+//
+// Of [ctx], the verification only uses the NetworkID, ChainID, SubnetID,
+// AVAXAssetID, NodeID and Log fields, along with:
+//   - SharedMemory, to look up the UTXOs consumed by ImportTxs.
+//   - ValidatorState, to check the destination of ImportTxs and ExportTxs.
+func New(cfg *config.Config, ctx *snow.Context) (*Verifier, error) {
+	vm := &fxVM{
+		codec:     linearcodec.NewDefault(time.Time{}),
+		log:       ctx.Log,
+		banffTime: cfg.BanffTime,
+	}
+	fx := &secp256k1fx.Fx{}
+	if err := fx.Initialize(vm); err != nil {
+		return nil, err
+	}
+	if err := fx.Bootstrapped(); err != nil {
+		return nil, err
+	}
+
+	// Verification skips some of the checks if the chain is bootstrapping.
+	bootstrapped := &utils.Atomic[bool]{}
+	bootstrapped.Set(true)
+
+	return &Verifier{
+		fxVM: vm,
+		backend: &executor.Backend{
+			Config:       cfg,
+			Ctx:          ctx,
+			Clk:          &vm.clock,
+			Fx:           fx,
+			FlowChecker:  utxo.NewHandler(ctx, &vm.clock, fx),
+			Rewards:      reward.NewCalculator(cfg.RewardConfig),
+			Bootstrapped: bootstrapped,
+		},
+	}, nil
+}
+
+// Clock returns the clock used as the local time during verification.
+func (v *Verifier) Clock() *mockable.Clock {
+	return &v.fxVM.clock
+}
+
+// ParseBlock parses [blkBytes] into a block, performing the syntactic checks
+// of the block format.
+func ParseBlock(blkBytes []byte) (block.Block, error) {
+	return block.Parse(block.Codec, blkBytes)
+}
+
+// ParseTx parses [txBytes] into a tx, performing the syntactic checks of the
+// tx format.
+func ParseTx(txBytes []byte) (*txs.Tx, error) {
+	return txs.Parse(txs.Codec, txBytes)
+}
+
+// VerifyTx verifies that [tx] can be issued on top of [parentState]. The
+// returned diff contains the state changes performed by [tx]. If [tx] is a
+// proposal tx, the returned diff is the state if the proposal is committed.
+//
+// [parentState] is never modified.
+func (v *Verifier) VerifyTx(parentState state.Chain, tx *txs.Tx) (state.Diff, error) {
+	v.fxVM.chainTime = parentState.GetTimestamp()
+
+	onAcceptState, err := state.NewDiffOn(parentState)
+	if err != nil {
+		return nil, err
+	}
+
+	switch tx.Unsigned.(type) {
+	case *txs.AdvanceTimeTx, *txs.RewardValidatorTx:
+		onAbortState, err := state.NewDiffOn(parentState)
+		if err != nil {
+			return nil, err
+		}
+		err = tx.Unsigned.Visit(&executor.ProposalTxExecutor{
+			OnCommitState: onAcceptState,
+			OnAbortState:  onAbortState,
+			Backend:       v.backend,
+			Tx:            tx,
+		})
+		if err != nil {
+			return nil, err
+		}
+		onAcceptState.AddTx(tx, status.Committed)
+		return onAcceptState, nil
+	default:
+		if err := v.executeStandardTxs(onAcceptState, []*txs.Tx{tx}); err != nil {
+			return nil, err
+		}
+		return onAcceptState, nil
+	}
+}
+
+// VerifyBlock verifies that [blk] is a valid child of [parent], where
+// [parentState] is the state after [parent] was accepted. The returned diff
+// contains the state changes performed by [blk]. If [blk] is a proposal block,
+// the returned diff is the state if the proposal is committed.
+//
+// Only Banff standard and proposal blocks are supported, as the verification
+// of the other blocks depends on the processing of their parent.
+//
+// [parentState] is never modified.
+func (v *Verifier) VerifyBlock(
+	parentState state.Chain,
+	parent block.Block,
+	blk block.Block,
+) (state.Diff, error) {
+	v.fxVM.chainTime = parentState.GetTimestamp()
+
+	if parentID := parent.ID(); blk.Parent() != parentID {
+		return nil, fmt.Errorf("%w: expected %s, but found %s",
+			ErrWrongParent,
+			parentID,
+			blk.Parent(),
+		)
+	}
+	if expectedHeight := parent.Height() + 1; blk.Height() != expectedHeight {
+		return nil, fmt.Errorf("%w: expected %d, but found %d",
+			ErrIncorrectBlockHeight,
+			expectedHeight,
+			blk.Height(),
+		)
+	}
+
+	switch blk := blk.(type) {
+	case *block.BanffStandardBlock:
+		return v.verifyStandardBlock(parentState, blk)
+	case *block.BanffProposalBlock:
+		return v.verifyProposalBlock(parentState, blk)
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedBlock, blk)
+	}
+}
+
+func (v *Verifier) verifyStandardBlock(
+	parentState state.Chain,
+	blk *block.BanffStandardBlock,
+) (state.Diff, error) {
+	if err := v.verifyChainTime(parentState, blk.Timestamp()); err != nil {
+		return nil, err
+	}
+
+	onAcceptState, err := state.NewDiffOn(parentState)
+	if err != nil {
+		return nil, err
+	}
+
+	changed, err := executor.AdvanceTimeTo(v.backend, onAcceptState, blk.Timestamp())
+	if err != nil {
+		return nil, err
+	}
+	if !changed && len(blk.Transactions) == 0 {
+		return nil, ErrStandardBlockWithoutChanges
+	}
+
+	if err := v.executeStandardTxs(onAcceptState, blk.Transactions); err != nil {
+		return nil, err
+	}
+	return onAcceptState, nil
+}
+
+func (v *Verifier) verifyProposalBlock(
+	parentState state.Chain,
+	blk *block.BanffProposalBlock,
+) (state.Diff, error) {
+	nextChainTime := blk.Timestamp()
+	if !v.backend.Config.IsDurangoActivated(nextChainTime) && len(blk.Transactions) != 0 {
+		return nil, ErrProposalBlockWithMultipleTx
+	}
+	if err := v.verifyChainTime(parentState, nextChainTime); err != nil {
+		return nil, err
+	}
+
+	onDecisionState, err := state.NewDiffOn(parentState)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := executor.AdvanceTimeTo(v.backend, onDecisionState, nextChainTime); err != nil {
+		return nil, err
+	}
+	if err := v.executeStandardTxs(onDecisionState, blk.Transactions); err != nil {
+		return nil, err
+	}
+
+	onCommitState, err := state.NewDiffOn(onDecisionState)
+	if err != nil {
+		return nil, err
+	}
+	onAbortState, err := state.NewDiffOn(onDecisionState)
+	if err != nil {
+		return nil, err
+	}
+
+	err = blk.Tx.Unsigned.Visit(&executor.ProposalTxExecutor{
+		OnCommitState: onCommitState,
+		OnAbortState:  onAbortState,
+		Backend:       v.backend,
+		Tx:            blk.Tx,
+	})
+	if err != nil {
+		return nil, err
+	}
+	onCommitState.AddTx(blk.Tx, status.Committed)
+	return onCommitState, nil
+}
+
+func (v *Verifier) verifyChainTime(parentState state.Chain, newChainTime time.Time) error {
+	parentChainTime := parentState.GetTimestamp()
+	if newChainTime.Before(parentChainTime) {
+		return fmt.Errorf(
+			"%w: proposed timestamp (%s), chain time (%s)",
+			ErrChildBlockEarlierThanParent,
+			newChainTime,
+			parentChainTime,
+		)
+	}
+
+	nextStakerChangeTime, err := executor.GetNextStakerChangeTime(parentState)
+	if err != nil {
+		return fmt.Errorf("could not verify block timestamp: %w", err)
+	}
+	return executor.VerifyNewChainTime(
+		newChainTime,
+		nextStakerChangeTime,
+		v.backend.Clk.Time(),
+	)
+}
+
+// executeStandardTxs executes [txs] on [diff], ensuring that they don't consume
+// the same UTXOs.
+func (v *Verifier) executeStandardTxs(diff state.Diff, txs []*txs.Tx) error {
+	var inputs set.Set[ids.ID]
+	for _, tx := range txs {
+		txExecutor := executor.StandardTxExecutor{
+			Backend: v.backend,
+			State:   diff,
+			Tx:      tx,
+		}
+		if err := tx.Unsigned.Visit(&txExecutor); err != nil {
+			return fmt.Errorf("tx %s failed verification: %w", tx.ID(), err)
+		}
+		if inputs.Overlaps(txExecutor.Inputs) {
+			return blockexecutor.ErrConflictingBlockTxs
+		}
+		inputs.Union(txExecutor.Inputs)
+
+		diff.AddTx(tx, status.Committed)
+	}
+	return nil
+}
+
+// fxVM provides the secp256k1fx with the environment of the verified chain.
+type fxVM struct {
+	codec     codec.Registry
+	clock     mockable.Clock
+	log       logging.Logger
+	banffTime time.Time
+
+	// chainTime is the timestamp of the state being verified against.
+	chainTime time.Time
+}
+
+func (vm *fxVM) CodecRegistry() codec.Registry {
+	return vm.codec
+}
+
+func (vm *fxVM) Clock() *mockable.Clock {
+	return &vm.clock
+}
+
+func (vm *fxVM) Logger() logging.Logger {
+	return vm.log
+}
+
+func (vm *fxVM) EthVerificationEnabled() bool {
+	return !vm.chainTime.Before(vm.banffTime)
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+)
+
+var (
+	errUnknownProfile         = errors.New("unknown profile")
+	errProfileNetworkMismatch = errors.New("profile is for another network")
+
+	// profileNetworkIDs are the networks of the profiles that may be selected
+	// with the chain config
+	profileNetworkIDs = map[string]uint32{
+		"flare":      constants.FlareID,
+		"songbird":   constants.SongbirdID,
+		"coston":     constants.CostonID,
+		"coston2":    constants.CostwoID,
+		"localflare": constants.LocalFlareID,
+	}
+)
+
+// applyProfile sets the network upgrades, the staking parameters, the reward
+// config and the tx fees of the network of the profile [name] in [cfg], so
+// that they can't be set piecemeal to values of different networks. The
+// profile must be for [networkID], the network the chain runs on.
+//
+// Returns the names of the parameters that were set by the node to values
+// other than those of the profile, and that the profile overrode.
+func applyProfile(cfg *config.Config, name string, networkID uint32) ([]string, error) {
+	profileNetworkID, ok := profileNetworkIDs[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", errUnknownProfile, name)
+	}
+	if profileNetworkID != networkID {
+		return nil, fmt.Errorf("%w: %q is for network %d, not %d",
+			errProfileNetworkMismatch,
+			name,
+			profileNetworkID,
+			networkID,
+		)
+	}
+
+	nodeCfg := *cfg
+	cfg.SetNetworkUpgrades(networkID)
+
+	stakingConfig := genesis.GetStakingConfig(networkID)
+	cfg.UptimePercentage = stakingConfig.UptimeRequirement
+	cfg.MinValidatorStake = stakingConfig.MinValidatorStake
+	cfg.MaxValidatorStake = stakingConfig.MaxValidatorStake
+	cfg.MinDelegatorStake = stakingConfig.MinDelegatorStake
+	cfg.MinDelegationFee = stakingConfig.MinDelegationFee
+	cfg.MinStakeDuration = stakingConfig.MinStakeDuration
+	cfg.MaxStakeDuration = stakingConfig.MaxStakeDuration
+	cfg.RewardConfig = stakingConfig.RewardConfig
+
+	txFeeConfig := genesis.GetTxFeeConfig(networkID)
+	cfg.TxFee = txFeeConfig.TxFee
+	cfg.CreateAssetTxFee = txFeeConfig.CreateAssetTxFee
+	cfg.CreateSubnetTxFee = txFeeConfig.CreateSubnetTxFee
+	cfg.TransformSubnetTxFee = txFeeConfig.TransformSubnetTxFee
+	cfg.CreateBlockchainTxFee = txFeeConfig.CreateBlockchainTxFee
+	cfg.AddPrimaryNetworkValidatorFee = txFeeConfig.AddPrimaryNetworkValidatorFee
+	cfg.AddPrimaryNetworkDelegatorFee = txFeeConfig.AddPrimaryNetworkDelegatorFee
+	cfg.AddSubnetValidatorFee = txFeeConfig.AddSubnetValidatorFee
+	cfg.AddSubnetDelegatorFee = txFeeConfig.AddSubnetDelegatorFee
+	return profileOverrides(&nodeCfg, cfg), nil
+}
+
+// profileOverrides returns the names of the node parameters that differ
+// between [nodeCfg] and [profileCfg].
+func profileOverrides(nodeCfg, profileCfg *config.Config) []string {
+	params := []struct {
+		name       string
+		overridden bool
+	}{
+		{name: "uptimeRequirement", overridden: nodeCfg.UptimePercentage != profileCfg.UptimePercentage},
+		{name: "minValidatorStake", overridden: nodeCfg.MinValidatorStake != profileCfg.MinValidatorStake},
+		{name: "maxValidatorStake", overridden: nodeCfg.MaxValidatorStake != profileCfg.MaxValidatorStake},
+		{name: "minDelegatorStake", overridden: nodeCfg.MinDelegatorStake != profileCfg.MinDelegatorStake},
+		{name: "minDelegationFee", overridden: nodeCfg.MinDelegationFee != profileCfg.MinDelegationFee},
+		{name: "minStakeDuration", overridden: nodeCfg.MinStakeDuration != profileCfg.MinStakeDuration},
+		{name: "maxStakeDuration", overridden: nodeCfg.MaxStakeDuration != profileCfg.MaxStakeDuration},
+		{name: "rewardConfig", overridden: nodeCfg.RewardConfig != profileCfg.RewardConfig},
+		{name: "txFee", overridden: nodeCfg.TxFee != profileCfg.TxFee},
+		{name: "createAssetTxFee", overridden: nodeCfg.CreateAssetTxFee != profileCfg.CreateAssetTxFee},
+		{name: "createSubnetTxFee", overridden: nodeCfg.CreateSubnetTxFee != profileCfg.CreateSubnetTxFee},
+		{name: "transformSubnetTxFee", overridden: nodeCfg.TransformSubnetTxFee != profileCfg.TransformSubnetTxFee},
+		{name: "createBlockchainTxFee", overridden: nodeCfg.CreateBlockchainTxFee != profileCfg.CreateBlockchainTxFee},
+		{name: "addPrimaryNetworkValidatorFee", overridden: nodeCfg.AddPrimaryNetworkValidatorFee != profileCfg.AddPrimaryNetworkValidatorFee},
+		{name: "addPrimaryNetworkDelegatorFee", overridden: nodeCfg.AddPrimaryNetworkDelegatorFee != profileCfg.AddPrimaryNetworkDelegatorFee},
+		{name: "addSubnetValidatorFee", overridden: nodeCfg.AddSubnetValidatorFee != profileCfg.AddSubnetValidatorFee},
+		{name: "addSubnetDelegatorFee", overridden: nodeCfg.AddSubnetDelegatorFee != profileCfg.AddSubnetDelegatorFee},
+	}
+
+	var overrides []string
+	for _, param := range params {
+		if param.overridden {
+			overrides = append(overrides, param.name)
+		}
+	}
+	return overrides
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+)
+
+func TestApplyProfile(t *testing.T) {
+	tests := []struct {
+		name              string
+		profile           string
+		networkID         uint32
+		setNodeFlags      func(*config.Config)
+		expectedOverrides []string
+		expectedErr       error
+	}{
+		{
+			name:      "flare",
+			profile:   "flare",
+			networkID: constants.FlareID,
+		},
+		{
+			name:      "localflare with node flags",
+			profile:   "localflare",
+			networkID: constants.LocalFlareID,
+			setNodeFlags: func(cfg *config.Config) {
+				cfg.MinValidatorStake++
+				cfg.TxFee++
+			},
+			expectedOverrides: []string{
+				"minValidatorStake",
+				"txFee",
+			},
+		},
+		{
+			name:        "unknown profile",
+			profile:     "mainnet",
+			networkID:   constants.MainnetID,
+			expectedErr: errUnknownProfile,
+		},
+		{
+			name:        "other network",
+			profile:     "songbird",
+			networkID:   constants.FlareID,
+			expectedErr: errProfileNetworkMismatch,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			nodeCfg := networkNodeConfig(test.networkID)
+			if test.setNodeFlags != nil {
+				test.setNodeFlags(&nodeCfg)
+			}
+			cfg := nodeCfg
+			overrides, err := applyProfile(&cfg, test.profile, test.networkID)
+			require.ErrorIs(err, test.expectedErr)
+			if err != nil {
+				require.Equal(nodeCfg, cfg)
+				return
+			}
+			require.Equal(test.expectedOverrides, overrides)
+
+			stakingConfig := genesis.GetStakingConfig(test.networkID)
+			require.Equal(version.GetDurangoTime(test.networkID), cfg.DurangoTime)
+			require.Equal(version.GetNameRegistryTime(test.networkID), cfg.NameRegistryTime)
+			require.Equal(version.GetNameRegistrationFee(test.networkID), cfg.NameRegistrationFee)
+			require.Equal(version.GetRewardExportTime(test.networkID), cfg.RewardExportTime)
+			require.Equal(version.GetFeeTreasury(test.networkID).Time, cfg.FeeTreasuryTime)
+			require.Equal(version.GetBlockComplexityLimit(test.networkID).MaxComplexity, cfg.MaxBlockComplexity)
+			require.Equal(stakingConfig.MinValidatorStake, cfg.MinValidatorStake)
+			require.Equal(stakingConfig.RewardConfig, cfg.RewardConfig)
+			require.Equal(genesis.GetTxFeeConfig(test.networkID).TxFee, cfg.TxFee)
+		})
+	}
+}
+
+// networkNodeConfig returns the config that a node sets when its staking and
+// fee flags are left to the defaults of [networkID].
+func networkNodeConfig(networkID uint32) config.Config {
+	stakingConfig := genesis.GetStakingConfig(networkID)
+	txFeeConfig := genesis.GetTxFeeConfig(networkID)
+	return config.Config{
+		UptimePercentage:              stakingConfig.UptimeRequirement,
+		MinValidatorStake:             stakingConfig.MinValidatorStake,
+		MaxValidatorStake:             stakingConfig.MaxValidatorStake,
+		MinDelegatorStake:             stakingConfig.MinDelegatorStake,
+		MinDelegationFee:              stakingConfig.MinDelegationFee,
+		MinStakeDuration:              stakingConfig.MinStakeDuration,
+		MaxStakeDuration:              stakingConfig.MaxStakeDuration,
+		RewardConfig:                  stakingConfig.RewardConfig,
+		TxFee:                         txFeeConfig.TxFee,
+		CreateAssetTxFee:              txFeeConfig.CreateAssetTxFee,
+		CreateSubnetTxFee:             txFeeConfig.CreateSubnetTxFee,
+		TransformSubnetTxFee:          txFeeConfig.TransformSubnetTxFee,
+		CreateBlockchainTxFee:         txFeeConfig.CreateBlockchainTxFee,
+		AddPrimaryNetworkValidatorFee: txFeeConfig.AddPrimaryNetworkValidatorFee,
+		AddPrimaryNetworkDelegatorFee: txFeeConfig.AddPrimaryNetworkDelegatorFee,
+		AddSubnetValidatorFee:         txFeeConfig.AddSubnetValidatorFee,
+		AddSubnetDelegatorFee:         txFeeConfig.AddSubnetDelegatorFee,
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/vms/platformvm/network"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+// readReplicaRetryDelay is how long a read replica waits before reconnecting
+// to its primary node.
+const readReplicaRetryDelay = 5 * time.Second
+
+var (
+	_ network.TxVerifier = readReplicaTxVerifier{}
+
+	errReadReplica          = errors.New("txs can't be issued to a read replica")
+	errUnexpectedStatusCode = errors.New("unexpected status code")
+	errUnexpectedBlockID    = errors.New("unexpected block ID")
+)
+
+// readReplicaTxVerifier rejects all txs, so that a read replica neither
+// accepts txs from the APIs nor from gossip. The txs are rejected by policy,
+// so the peers gossiping them aren't penalized.
+type readReplicaTxVerifier struct{}
+
+func (readReplicaTxVerifier) VerifyTx(*txs.Tx) error {
+	return fmt.Errorf("%w: %w", network.ErrTxRejectedByPolicy, errReadReplica)
+}
+
+// readReplica keeps the state of a VM in sync with a primary node by
+// following the blocks it accepted. The blocks are verified before they are
+// accepted, but the primary node is trusted to stream the blocks the network
+// accepted.
+type readReplica struct {
+	vm *VM
+	// URI of the P-chain API of the primary node
+	primaryURI string
+	client     *http.Client
+}
+
+// Run follows the primary node until [ctx] is cancelled, reconnecting to it
+// whenever the stream of accepted blocks fails.
+func (r *readReplica) Run(ctx context.Context) {
+	for {
+		err := r.follow(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		r.vm.ctx.Log.Warn("failed to follow the primary node",
+			zap.String("primaryURI", r.primaryURI),
+			zap.Error(err),
+		)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(readReplicaRetryDelay):
+		}
+	}
+}
+
+// follow accepts the blocks streamed by the primary node, starting after the
+// last accepted block.
+func (r *readReplica) follow(ctx context.Context) error {
+	height, err := r.vm.lastAcceptedHeight(ctx)
+	if err != nil {
+		return err
+	}
+
+	uri := fmt.Sprintf("%s/acceptedBlocks?startHeight=%d", r.primaryURI, height+1)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s", errUnexpectedStatusCode, resp.Status)
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var apiBlk APIAcceptedBlock
+		if err := decoder.Decode(&apiBlk); err != nil {
+			return err
+		}
+		if err := r.accept(ctx, &apiBlk); err != nil {
+			return err
+		}
+	}
+}
+
+func (r *readReplica) accept(ctx context.Context, apiBlk *APIAcceptedBlock) error {
+	blkBytes, err := formatting.Decode(formatting.Hex, apiBlk.Block)
+	if err != nil {
+		return fmt.Errorf("couldn't decode block at height %d: %w", apiBlk.Height, err)
+	}
+
+	r.vm.ctx.Lock.Lock()
+	defer r.vm.ctx.Lock.Unlock()
+
+	blk, err := r.vm.manager.ParseBlock(blkBytes)
+	if err != nil {
+		return fmt.Errorf("couldn't parse block at height %d: %w", apiBlk.Height, err)
+	}
+	blkID := blk.ID()
+	if blkID != apiBlk.BlockID {
+		return fmt.Errorf("%w: expected %s but got %s", errUnexpectedBlockID, apiBlk.BlockID, blkID)
+	}
+
+	lastAcceptedHeight, err := r.vm.GetCurrentHeight(ctx)
+	if err != nil {
+		return err
+	}
+	if blk.Height() <= lastAcceptedHeight {
+		// The block was already accepted, possibly through consensus.
+		return nil
+	}
+
+	// A proposal block is only committed once its option is accepted, so a
+	// proposal block streamed again after reconnecting is verified and
+	// accepted again.
+	if err := blk.Verify(ctx); err != nil {
+		return fmt.Errorf("couldn't verify block %s: %w", blkID, err)
+	}
+	if err := blk.Accept(ctx); err != nil {
+		return fmt.Errorf("couldn't accept block %s: %w", blkID, err)
+	}
+	return r.vm.SetPreference(ctx, r.vm.manager.LastAccepted())
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/network"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestReadReplica(t *testing.T) {
+	require := require.New(t)
+	primary, _, _ := defaultVM(t, latestFork)
+	replica, _, _ := defaultVM(t, latestFork)
+
+	primary.ctx.Lock.Lock()
+	tx, err := primary.txBuilder.NewCreateSubnetTx(
+		1,
+		[]ids.ShortID{keys[0].PublicKey().Address()},
+		secp256k1fx.NewKeychain(keys[0]),
+		keys[0].PublicKey().Address(), // change addr
+		nil,
+	)
+	require.NoError(err)
+	primary.ctx.Lock.Unlock()
+	require.NoError(primary.issueTx(context.Background(), tx))
+	primary.ctx.Lock.Lock()
+	blk, err := primary.Builder.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(blk.Accept(context.Background()))
+	require.NoError(primary.SetPreference(context.Background(), blk.ID()))
+	primary.ctx.Lock.Unlock()
+
+	mux := http.NewServeMux()
+	mux.Handle("/acceptedBlocks", &acceptedBlockStream{vm: primary})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	follower := &readReplica{
+		vm:         replica,
+		primaryURI: server.URL,
+		client:     server.Client(),
+	}
+	go func() {
+		defer close(done)
+		follower.Run(ctx)
+	}()
+
+	// The replica accepts the block accepted by the primary node.
+	require.Eventually(func() bool {
+		replica.ctx.Lock.Lock()
+		defer replica.ctx.Lock.Unlock()
+
+		return replica.manager.LastAccepted() == blk.ID()
+	}, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	<-done
+
+	replica.ctx.Lock.Lock()
+	defer replica.ctx.Lock.Unlock()
+
+	_, txStatus, err := replica.state.GetTx(tx.ID())
+	require.NoError(err)
+	require.Equal(status.Committed, txStatus)
+
+	// Read replicas reject txs without penalizing the peers gossiping them.
+	err = readReplicaTxVerifier{}.VerifyTx(tx)
+	require.ErrorIs(err, errReadReplica)
+	require.ErrorIs(err, network.ErrTxRejectedByPolicy)
+}
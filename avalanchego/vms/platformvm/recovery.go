@@ -0,0 +1,162 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+)
+
+// recoveryMessagePrefix is prepended to the ID of a recovery block before it
+// is signed, so that a recovery signature can't be mistaken for a signature of
+// anything else.
+const recoveryMessagePrefix = "\x1AAvalanche Recovery Block:\n"
+
+var (
+	errRecoveryDisabled            = errors.New("no recovery keys are configured")
+	errInvalidRecoveryThreshold    = errors.New("recovery threshold must be between 1 and the number of recovery keys")
+	errUnknownRecoverySigner       = errors.New("signer isn't a recovery key")
+	errDuplicateRecoverySigner     = errors.New("duplicate recovery signer")
+	errInsufficientRecoverySigners = errors.New("not enough recovery keys signed the block")
+	errRecoveryBlockNotChild       = errors.New("recovery block isn't a child of the last accepted block")
+	errRecoveryProposalBlock       = errors.New("proposal blocks can't be force accepted")
+	errRecoveryConflictingBlock    = errors.New("a block conflicting with the recovery block is processing")
+)
+
+// recoveryKeys is the set of keys that can jointly authorize a block to be
+// force accepted, bypassing consensus, to recover a halted network.
+type recoveryKeys struct {
+	addrs     set.Set[ids.ShortID]
+	threshold int
+}
+
+// newRecoveryKeys parses the recovery keys from the execution config. Nil is
+// returned if no recovery keys are configured.
+func newRecoveryKeys(
+	addrManager avax.AddressManager,
+	addrStrs []string,
+	threshold int,
+) (*recoveryKeys, error) {
+	if len(addrStrs) == 0 {
+		return nil, nil
+	}
+
+	addrs, err := avax.ParseServiceAddresses(addrManager, addrStrs)
+	if err != nil {
+		return nil, err
+	}
+	if threshold < 1 || threshold > addrs.Len() {
+		return nil, fmt.Errorf("%w: threshold %d, %d keys", errInvalidRecoveryThreshold, threshold, addrs.Len())
+	}
+	return &recoveryKeys{
+		addrs:     addrs,
+		threshold: threshold,
+	}, nil
+}
+
+// recoveryMessageHash returns the hash that is signed by the recovery keys to
+// authorize the block [blkID].
+func recoveryMessageHash(blkID ids.ID) []byte {
+	msg := make([]byte, 0, len(recoveryMessagePrefix)+ids.IDLen)
+	msg = append(msg, recoveryMessagePrefix...)
+	msg = append(msg, blkID[:]...)
+	return hashing.ComputeHash256(msg)
+}
+
+// verify that [sigs] were made by at least [threshold] distinct recovery keys
+// over the block [blkID].
+func (k *recoveryKeys) verify(blkID ids.ID, sigs [][]byte) error {
+	if k == nil {
+		return errRecoveryDisabled
+	}
+
+	var (
+		hash    = recoveryMessageHash(blkID)
+		signers = set.NewSet[ids.ShortID](len(sigs))
+	)
+	for i, sig := range sigs {
+		pk, err := secp256k1.RecoverPublicKeyFromHash(hash, sig)
+		if err != nil {
+			return fmt.Errorf("couldn't recover signer of signature %d: %w", i, err)
+		}
+		signer := pk.Address()
+		if !k.addrs.Contains(signer) {
+			return fmt.Errorf("%w: %s", errUnknownRecoverySigner, signer)
+		}
+		if signers.Contains(signer) {
+			return fmt.Errorf("%w: %s", errDuplicateRecoverySigner, signer)
+		}
+		signers.Add(signer)
+	}
+	if signers.Len() < k.threshold {
+		return fmt.Errorf("%w: %d < %d", errInsufficientRecoverySigners, signers.Len(), k.threshold)
+	}
+	return nil
+}
+
+// forceAcceptBlock verifies and accepts the block [blkBytes] without running
+// consensus on it, if it is authorized by [sigs] of the recovery keys.
+//
+// The block must be a child of the last accepted block and pass the usual
+// block verification. Proposal blocks are rejected, as accepting them would
+// require an option to be force accepted as well.
+//
+// The block is refused while another child of the last accepted block is
+// processing, as the consensus engine could still accept the conflicting
+// block. Restarting the node drops the processing blocks.
+//
+// The consensus engine isn't notified of the accepted block, so the node
+// should be restarted after the network was recovered.
+//
+// Invariant: the context lock is held.
+func (vm *VM) forceAcceptBlock(ctx context.Context, blkBytes []byte, sigs [][]byte) (block.Block, error) {
+	statelessBlk, err := block.Parse(block.Codec, blkBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	blkID := statelessBlk.ID()
+	if err := vm.recoveryKeys.verify(blkID, sigs); err != nil {
+		return nil, err
+	}
+
+	switch statelessBlk.(type) {
+	case *block.ApricotProposalBlock, *block.BanffProposalBlock:
+		return nil, errRecoveryProposalBlock
+	}
+	lastAcceptedID := vm.manager.LastAccepted()
+	if statelessBlk.Parent() != lastAcceptedID {
+		return nil, fmt.Errorf("%w: parent %s, last accepted %s", errRecoveryBlockNotChild, statelessBlk.Parent(), lastAcceptedID)
+	}
+	for _, siblingID := range vm.manager.ProcessingChildren(lastAcceptedID) {
+		if siblingID != blkID {
+			return nil, fmt.Errorf("%w: %s", errRecoveryConflictingBlock, siblingID)
+		}
+	}
+
+	blk := vm.manager.NewBlock(statelessBlk)
+	if err := blk.Verify(ctx); err != nil {
+		return nil, fmt.Errorf("failed to verify recovery block %s: %w", blkID, err)
+	}
+
+	vm.ctx.Log.Warn("force accepting recovery block",
+		zap.Stringer("blkID", blkID),
+		zap.Uint64("height", statelessBlk.Height()),
+		zap.Int("numSignatures", len(sigs)),
+	)
+	if err := blk.Accept(ctx); err != nil {
+		return nil, fmt.Errorf("failed to accept recovery block %s: %w", blkID, err)
+	}
+	return statelessBlk, vm.SetPreference(ctx, blkID)
+}
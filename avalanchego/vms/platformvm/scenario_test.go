@@ -0,0 +1,302 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	blockbuilder "github.com/ava-labs/avalanchego/vms/platformvm/block/builder"
+)
+
+// scenario is a declarative staking test. Its steps are run in order against a
+// fresh VM. The times of the steps are offsets from the time of the VM clock
+// when the scenario starts.
+type scenario struct {
+	name  string
+	fork  fork
+	steps []scenarioStep
+}
+
+type scenarioStep interface {
+	run(r *scenarioRunner)
+}
+
+type scenarioRunner struct {
+	require *require.Assertions
+	vm      *VM
+	start   time.Time
+	// Node IDs of the validators added by the scenario, by name
+	nodeIDs map[string]ids.NodeID
+	// IDs of the txs that added the stakers of the scenario, by name
+	txIDs map[string]ids.ID
+}
+
+func runScenarios(t *testing.T, scenarios []scenario) {
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			vm, _, _ := defaultVM(t, s.fork)
+			vm.ctx.Lock.Lock()
+			defer vm.ctx.Lock.Unlock()
+
+			r := &scenarioRunner{
+				require: require.New(t),
+				vm:      vm,
+				start:   vm.clock.Time(),
+				nodeIDs: make(map[string]ids.NodeID),
+				txIDs:   make(map[string]ids.ID),
+			}
+			for i, step := range s.steps {
+				t.Logf("step %d: %T%+v", i, step, step)
+				step.run(r)
+			}
+		})
+	}
+}
+
+func (r *scenarioRunner) unix(offset time.Duration) uint64 {
+	return uint64(r.start.Add(offset).Unix())
+}
+
+// issue [tx], that adds the staker named [name], and build and accept the
+// block that includes it. If [expectedErr] isn't nil, [tx] must be rejected
+// with it instead.
+//
+// Invariant: the context lock is held.
+func (r *scenarioRunner) issue(name string, tx *txs.Tx, expectedErr error) {
+	r.vm.ctx.Lock.Unlock()
+	err := r.vm.issueTx(context.Background(), tx)
+	r.vm.ctx.Lock.Lock()
+
+	r.require.ErrorIs(err, expectedErr)
+	if expectedErr != nil {
+		return
+	}
+	r.txIDs[name] = tx.ID()
+
+	buildAndAccept(r.require, r.vm)
+}
+
+// addValidator adds a primary network validator, named [name], that stakes
+// [weight] from [start] to [end].
+type addValidator struct {
+	name       string
+	start, end time.Duration
+	weight     uint64
+	shares     uint32
+	err        error
+}
+
+func (s addValidator) run(r *scenarioRunner) {
+	nodeID := ids.GenerateTestNodeID()
+	r.nodeIDs[s.name] = nodeID
+	tx, err := r.vm.txBuilder.NewAddValidatorTx(
+		s.weight,
+		r.unix(s.start),
+		r.unix(s.end),
+		nodeID,
+		keys[0].Address(),
+		s.shares,
+		secp256k1fx.NewKeychain(keys...),
+		keys[0].Address(),
+		nil,
+	)
+	r.require.NoError(err)
+	r.issue(s.name, tx, s.err)
+}
+
+// addDelegator adds a delegator, named [name], that delegates [weight] to the
+// validator named [validator] from [start] to [end].
+type addDelegator struct {
+	name       string
+	validator  string
+	start, end time.Duration
+	weight     uint64
+	err        error
+}
+
+func (s addDelegator) run(r *scenarioRunner) {
+	nodeID, ok := r.nodeIDs[s.validator]
+	r.require.True(ok, "unknown validator %q", s.validator)
+	tx, err := r.vm.txBuilder.NewAddDelegatorTx(
+		s.weight,
+		r.unix(s.start),
+		r.unix(s.end),
+		nodeID,
+		keys[0].Address(),
+		secp256k1fx.NewKeychain(keys...),
+		keys[0].Address(),
+		nil,
+	)
+	r.require.NoError(err)
+	r.issue(s.name, tx, s.err)
+}
+
+// advanceTo sets the clock to [at] and accepts the blocks that are due by
+// then, such as the blocks that start and reward stakers. Proposal blocks are
+// always committed.
+type advanceTo struct {
+	at time.Duration
+}
+
+func (s advanceTo) run(r *scenarioRunner) {
+	r.vm.clock.Set(r.start.Add(s.at))
+	for {
+		blk, err := r.vm.Builder.BuildBlock(context.Background())
+		if errors.Is(err, blockbuilder.ErrNoPendingBlocks) {
+			return
+		}
+		r.require.NoError(err)
+		accept(r.require, r.vm, blk)
+	}
+}
+
+type stakerStatus uint8
+
+const (
+	stakerRemoved stakerStatus = iota
+	stakerPending
+	stakerCurrent
+)
+
+// expectValidator asserts the status of the validator named [name].
+type expectValidator struct {
+	name   string
+	status stakerStatus
+}
+
+func (s expectValidator) run(r *scenarioRunner) {
+	nodeID, ok := r.nodeIDs[s.name]
+	r.require.True(ok, "unknown validator %q", s.name)
+
+	_, err := r.vm.state.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+	isCurrent := err == nil
+	if err != nil {
+		r.require.ErrorIs(err, database.ErrNotFound)
+	}
+	_, err = r.vm.state.GetPendingValidator(constants.PrimaryNetworkID, nodeID)
+	isPending := err == nil
+	if err != nil {
+		r.require.ErrorIs(err, database.ErrNotFound)
+	}
+
+	r.require.Equal(s.status == stakerCurrent, isCurrent, "current validator %q", s.name)
+	r.require.Equal(s.status == stakerPending, isPending, "pending validator %q", s.name)
+}
+
+// expectReward asserts that the staker named [name] was paid [amount] in
+// rewards.
+type expectReward struct {
+	name   string
+	amount uint64
+}
+
+func (s expectReward) run(r *scenarioRunner) {
+	txID, ok := r.txIDs[s.name]
+	r.require.True(ok, "unknown staker %q", s.name)
+
+	utxos, err := r.vm.state.GetRewardUTXOs(txID)
+	r.require.NoError(err)
+	var amount uint64
+	for _, utxo := range utxos {
+		out, ok := utxo.Out.(avax.TransferableOut)
+		r.require.True(ok, "unexpected reward output %T", utxo.Out)
+		amount += out.Amount()
+	}
+	r.require.Equal(s.amount, amount, fmt.Sprintf("reward of %q", s.name))
+}
+
+func TestStakingScenarios(t *testing.T) {
+	var (
+		validatorStart      = executor.SyncBound + time.Second
+		firstDelegatorStart = validatorStart + executor.SyncBound + time.Second
+		firstDelegatorEnd   = firstDelegatorStart + defaultMinStakingDuration
+	)
+	runScenarios(t, []scenario{
+		{
+			// See TestAddDelegatorTxOverDelegatedRegression
+			name: "over delegation",
+			fork: cortina,
+			steps: []scenarioStep{
+				addValidator{
+					name:   "validator",
+					start:  validatorStart,
+					end:    validatorStart + 360*24*time.Hour,
+					weight: defaultMinValidatorStake,
+					shares: reward.PercentDenominator,
+				},
+				advanceTo{at: validatorStart},
+				expectValidator{name: "validator", status: stakerCurrent},
+				addDelegator{
+					name:      "first",
+					validator: "validator",
+					start:     firstDelegatorStart,
+					end:       firstDelegatorEnd,
+					weight:    4 * defaultMinValidatorStake,
+				},
+				advanceTo{at: firstDelegatorStart},
+				advanceTo{at: firstDelegatorEnd + 2*time.Second - 10*executor.SyncBound},
+				addDelegator{
+					name:      "second",
+					validator: "validator",
+					start:     firstDelegatorEnd + 2*time.Second,
+					end:       firstDelegatorEnd + 2*time.Second + defaultMinStakingDuration,
+					weight:    defaultMinDelegatorStake,
+				},
+				addDelegator{
+					name:      "third",
+					validator: "validator",
+					start:     firstDelegatorEnd - time.Second,
+					end:       firstDelegatorEnd - time.Second + defaultMinStakingDuration,
+					weight:    defaultMinDelegatorStake,
+					err:       executor.ErrOverDelegated,
+				},
+			},
+		},
+		{
+			// Staking rewards aren't paid on the P-chain, so stakers are
+			// removed without reward UTXOs.
+			name: "staker lifecycle",
+			fork: cortina,
+			steps: []scenarioStep{
+				addValidator{
+					name:   "validator",
+					start:  validatorStart,
+					end:    validatorStart + 2*defaultMinStakingDuration,
+					weight: defaultMinValidatorStake,
+					shares: reward.PercentDenominator / 2,
+				},
+				expectValidator{name: "validator", status: stakerPending},
+				advanceTo{at: validatorStart},
+				addDelegator{
+					name:      "delegator",
+					validator: "validator",
+					start:     firstDelegatorStart,
+					end:       firstDelegatorEnd,
+					weight:    defaultMinValidatorStake,
+				},
+				advanceTo{at: firstDelegatorEnd},
+				expectValidator{name: "validator", status: stakerCurrent},
+				expectReward{name: "delegator", amount: 0},
+				advanceTo{at: validatorStart + 2*defaultMinStakingDuration},
+				expectValidator{name: "validator", status: stakerRemoved},
+				expectReward{name: "validator", amount: 0},
+			},
+		},
+	})
+}
@@ -4,17 +4,22 @@
 package platformvm
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"maps"
 	"math"
 	"net/http"
 	"os"
+	"reflect"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/crypto"
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanchego/api"
@@ -27,10 +32,16 @@ import (
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/keystore"
+	"github.com/ava-labs/avalanchego/vms/platformvm/alerts"
+	"github.com/ava-labs/avalanchego/vms/platformvm/attestation"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
 	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
@@ -40,6 +51,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/builder"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/mempool"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	avajson "github.com/ava-labs/avalanchego/utils/json"
@@ -58,6 +70,22 @@ const (
 	// API
 	minAddStakerDelay = 2 * executor.SyncBound
 
+	// Max number of days that can be simulated by SimulateChainTime
+	maxSimulatedDays = 366
+
+	// Max number of staker changes that can be simulated by SimulateChainTime
+	maxSimulatedStakerChanges = 100_000
+
+	// Max number of blocks whose fees can be reported by a single call to
+	// getFeesBurned
+	maxFeeReportBlocks = 4096
+
+	// Max size of the validator metadata fetched by GetValidatorMetadata
+	maxValidatorMetadataSize = 64 * 1024
+
+	// Max duration of fetching the validator metadata
+	validatorMetadataFetchTimeout = 10 * time.Second
+
 	// Note: Staker attributes cache should be large enough so that no evictions
 	// happen when the API loops through all stakers.
 	stakerAttributesCacheSize = 100_000
@@ -81,6 +109,24 @@ var (
 	errMissingPrivateKey          = errors.New("argument 'privateKey' not given")
 	errStartAfterEndTime          = errors.New("start time must be before end time")
 	errStartTimeInThePast         = errors.New("start time in the past")
+	errUnknownGraphFormat         = errors.New("argument 'format' must be either \"json\" or \"dot\"")
+	errVerificationTracingOff     = errors.New("verification tracing is disabled")
+	errBlockPrevalidationOff      = errors.New("block prevalidation is disabled")
+	errParentNotPreferred         = errors.New("parent isn't the preferred block")
+	errNotImportTx                = errors.New("tx is not an ImportTx")
+	errInvalidFeeReportRange      = fmt.Errorf("argument 'endHeight' must not be before 'startHeight' nor cover more than %d blocks", maxFeeReportBlocks)
+	errInvalidRewardHistoryRange  = errors.New("argument 'toHeight' must not be before 'fromHeight'")
+	errInvalidSimulatedDays       = fmt.Errorf("argument 'days' must be between 1 and %d", maxSimulatedDays)
+	errDelegationTooSmall         = errors.New("argument 'amount' is below the minimum delegator stake")
+	errInvalidDelegationDuration  = errors.New("argument 'duration' is outside of the allowed delegation durations")
+	errHeartbeatsDisabled         = errors.New("heartbeats are disabled")
+	errNoValidatorMetadata        = errors.New("validator didn't commit to metadata")
+	errValidatorMetadataTooLarge  = fmt.Errorf("validator metadata exceeds %d bytes", maxValidatorMetadataSize)
+	errTxRootMismatch             = errors.New("tx root doesn't match the block")
+	errStakeCompositionHeight     = errors.New("stake composition is only available at the last accepted height")
+	errNoAddressToConvert         = errors.New("argument 'address' or 'publicKey' must be given")
+	errPublicKeyAddressMismatch   = errors.New("address isn't derived from the public key")
+	errUnexpectedHRP              = errors.New("unexpected hrp")
 
 	completeGetValidators = false
 )
@@ -96,6 +142,8 @@ type Service struct {
 	vm                    *VM
 	addrManager           avax.AddressManager
 	stakerAttributesCache *cache.LRU[ids.ID, *stakerAttributes]
+	addressAllowLists     addressAllowLists
+	issueTxQuotas         *ipQuotas
 }
 
 // All attributes are optional and may not be filled for each stakerTx.
@@ -231,7 +279,7 @@ type GetBalanceResponse struct {
 }
 
 // GetBalance gets the balance of an address
-func (s *Service) GetBalance(_ *http.Request, args *GetBalanceRequest, response *GetBalanceResponse) error {
+func (s *Service) GetBalance(r *http.Request, args *GetBalanceRequest, response *GetBalanceResponse) error {
 	s.vm.ctx.Log.Debug("deprecated API called",
 		zap.String("service", "platform"),
 		zap.String("method", "getBalance"),
@@ -242,6 +290,9 @@ func (s *Service) GetBalance(_ *http.Request, args *GetBalanceRequest, response
 	if err != nil {
 		return err
 	}
+	if err := s.addressAllowLists.authorize(r, addrs); err != nil {
+		return err
+	}
 
 	s.vm.ctx.Lock.Lock()
 	defer s.vm.ctx.Lock.Unlock()
@@ -412,6 +463,236 @@ func (s *Service) ListAddresses(_ *http.Request, args *api.UserPass, response *a
 	return user.Close()
 }
 
+// SetAddressLabelArgs are the arguments for calling SetAddressLabel
+type SetAddressLabelArgs struct {
+	api.UserPass
+	Address string `json:"address"`
+	// Label to attach to [Address]. An empty label removes the label of
+	// [Address].
+	Label string `json:"label"`
+}
+
+// SetAddressLabel attaches a label to an address in the keystore of
+// [args.Username]. The address doesn't need to be controlled by the user.
+func (s *Service) SetAddressLabel(_ *http.Request, args *SetAddressLabelArgs, _ *api.EmptyReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "setAddressLabel"),
+		logging.UserString("username", args.Username),
+	)
+
+	addr, err := avax.ParseServiceAddress(s.addrManager, args.Address)
+	if err != nil {
+		return err
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	user, err := keystore.NewUserFromKeystore(s.vm.ctx.Keystore, args.Username, args.Password)
+	if err != nil {
+		return err
+	}
+	defer user.Close()
+
+	if err := user.SetLabel(addr, args.Label); err != nil {
+		return fmt.Errorf("couldn't set label of %s: %w", args.Address, err)
+	}
+	return user.Close()
+}
+
+// GetAddressLabelsReply is the response from calling GetAddressLabels
+type GetAddressLabelsReply struct {
+	// Address --> label
+	Labels map[string]string `json:"labels"`
+}
+
+// GetAddressLabels returns the labels attached to addresses in the keystore of
+// [args.Username]
+func (s *Service) GetAddressLabels(_ *http.Request, args *api.UserPass, reply *GetAddressLabelsReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getAddressLabels"),
+		logging.UserString("username", args.Username),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	user, err := keystore.NewUserFromKeystore(s.vm.ctx.Keystore, args.Username, args.Password)
+	if err != nil {
+		return err
+	}
+	defer user.Close()
+
+	labels, err := user.GetLabels()
+	if err != nil {
+		return fmt.Errorf("couldn't get labels: %w", err)
+	}
+	reply.Labels = make(map[string]string, len(labels))
+	for addr, label := range labels {
+		addrStr, err := s.addrManager.FormatLocalAddress(addr)
+		if err != nil {
+			return fmt.Errorf("problem formatting address: %w", err)
+		}
+		reply.Labels[addrStr] = label
+	}
+	return user.Close()
+}
+
+// LabeledBalance is the balance and the stake of the addresses with a label
+type LabeledBalance struct {
+	Label              string         `json:"label"`
+	Addresses          []string       `json:"addresses"`
+	Balance            avajson.Uint64 `json:"balance"`
+	Unlocked           avajson.Uint64 `json:"unlocked"`
+	LockedStakeable    avajson.Uint64 `json:"lockedStakeable"`
+	LockedNotStakeable avajson.Uint64 `json:"lockedNotStakeable"`
+	// Amount staked on the Primary Network by current and pending stakers
+	Staked avajson.Uint64 `json:"staked"`
+}
+
+// GetLabeledBalancesReply is the response from calling GetLabeledBalances
+type GetLabeledBalancesReply struct {
+	Groups []LabeledBalance `json:"groups"`
+}
+
+// GetLabeledBalances returns the AVAX balance and stake of the addresses in
+// the keystore of [args.Username], grouped by label. The addresses controlled
+// by the user that have no label are grouped under the empty label.
+func (s *Service) GetLabeledBalances(r *http.Request, args *api.UserPass, reply *GetLabeledBalancesReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getLabeledBalances"),
+		logging.UserString("username", args.Username),
+	)
+
+	groups, err := s.getLabeledAddresses(args)
+	if err != nil {
+		return err
+	}
+	labels := make([]string, 0, len(groups))
+	for label := range groups {
+		labels = append(labels, label)
+	}
+	slices.Sort(labels)
+
+	reply.Groups = make([]LabeledBalance, len(labels))
+	addrSets := make([]set.Set[ids.ShortID], len(labels))
+	for i, label := range labels {
+		addrSets[i] = groups[label]
+
+		group := &reply.Groups[i]
+		group.Label = label
+		addrs := groups[label].List()
+		utils.Sort(addrs)
+		group.Addresses = make([]string, 0, len(addrs))
+		for _, addr := range addrs {
+			addrStr, err := s.addrManager.FormatLocalAddress(addr)
+			if err != nil {
+				return fmt.Errorf("problem formatting address: %w", err)
+			}
+			group.Addresses = append(group.Addresses, addrStr)
+		}
+
+		balance := GetBalanceResponse{}
+		if err := s.GetBalance(r, &GetBalanceRequest{Addresses: group.Addresses}, &balance); err != nil {
+			return fmt.Errorf("couldn't get balance of label %q: %w", label, err)
+		}
+		group.Balance = balance.Balance
+		group.Unlocked = balance.Unlocked
+		group.LockedStakeable = balance.LockedStakeable
+		group.LockedNotStakeable = balance.LockedNotStakeable
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	stakeds, err := s.getStakeds(addrSets)
+	if err != nil {
+		return err
+	}
+	for i, staked := range stakeds {
+		reply.Groups[i].Staked = avajson.Uint64(staked[s.vm.ctx.AVAXAssetID])
+	}
+	return nil
+}
+
+// getLabeledAddresses returns the addresses in the keystore of [args.Username]
+// grouped by label
+func (s *Service) getLabeledAddresses(args *api.UserPass) (map[string]set.Set[ids.ShortID], error) {
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	user, err := keystore.NewUserFromKeystore(s.vm.ctx.Keystore, args.Username, args.Password)
+	if err != nil {
+		return nil, err
+	}
+	defer user.Close()
+
+	addrs, err := user.GetAddresses()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get addresses: %w", err)
+	}
+	labels, err := user.GetLabels()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get labels: %w", err)
+	}
+
+	groups := make(map[string]set.Set[ids.ShortID])
+	addToGroup := func(label string, addr ids.ShortID) {
+		group, ok := groups[label]
+		if !ok {
+			group = set.Set[ids.ShortID]{}
+			groups[label] = group
+		}
+		group.Add(addr)
+	}
+	for addr, label := range labels {
+		addToGroup(label, addr)
+	}
+	for _, addr := range addrs {
+		if _, ok := labels[addr]; !ok {
+			addToGroup("", addr)
+		}
+	}
+	return groups, user.Close()
+}
+
+// getStakeds returns the amount staked on the Primary Network by the current
+// and pending stakers from each of [addrSets].
+//
+// Invariant: the context lock is held.
+func (s *Service) getStakeds(addrSets []set.Set[ids.ShortID]) ([]map[ids.ID]uint64, error) {
+	stakeds := make([]map[ids.ID]uint64, len(addrSets))
+	for i := range stakeds {
+		stakeds[i] = make(map[ids.ID]uint64)
+	}
+
+	currentStakerIterator, err := s.vm.state.GetCurrentStakerIterator()
+	if err != nil {
+		return nil, err
+	}
+	pendingStakerIterator, err := s.vm.state.GetPendingStakerIterator()
+	if err != nil {
+		currentStakerIterator.Release()
+		return nil, err
+	}
+	stakerIterator := state.NewMergedIterator(currentStakerIterator, pendingStakerIterator)
+	defer stakerIterator.Release()
+
+	for stakerIterator.Next() {
+		tx, _, err := s.vm.state.GetTx(stakerIterator.Value().TxID)
+		if err != nil {
+			return nil, err
+		}
+		for i, addrs := range addrSets {
+			_ = getStakeHelper(tx, addrs, stakeds[i])
+		}
+	}
+	return stakeds, nil
+}
+
 // Index is an address and an associated UTXO.
 // Marks a starting or stopping point when fetching UTXOs. Used for pagination.
 type Index struct {
@@ -420,7 +701,7 @@ type Index struct {
 }
 
 // GetUTXOs returns the UTXOs controlled by the given addresses
-func (s *Service) GetUTXOs(_ *http.Request, args *api.GetUTXOsArgs, response *api.GetUTXOsReply) error {
+func (s *Service) GetUTXOs(r *http.Request, args *api.GetUTXOsArgs, response *api.GetUTXOsReply) error {
 	s.vm.ctx.Log.Debug("API called",
 		zap.String("service", "platform"),
 		zap.String("method", "getUTXOs"),
@@ -448,6 +729,9 @@ func (s *Service) GetUTXOs(_ *http.Request, args *api.GetUTXOsArgs, response *ap
 	if err != nil {
 		return err
 	}
+	if err := s.addressAllowLists.authorize(r, addrSet); err != nil {
+		return err
+	}
 
 	startAddr := ids.ShortEmpty
 	startUTXO := ids.Empty
@@ -520,6 +804,149 @@ func (s *Service) GetUTXOs(_ *http.Request, args *api.GetUTXOsArgs, response *ap
 	return nil
 }
 
+// GetAtomicSummaryArgs are the arguments for calling GetAtomicSummary
+type GetAtomicSummaryArgs struct {
+	Addresses []string `json:"addresses"`
+	// Chains that exported the in-flight UTXOs. Defaults to the X-chain and
+	// the C-chain.
+	SourceChains []string `json:"sourceChains"`
+}
+
+// InFlightAtomicBalance is the value that a chain exported to the P-chain
+// which wasn't imported yet.
+type InFlightAtomicBalance struct {
+	SourceChain ids.ID                    `json:"sourceChain"`
+	Balances    map[ids.ID]avajson.Uint64 `json:"balances"`
+	UTXOIDs     []*avax.UTXOID            `json:"utxoIDs"`
+}
+
+// GetAtomicSummaryReply is the response from calling GetAtomicSummary
+type GetAtomicSummaryReply struct {
+	// Value of the P-chain UTXOs, by assetID
+	Balances map[ids.ID]avajson.Uint64 `json:"balances"`
+	// Value exported to the P-chain that can be imported, by source chain
+	InFlight []InFlightAtomicBalance `json:"inFlight"`
+}
+
+// GetAtomicSummary returns the value held by the given addresses on the
+// P-chain along with the value that was exported to them from other chains
+// but wasn't imported yet.
+//
+// UTXOs exported from the P-chain sit in the shared memory of the destination
+// chain, so they are reported by the destination chain rather than here.
+func (s *Service) GetAtomicSummary(r *http.Request, args *GetAtomicSummaryArgs, reply *GetAtomicSummaryReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getAtomicSummary"),
+		logging.UserStrings("addresses", args.Addresses),
+	)
+
+	if len(args.Addresses) == 0 {
+		return errNoAddresses
+	}
+	if len(args.Addresses) > maxGetUTXOsAddrs {
+		return fmt.Errorf("number of addresses given, %d, exceeds maximum, %d", len(args.Addresses), maxGetUTXOsAddrs)
+	}
+
+	sourceChains := []ids.ID{s.vm.ctx.XChainID, s.vm.ctx.CChainID}
+	if len(args.SourceChains) > 0 {
+		sourceChains = make([]ids.ID, len(args.SourceChains))
+		for i, alias := range args.SourceChains {
+			chainID, err := s.vm.ctx.BCLookup.Lookup(alias)
+			if err != nil {
+				return fmt.Errorf("problem parsing source chainID %q: %w", alias, err)
+			}
+			sourceChains[i] = chainID
+		}
+	}
+
+	addrs, err := avax.ParseServiceAddresses(s.addrManager, args.Addresses)
+	if err != nil {
+		return err
+	}
+	if err := s.addressAllowLists.authorize(r, addrs); err != nil {
+		return err
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	utxos, err := avax.GetAllUTXOs(s.vm.state, addrs)
+	if err != nil {
+		return fmt.Errorf("couldn't get UTXO set of %v: %w", args.Addresses, err)
+	}
+	reply.Balances = newJSONBalanceMap(sumUTXOs(utxos))
+
+	reply.InFlight = make([]InFlightAtomicBalance, 0, len(sourceChains))
+	for _, sourceChain := range sourceChains {
+		if sourceChain == s.vm.ctx.ChainID {
+			continue
+		}
+
+		atomicUTXOs, err := s.getAllAtomicUTXOs(sourceChain, addrs)
+		if err != nil {
+			return fmt.Errorf("couldn't get atomic UTXOs exported from %s: %w", sourceChain, err)
+		}
+		inFlight := InFlightAtomicBalance{
+			SourceChain: sourceChain,
+			Balances:    newJSONBalanceMap(sumUTXOs(atomicUTXOs)),
+			UTXOIDs:     make([]*avax.UTXOID, len(atomicUTXOs)),
+		}
+		for i, utxo := range atomicUTXOs {
+			inFlight.UTXOIDs[i] = &utxo.UTXOID
+		}
+		reply.InFlight = append(reply.InFlight, inFlight)
+	}
+	return nil
+}
+
+// getAllAtomicUTXOs returns all the UTXOs that [sourceChain] exported to
+// [addrs] which weren't imported yet.
+func (s *Service) getAllAtomicUTXOs(sourceChain ids.ID, addrs set.Set[ids.ShortID]) ([]*avax.UTXO, error) {
+	var (
+		utxos     []*avax.UTXO
+		startAddr = ids.ShortEmpty
+		startUTXO = ids.Empty
+	)
+	for {
+		page, endAddr, endUTXO, err := s.vm.atomicUtxosManager.GetAtomicUTXOs(
+			sourceChain,
+			addrs,
+			startAddr,
+			startUTXO,
+			builder.MaxPageSize,
+		)
+		if err != nil {
+			return nil, err
+		}
+		utxos = append(utxos, page...)
+		if len(page) < builder.MaxPageSize {
+			return utxos, nil
+		}
+		startAddr, startUTXO = endAddr, endUTXO
+	}
+}
+
+// sumUTXOs returns the value of [utxos] by assetID. Sums that overflow are
+// capped to MaxUint64.
+func sumUTXOs(utxos []*avax.UTXO) map[ids.ID]uint64 {
+	balances := make(map[ids.ID]uint64)
+	for _, utxo := range utxos {
+		out, ok := utxo.Out.(avax.Amounter)
+		if !ok {
+			continue
+		}
+		assetID := utxo.AssetID()
+		newBalance, err := safemath.Add64(balances[assetID], out.Amount())
+		if err != nil {
+			balances[assetID] = math.MaxUint64
+		} else {
+			balances[assetID] = newBalance
+		}
+	}
+	return balances
+}
+
 // GetSubnetArgs are the arguments to GetSubnet
 type GetSubnetArgs struct {
 	// ID of the subnet to retrieve information about
@@ -817,12 +1244,16 @@ func (s *Service) loadStakerTxAttributes(txID ids.ID) (*stakerAttributes, error)
 
 	switch stakerTx := tx.Unsigned.(type) {
 	case txs.ValidatorTx:
-		var pop *signer.ProofOfPossession
-		if staker, ok := stakerTx.(*txs.AddPermissionlessValidatorTx); ok {
-			if s, ok := staker.Signer.(*signer.ProofOfPossession); ok {
-				pop = s
-			}
+		var txSigner signer.Signer
+		switch staker := stakerTx.(type) {
+		case *txs.AddPermissionlessValidatorTx:
+			txSigner = staker.Signer
+		case *txs.AddCappedPermissionlessValidatorTx:
+			txSigner = staker.Signer
+		case *txs.AddPermissionlessValidatorWithMetadataTx:
+			txSigner = staker.Signer
 		}
+		pop, _ := txSigner.(*signer.ProofOfPossession)
 
 		attr = &stakerAttributes{
 			shares:                 stakerTx.Shares(),
@@ -1278,14 +1709,14 @@ func (s *Service) AddValidator(req *http.Request, args *AddValidatorArgs, reply
 		return fmt.Errorf("couldn't format address: %w", err)
 	}
 
-	return s.vm.issueTx(req.Context(), tx)
+	return s.issueTx(req.Context(), tx)
 }
 
 func (s *Service) buildAddValidatorTx(args *AddValidatorArgs) (*txs.Tx, ids.ShortID, error) {
 	now := s.vm.clock.Time()
 	minAddStakerTime := now.Add(minAddStakerDelay)
 	minAddStakerUnix := avajson.Uint64(minAddStakerTime.Unix())
-	maxAddStakerTime := now.Add(executor.MaxFutureStartTime)
+	maxAddStakerTime := now.Add(executor.GetMaxFutureStartTime(&s.vm.Config, now))
 	maxAddStakerUnix := avajson.Uint64(maxAddStakerTime.Unix())
 
 	if args.StartTime == 0 {
@@ -1333,16 +1764,16 @@ func (s *Service) buildAddValidatorTx(args *AddValidatorArgs) (*txs.Tx, ids.Shor
 	defer user.Close()
 
 	// Get the user's keys
-	privKeys, err := keystore.GetKeychain(user, fromAddrs)
+	kc, err := keystore.NewKeychain(user, fromAddrs)
 	if err != nil {
 		return nil, ids.ShortEmpty, fmt.Errorf("couldn't get addresses controlled by the user: %w", err)
 	}
 
 	// Parse the change address.
-	if len(privKeys.Keys) == 0 {
+	if len(kc.Addrs) == 0 {
 		return nil, ids.ShortEmpty, errNoKeys
 	}
-	changeAddr := privKeys.Keys[0].PublicKey().Address() // By default, use a key controlled by the user
+	changeAddr := kc.Addrs[0] // By default, use a key controlled by the user
 	if args.ChangeAddr != "" {
 		changeAddr, err = avax.ParseServiceAddress(s.addrManager, args.ChangeAddr)
 		if err != nil {
@@ -1363,7 +1794,7 @@ func (s *Service) buildAddValidatorTx(args *AddValidatorArgs) (*txs.Tx, ids.Shor
 		nodeID,                               // Node ID
 		rewardAddress,                        // Reward Address
 		uint32(10000*args.DelegationFeeRate), // Shares
-		privKeys.Keys,                        // Keys providing the staked tokens
+		kc,                                   // Keychain providing the staked tokens
 		changeAddr,
 		nil,
 	)
@@ -1401,14 +1832,14 @@ func (s *Service) AddDelegator(req *http.Request, args *AddDelegatorArgs, reply
 		return fmt.Errorf("couldn't format address: %w", err)
 	}
 
-	return s.vm.issueTx(req.Context(), tx)
+	return s.issueTx(req.Context(), tx)
 }
 
 func (s *Service) buildAddDelegatorTx(args *AddDelegatorArgs) (*txs.Tx, ids.ShortID, error) {
 	now := s.vm.clock.Time()
 	minAddStakerTime := now.Add(minAddStakerDelay)
 	minAddStakerUnix := avajson.Uint64(minAddStakerTime.Unix())
-	maxAddStakerTime := now.Add(executor.MaxFutureStartTime)
+	maxAddStakerTime := now.Add(executor.GetMaxFutureStartTime(&s.vm.Config, now))
 	maxAddStakerUnix := avajson.Uint64(maxAddStakerTime.Unix())
 
 	if args.StartTime == 0 {
@@ -1452,17 +1883,17 @@ func (s *Service) buildAddDelegatorTx(args *AddDelegatorArgs) (*txs.Tx, ids.Shor
 	}
 	defer user.Close()
 
-	privKeys, err := keystore.GetKeychain(user, fromAddrs)
+	kc, err := keystore.NewKeychain(user, fromAddrs)
 	if err != nil {
 		return nil, ids.ShortEmpty, fmt.Errorf("couldn't get addresses controlled by the user: %w", err)
 	}
 
 	// Parse the change address. Assumes that if the user has no keys,
 	// this operation will fail so the change address can be anything.
-	if len(privKeys.Keys) == 0 {
+	if len(kc.Addrs) == 0 {
 		return nil, ids.ShortEmpty, errNoKeys
 	}
-	changeAddr := privKeys.Keys[0].PublicKey().Address() // By default, use a key controlled by the user
+	changeAddr := kc.Addrs[0] // By default, use a key controlled by the user
 	if args.ChangeAddr != "" {
 		changeAddr, err = avax.ParseServiceAddress(s.addrManager, args.ChangeAddr)
 		if err != nil {
@@ -1482,7 +1913,7 @@ func (s *Service) buildAddDelegatorTx(args *AddDelegatorArgs) (*txs.Tx, ids.Shor
 		uint64(args.EndTime),   // End time
 		nodeID,                 // Node ID
 		rewardAddress,          // Reward Address
-		privKeys.Keys,          // Private keys
+		kc,                     // Keychain
 		changeAddr,             // Change address
 		nil,                    // Memo
 	)
@@ -1521,14 +1952,14 @@ func (s *Service) AddSubnetValidator(req *http.Request, args *AddSubnetValidator
 		return fmt.Errorf("couldn't format address: %w", err)
 	}
 
-	return s.vm.issueTx(req.Context(), tx)
+	return s.issueTx(req.Context(), tx)
 }
 
 func (s *Service) buildAddSubnetValidatorTx(args *AddSubnetValidatorArgs) (*txs.Tx, ids.ShortID, error) {
 	now := s.vm.clock.Time()
 	minAddStakerTime := now.Add(minAddStakerDelay)
 	minAddStakerUnix := avajson.Uint64(minAddStakerTime.Unix())
-	maxAddStakerTime := now.Add(executor.MaxFutureStartTime)
+	maxAddStakerTime := now.Add(executor.GetMaxFutureStartTime(&s.vm.Config, now))
 	maxAddStakerUnix := avajson.Uint64(maxAddStakerTime.Unix())
 
 	if args.StartTime == 0 {
@@ -1568,16 +1999,16 @@ func (s *Service) buildAddSubnetValidatorTx(args *AddSubnetValidatorArgs) (*txs.
 	}
 	defer user.Close()
 
-	keys, err := keystore.GetKeychain(user, fromAddrs)
+	kc, err := keystore.NewKeychain(user, fromAddrs)
 	if err != nil {
 		return nil, ids.ShortEmpty, fmt.Errorf("couldn't get addresses controlled by the user: %w", err)
 	}
 
 	// Parse the change address.
-	if len(keys.Keys) == 0 {
+	if len(kc.Addrs) == 0 {
 		return nil, ids.ShortEmpty, errNoKeys
 	}
-	changeAddr := keys.Keys[0].PublicKey().Address() // By default, use a key controlled by the user
+	changeAddr := kc.Addrs[0] // By default, use a key controlled by the user
 	if args.ChangeAddr != "" {
 		changeAddr, err = avax.ParseServiceAddress(s.addrManager, args.ChangeAddr)
 		if err != nil {
@@ -1597,7 +2028,7 @@ func (s *Service) buildAddSubnetValidatorTx(args *AddSubnetValidatorArgs) (*txs.
 		uint64(args.EndTime),   // End time
 		args.NodeID,            // Node ID
 		subnetID,               // Subnet ID
-		keys.Keys,
+		kc,
 		changeAddr,
 		nil,
 	)
@@ -1635,7 +2066,7 @@ func (s *Service) CreateSubnet(req *http.Request, args *CreateSubnetArgs, respon
 		return fmt.Errorf("couldn't format address: %w", err)
 	}
 
-	return s.vm.issueTx(req.Context(), tx)
+	return s.issueTx(req.Context(), tx)
 }
 
 func (s *Service) buildCreateSubnetTx(args *CreateSubnetArgs) (*txs.Tx, ids.ShortID, error) {
@@ -1660,17 +2091,17 @@ func (s *Service) buildCreateSubnetTx(args *CreateSubnetArgs) (*txs.Tx, ids.Shor
 	}
 	defer user.Close()
 
-	privKeys, err := keystore.GetKeychain(user, fromAddrs)
+	kc, err := keystore.NewKeychain(user, fromAddrs)
 	if err != nil {
 		return nil, ids.ShortEmpty, fmt.Errorf("couldn't get addresses controlled by the user: %w", err)
 	}
 
 	// Parse the change address. Assumes that if the user has no keys,
 	// this operation will fail so the change address can be anything.
-	if len(privKeys.Keys) == 0 {
+	if len(kc.Addrs) == 0 {
 		return nil, ids.ShortEmpty, errNoKeys
 	}
-	changeAddr := privKeys.Keys[0].PublicKey().Address() // By default, use a key controlled by the user
+	changeAddr := kc.Addrs[0] // By default, use a key controlled by the user
 	if args.ChangeAddr != "" {
 		changeAddr, err = avax.ParseServiceAddress(s.addrManager, args.ChangeAddr)
 		if err != nil {
@@ -1682,7 +2113,7 @@ func (s *Service) buildCreateSubnetTx(args *CreateSubnetArgs) (*txs.Tx, ids.Shor
 	tx, err := s.vm.txBuilder.NewCreateSubnetTx(
 		uint32(args.Threshold), // Threshold
 		controlKeys.List(),     // Control Addresses
-		privKeys.Keys,          // Private keys
+		kc,                     // Keychain
 		changeAddr,
 		nil,
 	)
@@ -1728,7 +2159,7 @@ func (s *Service) ExportAVAX(req *http.Request, args *ExportAVAXArgs, response *
 		return fmt.Errorf("couldn't format address: %w", err)
 	}
 
-	return s.vm.issueTx(req.Context(), tx)
+	return s.issueTx(req.Context(), tx)
 }
 
 func (s *Service) buildExportAVAX(args *ExportAVAXArgs) (*txs.Tx, ids.ShortID, error) {
@@ -1764,17 +2195,17 @@ func (s *Service) buildExportAVAX(args *ExportAVAXArgs) (*txs.Tx, ids.ShortID, e
 	}
 	defer user.Close()
 
-	privKeys, err := keystore.GetKeychain(user, fromAddrs)
+	kc, err := keystore.NewKeychain(user, fromAddrs)
 	if err != nil {
 		return nil, ids.ShortEmpty, fmt.Errorf("couldn't get addresses controlled by the user: %w", err)
 	}
 
 	// Parse the change address. Assumes that if the user has no keys,
 	// this operation will fail so the change address can be anything.
-	if len(privKeys.Keys) == 0 {
+	if len(kc.Addrs) == 0 {
 		return nil, ids.ShortEmpty, errNoKeys
 	}
-	changeAddr := privKeys.Keys[0].PublicKey().Address() // By default, use a key controlled by the user
+	changeAddr := kc.Addrs[0] // By default, use a key controlled by the user
 	if args.ChangeAddr != "" {
 		changeAddr, err = avax.ParseServiceAddress(s.addrManager, args.ChangeAddr)
 		if err != nil {
@@ -1787,7 +2218,7 @@ func (s *Service) buildExportAVAX(args *ExportAVAXArgs) (*txs.Tx, ids.ShortID, e
 		uint64(args.Amount), // Amount
 		chainID,             // ID of the chain to send the funds to
 		to,                  // Address
-		privKeys.Keys,       // Private keys
+		kc,                  // Keychain
 		changeAddr,          // Change address
 		nil,
 	)
@@ -1829,7 +2260,7 @@ func (s *Service) ImportAVAX(req *http.Request, args *ImportAVAXArgs, response *
 		return fmt.Errorf("problem formatting address: %w", err)
 	}
 
-	return s.vm.issueTx(req.Context(), tx)
+	return s.issueTx(req.Context(), tx)
 }
 
 func (s *Service) buildImportAVAXTx(args *ImportAVAXArgs) (*txs.Tx, ids.ShortID, error) {
@@ -1860,17 +2291,17 @@ func (s *Service) buildImportAVAXTx(args *ImportAVAXArgs) (*txs.Tx, ids.ShortID,
 	}
 	defer user.Close()
 
-	privKeys, err := keystore.GetKeychain(user, fromAddrs)
+	kc, err := keystore.NewKeychain(user, fromAddrs)
 	if err != nil { // Get keys
 		return nil, ids.ShortEmpty, fmt.Errorf("couldn't get keys controlled by the user: %w", err)
 	}
 
 	// Parse the change address. Assumes that if the user has no keys,
 	// this operation will fail so the change address can be anything.
-	if len(privKeys.Keys) == 0 {
+	if len(kc.Addrs) == 0 {
 		return nil, ids.ShortEmpty, errNoKeys
 	}
-	changeAddr := privKeys.Keys[0].PublicKey().Address() // By default, use a key controlled by the user
+	changeAddr := kc.Addrs[0] // By default, use a key controlled by the user
 	if args.ChangeAddr != "" {
 		changeAddr, err = avax.ParseServiceAddress(s.addrManager, args.ChangeAddr)
 		if err != nil {
@@ -1881,7 +2312,7 @@ func (s *Service) buildImportAVAXTx(args *ImportAVAXArgs) (*txs.Tx, ids.ShortID,
 	tx, err := s.vm.txBuilder.NewImportTx(
 		chainID,
 		to,
-		privKeys.Keys,
+		kc,
 		changeAddr,
 		nil,
 	)
@@ -1934,7 +2365,7 @@ func (s *Service) CreateBlockchain(req *http.Request, args *CreateBlockchainArgs
 		return fmt.Errorf("problem formatting address: %w", err)
 	}
 
-	return s.vm.issueTx(req.Context(), tx)
+	return s.issueTx(req.Context(), tx)
 }
 
 func (s *Service) buildCreateBlockchainTx(args *CreateBlockchainArgs) (*txs.Tx, ids.ShortID, error) {
@@ -1989,17 +2420,17 @@ func (s *Service) buildCreateBlockchainTx(args *CreateBlockchainArgs) (*txs.Tx,
 	}
 	defer user.Close()
 
-	keys, err := keystore.GetKeychain(user, fromAddrs)
+	kc, err := keystore.NewKeychain(user, fromAddrs)
 	if err != nil {
 		return nil, ids.ShortEmpty, fmt.Errorf("couldn't get addresses controlled by the user: %w", err)
 	}
 
 	// Parse the change address. Assumes that if the user has no keys,
 	// this operation will fail so the change address can be anything.
-	if len(keys.Keys) == 0 {
+	if len(kc.Addrs) == 0 {
 		return nil, ids.ShortEmpty, errNoKeys
 	}
-	changeAddr := keys.Keys[0].PublicKey().Address() // By default, use a key controlled by the user
+	changeAddr := kc.Addrs[0] // By default, use a key controlled by the user
 	if args.ChangeAddr != "" {
 		changeAddr, err = avax.ParseServiceAddress(s.addrManager, args.ChangeAddr)
 		if err != nil {
@@ -2014,7 +2445,7 @@ func (s *Service) buildCreateBlockchainTx(args *CreateBlockchainArgs) (*txs.Tx,
 		vmID,
 		fxIDs,
 		args.Name,
-		keys.Keys,
+		kc,
 		changeAddr, // Change address
 		nil,
 	)
@@ -2300,6 +2731,10 @@ func (s *Service) IssueTx(req *http.Request, args *api.FormattedTx, response *ap
 		zap.String("method", "issueTx"),
 	)
 
+	if err := s.issueTxQuotas.consume(req); err != nil {
+		return err
+	}
+
 	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
 	if err != nil {
 		return fmt.Errorf("problem decoding transaction: %w", err)
@@ -2309,7 +2744,7 @@ func (s *Service) IssueTx(req *http.Request, args *api.FormattedTx, response *ap
 		return fmt.Errorf("couldn't parse tx: %w", err)
 	}
 
-	if err := s.vm.issueTx(req.Context(), tx); err != nil {
+	if err := s.issueTx(req.Context(), tx); err != nil {
 		return fmt.Errorf("couldn't issue tx: %w", err)
 	}
 
@@ -2317,48 +2752,151 @@ func (s *Service) IssueTx(req *http.Request, args *api.FormattedTx, response *ap
 	return nil
 }
 
-func (s *Service) GetTx(_ *http.Request, args *api.GetTxArgs, response *api.GetTxReply) error {
-	s.vm.ctx.Log.Debug("API called",
-		zap.String("service", "platform"),
-		zap.String("method", "getTx"),
-	)
+// issueTx issues [tx] once it is counted against the quotas of the addresses
+// whose UTXOs it spends. If [tx] fails to be issued, it is removed from the
+// quotas again.
+func (s *Service) issueTx(ctx context.Context, tx *txs.Tx) error {
+	if s.vm.issueTxAddressQuotas == nil || s.vm.issueTxAddressQuotas.limit <= 0 {
+		return s.vm.issueTx(ctx, tx)
+	}
 
 	s.vm.ctx.Lock.Lock()
-	defer s.vm.ctx.Lock.Unlock()
-
-	tx, _, err := s.vm.state.GetTx(args.TxID)
+	addrs, err := spenderAddresses(s.vm.state, tx)
+	s.vm.ctx.Lock.Unlock()
 	if err != nil {
-		return fmt.Errorf("couldn't get tx: %w", err)
+		return err
 	}
-	response.Encoding = args.Encoding
-
-	var result any
-	if args.Encoding == formatting.JSON {
-		tx.Unsigned.InitCtx(s.vm.ctx)
-		result = tx
-	} else {
-		result, err = formatting.Encode(args.Encoding, tx.Bytes())
-		if err != nil {
-			return fmt.Errorf("couldn't encode tx as %s: %w", args.Encoding, err)
+	if err := s.vm.issueTxAddressQuotas.consume(addrs); err != nil {
+		return err
+	}
+	if err := s.vm.issueTx(ctx, tx); err != nil {
+		if releaseErr := s.vm.issueTxAddressQuotas.release(addrs); releaseErr != nil {
+			s.vm.ctx.Log.Warn("failed to release address quotas",
+				zap.Stringer("txID", tx.ID()),
+				zap.Error(releaseErr),
+			)
 		}
+		return err
 	}
-
-	response.Tx, err = json.Marshal(result)
-	return err
+	return nil
 }
 
-type GetTxStatusArgs struct {
+// InspectTxReply is the response from InspectTx
+type InspectTxReply struct {
 	TxID ids.ID `json:"txID"`
+	// Type is the name of the tx type, e.g. "AddValidatorTx"
+	Type string `json:"type"`
+	// Size is the number of bytes of the tx, including its credentials
+	Size avajson.Uint64 `json:"size"`
+	// Complexity is the number of complexity units charged for the tx once it
+	// holds the signatures it requires
+	Complexity avajson.Uint64 `json:"complexity"`
+	// RequiredSignatures is the number of signatures the credentials of the
+	// tx must hold
+	RequiredSignatures avajson.Uint64 `json:"requiredSignatures"`
+	// Fee is the amount of [FeeAssetID] the tx must burn under the fee rules
+	// of the last accepted state
+	Fee        avajson.Uint64 `json:"fee"`
+	FeeAssetID ids.ID         `json:"feeAssetID"`
 }
 
-type GetTxStatusResponse struct {
-	Status status.Status `json:"status"`
-	// Reason this tx was dropped.
-	// Only non-empty if Status is dropped
-	Reason string `json:"reason,omitempty"`
-}
-
-// GetTxStatus gets a tx's status
+// InspectTx parses a tx, that may not be signed yet, and returns its type,
+// size, complexity, required signatures and fee. The tx isn't verified.
+func (s *Service) InspectTx(_ *http.Request, args *api.FormattedTx, reply *InspectTxReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "inspectTx"),
+	)
+
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	tx, err := txs.Parse(txs.Codec, txBytes)
+	if err != nil {
+		return fmt.Errorf("couldn't parse tx: %w", err)
+	}
+	complexity, err := txs.EstimatedComplexity(tx.Unsigned)
+	if err != nil {
+		return fmt.Errorf("couldn't calculate complexity: %w", err)
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	feeAssetID, fee, err := executor.TxFee(&s.vm.Config, s.vm.ctx.AVAXAssetID, s.vm.state, tx.Unsigned)
+	if err != nil {
+		return fmt.Errorf("couldn't calculate fee: %w", err)
+	}
+
+	reply.TxID = tx.ID()
+	reply.Type = reflect.TypeOf(tx.Unsigned).Elem().Name()
+	reply.Size = avajson.Uint64(len(txBytes))
+	reply.Complexity = avajson.Uint64(complexity)
+	reply.RequiredSignatures = avajson.Uint64(txs.NumRequiredSignatures(tx.Unsigned))
+	reply.Fee = avajson.Uint64(fee)
+	reply.FeeAssetID = feeAssetID
+	return nil
+}
+
+func (s *Service) GetTx(_ *http.Request, args *api.GetTxArgs, response *api.GetTxReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getTx"),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	tx, _, err := s.vm.state.GetTx(args.TxID)
+	if err != nil {
+		return fmt.Errorf("couldn't get tx: %w", err)
+	}
+	response.Encoding = args.Encoding
+
+	var result any
+	if args.Encoding == formatting.JSON {
+		tx.Unsigned.InitCtx(s.vm.ctx)
+		result = tx
+	} else {
+		result, err = formatting.Encode(args.Encoding, tx.Bytes())
+		if err != nil {
+			return fmt.Errorf("couldn't encode tx as %s: %w", args.Encoding, err)
+		}
+	}
+
+	response.Tx, err = json.Marshal(result)
+	return err
+}
+
+type GetTxStatusArgs struct {
+	TxID ids.ID `json:"txID"`
+}
+
+type GetTxStatusResponse struct {
+	Status status.Status `json:"status"`
+	// Reason this tx was dropped.
+	// Only non-empty if Status is dropped
+	Reason string `json:"reason,omitempty"`
+	// Receipt of the staker added by this tx, if it was removed before its
+	// end time.
+	// Only set if Status is committed
+	Exit *StakerExit `json:"exit,omitempty"`
+}
+
+// StakerExit is the receipt of a staker that was removed before its end time
+type StakerExit struct {
+	// ID of the tx that removed the staker
+	TxID ids.ID `json:"txID"`
+	// Unix time the staker was removed at
+	Timestamp avajson.Uint64 `json:"timestamp"`
+	// Amount of stake returned to the staker
+	Refunded avajson.Uint64 `json:"refunded"`
+	// Potential reward that the staker forfeited
+	ForfeitedReward avajson.Uint64 `json:"forfeitedReward"`
+}
+
+// GetTxStatus gets a tx's status
 func (s *Service) GetTxStatus(_ *http.Request, args *GetTxStatusArgs, response *GetTxStatusResponse) error {
 	s.vm.ctx.Log.Debug("API called",
 		zap.String("service", "platform"),
@@ -2371,6 +2909,20 @@ func (s *Service) GetTxStatus(_ *http.Request, args *GetTxStatusArgs, response *
 	_, txStatus, err := s.vm.state.GetTx(args.TxID)
 	if err == nil { // Found the status. Report it.
 		response.Status = txStatus
+		exit, err := s.vm.state.GetStakerExit(args.TxID)
+		switch err {
+		case nil:
+			response.Exit = &StakerExit{
+				TxID:            exit.TxID,
+				Timestamp:       avajson.Uint64(exit.Timestamp),
+				Refunded:        avajson.Uint64(exit.Refunded),
+				ForfeitedReward: avajson.Uint64(exit.ForfeitedReward),
+			}
+		case database.ErrNotFound:
+			// The tx didn't add a staker that was removed early.
+		default:
+			return err
+		}
 		return nil
 	}
 	if err != database.ErrNotFound {
@@ -2404,15 +2956,209 @@ func (s *Service) GetTxStatus(_ *http.Request, args *GetTxStatusArgs, response *
 	// Note: we check if tx is dropped only after having looked for it
 	// in the database and the mempool, because dropped txs may be re-issued.
 	reason := s.vm.Builder.GetDropReason(args.TxID)
-	if reason == nil {
-		// The tx isn't being tracked by the node.
-		response.Status = status.Unknown
+	if reason != nil {
+		// The tx was recently dropped because it was invalid.
+		response.Status = status.Dropped
+		response.Reason = reason.Error()
 		return nil
 	}
 
-	// The tx was recently dropped because it was invalid.
-	response.Status = status.Dropped
-	response.Reason = reason.Error()
+	if s.vm.droppedTxIndex != nil {
+		reason, err := s.vm.droppedTxIndex.Get(args.TxID)
+		if err == nil {
+			// The tx was dropped, possibly before the node restarted.
+			response.Status = status.Dropped
+			response.Reason = reason
+			return nil
+		}
+		if err != database.ErrNotFound {
+			return err
+		}
+	}
+
+	// The tx isn't being tracked by the node.
+	response.Status = status.Unknown
+	return nil
+}
+
+// GetTxInclusionProofArgs are the arguments for calling GetTxInclusionProof
+type GetTxInclusionProofArgs struct {
+	TxID ids.ID `json:"txID"`
+}
+
+// GetTxInclusionProofReply is the response from calling GetTxInclusionProof
+type GetTxInclusionProofReply struct {
+	// ID of the accepted block that includes the tx
+	BlockID ids.ID         `json:"blockID"`
+	Height  avajson.Uint64 `json:"height"`
+	// Index of the tx in the block
+	Index avajson.Uint32 `json:"index"`
+	// Number of txs in the block
+	NumTxs avajson.Uint32 `json:"numTxs"`
+	// Root of the Merkle tree of the txIDs of the block
+	Root ids.ID `json:"root"`
+	// Siblings of the tx in the Merkle tree, from the leaves towards the root
+	Proof []ids.ID `json:"proof"`
+}
+
+// GetTxInclusionProof returns a proof that an accepted tx is included in its
+// block, which can be checked against the block's tx root with
+// block.VerifyTxInclusion.
+func (s *Service) GetTxInclusionProof(_ *http.Request, args *GetTxInclusionProofArgs, reply *GetTxInclusionProofReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getTxInclusionProof"),
+		zap.Stringer("txID", args.TxID),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	blkID, err := s.vm.state.GetTxBlockID(args.TxID)
+	if err != nil {
+		return fmt.Errorf("couldn't get the block of tx %s: %w", args.TxID, err)
+	}
+	root, err := s.vm.state.GetTxRoot(blkID)
+	if err != nil {
+		return fmt.Errorf("couldn't get the tx root of block %s: %w", blkID, err)
+	}
+	blk, err := s.vm.manager.GetStatelessBlock(blkID)
+	if err != nil {
+		return fmt.Errorf("couldn't get block %s: %w", blkID, err)
+	}
+
+	txIDs := block.TxIDs(blk)
+	index := slices.Index(txIDs, args.TxID)
+	if index < 0 {
+		return fmt.Errorf("%w: tx %s isn't in block %s", errTxRootMismatch, args.TxID, blkID)
+	}
+	if block.TxRoot(txIDs) != root {
+		return fmt.Errorf("%w: block %s", errTxRootMismatch, blkID)
+	}
+	proof, err := block.TxInclusionProof(txIDs, index)
+	if err != nil {
+		return err
+	}
+
+	reply.BlockID = blkID
+	reply.Height = avajson.Uint64(blk.Height())
+	reply.Index = avajson.Uint32(index)
+	reply.NumTxs = avajson.Uint32(len(txIDs))
+	reply.Root = root
+	reply.Proof = proof
+	return nil
+}
+
+const (
+	MempoolGraphFormatJSON = "json"
+	MempoolGraphFormatDOT  = "dot"
+)
+
+type GetMempoolGraphArgs struct {
+	// Format of the graph in the reply. Defaults to "json".
+	Format string `json:"format"`
+	// Tx, if provided, is checked for conflicts against the mempool.
+	Tx       string              `json:"tx"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+type GetMempoolGraphReply struct {
+	// Txs and Dependencies are only populated with the "json" format.
+	Txs          []ids.ID             `json:"txs,omitempty"`
+	Dependencies []mempool.Dependency `json:"dependencies,omitempty"`
+	// DOT is only populated with the "dot" format.
+	DOT string `json:"dot,omitempty"`
+	// Conflicts are the txs in the mempool that consume inputs of the
+	// provided tx.
+	Conflicts []ids.ID `json:"conflicts,omitempty"`
+}
+
+// GetMempoolGraph returns the dependencies between the txs in the mempool.
+// If a tx is provided, the mempool txs that conflict with it are returned as
+// well.
+func (s *Service) GetMempoolGraph(_ *http.Request, args *GetMempoolGraphArgs, reply *GetMempoolGraphReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getMempoolGraph"),
+	)
+
+	format := args.Format
+	if format == "" {
+		format = MempoolGraphFormatJSON
+	}
+	if format != MempoolGraphFormatJSON && format != MempoolGraphFormatDOT {
+		return errUnknownGraphFormat
+	}
+
+	var tx *txs.Tx
+	if args.Tx != "" {
+		txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+		if err != nil {
+			return fmt.Errorf("problem decoding transaction: %w", err)
+		}
+		tx, err = txs.Parse(txs.Codec, txBytes)
+		if err != nil {
+			return fmt.Errorf("couldn't parse tx: %w", err)
+		}
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	graph := s.vm.Builder.Graph()
+	if format == MempoolGraphFormatDOT {
+		reply.DOT = graph.DOT()
+	} else {
+		reply.Txs = graph.Txs
+		reply.Dependencies = graph.Dependencies
+	}
+	if tx != nil {
+		reply.Conflicts = s.vm.Builder.Conflicts(tx)
+	}
+	return nil
+}
+
+type CheckImportTxReply struct {
+	SourceChain ids.ID `json:"sourceChain"`
+	// MissingUTXOs are the imported UTXOs that aren't currently in shared
+	// memory.
+	MissingUTXOs []avax.UTXOID `json:"missingUTXOs"`
+}
+
+// CheckImportTx reports which of the UTXOs imported by an ImportTx are missing
+// from shared memory, without issuing the tx.
+func (s *Service) CheckImportTx(_ *http.Request, args *api.FormattedTx, reply *CheckImportTxReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "checkImportTx"),
+	)
+
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	tx, err := txs.Parse(txs.Codec, txBytes)
+	if err != nil {
+		return fmt.Errorf("couldn't parse tx: %w", err)
+	}
+	importTx, ok := tx.Unsigned.(*txs.ImportTx)
+	if !ok {
+		return fmt.Errorf("%w: %T", errNotImportTx, tx.Unsigned)
+	}
+
+	reply.SourceChain = importTx.SourceChain
+	reply.MissingUTXOs = []avax.UTXOID{}
+	for _, in := range importTx.ImportedInputs {
+		utxoID := in.InputID()
+		_, err := s.vm.ctx.SharedMemory.Get(importTx.SourceChain, [][]byte{utxoID[:]})
+		if err == database.ErrNotFound {
+			reply.MissingUTXOs = append(reply.MissingUTXOs, in.UTXOID)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get UTXO %s from shared memory: %w", &in.UTXOID, err)
+		}
+	}
 	return nil
 }
 
@@ -2420,6 +3166,9 @@ type GetStakeArgs struct {
 	api.JSONAddresses
 	ValidatorsOnly bool                `json:"validatorsOnly"`
 	Encoding       formatting.Encoding `json:"encoding"`
+	// Optional proof that the caller controls [Addresses]. If provided, the
+	// reply includes a breakdown of the stake per staker tx.
+	Proof *StakeOwnershipProof `json:"proof"`
 }
 
 // GetStakeReply is the response from calling GetStake.
@@ -2431,6 +3180,18 @@ type GetStakeReply struct {
 	Outputs []string `json:"stakedOutputs"`
 	// Encoding of [Outputs]
 	Encoding formatting.Encoding `json:"encoding"`
+
+	// The following fields are only populated if an ownership proof was
+	// provided.
+
+	// Message that the addresses were proven with
+	Message string `json:"message,omitempty"`
+	// Amounts staked by validator txs
+	ValidatorStakeds map[ids.ID]avajson.Uint64 `json:"validatorStakeds,omitempty"`
+	// Amounts staked by delegator txs
+	DelegatorStakeds map[ids.ID]avajson.Uint64 `json:"delegatorStakeds,omitempty"`
+	// Stake per staker tx
+	Stakers []StakerStake `json:"stakers,omitempty"`
 }
 
 // GetStake returns the amount of nAVAX that [args.Addresses] have cumulatively
@@ -2441,7 +3202,7 @@ type GetStakeReply struct {
 // This method only concerns itself with the Primary Network, not subnets
 // TODO: Improve the performance of this method by maintaining this data
 // in a data structure rather than re-calculating it by iterating over stakers
-func (s *Service) GetStake(_ *http.Request, args *GetStakeArgs, response *GetStakeReply) error {
+func (s *Service) GetStake(r *http.Request, args *GetStakeArgs, response *GetStakeReply) error {
 	s.vm.ctx.Log.Debug("deprecated API called",
 		zap.String("service", "platform"),
 		zap.String("method", "getStake"),
@@ -2455,6 +3216,14 @@ func (s *Service) GetStake(_ *http.Request, args *GetStakeArgs, response *GetSta
 	if err != nil {
 		return err
 	}
+	if err := s.addressAllowLists.authorize(r, addrs); err != nil {
+		return err
+	}
+	if args.Proof != nil {
+		if err := args.Proof.verify(args.Encoding, addrs); err != nil {
+			return err
+		}
+	}
 
 	s.vm.ctx.Lock.Lock()
 	defer s.vm.ctx.Lock.Unlock()
@@ -2468,6 +3237,7 @@ func (s *Service) GetStake(_ *http.Request, args *GetStakeArgs, response *GetSta
 	var (
 		totalAmountStaked = make(map[ids.ID]uint64)
 		stakedOuts        []avax.TransferableOutput
+		stakers           []StakerStake
 	)
 	for currentStakerIterator.Next() { // Iterates over current stakers
 		staker := currentStakerIterator.Value()
@@ -2481,7 +3251,11 @@ func (s *Service) GetStake(_ *http.Request, args *GetStakeArgs, response *GetSta
 			return err
 		}
 
-		stakedOuts = append(stakedOuts, getStakeHelper(tx, addrs, totalAmountStaked)...)
+		outs := getStakeHelper(tx, addrs, totalAmountStaked)
+		stakedOuts = append(stakedOuts, outs...)
+		if args.Proof != nil && len(outs) > 0 {
+			stakers = append(stakers, newStakerStake(staker, outs))
+		}
 	}
 
 	pendingStakerIterator, err := s.vm.state.GetPendingStakerIterator()
@@ -2502,7 +3276,29 @@ func (s *Service) GetStake(_ *http.Request, args *GetStakeArgs, response *GetSta
 			return err
 		}
 
-		stakedOuts = append(stakedOuts, getStakeHelper(tx, addrs, totalAmountStaked)...)
+		outs := getStakeHelper(tx, addrs, totalAmountStaked)
+		stakedOuts = append(stakedOuts, outs...)
+		if args.Proof != nil && len(outs) > 0 {
+			stakers = append(stakers, newStakerStake(staker, outs))
+		}
+	}
+
+	if args.Proof != nil {
+		var (
+			validatorStaked = make(map[ids.ID]uint64)
+			delegatorStaked = make(map[ids.ID]uint64)
+		)
+		for _, staker := range stakers {
+			if staker.Delegator {
+				addStakeds(delegatorStaked, staker.Stakeds)
+			} else {
+				addStakeds(validatorStaked, staker.Stakeds)
+			}
+		}
+		response.Message = args.Proof.Message
+		response.ValidatorStakeds = newJSONBalanceMap(validatorStaked)
+		response.DelegatorStakeds = newJSONBalanceMap(delegatorStaked)
+		response.Stakers = stakers
 	}
 
 	response.Stakeds = newJSONBalanceMap(totalAmountStaked)
@@ -2523,6 +3319,129 @@ func (s *Service) GetStake(_ *http.Request, args *GetStakeArgs, response *GetSta
 	return nil
 }
 
+// GetSpendabilityReply is the response from calling GetSpendability
+type GetSpendabilityReply struct {
+	// Chain time the spendability was evaluated at
+	Time time.Time `json:"time"`
+	// UTXOs that can be spent now
+	Spendable []APISpendabilityUTXO `json:"spendable"`
+	// UTXOs that can't be spent now, sorted by unlock time. Stakeable locked
+	// UTXOs can already be staked if their stakeable time has passed.
+	Locked []APISpendabilityUTXO `json:"locked"`
+	// Outputs bonded in current and pending stakes, sorted by unlock time
+	Bonded []APIBondedOutput `json:"bonded"`
+	// Amounts of each asset in [Spendable], [Locked] and [Bonded]
+	Spendables map[ids.ID]avajson.Uint64 `json:"spendables"`
+	Lockeds    map[ids.ID]avajson.Uint64 `json:"lockeds"`
+	Bondeds    map[ids.ID]avajson.Uint64 `json:"bondeds"`
+}
+
+// GetSpendability itemizes the UTXOs of [args.Addresses] that can be spent at
+// the current chain time, the UTXOs that unlock later, and the outputs that are
+// bonded in stakes, along with the times they can be spent.
+func (s *Service) GetSpendability(r *http.Request, args *api.JSONAddresses, reply *GetSpendabilityReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getSpendability"),
+		logging.UserStrings("addresses", args.Addresses),
+	)
+
+	if len(args.Addresses) == 0 {
+		return errNoAddresses
+	}
+	if len(args.Addresses) > maxGetStakeAddrs {
+		return fmt.Errorf("%d addresses provided but this method can take at most %d", len(args.Addresses), maxGetStakeAddrs)
+	}
+
+	addrs, err := avax.ParseServiceAddresses(s.addrManager, args.Addresses)
+	if err != nil {
+		return err
+	}
+	if err := s.addressAllowLists.authorize(r, addrs); err != nil {
+		return err
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	utxos, err := avax.GetAllUTXOs(s.vm.state, addrs)
+	if err != nil {
+		return fmt.Errorf("couldn't get UTXO set of %v: %w", args.Addresses, err)
+	}
+
+	var (
+		chainTime   = s.vm.state.GetTimestamp()
+		currentTime = uint64(chainTime.Unix())
+		spendables  = make(map[ids.ID]uint64)
+		lockeds     = make(map[ids.ID]uint64)
+		bondeds     = make(map[ids.ID]uint64)
+	)
+	reply.Time = chainTime
+	reply.Spendable = []APISpendabilityUTXO{}
+	reply.Locked = []APISpendabilityUTXO{}
+	reply.Bonded = []APIBondedOutput{}
+	for _, utxo := range utxos {
+		out, ok := utxo.Out.(avax.TransferableOut)
+		if !ok {
+			continue
+		}
+		stakeableTime, unlockTime, ok := outputLocktimes(out)
+		if !ok {
+			continue
+		}
+
+		assetID := utxo.AssetID()
+		apiUTXO := APISpendabilityUTXO{
+			UTXOID:        utxo.UTXOID,
+			AssetID:       assetID,
+			Amount:        avajson.Uint64(out.Amount()),
+			StakeableTime: avajson.Uint64(stakeableTime),
+			UnlockTime:    avajson.Uint64(unlockTime),
+		}
+		if unlockTime <= currentTime {
+			reply.Spendable = append(reply.Spendable, apiUTXO)
+			addAmount(spendables, assetID, out.Amount())
+		} else {
+			reply.Locked = append(reply.Locked, apiUTXO)
+			addAmount(lockeds, assetID, out.Amount())
+		}
+	}
+
+	for _, getStakerIterator := range []func() (state.StakerIterator, error){
+		s.vm.state.GetCurrentStakerIterator,
+		s.vm.state.GetPendingStakerIterator,
+	} {
+		stakerIterator, err := getStakerIterator()
+		if err != nil {
+			return err
+		}
+		for stakerIterator.Next() {
+			staker := stakerIterator.Value()
+			tx, _, err := s.vm.state.GetTx(staker.TxID)
+			if err != nil {
+				stakerIterator.Release()
+				return fmt.Errorf("couldn't get tx of staker %s: %w", staker.TxID, err)
+			}
+			for _, bonded := range newBondedOutputs(staker, tx, addrs) {
+				reply.Bonded = append(reply.Bonded, bonded)
+				addAmount(bondeds, bonded.AssetID, uint64(bonded.Amount))
+			}
+		}
+		stakerIterator.Release()
+	}
+
+	slices.SortStableFunc(reply.Locked, func(a, b APISpendabilityUTXO) int {
+		return cmp.Compare(a.UnlockTime, b.UnlockTime)
+	})
+	slices.SortStableFunc(reply.Bonded, func(a, b APIBondedOutput) int {
+		return cmp.Compare(a.UnlockTime, b.UnlockTime)
+	})
+	reply.Spendables = newJSONBalanceMap(spendables)
+	reply.Lockeds = newJSONBalanceMap(lockeds)
+	reply.Bondeds = newJSONBalanceMap(bondeds)
+	return nil
+}
+
 // GetMinStakeArgs are the arguments for calling GetMinStake.
 type GetMinStakeArgs struct {
 	SubnetID ids.ID `json:"subnetID"`
@@ -2543,17 +3462,19 @@ func (s *Service) GetMinStake(_ *http.Request, args *GetMinStakeArgs, reply *Get
 		zap.String("method", "getMinStake"),
 	)
 
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
 	if args.SubnetID == constants.PrimaryNetworkID {
-		timestamp := s.vm.state.GetTimestamp()
-		minValidatorStake, _, minDelegatorStake, _, _, _, _, _, _, _ := executor.GetCurrentInflationSettings(timestamp, s.vm.ctx.NetworkID, &s.vm.Config)
-		reply.MinValidatorStake = avajson.Uint64(minValidatorStake)
-		reply.MinDelegatorStake = avajson.Uint64(minDelegatorStake)
+		params, err := s.getStakingParameters()
+		if err != nil {
+			return err
+		}
+		reply.MinValidatorStake = params.MinValidatorStake
+		reply.MinDelegatorStake = params.MinDelegatorStake
 		return nil
 	}
 
-	s.vm.ctx.Lock.Lock()
-	defer s.vm.ctx.Lock.Unlock()
-
 	transformSubnetIntf, err := s.vm.state.GetSubnetTransformation(args.SubnetID)
 	if err != nil {
 		return fmt.Errorf(
@@ -2576,26 +3497,159 @@ func (s *Service) GetMinStake(_ *http.Request, args *GetMinStakeArgs, reply *Get
 	return nil
 }
 
-// GetTotalStakeArgs are the arguments for calling GetTotalStake
-type GetTotalStakeArgs struct {
-	// Subnet we're getting the total stake
-	// If omitted returns Primary network weight
-	SubnetID ids.ID `json:"subnetID"`
+// APIParameterChange is a value of a runtime parameter scheduled by
+// governance
+type APIParameterChange struct {
+	Parameter      string         `json:"parameter"`
+	Value          avajson.Uint64 `json:"value"`
+	ActivationTime avajson.Uint64 `json:"activationTime"`
 }
 
-// GetTotalStakeReply is the response from calling GetTotalStake.
-type GetTotalStakeReply struct {
-	// Deprecated: Use Weight instead.
-	Stake avajson.Uint64 `json:"stake"`
-
-	Weight avajson.Uint64 `json:"weight"`
+// GetStakingParametersReply is the response from calling
+// GetStakingParameters. Amounts are denominated in nAVAX and durations in
+// seconds.
+type GetStakingParametersReply struct {
+	// Chain time the parameters are effective at
+	Timestamp avajson.Uint64 `json:"timestamp"`
+
+	MinValidatorStake        avajson.Uint64 `json:"minValidatorStake"`
+	MaxValidatorStake        avajson.Uint64 `json:"maxValidatorStake"`
+	MinDelegatorStake        avajson.Uint64 `json:"minDelegatorStake"`
+	MinDelegationFee         avajson.Uint32 `json:"minDelegationFee"`
+	MinStakeDuration         avajson.Uint64 `json:"minStakeDuration"`
+	MinDelegateDuration      avajson.Uint64 `json:"minDelegateDuration"`
+	MaxStakeDuration         avajson.Uint64 `json:"maxStakeDuration"`
+	MinFutureStartTimeOffset avajson.Uint64 `json:"minFutureStartTimeOffset"`
+	MaxValidatorWeightFactor avajson.Uint64 `json:"maxValidatorWeightFactor"`
+	// Uptime, as a percentage, a staker must have to be rewarded
+	UptimeRequirement avajson.Float64 `json:"uptimeRequirement"`
+
+	TxFee                         avajson.Uint64 `json:"txFee"`
+	CreateSubnetTxFee             avajson.Uint64 `json:"createSubnetTxFee"`
+	CreateBlockchainTxFee         avajson.Uint64 `json:"createBlockchainTxFee"`
+	TransformSubnetTxFee          avajson.Uint64 `json:"transformSubnetTxFee"`
+	AddPrimaryNetworkValidatorFee avajson.Uint64 `json:"addPrimaryNetworkValidatorFee"`
+	AddPrimaryNetworkDelegatorFee avajson.Uint64 `json:"addPrimaryNetworkDelegatorFee"`
+	AddSubnetValidatorFee         avajson.Uint64 `json:"addSubnetValidatorFee"`
+	AddSubnetDelegatorFee         avajson.Uint64 `json:"addSubnetDelegatorFee"`
+	NameRegistrationFee           avajson.Uint64 `json:"nameRegistrationFee"`
+
+	// Changes of the runtime parameters scheduled by governance after
+	// [Timestamp], ordered by activation time
+	ScheduledChanges []APIParameterChange `json:"scheduledChanges"`
 }
 
-// GetTotalStake returns the total amount staked on the Primary Network
-func (s *Service) GetTotalStake(_ *http.Request, args *GetTotalStakeArgs, reply *GetTotalStakeReply) error {
+// GetStakingParameters returns the staking parameters and the fees of the
+// primary network that are effective at the current chain time, along with the
+// changes of them that are scheduled by governance.
+func (s *Service) GetStakingParameters(_ *http.Request, _ *struct{}, reply *GetStakingParametersReply) error {
 	s.vm.ctx.Log.Debug("API called",
 		zap.String("service", "platform"),
-		zap.String("method", "getTotalStake"),
+		zap.String("method", "getStakingParameters"),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	params, err := s.getStakingParameters()
+	if err != nil {
+		return err
+	}
+	*reply = *params
+	return nil
+}
+
+func (s *Service) getStakingParameters() (*GetStakingParametersReply, error) {
+	var (
+		cfg       = &s.vm.Config
+		chainTime = s.vm.state.GetTimestamp()
+		now       = uint64(chainTime.Unix())
+	)
+	minValidatorStake, maxValidatorStake, minDelegatorStake, minDelegationFee, minStakeDuration, minDelegateDuration, maxStakeDuration, minFutureStartTimeOffset, maxValidatorWeightFactor, _ := executor.GetCurrentInflationSettings(chainTime, s.vm.ctx.NetworkID, cfg)
+
+	// The runtime parameters default to the values of the config, or of the
+	// inflation settings for the stake amounts.
+	defaults := map[txs.Parameter]uint64{
+		txs.TxFeeParameter:                         cfg.TxFee,
+		txs.AddPrimaryNetworkValidatorFeeParameter: cfg.AddPrimaryNetworkValidatorFee,
+		txs.AddPrimaryNetworkDelegatorFeeParameter: cfg.AddPrimaryNetworkDelegatorFee,
+		txs.MinValidatorStakeParameter:             minValidatorStake,
+		txs.MinDelegatorStakeParameter:             minDelegatorStake,
+		txs.NameRegistrationFeeParameter:           cfg.NameRegistrationFee,
+	}
+	values := make(map[txs.Parameter]uint64, len(txs.Parameters))
+	var scheduledChanges []APIParameterChange
+	for _, parameter := range txs.Parameters {
+		value, err := state.GetParameter(s.vm.state, parameter, defaults[parameter])
+		if err != nil {
+			return nil, err
+		}
+		values[parameter] = value
+
+		changes, err := s.vm.state.GetParameterChanges(parameter)
+		if err != nil {
+			return nil, err
+		}
+		for _, change := range changes {
+			if change.ActivationTime <= now {
+				continue
+			}
+			scheduledChanges = append(scheduledChanges, APIParameterChange{
+				Parameter:      parameter.String(),
+				Value:          avajson.Uint64(change.Value),
+				ActivationTime: avajson.Uint64(change.ActivationTime),
+			})
+		}
+	}
+	slices.SortStableFunc(scheduledChanges, func(a, b APIParameterChange) int {
+		return cmp.Compare(a.ActivationTime, b.ActivationTime)
+	})
+
+	return &GetStakingParametersReply{
+		Timestamp:                     avajson.Uint64(now),
+		MinValidatorStake:             avajson.Uint64(values[txs.MinValidatorStakeParameter]),
+		MaxValidatorStake:             avajson.Uint64(maxValidatorStake),
+		MinDelegatorStake:             avajson.Uint64(values[txs.MinDelegatorStakeParameter]),
+		MinDelegationFee:              avajson.Uint32(minDelegationFee),
+		MinStakeDuration:              avajson.Uint64(minStakeDuration / time.Second),
+		MinDelegateDuration:           avajson.Uint64(minDelegateDuration / time.Second),
+		MaxStakeDuration:              avajson.Uint64(maxStakeDuration / time.Second),
+		MinFutureStartTimeOffset:      avajson.Uint64(minFutureStartTimeOffset / time.Second),
+		MaxValidatorWeightFactor:      avajson.Uint64(maxValidatorWeightFactor),
+		UptimeRequirement:             avajson.Float64(100 * cfg.UptimePercentage),
+		TxFee:                         avajson.Uint64(values[txs.TxFeeParameter]),
+		CreateSubnetTxFee:             avajson.Uint64(cfg.GetCreateSubnetTxFee(chainTime)),
+		CreateBlockchainTxFee:         avajson.Uint64(cfg.GetCreateBlockchainTxFee(chainTime)),
+		TransformSubnetTxFee:          avajson.Uint64(cfg.TransformSubnetTxFee),
+		AddPrimaryNetworkValidatorFee: avajson.Uint64(values[txs.AddPrimaryNetworkValidatorFeeParameter]),
+		AddPrimaryNetworkDelegatorFee: avajson.Uint64(values[txs.AddPrimaryNetworkDelegatorFeeParameter]),
+		AddSubnetValidatorFee:         avajson.Uint64(cfg.AddSubnetValidatorFee),
+		AddSubnetDelegatorFee:         avajson.Uint64(cfg.AddSubnetDelegatorFee),
+		NameRegistrationFee:           avajson.Uint64(values[txs.NameRegistrationFeeParameter]),
+		ScheduledChanges:              scheduledChanges,
+	}, nil
+}
+
+// GetTotalStakeArgs are the arguments for calling GetTotalStake
+type GetTotalStakeArgs struct {
+	// Subnet we're getting the total stake
+	// If omitted returns Primary network weight
+	SubnetID ids.ID `json:"subnetID"`
+}
+
+// GetTotalStakeReply is the response from calling GetTotalStake.
+type GetTotalStakeReply struct {
+	// Deprecated: Use Weight instead.
+	Stake avajson.Uint64 `json:"stake"`
+
+	Weight avajson.Uint64 `json:"weight"`
+}
+
+// GetTotalStake returns the total amount staked on the Primary Network
+func (s *Service) GetTotalStake(_ *http.Request, args *GetTotalStakeArgs, reply *GetTotalStakeReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getTotalStake"),
 	)
 
 	totalWeight, err := s.vm.Validators.TotalWeight(args.SubnetID)
@@ -2664,169 +3718,1897 @@ func (s *Service) GetMaxStakeAmount(_ *http.Request, args *GetMaxStakeAmountArgs
 	return err
 }
 
-// GetRewardUTXOsReply defines the GetRewardUTXOs replies returned from the API
-type GetRewardUTXOsReply struct {
-	// Number of UTXOs returned
-	NumFetched avajson.Uint64 `json:"numFetched"`
-	// The UTXOs
-	UTXOs []string `json:"utxos"`
-	// Encoding specifies the encoding format the UTXOs are returned in
-	Encoding formatting.Encoding `json:"encoding"`
+// GetValidatorStakesArgs are the arguments for calling GetValidatorStakes
+type GetValidatorStakesArgs struct {
+	SubnetID ids.ID `json:"subnetID"`
+	// NodeIDs of the validators to return. If empty, every permissionless
+	// validator of the subnet is returned.
+	NodeIDs []ids.NodeID `json:"nodeIDs"`
 }
 
-// GetRewardUTXOs returns the UTXOs that were rewarded after the provided
-// transaction's staking period ended.
-func (s *Service) GetRewardUTXOs(_ *http.Request, args *api.GetTxArgs, reply *GetRewardUTXOsReply) error {
-	s.vm.ctx.Log.Debug("deprecated API called",
+// APIDelegation is a current or pending delegation to a validator
+type APIDelegation struct {
+	TxID      ids.ID         `json:"txID"`
+	Weight    avajson.Uint64 `json:"weight"`
+	StartTime avajson.Uint64 `json:"startTime"`
+	EndTime   avajson.Uint64 `json:"endTime"`
+	Pending   bool           `json:"pending"`
+}
+
+// APIValidatorStake is the stake of a current or pending validator, including
+// the stake delegated to it
+type APIValidatorStake struct {
+	NodeID    ids.NodeID     `json:"nodeID"`
+	TxID      ids.ID         `json:"txID"`
+	StartTime avajson.Uint64 `json:"startTime"`
+	EndTime   avajson.Uint64 `json:"endTime"`
+	Pending   bool           `json:"pending"`
+	// SelfStake is the weight of the validator itself
+	SelfStake avajson.Uint64 `json:"selfStake"`
+	// DelegatedStake is the total weight of the current and pending
+	// delegations to the validator
+	DelegatedStake avajson.Uint64 `json:"delegatedStake"`
+	// MaxWeight is the total weight that delegations can't make the validator
+	// exceed: its self stake multiplied by the max validator weight factor,
+	// bounded by the maximum validator stake.
+	MaxWeight avajson.Uint64 `json:"maxWeight"`
+	// PeakWeight is the highest total weight of the validator from the later
+	// of the current chain time and its start time until its end time.
+	PeakWeight avajson.Uint64 `json:"peakWeight"`
+	// RemainingCapacity is the weight that can still be delegated to the
+	// validator until its end time without over delegating it.
+	RemainingCapacity avajson.Uint64 `json:"remainingCapacity"`
+	// Delegations ordered by start time
+	Delegations []APIDelegation `json:"delegations"`
+}
+
+// GetValidatorStakesReply is the response from calling GetValidatorStakes
+type GetValidatorStakesReply struct {
+	// Validators ordered by node ID
+	Validators []APIValidatorStake `json:"validators"`
+}
+
+// GetValidatorStakes returns the self stake, the delegated stake and the
+// remaining delegation capacity of the current and pending permissionless
+// validators of a subnet, along with the period of each of their delegations.
+func (s *Service) GetValidatorStakes(_ *http.Request, args *GetValidatorStakesArgs, reply *GetValidatorStakesReply) error {
+	s.vm.ctx.Log.Debug("API called",
 		zap.String("service", "platform"),
-		zap.String("method", "getRewardUTXOs"),
+		zap.String("method", "getValidatorStakes"),
+		zap.Stringer("subnetID", args.SubnetID),
 	)
 
 	s.vm.ctx.Lock.Lock()
 	defer s.vm.ctx.Lock.Unlock()
 
-	utxos, err := s.vm.state.GetRewardUTXOs(args.TxID)
-	if err != nil {
-		return fmt.Errorf("couldn't get reward UTXOs: %w", err)
+	nodeIDs := set.Of(args.NodeIDs...)
+	var validators []*state.Staker
+	for _, getStakerIterator := range []func() (state.StakerIterator, error){
+		s.vm.state.GetCurrentStakerIterator,
+		s.vm.state.GetPendingStakerIterator,
+	} {
+		stakerIterator, err := getStakerIterator()
+		if err != nil {
+			return err
+		}
+		for stakerIterator.Next() {
+			staker := stakerIterator.Value()
+			if staker.SubnetID != args.SubnetID ||
+				!staker.Priority.IsValidator() ||
+				staker.Priority.IsPermissionedValidator() ||
+				(nodeIDs.Len() != 0 && !nodeIDs.Contains(staker.NodeID)) {
+				continue
+			}
+			validators = append(validators, staker)
+		}
+		stakerIterator.Release()
 	}
 
-	reply.NumFetched = avajson.Uint64(len(utxos))
-	reply.UTXOs = make([]string, len(utxos))
-	for i, utxo := range utxos {
-		utxoBytes, err := txs.GenesisCodec.Marshal(txs.CodecVersion, utxo)
+	backend := &executor.Backend{
+		Config: &s.vm.Config,
+		Ctx:    s.vm.ctx,
+	}
+	chainTime := s.vm.state.GetTimestamp()
+	reply.Validators = make([]APIValidatorStake, 0, len(validators))
+	for _, validator := range validators {
+		stake, err := s.getValidatorStake(backend, chainTime, validator)
 		if err != nil {
-			return fmt.Errorf("couldn't encode UTXO to bytes: %w", err)
+			return err
 		}
+		reply.Validators = append(reply.Validators, stake)
+	}
+	slices.SortFunc(reply.Validators, func(a, b APIValidatorStake) int {
+		return a.NodeID.Compare(b.NodeID)
+	})
+	return nil
+}
 
-		utxoStr, err := formatting.Encode(args.Encoding, utxoBytes)
+func (s *Service) getValidatorStake(
+	backend *executor.Backend,
+	chainTime time.Time,
+	validator *state.Staker,
+) (APIValidatorStake, error) {
+	stake := APIValidatorStake{
+		NodeID:      validator.NodeID,
+		TxID:        validator.TxID,
+		StartTime:   avajson.Uint64(validator.StartTime.Unix()),
+		EndTime:     avajson.Uint64(validator.EndTime.Unix()),
+		Pending:     validator.Priority.IsPending(),
+		SelfStake:   avajson.Uint64(validator.Weight),
+		Delegations: []APIDelegation{},
+	}
+
+	var delegatedStake uint64
+	for _, getDelegatorIterator := range []func(ids.ID, ids.NodeID) (state.StakerIterator, error){
+		s.vm.state.GetCurrentDelegatorIterator,
+		s.vm.state.GetPendingDelegatorIterator,
+	} {
+		delegatorIterator, err := getDelegatorIterator(validator.SubnetID, validator.NodeID)
 		if err != nil {
-			return fmt.Errorf("couldn't encode utxo as %s: %w", args.Encoding, err)
+			return APIValidatorStake{}, err
 		}
-		reply.UTXOs[i] = utxoStr
+		for delegatorIterator.Next() {
+			delegator := delegatorIterator.Value()
+			delegatedStake, err = safemath.Add64(delegatedStake, delegator.Weight)
+			if err != nil {
+				delegatorIterator.Release()
+				return APIValidatorStake{}, err
+			}
+			stake.Delegations = append(stake.Delegations, APIDelegation{
+				TxID:      delegator.TxID,
+				Weight:    avajson.Uint64(delegator.Weight),
+				StartTime: avajson.Uint64(delegator.StartTime.Unix()),
+				EndTime:   avajson.Uint64(delegator.EndTime.Unix()),
+				Pending:   delegator.Priority.IsPending(),
+			})
+		}
+		delegatorIterator.Release()
 	}
-	reply.Encoding = args.Encoding
-	return nil
+	stake.DelegatedStake = avajson.Uint64(delegatedStake)
+	slices.SortFunc(stake.Delegations, func(a, b APIDelegation) int {
+		if a.StartTime != b.StartTime {
+			return cmp.Compare(a.StartTime, b.StartTime)
+		}
+		return a.TxID.Compare(b.TxID)
+	})
+
+	maxWeight, err := executor.GetMaximumWeight(backend, s.vm.state, validator)
+	if err != nil {
+		return APIValidatorStake{}, err
+	}
+	stake.MaxWeight = avajson.Uint64(maxWeight)
+
+	startTime := validator.StartTime
+	if startTime.Before(chainTime) {
+		startTime = chainTime
+	}
+	if !startTime.Before(validator.EndTime) {
+		// The validator is about to be removed, so nothing can be delegated
+		// to it anymore.
+		stake.PeakWeight = stake.SelfStake
+		return stake, nil
+	}
+
+	peakWeight, err := executor.GetMaxWeight(s.vm.state, validator, startTime, validator.EndTime)
+	if err != nil {
+		return APIValidatorStake{}, err
+	}
+	stake.PeakWeight = avajson.Uint64(peakWeight)
+	if peakWeight < maxWeight {
+		stake.RemainingCapacity = avajson.Uint64(maxWeight - peakWeight)
+	}
+	return stake, nil
 }
 
-// GetTimestampReply is the response from GetTimestamp
-type GetTimestampReply struct {
-	// Current timestamp
-	Timestamp time.Time `json:"timestamp"`
+// GetStakeCompositionArgs are the arguments for calling GetStakeComposition
+type GetStakeCompositionArgs struct {
+	// Height to return the composition at. Only the last accepted height is
+	// available. Defaults to the last accepted height.
+	Height *avajson.Uint64 `json:"height"`
+	// Validators to return the composition of. Defaults to every current
+	// primary network validator.
+	NodeIDs []ids.NodeID `json:"nodeIDs"`
 }
 
-// GetTimestamp returns the current timestamp on chain.
-func (s *Service) GetTimestamp(_ *http.Request, _ *struct{}, reply *GetTimestampReply) error {
+// APIStakeMirrorEntry is the stake that an address put into a validator, as it
+// is hashed into the stake mirror root of the validator
+type APIStakeMirrorEntry struct {
+	TxID      ids.ID         `json:"txID"`
+	Address   string         `json:"address"`
+	Amount    avajson.Uint64 `json:"amount"`
+	StartTime avajson.Uint64 `json:"startTime"`
+	EndTime   avajson.Uint64 `json:"endTime"`
+}
+
+// APIStakeComposition is the stake of a validator and of its delegators
+type APIStakeComposition struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	// Root of the Merkle tree of the hashes of [Entries]
+	Root ids.ID `json:"root"`
+	// Entries ordered by txID and then by address
+	Entries []APIStakeMirrorEntry `json:"entries"`
+}
+
+// GetStakeCompositionReply is the response from calling GetStakeComposition
+type GetStakeCompositionReply struct {
+	Height  avajson.Uint64 `json:"height"`
+	BlockID ids.ID         `json:"blockID"`
+	// Validators ordered by node ID
+	Validators []APIStakeComposition `json:"validators"`
+}
+
+// GetStakeComposition returns the entries of the stake of the current primary
+// network validators and of their current delegators, along with the stake
+// mirror root of every validator, so that the root can be reproduced
+// off-chain.
+//
+// The hash of an entry is the SHA-256 hash of its txID, its address, and its
+// big endian amount, start time and end time. The root is computed over the
+// hashes of the entries as [block.TxRoot] is over txIDs.
+func (s *Service) GetStakeComposition(_ *http.Request, args *GetStakeCompositionArgs, reply *GetStakeCompositionReply) error {
 	s.vm.ctx.Log.Debug("API called",
 		zap.String("service", "platform"),
-		zap.String("method", "getTimestamp"),
+		zap.String("method", "getStakeComposition"),
 	)
 
 	s.vm.ctx.Lock.Lock()
 	defer s.vm.ctx.Lock.Unlock()
 
-	reply.Timestamp = s.vm.state.GetTimestamp()
-	return nil
-}
-
-// GetValidatorsAtArgs is the response from GetValidatorsAt
-type GetValidatorsAtArgs struct {
-	Height   avajson.Uint64 `json:"height"`
-	SubnetID ids.ID         `json:"subnetID"`
-}
+	lastAcceptedID := s.vm.state.GetLastAccepted()
+	lastAccepted, err := s.vm.state.GetStatelessBlock(lastAcceptedID)
+	if err != nil {
+		return err
+	}
+	height := lastAccepted.Height()
+	if args.Height != nil && uint64(*args.Height) != height {
+		return fmt.Errorf("%w: requested %d, last accepted %d",
+			errStakeCompositionHeight,
+			uint64(*args.Height),
+			height,
+		)
+	}
 
-type jsonGetValidatorOutput struct {
-	PublicKey *string        `json:"publicKey"`
-	Weight    avajson.Uint64 `json:"weight"`
-}
+	nodeIDs := set.Of(args.NodeIDs...)
+	stakerIterator, err := s.vm.state.GetCurrentStakerIterator()
+	if err != nil {
+		return err
+	}
+	defer stakerIterator.Release()
 
-func (v *GetValidatorsAtReply) MarshalJSON() ([]byte, error) {
-	m := make(map[ids.NodeID]*jsonGetValidatorOutput, len(v.Validators))
-	for _, vdr := range v.Validators {
-		vdrJSON := &jsonGetValidatorOutput{
-			Weight: avajson.Uint64(vdr.Weight),
+	reply.Height = avajson.Uint64(height)
+	reply.BlockID = lastAcceptedID
+	reply.Validators = []APIStakeComposition{}
+	for stakerIterator.Next() {
+		staker := stakerIterator.Value()
+		if staker.SubnetID != constants.PrimaryNetworkID ||
+			!staker.Priority.IsCurrentValidator() ||
+			(nodeIDs.Len() != 0 && !nodeIDs.Contains(staker.NodeID)) {
+			continue
 		}
 
-		if vdr.PublicKey != nil {
-			pk, err := formatting.Encode(formatting.HexNC, bls.PublicKeyToBytes(vdr.PublicKey))
-			if err != nil {
-				return nil, err
-			}
-			vdrJSON.PublicKey = &pk
+		composition, err := s.getStakeComposition(staker)
+		if err != nil {
+			return err
 		}
-
-		m[vdr.NodeID] = vdrJSON
+		reply.Validators = append(reply.Validators, composition)
 	}
-	return json.Marshal(m)
+	slices.SortFunc(reply.Validators, func(a, b APIStakeComposition) int {
+		return a.NodeID.Compare(b.NodeID)
+	})
+	return nil
 }
 
-func (v *GetValidatorsAtReply) UnmarshalJSON(b []byte) error {
-	var m map[ids.NodeID]*jsonGetValidatorOutput
-	if err := json.Unmarshal(b, &m); err != nil {
-		return err
+func (s *Service) getStakeComposition(validator *state.Staker) (APIStakeComposition, error) {
+	stakers := []*state.Staker{validator}
+	delegatorIterator, err := s.vm.state.GetCurrentDelegatorIterator(validator.SubnetID, validator.NodeID)
+	if err != nil {
+		return APIStakeComposition{}, err
 	}
-
-	if m == nil {
-		v.Validators = nil
-		return nil
+	for delegatorIterator.Next() {
+		stakers = append(stakers, delegatorIterator.Value())
 	}
+	delegatorIterator.Release()
 
-	v.Validators = make(map[ids.NodeID]*validators.GetValidatorOutput, len(m))
-	for nodeID, vdrJSON := range m {
-		vdr := &validators.GetValidatorOutput{
-			NodeID: nodeID,
-			Weight: uint64(vdrJSON.Weight),
+	var entries []stakeMirrorEntry
+	for _, staker := range stakers {
+		tx, _, err := s.vm.state.GetTx(staker.TxID)
+		if err != nil {
+			return APIStakeComposition{}, fmt.Errorf("couldn't get staker tx %s: %w", staker.TxID, err)
 		}
-
-		if vdrJSON.PublicKey != nil {
-			pkBytes, err := formatting.Decode(formatting.HexNC, *vdrJSON.PublicKey)
-			if err != nil {
-				return err
-			}
-			vdr.PublicKey, err = bls.PublicKeyFromBytes(pkBytes)
-			if err != nil {
-				return err
-			}
+		stakerEntries, err := stakeMirrorEntries(staker, tx)
+		if err != nil {
+			return APIStakeComposition{}, err
 		}
+		entries = append(entries, stakerEntries...)
+	}
+	sortStakeMirrorEntries(entries)
 
-		v.Validators[nodeID] = vdr
+	composition := APIStakeComposition{
+		NodeID:  validator.NodeID,
+		Root:    stakeMirrorRoot(entries),
+		Entries: make([]APIStakeMirrorEntry, len(entries)),
 	}
-	return nil
+	for i, entry := range entries {
+		addr, err := s.addrManager.FormatLocalAddress(entry.Address)
+		if err != nil {
+			return APIStakeComposition{}, err
+		}
+		composition.Entries[i] = APIStakeMirrorEntry{
+			TxID:      entry.TxID,
+			Address:   addr,
+			Amount:    avajson.Uint64(entry.Amount),
+			StartTime: avajson.Uint64(entry.StartTime),
+			EndTime:   avajson.Uint64(entry.EndTime),
+		}
+	}
+	return composition, nil
 }
 
-// GetValidatorsAtReply is the response from GetValidatorsAt
-type GetValidatorsAtReply struct {
-	Validators map[ids.NodeID]*validators.GetValidatorOutput
+// GetConnectedValidatorsArgs are the arguments for calling
+// GetConnectedValidators
+type GetConnectedValidatorsArgs struct {
+	// Subnet whose validators are returned. Defaults to the primary network.
+	SubnetID ids.ID `json:"subnetID"`
 }
 
-// GetValidatorsAt returns the weights of the validator set of a provided subnet
-// at the specified height.
-func (s *Service) GetValidatorsAt(r *http.Request, args *GetValidatorsAtArgs, reply *GetValidatorsAtReply) error {
-	height := uint64(args.Height)
+// APIValidatorConnection is the connection of this node to a validator
+type APIValidatorConnection struct {
+	NodeID    ids.NodeID     `json:"nodeID"`
+	Weight    avajson.Uint64 `json:"weight"`
+	Connected bool           `json:"connected"`
+	// Unix time the validator connected, and for how many seconds it has been
+	// connected. Omitted if the validator isn't connected, or if it is this
+	// node.
+	ConnectedSince    *avajson.Uint64 `json:"connectedSince,omitempty"`
+	ConnectedDuration *avajson.Uint64 `json:"connectedDuration,omitempty"`
+}
+
+// GetConnectedValidatorsReply is the response from calling
+// GetConnectedValidators
+type GetConnectedValidatorsReply struct {
+	// Validators ordered by node ID
+	Validators []APIValidatorConnection `json:"validators"`
+	// Stake of the validators this node is connected to, including its own
+	ConnectedWeight avajson.Uint64 `json:"connectedWeight"`
+	TotalWeight     avajson.Uint64 `json:"totalWeight"`
+	// Percentage of the total stake this node is connected to
+	ConnectedPercentage avajson.Float64 `json:"connectedPercentage"`
+}
+
+// GetConnectedValidators returns, for every current validator of a subnet,
+// whether this node is connected to it and for how long, along with the
+// stake-weighted connectivity of this node.
+func (s *Service) GetConnectedValidators(_ *http.Request, args *GetConnectedValidatorsArgs, reply *GetConnectedValidatorsReply) error {
 	s.vm.ctx.Log.Debug("API called",
 		zap.String("service", "platform"),
-		zap.String("method", "getValidatorsAt"),
-		zap.Uint64("height", height),
+		zap.String("method", "getConnectedValidators"),
 		zap.Stringer("subnetID", args.SubnetID),
 	)
 
-	s.vm.ctx.Lock.Lock()
-	defer s.vm.ctx.Lock.Unlock()
+	vdrs := s.vm.Validators.GetMap(args.SubnetID)
+	connectivity := s.vm.peerConnections.connectivity(vdrs, s.vm.ctx.NodeID)
+	now := s.vm.clock.Time()
 
-	ctx := r.Context()
-	var err error
-	reply.Validators, err = s.vm.GetValidatorSet(ctx, height, args.SubnetID)
-	if err != nil {
-		return fmt.Errorf("failed to get validator set: %w", err)
+	reply.Validators = make([]APIValidatorConnection, len(connectivity.Validators))
+	for i, vdr := range connectivity.Validators {
+		reply.Validators[i] = APIValidatorConnection{
+			NodeID:    vdr.NodeID,
+			Weight:    avajson.Uint64(vdr.Weight),
+			Connected: vdr.Connected,
+		}
+		if vdr.ConnectedSince.IsZero() {
+			continue
+		}
+		connectedSince := avajson.Uint64(vdr.ConnectedSince.Unix())
+		reply.Validators[i].ConnectedSince = &connectedSince
+		var connectedDuration avajson.Uint64
+		if now.After(vdr.ConnectedSince) {
+			connectedDuration = avajson.Uint64(now.Sub(vdr.ConnectedSince) / time.Second)
+		}
+		reply.Validators[i].ConnectedDuration = &connectedDuration
 	}
+	reply.ConnectedWeight = avajson.Uint64(connectivity.ConnectedWeight)
+	reply.TotalWeight = avajson.Uint64(connectivity.TotalWeight)
+	reply.ConnectedPercentage = avajson.Float64(connectivity.ConnectedPercentage())
 	return nil
 }
 
-func (s *Service) GetBlock(_ *http.Request, args *api.GetBlockArgs, response *api.GetBlockResponse) error {
+// APIDashboardStaker is a validator of this node, or a delegator of one of its
+// validators
+type APIDashboardStaker struct {
+	TxID      ids.ID         `json:"txID"`
+	SubnetID  ids.ID         `json:"subnetID"`
+	Weight    avajson.Uint64 `json:"weight"`
+	StartTime avajson.Uint64 `json:"startTime"`
+	EndTime   avajson.Uint64 `json:"endTime"`
+	// True if the staker is current, false if it is pending
+	Current bool `json:"current"`
+}
+
+// APIDashboardValidator is a validator of this node, along with its delegators
+type APIDashboardValidator struct {
+	APIDashboardStaker
+	// Uptime of the validator, as estimated by this node. Omitted if the
+	// validator is pending or if its subnet isn't tracked.
+	Uptime          *avajson.Float32 `json:"uptime,omitempty"`
+	DelegatorWeight avajson.Uint64   `json:"delegatorWeight"`
+	// Current and pending delegators, ordered by end time
+	Delegators []APIDashboardStaker `json:"delegators"`
+}
+
+// APIUpcomingEndTime is a staker of the node dashboard that will end
+type APIUpcomingEndTime struct {
+	TxID      ids.ID         `json:"txID"`
+	SubnetID  ids.ID         `json:"subnetID"`
+	Delegator bool           `json:"delegator"`
+	EndTime   avajson.Uint64 `json:"endTime"`
+}
+
+// APIMempoolStats summarizes the txs in the mempool
+type APIMempoolStats struct {
+	NumTxs avajson.Uint64 `json:"numTxs"`
+	Bytes  avajson.Uint64 `json:"bytes"`
+	// Oldest tx of the mempool. Omitted if the mempool is empty.
+	OldestTxID *ids.ID `json:"oldestTxID,omitempty"`
+}
+
+// GetNodeDashboardReply is the response from calling GetNodeDashboard
+type GetNodeDashboardReply struct {
+	NodeID         ids.NodeID     `json:"nodeID"`
+	Height         avajson.Uint64 `json:"height"`
+	LastAcceptedID ids.ID         `json:"lastAcceptedID"`
+	ChainTime      time.Time      `json:"chainTime"`
+	LocalTime      time.Time      `json:"localTime"`
+	// Validators of this node on the primary network and on the tracked
+	// subnets, ordered by subnet ID and then by start time
+	Validators []APIDashboardValidator `json:"validators"`
+	// End times of [Validators] and of their delegators, in the order they
+	// will end
+	UpcomingEndTimes []APIUpcomingEndTime `json:"upcomingEndTimes"`
+	// Primary network stake this node is connected to, including its own
+	ConnectedWeight     avajson.Uint64  `json:"connectedWeight"`
+	TotalWeight         avajson.Uint64  `json:"totalWeight"`
+	ConnectedPercentage avajson.Float64 `json:"connectedPercentage"`
+	Mempool             APIMempoolStats `json:"mempool"`
+	// Alerts that were firing as of the last health check
+	Alerts []alerts.Alert `json:"alerts"`
+}
+
+// GetNodeDashboard returns, in a single call, the status of this node that an
+// operator dashboard displays.
+func (s *Service) GetNodeDashboard(_ *http.Request, _ *struct{}, reply *GetNodeDashboardReply) error {
 	s.vm.ctx.Log.Debug("API called",
 		zap.String("service", "platform"),
-		zap.String("method", "getBlock"),
-		zap.Stringer("blkID", args.BlockID),
-		zap.Stringer("encoding", args.Encoding),
+		zap.String("method", "getNodeDashboard"),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	lastAcceptedID := s.vm.state.GetLastAccepted()
+	lastAccepted, err := s.vm.state.GetStatelessBlock(lastAcceptedID)
+	if err != nil {
+		return err
+	}
+	reply.NodeID = s.vm.ctx.NodeID
+	reply.Height = avajson.Uint64(lastAccepted.Height())
+	reply.LastAcceptedID = lastAcceptedID
+	reply.ChainTime = s.vm.state.GetTimestamp()
+	reply.LocalTime = s.vm.clock.Time()
+
+	subnetIDs := append([]ids.ID{constants.PrimaryNetworkID}, s.vm.TrackedSubnets.List()...)
+	slices.SortFunc(subnetIDs, func(a, b ids.ID) int {
+		return a.Compare(b)
+	})
+	reply.Validators = []APIDashboardValidator{}
+	for _, subnetID := range subnetIDs {
+		validators, err := s.getDashboardValidators(subnetID)
+		if err != nil {
+			return err
+		}
+		reply.Validators = append(reply.Validators, validators...)
+	}
+
+	reply.UpcomingEndTimes = []APIUpcomingEndTime{}
+	for _, validator := range reply.Validators {
+		reply.UpcomingEndTimes = append(reply.UpcomingEndTimes, APIUpcomingEndTime{
+			TxID:     validator.TxID,
+			SubnetID: validator.SubnetID,
+			EndTime:  validator.EndTime,
+		})
+		for _, delegator := range validator.Delegators {
+			reply.UpcomingEndTimes = append(reply.UpcomingEndTimes, APIUpcomingEndTime{
+				TxID:      delegator.TxID,
+				SubnetID:  delegator.SubnetID,
+				Delegator: true,
+				EndTime:   delegator.EndTime,
+			})
+		}
+	}
+	slices.SortStableFunc(reply.UpcomingEndTimes, func(a, b APIUpcomingEndTime) int {
+		return cmp.Compare(a.EndTime, b.EndTime)
+	})
+
+	connectivity := s.vm.peerConnections.connectivity(
+		s.vm.Validators.GetMap(constants.PrimaryNetworkID),
+		s.vm.ctx.NodeID,
+	)
+	reply.ConnectedWeight = avajson.Uint64(connectivity.ConnectedWeight)
+	reply.TotalWeight = avajson.Uint64(connectivity.TotalWeight)
+	reply.ConnectedPercentage = avajson.Float64(connectivity.ConnectedPercentage())
+
+	reply.Mempool.NumTxs = avajson.Uint64(s.vm.Builder.Len())
+	s.vm.Builder.Iterate(func(tx *txs.Tx) bool {
+		reply.Mempool.Bytes += avajson.Uint64(len(tx.Bytes()))
+		return true
+	})
+	if oldestTx, ok := s.vm.Builder.Peek(); ok {
+		oldestTxID := oldestTx.ID()
+		reply.Mempool.OldestTxID = &oldestTxID
+	}
+
+	reply.Alerts = s.vm.alertEvaluator.Firing()
+	return nil
+}
+
+// getDashboardValidators returns the current and pending validators of this
+// node on [subnetID], ordered by start time.
+func (s *Service) getDashboardValidators(subnetID ids.ID) ([]APIDashboardValidator, error) {
+	var validators []APIDashboardValidator
+	currentValidator, err := s.vm.state.GetCurrentValidator(subnetID, s.vm.ctx.NodeID)
+	switch err {
+	case nil:
+		uptime, err := s.getAPIUptime(currentValidator)
+		if err != nil {
+			return nil, err
+		}
+		validator := APIDashboardValidator{
+			APIDashboardStaker: newAPIDashboardStaker(currentValidator, true),
+			Uptime:             uptime,
+		}
+		validators = append(validators, validator)
+	case database.ErrNotFound:
+	default:
+		return nil, err
+	}
+
+	pendingValidator, err := s.vm.state.GetPendingValidator(subnetID, s.vm.ctx.NodeID)
+	switch err {
+	case nil:
+		validators = append(validators, APIDashboardValidator{
+			APIDashboardStaker: newAPIDashboardStaker(pendingValidator, false),
+		})
+	case database.ErrNotFound:
+	default:
+		return nil, err
+	}
+
+	// A node is never both a current and a pending validator of a subnet, so
+	// every delegator delegates to the validator returned, if any.
+	for i := range validators {
+		validator := &validators[i]
+		validator.Delegators = []APIDashboardStaker{}
+
+		for _, current := range []bool{true, false} {
+			var delegatorIterator state.StakerIterator
+			if current {
+				delegatorIterator, err = s.vm.state.GetCurrentDelegatorIterator(subnetID, s.vm.ctx.NodeID)
+			} else {
+				delegatorIterator, err = s.vm.state.GetPendingDelegatorIterator(subnetID, s.vm.ctx.NodeID)
+			}
+			if err != nil {
+				return nil, err
+			}
+			for delegatorIterator.Next() {
+				delegator := delegatorIterator.Value()
+				validator.DelegatorWeight += avajson.Uint64(delegator.Weight)
+				validator.Delegators = append(validator.Delegators, newAPIDashboardStaker(delegator, current))
+			}
+			delegatorIterator.Release()
+		}
+
+		slices.SortStableFunc(validator.Delegators, func(a, b APIDashboardStaker) int {
+			return cmp.Compare(a.EndTime, b.EndTime)
+		})
+	}
+	return validators, nil
+}
+
+func newAPIDashboardStaker(staker *state.Staker, current bool) APIDashboardStaker {
+	return APIDashboardStaker{
+		TxID:      staker.TxID,
+		SubnetID:  staker.SubnetID,
+		Weight:    avajson.Uint64(staker.Weight),
+		StartTime: avajson.Uint64(staker.StartTime.Unix()),
+		EndTime:   avajson.Uint64(staker.EndTime.Unix()),
+		Current:   current,
+	}
+}
+
+// ReserveDelegationCapacityArgs are the arguments for calling
+// ReserveDelegationCapacity
+type ReserveDelegationCapacityArgs struct {
+	SubnetID ids.ID     `json:"subnetID"`
+	NodeID   ids.NodeID `json:"nodeID"`
+	// RewardAddress is an address of the rewards owner of the delegation that
+	// the capacity is reserved for
+	RewardAddress string `json:"rewardAddress"`
+	// Amount of stake to reserve
+	Amount avajson.Uint64 `json:"amount"`
+	// EndTime of the delegation that the capacity is reserved for
+	EndTime avajson.Uint64 `json:"endTime"`
+	// Signature of the message returned by [DelegationReservationMessage]
+	// for the reservation, made with the key of [RewardAddress]
+	Signature string `json:"signature"`
+	// Encoding of [Signature]
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// ReserveDelegationCapacityReply is the response from calling
+// ReserveDelegationCapacity
+type ReserveDelegationCapacityReply struct {
+	// Expiry of the reservation. The delegation must be issued before then.
+	Expiry avajson.Uint64 `json:"expiry"`
+}
+
+// ReserveDelegationCapacity reserves the delegation capacity of a validator
+// for a short time, so that the delegations to the validator issued to this
+// node by other owners can't take it. The reservation is released once a
+// delegation rewarded to [RewardAddress] is admitted into the mempool.
+//
+// The caller must prove control of [RewardAddress] by signing the
+// reservation. As a reservation replaces the previous reservation of its
+// owner, each owner holds at most one reservation per validator.
+func (s *Service) ReserveDelegationCapacity(_ *http.Request, args *ReserveDelegationCapacityArgs, reply *ReserveDelegationCapacityReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "reserveDelegationCapacity"),
+		zap.Stringer("subnetID", args.SubnetID),
+		zap.Stringer("nodeID", args.NodeID),
+		zap.Uint64("amount", uint64(args.Amount)),
+	)
+
+	if s.vm.delegationReservations == nil {
+		return errDelegationReservationsDisabled
+	}
+
+	rewardAddr, err := avax.ParseServiceAddress(s.addrManager, args.RewardAddress)
+	if err != nil {
+		return fmt.Errorf("couldn't parse reward address: %w", err)
+	}
+
+	proof := StakeOwnershipProof{
+		Message: DelegationReservationMessage(
+			args.SubnetID,
+			args.NodeID,
+			uint64(args.Amount),
+			time.Unix(int64(args.EndTime), 0),
+		),
+		Signatures: []string{args.Signature},
+	}
+	if err := proof.verify(args.Encoding, set.Of(rewardAddr)); err != nil {
+		return fmt.Errorf("couldn't authorize reservation: %w", err)
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	capacity, err := s.vm.availableDelegationCapacity(
+		args.SubnetID,
+		args.NodeID,
+		time.Unix(int64(args.EndTime), 0),
+	)
+	if err != nil {
+		return err
+	}
+
+	expiry, err := s.vm.delegationReservations.Reserve(
+		args.SubnetID,
+		args.NodeID,
+		rewardAddr,
+		uint64(args.Amount),
+		capacity,
+	)
+	reply.Expiry = avajson.Uint64(expiry.Unix())
+	return err
+}
+
+// GetDelegationSuggestionsArgs are the arguments for calling
+// GetDelegationSuggestions
+type GetDelegationSuggestionsArgs struct {
+	// Amount of nAVAX to delegate
+	Amount avajson.Uint64 `json:"amount"`
+	// Duration of the delegation in seconds. The delegation is assumed to
+	// start at the current chain time.
+	Duration avajson.Uint64 `json:"duration"`
+	// Limit is the maximum number of suggestions returned. If it is 0, every
+	// validator that can accept the delegation is returned.
+	Limit avajson.Uint32 `json:"limit"`
+}
+
+// DelegationSuggestion is a primary network validator that can accept a
+// proposed delegation
+type DelegationSuggestion struct {
+	NodeID  ids.NodeID     `json:"nodeID"`
+	EndTime avajson.Uint64 `json:"endTime"`
+	// DelegationFee is the percentage of the delegation rewards kept by the
+	// validator.
+	DelegationFee avajson.Float32 `json:"delegationFee"`
+	// RemainingCapacity is the nAVAX that can still be delegated to the
+	// validator over the proposed delegation period.
+	RemainingCapacity avajson.Uint64 `json:"remainingCapacity"`
+	// Uptime is the percentage of its current staking period that this node
+	// measured the validator to be online.
+	Uptime *avajson.Float32 `json:"uptime,omitempty"`
+	// HistoricalUptime is the average uptime recorded when the previous
+	// primary network stakers of the node were rewarded. It is omitted if
+	// none were.
+	HistoricalUptime *avajson.Float32 `json:"historicalUptime,omitempty"`
+}
+
+// GetDelegationSuggestionsReply is the response from calling
+// GetDelegationSuggestions
+type GetDelegationSuggestionsReply struct {
+	StartTime   avajson.Uint64         `json:"startTime"`
+	EndTime     avajson.Uint64         `json:"endTime"`
+	Suggestions []DelegationSuggestion `json:"suggestions"`
+}
+
+// GetDelegationSuggestions returns the primary network validators that can
+// accept a delegation of [Amount] for [Duration], ranked as described in
+// [sortDelegationSuggestions].
+func (s *Service) GetDelegationSuggestions(_ *http.Request, args *GetDelegationSuggestionsArgs, reply *GetDelegationSuggestionsReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getDelegationSuggestions"),
+		zap.Uint64("amount", uint64(args.Amount)),
+		zap.Uint64("duration", uint64(args.Duration)),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	chainTime := s.vm.state.GetTimestamp()
+	_, _, minDelegatorStake, _, _, minDelegateDuration, maxStakeDuration, _, _, _ := executor.GetCurrentInflationSettings(chainTime, s.vm.ctx.NetworkID, &s.vm.Config)
+	minDelegatorStake, err := state.GetParameter(s.vm.state, txs.MinDelegatorStakeParameter, minDelegatorStake)
+	if err != nil {
+		return err
+	}
+
+	amount := uint64(args.Amount)
+	if amount < minDelegatorStake {
+		return fmt.Errorf("%w: %d < %d", errDelegationTooSmall, amount, minDelegatorStake)
+	}
+	if args.Duration < avajson.Uint64(minDelegateDuration/time.Second) || args.Duration > avajson.Uint64(maxStakeDuration/time.Second) {
+		return fmt.Errorf(
+			"%w: %d not in [%d, %d]",
+			errInvalidDelegationDuration,
+			args.Duration,
+			minDelegateDuration/time.Second,
+			maxStakeDuration/time.Second,
+		)
+	}
+	endTime := chainTime.Add(time.Duration(args.Duration) * time.Second)
+
+	currentStakerIterator, err := s.vm.state.GetCurrentStakerIterator()
+	if err != nil {
+		return err
+	}
+	var candidates []*state.Staker
+	for currentStakerIterator.Next() {
+		staker := currentStakerIterator.Value()
+		if staker.Priority == txs.PrimaryNetworkValidatorCurrentPriority && !staker.EndTime.Before(endTime) {
+			candidates = append(candidates, staker)
+		}
+	}
+	currentStakerIterator.Release()
+
+	backend := &executor.Backend{
+		Config: &s.vm.Config,
+		Ctx:    s.vm.ctx,
+	}
+	suggestions := []DelegationSuggestion{}
+	for _, validator := range candidates {
+		accepts, err := s.acceptsDelegation(validator.TxID, amount)
+		if err != nil {
+			return err
+		}
+		if !accepts {
+			continue
+		}
+
+		capacity, err := executor.GetDelegationCapacity(backend, s.vm.state, validator, chainTime, endTime)
+		if err != nil {
+			return err
+		}
+		if capacity < amount {
+			continue
+		}
+
+		attr, err := s.loadStakerTxAttributes(validator.TxID)
+		if err != nil {
+			return err
+		}
+		uptime, err := s.getAPIUptime(validator)
+		if err != nil {
+			return err
+		}
+		historicalUptime, err := s.getHistoricalUptime(validator.NodeID)
+		if err != nil {
+			return err
+		}
+		suggestions = append(suggestions, DelegationSuggestion{
+			NodeID:            validator.NodeID,
+			EndTime:           avajson.Uint64(validator.EndTime.Unix()),
+			DelegationFee:     avajson.Float32(100 * float32(attr.shares) / float32(reward.PercentDenominator)),
+			RemainingCapacity: avajson.Uint64(capacity),
+			Uptime:            uptime,
+			HistoricalUptime:  historicalUptime,
+		})
+	}
+
+	sortDelegationSuggestions(suggestions, avajson.Float32(100*s.vm.UptimePercentage))
+	if args.Limit != 0 && len(suggestions) > int(args.Limit) {
+		suggestions = suggestions[:args.Limit]
+	}
+
+	reply.StartTime = avajson.Uint64(chainTime.Unix())
+	reply.EndTime = avajson.Uint64(endTime.Unix())
+	reply.Suggestions = suggestions
+	return nil
+}
+
+// acceptsDelegation returns false if the validator added by [validatorTxID]
+// caps the stake of each delegator below [amount].
+func (s *Service) acceptsDelegation(validatorTxID ids.ID, amount uint64) (bool, error) {
+	validatorTx, _, err := s.vm.state.GetTx(validatorTxID)
+	if err != nil {
+		return false, err
+	}
+	cappedTx, ok := validatorTx.Unsigned.(*txs.AddCappedPermissionlessValidatorTx)
+	return !ok || amount <= cappedTx.MaxDelegatorStake, nil
+}
+
+// getHistoricalUptime returns the average uptime, as a percentage, recorded
+// in the reward receipts of the primary network stakers of [nodeID]. Nil is
+// returned if there are no such receipts.
+func (s *Service) getHistoricalUptime(nodeID ids.NodeID) (*avajson.Float32, error) {
+	receipts, err := s.vm.state.GetRewardReceipts(nodeID, 0, math.MaxUint64)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		total      float64
+		numUptimes int
+	)
+	for _, receipt := range receipts {
+		if receipt.SubnetID != constants.PrimaryNetworkID || receipt.Cause == state.RewardCauseUptimeUnavailable {
+			continue
+		}
+		total += float64(receipt.Uptime)
+		numUptimes++
+	}
+	if numUptimes == 0 {
+		return nil, nil
+	}
+	uptime := avajson.Float32(100 * total / float64(numUptimes) / reward.PercentDenominator)
+	return &uptime, nil
+}
+
+// sortDelegationSuggestions orders [suggestions] from the most to the least
+// preferable. Validators that this node measured to meet [requiredUptime] come
+// first. Ties are broken by the higher historical uptime, then the lower
+// delegation fee, then the higher remaining capacity.
+func sortDelegationSuggestions(suggestions []DelegationSuggestion, requiredUptime avajson.Float32) {
+	meetsUptime := func(s DelegationSuggestion) bool {
+		return s.Uptime != nil && *s.Uptime >= requiredUptime
+	}
+	historicalUptime := func(s DelegationSuggestion) avajson.Float32 {
+		if s.HistoricalUptime == nil {
+			return -1
+		}
+		return *s.HistoricalUptime
+	}
+	slices.SortStableFunc(suggestions, func(a, b DelegationSuggestion) int {
+		switch aMeets, bMeets := meetsUptime(a), meetsUptime(b); {
+		case aMeets && !bMeets:
+			return -1
+		case !aMeets && bMeets:
+			return 1
+		}
+		if aUptime, bUptime := historicalUptime(a), historicalUptime(b); aUptime != bUptime {
+			if aUptime > bUptime {
+				return -1
+			}
+			return 1
+		}
+		if a.DelegationFee != b.DelegationFee {
+			if a.DelegationFee < b.DelegationFee {
+				return -1
+			}
+			return 1
+		}
+		switch {
+		case a.RemainingCapacity > b.RemainingCapacity:
+			return -1
+		case a.RemainingCapacity < b.RemainingCapacity:
+			return 1
+		default:
+			return a.NodeID.Compare(b.NodeID)
+		}
+	})
+}
+
+// GetRewardUTXOsReply defines the GetRewardUTXOs replies returned from the API
+type GetRewardUTXOsReply struct {
+	// Number of UTXOs returned
+	NumFetched avajson.Uint64 `json:"numFetched"`
+	// The UTXOs
+	UTXOs []string `json:"utxos"`
+	// Encoding specifies the encoding format the UTXOs are returned in
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetRewardUTXOs returns the UTXOs that were rewarded after the provided
+// transaction's staking period ended.
+func (s *Service) GetRewardUTXOs(_ *http.Request, args *api.GetTxArgs, reply *GetRewardUTXOsReply) error {
+	s.vm.ctx.Log.Debug("deprecated API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getRewardUTXOs"),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	utxos, err := s.vm.state.GetRewardUTXOs(args.TxID)
+	if err != nil {
+		return fmt.Errorf("couldn't get reward UTXOs: %w", err)
+	}
+
+	reply.NumFetched = avajson.Uint64(len(utxos))
+	reply.UTXOs = make([]string, len(utxos))
+	for i, utxo := range utxos {
+		utxoBytes, err := txs.GenesisCodec.Marshal(txs.CodecVersion, utxo)
+		if err != nil {
+			return fmt.Errorf("couldn't encode UTXO to bytes: %w", err)
+		}
+
+		utxoStr, err := formatting.Encode(args.Encoding, utxoBytes)
+		if err != nil {
+			return fmt.Errorf("couldn't encode utxo as %s: %w", args.Encoding, err)
+		}
+		reply.UTXOs[i] = utxoStr
+	}
+	reply.Encoding = args.Encoding
+	return nil
+}
+
+// GetValidatorRewardHistoryArgs are the arguments for calling
+// GetValidatorRewardHistory
+type GetValidatorRewardHistoryArgs struct {
+	NodeID     ids.NodeID     `json:"nodeID"`
+	FromHeight avajson.Uint64 `json:"fromHeight"`
+	// ToHeight defaults to the last accepted height if it is 0.
+	ToHeight avajson.Uint64 `json:"toHeight"`
+}
+
+// APIRewardReceipt is the API representation of a reward decision
+type APIRewardReceipt struct {
+	StakerTxID ids.ID         `json:"stakerTxID"`
+	SubnetID   ids.ID         `json:"subnetID"`
+	Height     avajson.Uint64 `json:"height"`
+	Timestamp  avajson.Uint64 `json:"timestamp"`
+	// Rewarded is false if the reward was aborted.
+	Rewarded        bool           `json:"rewarded"`
+	PotentialReward avajson.Uint64 `json:"potentialReward"`
+	Reward          avajson.Uint64 `json:"reward"`
+	// Uptime is the percentage of time this node measured the staker to be
+	// online when the reward was decided.
+	Uptime avajson.Float32 `json:"uptime"`
+	// RequiredUptime is the percentage of time the staker needed to be online
+	// to be rewarded.
+	RequiredUptime avajson.Float32 `json:"requiredUptime"`
+	// Cause explains why the staker was, or wasn't, rewarded.
+	Cause string `json:"cause"`
+}
+
+// GetValidatorRewardHistoryReply is the response from calling
+// GetValidatorRewardHistory
+type GetValidatorRewardHistoryReply struct {
+	Receipts []APIRewardReceipt `json:"receipts"`
+}
+
+// GetValidatorRewardHistory returns the reward decisions of the stakers of a
+// node that were accepted at heights in [FromHeight, ToHeight].
+func (s *Service) GetValidatorRewardHistory(r *http.Request, args *GetValidatorRewardHistoryArgs, reply *GetValidatorRewardHistoryReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getValidatorRewardHistory"),
+		zap.Stringer("nodeID", args.NodeID),
+		zap.Uint64("fromHeight", uint64(args.FromHeight)),
+		zap.Uint64("toHeight", uint64(args.ToHeight)),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	toHeight := uint64(args.ToHeight)
+	if toHeight == 0 {
+		height, err := s.vm.GetCurrentHeight(r.Context())
+		if err != nil {
+			return fmt.Errorf("couldn't get the last accepted height: %w", err)
+		}
+		toHeight = height
+	}
+	if toHeight < uint64(args.FromHeight) {
+		return errInvalidRewardHistoryRange
+	}
+
+	receipts, err := s.vm.state.GetRewardReceipts(args.NodeID, uint64(args.FromHeight), toHeight)
+	if err != nil {
+		return fmt.Errorf("couldn't get reward receipts: %w", err)
+	}
+
+	reply.Receipts = make([]APIRewardReceipt, len(receipts))
+	for i, receipt := range receipts {
+		reply.Receipts[i] = newAPIRewardReceipt(receipt)
+	}
+	return nil
+}
+
+func newAPIRewardReceipt(receipt *state.RewardReceipt) APIRewardReceipt {
+	return APIRewardReceipt{
+		StakerTxID:      receipt.StakerTxID,
+		SubnetID:        receipt.SubnetID,
+		Height:          avajson.Uint64(receipt.Height),
+		Timestamp:       avajson.Uint64(receipt.Timestamp),
+		Rewarded:        receipt.Rewarded,
+		PotentialReward: avajson.Uint64(receipt.PotentialReward),
+		Reward:          avajson.Uint64(receipt.Reward),
+		Uptime:          avajson.Float32(100 * float32(receipt.Uptime) / float32(reward.PercentDenominator)),
+		RequiredUptime:  avajson.Float32(100 * float32(receipt.RequiredUptime) / float32(reward.PercentDenominator)),
+		Cause:           receipt.Cause.String(),
+	}
+}
+
+// GetRewardReceiptArgs are the arguments for calling GetRewardReceipt
+type GetRewardReceiptArgs struct {
+	// ID of the tx that added the validator or the delegator
+	StakerTxID ids.ID `json:"stakerTxID"`
+}
+
+// GetRewardReceiptReply is the response from calling GetRewardReceipt
+type GetRewardReceiptReply struct {
+	// NodeID whose uptime the reward was decided on. Delegators are decided on
+	// the uptime of the node they delegated to.
+	NodeID  ids.NodeID       `json:"nodeID"`
+	Receipt APIRewardReceipt `json:"receipt"`
+}
+
+// GetRewardReceipt returns the reward decision of a staker, including the
+// uptime this node measured for it when the decision was accepted.
+func (s *Service) GetRewardReceipt(_ *http.Request, args *GetRewardReceiptArgs, reply *GetRewardReceiptReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getRewardReceipt"),
+		zap.Stringer("stakerTxID", args.StakerTxID),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	nodeID, receipt, err := s.vm.state.GetRewardReceipt(args.StakerTxID)
+	if err != nil {
+		return fmt.Errorf("couldn't get reward receipt of %s: %w", args.StakerTxID, err)
+	}
+	reply.NodeID = nodeID
+	reply.Receipt = newAPIRewardReceipt(receipt)
+	return nil
+}
+
+// GetClaimableRewardArgs are the arguments for calling GetClaimableReward
+type GetClaimableRewardArgs struct {
+	// Rewards owner whose claimable rewards are returned
+	Owner platformapi.Owner `json:"owner"`
+	// AssetID defaults to AVAX if empty.
+	AssetID ids.ID `json:"assetID"`
+}
+
+// GetClaimableRewardReply is the response from calling GetClaimableReward
+type GetClaimableRewardReply struct {
+	Amount avajson.Uint64 `json:"amount"`
+}
+
+// GetClaimableReward returns the rewards that accrued to a rewards owner and
+// that it can claim with a ClaimRewardTx.
+func (s *Service) GetClaimableReward(_ *http.Request, args *GetClaimableRewardArgs, reply *GetClaimableRewardReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getClaimableReward"),
+		zap.Stringer("assetID", args.AssetID),
+	)
+
+	addrs, err := avax.ParseServiceAddresses(s.addrManager, args.Owner.Addresses)
+	if err != nil {
+		return err
+	}
+	owner := &secp256k1fx.OutputOwners{
+		Locktime:  uint64(args.Owner.Locktime),
+		Threshold: uint32(args.Owner.Threshold),
+		Addrs:     addrs.List(),
+	}
+	utils.Sort(owner.Addrs)
+	ownerID, err := txs.RewardsOwnerID(owner)
+	if err != nil {
+		return err
+	}
+
+	assetID := args.AssetID
+	if assetID == ids.Empty {
+		assetID = s.vm.ctx.AVAXAssetID
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	amount, err := s.vm.state.GetClaimableReward(ownerID, assetID)
+	if err != nil {
+		return fmt.Errorf("couldn't get claimable reward: %w", err)
+	}
+	reply.Amount = avajson.Uint64(amount)
+	return nil
+}
+
+// GetRewardAddressArgs are the arguments for calling GetRewardAddress
+type GetRewardAddressArgs struct {
+	// Rewards owner whose bound C-chain address is returned
+	Owner platformapi.Owner `json:"owner"`
+}
+
+// GetRewardAddressReply is the response from calling GetRewardAddress
+type GetRewardAddressReply struct {
+	// C-chain address that the rewards of the owner are exported to. Empty if
+	// the owner isn't bound to an address.
+	Address string `json:"address"`
+}
+
+// GetRewardAddress returns the C-chain address that a rewards owner bound
+// with a BindRewardAddressTx.
+func (s *Service) GetRewardAddress(_ *http.Request, args *GetRewardAddressArgs, reply *GetRewardAddressReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getRewardAddress"),
+	)
+
+	addrs, err := avax.ParseServiceAddresses(s.addrManager, args.Owner.Addresses)
+	if err != nil {
+		return err
+	}
+	owner := &secp256k1fx.OutputOwners{
+		Locktime:  uint64(args.Owner.Locktime),
+		Threshold: uint32(args.Owner.Threshold),
+		Addrs:     addrs.List(),
+	}
+	utils.Sort(owner.Addrs)
+	ownerID, err := txs.RewardsOwnerID(owner)
+	if err != nil {
+		return err
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	addr, err := s.vm.state.GetRewardAddress(ownerID)
+	if err != nil {
+		return fmt.Errorf("couldn't get reward address: %w", err)
+	}
+	if addr == ids.ShortEmpty {
+		return nil
+	}
+	reply.Address, err = s.addrManager.FormatAddress(s.vm.ctx.CChainID, addr)
+	return err
+}
+
+// GetStakerTimelineArgs are the arguments for calling GetStakerTimeline
+type GetStakerTimelineArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+}
+
+// APIStakingPeriod is the API representation of a period a node validated a
+// subnet for
+type APIStakingPeriod struct {
+	TxID     ids.ID `json:"txID"`
+	SubnetID ids.ID `json:"subnetID"`
+	// StartTime is 0 if the period ended and its start time wasn't part of
+	// the tx that added it.
+	StartTime avajson.Uint64 `json:"startTime"`
+	EndTime   avajson.Uint64 `json:"endTime"`
+	Weight    avajson.Uint64 `json:"weight"`
+	// Status is one of "pending", "current" or "ended".
+	Status string `json:"status"`
+	// PublicKey is the BLS key the node registered for the period, if any.
+	PublicKey *string `json:"publicKey,omitempty"`
+	// PublicKeyChanged is true if the node registered a different BLS key
+	// than for its previous period on the same subnet.
+	PublicKeyChanged bool `json:"publicKeyChanged"`
+	// GapBefore is the number of seconds between the end of the previous
+	// period on the same subnet and the start of this one. It is 0 for the
+	// first period and if the start of this period is unknown.
+	GapBefore avajson.Uint64 `json:"gapBefore"`
+	// Receipt is the reward decision of the period, if it ended.
+	Receipt *APIRewardReceipt `json:"receipt,omitempty"`
+}
+
+// GetStakerTimelineReply is the response from calling GetStakerTimeline
+type GetStakerTimelineReply struct {
+	// Periods are ordered by subnet and then by end time.
+	Periods []APIStakingPeriod `json:"periods"`
+}
+
+// GetStakerTimeline returns the periods the node validated the primary
+// network and subnets for. Ended periods are recovered from the reward
+// receipts of the node, and pending and current periods from the stakers.
+func (s *Service) GetStakerTimeline(_ *http.Request, args *GetStakerTimelineArgs, reply *GetStakerTimelineReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getStakerTimeline"),
+		zap.Stringer("nodeID", args.NodeID),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	receipts, err := s.vm.state.GetRewardReceipts(args.NodeID, 0, math.MaxUint64)
+	if err != nil {
+		return fmt.Errorf("couldn't get reward receipts: %w", err)
+	}
+
+	var periods []APIStakingPeriod
+	for _, receipt := range receipts {
+		tx, _, err := s.vm.state.GetTx(receipt.StakerTxID)
+		if err != nil {
+			return fmt.Errorf("couldn't get staker tx %s: %w", receipt.StakerTxID, err)
+		}
+		staker, ok := tx.Unsigned.(txs.Staker)
+		if !ok {
+			return fmt.Errorf("expected a staker but got %T", tx.Unsigned)
+		}
+		if !staker.CurrentPriority().IsValidator() {
+			// The rewards of the delegators are reported by their own
+			// receipts, but they don't define the periods of the node.
+			continue
+		}
+
+		period := APIStakingPeriod{
+			TxID:     receipt.StakerTxID,
+			SubnetID: receipt.SubnetID,
+			EndTime:  avajson.Uint64(staker.EndTime().Unix()),
+			Weight:   avajson.Uint64(staker.Weight()),
+			Status:   "ended",
+		}
+		if scheduledStaker, ok := staker.(txs.ScheduledStaker); ok {
+			period.StartTime = avajson.Uint64(scheduledStaker.StartTime().Unix())
+		}
+		pk, _, err := staker.PublicKey()
+		if err != nil {
+			return fmt.Errorf("couldn't get public key of staker tx %s: %w", receipt.StakerTxID, err)
+		}
+		if err := setPeriodPublicKey(&period, pk); err != nil {
+			return err
+		}
+		apiReceipt := newAPIRewardReceipt(receipt)
+		period.Receipt = &apiReceipt
+		periods = append(periods, period)
+	}
+
+	for _, getIterator := range []func() (state.StakerIterator, error){
+		s.vm.state.GetCurrentStakerIterator,
+		s.vm.state.GetPendingStakerIterator,
+	} {
+		it, err := getIterator()
+		if err != nil {
+			return fmt.Errorf("couldn't get stakers: %w", err)
+		}
+		for it.Next() {
+			staker := it.Value()
+			if staker.NodeID != args.NodeID || !staker.Priority.IsValidator() {
+				continue
+			}
+
+			period := APIStakingPeriod{
+				TxID:      staker.TxID,
+				SubnetID:  staker.SubnetID,
+				StartTime: avajson.Uint64(staker.StartTime.Unix()),
+				EndTime:   avajson.Uint64(staker.EndTime.Unix()),
+				Weight:    avajson.Uint64(staker.Weight),
+				Status:    "current",
+			}
+			if staker.Priority.IsPending() {
+				period.Status = "pending"
+			}
+			if err := setPeriodPublicKey(&period, staker.PublicKey); err != nil {
+				it.Release()
+				return err
+			}
+			periods = append(periods, period)
+		}
+		it.Release()
+	}
+
+	slices.SortStableFunc(periods, func(a, b APIStakingPeriod) int {
+		if c := a.SubnetID.Compare(b.SubnetID); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.EndTime, b.EndTime)
+	})
+	for i := 1; i < len(periods); i++ {
+		previous, period := &periods[i-1], &periods[i]
+		if previous.SubnetID != period.SubnetID {
+			continue
+		}
+		if period.StartTime > previous.EndTime {
+			period.GapBefore = period.StartTime - previous.EndTime
+		}
+		switch {
+		case previous.PublicKey == nil:
+			period.PublicKeyChanged = period.PublicKey != nil
+		case period.PublicKey == nil:
+			period.PublicKeyChanged = true
+		default:
+			period.PublicKeyChanged = *previous.PublicKey != *period.PublicKey
+		}
+	}
+	reply.Periods = periods
+	return nil
+}
+
+func setPeriodPublicKey(period *APIStakingPeriod, pk *bls.PublicKey) error {
+	if pk == nil {
+		return nil
+	}
+	pkStr, err := formatting.Encode(formatting.HexNC, bls.PublicKeyToBytes(pk))
+	if err != nil {
+		return fmt.Errorf("couldn't encode public key: %w", err)
+	}
+	period.PublicKey = &pkStr
+	return nil
+}
+
+// GetTimestampReply is the response from GetTimestamp
+type GetTimestampReply struct {
+	// Current timestamp
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// GetTimestamp returns the current timestamp on chain.
+func (s *Service) GetTimestamp(_ *http.Request, _ *struct{}, reply *GetTimestampReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getTimestamp"),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	reply.Timestamp = s.vm.state.GetTimestamp()
+	return nil
+}
+
+// GetChainTimeReply is the response from GetChainTime
+type GetChainTimeReply struct {
+	// ChainTime is the timestamp of the last accepted block
+	ChainTime time.Time `json:"chainTime"`
+	// LocalTime is the wall clock time of the node
+	LocalTime time.Time `json:"localTime"`
+	// SyncBound is the number of seconds that a block timestamp may be ahead
+	// of the wall clock time of a node for the block to be verified
+	SyncBound avajson.Uint64 `json:"syncBound"`
+	// EarliestStartTime is the earliest staker start time that remains valid
+	// if the staker tx is accepted before LocalTime + SyncBound
+	EarliestStartTime time.Time `json:"earliestStartTime"`
+	// LatestStartTime is the latest staker start time that is valid
+	LatestStartTime time.Time `json:"latestStartTime"`
+}
+
+// GetChainTime returns the chain time and the local time of the node, along
+// with the range of start times that a new staker may safely use.
+func (s *Service) GetChainTime(_ *http.Request, _ *struct{}, reply *GetChainTimeReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getChainTime"),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	chainTime := s.vm.state.GetTimestamp()
+	_, _, _, _, _, _, _, minFutureStartTimeOffset, _, minStakeStartTime := executor.GetCurrentInflationSettings(chainTime, s.vm.ctx.NetworkID, &s.vm.Config)
+
+	reply.ChainTime = chainTime
+	reply.LocalTime = s.vm.clock.Time()
+	reply.SyncBound = avajson.Uint64(executor.SyncBound / time.Second)
+	maxFutureStartTime := executor.GetMaxFutureStartTime(&s.vm.Config, chainTime)
+	reply.EarliestStartTime = earliestStakerStartTime(
+		chainTime,
+		reply.LocalTime,
+		maxFutureStartTime,
+		minFutureStartTimeOffset,
+		minStakeStartTime,
+	)
+	reply.LatestStartTime = chainTime.Add(maxFutureStartTime)
+	return nil
+}
+
+// earliestStakerStartTime returns the earliest start time, in whole seconds,
+// that is valid for a staker tx that is accepted while the chain time is at
+// most max([chainTime], [now] + SyncBound).
+//
+// The start time must be after the chain time and, for legacy staker txs, at
+// least [maxFutureStartTime] - [minFutureStartTimeOffset] after it. It must
+// also be after [minStakeStartTime].
+func earliestStakerStartTime(
+	chainTime time.Time,
+	now time.Time,
+	maxFutureStartTime time.Duration,
+	minFutureStartTimeOffset time.Duration,
+	minStakeStartTime time.Time,
+) time.Time {
+	maxAcceptanceTime := now.Add(executor.SyncBound)
+	if chainTime.After(maxAcceptanceTime) {
+		maxAcceptanceTime = chainTime
+	}
+
+	minOffset := max(maxFutureStartTime-minFutureStartTimeOffset, 0)
+	startTime := maxAcceptanceTime.Add(minOffset)
+	if minStakeStartTime.After(startTime) {
+		startTime = minStakeStartTime
+	}
+	// Start times are in whole seconds and must be strictly after the bounds.
+	return startTime.Truncate(time.Second).Add(time.Second)
+}
+
+// APIUpgrade is a network upgrade of the P-chain
+type APIUpgrade struct {
+	Name string    `json:"name"`
+	Time time.Time `json:"time"`
+	// Activated is true if the chain time reached the upgrade
+	Activated bool `json:"activated"`
+}
+
+// GetCompatibilityReply is the response from GetCompatibility
+type GetCompatibilityReply struct {
+	// Version of this node
+	Version string `json:"version"`
+	// MinimumCompatibleVersion is the minimum version of the peers whose
+	// messages are handled by this node
+	MinimumCompatibleVersion string `json:"minimumCompatibleVersion"`
+	// CodecVersion is the version of the codec of blocks and txs
+	CodecVersion avajson.Uint16 `json:"codecVersion"`
+	// MetadataCodecVersions are the versions of the codec of the staker
+	// metadata that can be read from the state
+	MetadataCodecVersions []avajson.Uint16 `json:"metadataCodecVersions"`
+	// Upgrades is the fork schedule of the chain
+	Upgrades []APIUpgrade `json:"upgrades"`
+}
+
+// GetCompatibility returns the versions of the node, its peers and its codecs
+// along with the fork schedule, so that operators of mixed-version networks
+// can check that their nodes agree before an upgrade.
+func (s *Service) GetCompatibility(_ *http.Request, _ *struct{}, reply *GetCompatibilityReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getCompatibility"),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	now := s.vm.clock.Time()
+	reply.Version = s.vm.peerCompatibility.compatibility.Version().String()
+	reply.MinimumCompatibleVersion = s.vm.peerCompatibility.minimumVersion(now).String()
+	reply.CodecVersion = block.CodecVersion
+	reply.MetadataCodecVersions = []avajson.Uint16{
+		avajson.Uint16(state.CodecVersion0),
+		avajson.Uint16(state.CodecVersion1),
+	}
+
+	reply.Upgrades = s.upgrades()
+	return nil
+}
+
+// upgrades returns the fork schedule of the chain.
+//
+// Invariant: Assumes the context lock is held.
+func (s *Service) upgrades() []APIUpgrade {
+	chainTime := s.vm.state.GetTimestamp()
+	var upgrades []APIUpgrade
+	for _, upgrade := range []struct {
+		name string
+		time time.Time
+	}{
+		{name: "apricotPhase3", time: s.vm.ApricotPhase3Time},
+		{name: "apricotPhase5", time: s.vm.ApricotPhase5Time},
+		{name: "banff", time: s.vm.BanffTime},
+		{name: "cortina", time: s.vm.CortinaTime},
+		{name: "durango", time: s.vm.DurangoTime},
+		{name: "feeTreasury", time: s.vm.FeeTreasuryTime},
+		{name: "openValidatorSet", time: s.vm.OpenValidatorSetTime},
+	} {
+		upgrades = append(upgrades, APIUpgrade{
+			Name:      upgrade.name,
+			Time:      upgrade.time,
+			Activated: !chainTime.Before(upgrade.time),
+		})
+	}
+	return upgrades
+}
+
+// GetProfileReply is the response from GetProfile
+type GetProfileReply struct {
+	// Profile selected with the chain config. Empty if the parameters were
+	// set by the node.
+	Profile   string         `json:"profile"`
+	NetworkID avajson.Uint32 `json:"networkID"`
+	// Upgrades is the fork schedule of the chain
+	Upgrades          []APIUpgrade   `json:"upgrades"`
+	UptimeRequirement float64        `json:"uptimeRequirement"`
+	MinValidatorStake avajson.Uint64 `json:"minValidatorStake"`
+	MaxValidatorStake avajson.Uint64 `json:"maxValidatorStake"`
+	MinDelegatorStake avajson.Uint64 `json:"minDelegatorStake"`
+	MinDelegationFee  avajson.Uint32 `json:"minDelegationFee"`
+	// Minimum and maximum stake durations, in seconds
+	MinStakeDuration avajson.Uint64 `json:"minStakeDuration"`
+	MaxStakeDuration avajson.Uint64 `json:"maxStakeDuration"`
+	RewardConfig     reward.Config  `json:"rewardConfig"`
+}
+
+// GetProfile returns the active profile along with the fork times, staking
+// parameters and reward config in use, so that operators can check that they
+// match the network.
+func (s *Service) GetProfile(_ *http.Request, _ *struct{}, reply *GetProfileReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getProfile"),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	reply.Profile = s.vm.execConfig.Profile
+	reply.NetworkID = avajson.Uint32(s.vm.ctx.NetworkID)
+	reply.Upgrades = s.upgrades()
+	reply.UptimeRequirement = s.vm.UptimePercentage
+	reply.MinValidatorStake = avajson.Uint64(s.vm.MinValidatorStake)
+	reply.MaxValidatorStake = avajson.Uint64(s.vm.MaxValidatorStake)
+	reply.MinDelegatorStake = avajson.Uint64(s.vm.MinDelegatorStake)
+	reply.MinDelegationFee = avajson.Uint32(s.vm.MinDelegationFee)
+	reply.MinStakeDuration = avajson.Uint64(s.vm.MinStakeDuration / time.Second)
+	reply.MaxStakeDuration = avajson.Uint64(s.vm.MaxStakeDuration / time.Second)
+	reply.RewardConfig = s.vm.RewardConfig
+	return nil
+}
+
+type SimulateChainTimeArgs struct {
+	// Number of days to simulate, starting from the current chain time
+	Days avajson.Uint64 `json:"days"`
+}
+
+// SimulatedDay summarizes the staker changes expected during a day
+type SimulatedDay struct {
+	StartTime         time.Time      `json:"startTime"`
+	ValidatorsAdded   avajson.Uint64 `json:"validatorsAdded"`
+	DelegatorsAdded   avajson.Uint64 `json:"delegatorsAdded"`
+	ValidatorsRemoved avajson.Uint64 `json:"validatorsRemoved"`
+	DelegatorsRemoved avajson.Uint64 `json:"delegatorsRemoved"`
+	// Rewards paid on the Primary Network to the stakers removed during the
+	// day, assuming that all of them are rewarded
+	Rewards avajson.Uint64 `json:"rewards"`
+	// Supply of the Primary Network at the end of the day
+	Supply avajson.Uint64 `json:"supply"`
+}
+
+type SimulateChainTimeReply struct {
+	StartTime time.Time      `json:"startTime"`
+	Supply    avajson.Uint64 `json:"supply"`
+	Days      []SimulatedDay `json:"days"`
+}
+
+// SimulateChainTime advances the chain time of the preferred state as if a
+// block was issued at every staker change, and reports the staker churn,
+// rewards and supply of each simulated day.
+func (s *Service) SimulateChainTime(_ *http.Request, args *SimulateChainTimeArgs, reply *SimulateChainTimeReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "simulateChainTime"),
+		zap.Uint64("days", uint64(args.Days)),
+	)
+
+	if args.Days == 0 || args.Days > maxSimulatedDays {
+		return errInvalidSimulatedDays
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	chainState, err := state.NewDiff(s.vm.manager.Preferred(), s.vm.manager)
+	if err != nil {
+		return err
+	}
+	supply, err := chainState.GetCurrentSupply(constants.PrimaryNetworkID)
+	if err != nil {
+		return err
+	}
+
+	const day = 24 * time.Hour
+	var (
+		numDays   = int(args.Days)
+		startTime = chainState.GetTimestamp()
+		endTime   = startTime.Add(time.Duration(numDays) * day)
+		backend   = &executor.Backend{
+			Config:  &s.vm.Config,
+			Ctx:     s.vm.ctx,
+			Clk:     &s.vm.clock,
+			Rewards: reward.NewCalculator(s.vm.RewardConfig),
+		}
+	)
+	changes, err := executor.SimulateChainTime(backend, chainState, endTime, maxSimulatedStakerChanges)
+	if err != nil {
+		return err
+	}
+
+	reply.StartTime = startTime
+	reply.Supply = avajson.Uint64(supply)
+	reply.Days = make([]SimulatedDay, numDays)
+
+	// Changes are sorted by time, so they are assigned to the days in order.
+	dayIndex := 0
+	for _, change := range changes {
+		changeDayIndex := min(int(change.Time.Sub(startTime)/day), numDays-1)
+		for ; dayIndex < changeDayIndex; dayIndex++ {
+			reply.Days[dayIndex].StartTime = startTime.Add(time.Duration(dayIndex) * day)
+			reply.Days[dayIndex].Supply = avajson.Uint64(supply)
+		}
+
+		supply = change.Supply
+		simulatedDay := &reply.Days[dayIndex]
+		isValidator := change.Staker.Priority.IsValidator()
+		switch {
+		case change.Added && isValidator:
+			simulatedDay.ValidatorsAdded++
+		case change.Added:
+			simulatedDay.DelegatorsAdded++
+		case isValidator:
+			simulatedDay.ValidatorsRemoved++
+		default:
+			simulatedDay.DelegatorsRemoved++
+		}
+		if !change.Added && change.Staker.SubnetID == constants.PrimaryNetworkID {
+			simulatedDay.Rewards += avajson.Uint64(change.Staker.PotentialReward)
+		}
+	}
+
+	for ; dayIndex < numDays; dayIndex++ {
+		reply.Days[dayIndex].StartTime = startTime.Add(time.Duration(dayIndex) * day)
+		reply.Days[dayIndex].Supply = avajson.Uint64(supply)
+	}
+	return nil
+}
+
+// GetValidatorsAtArgs is the response from GetValidatorsAt
+type GetValidatorsAtArgs struct {
+	Height   avajson.Uint64 `json:"height"`
+	SubnetID ids.ID         `json:"subnetID"`
+}
+
+type jsonGetValidatorOutput struct {
+	PublicKey *string        `json:"publicKey"`
+	Weight    avajson.Uint64 `json:"weight"`
+}
+
+func (v *GetValidatorsAtReply) MarshalJSON() ([]byte, error) {
+	m := make(map[ids.NodeID]*jsonGetValidatorOutput, len(v.Validators))
+	for _, vdr := range v.Validators {
+		vdrJSON := &jsonGetValidatorOutput{
+			Weight: avajson.Uint64(vdr.Weight),
+		}
+
+		if vdr.PublicKey != nil {
+			pk, err := formatting.Encode(formatting.HexNC, bls.PublicKeyToBytes(vdr.PublicKey))
+			if err != nil {
+				return nil, err
+			}
+			vdrJSON.PublicKey = &pk
+		}
+
+		m[vdr.NodeID] = vdrJSON
+	}
+	return json.Marshal(m)
+}
+
+func (v *GetValidatorsAtReply) UnmarshalJSON(b []byte) error {
+	var m map[ids.NodeID]*jsonGetValidatorOutput
+	if err := json.Unmarshal(b, &m); err != nil {
+		return err
+	}
+
+	if m == nil {
+		v.Validators = nil
+		return nil
+	}
+
+	v.Validators = make(map[ids.NodeID]*validators.GetValidatorOutput, len(m))
+	for nodeID, vdrJSON := range m {
+		vdr := &validators.GetValidatorOutput{
+			NodeID: nodeID,
+			Weight: uint64(vdrJSON.Weight),
+		}
+
+		if vdrJSON.PublicKey != nil {
+			pkBytes, err := formatting.Decode(formatting.HexNC, *vdrJSON.PublicKey)
+			if err != nil {
+				return err
+			}
+			vdr.PublicKey, err = bls.PublicKeyFromBytes(pkBytes)
+			if err != nil {
+				return err
+			}
+		}
+
+		v.Validators[nodeID] = vdr
+	}
+	return nil
+}
+
+// GetValidatorsAtReply is the response from GetValidatorsAt
+type GetValidatorsAtReply struct {
+	Validators map[ids.NodeID]*validators.GetValidatorOutput
+}
+
+// GetValidatorsAt returns the weights of the validator set of a provided subnet
+// at the specified height.
+func (s *Service) GetValidatorsAt(r *http.Request, args *GetValidatorsAtArgs, reply *GetValidatorsAtReply) error {
+	height := uint64(args.Height)
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getValidatorsAt"),
+		zap.Uint64("height", height),
+		zap.Stringer("subnetID", args.SubnetID),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	ctx := r.Context()
+	var err error
+	reply.Validators, err = s.vm.GetValidatorSet(ctx, height, args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("failed to get validator set: %w", err)
+	}
+	return nil
+}
+
+// GetCanonicalValidatorSetArgs are the arguments for calling
+// GetCanonicalValidatorSet
+type GetCanonicalValidatorSetArgs struct {
+	Height   avajson.Uint64 `json:"height"`
+	SubnetID ids.ID         `json:"subnetID"`
+}
+
+// CanonicalValidator is a validator of a canonical validator set. Validators
+// that registered the same BLS key are merged into a single entry.
+type CanonicalValidator struct {
+	PublicKey string         `json:"publicKey"`
+	Weight    avajson.Uint64 `json:"weight"`
+	NodeIDs   []ids.NodeID   `json:"nodeIDs"`
+}
+
+// GetCanonicalValidatorSetReply is the response from GetCanonicalValidatorSet
+type GetCanonicalValidatorSetReply struct {
+	// Validators with a BLS key, in the order that the signers of warp
+	// messages are indexed in
+	Validators []CanonicalValidator `json:"validators"`
+	// Total weight of the subnet, including validators without a BLS key
+	TotalWeight avajson.Uint64 `json:"totalWeight"`
+	// Aggregate of the public keys of [Validators]. Empty if there are none.
+	AggregatePublicKey string `json:"aggregatePublicKey"`
+}
+
+// GetCanonicalValidatorSet returns the validator set of a subnet at the
+// specified height in the canonical order used to verify warp messages.
+func (s *Service) GetCanonicalValidatorSet(r *http.Request, args *GetCanonicalValidatorSetArgs, reply *GetCanonicalValidatorSetReply) error {
+	height := uint64(args.Height)
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getCanonicalValidatorSet"),
+		zap.Uint64("height", height),
+		zap.Stringer("subnetID", args.SubnetID),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	vdrSet, err := s.vm.getCanonicalValidatorSet(r.Context(), height, args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("failed to get canonical validator set: %w", err)
+	}
+
+	reply.Validators = make([]CanonicalValidator, len(vdrSet.validators))
+	for i, vdr := range vdrSet.validators {
+		pk, err := formatting.Encode(formatting.HexNC, vdrSet.publicKeys[i])
+		if err != nil {
+			return err
+		}
+		reply.Validators[i] = CanonicalValidator{
+			PublicKey: pk,
+			Weight:    avajson.Uint64(vdr.Weight),
+			NodeIDs:   vdr.NodeIDs,
+		}
+	}
+	reply.TotalWeight = avajson.Uint64(vdrSet.totalWeight)
+	if vdrSet.aggregatePublicKey != nil {
+		reply.AggregatePublicKey, err = formatting.Encode(formatting.HexNC, bls.PublicKeyToBytes(vdrSet.aggregatePublicKey))
+	}
+	return err
+}
+
+// GetLivePeersArgs are the arguments for calling GetLivePeers
+type GetLivePeersArgs struct {
+	SubnetID ids.ID `json:"subnetID"`
+}
+
+// LivePeer is the latest heartbeat of a live validator
+type LivePeer struct {
+	NodeID         ids.NodeID     `json:"nodeID"`
+	Height         avajson.Uint64 `json:"height"`
+	LastSeen       time.Time      `json:"lastSeen"`
+	Version        string         `json:"version"`
+	TrackedSubnets []ids.ID       `json:"trackedSubnets"`
+}
+
+// GetLivePeersReply is the response from calling GetLivePeers
+type GetLivePeersReply struct {
+	Peers []LivePeer `json:"peers"`
+}
+
+// GetLivePeers returns the primary network validators that recently sent a
+// heartbeat announcing that they track the subnet. Unlike the validator set,
+// which lists the registered validators, it only lists the validators that
+// are online.
+func (s *Service) GetLivePeers(_ *http.Request, args *GetLivePeersArgs, reply *GetLivePeersReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getLivePeers"),
+		zap.Stringer("subnetID", args.SubnetID),
+	)
+
+	if s.vm.heartbeats == nil {
+		return errHeartbeatsDisabled
+	}
+
+	heartbeats := s.vm.heartbeats.Live(args.SubnetID)
+	reply.Peers = make([]LivePeer, len(heartbeats))
+	for i, heartbeat := range heartbeats {
+		reply.Peers[i] = LivePeer{
+			NodeID:         heartbeat.NodeID,
+			Height:         avajson.Uint64(heartbeat.Height),
+			LastSeen:       time.Unix(int64(heartbeat.Timestamp), 0),
+			Version:        heartbeat.Version,
+			TrackedSubnets: heartbeat.TrackedSubnets,
+		}
+	}
+	slices.SortFunc(reply.Peers, func(a, b LivePeer) int {
+		return a.NodeID.Compare(b.NodeID)
+	})
+	return nil
+}
+
+func (s *Service) GetBlock(_ *http.Request, args *api.GetBlockArgs, response *api.GetBlockResponse) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getBlock"),
+		zap.Stringer("blkID", args.BlockID),
+		zap.Stringer("encoding", args.Encoding),
 	)
 
 	s.vm.ctx.Lock.Lock()
@@ -2834,26 +5616,409 @@ func (s *Service) GetBlock(_ *http.Request, args *api.GetBlockArgs, response *ap
 
 	block, err := s.vm.manager.GetStatelessBlock(args.BlockID)
 	if err != nil {
-		return fmt.Errorf("couldn't get block with id %s: %w", args.BlockID, err)
+		return fmt.Errorf("couldn't get block with id %s: %w", args.BlockID, err)
+	}
+	response.Encoding = args.Encoding
+
+	var result any
+	if args.Encoding == formatting.JSON {
+		block.InitCtx(s.vm.ctx)
+		result = block
+	} else {
+		result, err = formatting.Encode(args.Encoding, block.Bytes())
+		if err != nil {
+			return fmt.Errorf("couldn't encode block %s as %s: %w", args.BlockID, args.Encoding, err)
+		}
+	}
+
+	response.Block, err = json.Marshal(result)
+	return err
+}
+
+// GetBlockByHeight returns the block at the given height.
+type GetFeesBurnedArgs struct {
+	StartHeight avajson.Uint64 `json:"startHeight"`
+	EndHeight   avajson.Uint64 `json:"endHeight"`
+}
+
+type BlockFees struct {
+	Height  avajson.Uint64 `json:"height"`
+	BlockID ids.ID         `json:"blockID"`
+	// Burned is the amount of fees destroyed by the txs of the block.
+	Burned avajson.Uint64 `json:"burned"`
+	// Redirected is the amount of fees sent to the fee treasury instead of
+	// being burned.
+	Redirected avajson.Uint64 `json:"redirected"`
+}
+
+type GetFeesBurnedReply struct {
+	Blocks     []BlockFees    `json:"blocks"`
+	Burned     avajson.Uint64 `json:"burned"`
+	Redirected avajson.Uint64 `json:"redirected"`
+}
+
+// GetFeesBurned returns the fees paid by the txs of the accepted blocks with a
+// height in [StartHeight, EndHeight].
+func (s *Service) GetFeesBurned(_ *http.Request, args *GetFeesBurnedArgs, reply *GetFeesBurnedReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getFeesBurned"),
+		zap.Uint64("startHeight", uint64(args.StartHeight)),
+		zap.Uint64("endHeight", uint64(args.EndHeight)),
+	)
+
+	if args.EndHeight < args.StartHeight || args.EndHeight-args.StartHeight >= maxFeeReportBlocks {
+		return errInvalidFeeReportRange
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	reply.Blocks = make([]BlockFees, 0, args.EndHeight-args.StartHeight+1)
+	for height := uint64(args.StartHeight); height <= uint64(args.EndHeight); height++ {
+		blkID, err := s.vm.state.GetBlockIDAtHeight(height)
+		if err != nil {
+			return fmt.Errorf("couldn't get block at height %d: %w", height, err)
+		}
+		blk, err := s.vm.manager.GetStatelessBlock(blkID)
+		if err != nil {
+			return fmt.Errorf("couldn't get block with id %s: %w", blkID, err)
+		}
+
+		// Fees are only redirected for the decision txs of Banff blocks, which
+		// are executed at the block's timestamp.
+		redirect := false
+		if banffBlk, ok := blk.(block.BanffBlock); ok {
+			redirect = s.vm.IsFeeTreasuryActivated(banffBlk.Timestamp())
+		}
+
+		var fees BlockFees
+		fees.Height = avajson.Uint64(height)
+		fees.BlockID = blkID
+		for _, tx := range blk.Txs() {
+			burned, err := txs.Burned(tx.Unsigned, s.vm.ctx.AVAXAssetID)
+			if err != nil {
+				return fmt.Errorf("couldn't calculate fees of tx %s: %w", tx.ID(), err)
+			}
+			var redirected uint64
+			if redirect {
+				redirected = executor.FeeTreasuryAmount(&s.vm.Config, burned)
+			}
+			fees.Burned += avajson.Uint64(burned - redirected)
+			fees.Redirected += avajson.Uint64(redirected)
+		}
+
+		reply.Blocks = append(reply.Blocks, fees)
+		reply.Burned += fees.Burned
+		reply.Redirected += fees.Redirected
+	}
+	return nil
+}
+
+// GetStateCertificateArgs are the arguments for calling GetStateCertificate
+type GetStateCertificateArgs struct {
+	// Height of the attested state. If it is 0, the certificate of the most
+	// recently attested state is returned.
+	Height   avajson.Uint64      `json:"height"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetStateCertificateReply is the response from calling GetStateCertificate
+type GetStateCertificateReply struct {
+	Height    avajson.Uint64 `json:"height"`
+	BlockID   ids.ID         `json:"blockID"`
+	StateHash ids.ID         `json:"stateHash"`
+	// Message is the warp message, signed by a quorum of the primary network
+	// validators at [Height], whose payload is the hash of the commitment.
+	Message  string              `json:"message"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetStateCertificate returns the certificate of the state hash at a height
+// that was attested by the validators.
+func (s *Service) GetStateCertificate(_ *http.Request, args *GetStateCertificateArgs, reply *GetStateCertificateReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getStateCertificate"),
+		zap.Uint64("height", uint64(args.Height)),
+	)
+
+	var (
+		cert *attestation.Certificate
+		err  error
+	)
+	if args.Height == 0 {
+		cert, err = s.vm.stateCertificates.GetLatest()
+	} else {
+		cert, err = s.vm.stateCertificates.Get(uint64(args.Height))
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't get state certificate: %w", err)
+	}
+
+	reply.Message, err = formatting.Encode(args.Encoding, cert.Message)
+	if err != nil {
+		return fmt.Errorf("couldn't encode state certificate as %s: %w", args.Encoding, err)
+	}
+	reply.Height = avajson.Uint64(cert.Commitment.Height)
+	reply.BlockID = cert.Commitment.BlockID
+	reply.StateHash = cert.Commitment.StateHash
+	reply.Encoding = args.Encoding
+	return nil
+}
+
+// GetNameArgs are the arguments for calling GetName
+type GetNameArgs struct {
+	Name string `json:"name"`
+}
+
+// GetNameReply is the response from calling GetName
+type GetNameReply struct {
+	// ID of the tx that last registered or updated the name
+	TxID ids.ID `json:"txID"`
+	// Node ID of the validator the name refers to
+	NodeID ids.NodeID `json:"nodeID"`
+	// Who is authorized to update the name. The name refers to its addresses.
+	Owner *platformapi.Owner `json:"owner"`
+	// Unix time the name expires at
+	Expiry avajson.Uint64 `json:"expiry"`
+	// True if the name expired and may be registered again
+	Expired bool `json:"expired"`
+}
+
+// GetName returns the validator and the owner that a registered name refers
+// to.
+func (s *Service) GetName(_ *http.Request, args *GetNameArgs, reply *GetNameReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getName"),
+		zap.String("name", args.Name),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	record, err := s.vm.state.GetName(args.Name)
+	if err != nil {
+		return fmt.Errorf("couldn't get name %q: %w", args.Name, err)
+	}
+	owner, err := executor.GetNameOwner(s.vm.state, record)
+	if err != nil {
+		return err
+	}
+	outputOwners, ok := owner.(*secp256k1fx.OutputOwners)
+	if !ok {
+		return fmt.Errorf("expected *secp256k1fx.OutputOwners but got %T", owner)
+	}
+	reply.Owner, err = s.getAPIOwner(outputOwners)
+	if err != nil {
+		return err
 	}
-	response.Encoding = args.Encoding
 
-	var result any
-	if args.Encoding == formatting.JSON {
-		block.InitCtx(s.vm.ctx)
-		result = block
-	} else {
-		result, err = formatting.Encode(args.Encoding, block.Bytes())
+	reply.TxID = record.TxID
+	reply.NodeID = record.NodeID
+	reply.Expiry = avajson.Uint64(record.Expiry)
+	reply.Expired = record.Expiry <= uint64(s.vm.state.GetTimestamp().Unix())
+	return nil
+}
+
+// GetValidatorMetadataArgs are the arguments for calling GetValidatorMetadata
+type GetValidatorMetadataArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+}
+
+// GetValidatorMetadataReply is the response from calling
+// GetValidatorMetadata
+type GetValidatorMetadataReply struct {
+	// ID of the tx that added the validator
+	TxID ids.ID `json:"txID"`
+	// Where the metadata is served
+	MetadataURL string `json:"metadataURL"`
+	// SHA-256 hash of the metadata committed to by the validator
+	MetadataHash ids.ID `json:"metadataHash"`
+	// True if the fetched metadata matches [MetadataHash]
+	Verified bool `json:"verified"`
+	// The fetched metadata.
+	// Only set if Verified is true and the metadata is a JSON document
+	Metadata json.RawMessage `json:"metadata,omitempty"`
+}
+
+// GetValidatorMetadata fetches the metadata committed to by the current
+// primary network validator and verifies it against the committed hash.
+func (s *Service) GetValidatorMetadata(r *http.Request, args *GetValidatorMetadataArgs, reply *GetValidatorMetadataReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getValidatorMetadata"),
+		zap.Stringer("nodeID", args.NodeID),
+	)
+
+	metadataTx, txID, err := s.getValidatorMetadataTx(args.NodeID)
+	if err != nil {
+		return err
+	}
+
+	// The metadata is fetched without holding the lock, as the server may be
+	// slow to respond.
+	metadata, err := fetchValidatorMetadata(r.Context(), metadataTx.MetadataURL)
+	if err != nil {
+		return fmt.Errorf("couldn't fetch metadata of %s: %w", args.NodeID, err)
+	}
+
+	reply.TxID = txID
+	reply.MetadataURL = metadataTx.MetadataURL
+	reply.MetadataHash = metadataTx.MetadataHash
+	reply.Verified = hashing.ComputeHash256Array(metadata) == metadataTx.MetadataHash
+	if reply.Verified && json.Valid(metadata) {
+		reply.Metadata = metadata
+	}
+	return nil
+}
+
+func (s *Service) getValidatorMetadataTx(nodeID ids.NodeID) (*txs.AddPermissionlessValidatorWithMetadataTx, ids.ID, error) {
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	vdr, err := s.vm.state.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+	if err != nil {
+		return nil, ids.Empty, fmt.Errorf("couldn't get validator %s: %w", nodeID, err)
+	}
+	vdrTx, _, err := s.vm.state.GetTx(vdr.TxID)
+	if err != nil {
+		return nil, ids.Empty, fmt.Errorf("couldn't get validator tx %s: %w", vdr.TxID, err)
+	}
+	metadataTx, ok := vdrTx.Unsigned.(*txs.AddPermissionlessValidatorWithMetadataTx)
+	if !ok {
+		return nil, ids.Empty, fmt.Errorf("%w: %s", errNoValidatorMetadata, nodeID)
+	}
+	return metadataTx, vdr.TxID, nil
+}
+
+// fetchValidatorMetadata returns the document served at [metadataURL].
+func fetchValidatorMetadata(ctx context.Context, metadataURL string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, validatorMetadataFetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metadataURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %q", resp.Status)
+	}
+	metadata, err := io.ReadAll(io.LimitReader(resp.Body, maxValidatorMetadataSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(metadata) > maxValidatorMetadataSize {
+		return nil, errValidatorMetadataTooLarge
+	}
+	return metadata, nil
+}
+
+// VerifyGenesisArgs are the arguments for calling VerifyGenesis
+type VerifyGenesisArgs struct {
+	// Genesis bytes to verify. If empty, the genesis bytes the chain was
+	// initialized with are verified.
+	Genesis  string              `json:"genesis"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// VerifyGenesisReply is the response from calling VerifyGenesis
+type VerifyGenesisReply struct {
+	// True if the genesis produces the accepted genesis block and nothing it
+	// created was altered
+	Valid bool `json:"valid"`
+	// Hash of the verified genesis bytes
+	GenesisID ids.ID `json:"genesisID"`
+	// True if the verified genesis bytes are the ones the chain was
+	// initialized with
+	MatchesInitialGenesis bool `json:"matchesInitialGenesis"`
+	// ID of the genesis block produced by the verified genesis bytes
+	GenesisBlockID ids.ID `json:"genesisBlockID"`
+	// ID of the genesis block accepted by the chain
+	AcceptedGenesisBlockID ids.ID `json:"acceptedGenesisBlockID"`
+
+	Timestamp     avajson.Uint64 `json:"timestamp"`
+	InitialSupply avajson.Uint64 `json:"initialSupply"`
+	Message       string         `json:"message"`
+
+	// Amount allocated at genesis to each asset
+	Allocations map[ids.ID]avajson.Uint64 `json:"allocations"`
+
+	NumUTXOs             avajson.Uint32 `json:"numUTXOs"`
+	NumUnspentUTXOs      avajson.Uint32 `json:"numUnspentUTXOs"`
+	NumSpentUTXOs        avajson.Uint32 `json:"numSpentUTXOs"`
+	NumValidators        avajson.Uint32 `json:"numValidators"`
+	NumCurrentValidators avajson.Uint32 `json:"numCurrentValidators"`
+	NumChains            avajson.Uint32 `json:"numChains"`
+	MismatchedUTXOIDs    []ids.ID       `json:"mismatchedUTXOIDs"`
+	MismatchedTxIDs      []ids.ID       `json:"mismatchedTxIDs"`
+}
+
+// VerifyGenesis recomputes the UTXOs, the validators and the chains created at
+// genesis and compares them against the state of the chain.
+func (s *Service) VerifyGenesis(_ *http.Request, args *VerifyGenesisArgs, reply *VerifyGenesisReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "verifyGenesis"),
+	)
+
+	genesisBytes := s.vm.genesisBytes
+	if args.Genesis != "" {
+		var err error
+		genesisBytes, err = formatting.Decode(args.Encoding, args.Genesis)
 		if err != nil {
-			return fmt.Errorf("couldn't encode block %s as %s: %w", args.BlockID, args.Encoding, err)
+			return fmt.Errorf("couldn't decode genesis as %s: %w", args.Encoding, err)
 		}
 	}
 
-	response.Block, err = json.Marshal(result)
-	return err
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	v, err := verifyGenesis(genesisBytes, s.vm.state)
+	if err != nil {
+		return err
+	}
+
+	allocations := make(map[ids.ID]uint64)
+	for _, utxo := range v.genesis.UTXOs {
+		out, ok := utxo.Out.(avax.Amounter)
+		if !ok {
+			continue
+		}
+		assetID := utxo.AssetID()
+		allocations[assetID], err = safemath.Add64(allocations[assetID], out.Amount())
+		if err != nil {
+			return err
+		}
+	}
+
+	reply.Valid = v.valid()
+	reply.GenesisID = v.genesisID
+	reply.MatchesInitialGenesis = v.genesisID == hashing.ComputeHash256Array(s.vm.genesisBytes)
+	reply.GenesisBlockID = v.genesisBlockID
+	reply.AcceptedGenesisBlockID = v.acceptedGenesisBlockID
+	reply.Timestamp = avajson.Uint64(v.genesis.Timestamp)
+	reply.InitialSupply = avajson.Uint64(v.genesis.InitialSupply)
+	reply.Message = v.genesis.Message
+	reply.Allocations = newJSONBalanceMap(allocations)
+	reply.NumUTXOs = avajson.Uint32(len(v.genesis.UTXOs))
+	reply.NumUnspentUTXOs = avajson.Uint32(v.unspentUTXOs)
+	reply.NumSpentUTXOs = avajson.Uint32(v.spentUTXOs)
+	reply.NumValidators = avajson.Uint32(len(v.genesis.Validators))
+	reply.NumCurrentValidators = avajson.Uint32(v.currentValidators)
+	reply.NumChains = avajson.Uint32(len(v.genesis.Chains))
+	reply.MismatchedUTXOIDs = v.mismatchedUTXOs
+	reply.MismatchedTxIDs = v.mismatchedTxs
+	return nil
 }
 
-// GetBlockByHeight returns the block at the given height.
 func (s *Service) GetBlockByHeight(_ *http.Request, args *api.GetBlockByHeightArgs, response *api.GetBlockResponse) error {
 	s.vm.ctx.Log.Debug("API called",
 		zap.String("service", "platform"),
@@ -2895,6 +6060,376 @@ func (s *Service) GetBlockByHeight(_ *http.Request, args *api.GetBlockByHeightAr
 	return err
 }
 
+type TraceBlockVerificationArgs struct {
+	// BlockID of a processing block. Ignored if Block is provided.
+	BlockID ids.ID `json:"blockID"`
+	// Block, if provided, is parsed and traced instead of looking up BlockID.
+	// This allows tracing blocks that failed verification, which the node
+	// doesn't keep around.
+	Block    string              `json:"block"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+type TraceBlockVerificationReply struct {
+	BlockID  ids.ID         `json:"blockID"`
+	Accesses []state.Access `json:"accesses"`
+	// ForkChecks are the network upgrade activation checks that gated the
+	// branches taken by the verification.
+	ForkChecks []config.ForkCheck `json:"forkChecks"`
+	// FailedTxID is the tx that failed execution, if any.
+	FailedTxID ids.ID `json:"failedTxID"`
+	// Error returned by the verification. Empty if the block is valid.
+	Error string `json:"error,omitempty"`
+	// Rules are the validation rules the block violates, if any.
+	Rules []string `json:"rules,omitempty"`
+}
+
+// TraceBlockVerification re-runs the verification of a block, returning every
+// state read and write and every fork activation check performed along with
+// the failing check, if any. Comparing the traces of a block produced by two
+// versions of the node verifies that they execute it identically.
+func (s *Service) TraceBlockVerification(_ *http.Request, args *TraceBlockVerificationArgs, reply *TraceBlockVerificationReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "traceBlockVerification"),
+		zap.Stringer("blkID", args.BlockID),
+	)
+
+	if !s.vm.execConfig.VerificationTracingEnabled {
+		return errVerificationTracingOff
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	var blkBytes []byte
+	if args.Block != "" {
+		var err error
+		blkBytes, err = formatting.Decode(args.Encoding, args.Block)
+		if err != nil {
+			return fmt.Errorf("problem decoding block: %w", err)
+		}
+	} else {
+		blk, err := s.vm.manager.GetStatelessBlock(args.BlockID)
+		if err != nil {
+			return fmt.Errorf("couldn't get block with id %s: %w", args.BlockID, err)
+		}
+		blkBytes = blk.Bytes()
+	}
+
+	// Processing blocks are always re-parsed, as the block kept by the
+	// verifier may not be the original Banff block.
+	blk, err := block.Parse(block.Codec, blkBytes)
+	if err != nil {
+		return fmt.Errorf("couldn't parse block: %w", err)
+	}
+
+	trace := s.vm.manager.TraceVerify(blk)
+	reply.BlockID = trace.BlockID
+	reply.Accesses = trace.Accesses
+	reply.ForkChecks = trace.ForkChecks
+	reply.FailedTxID = trace.FailedTxID
+	if trace.Err != nil {
+		reply.Error = trace.Err.Error()
+	}
+	reply.Rules = trace.Rules
+	return nil
+}
+
+type PrevalidateBlockArgs struct {
+	Block    string              `json:"block"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// APIStateChange summarizes the writes of one kind that the verification of a
+// block performed on one of the states it produced
+type APIStateChange struct {
+	// Scope is the state that was written to. It is "onAccept" for standard
+	// and atomic blocks, and "onCommit" or "onAbort" for proposal blocks.
+	Scope  string `json:"scope"`
+	Method string `json:"method"`
+	Count  int    `json:"count"`
+	// Keys are the distinct keys written, in the order they were first
+	// written.
+	Keys []string `json:"keys,omitempty"`
+	// Value is the value of the last write.
+	Value string `json:"value,omitempty"`
+}
+
+type PrevalidateBlockReply struct {
+	BlockID ids.ID `json:"blockID"`
+	// Feasible is true if the block can be accepted on top of the preferred
+	// block.
+	Feasible bool `json:"feasible"`
+	// FailedTxID is the tx that failed execution, if any.
+	FailedTxID ids.ID `json:"failedTxID"`
+	// Error returned by the verification. Empty if the block is feasible.
+	Error string `json:"error,omitempty"`
+	// Rules are the validation rules the block violates, if any.
+	Rules []string `json:"rules,omitempty"`
+	// Changes summarize the state changes the block would perform.
+	Changes []APIStateChange `json:"changes"`
+}
+
+// PrevalidateBlock verifies a candidate block built on top of the preferred
+// block without issuing it to consensus. The block is verified the same way
+// TraceBlockVerification does, so neither the processing blocks nor the
+// mempool are modified.
+func (s *Service) PrevalidateBlock(_ *http.Request, args *PrevalidateBlockArgs, reply *PrevalidateBlockReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "prevalidateBlock"),
+	)
+
+	if !s.vm.execConfig.BlockPrevalidationEnabled {
+		return errBlockPrevalidationOff
+	}
+
+	blkBytes, err := formatting.Decode(args.Encoding, args.Block)
+	if err != nil {
+		return fmt.Errorf("problem decoding block: %w", err)
+	}
+	blk, err := block.Parse(block.Codec, blkBytes)
+	if err != nil {
+		return fmt.Errorf("couldn't parse block: %w", err)
+	}
+	reply.BlockID = blk.ID()
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	if preferredID := s.vm.manager.Preferred(); blk.Parent() != preferredID {
+		reply.Error = fmt.Sprintf("%s: expected %s but got %s", errParentNotPreferred, preferredID, blk.Parent())
+		reply.Rules = []string{errParentNotPreferred.Error()}
+		return nil
+	}
+
+	trace := s.vm.manager.TraceVerify(blk)
+	reply.Feasible = trace.Err == nil
+	reply.FailedTxID = trace.FailedTxID
+	if trace.Err != nil {
+		reply.Error = trace.Err.Error()
+	}
+	reply.Rules = trace.Rules
+	reply.Changes = summarizeStateChanges(trace.Accesses)
+	return nil
+}
+
+// summarizeStateChanges groups the writes of [accesses] by scope and method,
+// in the order they were first performed.
+func summarizeStateChanges(accesses []state.Access) []APIStateChange {
+	type changeKey struct {
+		scope  string
+		method string
+	}
+	type writtenKey struct {
+		changeKey
+		key string
+	}
+	var (
+		changes     = []APIStateChange{}
+		changeIndex = make(map[changeKey]int)
+		writtenKeys = set.Set[writtenKey]{}
+	)
+	for _, access := range accesses {
+		if !access.Write || access.Error != "" {
+			continue
+		}
+
+		k := changeKey{
+			scope:  access.Scope,
+			method: access.Method,
+		}
+		i, ok := changeIndex[k]
+		if !ok {
+			i = len(changes)
+			changeIndex[k] = i
+			changes = append(changes, APIStateChange{
+				Scope:  access.Scope,
+				Method: access.Method,
+			})
+		}
+
+		change := &changes[i]
+		change.Count++
+		change.Value = access.Value
+		written := writtenKey{
+			changeKey: k,
+			key:       access.Key,
+		}
+		if access.Key != "" && !writtenKeys.Contains(written) {
+			writtenKeys.Add(written)
+			change.Keys = append(change.Keys, access.Key)
+		}
+	}
+	return changes
+}
+
+// ConvertAddressArgs are the arguments for calling ConvertAddress
+type ConvertAddressArgs struct {
+	// Address in any of the formats returned by ConvertAddress, other than
+	// the EVM format
+	Address string `json:"address"`
+	// Hex encoded compressed secp256k1 public key. Required to derive the EVM
+	// address.
+	PublicKey string `json:"publicKey"`
+}
+
+// ConvertAddressReply is the response from calling ConvertAddress
+type ConvertAddressReply struct {
+	// Hex encoding of the 20 bytes of the address
+	Hex string `json:"hex"`
+	// Bech32 address on the P-chain
+	PChainAddress string `json:"pChainAddress"`
+	// Bech32 address on the C-chain, used by atomic txs
+	CChainAddress string `json:"cChainAddress"`
+	// Address of the EVM account of the C-chain controlled by the same key.
+	// Only set if the public key was given.
+	EVMAddress string `json:"evmAddress,omitempty"`
+}
+
+// ConvertAddress returns the encodings of an address on the P-chain and the
+// C-chain. The address is given either in one of these encodings or as the
+// public key that it is derived from.
+//
+// The EVM address of a key is derived with a different hash than its other
+// encodings, so it can only be returned if the public key is given.
+func (s *Service) ConvertAddress(_ *http.Request, args *ConvertAddressArgs, reply *ConvertAddressReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "convertAddress"),
+		zap.String("address", args.Address),
+	)
+
+	var (
+		addr ids.ShortID
+		err  error
+	)
+	switch {
+	case args.PublicKey != "":
+		pkBytes, err := formatting.Decode(formatting.HexNC, args.PublicKey)
+		if err != nil {
+			return fmt.Errorf("couldn't decode public key: %w", err)
+		}
+		pk, err := secp256k1.ToPublicKey(pkBytes)
+		if err != nil {
+			return fmt.Errorf("couldn't parse public key: %w", err)
+		}
+		addr = pk.Address()
+		reply.EVMAddress = crypto.PubkeyToAddress(*pk.ToECDSA()).Hex()
+
+		if args.Address != "" {
+			givenAddr, err := s.parseAnyAddress(args.Address)
+			if err != nil {
+				return err
+			}
+			if givenAddr != addr {
+				return fmt.Errorf("%w: %s", errPublicKeyAddressMismatch, args.Address)
+			}
+		}
+	case args.Address != "":
+		addr, err = s.parseAnyAddress(args.Address)
+		if err != nil {
+			return err
+		}
+	default:
+		return errNoAddressToConvert
+	}
+
+	reply.Hex, err = formatting.Encode(formatting.HexNC, addr[:])
+	if err != nil {
+		return err
+	}
+	reply.PChainAddress, err = s.addrManager.FormatLocalAddress(addr)
+	if err != nil {
+		return err
+	}
+	reply.CChainAddress, err = s.addrManager.FormatAddress(s.vm.ctx.CChainID, addr)
+	return err
+}
+
+// parseAnyAddress parses [addrStr] given as a hex string, as a bech32 string,
+// or as a bech32 string prefixed by the alias of a chain. The HRP of a bech32
+// string must be the HRP of the network.
+func (s *Service) parseAnyAddress(addrStr string) (ids.ShortID, error) {
+	if strings.HasPrefix(addrStr, "0x") {
+		addrBytes, err := formatting.Decode(formatting.HexNC, addrStr)
+		if err != nil {
+			return ids.ShortEmpty, fmt.Errorf("couldn't decode address %q: %w", addrStr, err)
+		}
+		return ids.ToShortID(addrBytes)
+	}
+	if strings.Contains(addrStr, "-") {
+		_, addr, err := s.addrManager.ParseAddress(addrStr)
+		if err != nil {
+			return ids.ShortEmpty, fmt.Errorf("couldn't parse address %q: %w", addrStr, err)
+		}
+		return addr, nil
+	}
+
+	hrp, addrBytes, err := address.ParseBech32(addrStr)
+	if err != nil {
+		return ids.ShortEmpty, fmt.Errorf("couldn't parse address %q: %w", addrStr, err)
+	}
+	if expectedHRP := constants.GetHRP(s.vm.ctx.NetworkID); hrp != expectedHRP {
+		return ids.ShortEmpty, fmt.Errorf("%w: expected %q but got %q", errUnexpectedHRP, expectedHRP, hrp)
+	}
+	return ids.ToShortID(addrBytes)
+}
+
+// ValidateAddressArgs are the arguments for calling ValidateAddress
+type ValidateAddressArgs struct {
+	Address string `json:"address"`
+}
+
+// ValidateAddressReply is the response from calling ValidateAddress
+type ValidateAddressReply struct {
+	// True if the address is a valid bech32 address on a chain of this network
+	Valid bool `json:"valid"`
+	// Why the address is invalid. Only set if Valid is false.
+	Reason string `json:"reason,omitempty"`
+	// ID of the chain that the address is on
+	ChainID ids.ID `json:"chainID"`
+	// HRP of the address
+	HRP string `json:"hrp"`
+	// HRP of the addresses of this network
+	ExpectedHRP string `json:"expectedHRP"`
+}
+
+// ValidateAddress checks the checksum of a bech32 address and that its chain
+// and its HRP are those of this network.
+func (s *Service) ValidateAddress(_ *http.Request, args *ValidateAddressArgs, reply *ValidateAddressReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "validateAddress"),
+		zap.String("address", args.Address),
+	)
+
+	reply.ExpectedHRP = constants.GetHRP(s.vm.ctx.NetworkID)
+
+	chainIDAlias, hrp, addrBytes, err := address.Parse(args.Address)
+	if err != nil {
+		reply.Reason = err.Error()
+		return nil
+	}
+	reply.HRP = hrp
+	if hrp != reply.ExpectedHRP {
+		reply.Reason = fmt.Sprintf("expected hrp %q but got %q", reply.ExpectedHRP, hrp)
+		return nil
+	}
+	if _, err := ids.ToShortID(addrBytes); err != nil {
+		reply.Reason = err.Error()
+		return nil
+	}
+	reply.ChainID, err = s.vm.ctx.BCLookup.Lookup(chainIDAlias)
+	if err != nil {
+		reply.Reason = fmt.Sprintf("unknown chain %q", chainIDAlias)
+		return nil
+	}
+	reply.Valid = true
+	return nil
+}
+
 func (s *Service) getAPIUptime(staker *state.Staker) (*avajson.Float32, error) {
 	// Only report uptimes that we have been actively tracking.
 	if constants.PrimaryNetworkID != staker.SubnetID && !s.vm.TrackedSubnets.Contains(staker.SubnetID) {
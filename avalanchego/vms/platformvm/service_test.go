@@ -10,9 +10,13 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
@@ -23,22 +27,35 @@ import (
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/attestation"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block/builder"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/network"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/mempool"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	avajson "github.com/ava-labs/avalanchego/utils/json"
@@ -207,7 +224,7 @@ func TestGetTxStatus(t *testing.T) {
 	tx, err := service.vm.txBuilder.NewImportTx(
 		service.vm.ctx.XChainID,
 		ids.ShortEmpty,
-		[]*secp256k1.PrivateKey{recipientKey},
+		secp256k1fx.NewKeychain(recipientKey),
 		ids.ShortEmpty,
 		nil,
 	)
@@ -243,7 +260,242 @@ func TestGetTxStatus(t *testing.T) {
 	require.Zero(resp.Reason)
 }
 
+func TestGetTxInclusionProof(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+
+	tx, err := service.vm.txBuilder.NewCreateSubnetTx(
+		1,
+		[]ids.ShortID{keys[0].PublicKey().Address()},
+		secp256k1fx.NewKeychain(keys[0]),
+		keys[0].PublicKey().Address(),
+		nil,
+	)
+	require.NoError(err)
+	service.vm.ctx.Lock.Unlock()
+
+	args := &GetTxInclusionProofArgs{TxID: tx.ID()}
+	reply := GetTxInclusionProofReply{}
+	err = service.GetTxInclusionProof(nil, args, &reply)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	require.NoError(service.vm.Network.IssueTx(context.Background(), tx))
+	service.vm.ctx.Lock.Lock()
+	blk, err := service.vm.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(blk.Accept(context.Background()))
+	service.vm.ctx.Lock.Unlock()
+
+	require.NoError(service.GetTxInclusionProof(nil, args, &reply))
+	require.Equal(blk.ID(), reply.BlockID)
+	require.Equal(avajson.Uint64(blk.Height()), reply.Height)
+	require.True(block.VerifyTxInclusion(
+		reply.Root,
+		tx.ID(),
+		int(reply.Index),
+		int(reply.NumTxs),
+		reply.Proof,
+	))
+}
+
+func TestCheckImportTx(t *testing.T) {
+	require := require.New(t)
+	service, mutableSharedMemory := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+
+	recipientKey, err := secp256k1.NewPrivateKey()
+	require.NoError(err)
+
+	m := atomic.NewMemory(prefixdb.New([]byte{}, service.vm.db))
+	sm := m.NewSharedMemory(service.vm.ctx.ChainID)
+	peerSharedMemory := m.NewSharedMemory(service.vm.ctx.XChainID)
+
+	utxo := &avax.UTXO{
+		UTXOID: avax.UTXOID{
+			TxID:        ids.GenerateTestID(),
+			OutputIndex: 1,
+		},
+		Asset: avax.Asset{ID: service.vm.ctx.AVAXAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 1234567,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Addrs:     []ids.ShortID{recipientKey.PublicKey().Address()},
+				Threshold: 1,
+			},
+		},
+	}
+	utxoBytes, err := txs.Codec.Marshal(txs.CodecVersion, utxo)
+	require.NoError(err)
+
+	inputID := utxo.InputID()
+	require.NoError(peerSharedMemory.Apply(map[ids.ID]*atomic.Requests{
+		service.vm.ctx.ChainID: {
+			PutRequests: []*atomic.Element{
+				{
+					Key:   inputID[:],
+					Value: utxoBytes,
+					Traits: [][]byte{
+						recipientKey.PublicKey().Address().Bytes(),
+					},
+				},
+			},
+		},
+	}))
+
+	mutableSharedMemory.SharedMemory = sm
+
+	tx, err := service.vm.txBuilder.NewImportTx(
+		service.vm.ctx.XChainID,
+		ids.ShortEmpty,
+		secp256k1fx.NewKeychain(recipientKey),
+		ids.ShortEmpty,
+		nil,
+	)
+	require.NoError(err)
+
+	service.vm.ctx.Lock.Unlock()
+
+	txStr, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	require.NoError(err)
+	args := &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}
+
+	var reply CheckImportTxReply
+	require.NoError(service.CheckImportTx(nil, args, &reply))
+	require.Equal(service.vm.ctx.XChainID, reply.SourceChain)
+	require.Empty(reply.MissingUTXOs)
+
+	// Consume the UTXO from shared memory.
+	require.NoError(sm.Apply(map[ids.ID]*atomic.Requests{
+		service.vm.ctx.XChainID: {
+			RemoveRequests: [][]byte{inputID[:]},
+		},
+	}))
+
+	reply = CheckImportTxReply{}
+	require.NoError(service.CheckImportTx(nil, args, &reply))
+	require.Equal([]avax.UTXOID{utxo.UTXOID}, reply.MissingUTXOs)
+
+	// Only ImportTxs can be checked.
+	service.vm.ctx.Lock.Lock()
+	createSubnetTx, err := service.vm.txBuilder.NewCreateSubnetTx(
+		1,
+		[]ids.ShortID{keys[0].PublicKey().Address()},
+		secp256k1fx.NewKeychain(keys[0]),
+		keys[0].PublicKey().Address(),
+		nil,
+	)
+	require.NoError(err)
+	service.vm.ctx.Lock.Unlock()
+
+	args.Tx, err = formatting.Encode(formatting.Hex, createSubnetTx.Bytes())
+	require.NoError(err)
+	err = service.CheckImportTx(nil, args, &reply)
+	require.ErrorIs(err, errNotImportTx)
+}
+
+func TestInspectTx(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+
+	service.vm.ctx.Lock.Lock()
+	tx, err := service.vm.txBuilder.NewCreateChainTx(
+		testSubnet1.ID(),
+		[]byte{},
+		constants.AVMID,
+		[]ids.ID{},
+		"chain name",
+		secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
+		keys[0].PublicKey().Address(), // change addr
+		nil,
+	)
+	require.NoError(err)
+	expectedFee := service.vm.Config.GetCreateBlockchainTxFee(service.vm.state.GetTimestamp())
+	service.vm.ctx.Lock.Unlock()
+
+	// The signed tx holds the signatures it requires
+	var numSigs uint64
+	for _, cred := range tx.Creds {
+		numSigs += uint64(len(cred.(*secp256k1fx.Credential).Sigs))
+	}
+	expectedComplexity, err := txs.Complexity(tx)
+	require.NoError(err)
+
+	txStr, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	require.NoError(err)
+	reply := InspectTxReply{}
+	require.NoError(service.InspectTx(nil, &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, &reply))
+	require.Equal(tx.ID(), reply.TxID)
+	require.Equal("CreateChainTx", reply.Type)
+	require.Equal(avajson.Uint64(len(tx.Bytes())), reply.Size)
+	require.Equal(avajson.Uint64(expectedComplexity), reply.Complexity)
+	require.Equal(avajson.Uint64(numSigs), reply.RequiredSignatures)
+	require.Equal(avajson.Uint64(expectedFee), reply.Fee)
+	require.Equal(service.vm.ctx.AVAXAssetID, reply.FeeAssetID)
+}
+
 // Test issuing and then retrieving a transaction
+func TestGetMempoolGraph(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+
+	newTx := func(chainName string) *txs.Tx {
+		tx, err := service.vm.txBuilder.NewCreateChainTx(
+			testSubnet1.ID(),
+			[]byte{},
+			constants.AVMID,
+			[]ids.ID{},
+			chainName,
+			secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
+			keys[0].PublicKey().Address(), // change addr
+			nil,
+		)
+		require.NoError(err)
+		return tx
+	}
+
+	// Both txs are funded by the same UTXOs
+	mempoolTx := newTx("chain name")
+	conflictingTx := newTx("other chain name")
+	require.NoError(service.vm.Builder.Add(mempoolTx))
+
+	service.vm.ctx.Lock.Unlock()
+
+	txStr, err := formatting.Encode(formatting.Hex, conflictingTx.Bytes())
+	require.NoError(err)
+
+	var reply GetMempoolGraphReply
+	require.NoError(service.GetMempoolGraph(nil, &GetMempoolGraphArgs{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, &reply))
+	require.Equal([]ids.ID{mempoolTx.ID()}, reply.Txs)
+	require.Empty(reply.Dependencies)
+	require.Empty(reply.DOT)
+	require.Equal([]ids.ID{mempoolTx.ID()}, reply.Conflicts)
+
+	reply = GetMempoolGraphReply{}
+	require.NoError(service.GetMempoolGraph(nil, &GetMempoolGraphArgs{
+		Format: MempoolGraphFormatDOT,
+	}, &reply))
+	require.Empty(reply.Txs)
+	require.Contains(reply.DOT, mempoolTx.ID().String())
+	require.Empty(reply.Conflicts)
+
+	err = service.GetMempoolGraph(nil, &GetMempoolGraphArgs{
+		Format: "svg",
+	}, &reply)
+	require.ErrorIs(err, errUnknownGraphFormat)
+}
+
 func TestGetTx(t *testing.T) {
 	type test struct {
 		description string
@@ -260,7 +512,7 @@ func TestGetTx(t *testing.T) {
 					constants.AVMID,
 					[]ids.ID{},
 					"chain name",
-					[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+					secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
 					keys[0].PublicKey().Address(), // change addr
 					nil,
 				)
@@ -280,7 +532,7 @@ func TestGetTx(t *testing.T) {
 					signer.NewProofOfPossession(sk),
 					ids.GenerateTestShortID(),
 					0,
-					[]*secp256k1.PrivateKey{keys[0]},
+					secp256k1fx.NewKeychain(keys[0]),
 					keys[0].PublicKey().Address(), // change addr
 					nil,
 				)
@@ -293,7 +545,7 @@ func TestGetTx(t *testing.T) {
 					100,
 					service.vm.ctx.XChainID,
 					ids.GenerateTestShortID(),
-					[]*secp256k1.PrivateKey{keys[0]},
+					secp256k1fx.NewKeychain(keys[0]),
 					keys[0].PublicKey().Address(), // change addr
 					nil,
 				)
@@ -482,7 +734,7 @@ func TestGetStake(t *testing.T) {
 		uint64(delegatorEndTime.Unix()),
 		delegatorNodeID,
 		ids.GenerateTestShortID(),
-		[]*secp256k1.PrivateKey{keys[0]},
+		secp256k1fx.NewKeychain(keys[0]),
 		keys[0].PublicKey().Address(), // change addr
 		nil,
 	)
@@ -538,7 +790,7 @@ func TestGetStake(t *testing.T) {
 		pendingStakerNodeID,
 		ids.GenerateTestShortID(),
 		0,
-		[]*secp256k1.PrivateKey{keys[0]},
+		secp256k1fx.NewKeychain(keys[0]),
 		keys[0].PublicKey().Address(), // change addr
 		nil,
 	)
@@ -574,6 +826,167 @@ func TestGetStake(t *testing.T) {
 	require.Equal(stakeAmount+oldStake, outputs[0].Out.Amount()+outputs[1].Out.Amount()+outputs[2].Out.Amount())
 }
 
+func TestGetSpendability(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+
+	// The genesis UTXOs are spendable
+	addr, err := service.addrManager.FormatLocalAddress(keys[1].PublicKey().Address())
+	require.NoError(err)
+	args := api.JSONAddresses{
+		Addresses: []string{addr},
+	}
+	reply := GetSpendabilityReply{}
+	require.NoError(service.GetSpendability(nil, &args, &reply))
+	require.Len(reply.Spendable, 1)
+	require.Equal(avajson.Uint64(defaultBalance), reply.Spendable[0].Amount)
+	require.Empty(reply.Locked)
+	require.Equal(avajson.Uint64(defaultBalance), reply.Spendables[service.vm.ctx.AVAXAssetID])
+
+	// Give the key of a genesis validator a stakeable locked UTXO
+	service.vm.ctx.Lock.Lock()
+	var (
+		validatorAddr = ids.ShortID(genesisNodeIDs[0])
+		chainTime     = service.vm.state.GetTimestamp()
+		locktime      = uint64(chainTime.Add(time.Hour).Unix())
+		lockedAmount  = 5 * units.Avax
+	)
+	service.vm.state.AddUTXO(&avax.UTXO{
+		UTXOID: avax.UTXOID{
+			TxID: ids.GenerateTestID(),
+		},
+		Asset: avax.Asset{ID: service.vm.ctx.AVAXAssetID},
+		Out: &stakeable.LockOut{
+			Locktime: locktime,
+			TransferableOut: &secp256k1fx.TransferOutput{
+				Amt: lockedAmount,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{validatorAddr},
+				},
+			},
+		},
+	})
+	require.NoError(service.vm.state.Commit())
+	service.vm.ctx.Lock.Unlock()
+
+	addr, err = service.addrManager.FormatLocalAddress(validatorAddr)
+	require.NoError(err)
+	args.Addresses = []string{addr}
+	reply = GetSpendabilityReply{}
+	require.NoError(service.GetSpendability(nil, &args, &reply))
+	require.Equal(chainTime, reply.Time)
+	require.Len(reply.Spendable, 1)
+
+	require.Len(reply.Locked, 1)
+	locked := reply.Locked[0]
+	require.Equal(avajson.Uint64(lockedAmount), locked.Amount)
+	require.Zero(locked.StakeableTime)
+	require.Equal(avajson.Uint64(locktime), locked.UnlockTime)
+
+	require.Len(reply.Bonded, 1)
+	bonded := reply.Bonded[0]
+	require.Equal(genesisNodeIDs[0], bonded.NodeID)
+	require.False(bonded.Delegator)
+	require.Equal(avajson.Uint64(defaultWeight), bonded.Amount)
+	require.Equal(avajson.Uint64(defaultValidateEndTime.Unix()), bonded.EndTime)
+	require.Equal(bonded.EndTime, bonded.UnlockTime)
+	require.Equal(avajson.Uint64(defaultWeight), reply.Bondeds[service.vm.ctx.AVAXAssetID])
+}
+
+func TestGetStakeOwnershipProof(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+
+	service.vm.ctx.Lock.Lock()
+
+	// Delegate from keys[0], which also owns the stake of the first genesis
+	// validator.
+	stakeAmount := service.vm.MinDelegatorStake
+	delegatorEndTime := defaultGenesisTime.Add(defaultMinStakingDuration)
+	tx, err := service.vm.txBuilder.NewAddDelegatorTx(
+		stakeAmount,
+		uint64(defaultValidateStartTime.Unix()),
+		uint64(delegatorEndTime.Unix()),
+		genesisNodeIDs[1],
+		ids.GenerateTestShortID(),
+		secp256k1fx.NewKeychain(keys[0]),
+		keys[0].Address(),
+		nil,
+	)
+	require.NoError(err)
+
+	staker, err := state.NewCurrentStaker(
+		tx.ID(),
+		tx.Unsigned.(*txs.AddDelegatorTx),
+		defaultValidateStartTime,
+		0,
+	)
+	require.NoError(err)
+
+	service.vm.state.PutCurrentDelegator(staker)
+	service.vm.state.AddTx(tx, status.Committed)
+	require.NoError(service.vm.state.Commit())
+
+	service.vm.ctx.Lock.Unlock()
+
+	provenAddr, err := service.addrManager.FormatLocalAddress(keys[0].Address())
+	require.NoError(err)
+	otherAddr, err := service.addrManager.FormatLocalAddress(keys[1].Address())
+	require.NoError(err)
+
+	message := "audit 2024"
+	sig, err := keys[0].SignHash(signedMessageHash([]byte(message)))
+	require.NoError(err)
+	sigStr, err := formatting.Encode(formatting.Hex, sig)
+	require.NoError(err)
+
+	args := GetStakeArgs{
+		JSONAddresses: api.JSONAddresses{
+			Addresses: []string{provenAddr},
+		},
+		Encoding: formatting.Hex,
+		Proof: &StakeOwnershipProof{
+			Message:    message,
+			Signatures: []string{sigStr},
+		},
+	}
+	response := GetStakeReply{}
+	require.NoError(service.GetStake(nil, &args, &response))
+
+	avaxAssetID := service.vm.ctx.AVAXAssetID
+	require.Equal(message, response.Message)
+	require.Equal(avajson.Uint64(defaultWeight), response.ValidatorStakeds[avaxAssetID])
+	require.Equal(avajson.Uint64(stakeAmount), response.DelegatorStakeds[avaxAssetID])
+	require.Equal(avajson.Uint64(defaultWeight+stakeAmount), response.Staked)
+	require.Len(response.Stakers, 2)
+	for _, stakerStake := range response.Stakers {
+		if stakerStake.Delegator {
+			require.Equal(tx.ID(), stakerStake.TxID)
+			require.Equal(genesisNodeIDs[1], stakerStake.NodeID)
+			require.Equal(avajson.Uint64(stakeAmount), stakerStake.Stakeds[avaxAssetID])
+		} else {
+			require.Equal(genesisNodeIDs[0], stakerStake.NodeID)
+			require.Equal(avajson.Uint64(defaultWeight), stakerStake.Stakeds[avaxAssetID])
+		}
+	}
+
+	// Every address must be proven.
+	args.Addresses = []string{provenAddr, otherAddr}
+	err = service.GetStake(nil, &args, &GetStakeReply{})
+	require.ErrorIs(err, errAddressNotProven)
+
+	// The signature must be of the message.
+	args.Addresses = []string{provenAddr}
+	args.Proof.Message = "audit 2025"
+	err = service.GetStake(nil, &args, &GetStakeReply{})
+	require.ErrorIs(err, errAddressNotProven)
+
+	args.Proof.Signatures = nil
+	err = service.GetStake(nil, &args, &GetStakeReply{})
+	require.ErrorIs(err, errNoStakeProofSignatures)
+}
+
 func TestGetCurrentValidators(t *testing.T) {
 	require := require.New(t)
 	service, _ := defaultService(t)
@@ -617,7 +1030,7 @@ func TestGetCurrentValidators(t *testing.T) {
 		uint64(delegatorEndTime.Unix()),
 		validatorNodeID,
 		ids.GenerateTestShortID(),
-		[]*secp256k1.PrivateKey{keys[0]},
+		secp256k1fx.NewKeychain(keys[0]),
 		keys[0].PublicKey().Address(), // change addr
 		nil,
 	)
@@ -721,6 +1134,115 @@ func TestGetTimestamp(t *testing.T) {
 	require.Equal(newTimestamp, reply.Timestamp)
 }
 
+func TestGetChainTime(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+
+	service.vm.ctx.Lock.Lock()
+	chainTime := service.vm.state.GetTimestamp()
+	service.vm.clock.Set(chainTime.Add(time.Minute))
+	service.vm.ctx.Lock.Unlock()
+
+	reply := GetChainTimeReply{}
+	require.NoError(service.GetChainTime(nil, nil, &reply))
+	require.Equal(chainTime, reply.ChainTime)
+	require.Equal(chainTime.Add(time.Minute), reply.LocalTime)
+	require.Equal(avajson.Uint64(txexecutor.SyncBound/time.Second), reply.SyncBound)
+	require.Equal(reply.LocalTime.Add(txexecutor.SyncBound+time.Second), reply.EarliestStartTime)
+	require.Equal(chainTime.Add(txexecutor.MaxFutureStartTime), reply.LatestStartTime)
+}
+
+func TestGetCompatibility(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+
+	reply := GetCompatibilityReply{}
+	require.NoError(service.GetCompatibility(nil, nil, &reply))
+	require.Equal(version.CurrentApp.String(), reply.Version)
+	require.Equal(
+		version.GetMinimumCompatibleVersion(constants.UnitTestID, service.vm.clock.Time()).String(),
+		reply.MinimumCompatibleVersion,
+	)
+	require.Equal(avajson.Uint16(block.CodecVersion), reply.CodecVersion)
+	require.Len(reply.MetadataCodecVersions, 2)
+
+	upgrades := make(map[string]APIUpgrade)
+	for _, upgrade := range reply.Upgrades {
+		upgrades[upgrade.Name] = upgrade
+	}
+	require.Equal(service.vm.DurangoTime, upgrades["durango"].Time)
+	require.True(upgrades["durango"].Activated)
+}
+
+func TestGetProfile(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+
+	reply := GetProfileReply{}
+	require.NoError(service.GetProfile(nil, nil, &reply))
+	require.Empty(reply.Profile)
+	require.Equal(avajson.Uint32(constants.UnitTestID), reply.NetworkID)
+	require.Equal(avajson.Uint64(service.vm.MinValidatorStake), reply.MinValidatorStake)
+	require.Equal(avajson.Uint64(service.vm.MaxStakeDuration/time.Second), reply.MaxStakeDuration)
+	require.Equal(service.vm.RewardConfig, reply.RewardConfig)
+	require.NotEmpty(reply.Upgrades)
+}
+
+func TestEarliestStakerStartTime(t *testing.T) {
+	var (
+		chainTime = time.Unix(1_000_000, 0)
+		day       = 24 * time.Hour
+	)
+	tests := []struct {
+		name                     string
+		now                      time.Time
+		minFutureStartTimeOffset time.Duration
+		minStakeStartTime        time.Time
+		expected                 time.Time
+	}{
+		{
+			name:                     "local time ahead of chain time",
+			now:                      chainTime.Add(time.Minute),
+			minFutureStartTimeOffset: txexecutor.MaxFutureStartTime,
+			expected:                 chainTime.Add(time.Minute + txexecutor.SyncBound + time.Second),
+		},
+		{
+			name:                     "chain time ahead of local time",
+			now:                      chainTime.Add(-time.Minute),
+			minFutureStartTimeOffset: txexecutor.MaxFutureStartTime,
+			expected:                 chainTime.Add(time.Second),
+		},
+		{
+			name:                     "sub-second local time",
+			now:                      chainTime.Add(time.Minute + time.Millisecond),
+			minFutureStartTimeOffset: txexecutor.MaxFutureStartTime,
+			expected:                 chainTime.Add(time.Minute + txexecutor.SyncBound + time.Second),
+		},
+		{
+			name:                     "min future start time offset",
+			now:                      chainTime,
+			minFutureStartTimeOffset: 3 * day,
+			expected:                 chainTime.Add(txexecutor.SyncBound + txexecutor.MaxFutureStartTime - 3*day + time.Second),
+		},
+		{
+			name:                     "min stake start time",
+			now:                      chainTime,
+			minFutureStartTimeOffset: txexecutor.MaxFutureStartTime,
+			minStakeStartTime:        chainTime.Add(day),
+			expected:                 chainTime.Add(day + time.Second),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(
+				t,
+				test.expected,
+				earliestStakerStartTime(chainTime, test.now, txexecutor.MaxFutureStartTime, test.minFutureStartTimeOffset, test.minStakeStartTime),
+			)
+		})
+	}
+}
+
 func TestGetBlock(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -751,7 +1273,7 @@ func TestGetBlock(t *testing.T) {
 				constants.AVMID,
 				[]ids.ID{},
 				"chain name",
-				[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+				secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
 				keys[0].PublicKey().Address(), // change addr
 				nil,
 			)
@@ -802,28 +1324,874 @@ func TestGetBlock(t *testing.T) {
 	}
 }
 
-func TestGetValidatorsAtReplyMarshalling(t *testing.T) {
+func TestTraceBlockVerification(t *testing.T) {
 	require := require.New(t)
+	service, _ := defaultService(t)
 
-	reply := &GetValidatorsAtReply{
-		Validators: make(map[ids.NodeID]*validators.GetValidatorOutput),
-	}
+	err := service.TraceBlockVerification(nil, &TraceBlockVerificationArgs{}, &TraceBlockVerificationReply{})
+	require.ErrorIs(err, errVerificationTracingOff)
 
-	{
-		reply.Validators[ids.EmptyNodeID] = &validators.GetValidatorOutput{
-			NodeID:    ids.EmptyNodeID,
-			PublicKey: nil,
-			Weight:    0,
-		}
-	}
-	{
-		nodeID := ids.GenerateTestNodeID()
-		sk, err := bls.NewSecretKey()
-		require.NoError(err)
-		reply.Validators[nodeID] = &validators.GetValidatorOutput{
-			NodeID:    nodeID,
-			PublicKey: bls.PublicFromSecretKey(sk),
-			Weight:    math.MaxUint64,
+	service.vm.execConfig.VerificationTracingEnabled = true
+	service.vm.ctx.Lock.Lock()
+
+	tx, err := service.vm.txBuilder.NewCreateChainTx(
+		testSubnet1.ID(),
+		[]byte{},
+		constants.AVMID,
+		[]ids.ID{},
+		"chain name",
+		secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
+		keys[0].PublicKey().Address(), // change addr
+		nil,
+	)
+	require.NoError(err)
+
+	preferredID := service.vm.manager.Preferred()
+	preferred, err := service.vm.manager.GetBlock(preferredID)
+	require.NoError(err)
+
+	statelessBlk, err := block.NewBanffStandardBlock(
+		preferred.Timestamp(),
+		preferred.ID(),
+		preferred.Height()+1,
+		[]*txs.Tx{tx},
+	)
+	require.NoError(err)
+	blk := service.vm.manager.NewBlock(statelessBlk)
+	require.NoError(blk.Verify(context.Background()))
+
+	// The child block attempts to consume the same UTXOs as its parent.
+	statelessChildBlk, err := block.NewBanffStandardBlock(
+		preferred.Timestamp(),
+		blk.ID(),
+		blk.Height()+1,
+		[]*txs.Tx{tx},
+	)
+	require.NoError(err)
+
+	service.vm.ctx.Lock.Unlock()
+
+	// Tracing a processing block
+	reply := TraceBlockVerificationReply{}
+	require.NoError(service.TraceBlockVerification(nil, &TraceBlockVerificationArgs{
+		BlockID: blk.ID(),
+	}, &reply))
+	require.Equal(blk.ID(), reply.BlockID)
+	require.Empty(reply.Error)
+	require.Equal(ids.Empty, reply.FailedTxID)
+	require.Contains(reply.Accesses, state.Access{
+		Scope:  "onAccept",
+		Method: "AddTx",
+		Write:  true,
+		Key:    tx.ID().String(),
+		Value:  status.Committed.String(),
+	})
+	require.Contains(reply.ForkChecks, config.ForkCheck{
+		Fork:      "durango",
+		Timestamp: preferred.Timestamp().Unix(),
+		Activated: true,
+	})
+	require.Empty(reply.Rules)
+
+	// Tracing an invalid block
+	childBlkStr, err := formatting.Encode(formatting.Hex, statelessChildBlk.Bytes())
+	require.NoError(err)
+
+	reply = TraceBlockVerificationReply{}
+	require.NoError(service.TraceBlockVerification(nil, &TraceBlockVerificationArgs{
+		Block:    childBlkStr,
+		Encoding: formatting.Hex,
+	}, &reply))
+	require.Equal(statelessChildBlk.ID(), reply.BlockID)
+	require.NotEmpty(reply.Error)
+	require.Equal(tx.ID(), reply.FailedTxID)
+	require.Equal([]string{database.ErrNotFound.Error()}, reply.Rules)
+
+	// Tracing must not modify the processing blocks or the mempool
+	service.vm.ctx.Lock.Lock()
+	defer service.vm.ctx.Lock.Unlock()
+
+	_, ok := service.vm.manager.GetState(statelessChildBlk.ID())
+	require.False(ok)
+	_, ok = service.vm.manager.GetState(blk.ID())
+	require.True(ok)
+	require.NoError(service.vm.Builder.GetDropReason(tx.ID()))
+}
+
+func TestPrevalidateBlock(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+
+	err := service.PrevalidateBlock(nil, &PrevalidateBlockArgs{}, &PrevalidateBlockReply{})
+	require.ErrorIs(err, errBlockPrevalidationOff)
+
+	service.vm.execConfig.BlockPrevalidationEnabled = true
+	service.vm.ctx.Lock.Lock()
+
+	tx, err := service.vm.txBuilder.NewCreateChainTx(
+		testSubnet1.ID(),
+		[]byte{},
+		constants.AVMID,
+		[]ids.ID{},
+		"chain name",
+		secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
+		keys[0].PublicKey().Address(), // change addr
+		nil,
+	)
+	require.NoError(err)
+
+	preferredID := service.vm.manager.Preferred()
+	preferred, err := service.vm.manager.GetBlock(preferredID)
+	require.NoError(err)
+
+	blk, err := block.NewBanffStandardBlock(
+		preferred.Timestamp(),
+		preferredID,
+		preferred.Height()+1,
+		[]*txs.Tx{tx},
+	)
+	require.NoError(err)
+
+	// The child block isn't built on top of the preferred block.
+	childBlk, err := block.NewBanffStandardBlock(
+		preferred.Timestamp(),
+		blk.ID(),
+		blk.Height()+1,
+		[]*txs.Tx{tx},
+	)
+	require.NoError(err)
+	service.vm.ctx.Lock.Unlock()
+
+	blkStr, err := formatting.Encode(formatting.Hex, blk.Bytes())
+	require.NoError(err)
+	reply := PrevalidateBlockReply{}
+	require.NoError(service.PrevalidateBlock(nil, &PrevalidateBlockArgs{
+		Block:    blkStr,
+		Encoding: formatting.Hex,
+	}, &reply))
+	require.Equal(blk.ID(), reply.BlockID)
+	require.True(reply.Feasible)
+	require.Empty(reply.Error)
+	require.Contains(reply.Changes, APIStateChange{
+		Scope:  "onAccept",
+		Method: "AddTx",
+		Count:  1,
+		Keys:   []string{tx.ID().String()},
+		Value:  status.Committed.String(),
+	})
+
+	childBlkStr, err := formatting.Encode(formatting.Hex, childBlk.Bytes())
+	require.NoError(err)
+	reply = PrevalidateBlockReply{}
+	require.NoError(service.PrevalidateBlock(nil, &PrevalidateBlockArgs{
+		Block:    childBlkStr,
+		Encoding: formatting.Hex,
+	}, &reply))
+	require.False(reply.Feasible)
+	require.Equal([]string{errParentNotPreferred.Error()}, reply.Rules)
+
+	// Prevalidation must not modify the processing blocks
+	service.vm.ctx.Lock.Lock()
+	defer service.vm.ctx.Lock.Unlock()
+
+	_, ok := service.vm.manager.GetState(blk.ID())
+	require.False(ok)
+}
+
+func TestSimulateChainTime(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+
+	err := service.SimulateChainTime(nil, &SimulateChainTimeArgs{}, &SimulateChainTimeReply{})
+	require.ErrorIs(err, errInvalidSimulatedDays)
+
+	service.vm.ctx.Lock.Lock()
+	it, err := service.vm.state.GetCurrentStakerIterator()
+	require.NoError(err)
+	numStakers := 0
+	for it.Next() {
+		numStakers++
+	}
+	it.Release()
+	chainTime := service.vm.state.GetTimestamp()
+	service.vm.ctx.Lock.Unlock()
+
+	const numDays = 20
+	reply := SimulateChainTimeReply{}
+	require.NoError(service.SimulateChainTime(nil, &SimulateChainTimeArgs{
+		Days: numDays,
+	}, &reply))
+	require.Equal(chainTime, reply.StartTime)
+	require.Len(reply.Days, numDays)
+
+	// All the genesis validators stop validating during the simulation
+	numRemoved := 0
+	for i, day := range reply.Days {
+		require.Equal(chainTime.Add(time.Duration(i)*24*time.Hour), day.StartTime)
+		require.Zero(day.ValidatorsAdded)
+		require.Zero(day.DelegatorsAdded)
+		require.Equal(reply.Supply, day.Supply)
+		numRemoved += int(day.ValidatorsRemoved + day.DelegatorsRemoved)
+	}
+	require.Equal(numStakers, numRemoved)
+}
+
+func TestGetDelegationSuggestions(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+
+	service.vm.ctx.Lock.Lock()
+	chainTime := service.vm.state.GetTimestamp()
+	// The genesis validators can only accept delegations of up to 4 times
+	// their weight.
+	service.vm.MinDelegatorStake = defaultWeight
+	service.vm.ctx.Lock.Unlock()
+
+	var (
+		amount   = avajson.Uint64(defaultWeight)
+		duration = avajson.Uint64(defaultMinStakingDuration / time.Second)
+	)
+	err := service.GetDelegationSuggestions(nil, &GetDelegationSuggestionsArgs{
+		Amount:   amount - 1,
+		Duration: duration,
+	}, &GetDelegationSuggestionsReply{})
+	require.ErrorIs(err, errDelegationTooSmall)
+
+	err = service.GetDelegationSuggestions(nil, &GetDelegationSuggestionsArgs{
+		Amount:   amount,
+		Duration: duration - 1,
+	}, &GetDelegationSuggestionsReply{})
+	require.ErrorIs(err, errInvalidDelegationDuration)
+
+	// Every genesis validator can accept the delegation
+	reply := GetDelegationSuggestionsReply{}
+	require.NoError(service.GetDelegationSuggestions(nil, &GetDelegationSuggestionsArgs{
+		Amount:   amount,
+		Duration: duration,
+	}, &reply))
+	require.Equal(avajson.Uint64(chainTime.Unix()), reply.StartTime)
+	require.Equal(avajson.Uint64(chainTime.Add(defaultMinStakingDuration).Unix()), reply.EndTime)
+	require.Len(reply.Suggestions, len(genesisNodeIDs))
+	for _, suggestion := range reply.Suggestions {
+		require.Contains(genesisNodeIDs, suggestion.NodeID)
+		require.Equal(avajson.Uint64(4*defaultWeight), suggestion.RemainingCapacity)
+	}
+
+	reply = GetDelegationSuggestionsReply{}
+	require.NoError(service.GetDelegationSuggestions(nil, &GetDelegationSuggestionsArgs{
+		Amount:   amount,
+		Duration: duration,
+		Limit:    2,
+	}, &reply))
+	require.Len(reply.Suggestions, 2)
+
+	// No validator can accept a delegation that outlasts it
+	reply = GetDelegationSuggestionsReply{}
+	require.NoError(service.GetDelegationSuggestions(nil, &GetDelegationSuggestionsArgs{
+		Amount:   amount,
+		Duration: avajson.Uint64(defaultValidateEndTime.Sub(chainTime)/time.Second) + 1,
+	}, &reply))
+	require.Empty(reply.Suggestions)
+
+	// No validator can accept more than its remaining capacity
+	reply = GetDelegationSuggestionsReply{}
+	require.NoError(service.GetDelegationSuggestions(nil, &GetDelegationSuggestionsArgs{
+		Amount:   avajson.Uint64(4*defaultWeight + 1),
+		Duration: duration,
+	}, &reply))
+	require.Empty(reply.Suggestions)
+}
+
+func TestGetValidatorStakes(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+
+	var (
+		validatorNodeID    = genesisNodeIDs[1]
+		delegatorWeight    = uint64(defaultWeight / 2)
+		delegatorStartTime = defaultValidateStartTime
+		delegatorEndTime   = delegatorStartTime.Add(defaultMinStakingDuration)
+	)
+
+	service.vm.ctx.Lock.Lock()
+	delTx, err := service.vm.txBuilder.NewAddDelegatorTx(
+		delegatorWeight,
+		uint64(delegatorStartTime.Unix()),
+		uint64(delegatorEndTime.Unix()),
+		validatorNodeID,
+		ids.GenerateTestShortID(),
+		secp256k1fx.NewKeychain(keys[0]),
+		keys[0].PublicKey().Address(), // change addr
+		nil,
+	)
+	require.NoError(err)
+	delegator, err := state.NewCurrentStaker(
+		delTx.ID(),
+		delTx.Unsigned.(*txs.AddDelegatorTx),
+		delegatorStartTime,
+		0,
+	)
+	require.NoError(err)
+	service.vm.state.PutCurrentDelegator(delegator)
+	service.vm.state.AddTx(delTx, status.Committed)
+	require.NoError(service.vm.state.Commit())
+	service.vm.ctx.Lock.Unlock()
+
+	reply := GetValidatorStakesReply{}
+	require.NoError(service.GetValidatorStakes(nil, &GetValidatorStakesArgs{
+		SubnetID: constants.PrimaryNetworkID,
+	}, &reply))
+	require.Len(reply.Validators, len(genesisNodeIDs))
+	for i := 1; i < len(reply.Validators); i++ {
+		require.Negative(reply.Validators[i-1].NodeID.Compare(reply.Validators[i].NodeID))
+	}
+
+	reply = GetValidatorStakesReply{}
+	require.NoError(service.GetValidatorStakes(nil, &GetValidatorStakesArgs{
+		SubnetID: constants.PrimaryNetworkID,
+		NodeIDs:  []ids.NodeID{validatorNodeID},
+	}, &reply))
+	require.Len(reply.Validators, 1)
+	stake := reply.Validators[0]
+	require.Equal(validatorNodeID, stake.NodeID)
+	require.False(stake.Pending)
+	require.Equal(avajson.Uint64(defaultWeight), stake.SelfStake)
+	require.Equal(avajson.Uint64(delegatorWeight), stake.DelegatedStake)
+	// The genesis validators can be delegated up to 4 times their weight
+	require.Equal(avajson.Uint64(5*defaultWeight), stake.MaxWeight)
+	require.Equal(avajson.Uint64(defaultWeight+delegatorWeight), stake.PeakWeight)
+	require.Equal(avajson.Uint64(4*defaultWeight-delegatorWeight), stake.RemainingCapacity)
+	require.Equal([]APIDelegation{
+		{
+			TxID:      delTx.ID(),
+			Weight:    avajson.Uint64(delegatorWeight),
+			StartTime: avajson.Uint64(delegatorStartTime.Unix()),
+			EndTime:   avajson.Uint64(delegatorEndTime.Unix()),
+		},
+	}, stake.Delegations)
+}
+
+func TestGetStakeComposition(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+
+	var (
+		validatorNodeID    = genesisNodeIDs[1]
+		delegatorWeight    = uint64(defaultWeight / 2)
+		delegatorStartTime = defaultValidateStartTime
+		delegatorEndTime   = delegatorStartTime.Add(defaultMinStakingDuration)
+	)
+
+	service.vm.ctx.Lock.Lock()
+	delTx, err := service.vm.txBuilder.NewAddDelegatorTx(
+		delegatorWeight,
+		uint64(delegatorStartTime.Unix()),
+		uint64(delegatorEndTime.Unix()),
+		validatorNodeID,
+		ids.GenerateTestShortID(),
+		secp256k1fx.NewKeychain(keys[0]),
+		keys[0].PublicKey().Address(), // change addr
+		nil,
+	)
+	require.NoError(err)
+	delegator, err := state.NewCurrentStaker(
+		delTx.ID(),
+		delTx.Unsigned.(*txs.AddDelegatorTx),
+		delegatorStartTime,
+		0,
+	)
+	require.NoError(err)
+	service.vm.state.PutCurrentDelegator(delegator)
+	service.vm.state.AddTx(delTx, status.Committed)
+	require.NoError(service.vm.state.Commit())
+
+	lastAcceptedID := service.vm.state.GetLastAccepted()
+	lastAccepted, err := service.vm.state.GetStatelessBlock(lastAcceptedID)
+	require.NoError(err)
+	height := lastAccepted.Height()
+	service.vm.ctx.Lock.Unlock()
+
+	reply := GetStakeCompositionReply{}
+	require.NoError(service.GetStakeComposition(nil, &GetStakeCompositionArgs{}, &reply))
+	require.Equal(avajson.Uint64(height), reply.Height)
+	require.Equal(lastAcceptedID, reply.BlockID)
+	require.Len(reply.Validators, len(genesisNodeIDs))
+
+	// Compositions are only available at the last accepted height.
+	pastHeight := avajson.Uint64(height + 1)
+	err = service.GetStakeComposition(nil, &GetStakeCompositionArgs{
+		Height: &pastHeight,
+	}, &GetStakeCompositionReply{})
+	require.ErrorIs(err, errStakeCompositionHeight)
+
+	reply = GetStakeCompositionReply{}
+	require.NoError(service.GetStakeComposition(nil, &GetStakeCompositionArgs{
+		NodeIDs: []ids.NodeID{validatorNodeID},
+	}, &reply))
+	require.Len(reply.Validators, 1)
+	composition := reply.Validators[0]
+	require.Equal(validatorNodeID, composition.NodeID)
+
+	// The root can be reproduced from the returned entries.
+	var (
+		entries        = make([]stakeMirrorEntry, len(composition.Entries))
+		delegatedStake uint64
+	)
+	for i, apiEntry := range composition.Entries {
+		addr, err := service.addrManager.ParseLocalAddress(apiEntry.Address)
+		require.NoError(err)
+		entries[i] = stakeMirrorEntry{
+			TxID:      apiEntry.TxID,
+			Address:   addr,
+			Amount:    uint64(apiEntry.Amount),
+			StartTime: uint64(apiEntry.StartTime),
+			EndTime:   uint64(apiEntry.EndTime),
+		}
+		if apiEntry.TxID == delTx.ID() {
+			require.Equal(avajson.Uint64(delegatorStartTime.Unix()), apiEntry.StartTime)
+			require.Equal(avajson.Uint64(delegatorEndTime.Unix()), apiEntry.EndTime)
+			delegatedStake += uint64(apiEntry.Amount)
+		}
+	}
+	require.Equal(delegatorWeight, delegatedStake)
+	require.Equal(stakeMirrorRoot(entries), composition.Root)
+}
+
+func TestGetNodeDashboard(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+
+	var (
+		nodeID             = genesisNodeIDs[1]
+		delegatorWeight    = uint64(defaultWeight / 2)
+		delegatorStartTime = defaultValidateStartTime
+		delegatorEndTime   = delegatorStartTime.Add(defaultMinStakingDuration)
+	)
+
+	service.vm.ctx.Lock.Lock()
+	service.vm.ctx.NodeID = nodeID
+	delTx, err := service.vm.txBuilder.NewAddDelegatorTx(
+		delegatorWeight,
+		uint64(delegatorStartTime.Unix()),
+		uint64(delegatorEndTime.Unix()),
+		nodeID,
+		ids.GenerateTestShortID(),
+		secp256k1fx.NewKeychain(keys[0]),
+		keys[0].PublicKey().Address(), // change addr
+		nil,
+	)
+	require.NoError(err)
+	delegator, err := state.NewCurrentStaker(
+		delTx.ID(),
+		delTx.Unsigned.(*txs.AddDelegatorTx),
+		delegatorStartTime,
+		0,
+	)
+	require.NoError(err)
+	service.vm.state.PutCurrentDelegator(delegator)
+	service.vm.state.AddTx(delTx, status.Committed)
+	require.NoError(service.vm.state.Commit())
+
+	validator, err := service.vm.state.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+	require.NoError(err)
+	lastAcceptedID := service.vm.state.GetLastAccepted()
+	chainTime := service.vm.state.GetTimestamp()
+	service.vm.ctx.Lock.Unlock()
+
+	reply := GetNodeDashboardReply{}
+	require.NoError(service.GetNodeDashboard(nil, nil, &reply))
+	require.Equal(nodeID, reply.NodeID)
+	require.Equal(lastAcceptedID, reply.LastAcceptedID)
+	require.Equal(chainTime, reply.ChainTime)
+
+	require.Len(reply.Validators, 1)
+	vdr := reply.Validators[0]
+	require.Equal(validator.TxID, vdr.TxID)
+	require.Equal(constants.PrimaryNetworkID, vdr.SubnetID)
+	require.True(vdr.Current)
+	require.Equal(avajson.Uint64(validator.EndTime.Unix()), vdr.EndTime)
+	require.Equal(avajson.Uint64(delegatorWeight), vdr.DelegatorWeight)
+	require.Equal([]APIDashboardStaker{
+		{
+			TxID:      delTx.ID(),
+			SubnetID:  constants.PrimaryNetworkID,
+			Weight:    avajson.Uint64(delegatorWeight),
+			StartTime: avajson.Uint64(delegatorStartTime.Unix()),
+			EndTime:   avajson.Uint64(delegatorEndTime.Unix()),
+			Current:   true,
+		},
+	}, vdr.Delegators)
+
+	// The delegator ends before its validator.
+	require.Equal([]APIUpcomingEndTime{
+		{
+			TxID:      delTx.ID(),
+			SubnetID:  constants.PrimaryNetworkID,
+			Delegator: true,
+			EndTime:   avajson.Uint64(delegatorEndTime.Unix()),
+		},
+		{
+			TxID:     validator.TxID,
+			SubnetID: constants.PrimaryNetworkID,
+			EndTime:  avajson.Uint64(validator.EndTime.Unix()),
+		},
+	}, reply.UpcomingEndTimes)
+
+	// This node is only connected to itself. The weights include the
+	// delegated stake.
+	require.Equal(avajson.Uint64(validator.Weight+delegatorWeight), reply.ConnectedWeight)
+	require.Equal(avajson.Uint64(uint64(len(genesisNodeIDs))*validator.Weight+delegatorWeight), reply.TotalWeight)
+	require.Zero(reply.Mempool.NumTxs)
+	require.Nil(reply.Mempool.OldestTxID)
+	require.Empty(reply.Alerts)
+}
+
+func TestGetStakerTimeline(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+
+	var (
+		nodeID       = genesisNodeIDs[0]
+		endedEndTime = defaultValidateStartTime.Add(-defaultMinStakingDuration)
+		endedStart   = endedEndTime.Add(-defaultMinStakingDuration)
+	)
+
+	service.vm.ctx.Lock.Lock()
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	endedTx, err := service.vm.txBuilder.NewAddPermissionlessValidatorTx(
+		service.vm.MinValidatorStake,
+		uint64(endedStart.Unix()),
+		uint64(endedEndTime.Unix()),
+		nodeID,
+		signer.NewProofOfPossession(sk),
+		ids.GenerateTestShortID(),
+		reward.PercentDenominator,
+		secp256k1fx.NewKeychain(keys[0]),
+		keys[0].PublicKey().Address(), // change addr
+		nil,
+	)
+	require.NoError(err)
+	service.vm.state.AddTx(endedTx, status.Committed)
+	receipt := &state.RewardReceipt{
+		StakerTxID:      endedTx.ID(),
+		SubnetID:        constants.PrimaryNetworkID,
+		Height:          1,
+		Timestamp:       uint64(endedEndTime.Unix()),
+		Rewarded:        true,
+		PotentialReward: 10,
+		Reward:          10,
+		Cause:           state.RewardCauseUptimeSufficient,
+	}
+	service.vm.state.AddRewardReceipt(nodeID, receipt)
+	require.NoError(service.vm.state.Commit())
+	service.vm.ctx.Lock.Unlock()
+
+	reply := GetStakerTimelineReply{}
+	require.NoError(service.GetStakerTimeline(nil, &GetStakerTimelineArgs{
+		NodeID: nodeID,
+	}, &reply))
+	require.Len(reply.Periods, 2)
+
+	ended := reply.Periods[0]
+	require.Equal(endedTx.ID(), ended.TxID)
+	require.Equal("ended", ended.Status)
+	require.Equal(avajson.Uint64(endedStart.Unix()), ended.StartTime)
+	require.Equal(avajson.Uint64(endedEndTime.Unix()), ended.EndTime)
+	require.Equal(avajson.Uint64(service.vm.MinValidatorStake), ended.Weight)
+	require.NotNil(ended.PublicKey)
+	require.False(ended.PublicKeyChanged)
+	require.Zero(ended.GapBefore)
+	expectedReceipt := newAPIRewardReceipt(receipt)
+	require.Equal(&expectedReceipt, ended.Receipt)
+
+	// The genesis validator didn't register a BLS key and started a staking
+	// period after the ended one.
+	current := reply.Periods[1]
+	require.Equal("current", current.Status)
+	require.Equal(constants.PrimaryNetworkID, current.SubnetID)
+	require.Equal(avajson.Uint64(defaultWeight), current.Weight)
+	require.Nil(current.PublicKey)
+	require.True(current.PublicKeyChanged)
+	require.Equal(avajson.Uint64(defaultMinStakingDuration/time.Second), current.GapBefore)
+	require.Nil(current.Receipt)
+}
+
+type noopTxVerifier struct{}
+
+func (noopTxVerifier) VerifyTx(*txs.Tx) error {
+	return nil
+}
+
+func TestReserveDelegationCapacity(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+
+	var (
+		nodeID        = genesisNodeIDs[1]
+		rewardKey     = keys[1]
+		otherKey      = keys[2]
+		rewardAddr    = rewardKey.Address()
+		otherAddr     = otherKey.Address()
+		capacity      = uint64(4 * defaultWeight)
+		delegationEnd = defaultValidateStartTime.Add(defaultMinStakingDuration)
+	)
+	signReservation := func(key *secp256k1.PrivateKey, amount uint64) string {
+		msg := DelegationReservationMessage(constants.PrimaryNetworkID, nodeID, amount, delegationEnd)
+		sig, err := key.SignHash(signedMessageHash([]byte(msg)))
+		require.NoError(err)
+		sigStr, err := formatting.Encode(formatting.Hex, sig)
+		require.NoError(err)
+		return sigStr
+	}
+
+	args := &ReserveDelegationCapacityArgs{
+		SubnetID:      constants.PrimaryNetworkID,
+		NodeID:        nodeID,
+		RewardAddress: rewardAddr.String(),
+		Amount:        avajson.Uint64(capacity),
+		EndTime:       avajson.Uint64(delegationEnd.Unix()),
+		Signature:     signReservation(rewardKey, capacity),
+		Encoding:      formatting.Hex,
+	}
+	reply := ReserveDelegationCapacityReply{}
+	err := service.ReserveDelegationCapacity(nil, args, &reply)
+	require.ErrorIs(err, errDelegationReservationsDisabled)
+
+	service.vm.delegationReservations = mempool.NewReservations(&service.vm.clock, time.Minute)
+
+	// Only the reward address can reserve capacity for its delegations
+	args.Signature = signReservation(otherKey, capacity)
+	err = service.ReserveDelegationCapacity(nil, args, &reply)
+	require.ErrorIs(err, errAddressNotProven)
+
+	// The signature only authorizes the reservation that was signed
+	args.Signature = signReservation(rewardKey, capacity-1)
+	err = service.ReserveDelegationCapacity(nil, args, &reply)
+	require.ErrorIs(err, errAddressNotProven)
+
+	args.Signature = signReservation(rewardKey, capacity)
+	require.NoError(service.ReserveDelegationCapacity(nil, args, &reply))
+	require.Equal(avajson.Uint64(service.vm.clock.Time().Add(time.Minute).Unix()), reply.Expiry)
+
+	// The whole capacity of the validator is reserved
+	err = service.ReserveDelegationCapacity(nil, &ReserveDelegationCapacityArgs{
+		SubnetID:      constants.PrimaryNetworkID,
+		NodeID:        nodeID,
+		RewardAddress: otherAddr.String(),
+		Amount:        1,
+		EndTime:       avajson.Uint64(delegationEnd.Unix()),
+		Signature:     signReservation(otherKey, 1),
+		Encoding:      formatting.Hex,
+	}, &reply)
+	require.ErrorIs(err, mempool.ErrInsufficientDelegationCapacity)
+
+	verifier := &reservationTxVerifier{
+		vm:         service.vm,
+		txVerifier: noopTxVerifier{},
+	}
+	newDelegatorTx := func(rewardAddr ids.ShortID) *txs.Tx {
+		tx, err := service.vm.txBuilder.NewAddDelegatorTx(
+			defaultWeight,
+			uint64(defaultValidateStartTime.Unix()),
+			uint64(delegationEnd.Unix()),
+			nodeID,
+			rewardAddr,
+			secp256k1fx.NewKeychain(keys[0]),
+			keys[0].PublicKey().Address(), // change addr
+			nil,
+		)
+		require.NoError(err)
+		return tx
+	}
+
+	service.vm.ctx.Lock.Lock()
+	defer service.vm.ctx.Lock.Unlock()
+
+	// Delegations of other owners can't take the reserved capacity
+	err = verifier.VerifyTx(newDelegatorTx(otherAddr))
+	require.ErrorIs(err, network.ErrTxRejectedByPolicy)
+	require.ErrorIs(err, mempool.ErrInsufficientDelegationCapacity)
+
+	// The delegation of the owner of the reservation releases it
+	tx := newDelegatorTx(rewardAddr)
+	require.NoError(verifier.VerifyTx(tx))
+	require.NoError(service.vm.Builder.Add(tx))
+
+	// The delegation in the mempool takes the capacity it was reserved
+	capacityLeft, err := service.vm.availableDelegationCapacity(constants.PrimaryNetworkID, nodeID, delegationEnd)
+	require.NoError(err)
+	require.Equal(capacity-defaultWeight, capacityLeft)
+	require.NoError(verifier.VerifyTx(newDelegatorTx(otherAddr)))
+}
+
+func TestSortDelegationSuggestions(t *testing.T) {
+	newUptime := func(uptime avajson.Float32) *avajson.Float32 {
+		return &uptime
+	}
+	var (
+		lowUptime = DelegationSuggestion{
+			NodeID:           ids.BuildTestNodeID([]byte{1}),
+			Uptime:           newUptime(70),
+			HistoricalUptime: newUptime(100),
+		}
+		noHistory = DelegationSuggestion{
+			NodeID: ids.BuildTestNodeID([]byte{2}),
+			Uptime: newUptime(90),
+		}
+		highFee = DelegationSuggestion{
+			NodeID:           ids.BuildTestNodeID([]byte{3}),
+			DelegationFee:    10,
+			Uptime:           newUptime(90),
+			HistoricalUptime: newUptime(95),
+		}
+		lowCapacity = DelegationSuggestion{
+			NodeID:            ids.BuildTestNodeID([]byte{4}),
+			DelegationFee:     5,
+			RemainingCapacity: 1,
+			Uptime:            newUptime(90),
+			HistoricalUptime:  newUptime(95),
+		}
+		highCapacity = DelegationSuggestion{
+			NodeID:            ids.BuildTestNodeID([]byte{5}),
+			DelegationFee:     5,
+			RemainingCapacity: 2,
+			Uptime:            newUptime(80),
+			HistoricalUptime:  newUptime(95),
+		}
+		bestHistory = DelegationSuggestion{
+			NodeID:           ids.BuildTestNodeID([]byte{6}),
+			DelegationFee:    20,
+			Uptime:           newUptime(80),
+			HistoricalUptime: newUptime(99),
+		}
+	)
+	suggestions := []DelegationSuggestion{
+		lowUptime,
+		noHistory,
+		highFee,
+		lowCapacity,
+		highCapacity,
+		bestHistory,
+	}
+	sortDelegationSuggestions(suggestions, 80)
+	require.Equal(t, []DelegationSuggestion{
+		bestHistory,
+		highCapacity,
+		lowCapacity,
+		highFee,
+		noHistory,
+		lowUptime,
+	}, suggestions)
+}
+
+func TestGetStateCertificate(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+
+	err := service.GetStateCertificate(nil, &GetStateCertificateArgs{}, &GetStateCertificateReply{})
+	require.ErrorIs(err, database.ErrNotFound)
+
+	cert := &attestation.Certificate{
+		Commitment: attestation.Commitment{
+			Height:    10,
+			BlockID:   ids.GenerateTestID(),
+			StateHash: ids.GenerateTestID(),
+		},
+		Message: []byte{1, 2, 3},
+	}
+	require.NoError(service.vm.stateCertificates.Put(cert))
+
+	for _, height := range []avajson.Uint64{0, 10} {
+		reply := GetStateCertificateReply{}
+		require.NoError(service.GetStateCertificate(nil, &GetStateCertificateArgs{
+			Height:   height,
+			Encoding: formatting.Hex,
+		}, &reply))
+		require.Equal(avajson.Uint64(10), reply.Height)
+		require.Equal(cert.Commitment.BlockID, reply.BlockID)
+		require.Equal(cert.Commitment.StateHash, reply.StateHash)
+		require.Equal("0x010203011cfb81", reply.Message)
+	}
+}
+
+func TestGetCanonicalValidatorSet(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+
+	reply := GetCanonicalValidatorSetReply{}
+	require.NoError(service.GetCanonicalValidatorSet(&http.Request{}, &GetCanonicalValidatorSetArgs{
+		SubnetID: constants.PrimaryNetworkID,
+	}, &reply))
+
+	// The genesis validators didn't register BLS keys
+	require.Empty(reply.Validators)
+	require.Empty(reply.AggregatePublicKey)
+	require.Equal(avajson.Uint64(uint64(len(genesisNodeIDs))*defaultWeight), reply.TotalWeight)
+
+	key := canonicalValidatorSetKey{
+		height:   0,
+		subnetID: constants.PrimaryNetworkID,
+	}
+	_, ok := service.vm.canonicalValidatorSets.Get(key)
+	require.True(ok)
+
+	// Sets are served from the cache
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	pk := bls.PublicFromSecretKey(sk)
+	pkBytes := bls.SerializePublicKey(pk)
+	nodeID := ids.GenerateTestNodeID()
+	service.vm.canonicalValidatorSets.Put(key, &canonicalValidatorSet{
+		validators: []*warp.Validator{{
+			PublicKey:      pk,
+			PublicKeyBytes: pkBytes,
+			Weight:         defaultWeight,
+			NodeIDs:        []ids.NodeID{nodeID},
+		}},
+		publicKeys:         [][]byte{bls.PublicKeyToBytes(pk)},
+		totalWeight:        defaultWeight,
+		aggregatePublicKey: pk,
+	})
+
+	reply = GetCanonicalValidatorSetReply{}
+	require.NoError(service.GetCanonicalValidatorSet(&http.Request{}, &GetCanonicalValidatorSetArgs{
+		SubnetID: constants.PrimaryNetworkID,
+	}, &reply))
+
+	expectedPK, err := formatting.Encode(formatting.HexNC, bls.PublicKeyToBytes(pk))
+	require.NoError(err)
+	require.Equal([]CanonicalValidator{{
+		PublicKey: expectedPK,
+		Weight:    avajson.Uint64(defaultWeight),
+		NodeIDs:   []ids.NodeID{nodeID},
+	}}, reply.Validators)
+	require.Equal(avajson.Uint64(defaultWeight), reply.TotalWeight)
+	require.Equal(expectedPK, reply.AggregatePublicKey)
+}
+
+func TestGetValidatorsAtReplyMarshalling(t *testing.T) {
+	require := require.New(t)
+
+	reply := &GetValidatorsAtReply{
+		Validators: make(map[ids.NodeID]*validators.GetValidatorOutput),
+	}
+
+	{
+		reply.Validators[ids.EmptyNodeID] = &validators.GetValidatorOutput{
+			NodeID:    ids.EmptyNodeID,
+			PublicKey: nil,
+			Weight:    0,
+		}
+	}
+	{
+		nodeID := ids.GenerateTestNodeID()
+		sk, err := bls.NewSecretKey()
+		require.NoError(err)
+		reply.Validators[nodeID] = &validators.GetValidatorOutput{
+			NodeID:    nodeID,
+			PublicKey: bls.PublicFromSecretKey(sk),
+			Weight:    math.MaxUint64,
 		}
 	}
 
@@ -1025,3 +2393,419 @@ func TestServiceGetBlockByHeight(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAtomicSummary(t *testing.T) {
+	require := require.New(t)
+	service, mutableSharedMemory := defaultService(t)
+
+	m := atomic.NewMemory(prefixdb.New([]byte{}, service.vm.db))
+	mutableSharedMemory.SharedMemory = m.NewSharedMemory(service.vm.ctx.ChainID)
+	peerSharedMemory := m.NewSharedMemory(service.vm.ctx.XChainID)
+
+	addr := keys[0].PublicKey().Address()
+	elems := make([]*atomic.Element, 2)
+	for i := range elems {
+		utxo := &avax.UTXO{
+			UTXOID: avax.UTXOID{
+				TxID:        ids.GenerateTestID(),
+				OutputIndex: uint32(i),
+			},
+			Asset: avax.Asset{ID: service.vm.ctx.AVAXAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 1000,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Addrs:     []ids.ShortID{addr},
+					Threshold: 1,
+				},
+			},
+		}
+		utxoBytes, err := txs.Codec.Marshal(txs.CodecVersion, utxo)
+		require.NoError(err)
+		inputID := utxo.InputID()
+		elems[i] = &atomic.Element{
+			Key:    inputID[:],
+			Value:  utxoBytes,
+			Traits: [][]byte{addr.Bytes()},
+		}
+	}
+	require.NoError(peerSharedMemory.Apply(map[ids.ID]*atomic.Requests{
+		service.vm.ctx.ChainID: {
+			PutRequests: elems,
+		},
+	}))
+
+	addrStr, err := service.addrManager.FormatLocalAddress(addr)
+	require.NoError(err)
+
+	balanceReply := GetBalanceResponse{}
+	require.NoError(service.GetBalance(nil, &GetBalanceRequest{Addresses: []string{addrStr}}, &balanceReply))
+
+	reply := GetAtomicSummaryReply{}
+	require.NoError(service.GetAtomicSummary(nil, &GetAtomicSummaryArgs{Addresses: []string{addrStr}}, &reply))
+	require.Equal(balanceReply.Balances, reply.Balances)
+	require.Len(reply.InFlight, 2)
+
+	xChain := reply.InFlight[0]
+	require.Equal(service.vm.ctx.XChainID, xChain.SourceChain)
+	require.Equal(map[ids.ID]avajson.Uint64{service.vm.ctx.AVAXAssetID: 2000}, xChain.Balances)
+	require.Len(xChain.UTXOIDs, 2)
+
+	cChain := reply.InFlight[1]
+	require.Equal(service.vm.ctx.CChainID, cChain.SourceChain)
+	require.Empty(cChain.Balances)
+	require.Empty(cChain.UTXOIDs)
+
+	// Chains can be selected by alias
+	reply = GetAtomicSummaryReply{}
+	require.NoError(service.GetAtomicSummary(nil, &GetAtomicSummaryArgs{
+		Addresses:    []string{addrStr},
+		SourceChains: []string{"C"},
+	}, &reply))
+	require.Len(reply.InFlight, 1)
+	require.Equal(service.vm.ctx.CChainID, reply.InFlight[0].SourceChain)
+
+	err = service.GetAtomicSummary(nil, &GetAtomicSummaryArgs{}, &reply)
+	require.ErrorIs(err, errNoAddresses)
+}
+
+func TestGetLivePeersDisabled(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+
+	err := service.GetLivePeers(nil, &GetLivePeersArgs{}, &GetLivePeersReply{})
+	require.ErrorIs(err, errHeartbeatsDisabled)
+}
+
+func TestGetValidatorMetadata(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+
+	metadata := []byte(`{"name":"validator","website":"https://example.com"}`)
+	var servedMetadata utils.Atomic[[]byte]
+	servedMetadata.Set(metadata)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(servedMetadata.Get())
+	}))
+	defer server.Close()
+
+	service.vm.ctx.Lock.Lock()
+	var (
+		nodeID       = ids.GenerateTestNodeID()
+		startTime    = service.vm.clock.Time().Add(txexecutor.SyncBound).Add(time.Second)
+		endTime      = startTime.Add(defaultMinStakingDuration)
+		metadataHash = ids.ID(hashing.ComputeHash256Array(metadata))
+	)
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	validatorTx, err := service.vm.txBuilder.NewAddPermissionlessValidatorWithMetadataTx(
+		service.vm.MinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		nodeID,
+		signer.NewProofOfPossession(sk),
+		keys[0].Address(), // reward address
+		20_000,
+		server.URL,
+		metadataHash,
+		secp256k1fx.NewKeychain(keys[0]),
+		keys[0].Address(), // change address
+		nil,
+	)
+	require.NoError(err)
+	validator, err := state.NewCurrentStaker(validatorTx.ID(), validatorTx.Unsigned.(txs.Staker), startTime, 0)
+	require.NoError(err)
+	service.vm.state.PutCurrentValidator(validator)
+	service.vm.state.AddTx(validatorTx, status.Committed)
+	require.NoError(service.vm.state.Commit())
+	service.vm.ctx.Lock.Unlock()
+
+	request := httptest.NewRequest(http.MethodPost, "/", nil)
+	reply := GetValidatorMetadataReply{}
+	require.NoError(service.GetValidatorMetadata(request, &GetValidatorMetadataArgs{NodeID: nodeID}, &reply))
+	require.Equal(GetValidatorMetadataReply{
+		TxID:         validatorTx.ID(),
+		MetadataURL:  server.URL,
+		MetadataHash: metadataHash,
+		Verified:     true,
+		Metadata:     metadata,
+	}, reply)
+
+	// Metadata that was changed since the validator committed to it isn't
+	// returned.
+	servedMetadata.Set([]byte(`{"name":"impostor"}`))
+	reply = GetValidatorMetadataReply{}
+	require.NoError(service.GetValidatorMetadata(request, &GetValidatorMetadataArgs{NodeID: nodeID}, &reply))
+	require.False(reply.Verified)
+	require.Nil(reply.Metadata)
+
+	// Validators added without metadata have none to report.
+	err = service.GetValidatorMetadata(request, &GetValidatorMetadataArgs{NodeID: genesisNodeIDs[0]}, &GetValidatorMetadataReply{})
+	require.ErrorIs(err, errNoValidatorMetadata)
+}
+
+func TestGetStakingParameters(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+
+	service.vm.ctx.Lock.Lock()
+	now := uint64(service.vm.state.GetTimestamp().Unix())
+	service.vm.state.SetParameterChanges(txs.TxFeeParameter, []state.ParameterChange{
+		{Value: 2 * defaultTxFee, ActivationTime: now},
+		{Value: 3 * defaultTxFee, ActivationTime: now + 2},
+	})
+	service.vm.state.SetParameterChanges(txs.MinDelegatorStakeParameter, []state.ParameterChange{
+		{Value: 2 * defaultMinDelegatorStake, ActivationTime: now + 1},
+	})
+	service.vm.ctx.Lock.Unlock()
+
+	reply := GetStakingParametersReply{}
+	require.NoError(service.GetStakingParameters(nil, nil, &reply))
+	require.Equal(avajson.Uint64(now), reply.Timestamp)
+	require.Equal(avajson.Uint64(2*defaultTxFee), reply.TxFee)
+	require.Equal(avajson.Uint64(service.vm.AddPrimaryNetworkValidatorFee), reply.AddPrimaryNetworkValidatorFee)
+	require.Equal(avajson.Float64(100*service.vm.UptimePercentage), reply.UptimeRequirement)
+	require.Equal([]APIParameterChange{
+		{
+			Parameter:      txs.MinDelegatorStakeParameter.String(),
+			Value:          avajson.Uint64(2 * defaultMinDelegatorStake),
+			ActivationTime: avajson.Uint64(now + 1),
+		},
+		{
+			Parameter:      txs.TxFeeParameter.String(),
+			Value:          avajson.Uint64(3 * defaultTxFee),
+			ActivationTime: avajson.Uint64(now + 2),
+		},
+	}, reply.ScheduledChanges)
+
+	// getMinStake reports the same stake amounts.
+	minStakeReply := GetMinStakeReply{}
+	require.NoError(service.GetMinStake(nil, &GetMinStakeArgs{SubnetID: constants.PrimaryNetworkID}, &minStakeReply))
+	require.Equal(reply.MinValidatorStake, minStakeReply.MinValidatorStake)
+	require.Equal(reply.MinDelegatorStake, minStakeReply.MinDelegatorStake)
+}
+
+func TestVerifyGenesis(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+
+	reply := VerifyGenesisReply{}
+	require.NoError(service.VerifyGenesis(nil, &VerifyGenesisArgs{}, &reply))
+	require.True(reply.Valid)
+	require.True(reply.MatchesInitialGenesis)
+	require.Equal(reply.GenesisBlockID, reply.AcceptedGenesisBlockID)
+	require.Equal(avajson.Uint32(len(keys)), reply.NumUTXOs)
+	// The default VM spends genesis UTXOs to create a subnet.
+	require.Equal(reply.NumUTXOs, reply.NumUnspentUTXOs+reply.NumSpentUTXOs)
+	require.NotZero(reply.NumSpentUTXOs)
+	require.Equal(avajson.Uint32(len(genesisNodeIDs)), reply.NumValidators)
+	require.Equal(avajson.Uint32(len(genesisNodeIDs)), reply.NumCurrentValidators)
+	require.Equal(avajson.Uint64(uint64(len(keys))*defaultBalance), reply.Allocations[service.vm.ctx.AVAXAssetID])
+	require.Empty(reply.MismatchedUTXOIDs)
+	require.Empty(reply.MismatchedTxIDs)
+
+	// A genesis that allocates different amounts doesn't match the chain.
+	numUnspentUTXOs := int(reply.NumUnspentUTXOs)
+	genesisArgs, _ := defaultGenesis(t, service.vm.ctx.AVAXAssetID)
+	for i := range genesisArgs.UTXOs {
+		genesisArgs.UTXOs[i].Amount++
+	}
+	genesisReply := pchainapi.BuildGenesisReply{}
+	require.NoError((&pchainapi.StaticService{}).BuildGenesis(nil, genesisArgs, &genesisReply))
+
+	reply = VerifyGenesisReply{}
+	require.NoError(service.VerifyGenesis(nil, &VerifyGenesisArgs{
+		Genesis:  genesisReply.Bytes,
+		Encoding: genesisReply.Encoding,
+	}, &reply))
+	require.False(reply.Valid)
+	require.False(reply.MatchesInitialGenesis)
+	require.NotEqual(reply.GenesisBlockID, reply.AcceptedGenesisBlockID)
+	require.Len(reply.MismatchedUTXOIDs, numUnspentUTXOs)
+}
+
+func TestConvertAddress(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+
+	key := keys[0]
+	pChainAddr, err := service.addrManager.FormatLocalAddress(key.Address())
+	require.NoError(err)
+	cChainAddr, err := service.addrManager.FormatAddress(service.vm.ctx.CChainID, key.Address())
+	require.NoError(err)
+	hexAddr, err := formatting.Encode(formatting.HexNC, key.Address().Bytes())
+	require.NoError(err)
+	pkStr, err := formatting.Encode(formatting.HexNC, key.PublicKey().Bytes())
+	require.NoError(err)
+
+	// Every encoding converts to the others
+	for _, addr := range []string{
+		pChainAddr,
+		cChainAddr,
+		hexAddr,
+		pChainAddr[len("P-"):],
+	} {
+		reply := ConvertAddressReply{}
+		require.NoError(service.ConvertAddress(nil, &ConvertAddressArgs{Address: addr}, &reply))
+		require.Equal(ConvertAddressReply{
+			Hex:           hexAddr,
+			PChainAddress: pChainAddr,
+			CChainAddress: cChainAddr,
+		}, reply)
+	}
+
+	// The EVM address can only be derived from the public key
+	reply := ConvertAddressReply{}
+	require.NoError(service.ConvertAddress(nil, &ConvertAddressArgs{
+		Address:   pChainAddr,
+		PublicKey: pkStr,
+	}, &reply))
+	require.Equal(pChainAddr, reply.PChainAddress)
+	require.Equal(crypto.PubkeyToAddress(*key.PublicKey().ToECDSA()).Hex(), reply.EVMAddress)
+
+	// The EVM address of the EWOQ key is well known
+	ewoqPKStr, err := formatting.Encode(formatting.HexNC, genesis.EWOQKey.PublicKey().Bytes())
+	require.NoError(err)
+	require.NoError(service.ConvertAddress(nil, &ConvertAddressArgs{PublicKey: ewoqPKStr}, &reply))
+	require.Equal("0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC", reply.EVMAddress)
+
+	otherPKStr, err := formatting.Encode(formatting.HexNC, keys[1].PublicKey().Bytes())
+	require.NoError(err)
+	err = service.ConvertAddress(nil, &ConvertAddressArgs{
+		Address:   pChainAddr,
+		PublicKey: otherPKStr,
+	}, &reply)
+	require.ErrorIs(err, errPublicKeyAddressMismatch)
+
+	err = service.ConvertAddress(nil, &ConvertAddressArgs{}, &reply)
+	require.ErrorIs(err, errNoAddressToConvert)
+
+	mainnetAddr, err := address.FormatBech32(constants.FlareHRP, key.Address().Bytes())
+	require.NoError(err)
+	err = service.ConvertAddress(nil, &ConvertAddressArgs{Address: mainnetAddr}, &reply)
+	require.ErrorIs(err, errUnexpectedHRP)
+}
+
+func TestValidateAddress(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+
+	addr := keys[0].Address()
+	expectedHRP := constants.GetHRP(service.vm.ctx.NetworkID)
+	pChainAddr, err := service.addrManager.FormatLocalAddress(addr)
+	require.NoError(err)
+
+	reply := ValidateAddressReply{}
+	require.NoError(service.ValidateAddress(nil, &ValidateAddressArgs{Address: pChainAddr}, &reply))
+	require.Equal(ValidateAddressReply{
+		Valid:       true,
+		ChainID:     constants.PlatformChainID,
+		HRP:         expectedHRP,
+		ExpectedHRP: expectedHRP,
+	}, reply)
+
+	mainnetAddr, err := address.Format("P", constants.FlareHRP, addr.Bytes())
+	require.NoError(err)
+	// Changing a character of a bech32 string breaks its checksum
+	lastChar := "q"
+	if strings.HasSuffix(pChainAddr, lastChar) {
+		lastChar = "p"
+	}
+	invalidChecksumAddr := pChainAddr[:len(pChainAddr)-1] + lastChar
+	unknownChainAddr, err := address.Format("Z", expectedHRP, addr.Bytes())
+	require.NoError(err)
+
+	for _, invalidAddr := range []string{
+		mainnetAddr,
+		invalidChecksumAddr,
+		unknownChainAddr,
+		pChainAddr[len("P-"):],
+	} {
+		reply := ValidateAddressReply{}
+		require.NoError(service.ValidateAddress(nil, &ValidateAddressArgs{Address: invalidAddr}, &reply))
+		require.False(reply.Valid, invalidAddr)
+		require.NotEmpty(reply.Reason)
+	}
+}
+
+func TestAddressLabels(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	defaultAddress(t, service)
+
+	user := api.UserPass{Username: testUsername, Password: testPassword}
+	pk, err := secp256k1.ToPrivateKey(testPrivateKey)
+	require.NoError(err)
+
+	formatAddress := func(addr ids.ShortID) string {
+		addrStr, err := service.addrManager.FormatLocalAddress(addr)
+		require.NoError(err)
+		return addrStr
+	}
+	var (
+		unlabeledAddr = formatAddress(pk.Address())
+		fundedAddr    = formatAddress(keys[0].Address())
+		// Addresses that the user doesn't control can be labeled too
+		watchedAddr = formatAddress(keys[1].Address())
+	)
+	for addr, label := range map[string]string{
+		fundedAddr:  "funded",
+		watchedAddr: "watched",
+	} {
+		require.NoError(service.SetAddressLabel(nil, &SetAddressLabelArgs{
+			UserPass: user,
+			Address:  addr,
+			Label:    label,
+		}, &api.EmptyReply{}))
+	}
+
+	labelsReply := GetAddressLabelsReply{}
+	require.NoError(service.GetAddressLabels(nil, &user, &labelsReply))
+	require.Equal(map[string]string{
+		fundedAddr:  "funded",
+		watchedAddr: "watched",
+	}, labelsReply.Labels)
+
+	reply := GetLabeledBalancesReply{}
+	require.NoError(service.GetLabeledBalances(nil, &user, &reply))
+	require.Len(reply.Groups, 3)
+	for i, expected := range []struct {
+		label string
+		addr  string
+	}{
+		{label: "", addr: unlabeledAddr},
+		{label: "funded", addr: fundedAddr},
+		{label: "watched", addr: watchedAddr},
+	} {
+		group := reply.Groups[i]
+		require.Equal(expected.label, group.Label)
+		require.Equal([]string{expected.addr}, group.Addresses)
+
+		balance := GetBalanceResponse{}
+		require.NoError(service.GetBalance(nil, &GetBalanceRequest{Addresses: group.Addresses}, &balance))
+		require.Equal(balance.Balance, group.Balance)
+		require.Equal(balance.Unlocked, group.Unlocked)
+		require.Equal(balance.LockedStakeable, group.LockedStakeable)
+		require.Equal(balance.LockedNotStakeable, group.LockedNotStakeable)
+
+		stake := GetStakeReply{}
+		require.NoError(service.GetStake(nil, &GetStakeArgs{
+			JSONAddresses: api.JSONAddresses{Addresses: group.Addresses},
+			Encoding:      formatting.Hex,
+		}, &stake))
+		require.Equal(stake.Staked, group.Staked)
+	}
+	require.NotZero(reply.Groups[1].Balance)
+
+	// Removing the label of a controlled address moves it to the unlabeled
+	// group
+	require.NoError(service.SetAddressLabel(nil, &SetAddressLabelArgs{
+		UserPass: user,
+		Address:  fundedAddr,
+	}, &api.EmptyReply{}))
+	reply = GetLabeledBalancesReply{}
+	require.NoError(service.GetLabeledBalances(nil, &user, &reply))
+	require.Len(reply.Groups, 2)
+	require.Empty(reply.Groups[0].Label)
+	require.ElementsMatch([]string{unlabeledAddr, fundedAddr}, reply.Groups[0].Addresses)
+	require.Equal("watched", reply.Groups[1].Label)
+}
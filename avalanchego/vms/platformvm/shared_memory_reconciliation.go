@@ -0,0 +1,133 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"golang.org/x/exp/maps"
+
+	"github.com/ava-labs/avalanchego/chains/atomic"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+// Max number of blocks whose shared memory requests can be reconciled by a
+// single call to reconcileSharedMemory
+const maxReconciledBlocks = 4096
+
+var errInvalidReconciliationRange = errors.New("invalid reconciliation range")
+
+// missingRemovals are the shared memory removals of an accepted block that
+// weren't applied to shared memory.
+type missingRemovals struct {
+	blkID       ids.ID
+	height      uint64
+	peerChainID ids.ID
+	keys        [][]byte
+}
+
+// reconcileSharedMemory checks that the removals requested by the accepted
+// blocks at heights in [startHeight, endHeight] were applied to shared memory,
+// and applies the missing ones unless [dryRun] is set.
+//
+// Only removals can be checked, as the values that this chain puts into shared
+// memory can only be read by the peer chains. Blocks accepted before their
+// requests were recorded are skipped.
+//
+// Invariant: the context lock is held.
+func (vm *VM) reconcileSharedMemory(startHeight, endHeight uint64, dryRun bool) ([]missingRemovals, error) {
+	lastAccepted, err := vm.state.GetStatelessBlock(vm.state.GetLastAccepted())
+	if err != nil {
+		return nil, err
+	}
+	if startHeight > endHeight || endHeight > lastAccepted.Height() {
+		return nil, fmt.Errorf("%w: [%d, %d] with last accepted height %d",
+			errInvalidReconciliationRange,
+			startHeight,
+			endHeight,
+			lastAccepted.Height(),
+		)
+	}
+	if endHeight-startHeight >= maxReconciledBlocks {
+		return nil, fmt.Errorf("%w: more than %d blocks",
+			errInvalidReconciliationRange,
+			maxReconciledBlocks,
+		)
+	}
+
+	var missing []missingRemovals
+	for height := startHeight; height <= endHeight; height++ {
+		blkID, err := vm.state.GetBlockIDAtHeight(height)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get block at height %d: %w", height, err)
+		}
+		requests, err := vm.state.GetAtomicRequests(blkID)
+		if err == database.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		peerChainIDs := maps.Keys(requests)
+		utils.Sort(peerChainIDs)
+		for _, peerChainID := range peerChainIDs {
+			keys, err := vm.unappliedRemovals(peerChainID, requests[peerChainID].RemoveRequests)
+			if err != nil {
+				return nil, err
+			}
+			if len(keys) == 0 {
+				continue
+			}
+			missing = append(missing, missingRemovals{
+				blkID:       blkID,
+				height:      height,
+				peerChainID: peerChainID,
+				keys:        keys,
+			})
+		}
+	}
+	if dryRun || len(missing) == 0 {
+		return missing, nil
+	}
+
+	for _, m := range missing {
+		vm.ctx.Log.Warn("applying missing shared memory removals",
+			zap.Stringer("blkID", m.blkID),
+			zap.Uint64("height", m.height),
+			zap.Stringer("peerChainID", m.peerChainID),
+			zap.Int("numRemovals", len(m.keys)),
+		)
+		err := vm.ctx.SharedMemory.Apply(map[ids.ID]*atomic.Requests{
+			m.peerChainID: {
+				RemoveRequests: m.keys,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply removals of block %s: %w", m.blkID, err)
+		}
+	}
+	return missing, nil
+}
+
+// unappliedRemovals returns the [keys] that are still present in the shared
+// memory of [peerChainID].
+func (vm *VM) unappliedRemovals(peerChainID ids.ID, keys [][]byte) ([][]byte, error) {
+	var present [][]byte
+	for _, key := range keys {
+		_, err := vm.ctx.SharedMemory.Get(peerChainID, [][]byte{key})
+		switch err {
+		case nil:
+			present = append(present, key)
+		case database.ErrNotFound:
+		default:
+			return nil, fmt.Errorf("failed to get shared memory of chain %s: %w", peerChainID, err)
+		}
+	}
+	return present, nil
+}
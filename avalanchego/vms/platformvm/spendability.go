@@ -0,0 +1,125 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"math"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	avajson "github.com/ava-labs/avalanchego/utils/json"
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+// APISpendabilityUTXO is a UTXO owned by the queried addresses
+type APISpendabilityUTXO struct {
+	UTXOID  avax.UTXOID    `json:"utxoID"`
+	AssetID ids.ID         `json:"assetID"`
+	Amount  avajson.Uint64 `json:"amount"`
+	// Unix time from which the UTXO can be staked
+	StakeableTime avajson.Uint64 `json:"stakeableTime"`
+	// Unix time from which the UTXO can be spent by any tx
+	UnlockTime avajson.Uint64 `json:"unlockTime"`
+}
+
+// APIBondedOutput is an output owned by the queried addresses that is bonded
+// in a current or pending stake
+type APIBondedOutput struct {
+	// ID of the tx that added the staker
+	TxID      ids.ID         `json:"txID"`
+	NodeID    ids.NodeID     `json:"nodeID"`
+	Delegator bool           `json:"delegator"`
+	Pending   bool           `json:"pending"`
+	AssetID   ids.ID         `json:"assetID"`
+	Amount    avajson.Uint64 `json:"amount"`
+	// Unix time at which the stake is returned
+	EndTime avajson.Uint64 `json:"endTime"`
+	// Unix time from which the returned output can be spent by any tx. It is
+	// after [EndTime] if the staked output was stakeable locked.
+	UnlockTime avajson.Uint64 `json:"unlockTime"`
+}
+
+// outputLocktimes returns the times from which [out] can be staked and spent.
+// False is returned if [out] isn't a secp256k1fx transfer output.
+func outputLocktimes(out avax.TransferableOut) (uint64, uint64, bool) {
+	lockedOut, isLocked := out.(*stakeable.LockOut)
+	if isLocked {
+		out = lockedOut.TransferableOut
+	}
+	transferOut, ok := out.(*secp256k1fx.TransferOutput)
+	if !ok {
+		return 0, 0, false
+	}
+	if !isLocked {
+		return transferOut.Locktime, transferOut.Locktime, true
+	}
+	return transferOut.Locktime, max(transferOut.Locktime, lockedOut.Locktime), true
+}
+
+// ownsOutput returns true if any of the owners of [out] is in [addrs].
+func ownsOutput(out avax.TransferableOut, addrs set.Set[ids.ShortID]) bool {
+	if lockedOut, ok := out.(*stakeable.LockOut); ok {
+		out = lockedOut.TransferableOut
+	}
+	transferOut, ok := out.(*secp256k1fx.TransferOutput)
+	if !ok {
+		return false
+	}
+	for _, addr := range transferOut.Addrs {
+		if addrs.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// newBondedOutputs returns the outputs staked by [staker] in [tx] that are
+// owned by [addrs].
+func newBondedOutputs(staker *state.Staker, tx *txs.Tx, addrs set.Set[ids.ShortID]) []APIBondedOutput {
+	stakerTx, ok := tx.Unsigned.(txs.PermissionlessStaker)
+	if !ok {
+		return nil
+	}
+
+	var (
+		endTime = uint64(staker.EndTime.Unix())
+		bonded  []APIBondedOutput
+	)
+	for _, output := range stakerTx.Stake() {
+		if !ownsOutput(output.Out, addrs) {
+			continue
+		}
+		_, unlockTime, ok := outputLocktimes(output.Out)
+		if !ok {
+			continue
+		}
+		bonded = append(bonded, APIBondedOutput{
+			TxID:       staker.TxID,
+			NodeID:     staker.NodeID,
+			Delegator:  staker.Priority.IsDelegator(),
+			Pending:    staker.Priority.IsPending(),
+			AssetID:    output.AssetID(),
+			Amount:     avajson.Uint64(output.Out.Amount()),
+			EndTime:    avajson.Uint64(endTime),
+			UnlockTime: avajson.Uint64(max(endTime, unlockTime)),
+		})
+	}
+	return bonded
+}
+
+// addAmount adds [amount] of [assetID] to [totals], saturating at
+// [math.MaxUint64].
+func addAmount(totals map[ids.ID]uint64, assetID ids.ID, amount uint64) {
+	newAmount, err := safemath.Add64(totals[assetID], amount)
+	if err != nil {
+		newAmount = math.MaxUint64
+	}
+	totals[assetID] = newAmount
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"slices"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+const stakeMirrorEntryLen = ids.IDLen + ids.ShortIDLen + 3*wrappers.LongLen
+
+// stakeMirrorEntry is the stake that an address put into a validator, either
+// by validating or by delegating, as it is mirrored off-chain.
+type stakeMirrorEntry struct {
+	TxID      ids.ID
+	Address   ids.ShortID
+	Amount    uint64
+	StartTime uint64
+	EndTime   uint64
+}
+
+// Bytes returns the preimage of the hash of the entry: the txID, the address
+// and the big endian amount, start time and end time.
+func (e *stakeMirrorEntry) Bytes() []byte {
+	p := wrappers.Packer{
+		Bytes: make([]byte, stakeMirrorEntryLen),
+	}
+	p.PackFixedBytes(e.TxID[:])
+	p.PackFixedBytes(e.Address[:])
+	p.PackLong(e.Amount)
+	p.PackLong(e.StartTime)
+	p.PackLong(e.EndTime)
+	return p.Bytes
+}
+
+// stakeMirrorEntries returns the entries of the stake of [staker], added by
+// [tx]. The stake of every output is attributed to the first of the sorted
+// addresses of its owner, and the stake of an address is summed over the
+// outputs of the tx. Entries are sorted by address.
+func stakeMirrorEntries(staker *state.Staker, tx *txs.Tx) ([]stakeMirrorEntry, error) {
+	stakerTx, ok := tx.Unsigned.(txs.PermissionlessStaker)
+	if !ok {
+		return nil, nil
+	}
+
+	amounts := make(map[ids.ShortID]uint64)
+	for _, output := range stakerTx.Stake() {
+		out := output.Out
+		if lockedOut, ok := out.(*stakeable.LockOut); ok {
+			out = lockedOut.TransferableOut
+		}
+		secpOut, ok := out.(*secp256k1fx.TransferOutput)
+		if !ok || len(secpOut.Addrs) == 0 {
+			continue
+		}
+
+		addr := secpOut.Addrs[0]
+		amount, err := safemath.Add64(amounts[addr], secpOut.Amt)
+		if err != nil {
+			return nil, err
+		}
+		amounts[addr] = amount
+	}
+
+	entries := make([]stakeMirrorEntry, 0, len(amounts))
+	for addr, amount := range amounts {
+		entries = append(entries, stakeMirrorEntry{
+			TxID:      staker.TxID,
+			Address:   addr,
+			Amount:    amount,
+			StartTime: uint64(staker.StartTime.Unix()),
+			EndTime:   uint64(staker.EndTime.Unix()),
+		})
+	}
+	slices.SortFunc(entries, func(a, b stakeMirrorEntry) int {
+		return a.Address.Compare(b.Address)
+	})
+	return entries, nil
+}
+
+// sortStakeMirrorEntries sorts [entries] by txID, and then by address.
+func sortStakeMirrorEntries(entries []stakeMirrorEntry) {
+	slices.SortFunc(entries, func(a, b stakeMirrorEntry) int {
+		if c := a.TxID.Compare(b.TxID); c != 0 {
+			return c
+		}
+		return a.Address.Compare(b.Address)
+	})
+}
+
+// stakeMirrorRoot returns the root that the stake mirror commits to for the
+// sorted [entries] of a validator.
+//
+// The hashes of the entries are the leaves of the same Merkle tree as the
+// txIDs of a block, so that verifiers can reuse a single implementation. See
+// [block.TxRoot].
+func stakeMirrorRoot(entries []stakeMirrorEntry) ids.ID {
+	leaves := make([]ids.ID, len(entries))
+	for i, entry := range entries {
+		leaves[i] = hashing.ComputeHash256Array(entry.Bytes())
+	}
+	return block.TxRoot(leaves)
+}
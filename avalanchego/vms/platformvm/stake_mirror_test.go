@@ -0,0 +1,90 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestStakeMirrorEntries(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		addr0 = ids.ShortID{1}
+		addr1 = ids.ShortID{2}
+	)
+	stakeOut := func(amount uint64, addrs ...ids.ShortID) *avax.TransferableOutput {
+		return &avax.TransferableOutput{
+			Out: &secp256k1fx.TransferOutput{
+				Amt: amount,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     addrs,
+				},
+			},
+		}
+	}
+	lockedOut := stakeOut(4, addr0)
+	lockedOut.Out = &stakeable.LockOut{
+		Locktime:        1,
+		TransferableOut: lockedOut.Out.(avax.TransferableOut),
+	}
+	tx := &txs.Tx{
+		Unsigned: &txs.AddDelegatorTx{
+			StakeOuts: []*avax.TransferableOutput{
+				stakeOut(1, addr1),
+				stakeOut(2, addr0, addr1),
+				lockedOut,
+			},
+		},
+	}
+	staker := &state.Staker{
+		TxID:      ids.GenerateTestID(),
+		StartTime: time.Unix(10, 0),
+		EndTime:   time.Unix(20, 0),
+	}
+
+	// Every output is attributed to the first of its addresses.
+	entries, err := stakeMirrorEntries(staker, tx)
+	require.NoError(err)
+	require.Equal([]stakeMirrorEntry{
+		{
+			TxID:      staker.TxID,
+			Address:   addr0,
+			Amount:    6,
+			StartTime: 10,
+			EndTime:   20,
+		},
+		{
+			TxID:      staker.TxID,
+			Address:   addr1,
+			Amount:    1,
+			StartTime: 10,
+			EndTime:   20,
+		},
+	}, entries)
+
+	// The leaves of the tree are the hashes of the entries.
+	require.Equal(
+		block.TxRoot([]ids.ID{
+			hashing.ComputeHash256Array(entries[0].Bytes()),
+			hashing.ComputeHash256Array(entries[1].Bytes()),
+		}),
+		stakeMirrorRoot(entries),
+	)
+	require.NotEqual(stakeMirrorRoot(entries), stakeMirrorRoot(entries[:1]))
+	require.Equal(ids.Empty, stakeMirrorRoot(nil))
+}
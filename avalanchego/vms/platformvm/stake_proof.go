@@ -0,0 +1,134 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+
+	avajson "github.com/ava-labs/avalanchego/utils/json"
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+// signedMessagePrefix is prepended to messages before they are signed, so that
+// a signed message can't be mistaken for a signed tx.
+const signedMessagePrefix = "\x1AAvalanche Signed Message:\n"
+
+var (
+	errEmptyStakeProofMessage      = errors.New("stake ownership proof has an empty message")
+	errNoStakeProofSignatures      = errors.New("stake ownership proof has no signatures")
+	errTooManyStakeProofSignatures = errors.New("stake ownership proof has too many signatures")
+	errAddressNotProven            = errors.New("address isn't controlled by a signer of the stake ownership proof")
+)
+
+// StakeOwnershipProof proves control of a set of addresses by signing
+// [Message] with the key of every address.
+//
+// Each signature is made over the hash of the message prefixed by
+// [signedMessagePrefix] and the big endian length of the message, which is the
+// format that wallets use to sign arbitrary messages.
+type StakeOwnershipProof struct {
+	Message string `json:"message"`
+	// Signatures of [Message], encoded with the encoding of the request
+	Signatures []string `json:"signatures"`
+}
+
+// StakerStake is the stake that the proven addresses own in a single staker
+// tx.
+type StakerStake struct {
+	TxID      ids.ID                    `json:"txID"`
+	NodeID    ids.NodeID                `json:"nodeID"`
+	SubnetID  ids.ID                    `json:"subnetID"`
+	Delegator bool                      `json:"delegator"`
+	Pending   bool                      `json:"pending"`
+	Stakeds   map[ids.ID]avajson.Uint64 `json:"stakeds"`
+}
+
+// signedMessageHash returns the hash that is signed to sign [msg].
+func signedMessageHash(msg []byte) []byte {
+	p := wrappers.Packer{
+		MaxSize: len(signedMessagePrefix) + wrappers.IntLen + len(msg),
+	}
+	p.PackFixedBytes([]byte(signedMessagePrefix))
+	p.PackBytes(msg)
+	return hashing.ComputeHash256(p.Bytes)
+}
+
+// verify that the signers of [p] control all of [addrs].
+func (p *StakeOwnershipProof) verify(encoding formatting.Encoding, addrs set.Set[ids.ShortID]) error {
+	switch {
+	case len(p.Message) == 0:
+		return errEmptyStakeProofMessage
+	case len(p.Signatures) == 0:
+		return errNoStakeProofSignatures
+	case len(p.Signatures) > maxGetStakeAddrs:
+		return fmt.Errorf("%w: %d > %d", errTooManyStakeProofSignatures, len(p.Signatures), maxGetStakeAddrs)
+	}
+
+	var (
+		hash    = signedMessageHash([]byte(p.Message))
+		signers = set.NewSet[ids.ShortID](len(p.Signatures))
+	)
+	for i, sigStr := range p.Signatures {
+		sig, err := formatting.Decode(encoding, sigStr)
+		if err != nil {
+			return fmt.Errorf("couldn't decode signature %d: %w", i, err)
+		}
+		pk, err := secp256k1.RecoverPublicKeyFromHash(hash, sig)
+		if err != nil {
+			return fmt.Errorf("couldn't recover signer of signature %d: %w", i, err)
+		}
+		signers.Add(pk.Address())
+	}
+
+	for addr := range addrs {
+		if !signers.Contains(addr) {
+			return fmt.Errorf("%w: %s", errAddressNotProven, addr)
+		}
+	}
+	return nil
+}
+
+// newStakerStake returns the breakdown of [stakedOuts], which are the outputs
+// staked by [staker] that are owned by the proven addresses.
+func newStakerStake(staker *state.Staker, stakedOuts []avax.TransferableOutput) StakerStake {
+	stakeds := make(map[ids.ID]uint64)
+	for _, out := range stakedOuts {
+		assetID := out.AssetID()
+		newAmount, err := safemath.Add64(stakeds[assetID], out.Out.Amount())
+		if err != nil {
+			newAmount = math.MaxUint64
+		}
+		stakeds[assetID] = newAmount
+	}
+	return StakerStake{
+		TxID:      staker.TxID,
+		NodeID:    staker.NodeID,
+		SubnetID:  staker.SubnetID,
+		Delegator: staker.Priority.IsDelegator(),
+		Pending:   staker.Priority.IsPending(),
+		Stakeds:   newJSONBalanceMap(stakeds),
+	}
+}
+
+// addStakeds adds [stakeds] to [totals].
+func addStakeds(totals map[ids.ID]uint64, stakeds map[ids.ID]avajson.Uint64) {
+	for assetID, amount := range stakeds {
+		newAmount, err := safemath.Add64(totals[assetID], uint64(amount))
+		if err != nil {
+			newAmount = math.MaxUint64
+		}
+		totals[assetID] = newAmount
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/DataDog/zstd"
+
+	_ "embed"
+)
+
+const (
+	// compressedBlockMarker prefixes the stored bytes of compressed blocks.
+	// Serialized blocks and legacy stateBlks start with their 2 byte codec
+	// version, which is never 0xffff, so the marker can't be confused with an
+	// uncompressed block.
+	compressedBlockMarker = 0xff
+
+	// compressedBlockHeaderLen is the length of the marker followed by the
+	// version of the dictionary the block was compressed with.
+	compressedBlockHeaderLen = 3
+
+	blockCompressionLevel = zstd.DefaultCompression
+
+	// currentBlockDictionaryVersion is the dictionary that newly written
+	// blocks are compressed with.
+	currentBlockDictionaryVersion = 0
+)
+
+var (
+	// blockDictionaryV0 is a zstd raw content dictionary made of accepted
+	// blocks containing the common P-chain tx types. Blocks share most of
+	// their structure, such as codec type IDs, asset IDs and output owners,
+	// so even small blocks compress well against it.
+	//
+	// The blocks are built by TestBlockDictionary in the platformvm package,
+	// which also generates new dictionary versions. They include a random BLS
+	// key and signatures, so regenerating a dictionary doesn't reproduce it
+	// byte for byte.
+	//
+	// Stored blocks reference their dictionary by version, so a dictionary
+	// must never be modified once released. New dictionaries must be added
+	// with a new version.
+	//
+	//go:embed block_dictionary_v0.bin
+	blockDictionaryV0 []byte
+
+	blockDictionaries = [][]byte{
+		blockDictionaryV0,
+	}
+
+	blockProcessorsOnce sync.Once
+	blockProcessors     []*zstd.BulkProcessor
+	blockProcessorsErr  error
+
+	errUnknownBlockDictionary = errors.New("unknown block compression dictionary")
+	errTruncatedBlock         = errors.New("truncated compressed block")
+)
+
+func getBlockProcessors() ([]*zstd.BulkProcessor, error) {
+	blockProcessorsOnce.Do(func() {
+		blockProcessors = make([]*zstd.BulkProcessor, len(blockDictionaries))
+		for i, dictionary := range blockDictionaries {
+			blockProcessors[i], blockProcessorsErr = zstd.NewBulkProcessor(dictionary, blockCompressionLevel)
+			if blockProcessorsErr != nil {
+				blockProcessorsErr = fmt.Errorf("failed to load block dictionary %d: %w", i, blockProcessorsErr)
+				return
+			}
+		}
+	})
+	return blockProcessors, blockProcessorsErr
+}
+
+func isCompressedBlock(stored []byte) bool {
+	return len(stored) >= 2 &&
+		stored[0] == compressedBlockMarker &&
+		stored[1] == compressedBlockMarker
+}
+
+// compressBlock returns the bytes to store for a block serialized as
+// [blkBytes].
+func compressBlock(blkBytes []byte) ([]byte, error) {
+	processors, err := getBlockProcessors()
+	if err != nil {
+		return nil, err
+	}
+
+	compressed, err := processors[currentBlockDictionaryVersion].Compress(nil, blkBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	stored := make([]byte, compressedBlockHeaderLen+len(compressed))
+	stored[0] = compressedBlockMarker
+	stored[1] = compressedBlockMarker
+	stored[2] = currentBlockDictionaryVersion
+	copy(stored[compressedBlockHeaderLen:], compressed)
+	return stored, nil
+}
+
+// decompressBlock returns the serialized block stored as [stored]. Blocks that
+// were stored uncompressed are returned as is.
+func decompressBlock(stored []byte) ([]byte, error) {
+	if !isCompressedBlock(stored) {
+		return stored, nil
+	}
+	if len(stored) <= compressedBlockHeaderLen {
+		return nil, errTruncatedBlock
+	}
+
+	processors, err := getBlockProcessors()
+	if err != nil {
+		return nil, err
+	}
+
+	version := stored[2]
+	if int(version) >= len(processors) {
+		return nil, fmt.Errorf("%w: %d", errUnknownBlockDictionary, version)
+	}
+	return processors[version].Decompress(nil, stored[compressedBlockHeaderLen:])
+}
+
+// blockBytesToStore returns the bytes that [blkBytes] should be written to
+// disk as.
+func (s *state) blockBytesToStore(blkBytes []byte) ([]byte, error) {
+	if !s.compressBlocks {
+		return blkBytes, nil
+	}
+	return compressBlock(blkBytes)
+}
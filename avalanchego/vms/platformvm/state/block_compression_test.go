@@ -0,0 +1,98 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+)
+
+func TestCompressBlock(t *testing.T) {
+	require := require.New(t)
+
+	blk, err := block.NewApricotCommitBlock(ids.GenerateTestID(), 1)
+	require.NoError(err)
+
+	stored, err := compressBlock(blk.Bytes())
+	require.NoError(err)
+	require.True(isCompressedBlock(stored))
+	require.False(isCompressedBlock(blk.Bytes()))
+
+	blkBytes, err := decompressBlock(stored)
+	require.NoError(err)
+	require.Equal(blk.Bytes(), blkBytes)
+
+	// Uncompressed blocks are returned as is.
+	blkBytes, err = decompressBlock(blk.Bytes())
+	require.NoError(err)
+	require.Equal(blk.Bytes(), blkBytes)
+
+	_, err = decompressBlock(stored[:compressedBlockHeaderLen])
+	require.ErrorIs(err, errTruncatedBlock)
+
+	stored[2] = byte(len(blockDictionaries))
+	_, err = decompressBlock(stored)
+	require.ErrorIs(err, errUnknownBlockDictionary)
+}
+
+func TestBlockCompressionDictionary(t *testing.T) {
+	require := require.New(t)
+
+	// The dictionary is made of representative blocks, which should compress
+	// to a fraction of their size against it.
+	stored, err := compressBlock(blockDictionaryV0)
+	require.NoError(err)
+	require.Less(len(stored), len(blockDictionaryV0)/10)
+}
+
+func TestStoredBlockCompression(t *testing.T) {
+	require := require.New(t)
+
+	s := newInitializedState(require).(*state)
+	s.blockCache = &cache.Empty[ids.ID, block.Block]{}
+
+	// Blocks written before compression was enabled must remain readable.
+	uncompressedBlk, err := block.NewApricotCommitBlock(s.GetLastAccepted(), 1)
+	require.NoError(err)
+	s.AddStatelessBlock(uncompressedBlk)
+	s.SetLastAccepted(uncompressedBlk.ID())
+	s.SetHeight(1)
+	require.NoError(s.Commit())
+
+	s.compressBlocks = true
+	compressedBlk, err := block.NewApricotCommitBlock(uncompressedBlk.ID(), 2)
+	require.NoError(err)
+	s.AddStatelessBlock(compressedBlk)
+	s.SetLastAccepted(compressedBlk.ID())
+	s.SetHeight(2)
+	require.NoError(s.Commit())
+
+	for _, test := range []struct {
+		blk        block.Block
+		compressed bool
+	}{
+		{
+			blk:        uncompressedBlk,
+			compressed: false,
+		},
+		{
+			blk:        compressedBlk,
+			compressed: true,
+		},
+	} {
+		blkID := test.blk.ID()
+		stored, err := s.blockDB.Get(blkID[:])
+		require.NoError(err)
+		require.Equal(test.compressed, isCompressedBlock(stored))
+
+		blk, err := s.GetStatelessBlock(blkID)
+		require.NoError(err)
+		require.Equal(test.blk.Bytes(), blk.Bytes())
+	}
+}
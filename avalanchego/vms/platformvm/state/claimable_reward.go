@@ -0,0 +1,20 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import "github.com/ava-labs/avalanchego/ids"
+
+// claimableRewardKey identifies the rewards of an asset that a rewards owner
+// can claim.
+type claimableRewardKey struct {
+	ownerID ids.ID
+	assetID ids.ID
+}
+
+func (k claimableRewardKey) Bytes() []byte {
+	key := make([]byte, 2*ids.IDLen)
+	copy(key, k.ownerID[:])
+	copy(key[ids.IDLen:], k.assetID[:])
+	return key
+}
@@ -0,0 +1,142 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+)
+
+const (
+	coldBlockDBName = "cold_blocks"
+
+	// maxColdBlocksPerCommit limits how many blocks are moved to the cold
+	// block database per commit, so that catching up on a long history
+	// doesn't stall block acceptance.
+	maxColdBlocksPerCommit = 64
+)
+
+// NextColdBlockHeightKey is the height of the next accepted block to be moved
+// to the cold block database.
+var NextColdBlockHeightKey = []byte("next cold block height")
+
+// coldBlocks moves accepted blocks that are more than [depth] blocks below the
+// last accepted block out of the main database and into [db].
+type coldBlocks struct {
+	db         database.Database
+	depth      uint64
+	nextHeight uint64
+}
+
+// openColdBlocks opens the cold block database configured in [execCfg], if
+// any.
+func openColdBlocks(
+	execCfg *config.ExecutionConfig,
+	ctx *snow.Context,
+	metricsReg prometheus.Registerer,
+) (*coldBlocks, error) {
+	if execCfg.ColdBlockDepth == 0 {
+		return nil, nil
+	}
+
+	path := execCfg.ColdBlockDBPath
+	if path == "" {
+		path = filepath.Join(ctx.ChainDataDir, coldBlockDBName)
+	}
+	db, err := leveldb.New(path, nil, ctx.Log, coldBlockDBName, metricsReg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cold block database at %q: %w", path, err)
+	}
+	return &coldBlocks{
+		db:    db,
+		depth: execCfg.ColdBlockDepth,
+	}, nil
+}
+
+func (s *state) loadColdBlocks() error {
+	if s.coldBlocks == nil {
+		return nil
+	}
+
+	nextHeight, err := database.GetUInt64(s.singletonDB, NextColdBlockHeightKey)
+	if err == database.ErrNotFound {
+		return nil
+	}
+	s.coldBlocks.nextHeight = nextHeight
+	return err
+}
+
+func (s *state) closeColdBlocks() error {
+	if s.coldBlocks == nil {
+		return nil
+	}
+	return s.coldBlocks.db.Close()
+}
+
+// getColdBlock returns the bytes of [blkID] from the cold block database.
+func (s *state) getColdBlock(blkID ids.ID) ([]byte, error) {
+	if s.coldBlocks == nil {
+		return nil, database.ErrNotFound
+	}
+	return s.coldBlocks.db.Get(blkID[:])
+}
+
+// writeColdBlocks moves the accepted blocks that have fallen more than the
+// configured depth behind [height] into the cold block database.
+//
+// Blocks are written to the cold block database before they are removed from
+// the main database, so a block may temporarily be stored in both databases,
+// but is never missing from both.
+func (s *state) writeColdBlocks(height uint64) error {
+	if s.coldBlocks == nil || height < s.coldBlocks.depth {
+		return nil
+	}
+
+	var (
+		maxHeight = height - s.coldBlocks.depth
+		numMoved  = 0
+		h         = s.coldBlocks.nextHeight
+	)
+	for ; h <= maxHeight && numMoved < maxColdBlocksPerCommit; h++ {
+		blkID, err := s.GetBlockIDAtHeight(h)
+		if err == database.ErrNotFound {
+			// Blocks accepted before the height index was populated aren't
+			// moved.
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		blkBytes, err := s.blockDB.Get(blkID[:])
+		if err == database.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := s.coldBlocks.db.Put(blkID[:], blkBytes); err != nil {
+			return fmt.Errorf("failed to write cold block %s: %w", blkID, err)
+		}
+		if err := s.blockDB.Delete(blkID[:]); err != nil {
+			return fmt.Errorf("failed to delete block %s: %w", blkID, err)
+		}
+		numMoved++
+	}
+
+	if h == s.coldBlocks.nextHeight {
+		return nil
+	}
+	s.coldBlocks.nextHeight = h
+	return database.PutUInt64(s.singletonDB, NextColdBlockHeightKey, h)
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+)
+
+func TestColdBlocks(t *testing.T) {
+	require := require.New(t)
+
+	s := newInitializedState(require).(*state)
+	coldDB := memdb.New()
+	s.coldBlocks = &coldBlocks{
+		db:    coldDB,
+		depth: 2,
+	}
+	s.blockCache = &cache.Empty[ids.ID, block.Block]{}
+
+	parentID := s.GetLastAccepted()
+	blkIDs := []ids.ID{parentID}
+	for height := uint64(1); height <= 4; height++ {
+		blk, err := block.NewApricotCommitBlock(parentID, height)
+		require.NoError(err)
+
+		s.AddStatelessBlock(blk)
+		s.SetLastAccepted(blk.ID())
+		s.SetHeight(height)
+		require.NoError(s.Commit())
+
+		parentID = blk.ID()
+		blkIDs = append(blkIDs, parentID)
+	}
+
+	// Blocks at heights [0, 2] are cold.
+	for height, blkID := range blkIDs {
+		hasHot, err := s.blockDB.Has(blkID[:])
+		require.NoError(err)
+		hasCold, err := coldDB.Has(blkID[:])
+		require.NoError(err)
+
+		isCold := height <= 2
+		require.Equal(!isCold, hasHot)
+		require.Equal(isCold, hasCold)
+
+		blk, err := s.GetStatelessBlock(blkID)
+		require.NoError(err)
+		require.Equal(uint64(height), blk.Height())
+	}
+
+	nextHeight, err := database.GetUInt64(s.singletonDB, NextColdBlockHeightKey)
+	require.NoError(err)
+	require.Equal(uint64(3), nextHeight)
+}
@@ -0,0 +1,226 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// compactionCheckFrequency is how often the scheduler checks whether it has
+// entered a compaction window.
+const compactionCheckFrequency = time.Minute
+
+// coldPrefixes returns the database prefixes of the data that is rarely read
+// or written once it has been accepted. Compacting these ranges is cheap to
+// schedule and avoids the organic compactions that would otherwise be
+// triggered while processing blocks.
+func coldPrefixes() [][]byte {
+	validatorsPrefix := prefixdb.MakePrefix(ValidatorsPrefix)
+	return [][]byte{
+		prefixdb.MakePrefix(BlockIDPrefix),
+		prefixdb.MakePrefix(BlockPrefix),
+		prefixdb.JoinPrefixes(validatorsPrefix, NestedValidatorWeightDiffsPrefix),
+		prefixdb.JoinPrefixes(validatorsPrefix, NestedValidatorPublicKeyDiffsPrefix),
+		prefixdb.JoinPrefixes(validatorsPrefix, FlatValidatorWeightDiffsPrefix),
+		prefixdb.JoinPrefixes(validatorsPrefix, FlatValidatorPublicKeyDiffsPrefix),
+	}
+}
+
+// prefixLimit returns the smallest key that is larger than every key starting
+// with [prefix]. If no such key exists, nil is returned.
+func prefixLimit(prefix []byte) []byte {
+	limit := make([]byte, len(prefix))
+	copy(limit, prefix)
+	for i := len(limit) - 1; i >= 0; i-- {
+		limit[i]++
+		if limit[i] != 0 {
+			return limit[:i+1]
+		}
+	}
+	return nil
+}
+
+func (s *state) Compact() error {
+	for _, prefix := range coldPrefixes() {
+		if err := s.baseDB.Compact(prefix, prefixLimit(prefix)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Compacter compacts the cold regions of the database.
+type Compacter interface {
+	Compact() error
+}
+
+// CompactionScheduler compacts the database once during every daily
+// compaction window, unless it has been paused. Compactions can also be
+// triggered explicitly, regardless of the window or of being paused.
+type CompactionScheduler struct {
+	log       logging.Logger
+	clk       *mockable.Clock
+	compacter Compacter
+
+	// The window starts [windowStart] after midnight UTC and lasts for
+	// [windowLength]. If [windowLength] is 0, compactions are never
+	// scheduled.
+	windowStart  time.Duration
+	windowLength time.Duration
+
+	compactions        prometheus.Counter
+	compactionDuration prometheus.Gauge
+
+	trigger chan struct{}
+
+	lock           sync.Mutex
+	paused         bool
+	lastCompaction time.Time
+}
+
+func NewCompactionScheduler(
+	log logging.Logger,
+	clk *mockable.Clock,
+	compacter Compacter,
+	windowStart time.Duration,
+	windowLength time.Duration,
+	registerer prometheus.Registerer,
+) (*CompactionScheduler, error) {
+	c := &CompactionScheduler{
+		log:          log,
+		clk:          clk,
+		compacter:    compacter,
+		windowStart:  windowStart % (24 * time.Hour),
+		windowLength: windowLength,
+		compactions: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "db_compactions",
+			Help: "Number of compactions of the cold database prefixes",
+		}),
+		compactionDuration: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "db_compaction_duration",
+			Help: "Duration (in ns) of the last compaction of the cold database prefixes",
+		}),
+		trigger: make(chan struct{}, 1),
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(c.compactions),
+		registerer.Register(c.compactionDuration),
+	)
+	return c, errs.Err
+}
+
+// Dispatch runs the scheduler until [ctx] is cancelled.
+func (c *CompactionScheduler) Dispatch(ctx context.Context) {
+	ticker := time.NewTicker(compactionCheckFrequency)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.trigger:
+			c.compact()
+		case <-ticker.C:
+			if c.shouldCompact() {
+				c.compact()
+			}
+		}
+	}
+}
+
+// Trigger requests a compaction to be performed as soon as possible.
+func (c *CompactionScheduler) Trigger() {
+	select {
+	case c.trigger <- struct{}{}:
+	default:
+		// A compaction is already pending.
+	}
+}
+
+// Pause stops compactions from being scheduled until Resume is called.
+func (c *CompactionScheduler) Pause() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.paused = true
+}
+
+// Resume allows compactions to be scheduled again.
+func (c *CompactionScheduler) Resume() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.paused = false
+}
+
+// Paused returns true if scheduled compactions are paused.
+func (c *CompactionScheduler) Paused() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.paused
+}
+
+// LastCompaction returns when the last compaction finished.
+func (c *CompactionScheduler) LastCompaction() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.lastCompaction
+}
+
+// shouldCompact returns true if the current time is inside of a compaction
+// window that hasn't had a compaction yet.
+func (c *CompactionScheduler) shouldCompact() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.paused || c.windowLength <= 0 {
+		return false
+	}
+
+	now := c.clk.Time().UTC()
+	windowStart := now.Truncate(24 * time.Hour).Add(c.windowStart)
+	if windowStart.After(now) {
+		// The window may have started the previous day and not have ended
+		// yet.
+		windowStart = windowStart.Add(-24 * time.Hour)
+	}
+	windowEnd := windowStart.Add(c.windowLength)
+	return now.Before(windowEnd) && c.lastCompaction.Before(windowStart)
+}
+
+func (c *CompactionScheduler) compact() {
+	start := c.clk.Time()
+	if err := c.compacter.Compact(); err != nil {
+		c.log.Warn("failed to compact database",
+			zap.Error(err),
+		)
+		return
+	}
+	end := c.clk.Time()
+	duration := end.Sub(start)
+
+	c.lock.Lock()
+	c.lastCompaction = end
+	c.lock.Unlock()
+
+	c.compactions.Inc()
+	c.compactionDuration.Set(float64(duration))
+	c.log.Info("compacted database",
+		zap.Duration("duration", duration),
+	)
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+type countingCompacter struct {
+	compactions int
+}
+
+func (c *countingCompacter) Compact() error {
+	c.compactions++
+	return nil
+}
+
+func TestPrefixLimit(t *testing.T) {
+	tests := []struct {
+		prefix []byte
+		limit  []byte
+	}{
+		{
+			prefix: []byte{0x01, 0x02},
+			limit:  []byte{0x01, 0x03},
+		},
+		{
+			prefix: []byte{0x01, 0xff},
+			limit:  []byte{0x02},
+		},
+		{
+			prefix: []byte{0xff, 0xff},
+			limit:  nil,
+		},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.limit, prefixLimit(test.prefix))
+	}
+}
+
+func TestCompactionSchedulerWindow(t *testing.T) {
+	require := require.New(t)
+
+	clk := &mockable.Clock{}
+	compacter := &countingCompacter{}
+	c, err := NewCompactionScheduler(
+		logging.NoLog{},
+		clk,
+		compacter,
+		23*time.Hour,
+		2*time.Hour,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+
+	midnight := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	// Outside of the window.
+	clk.Set(midnight.Add(12 * time.Hour))
+	require.False(c.shouldCompact())
+
+	// The window started on the previous day.
+	clk.Set(midnight.Add(30 * time.Minute))
+	require.True(c.shouldCompact())
+	c.compact()
+	require.Equal(1, compacter.compactions)
+	require.Equal(midnight.Add(30*time.Minute), c.LastCompaction())
+
+	// Only one compaction is scheduled per window.
+	clk.Set(midnight.Add(time.Hour))
+	require.False(c.shouldCompact())
+
+	// Paused schedulers don't compact during the next window.
+	c.Pause()
+	clk.Set(midnight.Add(23*time.Hour + time.Minute))
+	require.False(c.shouldCompact())
+
+	c.Resume()
+	require.True(c.shouldCompact())
+}
+
+func TestCompactionSchedulerDisabled(t *testing.T) {
+	clk := &mockable.Clock{}
+	c, err := NewCompactionScheduler(
+		logging.NoLog{},
+		clk,
+		&countingCompacter{},
+		0,
+		0,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+
+	clk.Set(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+	require.False(t, c.shouldCompact())
+}
@@ -0,0 +1,20 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import "github.com/ava-labs/avalanchego/ids"
+
+// delegatedStakeKey identifies the stake that a rewards owner delegates to a
+// validator.
+type delegatedStakeKey struct {
+	validatorTxID ids.ID
+	ownerID       ids.ID
+}
+
+func (k delegatedStakeKey) Bytes() []byte {
+	key := make([]byte, 2*ids.IDLen)
+	copy(key, k.validatorTxID[:])
+	copy(key[ids.IDLen:], k.ownerID[:])
+	return key
+}
@@ -48,6 +48,22 @@ type diff struct {
 	addedSubnets []*txs.Tx
 	// Subnet ID --> Owner of the subnet
 	subnetOwners map[ids.ID]fx.Owner
+	// Subnet ID --> Node ID --> true if the node was added to the allow list,
+	// false if it was removed
+	modifiedSubnetAllowList map[ids.ID]map[ids.NodeID]ids.ID
+	// Staker Tx ID --> receipt of the staker's early removal
+	addedStakerExits map[ids.ID]*StakerExit
+	// Name --> registration of the name
+	modifiedNames map[string]*NameRecord
+	// Rewards owner ID + asset ID --> rewards that haven't been claimed
+	modifiedClaimableRewards map[claimableRewardKey]uint64
+	// Validator Tx ID + rewards owner ID --> stake delegated to a capped
+	// validator
+	modifiedDelegatedStakes map[delegatedStakeKey]uint64
+	// Rewards owner ID --> C-chain address that its rewards are exported to
+	modifiedRewardAddresses map[ids.ID]ids.ShortID
+
+	modifiedParameterChanges map[txs.Parameter][]ParameterChange
 	// Subnet ID --> Tx that transforms the subnet
 	transformedSubnets map[ids.ID]*txs.Tx
 
@@ -133,7 +149,7 @@ func (d *diff) GetCurrentValidator(subnetID ids.ID, nodeID ids.NodeID) (*Staker,
 	// validator.
 	newValidator, status := d.currentStakerDiffs.GetValidator(subnetID, nodeID)
 	switch status {
-	case added:
+	case added, modified:
 		return newValidator, nil
 	case deleted:
 		return nil, database.ErrNotFound
@@ -180,6 +196,10 @@ func (d *diff) DeleteCurrentValidator(staker *Staker) {
 	d.currentStakerDiffs.DeleteValidator(staker)
 }
 
+func (d *diff) UpdateCurrentValidator(staker *Staker) {
+	d.currentStakerDiffs.UpdateValidator(staker)
+}
+
 func (d *diff) GetCurrentDelegatorIterator(subnetID ids.ID, nodeID ids.NodeID) (StakerIterator, error) {
 	parentState, ok := d.stateVersions.GetState(d.parentID)
 	if !ok {
@@ -279,6 +299,20 @@ func (d *diff) GetPendingStakerIterator() (StakerIterator, error) {
 	return d.pendingStakerDiffs.GetStakerIterator(parentIterator), nil
 }
 
+func (d *diff) GetPendingStakerIteratorUntil(timestamp time.Time) (StakerIterator, error) {
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+
+	parentIterator, err := parentState.GetPendingStakerIteratorUntil(timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.pendingStakerDiffs.GetStakerIteratorUntil(parentIterator, timestamp), nil
+}
+
 func (d *diff) AddSubnet(createSubnetTx *txs.Tx) {
 	d.addedSubnets = append(d.addedSubnets, createSubnetTx)
 }
@@ -301,6 +335,169 @@ func (d *diff) SetSubnetOwner(subnetID ids.ID, owner fx.Owner) {
 	d.subnetOwners[subnetID] = owner
 }
 
+func (d *diff) GetSubnetAllowListEntry(subnetID ids.ID, nodeID ids.NodeID) (ids.ID, error) {
+	if txID, exists := d.modifiedSubnetAllowList[subnetID][nodeID]; exists {
+		if txID == ids.Empty {
+			return ids.Empty, database.ErrNotFound
+		}
+		return txID, nil
+	}
+
+	// If the allow list entry was not modified in this diff, ask the parent
+	// state.
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return ids.Empty, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+	return parentState.GetSubnetAllowListEntry(subnetID, nodeID)
+}
+
+func (d *diff) SetSubnetAllowListEntry(subnetID ids.ID, nodeID ids.NodeID, txID ids.ID) {
+	if d.modifiedSubnetAllowList == nil {
+		d.modifiedSubnetAllowList = make(map[ids.ID]map[ids.NodeID]ids.ID)
+	}
+	allowList, ok := d.modifiedSubnetAllowList[subnetID]
+	if !ok {
+		allowList = make(map[ids.NodeID]ids.ID)
+		d.modifiedSubnetAllowList[subnetID] = allowList
+	}
+	allowList[nodeID] = txID
+}
+
+func (d *diff) GetStakerExit(stakerTxID ids.ID) (*StakerExit, error) {
+	if exit, exists := d.addedStakerExits[stakerTxID]; exists {
+		return exit, nil
+	}
+
+	// If the staker wasn't removed in this diff, ask the parent state.
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+	return parentState.GetStakerExit(stakerTxID)
+}
+
+func (d *diff) SetStakerExit(stakerTxID ids.ID, exit *StakerExit) {
+	if d.addedStakerExits == nil {
+		d.addedStakerExits = make(map[ids.ID]*StakerExit)
+	}
+	d.addedStakerExits[stakerTxID] = exit
+}
+
+func (d *diff) GetName(name string) (*NameRecord, error) {
+	if record, exists := d.modifiedNames[name]; exists {
+		return record, nil
+	}
+
+	// If the name wasn't modified in this diff, ask the parent state.
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+	return parentState.GetName(name)
+}
+
+func (d *diff) SetName(name string, record *NameRecord) {
+	if d.modifiedNames == nil {
+		d.modifiedNames = make(map[string]*NameRecord)
+	}
+	d.modifiedNames[name] = record
+}
+
+func (d *diff) GetClaimableReward(ownerID ids.ID, assetID ids.ID) (uint64, error) {
+	key := claimableRewardKey{
+		ownerID: ownerID,
+		assetID: assetID,
+	}
+	if amount, exists := d.modifiedClaimableRewards[key]; exists {
+		return amount, nil
+	}
+
+	// If the rewards weren't modified in this diff, ask the parent state.
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+	return parentState.GetClaimableReward(ownerID, assetID)
+}
+
+func (d *diff) SetClaimableReward(ownerID ids.ID, assetID ids.ID, amount uint64) {
+	if d.modifiedClaimableRewards == nil {
+		d.modifiedClaimableRewards = make(map[claimableRewardKey]uint64)
+	}
+	d.modifiedClaimableRewards[claimableRewardKey{
+		ownerID: ownerID,
+		assetID: assetID,
+	}] = amount
+}
+
+func (d *diff) GetDelegatedStake(validatorTxID ids.ID, ownerID ids.ID) (uint64, error) {
+	key := delegatedStakeKey{
+		validatorTxID: validatorTxID,
+		ownerID:       ownerID,
+	}
+	if stake, exists := d.modifiedDelegatedStakes[key]; exists {
+		return stake, nil
+	}
+
+	// If the stake wasn't modified in this diff, ask the parent state.
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+	return parentState.GetDelegatedStake(validatorTxID, ownerID)
+}
+
+func (d *diff) SetDelegatedStake(validatorTxID ids.ID, ownerID ids.ID, stake uint64) {
+	if d.modifiedDelegatedStakes == nil {
+		d.modifiedDelegatedStakes = make(map[delegatedStakeKey]uint64)
+	}
+	d.modifiedDelegatedStakes[delegatedStakeKey{
+		validatorTxID: validatorTxID,
+		ownerID:       ownerID,
+	}] = stake
+}
+
+func (d *diff) GetRewardAddress(ownerID ids.ID) (ids.ShortID, error) {
+	if addr, exists := d.modifiedRewardAddresses[ownerID]; exists {
+		return addr, nil
+	}
+
+	// If the binding wasn't modified in this diff, ask the parent state.
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return ids.ShortEmpty, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+	return parentState.GetRewardAddress(ownerID)
+}
+
+func (d *diff) SetRewardAddress(ownerID ids.ID, addr ids.ShortID) {
+	if d.modifiedRewardAddresses == nil {
+		d.modifiedRewardAddresses = make(map[ids.ID]ids.ShortID)
+	}
+	d.modifiedRewardAddresses[ownerID] = addr
+}
+
+func (d *diff) GetParameterChanges(parameter txs.Parameter) ([]ParameterChange, error) {
+	if changes, exists := d.modifiedParameterChanges[parameter]; exists {
+		return changes, nil
+	}
+
+	// If the parameter wasn't changed in this diff, ask the parent state.
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+	return parentState.GetParameterChanges(parameter)
+}
+
+func (d *diff) SetParameterChanges(parameter txs.Parameter, changes []ParameterChange) {
+	if d.modifiedParameterChanges == nil {
+		d.modifiedParameterChanges = make(map[txs.Parameter][]ParameterChange)
+	}
+	d.modifiedParameterChanges[parameter] = changes
+}
+
 func (d *diff) GetSubnetTransformation(subnetID ids.ID) (*txs.Tx, error) {
 	tx, exists := d.transformedSubnets[subnetID]
 	if exists {
@@ -411,13 +608,25 @@ func (d *diff) Apply(baseState Chain) error {
 	for subnetID, supply := range d.currentSupply {
 		baseState.SetCurrentSupply(subnetID, supply)
 	}
+	// Stakers are removed before any are added, as a re-keyed staker is
+	// removed from its prior nodeID and added back with the same TxID.
+	for _, subnetValidatorDiffs := range d.currentStakerDiffs.validatorDiffs {
+		for _, validatorDiff := range subnetValidatorDiffs {
+			if validatorDiff.validatorStatus == deleted {
+				baseState.DeleteCurrentValidator(validatorDiff.validator)
+			}
+			for _, delegator := range validatorDiff.deletedDelegators {
+				baseState.DeleteCurrentDelegator(delegator)
+			}
+		}
+	}
 	for _, subnetValidatorDiffs := range d.currentStakerDiffs.validatorDiffs {
 		for _, validatorDiff := range subnetValidatorDiffs {
 			switch validatorDiff.validatorStatus {
 			case added:
 				baseState.PutCurrentValidator(validatorDiff.validator)
-			case deleted:
-				baseState.DeleteCurrentValidator(validatorDiff.validator)
+			case modified:
+				baseState.UpdateCurrentValidator(validatorDiff.validator)
 			}
 
 			addedDelegatorIterator := NewTreeIterator(validatorDiff.addedDelegators)
@@ -425,10 +634,6 @@ func (d *diff) Apply(baseState Chain) error {
 				baseState.PutCurrentDelegator(addedDelegatorIterator.Value())
 			}
 			addedDelegatorIterator.Release()
-
-			for _, delegator := range validatorDiff.deletedDelegators {
-				baseState.DeleteCurrentDelegator(delegator)
-			}
 		}
 	}
 	for subnetID, nodes := range d.modifiedDelegateeRewards {
@@ -487,5 +692,28 @@ func (d *diff) Apply(baseState Chain) error {
 	for subnetID, owner := range d.subnetOwners {
 		baseState.SetSubnetOwner(subnetID, owner)
 	}
+	for subnetID, allowList := range d.modifiedSubnetAllowList {
+		for nodeID, txID := range allowList {
+			baseState.SetSubnetAllowListEntry(subnetID, nodeID, txID)
+		}
+	}
+	for stakerTxID, exit := range d.addedStakerExits {
+		baseState.SetStakerExit(stakerTxID, exit)
+	}
+	for name, record := range d.modifiedNames {
+		baseState.SetName(name, record)
+	}
+	for key, amount := range d.modifiedClaimableRewards {
+		baseState.SetClaimableReward(key.ownerID, key.assetID, amount)
+	}
+	for key, stake := range d.modifiedDelegatedStakes {
+		baseState.SetDelegatedStake(key.validatorTxID, key.ownerID, stake)
+	}
+	for ownerID, addr := range d.modifiedRewardAddresses {
+		baseState.SetRewardAddress(ownerID, addr)
+	}
+	for parameter, changes := range d.modifiedParameterChanges {
+		baseState.SetParameterChanges(parameter, changes)
+	}
 	return nil
 }
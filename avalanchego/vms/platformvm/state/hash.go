@@ -0,0 +1,187 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/linkeddb"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+const (
+	hashEndMarker byte = iota
+	hashEntryMarker
+)
+
+// hashExcludedSingletons are the singleton keys that record how the state is
+// stored, rather than the state itself.
+var hashExcludedSingletons = [][]byte{
+	PrunedKey,
+	HeightsIndexedKey,
+	MigrationProgressKey,
+	NextColdBlockHeightKey,
+}
+
+// Hash returns a canonical hash of the state. The state should be committed
+// before it is hashed.
+//
+// Accepted blocks are hashed in height order by their serialized bytes, so the
+// hash doesn't depend on whether blocks are compressed or were moved to the
+// cold block database. The staker lists are hashed in key order, as the order
+// of a linked list depends on the order its entries were written in. The
+// nested validator diffs are a legacy copy of the flat validator diffs, so
+// only the flat diffs are hashed. Reward receipts hold the uptimes this node
+// measured, which other nodes don't agree on, so they aren't hashed either. The
+// tx roots and the blocks of the txs are derived from the accepted blocks, as
+// are the shared memory requests of the accepted blocks.
+func (s *state) Hash() (ids.ID, error) {
+	h := sha256.New()
+	if err := s.hashBlocks(h); err != nil {
+		return ids.Empty, err
+	}
+
+	for _, list := range []linkeddb.LinkedDB{
+		s.currentValidatorList,
+		s.currentDelegatorList,
+		s.currentSubnetValidatorList,
+		s.currentSubnetDelegatorList,
+		s.pendingValidatorList,
+		s.pendingDelegatorList,
+		s.pendingSubnetValidatorList,
+		s.pendingSubnetDelegatorList,
+	} {
+		if err := hashList(h, list); err != nil {
+			return ids.Empty, err
+		}
+	}
+
+	for _, db := range []database.Database{
+		s.flatValidatorWeightDiffsDB,
+		s.flatValidatorPublicKeyDiffsDB,
+		s.validatorSetCheckpointsDB,
+		s.txDB,
+		s.rewardUTXODB,
+		s.utxoDB,
+		s.subnetBaseDB,
+		s.subnetOwnerDB,
+		s.subnetAllowListDB,
+		s.stakerExitDB,
+		s.nameDB,
+		s.claimableRewardDB,
+		s.delegatedStakeDB,
+		s.rewardAddressDB,
+		s.subnetValidatorWeightDB,
+		s.stakerNodeIDDB,
+		s.stakerPublicKeyDB,
+		s.parameterDB,
+		s.transformedSubnetDB,
+		s.supplyDB,
+		s.chainDB,
+	} {
+		if err := hashDB(h, db, nil); err != nil {
+			return ids.Empty, err
+		}
+	}
+	if err := hashDB(h, s.singletonDB, hashExcludedSingletons); err != nil {
+		return ids.Empty, err
+	}
+	return ids.ID(h.Sum(nil)), nil
+}
+
+func (s *state) hashBlocks(h hash.Hash) error {
+	for height := uint64(0); height <= s.currentHeight; height++ {
+		blkID, err := s.GetBlockIDAtHeight(height)
+		if err == database.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		blk, err := s.GetStatelessBlock(blkID)
+		if err != nil {
+			return err
+		}
+
+		writeHashEntry(h, database.PackUInt64(height), blk.Bytes())
+	}
+	h.Write([]byte{hashEndMarker})
+	return nil
+}
+
+func hashList(h hash.Hash, list linkeddb.LinkedDB) error {
+	type entry struct {
+		key, value []byte
+	}
+	var entries []entry
+
+	it := list.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		entries = append(entries, entry{
+			key:   slices.Clone(it.Key()),
+			value: slices.Clone(it.Value()),
+		})
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	slices.SortFunc(entries, func(a, b entry) int {
+		return bytes.Compare(a.key, b.key)
+	})
+
+	for _, e := range entries {
+		writeHashEntry(h, e.key, e.value)
+	}
+	h.Write([]byte{hashEndMarker})
+	return nil
+}
+
+// hashDB hashes the entries of [db], other than [excludedKeys], in key order.
+func hashDB(h hash.Hash, db database.Database, excludedKeys [][]byte) error {
+	it := db.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		if slices.ContainsFunc(excludedKeys, func(excludedKey []byte) bool {
+			return bytes.Equal(key, excludedKey)
+		}) {
+			continue
+		}
+		writeHashEntry(h, key, it.Value())
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	h.Write([]byte{hashEndMarker})
+	return nil
+}
+
+// writeHashEntry writes a key-value pair to [h]. Every entry is preceded by
+// [hashEntryMarker] and every collection of entries is followed by
+// [hashEndMarker], so that the entries of consecutive collections can't be
+// confused with each other.
+func writeHashEntry(h hash.Hash, key, value []byte) {
+	h.Write([]byte{hashEntryMarker})
+	writeHashBytes(h, key)
+	writeHashBytes(h, value)
+}
+
+// writeHashBytes writes the length prefixed [b] to [h], so that the boundaries
+// between the hashed values are unambiguous.
+func writeHashBytes(h hash.Hash, b []byte) {
+	var lenBytes [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(lenBytes[:], uint64(len(b)))
+	h.Write(lenBytes[:n])
+	h.Write(b)
+}
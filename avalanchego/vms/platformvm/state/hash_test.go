@@ -0,0 +1,83 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+)
+
+func TestStateHash(t *testing.T) {
+	require := require.New(t)
+
+	uncompressed := newInitializedState(require).(*state)
+	require.NoError(uncompressed.Commit())
+
+	// Open a copy of the state.
+	db := memdb.New()
+	it := uncompressed.baseDB.NewIterator()
+	defer it.Release()
+	for it.Next() {
+		require.NoError(db.Put(it.Key(), it.Value()))
+	}
+	require.NoError(it.Error())
+
+	compressed := newStateFromDB(require, db)
+	require.NoError(compressed.load())
+	compressed.compressBlocks = true
+	compressed.blockCache = &cache.Empty[ids.ID, block.Block]{}
+
+	expectedHash, err := uncompressed.Hash()
+	require.NoError(err)
+	hash, err := compressed.Hash()
+	require.NoError(err)
+	require.Equal(expectedHash, hash)
+
+	// How blocks are stored doesn't change the hash.
+	for _, s := range []*state{uncompressed, compressed} {
+		blk, err := block.NewApricotCommitBlock(s.GetLastAccepted(), 1)
+		require.NoError(err)
+		s.AddStatelessBlock(blk)
+		s.SetLastAccepted(blk.ID())
+		s.SetHeight(1)
+		require.NoError(s.Commit())
+	}
+
+	expectedHash, err = uncompressed.Hash()
+	require.NoError(err)
+	hash, err = compressed.Hash()
+	require.NoError(err)
+	require.Equal(expectedHash, hash)
+
+	// The order that stakers were written in doesn't change the hash.
+	var (
+		firstTxID  = ids.ID{1}
+		secondTxID = ids.ID{2}
+	)
+	require.NoError(uncompressed.currentValidatorList.Put(firstTxID[:], nil))
+	require.NoError(uncompressed.currentValidatorList.Put(secondTxID[:], nil))
+	require.NoError(compressed.currentValidatorList.Put(secondTxID[:], nil))
+	require.NoError(compressed.currentValidatorList.Put(firstTxID[:], nil))
+
+	expectedHash, err = uncompressed.Hash()
+	require.NoError(err)
+	hash, err = compressed.Hash()
+	require.NoError(err)
+	require.Equal(expectedHash, hash)
+
+	// Modifying the state changes the hash.
+	compressed.SetTimestamp(compressed.GetTimestamp().Add(time.Second))
+	require.NoError(compressed.Commit())
+
+	hash, err = compressed.Hash()
+	require.NoError(err)
+	require.NotEqual(expectedHash, hash)
+}
@@ -0,0 +1,304 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+// maxIntegrityIssues limits the number of inconsistencies included in the
+// report of a failed integrity check.
+const maxIntegrityIssues = 64
+
+var (
+	// ErrInvariantViolated is returned by CheckInvariants if the state is
+	// inconsistent.
+	ErrInvariantViolated = errors.New("state invariant violated")
+
+	errIntegrityCheckFailed = errors.New("integrity check failed")
+)
+
+// integrityReport collects the inconsistencies found by an integrity check.
+type integrityReport struct {
+	issues    []string
+	numIssues int
+}
+
+func (r *integrityReport) add(issue string) {
+	r.numIssues++
+	if len(r.issues) < maxIntegrityIssues {
+		r.issues = append(r.issues, issue)
+	}
+}
+
+func (r *integrityReport) addf(format string, args ...interface{}) {
+	r.add(fmt.Sprintf(format, args...))
+}
+
+func (r *integrityReport) err() error {
+	if r.numIssues == 0 {
+		return nil
+	}
+	msg := strings.Join(r.issues, "\n\t")
+	if omitted := r.numIssues - len(r.issues); omitted > 0 {
+		msg += fmt.Sprintf("\n\t... and %d more", omitted)
+	}
+	return fmt.Errorf("%w: found %d inconsistencies:\n\t%s", errIntegrityCheckFailed, r.numIssues, msg)
+}
+
+// checkIntegrity cross-verifies the loaded staker sets against the stored
+// staker txs, the validator manager and the supply counters, and verifies the
+// UTXO address index against the stored UTXOs.
+//
+// Invariant: checkIntegrity must be called after the state has been loaded
+// and before any modifications have been made.
+func (s *state) checkIntegrity() error {
+	report := &integrityReport{}
+
+	outstandingRewards, err := s.checkCurrentStakers(report)
+	if err != nil {
+		return err
+	}
+	if err := s.checkPendingStakers(report); err != nil {
+		return err
+	}
+	if err := s.checkSupplies(report, outstandingRewards); err != nil {
+		return err
+	}
+
+	utxoDB := prefixdb.New(UTXOPrefix, s.baseDB)
+	if err := avax.CheckUTXOIndex(utxoDB, txs.GenesisCodec, report.add); err != nil {
+		return err
+	}
+	return report.err()
+}
+
+// CheckInvariants verifies the properties of the staker sets and supplies that
+// must hold after every accepted block. Unlike the integrity check performed
+// on startup, the UTXOs aren't scanned, so that it is cheap enough to run
+// while blocks are being accepted.
+//
+// Invariant: the state was written since the last block was accepted.
+func (s *state) CheckInvariants() error {
+	report := &integrityReport{}
+
+	outstandingRewards, err := s.checkCurrentStakers(report)
+	if err != nil {
+		return err
+	}
+	if err := s.checkPendingStakers(report); err != nil {
+		return err
+	}
+	if err := s.checkSupplies(report, outstandingRewards); err != nil {
+		return err
+	}
+	if err := report.err(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvariantViolated, err)
+	}
+	return nil
+}
+
+func checkStakerPeriod(report *integrityReport, staker *Staker) {
+	if staker.EndTime.Before(staker.StartTime) {
+		report.addf("staker %s of %s on subnet %s ends at %s before it starts at %s",
+			staker.TxID,
+			staker.NodeID,
+			staker.SubnetID,
+			staker.EndTime,
+			staker.StartTime,
+		)
+	}
+}
+
+// checkStakerTx verifies that [staker] matches the tx that created it.
+func (s *state) checkStakerTx(report *integrityReport, staker *Staker) error {
+	tx, _, err := s.GetTx(staker.TxID)
+	if err == database.ErrNotFound {
+		report.addf("staker %s of %s on subnet %s has no stored tx", staker.TxID, staker.NodeID, staker.SubnetID)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	stakerTx, ok := tx.Unsigned.(txs.Staker)
+	if !ok {
+		report.addf("staker %s was created by a %T", staker.TxID, tx.Unsigned)
+		return nil
+	}
+	// The nodeID of a re-keyed staker is stored separately from its tx.
+	nodeID, rekeyed, err := s.getStakerNodeID(staker.TxID)
+	if err != nil {
+		return err
+	}
+	if !rekeyed {
+		nodeID = stakerTx.NodeID()
+	}
+	// As is the weight of a subnet validator whose weight was set.
+	weight, err := database.GetUInt64(s.subnetValidatorWeightDB, staker.TxID[:])
+	if err == database.ErrNotFound {
+		weight = stakerTx.Weight()
+	} else if err != nil {
+		return err
+	}
+	if nodeID != staker.NodeID ||
+		stakerTx.SubnetID() != staker.SubnetID ||
+		weight != staker.Weight {
+		report.addf("staker %s is %s on subnet %s with weight %d but its tx specifies %s on subnet %s with weight %d",
+			staker.TxID,
+			staker.NodeID,
+			staker.SubnetID,
+			staker.Weight,
+			nodeID,
+			stakerTx.SubnetID(),
+			weight,
+		)
+	}
+	return nil
+}
+
+// checkCurrentStakers returns the potential rewards of the current stakers of
+// each subnet.
+func (s *state) checkCurrentStakers(report *integrityReport) (map[ids.ID]uint64, error) {
+	it, err := s.GetCurrentStakerIterator()
+	if err != nil {
+		return nil, err
+	}
+	defer it.Release()
+
+	var (
+		outstandingRewards = make(map[ids.ID]uint64)
+		weights            = make(map[ids.ID]map[ids.NodeID]uint64)
+	)
+	for it.Next() {
+		staker := it.Value()
+		if err := s.checkStakerTx(report, staker); err != nil {
+			return nil, err
+		}
+		checkStakerPeriod(report, staker)
+
+		if staker.Priority.IsCurrentDelegator() {
+			_, err := s.GetCurrentValidator(staker.SubnetID, staker.NodeID)
+			if err == database.ErrNotFound {
+				report.addf("delegator %s of %s on subnet %s has no current validator", staker.TxID, staker.NodeID, staker.SubnetID)
+			} else if err != nil {
+				return nil, err
+			}
+		}
+
+		outstandingRewards[staker.SubnetID], err = safemath.Add64(outstandingRewards[staker.SubnetID], staker.PotentialReward)
+		if err != nil {
+			report.addf("potential rewards of subnet %s overflow", staker.SubnetID)
+		}
+
+		subnetWeights, ok := weights[staker.SubnetID]
+		if !ok {
+			subnetWeights = make(map[ids.NodeID]uint64)
+			weights[staker.SubnetID] = subnetWeights
+		}
+		subnetWeights[staker.NodeID], err = safemath.Add64(subnetWeights[staker.NodeID], staker.Weight)
+		if err != nil {
+			report.addf("weight of %s on subnet %s overflows", staker.NodeID, staker.SubnetID)
+		}
+	}
+
+	for subnetID, subnetWeights := range weights {
+		if count := s.validators.Count(subnetID); count != len(subnetWeights) {
+			report.addf("subnet %s has %d current validators but the validator set has %d", subnetID, len(subnetWeights), count)
+		}
+		for nodeID, weight := range subnetWeights {
+			if setWeight := s.validators.GetWeight(subnetID, nodeID); setWeight != weight {
+				report.addf("%s has a weight of %d on subnet %s but the validator set has %d", nodeID, weight, subnetID, setWeight)
+			}
+		}
+	}
+	return outstandingRewards, nil
+}
+
+func (s *state) checkPendingStakers(report *integrityReport) error {
+	it, err := s.GetPendingStakerIterator()
+	if err != nil {
+		return err
+	}
+	defer it.Release()
+
+	for it.Next() {
+		staker := it.Value()
+		if err := s.checkStakerTx(report, staker); err != nil {
+			return err
+		}
+		checkStakerPeriod(report, staker)
+
+		if !staker.Priority.IsPendingDelegator() {
+			continue
+		}
+		_, err := s.GetCurrentValidator(staker.SubnetID, staker.NodeID)
+		if err == database.ErrNotFound {
+			_, err = s.GetPendingValidator(staker.SubnetID, staker.NodeID)
+		}
+		if err == database.ErrNotFound {
+			report.addf("pending delegator %s of %s on subnet %s has no validator", staker.TxID, staker.NodeID, staker.SubnetID)
+		} else if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkSupplies verifies that the supply of every subnet with current stakers
+// covers their potential rewards and, for elastic subnets, doesn't exceed the
+// maximum supply.
+func (s *state) checkSupplies(report *integrityReport, outstandingRewards map[ids.ID]uint64) error {
+	for subnetID, rewards := range outstandingRewards {
+		maxSupply := uint64(math.MaxUint64)
+		if subnetID != constants.PrimaryNetworkID {
+			transformSubnetIntf, err := s.GetSubnetTransformation(subnetID)
+			if err == database.ErrNotFound {
+				// Permissioned subnets don't have a supply to reward their
+				// stakers from.
+				if rewards != 0 {
+					report.addf("permissioned subnet %s has %d of potential rewards", subnetID, rewards)
+				}
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			transformSubnet, ok := transformSubnetIntf.Unsigned.(*txs.TransformSubnetTx)
+			if !ok {
+				report.addf("subnet %s was transformed by a %T", subnetID, transformSubnetIntf.Unsigned)
+				continue
+			}
+			maxSupply = transformSubnet.MaximumSupply
+		}
+
+		supply, err := s.GetCurrentSupply(subnetID)
+		if err == database.ErrNotFound {
+			report.addf("elastic subnet %s has no supply", subnetID)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if supply < rewards {
+			report.addf("subnet %s has a supply of %d but %d of potential rewards", subnetID, supply, rewards)
+		}
+		if supply > maxSupply {
+			report.addf("subnet %s has a supply of %d but a maximum supply of %d", subnetID, supply, maxSupply)
+		}
+	}
+	return nil
+}
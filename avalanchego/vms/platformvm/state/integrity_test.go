@@ -0,0 +1,74 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+func TestCheckIntegrity(t *testing.T) {
+	require := require.New(t)
+
+	s := newInitializedState(require).(*state)
+	require.NoError(s.initValidatorSets())
+	require.NoError(s.checkIntegrity())
+
+	// A delegator without a validator or a stored tx.
+	s.PutCurrentDelegator(&Staker{
+		TxID:     ids.GenerateTestID(),
+		NodeID:   ids.GenerateTestNodeID(),
+		SubnetID: constants.PrimaryNetworkID,
+		Weight:   1,
+		Priority: txs.PrimaryNetworkDelegatorCurrentPriority,
+	})
+	// A validator whose weight diverged from its staker.
+	require.NoError(s.validators.AddWeight(constants.PrimaryNetworkID, initialNodeID, 1))
+
+	err := s.checkIntegrity()
+	require.ErrorIs(err, errIntegrityCheckFailed)
+	require.ErrorContains(err, "found 5 inconsistencies")
+}
+
+func TestIntegrityReportLimit(t *testing.T) {
+	require := require.New(t)
+
+	report := &integrityReport{}
+	require.NoError(report.err())
+
+	for i := 0; i < maxIntegrityIssues+2; i++ {
+		report.add("issue")
+	}
+	require.Len(report.issues, maxIntegrityIssues)
+	require.ErrorContains(report.err(), "... and 2 more")
+}
+
+func TestCheckInvariants(t *testing.T) {
+	require := require.New(t)
+
+	s := newInitializedState(require).(*state)
+	require.NoError(s.initValidatorSets())
+	require.NoError(s.CheckInvariants())
+
+	startTime := s.GetTimestamp().Add(time.Hour)
+	s.PutPendingValidator(&Staker{
+		TxID:      ids.GenerateTestID(),
+		NodeID:    ids.GenerateTestNodeID(),
+		SubnetID:  constants.PrimaryNetworkID,
+		StartTime: startTime,
+		EndTime:   startTime.Add(-time.Second),
+		NextTime:  startTime,
+		Priority:  txs.PrimaryNetworkValidatorPendingPriority,
+	})
+
+	err := s.CheckInvariants()
+	require.ErrorIs(err, ErrInvariantViolated)
+	require.ErrorContains(err, "before it starts")
+}
@@ -0,0 +1,165 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/versiondb"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+var (
+	SchemaVersionKey     = []byte("schema version")
+	MigrationProgressKey = []byte("migration progress")
+
+	errUnsupportedSchemaVersion = errors.New("unsupported schema version")
+
+	// schemaMigrations are the migrations that upgrade the on-disk layout of
+	// the state. schemaMigrations[i] upgrades the database from schema
+	// version i to schema version i+1.
+	//
+	// Migrations must only ever be appended to this list.
+	schemaMigrations []Migration
+)
+
+// Migration upgrades the on-disk layout of the state by one schema version.
+type Migration struct {
+	Name string
+	// Migrate upgrades [db]. Long running migrations should periodically call
+	// [checkpoint], which atomically persists all the writes made to [db] so
+	// far along with [cursor]. If the node restarts before the migration
+	// completes, Migrate is called again with the last checkpointed cursor.
+	// The first attempt of a migration is passed a nil cursor.
+	Migrate func(db database.Database, cursor []byte, checkpoint func(cursor []byte) error) error
+}
+
+type migrationMetrics struct {
+	schemaVersion prometheus.Gauge
+	checkpoints   prometheus.Counter
+}
+
+func newMigrationMetrics(registerer prometheus.Registerer) (*migrationMetrics, error) {
+	m := &migrationMetrics{
+		schemaVersion: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "schema_version",
+			Help: "Schema version of the on-disk state",
+		}),
+		checkpoints: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "migration_checkpoints",
+			Help: "Number of checkpoints persisted by schema migrations",
+		}),
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(m.schemaVersion),
+		registerer.Register(m.checkpoints),
+	)
+	return m, errs.Err
+}
+
+// migrator applies schema migrations to an initialized database.
+type migrator struct {
+	log         logging.Logger
+	metrics     *migrationMetrics
+	baseDB      *versiondb.Database
+	singletonDB database.Database
+	migrations  []Migration
+}
+
+// markCurrent records that a newly initialized database is at the latest
+// schema version.
+func (m *migrator) markCurrent() error {
+	latest := uint64(len(m.migrations))
+	if err := database.PutUInt64(m.singletonDB, SchemaVersionKey, latest); err != nil {
+		return err
+	}
+	m.metrics.schemaVersion.Set(float64(latest))
+	return nil
+}
+
+// migrate upgrades the database to the latest schema version. Databases that
+// predate schema versioning are treated as being at version 0.
+func (m *migrator) migrate() error {
+	version, err := database.GetUInt64(m.singletonDB, SchemaVersionKey)
+	if err == database.ErrNotFound {
+		version = 0
+	} else if err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	latest := uint64(len(m.migrations))
+	if version > latest {
+		return fmt.Errorf("%w: database is at version %d but this node only supports up to version %d",
+			errUnsupportedSchemaVersion,
+			version,
+			latest,
+		)
+	}
+	m.metrics.schemaVersion.Set(float64(version))
+
+	for ; version < latest; version++ {
+		if err := m.apply(version); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// apply runs the migration from [version] to [version]+1 and records its
+// completion.
+func (m *migrator) apply(version uint64) error {
+	migration := m.migrations[version]
+	cursor, err := m.singletonDB.Get(MigrationProgressKey)
+	if err == database.ErrNotFound {
+		cursor = nil
+	} else if err != nil {
+		return fmt.Errorf("failed to read migration progress: %w", err)
+	}
+
+	m.log.Info("running state migration",
+		zap.String("name", migration.Name),
+		zap.Uint64("fromVersion", version),
+		zap.Bool("resumed", cursor != nil),
+	)
+
+	checkpoint := func(cursor []byte) error {
+		if err := m.singletonDB.Put(MigrationProgressKey, cursor); err != nil {
+			return err
+		}
+		if err := m.baseDB.Commit(); err != nil {
+			return err
+		}
+		m.metrics.checkpoints.Inc()
+		return nil
+	}
+	if err := migration.Migrate(m.baseDB, cursor, checkpoint); err != nil {
+		m.baseDB.Abort()
+		return fmt.Errorf("failed to migrate state to version %d with %q: %w", version+1, migration.Name, err)
+	}
+
+	if err := m.singletonDB.Delete(MigrationProgressKey); err != nil {
+		return err
+	}
+	if err := database.PutUInt64(m.singletonDB, SchemaVersionKey, version+1); err != nil {
+		return err
+	}
+	if err := m.baseDB.Commit(); err != nil {
+		return err
+	}
+	m.metrics.schemaVersion.Set(float64(version + 1))
+
+	m.log.Info("finished state migration",
+		zap.String("name", migration.Name),
+		zap.Uint64("version", version+1),
+	)
+	return nil
+}
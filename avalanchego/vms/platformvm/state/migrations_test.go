@@ -0,0 +1,123 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/database/versiondb"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func newTestMigrator(t *testing.T, db database.Database, migrations []Migration) *migrator {
+	metrics, err := newMigrationMetrics(prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	baseDB := versiondb.New(db)
+	return &migrator{
+		log:         logging.NoLog{},
+		metrics:     metrics,
+		baseDB:      baseDB,
+		singletonDB: prefixdb.New(SingletonPrefix, baseDB),
+		migrations:  migrations,
+	}
+}
+
+func TestMigratorResumesFromCheckpoint(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		db         = memdb.New()
+		errCrashed = errors.New("crashed")
+		keys       = [][]byte{{0}, {1}, {2}}
+		crash      = true
+		cursors    [][]byte
+	)
+	migrations := []Migration{
+		{
+			Name: "first",
+			Migrate: func(db database.Database, _ []byte, _ func([]byte) error) error {
+				return db.Put([]byte("first"), nil)
+			},
+		},
+		{
+			Name: "second",
+			Migrate: func(db database.Database, cursor []byte, checkpoint func([]byte) error) error {
+				cursors = append(cursors, cursor)
+				start := 0
+				if len(cursor) > 0 {
+					start = int(cursor[0]) + 1
+				}
+				for _, key := range keys[start:] {
+					if err := db.Put(key, nil); err != nil {
+						return err
+					}
+					if crash && key[0] == 1 {
+						return errCrashed
+					}
+					if err := checkpoint(key); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+	}
+
+	// The database predates schema versioning, so every migration is run.
+	m := newTestMigrator(t, db, migrations)
+	err := m.migrate()
+	require.ErrorIs(err, errCrashed)
+
+	version, err := database.GetUInt64(m.singletonDB, SchemaVersionKey)
+	require.NoError(err)
+	require.Equal(uint64(1), version)
+
+	// Only the writes made before the last checkpoint were persisted.
+	has, err := prefixdb.New(SingletonPrefix, db).Has(MigrationProgressKey)
+	require.NoError(err)
+	require.True(has)
+	has, err = db.Has(keys[1])
+	require.NoError(err)
+	require.False(has)
+
+	crash = false
+	m = newTestMigrator(t, db, migrations)
+	require.NoError(m.migrate())
+	require.Equal([][]byte{nil, keys[0]}, cursors)
+
+	for _, key := range append(keys, []byte("first")) {
+		has, err := db.Has(key)
+		require.NoError(err)
+		require.True(has)
+	}
+	version, err = database.GetUInt64(m.singletonDB, SchemaVersionKey)
+	require.NoError(err)
+	require.Equal(uint64(2), version)
+	has, err = m.singletonDB.Has(MigrationProgressKey)
+	require.NoError(err)
+	require.False(has)
+
+	// Migrating an up to date database is a noop.
+	require.NoError(m.migrate())
+	require.Len(cursors, 2)
+}
+
+func TestMigratorUnsupportedVersion(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	m := newTestMigrator(t, db, nil)
+	require.NoError(database.PutUInt64(m.singletonDB, SchemaVersionKey, 1))
+
+	err := m.migrate()
+	require.ErrorIs(err, errUnsupportedSchemaVersion)
+}
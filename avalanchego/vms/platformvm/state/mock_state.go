@@ -15,6 +15,7 @@ import (
 	sync "sync"
 	time "time"
 
+	atomic "github.com/ava-labs/avalanchego/chains/atomic"
 	database "github.com/ava-labs/avalanchego/database"
 	ids "github.com/ava-labs/avalanchego/ids"
 	validators "github.com/ava-labs/avalanchego/snow/validators"
@@ -257,6 +258,66 @@ func (mr *MockChainMockRecorder) GetDelegateeReward(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegateeReward", reflect.TypeOf((*MockChain)(nil).GetDelegateeReward), arg0, arg1)
 }
 
+// GetClaimableReward mocks base method.
+func (m *MockChain) GetClaimableReward(arg0, arg1 ids.ID) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClaimableReward", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClaimableReward indicates an expected call of GetClaimableReward.
+func (mr *MockChainMockRecorder) GetClaimableReward(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClaimableReward", reflect.TypeOf((*MockChain)(nil).GetClaimableReward), arg0, arg1)
+}
+
+// GetDelegatedStake mocks base method.
+func (m *MockChain) GetDelegatedStake(arg0, arg1 ids.ID) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelegatedStake", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelegatedStake indicates an expected call of GetDelegatedStake.
+func (mr *MockChainMockRecorder) GetDelegatedStake(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegatedStake", reflect.TypeOf((*MockChain)(nil).GetDelegatedStake), arg0, arg1)
+}
+
+// GetName mocks base method.
+func (m *MockChain) GetName(arg0 string) (*NameRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetName", arg0)
+	ret0, _ := ret[0].(*NameRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetName indicates an expected call of GetName.
+func (mr *MockChainMockRecorder) GetName(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetName", reflect.TypeOf((*MockChain)(nil).GetName), arg0)
+}
+
+// GetParameterChanges mocks base method.
+func (m *MockChain) GetParameterChanges(arg0 txs.Parameter) ([]ParameterChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetParameterChanges", arg0)
+	ret0, _ := ret[0].([]ParameterChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetParameterChanges indicates an expected call of GetParameterChanges.
+func (mr *MockChainMockRecorder) GetParameterChanges(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParameterChanges", reflect.TypeOf((*MockChain)(nil).GetParameterChanges), arg0)
+}
+
 // GetPendingDelegatorIterator mocks base method.
 func (m *MockChain) GetPendingDelegatorIterator(arg0 ids.ID, arg1 ids.NodeID) (StakerIterator, error) {
 	m.ctrl.T.Helper()
@@ -287,6 +348,21 @@ func (mr *MockChainMockRecorder) GetPendingStakerIterator() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingStakerIterator", reflect.TypeOf((*MockChain)(nil).GetPendingStakerIterator))
 }
 
+// GetPendingStakerIteratorUntil mocks base method.
+func (m *MockChain) GetPendingStakerIteratorUntil(arg0 time.Time) (StakerIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingStakerIteratorUntil", arg0)
+	ret0, _ := ret[0].(StakerIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingStakerIteratorUntil indicates an expected call of GetPendingStakerIteratorUntil.
+func (mr *MockChainMockRecorder) GetPendingStakerIteratorUntil(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingStakerIteratorUntil", reflect.TypeOf((*MockChain)(nil).GetPendingStakerIteratorUntil), arg0)
+}
+
 // GetPendingValidator mocks base method.
 func (m *MockChain) GetPendingValidator(arg0 ids.ID, arg1 ids.NodeID) (*Staker, error) {
 	m.ctrl.T.Helper()
@@ -302,6 +378,51 @@ func (mr *MockChainMockRecorder) GetPendingValidator(arg0, arg1 any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingValidator", reflect.TypeOf((*MockChain)(nil).GetPendingValidator), arg0, arg1)
 }
 
+// GetRewardAddress mocks base method.
+func (m *MockChain) GetRewardAddress(arg0 ids.ID) (ids.ShortID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRewardAddress", arg0)
+	ret0, _ := ret[0].(ids.ShortID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRewardAddress indicates an expected call of GetRewardAddress.
+func (mr *MockChainMockRecorder) GetRewardAddress(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRewardAddress", reflect.TypeOf((*MockChain)(nil).GetRewardAddress), arg0)
+}
+
+// GetStakerExit mocks base method.
+func (m *MockChain) GetStakerExit(arg0 ids.ID) (*StakerExit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStakerExit", arg0)
+	ret0, _ := ret[0].(*StakerExit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStakerExit indicates an expected call of GetStakerExit.
+func (mr *MockChainMockRecorder) GetStakerExit(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStakerExit", reflect.TypeOf((*MockChain)(nil).GetStakerExit), arg0)
+}
+
+// GetSubnetAllowListEntry mocks base method.
+func (m *MockChain) GetSubnetAllowListEntry(arg0 ids.ID, arg1 ids.NodeID) (ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetAllowListEntry", arg0, arg1)
+	ret0, _ := ret[0].(ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetAllowListEntry indicates an expected call of GetSubnetAllowListEntry.
+func (mr *MockChainMockRecorder) GetSubnetAllowListEntry(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetAllowListEntry", reflect.TypeOf((*MockChain)(nil).GetSubnetAllowListEntry), arg0, arg1)
+}
+
 // GetSubnetOwner mocks base method.
 func (m *MockChain) GetSubnetOwner(arg0 ids.ID) (fx.Owner, error) {
 	m.ctrl.T.Helper()
@@ -451,6 +572,90 @@ func (mr *MockChainMockRecorder) SetDelegateeReward(arg0, arg1, arg2 any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDelegateeReward", reflect.TypeOf((*MockChain)(nil).SetDelegateeReward), arg0, arg1, arg2)
 }
 
+// SetClaimableReward mocks base method.
+func (m *MockChain) SetClaimableReward(arg0, arg1 ids.ID, arg2 uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetClaimableReward", arg0, arg1, arg2)
+}
+
+// SetClaimableReward indicates an expected call of SetClaimableReward.
+func (mr *MockChainMockRecorder) SetClaimableReward(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetClaimableReward", reflect.TypeOf((*MockChain)(nil).SetClaimableReward), arg0, arg1, arg2)
+}
+
+// SetDelegatedStake mocks base method.
+func (m *MockChain) SetDelegatedStake(arg0, arg1 ids.ID, arg2 uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetDelegatedStake", arg0, arg1, arg2)
+}
+
+// SetDelegatedStake indicates an expected call of SetDelegatedStake.
+func (mr *MockChainMockRecorder) SetDelegatedStake(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDelegatedStake", reflect.TypeOf((*MockChain)(nil).SetDelegatedStake), arg0, arg1, arg2)
+}
+
+// SetName mocks base method.
+func (m *MockChain) SetName(arg0 string, arg1 *NameRecord) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetName", arg0, arg1)
+}
+
+// SetName indicates an expected call of SetName.
+func (mr *MockChainMockRecorder) SetName(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetName", reflect.TypeOf((*MockChain)(nil).SetName), arg0, arg1)
+}
+
+// SetParameterChanges mocks base method.
+func (m *MockChain) SetParameterChanges(arg0 txs.Parameter, arg1 []ParameterChange) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetParameterChanges", arg0, arg1)
+}
+
+// SetParameterChanges indicates an expected call of SetParameterChanges.
+func (mr *MockChainMockRecorder) SetParameterChanges(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetParameterChanges", reflect.TypeOf((*MockChain)(nil).SetParameterChanges), arg0, arg1)
+}
+
+// SetRewardAddress mocks base method.
+func (m *MockChain) SetRewardAddress(arg0 ids.ID, arg1 ids.ShortID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetRewardAddress", arg0, arg1)
+}
+
+// SetRewardAddress indicates an expected call of SetRewardAddress.
+func (mr *MockChainMockRecorder) SetRewardAddress(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRewardAddress", reflect.TypeOf((*MockChain)(nil).SetRewardAddress), arg0, arg1)
+}
+
+// SetStakerExit mocks base method.
+func (m *MockChain) SetStakerExit(arg0 ids.ID, arg1 *StakerExit) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetStakerExit", arg0, arg1)
+}
+
+// SetStakerExit indicates an expected call of SetStakerExit.
+func (mr *MockChainMockRecorder) SetStakerExit(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStakerExit", reflect.TypeOf((*MockChain)(nil).SetStakerExit), arg0, arg1)
+}
+
+// SetSubnetAllowListEntry mocks base method.
+func (m *MockChain) SetSubnetAllowListEntry(arg0 ids.ID, arg1 ids.NodeID, arg2 ids.ID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSubnetAllowListEntry", arg0, arg1, arg2)
+}
+
+// SetSubnetAllowListEntry indicates an expected call of SetSubnetAllowListEntry.
+func (mr *MockChainMockRecorder) SetSubnetAllowListEntry(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetAllowListEntry", reflect.TypeOf((*MockChain)(nil).SetSubnetAllowListEntry), arg0, arg1, arg2)
+}
+
 // SetSubnetOwner mocks base method.
 func (m *MockChain) SetSubnetOwner(arg0 ids.ID, arg1 fx.Owner) {
 	m.ctrl.T.Helper()
@@ -723,6 +928,66 @@ func (mr *MockDiffMockRecorder) GetDelegateeReward(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegateeReward", reflect.TypeOf((*MockDiff)(nil).GetDelegateeReward), arg0, arg1)
 }
 
+// GetClaimableReward mocks base method.
+func (m *MockDiff) GetClaimableReward(arg0, arg1 ids.ID) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClaimableReward", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClaimableReward indicates an expected call of GetClaimableReward.
+func (mr *MockDiffMockRecorder) GetClaimableReward(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClaimableReward", reflect.TypeOf((*MockDiff)(nil).GetClaimableReward), arg0, arg1)
+}
+
+// GetDelegatedStake mocks base method.
+func (m *MockDiff) GetDelegatedStake(arg0, arg1 ids.ID) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelegatedStake", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelegatedStake indicates an expected call of GetDelegatedStake.
+func (mr *MockDiffMockRecorder) GetDelegatedStake(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegatedStake", reflect.TypeOf((*MockDiff)(nil).GetDelegatedStake), arg0, arg1)
+}
+
+// GetName mocks base method.
+func (m *MockDiff) GetName(arg0 string) (*NameRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetName", arg0)
+	ret0, _ := ret[0].(*NameRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetName indicates an expected call of GetName.
+func (mr *MockDiffMockRecorder) GetName(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetName", reflect.TypeOf((*MockDiff)(nil).GetName), arg0)
+}
+
+// GetParameterChanges mocks base method.
+func (m *MockDiff) GetParameterChanges(arg0 txs.Parameter) ([]ParameterChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetParameterChanges", arg0)
+	ret0, _ := ret[0].([]ParameterChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetParameterChanges indicates an expected call of GetParameterChanges.
+func (mr *MockDiffMockRecorder) GetParameterChanges(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParameterChanges", reflect.TypeOf((*MockDiff)(nil).GetParameterChanges), arg0)
+}
+
 // GetPendingDelegatorIterator mocks base method.
 func (m *MockDiff) GetPendingDelegatorIterator(arg0 ids.ID, arg1 ids.NodeID) (StakerIterator, error) {
 	m.ctrl.T.Helper()
@@ -753,6 +1018,21 @@ func (mr *MockDiffMockRecorder) GetPendingStakerIterator() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingStakerIterator", reflect.TypeOf((*MockDiff)(nil).GetPendingStakerIterator))
 }
 
+// GetPendingStakerIteratorUntil mocks base method.
+func (m *MockDiff) GetPendingStakerIteratorUntil(arg0 time.Time) (StakerIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingStakerIteratorUntil", arg0)
+	ret0, _ := ret[0].(StakerIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingStakerIteratorUntil indicates an expected call of GetPendingStakerIteratorUntil.
+func (mr *MockDiffMockRecorder) GetPendingStakerIteratorUntil(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingStakerIteratorUntil", reflect.TypeOf((*MockDiff)(nil).GetPendingStakerIteratorUntil), arg0)
+}
+
 // GetPendingValidator mocks base method.
 func (m *MockDiff) GetPendingValidator(arg0 ids.ID, arg1 ids.NodeID) (*Staker, error) {
 	m.ctrl.T.Helper()
@@ -768,6 +1048,51 @@ func (mr *MockDiffMockRecorder) GetPendingValidator(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingValidator", reflect.TypeOf((*MockDiff)(nil).GetPendingValidator), arg0, arg1)
 }
 
+// GetRewardAddress mocks base method.
+func (m *MockDiff) GetRewardAddress(arg0 ids.ID) (ids.ShortID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRewardAddress", arg0)
+	ret0, _ := ret[0].(ids.ShortID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRewardAddress indicates an expected call of GetRewardAddress.
+func (mr *MockDiffMockRecorder) GetRewardAddress(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRewardAddress", reflect.TypeOf((*MockDiff)(nil).GetRewardAddress), arg0)
+}
+
+// GetStakerExit mocks base method.
+func (m *MockDiff) GetStakerExit(arg0 ids.ID) (*StakerExit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStakerExit", arg0)
+	ret0, _ := ret[0].(*StakerExit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStakerExit indicates an expected call of GetStakerExit.
+func (mr *MockDiffMockRecorder) GetStakerExit(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStakerExit", reflect.TypeOf((*MockDiff)(nil).GetStakerExit), arg0)
+}
+
+// GetSubnetAllowListEntry mocks base method.
+func (m *MockDiff) GetSubnetAllowListEntry(arg0 ids.ID, arg1 ids.NodeID) (ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetAllowListEntry", arg0, arg1)
+	ret0, _ := ret[0].(ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetAllowListEntry indicates an expected call of GetSubnetAllowListEntry.
+func (mr *MockDiffMockRecorder) GetSubnetAllowListEntry(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetAllowListEntry", reflect.TypeOf((*MockDiff)(nil).GetSubnetAllowListEntry), arg0, arg1)
+}
+
 // GetSubnetOwner mocks base method.
 func (m *MockDiff) GetSubnetOwner(arg0 ids.ID) (fx.Owner, error) {
 	m.ctrl.T.Helper()
@@ -917,6 +1242,90 @@ func (mr *MockDiffMockRecorder) SetDelegateeReward(arg0, arg1, arg2 any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDelegateeReward", reflect.TypeOf((*MockDiff)(nil).SetDelegateeReward), arg0, arg1, arg2)
 }
 
+// SetClaimableReward mocks base method.
+func (m *MockDiff) SetClaimableReward(arg0, arg1 ids.ID, arg2 uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetClaimableReward", arg0, arg1, arg2)
+}
+
+// SetClaimableReward indicates an expected call of SetClaimableReward.
+func (mr *MockDiffMockRecorder) SetClaimableReward(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetClaimableReward", reflect.TypeOf((*MockDiff)(nil).SetClaimableReward), arg0, arg1, arg2)
+}
+
+// SetDelegatedStake mocks base method.
+func (m *MockDiff) SetDelegatedStake(arg0, arg1 ids.ID, arg2 uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetDelegatedStake", arg0, arg1, arg2)
+}
+
+// SetDelegatedStake indicates an expected call of SetDelegatedStake.
+func (mr *MockDiffMockRecorder) SetDelegatedStake(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDelegatedStake", reflect.TypeOf((*MockDiff)(nil).SetDelegatedStake), arg0, arg1, arg2)
+}
+
+// SetName mocks base method.
+func (m *MockDiff) SetName(arg0 string, arg1 *NameRecord) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetName", arg0, arg1)
+}
+
+// SetName indicates an expected call of SetName.
+func (mr *MockDiffMockRecorder) SetName(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetName", reflect.TypeOf((*MockDiff)(nil).SetName), arg0, arg1)
+}
+
+// SetParameterChanges mocks base method.
+func (m *MockDiff) SetParameterChanges(arg0 txs.Parameter, arg1 []ParameterChange) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetParameterChanges", arg0, arg1)
+}
+
+// SetParameterChanges indicates an expected call of SetParameterChanges.
+func (mr *MockDiffMockRecorder) SetParameterChanges(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetParameterChanges", reflect.TypeOf((*MockDiff)(nil).SetParameterChanges), arg0, arg1)
+}
+
+// SetRewardAddress mocks base method.
+func (m *MockDiff) SetRewardAddress(arg0 ids.ID, arg1 ids.ShortID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetRewardAddress", arg0, arg1)
+}
+
+// SetRewardAddress indicates an expected call of SetRewardAddress.
+func (mr *MockDiffMockRecorder) SetRewardAddress(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRewardAddress", reflect.TypeOf((*MockDiff)(nil).SetRewardAddress), arg0, arg1)
+}
+
+// SetStakerExit mocks base method.
+func (m *MockDiff) SetStakerExit(arg0 ids.ID, arg1 *StakerExit) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetStakerExit", arg0, arg1)
+}
+
+// SetStakerExit indicates an expected call of SetStakerExit.
+func (mr *MockDiffMockRecorder) SetStakerExit(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStakerExit", reflect.TypeOf((*MockDiff)(nil).SetStakerExit), arg0, arg1)
+}
+
+// SetSubnetAllowListEntry mocks base method.
+func (m *MockDiff) SetSubnetAllowListEntry(arg0 ids.ID, arg1 ids.NodeID, arg2 ids.ID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSubnetAllowListEntry", arg0, arg1, arg2)
+}
+
+// SetSubnetAllowListEntry indicates an expected call of SetSubnetAllowListEntry.
+func (mr *MockDiffMockRecorder) SetSubnetAllowListEntry(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetAllowListEntry", reflect.TypeOf((*MockDiff)(nil).SetSubnetAllowListEntry), arg0, arg1, arg2)
+}
+
 // SetSubnetOwner mocks base method.
 func (m *MockDiff) SetSubnetOwner(arg0 ids.ID, arg1 fx.Owner) {
 	m.ctrl.T.Helper()
@@ -941,6 +1350,18 @@ func (mr *MockDiffMockRecorder) SetTimestamp(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTimestamp", reflect.TypeOf((*MockDiff)(nil).SetTimestamp), arg0)
 }
 
+// UpdateCurrentValidator mocks base method.
+func (m *MockDiff) UpdateCurrentValidator(arg0 *Staker) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateCurrentValidator", arg0)
+}
+
+// UpdateCurrentValidator indicates an expected call of UpdateCurrentValidator.
+func (mr *MockDiffMockRecorder) UpdateCurrentValidator(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCurrentValidator", reflect.TypeOf((*MockDiff)(nil).UpdateCurrentValidator), arg0)
+}
+
 // MockState is a mock of State interface.
 type MockState struct {
 	ctrl     *gomock.Controller
@@ -980,6 +1401,18 @@ func (m *MockState) GetNetworkID() uint32 {
 	return 0
 }
 
+// AddAtomicRequests mocks base method.
+func (m *MockState) AddAtomicRequests(arg0 ids.ID, arg1 map[ids.ID]*atomic.Requests) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddAtomicRequests", arg0, arg1)
+}
+
+// AddAtomicRequests indicates an expected call of AddAtomicRequests.
+func (mr *MockStateMockRecorder) AddAtomicRequests(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAtomicRequests", reflect.TypeOf((*MockState)(nil).AddAtomicRequests), arg0, arg1)
+}
+
 // AddChain mocks base method.
 func (m *MockState) AddChain(arg0 *txs.Tx) {
 	m.ctrl.T.Helper()
@@ -992,6 +1425,18 @@ func (mr *MockStateMockRecorder) AddChain(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddChain", reflect.TypeOf((*MockState)(nil).AddChain), arg0)
 }
 
+// AddRewardReceipt mocks base method.
+func (m *MockState) AddRewardReceipt(arg0 ids.NodeID, arg1 *RewardReceipt) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddRewardReceipt", arg0, arg1)
+}
+
+// AddRewardReceipt indicates an expected call of AddRewardReceipt.
+func (mr *MockStateMockRecorder) AddRewardReceipt(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRewardReceipt", reflect.TypeOf((*MockState)(nil).AddRewardReceipt), arg0, arg1)
+}
+
 // AddRewardUTXO mocks base method.
 func (m *MockState) AddRewardUTXO(arg0 ids.ID, arg1 *avax.UTXO) {
 	m.ctrl.T.Helper()
@@ -1092,6 +1537,20 @@ func (mr *MockStateMockRecorder) ApplyValidatorWeightDiffs(arg0, arg1, arg2, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyValidatorWeightDiffs", reflect.TypeOf((*MockState)(nil).ApplyValidatorWeightDiffs), arg0, arg1, arg2, arg3, arg4)
 }
 
+// CheckInvariants mocks base method.
+func (m *MockState) CheckInvariants() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckInvariants")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckInvariants indicates an expected call of CheckInvariants.
+func (mr *MockStateMockRecorder) CheckInvariants() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckInvariants", reflect.TypeOf((*MockState)(nil).CheckInvariants))
+}
+
 // Checksum mocks base method.
 func (m *MockState) Checksum() ids.ID {
 	m.ctrl.T.Helper()
@@ -1149,6 +1608,20 @@ func (mr *MockStateMockRecorder) CommitBatch() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitBatch", reflect.TypeOf((*MockState)(nil).CommitBatch))
 }
 
+// Compact mocks base method.
+func (m *MockState) Compact() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Compact")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Compact indicates an expected call of Compact.
+func (mr *MockStateMockRecorder) Compact() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compact", reflect.TypeOf((*MockState)(nil).Compact))
+}
+
 // DeleteCurrentDelegator mocks base method.
 func (m *MockState) DeleteCurrentDelegator(arg0 *Staker) {
 	m.ctrl.T.Helper()
@@ -1209,6 +1682,21 @@ func (mr *MockStateMockRecorder) DeleteUTXO(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUTXO", reflect.TypeOf((*MockState)(nil).DeleteUTXO), arg0)
 }
 
+// GetAtomicRequests mocks base method.
+func (m *MockState) GetAtomicRequests(arg0 ids.ID) (map[ids.ID]*atomic.Requests, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAtomicRequests", arg0)
+	ret0, _ := ret[0].(map[ids.ID]*atomic.Requests)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAtomicRequests indicates an expected call of GetAtomicRequests.
+func (mr *MockStateMockRecorder) GetAtomicRequests(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAtomicRequests", reflect.TypeOf((*MockState)(nil).GetAtomicRequests), arg0)
+}
+
 // GetBlockIDAtHeight mocks base method.
 func (m *MockState) GetBlockIDAtHeight(arg0 uint64) (ids.ID, error) {
 	m.ctrl.T.Helper()
@@ -1328,6 +1816,51 @@ func (mr *MockStateMockRecorder) GetLastAccepted() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastAccepted", reflect.TypeOf((*MockState)(nil).GetLastAccepted))
 }
 
+// GetClaimableReward mocks base method.
+func (m *MockState) GetClaimableReward(arg0, arg1 ids.ID) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClaimableReward", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClaimableReward indicates an expected call of GetClaimableReward.
+func (mr *MockStateMockRecorder) GetClaimableReward(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClaimableReward", reflect.TypeOf((*MockState)(nil).GetClaimableReward), arg0, arg1)
+}
+
+// GetDelegatedStake mocks base method.
+func (m *MockState) GetDelegatedStake(arg0, arg1 ids.ID) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelegatedStake", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelegatedStake indicates an expected call of GetDelegatedStake.
+func (mr *MockStateMockRecorder) GetDelegatedStake(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegatedStake", reflect.TypeOf((*MockState)(nil).GetDelegatedStake), arg0, arg1)
+}
+
+// GetName mocks base method.
+func (m *MockState) GetName(arg0 string) (*NameRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetName", arg0)
+	ret0, _ := ret[0].(*NameRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetName indicates an expected call of GetName.
+func (mr *MockStateMockRecorder) GetName(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetName", reflect.TypeOf((*MockState)(nil).GetName), arg0)
+}
+
 // GetNetworkID mocks base method.
 func (m *MockChain) GetNetworkID() uint32 {
 	m.ctrl.T.Helper()
@@ -1342,6 +1875,33 @@ func (mr *MockChainMockRecorder) GetNetworkID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkID", reflect.TypeOf((*MockChain)(nil).GetNetworkID))
 }
 
+// UpdateCurrentValidator mocks base method.
+func (m *MockChain) UpdateCurrentValidator(arg0 *Staker) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateCurrentValidator", arg0)
+}
+
+// UpdateCurrentValidator indicates an expected call of UpdateCurrentValidator.
+func (mr *MockChainMockRecorder) UpdateCurrentValidator(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCurrentValidator", reflect.TypeOf((*MockChain)(nil).UpdateCurrentValidator), arg0)
+}
+
+// GetParameterChanges mocks base method.
+func (m *MockState) GetParameterChanges(arg0 txs.Parameter) ([]ParameterChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetParameterChanges", arg0)
+	ret0, _ := ret[0].([]ParameterChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetParameterChanges indicates an expected call of GetParameterChanges.
+func (mr *MockStateMockRecorder) GetParameterChanges(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParameterChanges", reflect.TypeOf((*MockState)(nil).GetParameterChanges), arg0)
+}
+
 // GetPendingDelegatorIterator mocks base method.
 func (m *MockState) GetPendingDelegatorIterator(arg0 ids.ID, arg1 ids.NodeID) (StakerIterator, error) {
 	m.ctrl.T.Helper()
@@ -1372,6 +1932,21 @@ func (mr *MockStateMockRecorder) GetPendingStakerIterator() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingStakerIterator", reflect.TypeOf((*MockState)(nil).GetPendingStakerIterator))
 }
 
+// GetPendingStakerIteratorUntil mocks base method.
+func (m *MockState) GetPendingStakerIteratorUntil(arg0 time.Time) (StakerIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingStakerIteratorUntil", arg0)
+	ret0, _ := ret[0].(StakerIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingStakerIteratorUntil indicates an expected call of GetPendingStakerIteratorUntil.
+func (mr *MockStateMockRecorder) GetPendingStakerIteratorUntil(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingStakerIteratorUntil", reflect.TypeOf((*MockState)(nil).GetPendingStakerIteratorUntil), arg0)
+}
+
 // GetPendingValidator mocks base method.
 func (m *MockState) GetPendingValidator(arg0 ids.ID, arg1 ids.NodeID) (*Staker, error) {
 	m.ctrl.T.Helper()
@@ -1387,6 +1962,52 @@ func (mr *MockStateMockRecorder) GetPendingValidator(arg0, arg1 any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingValidator", reflect.TypeOf((*MockState)(nil).GetPendingValidator), arg0, arg1)
 }
 
+// GetRewardAddress mocks base method.
+func (m *MockState) GetRewardAddress(arg0 ids.ID) (ids.ShortID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRewardAddress", arg0)
+	ret0, _ := ret[0].(ids.ShortID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRewardAddress indicates an expected call of GetRewardAddress.
+func (mr *MockStateMockRecorder) GetRewardAddress(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRewardAddress", reflect.TypeOf((*MockState)(nil).GetRewardAddress), arg0)
+}
+
+// GetRewardReceipt mocks base method.
+func (m *MockState) GetRewardReceipt(arg0 ids.ID) (ids.NodeID, *RewardReceipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRewardReceipt", arg0)
+	ret0, _ := ret[0].(ids.NodeID)
+	ret1, _ := ret[1].(*RewardReceipt)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetRewardReceipt indicates an expected call of GetRewardReceipt.
+func (mr *MockStateMockRecorder) GetRewardReceipt(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRewardReceipt", reflect.TypeOf((*MockState)(nil).GetRewardReceipt), arg0)
+}
+
+// GetRewardReceipts mocks base method.
+func (m *MockState) GetRewardReceipts(arg0 ids.NodeID, arg1 uint64, arg2 uint64) ([]*RewardReceipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRewardReceipts", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*RewardReceipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRewardReceipts indicates an expected call of GetRewardReceipts.
+func (mr *MockStateMockRecorder) GetRewardReceipts(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRewardReceipts", reflect.TypeOf((*MockState)(nil).GetRewardReceipts), arg0, arg1, arg2)
+}
+
 // GetRewardUTXOs mocks base method.
 func (m *MockState) GetRewardUTXOs(arg0 ids.ID) ([]*avax.UTXO, error) {
 	m.ctrl.T.Helper()
@@ -1402,6 +2023,21 @@ func (mr *MockStateMockRecorder) GetRewardUTXOs(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRewardUTXOs", reflect.TypeOf((*MockState)(nil).GetRewardUTXOs), arg0)
 }
 
+// GetStakerExit mocks base method.
+func (m *MockState) GetStakerExit(arg0 ids.ID) (*StakerExit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStakerExit", arg0)
+	ret0, _ := ret[0].(*StakerExit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStakerExit indicates an expected call of GetStakerExit.
+func (mr *MockStateMockRecorder) GetStakerExit(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStakerExit", reflect.TypeOf((*MockState)(nil).GetStakerExit), arg0)
+}
+
 // GetStartTime mocks base method.
 func (m *MockState) GetStartTime(arg0 ids.NodeID, arg1 ids.ID) (time.Time, error) {
 	m.ctrl.T.Helper()
@@ -1432,6 +2068,21 @@ func (mr *MockStateMockRecorder) GetStatelessBlock(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatelessBlock", reflect.TypeOf((*MockState)(nil).GetStatelessBlock), arg0)
 }
 
+// GetSubnetAllowListEntry mocks base method.
+func (m *MockState) GetSubnetAllowListEntry(arg0 ids.ID, arg1 ids.NodeID) (ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetAllowListEntry", arg0, arg1)
+	ret0, _ := ret[0].(ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetAllowListEntry indicates an expected call of GetSubnetAllowListEntry.
+func (mr *MockStateMockRecorder) GetSubnetAllowListEntry(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetAllowListEntry", reflect.TypeOf((*MockState)(nil).GetSubnetAllowListEntry), arg0, arg1)
+}
+
 // GetSubnetOwner mocks base method.
 func (m *MockState) GetSubnetOwner(arg0 ids.ID) (fx.Owner, error) {
 	m.ctrl.T.Helper()
@@ -1507,6 +2158,36 @@ func (mr *MockStateMockRecorder) GetTx(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTx", reflect.TypeOf((*MockState)(nil).GetTx), arg0)
 }
 
+// GetTxBlockID mocks base method.
+func (m *MockState) GetTxBlockID(arg0 ids.ID) (ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTxBlockID", arg0)
+	ret0, _ := ret[0].(ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTxBlockID indicates an expected call of GetTxBlockID.
+func (mr *MockStateMockRecorder) GetTxBlockID(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTxBlockID", reflect.TypeOf((*MockState)(nil).GetTxBlockID), arg0)
+}
+
+// GetTxRoot mocks base method.
+func (m *MockState) GetTxRoot(arg0 ids.ID) (ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTxRoot", arg0)
+	ret0, _ := ret[0].(ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTxRoot indicates an expected call of GetTxRoot.
+func (mr *MockStateMockRecorder) GetTxRoot(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTxRoot", reflect.TypeOf((*MockState)(nil).GetTxRoot), arg0)
+}
+
 // GetUTXO mocks base method.
 func (m *MockState) GetUTXO(arg0 ids.ID) (*avax.UTXO, error) {
 	m.ctrl.T.Helper()
@@ -1538,6 +2219,66 @@ func (mr *MockStateMockRecorder) GetUptime(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUptime", reflect.TypeOf((*MockState)(nil).GetUptime), arg0, arg1)
 }
 
+// GetValidatorDiffs mocks base method.
+func (m *MockState) GetValidatorDiffs(arg0 ids.ID, arg1 uint64) (*ValidatorDiffs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidatorDiffs", arg0, arg1)
+	ret0, _ := ret[0].(*ValidatorDiffs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValidatorDiffs indicates an expected call of GetValidatorDiffs.
+func (mr *MockStateMockRecorder) GetValidatorDiffs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidatorDiffs", reflect.TypeOf((*MockState)(nil).GetValidatorDiffs), arg0, arg1)
+}
+
+// GetValidatorSetCheckpoint mocks base method.
+func (m *MockState) GetValidatorSetCheckpoint(arg0 ids.ID, arg1 uint64) (uint64, map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidatorSetCheckpoint", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(map[ids.NodeID]*validators.GetValidatorOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetValidatorSetCheckpoint indicates an expected call of GetValidatorSetCheckpoint.
+func (mr *MockStateMockRecorder) GetValidatorSetCheckpoint(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidatorSetCheckpoint", reflect.TypeOf((*MockState)(nil).GetValidatorSetCheckpoint), arg0, arg1)
+}
+
+// Hash mocks base method.
+func (m *MockState) Hash() (ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hash")
+	ret0, _ := ret[0].(ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hash indicates an expected call of Hash.
+func (mr *MockStateMockRecorder) Hash() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hash", reflect.TypeOf((*MockState)(nil).Hash))
+}
+
+// IndexValidatorSetCheckpoints mocks base method.
+func (m *MockState) IndexValidatorSetCheckpoints(arg0 context.Context, arg1 sync.Locker, arg2 logging.Logger) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IndexValidatorSetCheckpoints", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IndexValidatorSetCheckpoints indicates an expected call of IndexValidatorSetCheckpoints.
+func (mr *MockStateMockRecorder) IndexValidatorSetCheckpoints(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexValidatorSetCheckpoints", reflect.TypeOf((*MockState)(nil).IndexValidatorSetCheckpoints), arg0, arg1, arg2)
+}
+
 // PruneAndIndex mocks base method.
 func (m *MockState) PruneAndIndex(arg0 sync.Locker, arg1 logging.Logger) error {
 	m.ctrl.T.Helper()
@@ -1650,6 +2391,90 @@ func (mr *MockStateMockRecorder) SetLastAccepted(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLastAccepted", reflect.TypeOf((*MockState)(nil).SetLastAccepted), arg0)
 }
 
+// SetClaimableReward mocks base method.
+func (m *MockState) SetClaimableReward(arg0, arg1 ids.ID, arg2 uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetClaimableReward", arg0, arg1, arg2)
+}
+
+// SetClaimableReward indicates an expected call of SetClaimableReward.
+func (mr *MockStateMockRecorder) SetClaimableReward(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetClaimableReward", reflect.TypeOf((*MockState)(nil).SetClaimableReward), arg0, arg1, arg2)
+}
+
+// SetDelegatedStake mocks base method.
+func (m *MockState) SetDelegatedStake(arg0, arg1 ids.ID, arg2 uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetDelegatedStake", arg0, arg1, arg2)
+}
+
+// SetDelegatedStake indicates an expected call of SetDelegatedStake.
+func (mr *MockStateMockRecorder) SetDelegatedStake(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDelegatedStake", reflect.TypeOf((*MockState)(nil).SetDelegatedStake), arg0, arg1, arg2)
+}
+
+// SetName mocks base method.
+func (m *MockState) SetName(arg0 string, arg1 *NameRecord) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetName", arg0, arg1)
+}
+
+// SetName indicates an expected call of SetName.
+func (mr *MockStateMockRecorder) SetName(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetName", reflect.TypeOf((*MockState)(nil).SetName), arg0, arg1)
+}
+
+// SetParameterChanges mocks base method.
+func (m *MockState) SetParameterChanges(arg0 txs.Parameter, arg1 []ParameterChange) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetParameterChanges", arg0, arg1)
+}
+
+// SetParameterChanges indicates an expected call of SetParameterChanges.
+func (mr *MockStateMockRecorder) SetParameterChanges(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetParameterChanges", reflect.TypeOf((*MockState)(nil).SetParameterChanges), arg0, arg1)
+}
+
+// SetRewardAddress mocks base method.
+func (m *MockState) SetRewardAddress(arg0 ids.ID, arg1 ids.ShortID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetRewardAddress", arg0, arg1)
+}
+
+// SetRewardAddress indicates an expected call of SetRewardAddress.
+func (mr *MockStateMockRecorder) SetRewardAddress(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRewardAddress", reflect.TypeOf((*MockState)(nil).SetRewardAddress), arg0, arg1)
+}
+
+// SetStakerExit mocks base method.
+func (m *MockState) SetStakerExit(arg0 ids.ID, arg1 *StakerExit) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetStakerExit", arg0, arg1)
+}
+
+// SetStakerExit indicates an expected call of SetStakerExit.
+func (mr *MockStateMockRecorder) SetStakerExit(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStakerExit", reflect.TypeOf((*MockState)(nil).SetStakerExit), arg0, arg1)
+}
+
+// SetSubnetAllowListEntry mocks base method.
+func (m *MockState) SetSubnetAllowListEntry(arg0 ids.ID, arg1 ids.NodeID, arg2 ids.ID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSubnetAllowListEntry", arg0, arg1, arg2)
+}
+
+// SetSubnetAllowListEntry indicates an expected call of SetSubnetAllowListEntry.
+func (mr *MockStateMockRecorder) SetSubnetAllowListEntry(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetAllowListEntry", reflect.TypeOf((*MockState)(nil).SetSubnetAllowListEntry), arg0, arg1, arg2)
+}
+
 // SetSubnetOwner mocks base method.
 func (m *MockState) SetSubnetOwner(arg0 ids.ID, arg1 fx.Owner) {
 	m.ctrl.T.Helper()
@@ -1688,6 +2513,21 @@ func (mr *MockStateMockRecorder) SetUptime(arg0, arg1, arg2, arg3 any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUptime", reflect.TypeOf((*MockState)(nil).SetUptime), arg0, arg1, arg2, arg3)
 }
 
+// ShouldIndexValidatorSetCheckpoints mocks base method.
+func (m *MockState) ShouldIndexValidatorSetCheckpoints() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShouldIndexValidatorSetCheckpoints")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShouldIndexValidatorSetCheckpoints indicates an expected call of ShouldIndexValidatorSetCheckpoints.
+func (mr *MockStateMockRecorder) ShouldIndexValidatorSetCheckpoints() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShouldIndexValidatorSetCheckpoints", reflect.TypeOf((*MockState)(nil).ShouldIndexValidatorSetCheckpoints))
+}
+
 // ShouldPrune mocks base method.
 func (m *MockState) ShouldPrune() (bool, error) {
 	m.ctrl.T.Helper()
@@ -1718,6 +2558,32 @@ func (mr *MockStateMockRecorder) UTXOIDs(arg0, arg1, arg2 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UTXOIDs", reflect.TypeOf((*MockState)(nil).UTXOIDs), arg0, arg1, arg2)
 }
 
+// UpdateCurrentValidator mocks base method.
+func (m *MockState) UpdateCurrentValidator(arg0 *Staker) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateCurrentValidator", arg0)
+}
+
+// UpdateCurrentValidator indicates an expected call of UpdateCurrentValidator.
+func (mr *MockStateMockRecorder) UpdateCurrentValidator(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCurrentValidator", reflect.TypeOf((*MockState)(nil).UpdateCurrentValidator), arg0)
+}
+
+// Write mocks base method.
+func (m *MockState) Write() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Write")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Write indicates an expected call of Write.
+func (mr *MockStateMockRecorder) Write() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockState)(nil).Write))
+}
+
 // MockVersions is a mock of Versions interface.
 type MockVersions struct {
 	ctrl     *gomock.Controller
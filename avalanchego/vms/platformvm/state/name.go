@@ -0,0 +1,23 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import "github.com/ava-labs/avalanchego/ids"
+
+// NameRecord is the registration of a name. The owner of the name is the
+// owner set by the tx that last registered or updated it.
+type NameRecord struct {
+	// ID of the RegisterNameTx or UpdateNameTx that last modified the name
+	TxID ids.ID `v0:"true"`
+	// Node ID of the validator the name refers to
+	NodeID ids.NodeID `v0:"true"`
+	// Unix time the name expires at
+	Expiry uint64 `v0:"true"`
+}
+
+func parseNameRecord(bytes []byte) (*NameRecord, error) {
+	record := &NameRecord{}
+	_, err := MetadataCodec.Unmarshal(bytes, record)
+	return record, err
+}
@@ -0,0 +1,51 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"encoding/binary"
+
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+// ParameterChange is a value of a runtime parameter scheduled by governance.
+type ParameterChange struct {
+	// New value of the parameter
+	Value uint64 `v0:"true"`
+	// Unix time the value takes effect at
+	ActivationTime uint64 `v0:"true"`
+}
+
+type parameterChanges struct {
+	Changes []ParameterChange `v0:"true"`
+}
+
+// GetParameter returns the value of [parameter] at the current chain time of
+// [chain]. [defaultValue] is returned if no change of [parameter] has been
+// activated.
+func GetParameter(chain Chain, parameter txs.Parameter, defaultValue uint64) (uint64, error) {
+	changes, err := chain.GetParameterChanges(parameter)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		value       = defaultValue
+		currentTime = uint64(chain.GetTimestamp().Unix())
+	)
+	for _, change := range changes {
+		if change.ActivationTime > currentTime {
+			break
+		}
+		value = change.Value
+	}
+	return value, nil
+}
+
+func parameterKey(parameter txs.Parameter) []byte {
+	key := make([]byte, wrappers.IntLen)
+	binary.BigEndian.PutUint32(key, uint32(parameter))
+	return key
+}
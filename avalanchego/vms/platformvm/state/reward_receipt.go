@@ -0,0 +1,102 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const rewardReceiptKeyLen = ids.NodeIDLen + wrappers.LongLen + ids.IDLen
+
+const (
+	// RewardCauseUnknown is the cause of the receipts that were recorded
+	// before causes were.
+	RewardCauseUnknown RewardCause = iota
+	// RewardCauseUptimeSufficient means the staker's measured uptime met the
+	// requirement, so it was rewarded.
+	RewardCauseUptimeSufficient
+	// RewardCauseUptimeInsufficient means the staker's measured uptime was
+	// below the requirement, so its reward was aborted.
+	RewardCauseUptimeInsufficient
+	// RewardCauseUptimeUnavailable means this node couldn't measure the
+	// staker's uptime, in which case it prefers to reward the staker.
+	RewardCauseUptimeUnavailable
+	// RewardCauseNetworkDecision means the decision differs from the one this
+	// node preferred based on its measured uptime, so the uptimes measured by
+	// the other validators prevailed.
+	RewardCauseNetworkDecision
+)
+
+var _ fmt.Stringer = RewardCause(0)
+
+// RewardCause explains why a staker was, or wasn't, rewarded.
+type RewardCause byte
+
+func (c RewardCause) String() string {
+	switch c {
+	case RewardCauseUnknown:
+		return "unknown"
+	case RewardCauseUptimeSufficient:
+		return "uptimeSufficient"
+	case RewardCauseUptimeInsufficient:
+		return "uptimeInsufficient"
+	case RewardCauseUptimeUnavailable:
+		return "uptimeUnavailable"
+	case RewardCauseNetworkDecision:
+		return "networkDecision"
+	default:
+		return "invalid cause"
+	}
+}
+
+// RewardReceipt records the decision to reward, or not, a staker that reached
+// its end time.
+type RewardReceipt struct {
+	// ID of the tx that added the staker
+	StakerTxID ids.ID `v0:"true"`
+	// ID of the subnet the staker was staking on
+	SubnetID ids.ID `v0:"true"`
+	// Height of the block that accepted the decision
+	Height uint64 `v0:"true"`
+	// Unix time the staker was removed at
+	Timestamp uint64 `v0:"true"`
+	// True if the reward was minted, false if it was aborted
+	Rewarded bool `v0:"true"`
+	// Reward the staker would receive if it was rewarded
+	PotentialReward uint64 `v0:"true"`
+	// Amount that was minted to the staker, including the rewards of its
+	// delegators that were paid to a validator
+	Reward uint64 `v0:"true"`
+	// Uptime of the staker measured by this node when the decision was
+	// accepted, denominated in [reward.PercentDenominator]. Uptimes are
+	// measured locally, so they may differ between nodes.
+	Uptime uint64 `v0:"true"`
+	// Uptime the staker needed to be rewarded, denominated in
+	// [reward.PercentDenominator]
+	RequiredUptime uint64 `v1:"true"`
+	// Why the staker was, or wasn't, rewarded
+	Cause RewardCause `v1:"true"`
+}
+
+// parseRewardReceipt parses receipts of any codec version. Receipts written
+// with [CodecVersion0] have an unknown cause.
+func parseRewardReceipt(bytes []byte) (*RewardReceipt, error) {
+	receipt := &RewardReceipt{}
+	_, err := MetadataCodec.Unmarshal(bytes, receipt)
+	return receipt, err
+}
+
+// rewardReceiptKey orders the receipts of a node by the height they were
+// accepted at.
+func rewardReceiptKey(nodeID ids.NodeID, height uint64, stakerTxID ids.ID) []byte {
+	key := make([]byte, rewardReceiptKeyLen)
+	copy(key, nodeID[:])
+	binary.BigEndian.PutUint64(key[ids.NodeIDLen:], height)
+	copy(key[ids.NodeIDLen+wrappers.LongLen:], stakerTxID[:])
+	return key
+}
@@ -0,0 +1,66 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestParseRewardReceipt(t *testing.T) {
+	receipt := &RewardReceipt{
+		StakerTxID:      ids.GenerateTestID(),
+		SubnetID:        ids.GenerateTestID(),
+		Height:          12,
+		Timestamp:       34,
+		Rewarded:        true,
+		PotentialReward: 56,
+		Reward:          78,
+		Uptime:          900_000,
+		RequiredUptime:  800_000,
+		Cause:           RewardCauseUptimeSufficient,
+	}
+
+	tests := []struct {
+		name     string
+		version  uint16
+		expected *RewardReceipt
+	}{
+		{
+			name:    CodecVersion0Tag,
+			version: CodecVersion0,
+			expected: &RewardReceipt{
+				StakerTxID:      receipt.StakerTxID,
+				SubnetID:        receipt.SubnetID,
+				Height:          receipt.Height,
+				Timestamp:       receipt.Timestamp,
+				Rewarded:        receipt.Rewarded,
+				PotentialReward: receipt.PotentialReward,
+				Reward:          receipt.Reward,
+				Uptime:          receipt.Uptime,
+				Cause:           RewardCauseUnknown,
+			},
+		},
+		{
+			name:     CodecVersion1Tag,
+			version:  CodecVersion1,
+			expected: receipt,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			receiptBytes, err := MetadataCodec.Marshal(tt.version, receipt)
+			require.NoError(err)
+
+			parsedReceipt, err := parseRewardReceipt(receiptBytes)
+			require.NoError(err)
+			require.Equal(tt.expected, parsedReceipt)
+		})
+	}
+}
@@ -0,0 +1,24 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import "github.com/ava-labs/avalanchego/ids"
+
+// StakerExit is the receipt of a staker that was removed before its end time.
+type StakerExit struct {
+	// ID of the tx that removed the staker
+	TxID ids.ID `v0:"true"`
+	// Unix time the staker was removed at
+	Timestamp uint64 `v0:"true"`
+	// Amount of stake returned to the staker
+	Refunded uint64 `v0:"true"`
+	// Potential reward that the staker forfeited
+	ForfeitedReward uint64 `v0:"true"`
+}
+
+func parseStakerExit(bytes []byte) (*StakerExit, error) {
+	exit := &StakerExit{}
+	_, err := MetadataCodec.Unmarshal(bytes, exit)
+	return exit, err
+}
@@ -7,6 +7,9 @@ const (
 	unmodified diffValidatorStatus = iota
 	added
 	deleted
+	// modified means the validator remains in the staker set with a new
+	// weight.
+	modified
 )
 
 type diffValidatorStatus uint8
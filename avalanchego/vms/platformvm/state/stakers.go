@@ -4,6 +4,8 @@
 package state
 
 import (
+	"time"
+
 	"github.com/google/btree"
 
 	"github.com/ava-labs/avalanchego/database"
@@ -33,6 +35,14 @@ type CurrentStakers interface {
 	// Invariant: [staker] is currently a CurrentValidator
 	DeleteCurrentValidator(staker *Staker)
 
+	// UpdateCurrentValidator replaces the validator with the same TxID as
+	// [staker] in the staker set. Only the weight of the validator may be
+	// changed.
+	//
+	// Invariant: A validator with the same TxID as [staker] is currently a
+	// CurrentValidator
+	UpdateCurrentValidator(staker *Staker)
+
 	// SetDelegateeReward sets the accrued delegation rewards for [nodeID] on
 	// [subnetID] to [amount].
 	SetDelegateeReward(subnetID ids.ID, nodeID ids.NodeID, amount uint64) error
@@ -93,6 +103,11 @@ type PendingStakers interface {
 	// GetPendingStakerIterator returns stakers in order of their removal from
 	// the pending staker set.
 	GetPendingStakerIterator() (StakerIterator, error)
+
+	// GetPendingStakerIteratorUntil returns the stakers that start at or
+	// before [timestamp] in order of their removal from the pending staker
+	// set. Stakers that start after [timestamp] aren't visited.
+	GetPendingStakerIteratorUntil(timestamp time.Time) (StakerIterator, error)
 }
 
 type baseStakers struct {
@@ -142,12 +157,36 @@ func (v *baseStakers) PutValidator(staker *Staker) {
 	v.stakers.ReplaceOrInsert(staker)
 }
 
+func (v *baseStakers) UpdateValidator(staker *Staker) {
+	validator := v.getOrCreateValidator(staker.SubnetID, staker.NodeID)
+	prevWeight := validator.validator.Weight
+	validator.validator = staker
+
+	validatorDiff := v.getOrCreateValidatorDiff(staker.SubnetID, staker.NodeID)
+	if validatorDiff.validatorStatus == unmodified {
+		validatorDiff.validatorStatus = modified
+		validatorDiff.prevWeight = prevWeight
+	}
+	validatorDiff.validator = staker
+
+	// The weight doesn't affect the ordering of stakers, so this replaces the
+	// prior staker.
+	v.stakers.ReplaceOrInsert(staker)
+}
+
 func (v *baseStakers) DeleteValidator(staker *Staker) {
 	validator := v.getOrCreateValidator(staker.SubnetID, staker.NodeID)
 	validator.validator = nil
 	v.pruneValidator(staker.SubnetID, staker.NodeID)
 
 	validatorDiff := v.getOrCreateValidatorDiff(staker.SubnetID, staker.NodeID)
+	if validatorDiff.validatorStatus == modified {
+		// The weight that is removed from the validator set is the weight
+		// prior to the modification, which hasn't been written yet.
+		prevStaker := *staker
+		prevStaker.Weight = validatorDiff.prevWeight
+		staker = &prevStaker
+	}
 	validatorDiff.validatorStatus = deleted
 	validatorDiff.validator = staker
 
@@ -202,6 +241,10 @@ func (v *baseStakers) GetStakerIterator() StakerIterator {
 	return NewTreeIterator(v.stakers)
 }
 
+func (v *baseStakers) GetStakerIteratorUntil(timestamp time.Time) StakerIterator {
+	return NewTreeIteratorUntil(v.stakers, timestamp)
+}
+
 func (v *baseStakers) getOrCreateValidator(subnetID ids.ID, nodeID ids.NodeID) *baseStaker {
 	subnetValidators, ok := v.validators[subnetID]
 	if !ok {
@@ -263,6 +306,9 @@ type diffValidator struct {
 	// mean that diffValidator hasn't change, since delegators may have changed.
 	validatorStatus diffValidatorStatus
 	validator       *Staker
+	// prevWeight is the weight of the validator before it was modified. It is
+	// only set if validatorStatus is modified.
+	prevWeight uint64
 
 	addedDelegators   *btree.BTreeG[*Staker]
 	deletedDelegators map[ids.ID]*Staker
@@ -282,10 +328,12 @@ func (s *diffStakers) GetValidator(subnetID ids.ID, nodeID ids.NodeID) (*Staker,
 		return nil, unmodified
 	}
 
-	if validatorDiff.validatorStatus == added {
-		return validatorDiff.validator, added
+	switch validatorDiff.validatorStatus {
+	case added, modified:
+		return validatorDiff.validator, validatorDiff.validatorStatus
+	default:
+		return nil, validatorDiff.validatorStatus
 	}
-	return nil, validatorDiff.validatorStatus
 }
 
 func (s *diffStakers) PutValidator(staker *Staker) {
@@ -299,15 +347,41 @@ func (s *diffStakers) PutValidator(staker *Staker) {
 	s.addedStakers.ReplaceOrInsert(staker)
 }
 
+func (s *diffStakers) UpdateValidator(staker *Staker) {
+	validatorDiff := s.getOrCreateDiff(staker.SubnetID, staker.NodeID)
+	if validatorDiff.validatorStatus != added {
+		// The staker in the parent state is masked and replaced by the
+		// updated staker.
+		validatorDiff.validatorStatus = modified
+		if s.deletedStakers == nil {
+			s.deletedStakers = make(map[ids.ID]*Staker)
+		}
+		s.deletedStakers[staker.TxID] = staker
+	}
+	validatorDiff.validator = staker
+
+	if s.addedStakers == nil {
+		s.addedStakers = btree.NewG(defaultTreeDegree, (*Staker).Less)
+	}
+	s.addedStakers.ReplaceOrInsert(staker)
+}
+
 func (s *diffStakers) DeleteValidator(staker *Staker) {
 	validatorDiff := s.getOrCreateDiff(staker.SubnetID, staker.NodeID)
-	if validatorDiff.validatorStatus == added {
+	switch validatorDiff.validatorStatus {
+	case added:
 		// This validator was added and immediately removed in this diff. We
 		// treat it as if it was never added.
 		validatorDiff.validatorStatus = unmodified
 		s.addedStakers.Delete(validatorDiff.validator)
 		validatorDiff.validator = nil
-	} else {
+	case modified:
+		// The updated staker is dropped and the staker in the parent state
+		// remains masked.
+		validatorDiff.validatorStatus = deleted
+		s.addedStakers.Delete(validatorDiff.validator)
+		validatorDiff.validator = staker
+	default:
 		validatorDiff.validatorStatus = deleted
 		validatorDiff.validator = staker
 		if s.deletedStakers == nil {
@@ -369,12 +443,18 @@ func (s *diffStakers) DeleteDelegator(staker *Staker) {
 }
 
 func (s *diffStakers) GetStakerIterator(parentIterator StakerIterator) StakerIterator {
-	return NewMaskedIterator(
-		NewMergedIterator(
-			parentIterator,
-			NewTreeIterator(s.addedStakers),
-		),
-		s.deletedStakers,
+	// Only the parent stakers are masked so that updated stakers, which keep
+	// their TxID, are still reported.
+	return NewMergedIterator(
+		NewMaskedIterator(parentIterator, s.deletedStakers),
+		NewTreeIterator(s.addedStakers),
+	)
+}
+
+func (s *diffStakers) GetStakerIteratorUntil(parentIterator StakerIterator, timestamp time.Time) StakerIterator {
+	return NewMergedIterator(
+		NewMaskedIterator(parentIterator, s.deletedStakers),
+		NewTreeIteratorUntil(s.addedStakers, timestamp),
 	)
 }
 
@@ -195,6 +195,56 @@ func TestDiffStakersDeleteValidator(t *testing.T) {
 	require.Nil(returnedStaker)
 }
 
+func TestDiffStakersUpdateValidator(t *testing.T) {
+	require := require.New(t)
+	staker := newTestStaker()
+
+	parentStakers := newBaseStakers()
+	parentStakers.PutValidator(staker)
+
+	v := diffStakers{}
+
+	updatedStaker := *staker
+	updatedStaker.Weight = 10
+	v.UpdateValidator(&updatedStaker)
+
+	returnedStaker, status := v.GetValidator(staker.SubnetID, staker.NodeID)
+	require.Equal(modified, status)
+	require.Equal(&updatedStaker, returnedStaker)
+
+	// The updated staker replaces the staker of the parent.
+	stakerIterator := v.GetStakerIterator(parentStakers.GetStakerIterator())
+	assertIteratorsEqual(t, NewSliceIterator(&updatedStaker), stakerIterator)
+
+	v.DeleteValidator(&updatedStaker)
+
+	_, status = v.GetValidator(staker.SubnetID, staker.NodeID)
+	require.Equal(deleted, status)
+
+	stakerIterator = v.GetStakerIterator(parentStakers.GetStakerIterator())
+	assertIteratorsEqual(t, EmptyIterator, stakerIterator)
+}
+
+func TestDiffStakersUpdateAddedValidator(t *testing.T) {
+	require := require.New(t)
+	staker := newTestStaker()
+
+	v := diffStakers{}
+	v.PutValidator(staker)
+
+	updatedStaker := *staker
+	updatedStaker.Weight = 10
+	v.UpdateValidator(&updatedStaker)
+
+	// Validators added and updated in the same diff remain added.
+	returnedStaker, status := v.GetValidator(staker.SubnetID, staker.NodeID)
+	require.Equal(added, status)
+	require.Equal(&updatedStaker, returnedStaker)
+
+	stakerIterator := v.GetStakerIterator(EmptyIterator)
+	assertIteratorsEqual(t, NewSliceIterator(&updatedStaker), stakerIterator)
+}
+
 func TestDiffStakersDelegator(t *testing.T) {
 	staker := newTestStaker()
 	delegator := newTestStaker()
@@ -14,9 +14,11 @@ import (
 	"github.com/google/btree"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
+	"golang.org/x/exp/maps"
 
 	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/cache/metercacher"
+	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/linkeddb"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
@@ -31,17 +33,20 @@ import (
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/faults"
 	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
 	"github.com/ava-labs/avalanchego/vms/platformvm/genesis"
 	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/validatordiffs"
 
 	safemath "github.com/ava-labs/avalanchego/utils/math"
 )
@@ -58,6 +63,7 @@ var (
 
 	errValidatorSetAlreadyPopulated = errors.New("validator set already populated")
 	errIsNotSubnet                  = errors.New("is not a subnet")
+	errMalformedRewardReceiptKey    = errors.New("malformed reward receipt key")
 
 	BlockIDPrefix                       = []byte("blockID")
 	BlockPrefix                         = []byte("block")
@@ -70,13 +76,29 @@ var (
 	SubnetDelegatorPrefix               = []byte("subnetDelegator")
 	NestedValidatorWeightDiffsPrefix    = []byte("validatorDiffs")
 	NestedValidatorPublicKeyDiffsPrefix = []byte("publicKeyDiffs")
-	FlatValidatorWeightDiffsPrefix      = []byte("flatValidatorDiffs")
-	FlatValidatorPublicKeyDiffsPrefix   = []byte("flatPublicKeyDiffs")
+	FlatValidatorWeightDiffsPrefix      = validatordiffs.WeightDiffsPrefix
+	FlatValidatorPublicKeyDiffsPrefix   = validatordiffs.PublicKeyDiffsPrefix
+	ValidatorSetCheckpointsPrefix       = []byte("validatorSetCheckpoints")
 	TxPrefix                            = []byte("tx")
 	RewardUTXOsPrefix                   = []byte("rewardUTXOs")
 	UTXOPrefix                          = []byte("utxo")
 	SubnetPrefix                        = []byte("subnet")
 	SubnetOwnerPrefix                   = []byte("subnetOwner")
+	SubnetAllowListPrefix               = []byte("subnetAllowList")
+	StakerExitPrefix                    = []byte("stakerExit")
+	SubnetValidatorWeightPrefix         = []byte("subnetValidatorWeight")
+	StakerNodeIDPrefix                  = []byte("stakerNodeID")
+	StakerPublicKeyPrefix               = []byte("stakerPublicKey")
+	RewardReceiptPrefix                 = []byte("rewardReceipt")
+	RewardReceiptIndexPrefix            = []byte("rewardReceiptIndex")
+	TxRootPrefix                        = []byte("txRoot")
+	TxBlockPrefix                       = []byte("txBlock")
+	AtomicRequestsPrefix                = []byte("atomicRequests")
+	ParameterPrefix                     = []byte("parameter")
+	NamePrefix                          = []byte("name")
+	ClaimableRewardPrefix               = []byte("claimableReward")
+	DelegatedStakePrefix                = []byte("delegatedStake")
+	RewardAddressPrefix                 = []byte("rewardAddress")
 	TransformedSubnetPrefix             = []byte("transformedSubnet")
 	SupplyPrefix                        = []byte("supply")
 	ChainPrefix                         = []byte("chain")
@@ -88,6 +110,8 @@ var (
 	HeightsIndexedKey = []byte("heights indexed")
 	InitializedKey    = []byte("initialized")
 	PrunedKey         = []byte("pruned")
+
+	ValidatorSetCheckpointIntervalKey = []byte("validator set checkpoint interval")
 )
 
 // Chain collects all methods to manage the state of the chain for block
@@ -113,6 +137,52 @@ type Chain interface {
 	GetSubnetOwner(subnetID ids.ID) (fx.Owner, error)
 	SetSubnetOwner(subnetID ids.ID, owner fx.Owner)
 
+	// GetSubnetAllowListEntry returns the ID of the tx that added [nodeID] to
+	// the allow list of [subnetID]. Returns [database.ErrNotFound] if [nodeID]
+	// isn't on the allow list of [subnetID].
+	GetSubnetAllowListEntry(subnetID ids.ID, nodeID ids.NodeID) (ids.ID, error)
+	// SetSubnetAllowListEntry records that [nodeID] was added to the allow list
+	// of [subnetID] by [txID]. If [txID] is [ids.Empty], [nodeID] is removed
+	// from the allow list.
+	SetSubnetAllowListEntry(subnetID ids.ID, nodeID ids.NodeID, txID ids.ID)
+
+	// GetStakerExit returns the receipt of the staker added by [stakerTxID]
+	// if it was removed before its end time. If the staker wasn't removed
+	// early, [database.ErrNotFound] is returned.
+	GetStakerExit(stakerTxID ids.ID) (*StakerExit, error)
+	SetStakerExit(stakerTxID ids.ID, exit *StakerExit)
+
+	// GetParameterChanges returns the changes of [parameter] that have been
+	// scheduled by governance, ordered by activation time.
+	GetParameterChanges(parameter txs.Parameter) ([]ParameterChange, error)
+	SetParameterChanges(parameter txs.Parameter, changes []ParameterChange)
+
+	// GetName returns the registration of [name]. Expired registrations are
+	// returned until the name is registered again. If [name] was never
+	// registered, [database.ErrNotFound] is returned.
+	GetName(name string) (*NameRecord, error)
+	SetName(name string, record *NameRecord)
+
+	// GetClaimableReward returns the amount of [assetID] that was rewarded to
+	// the rewards owner [ownerID] and that it hasn't claimed yet.
+	GetClaimableReward(ownerID ids.ID, assetID ids.ID) (uint64, error)
+	SetClaimableReward(ownerID ids.ID, assetID ids.ID, amount uint64)
+
+	// GetDelegatedStake returns the stake that the current and pending
+	// delegators rewarded to [ownerID] delegate to the validator added by
+	// [validatorTxID]. It is only tracked for the validators added with an
+	// AddCappedPermissionlessValidatorTx, 0 is returned for other validators.
+	GetDelegatedStake(validatorTxID ids.ID, ownerID ids.ID) (uint64, error)
+	SetDelegatedStake(validatorTxID ids.ID, ownerID ids.ID, stake uint64)
+
+	// GetRewardAddress returns the C-chain address that the rewards of the
+	// rewards owner [ownerID] are exported to. [ids.ShortEmpty] is returned if
+	// [ownerID] isn't bound to an address.
+	GetRewardAddress(ownerID ids.ID) (ids.ShortID, error)
+	// SetRewardAddress binds [ownerID] to [addr]. [ids.ShortEmpty] removes the
+	// binding.
+	SetRewardAddress(ownerID ids.ID, addr ids.ShortID)
+
 	GetSubnetTransformation(subnetID ids.ID) (*txs.Tx, error)
 	AddSubnetTransformation(transformSubnetTx *txs.Tx)
 
@@ -137,7 +207,42 @@ type State interface {
 
 	GetBlockIDAtHeight(height uint64) (ids.ID, error)
 
+	// GetTxRoot returns the root of the Merkle tree of the txIDs of the
+	// accepted block [blkID]. Returns [database.ErrNotFound] if the block has
+	// no txs or was accepted before tx roots were indexed.
+	GetTxRoot(blkID ids.ID) (ids.ID, error)
+
+	// GetTxBlockID returns the ID of the accepted block that includes
+	// [txID]. Returns [database.ErrNotFound] if the tx isn't included in an
+	// accepted block, or was accepted before tx roots were indexed.
+	GetTxBlockID(txID ids.ID) (ids.ID, error)
+
+	// AddAtomicRequests records the shared memory requests that were applied
+	// when the block [blkID] was accepted.
+	AddAtomicRequests(blkID ids.ID, requests map[ids.ID]*atomic.Requests)
+
+	// GetAtomicRequests returns the shared memory requests that were applied
+	// when the block [blkID] was accepted. Returns [database.ErrNotFound] if
+	// the block made no requests or was accepted before requests were
+	// recorded.
+	GetAtomicRequests(blkID ids.ID) (map[ids.ID]*atomic.Requests, error)
+
 	GetRewardUTXOs(txID ids.ID) ([]*avax.UTXO, error)
+
+	// AddRewardReceipt records the decision to reward, or not, a staker of
+	// [nodeID].
+	AddRewardReceipt(nodeID ids.NodeID, receipt *RewardReceipt)
+
+	// GetRewardReceipts returns the receipts of the stakers of [nodeID] that
+	// were decided at heights in [fromHeight, toHeight], ordered by height.
+	GetRewardReceipts(nodeID ids.NodeID, fromHeight, toHeight uint64) ([]*RewardReceipt, error)
+
+	// GetRewardReceipt returns the receipt of the staker added by
+	// [stakerTxID], along with the nodeID it was recorded for. Returns
+	// [database.ErrNotFound] if the staker's reward wasn't decided, or was
+	// decided before receipts were indexed by staker.
+	GetRewardReceipt(stakerTxID ids.ID) (ids.NodeID, *RewardReceipt, error)
+
 	GetSubnets() ([]*txs.Tx, error)
 	GetChains(subnetID ids.ID) ([]*txs.Tx, error)
 
@@ -178,6 +283,33 @@ type State interface {
 		endHeight uint64,
 	) error
 
+	// GetValidatorDiffs returns the changes of the validator weights of
+	// [subnetID], and of the validator public keys if [subnetID] is the
+	// primary network, that were applied at [height].
+	//
+	// Note: Only the diffs recorded by the flat diff indices are returned, so
+	// heights accepted before those indices were populated have no diffs.
+	GetValidatorDiffs(subnetID ids.ID, height uint64) (*ValidatorDiffs, error)
+
+	// GetValidatorSetCheckpoint returns the validator set of [subnetID] at the
+	// lowest checkpointed height that is greater than or equal to [height],
+	// along with that height. If no such checkpoint exists,
+	// [database.ErrNotFound] is returned.
+	GetValidatorSetCheckpoint(
+		subnetID ids.ID,
+		height uint64,
+	) (uint64, map[ids.NodeID]*validators.GetValidatorOutput, error)
+
+	// Returns if validator set checkpoints should be generated for the heights
+	// that were accepted before the configured checkpoint interval was
+	// applied.
+	ShouldIndexValidatorSetCheckpoints() (bool, error)
+
+	// Generates validator set checkpoints for all previously accepted heights
+	// that are a multiple of the configured checkpoint interval. This function
+	// supports being (and is recommended to be) called asynchronously.
+	IndexValidatorSetCheckpoints(context.Context, sync.Locker, logging.Logger) error
+
 	SetHeight(height uint64)
 
 	// Discard uncommitted changes to the database.
@@ -195,6 +327,20 @@ type State interface {
 	// TODO: Remove after v1.11.x is activated
 	PruneAndIndex(sync.Locker, logging.Logger) error
 
+	// Compact the regions of the database that are rarely modified, such as
+	// the block index and the historical validator diffs. This function
+	// supports being called asynchronously.
+	Compact() error
+
+	// Write the pending changes into the uncommitted layer of the database,
+	// without committing them to the base database. The written changes are
+	// included in the next Commit or CommitBatch, and are discarded by Abort.
+	Write() error
+
+	// Hash returns a canonical hash of the committed state that is
+	// independent of how the blocks are stored.
+	Hash() (ids.ID, error)
+
 	// Commit changes to the base database.
 	Commit() error
 
@@ -204,6 +350,10 @@ type State interface {
 
 	Checksum() ids.ID
 
+	// CheckInvariants returns an error wrapping [ErrInvariantViolated] if the
+	// staker sets or the supplies are inconsistent.
+	CheckInvariants() error
+
 	Close() error
 }
 
@@ -253,14 +403,18 @@ type stateBlk struct {
  * | |     '-- nodeID -> compressed public key
  * | |-. flat weight diffs
  * | | '-- subnet+height+nodeID -> weightChange
- * | '-. flat pub key diffs
- * |   '-- subnet+height+nodeID -> uncompressed public key or nil
+ * | |-. flat pub key diffs
+ * | | '-- subnet+height+nodeID -> uncompressed public key or nil
+ * | '-. validator set checkpoints
+ * |   '-- subnet+height -> validator set
  * |-. blockIDs
  * | '-- height -> blockID
  * |-. blocks
  * | '-- blockID -> block bytes
  * |-. txs
  * | '-- txID -> tx bytes + tx status
+ * |-. atomicRequests
+ * | '-- blockID -> shared memory requests
  * |- rewardUTXOs
  * | '-. txID
  * |   '-. list
@@ -272,6 +426,28 @@ type stateBlk struct {
  * |   '-- txID -> nil
  * |-. subnetOwners
  * | '-. subnetID -> owner
+ * |-. subnetAllowList
+ * | '-- subnetID+nodeID -> txID
+ * |-. stakerExit
+ * | '-- stakerTxID -> staker exit receipt
+ * |-. subnetValidatorWeight
+ * | '-- stakerTxID -> weight
+ * |-. stakerNodeID
+ * | '-- stakerTxID -> nodeID
+ * |-. stakerPublicKey
+ * | '-- stakerTxID -> compressed BLS public key
+ * |-. rewardReceipt
+ * | '-- nodeID+height+stakerTxID -> reward receipt
+ * |-. parameter
+ * | '-- parameter -> scheduled parameter changes
+ * |-. name
+ * | '-- name -> name record
+ * |-. claimableReward
+ * | '-- ownerID+assetID -> amount
+ * |-. delegatedStake
+ * | '-- validatorTxID+ownerID -> stake
+ * |-. rewardAddress
+ * | '-- ownerID -> C-chain address
  * |-. chains
  * | '-. subnetID
  * |   '-. list
@@ -282,7 +458,8 @@ type stateBlk struct {
  *   |-- timestampKey -> timestamp
  *   |-- currentSupplyKey -> currentSupply
  *   |-- lastAcceptedKey -> lastAccepted
- *   '-- heightsIndexKey -> startIndexHeight + endIndexHeight
+ *   |-- heightsIndexKey -> startIndexHeight + endIndexHeight
+ *   '-- validatorSetCheckpointIntervalKey -> checkpoint interval
  */
 type state struct {
 	validatorState
@@ -293,7 +470,8 @@ type state struct {
 	metrics    metrics.Metrics
 	rewards    reward.Calculator
 
-	baseDB *versiondb.Database
+	baseDB   *versiondb.Database
+	migrator *migrator
 
 	currentStakers *baseStakers
 	pendingStakers *baseStakers
@@ -307,6 +485,18 @@ type state struct {
 	addedBlocks map[ids.ID]block.Block            // map of blockID -> Block
 	blockCache  cache.Cacher[ids.ID, block.Block] // cache of blockID -> Block. If the entry is nil, it is not in the database
 	blockDB     database.Database
+	// Optional database that old accepted blocks are moved to
+	coldBlocks *coldBlocks
+	// If true, blocks are compressed before being written to disk
+	compressBlocks bool
+	// blockID --> root of the Merkle tree of the txIDs of the block
+	txRootDB database.Database
+	// txID --> ID of the accepted block that includes the tx
+	txBlockDB database.Database
+
+	addedAtomicRequests map[ids.ID]map[ids.ID]*atomic.Requests // map of blockID -> requests
+	// blockID --> shared memory requests applied when the block was accepted
+	atomicRequestsDB database.Database
 
 	validatorsDB                 database.Database
 	currentValidatorsDB          database.Database
@@ -333,6 +523,14 @@ type state struct {
 	flatValidatorWeightDiffsDB      database.Database
 	flatValidatorPublicKeyDiffsDB   database.Database
 
+	// Full validator sets are written every [validatorSetCheckpointInterval]
+	// heights so that generating a historical validator set requires applying
+	// a bounded number of diffs. If the interval is 0, no new checkpoints are
+	// written.
+	validatorSetCheckpointInterval uint64
+	lastCheckpointHeight           uint64
+	validatorSetCheckpointsDB      database.Database
+
 	addedTxs map[ids.ID]*txAndStatus            // map of txID -> {*txs.Tx, Status}
 	txCache  cache.Cacher[ids.ID, *txAndStatus] // txID -> {*txs.Tx, Status}. If the entry is nil, it isn't in the database
 	txDB     database.Database
@@ -355,6 +553,54 @@ type state struct {
 	subnetOwnerCache cache.Cacher[ids.ID, fxOwnerAndSize] // cache of subnetID -> owner if the entry is nil, it is not in the database
 	subnetOwnerDB    database.Database
 
+	// Subnet ID --> Node ID --> true if the node was added to the allow list,
+	// false if it was removed
+	modifiedSubnetAllowList map[ids.ID]map[ids.NodeID]ids.ID // map of subnetID -> nodeID -> txID; ids.Empty means removed
+	subnetAllowListDB       database.Database
+
+	// Staker Tx ID --> receipt of the staker's early removal
+	addedStakerExits map[ids.ID]*StakerExit
+	stakerExitDB     database.Database
+
+	// Name --> registration of the name
+	modifiedNames map[string]*NameRecord
+	nameDB        database.Database
+
+	// Rewards owner ID + asset ID --> rewards that haven't been claimed
+	modifiedClaimableRewards map[claimableRewardKey]uint64
+	claimableRewardDB        database.Database
+
+	// Validator Tx ID + rewards owner ID --> stake delegated to a capped
+	// validator
+	modifiedDelegatedStakes map[delegatedStakeKey]uint64
+	delegatedStakeDB        database.Database
+
+	// Rewards owner ID --> C-chain address that its rewards are exported to
+	modifiedRewardAddresses map[ids.ID]ids.ShortID
+	rewardAddressDB         database.Database
+
+	// Staker Tx ID --> weight of a current subnet validator whose weight was
+	// changed after it was added
+	subnetValidatorWeightDB database.Database
+
+	// Staker Tx ID --> nodeID of a current staker that was re-keyed after it
+	// was added
+	stakerNodeIDDB database.Database
+
+	// Staker Tx ID --> BLS public key of a current validator that was re-keyed
+	// after it was added
+	stakerPublicKeyDB database.Database
+
+	// Node ID --> receipts of the node's stakers that were decided since the
+	// last commit
+	addedRewardReceipts map[ids.NodeID][]*RewardReceipt
+	rewardReceiptDB     database.Database
+	// Staker txID --> key of the staker's receipt in [rewardReceiptDB]
+	rewardReceiptIndexDB database.Database
+
+	modifiedParameterChanges map[txs.Parameter][]ParameterChange
+	parameterDB              database.Database
+
 	transformedSubnets     map[ids.ID]*txs.Tx            // map of subnetID -> transformSubnetTx
 	transformedSubnetCache cache.Cacher[ids.ID, *txs.Tx] // cache of subnetID -> transformSubnetTx if the entry is nil, it is not in the database
 	transformedSubnetDB    database.Database
@@ -479,6 +725,15 @@ func New(
 		return nil, err
 	}
 
+	if execCfg.IntegrityCheckEnabled {
+		if err := s.checkIntegrity(); err != nil {
+			// Drop any errors on close to return the first error
+			_ = s.Close()
+
+			return nil, err
+		}
+	}
+
 	// Before we start accepting new blocks, we check if the pruning process needs
 	// to be run.
 	//
@@ -530,7 +785,18 @@ func newState(
 		return nil, err
 	}
 
+	migrationMetrics, err := newMigrationMetrics(metricsReg)
+	if err != nil {
+		return nil, err
+	}
+
+	coldBlocks, err := openColdBlocks(execCfg, ctx, metricsReg)
+	if err != nil {
+		return nil, err
+	}
+
 	baseDB := versiondb.New(db)
+	singletonDB := prefixdb.New(SingletonPrefix, baseDB)
 
 	validatorsDB := prefixdb.New(ValidatorsPrefix, baseDB)
 
@@ -550,6 +816,7 @@ func newState(
 	nestedValidatorPublicKeyDiffsDB := prefixdb.New(NestedValidatorPublicKeyDiffsPrefix, validatorsDB)
 	flatValidatorWeightDiffsDB := prefixdb.New(FlatValidatorWeightDiffsPrefix, validatorsDB)
 	flatValidatorPublicKeyDiffsDB := prefixdb.New(FlatValidatorPublicKeyDiffsPrefix, validatorsDB)
+	validatorSetCheckpointsDB := prefixdb.New(ValidatorSetCheckpointsPrefix, validatorsDB)
 
 	txCache, err := metercacher.New(
 		"tx_cache",
@@ -635,14 +902,28 @@ func newState(
 		metrics:    metrics,
 		rewards:    rewards,
 		baseDB:     baseDB,
+		migrator: &migrator{
+			log:         ctx.Log,
+			metrics:     migrationMetrics,
+			baseDB:      baseDB,
+			singletonDB: singletonDB,
+			migrations:  schemaMigrations,
+		},
 
 		addedBlockIDs: make(map[uint64]ids.ID),
 		blockIDCache:  blockIDCache,
 		blockIDDB:     prefixdb.New(BlockIDPrefix, baseDB),
 
-		addedBlocks: make(map[ids.ID]block.Block),
-		blockCache:  blockCache,
-		blockDB:     prefixdb.New(BlockPrefix, baseDB),
+		addedBlocks:    make(map[ids.ID]block.Block),
+		blockCache:     blockCache,
+		blockDB:        prefixdb.New(BlockPrefix, baseDB),
+		coldBlocks:     coldBlocks,
+		compressBlocks: execCfg.BlockCompressionEnabled,
+		txRootDB:       prefixdb.New(TxRootPrefix, baseDB),
+		txBlockDB:      prefixdb.New(TxBlockPrefix, baseDB),
+
+		addedAtomicRequests: make(map[ids.ID]map[ids.ID]*atomic.Requests),
+		atomicRequestsDB:    prefixdb.New(AtomicRequestsPrefix, baseDB),
 
 		currentStakers: newBaseStakers(),
 		pendingStakers: newBaseStakers(),
@@ -671,6 +952,9 @@ func newState(
 		flatValidatorWeightDiffsDB:      flatValidatorWeightDiffsDB,
 		flatValidatorPublicKeyDiffsDB:   flatValidatorPublicKeyDiffsDB,
 
+		validatorSetCheckpointInterval: execCfg.ValidatorSetCheckpointInterval,
+		validatorSetCheckpointsDB:      validatorSetCheckpointsDB,
+
 		addedTxs: make(map[ids.ID]*txAndStatus),
 		txDB:     prefixdb.New(TxPrefix, baseDB),
 		txCache:  txCache,
@@ -690,6 +974,35 @@ func newState(
 		subnetOwnerDB:    subnetOwnerDB,
 		subnetOwnerCache: subnetOwnerCache,
 
+		modifiedSubnetAllowList: make(map[ids.ID]map[ids.NodeID]ids.ID),
+		subnetAllowListDB:       prefixdb.New(SubnetAllowListPrefix, baseDB),
+
+		addedStakerExits: make(map[ids.ID]*StakerExit),
+		stakerExitDB:     prefixdb.New(StakerExitPrefix, baseDB),
+
+		modifiedNames: make(map[string]*NameRecord),
+		nameDB:        prefixdb.New(NamePrefix, baseDB),
+
+		modifiedClaimableRewards: make(map[claimableRewardKey]uint64),
+		claimableRewardDB:        prefixdb.New(ClaimableRewardPrefix, baseDB),
+
+		modifiedDelegatedStakes: make(map[delegatedStakeKey]uint64),
+		delegatedStakeDB:        prefixdb.New(DelegatedStakePrefix, baseDB),
+
+		modifiedRewardAddresses: make(map[ids.ID]ids.ShortID),
+		rewardAddressDB:         prefixdb.New(RewardAddressPrefix, baseDB),
+
+		subnetValidatorWeightDB: prefixdb.New(SubnetValidatorWeightPrefix, baseDB),
+		stakerNodeIDDB:          prefixdb.New(StakerNodeIDPrefix, baseDB),
+		stakerPublicKeyDB:       prefixdb.New(StakerPublicKeyPrefix, baseDB),
+
+		addedRewardReceipts:  make(map[ids.NodeID][]*RewardReceipt),
+		rewardReceiptDB:      prefixdb.New(RewardReceiptPrefix, baseDB),
+		rewardReceiptIndexDB: prefixdb.New(RewardReceiptIndexPrefix, baseDB),
+
+		modifiedParameterChanges: make(map[txs.Parameter][]ParameterChange),
+		parameterDB:              prefixdb.New(ParameterPrefix, baseDB),
+
 		transformedSubnets:     make(map[ids.ID]*txs.Tx),
 		transformedSubnetCache: transformedSubnetCache,
 		transformedSubnetDB:    prefixdb.New(TransformedSubnetPrefix, baseDB),
@@ -703,7 +1016,7 @@ func newState(
 		chainCache:   chainCache,
 		chainDBCache: chainDBCache,
 
-		singletonDB: prefixdb.New(SingletonPrefix, baseDB),
+		singletonDB: singletonDB,
 	}, nil
 }
 
@@ -723,6 +1036,10 @@ func (s *state) DeleteCurrentValidator(staker *Staker) {
 	s.currentStakers.DeleteValidator(staker)
 }
 
+func (s *state) UpdateCurrentValidator(staker *Staker) {
+	s.currentStakers.UpdateValidator(staker)
+}
+
 func (s *state) GetCurrentDelegatorIterator(subnetID ids.ID, nodeID ids.NodeID) (StakerIterator, error) {
 	return s.currentStakers.GetDelegatorIterator(subnetID, nodeID), nil
 }
@@ -767,12 +1084,19 @@ func (s *state) GetPendingStakerIterator() (StakerIterator, error) {
 	return s.pendingStakers.GetStakerIterator(), nil
 }
 
+func (s *state) GetPendingStakerIteratorUntil(timestamp time.Time) (StakerIterator, error) {
+	return s.pendingStakers.GetStakerIteratorUntil(timestamp), nil
+}
+
 func (s *state) shouldInit() (bool, error) {
 	has, err := s.singletonDB.Has(InitializedKey)
 	return !has, err
 }
 
 func (s *state) doneInit() error {
+	if err := s.migrator.markCurrent(); err != nil {
+		return err
+	}
 	return s.singletonDB.Put(InitializedKey, nil)
 }
 
@@ -892,6 +1216,208 @@ func (s *state) SetSubnetOwner(subnetID ids.ID, owner fx.Owner) {
 	s.subnetOwners[subnetID] = owner
 }
 
+func (s *state) GetSubnetAllowListEntry(subnetID ids.ID, nodeID ids.NodeID) (ids.ID, error) {
+	if txID, exists := s.modifiedSubnetAllowList[subnetID][nodeID]; exists {
+		if txID == ids.Empty {
+			return ids.Empty, database.ErrNotFound
+		}
+		return txID, nil
+	}
+	return database.GetID(s.subnetAllowListDB, subnetAllowListKey(subnetID, nodeID))
+}
+
+func (s *state) SetSubnetAllowListEntry(subnetID ids.ID, nodeID ids.NodeID, txID ids.ID) {
+	allowList, ok := s.modifiedSubnetAllowList[subnetID]
+	if !ok {
+		allowList = make(map[ids.NodeID]ids.ID)
+		s.modifiedSubnetAllowList[subnetID] = allowList
+	}
+	allowList[nodeID] = txID
+}
+
+func (s *state) GetStakerExit(stakerTxID ids.ID) (*StakerExit, error) {
+	if exit, exists := s.addedStakerExits[stakerTxID]; exists {
+		return exit, nil
+	}
+	exitBytes, err := s.stakerExitDB.Get(stakerTxID[:])
+	if err != nil {
+		return nil, err
+	}
+	return parseStakerExit(exitBytes)
+}
+
+func (s *state) SetStakerExit(stakerTxID ids.ID, exit *StakerExit) {
+	s.addedStakerExits[stakerTxID] = exit
+}
+
+func (s *state) GetName(name string) (*NameRecord, error) {
+	if record, exists := s.modifiedNames[name]; exists {
+		return record, nil
+	}
+	recordBytes, err := s.nameDB.Get([]byte(name))
+	if err != nil {
+		return nil, err
+	}
+	return parseNameRecord(recordBytes)
+}
+
+func (s *state) SetName(name string, record *NameRecord) {
+	s.modifiedNames[name] = record
+}
+
+func (s *state) GetClaimableReward(ownerID ids.ID, assetID ids.ID) (uint64, error) {
+	key := claimableRewardKey{
+		ownerID: ownerID,
+		assetID: assetID,
+	}
+	if amount, exists := s.modifiedClaimableRewards[key]; exists {
+		return amount, nil
+	}
+	amount, err := database.GetUInt64(s.claimableRewardDB, key.Bytes())
+	if err == database.ErrNotFound {
+		return 0, nil
+	}
+	return amount, err
+}
+
+func (s *state) SetClaimableReward(ownerID ids.ID, assetID ids.ID, amount uint64) {
+	s.modifiedClaimableRewards[claimableRewardKey{
+		ownerID: ownerID,
+		assetID: assetID,
+	}] = amount
+}
+
+func (s *state) GetDelegatedStake(validatorTxID ids.ID, ownerID ids.ID) (uint64, error) {
+	key := delegatedStakeKey{
+		validatorTxID: validatorTxID,
+		ownerID:       ownerID,
+	}
+	if stake, exists := s.modifiedDelegatedStakes[key]; exists {
+		return stake, nil
+	}
+	stake, err := database.GetUInt64(s.delegatedStakeDB, key.Bytes())
+	if err == database.ErrNotFound {
+		return 0, nil
+	}
+	return stake, err
+}
+
+func (s *state) SetDelegatedStake(validatorTxID ids.ID, ownerID ids.ID, stake uint64) {
+	s.modifiedDelegatedStakes[delegatedStakeKey{
+		validatorTxID: validatorTxID,
+		ownerID:       ownerID,
+	}] = stake
+}
+
+func (s *state) GetRewardAddress(ownerID ids.ID) (ids.ShortID, error) {
+	if addr, exists := s.modifiedRewardAddresses[ownerID]; exists {
+		return addr, nil
+	}
+	addrBytes, err := s.rewardAddressDB.Get(ownerID[:])
+	if err == database.ErrNotFound {
+		return ids.ShortEmpty, nil
+	}
+	if err != nil {
+		return ids.ShortEmpty, err
+	}
+	return ids.ToShortID(addrBytes)
+}
+
+func (s *state) SetRewardAddress(ownerID ids.ID, addr ids.ShortID) {
+	s.modifiedRewardAddresses[ownerID] = addr
+}
+
+func (s *state) GetParameterChanges(parameter txs.Parameter) ([]ParameterChange, error) {
+	if changes, exists := s.modifiedParameterChanges[parameter]; exists {
+		return changes, nil
+	}
+	changesBytes, err := s.parameterDB.Get(parameterKey(parameter))
+	if err == database.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	changes := parameterChanges{}
+	if _, err := MetadataCodec.Unmarshal(changesBytes, &changes); err != nil {
+		return nil, err
+	}
+	return changes.Changes, nil
+}
+
+func (s *state) SetParameterChanges(parameter txs.Parameter, changes []ParameterChange) {
+	s.modifiedParameterChanges[parameter] = changes
+}
+
+func (s *state) AddRewardReceipt(nodeID ids.NodeID, receipt *RewardReceipt) {
+	s.addedRewardReceipts[nodeID] = append(s.addedRewardReceipts[nodeID], receipt)
+}
+
+func (s *state) GetRewardReceipts(nodeID ids.NodeID, fromHeight, toHeight uint64) ([]*RewardReceipt, error) {
+	var receipts []*RewardReceipt
+	if fromHeight > toHeight {
+		return receipts, nil
+	}
+
+	it := s.rewardReceiptDB.NewIteratorWithStartAndPrefix(
+		rewardReceiptKey(nodeID, fromHeight, ids.Empty),
+		nodeID[:],
+	)
+	defer it.Release()
+
+	for it.Next() {
+		receipt, err := parseRewardReceipt(it.Value())
+		if err != nil {
+			return nil, err
+		}
+		if receipt.Height > toHeight {
+			break
+		}
+		receipts = append(receipts, receipt)
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	// Receipts that haven't been written yet were accepted after all of the
+	// written receipts.
+	for _, receipt := range s.addedRewardReceipts[nodeID] {
+		if fromHeight <= receipt.Height && receipt.Height <= toHeight {
+			receipts = append(receipts, receipt)
+		}
+	}
+	return receipts, nil
+}
+
+func (s *state) GetRewardReceipt(stakerTxID ids.ID) (ids.NodeID, *RewardReceipt, error) {
+	for nodeID, receipts := range s.addedRewardReceipts {
+		for _, receipt := range receipts {
+			if receipt.StakerTxID == stakerTxID {
+				return nodeID, receipt, nil
+			}
+		}
+	}
+
+	key, err := s.rewardReceiptIndexDB.Get(stakerTxID[:])
+	if err != nil {
+		return ids.EmptyNodeID, nil, err
+	}
+	if len(key) != rewardReceiptKeyLen {
+		return ids.EmptyNodeID, nil, fmt.Errorf("%w: %d", errMalformedRewardReceiptKey, len(key))
+	}
+	receiptBytes, err := s.rewardReceiptDB.Get(key)
+	if err != nil {
+		return ids.EmptyNodeID, nil, err
+	}
+	receipt, err := parseRewardReceipt(receiptBytes)
+	if err != nil {
+		return ids.EmptyNodeID, nil, err
+	}
+	nodeID, err := ids.ToNodeID(key[:ids.NodeIDLen])
+	return nodeID, receipt, err
+}
+
 func (s *state) GetSubnetTransformation(subnetID ids.ID) (*txs.Tx, error) {
 	if tx, exists := s.transformedSubnets[subnetID]; exists {
 		return tx, nil
@@ -1144,55 +1670,23 @@ func (s *state) ApplyValidatorWeightDiffs(
 	endHeight uint64,
 	subnetID ids.ID,
 ) error {
-	diffIter := s.flatValidatorWeightDiffsDB.NewIteratorWithStartAndPrefix(
-		marshalStartDiffKey(subnetID, startHeight),
-		subnetID[:],
-	)
-	defer diffIter.Release()
-
 	prevHeight := startHeight + 1
 	// TODO: Remove the index continuity checks once we are guaranteed nodes can
 	// not rollback to not support the new indexing mechanism.
-	for diffIter.Next() && s.indexedHeights != nil && s.indexedHeights.LowerBound <= endHeight {
-		if err := ctx.Err(); err != nil {
-			return err
-		}
-
-		_, parsedHeight, nodeID, err := unmarshalDiffKey(diffIter.Key())
-		if err != nil {
-			return err
-		}
-
-		if parsedHeight > prevHeight {
-			s.ctx.Log.Error("unexpected parsed height",
-				zap.Stringer("subnetID", subnetID),
-				zap.Uint64("parsedHeight", parsedHeight),
-				zap.Stringer("nodeID", nodeID),
-				zap.Uint64("prevHeight", prevHeight),
-				zap.Uint64("startHeight", startHeight),
-				zap.Uint64("endHeight", endHeight),
-			)
-		}
-
-		// If the parsedHeight is less than our target endHeight, then we have
-		// fully processed the diffs from startHeight through endHeight.
-		if parsedHeight < endHeight {
-			return diffIter.Error()
-		}
-
-		prevHeight = parsedHeight
-
-		weightDiff, err := unmarshalWeightDiff(diffIter.Value())
+	if s.indexedHeights != nil && s.indexedHeights.LowerBound <= endHeight {
+		var err error
+		prevHeight, err = validatordiffs.ApplyWeightDiffs(
+			ctx,
+			s.ctx.Log,
+			s.flatValidatorWeightDiffsDB,
+			validators,
+			startHeight,
+			endHeight,
+			subnetID,
+		)
 		if err != nil {
 			return err
 		}
-
-		if err := applyWeightDiff(validators, nodeID, weightDiff); err != nil {
-			return err
-		}
-	}
-	if err := diffIter.Error(); err != nil {
-		return err
 	}
 
 	// TODO: Remove this once it is assumed that all subnet validators have
@@ -1228,7 +1722,7 @@ func (s *state) ApplyValidatorWeightDiffs(
 				return err
 			}
 
-			if err := applyWeightDiff(validators, nodeID, &weightDiff); err != nil {
+			if err := validatordiffs.ApplyWeightDiff(validators, nodeID, weightDiff.Decrease, weightDiff.Amount); err != nil {
 				return err
 			}
 		}
@@ -1237,90 +1731,66 @@ func (s *state) ApplyValidatorWeightDiffs(
 	return nil
 }
 
-func applyWeightDiff(
-	vdrs map[ids.NodeID]*validators.GetValidatorOutput,
-	nodeID ids.NodeID,
-	weightDiff *ValidatorWeightDiff,
-) error {
-	vdr, ok := vdrs[nodeID]
-	if !ok {
-		// This node isn't in the current validator set.
-		vdr = &validators.GetValidatorOutput{
-			NodeID: nodeID,
-		}
-		vdrs[nodeID] = vdr
-	}
-
-	// The weight of this node changed at this block.
-	var err error
-	if weightDiff.Decrease {
-		// The validator's weight was decreased at this block, so in the
-		// prior block it was higher.
-		vdr.Weight, err = safemath.Add64(vdr.Weight, weightDiff.Amount)
-	} else {
-		// The validator's weight was increased at this block, so in the
-		// prior block it was lower.
-		vdr.Weight, err = safemath.Sub(vdr.Weight, weightDiff.Amount)
-	}
-	if err != nil {
-		return err
-	}
-
-	if vdr.Weight == 0 {
-		// The validator's weight was 0 before this block so they weren't in the
-		// validator set.
-		delete(vdrs, nodeID)
-	}
-	return nil
-}
-
 func (s *state) ApplyValidatorPublicKeyDiffs(
 	ctx context.Context,
 	validators map[ids.NodeID]*validators.GetValidatorOutput,
 	startHeight uint64,
 	endHeight uint64,
 ) error {
-	diffIter := s.flatValidatorPublicKeyDiffsDB.NewIteratorWithStartAndPrefix(
-		marshalStartDiffKey(constants.PrimaryNetworkID, startHeight),
-		constants.PrimaryNetworkID[:],
+	// Note: this does not fallback to the linkeddb index because the linkeddb
+	// index does not contain entries for when to remove the public key.
+	//
+	// Nodes may see inconsistent public keys for heights before the new public
+	// key index was populated.
+	return validatordiffs.ApplyPublicKeyDiffs(
+		ctx,
+		s.flatValidatorPublicKeyDiffsDB,
+		validators,
+		startHeight,
+		endHeight,
 	)
-	defer diffIter.Release()
+}
 
-	for diffIter.Next() {
-		if err := ctx.Err(); err != nil {
-			return err
-		}
+func (s *state) GetValidatorSetCheckpoint(
+	subnetID ids.ID,
+	height uint64,
+) (uint64, map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	checkpointIter := s.validatorSetCheckpointsDB.NewIteratorWithStartAndPrefix(
+		marshalCheckpointKey(subnetID, height),
+		subnetID[:],
+	)
+	defer checkpointIter.Release()
 
-		_, parsedHeight, nodeID, err := unmarshalDiffKey(diffIter.Key())
-		if err != nil {
-			return err
-		}
-		// If the parsedHeight is less than our target endHeight, then we have
-		// fully processed the diffs from startHeight through endHeight.
-		if parsedHeight < endHeight {
-			break
+	if !checkpointIter.Next() {
+		if err := checkpointIter.Error(); err != nil {
+			return 0, nil, err
 		}
+		return 0, nil, database.ErrNotFound
+	}
 
-		vdr, ok := validators[nodeID]
-		if !ok {
-			continue
-		}
+	_, checkpointHeight, err := unmarshalCheckpointKey(checkpointIter.Key())
+	if err != nil {
+		return 0, nil, err
+	}
 
-		pkBytes := diffIter.Value()
-		if len(pkBytes) == 0 {
-			vdr.PublicKey = nil
-			continue
-		}
+	vdrs, err := unmarshalValidatorSetCheckpoint(checkpointIter.Value())
+	return checkpointHeight, vdrs, err
+}
 
-		vdr.PublicKey = bls.DeserializePublicKey(pkBytes)
+func (s *state) putValidatorSetCheckpoint(
+	subnetID ids.ID,
+	height uint64,
+	vdrs map[ids.NodeID]*validators.GetValidatorOutput,
+	primaryVdrs map[ids.NodeID]*validators.GetValidatorOutput,
+) error {
+	checkpointBytes, err := marshalValidatorSetCheckpoint(vdrs, primaryVdrs)
+	if err != nil {
+		return fmt.Errorf("failed to serialize validator set checkpoint: %w", err)
 	}
-
-	// Note: this does not fallback to the linkeddb index because the linkeddb
-	// index does not contain entries for when to remove the public key.
-	//
-	// Nodes may see inconsistent public keys for heights before the new public
-	// key index was populated.
-	return diffIter.Error()
+	return s.validatorSetCheckpointsDB.Put(
+		marshalCheckpointKey(subnetID, height),
+		checkpointBytes,
+	)
 }
 
 func (s *state) syncGenesis(genesisBlk block.Block, genesis *genesis.Genesis) error {
@@ -1406,6 +1876,7 @@ func (s *state) load() error {
 		s.loadCurrentValidators(),
 		s.loadPendingValidators(),
 		s.initValidatorSets(),
+		s.loadColdBlocks(),
 	)
 }
 
@@ -1505,6 +1976,9 @@ func (s *state) loadCurrentValidators() error {
 		if err != nil {
 			return err
 		}
+		if err := s.loadStakerNodeID(staker); err != nil {
+			return err
+		}
 
 		validator := s.currentStakers.getOrCreateValidator(staker.SubnetID, staker.NodeID)
 		validator.validator = staker
@@ -1556,6 +2030,10 @@ func (s *state) loadCurrentValidators() error {
 		if err != nil {
 			return err
 		}
+		if err := s.loadCurrentValidatorWeight(staker); err != nil {
+			return err
+		}
+
 		validator := s.currentStakers.getOrCreateValidator(staker.SubnetID, staker.NodeID)
 		validator.validator = staker
 
@@ -1611,6 +2089,9 @@ func (s *state) loadCurrentValidators() error {
 			if err != nil {
 				return err
 			}
+			if err := s.loadStakerNodeID(staker); err != nil {
+				return err
+			}
 
 			validator := s.currentStakers.getOrCreateValidator(staker.SubnetID, staker.NodeID)
 			if validator.delegators == nil {
@@ -1630,11 +2111,63 @@ func (s *state) loadCurrentValidators() error {
 	)
 }
 
-func (s *state) loadPendingValidators() error {
-	s.pendingStakers = newBaseStakers()
+// loadCurrentValidatorWeight replaces the weight of [staker], which is read
+// from the tx that added it, with the weight it was last set to, if any.
+func (s *state) loadCurrentValidatorWeight(staker *Staker) error {
+	weight, err := database.GetUInt64(s.subnetValidatorWeightDB, staker.TxID[:])
+	if err == database.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed loading weight of validator txID %s: %w", staker.TxID, err)
+	}
+	staker.Weight = weight
+	return nil
+}
 
-	validatorIt := s.pendingValidatorList.NewIterator()
-	defer validatorIt.Release()
+// loadStakerNodeID replaces the nodeID and the BLS public key of [staker],
+// which are read from the tx that added it, with the ones it was re-keyed to,
+// if any.
+func (s *state) loadStakerNodeID(staker *Staker) error {
+	nodeID, rekeyed, err := s.getStakerNodeID(staker.TxID)
+	if err != nil {
+		return err
+	}
+	if !rekeyed {
+		return nil
+	}
+	staker.NodeID = nodeID
+
+	pkBytes, err := s.stakerPublicKeyDB.Get(staker.TxID[:])
+	if err == database.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed loading public key of staker txID %s: %w", staker.TxID, err)
+	}
+	staker.PublicKey, err = bls.PublicKeyFromBytes(pkBytes)
+	return err
+}
+
+// getStakerNodeID returns the nodeID the staker added by [stakerTxID] was
+// re-keyed to. False is returned if the staker was never re-keyed.
+func (s *state) getStakerNodeID(stakerTxID ids.ID) (ids.NodeID, bool, error) {
+	nodeIDBytes, err := s.stakerNodeIDDB.Get(stakerTxID[:])
+	if err == database.ErrNotFound {
+		return ids.EmptyNodeID, false, nil
+	}
+	if err != nil {
+		return ids.EmptyNodeID, false, fmt.Errorf("failed loading nodeID of staker txID %s: %w", stakerTxID, err)
+	}
+	nodeID, err := ids.ToNodeID(nodeIDBytes)
+	return nodeID, true, err
+}
+
+func (s *state) loadPendingValidators() error {
+	s.pendingStakers = newBaseStakers()
+
+	validatorIt := s.pendingValidatorList.NewIterator()
+	defer validatorIt.Release()
 
 	subnetValidatorIt := s.pendingSubnetValidatorList.NewIterator()
 	defer subnetValidatorIt.Release()
@@ -1739,6 +2272,7 @@ func (s *state) initValidatorSets() error {
 			}
 			delegatorIterator.Release()
 		}
+		s.metrics.AddValidators(subnetID, len(validators))
 	}
 
 	s.metrics.SetLocalStake(s.validators.GetWeight(constants.PrimaryNetworkID, s.ctx.NodeID))
@@ -1756,16 +2290,38 @@ func (s *state) write(updateValidators bool, height uint64) error {
 		codecVersion = CodecVersion0
 	}
 
-	return utils.Err(
+	err := utils.Err(
 		s.writeBlocks(),
+		s.writeColdBlocks(height), // Must be called after writeBlocks
 		s.writeCurrentStakers(updateValidators, height, codecVersion),
 		s.writePendingStakers(),
 		s.WriteValidatorMetadata(s.currentValidatorList, s.currentSubnetValidatorList, codecVersion), // Must be called after writeCurrentStakers
+		s.writeValidatorSetCheckpoints(updateValidators, height),                                     // Must be called after writeCurrentStakers
 		s.writeTXs(),
+		s.writeAtomicRequests(),
 		s.writeRewardUTXOs(),
 		s.writeUTXOs(),
+	)
+	if err != nil {
+		return err
+	}
+	// Tests can fail the write here, once only part of the changes were
+	// written.
+	if err := faults.Inject(faults.PartialStateWrite); err != nil {
+		return err
+	}
+
+	return utils.Err(
 		s.writeSubnets(),
 		s.writeSubnetOwners(),
+		s.writeSubnetAllowList(),
+		s.writeStakerExits(),
+		s.writeNames(),
+		s.writeClaimableRewards(),
+		s.writeDelegatedStakes(),
+		s.writeRewardAddresses(),
+		s.writeRewardReceipts(),
+		s.writeParameterChanges(),
 		s.writeTransformedSubnets(),
 		s.writeSubnetSupplies(),
 		s.writeChains(),
@@ -1796,6 +2352,7 @@ func (s *state) Close() error {
 		s.singletonDB.Close(),
 		s.blockDB.Close(),
 		s.blockIDDB.Close(),
+		s.closeColdBlocks(),
 	)
 }
 
@@ -1809,7 +2366,8 @@ func (s *state) sync(genesis []byte) error {
 	}
 
 	// If the database is empty, create the platform chain anew using the
-	// provided genesis state
+	// provided genesis state. Otherwise, upgrade the database to the latest
+	// schema version before loading it.
 	if shouldInit {
 		if err := s.init(genesis); err != nil {
 			return fmt.Errorf(
@@ -1817,6 +2375,11 @@ func (s *state) sync(genesis []byte) error {
 				err,
 			)
 		}
+	} else if err := s.migrator.migrate(); err != nil {
+		return fmt.Errorf(
+			"failed to migrate the database: %w",
+			err,
+		)
 	}
 
 	if err := s.load(); err != nil {
@@ -1890,10 +2453,14 @@ func (s *state) Checksum() ids.ID {
 	return s.utxoState.Checksum()
 }
 
-func (s *state) CommitBatch() (database.Batch, error) {
+func (s *state) Write() error {
 	// updateValidators is set to true here so that the validator manager is
 	// kept up to date with the last accepted state.
-	if err := s.write(true /*=updateValidators*/, s.currentHeight); err != nil {
+	return s.write(true /*=updateValidators*/, s.currentHeight)
+}
+
+func (s *state) CommitBatch() (database.Batch, error) {
+	if err := s.Write(); err != nil {
 		return nil, err
 	}
 	return s.baseDB.CommitBatch()
@@ -1917,9 +2484,90 @@ func (s *state) writeBlocks() error {
 		// referencing additional data (because of shared byte slices) that
 		// would not be properly accounted for in the cache sizing.
 		s.blockCache.Evict(blkID)
+		blkBytes, err := s.blockBytesToStore(blkBytes)
+		if err != nil {
+			return fmt.Errorf("failed to compress block %s: %w", blkID, err)
+		}
 		if err := s.blockDB.Put(blkID[:], blkBytes); err != nil {
 			return fmt.Errorf("failed to write block %s: %w", blkID, err)
 		}
+		if err := s.writeTxRoot(blkID, blk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeTxRoot indexes the root of the Merkle tree of the txIDs of [blk] and
+// the block that includes each of them.
+func (s *state) writeTxRoot(blkID ids.ID, blk block.Block) error {
+	txIDs := block.TxIDs(blk)
+	if len(txIDs) == 0 {
+		return nil
+	}
+	if err := database.PutID(s.txRootDB, blkID[:], block.TxRoot(txIDs)); err != nil {
+		return fmt.Errorf("failed to write tx root of block %s: %w", blkID, err)
+	}
+	for _, txID := range txIDs {
+		if err := database.PutID(s.txBlockDB, txID[:], blkID); err != nil {
+			return fmt.Errorf("failed to index block of tx %s: %w", txID, err)
+		}
+	}
+	return nil
+}
+
+func (s *state) GetTxRoot(blkID ids.ID) (ids.ID, error) {
+	if blk, exists := s.addedBlocks[blkID]; exists {
+		txIDs := block.TxIDs(blk)
+		if len(txIDs) == 0 {
+			return ids.Empty, database.ErrNotFound
+		}
+		return block.TxRoot(txIDs), nil
+	}
+	return database.GetID(s.txRootDB, blkID[:])
+}
+
+func (s *state) GetTxBlockID(txID ids.ID) (ids.ID, error) {
+	for blkID, blk := range s.addedBlocks {
+		for _, tx := range blk.Txs() {
+			if tx.ID() == txID {
+				return blkID, nil
+			}
+		}
+	}
+	return database.GetID(s.txBlockDB, txID[:])
+}
+
+func (s *state) AddAtomicRequests(blkID ids.ID, requests map[ids.ID]*atomic.Requests) {
+	s.addedAtomicRequests[blkID] = requests
+}
+
+func (s *state) GetAtomicRequests(blkID ids.ID) (map[ids.ID]*atomic.Requests, error) {
+	if requests, exists := s.addedAtomicRequests[blkID]; exists {
+		return requests, nil
+	}
+	requestsBytes, err := s.atomicRequestsDB.Get(blkID[:])
+	if err != nil {
+		return nil, err
+	}
+	requests := make(map[ids.ID]*atomic.Requests)
+	if _, err := atomic.Codec.Unmarshal(requestsBytes, &requests); err != nil {
+		return nil, fmt.Errorf("failed to parse atomic requests of block %s: %w", blkID, err)
+	}
+	return requests, nil
+}
+
+func (s *state) writeAtomicRequests() error {
+	for blkID, requests := range s.addedAtomicRequests {
+		delete(s.addedAtomicRequests, blkID)
+
+		requestsBytes, err := atomic.Codec.Marshal(atomic.CodecVersion, requests)
+		if err != nil {
+			return fmt.Errorf("failed to serialize atomic requests of block %s: %w", blkID, err)
+		}
+		if err := s.atomicRequestsDB.Put(blkID[:], requestsBytes); err != nil {
+			return fmt.Errorf("failed to write atomic requests of block %s: %w", blkID, err)
+		}
 	}
 	return nil
 }
@@ -1937,6 +2585,9 @@ func (s *state) GetStatelessBlock(blockID ids.ID) (block.Block, error) {
 	}
 
 	blkBytes, err := s.blockDB.Get(blockID[:])
+	if err == database.ErrNotFound {
+		blkBytes, err = s.getColdBlock(blockID)
+	}
 	if err == database.ErrNotFound {
 		s.blockCache.Put(blockID, nil)
 		return nil, database.ErrNotFound
@@ -1991,6 +2642,14 @@ func (s *state) writeCurrentStakers(updateValidators bool, height uint64, codecV
 	rawNestedPublicKeyDiffDB := prefixdb.New(heightBytes, s.nestedValidatorPublicKeyDiffsDB)
 	nestedPKDiffDB := linkeddb.NewDefault(rawNestedPublicKeyDiffDB)
 
+	// A re-keyed staker is removed from its prior nodeID and added to its new
+	// nodeID with the same TxID. [removedStakers] allows the addition to be
+	// recognized, and [removedMetadata] carries the uptime and accrued
+	// delegatee rewards of a re-keyed validator over to its new nodeID.
+	var (
+		removedStakers  = set.Set[ids.ID]{}
+		removedMetadata = make(map[ids.ID]*validatorMetadata)
+	)
 	for subnetID, validatorDiffs := range s.currentStakers.validatorDiffs {
 		delete(s.currentStakers.validatorDiffs, subnetID)
 
@@ -2013,16 +2672,17 @@ func (s *state) writeCurrentStakers(updateValidators bool, height uint64, codecV
 		rawNestedWeightDiffDB := prefixdb.New(prefixBytes, s.nestedValidatorWeightDiffsDB)
 		nestedWeightDiffDB := linkeddb.NewDefault(rawNestedWeightDiffDB)
 
-		// Record the change in weight and/or public key for each validator.
-		for nodeID, validatorDiff := range validatorDiffs {
-			// Copy [nodeID] so it doesn't get overwritten next iteration.
-			nodeID := nodeID
+		var numAdded, numRemoved int
 
+		// Record the change in weight and/or public key for each validator.
+		for _, nodeID := range removalsFirst(validatorDiffs) {
+			validatorDiff := validatorDiffs[nodeID]
 			weightDiff := &ValidatorWeightDiff{
 				Decrease: validatorDiff.validatorStatus == deleted,
 			}
 			switch validatorDiff.validatorStatus {
 			case added:
+				numAdded++
 				staker := validatorDiff.validator
 				weightDiff.Amount = staker.Weight
 
@@ -2033,7 +2693,7 @@ func (s *state) writeCurrentStakers(updateValidators bool, height uint64, codecV
 					// added. This means the prior value for the public key was
 					// nil.
 					err := s.flatValidatorPublicKeyDiffsDB.Put(
-						marshalDiffKey(constants.PrimaryNetworkID, height, nodeID),
+						validatordiffs.MarshalKey(constants.PrimaryNetworkID, height, nodeID),
 						nil,
 					)
 					if err != nil {
@@ -2056,6 +2716,12 @@ func (s *state) writeCurrentStakers(updateValidators bool, height uint64, codecV
 					PotentialReward:          staker.PotentialReward,
 					PotentialDelegateeReward: 0,
 				}
+				if removed, ok := removedMetadata[staker.TxID]; ok {
+					metadata.lastUpdated = removed.lastUpdated
+					metadata.UpDuration = removed.UpDuration
+					metadata.LastUpdated = uint64(removed.lastUpdated.Unix())
+					metadata.PotentialDelegateeReward = removed.PotentialDelegateeReward
+				}
 
 				metadataBytes, err := MetadataCodec.Marshal(codecVersion, metadata)
 				if err != nil {
@@ -2068,6 +2734,7 @@ func (s *state) writeCurrentStakers(updateValidators bool, height uint64, codecV
 
 				s.validatorState.LoadValidatorMetadata(nodeID, subnetID, metadata)
 			case deleted:
+				numRemoved++
 				staker := validatorDiff.validator
 				weightDiff.Amount = staker.Weight
 
@@ -2082,7 +2749,7 @@ func (s *state) writeCurrentStakers(updateValidators bool, height uint64, codecV
 					// significantly more efficient to parse when applying
 					// diffs.
 					err := s.flatValidatorPublicKeyDiffsDB.Put(
-						marshalDiffKey(constants.PrimaryNetworkID, height, nodeID),
+						validatordiffs.MarshalKey(constants.PrimaryNetworkID, height, nodeID),
 						bls.SerializePublicKey(staker.PublicKey),
 					)
 					if err != nil {
@@ -2102,8 +2769,39 @@ func (s *state) writeCurrentStakers(updateValidators bool, height uint64, codecV
 				if err := validatorDB.Delete(staker.TxID[:]); err != nil {
 					return fmt.Errorf("failed to delete current staker: %w", err)
 				}
+				if err := s.subnetValidatorWeightDB.Delete(staker.TxID[:]); err != nil {
+					return fmt.Errorf("failed to delete current staker weight: %w", err)
+				}
+
+				upDuration, lastUpdated, err := s.validatorState.GetUptime(nodeID, subnetID)
+				if err != nil {
+					return fmt.Errorf("failed to get uptime of current validator: %w", err)
+				}
+				delegateeReward, err := s.validatorState.GetDelegateeReward(subnetID, nodeID)
+				if err != nil {
+					return fmt.Errorf("failed to get delegatee reward of current validator: %w", err)
+				}
+				removedMetadata[staker.TxID] = &validatorMetadata{
+					UpDuration:               upDuration,
+					PotentialDelegateeReward: delegateeReward,
+					lastUpdated:              lastUpdated,
+				}
 
 				s.validatorState.DeleteValidatorMetadata(nodeID, subnetID)
+			case modified:
+				staker := validatorDiff.validator
+				weightDiff.Amount = staker.Weight
+				if err := weightDiff.Add(true, validatorDiff.prevWeight); err != nil {
+					return fmt.Errorf("failed to calculate node weight diff: %w", err)
+				}
+
+				if err := database.PutUInt64(s.subnetValidatorWeightDB, staker.TxID[:], staker.Weight); err != nil {
+					return fmt.Errorf("failed to write current staker weight: %w", err)
+				}
+			}
+
+			if err := s.writeStakerNodeIDs(nodeID, validatorDiff, removedStakers); err != nil {
+				return err
 			}
 
 			err := writeCurrentDelegatorDiff(
@@ -2122,8 +2820,8 @@ func (s *state) writeCurrentStakers(updateValidators bool, height uint64, codecV
 			}
 
 			err = s.flatValidatorWeightDiffsDB.Put(
-				marshalDiffKey(subnetID, height, nodeID),
-				marshalWeightDiff(weightDiff),
+				validatordiffs.MarshalKey(subnetID, height, nodeID),
+				validatordiffs.MarshalWeightDiff(weightDiff.Decrease, weightDiff.Amount),
 			)
 			if err != nil {
 				return err
@@ -2163,6 +2861,10 @@ func (s *state) writeCurrentStakers(updateValidators bool, height uint64, codecV
 				return fmt.Errorf("failed to update validator weight: %w", err)
 			}
 		}
+
+		if updateValidators {
+			s.metrics.AddValidators(subnetID, numAdded-numRemoved)
+		}
 	}
 
 	// TODO: Move validator set management out of the state package
@@ -2182,6 +2884,125 @@ func (s *state) writeCurrentStakers(updateValidators bool, height uint64, codecV
 	return nil
 }
 
+// writeValidatorSetCheckpoints persists the current validator set of every
+// subnet if [height] is a multiple of the checkpoint interval.
+//
+// Invariant: writeCurrentStakers must have already been called so that the
+// validator manager reflects the validator sets at [height].
+func (s *state) writeValidatorSetCheckpoints(updateValidators bool, height uint64) error {
+	// The validator manager is only up to date when [updateValidators] is set.
+	// Commits that didn't accept a new block leave [height] unchanged, so each
+	// height is only checkpointed once.
+	interval := s.validatorSetCheckpointInterval
+	if !updateValidators || interval == 0 || height%interval != 0 || height == s.lastCheckpointHeight {
+		return nil
+	}
+
+	subnetIDs, err := s.getCheckpointedSubnetIDs()
+	if err != nil {
+		return err
+	}
+
+	primaryVdrs := s.validators.GetMap(constants.PrimaryNetworkID)
+	for _, subnetID := range subnetIDs {
+		vdrs := primaryVdrs
+		if subnetID != constants.PrimaryNetworkID {
+			vdrs = s.validators.GetMap(subnetID)
+		}
+		if err := s.putValidatorSetCheckpoint(subnetID, height, vdrs, primaryVdrs); err != nil {
+			return err
+		}
+	}
+
+	s.lastCheckpointHeight = height
+	return nil
+}
+
+// getCheckpointedSubnetIDs returns the Primary Network ID followed by the IDs
+// of all the subnets that have been created.
+func (s *state) getCheckpointedSubnetIDs() ([]ids.ID, error) {
+	subnets, err := s.GetSubnets()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch subnets: %w", err)
+	}
+
+	subnetIDs := make([]ids.ID, 0, len(subnets)+1)
+	subnetIDs = append(subnetIDs, constants.PrimaryNetworkID)
+	for _, subnet := range subnets {
+		subnetIDs = append(subnetIDs, subnet.ID())
+	}
+	return subnetIDs, nil
+}
+
+// removalsFirst returns the nodeIDs of [validatorDiffs], starting with the
+// nodeIDs whose validator was removed.
+func removalsFirst(validatorDiffs map[ids.NodeID]*diffValidator) []ids.NodeID {
+	nodeIDs := make([]ids.NodeID, 0, len(validatorDiffs))
+	for nodeID, validatorDiff := range validatorDiffs {
+		if validatorDiff.validatorStatus == deleted {
+			nodeIDs = append(nodeIDs, nodeID)
+		}
+	}
+	for nodeID, validatorDiff := range validatorDiffs {
+		if validatorDiff.validatorStatus != deleted {
+			nodeIDs = append(nodeIDs, nodeID)
+		}
+	}
+	return nodeIDs
+}
+
+// writeStakerNodeIDs records [nodeID], and the BLS public key of the
+// validator, as the keys of the stakers of [validatorDiff] that were re-keyed
+// to it, and drops the recorded keys of the stakers that were removed. The TxIDs of the removed stakers are added to
+// [removedStakers].
+//
+// Invariant: The stakers removed from a nodeID must be written before the
+// stakers added to a nodeID.
+func (s *state) writeStakerNodeIDs(
+	nodeID ids.NodeID,
+	validatorDiff *diffValidator,
+	removedStakers set.Set[ids.ID],
+) error {
+	deletedStakers := maps.Values(validatorDiff.deletedDelegators)
+	if validatorDiff.validatorStatus == deleted {
+		deletedStakers = append(deletedStakers, validatorDiff.validator)
+	}
+	for _, staker := range deletedStakers {
+		removedStakers.Add(staker.TxID)
+		if err := s.stakerNodeIDDB.Delete(staker.TxID[:]); err != nil {
+			return fmt.Errorf("failed to delete staker nodeID: %w", err)
+		}
+		if err := s.stakerPublicKeyDB.Delete(staker.TxID[:]); err != nil {
+			return fmt.Errorf("failed to delete staker public key: %w", err)
+		}
+	}
+
+	var addedStakers []*Staker
+	if validatorDiff.validatorStatus == added {
+		addedStakers = append(addedStakers, validatorDiff.validator)
+	}
+	addedDelegatorIterator := NewTreeIterator(validatorDiff.addedDelegators)
+	defer addedDelegatorIterator.Release()
+	for addedDelegatorIterator.Next() {
+		addedStakers = append(addedStakers, addedDelegatorIterator.Value())
+	}
+	for _, staker := range addedStakers {
+		if !removedStakers.Contains(staker.TxID) {
+			continue
+		}
+		if err := s.stakerNodeIDDB.Put(staker.TxID[:], nodeID.Bytes()); err != nil {
+			return fmt.Errorf("failed to write staker nodeID: %w", err)
+		}
+		if staker.PublicKey == nil {
+			continue
+		}
+		if err := s.stakerPublicKeyDB.Put(staker.TxID[:], bls.PublicKeyToBytes(staker.PublicKey)); err != nil {
+			return fmt.Errorf("failed to write staker public key: %w", err)
+		}
+	}
+	return nil
+}
+
 func writeCurrentDelegatorDiff(
 	currentDelegatorList linkeddb.LinkedDB,
 	weightDiff *ValidatorWeightDiff,
@@ -2381,6 +3202,136 @@ func (s *state) writeSubnetOwners() error {
 	return nil
 }
 
+func (s *state) writeSubnetAllowList() error {
+	for subnetID, allowList := range s.modifiedSubnetAllowList {
+		delete(s.modifiedSubnetAllowList, subnetID)
+
+		for nodeID, txID := range allowList {
+			key := subnetAllowListKey(subnetID, nodeID)
+			var err error
+			if txID == ids.Empty {
+				err = s.subnetAllowListDB.Delete(key)
+			} else {
+				err = database.PutID(s.subnetAllowListDB, key, txID)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to write subnet allow list: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *state) writeStakerExits() error {
+	for stakerTxID, exit := range s.addedStakerExits {
+		stakerTxID := stakerTxID
+		delete(s.addedStakerExits, stakerTxID)
+
+		exitBytes, err := MetadataCodec.Marshal(CodecVersion0, exit)
+		if err != nil {
+			return fmt.Errorf("failed to serialize staker exit: %w", err)
+		}
+		if err := s.stakerExitDB.Put(stakerTxID[:], exitBytes); err != nil {
+			return fmt.Errorf("failed to write staker exit: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *state) writeNames() error {
+	for name, record := range s.modifiedNames {
+		delete(s.modifiedNames, name)
+
+		recordBytes, err := MetadataCodec.Marshal(CodecVersion0, record)
+		if err != nil {
+			return fmt.Errorf("failed to serialize name record: %w", err)
+		}
+		if err := s.nameDB.Put([]byte(name), recordBytes); err != nil {
+			return fmt.Errorf("failed to write name record: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *state) writeClaimableRewards() error {
+	for key, amount := range s.modifiedClaimableRewards {
+		delete(s.modifiedClaimableRewards, key)
+
+		var err error
+		if amount == 0 {
+			err = s.claimableRewardDB.Delete(key.Bytes())
+		} else {
+			err = database.PutUInt64(s.claimableRewardDB, key.Bytes(), amount)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write claimable reward: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *state) writeDelegatedStakes() error {
+	for key, stake := range s.modifiedDelegatedStakes {
+		delete(s.modifiedDelegatedStakes, key)
+
+		var err error
+		if stake == 0 {
+			err = s.delegatedStakeDB.Delete(key.Bytes())
+		} else {
+			err = database.PutUInt64(s.delegatedStakeDB, key.Bytes(), stake)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write delegated stake: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *state) writeRewardAddresses() error {
+	for ownerID, addr := range s.modifiedRewardAddresses {
+		delete(s.modifiedRewardAddresses, ownerID)
+
+		var err error
+		if addr == ids.ShortEmpty {
+			err = s.rewardAddressDB.Delete(ownerID[:])
+		} else {
+			err = s.rewardAddressDB.Put(ownerID[:], addr[:])
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write reward address: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *state) writeRewardReceipts() error {
+	for nodeID, receipts := range s.addedRewardReceipts {
+		delete(s.addedRewardReceipts, nodeID)
+
+		for _, receipt := range receipts {
+			receiptBytes, err := MetadataCodec.Marshal(CodecVersion1, receipt)
+			if err != nil {
+				return fmt.Errorf("failed to serialize reward receipt: %w", err)
+			}
+			key := rewardReceiptKey(nodeID, receipt.Height, receipt.StakerTxID)
+			if err := s.rewardReceiptDB.Put(key, receiptBytes); err != nil {
+				return fmt.Errorf("failed to write reward receipt: %w", err)
+			}
+			if err := s.rewardReceiptIndexDB.Put(receipt.StakerTxID[:], key); err != nil {
+				return fmt.Errorf("failed to index reward receipt: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+func subnetAllowListKey(subnetID ids.ID, nodeID ids.NodeID) []byte {
+	key := make([]byte, ids.IDLen+ids.NodeIDLen)
+	copy(key, subnetID[:])
+	copy(key[ids.IDLen:], nodeID[:])
+	return key
+}
+
 func (s *state) writeTransformedSubnets() error {
 	for subnetID, tx := range s.transformedSubnets {
 		txID := tx.ID()
@@ -2397,6 +3348,23 @@ func (s *state) writeTransformedSubnets() error {
 	return nil
 }
 
+func (s *state) writeParameterChanges() error {
+	for parameter, changes := range s.modifiedParameterChanges {
+		delete(s.modifiedParameterChanges, parameter)
+
+		changesBytes, err := MetadataCodec.Marshal(CodecVersion0, &parameterChanges{
+			Changes: changes,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to serialize parameter changes: %w", err)
+		}
+		if err := s.parameterDB.Put(parameterKey(parameter), changesBytes); err != nil {
+			return fmt.Errorf("failed to write parameter changes: %w", err)
+		}
+	}
+	return nil
+}
+
 func (s *state) writeSubnetSupplies() error {
 	for subnetID, supply := range s.modifiedSupplies {
 		supply := supply
@@ -2461,7 +3429,12 @@ func (s *state) writeMetadata() error {
 // Invariant: blkBytes is safe to parse with blocks.GenesisCodec
 //
 // TODO: Remove after v1.11.x is activated
-func parseStoredBlock(blkBytes []byte) (block.Block, choices.Status, bool, error) {
+func parseStoredBlock(storedBytes []byte) (block.Block, choices.Status, bool, error) {
+	blkBytes, err := decompressBlock(storedBytes)
+	if err != nil {
+		return nil, choices.Processing, false, err
+	}
+
 	// Attempt to parse as blocks.Block
 	blk, err := block.Parse(block.GenesisCodec, blkBytes)
 	if err == nil {
@@ -2546,6 +3519,10 @@ func (s *state) PruneAndIndex(lock sync.Locker, log logging.Logger) error {
 		// Since we only store accepted blocks on disk, we only need to store a map of
 		// ids.ID to Block.
 		if isStateBlk {
+			blkBytes, err := s.blockBytesToStore(blk.Bytes())
+			if err != nil {
+				return fmt.Errorf("failed to compress block: %w", err)
+			}
 			if err := s.blockDB.Put(blkID[:], blkBytes); err != nil {
 				return fmt.Errorf("failed to write block: %w", err)
 			}
@@ -2640,3 +3617,136 @@ func (s *state) PruneAndIndex(lock sync.Locker, log logging.Logger) error {
 
 	return s.Commit()
 }
+
+func (s *state) ShouldIndexValidatorSetCheckpoints() (bool, error) {
+	if s.validatorSetCheckpointInterval == 0 {
+		return false, nil
+	}
+
+	// If [validatorSetCheckpointIntervalKey] doesn't match the configured
+	// interval, [IndexValidatorSetCheckpoints()] did not finish execution with
+	// the current interval.
+	interval, err := database.GetUInt64(s.singletonDB, ValidatorSetCheckpointIntervalKey)
+	if err == database.ErrNotFound {
+		return true, nil
+	}
+	if err != nil {
+		return true, err
+	}
+	return interval != s.validatorSetCheckpointInterval, nil
+}
+
+func (s *state) IndexValidatorSetCheckpoints(ctx context.Context, lock sync.Locker, log logging.Logger) error {
+	interval := s.validatorSetCheckpointInterval
+
+	lock.Lock()
+	lastAccepted, err := s.GetStatelessBlock(s.lastAccepted)
+	if err != nil {
+		lock.Unlock()
+		return err
+	}
+	subnetIDs, err := s.getCheckpointedSubnetIDs()
+	if err != nil {
+		lock.Unlock()
+		return err
+	}
+
+	// The validator sets are reverted from the last accepted height towards
+	// genesis. Any blocks accepted after this point are checkpointed during
+	// normal block acceptance.
+	height := lastAccepted.Height()
+	vdrSets := make(map[ids.ID]map[ids.NodeID]*validators.GetValidatorOutput, len(subnetIDs))
+	for _, subnetID := range subnetIDs {
+		vdrSets[subnetID] = s.validators.GetMap(subnetID)
+	}
+	lock.Unlock()
+
+	log.Info("starting validator set checkpoint indexing",
+		zap.Uint64("interval", interval),
+		zap.Uint64("height", height),
+	)
+
+	var (
+		startTime        = time.Now()
+		lastUpdate       = startTime
+		numIndexed       = 0
+		checkpointHeight = height - height%interval
+	)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		// The lock is held while applying the diffs to avoid racing with the
+		// height index being updated by block acceptance.
+		lock.Lock()
+		err := s.indexValidatorSetCheckpoint(ctx, vdrSets, height, checkpointHeight)
+		lock.Unlock()
+		if err != nil {
+			return err
+		}
+
+		numIndexed++
+
+		if now := time.Now(); now.Sub(lastUpdate) > pruneUpdateFrequency {
+			lastUpdate = now
+			log.Info("committing validator set checkpoints",
+				zap.Int("numIndexed", numIndexed),
+				zap.Uint64("height", checkpointHeight),
+			)
+		}
+
+		if checkpointHeight < interval {
+			break
+		}
+		height = checkpointHeight
+		checkpointHeight -= interval
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	if err := database.PutUInt64(s.singletonDB, ValidatorSetCheckpointIntervalKey, interval); err != nil {
+		return fmt.Errorf("failed to write validator set checkpoint interval: %w", err)
+	}
+
+	log.Info("finished validator set checkpoint indexing",
+		zap.Int("numIndexed", numIndexed),
+		zap.Duration("duration", time.Since(startTime)),
+	)
+
+	return s.Commit()
+}
+
+// indexValidatorSetCheckpoint reverts [vdrSets] from [height] to
+// [checkpointHeight] and persists the resulting validator sets.
+//
+// Invariant: [vdrSets] must contain the validator sets at [height] and must
+// include the Primary Network.
+func (s *state) indexValidatorSetCheckpoint(
+	ctx context.Context,
+	vdrSets map[ids.ID]map[ids.NodeID]*validators.GetValidatorOutput,
+	height uint64,
+	checkpointHeight uint64,
+) error {
+	// Note: Because the state interface is implemented to be inclusive, we
+	// apply diffs in [checkpointHeight + 1, height].
+	lastDiffHeight := checkpointHeight + 1
+	for subnetID, vdrs := range vdrSets {
+		if err := s.ApplyValidatorWeightDiffs(ctx, vdrs, height, lastDiffHeight, subnetID); err != nil {
+			return err
+		}
+	}
+
+	primaryVdrs := vdrSets[constants.PrimaryNetworkID]
+	if err := s.ApplyValidatorPublicKeyDiffs(ctx, primaryVdrs, height, lastDiffHeight); err != nil {
+		return err
+	}
+
+	for subnetID, vdrs := range vdrSets {
+		if err := s.putValidatorSetCheckpoint(subnetID, checkpointHeight, vdrs, primaryVdrs); err != nil {
+			return err
+		}
+	}
+	return s.Commit()
+}
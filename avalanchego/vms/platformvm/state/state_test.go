@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sync"
 	"testing"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
@@ -22,6 +24,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
@@ -34,6 +37,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/validatordiffs"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/ava-labs/avalanchego/vms/types"
 
@@ -164,7 +168,7 @@ func TestPersistStakers(t *testing.T) {
 				r.Equal(lastUpdated, staker.StartTime)
 			},
 			checkDiffs: func(r *require.Assertions, s *state, staker *Staker, height uint64) {
-				weightDiffBytes, err := s.flatValidatorWeightDiffsDB.Get(marshalDiffKey(staker.SubnetID, height, staker.NodeID))
+				weightDiffBytes, err := s.flatValidatorWeightDiffsDB.Get(validatordiffs.MarshalKey(staker.SubnetID, height, staker.NodeID))
 				r.NoError(err)
 				weightDiff, err := unmarshalWeightDiff(weightDiffBytes)
 				r.NoError(err)
@@ -173,7 +177,7 @@ func TestPersistStakers(t *testing.T) {
 					Amount:   staker.Weight,
 				}, weightDiff)
 
-				blsDiffBytes, err := s.flatValidatorPublicKeyDiffsDB.Get(marshalDiffKey(staker.SubnetID, height, staker.NodeID))
+				blsDiffBytes, err := s.flatValidatorPublicKeyDiffsDB.Get(validatordiffs.MarshalKey(staker.SubnetID, height, staker.NodeID))
 				if staker.SubnetID == constants.PrimaryNetworkID {
 					r.NoError(err)
 					r.Nil(blsDiffBytes)
@@ -262,7 +266,7 @@ func TestPersistStakers(t *testing.T) {
 			checkValidatorUptimes: func(*require.Assertions, *state, *Staker) {},
 			checkDiffs: func(r *require.Assertions, s *state, staker *Staker, height uint64) {
 				// validator's weight must increase of delegator's weight amount
-				weightDiffBytes, err := s.flatValidatorWeightDiffsDB.Get(marshalDiffKey(staker.SubnetID, height, staker.NodeID))
+				weightDiffBytes, err := s.flatValidatorWeightDiffsDB.Get(validatordiffs.MarshalKey(staker.SubnetID, height, staker.NodeID))
 				r.NoError(err)
 				weightDiff, err := unmarshalWeightDiff(weightDiffBytes)
 				r.NoError(err)
@@ -318,10 +322,10 @@ func TestPersistStakers(t *testing.T) {
 			},
 			checkDiffs: func(r *require.Assertions, s *state, staker *Staker, height uint64) {
 				// pending validators  weight diff and bls diffs are not stored
-				_, err := s.flatValidatorWeightDiffsDB.Get(marshalDiffKey(staker.SubnetID, height, staker.NodeID))
+				_, err := s.flatValidatorWeightDiffsDB.Get(validatordiffs.MarshalKey(staker.SubnetID, height, staker.NodeID))
 				r.ErrorIs(err, database.ErrNotFound)
 
-				_, err = s.flatValidatorPublicKeyDiffsDB.Get(marshalDiffKey(staker.SubnetID, height, staker.NodeID))
+				_, err = s.flatValidatorPublicKeyDiffsDB.Get(validatordiffs.MarshalKey(staker.SubnetID, height, staker.NodeID))
 				r.ErrorIs(err, database.ErrNotFound)
 			},
 		},
@@ -439,7 +443,7 @@ func TestPersistStakers(t *testing.T) {
 				r.ErrorIs(err, database.ErrNotFound)
 			},
 			checkDiffs: func(r *require.Assertions, s *state, staker *Staker, height uint64) {
-				weightDiffBytes, err := s.flatValidatorWeightDiffsDB.Get(marshalDiffKey(staker.SubnetID, height, staker.NodeID))
+				weightDiffBytes, err := s.flatValidatorWeightDiffsDB.Get(validatordiffs.MarshalKey(staker.SubnetID, height, staker.NodeID))
 				r.NoError(err)
 				weightDiff, err := unmarshalWeightDiff(weightDiffBytes)
 				r.NoError(err)
@@ -448,7 +452,7 @@ func TestPersistStakers(t *testing.T) {
 					Amount:   staker.Weight,
 				}, weightDiff)
 
-				blsDiffBytes, err := s.flatValidatorPublicKeyDiffsDB.Get(marshalDiffKey(staker.SubnetID, height, staker.NodeID))
+				blsDiffBytes, err := s.flatValidatorPublicKeyDiffsDB.Get(validatordiffs.MarshalKey(staker.SubnetID, height, staker.NodeID))
 				if staker.SubnetID == constants.PrimaryNetworkID {
 					r.NoError(err)
 					r.Equal(bls.DeserializePublicKey(blsDiffBytes), staker.PublicKey)
@@ -537,7 +541,7 @@ func TestPersistStakers(t *testing.T) {
 			checkValidatorUptimes: func(*require.Assertions, *state, *Staker) {},
 			checkDiffs: func(r *require.Assertions, s *state, staker *Staker, height uint64) {
 				// validator's weight must decrease of delegator's weight amount
-				weightDiffBytes, err := s.flatValidatorWeightDiffsDB.Get(marshalDiffKey(staker.SubnetID, height, staker.NodeID))
+				weightDiffBytes, err := s.flatValidatorWeightDiffsDB.Get(validatordiffs.MarshalKey(staker.SubnetID, height, staker.NodeID))
 				r.NoError(err)
 				weightDiff, err := unmarshalWeightDiff(weightDiffBytes)
 				r.NoError(err)
@@ -593,10 +597,10 @@ func TestPersistStakers(t *testing.T) {
 				r.ErrorIs(err, database.ErrNotFound)
 			},
 			checkDiffs: func(r *require.Assertions, s *state, staker *Staker, height uint64) {
-				_, err := s.flatValidatorWeightDiffsDB.Get(marshalDiffKey(staker.SubnetID, height, staker.NodeID))
+				_, err := s.flatValidatorWeightDiffsDB.Get(validatordiffs.MarshalKey(staker.SubnetID, height, staker.NodeID))
 				r.ErrorIs(err, database.ErrNotFound)
 
-				_, err = s.flatValidatorPublicKeyDiffsDB.Get(marshalDiffKey(staker.SubnetID, height, staker.NodeID))
+				_, err = s.flatValidatorPublicKeyDiffsDB.Get(validatordiffs.MarshalKey(staker.SubnetID, height, staker.NodeID))
 				r.ErrorIs(err, database.ErrNotFound)
 			},
 		},
@@ -1253,6 +1257,491 @@ func TestStateAddRemoveValidator(t *testing.T) {
 	}
 }
 
+func TestStateUpdateCurrentValidator(t *testing.T) {
+	require := require.New(t)
+
+	s, db := newUninitializedState(require)
+
+	var (
+		subnetID  = ids.GenerateTestID()
+		nodeID    = ids.GenerateTestNodeID()
+		startTime = time.Now().Truncate(time.Second)
+		endTime   = startTime.Add(24 * time.Hour)
+	)
+	utx := &txs.AddSubnetValidatorTx{
+		SubnetValidator: txs.SubnetValidator{
+			Validator: txs.Validator{
+				NodeID: nodeID,
+				End:    uint64(endTime.Unix()),
+				Wght:   1234,
+			},
+			Subnet: subnetID,
+		},
+		SubnetAuth: &secp256k1fx.Input{},
+	}
+	tx := &txs.Tx{Unsigned: utx}
+	require.NoError(tx.Initialize(txs.Codec))
+
+	txID := tx.ID()
+	staker, err := NewCurrentStaker(txID, utx, startTime, 0)
+	require.NoError(err)
+
+	s.PutCurrentValidator(staker)
+	s.AddTx(tx, status.Committed) // this is currently needed to reload the staker
+	s.SetHeight(1)
+	require.NoError(s.Commit())
+
+	requireWeight := func(chainState *state, weight uint64) {
+		gotStaker, err := chainState.GetCurrentValidator(subnetID, nodeID)
+		require.NoError(err)
+		require.Equal(weight, gotStaker.Weight)
+		require.Equal(weight, chainState.cfg.Validators.GetWeight(subnetID, nodeID))
+
+		stakerIterator, err := chainState.GetCurrentStakerIterator()
+		require.NoError(err)
+		require.True(stakerIterator.Next())
+		require.Equal(weight, stakerIterator.Value().Weight)
+		require.False(stakerIterator.Next())
+		stakerIterator.Release()
+	}
+	requireWeightDiff := func(height uint64, expected *ValidatorWeightDiff) {
+		weightDiffBytes, err := s.flatValidatorWeightDiffsDB.Get(validatordiffs.MarshalKey(subnetID, height, nodeID))
+		require.NoError(err)
+		weightDiff, err := unmarshalWeightDiff(weightDiffBytes)
+		require.NoError(err)
+		require.Equal(expected, weightDiff)
+	}
+
+	// Increase the weight of the validator
+	increasedStaker := *staker
+	increasedStaker.Weight = 3000
+	s.UpdateCurrentValidator(&increasedStaker)
+	s.SetHeight(2)
+	require.NoError(s.Commit())
+
+	requireWeight(s, 3000)
+	requireWeightDiff(2, &ValidatorWeightDiff{
+		Decrease: false,
+		Amount:   3000 - 1234,
+	})
+
+	// Decrease the weight of the validator
+	decreasedStaker := *staker
+	decreasedStaker.Weight = 1000
+	s.UpdateCurrentValidator(&decreasedStaker)
+	s.SetHeight(3)
+	require.NoError(s.Commit())
+
+	requireWeight(s, 1000)
+	requireWeightDiff(3, &ValidatorWeightDiff{
+		Decrease: true,
+		Amount:   3000 - 1000,
+	})
+
+	// The validator set at prior heights can be recovered from the diffs
+	validatorSet := copyValidatorSet(s.cfg.Validators.GetMap(subnetID))
+	require.NoError(s.ApplyValidatorWeightDiffs(
+		context.Background(),
+		validatorSet,
+		3,
+		2,
+		subnetID,
+	))
+	require.Equal(uint64(1234), validatorSet[nodeID].Weight)
+
+	// The updated weight is reloaded from disk
+	rebuiltState := newStateFromDB(require, db)
+	require.NoError(rebuiltState.loadCurrentValidators())
+	require.NoError(rebuiltState.initValidatorSets())
+	requireWeight(rebuiltState, 1000)
+
+	// Removing an updated validator removes the updated weight
+	removedStaker := *staker
+	removedStaker.Weight = 500
+	s.UpdateCurrentValidator(&removedStaker)
+	s.DeleteCurrentValidator(&removedStaker)
+	s.SetHeight(4)
+	require.NoError(s.Commit())
+
+	_, err = s.GetCurrentValidator(subnetID, nodeID)
+	require.ErrorIs(err, database.ErrNotFound)
+	require.Zero(s.cfg.Validators.GetWeight(subnetID, nodeID))
+	requireWeightDiff(4, &ValidatorWeightDiff{
+		Decrease: true,
+		Amount:   1000,
+	})
+
+	_, err = s.subnetValidatorWeightDB.Get(txID[:])
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestStateRekeyStaker(t *testing.T) {
+	require := require.New(t)
+
+	s, db := newUninitializedState(require)
+
+	var (
+		nodeID          = ids.GenerateTestNodeID()
+		newNodeID       = ids.GenerateTestNodeID()
+		startTime       = time.Now().Truncate(time.Second)
+		endTime         = startTime.Add(24 * time.Hour)
+		upDuration      = time.Hour
+		lastUpdated     = startTime.Add(2 * time.Hour)
+		delegateeReward = uint64(10)
+	)
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	newPublicKey := bls.PublicFromSecretKey(sk)
+
+	validatorTx := &txs.Tx{Unsigned: createPermissionlessValidatorTx(require, constants.PrimaryNetworkID, txs.Validator{
+		NodeID: nodeID,
+		End:    uint64(endTime.Unix()),
+		Wght:   1234,
+	})}
+	require.NoError(validatorTx.Initialize(txs.Codec))
+	delegatorTx := &txs.Tx{Unsigned: createPermissionlessDelegatorTx(constants.PrimaryNetworkID, txs.Validator{
+		NodeID: nodeID,
+		End:    uint64(endTime.Unix()),
+		Wght:   100,
+	})}
+	require.NoError(delegatorTx.Initialize(txs.Codec))
+
+	validator, err := NewCurrentStaker(validatorTx.ID(), validatorTx.Unsigned.(txs.Staker), startTime, 0)
+	require.NoError(err)
+	delegator, err := NewCurrentStaker(delegatorTx.ID(), delegatorTx.Unsigned.(txs.Staker), startTime, 0)
+	require.NoError(err)
+
+	s.PutCurrentValidator(validator)
+	s.PutCurrentDelegator(delegator)
+	s.AddTx(validatorTx, status.Committed)
+	s.AddTx(delegatorTx, status.Committed)
+	s.SetHeight(1)
+	require.NoError(s.Commit())
+
+	require.NoError(s.SetUptime(nodeID, constants.PrimaryNetworkID, upDuration, lastUpdated))
+	require.NoError(s.SetDelegateeReward(constants.PrimaryNetworkID, nodeID, delegateeReward))
+	require.NoError(s.Commit())
+
+	// Move both stakers to [newNodeID], and the validator to [newPublicKey]
+	rekeyedValidator := *validator
+	rekeyedValidator.NodeID = newNodeID
+	rekeyedValidator.PublicKey = newPublicKey
+	rekeyedDelegator := *delegator
+	rekeyedDelegator.NodeID = newNodeID
+
+	s.DeleteCurrentDelegator(delegator)
+	s.DeleteCurrentValidator(validator)
+	s.PutCurrentValidator(&rekeyedValidator)
+	s.PutCurrentDelegator(&rekeyedDelegator)
+	s.SetHeight(2)
+	require.NoError(s.Commit())
+
+	requireRekeyed := func(chainState *state) {
+		_, err := chainState.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+		require.ErrorIs(err, database.ErrNotFound)
+		require.Zero(chainState.cfg.Validators.GetWeight(constants.PrimaryNetworkID, nodeID))
+
+		gotValidator, err := chainState.GetCurrentValidator(constants.PrimaryNetworkID, newNodeID)
+		require.NoError(err)
+		require.Equal(validator.TxID, gotValidator.TxID)
+		require.Equal(newPublicKey, gotValidator.PublicKey)
+		require.Equal(uint64(1234+100), chainState.cfg.Validators.GetWeight(constants.PrimaryNetworkID, newNodeID))
+		vdr, ok := chainState.cfg.Validators.GetValidator(constants.PrimaryNetworkID, newNodeID)
+		require.True(ok)
+		require.Equal(newPublicKey, vdr.PublicKey)
+
+		delegatorIterator, err := chainState.GetCurrentDelegatorIterator(constants.PrimaryNetworkID, newNodeID)
+		require.NoError(err)
+		require.True(delegatorIterator.Next())
+		require.Equal(delegator.TxID, delegatorIterator.Value().TxID)
+		require.False(delegatorIterator.Next())
+		delegatorIterator.Release()
+
+		// The uptime and delegatee reward follow the validator
+		gotUpDuration, gotLastUpdated, err := chainState.GetUptime(newNodeID, constants.PrimaryNetworkID)
+		require.NoError(err)
+		require.Equal(upDuration, gotUpDuration)
+		require.Equal(lastUpdated, gotLastUpdated)
+
+		gotDelegateeReward, err := chainState.GetDelegateeReward(constants.PrimaryNetworkID, newNodeID)
+		require.NoError(err)
+		require.Equal(delegateeReward, gotDelegateeReward)
+	}
+	requireRekeyed(s)
+
+	// The new nodeID and public key are reloaded from disk
+	rebuiltState := newStateFromDB(require, db)
+	require.NoError(rebuiltState.loadCurrentValidators())
+	require.NoError(rebuiltState.initValidatorSets())
+	requireRekeyed(rebuiltState)
+
+	// Removing a re-keyed staker drops its nodeID and public key
+	s.DeleteCurrentDelegator(&rekeyedDelegator)
+	s.DeleteCurrentValidator(&rekeyedValidator)
+	s.SetHeight(3)
+	require.NoError(s.Commit())
+
+	for _, txID := range []ids.ID{validator.TxID, delegator.TxID} {
+		_, rekeyed, err := s.getStakerNodeID(txID)
+		require.NoError(err)
+		require.False(rekeyed)
+
+		has, err := s.stakerPublicKeyDB.Has(txID[:])
+		require.NoError(err)
+		require.False(has)
+	}
+}
+
+func TestStateRewardReceipts(t *testing.T) {
+	require := require.New(t)
+
+	s, db := newUninitializedState(require)
+
+	var (
+		nodeID      = ids.GenerateTestNodeID()
+		otherNodeID = ids.GenerateTestNodeID()
+		receipt1    = &RewardReceipt{
+			StakerTxID:      ids.GenerateTestID(),
+			SubnetID:        constants.PrimaryNetworkID,
+			Height:          1,
+			Timestamp:       10,
+			Rewarded:        true,
+			PotentialReward: 100,
+			Reward:          150,
+			Uptime:          900_000,
+			RequiredUptime:  800_000,
+			Cause:           RewardCauseUptimeSufficient,
+		}
+		receipt2 = &RewardReceipt{
+			StakerTxID:      ids.GenerateTestID(),
+			SubnetID:        ids.GenerateTestID(),
+			Height:          3,
+			Timestamp:       30,
+			PotentialReward: 100,
+			Uptime:          500_000,
+			RequiredUptime:  800_000,
+			Cause:           RewardCauseUptimeInsufficient,
+		}
+		otherReceipt = &RewardReceipt{
+			StakerTxID: ids.GenerateTestID(),
+			Height:     2,
+		}
+	)
+
+	s.AddRewardReceipt(nodeID, receipt1)
+	s.AddRewardReceipt(otherNodeID, otherReceipt)
+	require.NoError(s.Commit())
+
+	// Pending receipts are returned along with the written ones.
+	s.AddRewardReceipt(nodeID, receipt2)
+
+	receipts, err := s.GetRewardReceipts(nodeID, 0, 3)
+	require.NoError(err)
+	require.Equal([]*RewardReceipt{receipt1, receipt2}, receipts)
+
+	require.NoError(s.Commit())
+
+	s = newStateFromDB(require, db)
+
+	receipts, err = s.GetRewardReceipts(nodeID, 0, math.MaxUint64)
+	require.NoError(err)
+	require.Equal([]*RewardReceipt{receipt1, receipt2}, receipts)
+
+	receipts, err = s.GetRewardReceipts(nodeID, 2, 3)
+	require.NoError(err)
+	require.Equal([]*RewardReceipt{receipt2}, receipts)
+
+	receipts, err = s.GetRewardReceipts(nodeID, 1, 2)
+	require.NoError(err)
+	require.Equal([]*RewardReceipt{receipt1}, receipts)
+
+	receipts, err = s.GetRewardReceipts(otherNodeID, 0, 1)
+	require.NoError(err)
+	require.Empty(receipts)
+
+	// Receipts can be looked up by the staker that they were recorded for.
+	receiptNodeID, receipt, err := s.GetRewardReceipt(receipt2.StakerTxID)
+	require.NoError(err)
+	require.Equal(nodeID, receiptNodeID)
+	require.Equal(receipt2, receipt)
+
+	pendingReceipt := &RewardReceipt{
+		StakerTxID: ids.GenerateTestID(),
+		Height:     4,
+	}
+	s.AddRewardReceipt(otherNodeID, pendingReceipt)
+	receiptNodeID, receipt, err = s.GetRewardReceipt(pendingReceipt.StakerTxID)
+	require.NoError(err)
+	require.Equal(otherNodeID, receiptNodeID)
+	require.Equal(pendingReceipt, receipt)
+
+	_, _, err = s.GetRewardReceipt(ids.GenerateTestID())
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestStateAtomicRequests(t *testing.T) {
+	require := require.New(t)
+
+	s, db := newUninitializedState(require)
+
+	var (
+		blkID          = ids.GenerateTestID()
+		atomicRequests = map[ids.ID]*atomic.Requests{
+			ids.GenerateTestID(): {
+				RemoveRequests: [][]byte{{1}, {2}},
+				PutRequests: []*atomic.Element{
+					{
+						Key:    []byte{3},
+						Value:  []byte{4},
+						Traits: [][]byte{{5}},
+					},
+				},
+			},
+		}
+	)
+
+	// Pending requests are returned before they are written.
+	s.AddAtomicRequests(blkID, atomicRequests)
+	requests, err := s.GetAtomicRequests(blkID)
+	require.NoError(err)
+	require.Equal(atomicRequests, requests)
+
+	require.NoError(s.Commit())
+
+	s = newStateFromDB(require, db)
+
+	requests, err = s.GetAtomicRequests(blkID)
+	require.NoError(err)
+	require.Equal(atomicRequests, requests)
+
+	_, err = s.GetAtomicRequests(ids.GenerateTestID())
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestStateParameterChanges(t *testing.T) {
+	require := require.New(t)
+
+	s, db := newUninitializedState(require)
+	s.SetTimestamp(time.Unix(100, 0))
+
+	changes, err := s.GetParameterChanges(txs.TxFeeParameter)
+	require.NoError(err)
+	require.Empty(changes)
+
+	value, err := GetParameter(s, txs.TxFeeParameter, 1)
+	require.NoError(err)
+	require.Equal(uint64(1), value)
+
+	expectedChanges := []ParameterChange{
+		{Value: 2, ActivationTime: 50},
+		{Value: 3, ActivationTime: 200},
+	}
+	s.SetParameterChanges(txs.TxFeeParameter, expectedChanges)
+	require.NoError(s.Commit())
+
+	s = newStateFromDB(require, db)
+	s.SetTimestamp(time.Unix(100, 0))
+
+	changes, err = s.GetParameterChanges(txs.TxFeeParameter)
+	require.NoError(err)
+	require.Equal(expectedChanges, changes)
+
+	changes, err = s.GetParameterChanges(txs.MinValidatorStakeParameter)
+	require.NoError(err)
+	require.Empty(changes)
+
+	value, err = GetParameter(s, txs.TxFeeParameter, 1)
+	require.NoError(err)
+	require.Equal(uint64(2), value)
+
+	s.SetTimestamp(time.Unix(200, 0))
+	value, err = GetParameter(s, txs.TxFeeParameter, 1)
+	require.NoError(err)
+	require.Equal(uint64(3), value)
+}
+
+func TestValidatorSetCheckpoints(t *testing.T) {
+	require := require.New(t)
+
+	s := newInitializedState(require).(*state)
+	s.validatorSetCheckpointInterval = 2
+
+	var (
+		startTime    = time.Now()
+		parentID     = s.GetLastAccepted()
+		expectedSets = []map[ids.NodeID]*validators.GetValidatorOutput{
+			{}, // height 0
+		}
+	)
+	for height := uint64(1); height <= 5; height++ {
+		sk, err := bls.NewSecretKey()
+		require.NoError(err)
+
+		staker := &Staker{
+			TxID:            ids.GenerateTestID(),
+			NodeID:          ids.GenerateTestNodeID(),
+			PublicKey:       bls.PublicFromSecretKey(sk),
+			SubnetID:        constants.PrimaryNetworkID,
+			Weight:          height,
+			StartTime:       startTime,
+			EndTime:         startTime.Add(24 * time.Hour),
+			PotentialReward: height,
+		}
+		s.PutCurrentValidator(staker)
+
+		blk, err := block.NewApricotCommitBlock(parentID, height)
+		require.NoError(err)
+		parentID = blk.ID()
+
+		s.AddStatelessBlock(blk)
+		s.SetLastAccepted(parentID)
+		s.SetHeight(height)
+		require.NoError(s.Commit())
+
+		expectedSet := copyValidatorSet(expectedSets[height-1])
+		expectedSet[staker.NodeID] = &validators.GetValidatorOutput{
+			NodeID:    staker.NodeID,
+			PublicKey: staker.PublicKey,
+			Weight:    staker.Weight,
+		}
+		expectedSets = append(expectedSets, expectedSet)
+	}
+
+	// Checkpoints are written during block acceptance at every even height.
+	for height, expectedCheckpointHeight := range []uint64{2, 2, 2, 4, 4} {
+		checkpointHeight, checkpoint, err := s.GetValidatorSetCheckpoint(constants.PrimaryNetworkID, uint64(height))
+		require.NoError(err)
+		require.Equal(expectedCheckpointHeight, checkpointHeight)
+		requireEqualWeightsValidatorSet(require, expectedSets[checkpointHeight], checkpoint)
+		requireEqualPublicKeysValidatorSet(require, expectedSets[checkpointHeight], checkpoint)
+	}
+
+	_, _, err := s.GetValidatorSetCheckpoint(constants.PrimaryNetworkID, 5)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// Changing the interval requires the previously accepted heights to be
+	// indexed.
+	s.validatorSetCheckpointInterval = 3
+
+	shouldIndex, err := s.ShouldIndexValidatorSetCheckpoints()
+	require.NoError(err)
+	require.True(shouldIndex)
+
+	require.NoError(s.IndexValidatorSetCheckpoints(context.Background(), &sync.Mutex{}, logging.NoLog{}))
+
+	shouldIndex, err = s.ShouldIndexValidatorSetCheckpoints()
+	require.NoError(err)
+	require.False(shouldIndex)
+
+	for _, height := range []uint64{0, 3} {
+		checkpointHeight, checkpoint, err := s.GetValidatorSetCheckpoint(constants.PrimaryNetworkID, height)
+		require.NoError(err)
+		require.Equal(height, checkpointHeight)
+		requireEqualWeightsValidatorSet(require, expectedSets[height], checkpoint)
+		requireEqualPublicKeysValidatorSet(require, expectedSets[height], checkpoint)
+	}
+}
+
 func copyValidatorSet(
 	input map[ids.NodeID]*validators.GetValidatorOutput,
 ) map[ids.NodeID]*validators.GetValidatorOutput {
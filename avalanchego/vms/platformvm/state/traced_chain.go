@@ -0,0 +1,393 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+var (
+	_ Chain = (*tracedChain)(nil)
+	_ Diff  = (*tracedDiff)(nil)
+)
+
+// Access describes a single read or write performed on a traced Chain.
+type Access struct {
+	// Scope identifies which Chain the access was performed on.
+	Scope  string `json:"scope"`
+	Method string `json:"method"`
+	Write  bool   `json:"write"`
+	Key    string `json:"key,omitempty"`
+	Value  string `json:"value,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+type tracedChain struct {
+	chain  Chain
+	scope  string
+	record func(Access)
+}
+
+// NewTracedChain returns a Chain that reports every access to [chain] to
+// [record], tagged with [scope].
+func NewTracedChain(chain Chain, scope string, record func(Access)) Chain {
+	return &tracedChain{
+		chain:  chain,
+		scope:  scope,
+		record: record,
+	}
+}
+
+type tracedDiff struct {
+	*tracedChain
+	diff Diff
+}
+
+// NewTracedDiff returns a Diff that reports every access to [diff] to
+// [record], tagged with [scope].
+func NewTracedDiff(diff Diff, scope string, record func(Access)) Diff {
+	return &tracedDiff{
+		tracedChain: &tracedChain{
+			chain:  diff,
+			scope:  scope,
+			record: record,
+		},
+		diff: diff,
+	}
+}
+
+func (d *tracedDiff) Apply(baseState Chain) error {
+	err := d.diff.Apply(baseState)
+	d.write("Apply", "", "", err)
+	return err
+}
+
+func (c *tracedChain) read(method, key, value string, err error) {
+	c.access(method, false, key, value, err)
+}
+
+func (c *tracedChain) write(method, key, value string, err error) {
+	c.access(method, true, key, value, err)
+}
+
+func (c *tracedChain) access(method string, write bool, key, value string, err error) {
+	access := Access{
+		Scope:  c.scope,
+		Method: method,
+		Write:  write,
+		Key:    key,
+		Value:  value,
+	}
+	if err != nil {
+		access.Error = err.Error()
+	}
+	c.record(access)
+}
+
+func stakerKey(subnetID ids.ID, nodeID ids.NodeID) string {
+	return fmt.Sprintf("%s/%s", subnetID, nodeID)
+}
+
+func claimableRewardTraceKey(ownerID ids.ID, assetID ids.ID) string {
+	return fmt.Sprintf("%s/%s", ownerID, assetID)
+}
+
+func delegatedStakeTraceKey(validatorTxID ids.ID, ownerID ids.ID) string {
+	return fmt.Sprintf("%s/%s", validatorTxID, ownerID)
+}
+
+func stakerValue(staker *Staker) string {
+	if staker == nil {
+		return ""
+	}
+	return staker.TxID.String()
+}
+
+func (c *tracedChain) GetCurrentValidator(subnetID ids.ID, nodeID ids.NodeID) (*Staker, error) {
+	staker, err := c.chain.GetCurrentValidator(subnetID, nodeID)
+	c.read("GetCurrentValidator", stakerKey(subnetID, nodeID), stakerValue(staker), err)
+	return staker, err
+}
+
+func (c *tracedChain) PutCurrentValidator(staker *Staker) {
+	c.chain.PutCurrentValidator(staker)
+	c.write("PutCurrentValidator", stakerKey(staker.SubnetID, staker.NodeID), stakerValue(staker), nil)
+}
+
+func (c *tracedChain) DeleteCurrentValidator(staker *Staker) {
+	c.chain.DeleteCurrentValidator(staker)
+	c.write("DeleteCurrentValidator", stakerKey(staker.SubnetID, staker.NodeID), stakerValue(staker), nil)
+}
+
+func (c *tracedChain) UpdateCurrentValidator(staker *Staker) {
+	c.chain.UpdateCurrentValidator(staker)
+	c.write("UpdateCurrentValidator", stakerKey(staker.SubnetID, staker.NodeID), strconv.FormatUint(staker.Weight, 10), nil)
+}
+
+func (c *tracedChain) SetDelegateeReward(subnetID ids.ID, nodeID ids.NodeID, amount uint64) error {
+	err := c.chain.SetDelegateeReward(subnetID, nodeID, amount)
+	c.write("SetDelegateeReward", stakerKey(subnetID, nodeID), strconv.FormatUint(amount, 10), err)
+	return err
+}
+
+func (c *tracedChain) GetDelegateeReward(subnetID ids.ID, nodeID ids.NodeID) (uint64, error) {
+	amount, err := c.chain.GetDelegateeReward(subnetID, nodeID)
+	c.read("GetDelegateeReward", stakerKey(subnetID, nodeID), strconv.FormatUint(amount, 10), err)
+	return amount, err
+}
+
+func (c *tracedChain) GetCurrentDelegatorIterator(subnetID ids.ID, nodeID ids.NodeID) (StakerIterator, error) {
+	it, err := c.chain.GetCurrentDelegatorIterator(subnetID, nodeID)
+	c.read("GetCurrentDelegatorIterator", stakerKey(subnetID, nodeID), "", err)
+	return it, err
+}
+
+func (c *tracedChain) PutCurrentDelegator(staker *Staker) {
+	c.chain.PutCurrentDelegator(staker)
+	c.write("PutCurrentDelegator", stakerKey(staker.SubnetID, staker.NodeID), stakerValue(staker), nil)
+}
+
+func (c *tracedChain) DeleteCurrentDelegator(staker *Staker) {
+	c.chain.DeleteCurrentDelegator(staker)
+	c.write("DeleteCurrentDelegator", stakerKey(staker.SubnetID, staker.NodeID), stakerValue(staker), nil)
+}
+
+func (c *tracedChain) GetCurrentStakerIterator() (StakerIterator, error) {
+	it, err := c.chain.GetCurrentStakerIterator()
+	c.read("GetCurrentStakerIterator", "", "", err)
+	return it, err
+}
+
+func (c *tracedChain) GetPendingValidator(subnetID ids.ID, nodeID ids.NodeID) (*Staker, error) {
+	staker, err := c.chain.GetPendingValidator(subnetID, nodeID)
+	c.read("GetPendingValidator", stakerKey(subnetID, nodeID), stakerValue(staker), err)
+	return staker, err
+}
+
+func (c *tracedChain) PutPendingValidator(staker *Staker) {
+	c.chain.PutPendingValidator(staker)
+	c.write("PutPendingValidator", stakerKey(staker.SubnetID, staker.NodeID), stakerValue(staker), nil)
+}
+
+func (c *tracedChain) DeletePendingValidator(staker *Staker) {
+	c.chain.DeletePendingValidator(staker)
+	c.write("DeletePendingValidator", stakerKey(staker.SubnetID, staker.NodeID), stakerValue(staker), nil)
+}
+
+func (c *tracedChain) GetPendingDelegatorIterator(subnetID ids.ID, nodeID ids.NodeID) (StakerIterator, error) {
+	it, err := c.chain.GetPendingDelegatorIterator(subnetID, nodeID)
+	c.read("GetPendingDelegatorIterator", stakerKey(subnetID, nodeID), "", err)
+	return it, err
+}
+
+func (c *tracedChain) PutPendingDelegator(staker *Staker) {
+	c.chain.PutPendingDelegator(staker)
+	c.write("PutPendingDelegator", stakerKey(staker.SubnetID, staker.NodeID), stakerValue(staker), nil)
+}
+
+func (c *tracedChain) DeletePendingDelegator(staker *Staker) {
+	c.chain.DeletePendingDelegator(staker)
+	c.write("DeletePendingDelegator", stakerKey(staker.SubnetID, staker.NodeID), stakerValue(staker), nil)
+}
+
+func (c *tracedChain) GetPendingStakerIterator() (StakerIterator, error) {
+	it, err := c.chain.GetPendingStakerIterator()
+	c.read("GetPendingStakerIterator", "", "", err)
+	return it, err
+}
+
+func (c *tracedChain) GetPendingStakerIteratorUntil(timestamp time.Time) (StakerIterator, error) {
+	it, err := c.chain.GetPendingStakerIteratorUntil(timestamp)
+	c.read("GetPendingStakerIteratorUntil", strconv.FormatInt(timestamp.Unix(), 10), "", err)
+	return it, err
+}
+
+func (c *tracedChain) AddUTXO(utxo *avax.UTXO) {
+	c.chain.AddUTXO(utxo)
+	c.write("AddUTXO", utxo.InputID().String(), "", nil)
+}
+
+func (c *tracedChain) GetUTXO(utxoID ids.ID) (*avax.UTXO, error) {
+	utxo, err := c.chain.GetUTXO(utxoID)
+	c.read("GetUTXO", utxoID.String(), "", err)
+	return utxo, err
+}
+
+func (c *tracedChain) DeleteUTXO(utxoID ids.ID) {
+	c.chain.DeleteUTXO(utxoID)
+	c.write("DeleteUTXO", utxoID.String(), "", nil)
+}
+
+func (c *tracedChain) GetNetworkID() uint32 {
+	networkID := c.chain.GetNetworkID()
+	c.read("GetNetworkID", "", strconv.FormatUint(uint64(networkID), 10), nil)
+	return networkID
+}
+
+func (c *tracedChain) GetTimestamp() time.Time {
+	timestamp := c.chain.GetTimestamp()
+	c.read("GetTimestamp", "", timestamp.String(), nil)
+	return timestamp
+}
+
+func (c *tracedChain) SetTimestamp(tm time.Time) {
+	c.chain.SetTimestamp(tm)
+	c.write("SetTimestamp", "", tm.String(), nil)
+}
+
+func (c *tracedChain) GetCurrentSupply(subnetID ids.ID) (uint64, error) {
+	supply, err := c.chain.GetCurrentSupply(subnetID)
+	c.read("GetCurrentSupply", subnetID.String(), strconv.FormatUint(supply, 10), err)
+	return supply, err
+}
+
+func (c *tracedChain) SetCurrentSupply(subnetID ids.ID, cs uint64) {
+	c.chain.SetCurrentSupply(subnetID, cs)
+	c.write("SetCurrentSupply", subnetID.String(), strconv.FormatUint(cs, 10), nil)
+}
+
+func (c *tracedChain) AddRewardUTXO(txID ids.ID, utxo *avax.UTXO) {
+	c.chain.AddRewardUTXO(txID, utxo)
+	c.write("AddRewardUTXO", txID.String(), utxo.InputID().String(), nil)
+}
+
+func (c *tracedChain) AddSubnet(createSubnetTx *txs.Tx) {
+	c.chain.AddSubnet(createSubnetTx)
+	c.write("AddSubnet", createSubnetTx.ID().String(), "", nil)
+}
+
+func (c *tracedChain) GetSubnetOwner(subnetID ids.ID) (fx.Owner, error) {
+	owner, err := c.chain.GetSubnetOwner(subnetID)
+	c.read("GetSubnetOwner", subnetID.String(), "", err)
+	return owner, err
+}
+
+func (c *tracedChain) SetSubnetOwner(subnetID ids.ID, owner fx.Owner) {
+	c.chain.SetSubnetOwner(subnetID, owner)
+	c.write("SetSubnetOwner", subnetID.String(), "", nil)
+}
+
+func (c *tracedChain) GetSubnetAllowListEntry(subnetID ids.ID, nodeID ids.NodeID) (ids.ID, error) {
+	txID, err := c.chain.GetSubnetAllowListEntry(subnetID, nodeID)
+	c.read("GetSubnetAllowListEntry", stakerKey(subnetID, nodeID), txID.String(), err)
+	return txID, err
+}
+
+func (c *tracedChain) SetSubnetAllowListEntry(subnetID ids.ID, nodeID ids.NodeID, txID ids.ID) {
+	c.chain.SetSubnetAllowListEntry(subnetID, nodeID, txID)
+	c.write("SetSubnetAllowListEntry", stakerKey(subnetID, nodeID), txID.String(), nil)
+}
+
+func (c *tracedChain) GetStakerExit(stakerTxID ids.ID) (*StakerExit, error) {
+	exit, err := c.chain.GetStakerExit(stakerTxID)
+	var value string
+	if exit != nil {
+		value = exit.TxID.String()
+	}
+	c.read("GetStakerExit", stakerTxID.String(), value, err)
+	return exit, err
+}
+
+func (c *tracedChain) SetStakerExit(stakerTxID ids.ID, exit *StakerExit) {
+	c.chain.SetStakerExit(stakerTxID, exit)
+	c.write("SetStakerExit", stakerTxID.String(), exit.TxID.String(), nil)
+}
+
+func (c *tracedChain) GetName(name string) (*NameRecord, error) {
+	record, err := c.chain.GetName(name)
+	var value string
+	if record != nil {
+		value = record.TxID.String()
+	}
+	c.read("GetName", name, value, err)
+	return record, err
+}
+
+func (c *tracedChain) SetName(name string, record *NameRecord) {
+	c.chain.SetName(name, record)
+	c.write("SetName", name, record.TxID.String(), nil)
+}
+
+func (c *tracedChain) GetClaimableReward(ownerID ids.ID, assetID ids.ID) (uint64, error) {
+	amount, err := c.chain.GetClaimableReward(ownerID, assetID)
+	c.read("GetClaimableReward", claimableRewardTraceKey(ownerID, assetID), strconv.FormatUint(amount, 10), err)
+	return amount, err
+}
+
+func (c *tracedChain) SetClaimableReward(ownerID ids.ID, assetID ids.ID, amount uint64) {
+	c.chain.SetClaimableReward(ownerID, assetID, amount)
+	c.write("SetClaimableReward", claimableRewardTraceKey(ownerID, assetID), strconv.FormatUint(amount, 10), nil)
+}
+
+func (c *tracedChain) GetDelegatedStake(validatorTxID ids.ID, ownerID ids.ID) (uint64, error) {
+	stake, err := c.chain.GetDelegatedStake(validatorTxID, ownerID)
+	c.read("GetDelegatedStake", delegatedStakeTraceKey(validatorTxID, ownerID), strconv.FormatUint(stake, 10), err)
+	return stake, err
+}
+
+func (c *tracedChain) SetDelegatedStake(validatorTxID ids.ID, ownerID ids.ID, stake uint64) {
+	c.chain.SetDelegatedStake(validatorTxID, ownerID, stake)
+	c.write("SetDelegatedStake", delegatedStakeTraceKey(validatorTxID, ownerID), strconv.FormatUint(stake, 10), nil)
+}
+
+func (c *tracedChain) GetRewardAddress(ownerID ids.ID) (ids.ShortID, error) {
+	addr, err := c.chain.GetRewardAddress(ownerID)
+	c.read("GetRewardAddress", ownerID.String(), addr.String(), err)
+	return addr, err
+}
+
+func (c *tracedChain) SetRewardAddress(ownerID ids.ID, addr ids.ShortID) {
+	c.chain.SetRewardAddress(ownerID, addr)
+	c.write("SetRewardAddress", ownerID.String(), addr.String(), nil)
+}
+
+func (c *tracedChain) GetParameterChanges(parameter txs.Parameter) ([]ParameterChange, error) {
+	changes, err := c.chain.GetParameterChanges(parameter)
+	c.read("GetParameterChanges", parameter.String(), strconv.Itoa(len(changes)), err)
+	return changes, err
+}
+
+func (c *tracedChain) SetParameterChanges(parameter txs.Parameter, changes []ParameterChange) {
+	c.chain.SetParameterChanges(parameter, changes)
+	c.write("SetParameterChanges", parameter.String(), strconv.Itoa(len(changes)), nil)
+}
+
+func (c *tracedChain) GetSubnetTransformation(subnetID ids.ID) (*txs.Tx, error) {
+	tx, err := c.chain.GetSubnetTransformation(subnetID)
+	var value string
+	if tx != nil {
+		value = tx.ID().String()
+	}
+	c.read("GetSubnetTransformation", subnetID.String(), value, err)
+	return tx, err
+}
+
+func (c *tracedChain) AddSubnetTransformation(transformSubnetTx *txs.Tx) {
+	c.chain.AddSubnetTransformation(transformSubnetTx)
+	c.write("AddSubnetTransformation", transformSubnetTx.ID().String(), "", nil)
+}
+
+func (c *tracedChain) AddChain(createChainTx *txs.Tx) {
+	c.chain.AddChain(createChainTx)
+	c.write("AddChain", createChainTx.ID().String(), "", nil)
+}
+
+func (c *tracedChain) GetTx(txID ids.ID) (*txs.Tx, status.Status, error) {
+	tx, status, err := c.chain.GetTx(txID)
+	c.read("GetTx", txID.String(), status.String(), err)
+	return tx, status, err
+}
+
+func (c *tracedChain) AddTx(tx *txs.Tx, status status.Status) {
+	c.chain.AddTx(tx, status)
+	c.write("AddTx", tx.ID().String(), status.String(), nil)
+}
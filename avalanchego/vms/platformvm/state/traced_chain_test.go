@@ -0,0 +1,86 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+func TestTracedDiff(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	var accesses []Access
+	record := func(access Access) {
+		accesses = append(accesses, access)
+	}
+
+	lastAcceptedID := ids.GenerateTestID()
+	state := newInitializedState(require)
+	versions := NewMockVersions(ctrl)
+	versions.EXPECT().GetState(lastAcceptedID).AnyTimes().Return(
+		NewTracedChain(state, "parent", record),
+		true,
+	)
+
+	d, err := NewDiff(lastAcceptedID, versions)
+	require.NoError(err)
+	d = NewTracedDiff(d, "diff", record)
+
+	// Creating the diff reads from the parent state
+	require.Len(accesses, 2)
+	accesses = nil
+
+	supply, err := d.GetCurrentSupply(constants.PrimaryNetworkID)
+	require.NoError(err)
+	d.SetCurrentSupply(constants.PrimaryNetworkID, supply+1)
+
+	_, err = d.GetUTXO(ids.Empty)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	require.Equal(
+		[]Access{
+			{
+				Scope:  "parent",
+				Method: "GetCurrentSupply",
+				Key:    constants.PrimaryNetworkID.String(),
+				Value:  strconv.FormatUint(supply, 10),
+			},
+			{
+				Scope:  "diff",
+				Method: "GetCurrentSupply",
+				Key:    constants.PrimaryNetworkID.String(),
+				Value:  strconv.FormatUint(supply, 10),
+			},
+			{
+				Scope:  "diff",
+				Method: "SetCurrentSupply",
+				Write:  true,
+				Key:    constants.PrimaryNetworkID.String(),
+				Value:  strconv.FormatUint(supply+1, 10),
+			},
+			{
+				Scope:  "parent",
+				Method: "GetUTXO",
+				Key:    ids.Empty.String(),
+				Error:  database.ErrNotFound.Error(),
+			},
+			{
+				Scope:  "diff",
+				Method: "GetUTXO",
+				Key:    ids.Empty.String(),
+				Error:  database.ErrNotFound.Error(),
+			},
+		},
+		accesses,
+	)
+}
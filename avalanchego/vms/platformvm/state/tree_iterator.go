@@ -5,6 +5,7 @@ package state
 
 import (
 	"sync"
+	"time"
 
 	"github.com/google/btree"
 )
@@ -27,6 +28,28 @@ func NewTreeIterator(tree *btree.BTreeG[*Staker]) StakerIterator {
 	if tree == nil {
 		return EmptyIterator
 	}
+	return newTreeIterator(tree.Ascend)
+}
+
+// NewTreeIteratorUntil returns a new iterator of the stakers in [tree] whose
+// NextTime is not after [timestamp], in ascending order. The stakers after
+// [timestamp] are never visited. Note that it isn't safe to modify [tree]
+// while iterating over it.
+func NewTreeIteratorUntil(tree *btree.BTreeG[*Staker], timestamp time.Time) StakerIterator {
+	if tree == nil {
+		return EmptyIterator
+	}
+	// [pivot] sorts before every staker with a later NextTime than
+	// [timestamp], and after every other staker.
+	pivot := &Staker{
+		NextTime: timestamp.Add(time.Nanosecond),
+	}
+	return newTreeIterator(func(iterator btree.ItemIteratorG[*Staker]) {
+		tree.AscendLessThan(pivot, iterator)
+	})
+}
+
+func newTreeIterator(ascend func(btree.ItemIteratorG[*Staker])) StakerIterator {
 	it := &treeIterator{
 		next:    make(chan *Staker),
 		release: make(chan struct{}),
@@ -34,7 +57,7 @@ func NewTreeIterator(tree *btree.BTreeG[*Staker]) StakerIterator {
 	it.wg.Add(1)
 	go func() {
 		defer it.wg.Done()
-		tree.Ascend(func(i *Staker) bool {
+		ascend(func(i *Staker) bool {
 			select {
 			case it.next <- i:
 				return true
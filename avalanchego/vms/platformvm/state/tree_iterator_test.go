@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 )
 
 func TestTreeIterator(t *testing.T) {
@@ -50,6 +51,42 @@ func TestTreeIteratorNil(t *testing.T) {
 	it.Release()
 }
 
+func TestTreeIteratorUntil(t *testing.T) {
+	require := require.New(t)
+	stakers := []*Staker{
+		{
+			TxID:     ids.GenerateTestID(),
+			NextTime: time.Unix(0, 0),
+		},
+		{
+			TxID:     ids.GenerateTestID(),
+			NextTime: time.Unix(1, 0),
+			Priority: txs.PrimaryNetworkValidatorPendingPriority,
+		},
+		{
+			TxID:     ids.GenerateTestID(),
+			NextTime: time.Unix(2, 0),
+		},
+	}
+
+	tree := btree.NewG(defaultTreeDegree, (*Staker).Less)
+	for _, staker := range stakers {
+		require.Nil(tree.ReplaceOrInsert(staker))
+	}
+
+	it := NewTreeIteratorUntil(tree, time.Unix(1, 0))
+	for _, staker := range stakers[:2] {
+		require.True(it.Next())
+		require.Equal(staker, it.Value())
+	}
+	require.False(it.Next())
+	it.Release()
+
+	it = NewTreeIteratorUntil(nil, time.Unix(1, 0))
+	require.False(it.Next())
+	it.Release()
+}
+
 func TestTreeIteratorEarlyRelease(t *testing.T) {
 	require := require.New(t)
 	stakers := []*Staker{
@@ -0,0 +1,76 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/platformvm/validatordiffs"
+)
+
+// ValidatorDiffs are the raw changes of a validator set that were applied at a
+// height.
+type ValidatorDiffs struct {
+	// WeightDiffs maps the validators whose weight changed at the height to
+	// the change of their weight.
+	WeightDiffs map[ids.NodeID]*ValidatorWeightDiff
+	// PublicKeyDiffs maps the validators whose public key changed at the
+	// height to the uncompressed public key they had before the height. The
+	// public key is empty if the validator registered it at the height.
+	PublicKeyDiffs map[ids.NodeID][]byte
+}
+
+func (s *state) GetValidatorDiffs(subnetID ids.ID, height uint64) (*ValidatorDiffs, error) {
+	diffs := &ValidatorDiffs{
+		WeightDiffs:    make(map[ids.NodeID]*ValidatorWeightDiff),
+		PublicKeyDiffs: make(map[ids.NodeID][]byte),
+	}
+
+	prefix := validatordiffs.MarshalStartKey(subnetID, height)
+	weightIter := s.flatValidatorWeightDiffsDB.NewIteratorWithPrefix(prefix)
+	defer weightIter.Release()
+
+	for weightIter.Next() {
+		_, _, nodeID, err := validatordiffs.UnmarshalKey(weightIter.Key())
+		if err != nil {
+			return nil, err
+		}
+		weightDiff, err := unmarshalWeightDiff(weightIter.Value())
+		if err != nil {
+			return nil, err
+		}
+		diffs.WeightDiffs[nodeID] = weightDiff
+	}
+	if err := weightIter.Error(); err != nil {
+		return nil, err
+	}
+
+	// Invariant: Only the Primary Network contains non-nil public keys.
+	if subnetID != constants.PrimaryNetworkID {
+		return diffs, nil
+	}
+
+	pkIter := s.flatValidatorPublicKeyDiffsDB.NewIteratorWithPrefix(prefix)
+	defer pkIter.Release()
+
+	for pkIter.Next() {
+		_, _, nodeID, err := validatordiffs.UnmarshalKey(pkIter.Key())
+		if err != nil {
+			return nil, err
+		}
+		diffs.PublicKeyDiffs[nodeID] = pkIter.Value()
+	}
+	return diffs, pkIter.Error()
+}
+
+func unmarshalWeightDiff(value []byte) (*ValidatorWeightDiff, error) {
+	decrease, amount, err := validatordiffs.UnmarshalWeightDiff(value)
+	if err != nil {
+		return nil, err
+	}
+	return &ValidatorWeightDiff{
+		Decrease: decrease,
+		Amount:   amount,
+	}, nil
+}
@@ -0,0 +1,99 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+)
+
+// checkpointKey = [subnetID] + [height]
+//
+// Unlike the diff keys, the height is not inverted so that iterating from
+// [height] returns the closest checkpoint at or above [height].
+const checkpointKeyLength = ids.IDLen + database.Uint64Size
+
+var errUnexpectedCheckpointKeyLength = fmt.Errorf("expected checkpoint key length %d", checkpointKeyLength)
+
+type validatorSetCheckpoint struct {
+	Validators []checkpointValidator `serialize:"true"`
+}
+
+type checkpointValidator struct {
+	NodeID ids.NodeID `serialize:"true"`
+	// PublicKey is the uncompressed public key of the validator or empty if
+	// the validator didn't have a registered key at the checkpointed height.
+	PublicKey []byte `serialize:"true"`
+	Weight    uint64 `serialize:"true"`
+}
+
+func marshalCheckpointKey(subnetID ids.ID, height uint64) []byte {
+	key := make([]byte, checkpointKeyLength)
+	copy(key, subnetID[:])
+	copy(key[ids.IDLen:], database.PackUInt64(height))
+	return key
+}
+
+func unmarshalCheckpointKey(key []byte) (ids.ID, uint64, error) {
+	if len(key) != checkpointKeyLength {
+		return ids.Empty, 0, errUnexpectedCheckpointKeyLength
+	}
+	var subnetID ids.ID
+	copy(subnetID[:], key)
+	height, err := database.ParseUInt64(key[ids.IDLen:])
+	return subnetID, height, err
+}
+
+// marshalValidatorSetCheckpoint serializes [vdrs] sorted by nodeID. Public keys
+// are taken from [primaryVdrs], as only the Primary Network tracks them.
+func marshalValidatorSetCheckpoint(
+	vdrs map[ids.NodeID]*validators.GetValidatorOutput,
+	primaryVdrs map[ids.NodeID]*validators.GetValidatorOutput,
+) ([]byte, error) {
+	nodeIDs := make([]ids.NodeID, 0, len(vdrs))
+	for nodeID := range vdrs {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	utils.Sort(nodeIDs)
+
+	checkpoint := validatorSetCheckpoint{
+		Validators: make([]checkpointValidator, len(nodeIDs)),
+	}
+	for i, nodeID := range nodeIDs {
+		checkpoint.Validators[i] = checkpointValidator{
+			NodeID: nodeID,
+			Weight: vdrs[nodeID].Weight,
+		}
+		if primaryVdr, ok := primaryVdrs[nodeID]; ok && primaryVdr.PublicKey != nil {
+			checkpoint.Validators[i].PublicKey = bls.SerializePublicKey(primaryVdr.PublicKey)
+		}
+	}
+	return block.GenesisCodec.Marshal(block.CodecVersion, &checkpoint)
+}
+
+func unmarshalValidatorSetCheckpoint(b []byte) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	var checkpoint validatorSetCheckpoint
+	if _, err := block.GenesisCodec.Unmarshal(b, &checkpoint); err != nil {
+		return nil, err
+	}
+
+	vdrs := make(map[ids.NodeID]*validators.GetValidatorOutput, len(checkpoint.Validators))
+	for _, vdr := range checkpoint.Validators {
+		output := &validators.GetValidatorOutput{
+			NodeID: vdr.NodeID,
+			Weight: vdr.Weight,
+		}
+		if len(vdr.PublicKey) != 0 {
+			output.PublicKey = bls.DeserializePublicKey(vdr.PublicKey)
+		}
+		vdrs[vdr.NodeID] = output
+	}
+	return vdrs, nil
+}
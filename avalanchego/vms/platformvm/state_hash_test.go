@@ -0,0 +1,195 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	smcon "github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	blockexecutor "github.com/ava-labs/avalanchego/vms/platformvm/block/executor"
+	txexecutor "github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+)
+
+var updateStateHashes = flag.Bool("update-state-hashes", false, "overwrite the golden state hashes")
+
+const stateHashesFile = "state_hashes.golden"
+
+// TestStateHashes executes a fixed sequence of state transitions and compares
+// the hash of the state after every transition against the golden file. Any
+// change to the state transitions, intended or not, changes the hashes.
+//
+// After verifying that a change to the hashes is intended, the golden file is
+// regenerated with:
+//
+//	go test ./vms/platformvm -run TestStateHashes -update-state-hashes
+func TestStateHashes(t *testing.T) {
+	require := require.New(t)
+	vm, _, _ := defaultVM(t, latestFork)
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	issueAndAccept := func(tx *txs.Tx, err error) {
+		require.NoError(err)
+
+		vm.ctx.Lock.Unlock()
+		require.NoError(vm.issueTx(context.Background(), tx))
+		vm.ctx.Lock.Lock()
+		buildAndAccept(require, vm)
+	}
+
+	var (
+		startTime     = vm.clock.Time().Add(txexecutor.SyncBound).Add(1)
+		endTime       = startTime.Add(defaultMinStakingDuration)
+		recipientAddr = keys[4].Address()
+	)
+	steps := []struct {
+		name string
+		run  func()
+	}{
+		{
+			name: "genesis",
+			run:  func() {},
+		},
+		{
+			name: "add_subnet_validator",
+			run: func() {
+				issueAndAccept(vm.txBuilder.NewAddSubnetValidatorTx(
+					defaultWeight,
+					uint64(startTime.Unix()),
+					uint64(endTime.Unix()),
+					genesisNodeIDs[0],
+					testSubnet1.ID(),
+					secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
+					keys[0].Address(),
+					nil,
+				))
+			},
+		},
+		{
+			name: "set_subnet_validator_weight",
+			run: func() {
+				issueAndAccept(vm.txBuilder.NewSetSubnetValidatorWeightTx(
+					genesisNodeIDs[0],
+					testSubnet1.ID(),
+					2*defaultWeight,
+					secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
+					keys[0].Address(),
+					nil,
+				))
+			},
+		},
+		{
+			name: "create_chain",
+			run: func() {
+				issueAndAccept(vm.txBuilder.NewCreateChainTx(
+					testSubnet1.ID(),
+					nil,
+					ids.ID{'t', 'e', 's', 't', 'v', 'm'},
+					nil,
+					"name",
+					secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
+					keys[0].Address(),
+					nil,
+				))
+			},
+		},
+		{
+			name: "base_tx",
+			run: func() {
+				issueAndAccept(vm.txBuilder.NewBaseTx(
+					defaultMinValidatorStake,
+					secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{recipientAddr},
+					},
+					secp256k1fx.NewKeychain(keys[3]),
+					keys[3].Address(),
+					nil,
+				))
+			},
+		},
+		{
+			name: "export_tx",
+			run: func() {
+				issueAndAccept(vm.txBuilder.NewExportTx(
+					defaultMinValidatorStake,
+					vm.ctx.XChainID,
+					recipientAddr,
+					secp256k1fx.NewKeychain(keys[2]),
+					keys[2].Address(),
+					nil,
+				))
+			},
+		},
+		{
+			name: "reward_validator",
+			run: func() {
+				// Advance to the end of the genesis validators' staking period
+				// and reward the first of them.
+				vm.clock.Set(defaultValidateEndTime)
+				buildAndAccept(require, vm)
+			},
+		},
+	}
+
+	var hashes strings.Builder
+	for _, step := range steps {
+		step.run()
+
+		require.NoError(vm.state.Commit())
+		hash, err := vm.state.Hash()
+		require.NoError(err)
+		fmt.Fprintf(&hashes, "%s %s\n", step.name, hash)
+	}
+
+	if *updateStateHashes {
+		require.NoError(os.WriteFile(stateHashesFile, []byte(hashes.String()), 0o600))
+		return
+	}
+
+	expected, err := os.ReadFile(stateHashesFile)
+	require.NoError(err)
+	require.Equal(string(expected), hashes.String())
+}
+
+// buildAndAccept builds the next block and accepts it.
+func buildAndAccept(require *require.Assertions, vm *VM) {
+	blk, err := vm.Builder.BuildBlock(context.Background())
+	require.NoError(err)
+	accept(require, vm, blk)
+}
+
+// accept [blk]. If [blk] is a proposal block, its commit option is accepted as
+// well.
+func accept(require *require.Assertions, vm *VM, blk smcon.Block) {
+	require.NoError(blk.Verify(context.Background()))
+
+	options, err := blk.(smcon.OracleBlock).Options(context.Background())
+	if errors.Is(err, smcon.ErrNotOracle) {
+		require.NoError(blk.Accept(context.Background()))
+		require.NoError(vm.SetPreference(context.Background(), vm.manager.LastAccepted()))
+		return
+	}
+	require.NoError(err)
+	commit := options[0].(*blockexecutor.Block)
+	require.IsType(&block.BanffCommitBlock{}, commit.Block)
+	require.NoError(commit.Verify(context.Background()))
+
+	require.NoError(blk.Accept(context.Background()))
+	require.NoError(commit.Accept(context.Background()))
+	require.NoError(vm.SetPreference(context.Background(), vm.manager.LastAccepted()))
+}
@@ -0,0 +1,120 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
+	"github.com/ava-labs/avalanchego/vms/platformvm/network"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/mempool"
+	"github.com/ava-labs/avalanchego/vms/platformvm/utxo"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	blockexecutor "github.com/ava-labs/avalanchego/vms/platformvm/block/executor"
+	txexecutor "github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+)
+
+// Reasons the mempool_tx_rejections metric is labeled with
+const (
+	rejectionConflict         = "conflict"
+	rejectionFeeTooLow        = "feeTooLow"
+	rejectionOverDelegated    = "overDelegated"
+	rejectionStartTimeTooSoon = "startTimeTooSoon"
+	rejectionInvalidSignature = "invalidSignature"
+	rejectionMempoolFull      = "mempoolFull"
+	rejectionPolicy           = "policy"
+	rejectionOther            = "other"
+)
+
+var (
+	_ mempool.Mempool    = (*rejectionMeter)(nil)
+	_ network.TxVerifier = (*invalidTxVerifier)(nil)
+)
+
+// rejectionMeter counts the txs that the network fails to admit into the
+// mempool, by the reason they were rejected for.
+//
+// Invariant: The rejectionMeter must only be handed to the network, so that
+// the txs dropped by the block builder and the block verifier aren't counted.
+type rejectionMeter struct {
+	mempool.Mempool
+	metrics metrics.Metrics
+}
+
+func (r *rejectionMeter) MarkDropped(txID ids.ID, reason error) {
+	r.Mempool.MarkDropped(txID, reason)
+	r.metrics.IncTxRejections(rejectionReason(reason))
+}
+
+// rejectionReason classifies the error a tx was rejected from the mempool
+// with. Errors are matched against the most specific classes first, so that a
+// rejection by policy is reported by its underlying reason when it has one.
+func rejectionReason(err error) string {
+	switch {
+	case errors.Is(err, mempool.ErrConflictsWithOtherTx),
+		errors.Is(err, blockexecutor.ErrConflictingProcessingTx):
+		return rejectionConflict
+	case errors.Is(err, utxo.ErrInsufficientFunds),
+		errors.Is(err, utxo.ErrInsufficientUnlockedFunds),
+		errors.Is(err, avax.ErrInsufficientFunds):
+		return rejectionFeeTooLow
+	case errors.Is(err, txexecutor.ErrOverDelegated),
+		errors.Is(err, mempool.ErrInsufficientDelegationCapacity):
+		return rejectionOverDelegated
+	case errors.Is(err, txexecutor.ErrTimestampNotBeforeStartTime):
+		return rejectionStartTimeTooSoon
+	case isInvalidSignature(err):
+		return rejectionInvalidSignature
+	case errors.Is(err, mempool.ErrMempoolFull):
+		return rejectionMempoolFull
+	case errors.Is(err, network.ErrTxRejectedByPolicy),
+		errors.Is(err, network.ErrTxTypeDisabled):
+		return rejectionPolicy
+	default:
+		return rejectionOther
+	}
+}
+
+// invalidTxVerifier wraps the errors of the txs that fail syntactic or
+// signature checks with [network.ErrInvalidTx], so that the peers gossiping
+// them are penalized.
+type invalidTxVerifier struct {
+	ctx        *snow.Context
+	txVerifier network.TxVerifier
+}
+
+func (v *invalidTxVerifier) VerifyTx(tx *txs.Tx) error {
+	if err := tx.SyntacticVerify(v.ctx); err != nil {
+		return fmt.Errorf("%w: %w", network.ErrInvalidTx, err)
+	}
+
+	err := v.txVerifier.VerifyTx(tx)
+	if err == nil || isStateDependent(err) || !isInvalidSignature(err) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", network.ErrInvalidTx, err)
+}
+
+// isStateDependent returns true if [err] may be caused by the state of this
+// node rather than by the tx, so that other nodes may consider the tx valid.
+func isStateDependent(err error) bool {
+	return errors.Is(err, blockexecutor.ErrChainNotSynced) ||
+		errors.Is(err, blockexecutor.ErrConflictingProcessingTx) ||
+		errors.Is(err, database.ErrNotFound) || // Missing UTXOs
+		errors.Is(err, txexecutor.ErrValidatorNotAllowlisted)
+}
+
+func isInvalidSignature(err error) bool {
+	return errors.Is(err, secp256k1fx.ErrWrongSig) ||
+		errors.Is(err, secp256k1fx.ErrTooFewSigners) ||
+		errors.Is(err, secp256k1fx.ErrTooManySigners) ||
+		errors.Is(err, secp256k1fx.ErrInputCredentialSignersMismatch)
+}
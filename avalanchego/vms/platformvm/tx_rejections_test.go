@@ -0,0 +1,186 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/network"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/mempool"
+	"github.com/ava-labs/avalanchego/vms/platformvm/utxo"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	blockexecutor "github.com/ava-labs/avalanchego/vms/platformvm/block/executor"
+	txexecutor "github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+)
+
+func TestRejectionReason(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{
+			name:     "conflict with mempool tx",
+			err:      mempool.ErrConflictsWithOtherTx,
+			expected: rejectionConflict,
+		},
+		{
+			name:     "conflict with processing tx",
+			err:      fmt.Errorf("%w: %w", txexecutor.ErrFlowCheckFailed, blockexecutor.ErrConflictingProcessingTx),
+			expected: rejectionConflict,
+		},
+		{
+			name:     "insufficient funds for the fee",
+			err:      fmt.Errorf("%w: %w", txexecutor.ErrFlowCheckFailed, utxo.ErrInsufficientUnlockedFunds),
+			expected: rejectionFeeTooLow,
+		},
+		{
+			name:     "over delegated",
+			err:      fmt.Errorf("%w: %w", txexecutor.ErrOverDelegated, errors.New("peak weight")),
+			expected: rejectionOverDelegated,
+		},
+		{
+			name:     "delegation capacity reserved",
+			err:      fmt.Errorf("%w: %w", network.ErrTxRejectedByPolicy, mempool.ErrInsufficientDelegationCapacity),
+			expected: rejectionOverDelegated,
+		},
+		{
+			name:     "start time too soon",
+			err:      txexecutor.ErrTimestampNotBeforeStartTime,
+			expected: rejectionStartTimeTooSoon,
+		},
+		{
+			name:     "invalid signature",
+			err:      fmt.Errorf("%w: failed to verify transfer: %w", txexecutor.ErrFlowCheckFailed, secp256k1fx.ErrWrongSig),
+			expected: rejectionInvalidSignature,
+		},
+		{
+			name:     "mempool full",
+			err:      mempool.ErrMempoolFull,
+			expected: rejectionMempoolFull,
+		},
+		{
+			name:     "policy",
+			err:      fmt.Errorf("%w: %w", network.ErrTxRejectedByPolicy, errReadReplica),
+			expected: rejectionPolicy,
+		},
+		{
+			name:     "other",
+			err:      txexecutor.ErrStakeOverflow,
+			expected: rejectionOther,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, rejectionReason(test.err))
+		})
+	}
+}
+
+type testTxVerifier struct {
+	err error
+}
+
+func (v testTxVerifier) VerifyTx(*txs.Tx) error {
+	return v.err
+}
+
+func TestInvalidTxVerifier(t *testing.T) {
+	ctx := &snow.Context{
+		NetworkID: 1337,
+		ChainID:   ids.GenerateTestID(),
+	}
+	newTx := func(t *testing.T, networkID uint32) *txs.Tx {
+		tx := &txs.Tx{
+			Unsigned: &txs.BaseTx{
+				BaseTx: avax.BaseTx{
+					NetworkID:    networkID,
+					BlockchainID: ctx.ChainID,
+				},
+			},
+		}
+		require.NoError(t, tx.Initialize(txs.Codec))
+		return tx
+	}
+
+	errFoo := errors.New("foo")
+	tests := []struct {
+		name            string
+		networkID       uint32
+		err             error
+		expectedErr     error
+		expectedInvalid bool
+	}{
+		{
+			name:      "valid",
+			networkID: ctx.NetworkID,
+		},
+		{
+			name:            "syntactically invalid",
+			networkID:       ctx.NetworkID + 1,
+			expectedErr:     avax.ErrWrongNetworkID,
+			expectedInvalid: true,
+		},
+		{
+			name:            "invalid signature",
+			networkID:       ctx.NetworkID,
+			err:             fmt.Errorf("%w: failed to verify transfer: %w", txexecutor.ErrFlowCheckFailed, secp256k1fx.ErrWrongSig),
+			expectedErr:     secp256k1fx.ErrWrongSig,
+			expectedInvalid: true,
+		},
+		{
+			name:        "chain not synced",
+			networkID:   ctx.NetworkID,
+			err:         blockexecutor.ErrChainNotSynced,
+			expectedErr: blockexecutor.ErrChainNotSynced,
+		},
+		{
+			name:        "conflict with processing tx",
+			networkID:   ctx.NetworkID,
+			err:         fmt.Errorf("%w: %w", txexecutor.ErrFlowCheckFailed, blockexecutor.ErrConflictingProcessingTx),
+			expectedErr: blockexecutor.ErrConflictingProcessingTx,
+		},
+		{
+			name:        "missing UTXO",
+			networkID:   ctx.NetworkID,
+			err:         fmt.Errorf("%w: failed to read consumed UTXO: %w", txexecutor.ErrFlowCheckFailed, database.ErrNotFound),
+			expectedErr: database.ErrNotFound,
+		},
+		{
+			name:        "validator not allowlisted",
+			networkID:   ctx.NetworkID,
+			err:         fmt.Errorf("%w: %w", txexecutor.ErrValidatorNotAllowlisted, secp256k1fx.ErrWrongSig),
+			expectedErr: txexecutor.ErrValidatorNotAllowlisted,
+		},
+		{
+			name:        "other",
+			networkID:   ctx.NetworkID,
+			err:         errFoo,
+			expectedErr: errFoo,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			verifier := &invalidTxVerifier{
+				ctx:        ctx,
+				txVerifier: testTxVerifier{err: test.err},
+			}
+			err := verifier.VerifyTx(newTx(t, test.networkID))
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedInvalid, errors.Is(err, network.ErrInvalidTx))
+		})
+	}
+}
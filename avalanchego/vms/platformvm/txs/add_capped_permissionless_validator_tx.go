@@ -0,0 +1,43 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/snow"
+)
+
+var (
+	_ ValidatorTx = (*AddCappedPermissionlessValidatorTx)(nil)
+
+	errNoMaxDelegatorStake = errors.New("max delegator stake must be non-zero")
+)
+
+// AddCappedPermissionlessValidatorTx is an [AddPermissionlessValidatorTx] that
+// additionally limits the stake any single delegator can delegate to the
+// validator. Delegations are attributed to a delegator by their rewards owner.
+type AddCappedPermissionlessValidatorTx struct {
+	AddPermissionlessValidatorTx `serialize:"true"`
+	// Maximum amount of stake the delegations sharing the same rewards owner
+	// can add to this validator
+	MaxDelegatorStake uint64 `serialize:"true" json:"maxDelegatorStake"`
+}
+
+func (tx *AddCappedPermissionlessValidatorTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified:
+		// already passed syntactic verification
+		return nil
+	case tx.MaxDelegatorStake == 0:
+		return errNoMaxDelegatorStake
+	}
+	return tx.AddPermissionlessValidatorTx.SyntacticVerify(ctx)
+}
+
+func (tx *AddCappedPermissionlessValidatorTx) Visit(visitor Visitor) error {
+	return visitor.AddCappedPermissionlessValidatorTx(tx)
+}
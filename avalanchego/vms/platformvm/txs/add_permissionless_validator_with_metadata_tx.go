@@ -0,0 +1,77 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+)
+
+// MaxMetadataURLLen is the max length of the URL of validator metadata
+const MaxMetadataURLLen = 256
+
+var (
+	_ ValidatorTx = (*AddPermissionlessValidatorWithMetadataTx)(nil)
+
+	ErrInvalidMetadataURL = errors.New("invalid metadata URL")
+
+	errEmptyMetadataHash = errors.New("metadata hash must be non-empty")
+)
+
+// AddPermissionlessValidatorWithMetadataTx is an
+// [AddPermissionlessValidatorTx] that additionally commits to metadata the
+// validator reports about itself, such as its logo and website. The metadata
+// is a JSON document served at [MetadataURL] whose SHA-256 hash is
+// [MetadataHash].
+type AddPermissionlessValidatorWithMetadataTx struct {
+	AddPermissionlessValidatorTx `serialize:"true"`
+	// Where the metadata is served. Must be an http or https URL.
+	MetadataURL string `serialize:"true" json:"metadataURL"`
+	// SHA-256 hash of the metadata
+	MetadataHash ids.ID `serialize:"true" json:"metadataHash"`
+}
+
+func (tx *AddPermissionlessValidatorWithMetadataTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified:
+		// already passed syntactic verification
+		return nil
+	case tx.MetadataHash == ids.Empty:
+		return errEmptyMetadataHash
+	}
+
+	if err := VerifyMetadataURL(tx.MetadataURL); err != nil {
+		return err
+	}
+	return tx.AddPermissionlessValidatorTx.SyntacticVerify(ctx)
+}
+
+func (tx *AddPermissionlessValidatorWithMetadataTx) Visit(visitor Visitor) error {
+	return visitor.AddPermissionlessValidatorWithMetadataTx(tx)
+}
+
+// VerifyMetadataURL returns an error if [rawURL] isn't an absolute http or
+// https URL of at most [MaxMetadataURLLen] bytes.
+func VerifyMetadataURL(rawURL string) error {
+	if len(rawURL) > MaxMetadataURLLen {
+		return fmt.Errorf("%w: length %d exceeds %d", ErrInvalidMetadataURL, len(rawURL), MaxMetadataURLLen)
+	}
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidMetadataURL, err)
+	}
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		return fmt.Errorf("%w: unsupported scheme %q", ErrInvalidMetadataURL, parsedURL.Scheme)
+	}
+	if parsedURL.Host == "" {
+		return fmt.Errorf("%w: missing host", ErrInvalidMetadataURL)
+	}
+	return nil
+}
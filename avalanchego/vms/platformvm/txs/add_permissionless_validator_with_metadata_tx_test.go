@@ -0,0 +1,64 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestAddPermissionlessValidatorWithMetadataTxSyntacticVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		tx          *AddPermissionlessValidatorWithMetadataTx
+		expectedErr error
+	}{
+		{
+			name:        "nil tx",
+			tx:          nil,
+			expectedErr: ErrNilTx,
+		},
+		{
+			name: "empty metadata hash",
+			tx: &AddPermissionlessValidatorWithMetadataTx{
+				MetadataURL: "https://example.com/validator.json",
+			},
+			expectedErr: errEmptyMetadataHash,
+		},
+		{
+			name: "unsupported scheme",
+			tx: &AddPermissionlessValidatorWithMetadataTx{
+				MetadataURL:  "ftp://example.com/validator.json",
+				MetadataHash: ids.GenerateTestID(),
+			},
+			expectedErr: ErrInvalidMetadataURL,
+		},
+		{
+			name: "missing host",
+			tx: &AddPermissionlessValidatorWithMetadataTx{
+				MetadataURL:  "https:///validator.json",
+				MetadataHash: ids.GenerateTestID(),
+			},
+			expectedErr: ErrInvalidMetadataURL,
+		},
+		{
+			name: "url too long",
+			tx: &AddPermissionlessValidatorWithMetadataTx{
+				MetadataURL:  "https://example.com/" + strings.Repeat("a", MaxMetadataURLLen),
+				MetadataHash: ids.GenerateTestID(),
+			},
+			expectedErr: ErrInvalidMetadataURL,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.tx.SyntacticVerify(nil)
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
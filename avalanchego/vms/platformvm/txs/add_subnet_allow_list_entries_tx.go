@@ -0,0 +1,99 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+)
+
+// MaxAllowListEntries is the maximum number of nodeIDs that can be added to or
+// removed from a subnet allow list in a single tx.
+const MaxAllowListEntries = 256
+
+var (
+	_ UnsignedTx = (*AddSubnetAllowListEntriesTx)(nil)
+
+	ErrPrimaryNetworkAllowList = errors.New("primary network doesn't have an allow list")
+
+	errNoAllowListEntries              = errors.New("no allow list entries")
+	errTooManyAllowListEntries         = errors.New("too many allow list entries")
+	errAllowListEntriesNotSortedUnique = errors.New("allow list entries not sorted and unique")
+	errEmptyNodeIDInAllowListEntries   = errors.New("allow list entries contain the empty nodeID")
+	errNoAllowListMaxWeight            = errors.New("allow list entries have no max weight")
+)
+
+// AddSubnetAllowListEntriesTx adds nodes to the allow list of a subnet. The
+// nodes on the allow list of a subnet can be added as validators of the subnet
+// with a weight of at most MaxWeight by NodeOwner, rather than by the subnet
+// owner.
+type AddSubnetAllowListEntriesTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// ID of the subnet this tx is modifying
+	Subnet ids.ID `serialize:"true" json:"subnetID"`
+	// Nodes to add to the allow list, sorted and unique
+	NodeIDs []ids.NodeID `serialize:"true" json:"nodeIDs"`
+	// Maximum weight the nodes can be added with without the authorization
+	// of the subnet owner
+	MaxWeight uint64 `serialize:"true" json:"maxWeight"`
+	// Who is authorized to add the nodes as validators of the subnet
+	NodeOwner fx.Owner `serialize:"true" json:"nodeOwner"`
+	// Proves that the issuer has the right to modify the allow list.
+	SubnetAuth verify.Verifiable `serialize:"true" json:"subnetAuthorization"`
+}
+
+func (tx *AddSubnetAllowListEntriesTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified:
+		// already passed syntactic verification
+		return nil
+	case tx.Subnet == constants.PrimaryNetworkID:
+		return ErrPrimaryNetworkAllowList
+	case tx.MaxWeight == 0:
+		return errNoAllowListMaxWeight
+	}
+
+	if err := verifyAllowListEntries(tx.NodeIDs); err != nil {
+		return err
+	}
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := verify.All(tx.SubnetAuth, tx.NodeOwner); err != nil {
+		return err
+	}
+
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *AddSubnetAllowListEntriesTx) Visit(visitor Visitor) error {
+	return visitor.AddSubnetAllowListEntriesTx(tx)
+}
+
+func verifyAllowListEntries(nodeIDs []ids.NodeID) error {
+	switch {
+	case len(nodeIDs) == 0:
+		return errNoAllowListEntries
+	case len(nodeIDs) > MaxAllowListEntries:
+		return errTooManyAllowListEntries
+	case !utils.IsSortedAndUnique(nodeIDs):
+		return errAllowListEntriesNotSortedUnique
+	}
+	for _, nodeID := range nodeIDs {
+		if nodeID == ids.EmptyNodeID {
+			return errEmptyNodeIDInAllowListEntries
+		}
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestAddSubnetAllowListEntriesTxSyntacticVerify(t *testing.T) {
+	type test struct {
+		name        string
+		txFunc      func(*gomock.Controller) *AddSubnetAllowListEntriesTx
+		expectedErr error
+	}
+
+	var (
+		networkID = uint32(1337)
+		chainID   = ids.GenerateTestID()
+		nodeID0   = ids.BuildTestNodeID([]byte{0x01})
+		nodeID1   = ids.BuildTestNodeID([]byte{0x02})
+	)
+
+	ctx := &snow.Context{
+		ChainID:   chainID,
+		NetworkID: networkID,
+	}
+
+	// A BaseTx that passes syntactic verification.
+	validBaseTx := BaseTx{
+		BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		},
+	}
+
+	tests := []test{
+		{
+			name: "nil tx",
+			txFunc: func(*gomock.Controller) *AddSubnetAllowListEntriesTx {
+				return nil
+			},
+			expectedErr: ErrNilTx,
+		},
+		{
+			name: "primary network",
+			txFunc: func(*gomock.Controller) *AddSubnetAllowListEntriesTx {
+				return &AddSubnetAllowListEntriesTx{
+					BaseTx:  validBaseTx,
+					Subnet:  constants.PrimaryNetworkID,
+					NodeIDs: []ids.NodeID{nodeID0},
+				}
+			},
+			expectedErr: ErrPrimaryNetworkAllowList,
+		},
+		{
+			name: "no max weight",
+			txFunc: func(*gomock.Controller) *AddSubnetAllowListEntriesTx {
+				return &AddSubnetAllowListEntriesTx{
+					BaseTx:  validBaseTx,
+					Subnet:  ids.GenerateTestID(),
+					NodeIDs: []ids.NodeID{nodeID0},
+				}
+			},
+			expectedErr: errNoAllowListMaxWeight,
+		},
+		{
+			name: "no entries",
+			txFunc: func(*gomock.Controller) *AddSubnetAllowListEntriesTx {
+				return &AddSubnetAllowListEntriesTx{
+					BaseTx:    validBaseTx,
+					Subnet:    ids.GenerateTestID(),
+					MaxWeight: 1,
+				}
+			},
+			expectedErr: errNoAllowListEntries,
+		},
+		{
+			name: "too many entries",
+			txFunc: func(*gomock.Controller) *AddSubnetAllowListEntriesTx {
+				return &AddSubnetAllowListEntriesTx{
+					BaseTx:    validBaseTx,
+					Subnet:    ids.GenerateTestID(),
+					MaxWeight: 1,
+					NodeIDs:   make([]ids.NodeID, MaxAllowListEntries+1),
+				}
+			},
+			expectedErr: errTooManyAllowListEntries,
+		},
+		{
+			name: "unsorted entries",
+			txFunc: func(*gomock.Controller) *AddSubnetAllowListEntriesTx {
+				return &AddSubnetAllowListEntriesTx{
+					BaseTx:    validBaseTx,
+					Subnet:    ids.GenerateTestID(),
+					MaxWeight: 1,
+					NodeIDs:   []ids.NodeID{nodeID1, nodeID0},
+				}
+			},
+			expectedErr: errAllowListEntriesNotSortedUnique,
+		},
+		{
+			name: "empty nodeID",
+			txFunc: func(*gomock.Controller) *AddSubnetAllowListEntriesTx {
+				return &AddSubnetAllowListEntriesTx{
+					BaseTx:    validBaseTx,
+					Subnet:    ids.GenerateTestID(),
+					MaxWeight: 1,
+					NodeIDs:   []ids.NodeID{ids.EmptyNodeID, nodeID0},
+				}
+			},
+			expectedErr: errEmptyNodeIDInAllowListEntries,
+		},
+		{
+			name: "invalid subnetAuth",
+			txFunc: func(ctrl *gomock.Controller) *AddSubnetAllowListEntriesTx {
+				// This SubnetAuth fails verification.
+				invalidSubnetAuth := verify.NewMockVerifiable(ctrl)
+				invalidSubnetAuth.EXPECT().Verify().Return(errInvalidSubnetAuth)
+				return &AddSubnetAllowListEntriesTx{
+					BaseTx:     validBaseTx,
+					Subnet:     ids.GenerateTestID(),
+					MaxWeight:  1,
+					NodeIDs:    []ids.NodeID{nodeID0, nodeID1},
+					SubnetAuth: invalidSubnetAuth,
+					NodeOwner:  &secp256k1fx.OutputOwners{},
+				}
+			},
+			expectedErr: errInvalidSubnetAuth,
+		},
+		{
+			name: "invalid nodeOwner",
+			txFunc: func(ctrl *gomock.Controller) *AddSubnetAllowListEntriesTx {
+				validSubnetAuth := verify.NewMockVerifiable(ctrl)
+				validSubnetAuth.EXPECT().Verify().Return(nil)
+				return &AddSubnetAllowListEntriesTx{
+					BaseTx:     validBaseTx,
+					Subnet:     ids.GenerateTestID(),
+					MaxWeight:  1,
+					NodeIDs:    []ids.NodeID{nodeID0, nodeID1},
+					SubnetAuth: validSubnetAuth,
+					NodeOwner: &secp256k1fx.OutputOwners{
+						Threshold: 1,
+					},
+				}
+			},
+			expectedErr: secp256k1fx.ErrOutputUnspendable,
+		},
+		{
+			name: "passes verification",
+			txFunc: func(ctrl *gomock.Controller) *AddSubnetAllowListEntriesTx {
+				// This SubnetAuth passes verification.
+				validSubnetAuth := verify.NewMockVerifiable(ctrl)
+				validSubnetAuth.EXPECT().Verify().Return(nil)
+				return &AddSubnetAllowListEntriesTx{
+					BaseTx:     validBaseTx,
+					Subnet:     ids.GenerateTestID(),
+					MaxWeight:  1,
+					NodeIDs:    []ids.NodeID{nodeID0, nodeID1},
+					SubnetAuth: validSubnetAuth,
+					NodeOwner:  &secp256k1fx.OutputOwners{},
+				}
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			tx := tt.txFunc(ctrl)
+			err := tx.SyntacticVerify(ctx)
+			require.ErrorIs(err, tt.expectedErr)
+			if tt.expectedErr != nil {
+				return
+			}
+			require.True(tx.SyntacticallyVerified)
+		})
+	}
+}
@@ -55,6 +55,10 @@ func (tx *BaseTx) Outputs() []*avax.TransferableOutput {
 	return tx.Outs
 }
 
+func (tx *BaseTx) base() *BaseTx {
+	return tx
+}
+
 // InitCtx sets the FxID fields in the inputs and outputs of this [BaseTx]. Also
 // sets the [ctx] to the given [vm.ctx] so that the addresses can be json
 // marshalled into human readable format
@@ -0,0 +1,60 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+)
+
+var _ UnsignedTx = (*BindRewardAddressTx)(nil)
+
+// BindRewardAddressTx binds the rewards owner [Owner] to the C-chain address
+// [Address]. Once reward exports are activated, the rewards paid out to
+// [Owner] are exported to [Address] instead of being added to the UTXO set of
+// the P-chain. An empty [Address] removes the binding.
+type BindRewardAddressTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// Rewards owner whose rewards are exported
+	Owner fx.Owner `serialize:"true" json:"owner"`
+	// C-chain address that owns the exported rewards
+	Address ids.ShortID `serialize:"true" json:"address"`
+	// Proves that the issuer controls [Owner]
+	OwnerAuth verify.Verifiable `serialize:"true" json:"ownerAuthorization"`
+}
+
+// InitCtx sets the FxID fields in the inputs and outputs of this
+// [BindRewardAddressTx]. Also sets the [ctx] to the given [vm.ctx] so that
+// the addresses can be json marshalled into human readable format
+func (tx *BindRewardAddressTx) InitCtx(ctx *snow.Context) {
+	tx.BaseTx.InitCtx(ctx)
+	tx.Owner.InitCtx(ctx)
+}
+
+func (tx *BindRewardAddressTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified:
+		// already passed syntactic verification
+		return nil
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := verify.All(tx.Owner, tx.OwnerAuth); err != nil {
+		return err
+	}
+
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *BindRewardAddressTx) Visit(visitor Visitor) error {
+	return visitor.BindRewardAddressTx(tx)
+}
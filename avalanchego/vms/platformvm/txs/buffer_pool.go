@@ -0,0 +1,101 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	initialBufferSize = 16 * units.KiB
+	// Buffers that grew beyond this size aren't returned to the pool, so that
+	// a burst of large blocks doesn't pin large buffers in memory.
+	maxPooledBufferSize = 512 * units.KiB
+)
+
+// bufferPool holds the buffers that txs are marshalled into while they are
+// initialized. Marshalling into a reused buffer and copying the result out
+// once avoids repeatedly growing a new slice for every tx.
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		buf := make([]byte, 0, initialBufferSize)
+		return &buf
+	},
+}
+
+// pooledCodec is a codec registered in [Codec] or [GenesisCodec], along with
+// the max size of the values marshalled by the manager.
+type pooledCodec struct {
+	codec   codec.Codec
+	maxSize int
+}
+
+// marshalTxs returns the signed bytes of each of [txs] and the length of their
+// unsigned bytes. The signed bytes of all [txs] share a single allocation.
+func marshalTxs(c codec.Manager, txs []*Tx) ([][]byte, []int, error) {
+	var (
+		signedBytes      = make([][]byte, len(txs))
+		unsignedBytesLen = make([]int, len(txs))
+	)
+	pc, ok := pooledCodecs[c]
+	if !ok {
+		for i, tx := range txs {
+			bytes, err := c.Marshal(CodecVersion, tx)
+			if err != nil {
+				return nil, nil, err
+			}
+			unsignedLen, err := c.Size(CodecVersion, &tx.Unsigned)
+			if err != nil {
+				return nil, nil, err
+			}
+			signedBytes[i] = bytes
+			unsignedBytesLen[i] = unsignedLen
+		}
+		return signedBytes, unsignedBytesLen, nil
+	}
+
+	bufPtr := bufferPool.Get().(*[]byte)
+	p := wrappers.Packer{
+		Bytes: (*bufPtr)[:0],
+	}
+	defer func() {
+		if cap(p.Bytes) <= maxPooledBufferSize {
+			*bufPtr = p.Bytes[:0]
+			bufferPool.Put(bufPtr)
+		}
+	}()
+
+	offsets := make([]int, len(txs)+1)
+	for i, tx := range txs {
+		start := p.Offset
+		p.MaxSize = start + pc.maxSize
+		p.PackShort(CodecVersion)
+		if p.Errored() {
+			return nil, nil, codec.ErrCantPackVersion
+		}
+		// A tx is marshalled as its unsigned tx followed by its credentials,
+		// which gives the length of the unsigned bytes without sizing the
+		// unsigned tx separately.
+		if err := pc.codec.MarshalInto(&tx.Unsigned, &p); err != nil {
+			return nil, nil, err
+		}
+		unsignedBytesLen[i] = p.Offset - start
+		if err := pc.codec.MarshalInto(&tx.Creds, &p); err != nil {
+			return nil, nil, err
+		}
+		offsets[i+1] = p.Offset
+	}
+
+	bytes := slices.Clone(p.Bytes[:p.Offset])
+	for i := range txs {
+		start, end := offsets[i], offsets[i+1]
+		signedBytes[i] = bytes[start:end:end]
+	}
+	return signedBytes, unsignedBytesLen, nil
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func newTestBaseTxs(t testing.TB, numTxs int) []*Tx {
+	keys := secp256k1.TestKeys()
+	txs := make([]*Tx, numTxs)
+	for i := range txs {
+		utx := &BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    10,
+			BlockchainID: ids.ID{'c', 'h', 'a', 'i', 'n', 'I', 'D'},
+			Ins: []*avax.TransferableInput{{
+				UTXOID: avax.UTXOID{
+					TxID:        ids.ID{'t', 'x', 'I', 'D'},
+					OutputIndex: uint32(i),
+				},
+				Asset: avax.Asset{ID: ids.ID{'a', 's', 's', 'e', 't'}},
+				In: &secp256k1fx.TransferInput{
+					Amt:   5678,
+					Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+				},
+			}},
+			Outs: []*avax.TransferableOutput{{
+				Asset: avax.Asset{ID: ids.ID{'a', 's', 's', 'e', 't'}},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: 1234,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{keys[0].Address()},
+					},
+				},
+			}},
+			Memo: []byte{1, 2, 3, 4},
+		}}
+		tx, err := NewSigned(utx, Codec, [][]*secp256k1.PrivateKey{{keys[0]}})
+		require.NoError(t, err)
+		txs[i] = tx
+	}
+	return txs
+}
+
+func TestInitializeTxs(t *testing.T) {
+	for _, c := range []codec.Manager{Codec, GenesisCodec} {
+		require := require.New(t)
+
+		expectedTxs := newTestBaseTxs(t, 3)
+		txs := make([]*Tx, len(expectedTxs))
+		for i, expectedTx := range expectedTxs {
+			txs[i] = &Tx{
+				Unsigned: expectedTx.Unsigned,
+				Creds:    expectedTx.Creds,
+			}
+		}
+		require.NoError(InitializeTxs(c, txs))
+
+		for i, tx := range txs {
+			expectedTx := expectedTxs[i]
+			require.Equal(expectedTx.ID(), tx.ID())
+			require.Equal(expectedTx.Bytes(), tx.Bytes())
+			require.Equal(expectedTx.Unsigned.Bytes(), tx.Unsigned.Bytes())
+		}
+	}
+}
+
+func BenchmarkInitializeTxs(b *testing.B) {
+	txs := newTestBaseTxs(b, 32)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := InitializeTxs(Codec, txs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkInitializeTxsUnpooled(b *testing.B) {
+	txs := newTestBaseTxs(b, 32)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, tx := range txs {
+			signedBytes, err := Codec.Marshal(CodecVersion, tx)
+			if err != nil {
+				b.Fatal(err)
+			}
+			unsignedBytesLen, err := Codec.Size(CodecVersion, &tx.Unsigned)
+			if err != nil {
+				b.Fatal(err)
+			}
+			tx.SetBytes(signedBytes[:unsignedBytesLen], signedBytes)
+		}
+	}
+}
@@ -6,16 +6,19 @@ package builder
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
-	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/platformvm/config"
 	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
@@ -31,7 +34,15 @@ const MaxPageSize = 1024
 var (
 	_ Builder = (*builder)(nil)
 
-	ErrNoFunds = errors.New("no spendable funds were found")
+	ErrNoFunds               = errors.New("no spendable funds were found")
+	ErrCantSignValidatorExit = errors.New("keys don't control the validation rewards owner")
+	ErrCantSignStakeOwner    = errors.New("keys don't control the stake owner")
+	ErrCantSignGovernance    = errors.New("keys don't control the governance owner")
+	ErrCantSignName          = errors.New("keys don't control the name owner")
+	ErrCantSignRewardsOwner  = errors.New("keys don't control the rewards owner")
+
+	errNotPermissionlessValidator   = errors.New("not a permissionless validator")
+	errCantSignAllowListedValidator = errors.New("keys don't control the node owner of the allow list entry")
 )
 
 type Builder interface {
@@ -43,12 +54,12 @@ type Builder interface {
 type AtomicTxBuilder interface {
 	// chainID: chain to import UTXOs from
 	// to: address of recipient
-	// keys: keys to import the funds
+	// kc: keychain to import the funds
 	// changeAddr: address to send change to, if there is any
 	NewImportTx(
 		chainID ids.ID,
 		to ids.ShortID,
-		keys []*secp256k1.PrivateKey,
+		kc keychain.Keychain,
 		changeAddr ids.ShortID,
 		memo []byte,
 	) (*txs.Tx, error)
@@ -56,13 +67,13 @@ type AtomicTxBuilder interface {
 	// amount: amount of tokens to export
 	// chainID: chain to send the UTXOs to
 	// to: address of recipient
-	// keys: keys to pay the fee and provide the tokens
+	// kc: keychain to pay the fee and provide the tokens
 	// changeAddr: address to send change to, if there is any
 	NewExportTx(
 		amount uint64,
 		chainID ids.ID,
 		to ids.ShortID,
-		keys []*secp256k1.PrivateKey,
+		kc keychain.Keychain,
 		changeAddr ids.ShortID,
 		memo []byte,
 	) (*txs.Tx, error)
@@ -74,7 +85,7 @@ type DecisionTxBuilder interface {
 	// vmID: ID of VM this chain runs
 	// fxIDs: ids of features extensions this chain supports
 	// chainName: name of the chain
-	// keys: keys to sign the tx
+	// kc: keychain to sign the tx
 	// changeAddr: address to send change to, if there is any
 	NewCreateChainTx(
 		subnetID ids.ID,
@@ -82,19 +93,19 @@ type DecisionTxBuilder interface {
 		vmID ids.ID,
 		fxIDs []ids.ID,
 		chainName string,
-		keys []*secp256k1.PrivateKey,
+		kc keychain.Keychain,
 		changeAddr ids.ShortID,
 		memo []byte,
 	) (*txs.Tx, error)
 
 	// threshold: [threshold] of [ownerAddrs] needed to manage this subnet
 	// ownerAddrs: control addresses for the new subnet
-	// keys: keys to pay the fee
+	// kc: keychain to pay the fee
 	// changeAddr: address to send change to, if there is any
 	NewCreateSubnetTx(
 		threshold uint32,
 		ownerAddrs []ids.ShortID,
-		keys []*secp256k1.PrivateKey,
+		kc keychain.Keychain,
 		changeAddr ids.ShortID,
 		memo []byte,
 	) (*txs.Tx, error)
@@ -114,19 +125,32 @@ type DecisionTxBuilder interface {
 		minDelegatorStake uint64,
 		maxValidatorWeightFactor byte,
 		uptimeRequirement uint32,
-		keys []*secp256k1.PrivateKey,
+		kc keychain.Keychain,
 		changeAddr ids.ShortID,
 		memo []byte,
 	) (*txs.Tx, error)
 
 	// amount: amount the sender is sending
 	// owner: recipient of the funds
-	// keys: keys to sign the tx and pay the amount
+	// kc: keychain to sign the tx and pay the amount
 	// changeAddr: address to send change to, if there is any
 	NewBaseTx(
 		amount uint64,
 		owner secp256k1fx.OutputOwners,
-		keys []*secp256k1.PrivateKey,
+		kc keychain.Keychain,
+		changeAddr ids.ShortID,
+		memo []byte,
+	) (*txs.Tx, error)
+
+	// Creates a transaction that removes [nodeID] as a validator of
+	// [subnetID], along with its delegators, before its end time
+	// kc: keychain to use for paying the fee and for proving control of the
+	//       owner of the validator's stake
+	// changeAddr: address to send change to, if there is any
+	NewExitValidatorTx(
+		subnetID ids.ID,
+		nodeID ids.NodeID,
+		kc keychain.Keychain,
 		changeAddr ids.ShortID,
 		memo []byte,
 	) (*txs.Tx, error)
@@ -139,7 +163,7 @@ type ProposalTxBuilder interface {
 	// nodeID: ID of the node we want to validate with
 	// rewardAddress: address to send reward to, if applicable
 	// shares: 10,000 times percentage of reward taken from delegators
-	// keys: Keys providing the staked tokens
+	// kc: keychain providing the staked tokens
 	// changeAddr: Address to send change to, if there is any
 	NewAddValidatorTx(
 		stakeAmount,
@@ -148,7 +172,7 @@ type ProposalTxBuilder interface {
 		nodeID ids.NodeID,
 		rewardAddress ids.ShortID,
 		shares uint32,
-		keys []*secp256k1.PrivateKey,
+		kc keychain.Keychain,
 		changeAddr ids.ShortID,
 		memo []byte,
 	) (*txs.Tx, error)
@@ -160,7 +184,7 @@ type ProposalTxBuilder interface {
 	// pop: the node proof of possession
 	// rewardAddress: address to send reward to, if applicable
 	// shares: 10,000 times percentage of reward taken from delegators
-	// keys: Keys providing the staked tokens
+	// kc: keychain providing the staked tokens
 	// changeAddr: Address to send change to, if there is any
 	NewAddPermissionlessValidatorTx(
 		stakeAmount,
@@ -170,7 +194,57 @@ type ProposalTxBuilder interface {
 		pop *signer.ProofOfPossession,
 		rewardAddress ids.ShortID,
 		shares uint32,
-		keys []*secp256k1.PrivateKey,
+		kc keychain.Keychain,
+		changeAddr ids.ShortID,
+		memo []byte,
+	) (*txs.Tx, error)
+
+	// stakeAmount: amount the validator stakes
+	// startTime: unix time they start validating
+	// endTime: unix time they stop validating
+	// nodeID: ID of the node we want to validate with
+	// pop: the node proof of possession
+	// rewardAddress: address to send reward to, if applicable
+	// shares: 10,000 times percentage of reward taken from delegators
+	// maxDelegatorStake: max amount each delegator can delegate
+	// kc: keychain providing the staked tokens
+	// changeAddr: Address to send change to, if there is any
+	NewAddCappedPermissionlessValidatorTx(
+		stakeAmount,
+		startTime,
+		endTime uint64,
+		nodeID ids.NodeID,
+		pop *signer.ProofOfPossession,
+		rewardAddress ids.ShortID,
+		shares uint32,
+		maxDelegatorStake uint64,
+		kc keychain.Keychain,
+		changeAddr ids.ShortID,
+		memo []byte,
+	) (*txs.Tx, error)
+
+	// stakeAmount: amount the validator stakes
+	// startTime: unix time they start validating
+	// endTime: unix time they stop validating
+	// nodeID: ID of the node we want to validate with
+	// pop: the node proof of possession
+	// rewardAddress: address to send reward to, if applicable
+	// shares: 10,000 times percentage of reward taken from delegators
+	// metadataURL: where the metadata of the validator is served
+	// metadataHash: SHA-256 hash of the metadata
+	// kc: keychain providing the staked tokens
+	// changeAddr: Address to send change to, if there is any
+	NewAddPermissionlessValidatorWithMetadataTx(
+		stakeAmount,
+		startTime,
+		endTime uint64,
+		nodeID ids.NodeID,
+		pop *signer.ProofOfPossession,
+		rewardAddress ids.ShortID,
+		shares uint32,
+		metadataURL string,
+		metadataHash ids.ID,
+		kc keychain.Keychain,
 		changeAddr ids.ShortID,
 		memo []byte,
 	) (*txs.Tx, error)
@@ -180,7 +254,7 @@ type ProposalTxBuilder interface {
 	// endTime: unix time they stop delegating
 	// nodeID: ID of the node we are delegating to
 	// rewardAddress: address to send reward to, if applicable
-	// keys: keys providing the staked tokens
+	// kc: keychain providing the staked tokens
 	// changeAddr: address to send change to, if there is any
 	NewAddDelegatorTx(
 		stakeAmount,
@@ -188,7 +262,7 @@ type ProposalTxBuilder interface {
 		endTime uint64,
 		nodeID ids.NodeID,
 		rewardAddress ids.ShortID,
-		keys []*secp256k1.PrivateKey,
+		kc keychain.Keychain,
 		changeAddr ids.ShortID,
 		memo []byte,
 	) (*txs.Tx, error)
@@ -198,7 +272,7 @@ type ProposalTxBuilder interface {
 	// endTime: unix time they stop delegating
 	// nodeID: ID of the node we are delegating to
 	// rewardAddress: address to send reward to, if applicable
-	// keys: keys providing the staked tokens
+	// kc: keychain providing the staked tokens
 	// changeAddr: address to send change to, if there is any
 	NewAddPermissionlessDelegatorTx(
 		stakeAmount,
@@ -206,7 +280,7 @@ type ProposalTxBuilder interface {
 		endTime uint64,
 		nodeID ids.NodeID,
 		rewardAddress ids.ShortID,
-		keys []*secp256k1.PrivateKey,
+		kc keychain.Keychain,
 		changeAddr ids.ShortID,
 		memo []byte,
 	) (*txs.Tx, error)
@@ -216,7 +290,7 @@ type ProposalTxBuilder interface {
 	// endTime:  unix time they top delegating
 	// nodeID: ID of the node validating
 	// subnetID: ID of the subnet the validator will validate
-	// keys: keys to use for adding the validator
+	// kc: keychain to use for adding the validator
 	// changeAddr: address to send change to, if there is any
 	NewAddSubnetValidatorTx(
 		weight,
@@ -224,19 +298,32 @@ type ProposalTxBuilder interface {
 		endTime uint64,
 		nodeID ids.NodeID,
 		subnetID ids.ID,
-		keys []*secp256k1.PrivateKey,
+		kc keychain.Keychain,
 		changeAddr ids.ShortID,
 		memo []byte,
 	) (*txs.Tx, error)
 
 	// Creates a transaction that removes [nodeID]
 	// as a validator from [subnetID]
-	// keys: keys to use for removing the validator
+	// kc: keychain to use for removing the validator
 	// changeAddr: address to send change to, if there is any
 	NewRemoveSubnetValidatorTx(
 		nodeID ids.NodeID,
 		subnetID ids.ID,
-		keys []*secp256k1.PrivateKey,
+		kc keychain.Keychain,
+		changeAddr ids.ShortID,
+		memo []byte,
+	) (*txs.Tx, error)
+
+	// Creates a transaction that sets the weight of the current validator
+	// [nodeID] of [subnetID] to [weight]
+	// kc: keychain to use for modifying the validator
+	// changeAddr: address to send change to, if there is any
+	NewSetSubnetValidatorWeightTx(
+		nodeID ids.NodeID,
+		subnetID ids.ID,
+		weight uint64,
+		kc keychain.Keychain,
 		changeAddr ids.ShortID,
 		memo []byte,
 	) (*txs.Tx, error)
@@ -244,13 +331,128 @@ type ProposalTxBuilder interface {
 	// Creates a transaction that transfers ownership of [subnetID]
 	// threshold: [threshold] of [ownerAddrs] needed to manage this subnet
 	// ownerAddrs: control addresses for the new subnet
-	// keys: keys to use for modifying the subnet
+	// kc: keychain to use for modifying the subnet
 	// changeAddr: address to send change to, if there is any
 	NewTransferSubnetOwnershipTx(
 		subnetID ids.ID,
 		threshold uint32,
 		ownerAddrs []ids.ShortID,
-		keys []*secp256k1.PrivateKey,
+		kc keychain.Keychain,
+		changeAddr ids.ShortID,
+		memo []byte,
+	) (*txs.Tx, error)
+
+	// Creates a transaction that adds [nodeIDs] to the allow list of
+	// [subnetID]
+	// maxWeight: max weight the nodes can be added with by [nodeOwner]
+	// nodeOwner: who can add the nodes as validators of the subnet
+	// kc: keychain to use for modifying the subnet
+	// changeAddr: address to send change to, if there is any
+	NewAddSubnetAllowListEntriesTx(
+		subnetID ids.ID,
+		nodeIDs []ids.NodeID,
+		maxWeight uint64,
+		nodeOwner *secp256k1fx.OutputOwners,
+		kc keychain.Keychain,
+		changeAddr ids.ShortID,
+		memo []byte,
+	) (*txs.Tx, error)
+
+	// Creates a transaction that removes [nodeIDs] from the allow list of
+	// [subnetID]
+	// kc: keychain to use for modifying the subnet
+	// changeAddr: address to send change to, if there is any
+	NewRemoveSubnetAllowListEntriesTx(
+		subnetID ids.ID,
+		nodeIDs []ids.NodeID,
+		kc keychain.Keychain,
+		changeAddr ids.ShortID,
+		memo []byte,
+	) (*txs.Tx, error)
+
+	// Creates a transaction that moves the primary network validator [nodeID],
+	// along with its delegators, to [newNodeID] and to the BLS key of [signer]
+	// kc: keychain to use for paying the fee and for proving control of the
+	//       owner of the validator's stake
+	// changeAddr: address to send change to, if there is any
+	NewRekeyValidatorTx(
+		nodeID ids.NodeID,
+		newNodeID ids.NodeID,
+		signer signer.Signer,
+		kc keychain.Keychain,
+		changeAddr ids.ShortID,
+		memo []byte,
+	) (*txs.Tx, error)
+
+	// Creates a transaction that sets [parameter] to [value] from
+	// [activationTime] onwards
+	// kc: keychain to use for paying the fee and for proving control of the
+	//       governance owner
+	// changeAddr: address to send change to, if there is any
+	NewParameterChangeTx(
+		parameter txs.Parameter,
+		value uint64,
+		activationTime uint64,
+		kc keychain.Keychain,
+		changeAddr ids.ShortID,
+		memo []byte,
+	) (*txs.Tx, error)
+
+	// Creates a transaction that registers [name] for the primary network
+	// validator [nodeID] until [expiry]
+	// owner: who is authorized to update the name
+	// kc: keychain to use for paying the fee and for proving control of the
+	//       validation rewards owner
+	// changeAddr: address to send change to, if there is any
+	NewRegisterNameTx(
+		name string,
+		nodeID ids.NodeID,
+		owner *secp256k1fx.OutputOwners,
+		expiry uint64,
+		kc keychain.Keychain,
+		changeAddr ids.ShortID,
+		memo []byte,
+	) (*txs.Tx, error)
+
+	// Creates a transaction that transfers [name] to [owner] and extends it
+	// until [expiry]
+	// kc: keychain to use for paying the fee and for proving control of the
+	//       name owner
+	// changeAddr: address to send change to, if there is any
+	NewUpdateNameTx(
+		name string,
+		owner *secp256k1fx.OutputOwners,
+		expiry uint64,
+		kc keychain.Keychain,
+		changeAddr ids.ShortID,
+		memo []byte,
+	) (*txs.Tx, error)
+
+	// Creates a transaction that pays out [amount] of the claimable rewards
+	// of [assetID] of the rewards owner [owner] to [to]
+	// kc: keychain to use for paying the fee and for proving control of the
+	//       rewards owner
+	// changeAddr: address to send change to, if there is any
+	NewClaimRewardTx(
+		owner *secp256k1fx.OutputOwners,
+		assetID ids.ID,
+		amount uint64,
+		to *secp256k1fx.OutputOwners,
+		kc keychain.Keychain,
+		changeAddr ids.ShortID,
+		memo []byte,
+	) (*txs.Tx, error)
+
+	// Creates a transaction that binds the rewards owner [owner] to the
+	// C-chain address [addr] that its rewards are exported to
+	// addr: C-chain address, or [ids.ShortEmpty] to remove the binding
+	// kc: keychain to use for paying the fee and for proving control of the
+	//       rewards owner
+	// changeAddr: address to send change to, if there is any
+	NewBindRewardAddressTx(
+		owner *secp256k1fx.OutputOwners,
+		addr ids.ShortID,
+		kc keychain.Keychain,
 		changeAddr ids.ShortID,
 		memo []byte,
 	) (*txs.Tx, error)
@@ -290,24 +492,22 @@ type builder struct {
 func (b *builder) NewImportTx(
 	from ids.ID,
 	to ids.ShortID,
-	keys []*secp256k1.PrivateKey,
+	kc keychain.Keychain,
 	changeAddr ids.ShortID,
 	memo []byte,
 ) (*txs.Tx, error) {
-	kc := secp256k1fx.NewKeychain(keys...)
-
 	atomicUTXOs, _, _, err := b.GetAtomicUTXOs(from, kc.Addresses(), ids.ShortEmpty, ids.Empty, MaxPageSize)
 	if err != nil {
 		return nil, fmt.Errorf("problem retrieving atomic UTXOs: %w", err)
 	}
 
 	importedInputs := []*avax.TransferableInput{}
-	signers := [][]*secp256k1.PrivateKey{}
+	signers := [][]keychain.Signer{}
 
 	importedAmounts := make(map[ids.ID]uint64)
 	now := b.clk.Unix()
-	for _, utxo := range atomicUTXOs {
-		inputIntf, utxoSigners, err := kc.Spend(utxo.Out, now)
+	for _, atomicUTXO := range atomicUTXOs {
+		inputIntf, utxoSigners, err := utxo.SpendOutput(kc, atomicUTXO.Out, now)
 		if err != nil {
 			continue
 		}
@@ -315,14 +515,14 @@ func (b *builder) NewImportTx(
 		if !ok {
 			continue
 		}
-		assetID := utxo.AssetID()
+		assetID := atomicUTXO.AssetID()
 		importedAmounts[assetID], err = math.Add64(importedAmounts[assetID], input.Amount())
 		if err != nil {
 			return nil, err
 		}
 		importedInputs = append(importedInputs, &avax.TransferableInput{
-			UTXOID: utxo.UTXOID,
-			Asset:  utxo.Asset,
+			UTXOID: atomicUTXO.UTXOID,
+			Asset:  atomicUTXO.Asset,
 			In:     input,
 		})
 		signers = append(signers, utxoSigners)
@@ -333,23 +533,28 @@ func (b *builder) NewImportTx(
 		return nil, ErrNoFunds // No imported UTXOs were spendable
 	}
 
+	txFee, err := state.GetParameter(b.state, txs.TxFeeParameter, b.cfg.TxFee)
+	if err != nil {
+		return nil, err
+	}
+
 	importedAVAX := importedAmounts[b.ctx.AVAXAssetID]
 
 	ins := []*avax.TransferableInput{}
 	outs := []*avax.TransferableOutput{}
 	switch {
-	case importedAVAX < b.cfg.TxFee: // imported amount goes toward paying tx fee
-		var baseSigners [][]*secp256k1.PrivateKey
-		ins, outs, _, baseSigners, err = b.Spend(b.state, keys, 0, b.cfg.TxFee-importedAVAX, changeAddr)
+	case importedAVAX < txFee: // imported amount goes toward paying tx fee
+		var baseSigners [][]keychain.Signer
+		ins, outs, _, baseSigners, err = b.Spend(b.state, kc, 0, txFee-importedAVAX, changeAddr)
 		if err != nil {
 			return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 		}
 		signers = append(baseSigners, signers...)
 		delete(importedAmounts, b.ctx.AVAXAssetID)
-	case importedAVAX == b.cfg.TxFee:
+	case importedAVAX == txFee:
 		delete(importedAmounts, b.ctx.AVAXAssetID)
 	default:
-		importedAmounts[b.ctx.AVAXAssetID] -= b.cfg.TxFee
+		importedAmounts[b.ctx.AVAXAssetID] -= txFee
 	}
 
 	for assetID, amount := range importedAmounts {
@@ -380,7 +585,7 @@ func (b *builder) NewImportTx(
 		SourceChain:    from,
 		ImportedInputs: importedInputs,
 	}
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	tx, err := txs.NewSignedWith(utx, txs.Codec, signers)
 	if err != nil {
 		return nil, err
 	}
@@ -392,15 +597,19 @@ func (b *builder) NewExportTx(
 	amount uint64,
 	chainID ids.ID,
 	to ids.ShortID,
-	keys []*secp256k1.PrivateKey,
+	kc keychain.Keychain,
 	changeAddr ids.ShortID,
 	memo []byte,
 ) (*txs.Tx, error) {
-	toBurn, err := math.Add64(amount, b.cfg.TxFee)
+	txFee, err := state.GetParameter(b.state, txs.TxFeeParameter, b.cfg.TxFee)
 	if err != nil {
-		return nil, fmt.Errorf("amount (%d) + tx fee(%d) overflows", amount, b.cfg.TxFee)
+		return nil, err
+	}
+	toBurn, err := math.Add64(amount, txFee)
+	if err != nil {
+		return nil, fmt.Errorf("amount (%d) + tx fee(%d) overflows", amount, txFee)
 	}
-	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, toBurn, changeAddr)
+	ins, outs, _, signers, err := b.Spend(b.state, kc, 0, toBurn, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
@@ -427,7 +636,7 @@ func (b *builder) NewExportTx(
 			},
 		}},
 	}
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	tx, err := txs.NewSignedWith(utx, txs.Codec, signers)
 	if err != nil {
 		return nil, err
 	}
@@ -440,18 +649,18 @@ func (b *builder) NewCreateChainTx(
 	vmID ids.ID,
 	fxIDs []ids.ID,
 	chainName string,
-	keys []*secp256k1.PrivateKey,
+	kc keychain.Keychain,
 	changeAddr ids.ShortID,
 	memo []byte,
 ) (*txs.Tx, error) {
 	timestamp := b.state.GetTimestamp()
 	createBlockchainTxFee := b.cfg.GetCreateBlockchainTxFee(timestamp)
-	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, createBlockchainTxFee, changeAddr)
+	ins, outs, _, signers, err := b.Spend(b.state, kc, 0, createBlockchainTxFee, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
 
-	subnetAuth, subnetSigners, err := b.Authorize(b.state, subnetID, keys)
+	subnetAuth, subnetSigners, err := b.Authorize(b.state, subnetID, kc)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
 	}
@@ -476,7 +685,7 @@ func (b *builder) NewCreateChainTx(
 		GenesisData: genesisData,
 		SubnetAuth:  subnetAuth,
 	}
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	tx, err := txs.NewSignedWith(utx, txs.Codec, signers)
 	if err != nil {
 		return nil, err
 	}
@@ -486,13 +695,13 @@ func (b *builder) NewCreateChainTx(
 func (b *builder) NewCreateSubnetTx(
 	threshold uint32,
 	ownerAddrs []ids.ShortID,
-	keys []*secp256k1.PrivateKey,
+	kc keychain.Keychain,
 	changeAddr ids.ShortID,
 	memo []byte,
 ) (*txs.Tx, error) {
 	timestamp := b.state.GetTimestamp()
 	createSubnetTxFee := b.cfg.GetCreateSubnetTxFee(timestamp)
-	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, createSubnetTxFee, changeAddr)
+	ins, outs, _, signers, err := b.Spend(b.state, kc, 0, createSubnetTxFee, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
@@ -514,7 +723,7 @@ func (b *builder) NewCreateSubnetTx(
 			Addrs:     ownerAddrs,
 		},
 	}
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	tx, err := txs.NewSignedWith(utx, txs.Codec, signers)
 	if err != nil {
 		return nil, err
 	}
@@ -536,16 +745,16 @@ func (b *builder) NewTransformSubnetTx(
 	minDelegatorStake uint64,
 	maxValidatorWeightFactor byte,
 	uptimeRequirement uint32,
-	keys []*secp256k1.PrivateKey,
+	kc keychain.Keychain,
 	changeAddr ids.ShortID,
 	memo []byte,
 ) (*txs.Tx, error) {
-	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, b.cfg.TransformSubnetTxFee, changeAddr)
+	ins, outs, _, signers, err := b.Spend(b.state, kc, 0, b.cfg.TransformSubnetTxFee, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
 
-	subnetAuth, subnetSigners, err := b.Authorize(b.state, subnetID, keys)
+	subnetAuth, subnetSigners, err := b.Authorize(b.state, subnetID, kc)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
 	}
@@ -578,7 +787,7 @@ func (b *builder) NewTransformSubnetTx(
 		SubnetAuth:               subnetAuth,
 	}
 
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	tx, err := txs.NewSignedWith(utx, txs.Codec, signers)
 	if err != nil {
 		return nil, err
 	}
@@ -592,11 +801,15 @@ func (b *builder) NewAddValidatorTx(
 	nodeID ids.NodeID,
 	rewardAddress ids.ShortID,
 	shares uint32,
-	keys []*secp256k1.PrivateKey,
+	kc keychain.Keychain,
 	changeAddr ids.ShortID,
 	memo []byte,
 ) (*txs.Tx, error) {
-	ins, unstakedOuts, stakedOuts, signers, err := b.Spend(b.state, keys, stakeAmount, b.cfg.AddPrimaryNetworkValidatorFee, changeAddr)
+	txFee, err := state.GetParameter(b.state, txs.AddPrimaryNetworkValidatorFeeParameter, b.cfg.AddPrimaryNetworkValidatorFee)
+	if err != nil {
+		return nil, err
+	}
+	ins, unstakedOuts, stakedOuts, signers, err := b.Spend(b.state, kc, stakeAmount, txFee, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
@@ -623,7 +836,7 @@ func (b *builder) NewAddValidatorTx(
 		},
 		DelegationShares: shares,
 	}
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	tx, err := txs.NewSignedWith(utx, txs.Codec, signers)
 	if err != nil {
 		return nil, err
 	}
@@ -638,13 +851,131 @@ func (b *builder) NewAddPermissionlessValidatorTx(
 	pop *signer.ProofOfPossession,
 	rewardAddress ids.ShortID,
 	shares uint32,
-	keys []*secp256k1.PrivateKey,
+	kc keychain.Keychain,
 	changeAddr ids.ShortID,
 	memo []byte,
 ) (*txs.Tx, error) {
-	ins, unstakedOuts, stakedOuts, signers, err := b.Spend(b.state, keys, stakeAmount, b.cfg.AddPrimaryNetworkValidatorFee, changeAddr)
+	utx, signers, err := b.newAddPermissionlessValidatorTx(
+		stakeAmount,
+		startTime,
+		endTime,
+		nodeID,
+		pop,
+		rewardAddress,
+		shares,
+		kc,
+		changeAddr,
+		memo,
+	)
 	if err != nil {
-		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+		return nil, err
+	}
+	tx, err := txs.NewSignedWith(utx, txs.Codec, signers)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.SyntacticVerify(b.ctx)
+}
+
+func (b *builder) NewAddCappedPermissionlessValidatorTx(
+	stakeAmount,
+	startTime,
+	endTime uint64,
+	nodeID ids.NodeID,
+	pop *signer.ProofOfPossession,
+	rewardAddress ids.ShortID,
+	shares uint32,
+	maxDelegatorStake uint64,
+	kc keychain.Keychain,
+	changeAddr ids.ShortID,
+	memo []byte,
+) (*txs.Tx, error) {
+	utx, signers, err := b.newAddPermissionlessValidatorTx(
+		stakeAmount,
+		startTime,
+		endTime,
+		nodeID,
+		pop,
+		rewardAddress,
+		shares,
+		kc,
+		changeAddr,
+		memo,
+	)
+	if err != nil {
+		return nil, err
+	}
+	cappedUtx := &txs.AddCappedPermissionlessValidatorTx{
+		AddPermissionlessValidatorTx: *utx,
+		MaxDelegatorStake:            maxDelegatorStake,
+	}
+	tx, err := txs.NewSignedWith(cappedUtx, txs.Codec, signers)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.SyntacticVerify(b.ctx)
+}
+
+func (b *builder) NewAddPermissionlessValidatorWithMetadataTx(
+	stakeAmount,
+	startTime,
+	endTime uint64,
+	nodeID ids.NodeID,
+	pop *signer.ProofOfPossession,
+	rewardAddress ids.ShortID,
+	shares uint32,
+	metadataURL string,
+	metadataHash ids.ID,
+	kc keychain.Keychain,
+	changeAddr ids.ShortID,
+	memo []byte,
+) (*txs.Tx, error) {
+	utx, signers, err := b.newAddPermissionlessValidatorTx(
+		stakeAmount,
+		startTime,
+		endTime,
+		nodeID,
+		pop,
+		rewardAddress,
+		shares,
+		kc,
+		changeAddr,
+		memo,
+	)
+	if err != nil {
+		return nil, err
+	}
+	metadataUtx := &txs.AddPermissionlessValidatorWithMetadataTx{
+		AddPermissionlessValidatorTx: *utx,
+		MetadataURL:                  metadataURL,
+		MetadataHash:                 metadataHash,
+	}
+	tx, err := txs.NewSignedWith(metadataUtx, txs.Codec, signers)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.SyntacticVerify(b.ctx)
+}
+
+func (b *builder) newAddPermissionlessValidatorTx(
+	stakeAmount,
+	startTime,
+	endTime uint64,
+	nodeID ids.NodeID,
+	pop *signer.ProofOfPossession,
+	rewardAddress ids.ShortID,
+	shares uint32,
+	kc keychain.Keychain,
+	changeAddr ids.ShortID,
+	memo []byte,
+) (*txs.AddPermissionlessValidatorTx, [][]keychain.Signer, error) {
+	txFee, err := state.GetParameter(b.state, txs.AddPrimaryNetworkValidatorFeeParameter, b.cfg.AddPrimaryNetworkValidatorFee)
+	if err != nil {
+		return nil, nil, err
+	}
+	ins, unstakedOuts, stakedOuts, signers, err := b.Spend(b.state, kc, stakeAmount, txFee, changeAddr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
 	// Create the tx
 	utx := &txs.AddPermissionlessValidatorTx{
@@ -676,11 +1007,7 @@ func (b *builder) NewAddPermissionlessValidatorTx(
 		},
 		DelegationShares: shares,
 	}
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
-	if err != nil {
-		return nil, err
-	}
-	return tx, tx.SyntacticVerify(b.ctx)
+	return utx, signers, nil
 }
 
 func (b *builder) NewAddDelegatorTx(
@@ -689,11 +1016,15 @@ func (b *builder) NewAddDelegatorTx(
 	endTime uint64,
 	nodeID ids.NodeID,
 	rewardAddress ids.ShortID,
-	keys []*secp256k1.PrivateKey,
+	kc keychain.Keychain,
 	changeAddr ids.ShortID,
 	memo []byte,
 ) (*txs.Tx, error) {
-	ins, unlockedOuts, lockedOuts, signers, err := b.Spend(b.state, keys, stakeAmount, b.cfg.AddPrimaryNetworkDelegatorFee, changeAddr)
+	txFee, err := state.GetParameter(b.state, txs.AddPrimaryNetworkDelegatorFeeParameter, b.cfg.AddPrimaryNetworkDelegatorFee)
+	if err != nil {
+		return nil, err
+	}
+	ins, unlockedOuts, lockedOuts, signers, err := b.Spend(b.state, kc, stakeAmount, txFee, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
@@ -719,7 +1050,7 @@ func (b *builder) NewAddDelegatorTx(
 			Addrs:     []ids.ShortID{rewardAddress},
 		},
 	}
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	tx, err := txs.NewSignedWith(utx, txs.Codec, signers)
 	if err != nil {
 		return nil, err
 	}
@@ -732,11 +1063,15 @@ func (b *builder) NewAddPermissionlessDelegatorTx(
 	endTime uint64,
 	nodeID ids.NodeID,
 	rewardAddress ids.ShortID,
-	keys []*secp256k1.PrivateKey,
+	kc keychain.Keychain,
 	changeAddr ids.ShortID,
 	memo []byte,
 ) (*txs.Tx, error) {
-	ins, unlockedOuts, lockedOuts, signers, err := b.Spend(b.state, keys, stakeAmount, b.cfg.AddPrimaryNetworkDelegatorFee, changeAddr)
+	txFee, err := state.GetParameter(b.state, txs.AddPrimaryNetworkDelegatorFeeParameter, b.cfg.AddPrimaryNetworkDelegatorFee)
+	if err != nil {
+		return nil, err
+	}
+	ins, unlockedOuts, lockedOuts, signers, err := b.Spend(b.state, kc, stakeAmount, txFee, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
@@ -763,7 +1098,7 @@ func (b *builder) NewAddPermissionlessDelegatorTx(
 			Addrs:     []ids.ShortID{rewardAddress},
 		},
 	}
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	tx, err := txs.NewSignedWith(utx, txs.Codec, signers)
 	if err != nil {
 		return nil, err
 	}
@@ -776,16 +1111,25 @@ func (b *builder) NewAddSubnetValidatorTx(
 	endTime uint64,
 	nodeID ids.NodeID,
 	subnetID ids.ID,
-	keys []*secp256k1.PrivateKey,
+	kc keychain.Keychain,
 	changeAddr ids.ShortID,
 	memo []byte,
 ) (*txs.Tx, error) {
-	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, b.cfg.TxFee, changeAddr)
+	txFee, err := state.GetParameter(b.state, txs.TxFeeParameter, b.cfg.TxFee)
+	if err != nil {
+		return nil, err
+	}
+	ins, outs, _, signers, err := b.Spend(b.state, kc, 0, txFee, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
 
-	subnetAuth, subnetSigners, err := b.Authorize(b.state, subnetID, keys)
+	// Nodes on the allow list of the subnet can be authorized by their node
+	// owner rather than by the subnet owner.
+	subnetAuth, subnetSigners, err := b.authorizeAllowListedValidator(subnetID, nodeID, weight, kc)
+	if err == database.ErrNotFound || err == errCantSignAllowListedValidator {
+		subnetAuth, subnetSigners, err = b.Authorize(b.state, subnetID, kc)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
 	}
@@ -811,7 +1155,7 @@ func (b *builder) NewAddSubnetValidatorTx(
 		},
 		SubnetAuth: subnetAuth,
 	}
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	tx, err := txs.NewSignedWith(utx, txs.Codec, signers)
 	if err != nil {
 		return nil, err
 	}
@@ -821,16 +1165,20 @@ func (b *builder) NewAddSubnetValidatorTx(
 func (b *builder) NewRemoveSubnetValidatorTx(
 	nodeID ids.NodeID,
 	subnetID ids.ID,
-	keys []*secp256k1.PrivateKey,
+	kc keychain.Keychain,
 	changeAddr ids.ShortID,
 	memo []byte,
 ) (*txs.Tx, error) {
-	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, b.cfg.TxFee, changeAddr)
+	txFee, err := state.GetParameter(b.state, txs.TxFeeParameter, b.cfg.TxFee)
+	if err != nil {
+		return nil, err
+	}
+	ins, outs, _, signers, err := b.Spend(b.state, kc, 0, txFee, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
 
-	subnetAuth, subnetSigners, err := b.Authorize(b.state, subnetID, keys)
+	subnetAuth, subnetSigners, err := b.Authorize(b.state, subnetID, kc)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
 	}
@@ -849,7 +1197,51 @@ func (b *builder) NewRemoveSubnetValidatorTx(
 		NodeID:     nodeID,
 		SubnetAuth: subnetAuth,
 	}
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	tx, err := txs.NewSignedWith(utx, txs.Codec, signers)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.SyntacticVerify(b.ctx)
+}
+
+func (b *builder) NewSetSubnetValidatorWeightTx(
+	nodeID ids.NodeID,
+	subnetID ids.ID,
+	weight uint64,
+	kc keychain.Keychain,
+	changeAddr ids.ShortID,
+	memo []byte,
+) (*txs.Tx, error) {
+	txFee, err := state.GetParameter(b.state, txs.TxFeeParameter, b.cfg.TxFee)
+	if err != nil {
+		return nil, err
+	}
+	ins, outs, _, signers, err := b.Spend(b.state, kc, 0, txFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	subnetAuth, subnetSigners, err := b.Authorize(b.state, subnetID, kc)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
+	}
+	signers = append(signers, subnetSigners)
+
+	// Create the tx
+	utx := &txs.SetSubnetValidatorWeightTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.ctx.NetworkID,
+			BlockchainID: b.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+			Memo:         memo,
+		}},
+		Subnet:     subnetID,
+		NodeID:     nodeID,
+		Weight:     weight,
+		SubnetAuth: subnetAuth,
+	}
+	tx, err := txs.NewSignedWith(utx, txs.Codec, signers)
 	if err != nil {
 		return nil, err
 	}
@@ -860,16 +1252,20 @@ func (b *builder) NewTransferSubnetOwnershipTx(
 	subnetID ids.ID,
 	threshold uint32,
 	ownerAddrs []ids.ShortID,
-	keys []*secp256k1.PrivateKey,
+	kc keychain.Keychain,
 	changeAddr ids.ShortID,
 	memo []byte,
 ) (*txs.Tx, error) {
-	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, b.cfg.TxFee, changeAddr)
+	txFee, err := state.GetParameter(b.state, txs.TxFeeParameter, b.cfg.TxFee)
+	if err != nil {
+		return nil, err
+	}
+	ins, outs, _, signers, err := b.Spend(b.state, kc, 0, txFee, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
 
-	subnetAuth, subnetSigners, err := b.Authorize(b.state, subnetID, keys)
+	subnetAuth, subnetSigners, err := b.Authorize(b.state, subnetID, kc)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
 	}
@@ -890,25 +1286,543 @@ func (b *builder) NewTransferSubnetOwnershipTx(
 			Addrs:     ownerAddrs,
 		},
 	}
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	tx, err := txs.NewSignedWith(utx, txs.Codec, signers)
 	if err != nil {
 		return nil, err
 	}
 	return tx, tx.SyntacticVerify(b.ctx)
 }
 
-func (b *builder) NewBaseTx(
-	amount uint64,
-	owner secp256k1fx.OutputOwners,
-	keys []*secp256k1.PrivateKey,
+func (b *builder) NewAddSubnetAllowListEntriesTx(
+	subnetID ids.ID,
+	nodeIDs []ids.NodeID,
+	maxWeight uint64,
+	nodeOwner *secp256k1fx.OutputOwners,
+	kc keychain.Keychain,
 	changeAddr ids.ShortID,
 	memo []byte,
 ) (*txs.Tx, error) {
-	toBurn, err := math.Add64(amount, b.cfg.TxFee)
+	txFee, err := state.GetParameter(b.state, txs.TxFeeParameter, b.cfg.TxFee)
+	if err != nil {
+		return nil, err
+	}
+	ins, outs, _, signers, err := b.Spend(b.state, kc, 0, txFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	subnetAuth, subnetSigners, err := b.Authorize(b.state, subnetID, kc)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
+	}
+	signers = append(signers, subnetSigners)
+
+	nodeIDs = slices.Clone(nodeIDs)
+	utils.Sort(nodeIDs)
+	utx := &txs.AddSubnetAllowListEntriesTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.ctx.NetworkID,
+			BlockchainID: b.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+			Memo:         memo,
+		}},
+		Subnet:     subnetID,
+		NodeIDs:    nodeIDs,
+		MaxWeight:  maxWeight,
+		NodeOwner:  nodeOwner,
+		SubnetAuth: subnetAuth,
+	}
+	tx, err := txs.NewSignedWith(utx, txs.Codec, signers)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.SyntacticVerify(b.ctx)
+}
+
+func (b *builder) NewRemoveSubnetAllowListEntriesTx(
+	subnetID ids.ID,
+	nodeIDs []ids.NodeID,
+	kc keychain.Keychain,
+	changeAddr ids.ShortID,
+	memo []byte,
+) (*txs.Tx, error) {
+	txFee, err := state.GetParameter(b.state, txs.TxFeeParameter, b.cfg.TxFee)
+	if err != nil {
+		return nil, err
+	}
+	ins, outs, _, signers, err := b.Spend(b.state, kc, 0, txFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	subnetAuth, subnetSigners, err := b.Authorize(b.state, subnetID, kc)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
+	}
+	signers = append(signers, subnetSigners)
+
+	nodeIDs = slices.Clone(nodeIDs)
+	utils.Sort(nodeIDs)
+	utx := &txs.RemoveSubnetAllowListEntriesTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.ctx.NetworkID,
+			BlockchainID: b.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+			Memo:         memo,
+		}},
+		Subnet:     subnetID,
+		NodeIDs:    nodeIDs,
+		SubnetAuth: subnetAuth,
+	}
+	tx, err := txs.NewSignedWith(utx, txs.Codec, signers)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.SyntacticVerify(b.ctx)
+}
+
+func (b *builder) NewExitValidatorTx(
+	subnetID ids.ID,
+	nodeID ids.NodeID,
+	kc keychain.Keychain,
+	changeAddr ids.ShortID,
+	memo []byte,
+) (*txs.Tx, error) {
+	txFee, err := state.GetParameter(b.state, txs.TxFeeParameter, b.cfg.TxFee)
+	if err != nil {
+		return nil, err
+	}
+	ins, outs, _, signers, err := b.Spend(b.state, kc, 0, txFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	validatorAuth, validatorSigners, err := b.authorizeStakeOwner(subnetID, nodeID, kc)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't authorize validator exit: %w", err)
+	}
+	signers = append(signers, validatorSigners)
+
+	utx := &txs.ExitValidatorTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.ctx.NetworkID,
+			BlockchainID: b.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+			Memo:         memo,
+		}},
+		Subnet:        subnetID,
+		NodeID:        nodeID,
+		ValidatorAuth: validatorAuth,
+	}
+	tx, err := txs.NewSignedWith(utx, txs.Codec, signers)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.SyntacticVerify(b.ctx)
+}
+
+func (b *builder) NewRekeyValidatorTx(
+	nodeID ids.NodeID,
+	newNodeID ids.NodeID,
+	signer signer.Signer,
+	kc keychain.Keychain,
+	changeAddr ids.ShortID,
+	memo []byte,
+) (*txs.Tx, error) {
+	txFee, err := state.GetParameter(b.state, txs.TxFeeParameter, b.cfg.TxFee)
+	if err != nil {
+		return nil, err
+	}
+	ins, outs, _, signers, err := b.Spend(b.state, kc, 0, txFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	validatorAuth, validatorSigners, err := b.authorizeStakeOwner(constants.PrimaryNetworkID, nodeID, kc)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't authorize validator re-key: %w", err)
+	}
+	signers = append(signers, validatorSigners)
+
+	utx := &txs.RekeyValidatorTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.ctx.NetworkID,
+			BlockchainID: b.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+			Memo:         memo,
+		}},
+		NodeID:        nodeID,
+		NewNodeID:     newNodeID,
+		Signer:        signer,
+		ValidatorAuth: validatorAuth,
+	}
+	tx, err := txs.NewSignedWith(utx, txs.Codec, signers)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.SyntacticVerify(b.ctx)
+}
+
+func (b *builder) NewParameterChangeTx(
+	parameter txs.Parameter,
+	value uint64,
+	activationTime uint64,
+	kc keychain.Keychain,
+	changeAddr ids.ShortID,
+	memo []byte,
+) (*txs.Tx, error) {
+	txFee, err := state.GetParameter(b.state, txs.TxFeeParameter, b.cfg.TxFee)
+	if err != nil {
+		return nil, err
+	}
+	ins, outs, _, signers, err := b.Spend(b.state, kc, 0, txFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	if b.cfg.GovernanceOwner == nil {
+		return nil, ErrCantSignGovernance
+	}
+	indices, governanceSigners, matches := utxo.MatchOwners(kc, b.cfg.GovernanceOwner, b.clk.Unix())
+	if !matches {
+		return nil, ErrCantSignGovernance
+	}
+	signers = append(signers, governanceSigners)
+
+	utx := &txs.ParameterChangeTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.ctx.NetworkID,
+			BlockchainID: b.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+			Memo:         memo,
+		}},
+		Parameter:      parameter,
+		Value:          value,
+		ActivationTime: activationTime,
+		GovernanceAuth: &secp256k1fx.Input{SigIndices: indices},
+	}
+	tx, err := txs.NewSignedWith(utx, txs.Codec, signers)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.SyntacticVerify(b.ctx)
+}
+
+func (b *builder) NewRegisterNameTx(
+	name string,
+	nodeID ids.NodeID,
+	owner *secp256k1fx.OutputOwners,
+	expiry uint64,
+	kc keychain.Keychain,
+	changeAddr ids.ShortID,
+	memo []byte,
+) (*txs.Tx, error) {
+	fee, err := state.GetParameter(b.state, txs.NameRegistrationFeeParameter, b.cfg.NameRegistrationFee)
+	if err != nil {
+		return nil, err
+	}
+	ins, outs, _, signers, err := b.Spend(b.state, kc, 0, fee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	validatorAuth, validatorSigners, err := b.authorizeValidator(constants.PrimaryNetworkID, nodeID, kc)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't authorize name registration: %w", err)
+	}
+	signers = append(signers, validatorSigners)
+
+	utx := &txs.RegisterNameTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.ctx.NetworkID,
+			BlockchainID: b.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+			Memo:         memo,
+		}},
+		Name:          name,
+		NodeID:        nodeID,
+		Owner:         owner,
+		Expiry:        expiry,
+		ValidatorAuth: validatorAuth,
+	}
+	tx, err := txs.NewSignedWith(utx, txs.Codec, signers)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.SyntacticVerify(b.ctx)
+}
+
+func (b *builder) NewUpdateNameTx(
+	name string,
+	owner *secp256k1fx.OutputOwners,
+	expiry uint64,
+	kc keychain.Keychain,
+	changeAddr ids.ShortID,
+	memo []byte,
+) (*txs.Tx, error) {
+	fee, err := state.GetParameter(b.state, txs.NameRegistrationFeeParameter, b.cfg.NameRegistrationFee)
+	if err != nil {
+		return nil, err
+	}
+	ins, outs, _, signers, err := b.Spend(b.state, kc, 0, fee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	record, err := b.state.GetName(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch name %q: %w", name, err)
+	}
+	recordTx, _, err := b.state.GetTx(record.TxID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch name tx %s: %w", record.TxID, err)
+	}
+	var nameOwner fx.Owner
+	switch utx := recordTx.Unsigned.(type) {
+	case *txs.RegisterNameTx:
+		nameOwner = utx.Owner
+	case *txs.UpdateNameTx:
+		nameOwner = utx.Owner
+	}
+	currentOwner, ok := nameOwner.(*secp256k1fx.OutputOwners)
+	if !ok {
+		return nil, fmt.Errorf("expected *secp256k1fx.OutputOwners but got %T", nameOwner)
+	}
+	indices, nameSigners, matches := utxo.MatchOwners(kc, currentOwner, b.clk.Unix())
+	if !matches {
+		return nil, ErrCantSignName
+	}
+	signers = append(signers, nameSigners)
+
+	utx := &txs.UpdateNameTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.ctx.NetworkID,
+			BlockchainID: b.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+			Memo:         memo,
+		}},
+		Name:     name,
+		Owner:    owner,
+		Expiry:   expiry,
+		NameAuth: &secp256k1fx.Input{SigIndices: indices},
+	}
+	tx, err := txs.NewSignedWith(utx, txs.Codec, signers)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.SyntacticVerify(b.ctx)
+}
+
+func (b *builder) NewClaimRewardTx(
+	owner *secp256k1fx.OutputOwners,
+	assetID ids.ID,
+	amount uint64,
+	to *secp256k1fx.OutputOwners,
+	kc keychain.Keychain,
+	changeAddr ids.ShortID,
+	memo []byte,
+) (*txs.Tx, error) {
+	txFee, err := state.GetParameter(b.state, txs.TxFeeParameter, b.cfg.TxFee)
+	if err != nil {
+		return nil, err
+	}
+	ins, outs, _, signers, err := b.Spend(b.state, kc, 0, txFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	indices, ownerSigners, matches := utxo.MatchOwners(kc, owner, b.clk.Unix())
+	if !matches {
+		return nil, ErrCantSignRewardsOwner
+	}
+	signers = append(signers, ownerSigners)
+
+	utx := &txs.ClaimRewardTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.ctx.NetworkID,
+			BlockchainID: b.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+			Memo:         memo,
+		}},
+		Owner:     owner,
+		AssetID:   assetID,
+		Amount:    amount,
+		To:        to,
+		OwnerAuth: &secp256k1fx.Input{SigIndices: indices},
+	}
+	tx, err := txs.NewSignedWith(utx, txs.Codec, signers)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.SyntacticVerify(b.ctx)
+}
+
+func (b *builder) NewBindRewardAddressTx(
+	owner *secp256k1fx.OutputOwners,
+	addr ids.ShortID,
+	kc keychain.Keychain,
+	changeAddr ids.ShortID,
+	memo []byte,
+) (*txs.Tx, error) {
+	txFee, err := state.GetParameter(b.state, txs.TxFeeParameter, b.cfg.TxFee)
+	if err != nil {
+		return nil, err
+	}
+	ins, outs, _, signers, err := b.Spend(b.state, kc, 0, txFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	indices, ownerSigners, matches := utxo.MatchOwners(kc, owner, b.clk.Unix())
+	if !matches {
+		return nil, ErrCantSignRewardsOwner
+	}
+	signers = append(signers, ownerSigners)
+
+	utx := &txs.BindRewardAddressTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.ctx.NetworkID,
+			BlockchainID: b.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+			Memo:         memo,
+		}},
+		Owner:     owner,
+		Address:   addr,
+		OwnerAuth: &secp256k1fx.Input{SigIndices: indices},
+	}
+	tx, err := txs.NewSignedWith(utx, txs.Codec, signers)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.SyntacticVerify(b.ctx)
+}
+
+// authorizeAllowListedValidator authorizes adding [nodeID] as a validator of
+// [subnetID] with [weight] on behalf of the node owner of its allow list
+// entry. Returns [database.ErrNotFound] if only the subnet owner can authorize
+// it.
+func (b *builder) authorizeAllowListedValidator(
+	subnetID ids.ID,
+	nodeID ids.NodeID,
+	weight uint64,
+	kc keychain.Keychain,
+) (verify.Verifiable, []keychain.Signer, error) {
+	allowListTxID, err := b.state.GetSubnetAllowListEntry(subnetID, nodeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	allowListTx, _, err := b.state.GetTx(allowListTxID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch allow list tx %s: %w", allowListTxID, err)
+	}
+	entries, ok := allowListTx.Unsigned.(*txs.AddSubnetAllowListEntriesTx)
+	if !ok {
+		return nil, nil, fmt.Errorf("expected *txs.AddSubnetAllowListEntriesTx but got %T", allowListTx.Unsigned)
+	}
+	if weight > entries.MaxWeight {
+		return nil, nil, database.ErrNotFound
+	}
+	owner, ok := entries.NodeOwner.(*secp256k1fx.OutputOwners)
+	if !ok {
+		return nil, nil, fmt.Errorf("expected *secp256k1fx.OutputOwners but got %T", entries.NodeOwner)
+	}
+
+	indices, signers, matches := utxo.MatchOwners(kc, owner, b.clk.Unix())
+	if !matches {
+		return nil, nil, errCantSignAllowListedValidator
+	}
+	return &secp256k1fx.Input{SigIndices: indices}, signers, nil
+}
+
+// authorizeValidator returns the input, and the signers signing it, that prove
+// control of the validation rewards owner of the current validator [nodeID]
+// of [subnetID].
+func (b *builder) authorizeValidator(
+	subnetID ids.ID,
+	nodeID ids.NodeID,
+	kc keychain.Keychain,
+) (verify.Verifiable, []keychain.Signer, error) {
+	vdr, err := b.state.GetCurrentValidator(subnetID, nodeID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch validator %s of %s: %w", nodeID, subnetID, err)
+	}
+	vdrTx, _, err := b.state.GetTx(vdr.TxID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch validator tx %s: %w", vdr.TxID, err)
+	}
+	validatorTx, ok := vdrTx.Unsigned.(txs.ValidatorTx)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s", errNotPermissionlessValidator, nodeID)
+	}
+	owner, ok := validatorTx.ValidationRewardsOwner().(*secp256k1fx.OutputOwners)
+	if !ok {
+		return nil, nil, fmt.Errorf("expected *secp256k1fx.OutputOwners but got %T", validatorTx.ValidationRewardsOwner())
+	}
+
+	indices, signers, matches := utxo.MatchOwners(kc, owner, b.clk.Unix())
+	if !matches {
+		return nil, nil, ErrCantSignValidatorExit
+	}
+	return &secp256k1fx.Input{SigIndices: indices}, signers, nil
+}
+
+// authorizeStakeOwner returns the input, and the signers signing it, that
+// prove control of the owner of the stake of the current validator [nodeID] of
+// [subnetID].
+func (b *builder) authorizeStakeOwner(
+	subnetID ids.ID,
+	nodeID ids.NodeID,
+	kc keychain.Keychain,
+) (verify.Verifiable, []keychain.Signer, error) {
+	vdr, err := b.state.GetCurrentValidator(subnetID, nodeID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch validator %s of %s: %w", nodeID, subnetID, err)
+	}
+	vdrTx, _, err := b.state.GetTx(vdr.TxID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch validator tx %s: %w", vdr.TxID, err)
+	}
+	validatorTx, ok := vdrTx.Unsigned.(txs.ValidatorTx)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s", errNotPermissionlessValidator, nodeID)
+	}
+	owner, err := txs.StakeOwner(validatorTx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	indices, signers, matches := utxo.MatchOwners(kc, owner, b.clk.Unix())
+	if !matches {
+		return nil, nil, ErrCantSignStakeOwner
+	}
+	return &secp256k1fx.Input{SigIndices: indices}, signers, nil
+}
+
+func (b *builder) NewBaseTx(
+	amount uint64,
+	owner secp256k1fx.OutputOwners,
+	kc keychain.Keychain,
+	changeAddr ids.ShortID,
+	memo []byte,
+) (*txs.Tx, error) {
+	txFee, err := state.GetParameter(b.state, txs.TxFeeParameter, b.cfg.TxFee)
+	if err != nil {
+		return nil, err
+	}
+	toBurn, err := math.Add64(amount, txFee)
 	if err != nil {
-		return nil, fmt.Errorf("amount (%d) + tx fee(%d) overflows", amount, b.cfg.TxFee)
+		return nil, fmt.Errorf("amount (%d) + tx fee(%d) overflows", amount, txFee)
 	}
-	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, toBurn, changeAddr)
+	ins, outs, _, signers, err := b.Spend(b.state, kc, 0, toBurn, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
@@ -932,7 +1846,7 @@ func (b *builder) NewBaseTx(
 			Memo:         memo,
 		},
 	}
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	tx, err := txs.NewSignedWith(utx, txs.Codec, signers)
 	if err != nil {
 		return nil, err
 	}
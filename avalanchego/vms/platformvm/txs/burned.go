@@ -0,0 +1,92 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+// Burned returns the amount of [assetID] that [tx] consumes without producing,
+// exporting or staking it. Txs that don't embed a [BaseTx] burn nothing.
+//
+// Invariant: [tx] must have been verified, as the amounts of its inputs are
+// trusted.
+func Burned(tx UnsignedTx, assetID ids.ID) (uint64, error) {
+	b, ok := tx.(interface{ base() *BaseTx })
+	if !ok {
+		return 0, nil
+	}
+
+	baseTx := b.base()
+	consumed, err := sumInputs(baseTx.Ins, assetID)
+	if err != nil {
+		return 0, err
+	}
+	produced, err := sumOutputs(baseTx.Outs, assetID)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		moreConsumed uint64
+		moreProduced uint64
+	)
+	switch tx := tx.(type) {
+	case *ImportTx:
+		moreConsumed, err = sumInputs(tx.ImportedInputs, assetID)
+	case *ExportTx:
+		moreProduced, err = sumOutputs(tx.ExportedOutputs, assetID)
+	case PermissionlessStaker:
+		moreProduced, err = sumOutputs(tx.Stake(), assetID)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	consumed, err = math.Add64(consumed, moreConsumed)
+	if err != nil {
+		return 0, err
+	}
+	produced, err = math.Add64(produced, moreProduced)
+	if err != nil {
+		return 0, err
+	}
+	return math.Sub(consumed, produced)
+}
+
+func sumInputs(ins []*avax.TransferableInput, assetID ids.ID) (uint64, error) {
+	var (
+		sum uint64
+		err error
+	)
+	for _, in := range ins {
+		if in.AssetID() != assetID {
+			continue
+		}
+		sum, err = math.Add64(sum, in.In.Amount())
+		if err != nil {
+			return 0, err
+		}
+	}
+	return sum, nil
+}
+
+func sumOutputs(outs []*avax.TransferableOutput, assetID ids.ID) (uint64, error) {
+	var (
+		sum uint64
+		err error
+	)
+	for _, out := range outs {
+		if out.AssetID() != assetID {
+			continue
+		}
+		sum, err = math.Add64(sum, out.Out.Amount())
+		if err != nil {
+			return 0, err
+		}
+	}
+	return sum, nil
+}
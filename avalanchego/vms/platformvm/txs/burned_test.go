@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestBurned(t *testing.T) {
+	var (
+		assetID      = ids.GenerateTestID()
+		otherAssetID = ids.GenerateTestID()
+	)
+	newIn := func(assetID ids.ID, amount uint64) *avax.TransferableInput {
+		return &avax.TransferableInput{
+			Asset: avax.Asset{ID: assetID},
+			In:    &secp256k1fx.TransferInput{Amt: amount},
+		}
+	}
+	newOut := func(assetID ids.ID, amount uint64) *avax.TransferableOutput {
+		return &avax.TransferableOutput{
+			Asset: avax.Asset{ID: assetID},
+			Out:   &secp256k1fx.TransferOutput{Amt: amount},
+		}
+	}
+	newBaseTx := func(ins []*avax.TransferableInput, outs []*avax.TransferableOutput) BaseTx {
+		return BaseTx{BaseTx: avax.BaseTx{
+			Ins:  ins,
+			Outs: outs,
+		}}
+	}
+
+	tests := []struct {
+		name           string
+		tx             UnsignedTx
+		expectedBurned uint64
+		expectedErr    error
+	}{
+		{
+			name: "base tx",
+			tx: &BaseTx{BaseTx: avax.BaseTx{
+				Ins:  []*avax.TransferableInput{newIn(assetID, 10), newIn(otherAssetID, 5)},
+				Outs: []*avax.TransferableOutput{newOut(assetID, 7)},
+			}},
+			expectedBurned: 3,
+		},
+		{
+			name: "import tx",
+			tx: &ImportTx{
+				BaseTx: newBaseTx(
+					[]*avax.TransferableInput{newIn(assetID, 1)},
+					[]*avax.TransferableOutput{newOut(assetID, 8)},
+				),
+				ImportedInputs: []*avax.TransferableInput{newIn(assetID, 10)},
+			},
+			expectedBurned: 3,
+		},
+		{
+			name: "export tx",
+			tx: &ExportTx{
+				BaseTx: newBaseTx(
+					[]*avax.TransferableInput{newIn(assetID, 10)},
+					[]*avax.TransferableOutput{newOut(assetID, 2)},
+				),
+				ExportedOutputs: []*avax.TransferableOutput{newOut(assetID, 7)},
+			},
+			expectedBurned: 1,
+		},
+		{
+			name: "staker tx",
+			tx: &AddDelegatorTx{
+				BaseTx: newBaseTx(
+					[]*avax.TransferableInput{newIn(assetID, 10)},
+					[]*avax.TransferableOutput{newOut(assetID, 2)},
+				),
+				StakeOuts: []*avax.TransferableOutput{newOut(assetID, 6)},
+			},
+			expectedBurned: 2,
+		},
+		{
+			name:           "reward tx",
+			tx:             &RewardValidatorTx{},
+			expectedBurned: 0,
+		},
+		{
+			name: "produces more than consumed",
+			tx: &BaseTx{BaseTx: avax.BaseTx{
+				Ins:  []*avax.TransferableInput{newIn(assetID, 1)},
+				Outs: []*avax.TransferableOutput{newOut(assetID, 2)},
+			}},
+			expectedErr: math.ErrUnderflow,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			burned, err := Burned(test.tx, assetID)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedBurned, burned)
+		})
+	}
+}
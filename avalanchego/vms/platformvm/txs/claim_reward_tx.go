@@ -0,0 +1,83 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+)
+
+var (
+	_ UnsignedTx = (*ClaimRewardTx)(nil)
+
+	errNoClaimedAmount = errors.New("claimed amount must be non-zero")
+)
+
+// ClaimRewardTx pays out [Amount] of the rewards of [AssetID] that accrued to
+// the claimable balance of the rewards owner [Owner]. The claimed rewards are
+// sent to [To] by the output that follows [Outs].
+type ClaimRewardTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// Rewards owner whose claimable balance is claimed
+	Owner fx.Owner `serialize:"true" json:"owner"`
+	// Asset of the claimed rewards
+	AssetID ids.ID `serialize:"true" json:"assetID"`
+	// Amount of rewards to claim
+	Amount uint64 `serialize:"true" json:"amount"`
+	// Who the claimed rewards are sent to
+	To fx.Owner `serialize:"true" json:"to"`
+	// Proves that the issuer controls [Owner]
+	OwnerAuth verify.Verifiable `serialize:"true" json:"ownerAuthorization"`
+}
+
+// InitCtx sets the FxID fields in the inputs and outputs of this
+// [ClaimRewardTx]. Also sets the [ctx] to the given [vm.ctx] so that
+// the addresses can be json marshalled into human readable format
+func (tx *ClaimRewardTx) InitCtx(ctx *snow.Context) {
+	tx.BaseTx.InitCtx(ctx)
+	tx.Owner.InitCtx(ctx)
+	tx.To.InitCtx(ctx)
+}
+
+func (tx *ClaimRewardTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified:
+		// already passed syntactic verification
+		return nil
+	case tx.Amount == 0:
+		return errNoClaimedAmount
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := verify.All(tx.Owner, tx.To, tx.OwnerAuth); err != nil {
+		return err
+	}
+
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *ClaimRewardTx) Visit(visitor Visitor) error {
+	return visitor.ClaimRewardTx(tx)
+}
+
+// RewardsOwnerID returns the ID that the claimable rewards of [owner] are
+// recorded under.
+func RewardsOwnerID(owner fx.Owner) (ids.ID, error) {
+	ownerBytes, err := Codec.Marshal(CodecVersion, &owner)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return hashing.ComputeHash256Array(ownerBytes), nil
+}
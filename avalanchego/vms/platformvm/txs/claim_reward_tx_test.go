@@ -0,0 +1,109 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestClaimRewardTxSyntacticVerify(t *testing.T) {
+	type test struct {
+		name        string
+		txFunc      func(*gomock.Controller) *ClaimRewardTx
+		expectedErr error
+	}
+
+	var (
+		networkID = uint32(1337)
+		chainID   = ids.GenerateTestID()
+	)
+
+	ctx := &snow.Context{
+		ChainID:   chainID,
+		NetworkID: networkID,
+	}
+
+	// A BaseTx that passes syntactic verification.
+	validBaseTx := BaseTx{
+		BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		},
+	}
+
+	tests := []test{
+		{
+			name: "nil tx",
+			txFunc: func(*gomock.Controller) *ClaimRewardTx {
+				return nil
+			},
+			expectedErr: ErrNilTx,
+		},
+		{
+			name: "no claimed amount",
+			txFunc: func(*gomock.Controller) *ClaimRewardTx {
+				return &ClaimRewardTx{
+					BaseTx: validBaseTx,
+				}
+			},
+			expectedErr: errNoClaimedAmount,
+		},
+		{
+			name: "invalid ownerAuth",
+			txFunc: func(ctrl *gomock.Controller) *ClaimRewardTx {
+				// This OwnerAuth fails verification.
+				invalidOwnerAuth := verify.NewMockVerifiable(ctrl)
+				invalidOwnerAuth.EXPECT().Verify().Return(errInvalidValidatorAuth)
+				return &ClaimRewardTx{
+					BaseTx:    validBaseTx,
+					Owner:     &secp256k1fx.OutputOwners{},
+					Amount:    1,
+					To:        &secp256k1fx.OutputOwners{},
+					OwnerAuth: invalidOwnerAuth,
+				}
+			},
+			expectedErr: errInvalidValidatorAuth,
+		},
+		{
+			name: "passes verification",
+			txFunc: func(ctrl *gomock.Controller) *ClaimRewardTx {
+				// This OwnerAuth passes verification.
+				validOwnerAuth := verify.NewMockVerifiable(ctrl)
+				validOwnerAuth.EXPECT().Verify().Return(nil)
+				return &ClaimRewardTx{
+					BaseTx:    validBaseTx,
+					Owner:     &secp256k1fx.OutputOwners{},
+					Amount:    1,
+					To:        &secp256k1fx.OutputOwners{},
+					OwnerAuth: validOwnerAuth,
+				}
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			tx := tt.txFunc(ctrl)
+			err := tx.SyntacticVerify(ctx)
+			require.ErrorIs(err, tt.expectedErr)
+			if tt.expectedErr != nil {
+				return
+			}
+			require.True(tx.SyntacticallyVerified)
+		})
+	}
+}
@@ -10,13 +10,19 @@ import (
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/codec/linearcodec"
 	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
-const CodecVersion = 0
+const (
+	CodecVersion = 0
+
+	// Max size of the txs marshalled with [Codec]
+	codecMaxSize = 256 * units.KiB
+)
 
 var (
 	Codec codec.Manager
@@ -26,6 +32,10 @@ var (
 	// it must not be used to parse new, unverified txs which instead
 	// must be processed by Codec
 	GenesisCodec codec.Manager
+
+	// pooledCodecs maps [Codec] and [GenesisCodec] to the codecs registered in
+	// them, so that txs can be marshalled into pooled buffers.
+	pooledCodecs map[codec.Manager]pooledCodec
 )
 
 // TODO: Remove after v1.11.x has activated
@@ -47,10 +57,13 @@ func InitCodec(durangoTime time.Time) error {
 
 		c.SkipRegistrations(4)
 
-		errs.Add(RegisterDUnsignedTxsTypes(c))
+		errs.Add(
+			RegisterDUnsignedTxsTypes(c),
+			RegisterExtensionTypes(c),
+		)
 	}
 
-	newCodec := codec.NewDefaultManager()
+	newCodec := codec.NewManager(codecMaxSize)
 	newGenesisCodec := codec.NewManager(math.MaxInt32)
 	errs.Add(
 		newCodec.RegisterCodec(CodecVersion, c),
@@ -62,6 +75,16 @@ func InitCodec(durangoTime time.Time) error {
 
 	Codec = newCodec
 	GenesisCodec = newGenesisCodec
+	pooledCodecs = map[codec.Manager]pooledCodec{
+		newCodec: {
+			codec:   c,
+			maxSize: codecMaxSize,
+		},
+		newGenesisCodec: {
+			codec:   gc,
+			maxSize: math.MaxInt32,
+		},
+	}
 	return nil
 }
 
@@ -122,5 +145,37 @@ func RegisterDUnsignedTxsTypes(targetCodec linearcodec.Codec) error {
 	return utils.Err(
 		targetCodec.RegisterType(&TransferSubnetOwnershipTx{}),
 		targetCodec.RegisterType(&BaseTx{}),
+		RegisterFlareUnsignedTxsTypes(targetCodec),
+	)
+}
+
+// RegisterFlareUnsignedTxsTypes registers the types of the txs added by the
+// Flare network upgrades. Every type is registered regardless of whether its
+// upgrade has activated, so that the type IDs never change, but the executors
+// reject the txs until the upgrade that enables them has activated.
+func RegisterFlareUnsignedTxsTypes(targetCodec linearcodec.Codec) error {
+	return utils.Err(
+		// Enabled by [config.Config.SubnetAllowListTime]
+		targetCodec.RegisterType(&AddSubnetAllowListEntriesTx{}),
+		targetCodec.RegisterType(&RemoveSubnetAllowListEntriesTx{}),
+		// Enabled by [config.Config.CappedDelegationTime]
+		targetCodec.RegisterType(&AddCappedPermissionlessValidatorTx{}),
+		// Enabled by [config.Config.ExitValidatorTime]
+		targetCodec.RegisterType(&ExitValidatorTx{}),
+		// Enabled by [config.Config.SubnetValidatorWeightTime]
+		targetCodec.RegisterType(&SetSubnetValidatorWeightTx{}),
+		// Enabled by [config.Config.ParameterChangeTime]
+		targetCodec.RegisterType(&ParameterChangeTx{}),
+		// Enabled by [config.Config.RekeyValidatorTime]
+		targetCodec.RegisterType(&RekeyValidatorTx{}),
+		// Enabled by [config.Config.NameRegistryTime]
+		targetCodec.RegisterType(&RegisterNameTx{}),
+		targetCodec.RegisterType(&UpdateNameTx{}),
+		// Enabled by [config.Config.ValidatorMetadataTime]
+		targetCodec.RegisterType(&AddPermissionlessValidatorWithMetadataTx{}),
+		// Enabled by [config.Config.ClaimableRewardsTime]
+		targetCodec.RegisterType(&ClaimRewardTx{}),
+		// Enabled by [config.Config.RewardExportTime]
+		targetCodec.RegisterType(&BindRewardAddressTx{}),
 	)
 }
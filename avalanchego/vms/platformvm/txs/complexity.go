@@ -0,0 +1,165 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// Complexity units charged for the resources consumed while executing a tx.
+const (
+	SignatureComplexity      uint64 = 1_000
+	UTXOReadComplexity       uint64 = 500
+	UTXOWriteComplexity      uint64 = 300
+	StakerMutationComplexity uint64 = 2_000
+)
+
+// Complexity returns the complexity units charged for executing [tx]. The
+// complexity accounts for the signatures that are verified, the UTXOs that are
+// read and written and the stakers that are added to or removed from the
+// staker sets.
+func Complexity(tx *Tx) (uint64, error) {
+	var numSigs uint64
+	for _, cred := range tx.Creds {
+		if cred, ok := cred.(*secp256k1fx.Credential); ok {
+			numSigs += uint64(len(cred.Sigs))
+		}
+	}
+	return complexity(tx.Unsigned, numSigs)
+}
+
+// EstimatedComplexity returns the complexity units that will be charged for
+// executing [utx] once it's signed with the signatures it requires.
+func EstimatedComplexity(utx UnsignedTx) (uint64, error) {
+	return complexity(utx, NumRequiredSignatures(utx))
+}
+
+// NumRequiredSignatures returns the number of signatures that the credentials
+// of [utx] must hold, one for each signature index of its inputs and of its
+// authorization.
+func NumRequiredSignatures(utx UnsignedTx) uint64 {
+	var ins []*avax.TransferableInput
+	if b, ok := utx.(interface{ base() *BaseTx }); ok {
+		ins = b.base().Ins
+	}
+	if importTx, ok := utx.(*ImportTx); ok {
+		ins = append(ins[:len(ins):len(ins)], importTx.ImportedInputs...)
+	}
+
+	var numSigs uint64
+	for _, in := range ins {
+		if in, ok := in.In.(*secp256k1fx.TransferInput); ok {
+			numSigs += uint64(len(in.SigIndices))
+		}
+	}
+
+	var auth verify.Verifiable
+	switch utx := utx.(type) {
+	case *AddSubnetValidatorTx:
+		auth = utx.SubnetAuth
+	case *CreateChainTx:
+		auth = utx.SubnetAuth
+	case *RemoveSubnetValidatorTx:
+		auth = utx.SubnetAuth
+	case *TransformSubnetTx:
+		auth = utx.SubnetAuth
+	case *TransferSubnetOwnershipTx:
+		auth = utx.SubnetAuth
+	case *AddSubnetAllowListEntriesTx:
+		auth = utx.SubnetAuth
+	case *RemoveSubnetAllowListEntriesTx:
+		auth = utx.SubnetAuth
+	case *SetSubnetValidatorWeightTx:
+		auth = utx.SubnetAuth
+	case *ExitValidatorTx:
+		auth = utx.ValidatorAuth
+	case *RekeyValidatorTx:
+		auth = utx.ValidatorAuth
+	case *RegisterNameTx:
+		auth = utx.ValidatorAuth
+	case *UpdateNameTx:
+		auth = utx.NameAuth
+	case *ClaimRewardTx:
+		auth = utx.OwnerAuth
+	case *BindRewardAddressTx:
+		auth = utx.OwnerAuth
+	case *ParameterChangeTx:
+		auth = utx.GovernanceAuth
+	}
+	if auth, ok := auth.(*secp256k1fx.Input); ok {
+		numSigs += uint64(len(auth.SigIndices))
+	}
+	return numSigs
+}
+
+func complexity(utx UnsignedTx, numSigs uint64) (uint64, error) {
+	var numReads, numWrites uint64
+	if b, ok := utx.(interface{ base() *BaseTx }); ok {
+		baseTx := b.base()
+		numReads = uint64(len(baseTx.Ins))
+		numWrites = uint64(len(baseTx.Outs))
+	}
+
+	var numStakerMutations uint64
+	switch utx := utx.(type) {
+	case *ImportTx:
+		numReads += uint64(len(utx.ImportedInputs))
+	case *ExportTx:
+		numWrites += uint64(len(utx.ExportedOutputs))
+	case *ClaimRewardTx:
+		// The claimed rewards are paid out by an extra output
+		numWrites++
+	case PermissionlessStaker:
+		numWrites += uint64(len(utx.Stake()))
+		numStakerMutations = 1
+	case Staker,
+		*RewardValidatorTx,
+		*RemoveSubnetValidatorTx,
+		*ExitValidatorTx,
+		*SetSubnetValidatorWeightTx:
+		numStakerMutations = 1
+	case *RekeyValidatorTx:
+		// The validator is removed and re-added under the new nodeID
+		numStakerMutations = 2
+	}
+
+	total, err := math.Mul64(numSigs, SignatureComplexity)
+	if err != nil {
+		return 0, err
+	}
+	for _, c := range []struct{ count, units uint64 }{
+		{count: numReads, units: UTXOReadComplexity},
+		{count: numWrites, units: UTXOWriteComplexity},
+		{count: numStakerMutations, units: StakerMutationComplexity},
+	} {
+		units, err := math.Mul64(c.count, c.units)
+		if err != nil {
+			return 0, err
+		}
+		total, err = math.Add64(total, units)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
+
+// TotalComplexity returns the sum of the complexities of [txs].
+func TotalComplexity(txs []*Tx) (uint64, error) {
+	var total uint64
+	for _, tx := range txs {
+		complexity, err := Complexity(tx)
+		if err != nil {
+			return 0, err
+		}
+		total, err = math.Add64(total, complexity)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
@@ -0,0 +1,171 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestComplexity(t *testing.T) {
+	var (
+		in  = &avax.TransferableInput{In: &secp256k1fx.TransferInput{}}
+		out = &avax.TransferableOutput{Out: &secp256k1fx.TransferOutput{}}
+		sig = [secp256k1.SignatureLen]byte{}
+	)
+	newBaseTx := func(numIns, numOuts int) BaseTx {
+		baseTx := BaseTx{}
+		for i := 0; i < numIns; i++ {
+			baseTx.Ins = append(baseTx.Ins, in)
+		}
+		for i := 0; i < numOuts; i++ {
+			baseTx.Outs = append(baseTx.Outs, out)
+		}
+		return baseTx
+	}
+	newCred := func(numSigs int) verify.Verifiable {
+		cred := &secp256k1fx.Credential{}
+		for i := 0; i < numSigs; i++ {
+			cred.Sigs = append(cred.Sigs, sig)
+		}
+		return cred
+	}
+
+	tests := []struct {
+		name               string
+		tx                 *Tx
+		expectedComplexity uint64
+	}{
+		{
+			name: "base tx",
+			tx: &Tx{
+				Unsigned: func() UnsignedTx {
+					baseTx := newBaseTx(2, 1)
+					return &baseTx
+				}(),
+				Creds: []verify.Verifiable{newCred(1), newCred(2)},
+			},
+			expectedComplexity: 3*SignatureComplexity + 2*UTXOReadComplexity + UTXOWriteComplexity,
+		},
+		{
+			name: "import tx",
+			tx: &Tx{
+				Unsigned: &ImportTx{
+					BaseTx:         newBaseTx(0, 1),
+					ImportedInputs: []*avax.TransferableInput{in, in},
+				},
+				Creds: []verify.Verifiable{newCred(1), newCred(1)},
+			},
+			expectedComplexity: 2*SignatureComplexity + 2*UTXOReadComplexity + UTXOWriteComplexity,
+		},
+		{
+			name: "export tx",
+			tx: &Tx{
+				Unsigned: &ExportTx{
+					BaseTx:          newBaseTx(1, 0),
+					ExportedOutputs: []*avax.TransferableOutput{out, out, out},
+				},
+				Creds: []verify.Verifiable{newCred(1)},
+			},
+			expectedComplexity: SignatureComplexity + UTXOReadComplexity + 3*UTXOWriteComplexity,
+		},
+		{
+			name: "permissionless staker",
+			tx: &Tx{
+				Unsigned: &AddPermissionlessDelegatorTx{
+					BaseTx:    newBaseTx(1, 1),
+					StakeOuts: []*avax.TransferableOutput{out},
+				},
+				Creds: []verify.Verifiable{newCred(1)},
+			},
+			expectedComplexity: SignatureComplexity + UTXOReadComplexity + 2*UTXOWriteComplexity + StakerMutationComplexity,
+		},
+		{
+			name: "subnet validator",
+			tx: &Tx{
+				Unsigned: &AddSubnetValidatorTx{
+					BaseTx: newBaseTx(1, 0),
+				},
+				Creds: []verify.Verifiable{newCred(1), newCred(2)},
+			},
+			expectedComplexity: 3*SignatureComplexity + UTXOReadComplexity + StakerMutationComplexity,
+		},
+		{
+			name: "reward validator tx",
+			tx: &Tx{
+				Unsigned: &RewardValidatorTx{},
+			},
+			expectedComplexity: StakerMutationComplexity,
+		},
+		{
+			name: "rekey validator tx",
+			tx: &Tx{
+				Unsigned: &RekeyValidatorTx{
+					BaseTx: newBaseTx(1, 1),
+				},
+				Creds: []verify.Verifiable{newCred(1), newCred(1)},
+			},
+			expectedComplexity: 2*SignatureComplexity + UTXOReadComplexity + UTXOWriteComplexity + 2*StakerMutationComplexity,
+		},
+		{
+			name: "advance time tx",
+			tx: &Tx{
+				Unsigned: &AdvanceTimeTx{},
+			},
+			expectedComplexity: 0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			complexity, err := Complexity(test.tx)
+			require.NoError(err)
+			require.Equal(test.expectedComplexity, complexity)
+		})
+	}
+}
+
+func TestEstimatedComplexity(t *testing.T) {
+	require := require.New(t)
+
+	in := &avax.TransferableInput{In: &secp256k1fx.TransferInput{
+		Input: secp256k1fx.Input{SigIndices: []uint32{0, 1}},
+	}}
+	utx := &AddSubnetValidatorTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			Ins: []*avax.TransferableInput{in},
+		}},
+		SubnetAuth: &secp256k1fx.Input{SigIndices: []uint32{0}},
+	}
+	require.Equal(uint64(3), NumRequiredSignatures(utx))
+
+	complexity, err := EstimatedComplexity(utx)
+	require.NoError(err)
+	require.Equal(3*SignatureComplexity+UTXOReadComplexity+StakerMutationComplexity, complexity)
+
+	importTx := &ImportTx{
+		BaseTx:         BaseTx{BaseTx: avax.BaseTx{Ins: []*avax.TransferableInput{in}}},
+		ImportedInputs: []*avax.TransferableInput{in},
+	}
+	require.Equal(uint64(4), NumRequiredSignatures(importTx))
+	require.Len(importTx.Ins, 1)
+}
+
+func TestTotalComplexity(t *testing.T) {
+	require := require.New(t)
+
+	complexity, err := TotalComplexity([]*Tx{
+		{Unsigned: &RewardValidatorTx{}},
+		{Unsigned: &RewardValidatorTx{}},
+	})
+	require.NoError(err)
+	require.Equal(2*StakerMutationComplexity, complexity)
+}
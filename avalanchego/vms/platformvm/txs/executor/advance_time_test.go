@@ -19,6 +19,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
 func newAdvanceTimeTx(t testing.TB, timestamp time.Time) (*txs.Tx, error) {
@@ -381,7 +382,7 @@ func TestAdvanceTimeTxUpdateStakers(t *testing.T) {
 					uint64(staker.endTime.Unix()),
 					staker.nodeID, // validator ID
 					subnetID,      // Subnet ID
-					[]*secp256k1.PrivateKey{preFundedKeys[0], preFundedKeys[1]},
+					secp256k1fx.NewKeychain(preFundedKeys[0], preFundedKeys[1]),
 					ids.ShortEmpty,
 					nil,
 				)
@@ -476,7 +477,7 @@ func TestAdvanceTimeTxRemoveSubnetValidator(t *testing.T) {
 		uint64(subnetVdr1EndTime.Unix()),   // end time
 		subnetValidatorNodeID,              // Node ID
 		subnetID,                           // Subnet ID
-		[]*secp256k1.PrivateKey{preFundedKeys[0], preFundedKeys[1]},
+		secp256k1fx.NewKeychain(preFundedKeys[0], preFundedKeys[1]),
 		ids.ShortEmpty,
 		nil,
 	)
@@ -506,7 +507,7 @@ func TestAdvanceTimeTxRemoveSubnetValidator(t *testing.T) {
 		uint64(subnetVdr1EndTime.Add(time.Second).Add(defaultMinStakingDuration).Unix()), // end time
 		subnetVdr2NodeID, // Node ID
 		subnetID,         // Subnet ID
-		[]*secp256k1.PrivateKey{preFundedKeys[0], preFundedKeys[1]}, // Keys
+		secp256k1fx.NewKeychain(preFundedKeys[0], preFundedKeys[1]), // Keys
 		ids.ShortEmpty, // reward address
 		nil,
 	)
@@ -583,7 +584,7 @@ func TestTrackedSubnet(t *testing.T) {
 				uint64(subnetVdr1EndTime.Unix()),   // end time
 				subnetValidatorNodeID,              // Node ID
 				subnetID,                           // Subnet ID
-				[]*secp256k1.PrivateKey{preFundedKeys[0], preFundedKeys[1]},
+				secp256k1fx.NewKeychain(preFundedKeys[0], preFundedKeys[1]),
 				ids.ShortEmpty,
 				nil,
 			)
@@ -686,11 +687,11 @@ func TestAdvanceTimeTxDelegatorStakerWeight(t *testing.T) {
 		uint64(pendingDelegatorEndTime.Unix()),
 		nodeID,
 		preFundedKeys[0].PublicKey().Address(),
-		[]*secp256k1.PrivateKey{
+		secp256k1fx.NewKeychain(
 			preFundedKeys[0],
 			preFundedKeys[1],
 			preFundedKeys[4],
-		},
+		),
 		ids.ShortEmpty,
 		nil,
 	)
@@ -785,7 +786,7 @@ func TestAdvanceTimeTxDelegatorStakers(t *testing.T) {
 		uint64(pendingDelegatorEndTime.Unix()),
 		nodeID,
 		preFundedKeys[0].PublicKey().Address(),
-		[]*secp256k1.PrivateKey{preFundedKeys[0], preFundedKeys[1], preFundedKeys[4]},
+		secp256k1fx.NewKeychain(preFundedKeys[0], preFundedKeys[1], preFundedKeys[4]),
 		ids.ShortEmpty,
 		nil,
 	)
@@ -899,7 +900,7 @@ func addPendingValidator(
 		nodeID,
 		ids.GenerateTestShortID(),
 		reward.PercentDenominator,
-		keys,
+		secp256k1fx.NewKeychain(keys...),
 		ids.ShortEmpty,
 		nil,
 	)
@@ -80,6 +80,54 @@ func (*AtomicTxExecutor) BaseTx(*txs.BaseTx) error {
 	return ErrWrongTxType
 }
 
+func (*AtomicTxExecutor) AddSubnetAllowListEntriesTx(*txs.AddSubnetAllowListEntriesTx) error {
+	return ErrWrongTxType
+}
+
+func (*AtomicTxExecutor) RemoveSubnetAllowListEntriesTx(*txs.RemoveSubnetAllowListEntriesTx) error {
+	return ErrWrongTxType
+}
+
+func (*AtomicTxExecutor) AddCappedPermissionlessValidatorTx(*txs.AddCappedPermissionlessValidatorTx) error {
+	return ErrWrongTxType
+}
+
+func (*AtomicTxExecutor) ExitValidatorTx(*txs.ExitValidatorTx) error {
+	return ErrWrongTxType
+}
+
+func (*AtomicTxExecutor) SetSubnetValidatorWeightTx(*txs.SetSubnetValidatorWeightTx) error {
+	return ErrWrongTxType
+}
+
+func (*AtomicTxExecutor) ParameterChangeTx(*txs.ParameterChangeTx) error {
+	return ErrWrongTxType
+}
+
+func (*AtomicTxExecutor) RekeyValidatorTx(*txs.RekeyValidatorTx) error {
+	return ErrWrongTxType
+}
+
+func (*AtomicTxExecutor) RegisterNameTx(*txs.RegisterNameTx) error {
+	return ErrWrongTxType
+}
+
+func (*AtomicTxExecutor) UpdateNameTx(*txs.UpdateNameTx) error {
+	return ErrWrongTxType
+}
+
+func (*AtomicTxExecutor) AddPermissionlessValidatorWithMetadataTx(*txs.AddPermissionlessValidatorWithMetadataTx) error {
+	return ErrWrongTxType
+}
+
+func (*AtomicTxExecutor) ClaimRewardTx(*txs.ClaimRewardTx) error {
+	return ErrWrongTxType
+}
+
+func (*AtomicTxExecutor) BindRewardAddressTx(*txs.BindRewardAddressTx) error {
+	return ErrWrongTxType
+}
+
 func (e *AtomicTxExecutor) ImportTx(tx *txs.ImportTx) error {
 	return e.atomicTx(tx)
 }
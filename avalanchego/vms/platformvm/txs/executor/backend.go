@@ -23,4 +23,8 @@ type Backend struct {
 	Uptimes      uptime.Calculator
 	Rewards      reward.Calculator
 	Bootstrapped *utils.Atomic[bool]
+	// Pre-admission hooks that the txs added to the mempool, or to the blocks
+	// built by this node, pass through before being executed. They don't apply
+	// to the verification of blocks. See [Backend.Execute].
+	Stages []Stage
 }
@@ -0,0 +1,151 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+)
+
+var ErrTooManyStakerChanges = errors.New("too many staker changes")
+
+// StakerChange is a modification of the current staker set that happens when
+// the chain time is advanced.
+type StakerChange struct {
+	Time   time.Time
+	Staker *state.Staker
+	// Added is true if [Staker] joined the current staker set and false if it
+	// left it.
+	Added bool
+	// Supply is the supply of the Primary Network after the change.
+	Supply uint64
+}
+
+// SimulateChainTime advances the chain time of [chainState] to [endTime] one
+// staker change at a time, as if a block was issued at every staker change
+// time. Every permissionless staker is assumed to be rewarded when it leaves
+// the current staker set.
+//
+// Returns the staker changes that occurred, in order. If more than [maxChanges]
+// changes occur, [ErrTooManyStakerChanges] is returned.
+func SimulateChainTime(
+	backend *Backend,
+	chainState state.Chain,
+	endTime time.Time,
+	maxChanges int,
+) ([]StakerChange, error) {
+	var changes []StakerChange
+	for {
+		chainTime := chainState.GetTimestamp()
+		nextChangeTime, err := GetNextStakerChangeTime(chainState)
+		if errors.Is(err, database.ErrNotFound) {
+			// There are no stakers left.
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		// Stakers may have already reached their end time without having been
+		// removed, so [nextChangeTime] can be equal to [chainTime].
+		if nextChangeTime.After(endTime) || nextChangeTime.Before(chainTime) {
+			break
+		}
+
+		added, err := stakersStartingBy(chainState, nextChangeTime)
+		if err != nil {
+			return nil, err
+		}
+		removed, err := stakersEndingBy(chainState, nextChangeTime)
+		if err != nil {
+			return nil, err
+		}
+		if len(added) == 0 && len(removed) == 0 {
+			// Can happen if the next staker change time is a fallback value.
+			break
+		}
+		if len(changes)+len(added)+len(removed) > maxChanges {
+			return nil, fmt.Errorf("%w: more than %d changes before %s",
+				ErrTooManyStakerChanges,
+				maxChanges,
+				nextChangeTime,
+			)
+		}
+
+		// Promotes the pending stakers and removes the permissioned validators.
+		if _, err := AdvanceTimeTo(backend, chainState, nextChangeTime); err != nil {
+			return nil, err
+		}
+
+		supply, err := chainState.GetCurrentSupply(constants.PrimaryNetworkID)
+		if err != nil {
+			return nil, err
+		}
+		for _, staker := range added {
+			changes = append(changes, StakerChange{
+				Time:   nextChangeTime,
+				Staker: staker,
+				Added:  true,
+				Supply: supply,
+			})
+		}
+
+		// Permissionless stakers would be removed by RewardValidatorTxs.
+		for _, staker := range removed {
+			switch {
+			case staker.Priority.IsPermissionedValidator():
+			case staker.Priority.IsValidator():
+				chainState.DeleteCurrentValidator(staker)
+			default:
+				chainState.DeleteCurrentDelegator(staker)
+			}
+			changes = append(changes, StakerChange{
+				Time:   nextChangeTime,
+				Staker: staker,
+				Supply: supply,
+			})
+		}
+	}
+	return changes, nil
+}
+
+// stakersStartingBy returns the pending stakers with a start time not after
+// [timestamp].
+func stakersStartingBy(chainState state.Chain, timestamp time.Time) ([]*state.Staker, error) {
+	it, err := chainState.GetPendingStakerIteratorUntil(timestamp)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Release()
+
+	var stakers []*state.Staker
+	for it.Next() {
+		stakers = append(stakers, it.Value())
+	}
+	return stakers, nil
+}
+
+// stakersEndingBy returns the current stakers with an end time not after
+// [timestamp].
+func stakersEndingBy(chainState state.Chain, timestamp time.Time) ([]*state.Staker, error) {
+	it, err := chainState.GetCurrentStakerIterator()
+	if err != nil {
+		return nil, err
+	}
+	defer it.Release()
+
+	var stakers []*state.Staker
+	for it.Next() {
+		staker := it.Value()
+		if staker.EndTime.After(timestamp) {
+			break
+		}
+		stakers = append(stakers, staker)
+	}
+	return stakers, nil
+}
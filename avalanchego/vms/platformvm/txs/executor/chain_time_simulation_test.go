@@ -0,0 +1,77 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+)
+
+func TestSimulateChainTime(t *testing.T) {
+	require := require.New(t)
+	env := newEnvironment(t, apricotPhase5)
+	env.ctx.Lock.Lock()
+	defer env.ctx.Lock.Unlock()
+
+	pendingValidatorStartTime := defaultValidateStartTime.Add(time.Second)
+	pendingValidatorEndTime := pendingValidatorStartTime.Add(defaultMinStakingDuration)
+	nodeID := ids.GenerateTestNodeID()
+	_, err := addPendingValidator(env, pendingValidatorStartTime, pendingValidatorEndTime, nodeID, []*secp256k1.PrivateKey{preFundedKeys[0]})
+	require.NoError(err)
+
+	it, err := env.state.GetCurrentStakerIterator()
+	require.NoError(err)
+	numGenesisStakers := 0
+	for it.Next() {
+		numGenesisStakers++
+	}
+	it.Release()
+
+	initialSupply, err := env.state.GetCurrentSupply(constants.PrimaryNetworkID)
+	require.NoError(err)
+
+	chainState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	_, err = SimulateChainTime(&env.backend, chainState, defaultValidateEndTime, 1)
+	require.ErrorIs(err, ErrTooManyStakerChanges)
+
+	chainState, err = state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	changes, err := SimulateChainTime(&env.backend, chainState, defaultValidateEndTime, 1000)
+	require.NoError(err)
+	require.Len(changes, 2+numGenesisStakers)
+
+	added := changes[0]
+	require.True(added.Added)
+	require.Equal(nodeID, added.Staker.NodeID)
+	require.Equal(pendingValidatorStartTime.Unix(), added.Time.Unix())
+
+	removed := changes[1]
+	require.False(removed.Added)
+	require.Equal(nodeID, removed.Staker.NodeID)
+	require.Equal(pendingValidatorEndTime.Unix(), removed.Time.Unix())
+	require.Equal(initialSupply+removed.Staker.PotentialReward, added.Supply)
+	require.Equal(added.Supply, removed.Supply)
+
+	for _, change := range changes[2:] {
+		require.False(change.Added)
+		require.Equal(defaultValidateEndTime.Unix(), change.Time.Unix())
+	}
+
+	require.Equal(defaultValidateEndTime.Unix(), chainState.GetTimestamp().Unix())
+
+	// The base state must not be modified
+	require.Equal(defaultValidateStartTime.Unix(), env.state.GetTimestamp().Unix())
+	_, err = env.state.GetPendingValidator(constants.PrimaryNetworkID, nodeID)
+	require.NoError(err)
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+var (
+	ErrClaimableRewardsNotActive   = errors.New("attempting to claim rewards prior to the activation of claimable rewards")
+	ErrInsufficientClaimableReward = errors.New("claimed amount exceeds the claimable rewards")
+
+	errUnauthorizedRewardClaim = errors.New("unauthorized reward claim")
+)
+
+// accrueReward adds [amount] of [assetID] to the claimable balance of the
+// rewards owner [owner].
+func accrueReward(chainState state.Chain, owner fx.Owner, assetID ids.ID, amount uint64) error {
+	ownerID, err := txs.RewardsOwnerID(owner)
+	if err != nil {
+		return fmt.Errorf("failed to compute rewards owner ID: %w", err)
+	}
+	balance, err := chainState.GetClaimableReward(ownerID, assetID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch claimable rewards of %s: %w", ownerID, err)
+	}
+	balance, err = math.Add64(balance, amount)
+	if err != nil {
+		return err
+	}
+	chainState.SetClaimableReward(ownerID, assetID, balance)
+	return nil
+}
+
+// verifyClaimRewardTx carries out the validation for a ClaimRewardTx. The
+// claimed amount must not exceed the claimable balance of [tx.Owner] and the
+// issuer must control [tx.Owner]. It returns the ID of [tx.Owner] and its
+// claimable balance before the claim.
+func verifyClaimRewardTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.ClaimRewardTx,
+) (ids.ID, uint64, error) {
+	if !backend.Config.IsClaimableRewardsActivated(chainState.GetTimestamp()) {
+		return ids.Empty, 0, ErrClaimableRewardsNotActive
+	}
+
+	// Verify the tx is well-formed
+	if err := sTx.SyntacticVerify(backend.Ctx); err != nil {
+		return ids.Empty, 0, err
+	}
+
+	if err := avax.VerifyMemoFieldLength(tx.Memo, true /*=isDurangoActive*/); err != nil {
+		return ids.Empty, 0, err
+	}
+
+	ownerID, err := txs.RewardsOwnerID(tx.Owner)
+	if err != nil {
+		return ids.Empty, 0, fmt.Errorf("failed to compute rewards owner ID: %w", err)
+	}
+	balance, err := chainState.GetClaimableReward(ownerID, tx.AssetID)
+	if err != nil {
+		return ids.Empty, 0, fmt.Errorf("failed to fetch claimable rewards of %s: %w", ownerID, err)
+	}
+	if tx.Amount > balance {
+		return ids.Empty, 0, fmt.Errorf("%w: claimed %d, claimable %d", ErrInsufficientClaimableReward, tx.Amount, balance)
+	}
+
+	if !backend.Bootstrapped.Get() {
+		// Not bootstrapped yet -- don't need to do full verification.
+		return ownerID, balance, nil
+	}
+
+	if len(sTx.Creds) == 0 {
+		// Ensure there is at least one credential for the owner authorization
+		return ids.Empty, 0, errWrongNumberOfCredentials
+	}
+
+	baseTxCredsLen := len(sTx.Creds) - 1
+	ownerCred := sTx.Creds[baseTxCredsLen]
+	if err := backend.Fx.VerifyPermission(sTx.Unsigned, tx.OwnerAuth, ownerCred, tx.Owner); err != nil {
+		return ids.Empty, 0, fmt.Errorf("%w: %w", errUnauthorizedRewardClaim, err)
+	}
+
+	fee, err := state.GetParameter(chainState, txs.TxFeeParameter, backend.Config.TxFee)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+
+	// Verify the flowcheck
+	if err := backend.FlowChecker.VerifySpend(
+		sTx.Unsigned,
+		chainState,
+		tx.Ins,
+		tx.Outs,
+		sTx.Creds[:baseTxCredsLen],
+		map[ids.ID]uint64{
+			backend.Ctx.AVAXAssetID: fee,
+		},
+	); err != nil {
+		return ids.Empty, 0, fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
+	}
+	return ownerID, balance, nil
+}
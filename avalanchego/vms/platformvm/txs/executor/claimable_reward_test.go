@@ -0,0 +1,143 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestClaimableRewards(t *testing.T) {
+	require := require.New(t)
+	env := newEnvironment(t, durango)
+	env.ctx.Lock.Lock()
+	defer env.ctx.Lock.Unlock()
+
+	var (
+		potentialReward = uint64(1_000)
+		startTime       = env.state.GetTimestamp()
+		endTime         = startTime.Add(defaultMinStakingDuration)
+		rewardsOwner    = &secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{preFundedKeys[1].Address()},
+		}
+		to = &secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{preFundedKeys[2].Address()},
+		}
+	)
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	validatorTx, err := env.txBuilder.NewAddPermissionlessValidatorTx(
+		env.config.MinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		ids.GenerateTestNodeID(),
+		signer.NewProofOfPossession(sk),
+		preFundedKeys[1].Address(), // reward address
+		reward.PercentDenominator,
+		secp256k1fx.NewKeychain(preFundedKeys[0]),
+		preFundedKeys[0].Address(), // change address
+		nil,
+	)
+	require.NoError(err)
+	validator, err := state.NewCurrentStaker(validatorTx.ID(), validatorTx.Unsigned.(txs.Staker), startTime, potentialReward)
+	require.NoError(err)
+	env.state.PutCurrentValidator(validator)
+	env.state.AddTx(validatorTx, status.Committed)
+	env.state.SetHeight(1)
+	require.NoError(env.state.Commit())
+
+	newClaimRewardTx := func(amount uint64) *txs.Tx {
+		tx, err := env.txBuilder.NewClaimRewardTx(
+			rewardsOwner,
+			env.ctx.AVAXAssetID,
+			amount,
+			to,
+			secp256k1fx.NewKeychain(preFundedKeys[0], preFundedKeys[1]),
+			preFundedKeys[0].Address(), // change address
+			nil,
+		)
+		require.NoError(err)
+		return tx
+	}
+	claim := func(tx *txs.Tx) (state.Diff, error) {
+		onAcceptState, err := state.NewDiff(lastAcceptedID, env)
+		require.NoError(err)
+		return onAcceptState, tx.Unsigned.Visit(&StandardTxExecutor{
+			Backend: &env.backend,
+			State:   onAcceptState,
+			Tx:      tx,
+		})
+	}
+
+	// Rewards can't be claimed before claimable rewards are activated.
+	_, err = claim(newClaimRewardTx(1))
+	require.ErrorIs(err, ErrClaimableRewardsNotActive)
+
+	env.config.ClaimableRewardsTime = startTime
+	env.state.SetTimestamp(endTime)
+
+	rewardTx, err := newRewardValidatorTx(t, validatorTx.ID())
+	require.NoError(err)
+	onCommitState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+	onAbortState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+	require.NoError(rewardTx.Unsigned.Visit(&ProposalTxExecutor{
+		OnCommitState: onCommitState,
+		OnAbortState:  onAbortState,
+		Backend:       &env.backend,
+		Tx:            rewardTx,
+	}))
+	require.NoError(onCommitState.Apply(env.state))
+	env.state.SetHeight(2)
+	require.NoError(env.state.Commit())
+
+	// The reward accrued to the rewards owner instead of being paid out.
+	rewardUTXOs, err := env.state.GetRewardUTXOs(validatorTx.ID())
+	require.NoError(err)
+	require.Empty(rewardUTXOs)
+
+	ownerID, err := txs.RewardsOwnerID(rewardsOwner)
+	require.NoError(err)
+	claimable, err := env.state.GetClaimableReward(ownerID, env.ctx.AVAXAssetID)
+	require.NoError(err)
+	require.Equal(potentialReward, claimable)
+
+	_, err = claim(newClaimRewardTx(potentialReward + 1))
+	require.ErrorIs(err, ErrInsufficientClaimableReward)
+
+	claimTx := newClaimRewardTx(600)
+	onAcceptState, err := claim(claimTx)
+	require.NoError(err)
+
+	claimable, err = onAcceptState.GetClaimableReward(ownerID, env.ctx.AVAXAssetID)
+	require.NoError(err)
+	require.Equal(uint64(400), claimable)
+
+	claimedUTXOID := avax.UTXOID{
+		TxID:        claimTx.ID(),
+		OutputIndex: uint32(len(claimTx.Unsigned.(*txs.ClaimRewardTx).Outs)),
+	}
+	claimedUTXO, err := onAcceptState.GetUTXO(claimedUTXOID.InputID())
+	require.NoError(err)
+	require.Equal(env.ctx.AVAXAssetID, claimedUTXO.AssetID())
+	require.Equal(&secp256k1fx.TransferOutput{
+		Amt:          600,
+		OutputOwners: *to,
+	}, claimedUTXO.Out)
+}
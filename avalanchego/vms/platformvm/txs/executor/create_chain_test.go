@@ -35,7 +35,7 @@ func TestCreateChainTxInsufficientControlSigs(t *testing.T) {
 		constants.AVMID,
 		nil,
 		"chain name",
-		[]*secp256k1.PrivateKey{preFundedKeys[0], preFundedKeys[1]},
+		secp256k1fx.NewKeychain(preFundedKeys[0], preFundedKeys[1]),
 		ids.ShortEmpty,
 		nil,
 	)
@@ -69,7 +69,7 @@ func TestCreateChainTxWrongControlSig(t *testing.T) {
 		constants.AVMID,
 		nil,
 		"chain name",
-		[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
 		ids.ShortEmpty,
 		nil,
 	)
@@ -110,7 +110,7 @@ func TestCreateChainTxNoSuchSubnet(t *testing.T) {
 		constants.AVMID,
 		nil,
 		"chain name",
-		[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
 		ids.ShortEmpty,
 		nil,
 	)
@@ -143,7 +143,7 @@ func TestCreateChainTxValid(t *testing.T) {
 		constants.AVMID,
 		nil,
 		"chain name",
-		[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
 		ids.ShortEmpty,
 		nil,
 	)
@@ -194,10 +194,10 @@ func TestCreateChainTxAP3FeeChange(t *testing.T) {
 			env := newEnvironment(t, banff)
 			env.config.ApricotPhase3Time = ap3Time
 
-			ins, outs, _, signers, err := env.utxosHandler.Spend(env.state, preFundedKeys, 0, test.fee, ids.ShortEmpty)
+			ins, outs, _, signers, err := env.utxosHandler.Spend(env.state, secp256k1fx.NewKeychain(preFundedKeys...), 0, test.fee, ids.ShortEmpty)
 			require.NoError(err)
 
-			subnetAuth, subnetSigners, err := env.utxosHandler.Authorize(env.state, testSubnet1.ID(), preFundedKeys)
+			subnetAuth, subnetSigners, err := env.utxosHandler.Authorize(env.state, testSubnet1.ID(), secp256k1fx.NewKeychain(preFundedKeys...))
 			require.NoError(err)
 
 			signers = append(signers, subnetSigners)
@@ -216,7 +216,7 @@ func TestCreateChainTxAP3FeeChange(t *testing.T) {
 				SubnetAuth: subnetAuth,
 			}
 			tx := &txs.Tx{Unsigned: utx}
-			require.NoError(tx.Sign(txs.Codec, signers))
+			require.NoError(tx.SignWith(txs.Codec, signers))
 
 			stateDiff, err := state.NewDiff(lastAcceptedID, env)
 			require.NoError(err)
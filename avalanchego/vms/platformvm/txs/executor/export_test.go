@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
 func TestNewExportTx(t *testing.T) {
@@ -52,7 +53,7 @@ func TestNewExportTx(t *testing.T) {
 				defaultBalance-defaultTxFee, // Amount of tokens to export
 				tt.destinationChainID,
 				to,
-				tt.sourceKeys,
+				secp256k1fx.NewKeychain(tt.sourceKeys...),
 				ids.ShortEmpty, // Change address
 				nil,
 			)
@@ -0,0 +1,64 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+var (
+	_ txs.ExtensionVisitor = (*StandardTxExecutor)(nil)
+	_ txs.ExtensionVisitor = (*AtomicTxExecutor)(nil)
+	_ txs.ExtensionVisitor = (*ProposalTxExecutor)(nil)
+
+	ErrUnknownExtension = errors.New("no executor is registered for the extension tx")
+
+	errDuplicateExtensionExecutor = errors.New("duplicate extension executor")
+
+	extensionExecutorsLock sync.RWMutex
+	extensionExecutors     = make(map[reflect.Type]ExtensionExecutor)
+)
+
+// ExtensionExecutor executes an extension tx as part of a standard block. It
+// must verify the tx against [e.State], modify [e.State] and fill the outputs
+// of [e], as the StandardTxExecutor does for the built-in txs.
+type ExtensionExecutor func(e *StandardTxExecutor, tx txs.UnsignedTx) error
+
+// RegisterExtensionExecutor registers [executor] as the executor of the txs
+// of the same type as [tx]. See [txs.RegisterExtension].
+func RegisterExtensionExecutor(tx txs.UnsignedTx, executor ExtensionExecutor) error {
+	txType := reflect.TypeOf(tx)
+
+	extensionExecutorsLock.Lock()
+	defer extensionExecutorsLock.Unlock()
+
+	if _, ok := extensionExecutors[txType]; ok {
+		return fmt.Errorf("%w: %s", errDuplicateExtensionExecutor, txType)
+	}
+	extensionExecutors[txType] = executor
+	return nil
+}
+
+func (e *StandardTxExecutor) ExtensionTx(tx txs.UnsignedTx) error {
+	extensionExecutorsLock.RLock()
+	executor, ok := extensionExecutors[reflect.TypeOf(tx)]
+	extensionExecutorsLock.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrUnknownExtension, tx)
+	}
+	return executor(e, tx)
+}
+
+func (*AtomicTxExecutor) ExtensionTx(txs.UnsignedTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) ExtensionTx(txs.UnsignedTx) error {
+	return ErrWrongTxType
+}
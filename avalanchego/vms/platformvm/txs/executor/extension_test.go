@@ -0,0 +1,56 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+type testExtensionTx struct {
+	txs.BaseTx `serialize:"true"`
+}
+
+func (tx *testExtensionTx) Visit(visitor txs.Visitor) error {
+	return txs.VisitExtension(tx, visitor)
+}
+
+func TestExtensionExecutor(t *testing.T) {
+	require := require.New(t)
+
+	tx := &testExtensionTx{}
+	e := &StandardTxExecutor{}
+
+	err := tx.Visit(e)
+	require.ErrorIs(err, ErrUnknownExtension)
+
+	var executed []txs.UnsignedTx
+	executor := func(executor *StandardTxExecutor, tx txs.UnsignedTx) error {
+		require.Equal(e, executor)
+		executed = append(executed, tx)
+		return nil
+	}
+	require.NoError(RegisterExtensionExecutor(tx, executor))
+	t.Cleanup(func() {
+		extensionExecutorsLock.Lock()
+		delete(extensionExecutors, reflect.TypeOf(tx))
+		extensionExecutorsLock.Unlock()
+	})
+
+	err = RegisterExtensionExecutor(tx, executor)
+	require.ErrorIs(err, errDuplicateExtensionExecutor)
+
+	require.NoError(tx.Visit(e))
+	require.Equal([]txs.UnsignedTx{tx}, executed)
+
+	// Extension txs are only executed in standard blocks
+	err = tx.Visit(&ProposalTxExecutor{})
+	require.ErrorIs(err, ErrWrongTxType)
+	err = tx.Visit(&AtomicTxExecutor{})
+	require.ErrorIs(err, ErrWrongTxType)
+}
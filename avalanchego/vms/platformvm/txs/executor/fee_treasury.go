@@ -0,0 +1,67 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// FeeTreasuryOutputIndex is the output index of the UTXO that pays the fees
+// redirected from a tx to the fee treasury. It can't collide with the index of
+// an output, stake output or reward UTXO of the tx.
+const FeeTreasuryOutputIndex = math.MaxUint32
+
+// FeeTreasuryAmount returns how much of [burned] is redirected to the fee
+// treasury.
+func FeeTreasuryAmount(cfg *config.Config, burned uint64) uint64 {
+	amount := new(big.Int).SetUint64(burned)
+	amount.Mul(amount, new(big.Int).SetUint64(cfg.FeeTreasuryPercentage))
+	amount.Div(amount, new(big.Int).SetUint64(reward.PercentDenominator))
+	return amount.Uint64()
+}
+
+// PayFeeTreasury redirects the configured share of the AVAX burned by [tx] to
+// the fee treasury, if the fee treasury is active at the timestamp of
+// [chainState].
+//
+// Invariant: [tx] must have been executed on [chainState].
+func PayFeeTreasury(backend *Backend, chainState state.Chain, tx *txs.Tx) error {
+	if !backend.Config.IsFeeTreasuryActivated(chainState.GetTimestamp()) {
+		return nil
+	}
+
+	burned, err := txs.Burned(tx.Unsigned, backend.Ctx.AVAXAssetID)
+	if err != nil {
+		return err
+	}
+	amount := FeeTreasuryAmount(backend.Config, burned)
+	if amount == 0 {
+		return nil
+	}
+
+	chainState.AddUTXO(&avax.UTXO{
+		UTXOID: avax.UTXOID{
+			TxID:        tx.ID(),
+			OutputIndex: FeeTreasuryOutputIndex,
+		},
+		Asset: avax.Asset{ID: backend.Ctx.AVAXAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: amount,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{backend.Config.FeeTreasuryAddress},
+			},
+		},
+	})
+	return nil
+}
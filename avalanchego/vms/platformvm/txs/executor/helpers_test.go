@@ -226,7 +226,7 @@ func addSubnet(
 			preFundedKeys[1].PublicKey().Address(),
 			preFundedKeys[2].PublicKey().Address(),
 		},
-		[]*secp256k1.PrivateKey{preFundedKeys[0]},
+		secp256k1fx.NewKeychain(preFundedKeys[0]),
 		preFundedKeys[0].PublicKey().Address(),
 		nil,
 	)
@@ -325,11 +325,19 @@ func defaultConfig(t *testing.T, f fork) *config.Config {
 			MintingPeriod:      365 * 24 * time.Hour,
 			SupplyCap:          720 * units.MegaAvax,
 		},
-		ApricotPhase3Time: apricotPhase3Time,
-		ApricotPhase5Time: apricotPhase5Time,
-		BanffTime:         banffTime,
-		CortinaTime:       cortinaTime,
-		DurangoTime:       durangoTime,
+		ApricotPhase3Time:         apricotPhase3Time,
+		ApricotPhase5Time:         apricotPhase5Time,
+		BanffTime:                 banffTime,
+		CortinaTime:               cortinaTime,
+		DurangoTime:               durangoTime,
+		SubnetAllowListTime:       durangoTime,
+		CappedDelegationTime:      durangoTime,
+		ExitValidatorTime:         durangoTime,
+		SubnetValidatorWeightTime: durangoTime,
+		ParameterChangeTime:       durangoTime,
+		RekeyValidatorTime:        durangoTime,
+		NameRegistryTime:          durangoTime,
+		ValidatorMetadataTime:     durangoTime,
 	}
 }
 
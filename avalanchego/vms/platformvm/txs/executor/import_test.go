@@ -115,7 +115,7 @@ func TestNewImportTx(t *testing.T) {
 			tx, err := env.txBuilder.NewImportTx(
 				tt.sourceChainID,
 				to,
-				tt.sourceKeys,
+				secp256k1fx.NewKeychain(tt.sourceKeys...),
 				ids.ShortEmpty,
 				nil,
 			)
@@ -0,0 +1,235 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+var (
+	ErrNameRegistryNotActive = errors.New("attempting to register a name prior to the activation of the name registry")
+	ErrNameTaken             = errors.New("name is already registered")
+	ErrNameNotRegistered     = errors.New("name isn't registered")
+	ErrNameExpired           = errors.New("name registration expired")
+	ErrInvalidNameExpiry     = errors.New("name expiry must be after the chain time and no later than the end of the validation period")
+
+	errUnauthorizedNameRegistration = errors.New("unauthorized name registration")
+	errUnauthorizedNameUpdate       = errors.New("unauthorized name update")
+)
+
+// verifyRegisterNameTx carries out the validation for a RegisterNameTx. The
+// name must not be held by an unexpired registration and the issuer must
+// control the validation rewards owner of [tx.NodeID].
+func verifyRegisterNameTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.RegisterNameTx,
+) error {
+	if !backend.Config.IsNameRegistryActivated(chainState.GetTimestamp()) {
+		return ErrNameRegistryNotActive
+	}
+
+	// Verify the tx is well-formed
+	if err := sTx.SyntacticVerify(backend.Ctx); err != nil {
+		return err
+	}
+
+	if err := avax.VerifyMemoFieldLength(tx.Memo, true /*=isDurangoActive*/); err != nil {
+		return err
+	}
+
+	now := uint64(chainState.GetTimestamp().Unix())
+	record, err := chainState.GetName(tx.Name)
+	switch {
+	case err == nil && record.Expiry > now:
+		return fmt.Errorf("%w: %q", ErrNameTaken, tx.Name)
+	case err != nil && err != database.ErrNotFound:
+		return fmt.Errorf("failed to fetch name %q: %w", tx.Name, err)
+	}
+
+	vdr, vdrTx, err := getNameValidator(chainState, tx.NodeID)
+	if err != nil {
+		return err
+	}
+	if tx.Expiry <= now || tx.Expiry > uint64(vdr.EndTime.Unix()) {
+		return fmt.Errorf("%w: expiry %d, validator end time %d", ErrInvalidNameExpiry, tx.Expiry, vdr.EndTime.Unix())
+	}
+
+	if !backend.Bootstrapped.Get() {
+		// Not bootstrapped yet -- don't need to do full verification.
+		return nil
+	}
+
+	return verifyNameAuthorization(
+		backend,
+		chainState,
+		sTx,
+		&tx.BaseTx,
+		tx.ValidatorAuth,
+		vdrTx.ValidationRewardsOwner(),
+		errUnauthorizedNameRegistration,
+	)
+}
+
+// verifyUpdateNameTx carries out the validation for an UpdateNameTx. The name
+// must be held by an unexpired registration and the issuer must control the
+// owner set by the tx that last registered or updated it.
+func verifyUpdateNameTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.UpdateNameTx,
+) (*state.NameRecord, error) {
+	if !backend.Config.IsNameRegistryActivated(chainState.GetTimestamp()) {
+		return nil, ErrNameRegistryNotActive
+	}
+
+	// Verify the tx is well-formed
+	if err := sTx.SyntacticVerify(backend.Ctx); err != nil {
+		return nil, err
+	}
+
+	if err := avax.VerifyMemoFieldLength(tx.Memo, true /*=isDurangoActive*/); err != nil {
+		return nil, err
+	}
+
+	record, err := chainState.GetName(tx.Name)
+	if err == database.ErrNotFound {
+		return nil, fmt.Errorf("%w: %q", ErrNameNotRegistered, tx.Name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch name %q: %w", tx.Name, err)
+	}
+
+	now := uint64(chainState.GetTimestamp().Unix())
+	if record.Expiry <= now {
+		return nil, fmt.Errorf("%w: %q expired at %d", ErrNameExpired, tx.Name, record.Expiry)
+	}
+
+	vdr, _, err := getNameValidator(chainState, record.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	if tx.Expiry <= now || tx.Expiry > uint64(vdr.EndTime.Unix()) {
+		return nil, fmt.Errorf("%w: expiry %d, validator end time %d", ErrInvalidNameExpiry, tx.Expiry, vdr.EndTime.Unix())
+	}
+
+	if !backend.Bootstrapped.Get() {
+		// Not bootstrapped yet -- don't need to do full verification.
+		return record, nil
+	}
+
+	owner, err := GetNameOwner(chainState, record)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyNameAuthorization(
+		backend,
+		chainState,
+		sTx,
+		&tx.BaseTx,
+		tx.NameAuth,
+		owner,
+		errUnauthorizedNameUpdate,
+	); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// GetNameOwner returns the owner of the name registered by [record], which is
+// set by the tx that last registered or updated it.
+func GetNameOwner(chainState state.Chain, record *state.NameRecord) (fx.Owner, error) {
+	recordTx, _, err := chainState.GetTx(record.TxID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch name tx %s: %w", record.TxID, err)
+	}
+	switch utx := recordTx.Unsigned.(type) {
+	case *txs.RegisterNameTx:
+		return utx.Owner, nil
+	case *txs.UpdateNameTx:
+		return utx.Owner, nil
+	default:
+		return nil, ErrWrongTxType
+	}
+}
+
+// getNameValidator returns the current primary network validator [nodeID] and
+// the tx that added it. Names are only backed by primary network stake.
+func getNameValidator(chainState state.Chain, nodeID ids.NodeID) (*state.Staker, txs.ValidatorTx, error) {
+	vdr, err := chainState.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"%s %w of %s: %w",
+			nodeID,
+			ErrNotValidator,
+			constants.PrimaryNetworkID,
+			err,
+		)
+	}
+
+	vdrTxIntf, _, err := chainState.GetTx(vdr.TxID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch validator tx %s: %w", vdr.TxID, err)
+	}
+	vdrTx, ok := vdrTxIntf.Unsigned.(txs.ValidatorTx)
+	if !ok {
+		return nil, nil, ErrWrongTxType
+	}
+	return vdr, vdrTx, nil
+}
+
+// verifyNameAuthorization verifies that the last credential of [sTx] satisfies
+// [owner] and that the remaining credentials burn the name registration fee.
+func verifyNameAuthorization(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.BaseTx,
+	auth verify.Verifiable,
+	owner fx.Owner,
+	errUnauthorized error,
+) error {
+	if len(sTx.Creds) == 0 {
+		// Ensure there is at least one credential for the name authorization
+		return errWrongNumberOfCredentials
+	}
+
+	baseTxCredsLen := len(sTx.Creds) - 1
+	authCred := sTx.Creds[baseTxCredsLen]
+	if err := backend.Fx.VerifyPermission(sTx.Unsigned, auth, authCred, owner); err != nil {
+		return fmt.Errorf("%w: %w", errUnauthorized, err)
+	}
+
+	fee, err := state.GetParameter(chainState, txs.NameRegistrationFeeParameter, backend.Config.NameRegistrationFee)
+	if err != nil {
+		return err
+	}
+
+	// Verify the flowcheck
+	if err := backend.FlowChecker.VerifySpend(
+		sTx.Unsigned,
+		chainState,
+		tx.Ins,
+		tx.Outs,
+		sTx.Creds[:baseTxCredsLen],
+		map[ids.ID]uint64{
+			backend.Ctx.AVAXAssetID: fee,
+		},
+	); err != nil {
+		return fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
+	}
+	return nil
+}
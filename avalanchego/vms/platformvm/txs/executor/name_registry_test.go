@@ -0,0 +1,171 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/builder"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestNameRegistry(t *testing.T) {
+	require := require.New(t)
+	env := newEnvironment(t, durango)
+	env.ctx.Lock.Lock()
+	defer env.ctx.Lock.Unlock()
+
+	var (
+		name      = "validator"
+		nodeID    = ids.GenerateTestNodeID()
+		startTime = env.state.GetTimestamp()
+		endTime   = startTime.Add(defaultMinStakingDuration)
+		expiry    = uint64(startTime.Add(time.Hour).Unix())
+		owner     = &secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{preFundedKeys[2].Address()},
+		}
+	)
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	validatorTx, err := env.txBuilder.NewAddPermissionlessValidatorTx(
+		env.config.MinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		nodeID,
+		signer.NewProofOfPossession(sk),
+		preFundedKeys[1].Address(), // reward address
+		reward.PercentDenominator,
+		secp256k1fx.NewKeychain(preFundedKeys[0]),
+		preFundedKeys[0].Address(), // change address
+		nil,
+	)
+	require.NoError(err)
+	validator, err := state.NewCurrentStaker(validatorTx.ID(), validatorTx.Unsigned.(txs.Staker), startTime, 0)
+	require.NoError(err)
+	env.state.PutCurrentValidator(validator)
+	env.state.AddTx(validatorTx, status.Committed)
+	env.state.SetHeight(1)
+	require.NoError(env.state.Commit())
+
+	newRegisterTx := func(expiry uint64) *txs.Tx {
+		tx, err := env.txBuilder.NewRegisterNameTx(
+			name,
+			nodeID,
+			owner,
+			expiry,
+			secp256k1fx.NewKeychain(preFundedKeys[1]),
+			preFundedKeys[1].Address(), // change address
+			nil,
+		)
+		require.NoError(err)
+		return tx
+	}
+	execute := func(tx *txs.Tx) (state.Diff, error) {
+		onAcceptState, err := state.NewDiff(lastAcceptedID, env)
+		require.NoError(err)
+		return onAcceptState, tx.Unsigned.Visit(&StandardTxExecutor{
+			Backend: &env.backend,
+			State:   onAcceptState,
+			Tx:      tx,
+		})
+	}
+
+	// Names can't be registered before the upgrade activates.
+	env.config.NameRegistryTime = time.Time{}
+	_, err = execute(newRegisterTx(expiry))
+	require.ErrorIs(err, ErrNameRegistryNotActive)
+	env.config.NameRegistryTime = env.config.DurangoTime
+
+	// The name can't outlive the stake backing it.
+	_, err = execute(newRegisterTx(uint64(endTime.Unix()) + 1))
+	require.ErrorIs(err, ErrInvalidNameExpiry)
+
+	// Only the validation rewards owner can register a name for the validator.
+	_, err = env.txBuilder.NewRegisterNameTx(
+		name,
+		nodeID,
+		owner,
+		expiry,
+		secp256k1fx.NewKeychain(preFundedKeys[0]),
+		preFundedKeys[0].Address(), // change address
+		nil,
+	)
+	require.ErrorIs(err, builder.ErrCantSignValidatorExit)
+
+	registerTx := newRegisterTx(expiry)
+	onAcceptState, err := execute(registerTx)
+	require.NoError(err)
+	require.NoError(onAcceptState.Apply(env.state))
+	env.state.AddTx(registerTx, status.Committed)
+	env.state.SetHeight(2)
+	require.NoError(env.state.Commit())
+
+	record, err := env.state.GetName(name)
+	require.NoError(err)
+	require.Equal(&state.NameRecord{
+		TxID:   registerTx.ID(),
+		NodeID: nodeID,
+		Expiry: expiry,
+	}, record)
+
+	// The name is taken until it expires.
+	_, err = execute(newRegisterTx(expiry))
+	require.ErrorIs(err, ErrNameTaken)
+
+	// Only the owner of the name can update it.
+	_, err = env.txBuilder.NewUpdateNameTx(
+		name,
+		owner,
+		expiry,
+		secp256k1fx.NewKeychain(preFundedKeys[1]),
+		preFundedKeys[1].Address(), // change address
+		nil,
+	)
+	require.ErrorIs(err, builder.ErrCantSignName)
+
+	newExpiry := uint64(endTime.Unix())
+	updateTx, err := env.txBuilder.NewUpdateNameTx(
+		name,
+		&secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{preFundedKeys[3].Address()},
+		},
+		newExpiry,
+		secp256k1fx.NewKeychain(preFundedKeys[0], preFundedKeys[2]),
+		preFundedKeys[0].Address(), // change address
+		nil,
+	)
+	require.NoError(err)
+	onAcceptState, err = execute(updateTx)
+	require.NoError(err)
+
+	record, err = onAcceptState.GetName(name)
+	require.NoError(err)
+	require.Equal(&state.NameRecord{
+		TxID:   updateTx.ID(),
+		NodeID: nodeID,
+		Expiry: newExpiry,
+	}, record)
+
+	// Once the name expired, it can't be updated but it can be registered
+	// again.
+	env.state.SetTimestamp(time.Unix(int64(expiry), 0))
+	_, err = execute(updateTx)
+	require.ErrorIs(err, ErrNameExpired)
+
+	_, err = execute(newRegisterTx(newExpiry))
+	require.NoError(err)
+}
@@ -0,0 +1,128 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+var (
+	ErrParameterChangeNotActive       = errors.New("attempting to change a parameter prior to the activation of parameter changes")
+	ErrGovernanceDisabled             = errors.New("no governance owner is configured")
+	ErrParameterActivationNotInFuture = errors.New("parameter change must activate after the current chain time")
+
+	errUnauthorizedParameterChange = errors.New("unauthorized parameter change")
+)
+
+// verifyParameterChangeTx carries out the validation for a ParameterChangeTx.
+// The last credential in [sTx.Creds] is used as the governance authorization.
+func verifyParameterChangeTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.ParameterChangeTx,
+) error {
+	currentTimestamp := chainState.GetTimestamp()
+	if !backend.Config.IsParameterChangeActivated(currentTimestamp) {
+		return ErrParameterChangeNotActive
+	}
+
+	// Verify the tx is well-formed
+	if err := sTx.SyntacticVerify(backend.Ctx); err != nil {
+		return err
+	}
+
+	if err := avax.VerifyMemoFieldLength(tx.Memo, true /*=isDurangoActive*/); err != nil {
+		return err
+	}
+
+	if backend.Config.GovernanceOwner == nil {
+		return ErrGovernanceDisabled
+	}
+
+	if tx.ActivationTime <= uint64(currentTimestamp.Unix()) {
+		return fmt.Errorf(
+			"%w: activation time %d, chain time %d",
+			ErrParameterActivationNotInFuture,
+			tx.ActivationTime,
+			currentTimestamp.Unix(),
+		)
+	}
+
+	if !backend.Bootstrapped.Get() {
+		// Not bootstrapped yet -- don't need to do full verification.
+		return nil
+	}
+
+	if len(sTx.Creds) == 0 {
+		// Ensure there is at least one credential for the governance
+		// authorization
+		return errWrongNumberOfCredentials
+	}
+
+	baseTxCredsLen := len(sTx.Creds) - 1
+	governanceCred := sTx.Creds[baseTxCredsLen]
+	if err := backend.Fx.VerifyPermission(sTx.Unsigned, tx.GovernanceAuth, governanceCred, backend.Config.GovernanceOwner); err != nil {
+		return fmt.Errorf("%w: %w", errUnauthorizedParameterChange, err)
+	}
+
+	txFee, err := state.GetParameter(chainState, txs.TxFeeParameter, backend.Config.TxFee)
+	if err != nil {
+		return err
+	}
+
+	// Verify the flowcheck
+	if err := backend.FlowChecker.VerifySpend(
+		tx,
+		chainState,
+		tx.Ins,
+		tx.Outs,
+		sTx.Creds[:baseTxCredsLen],
+		map[ids.ID]uint64{
+			backend.Ctx.AVAXAssetID: txFee,
+		},
+	); err != nil {
+		return fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
+	}
+
+	return nil
+}
+
+// scheduleParameterChange adds the change made by [tx] to the schedule of its
+// parameter. The change replaces the changes that would have activated at or
+// after it. Of the changes that already activated, only the latest is kept, as
+// the earlier ones can no longer take effect.
+func scheduleParameterChange(chainState state.Chain, tx *txs.ParameterChangeTx) error {
+	changes, err := chainState.GetParameterChanges(tx.Parameter)
+	if err != nil {
+		return err
+	}
+
+	var (
+		currentTime = uint64(chainState.GetTimestamp().Unix())
+		newChanges  = make([]state.ParameterChange, 0, len(changes)+1)
+	)
+	for i, change := range changes {
+		if change.ActivationTime >= tx.ActivationTime {
+			break
+		}
+		isSuperseded := i+1 < len(changes) && changes[i+1].ActivationTime <= currentTime
+		if !isSuperseded {
+			newChanges = append(newChanges, change)
+		}
+	}
+	newChanges = append(newChanges, state.ParameterChange{
+		Value:          tx.Value,
+		ActivationTime: tx.ActivationTime,
+	})
+
+	chainState.SetParameterChanges(tx.Parameter, newChanges)
+	return nil
+}
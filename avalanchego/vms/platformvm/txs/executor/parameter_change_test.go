@@ -0,0 +1,183 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/builder"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestParameterChangeTx(t *testing.T) {
+	require := require.New(t)
+	env := newEnvironment(t, durango)
+	env.ctx.Lock.Lock()
+	defer env.ctx.Lock.Unlock()
+
+	var (
+		governanceKey  = preFundedKeys[3]
+		chainTime      = env.state.GetTimestamp()
+		activationTime = chainTime.Add(time.Hour)
+		newTxFee       = 2 * defaultTxFee
+	)
+	env.config.GovernanceOwner = &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{governanceKey.Address()},
+	}
+
+	// Keys that don't control the governance owner can't build the tx.
+	_, err := env.txBuilder.NewParameterChangeTx(
+		txs.TxFeeParameter,
+		newTxFee,
+		uint64(activationTime.Unix()),
+		secp256k1fx.NewKeychain(preFundedKeys[0]),
+		preFundedKeys[0].Address(), // change address
+		nil,
+	)
+	require.ErrorIs(err, builder.ErrCantSignGovernance)
+
+	newParameterChangeTx := func(activationTime time.Time) *txs.Tx {
+		tx, err := env.txBuilder.NewParameterChangeTx(
+			txs.TxFeeParameter,
+			newTxFee,
+			uint64(activationTime.Unix()),
+			secp256k1fx.NewKeychain(preFundedKeys[0], governanceKey),
+			preFundedKeys[0].Address(), // change address
+			nil,
+		)
+		require.NoError(err)
+		return tx
+	}
+	execute := func(tx *txs.Tx) (state.Diff, error) {
+		onAcceptState, err := state.NewDiff(lastAcceptedID, env)
+		require.NoError(err)
+
+		return onAcceptState, tx.Unsigned.Visit(&StandardTxExecutor{
+			Backend: &env.backend,
+			State:   onAcceptState,
+			Tx:      tx,
+		})
+	}
+
+	_, err = execute(newParameterChangeTx(chainTime))
+	require.ErrorIs(err, ErrParameterActivationNotInFuture)
+
+	tx := newParameterChangeTx(activationTime)
+
+	// A tx signed by a previous governance owner is rejected.
+	env.config.GovernanceOwner = &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{preFundedKeys[4].Address()},
+	}
+	_, err = execute(tx)
+	require.ErrorIs(err, errUnauthorizedParameterChange)
+
+	env.config.GovernanceOwner = nil
+	_, err = execute(tx)
+	require.ErrorIs(err, ErrGovernanceDisabled)
+
+	// Parameters can't be changed before the upgrade activates.
+	env.config.ParameterChangeTime = time.Time{}
+	_, err = execute(tx)
+	require.ErrorIs(err, ErrParameterChangeNotActive)
+	env.config.ParameterChangeTime = env.config.DurangoTime
+
+	env.config.GovernanceOwner = &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{governanceKey.Address()},
+	}
+	onAcceptState, err := execute(tx)
+	require.NoError(err)
+
+	// The change only takes effect at its activation time.
+	txFee, err := state.GetParameter(onAcceptState, txs.TxFeeParameter, env.config.TxFee)
+	require.NoError(err)
+	require.Equal(env.config.TxFee, txFee)
+
+	onAcceptState.SetTimestamp(activationTime)
+	txFee, err = state.GetParameter(onAcceptState, txs.TxFeeParameter, env.config.TxFee)
+	require.NoError(err)
+	require.Equal(newTxFee, txFee)
+}
+
+func TestScheduleParameterChange(t *testing.T) {
+	tests := []struct {
+		name            string
+		changes         []state.ParameterChange
+		change          state.ParameterChange
+		expectedChanges []state.ParameterChange
+	}{
+		{
+			name:   "first change",
+			change: state.ParameterChange{Value: 1, ActivationTime: 20},
+			expectedChanges: []state.ParameterChange{
+				{Value: 1, ActivationTime: 20},
+			},
+		},
+		{
+			name: "appended after pending change",
+			changes: []state.ParameterChange{
+				{Value: 1, ActivationTime: 20},
+			},
+			change: state.ParameterChange{Value: 2, ActivationTime: 30},
+			expectedChanges: []state.ParameterChange{
+				{Value: 1, ActivationTime: 20},
+				{Value: 2, ActivationTime: 30},
+			},
+		},
+		{
+			name: "replaces later pending changes",
+			changes: []state.ParameterChange{
+				{Value: 1, ActivationTime: 20},
+				{Value: 2, ActivationTime: 30},
+			},
+			change: state.ParameterChange{Value: 3, ActivationTime: 20},
+			expectedChanges: []state.ParameterChange{
+				{Value: 3, ActivationTime: 20},
+			},
+		},
+		{
+			name: "drops superseded activated changes",
+			changes: []state.ParameterChange{
+				{Value: 1, ActivationTime: 5},
+				{Value: 2, ActivationTime: 8},
+				{Value: 3, ActivationTime: 20},
+			},
+			change: state.ParameterChange{Value: 4, ActivationTime: 30},
+			expectedChanges: []state.ParameterChange{
+				{Value: 2, ActivationTime: 8},
+				{Value: 3, ActivationTime: 20},
+				{Value: 4, ActivationTime: 30},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			env := newEnvironment(t, durango)
+
+			chainState, err := state.NewDiff(lastAcceptedID, env)
+			require.NoError(err)
+			chainState.SetTimestamp(time.Unix(10, 0))
+			chainState.SetParameterChanges(txs.TxFeeParameter, tt.changes)
+
+			require.NoError(scheduleParameterChange(chainState, &txs.ParameterChangeTx{
+				Parameter:      txs.TxFeeParameter,
+				Value:          tt.change.Value,
+				ActivationTime: tt.change.ActivationTime,
+			}))
+
+			changes, err := chainState.GetParameterChanges(txs.TxFeeParameter)
+			require.NoError(err)
+			require.Equal(tt.expectedChanges, changes)
+		})
+	}
+}
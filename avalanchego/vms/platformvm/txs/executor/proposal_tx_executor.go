@@ -8,11 +8,11 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
-	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
@@ -60,6 +60,14 @@ type ProposalTxExecutor struct {
 	// [OnAbortState] is modified by this struct's methods to
 	// reflect changes made to the state if the proposal is aborted.
 	OnAbortState state.Diff
+
+	// outputs of visitor execution
+	//
+	// [OnCommitAtomicRequests] and [OnAbortAtomicRequests] are the shared
+	// memory requests, such as the exports of rewards, to apply if the
+	// proposal is committed or aborted.
+	OnCommitAtomicRequests map[ids.ID]*atomic.Requests
+	OnAbortAtomicRequests  map[ids.ID]*atomic.Requests
 }
 
 func (*ProposalTxExecutor) CreateChainTx(*txs.CreateChainTx) error {
@@ -102,6 +110,54 @@ func (*ProposalTxExecutor) BaseTx(*txs.BaseTx) error {
 	return ErrWrongTxType
 }
 
+func (*ProposalTxExecutor) AddSubnetAllowListEntriesTx(*txs.AddSubnetAllowListEntriesTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) RemoveSubnetAllowListEntriesTx(*txs.RemoveSubnetAllowListEntriesTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) AddCappedPermissionlessValidatorTx(*txs.AddCappedPermissionlessValidatorTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) ExitValidatorTx(*txs.ExitValidatorTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) SetSubnetValidatorWeightTx(*txs.SetSubnetValidatorWeightTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) ParameterChangeTx(*txs.ParameterChangeTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) RekeyValidatorTx(*txs.RekeyValidatorTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) RegisterNameTx(*txs.RegisterNameTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) UpdateNameTx(*txs.UpdateNameTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) AddPermissionlessValidatorWithMetadataTx(*txs.AddPermissionlessValidatorWithMetadataTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) ClaimRewardTx(*txs.ClaimRewardTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) BindRewardAddressTx(*txs.BindRewardAddressTx) error {
+	return ErrWrongTxType
+}
+
 func (e *ProposalTxExecutor) AddValidatorTx(tx *txs.AddValidatorTx) error {
 	// AddValidatorTx is a proposal transaction until the Banff fork
 	// activation. Following the activation, AddValidatorTxs must be issued into
@@ -404,29 +460,32 @@ func (e *ProposalTxExecutor) rewardValidatorTx(uValidatorTx txs.ValidatorTx, val
 
 	utxosOffset := 0
 
+	// Once claimable rewards are activated, rewards accrue to the claimable
+	// balance of their owners rather than being paid out as UTXOs.
+	claimable := e.Config.IsClaimableRewardsActivated(e.OnCommitState.GetTimestamp())
+
 	// Provide the reward here
 	reward := validator.PotentialReward
-	if reward > 0 {
+	if reward > 0 && claimable {
 		validationRewardsOwner := uValidatorTx.ValidationRewardsOwner()
-		outIntf, err := e.Fx.CreateOutput(reward, validationRewardsOwner)
-		if err != nil {
-			return fmt.Errorf("failed to create output: %w", err)
-		}
-		out, ok := outIntf.(verify.State)
-		if !ok {
-			return ErrInvalidState
+		if err := accrueReward(e.OnCommitState, validationRewardsOwner, stakeAsset.ID, reward); err != nil {
+			return err
 		}
-
-		utxo := &avax.UTXO{
-			UTXOID: avax.UTXOID{
-				TxID:        txID,
-				OutputIndex: uint32(len(outputs) + len(stake)),
-			},
-			Asset: stakeAsset,
-			Out:   out,
+	} else if reward > 0 {
+		validationRewardsOwner := uValidatorTx.ValidationRewardsOwner()
+		err := payReward(
+			e.Backend,
+			e.OnCommitState,
+			&e.OnCommitAtomicRequests,
+			txID,
+			uint32(len(outputs)+len(stake)),
+			stakeAsset,
+			reward,
+			validationRewardsOwner,
+		)
+		if err != nil {
+			return err
 		}
-		e.OnCommitState.AddUTXO(utxo)
-		e.OnCommitState.AddRewardUTXO(txID, utxo)
 
 		utxosOffset++
 	}
@@ -445,39 +504,41 @@ func (e *ProposalTxExecutor) rewardValidatorTx(uValidatorTx txs.ValidatorTx, val
 	}
 
 	delegationRewardsOwner := uValidatorTx.DelegationRewardsOwner()
-	outIntf, err := e.Fx.CreateOutput(delegateeReward, delegationRewardsOwner)
-	if err != nil {
-		return fmt.Errorf("failed to create output: %w", err)
-	}
-	out, ok := outIntf.(verify.State)
-	if !ok {
-		return ErrInvalidState
+	if claimable {
+		// The delegatee rewards are paid whether or not the validator is
+		// rewarded.
+		if err := accrueReward(e.OnCommitState, delegationRewardsOwner, stakeAsset.ID, delegateeReward); err != nil {
+			return err
+		}
+		return accrueReward(e.OnAbortState, delegationRewardsOwner, stakeAsset.ID, delegateeReward)
 	}
 
-	onCommitUtxo := &avax.UTXO{
-		UTXOID: avax.UTXOID{
-			TxID:        txID,
-			OutputIndex: uint32(len(outputs) + len(stake) + utxosOffset),
-		},
-		Asset: stakeAsset,
-		Out:   out,
+	err = payReward(
+		e.Backend,
+		e.OnCommitState,
+		&e.OnCommitAtomicRequests,
+		txID,
+		uint32(len(outputs)+len(stake)+utxosOffset),
+		stakeAsset,
+		delegateeReward,
+		delegationRewardsOwner,
+	)
+	if err != nil {
+		return err
 	}
-	e.OnCommitState.AddUTXO(onCommitUtxo)
-	e.OnCommitState.AddRewardUTXO(txID, onCommitUtxo)
 
 	// Note: There is no [offset] if the RewardValidatorTx is
 	// aborted, because the validator reward is not awarded.
-	onAbortUtxo := &avax.UTXO{
-		UTXOID: avax.UTXOID{
-			TxID:        txID,
-			OutputIndex: uint32(len(outputs) + len(stake)),
-		},
-		Asset: stakeAsset,
-		Out:   out,
-	}
-	e.OnAbortState.AddUTXO(onAbortUtxo)
-	e.OnAbortState.AddRewardUTXO(txID, onAbortUtxo)
-	return nil
+	return payReward(
+		e.Backend,
+		e.OnAbortState,
+		&e.OnAbortAtomicRequests,
+		txID,
+		uint32(len(outputs)+len(stake)),
+		stakeAsset,
+		delegateeReward,
+		delegationRewardsOwner,
+	)
 }
 
 func (e *ProposalTxExecutor) rewardDelegatorTx(uDelegatorTx txs.DelegatorTx, delegator *state.Staker) error {
@@ -511,6 +572,13 @@ func (e *ProposalTxExecutor) rewardDelegatorTx(uDelegatorTx txs.DelegatorTx, del
 		return fmt.Errorf("failed to get whether %s is a validator: %w", delegator.NodeID, err)
 	}
 
+	// The delegator no longer counts against the stake cap of the validator.
+	for _, chainState := range []state.Diff{e.OnCommitState, e.OnAbortState} {
+		if err := removeDelegatedStake(chainState, validator.TxID, uDelegatorTx); err != nil {
+			return err
+		}
+	}
+
 	vdrTxIntf, _, err := e.OnCommitState.GetTx(validator.TxID)
 	if err != nil {
 		return fmt.Errorf("failed to get whether %s is a validator: %w", delegator.NodeID, err)
@@ -529,31 +597,31 @@ func (e *ProposalTxExecutor) rewardDelegatorTx(uDelegatorTx txs.DelegatorTx, del
 	delegateeReward, delegatorReward := reward.Split(delegator.PotentialReward, vdrTx.Shares())
 
 	utxosOffset := 0
+	claimable := e.Config.IsClaimableRewardsActivated(e.OnCommitState.GetTimestamp())
 
 	// Reward the delegator here
 	reward := delegatorReward
-	if reward > 0 {
+	if reward > 0 && claimable {
 		rewardsOwner := uDelegatorTx.RewardsOwner()
-		outIntf, err := e.Fx.CreateOutput(reward, rewardsOwner)
-		if err != nil {
-			return fmt.Errorf("failed to create output: %w", err)
-		}
-		out, ok := outIntf.(verify.State)
-		if !ok {
-			return ErrInvalidState
+		if err := accrueReward(e.OnCommitState, rewardsOwner, stakeAsset.ID, reward); err != nil {
+			return err
 		}
-		utxo := &avax.UTXO{
-			UTXOID: avax.UTXOID{
-				TxID:        txID,
-				OutputIndex: uint32(len(outputs) + len(stake)),
-			},
-			Asset: stakeAsset,
-			Out:   out,
+	} else if reward > 0 {
+		rewardsOwner := uDelegatorTx.RewardsOwner()
+		err := payReward(
+			e.Backend,
+			e.OnCommitState,
+			&e.OnCommitAtomicRequests,
+			txID,
+			uint32(len(outputs)+len(stake)),
+			stakeAsset,
+			reward,
+			rewardsOwner,
+		)
+		if err != nil {
+			return err
 		}
 
-		e.OnCommitState.AddUTXO(utxo)
-		e.OnCommitState.AddRewardUTXO(txID, utxo)
-
 		utxosOffset++
 	}
 
@@ -586,29 +654,29 @@ func (e *ProposalTxExecutor) rewardDelegatorTx(uDelegatorTx txs.DelegatorTx, del
 		if err != nil {
 			return fmt.Errorf("failed to update delegatee reward: %w", err)
 		}
+	} else if claimable {
+		// For any validators who started prior to [CortinaTime], the
+		// [delegateeReward] is claimable immediately.
+		if err := accrueReward(e.OnCommitState, vdrTx.DelegationRewardsOwner(), stakeAsset.ID, delegateeReward); err != nil {
+			return err
+		}
 	} else {
 		// For any validators who started prior to [CortinaTime], we issue the
 		// [delegateeReward] immediately.
 		delegationRewardsOwner := vdrTx.DelegationRewardsOwner()
-		outIntf, err := e.Fx.CreateOutput(delegateeReward, delegationRewardsOwner)
+		err := payReward(
+			e.Backend,
+			e.OnCommitState,
+			&e.OnCommitAtomicRequests,
+			txID,
+			uint32(len(outputs)+len(stake)+utxosOffset),
+			stakeAsset,
+			delegateeReward,
+			delegationRewardsOwner,
+		)
 		if err != nil {
-			return fmt.Errorf("failed to create output: %w", err)
-		}
-		out, ok := outIntf.(verify.State)
-		if !ok {
-			return ErrInvalidState
-		}
-		utxo := &avax.UTXO{
-			UTXOID: avax.UTXOID{
-				TxID:        txID,
-				OutputIndex: uint32(len(outputs) + len(stake) + utxosOffset),
-			},
-			Asset: stakeAsset,
-			Out:   out,
+			return err
 		}
-
-		e.OnCommitState.AddUTXO(utxo)
-		e.OnCommitState.AddRewardUTXO(txID, utxo)
 	}
 	return nil
 }
@@ -41,7 +41,7 @@ func TestProposalTxExecuteAddDelegator(t *testing.T) {
 			newValidatorID,                  // node ID
 			rewardAddress,                   // Reward Address
 			reward.PercentDenominator,       // Shares
-			[]*secp256k1.PrivateKey{preFundedKeys[0]},
+			secp256k1fx.NewKeychain(preFundedKeys[0]),
 			ids.ShortEmpty,
 			nil,
 		)
@@ -72,7 +72,7 @@ func TestProposalTxExecuteAddDelegator(t *testing.T) {
 			newValidatorID,                  // node ID
 			rewardAddress,                   // Reward Address
 			reward.PercentDenominator,       // Shared
-			[]*secp256k1.PrivateKey{preFundedKeys[0]},
+			secp256k1fx.NewKeychain(preFundedKeys[0]),
 			ids.ShortEmpty,
 			nil,
 		)
@@ -256,7 +256,7 @@ func TestProposalTxExecuteAddDelegator(t *testing.T) {
 				tt.endTime,
 				tt.nodeID,
 				tt.rewardAddress,
-				tt.feeKeys,
+				secp256k1fx.NewKeychain(tt.feeKeys...),
 				ids.ShortEmpty,
 				nil,
 			)
@@ -301,7 +301,7 @@ func TestProposalTxExecuteAddSubnetValidator(t *testing.T) {
 			uint64(defaultValidateEndTime.Unix())+1,
 			nodeID,
 			testSubnet1.ID(),
-			[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+			secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -334,7 +334,7 @@ func TestProposalTxExecuteAddSubnetValidator(t *testing.T) {
 			uint64(defaultValidateEndTime.Unix()),
 			nodeID,
 			testSubnet1.ID(),
-			[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+			secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -368,7 +368,7 @@ func TestProposalTxExecuteAddSubnetValidator(t *testing.T) {
 		pendingDSValidatorID,         // node ID
 		ids.GenerateTestShortID(),    // reward address
 		reward.PercentDenominator,    // shares
-		[]*secp256k1.PrivateKey{preFundedKeys[0]},
+		secp256k1fx.NewKeychain(preFundedKeys[0]),
 		ids.ShortEmpty,
 		nil,
 	)
@@ -382,7 +382,7 @@ func TestProposalTxExecuteAddSubnetValidator(t *testing.T) {
 			uint64(dsEndTime.Unix()),
 			pendingDSValidatorID,
 			testSubnet1.ID(),
-			[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+			secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -430,7 +430,7 @@ func TestProposalTxExecuteAddSubnetValidator(t *testing.T) {
 			uint64(dsEndTime.Unix()),
 			pendingDSValidatorID,
 			testSubnet1.ID(),
-			[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+			secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -461,7 +461,7 @@ func TestProposalTxExecuteAddSubnetValidator(t *testing.T) {
 			uint64(dsEndTime.Unix())+1, // stop validating subnet after stopping validating primary network
 			pendingDSValidatorID,
 			testSubnet1.ID(),
-			[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+			secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -492,7 +492,7 @@ func TestProposalTxExecuteAddSubnetValidator(t *testing.T) {
 			uint64(dsEndTime.Unix()),   // same end time as for primary network
 			pendingDSValidatorID,
 			testSubnet1.ID(),
-			[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+			secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -525,7 +525,7 @@ func TestProposalTxExecuteAddSubnetValidator(t *testing.T) {
 			uint64(newTimestamp.Add(defaultMinStakingDuration).Unix()), // end time
 			nodeID,           // node ID
 			testSubnet1.ID(), // subnet ID
-			[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+			secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -558,7 +558,7 @@ func TestProposalTxExecuteAddSubnetValidator(t *testing.T) {
 		uint64(defaultValidateEndTime.Unix()),   // end time
 		nodeID,                                  // node ID
 		testSubnet1.ID(),                        // subnet ID
-		[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
 		ids.ShortEmpty,
 		nil,
 	)
@@ -586,7 +586,7 @@ func TestProposalTxExecuteAddSubnetValidator(t *testing.T) {
 			uint64(defaultValidateEndTime.Unix()),     // end time
 			nodeID,                                    // node ID
 			testSubnet1.ID(),                          // subnet ID
-			[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+			secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -620,7 +620,7 @@ func TestProposalTxExecuteAddSubnetValidator(t *testing.T) {
 			uint64(defaultValidateStartTime.Add(defaultMinStakingDuration).Unix())+1, // end time
 			nodeID,           // node ID
 			testSubnet1.ID(), // subnet ID
-			[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[2]},
+			secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[2]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -657,7 +657,7 @@ func TestProposalTxExecuteAddSubnetValidator(t *testing.T) {
 			uint64(defaultValidateStartTime.Add(defaultMinStakingDuration).Unix())+1, // end time
 			nodeID,           // node ID
 			testSubnet1.ID(), // subnet ID
-			[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], preFundedKeys[1]},
+			secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], preFundedKeys[1]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -693,7 +693,7 @@ func TestProposalTxExecuteAddSubnetValidator(t *testing.T) {
 			uint64(defaultValidateStartTime.Add(defaultMinStakingDuration).Unix())+1, // end time
 			nodeID,           // node ID
 			testSubnet1.ID(), // subnet ID
-			[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+			secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -748,7 +748,7 @@ func TestProposalTxExecuteAddValidator(t *testing.T) {
 			nodeID,
 			ids.ShortEmpty,
 			reward.PercentDenominator,
-			[]*secp256k1.PrivateKey{preFundedKeys[0]},
+			secp256k1fx.NewKeychain(preFundedKeys[0]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -779,7 +779,7 @@ func TestProposalTxExecuteAddValidator(t *testing.T) {
 			nodeID,
 			ids.ShortEmpty,
 			reward.PercentDenominator,
-			[]*secp256k1.PrivateKey{preFundedKeys[0]},
+			secp256k1fx.NewKeychain(preFundedKeys[0]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -812,7 +812,7 @@ func TestProposalTxExecuteAddValidator(t *testing.T) {
 			nodeID,
 			ids.ShortEmpty,
 			reward.PercentDenominator,
-			[]*secp256k1.PrivateKey{preFundedKeys[0]},
+			secp256k1fx.NewKeychain(preFundedKeys[0]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -844,7 +844,7 @@ func TestProposalTxExecuteAddValidator(t *testing.T) {
 			nodeID,
 			ids.ShortEmpty,
 			reward.PercentDenominator, // shares
-			[]*secp256k1.PrivateKey{preFundedKeys[0]},
+			secp256k1fx.NewKeychain(preFundedKeys[0]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -890,7 +890,7 @@ func TestProposalTxExecuteAddValidator(t *testing.T) {
 			ids.GenerateTestNodeID(),
 			ids.ShortEmpty,
 			reward.PercentDenominator,
-			[]*secp256k1.PrivateKey{preFundedKeys[0]},
+			secp256k1fx.NewKeychain(preFundedKeys[0]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -0,0 +1,195 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/chains/atomic"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	ErrRewardExportNotActive = errors.New("attempting to bind a reward address prior to the activation of reward exports")
+
+	errUnauthorizedRewardAddressBinding = errors.New("unauthorized reward address binding")
+)
+
+// verifyBindRewardAddressTx carries out the validation for a
+// BindRewardAddressTx. The issuer must control [tx.Owner]. It returns the ID
+// of [tx.Owner].
+func verifyBindRewardAddressTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.BindRewardAddressTx,
+) (ids.ID, error) {
+	if !backend.Config.IsRewardExportActivated(chainState.GetTimestamp()) {
+		return ids.Empty, ErrRewardExportNotActive
+	}
+
+	// Verify the tx is well-formed
+	if err := sTx.SyntacticVerify(backend.Ctx); err != nil {
+		return ids.Empty, err
+	}
+
+	if err := avax.VerifyMemoFieldLength(tx.Memo, true /*=isDurangoActive*/); err != nil {
+		return ids.Empty, err
+	}
+
+	ownerID, err := txs.RewardsOwnerID(tx.Owner)
+	if err != nil {
+		return ids.Empty, fmt.Errorf("failed to compute rewards owner ID: %w", err)
+	}
+
+	if !backend.Bootstrapped.Get() {
+		// Not bootstrapped yet -- don't need to do full verification.
+		return ownerID, nil
+	}
+
+	if len(sTx.Creds) == 0 {
+		// Ensure there is at least one credential for the owner authorization
+		return ids.Empty, errWrongNumberOfCredentials
+	}
+
+	baseTxCredsLen := len(sTx.Creds) - 1
+	ownerCred := sTx.Creds[baseTxCredsLen]
+	if err := backend.Fx.VerifyPermission(sTx.Unsigned, tx.OwnerAuth, ownerCred, tx.Owner); err != nil {
+		return ids.Empty, fmt.Errorf("%w: %w", errUnauthorizedRewardAddressBinding, err)
+	}
+
+	fee, err := state.GetParameter(chainState, txs.TxFeeParameter, backend.Config.TxFee)
+	if err != nil {
+		return ids.Empty, err
+	}
+
+	// Verify the flowcheck
+	if err := backend.FlowChecker.VerifySpend(
+		sTx.Unsigned,
+		chainState,
+		tx.Ins,
+		tx.Outs,
+		sTx.Creds[:baseTxCredsLen],
+		map[ids.ID]uint64{
+			backend.Ctx.AVAXAssetID: fee,
+		},
+	); err != nil {
+		return ids.Empty, fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
+	}
+	return ownerID, nil
+}
+
+// payReward pays out [amount] of [asset] to the rewards owner [owner] as the
+// output at [outputIndex] of the staker tx [txID].
+//
+// Once reward exports are activated, a reward of an owner bound to a C-chain
+// address is exported to that address by adding it to [requests]. Otherwise,
+// it is added to the UTXO set of [chainState]. Either way, the paid out UTXO
+// is recorded as a reward UTXO of [txID].
+func payReward(
+	backend *Backend,
+	chainState state.Chain,
+	requests *map[ids.ID]*atomic.Requests,
+	txID ids.ID,
+	outputIndex uint32,
+	asset avax.Asset,
+	amount uint64,
+	owner fx.Owner,
+) error {
+	addr, err := rewardAddress(backend, chainState, owner)
+	if err != nil {
+		return err
+	}
+
+	var out verify.State
+	if addr == ids.ShortEmpty {
+		outIntf, err := backend.Fx.CreateOutput(amount, owner)
+		if err != nil {
+			return fmt.Errorf("failed to create output: %w", err)
+		}
+		var ok bool
+		out, ok = outIntf.(verify.State)
+		if !ok {
+			return ErrInvalidState
+		}
+	} else {
+		out = &secp256k1fx.TransferOutput{
+			Amt: amount,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr},
+			},
+		}
+	}
+
+	utxo := &avax.UTXO{
+		UTXOID: avax.UTXOID{
+			TxID:        txID,
+			OutputIndex: outputIndex,
+		},
+		Asset: asset,
+		Out:   out,
+	}
+	chainState.AddRewardUTXO(txID, utxo)
+	if addr == ids.ShortEmpty {
+		chainState.AddUTXO(utxo)
+		return nil
+	}
+	return exportReward(backend, requests, utxo, addr)
+}
+
+// rewardAddress returns the C-chain address that the rewards of [owner] are
+// exported to, or [ids.ShortEmpty] if they aren't exported.
+func rewardAddress(backend *Backend, chainState state.Chain, owner fx.Owner) (ids.ShortID, error) {
+	if !backend.Config.IsRewardExportActivated(chainState.GetTimestamp()) {
+		return ids.ShortEmpty, nil
+	}
+	ownerID, err := txs.RewardsOwnerID(owner)
+	if err != nil {
+		return ids.ShortEmpty, fmt.Errorf("failed to compute rewards owner ID: %w", err)
+	}
+	addr, err := chainState.GetRewardAddress(ownerID)
+	if err != nil {
+		return ids.ShortEmpty, fmt.Errorf("failed to fetch reward address of %s: %w", ownerID, err)
+	}
+	return addr, nil
+}
+
+// exportReward adds the request to put [utxo], owned by [addr], into the shared
+// memory of the C-chain to [requests].
+func exportReward(
+	backend *Backend,
+	requests *map[ids.ID]*atomic.Requests,
+	utxo *avax.UTXO,
+	addr ids.ShortID,
+) error {
+	utxoBytes, err := txs.Codec.Marshal(txs.CodecVersion, utxo)
+	if err != nil {
+		return fmt.Errorf("failed to marshal UTXO: %w", err)
+	}
+	utxoID := utxo.InputID()
+	elem := &atomic.Element{
+		Key:    utxoID[:],
+		Value:  utxoBytes,
+		Traits: [][]byte{addr.Bytes()},
+	}
+
+	if *requests == nil {
+		*requests = make(map[ids.ID]*atomic.Requests)
+	}
+	chainRequests, exists := (*requests)[backend.Ctx.CChainID]
+	if !exists {
+		chainRequests = &atomic.Requests{}
+		(*requests)[backend.Ctx.CChainID] = chainRequests
+	}
+	chainRequests.PutRequests = append(chainRequests.PutRequests, elem)
+	return nil
+}
@@ -0,0 +1,154 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestRewardExport(t *testing.T) {
+	require := require.New(t)
+	env := newEnvironment(t, durango)
+	env.ctx.Lock.Lock()
+	defer env.ctx.Lock.Unlock()
+
+	var (
+		potentialReward = uint64(1_000)
+		startTime       = env.state.GetTimestamp()
+		endTime         = startTime.Add(defaultMinStakingDuration)
+		rewardsOwner    = &secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{preFundedKeys[1].Address()},
+		}
+		cChainAddr = preFundedKeys[2].Address()
+	)
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	validatorTx, err := env.txBuilder.NewAddPermissionlessValidatorTx(
+		env.config.MinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		ids.GenerateTestNodeID(),
+		signer.NewProofOfPossession(sk),
+		preFundedKeys[1].Address(), // reward address
+		reward.PercentDenominator,
+		secp256k1fx.NewKeychain(preFundedKeys[0]),
+		preFundedKeys[0].Address(), // change address
+		nil,
+	)
+	require.NoError(err)
+	validator, err := state.NewCurrentStaker(validatorTx.ID(), validatorTx.Unsigned.(txs.Staker), startTime, potentialReward)
+	require.NoError(err)
+	env.state.PutCurrentValidator(validator)
+	env.state.AddTx(validatorTx, status.Committed)
+	env.state.SetHeight(1)
+	require.NoError(env.state.Commit())
+
+	bindTx, err := env.txBuilder.NewBindRewardAddressTx(
+		rewardsOwner,
+		cChainAddr,
+		secp256k1fx.NewKeychain(preFundedKeys[0], preFundedKeys[1]),
+		preFundedKeys[0].Address(), // change address
+		nil,
+	)
+	require.NoError(err)
+	bind := func() (state.Diff, error) {
+		onAcceptState, err := state.NewDiff(lastAcceptedID, env)
+		require.NoError(err)
+		return onAcceptState, bindTx.Unsigned.Visit(&StandardTxExecutor{
+			Backend: &env.backend,
+			State:   onAcceptState,
+			Tx:      bindTx,
+		})
+	}
+
+	// Reward addresses can't be bound before reward exports are activated.
+	_, err = bind()
+	require.ErrorIs(err, ErrRewardExportNotActive)
+
+	env.config.RewardExportTime = startTime
+	onAcceptState, err := bind()
+	require.NoError(err)
+	require.NoError(onAcceptState.Apply(env.state))
+	env.state.SetTimestamp(endTime)
+	env.state.SetHeight(2)
+	require.NoError(env.state.Commit())
+
+	ownerID, err := txs.RewardsOwnerID(rewardsOwner)
+	require.NoError(err)
+	boundAddr, err := env.state.GetRewardAddress(ownerID)
+	require.NoError(err)
+	require.Equal(cChainAddr, boundAddr)
+
+	rewardTx, err := newRewardValidatorTx(t, validatorTx.ID())
+	require.NoError(err)
+	onCommitState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+	onAbortState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+	txExecutor := ProposalTxExecutor{
+		OnCommitState: onCommitState,
+		OnAbortState:  onAbortState,
+		Backend:       &env.backend,
+		Tx:            rewardTx,
+	}
+	require.NoError(rewardTx.Unsigned.Visit(&txExecutor))
+	require.Empty(txExecutor.OnAbortAtomicRequests)
+
+	// The reward is exported to the bound address rather than added to the
+	// UTXO set.
+	uValidatorTx := validatorTx.Unsigned.(*txs.AddPermissionlessValidatorTx)
+	rewardUTXO := &avax.UTXO{
+		UTXOID: avax.UTXOID{
+			TxID:        validatorTx.ID(),
+			OutputIndex: uint32(len(uValidatorTx.Outs) + len(uValidatorTx.StakeOuts)),
+		},
+		Asset: avax.Asset{ID: env.ctx.AVAXAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: potentialReward,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{cChainAddr},
+			},
+		},
+	}
+	_, err = onCommitState.GetUTXO(rewardUTXO.InputID())
+	require.ErrorIs(err, database.ErrNotFound)
+
+	require.Len(txExecutor.OnCommitAtomicRequests, 1)
+	requests := txExecutor.OnCommitAtomicRequests[env.ctx.CChainID]
+	require.NotNil(requests)
+	require.Empty(requests.RemoveRequests)
+	require.Len(requests.PutRequests, 1)
+
+	rewardUTXOBytes, err := txs.Codec.Marshal(txs.CodecVersion, rewardUTXO)
+	require.NoError(err)
+	rewardUTXOID := rewardUTXO.InputID()
+	require.Equal(rewardUTXOID[:], requests.PutRequests[0].Key)
+	require.Equal(rewardUTXOBytes, requests.PutRequests[0].Value)
+	require.Equal([][]byte{cChainAddr.Bytes()}, requests.PutRequests[0].Traits)
+
+	// The exported reward is still recorded as a reward of the staker.
+	require.NoError(onCommitState.Apply(env.state))
+	env.state.SetHeight(3)
+	require.NoError(env.state.Commit())
+	rewardUTXOs, err := env.state.GetRewardUTXOs(validatorTx.ID())
+	require.NoError(err)
+	require.Len(rewardUTXOs, 1)
+	require.Equal(rewardUTXO.InputID(), rewardUTXOs[0].InputID())
+}
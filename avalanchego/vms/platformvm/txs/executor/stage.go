@@ -0,0 +1,148 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+var (
+	ErrDeniedAddress = errors.New("tx involves a denied address")
+
+	errUnknownStage   = errors.New("unknown stage")
+	errDuplicateStage = errors.New("duplicate stage")
+
+	stagesLock sync.RWMutex
+	stages     = make(map[string]Stage)
+)
+
+// Handler executes [tx] on top of [chainState] with [visitor], which is one of
+// the tx executors.
+type Handler func(backend *Backend, chainState state.Chain, tx *txs.Tx, visitor txs.Visitor) error
+
+// Stage is a pre-admission hook of the txs of this node. It either rejects the
+// tx, or passes it on to [next]. Stages run before the tx executor, which
+// still performs all of its checks, fee, timing and stake limits included, in
+// a single visit. A stage can't run between these checks.
+//
+// Stages are a local policy of the node: they only apply to the txs that are
+// added to the mempool and to the txs that this node includes in the blocks it
+// builds. Blocks are verified without them, whoever built them, so that a node
+// never rejects a block that the rest of the network accepts.
+type Stage func(next Handler) Handler
+
+// RegisterStage makes [stage] available to the execution config under [name].
+func RegisterStage(name string, stage Stage) error {
+	stagesLock.Lock()
+	defer stagesLock.Unlock()
+
+	if _, ok := stages[name]; ok {
+		return fmt.Errorf("%w: %q", errDuplicateStage, name)
+	}
+	stages[name] = stage
+	return nil
+}
+
+// GetStages returns the registered stages named [names], in the same order.
+func GetStages(names []string) ([]Stage, error) {
+	stagesLock.RLock()
+	defer stagesLock.RUnlock()
+
+	result := make([]Stage, len(names))
+	for i, name := range names {
+		stage, ok := stages[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", errUnknownStage, name)
+		}
+		result[i] = stage
+	}
+	return result, nil
+}
+
+// Execute passes [tx] through the stages of the backend, in order, before it
+// is executed on top of [chainState] with [visitor]. It must only be called to
+// admit txs to the mempool or to a block built by this node. Blocks are
+// verified by visiting their txs directly.
+func (b *Backend) Execute(chainState state.Chain, tx *txs.Tx, visitor txs.Visitor) error {
+	handler := visit
+	for i := len(b.Stages) - 1; i >= 0; i-- {
+		handler = b.Stages[i](handler)
+	}
+	return handler(b, chainState, tx, visitor)
+}
+
+func visit(_ *Backend, _ state.Chain, tx *txs.Tx, visitor txs.Visitor) error {
+	return tx.Unsigned.Visit(visitor)
+}
+
+// NewAddressScreeningStage returns a stage that rejects the txs that spend the
+// UTXOs owned by, or that create outputs owned by, any of [denied].
+func NewAddressScreeningStage(denied set.Set[ids.ShortID]) Stage {
+	return func(next Handler) Handler {
+		return func(backend *Backend, chainState state.Chain, tx *txs.Tx, visitor txs.Visitor) error {
+			if err := screenAddresses(chainState, tx, denied); err != nil {
+				return err
+			}
+			return next(backend, chainState, tx, visitor)
+		}
+	}
+}
+
+func screenAddresses(chainState state.Chain, tx *txs.Tx, denied set.Set[ids.ShortID]) error {
+	outs := tx.UTXOs()
+	for inputID := range tx.Unsigned.InputIDs() {
+		utxo, err := chainState.GetUTXO(inputID)
+		if err == database.ErrNotFound {
+			// Imported UTXOs aren't in the UTXO set of this chain
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get UTXO %s: %w", inputID, err)
+		}
+		outs = append(outs, utxo)
+	}
+
+	// Staked and exported outputs aren't added to the UTXO set of this chain
+	var otherOuts []*avax.TransferableOutput
+	switch utx := tx.Unsigned.(type) {
+	case txs.PermissionlessStaker:
+		otherOuts = utx.Stake()
+	case *txs.ExportTx:
+		otherOuts = utx.ExportedOutputs
+	}
+	for _, out := range otherOuts {
+		outs = append(outs, &avax.UTXO{Out: out.Out})
+	}
+
+	for _, utxo := range outs {
+		out := utxo.Out
+		if lockedOut, ok := out.(*stakeable.LockOut); ok {
+			out = lockedOut.TransferableOut
+		}
+		addressable, ok := out.(avax.Addressable)
+		if !ok {
+			continue
+		}
+		for _, addrBytes := range addressable.Addresses() {
+			addr, err := ids.ToShortID(addrBytes)
+			if err != nil {
+				return err
+			}
+			if denied.Contains(addr) {
+				return fmt.Errorf("%w: %s", ErrDeniedAddress, addr)
+			}
+		}
+	}
+	return nil
+}
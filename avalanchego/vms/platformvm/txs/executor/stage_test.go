@@ -0,0 +1,198 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+type baseTxVisitor struct {
+	txs.Visitor
+	visited []*txs.BaseTx
+}
+
+func (v *baseTxVisitor) BaseTx(tx *txs.BaseTx) error {
+	v.visited = append(v.visited, tx)
+	return nil
+}
+
+func newStageTestTx(t *testing.T, inputID ids.ID, outAddr ids.ShortID) *txs.Tx {
+	utx := &txs.BaseTx{
+		BaseTx: avax.BaseTx{
+			Ins: []*avax.TransferableInput{{
+				UTXOID: avax.UTXOID{TxID: inputID},
+				In:     &secp256k1fx.TransferInput{},
+			}},
+			Outs: []*avax.TransferableOutput{{
+				Out: &secp256k1fx.TransferOutput{
+					Amt: 1,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{outAddr},
+					},
+				},
+			}},
+		},
+	}
+	tx, err := txs.NewSigned(utx, txs.Codec, nil)
+	require.NoError(t, err)
+	return tx
+}
+
+func TestBackendExecuteStages(t *testing.T) {
+	require := require.New(t)
+
+	var called []string
+	newStage := func(name string, err error) Stage {
+		return func(next Handler) Handler {
+			return func(backend *Backend, chainState state.Chain, tx *txs.Tx, visitor txs.Visitor) error {
+				called = append(called, name)
+				if err != nil {
+					return err
+				}
+				return next(backend, chainState, tx, visitor)
+			}
+		}
+	}
+
+	tx := newStageTestTx(t, ids.GenerateTestID(), ids.GenerateTestShortID())
+	visitor := &baseTxVisitor{}
+	backend := &Backend{
+		Stages: []Stage{
+			newStage("first", nil),
+			newStage("second", nil),
+		},
+	}
+	require.NoError(backend.Execute(nil, tx, visitor))
+	require.Equal([]string{"first", "second"}, called)
+	require.Len(visitor.visited, 1)
+
+	// A rejecting stage stops the execution
+	called = nil
+	backend.Stages = []Stage{
+		newStage("first", errTest),
+		newStage("second", nil),
+	}
+	err := backend.Execute(nil, tx, visitor)
+	require.ErrorIs(err, errTest)
+	require.Equal([]string{"first"}, called)
+	require.Len(visitor.visited, 1)
+}
+
+func TestGetStages(t *testing.T) {
+	require := require.New(t)
+
+	stage := func(next Handler) Handler {
+		return next
+	}
+	require.NoError(RegisterStage("test", stage))
+	t.Cleanup(func() {
+		stagesLock.Lock()
+		delete(stages, "test")
+		stagesLock.Unlock()
+	})
+
+	err := RegisterStage("test", stage)
+	require.ErrorIs(err, errDuplicateStage)
+
+	registered, err := GetStages([]string{"test"})
+	require.NoError(err)
+	require.Len(registered, 1)
+
+	_, err = GetStages([]string{"test", "unknown"})
+	require.ErrorIs(err, errUnknownStage)
+}
+
+func TestAddressScreeningStage(t *testing.T) {
+	var (
+		deniedAddr  = ids.GenerateTestShortID()
+		allowedAddr = ids.GenerateTestShortID()
+		deniedUTXO  = ids.GenerateTestID()
+		allowedUTXO = ids.GenerateTestID()
+		importedID  = ids.GenerateTestID()
+	)
+	newUTXO := func(addr ids.ShortID) *avax.UTXO {
+		return &avax.UTXO{
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 1,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addr},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		inputID     ids.ID
+		outAddr     ids.ShortID
+		expectedErr error
+	}{
+		{
+			name:    "allowed",
+			inputID: allowedUTXO,
+			outAddr: allowedAddr,
+		},
+		{
+			name:    "imported input",
+			inputID: importedID,
+			outAddr: allowedAddr,
+		},
+		{
+			name:        "denied input",
+			inputID:     deniedUTXO,
+			outAddr:     allowedAddr,
+			expectedErr: ErrDeniedAddress,
+		},
+		{
+			name:        "denied output",
+			inputID:     allowedUTXO,
+			outAddr:     deniedAddr,
+			expectedErr: ErrDeniedAddress,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			tx := newStageTestTx(t, test.inputID, test.outAddr)
+			inputID := tx.Unsigned.(*txs.BaseTx).Ins[0].InputID()
+
+			chainState := state.NewMockChain(ctrl)
+			switch test.inputID {
+			case deniedUTXO:
+				chainState.EXPECT().GetUTXO(inputID).Return(newUTXO(deniedAddr), nil)
+			case allowedUTXO:
+				chainState.EXPECT().GetUTXO(inputID).Return(newUTXO(allowedAddr), nil)
+			default:
+				chainState.EXPECT().GetUTXO(inputID).Return(nil, database.ErrNotFound)
+			}
+
+			visitor := &baseTxVisitor{}
+			backend := &Backend{
+				Stages: []Stage{NewAddressScreeningStage(set.Of(deniedAddr))},
+			}
+			err := backend.Execute(chainState, tx, visitor)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				require.Len(visitor.visited, 1)
+			} else {
+				require.Empty(visitor.visited)
+			}
+		})
+	}
+}
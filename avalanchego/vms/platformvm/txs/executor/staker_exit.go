@@ -0,0 +1,201 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+// exitValidator removes [validator], which was added by [validatorTx], from
+// [chainState] before its end time. All of the current and pending delegators
+// of [validator] are removed at the same time, so no delegator outlives the
+// validator it delegates to.
+//
+// Every removed staker has its stake returned, forfeits its potential reward
+// and is given a [state.StakerExit] receipt naming [exitTxID]. The delegatee
+// rewards that [validator] already accrued are paid out as if its
+// RewardValidatorTx was aborted.
+func exitValidator(
+	backend *Backend,
+	chainState state.Chain,
+	exitTxID ids.ID,
+	validator *state.Staker,
+	validatorTx txs.ValidatorTx,
+) error {
+	// The delegators are collected before any of them are removed from the
+	// stakers being iterated over.
+	currentDelegatorIterator, err := chainState.GetCurrentDelegatorIterator(validator.SubnetID, validator.NodeID)
+	if err != nil {
+		return err
+	}
+	currentDelegators := collectStakers(currentDelegatorIterator)
+
+	pendingDelegatorIterator, err := chainState.GetPendingDelegatorIterator(validator.SubnetID, validator.NodeID)
+	if err != nil {
+		return err
+	}
+	pendingDelegators := collectStakers(pendingDelegatorIterator)
+
+	forfeitedRewards := validator.PotentialReward
+	for _, delegator := range currentDelegators {
+		if err := releaseDelegator(chainState, exitTxID, validator.TxID, delegator); err != nil {
+			return err
+		}
+		chainState.DeleteCurrentDelegator(delegator)
+
+		forfeitedRewards, err = math.Add64(forfeitedRewards, delegator.PotentialReward)
+		if err != nil {
+			return err
+		}
+	}
+	for _, delegator := range pendingDelegators {
+		// Pending delegators haven't been assigned a potential reward yet.
+		if err := releaseDelegator(chainState, exitTxID, validator.TxID, delegator); err != nil {
+			return err
+		}
+		chainState.DeletePendingDelegator(delegator)
+	}
+
+	if err := releaseStaker(chainState, exitTxID, validator); err != nil {
+		return err
+	}
+	if err := payDelegateeReward(backend, chainState, validatorTx, validator); err != nil {
+		return err
+	}
+	chainState.DeleteCurrentValidator(validator)
+
+	currentSupply, err := chainState.GetCurrentSupply(validator.SubnetID)
+	if err != nil {
+		return err
+	}
+	newSupply, err := math.Sub(currentSupply, forfeitedRewards)
+	if err != nil {
+		return err
+	}
+	chainState.SetCurrentSupply(validator.SubnetID, newSupply)
+	return nil
+}
+
+// collectStakers drains and releases [it].
+func collectStakers(it state.StakerIterator) []*state.Staker {
+	defer it.Release()
+
+	var stakers []*state.Staker
+	for it.Next() {
+		stakers = append(stakers, it.Value())
+	}
+	return stakers
+}
+
+// releaseStaker returns the stake of [staker] and records its receipt.
+func releaseStaker(chainState state.Chain, exitTxID ids.ID, staker *state.Staker) error {
+	stakerTx, _, err := chainState.GetTx(staker.TxID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch staker tx %s: %w", staker.TxID, err)
+	}
+	uStakerTx, ok := stakerTx.Unsigned.(txs.PermissionlessStaker)
+	if !ok {
+		return ErrShouldBePermissionlessStaker
+	}
+
+	var (
+		outputs  = uStakerTx.Outputs()
+		refunded uint64
+	)
+	for i, out := range uStakerTx.Stake() {
+		chainState.AddUTXO(&avax.UTXO{
+			UTXOID: avax.UTXOID{
+				TxID:        staker.TxID,
+				OutputIndex: uint32(len(outputs) + i),
+			},
+			Asset: out.Asset,
+			Out:   out.Output(),
+		})
+
+		refunded, err = math.Add64(refunded, out.Output().Amount())
+		if err != nil {
+			return err
+		}
+	}
+
+	chainState.SetStakerExit(staker.TxID, &state.StakerExit{
+		TxID:            exitTxID,
+		Timestamp:       uint64(chainState.GetTimestamp().Unix()),
+		Refunded:        refunded,
+		ForfeitedReward: staker.PotentialReward,
+	})
+	return nil
+}
+
+// releaseDelegator releases [delegator] like [releaseStaker] and removes its
+// stake from the stake delegated to the validator added by [validatorTxID].
+func releaseDelegator(
+	chainState state.Chain,
+	exitTxID ids.ID,
+	validatorTxID ids.ID,
+	delegator *state.Staker,
+) error {
+	if err := releaseStaker(chainState, exitTxID, delegator); err != nil {
+		return err
+	}
+
+	delegatorTx, _, err := chainState.GetTx(delegator.TxID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch delegator tx %s: %w", delegator.TxID, err)
+	}
+	uDelegatorTx, ok := delegatorTx.Unsigned.(txs.DelegatorTx)
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrWrongTxType, delegatorTx.Unsigned)
+	}
+	return removeDelegatedStake(chainState, validatorTxID, uDelegatorTx)
+}
+
+// payDelegateeReward pays out the delegatee rewards accrued by [validator].
+func payDelegateeReward(
+	backend *Backend,
+	chainState state.Chain,
+	validatorTx txs.ValidatorTx,
+	validator *state.Staker,
+) error {
+	delegateeReward, err := chainState.GetDelegateeReward(
+		validator.SubnetID,
+		validator.NodeID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to fetch accrued delegatee rewards: %w", err)
+	}
+	if delegateeReward == 0 {
+		return nil
+	}
+
+	outIntf, err := backend.Fx.CreateOutput(delegateeReward, validatorTx.DelegationRewardsOwner())
+	if err != nil {
+		return fmt.Errorf("failed to create output: %w", err)
+	}
+	out, ok := outIntf.(verify.State)
+	if !ok {
+		return ErrInvalidState
+	}
+
+	stake := validatorTx.Stake()
+	utxo := &avax.UTXO{
+		UTXOID: avax.UTXOID{
+			TxID:        validator.TxID,
+			OutputIndex: uint32(len(validatorTx.Outputs()) + len(stake)),
+		},
+		// Invariant: The staked asset must be equal to the reward asset.
+		Asset: stake[0].Asset,
+		Out:   out,
+	}
+	chainState.AddUTXO(utxo)
+	chainState.AddRewardUTXO(validator.TxID, utxo)
+	return nil
+}
@@ -0,0 +1,272 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// Mirrors the staker layout of TestAddDelegatorTxHeapCorruption: delegators
+// with interleaved start and end times, one of which is still pending, are
+// all released along with their validator without corrupting the staker sets.
+func TestExitValidatorReleasesDelegators(t *testing.T) {
+	require := require.New(t)
+	env := newEnvironment(t, durango)
+	env.ctx.Lock.Lock()
+	defer env.ctx.Lock.Unlock()
+
+	var (
+		nodeID                   = ids.GenerateTestNodeID()
+		validatorStartTime       = env.state.GetTimestamp()
+		validatorEndTime         = validatorStartTime.Add(8 * defaultMinStakingDuration)
+		validatorPotentialReward = uint64(1_000)
+		delegateeReward          = uint64(50)
+		exitTxID                 = ids.GenerateTestID()
+	)
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	validatorTx, err := env.txBuilder.NewAddPermissionlessValidatorTx(
+		env.config.MinValidatorStake,
+		uint64(validatorStartTime.Unix()),
+		uint64(validatorEndTime.Unix()),
+		nodeID,
+		signer.NewProofOfPossession(sk),
+		preFundedKeys[1].Address(), // reward address
+		reward.PercentDenominator,
+		secp256k1fx.NewKeychain(preFundedKeys[0]),
+		preFundedKeys[0].Address(), // change address
+		nil,
+	)
+	require.NoError(err)
+	validator, err := state.NewCurrentStaker(
+		validatorTx.ID(),
+		validatorTx.Unsigned.(txs.Staker),
+		validatorStartTime,
+		validatorPotentialReward,
+	)
+	require.NoError(err)
+	env.state.PutCurrentValidator(validator)
+	env.state.AddTx(validatorTx, status.Committed)
+	env.state.SetHeight(1)
+	require.NoError(env.state.Commit())
+	require.NoError(env.state.SetDelegateeReward(constants.PrimaryNetworkID, nodeID, delegateeReward))
+
+	newDelegatorTx := func(startOffset, endOffset time.Duration) *txs.Tx {
+		tx, err := env.txBuilder.NewAddPermissionlessDelegatorTx(
+			env.config.MinDelegatorStake,
+			uint64(validatorStartTime.Add(startOffset).Unix()),
+			uint64(validatorStartTime.Add(endOffset).Unix()),
+			nodeID,
+			preFundedKeys[2].Address(), // reward address
+			secp256k1fx.NewKeychain(preFundedKeys[0]),
+			preFundedKeys[0].Address(), // change address
+			nil,
+		)
+		require.NoError(err)
+		env.state.AddTx(tx, status.Committed)
+		return tx
+	}
+
+	var (
+		currentDelegatorTxs = []*txs.Tx{
+			newDelegatorTx(0, 3*defaultMinStakingDuration),
+			newDelegatorTx(defaultMinStakingDuration, 6*defaultMinStakingDuration),
+			newDelegatorTx(2*defaultMinStakingDuration, 4*defaultMinStakingDuration),
+		}
+		pendingDelegatorTx = newDelegatorTx(5*defaultMinStakingDuration, 7*defaultMinStakingDuration)
+
+		forfeitedRewards = validatorPotentialReward
+		potentialRewards = map[ids.ID]uint64{
+			validatorTx.ID(): validatorPotentialReward,
+		}
+	)
+	for i, tx := range currentDelegatorTxs {
+		potentialReward := uint64(i + 1)
+		delegator, err := state.NewCurrentStaker(
+			tx.ID(),
+			tx.Unsigned.(txs.Staker),
+			validatorStartTime,
+			potentialReward,
+		)
+		require.NoError(err)
+		env.state.PutCurrentDelegator(delegator)
+
+		forfeitedRewards += potentialReward
+		potentialRewards[tx.ID()] = potentialReward
+	}
+	pendingDelegator, err := state.NewPendingStaker(
+		pendingDelegatorTx.ID(),
+		pendingDelegatorTx.Unsigned.(txs.ScheduledStaker),
+	)
+	require.NoError(err)
+	env.state.PutPendingDelegator(pendingDelegator)
+	potentialRewards[pendingDelegatorTx.ID()] = 0
+
+	env.state.SetHeight(2)
+	require.NoError(env.state.Commit())
+
+	supply, err := env.state.GetCurrentSupply(constants.PrimaryNetworkID)
+	require.NoError(err)
+
+	onAcceptState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+	require.NoError(exitValidator(
+		&env.backend,
+		onAcceptState,
+		exitTxID,
+		validator,
+		validatorTx.Unsigned.(txs.ValidatorTx),
+	))
+
+	// The stakers are released in the diff and the remaining stakers are
+	// still iterated over in order.
+	requireNoStakers := func(chainState state.Chain) {
+		_, err := chainState.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+		require.ErrorIs(err, database.ErrNotFound)
+
+		for _, getIterator := range []func() (state.StakerIterator, error){
+			chainState.GetCurrentStakerIterator,
+			chainState.GetPendingStakerIterator,
+		} {
+			it, err := getIterator()
+			require.NoError(err)
+
+			var previous *state.Staker
+			for it.Next() {
+				staker := it.Value()
+				require.NotEqual(nodeID, staker.NodeID)
+				if previous != nil {
+					require.True(previous.Less(staker))
+				}
+				previous = staker
+			}
+			it.Release()
+		}
+	}
+	requireNoStakers(onAcceptState)
+
+	newSupply, err := onAcceptState.GetCurrentSupply(constants.PrimaryNetworkID)
+	require.NoError(err)
+	require.Equal(supply-forfeitedRewards, newSupply)
+
+	require.NoError(onAcceptState.Apply(env.state))
+	env.state.SetHeight(3)
+	require.NoError(env.state.Commit())
+	requireNoStakers(env.state)
+
+	// Every staker has a receipt and its stake returned.
+	for stakerTxID, potentialReward := range potentialRewards {
+		exit, err := env.state.GetStakerExit(stakerTxID)
+		require.NoError(err)
+		require.Equal(exitTxID, exit.TxID)
+		require.Equal(uint64(validatorStartTime.Unix()), exit.Timestamp)
+		require.Equal(potentialReward, exit.ForfeitedReward)
+
+		stakerTx, _, err := env.state.GetTx(stakerTxID)
+		require.NoError(err)
+		uStakerTx := stakerTx.Unsigned.(txs.PermissionlessStaker)
+		require.Equal(uStakerTx.Weight(), exit.Refunded)
+
+		stakeUTXOID := avax.UTXOID{
+			TxID:        stakerTxID,
+			OutputIndex: uint32(len(uStakerTx.Outputs())),
+		}
+		_, err = env.state.GetUTXO(stakeUTXOID.InputID())
+		require.NoError(err)
+	}
+
+	// The accrued delegatee reward is paid out.
+	rewardUTXOs, err := env.state.GetRewardUTXOs(validatorTx.ID())
+	require.NoError(err)
+	require.Len(rewardUTXOs, 1)
+	require.Equal(delegateeReward, rewardUTXOs[0].Out.(avax.Amounter).Amount())
+}
+
+func TestExitValidatorTxSubnetStakers(t *testing.T) {
+	require := require.New(t)
+	env := newEnvironment(t, durango)
+	env.ctx.Lock.Lock()
+	defer env.ctx.Lock.Unlock()
+
+	var (
+		nodeID    = ids.GenerateTestNodeID()
+		startTime = env.state.GetTimestamp()
+		endTime   = startTime.Add(defaultMinStakingDuration)
+	)
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	validatorTx, err := env.txBuilder.NewAddPermissionlessValidatorTx(
+		env.config.MinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		nodeID,
+		signer.NewProofOfPossession(sk),
+		preFundedKeys[1].Address(), // reward address
+		reward.PercentDenominator,
+		secp256k1fx.NewKeychain(preFundedKeys[0]),
+		preFundedKeys[0].Address(), // change address
+		nil,
+	)
+	require.NoError(err)
+	validator, err := state.NewCurrentStaker(validatorTx.ID(), validatorTx.Unsigned.(txs.Staker), startTime, 0)
+	require.NoError(err)
+	env.state.PutCurrentValidator(validator)
+	env.state.AddTx(validatorTx, status.Committed)
+
+	subnetValidatorTx, err := env.txBuilder.NewAddSubnetValidatorTx(
+		defaultWeight,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		nodeID,
+		testSubnet1.ID(),
+		secp256k1fx.NewKeychain(testSubnet1ControlKeys...),
+		ids.ShortEmpty, // change address
+		nil,
+	)
+	require.NoError(err)
+	subnetValidator, err := state.NewCurrentStaker(subnetValidatorTx.ID(), subnetValidatorTx.Unsigned.(txs.Staker), startTime, 0)
+	require.NoError(err)
+	env.state.PutCurrentValidator(subnetValidator)
+	env.state.AddTx(subnetValidatorTx, status.Committed)
+
+	env.state.SetHeight(1)
+	require.NoError(env.state.Commit())
+
+	exitTx, err := env.txBuilder.NewExitValidatorTx(
+		constants.PrimaryNetworkID,
+		nodeID,
+		secp256k1fx.NewKeychain(preFundedKeys[0]),
+		preFundedKeys[0].Address(), // change address
+		nil,
+	)
+	require.NoError(err)
+
+	onAcceptState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	executor := StandardTxExecutor{
+		Backend: &env.backend,
+		State:   onAcceptState,
+		Tx:      exitTx,
+	}
+	err = exitTx.Unsigned.Visit(&executor)
+	require.ErrorIs(err, ErrValidatorHasSubnetStakers)
+}
@@ -0,0 +1,45 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+)
+
+// rekeyValidator moves [validator] and its current delegators to [newNodeID]
+// in [chainState], and replaces the BLS key of [validator] with
+// [newPublicKey]. The stakers keep the txID that added them, along with their
+// staking period and potential reward, so they are still rewarded by the same
+// RewardValidatorTxs. The uptime and delegatee rewards that [validator]
+// accrued are carried over to [newNodeID] when the state is written.
+func rekeyValidator(
+	chainState state.Chain,
+	validator *state.Staker,
+	newNodeID ids.NodeID,
+	newPublicKey *bls.PublicKey,
+) error {
+	// The delegators are collected before any of them are removed from the
+	// stakers being iterated over.
+	currentDelegatorIterator, err := chainState.GetCurrentDelegatorIterator(validator.SubnetID, validator.NodeID)
+	if err != nil {
+		return err
+	}
+	currentDelegators := collectStakers(currentDelegatorIterator)
+
+	chainState.DeleteCurrentValidator(validator)
+	rekeyedValidator := *validator
+	rekeyedValidator.NodeID = newNodeID
+	rekeyedValidator.PublicKey = newPublicKey
+	chainState.PutCurrentValidator(&rekeyedValidator)
+
+	for _, delegator := range currentDelegators {
+		chainState.DeleteCurrentDelegator(delegator)
+		rekeyedDelegator := *delegator
+		rekeyedDelegator.NodeID = newNodeID
+		chainState.PutCurrentDelegator(&rekeyedDelegator)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/builder"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestRekeyValidatorTx(t *testing.T) {
+	require := require.New(t)
+	env := newEnvironment(t, durango)
+	env.ctx.Lock.Lock()
+	defer env.ctx.Lock.Unlock()
+
+	var (
+		nodeID    = ids.GenerateTestNodeID()
+		newNodeID = ids.GenerateTestNodeID()
+		startTime = env.state.GetTimestamp()
+		endTime   = startTime.Add(defaultMinStakingDuration)
+	)
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	newSK, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	validatorTx, err := env.txBuilder.NewAddPermissionlessValidatorTx(
+		env.config.MinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		nodeID,
+		signer.NewProofOfPossession(sk),
+		preFundedKeys[1].Address(), // reward address
+		reward.PercentDenominator,
+		secp256k1fx.NewKeychain(preFundedKeys[0]),
+		preFundedKeys[0].Address(), // change address
+		nil,
+	)
+	require.NoError(err)
+	validator, err := state.NewCurrentStaker(validatorTx.ID(), validatorTx.Unsigned.(txs.Staker), startTime, 1_000)
+	require.NoError(err)
+	env.state.PutCurrentValidator(validator)
+	env.state.AddTx(validatorTx, status.Committed)
+
+	newDelegatorTx := func() *txs.Tx {
+		tx, err := env.txBuilder.NewAddPermissionlessDelegatorTx(
+			env.config.MinDelegatorStake,
+			uint64(startTime.Unix()),
+			uint64(endTime.Unix()),
+			nodeID,
+			preFundedKeys[2].Address(), // reward address
+			secp256k1fx.NewKeychain(preFundedKeys[0]),
+			preFundedKeys[0].Address(), // change address
+			nil,
+		)
+		require.NoError(err)
+		env.state.AddTx(tx, status.Committed)
+		return tx
+	}
+	delegatorTx := newDelegatorTx()
+	delegator, err := state.NewCurrentStaker(delegatorTx.ID(), delegatorTx.Unsigned.(txs.Staker), startTime, 10)
+	require.NoError(err)
+	env.state.PutCurrentDelegator(delegator)
+
+	env.state.SetHeight(1)
+	require.NoError(env.state.Commit())
+
+	// Only the owner of the stake can re-key the validator, rather than its
+	// rewards owner.
+	_, err = env.txBuilder.NewRekeyValidatorTx(
+		nodeID,
+		newNodeID,
+		signer.NewProofOfPossession(newSK),
+		secp256k1fx.NewKeychain(preFundedKeys[1]),
+		preFundedKeys[1].Address(), // change address
+		nil,
+	)
+	require.ErrorIs(err, builder.ErrCantSignStakeOwner)
+
+	newRekeyTx := func(newNodeID ids.NodeID) *txs.Tx {
+		tx, err := env.txBuilder.NewRekeyValidatorTx(
+			nodeID,
+			newNodeID,
+			signer.NewProofOfPossession(newSK),
+			secp256k1fx.NewKeychain(preFundedKeys[0]),
+			preFundedKeys[0].Address(), // change address
+			nil,
+		)
+		require.NoError(err)
+		return tx
+	}
+	execute := func(chainState state.Diff, tx *txs.Tx) error {
+		return tx.Unsigned.Visit(&StandardTxExecutor{
+			Backend: &env.backend,
+			State:   chainState,
+			Tx:      tx,
+		})
+	}
+
+	// Validators can't be re-keyed before the upgrade activates.
+	env.config.RekeyValidatorTime = time.Time{}
+	onAcceptState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+	err = execute(onAcceptState, newRekeyTx(newNodeID))
+	require.ErrorIs(err, ErrRekeyValidatorNotActive)
+	env.config.RekeyValidatorTime = env.config.DurangoTime
+
+	// The validator can't take over the nodeID of another validator.
+	onAcceptState, err = state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+	err = execute(onAcceptState, newRekeyTx(genesisNodeIDs[0]))
+	require.ErrorIs(err, ErrAlreadyValidator)
+
+	// Pending delegators must start under the nodeID they were added with.
+	onAcceptState, err = state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+	pendingDelegatorTx := newDelegatorTx()
+	pendingDelegator, err := state.NewPendingStaker(pendingDelegatorTx.ID(), pendingDelegatorTx.Unsigned.(txs.ScheduledStaker))
+	require.NoError(err)
+	onAcceptState.PutPendingDelegator(pendingDelegator)
+	err = execute(onAcceptState, newRekeyTx(newNodeID))
+	require.ErrorIs(err, ErrValidatorHasPendingDelegators)
+
+	onAcceptState, err = state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+	require.NoError(execute(onAcceptState, newRekeyTx(newNodeID)))
+	require.NoError(onAcceptState.Apply(env.state))
+	env.state.SetHeight(2)
+	require.NoError(env.state.Commit())
+
+	_, err = env.state.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// The stakers keep everything but their nodeID, and the BLS key of the
+	// validator.
+	rekeyedValidator, err := env.state.GetCurrentValidator(constants.PrimaryNetworkID, newNodeID)
+	require.NoError(err)
+	expectedValidator := *validator
+	expectedValidator.NodeID = newNodeID
+	expectedValidator.PublicKey = bls.PublicFromSecretKey(newSK)
+	require.Equal(&expectedValidator, rekeyedValidator)
+
+	delegatorIterator, err := env.state.GetCurrentDelegatorIterator(constants.PrimaryNetworkID, newNodeID)
+	require.NoError(err)
+	require.True(delegatorIterator.Next())
+	expectedDelegator := *delegator
+	expectedDelegator.NodeID = newNodeID
+	require.Equal(&expectedDelegator, delegatorIterator.Value())
+	require.False(delegatorIterator.Next())
+	delegatorIterator.Release()
+}
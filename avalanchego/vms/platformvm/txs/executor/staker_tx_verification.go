@@ -13,6 +13,8 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 
@@ -20,27 +22,45 @@ import (
 )
 
 var (
-	ErrWeightTooSmall                  = errors.New("weight of this validator is too low")
-	ErrWeightTooLarge                  = errors.New("weight of this validator is too large")
-	ErrInsufficientDelegationFee       = errors.New("staker charges an insufficient delegation fee")
-	ErrStakeTooShort                   = errors.New("staking period is too short")
-	ErrStakeTooLong                    = errors.New("staking period is too long")
-	ErrFlowCheckFailed                 = errors.New("flow check failed")
-	ErrFutureStakeTime                 = fmt.Errorf("staker is attempting to start staking more than %s ahead of the current chain time", MaxFutureStartTime)
-	ErrNotValidator                    = errors.New("isn't a current or pending validator")
-	ErrRemovePermissionlessValidator   = errors.New("attempting to remove permissionless validator")
-	ErrStakeOverflow                   = errors.New("validator stake exceeds limit")
-	ErrPeriodMismatch                  = errors.New("proposed staking period is not inside dependant staking period")
-	ErrOverDelegated                   = errors.New("validator would be over delegated")
-	ErrIsNotTransformSubnetTx          = errors.New("is not a transform subnet tx")
-	ErrTimestampNotBeforeStartTime     = errors.New("chain timestamp not before start time")
-	ErrAlreadyValidator                = errors.New("already a validator")
-	ErrDuplicateValidator              = errors.New("duplicate validator")
-	ErrDelegateToPermissionedValidator = errors.New("delegation to permissioned validator")
-	ErrWrongStakedAssetID              = errors.New("incorrect staked assetID")
-	ErrDurangoUpgradeNotActive         = errors.New("attempting to use a Durango-upgrade feature prior to activation")
-	ErrAddValidatorTxPostDurango       = errors.New("AddValidatorTx is not permitted post-Durango")
-	ErrAddDelegatorTxPostDurango       = errors.New("AddDelegatorTx is not permitted post-Durango")
+	ErrWeightTooSmall                   = errors.New("weight of this validator is too low")
+	ErrWeightTooLarge                   = errors.New("weight of this validator is too large")
+	ErrInsufficientDelegationFee        = errors.New("staker charges an insufficient delegation fee")
+	ErrStakeTooShort                    = errors.New("staking period is too short")
+	ErrStakeTooLong                     = errors.New("staking period is too long")
+	ErrFlowCheckFailed                  = errors.New("flow check failed")
+	ErrFutureStakeTime                  = errors.New("staker is attempting to start staking too far ahead of the current chain time")
+	ErrNotValidator                     = errors.New("isn't a current or pending validator")
+	ErrRemovePermissionlessValidator    = errors.New("attempting to remove permissionless validator")
+	ErrStakeOverflow                    = errors.New("validator stake exceeds limit")
+	ErrPeriodMismatch                   = errors.New("proposed staking period is not inside dependant staking period")
+	ErrOverDelegated                    = errors.New("validator would be over delegated")
+	ErrIsNotTransformSubnetTx           = errors.New("is not a transform subnet tx")
+	ErrTimestampNotBeforeStartTime      = errors.New("chain timestamp not before start time")
+	ErrAlreadyValidator                 = errors.New("already a validator")
+	ErrDuplicateValidator               = errors.New("duplicate validator")
+	ErrDelegateToPermissionedValidator  = errors.New("delegation to permissioned validator")
+	ErrWrongStakedAssetID               = errors.New("incorrect staked assetID")
+	ErrDurangoUpgradeNotActive          = errors.New("attempting to use a Durango-upgrade feature prior to activation")
+	ErrAddValidatorTxPostDurango        = errors.New("AddValidatorTx is not permitted post-Durango")
+	ErrAddDelegatorTxPostDurango        = errors.New("AddDelegatorTx is not permitted post-Durango")
+	ErrAlreadyAllowListed               = errors.New("node is already on the subnet allow list")
+	ErrNotAllowListed                   = errors.New("node isn't on the subnet allow list")
+	ErrSubnetAllowListNotActive         = errors.New("attempting to modify a subnet allow list prior to the activation of subnet allow lists")
+	ErrExitValidatorNotActive           = errors.New("attempting to exit a validator prior to the activation of validator exits")
+	ErrCappedDelegationNotActive        = errors.New("attempting to add a capped validator prior to the activation of capped delegation")
+	ErrValidatorMetadataNotActive       = errors.New("attempting to add a validator with metadata prior to the activation of validator metadata")
+	ErrSubnetValidatorWeightNotActive   = errors.New("attempting to set the weight of a subnet validator prior to the activation of subnet validator weights")
+	ErrRekeyValidatorNotActive          = errors.New("attempting to re-key a validator prior to the activation of validator re-keys")
+	ErrDelegatorStakeCapExceeded        = errors.New("delegator would exceed the validator's delegator stake cap")
+	ErrExitPermissionedValidator        = errors.New("attempting to exit permissioned validator")
+	ErrValidatorHasSubnetStakers        = errors.New("primary network validator is still staking on a subnet")
+	ErrSetPermissionlessValidatorWeight = errors.New("attempting to set the weight of a permissionless validator")
+	ErrRekeyPermissionedValidator       = errors.New("attempting to re-key permissioned validator")
+	ErrValidatorHasPendingDelegators    = errors.New("validator has pending delegators")
+	ErrValidatorNotAllowlisted          = errors.New("node isn't on the validator allowlist")
+
+	errUnauthorizedValidatorExit  = errors.New("unauthorized validator exit")
+	errUnauthorizedValidatorRekey = errors.New("unauthorized validator re-key")
 )
 
 // verifySubnetValidatorPrimaryNetworkRequirements verifies the primary
@@ -145,6 +165,10 @@ func verifyAddValidatorTx(
 		return outs, nil
 	}
 
+	if err := verifyValidatorAllowlisted(backend.Config, currentTimestamp, tx.NodeID()); err != nil {
+		return nil, err
+	}
+
 	if err := verifyStakerStartTime(false /*=isDurangoActive*/, currentTimestamp, startTime); err != nil {
 		return nil, err
 	}
@@ -173,6 +197,11 @@ func verifyAddValidatorTx(
 		)
 	}
 
+	txFee, err := state.GetParameter(chainState, txs.AddPrimaryNetworkValidatorFeeParameter, backend.Config.AddPrimaryNetworkValidatorFee)
+	if err != nil {
+		return nil, err
+	}
+
 	// Verify the flowcheck
 	if err := backend.FlowChecker.VerifySpend(
 		tx,
@@ -181,20 +210,20 @@ func verifyAddValidatorTx(
 		outs,
 		sTx.Creds,
 		map[ids.ID]uint64{
-			backend.Ctx.AVAXAssetID: backend.Config.AddPrimaryNetworkValidatorFee,
+			backend.Ctx.AVAXAssetID: txFee,
 		},
 	); err != nil {
 		return nil, fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
 	}
 
-	err = verifyMinFutureStartTimeOffset(currentTimestamp, startTime, minFutureStartTimeOffset)
+	err = verifyMinFutureStartTimeOffset(backend.Config, currentTimestamp, startTime, minFutureStartTimeOffset)
 	if err != nil {
 		return nil, err
 	}
 
 	// verifyStakerStartsSoon is checked last to allow
 	// the verifier visitor to explicitly check for this error.
-	return outs, verifyStakerStartsSoon(false /*=isDurangoActive*/, currentTimestamp, startTime)
+	return outs, verifyStakerStartsSoon(backend.Config, false /*=isDurangoActive*/, currentTimestamp, startTime)
 }
 
 // verifyAddSubnetValidatorTx carries out the validation for an
@@ -218,11 +247,6 @@ func verifyAddSubnetValidatorTx(
 		return err
 	}
 
-	// Flare does not allow creation of subnets before Durango
-	if !isDurangoActive && (constants.IsFlareNetworkID(backend.Ctx.NetworkID) || constants.IsSgbNetworkID(backend.Ctx.NetworkID)) {
-		return ErrWrongTxType
-	}
-
 	startTime := currentTimestamp
 	if !isDurangoActive {
 		startTime = tx.StartTime()
@@ -268,7 +292,15 @@ func verifyAddSubnetValidatorTx(
 		return err
 	}
 
-	baseTxCreds, err := verifyPoASubnetAuthorization(backend, chainState, sTx, tx.SubnetValidator.Subnet, tx.SubnetAuth)
+	baseTxCreds, err := verifySubnetValidatorAuthorization(
+		backend,
+		chainState,
+		sTx,
+		tx.SubnetValidator.Subnet,
+		tx.Validator.NodeID,
+		tx.Validator.Wght,
+		tx.SubnetAuth,
+	)
 	if err != nil {
 		return err
 	}
@@ -280,16 +312,14 @@ func verifyAddSubnetValidatorTx(
 		tx.Ins,
 		tx.Outs,
 		baseTxCreds,
-		map[ids.ID]uint64{
-			backend.Ctx.AVAXAssetID: backend.Config.AddSubnetValidatorFee,
-		},
+		subnetTxFee(backend, chainState, tx, backend.Config.AddSubnetValidatorFee),
 	); err != nil {
 		return fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
 	}
 
 	// verifyStakerStartsSoon is checked last to allow
 	// the verifier visitor to explicitly check for this error.
-	return verifyStakerStartsSoon(isDurangoActive, currentTimestamp, startTime)
+	return verifyStakerStartsSoon(backend.Config, isDurangoActive, currentTimestamp, startTime)
 }
 
 // Returns the representation of [tx.NodeID] validating [tx.Subnet].
@@ -350,6 +380,11 @@ func verifyRemoveSubnetValidatorTx(
 		return nil, false, err
 	}
 
+	txFee, err := state.GetParameter(chainState, txs.TxFeeParameter, backend.Config.TxFee)
+	if err != nil {
+		return nil, false, err
+	}
+
 	// Verify the flowcheck
 	if err := backend.FlowChecker.VerifySpend(
 		tx,
@@ -358,7 +393,7 @@ func verifyRemoveSubnetValidatorTx(
 		tx.Outs,
 		baseTxCreds,
 		map[ids.ID]uint64{
-			backend.Ctx.AVAXAssetID: backend.Config.TxFee,
+			backend.Ctx.AVAXAssetID: txFee,
 		},
 	); err != nil {
 		return nil, false, fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
@@ -367,6 +402,81 @@ func verifyRemoveSubnetValidatorTx(
 	return vdr, isCurrentValidator, nil
 }
 
+// Returns the current validator whose weight is being set if the given tx is
+// valid.
+// The transaction is valid if:
+// * [tx.NodeID] is a current PoA validator of [tx.Subnet].
+// * [sTx]'s creds authorize it to spend the stated inputs.
+// * [sTx]'s creds authorize it to modify the validators of [tx.Subnet].
+// * The flow checker passes.
+func verifySetSubnetValidatorWeightTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.SetSubnetValidatorWeightTx,
+) (*state.Staker, error) {
+	if !backend.Config.IsSubnetValidatorWeightActivated(chainState.GetTimestamp()) {
+		return nil, ErrSubnetValidatorWeightNotActive
+	}
+
+	// Verify the tx is well-formed
+	if err := sTx.SyntacticVerify(backend.Ctx); err != nil {
+		return nil, err
+	}
+
+	if err := avax.VerifyMemoFieldLength(tx.Memo, true /*=isDurangoActive*/); err != nil {
+		return nil, err
+	}
+
+	// Pending validators are not supported, their weight is only applied once
+	// they are promoted.
+	vdr, err := chainState.GetCurrentValidator(tx.Subnet, tx.NodeID)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"%s %w of %s: %w",
+			tx.NodeID,
+			ErrNotValidator,
+			tx.Subnet,
+			err,
+		)
+	}
+
+	if !vdr.Priority.IsPermissionedValidator() {
+		return nil, ErrSetPermissionlessValidatorWeight
+	}
+
+	if !backend.Bootstrapped.Get() {
+		// Not bootstrapped yet -- don't need to do full verification.
+		return vdr, nil
+	}
+
+	baseTxCreds, err := verifySubnetAuthorization(backend, chainState, sTx, tx.Subnet, tx.SubnetAuth)
+	if err != nil {
+		return nil, err
+	}
+
+	txFee, err := state.GetParameter(chainState, txs.TxFeeParameter, backend.Config.TxFee)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify the flowcheck
+	if err := backend.FlowChecker.VerifySpend(
+		tx,
+		chainState,
+		tx.Ins,
+		tx.Outs,
+		baseTxCreds,
+		map[ids.ID]uint64{
+			backend.Ctx.AVAXAssetID: txFee,
+		},
+	); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
+	}
+
+	return vdr, nil
+}
+
 // verifyAddDelegatorTx carries out the validation for an AddDelegatorTx.
 // It returns the tx outputs that should be returned if this delegator is not
 // added to the staking set.
@@ -465,6 +575,14 @@ func verifyAddDelegatorTx(
 	if overDelegated {
 		return nil, ErrOverDelegated
 	}
+	if err := verifyDelegatorStakeCap(chainState, primaryNetworkValidator, tx); err != nil {
+		return nil, err
+	}
+
+	txFee, err := state.GetParameter(chainState, txs.AddPrimaryNetworkDelegatorFeeParameter, backend.Config.AddPrimaryNetworkDelegatorFee)
+	if err != nil {
+		return nil, err
+	}
 
 	// Verify the flowcheck
 	if err := backend.FlowChecker.VerifySpend(
@@ -474,20 +592,20 @@ func verifyAddDelegatorTx(
 		outs,
 		sTx.Creds,
 		map[ids.ID]uint64{
-			backend.Ctx.AVAXAssetID: backend.Config.AddPrimaryNetworkDelegatorFee,
+			backend.Ctx.AVAXAssetID: txFee,
 		},
 	); err != nil {
 		return nil, fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
 	}
 
-	err = verifyMinFutureStartTimeOffset(currentTimestamp, startTime, minFutureStartTimeOffset)
+	err = verifyMinFutureStartTimeOffset(backend.Config, currentTimestamp, startTime, minFutureStartTimeOffset)
 	if err != nil {
 		return nil, err
 	}
 
 	// verifyStakerStartsSoon is checked last to allow
 	// the verifier visitor to explicitly check for this error.
-	return outs, verifyStakerStartsSoon(false /*=isDurangoActive*/, currentTimestamp, startTime)
+	return outs, verifyStakerStartsSoon(backend.Config, false /*=isDurangoActive*/, currentTimestamp, startTime)
 }
 
 // verifyAddPermissionlessValidatorTx carries out the validation for an
@@ -527,6 +645,12 @@ func verifyAddPermissionlessValidatorTx(
 		}
 	}
 
+	if tx.Subnet == constants.PrimaryNetworkID {
+		if err := verifyValidatorAllowlisted(backend.Config, currentTimestamp, tx.NodeID()); err != nil {
+			return err
+		}
+	}
+
 	startTime := currentTimestamp
 	if !isDurangoActive {
 		startTime = tx.StartTime()
@@ -600,7 +724,10 @@ func verifyAddPermissionlessValidatorTx(
 
 		txFee = backend.Config.AddSubnetValidatorFee
 	} else {
-		txFee = backend.Config.AddPrimaryNetworkValidatorFee
+		txFee, err = state.GetParameter(chainState, txs.AddPrimaryNetworkValidatorFeeParameter, backend.Config.AddPrimaryNetworkValidatorFee)
+		if err != nil {
+			return err
+		}
 	}
 
 	outs := make([]*avax.TransferableOutput, len(tx.Outs)+len(tx.StakeOuts))
@@ -623,7 +750,7 @@ func verifyAddPermissionlessValidatorTx(
 
 	// verifyStakerStartsSoon is checked last to allow
 	// the verifier visitor to explicitly check for this error.
-	return verifyStakerStartsSoon(isDurangoActive, currentTimestamp, startTime)
+	return verifyStakerStartsSoon(backend.Config, isDurangoActive, currentTimestamp, startTime)
 }
 
 // verifyAddPermissionlessDelegatorTx carries out the validation for an
@@ -739,6 +866,9 @@ func verifyAddPermissionlessDelegatorTx(
 	if overDelegated {
 		return ErrOverDelegated
 	}
+	if err := verifyDelegatorStakeCap(chainState, validator, tx); err != nil {
+		return err
+	}
 
 	outs := make([]*avax.TransferableOutput, len(tx.Outs)+len(tx.StakeOuts))
 	copy(outs, tx.Outs)
@@ -758,7 +888,10 @@ func verifyAddPermissionlessDelegatorTx(
 
 		txFee = backend.Config.AddSubnetDelegatorFee
 	} else {
-		txFee = backend.Config.AddPrimaryNetworkDelegatorFee
+		txFee, err = state.GetParameter(chainState, txs.AddPrimaryNetworkDelegatorFeeParameter, backend.Config.AddPrimaryNetworkDelegatorFee)
+		if err != nil {
+			return err
+		}
 	}
 
 	// Verify the flowcheck
@@ -777,7 +910,7 @@ func verifyAddPermissionlessDelegatorTx(
 
 	// verifyStakerStartsSoon is checked last to allow
 	// the verifier visitor to explicitly check for this error.
-	return verifyStakerStartsSoon(isDurangoActive, currentTimestamp, startTime)
+	return verifyStakerStartsSoon(backend.Config, isDurangoActive, currentTimestamp, startTime)
 }
 
 // Returns an error if the given tx is invalid.
@@ -814,6 +947,11 @@ func verifyTransferSubnetOwnershipTx(
 		return err
 	}
 
+	txFee, err := state.GetParameter(chainState, txs.TxFeeParameter, backend.Config.TxFee)
+	if err != nil {
+		return err
+	}
+
 	// Verify the flowcheck
 	if err := backend.FlowChecker.VerifySpend(
 		tx,
@@ -822,7 +960,7 @@ func verifyTransferSubnetOwnershipTx(
 		tx.Outs,
 		baseTxCreds,
 		map[ids.ID]uint64{
-			backend.Ctx.AVAXAssetID: backend.Config.TxFee,
+			backend.Ctx.AVAXAssetID: txFee,
 		},
 	); err != nil {
 		return fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
@@ -831,6 +969,402 @@ func verifyTransferSubnetOwnershipTx(
 	return nil
 }
 
+// Returns an error if the given tx is invalid.
+// The transaction is valid if:
+// * [sTx]'s creds authorize it to spend the stated inputs.
+// * [sTx]'s creds authorize it to modify the allow list of [tx.Subnet].
+// * None of [tx.NodeIDs] is already on the allow list of [tx.Subnet].
+// * The flow checker passes.
+func verifyAddSubnetAllowListEntriesTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.AddSubnetAllowListEntriesTx,
+) error {
+	return verifySubnetAllowListTx(
+		backend,
+		chainState,
+		sTx,
+		&tx.BaseTx,
+		tx.Subnet,
+		tx.NodeIDs,
+		tx.SubnetAuth,
+		true, /*=add*/
+	)
+}
+
+// Returns an error if the given tx is invalid.
+// The transaction is valid if:
+// * [sTx]'s creds authorize it to spend the stated inputs.
+// * [sTx]'s creds authorize it to modify the allow list of [tx.Subnet].
+// * All of [tx.NodeIDs] are on the allow list of [tx.Subnet].
+// * The flow checker passes.
+func verifyRemoveSubnetAllowListEntriesTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.RemoveSubnetAllowListEntriesTx,
+) error {
+	return verifySubnetAllowListTx(
+		backend,
+		chainState,
+		sTx,
+		&tx.BaseTx,
+		tx.Subnet,
+		tx.NodeIDs,
+		tx.SubnetAuth,
+		false, /*=add*/
+	)
+}
+
+// Returns the validator to remove, and the tx that added it, if the given tx
+// is valid.
+// The transaction is valid if:
+// * [tx.NodeID] is a current permissionless validator of [tx.Subnet].
+// * [tx.NodeID] isn't staking on a subnet if [tx.Subnet] is the primary network.
+// * [sTx]'s last cred authorizes it with the owner of the validator's stake.
+// * The flow checker passes.
+func verifyExitValidatorTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.ExitValidatorTx,
+) (*state.Staker, txs.ValidatorTx, error) {
+	if !backend.Config.IsExitValidatorActivated(chainState.GetTimestamp()) {
+		return nil, nil, ErrExitValidatorNotActive
+	}
+
+	// Verify the tx is well-formed
+	if err := sTx.SyntacticVerify(backend.Ctx); err != nil {
+		return nil, nil, err
+	}
+
+	if err := avax.VerifyMemoFieldLength(tx.Memo, true /*=isDurangoActive*/); err != nil {
+		return nil, nil, err
+	}
+
+	vdr, err := chainState.GetCurrentValidator(tx.Subnet, tx.NodeID)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"%s %w of %s: %w",
+			tx.NodeID,
+			ErrNotValidator,
+			tx.Subnet,
+			err,
+		)
+	}
+
+	if vdr.Priority.IsPermissionedValidator() {
+		return nil, nil, ErrExitPermissionedValidator
+	}
+
+	vdrTxIntf, _, err := chainState.GetTx(vdr.TxID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch validator tx %s: %w", vdr.TxID, err)
+	}
+	vdrTx, ok := vdrTxIntf.Unsigned.(txs.ValidatorTx)
+	if !ok {
+		return nil, nil, ErrWrongTxType
+	}
+
+	// The primary network validator must outlive every subnet staker of the
+	// node.
+	if tx.Subnet == constants.PrimaryNetworkID {
+		hasSubnetStakers, err := hasSubnetStakers(chainState, tx.NodeID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if hasSubnetStakers {
+			return nil, nil, fmt.Errorf("%w: %s", ErrValidatorHasSubnetStakers, tx.NodeID)
+		}
+	}
+
+	if !backend.Bootstrapped.Get() {
+		// Not bootstrapped yet -- don't need to do full verification.
+		return vdr, vdrTx, nil
+	}
+
+	if len(sTx.Creds) == 0 {
+		// Ensure there is at least one credential for the validator
+		// authorization
+		return nil, nil, errWrongNumberOfCredentials
+	}
+
+	baseTxCredsLen := len(sTx.Creds) - 1
+	validatorCred := sTx.Creds[baseTxCredsLen]
+	stakeOwner, err := txs.StakeOwner(vdrTx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", errUnauthorizedValidatorExit, err)
+	}
+	if err := backend.Fx.VerifyPermission(sTx.Unsigned, tx.ValidatorAuth, validatorCred, stakeOwner); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", errUnauthorizedValidatorExit, err)
+	}
+
+	txFee, err := state.GetParameter(chainState, txs.TxFeeParameter, backend.Config.TxFee)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Verify the flowcheck
+	if err := backend.FlowChecker.VerifySpend(
+		tx,
+		chainState,
+		tx.Ins,
+		tx.Outs,
+		sTx.Creds[:baseTxCredsLen],
+		map[ids.ID]uint64{
+			backend.Ctx.AVAXAssetID: txFee,
+		},
+	); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
+	}
+
+	return vdr, vdrTx, nil
+}
+
+// verifyRekeyValidatorTx carries out the validation for a RekeyValidatorTx.
+// The last credential in [sTx.Creds] is used as the validator authorization,
+// which must be signed by the owner of the validator's stake.
+// It returns the primary network validator that will be moved to
+// [tx.NewNodeID].
+func verifyRekeyValidatorTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.RekeyValidatorTx,
+) (*state.Staker, error) {
+	if !backend.Config.IsRekeyValidatorActivated(chainState.GetTimestamp()) {
+		return nil, ErrRekeyValidatorNotActive
+	}
+
+	// Verify the tx is well-formed
+	if err := sTx.SyntacticVerify(backend.Ctx); err != nil {
+		return nil, err
+	}
+
+	if err := avax.VerifyMemoFieldLength(tx.Memo, true /*=isDurangoActive*/); err != nil {
+		return nil, err
+	}
+
+	vdr, err := chainState.GetCurrentValidator(constants.PrimaryNetworkID, tx.NodeID)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"%s %w of the primary network: %w",
+			tx.NodeID,
+			ErrNotValidator,
+			err,
+		)
+	}
+
+	if vdr.Priority.IsPermissionedValidator() {
+		return nil, ErrRekeyPermissionedValidator
+	}
+
+	vdrTxIntf, _, err := chainState.GetTx(vdr.TxID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch validator tx %s: %w", vdr.TxID, err)
+	}
+	vdrTx, ok := vdrTxIntf.Unsigned.(txs.ValidatorTx)
+	if !ok {
+		return nil, ErrWrongTxType
+	}
+
+	// Subnet stakers are bound to the nodeID they were added with, so they
+	// would be left without a primary network validator.
+	hasSubnetStakers, err := hasSubnetStakers(chainState, tx.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	if hasSubnetStakers {
+		return nil, fmt.Errorf("%w: %s", ErrValidatorHasSubnetStakers, tx.NodeID)
+	}
+
+	// Pending delegators only move to the current set once they start, which
+	// must happen under the nodeID they were added with.
+	pendingDelegatorIterator, err := chainState.GetPendingDelegatorIterator(constants.PrimaryNetworkID, tx.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	hasPendingDelegators := pendingDelegatorIterator.Next()
+	pendingDelegatorIterator.Release()
+	if hasPendingDelegators {
+		return nil, fmt.Errorf("%w: %s", ErrValidatorHasPendingDelegators, tx.NodeID)
+	}
+
+	_, err = GetValidator(chainState, constants.PrimaryNetworkID, tx.NewNodeID)
+	if err == nil {
+		return nil, fmt.Errorf(
+			"%s is %w of the primary network",
+			tx.NewNodeID,
+			ErrAlreadyValidator,
+		)
+	}
+	if err != database.ErrNotFound {
+		return nil, fmt.Errorf(
+			"failed to find whether %s is a primary network validator: %w",
+			tx.NewNodeID,
+			err,
+		)
+	}
+
+	if !backend.Bootstrapped.Get() {
+		// Not bootstrapped yet -- don't need to do full verification.
+		return vdr, nil
+	}
+
+	if len(sTx.Creds) == 0 {
+		// Ensure there is at least one credential for the validator
+		// authorization
+		return nil, errWrongNumberOfCredentials
+	}
+
+	baseTxCredsLen := len(sTx.Creds) - 1
+	validatorCred := sTx.Creds[baseTxCredsLen]
+	stakeOwner, err := txs.StakeOwner(vdrTx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errUnauthorizedValidatorRekey, err)
+	}
+	if err := backend.Fx.VerifyPermission(sTx.Unsigned, tx.ValidatorAuth, validatorCred, stakeOwner); err != nil {
+		return nil, fmt.Errorf("%w: %w", errUnauthorizedValidatorRekey, err)
+	}
+
+	txFee, err := state.GetParameter(chainState, txs.TxFeeParameter, backend.Config.TxFee)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify the flowcheck
+	if err := backend.FlowChecker.VerifySpend(
+		tx,
+		chainState,
+		tx.Ins,
+		tx.Outs,
+		sTx.Creds[:baseTxCredsLen],
+		map[ids.ID]uint64{
+			backend.Ctx.AVAXAssetID: txFee,
+		},
+	); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
+	}
+
+	return vdr, nil
+}
+
+// hasSubnetStakers returns true if [nodeID] is a current or pending staker of
+// any subnet other than the primary network.
+func hasSubnetStakers(chainState state.Chain, nodeID ids.NodeID) (bool, error) {
+	currentStakerIterator, err := chainState.GetCurrentStakerIterator()
+	if err != nil {
+		return false, err
+	}
+	defer currentStakerIterator.Release()
+
+	for currentStakerIterator.Next() {
+		staker := currentStakerIterator.Value()
+		if staker.NodeID == nodeID && staker.SubnetID != constants.PrimaryNetworkID {
+			return true, nil
+		}
+	}
+
+	pendingStakerIterator, err := chainState.GetPendingStakerIterator()
+	if err != nil {
+		return false, err
+	}
+	defer pendingStakerIterator.Release()
+
+	for pendingStakerIterator.Next() {
+		staker := pendingStakerIterator.Value()
+		if staker.NodeID == nodeID && staker.SubnetID != constants.PrimaryNetworkID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// verifySubnetAllowListTx carries out the validation shared by the txs that
+// add [nodeIDs] to, or remove them from, the allow list of [subnetID].
+func verifySubnetAllowListTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.BaseTx,
+	subnetID ids.ID,
+	nodeIDs []ids.NodeID,
+	subnetAuth verify.Verifiable,
+	add bool,
+) error {
+	if !backend.Config.IsSubnetAllowListActivated(chainState.GetTimestamp()) {
+		return ErrSubnetAllowListNotActive
+	}
+
+	// Verify the tx is well-formed
+	if err := sTx.SyntacticVerify(backend.Ctx); err != nil {
+		return err
+	}
+
+	if err := avax.VerifyMemoFieldLength(tx.Memo, true /*=isDurangoActive*/); err != nil {
+		return err
+	}
+
+	if !backend.Bootstrapped.Get() {
+		// Not bootstrapped yet -- don't need to do full verification.
+		return nil
+	}
+
+	for _, nodeID := range nodeIDs {
+		_, err := chainState.GetSubnetAllowListEntry(subnetID, nodeID)
+		if err != nil && err != database.ErrNotFound {
+			return err
+		}
+		allowListed := err == nil
+		switch {
+		case add && allowListed:
+			return fmt.Errorf("%w: %s", ErrAlreadyAllowListed, nodeID)
+		case !add && !allowListed:
+			return fmt.Errorf("%w: %s", ErrNotAllowListed, nodeID)
+		}
+	}
+
+	baseTxCreds, err := verifyPoASubnetAuthorization(backend, chainState, sTx, subnetID, subnetAuth)
+	if err != nil {
+		return err
+	}
+
+	txFee, err := state.GetParameter(chainState, txs.TxFeeParameter, backend.Config.TxFee)
+	if err != nil {
+		return err
+	}
+
+	// Verify the flowcheck
+	if err := backend.FlowChecker.VerifySpend(
+		tx,
+		chainState,
+		tx.Ins,
+		tx.Outs,
+		baseTxCreds,
+		map[ids.ID]uint64{
+			backend.Ctx.AVAXAssetID: txFee,
+		},
+	); err != nil {
+		return fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
+	}
+
+	return nil
+}
+
+// verifyValidatorAllowlisted verifies that [nodeID] may be added as a primary
+// network validator at [timestamp]. Until the open validator set upgrade,
+// validators are restricted to the configured allowlist, if any.
+func verifyValidatorAllowlisted(cfg *config.Config, timestamp time.Time, nodeID ids.NodeID) error {
+	if cfg.ValidatorAllowlist == nil || cfg.IsOpenValidatorSetActivated(timestamp) {
+		return nil
+	}
+	if !cfg.ValidatorAllowlist.Contains(nodeID) {
+		return fmt.Errorf("%w: %s", ErrValidatorNotAllowlisted, nodeID)
+	}
+	return nil
+}
+
 // Ensure the proposed validator starts after the current time
 func verifyStakerStartTime(isDurangoActive bool, chainTime, stakerTime time.Time) error {
 	// Pre Durango activation, start time must be after current chain time.
@@ -851,8 +1385,8 @@ func verifyStakerStartTime(isDurangoActive bool, chainTime, stakerTime time.Time
 }
 
 // For legacy addValidator and addDelegator transactions
-func verifyMinFutureStartTimeOffset(chainTime, stakerStartTime time.Time, minFutureStartTimeOffset time.Duration) error {
-	maxStartTime := chainTime.Add(MaxFutureStartTime)
+func verifyMinFutureStartTimeOffset(cfg *config.Config, chainTime, stakerStartTime time.Time, minFutureStartTimeOffset time.Duration) error {
+	maxStartTime := chainTime.Add(GetMaxFutureStartTime(cfg, chainTime))
 	minStartTime := maxStartTime.Add(-minFutureStartTimeOffset)
 	if stakerStartTime.Before(minStartTime) {
 		return fmt.Errorf(
@@ -864,16 +1398,32 @@ func verifyMinFutureStartTimeOffset(chainTime, stakerStartTime time.Time, minFut
 	return nil
 }
 
-func verifyStakerStartsSoon(isDurangoActive bool, chainTime, stakerStartTime time.Time) error {
+func verifyStakerStartsSoon(cfg *config.Config, isDurangoActive bool, chainTime, stakerStartTime time.Time) error {
 	if isDurangoActive {
 		return nil
 	}
 
 	// Make sure the tx doesn't start too far in the future. This is done last
 	// to allow the verifier visitor to explicitly check for this error.
-	maxStartTime := chainTime.Add(MaxFutureStartTime)
+	maxStartTime := chainTime.Add(GetMaxFutureStartTime(cfg, chainTime))
 	if stakerStartTime.After(maxStartTime) {
-		return ErrFutureStakeTime
+		return fmt.Errorf(
+			"%w: %s > %s",
+			ErrFutureStakeTime,
+			stakerStartTime,
+			maxStartTime,
+		)
 	}
 	return nil
 }
+
+// GetMaxFutureStartTime returns how far ahead of [chainTime] the start time of
+// a staker may be. After the staker start horizon upgrade, the configured
+// horizon tightens the default [MaxFutureStartTime] so that stakers can't
+// bloat the pending staker set with distant start times.
+func GetMaxFutureStartTime(cfg *config.Config, chainTime time.Time) time.Duration {
+	if cfg.StakerStartHorizon > 0 && cfg.IsStakerStartHorizonActivated(chainTime) {
+		return min(cfg.StakerStartHorizon, MaxFutureStartTime)
+	}
+	return MaxFutureStartTime
+}
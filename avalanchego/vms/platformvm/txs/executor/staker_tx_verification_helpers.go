@@ -4,6 +4,7 @@
 package executor
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/ava-labs/avalanchego/database"
@@ -32,7 +33,13 @@ func getValidatorRules(
 	subnetID ids.ID,
 ) (*addValidatorRules, error) {
 	if subnetID == constants.PrimaryNetworkID {
-		return getCurrentValidatorRules(timestamp, backend), nil
+		rules := getCurrentValidatorRules(timestamp, backend)
+		minValidatorStake, err := state.GetParameter(chainState, txs.MinValidatorStakeParameter, rules.minValidatorStake)
+		if err != nil {
+			return nil, err
+		}
+		rules.minValidatorStake = minValidatorStake
+		return rules, nil
 	}
 
 	transformSubnet, err := GetTransformSubnetTx(chainState, subnetID)
@@ -67,7 +74,13 @@ func getDelegatorRules(
 	subnetID ids.ID,
 ) (*addDelegatorRules, error) {
 	if subnetID == constants.PrimaryNetworkID {
-		return getCurrentDelegatorRules(timestamp, backend), nil
+		rules := getCurrentDelegatorRules(timestamp, backend)
+		minDelegatorStake, err := state.GetParameter(chainState, txs.MinDelegatorStakeParameter, rules.minDelegatorStake)
+		if err != nil {
+			return nil, err
+		}
+		rules.minDelegatorStake = minDelegatorStake
+		return rules, nil
 	}
 
 	transformSubnet, err := GetTransformSubnetTx(chainState, subnetID)
@@ -161,6 +174,55 @@ func overDelegated(
 	return newMaxWeight > weightLimit, nil
 }
 
+// GetDelegationCapacity returns the amount of stake that can still be
+// delegated to [validator] between [startTime] and [endTime] without over
+// delegating it.
+// Invariant:
+// - [validator.StartTime] <= [startTime] < [endTime] <= [validator.EndTime]
+func GetDelegationCapacity(
+	backend *Backend,
+	chainState state.Chain,
+	validator *state.Staker,
+	startTime time.Time,
+	endTime time.Time,
+) (uint64, error) {
+	maximumWeight, err := GetMaximumWeight(backend, chainState, validator)
+	if err != nil {
+		return 0, err
+	}
+
+	maxWeight, err := GetMaxWeight(chainState, validator, startTime, endTime)
+	if err != nil {
+		return 0, err
+	}
+	if maxWeight >= maximumWeight {
+		return 0, nil
+	}
+	return maximumWeight - maxWeight, nil
+}
+
+// GetMaximumWeight returns the total weight, including its own weight, that
+// delegations can't make [validator] exceed: its weight multiplied by the max
+// validator weight factor, bounded by the maximum validator stake.
+func GetMaximumWeight(
+	backend *Backend,
+	chainState state.Chain,
+	validator *state.Staker,
+) (uint64, error) {
+	delegatorRules, err := getDelegatorRules(chainState.GetTimestamp(), backend, chainState, validator.SubnetID)
+	if err != nil {
+		return 0, err
+	}
+
+	// If the multiplication overflows, the weight is only bounded by the
+	// maximum validator stake.
+	maximumWeight, err := math.Mul64(uint64(delegatorRules.maxValidatorWeightFactor), validator.Weight)
+	if err != nil {
+		maximumWeight = delegatorRules.maxValidatorStake
+	}
+	return min(maximumWeight, delegatorRules.maxValidatorStake), nil
+}
+
 // GetMaxWeight returns the maximum total weight of the [validator], including
 // its own weight, between [startTime] and [endTime].
 // The weight changes are applied in the order they will be applied as chain
@@ -259,3 +321,113 @@ func GetTransformSubnetTx(chain state.Chain, subnetID ids.ID) (*txs.TransformSub
 
 	return transformSubnet, nil
 }
+
+// verifyDelegatorStakeCap verifies that, if [validator] was added with an
+// AddCappedPermissionlessValidatorTx, adding [delegatorTx] doesn't make the
+// stake delegated to [validator] by the rewards owner of [delegatorTx] exceed
+// the cap of the validator.
+//
+// The cap applies per rewards owner: delegators rewarded to different owners
+// are capped separately, regardless of the addresses that fund their stake.
+func verifyDelegatorStakeCap(
+	chainState state.Chain,
+	validator *state.Staker,
+	delegatorTx txs.DelegatorTx,
+) error {
+	maxDelegatorStake, capped, err := delegatorStakeCap(chainState, validator)
+	if err != nil || !capped {
+		return err
+	}
+
+	ownerID, err := txs.RewardsOwnerID(delegatorTx.RewardsOwner())
+	if err != nil {
+		return err
+	}
+	delegatedStake, err := chainState.GetDelegatedStake(validator.TxID, ownerID)
+	if err != nil {
+		return err
+	}
+	delegatedStake, err = math.Add64(delegatedStake, delegatorTx.Weight())
+	if err != nil {
+		return err
+	}
+
+	if delegatedStake > maxDelegatorStake {
+		return fmt.Errorf(
+			"%w: %d > %d",
+			ErrDelegatorStakeCapExceeded,
+			delegatedStake,
+			maxDelegatorStake,
+		)
+	}
+	return nil
+}
+
+// delegatorStakeCap returns the stake that every rewards owner can delegate to
+// [validator]. False is returned if [validator] wasn't added with an
+// AddCappedPermissionlessValidatorTx.
+func delegatorStakeCap(chainState state.Chain, validator *state.Staker) (uint64, bool, error) {
+	validatorTx, _, err := chainState.GetTx(validator.TxID)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to fetch the validator tx %s: %w", validator.TxID, err)
+	}
+	cappedTx, ok := validatorTx.Unsigned.(*txs.AddCappedPermissionlessValidatorTx)
+	if !ok {
+		return 0, false, nil
+	}
+	return cappedTx.MaxDelegatorStake, true, nil
+}
+
+// addDelegatedStake adds the weight of [delegatorTx] to the stake delegated to
+// [validator] by the rewards owner of [delegatorTx]. The delegated stake is
+// only tracked if [validator] is capped.
+func addDelegatedStake(
+	chainState state.Chain,
+	validator *state.Staker,
+	delegatorTx txs.DelegatorTx,
+) error {
+	_, capped, err := delegatorStakeCap(chainState, validator)
+	if err != nil || !capped {
+		return err
+	}
+
+	ownerID, err := txs.RewardsOwnerID(delegatorTx.RewardsOwner())
+	if err != nil {
+		return err
+	}
+	delegatedStake, err := chainState.GetDelegatedStake(validator.TxID, ownerID)
+	if err != nil {
+		return err
+	}
+	delegatedStake, err = math.Add64(delegatedStake, delegatorTx.Weight())
+	if err != nil {
+		return err
+	}
+	chainState.SetDelegatedStake(validator.TxID, ownerID, delegatedStake)
+	return nil
+}
+
+// removeDelegatedStake subtracts the weight of [delegatorTx] from the stake
+// delegated to the validator added by [validatorTxID] by the rewards owner of
+// [delegatorTx]. As the delegated stake is only tracked for capped validators,
+// nothing is subtracted if no stake is recorded.
+func removeDelegatedStake(
+	chainState state.Chain,
+	validatorTxID ids.ID,
+	delegatorTx txs.DelegatorTx,
+) error {
+	ownerID, err := txs.RewardsOwnerID(delegatorTx.RewardsOwner())
+	if err != nil {
+		return err
+	}
+	delegatedStake, err := chainState.GetDelegatedStake(validatorTxID, ownerID)
+	if err != nil || delegatedStake == 0 {
+		return err
+	}
+	delegatedStake, err = math.Sub(delegatedStake, delegatorTx.Weight())
+	if err != nil {
+		return err
+	}
+	chainState.SetDelegatedStake(validatorTxID, ownerID, delegatedStake)
+	return nil
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/snowtest"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
@@ -625,8 +626,11 @@ func TestGetValidatorRules(t *testing.T) {
 					AVAXAssetID: avaxAssetID,
 				},
 			},
-			chainStateF: func(*gomock.Controller) state.Chain {
-				return nil
+			chainStateF: func(ctrl *gomock.Controller) state.Chain {
+				state := state.NewMockChain(ctrl)
+				state.EXPECT().GetParameterChanges(txs.MinValidatorStakeParameter).Return(nil, nil)
+				state.EXPECT().GetTimestamp().Return(time.Time{})
+				return state
 			},
 			expectedRules: &addValidatorRules{
 				assetID:                  avaxAssetID,
@@ -639,6 +643,35 @@ func TestGetValidatorRules(t *testing.T) {
 				minFutureStartTimeOffset: MaxFutureStartTime,
 			},
 		},
+		{
+			name:     "primary network with governance min stake",
+			subnetID: constants.PrimaryNetworkID,
+			backend: &Backend{
+				Config: config,
+				Ctx: &snow.Context{
+					AVAXAssetID: avaxAssetID,
+				},
+			},
+			chainStateF: func(ctrl *gomock.Controller) state.Chain {
+				chainState := state.NewMockChain(ctrl)
+				chainState.EXPECT().GetParameterChanges(txs.MinValidatorStakeParameter).Return(
+					[]state.ParameterChange{{Value: 1337}},
+					nil,
+				)
+				chainState.EXPECT().GetTimestamp().Return(time.Time{})
+				return chainState
+			},
+			expectedRules: &addValidatorRules{
+				assetID:                  avaxAssetID,
+				minValidatorStake:        1337,
+				maxValidatorStake:        config.MaxValidatorStake,
+				minStakeDuration:         config.MinStakeDuration,
+				maxStakeDuration:         config.MaxStakeDuration,
+				minDelegationFee:         config.MinDelegationFee,
+				minStakeStartTime:        time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC),
+				minFutureStartTimeOffset: MaxFutureStartTime,
+			},
+		},
 		{
 			name:     "can't get subnet transformation",
 			subnetID: subnetID,
@@ -744,8 +777,11 @@ func TestGetDelegatorRules(t *testing.T) {
 					AVAXAssetID: avaxAssetID,
 				},
 			},
-			chainStateF: func(*gomock.Controller) state.Chain {
-				return nil
+			chainStateF: func(ctrl *gomock.Controller) state.Chain {
+				state := state.NewMockChain(ctrl)
+				state.EXPECT().GetParameterChanges(txs.MinDelegatorStakeParameter).Return(nil, nil)
+				state.EXPECT().GetTimestamp().Return(time.Time{})
+				return state
 			},
 			expectedRules: &addDelegatorRules{
 				assetID:                  avaxAssetID,
@@ -832,3 +868,117 @@ func TestGetDelegatorRules(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifyValidatorAllowlisted(t *testing.T) {
+	var (
+		allowlistedNodeID = ids.GenerateTestNodeID()
+		otherNodeID       = ids.GenerateTestNodeID()
+		openTime          = time.Unix(1_000_000, 0)
+	)
+	tests := []struct {
+		name        string
+		allowlist   set.Set[ids.NodeID]
+		timestamp   time.Time
+		nodeID      ids.NodeID
+		expectedErr error
+	}{
+		{
+			name:      "no allowlist",
+			timestamp: openTime.Add(-time.Second),
+			nodeID:    otherNodeID,
+		},
+		{
+			name:      "allowlisted",
+			allowlist: set.Of(allowlistedNodeID),
+			timestamp: openTime.Add(-time.Second),
+			nodeID:    allowlistedNodeID,
+		},
+		{
+			name:        "not allowlisted",
+			allowlist:   set.Of(allowlistedNodeID),
+			timestamp:   openTime.Add(-time.Second),
+			nodeID:      otherNodeID,
+			expectedErr: ErrValidatorNotAllowlisted,
+		},
+		{
+			name:      "open validator set",
+			allowlist: set.Of(allowlistedNodeID),
+			timestamp: openTime,
+			nodeID:    otherNodeID,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := &config.Config{
+				OpenValidatorSetTime: openTime,
+				ValidatorAllowlist:   test.allowlist,
+			}
+			err := verifyValidatorAllowlisted(cfg, test.timestamp, test.nodeID)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestVerifyStakerStartsSoon(t *testing.T) {
+	var (
+		horizonTime = time.Unix(1_000_000, 0)
+		horizon     = 3 * 24 * time.Hour
+	)
+	tests := []struct {
+		name        string
+		horizon     time.Duration
+		chainTime   time.Time
+		startTime   time.Time
+		expectedErr error
+	}{
+		{
+			name:      "before horizon upgrade",
+			horizon:   horizon,
+			chainTime: horizonTime.Add(-time.Second),
+			startTime: horizonTime.Add(-time.Second).Add(MaxFutureStartTime),
+		},
+		{
+			name:        "before horizon upgrade too far",
+			horizon:     horizon,
+			chainTime:   horizonTime.Add(-time.Second),
+			startTime:   horizonTime.Add(MaxFutureStartTime),
+			expectedErr: ErrFutureStakeTime,
+		},
+		{
+			name:      "within horizon",
+			horizon:   horizon,
+			chainTime: horizonTime,
+			startTime: horizonTime.Add(horizon),
+		},
+		{
+			name:        "beyond horizon",
+			horizon:     horizon,
+			chainTime:   horizonTime,
+			startTime:   horizonTime.Add(horizon + time.Second),
+			expectedErr: ErrFutureStakeTime,
+		},
+		{
+			name:      "horizon only tightens",
+			horizon:   2 * MaxFutureStartTime,
+			chainTime: horizonTime,
+			startTime: horizonTime.Add(MaxFutureStartTime),
+		},
+		{
+			name:        "horizon only tightens too far",
+			horizon:     2 * MaxFutureStartTime,
+			chainTime:   horizonTime,
+			startTime:   horizonTime.Add(MaxFutureStartTime + time.Second),
+			expectedErr: ErrFutureStakeTime,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := &config.Config{
+				StakerStartHorizonTime: horizonTime,
+				StakerStartHorizon:     test.horizon,
+			}
+			err := verifyStakerStartsSoon(cfg, false /*=isDurangoActive*/, test.chainTime, test.startTime)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
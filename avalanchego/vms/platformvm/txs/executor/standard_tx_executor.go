@@ -75,9 +75,7 @@ func (e *StandardTxExecutor) CreateChainTx(tx *txs.CreateChainTx) error {
 		tx.Ins,
 		tx.Outs,
 		baseTxCreds,
-		map[ids.ID]uint64{
-			e.Ctx.AVAXAssetID: createBlockchainTxFee,
-		},
+		subnetTxFee(e.Backend, e.State, tx, createBlockchainTxFee),
 	); err != nil {
 		return err
 	}
@@ -194,6 +192,11 @@ func (e *StandardTxExecutor) ImportTx(tx *txs.ImportTx) error {
 		copy(ins, tx.Ins)
 		copy(ins[len(tx.Ins):], tx.ImportedInputs)
 
+		txFee, err := state.GetParameter(e.State, txs.TxFeeParameter, e.Config.TxFee)
+		if err != nil {
+			return err
+		}
+
 		if err := e.FlowChecker.VerifySpendUTXOs(
 			tx,
 			utxos,
@@ -201,7 +204,7 @@ func (e *StandardTxExecutor) ImportTx(tx *txs.ImportTx) error {
 			tx.Outs,
 			e.Tx.Creds,
 			map[ids.ID]uint64{
-				e.Ctx.AVAXAssetID: e.Config.TxFee,
+				e.Ctx.AVAXAssetID: txFee,
 			},
 		); err != nil {
 			return err
@@ -249,6 +252,11 @@ func (e *StandardTxExecutor) ExportTx(tx *txs.ExportTx) error {
 		}
 	}
 
+	txFee, err := state.GetParameter(e.State, txs.TxFeeParameter, e.Config.TxFee)
+	if err != nil {
+		return err
+	}
+
 	// Verify the flowcheck
 	if err := e.FlowChecker.VerifySpend(
 		tx,
@@ -257,7 +265,7 @@ func (e *StandardTxExecutor) ExportTx(tx *txs.ExportTx) error {
 		outs,
 		e.Tx.Creds,
 		map[ids.ID]uint64{
-			e.Ctx.AVAXAssetID: e.Config.TxFee,
+			e.Ctx.AVAXAssetID: txFee,
 		},
 	); err != nil {
 		return fmt.Errorf("failed verifySpend: %w", err)
@@ -411,6 +419,53 @@ func (e *StandardTxExecutor) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidat
 	return nil
 }
 
+// Verifies a [*txs.SetSubnetValidatorWeightTx] and, if it passes, executes it
+// on [e.State]. For verification rules, see
+// [verifySetSubnetValidatorWeightTx]. This transaction will result in the
+// weight of [tx.NodeID] on [tx.Subnet] being replaced by [tx.Weight] without
+// the validator leaving the current validator set.
+func (e *StandardTxExecutor) SetSubnetValidatorWeightTx(tx *txs.SetSubnetValidatorWeightTx) error {
+	staker, err := verifySetSubnetValidatorWeightTx(
+		e.Backend,
+		e.State,
+		e.Tx,
+		tx,
+	)
+	if err != nil {
+		return err
+	}
+
+	// The staker may be shared with parent states, so it must be copied
+	// rather than modified.
+	updatedStaker := *staker
+	updatedStaker.Weight = tx.Weight
+	e.State.UpdateCurrentValidator(&updatedStaker)
+
+	txID := e.Tx.ID()
+	avax.Consume(e.State, tx.Ins)
+	avax.Produce(e.State, txID, tx.Outs)
+	return nil
+}
+
+// Verifies a [*txs.ParameterChangeTx] and, if it passes, executes it on
+// [e.State]. For verification rules, see [verifyParameterChangeTx]. This
+// transaction will result in [tx.Parameter] being set to [tx.Value] once the
+// chain time reaches [tx.ActivationTime].
+func (e *StandardTxExecutor) ParameterChangeTx(tx *txs.ParameterChangeTx) error {
+	if err := verifyParameterChangeTx(e.Backend, e.State, e.Tx, tx); err != nil {
+		return err
+	}
+
+	if err := scheduleParameterChange(e.State, tx); err != nil {
+		return err
+	}
+
+	txID := e.Tx.ID()
+	avax.Consume(e.State, tx.Ins)
+	avax.Produce(e.State, txID, tx.Outs)
+	return nil
+}
+
 func (e *StandardTxExecutor) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
 	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
 		return err
@@ -497,6 +552,27 @@ func (e *StandardTxExecutor) AddPermissionlessValidatorTx(tx *txs.AddPermissionl
 	return nil
 }
 
+// AddCappedPermissionlessValidatorTx is only allowed after the capped
+// delegation activation. It is otherwise executed as an
+// AddPermissionlessValidatorTx, the delegation cap is enforced when verifying
+// the delegators.
+func (e *StandardTxExecutor) AddCappedPermissionlessValidatorTx(tx *txs.AddCappedPermissionlessValidatorTx) error {
+	if !e.Config.IsCappedDelegationActivated(e.State.GetTimestamp()) {
+		return ErrCappedDelegationNotActive
+	}
+	return e.AddPermissionlessValidatorTx(&tx.AddPermissionlessValidatorTx)
+}
+
+// AddPermissionlessValidatorWithMetadataTx is only allowed after the
+// validator metadata activation. It is otherwise executed as an
+// AddPermissionlessValidatorTx, the metadata is only committed to by the tx.
+func (e *StandardTxExecutor) AddPermissionlessValidatorWithMetadataTx(tx *txs.AddPermissionlessValidatorWithMetadataTx) error {
+	if !e.Config.IsValidatorMetadataActivated(e.State.GetTimestamp()) {
+		return ErrValidatorMetadataNotActive
+	}
+	return e.AddPermissionlessValidatorTx(&tx.AddPermissionlessValidatorTx)
+}
+
 func (e *StandardTxExecutor) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
 	if err := verifyAddPermissionlessDelegatorTx(
 		e.Backend,
@@ -540,6 +616,222 @@ func (e *StandardTxExecutor) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwn
 	return nil
 }
 
+// Verifies a [*txs.AddSubnetAllowListEntriesTx] and, if it passes, executes it
+// on [e.State]. For verification rules, see
+// [verifyAddSubnetAllowListEntriesTx]. This transaction will result in
+// [tx.NodeIDs] being added to the allow list of [tx.Subnet].
+func (e *StandardTxExecutor) AddSubnetAllowListEntriesTx(tx *txs.AddSubnetAllowListEntriesTx) error {
+	err := verifyAddSubnetAllowListEntriesTx(
+		e.Backend,
+		e.State,
+		e.Tx,
+		tx,
+	)
+	if err != nil {
+		return err
+	}
+
+	txID := e.Tx.ID()
+	for _, nodeID := range tx.NodeIDs {
+		e.State.SetSubnetAllowListEntry(tx.Subnet, nodeID, txID)
+	}
+
+	avax.Consume(e.State, tx.Ins)
+	avax.Produce(e.State, txID, tx.Outs)
+	return nil
+}
+
+// Verifies a [*txs.RemoveSubnetAllowListEntriesTx] and, if it passes, executes
+// it on [e.State]. For verification rules, see
+// [verifyRemoveSubnetAllowListEntriesTx]. This transaction will result in
+// [tx.NodeIDs] being removed from the allow list of [tx.Subnet].
+func (e *StandardTxExecutor) RemoveSubnetAllowListEntriesTx(tx *txs.RemoveSubnetAllowListEntriesTx) error {
+	err := verifyRemoveSubnetAllowListEntriesTx(
+		e.Backend,
+		e.State,
+		e.Tx,
+		tx,
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, nodeID := range tx.NodeIDs {
+		e.State.SetSubnetAllowListEntry(tx.Subnet, nodeID, ids.Empty)
+	}
+
+	txID := e.Tx.ID()
+	avax.Consume(e.State, tx.Ins)
+	avax.Produce(e.State, txID, tx.Outs)
+	return nil
+}
+
+// Verifies an [*txs.ExitValidatorTx] and, if it passes, executes it on
+// [e.State]. For verification rules, see [verifyExitValidatorTx]. This
+// transaction will result in [tx.NodeID] being removed as a validator of
+// [tx.Subnet] along with all of its delegators, as described in
+// [exitValidator].
+func (e *StandardTxExecutor) ExitValidatorTx(tx *txs.ExitValidatorTx) error {
+	validator, validatorTx, err := verifyExitValidatorTx(
+		e.Backend,
+		e.State,
+		e.Tx,
+		tx,
+	)
+	if err != nil {
+		return err
+	}
+
+	txID := e.Tx.ID()
+	if err := exitValidator(e.Backend, e.State, txID, validator, validatorTx); err != nil {
+		return err
+	}
+
+	avax.Consume(e.State, tx.Ins)
+	avax.Produce(e.State, txID, tx.Outs)
+	return nil
+}
+
+// Verifies a [*txs.RekeyValidatorTx] and, if it passes, executes it on
+// [e.State]. For verification rules, see [verifyRekeyValidatorTx]. This
+// transaction will result in the primary network validator [tx.NodeID], along
+// with its delegators, validating as [tx.NewNodeID] with the BLS key of
+// [tx.Signer] as described in [rekeyValidator].
+func (e *StandardTxExecutor) RekeyValidatorTx(tx *txs.RekeyValidatorTx) error {
+	validator, err := verifyRekeyValidatorTx(
+		e.Backend,
+		e.State,
+		e.Tx,
+		tx,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := rekeyValidator(e.State, validator, tx.NewNodeID, tx.Signer.Key()); err != nil {
+		return err
+	}
+
+	txID := e.Tx.ID()
+	avax.Consume(e.State, tx.Ins)
+	avax.Produce(e.State, txID, tx.Outs)
+	return nil
+}
+
+// Verifies a [*txs.RegisterNameTx] and, if it passes, executes it on [e.State].
+// For verification rules, see [verifyRegisterNameTx]. This transaction will
+// result in [tx.Name] referring to [tx.NodeID] until [tx.Expiry].
+func (e *StandardTxExecutor) RegisterNameTx(tx *txs.RegisterNameTx) error {
+	if err := verifyRegisterNameTx(
+		e.Backend,
+		e.State,
+		e.Tx,
+		tx,
+	); err != nil {
+		return err
+	}
+
+	txID := e.Tx.ID()
+	e.State.SetName(tx.Name, &state.NameRecord{
+		TxID:   txID,
+		NodeID: tx.NodeID,
+		Expiry: tx.Expiry,
+	})
+
+	avax.Consume(e.State, tx.Ins)
+	avax.Produce(e.State, txID, tx.Outs)
+	return nil
+}
+
+// Verifies a [*txs.UpdateNameTx] and, if it passes, executes it on [e.State].
+// For verification rules, see [verifyUpdateNameTx]. This transaction will
+// result in [tx.Name] being owned by [tx.Owner] until [tx.Expiry].
+func (e *StandardTxExecutor) UpdateNameTx(tx *txs.UpdateNameTx) error {
+	record, err := verifyUpdateNameTx(
+		e.Backend,
+		e.State,
+		e.Tx,
+		tx,
+	)
+	if err != nil {
+		return err
+	}
+
+	txID := e.Tx.ID()
+	e.State.SetName(tx.Name, &state.NameRecord{
+		TxID:   txID,
+		NodeID: record.NodeID,
+		Expiry: tx.Expiry,
+	})
+
+	avax.Consume(e.State, tx.Ins)
+	avax.Produce(e.State, txID, tx.Outs)
+	return nil
+}
+
+// Verifies a [*txs.ClaimRewardTx] and, if it passes, executes it on [e.State].
+// For verification rules, see [verifyClaimRewardTx]. This transaction will
+// result in [tx.Amount] of the claimable rewards of [tx.Owner] being paid out
+// to [tx.To].
+func (e *StandardTxExecutor) ClaimRewardTx(tx *txs.ClaimRewardTx) error {
+	ownerID, balance, err := verifyClaimRewardTx(
+		e.Backend,
+		e.State,
+		e.Tx,
+		tx,
+	)
+	if err != nil {
+		return err
+	}
+
+	outIntf, err := e.Fx.CreateOutput(tx.Amount, tx.To)
+	if err != nil {
+		return fmt.Errorf("failed to create output: %w", err)
+	}
+	out, ok := outIntf.(verify.State)
+	if !ok {
+		return ErrInvalidState
+	}
+
+	e.State.SetClaimableReward(ownerID, tx.AssetID, balance-tx.Amount)
+
+	txID := e.Tx.ID()
+	avax.Consume(e.State, tx.Ins)
+	avax.Produce(e.State, txID, tx.Outs)
+	e.State.AddUTXO(&avax.UTXO{
+		UTXOID: avax.UTXOID{
+			TxID:        txID,
+			OutputIndex: uint32(len(tx.Outs)),
+		},
+		Asset: avax.Asset{ID: tx.AssetID},
+		Out:   out,
+	})
+	return nil
+}
+
+// Verifies a [*txs.BindRewardAddressTx] and, if it passes, executes it on
+// [e.State]. For verification rules, see [verifyBindRewardAddressTx]. This
+// transaction will result in the rewards of [tx.Owner] being exported to
+// [tx.Address].
+func (e *StandardTxExecutor) BindRewardAddressTx(tx *txs.BindRewardAddressTx) error {
+	ownerID, err := verifyBindRewardAddressTx(
+		e.Backend,
+		e.State,
+		e.Tx,
+		tx,
+	)
+	if err != nil {
+		return err
+	}
+
+	e.State.SetRewardAddress(ownerID, tx.Address)
+
+	txID := e.Tx.ID()
+	avax.Consume(e.State, tx.Ins)
+	avax.Produce(e.State, txID, tx.Outs)
+	return nil
+}
+
 func (e *StandardTxExecutor) BaseTx(tx *txs.BaseTx) error {
 	if !e.Backend.Config.IsDurangoActivated(e.State.GetTimestamp()) {
 		return ErrDurangoUpgradeNotActive
@@ -554,6 +846,11 @@ func (e *StandardTxExecutor) BaseTx(tx *txs.BaseTx) error {
 		return err
 	}
 
+	txFee, err := state.GetParameter(e.State, txs.TxFeeParameter, e.Config.TxFee)
+	if err != nil {
+		return err
+	}
+
 	// Verify the flowcheck
 	if err := e.FlowChecker.VerifySpend(
 		tx,
@@ -562,7 +859,7 @@ func (e *StandardTxExecutor) BaseTx(tx *txs.BaseTx) error {
 		tx.Outs,
 		e.Tx.Creds,
 		map[ids.ID]uint64{
-			e.Ctx.AVAXAssetID: e.Config.TxFee,
+			e.Ctx.AVAXAssetID: txFee,
 		},
 	); err != nil {
 		return err
@@ -629,6 +926,16 @@ func (e *StandardTxExecutor) putStaker(stakerTx txs.Staker) error {
 		return err
 	}
 
+	if delegatorTx, ok := stakerTx.(txs.DelegatorTx); ok {
+		validator, err := GetValidator(e.State, staker.SubnetID, staker.NodeID)
+		if err != nil {
+			return err
+		}
+		if err := addDelegatedStake(e.State, validator, delegatorTx); err != nil {
+			return err
+		}
+	}
+
 	switch priority := staker.Priority; {
 	case priority.IsCurrentValidator():
 		e.State.PutCurrentValidator(staker)
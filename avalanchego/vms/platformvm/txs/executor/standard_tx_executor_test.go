@@ -78,7 +78,7 @@ func TestStandardTxExecutorAddValidatorTxEmptyID(t *testing.T) {
 			ids.EmptyNodeID,
 			ids.GenerateTestShortID(),
 			reward.PercentDenominator,
-			[]*secp256k1.PrivateKey{preFundedKeys[0]},
+			secp256k1fx.NewKeychain(preFundedKeys[0]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -116,7 +116,7 @@ func TestStandardTxExecutorAddDelegator(t *testing.T) {
 			newValidatorID,                       // node ID
 			rewardAddress,                        // Reward Address
 			reward.PercentDenominator,            // Shares
-			[]*secp256k1.PrivateKey{preFundedKeys[0]},
+			secp256k1fx.NewKeychain(preFundedKeys[0]),
 			ids.ShortEmpty,
 			nil,
 		)
@@ -147,7 +147,7 @@ func TestStandardTxExecutorAddDelegator(t *testing.T) {
 			newValidatorID,                       // node ID
 			rewardAddress,                        // Reward Address
 			reward.PercentDenominator,            // Shared
-			[]*secp256k1.PrivateKey{preFundedKeys[0]},
+			secp256k1fx.NewKeychain(preFundedKeys[0]),
 			ids.ShortEmpty,
 			nil,
 		)
@@ -331,7 +331,7 @@ func TestStandardTxExecutorAddDelegator(t *testing.T) {
 				uint64(tt.endTime.Unix()),
 				tt.nodeID,
 				tt.rewardAddress,
-				tt.feeKeys,
+				secp256k1fx.NewKeychain(tt.feeKeys...),
 				ids.ShortEmpty,
 				nil,
 			)
@@ -376,7 +376,7 @@ func TestApricotStandardTxExecutorAddSubnetValidator(t *testing.T) {
 			uint64(defaultValidateEndTime.Unix())+1,
 			nodeID,
 			testSubnet1.ID(),
-			[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+			secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -405,7 +405,7 @@ func TestApricotStandardTxExecutorAddSubnetValidator(t *testing.T) {
 			uint64(defaultValidateEndTime.Unix()),
 			nodeID,
 			testSubnet1.ID(),
-			[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+			secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -435,7 +435,7 @@ func TestApricotStandardTxExecutorAddSubnetValidator(t *testing.T) {
 		pendingDSValidatorID,         // node ID
 		ids.GenerateTestShortID(),    // reward address
 		reward.PercentDenominator,    // shares
-		[]*secp256k1.PrivateKey{preFundedKeys[0]},
+		secp256k1fx.NewKeychain(preFundedKeys[0]),
 		ids.ShortEmpty,
 		nil,
 	)
@@ -449,7 +449,7 @@ func TestApricotStandardTxExecutorAddSubnetValidator(t *testing.T) {
 			uint64(dsEndTime.Unix()),
 			pendingDSValidatorID,
 			testSubnet1.ID(),
-			[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+			secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -493,7 +493,7 @@ func TestApricotStandardTxExecutorAddSubnetValidator(t *testing.T) {
 			uint64(dsEndTime.Unix()),
 			pendingDSValidatorID,
 			testSubnet1.ID(),
-			[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+			secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -520,7 +520,7 @@ func TestApricotStandardTxExecutorAddSubnetValidator(t *testing.T) {
 			uint64(dsEndTime.Unix())+1, // stop validating subnet after stopping validating primary network
 			pendingDSValidatorID,
 			testSubnet1.ID(),
-			[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+			secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -547,7 +547,7 @@ func TestApricotStandardTxExecutorAddSubnetValidator(t *testing.T) {
 			uint64(dsEndTime.Unix()),   // same end time as for primary network
 			pendingDSValidatorID,
 			testSubnet1.ID(),
-			[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+			secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -575,7 +575,7 @@ func TestApricotStandardTxExecutorAddSubnetValidator(t *testing.T) {
 			uint64(newTimestamp.Add(defaultMinStakingDuration).Unix()), // end time
 			nodeID,           // node ID
 			testSubnet1.ID(), // subnet ID
-			[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+			secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -604,7 +604,7 @@ func TestApricotStandardTxExecutorAddSubnetValidator(t *testing.T) {
 		uint64(defaultValidateEndTime.Unix()),   // end time
 		nodeID,                                  // node ID
 		testSubnet1.ID(),                        // subnet ID
-		[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
 		ids.ShortEmpty,
 		nil,
 	)
@@ -633,7 +633,7 @@ func TestApricotStandardTxExecutorAddSubnetValidator(t *testing.T) {
 			uint64(defaultValidateEndTime.Unix()), // end time
 			nodeID,                                // node ID
 			testSubnet1.ID(),                      // subnet ID
-			[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+			secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -664,7 +664,7 @@ func TestApricotStandardTxExecutorAddSubnetValidator(t *testing.T) {
 			uint64(startTime.Add(defaultMinStakingDuration).Unix())+1, // end time
 			nodeID,           // node ID
 			testSubnet1.ID(), // subnet ID
-			[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1], testSubnet1ControlKeys[2]},
+			secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1], testSubnet1ControlKeys[2]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -698,7 +698,7 @@ func TestApricotStandardTxExecutorAddSubnetValidator(t *testing.T) {
 			uint64(startTime.Add(defaultMinStakingDuration).Unix()), // end time
 			nodeID,           // node ID
 			testSubnet1.ID(), // subnet ID
-			[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[2]},
+			secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[2]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -732,7 +732,7 @@ func TestApricotStandardTxExecutorAddSubnetValidator(t *testing.T) {
 			uint64(startTime.Add(defaultMinStakingDuration).Unix()), // end time
 			nodeID,           // node ID
 			testSubnet1.ID(), // subnet ID
-			[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], preFundedKeys[1]},
+			secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], preFundedKeys[1]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -765,7 +765,7 @@ func TestApricotStandardTxExecutorAddSubnetValidator(t *testing.T) {
 			uint64(startTime.Add(defaultMinStakingDuration).Unix())+1, // end time
 			nodeID,           // node ID
 			testSubnet1.ID(), // subnet ID
-			[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+			secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -815,7 +815,7 @@ func TestBanffStandardTxExecutorAddValidator(t *testing.T) {
 			nodeID,
 			ids.ShortEmpty,
 			reward.PercentDenominator,
-			[]*secp256k1.PrivateKey{preFundedKeys[0]},
+			secp256k1fx.NewKeychain(preFundedKeys[0]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -842,7 +842,7 @@ func TestBanffStandardTxExecutorAddValidator(t *testing.T) {
 			nodeID,
 			ids.ShortEmpty,
 			reward.PercentDenominator,
-			[]*secp256k1.PrivateKey{preFundedKeys[0]},
+			secp256k1fx.NewKeychain(preFundedKeys[0]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -870,7 +870,7 @@ func TestBanffStandardTxExecutorAddValidator(t *testing.T) {
 			nodeID,
 			ids.ShortEmpty,
 			reward.PercentDenominator, // shares
-			[]*secp256k1.PrivateKey{preFundedKeys[0]},
+			secp256k1fx.NewKeychain(preFundedKeys[0]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -910,7 +910,7 @@ func TestBanffStandardTxExecutorAddValidator(t *testing.T) {
 			nodeID,
 			ids.ShortEmpty,
 			reward.PercentDenominator, // shares
-			[]*secp256k1.PrivateKey{preFundedKeys[0]},
+			secp256k1fx.NewKeychain(preFundedKeys[0]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -947,7 +947,7 @@ func TestBanffStandardTxExecutorAddValidator(t *testing.T) {
 			nodeID,
 			ids.ShortEmpty,
 			reward.PercentDenominator,
-			[]*secp256k1.PrivateKey{preFundedKeys[0]},
+			secp256k1fx.NewKeychain(preFundedKeys[0]),
 			ids.ShortEmpty, // change addr
 			nil,
 		)
@@ -999,7 +999,7 @@ func TestDurangoDisabledTransactions(t *testing.T) {
 					nodeID,
 					ids.ShortEmpty,            // reward address,
 					reward.PercentDenominator, // shares
-					preFundedKeys,
+					secp256k1fx.NewKeychain(preFundedKeys...),
 					ids.ShortEmpty, // change address
 					nil,            // memo
 				)
@@ -1031,7 +1031,7 @@ func TestDurangoDisabledTransactions(t *testing.T) {
 					uint64(primaryValidator.EndTime.Unix()),
 					primaryValidator.NodeID,
 					ids.ShortEmpty, // reward address,
-					preFundedKeys,
+					secp256k1fx.NewKeychain(preFundedKeys...),
 					ids.ShortEmpty, // change address
 					nil,            // memo
 				)
@@ -1096,7 +1096,7 @@ func TestDurangoMemoField(t *testing.T) {
 					uint64(primaryValidator.EndTime.Unix()),
 					primaryValidator.NodeID,
 					testSubnet1.TxID,
-					preFundedKeys,
+					secp256k1fx.NewKeychain(preFundedKeys...),
 					ids.ShortEmpty,
 					memoField,
 				)
@@ -1116,7 +1116,7 @@ func TestDurangoMemoField(t *testing.T) {
 					ids.GenerateTestID(), // vmID
 					[]ids.ID{},           // fxIDs
 					"aaa",                // chain name
-					preFundedKeys,
+					secp256k1fx.NewKeychain(preFundedKeys...),
 					ids.ShortEmpty,
 					memoField,
 				)
@@ -1134,7 +1134,7 @@ func TestDurangoMemoField(t *testing.T) {
 				tx, err := env.txBuilder.NewCreateSubnetTx(
 					1,
 					[]ids.ShortID{ids.GenerateTestShortID()},
-					preFundedKeys,
+					secp256k1fx.NewKeychain(preFundedKeys...),
 					ids.ShortEmpty,
 					memoField,
 				)
@@ -1172,7 +1172,7 @@ func TestDurangoMemoField(t *testing.T) {
 				tx, err := env.txBuilder.NewImportTx(
 					sourceChain,
 					sourceKey.PublicKey().Address(),
-					preFundedKeys,
+					secp256k1fx.NewKeychain(preFundedKeys...),
 					ids.ShortEmpty, // change address
 					memoField,
 				)
@@ -1191,7 +1191,7 @@ func TestDurangoMemoField(t *testing.T) {
 					units.Avax,                // amount
 					env.ctx.XChainID,          // destination chain
 					ids.GenerateTestShortID(), // destination address
-					preFundedKeys,
+					secp256k1fx.NewKeychain(preFundedKeys...),
 					ids.ShortEmpty, // change address
 					memoField,
 				)
@@ -1226,7 +1226,7 @@ func TestDurangoMemoField(t *testing.T) {
 					uint64(endTime.Unix()),
 					primaryValidator.NodeID,
 					testSubnet1.ID(),
-					[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+					secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
 					ids.ShortEmpty,
 					nil,
 				)
@@ -1244,7 +1244,7 @@ func TestDurangoMemoField(t *testing.T) {
 				tx, err := env.txBuilder.NewRemoveSubnetValidatorTx(
 					primaryValidator.NodeID,
 					testSubnet1.ID(),
-					preFundedKeys,
+					secp256k1fx.NewKeychain(preFundedKeys...),
 					ids.ShortEmpty,
 					memoField,
 				)
@@ -1271,7 +1271,7 @@ func TestDurangoMemoField(t *testing.T) {
 					10,                        // min delegator stake
 					1,                         // max validator weight factor
 					80,                        // uptime requirement
-					preFundedKeys,
+					secp256k1fx.NewKeychain(preFundedKeys...),
 					ids.ShortEmpty, // change address
 					memoField,
 				)
@@ -1302,7 +1302,7 @@ func TestDurangoMemoField(t *testing.T) {
 					signer.NewProofOfPossession(sk),
 					ids.ShortEmpty,            // reward address
 					reward.PercentDenominator, // shares
-					preFundedKeys,
+					secp256k1fx.NewKeychain(preFundedKeys...),
 					ids.ShortEmpty, // change address
 					memoField,
 				)
@@ -1336,7 +1336,7 @@ func TestDurangoMemoField(t *testing.T) {
 					uint64(primaryValidator.EndTime.Unix()),
 					primaryValidator.NodeID,
 					ids.ShortEmpty, // reward address
-					preFundedKeys,
+					secp256k1fx.NewKeychain(preFundedKeys...),
 					ids.ShortEmpty, // change address
 					memoField,
 				)
@@ -1355,7 +1355,7 @@ func TestDurangoMemoField(t *testing.T) {
 					testSubnet1.TxID,
 					1,
 					[]ids.ShortID{ids.ShortEmpty},
-					preFundedKeys,
+					secp256k1fx.NewKeychain(preFundedKeys...),
 					ids.ShortEmpty, // change address
 					memoField,
 				)
@@ -1376,7 +1376,7 @@ func TestDurangoMemoField(t *testing.T) {
 						Threshold: 1,
 						Addrs:     []ids.ShortID{ids.ShortEmpty},
 					},
-					preFundedKeys,
+					secp256k1fx.NewKeychain(preFundedKeys...),
 					ids.ShortEmpty,
 					memoField,
 				)
@@ -1534,6 +1534,7 @@ func TestStandardExecutorRemoveSubnetValidatorTx(t *testing.T) {
 				subnetOwner := fx.NewMockOwner(ctrl)
 				env.state.EXPECT().GetSubnetOwner(env.unsignedTx.Subnet).Return(subnetOwner, nil).Times(1)
 				env.fx.EXPECT().VerifyPermission(env.unsignedTx, env.unsignedTx.SubnetAuth, env.tx.Creds[len(env.tx.Creds)-1], subnetOwner).Return(nil).Times(1)
+				env.state.EXPECT().GetParameterChanges(txs.TxFeeParameter).Return(nil, nil).Times(1)
 				env.flowChecker.EXPECT().VerifySpend(
 					env.unsignedTx, env.state, env.unsignedTx.Ins, env.unsignedTx.Outs, env.tx.Creds[:len(env.tx.Creds)-1], gomock.Any(),
 				).Return(nil).Times(1)
@@ -1738,11 +1739,12 @@ func TestStandardExecutorRemoveSubnetValidatorTx(t *testing.T) {
 			newExecutor: func(ctrl *gomock.Controller) (*txs.RemoveSubnetValidatorTx, *StandardTxExecutor) {
 				env := newValidRemoveSubnetValidatorTxVerifyEnv(t, ctrl)
 				env.state = state.NewMockDiff(ctrl)
-				env.state.EXPECT().GetTimestamp().Return(env.latestForkTime)
+				env.state.EXPECT().GetTimestamp().Return(env.latestForkTime).Times(2)
 				env.state.EXPECT().GetCurrentValidator(env.unsignedTx.Subnet, env.unsignedTx.NodeID).Return(env.staker, nil)
 				subnetOwner := fx.NewMockOwner(ctrl)
 				env.state.EXPECT().GetSubnetOwner(env.unsignedTx.Subnet).Return(subnetOwner, nil)
 				env.fx.EXPECT().VerifyPermission(gomock.Any(), env.unsignedTx.SubnetAuth, env.tx.Creds[len(env.tx.Creds)-1], subnetOwner).Return(nil)
+				env.state.EXPECT().GetParameterChanges(txs.TxFeeParameter).Return(nil, nil)
 				env.flowChecker.EXPECT().VerifySpend(
 					gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
 				).Return(errTest)
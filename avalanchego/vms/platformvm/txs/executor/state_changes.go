@@ -103,7 +103,7 @@ func AdvanceTimeTo(
 		return false, err
 	}
 
-	pendingStakerIterator, err := parentState.GetPendingStakerIterator()
+	pendingStakerIterator, err := parentState.GetPendingStakerIteratorUntil(newChainTime)
 	if err != nil {
 		return false, err
 	}
@@ -113,9 +113,6 @@ func AdvanceTimeTo(
 	// Promote any pending stakers to current if [StartTime] <= [newChainTime].
 	for pendingStakerIterator.Next() {
 		stakerToRemove := pendingStakerIterator.Value()
-		if stakerToRemove.StartTime.After(newChainTime) {
-			break
-		}
 
 		stakerToAdd := *stakerToRemove
 		stakerToAdd.NextTime = stakerToRemove.EndTime
@@ -0,0 +1,133 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestSubnetAllowListAuthorization(t *testing.T) {
+	require := require.New(t)
+	env := newEnvironment(t, durango)
+	env.ctx.Lock.Lock()
+	defer env.ctx.Lock.Unlock()
+
+	var (
+		nodeID     = genesisNodeIDs[0]
+		maxWeight  = uint64(10)
+		nodeKey    = preFundedKeys[3]
+		thirdParty = preFundedKeys[4]
+		startTime  = defaultValidateStartTime.Add(time.Second)
+		endTime    = startTime.Add(defaultMinStakingDuration)
+	)
+
+	execute := func(tx *txs.Tx) (state.Diff, error) {
+		onAcceptState, err := state.NewDiff(lastAcceptedID, env)
+		require.NoError(err)
+		return onAcceptState, tx.Unsigned.Visit(&StandardTxExecutor{
+			Backend: &env.backend,
+			State:   onAcceptState,
+			Tx:      tx,
+		})
+	}
+
+	entriesTx, err := env.txBuilder.NewAddSubnetAllowListEntriesTx(
+		testSubnet1.ID(),
+		[]ids.NodeID{nodeID},
+		maxWeight,
+		&secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{nodeKey.Address()},
+		},
+		secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
+		ids.ShortEmpty, // change addr
+		nil,
+	)
+	require.NoError(err)
+	// The allow lists can't be modified before the upgrade activates
+	env.config.SubnetAllowListTime = time.Time{}
+	_, err = execute(entriesTx)
+	require.ErrorIs(err, ErrSubnetAllowListNotActive)
+	env.config.SubnetAllowListTime = env.config.DurangoTime
+
+	onAcceptState, err := execute(entriesTx)
+	require.NoError(err)
+	require.NoError(onAcceptState.Apply(env.state))
+	env.state.AddTx(entriesTx, status.Committed)
+	env.state.SetHeight(1)
+	require.NoError(env.state.Commit())
+
+	gotTxID, err := env.state.GetSubnetAllowListEntry(testSubnet1.ID(), nodeID)
+	require.NoError(err)
+	require.Equal(entriesTx.ID(), gotTxID)
+
+	newAddValidatorTx := func(weight uint64, kc *secp256k1fx.Keychain) *txs.Tx {
+		tx, err := env.txBuilder.NewAddSubnetValidatorTx(
+			weight,
+			uint64(startTime.Unix()),
+			uint64(endTime.Unix()),
+			nodeID,
+			testSubnet1.ID(),
+			kc,
+			ids.ShortEmpty, // change addr
+			nil,
+		)
+		require.NoError(err)
+		return tx
+	}
+
+	{
+		// Case: A third party can't add the allow listed node
+		tx := newAddValidatorTx(maxWeight, secp256k1fx.NewKeychain(nodeKey))
+
+		sig, err := thirdParty.SignHash(hashing.ComputeHash256(tx.Unsigned.Bytes()))
+		require.NoError(err)
+		copy(tx.Creds[len(tx.Creds)-1].(*secp256k1fx.Credential).Sigs[0][:], sig)
+
+		_, err = execute(tx)
+		require.ErrorIs(err, errUnauthorizedSubnetModification)
+	}
+
+	{
+		// Case: The node owner can't exceed the max weight of the entry
+		tx := newAddValidatorTx(maxWeight, secp256k1fx.NewKeychain(nodeKey))
+		utx := tx.Unsigned.(*txs.AddSubnetValidatorTx)
+		utx.Wght = maxWeight + 1
+		signers := make([][]*secp256k1.PrivateKey, len(tx.Creds))
+		for i := range signers {
+			signers[i] = []*secp256k1.PrivateKey{nodeKey}
+		}
+		tx, err := txs.NewSigned(utx, txs.Codec, signers)
+		require.NoError(err)
+
+		_, err = execute(tx)
+		require.ErrorIs(err, errUnauthorizedSubnetModification)
+	}
+
+	{
+		// Case: The node owner can add the node with at most the max weight
+		_, err := execute(newAddValidatorTx(maxWeight, secp256k1fx.NewKeychain(nodeKey)))
+		require.NoError(err)
+	}
+
+	{
+		// Case: The subnet owner can add the node with any weight
+		_, err := execute(newAddValidatorTx(
+			maxWeight+1,
+			secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
+		))
+		require.NoError(err)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// SubnetFeeTreasuryOutputIndex is the output index of the UTXO that sweeps the
+// fee paid in the fee asset of a subnet to the treasury of the subnet.
+const SubnetFeeTreasuryOutputIndex = FeeTreasuryOutputIndex - 1
+
+// subnetFeeAsset returns the fee asset of the subnet that [utx] modifies, if
+// [utx] may pay its fee with one at [timestamp].
+func subnetFeeAsset(cfg *config.Config, timestamp time.Time, utx txs.UnsignedTx) (config.SubnetFeeAsset, uint64, bool) {
+	if !cfg.IsSubnetFeeAssetActivated(timestamp) {
+		return config.SubnetFeeAsset{}, 0, false
+	}
+
+	var (
+		subnetID ids.ID
+		fee      func(config.SubnetFeeAsset) uint64
+	)
+	switch utx := utx.(type) {
+	case *txs.AddSubnetValidatorTx:
+		subnetID = utx.SubnetValidator.Subnet
+		fee = func(a config.SubnetFeeAsset) uint64 { return a.AddSubnetValidatorFee }
+	case *txs.CreateChainTx:
+		subnetID = utx.SubnetID
+		fee = func(a config.SubnetFeeAsset) uint64 { return a.CreateBlockchainTxFee }
+	default:
+		return config.SubnetFeeAsset{}, 0, false
+	}
+
+	feeAsset, ok := cfg.SubnetFeeAssets[subnetID]
+	if !ok {
+		return config.SubnetFeeAsset{}, 0, false
+	}
+	return feeAsset, fee(feeAsset), true
+}
+
+// paysSubnetFee returns the fee asset of the subnet of [utx] and the fee owed
+// in it, if [utx] pays its fee at [timestamp] with the fee asset rather than
+// with AVAX. A tx pays with the fee asset if it burns any of it.
+func paysSubnetFee(cfg *config.Config, timestamp time.Time, utx txs.UnsignedTx) (config.SubnetFeeAsset, uint64, bool) {
+	feeAsset, fee, ok := subnetFeeAsset(cfg, timestamp, utx)
+	if !ok {
+		return config.SubnetFeeAsset{}, 0, false
+	}
+	// Txs that produce more than they consume fail their flow check, whatever
+	// the asset they pay with.
+	burned, err := txs.Burned(utx, feeAsset.AssetID)
+	if err != nil || burned == 0 {
+		return config.SubnetFeeAsset{}, 0, false
+	}
+	return feeAsset, fee, true
+}
+
+// subnetTxFee returns the fee that [utx] must burn, which is [avaxFee] AVAX
+// unless [utx] pays with the fee asset of its subnet on [chainState].
+func subnetTxFee(backend *Backend, chainState state.Chain, utx txs.UnsignedTx, avaxFee uint64) map[ids.ID]uint64 {
+	if feeAsset, fee, ok := paysSubnetFee(backend.Config, chainState.GetTimestamp(), utx); ok {
+		return map[ids.ID]uint64{
+			feeAsset.AssetID: fee,
+		}
+	}
+	return map[ids.ID]uint64{
+		backend.Ctx.AVAXAssetID: avaxFee,
+	}
+}
+
+// SweepSubnetFees sends the fee that [tx] paid with the fee asset of its
+// subnet to the treasury of the subnet. The fee stays burned if the subnet
+// has no treasury.
+//
+// Invariant: [tx] must have been executed on [chainState].
+func SweepSubnetFees(backend *Backend, chainState state.Chain, tx *txs.Tx) error {
+	feeAsset, _, ok := paysSubnetFee(backend.Config, chainState.GetTimestamp(), tx.Unsigned)
+	if !ok || feeAsset.Treasury == ids.ShortEmpty {
+		return nil
+	}
+
+	burned, err := txs.Burned(tx.Unsigned, feeAsset.AssetID)
+	if err != nil {
+		return err
+	}
+	chainState.AddUTXO(&avax.UTXO{
+		UTXOID: avax.UTXOID{
+			TxID:        tx.ID(),
+			OutputIndex: SubnetFeeTreasuryOutputIndex,
+		},
+		Asset: avax.Asset{ID: feeAsset.AssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: burned,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{feeAsset.Treasury},
+			},
+		},
+	})
+	return nil
+}
@@ -0,0 +1,167 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/utxo"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestCreateChainTxSubnetFeeAsset(t *testing.T) {
+	const (
+		fee     = 10
+		balance = 15
+	)
+	tests := []struct {
+		name          string
+		notActivated  bool
+		treasury      ids.ShortID
+		burned        uint64
+		expectedError error
+	}{
+		{
+			name:          "not activated",
+			notActivated:  true,
+			burned:        fee,
+			expectedError: utxo.ErrInsufficientUnlockedFunds,
+		},
+		{
+			name:          "insufficient fee",
+			burned:        fee - 1,
+			expectedError: utxo.ErrInsufficientUnlockedFunds,
+		},
+		{
+			name:   "burned",
+			burned: fee,
+		},
+		{
+			name:     "swept to treasury",
+			treasury: ids.GenerateTestShortID(),
+			burned:   fee + 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			env := newEnvironment(t, banff)
+			// The tx must burn AVAX if it can't pay with the fee asset
+			env.config.CreateAssetTxFee = defaultTxFee
+
+			feeAssetID := ids.GenerateTestID()
+			env.config.SubnetFeeAssets = map[ids.ID]config.SubnetFeeAsset{
+				testSubnet1.ID(): {
+					AssetID:               feeAssetID,
+					CreateBlockchainTxFee: fee,
+					Treasury:              test.treasury,
+				},
+			}
+
+			stateDiff, err := state.NewDiff(lastAcceptedID, env)
+			require.NoError(err)
+
+			env.config.SubnetFeeAssetTime = stateDiff.GetTimestamp()
+			if test.notActivated {
+				env.config.SubnetFeeAssetTime = env.config.SubnetFeeAssetTime.Add(time.Second)
+			}
+
+			// Fund the tx with the fee asset only
+			owner := secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{preFundedKeys[0].Address()},
+			}
+			feeUTXO := &avax.UTXO{
+				UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+				Asset:  avax.Asset{ID: feeAssetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt:          balance,
+					OutputOwners: owner,
+				},
+			}
+			stateDiff.AddUTXO(feeUTXO)
+
+			subnetAuth, subnetSigners, err := env.utxosHandler.Authorize(stateDiff, testSubnet1.ID(), secp256k1fx.NewKeychain(preFundedKeys...))
+			require.NoError(err)
+
+			utx := &txs.CreateChainTx{
+				BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+					NetworkID:    env.ctx.NetworkID,
+					BlockchainID: env.ctx.ChainID,
+					Ins: []*avax.TransferableInput{{
+						UTXOID: feeUTXO.UTXOID,
+						Asset:  feeUTXO.Asset,
+						In: &secp256k1fx.TransferInput{
+							Amt:   balance,
+							Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+						},
+					}},
+					Outs: []*avax.TransferableOutput{{
+						Asset: feeUTXO.Asset,
+						Out: &secp256k1fx.TransferOutput{
+							Amt:          balance - test.burned,
+							OutputOwners: owner,
+						},
+					}},
+				}},
+				SubnetID:   testSubnet1.ID(),
+				VMID:       constants.AVMID,
+				SubnetAuth: subnetAuth,
+			}
+			tx := &txs.Tx{Unsigned: utx}
+			require.NoError(tx.SignWith(txs.Codec, [][]keychain.Signer{
+				{preFundedKeys[0]},
+				subnetSigners,
+			}))
+
+			assetID, expectedFee, err := TxFee(env.config, env.ctx.AVAXAssetID, stateDiff, utx)
+			require.NoError(err)
+			if test.notActivated {
+				require.Equal(env.ctx.AVAXAssetID, assetID)
+				require.Equal(env.config.GetCreateBlockchainTxFee(stateDiff.GetTimestamp()), expectedFee)
+			} else {
+				require.Equal(feeAssetID, assetID)
+				require.Equal(uint64(fee), expectedFee)
+			}
+
+			executor := StandardTxExecutor{
+				Backend: &env.backend,
+				State:   stateDiff,
+				Tx:      tx,
+			}
+			err = tx.Unsigned.Visit(&executor)
+			require.ErrorIs(err, test.expectedError)
+			if test.expectedError != nil {
+				return
+			}
+			require.NoError(SweepSubnetFees(&env.backend, stateDiff, tx))
+
+			treasuryUTXOID := avax.UTXOID{
+				TxID:        tx.ID(),
+				OutputIndex: SubnetFeeTreasuryOutputIndex,
+			}
+			treasuryUTXO, err := stateDiff.GetUTXO(treasuryUTXOID.InputID())
+			if test.treasury == ids.ShortEmpty {
+				require.ErrorIs(err, database.ErrNotFound)
+				return
+			}
+			require.NoError(err)
+			require.Equal(feeAssetID, treasuryUTXO.AssetID())
+			out := treasuryUTXO.Out.(*secp256k1fx.TransferOutput)
+			require.Equal(test.burned, out.Amt)
+			require.Equal([]ids.ShortID{test.treasury}, out.Addrs)
+		})
+	}
+}
@@ -18,6 +18,7 @@ var (
 	errWrongNumberOfCredentials       = errors.New("should have the same number of credentials as inputs")
 	errIsImmutable                    = errors.New("is immutable")
 	errUnauthorizedSubnetModification = errors.New("unauthorized subnet modification")
+	errUnexpectedAllowListTxType      = errors.New("unexpected allow list tx type")
 )
 
 // verifyPoASubnetAuthorization carries out the validation for modifying a PoA
@@ -34,16 +35,69 @@ func verifyPoASubnetAuthorization(
 	if err != nil {
 		return nil, err
 	}
+	return creds, verifyPoASubnet(chainState, subnetID)
+}
 
-	_, err = chainState.GetSubnetTransformation(subnetID)
-	if err == nil {
-		return nil, fmt.Errorf("%q %w", subnetID, errIsImmutable)
+// verifySubnetValidatorAuthorization carries out the validation for adding
+// [nodeID] as a validator of a PoA subnet with [weight]. If [nodeID] is on the
+// allow list of [subnetID] and [weight] doesn't exceed the max weight of its
+// entry, the last credential in [sTx.Creds] may authorize the tx with the node
+// owner of the entry. Otherwise, it must authorize the tx with the subnet
+// owner.
+func verifySubnetValidatorAuthorization(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	subnetID ids.ID,
+	nodeID ids.NodeID,
+	weight uint64,
+	subnetAuth verify.Verifiable,
+) ([]verify.Verifiable, error) {
+	allowListTxID, err := chainState.GetSubnetAllowListEntry(subnetID, nodeID)
+	if err == database.ErrNotFound {
+		return verifyPoASubnetAuthorization(backend, chainState, sTx, subnetID, subnetAuth)
 	}
-	if err != database.ErrNotFound {
+	if err != nil {
 		return nil, err
 	}
 
-	return creds, nil
+	allowListTx, _, err := chainState.GetTx(allowListTxID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch allow list tx %s: %w", allowListTxID, err)
+	}
+	entries, ok := allowListTx.Unsigned.(*txs.AddSubnetAllowListEntriesTx)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", errUnexpectedAllowListTxType, allowListTx.Unsigned)
+	}
+	if weight > entries.MaxWeight {
+		return verifyPoASubnetAuthorization(backend, chainState, sTx, subnetID, subnetAuth)
+	}
+
+	if len(sTx.Creds) == 0 {
+		// Ensure there is a credential for the node authorization
+		return nil, errWrongNumberOfCredentials
+	}
+
+	baseTxCredsLen := len(sTx.Creds) - 1
+	nodeCred := sTx.Creds[baseTxCredsLen]
+	if err := backend.Fx.VerifyPermission(sTx.Unsigned, subnetAuth, nodeCred, entries.NodeOwner); err != nil {
+		// The subnet owner can add any node
+		return verifyPoASubnetAuthorization(backend, chainState, sTx, subnetID, subnetAuth)
+	}
+	return sTx.Creds[:baseTxCredsLen], verifyPoASubnet(chainState, subnetID)
+}
+
+// verifyPoASubnet verifies that [subnetID] hasn't been transformed into a
+// permissionless subnet.
+func verifyPoASubnet(chainState state.Chain, subnetID ids.ID) error {
+	_, err := chainState.GetSubnetTransformation(subnetID)
+	if err == nil {
+		return fmt.Errorf("%q %w", subnetID, errIsImmutable)
+	}
+	if err != database.ErrNotFound {
+		return err
+	}
+	return nil
 }
 
 // verifySubnetAuthorization carries out the validation for modifying a subnet.
@@ -0,0 +1,64 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+// TxFee returns the asset and the amount that [utx] must burn to be executed on
+// [chainState]. It follows the fees charged by the executors, including the
+// fees changed by governance and the fees paid with the fee asset of a subnet.
+func TxFee(cfg *config.Config, avaxAssetID ids.ID, chainState state.Chain, utx txs.UnsignedTx) (ids.ID, uint64, error) {
+	if feeAsset, fee, ok := paysSubnetFee(cfg, chainState.GetTimestamp(), utx); ok {
+		return feeAsset.AssetID, fee, nil
+	}
+	fee, err := avaxTxFee(cfg, chainState, utx)
+	return avaxAssetID, fee, err
+}
+
+func avaxTxFee(cfg *config.Config, chainState state.Chain, utx txs.UnsignedTx) (uint64, error) {
+	switch utx := utx.(type) {
+	case *txs.AdvanceTimeTx, *txs.RewardValidatorTx:
+		return 0, nil
+	case *txs.CreateChainTx:
+		return cfg.GetCreateBlockchainTxFee(chainState.GetTimestamp()), nil
+	case *txs.CreateSubnetTx:
+		return cfg.GetCreateSubnetTxFee(chainState.GetTimestamp()), nil
+	case *txs.TransformSubnetTx:
+		return cfg.TransformSubnetTxFee, nil
+	case *txs.AddSubnetValidatorTx:
+		return cfg.AddSubnetValidatorFee, nil
+	case *txs.AddValidatorTx:
+		return state.GetParameter(chainState, txs.AddPrimaryNetworkValidatorFeeParameter, cfg.AddPrimaryNetworkValidatorFee)
+	case *txs.AddDelegatorTx:
+		return state.GetParameter(chainState, txs.AddPrimaryNetworkDelegatorFeeParameter, cfg.AddPrimaryNetworkDelegatorFee)
+	case *txs.AddPermissionlessValidatorTx:
+		return permissionlessValidatorFee(cfg, chainState, utx)
+	case *txs.AddCappedPermissionlessValidatorTx:
+		return permissionlessValidatorFee(cfg, chainState, &utx.AddPermissionlessValidatorTx)
+	case *txs.AddPermissionlessValidatorWithMetadataTx:
+		return permissionlessValidatorFee(cfg, chainState, &utx.AddPermissionlessValidatorTx)
+	case *txs.RegisterNameTx, *txs.UpdateNameTx:
+		return state.GetParameter(chainState, txs.NameRegistrationFeeParameter, cfg.NameRegistrationFee)
+	case *txs.AddPermissionlessDelegatorTx:
+		if utx.Subnet != constants.PrimaryNetworkID {
+			return cfg.AddSubnetDelegatorFee, nil
+		}
+		return state.GetParameter(chainState, txs.AddPrimaryNetworkDelegatorFeeParameter, cfg.AddPrimaryNetworkDelegatorFee)
+	default:
+		return state.GetParameter(chainState, txs.TxFeeParameter, cfg.TxFee)
+	}
+}
+
+func permissionlessValidatorFee(cfg *config.Config, chainState state.Chain, utx *txs.AddPermissionlessValidatorTx) (uint64, error) {
+	if utx.Subnet != constants.PrimaryNetworkID {
+		return cfg.AddSubnetValidatorFee, nil
+	}
+	return state.GetParameter(chainState, txs.AddPrimaryNetworkValidatorFeeParameter, cfg.AddPrimaryNetworkValidatorFee)
+}
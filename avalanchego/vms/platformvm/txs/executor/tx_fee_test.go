@@ -0,0 +1,76 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+func TestTxFee(t *testing.T) {
+	require := require.New(t)
+	env := newEnvironment(t, durango)
+
+	chainState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+	chainState.SetTimestamp(time.Unix(10, 0))
+	chainState.SetParameterChanges(txs.TxFeeParameter, []state.ParameterChange{
+		{Value: 2 * env.config.TxFee, ActivationTime: 5},
+	})
+
+	subnetID := ids.GenerateTestID()
+	tests := []struct {
+		name        string
+		utx         txs.UnsignedTx
+		expectedFee uint64
+	}{
+		{
+			name:        "base tx",
+			utx:         &txs.BaseTx{},
+			expectedFee: 2 * env.config.TxFee,
+		},
+		{
+			name:        "create subnet tx",
+			utx:         &txs.CreateSubnetTx{},
+			expectedFee: env.config.GetCreateSubnetTxFee(chainState.GetTimestamp()),
+		},
+		{
+			name:        "primary network validator",
+			utx:         &txs.AddPermissionlessValidatorTx{Subnet: constants.PrimaryNetworkID},
+			expectedFee: env.config.AddPrimaryNetworkValidatorFee,
+		},
+		{
+			name: "capped subnet validator",
+			utx: &txs.AddCappedPermissionlessValidatorTx{
+				AddPermissionlessValidatorTx: txs.AddPermissionlessValidatorTx{Subnet: subnetID},
+			},
+			expectedFee: env.config.AddSubnetValidatorFee,
+		},
+		{
+			name:        "subnet delegator",
+			utx:         &txs.AddPermissionlessDelegatorTx{Subnet: subnetID},
+			expectedFee: env.config.AddSubnetDelegatorFee,
+		},
+		{
+			name:        "reward validator tx",
+			utx:         &txs.RewardValidatorTx{},
+			expectedFee: 0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assetID, fee, err := TxFee(env.config, env.ctx.AVAXAssetID, chainState, test.utx)
+			require.NoError(err)
+			require.Equal(env.ctx.AVAXAssetID, assetID)
+			require.Equal(test.expectedFee, fee)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+var _ UnsignedTx = (*ExitValidatorTx)(nil)
+
+// ExitValidatorTx removes a permissionless validator from the current
+// validator set before its end time. The stake of the validator and of all of
+// its delegators is returned, but none of their potential rewards are paid.
+type ExitValidatorTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// ID of the subnet the validator is validating
+	Subnet ids.ID `serialize:"true" json:"subnetID"`
+	// Node ID of the validator
+	NodeID ids.NodeID `serialize:"true" json:"nodeID"`
+	// Proves that the issuer controls the owner of the stake of the validator.
+	ValidatorAuth verify.Verifiable `serialize:"true" json:"validatorAuthorization"`
+}
+
+func (tx *ExitValidatorTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified:
+		// already passed syntactic verification
+		return nil
+	case tx.NodeID == ids.EmptyNodeID:
+		return errEmptyNodeID
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := tx.ValidatorAuth.Verify(); err != nil {
+		return err
+	}
+
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *ExitValidatorTx) Visit(visitor Visitor) error {
+	return visitor.ExitValidatorTx(tx)
+}
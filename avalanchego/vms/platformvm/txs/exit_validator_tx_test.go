@@ -0,0 +1,112 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+var errInvalidValidatorAuth = errors.New("invalid validator auth")
+
+func TestExitValidatorTxSyntacticVerify(t *testing.T) {
+	type test struct {
+		name        string
+		txFunc      func(*gomock.Controller) *ExitValidatorTx
+		expectedErr error
+	}
+
+	var (
+		networkID = uint32(1337)
+		chainID   = ids.GenerateTestID()
+		nodeID    = ids.GenerateTestNodeID()
+	)
+
+	ctx := &snow.Context{
+		ChainID:   chainID,
+		NetworkID: networkID,
+	}
+
+	// A BaseTx that passes syntactic verification.
+	validBaseTx := BaseTx{
+		BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		},
+	}
+
+	tests := []test{
+		{
+			name: "nil tx",
+			txFunc: func(*gomock.Controller) *ExitValidatorTx {
+				return nil
+			},
+			expectedErr: ErrNilTx,
+		},
+		{
+			name: "empty nodeID",
+			txFunc: func(*gomock.Controller) *ExitValidatorTx {
+				return &ExitValidatorTx{
+					BaseTx: validBaseTx,
+					Subnet: constants.PrimaryNetworkID,
+				}
+			},
+			expectedErr: errEmptyNodeID,
+		},
+		{
+			name: "invalid validatorAuth",
+			txFunc: func(ctrl *gomock.Controller) *ExitValidatorTx {
+				// This ValidatorAuth fails verification.
+				invalidValidatorAuth := verify.NewMockVerifiable(ctrl)
+				invalidValidatorAuth.EXPECT().Verify().Return(errInvalidValidatorAuth)
+				return &ExitValidatorTx{
+					BaseTx:        validBaseTx,
+					Subnet:        constants.PrimaryNetworkID,
+					NodeID:        nodeID,
+					ValidatorAuth: invalidValidatorAuth,
+				}
+			},
+			expectedErr: errInvalidValidatorAuth,
+		},
+		{
+			name: "passes verification",
+			txFunc: func(ctrl *gomock.Controller) *ExitValidatorTx {
+				// This ValidatorAuth passes verification.
+				validValidatorAuth := verify.NewMockVerifiable(ctrl)
+				validValidatorAuth.EXPECT().Verify().Return(nil)
+				return &ExitValidatorTx{
+					BaseTx:        validBaseTx,
+					Subnet:        constants.PrimaryNetworkID,
+					NodeID:        nodeID,
+					ValidatorAuth: validValidatorAuth,
+				}
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			tx := tt.txFunc(ctrl)
+			err := tx.SyntacticVerify(ctx)
+			require.ErrorIs(err, tt.expectedErr)
+			if tt.expectedErr != nil {
+				return
+			}
+			require.True(tx.SyntacticallyVerified)
+		})
+	}
+}
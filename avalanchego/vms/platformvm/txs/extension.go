@@ -0,0 +1,178 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/codec/linearcodec"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// FirstExtensionTypeID is the lowest codec type ID that can be assigned to an
+// extension tx. The type IDs below it are reserved for the types registered by
+// this package, so that adding built-in txs never shifts the type IDs of the
+// extension txs.
+const FirstExtensionTypeID = 256
+
+var (
+	ErrTypeIDConflict       = errors.New("type ID conflict")
+	ErrUnsupportedExtension = errors.New("visitor doesn't support extension txs")
+
+	errReservedTypeID    = errors.New("type ID is reserved for built-in txs")
+	errNilExtensionTx    = errors.New("nil extension tx")
+	errTooManyBuiltins   = errors.New("built-in types overflow into the extension type IDs")
+	errExtensionTxNotPtr = errors.New("extension tx must be a pointer to a struct")
+
+	extensionsLock sync.RWMutex
+	// extensions are sorted by type ID
+	extensions []Extension
+)
+
+// Extension is a tx type that is added to the codecs by a downstream package
+// rather than by this package.
+type Extension struct {
+	// TypeID of the tx in the codecs. Must be at least [FirstExtensionTypeID].
+	TypeID uint32
+	// Tx is a value of the registered type, e.g. &MyTx{}
+	Tx UnsignedTx
+}
+
+// Name of the tx type, e.g. "MyTx". Built-in txs are named the same way.
+func (e Extension) Name() string {
+	return reflect.TypeOf(e.Tx).Elem().Name()
+}
+
+// ExtensionVisitor is implemented by the visitors that support extension txs.
+type ExtensionVisitor interface {
+	ExtensionTx(UnsignedTx) error
+}
+
+// RegisterExtension adds the type of [tx] to the codecs built by the following
+// calls to [InitCodec], with the type ID [typeID]. Extensions must be
+// registered before the codecs are initialized by the node, typically from an
+// init function.
+//
+// The Visit method of an extension tx is expected to call [VisitExtension], so
+// that the executors can execute it without knowing its type.
+func RegisterExtension(typeID uint32, tx UnsignedTx) error {
+	if tx == nil {
+		return errNilExtensionTx
+	}
+	txType := reflect.TypeOf(tx)
+	if txType.Kind() != reflect.Pointer || txType.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: %s", errExtensionTxNotPtr, txType)
+	}
+	if typeID < FirstExtensionTypeID {
+		return fmt.Errorf("%w: %d < %d", errReservedTypeID, typeID, FirstExtensionTypeID)
+	}
+
+	extensionsLock.Lock()
+	defer extensionsLock.Unlock()
+
+	for _, e := range extensions {
+		switch {
+		case e.TypeID == typeID:
+			return fmt.Errorf("%w: %d is assigned to both %s and %s",
+				ErrTypeIDConflict,
+				typeID,
+				e.Name(),
+				txType.Elem().Name(),
+			)
+		case reflect.TypeOf(e.Tx) == txType:
+			return fmt.Errorf("%w: %s is registered with both %d and %d",
+				ErrTypeIDConflict,
+				e.Name(),
+				e.TypeID,
+				typeID,
+			)
+		}
+	}
+
+	extensions = append(extensions, Extension{
+		TypeID: typeID,
+		Tx:     tx,
+	})
+	slices.SortFunc(extensions, func(a, b Extension) int {
+		return cmp.Compare(a.TypeID, b.TypeID)
+	})
+	return nil
+}
+
+// Extensions returns the registered extensions, sorted by type ID.
+func Extensions() []Extension {
+	extensionsLock.RLock()
+	defer extensionsLock.RUnlock()
+
+	return slices.Clone(extensions)
+}
+
+// VisitExtension visits the extension tx [tx] with [visitor], if the visitor
+// supports extension txs.
+func VisitExtension(tx UnsignedTx, visitor Visitor) error {
+	v, ok := visitor.(ExtensionVisitor)
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrUnsupportedExtension, visitor)
+	}
+	return v.ExtensionTx(tx)
+}
+
+// RegisterExtensionTypes registers the types of the extension txs. It must be
+// called right after [RegisterDUnsignedTxsTypes].
+func RegisterExtensionTypes(targetCodec linearcodec.Codec) error {
+	nextTypeID, err := numBuiltinTypeIDs()
+	if err != nil {
+		return err
+	}
+	if nextTypeID > FirstExtensionTypeID {
+		return fmt.Errorf("%w: %d > %d", errTooManyBuiltins, nextTypeID, FirstExtensionTypeID)
+	}
+
+	errs := wrappers.Errs{}
+	for _, e := range Extensions() {
+		targetCodec.SkipRegistrations(int(e.TypeID - nextTypeID))
+		errs.Add(targetCodec.RegisterType(e.Tx))
+		nextTypeID = e.TypeID + 1
+	}
+	return errs.Err
+}
+
+// numBuiltinTypeIDs returns the number of type IDs that are assigned, or
+// skipped, when registering the built-in types.
+func numBuiltinTypeIDs() (uint32, error) {
+	c := &typeIDCounter{
+		Codec: linearcodec.NewDefault(time.Time{}),
+	}
+	c.SkipRegistrations(5)
+	if err := RegisterUnsignedTxsTypes(c); err != nil {
+		return 0, err
+	}
+	c.SkipRegistrations(4)
+	if err := RegisterDUnsignedTxsTypes(c); err != nil {
+		return 0, err
+	}
+	return c.nextTypeID, nil
+}
+
+// typeIDCounter counts the type IDs assigned by a codec.
+type typeIDCounter struct {
+	linearcodec.Codec
+	nextTypeID uint32
+}
+
+func (c *typeIDCounter) SkipRegistrations(num int) {
+	c.Codec.SkipRegistrations(num)
+	c.nextTypeID += uint32(num)
+}
+
+func (c *typeIDCounter) RegisterType(val interface{}) error {
+	c.nextTypeID++
+	return c.Codec.RegisterType(val)
+}
@@ -0,0 +1,146 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+type testExtensionTx struct {
+	BaseTx `serialize:"true"`
+	Value  uint64 `serialize:"true"`
+}
+
+func (tx *testExtensionTx) Visit(visitor Visitor) error {
+	return VisitExtension(tx, visitor)
+}
+
+type otherTestExtensionTx struct {
+	testExtensionTx `serialize:"true"`
+}
+
+type testExtensionVisitor struct {
+	Visitor
+	visited []UnsignedTx
+}
+
+func (v *testExtensionVisitor) ExtensionTx(tx UnsignedTx) error {
+	v.visited = append(v.visited, tx)
+	return nil
+}
+
+// resetExtensions removes the extensions registered by the test once it is
+// done.
+func resetExtensions(t *testing.T) {
+	t.Cleanup(func() {
+		extensionsLock.Lock()
+		extensions = nil
+		extensionsLock.Unlock()
+
+		require.NoError(t, InitCodec(time.Time{}))
+	})
+}
+
+func TestRegisterExtension(t *testing.T) {
+	resetExtensions(t)
+
+	tests := []struct {
+		name        string
+		typeID      uint32
+		tx          UnsignedTx
+		expectedErr error
+	}{
+		{
+			name:        "nil tx",
+			typeID:      FirstExtensionTypeID,
+			expectedErr: errNilExtensionTx,
+		},
+		{
+			name:        "reserved type ID",
+			typeID:      FirstExtensionTypeID - 1,
+			tx:          &testExtensionTx{},
+			expectedErr: errReservedTypeID,
+		},
+		{
+			name:   "valid",
+			typeID: FirstExtensionTypeID + 1,
+			tx:     &testExtensionTx{},
+		},
+		{
+			name:        "conflicting type ID",
+			typeID:      FirstExtensionTypeID + 1,
+			tx:          &otherTestExtensionTx{},
+			expectedErr: ErrTypeIDConflict,
+		},
+		{
+			name:        "conflicting type",
+			typeID:      FirstExtensionTypeID + 2,
+			tx:          &testExtensionTx{},
+			expectedErr: ErrTypeIDConflict,
+		},
+		{
+			name:   "lower type ID",
+			typeID: FirstExtensionTypeID,
+			tx:     &otherTestExtensionTx{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := RegisterExtension(test.typeID, test.tx)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+
+	extensions := Extensions()
+	require.Len(t, extensions, 2)
+	require.Equal(t, uint32(FirstExtensionTypeID), extensions[0].TypeID)
+	require.Equal(t, "otherTestExtensionTx", extensions[0].Name())
+	require.Equal(t, uint32(FirstExtensionTypeID+1), extensions[1].TypeID)
+	require.Equal(t, "testExtensionTx", extensions[1].Name())
+}
+
+func TestExtensionCodec(t *testing.T) {
+	require := require.New(t)
+	resetExtensions(t)
+
+	const typeID = FirstExtensionTypeID + 10
+	require.NoError(RegisterExtension(typeID, &testExtensionTx{}))
+	require.NoError(InitCodec(time.Time{}))
+
+	utx := &testExtensionTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    1,
+			BlockchainID: ids.GenerateTestID(),
+			Ins:          []*avax.TransferableInput{},
+			Outs:         []*avax.TransferableOutput{},
+			Memo:         []byte{},
+		}},
+		Value: 5,
+	}
+	tx, err := NewSigned(utx, Codec, nil)
+	require.NoError(err)
+
+	// The tx is prefixed by the codec version and its type ID
+	txBytes := tx.Bytes()
+	require.Equal(uint32(typeID), binary.BigEndian.Uint32(txBytes[2:6]))
+
+	parsedTx, err := Parse(Codec, txBytes)
+	require.NoError(err)
+	require.Equal(utx, parsedTx.Unsigned)
+
+	// Extension txs are only visited by the visitors that support them
+	visitor := &testExtensionVisitor{}
+	require.NoError(parsedTx.Unsigned.Visit(visitor))
+	require.Equal([]UnsignedTx{parsedTx.Unsigned}, visitor.visited)
+
+	err = parsedTx.Unsigned.Visit(struct{ Visitor }{})
+	require.ErrorIs(err, ErrUnsupportedExtension)
+}
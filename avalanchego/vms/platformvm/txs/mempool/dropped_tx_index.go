@@ -0,0 +1,181 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+var (
+	_ Mempool = (*droppedTxRecorder)(nil)
+
+	droppedTxReasonPrefix = []byte("reason")
+	droppedTxOrderPrefix  = []byte("order")
+
+	errInvalidDroppedTxIndexSize = errors.New("dropped tx index size must be positive")
+)
+
+// DroppedTxIndex persists the reasons that the most recently dropped txs were
+// dropped for. Unlike the drop reasons cached by the mempool, the index
+// survives restarts and is only consulted to report the status of a tx.
+type DroppedTxIndex struct {
+	lock sync.Mutex
+	size uint64
+
+	// txID -> sequence number || reason
+	reasons database.Database
+	// sequence number -> txID
+	order database.Database
+
+	// oldest is the sequence number of the oldest entry in [order] and next
+	// is the sequence number that will be assigned to the next dropped tx.
+	oldest uint64
+	next   uint64
+}
+
+// NewDroppedTxIndex returns an index, stored in [db], that keeps the reasons
+// for the last [size] dropped txs.
+func NewDroppedTxIndex(db database.Database, size int) (*DroppedTxIndex, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("%w: %d", errInvalidDroppedTxIndexSize, size)
+	}
+
+	i := &DroppedTxIndex{
+		size:    uint64(size),
+		reasons: prefixdb.New(droppedTxReasonPrefix, db),
+		order:   prefixdb.New(droppedTxOrderPrefix, db),
+	}
+
+	it := i.order.NewIterator()
+	defer it.Release()
+
+	first := true
+	for it.Next() {
+		seq, err := database.ParseUInt64(it.Key())
+		if err != nil {
+			return nil, err
+		}
+		if first {
+			i.oldest = seq
+			first = false
+		}
+		i.next = seq + 1
+	}
+	return i, it.Error()
+}
+
+// Put records that [txID] was dropped because of [reason]. If the index is
+// full, the oldest entry is evicted.
+func (i *DroppedTxIndex) Put(txID ids.ID, reason error) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	seq := i.next
+	seqBytes := database.PackUInt64(seq)
+	if err := i.order.Put(seqBytes, txID[:]); err != nil {
+		return err
+	}
+
+	value := append(seqBytes, reason.Error()...)
+	if err := i.reasons.Put(txID[:], value); err != nil {
+		return err
+	}
+	i.next++
+
+	for i.next-i.oldest > i.size {
+		if err := i.evictOldest(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Get returns the reason [txID] was dropped. If [txID] isn't in the index,
+// [database.ErrNotFound] is returned.
+func (i *DroppedTxIndex) Get(txID ids.ID) (string, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	value, err := i.reasons.Get(txID[:])
+	if err != nil {
+		return "", err
+	}
+	if len(value) < wrappers.LongLen {
+		return "", fmt.Errorf("malformed drop reason for tx %s", txID)
+	}
+	return string(value[wrappers.LongLen:]), nil
+}
+
+func (i *DroppedTxIndex) evictOldest() error {
+	seqBytes := database.PackUInt64(i.oldest)
+	txIDBytes, err := i.order.Get(seqBytes)
+	if err != nil && err != database.ErrNotFound {
+		return err
+	}
+	if err == nil {
+		value, err := i.reasons.Get(txIDBytes)
+		if err != nil && err != database.ErrNotFound {
+			return err
+		}
+		// The tx may have been dropped again since this entry was written, in
+		// which case the newer reason must be kept.
+		if err == nil && bytes.HasPrefix(value, seqBytes) {
+			if err := i.reasons.Delete(txIDBytes); err != nil {
+				return err
+			}
+		}
+		if err := i.order.Delete(seqBytes); err != nil {
+			return err
+		}
+	}
+	i.oldest++
+	return nil
+}
+
+// droppedTxRecorder records the txs dropped from the wrapped mempool in a
+// [DroppedTxIndex].
+type droppedTxRecorder struct {
+	Mempool
+	index *DroppedTxIndex
+	log   logging.Logger
+}
+
+// NewDroppedTxRecorder returns [mempool] with every tx marked as dropped also
+// being recorded in [index].
+func NewDroppedTxRecorder(mempool Mempool, index *DroppedTxIndex, log logging.Logger) Mempool {
+	return &droppedTxRecorder{
+		Mempool: mempool,
+		index:   index,
+		log:     log,
+	}
+}
+
+func (r *droppedTxRecorder) MarkDropped(txID ids.ID, reason error) {
+	r.Mempool.MarkDropped(txID, reason)
+
+	// Mirror the drops that the mempool ignores.
+	if errors.Is(reason, ErrMempoolFull) {
+		return
+	}
+	if _, ok := r.Mempool.Get(txID); ok {
+		return
+	}
+	if err := r.index.Put(txID, reason); err != nil {
+		r.log.Warn("failed to record dropped tx",
+			zap.Stringer("txID", txID),
+			zap.Error(err),
+		)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestDroppedTxIndex(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	index, err := NewDroppedTxIndex(db, 2)
+	require.NoError(err)
+
+	var (
+		txID0 = ids.GenerateTestID()
+		txID1 = ids.GenerateTestID()
+		txID2 = ids.GenerateTestID()
+	)
+	require.NoError(index.Put(txID0, errors.New("reason 0")))
+	require.NoError(index.Put(txID1, errors.New("reason 1")))
+
+	reason, err := index.Get(txID0)
+	require.NoError(err)
+	require.Equal("reason 0", reason)
+
+	// Dropping [txID0] again evicts its first entry without evicting its
+	// latest reason.
+	require.NoError(index.Put(txID0, errors.New("reason 0 again")))
+	reason, err = index.Get(txID0)
+	require.NoError(err)
+	require.Equal("reason 0 again", reason)
+	reason, err = index.Get(txID1)
+	require.NoError(err)
+	require.Equal("reason 1", reason)
+
+	// The index is reloaded from disk.
+	index, err = NewDroppedTxIndex(db, 2)
+	require.NoError(err)
+
+	require.NoError(index.Put(txID2, errors.New("reason 2")))
+	_, err = index.Get(txID1)
+	require.ErrorIs(err, database.ErrNotFound)
+	reason, err = index.Get(txID0)
+	require.NoError(err)
+	require.Equal("reason 0 again", reason)
+	reason, err = index.Get(txID2)
+	require.NoError(err)
+	require.Equal("reason 2", reason)
+
+	require.NoError(index.Put(txID1, errors.New("reason 1 again")))
+	_, err = index.Get(txID0)
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestDroppedTxIndexInvalidSize(t *testing.T) {
+	_, err := NewDroppedTxIndex(memdb.New(), 0)
+	require.ErrorIs(t, err, errInvalidDroppedTxIndexSize)
+}
+
+func TestDroppedTxRecorder(t *testing.T) {
+	require := require.New(t)
+
+	mpool, err := New("mempool", prometheus.NewRegistry(), nil)
+	require.NoError(err)
+
+	index, err := NewDroppedTxIndex(memdb.New(), 8)
+	require.NoError(err)
+	mpool = NewDroppedTxRecorder(mpool, index, logging.NoLog{})
+
+	decisionTxs, err := createTestDecisionTxs(2)
+	require.NoError(err)
+	issuedTx, droppedTx := decisionTxs[0], decisionTxs[1]
+
+	// Drops of txs in the mempool are ignored.
+	require.NoError(mpool.Add(issuedTx))
+	mpool.MarkDropped(issuedTx.ID(), errors.New("ignored"))
+	_, err = index.Get(issuedTx.ID())
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// Drops caused by a full mempool are ignored.
+	mpool.MarkDropped(droppedTx.ID(), ErrMempoolFull)
+	_, err = index.Get(droppedTx.ID())
+	require.ErrorIs(err, database.ErrNotFound)
+
+	mpool.MarkDropped(droppedTx.ID(), errors.New("invalid"))
+	reason, err := index.Get(droppedTx.ID())
+	require.NoError(err)
+	require.Equal("invalid", reason)
+	require.EqualError(mpool.GetDropReason(droppedTx.ID()), "invalid")
+}
@@ -0,0 +1,49 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// Graph describes how the txs in the mempool depend on each other.
+//
+// Txs in the mempool never conflict with each other, as a tx is only added if
+// none of its inputs are consumed by another tx in the mempool.
+type Graph struct {
+	// Txs are the IDs of all the txs in the mempool, oldest first.
+	Txs []ids.ID `json:"txs"`
+	// Dependencies are the txs that spend outputs produced by other txs in
+	// the mempool.
+	Dependencies []Dependency `json:"dependencies"`
+}
+
+// Dependency signals that [TxID] consumes [UTXOID], which is produced by
+// [DependsOn].
+type Dependency struct {
+	TxID      ids.ID `json:"txID"`
+	DependsOn ids.ID `json:"dependsOn"`
+	UTXOID    ids.ID `json:"utxoID"`
+}
+
+// DOT returns the graph in the graphviz DOT format.
+func (g *Graph) DOT() string {
+	var sb strings.Builder
+	sb.WriteString("digraph mempool {\n")
+	for _, txID := range g.Txs {
+		_, _ = fmt.Fprintf(&sb, "\t%q;\n", txID)
+	}
+	for _, dependency := range g.Dependencies {
+		_, _ = fmt.Fprintf(&sb, "\t%q -> %q [label=%q];\n",
+			dependency.TxID,
+			dependency.DependsOn,
+			dependency.UTXOID,
+		)
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
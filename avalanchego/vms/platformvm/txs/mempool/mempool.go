@@ -15,6 +15,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/linkedhashmap"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/setmap"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
@@ -70,6 +71,13 @@ type Mempool interface {
 
 	// Len returns the number of txs in the mempool.
 	Len() int
+
+	// Graph returns the dependencies between the txs in the mempool.
+	Graph() *Graph
+
+	// Conflicts returns the IDs of the txs in the mempool that consume any of
+	// the inputs of [tx].
+	Conflicts(tx *txs.Tx) []ids.ID
 }
 
 // Transactions from clients that have not yet been put into blocks and added to
@@ -256,3 +264,55 @@ func (m *mempool) Len() int {
 
 	return m.unissuedTxs.Len()
 }
+
+func (m *mempool) Graph() *Graph {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	// Maps the UTXOs produced by txs in the mempool to the tx that produced
+	// them.
+	producers := make(map[ids.ID]ids.ID)
+	graph := &Graph{
+		Txs: make([]ids.ID, 0, m.unissuedTxs.Len()),
+	}
+	itr := m.unissuedTxs.NewIterator()
+	for itr.Next() {
+		tx := itr.Value()
+		txID := itr.Key()
+		graph.Txs = append(graph.Txs, txID)
+		for _, utxo := range tx.UTXOs() {
+			producers[utxo.InputID()] = txID
+		}
+	}
+
+	for _, txID := range graph.Txs {
+		inputs, _ := m.consumedUTXOs.GetSet(txID)
+		for utxoID := range inputs {
+			producerID, ok := producers[utxoID]
+			if !ok {
+				continue
+			}
+			graph.Dependencies = append(graph.Dependencies, Dependency{
+				TxID:      txID,
+				DependsOn: producerID,
+				UTXOID:    utxoID,
+			})
+		}
+	}
+	return graph
+}
+
+func (m *mempool) Conflicts(tx *txs.Tx) []ids.ID {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	txID := tx.ID()
+	conflicts := set.Set[ids.ID]{}
+	for utxoID := range tx.Unsigned.InputIDs() {
+		conflictID, ok := m.consumedUTXOs.GetKey(utxoID)
+		if ok && conflictID != txID {
+			conflicts.Add(conflictID)
+		}
+	}
+	return conflicts.List()
+}
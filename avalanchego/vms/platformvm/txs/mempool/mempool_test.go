@@ -4,6 +4,7 @@
 package mempool
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -297,3 +298,60 @@ func TestIterate(t *testing.T) {
 
 	require.Equal(expectedSet, set)
 }
+
+func TestGraph(t *testing.T) {
+	require := require.New(t)
+
+	registerer := prometheus.NewRegistry()
+	mempool, err := New("mempool", registerer, nil)
+	require.NoError(err)
+
+	parentTxs, err := createTestDecisionTxs(1)
+	require.NoError(err)
+	parentTx := parentTxs[0]
+	parentTxID := parentTx.ID()
+
+	// childTx spends the only output of parentTx
+	childUTx := *parentTx.Unsigned.(*txs.CreateChainTx)
+	childUTx.Ins = []*avax.TransferableInput{{
+		UTXOID: avax.UTXOID{
+			TxID:        parentTxID,
+			OutputIndex: 0,
+		},
+		Asset: avax.Asset{ID: ids.ID{'a', 's', 's', 'e', 'r', 't'}},
+		In: &secp256k1fx.TransferInput{
+			Amt:   uint64(1234),
+			Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+		},
+	}}
+	childTx, err := txs.NewSigned(&childUTx, txs.Codec, nil)
+	require.NoError(err)
+	childTxID := childTx.ID()
+
+	require.NoError(mempool.Add(parentTx))
+	require.NoError(mempool.Add(childTx))
+
+	graph := mempool.Graph()
+	require.Equal([]ids.ID{parentTxID, childTxID}, graph.Txs)
+	require.Equal(
+		[]Dependency{{
+			TxID:      childTxID,
+			DependsOn: parentTxID,
+			UTXOID:    childUTx.Ins[0].InputID(),
+		}},
+		graph.Dependencies,
+	)
+	require.Contains(graph.DOT(), fmt.Sprintf("%q -> %q", childTxID, parentTxID))
+
+	// conflictTx spends the same input as parentTx
+	conflictTxs, err := createTestDecisionTxs(1)
+	require.NoError(err)
+	require.Equal([]ids.ID{parentTxID}, mempool.Conflicts(conflictTxs[0]))
+
+	// A tx doesn't conflict with itself
+	require.Empty(mempool.Conflicts(parentTx))
+
+	mempool.Remove(parentTx)
+	require.Empty(mempool.Graph().Dependencies)
+	require.Empty(mempool.Conflicts(conflictTxs[0]))
+}
@@ -54,6 +54,20 @@ func (mr *MockMempoolMockRecorder) Add(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockMempool)(nil).Add), arg0)
 }
 
+// Conflicts mocks base method.
+func (m *MockMempool) Conflicts(arg0 *txs.Tx) []ids.ID {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Conflicts", arg0)
+	ret0, _ := ret[0].([]ids.ID)
+	return ret0
+}
+
+// Conflicts indicates an expected call of Conflicts.
+func (mr *MockMempoolMockRecorder) Conflicts(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Conflicts", reflect.TypeOf((*MockMempool)(nil).Conflicts), arg0)
+}
+
 // Get mocks base method.
 func (m *MockMempool) Get(arg0 ids.ID) (*txs.Tx, bool) {
 	m.ctrl.T.Helper()
@@ -83,6 +97,20 @@ func (mr *MockMempoolMockRecorder) GetDropReason(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDropReason", reflect.TypeOf((*MockMempool)(nil).GetDropReason), arg0)
 }
 
+// Graph mocks base method.
+func (m *MockMempool) Graph() *Graph {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Graph")
+	ret0, _ := ret[0].(*Graph)
+	return ret0
+}
+
+// Graph indicates an expected call of Graph.
+func (mr *MockMempoolMockRecorder) Graph() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Graph", reflect.TypeOf((*MockMempool)(nil).Graph))
+}
+
 // Iterate mocks base method.
 func (m *MockMempool) Iterate(arg0 func(*txs.Tx) bool) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,161 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var ErrInsufficientDelegationCapacity = errors.New("insufficient unreserved delegation capacity")
+
+// Reservations hold the delegation capacity of validators for a short time
+// on behalf of the owners of delegations that are about to be issued. Without
+// a reservation, two delegations issued concurrently to the same validator can
+// both be admitted into the mempool, with the second one failing with
+// ErrOverDelegated once the first one is included in a block.
+//
+// Reservations are local to the node. They only restrict the delegations
+// admitted into its mempool.
+type Reservations struct {
+	clock    *mockable.Clock
+	duration time.Duration
+
+	lock         sync.Mutex
+	reservations map[reservationKey]reservation
+}
+
+type reservationKey struct {
+	subnetID ids.ID
+	nodeID   ids.NodeID
+	owner    ids.ShortID
+}
+
+type reservation struct {
+	amount uint64
+	expiry time.Time
+}
+
+// NewReservations returns reservations that expire [duration] after they are
+// made.
+func NewReservations(clock *mockable.Clock, duration time.Duration) *Reservations {
+	return &Reservations{
+		clock:        clock,
+		duration:     duration,
+		reservations: make(map[reservationKey]reservation),
+	}
+}
+
+// Reserve reserves [amount] of the delegation capacity of [nodeID] on
+// [subnetID] for the delegations rewarded to [owner], replacing the previous
+// reservation of [owner], if any. [capacity] is the delegation capacity of the
+// validator that isn't taken by the chain state or the mempool. The expiry of
+// the reservation is returned.
+func (r *Reservations) Reserve(
+	subnetID ids.ID,
+	nodeID ids.NodeID,
+	owner ids.ShortID,
+	amount uint64,
+	capacity uint64,
+) (time.Time, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	reserved, err := r.reserved(subnetID, nodeID, set.Of(owner))
+	if err != nil {
+		return time.Time{}, err
+	}
+	if err := verifyCapacity(amount, reserved, capacity); err != nil {
+		return time.Time{}, err
+	}
+
+	expiry := r.clock.Time().Add(r.duration)
+	r.reservations[reservationKey{
+		subnetID: subnetID,
+		nodeID:   nodeID,
+		owner:    owner,
+	}] = reservation{
+		amount: amount,
+		expiry: expiry,
+	}
+	return expiry, nil
+}
+
+// Admit verifies that a delegation of [amount] to [nodeID] on [subnetID],
+// rewarded to [owners], fits in [capacity] without taking the capacity
+// reserved by other owners. If it does, the reservations of [owners] on the
+// validator are released, as the delegation now holds the capacity.
+func (r *Reservations) Admit(
+	subnetID ids.ID,
+	nodeID ids.NodeID,
+	owners set.Set[ids.ShortID],
+	amount uint64,
+	capacity uint64,
+) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	reserved, err := r.reserved(subnetID, nodeID, owners)
+	if err != nil {
+		return err
+	}
+	if err := verifyCapacity(amount, reserved, capacity); err != nil {
+		return err
+	}
+
+	for owner := range owners {
+		delete(r.reservations, reservationKey{
+			subnetID: subnetID,
+			nodeID:   nodeID,
+			owner:    owner,
+		})
+	}
+	return nil
+}
+
+// reserved returns the delegation capacity of [nodeID] on [subnetID] that is
+// reserved by owners other than [owners]. Expired reservations are removed.
+//
+// Invariant: [r.lock] is held.
+func (r *Reservations) reserved(subnetID ids.ID, nodeID ids.NodeID, owners set.Set[ids.ShortID]) (uint64, error) {
+	now := r.clock.Time()
+	var reserved uint64
+	for key, reservation := range r.reservations {
+		if !now.Before(reservation.expiry) {
+			delete(r.reservations, key)
+			continue
+		}
+		if key.subnetID != subnetID || key.nodeID != nodeID || owners.Contains(key.owner) {
+			continue
+		}
+
+		var err error
+		reserved, err = safemath.Add64(reserved, reservation.amount)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return reserved, nil
+}
+
+func verifyCapacity(amount, reserved, capacity uint64) error {
+	if reserved > capacity || amount > capacity-reserved {
+		return fmt.Errorf(
+			"%w: %d requested, %d reserved, %d available",
+			ErrInsufficientDelegationCapacity,
+			amount,
+			reserved,
+			capacity,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+func TestReservations(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		clock       = &mockable.Clock{}
+		nodeID      = ids.GenerateTestNodeID()
+		otherNodeID = ids.GenerateTestNodeID()
+		owner0      = ids.GenerateTestShortID()
+		owner1      = ids.GenerateTestShortID()
+	)
+	clock.Set(time.Unix(1_000_000, 0))
+	reservations := NewReservations(clock, time.Minute)
+
+	expiry, err := reservations.Reserve(constants.PrimaryNetworkID, nodeID, owner0, 60, 100)
+	require.NoError(err)
+	require.Equal(clock.Time().Add(time.Minute), expiry)
+
+	// The capacity reserved by [owner0] can't be reserved by [owner1]
+	_, err = reservations.Reserve(constants.PrimaryNetworkID, nodeID, owner1, 41, 100)
+	require.ErrorIs(err, ErrInsufficientDelegationCapacity)
+	_, err = reservations.Reserve(constants.PrimaryNetworkID, nodeID, owner1, 40, 100)
+	require.NoError(err)
+
+	// Reservations are specific to a validator
+	_, err = reservations.Reserve(constants.PrimaryNetworkID, otherNodeID, owner1, 100, 100)
+	require.NoError(err)
+
+	// A reservation of [owner0] replaces the previous one
+	_, err = reservations.Reserve(constants.PrimaryNetworkID, nodeID, owner0, 50, 100)
+	require.NoError(err)
+
+	// The delegation of an owner without a reservation can't take the
+	// reserved capacity
+	err = reservations.Admit(constants.PrimaryNetworkID, nodeID, set.Of(ids.GenerateTestShortID()), 11, 100)
+	require.ErrorIs(err, ErrInsufficientDelegationCapacity)
+	require.NoError(reservations.Admit(constants.PrimaryNetworkID, nodeID, set.Of(ids.GenerateTestShortID()), 10, 100))
+
+	// Admitting the delegation of [owner0] releases its reservation
+	require.NoError(reservations.Admit(constants.PrimaryNetworkID, nodeID, set.Of(owner0), 50, 100))
+	_, err = reservations.Reserve(constants.PrimaryNetworkID, nodeID, owner0, 60, 100)
+	require.NoError(err)
+
+	// Reservations expire
+	clock.Set(clock.Time().Add(time.Minute))
+	require.NoError(reservations.Admit(constants.PrimaryNetworkID, nodeID, set.Of(ids.GenerateTestShortID()), 100, 100))
+	require.Empty(reservations.reservations)
+}
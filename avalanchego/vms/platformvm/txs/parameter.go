@@ -0,0 +1,78 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The runtime parameters that governance is allowed to change with a
+// ParameterChangeTx.
+const (
+	TxFeeParameter Parameter = iota
+	AddPrimaryNetworkValidatorFeeParameter
+	AddPrimaryNetworkDelegatorFeeParameter
+	MinValidatorStakeParameter
+	MinDelegatorStakeParameter
+	NameRegistrationFeeParameter
+)
+
+var (
+	_ fmt.Stringer = Parameter(0)
+
+	// Parameters are the runtime parameters, in the order of their values.
+	Parameters = []Parameter{
+		TxFeeParameter,
+		AddPrimaryNetworkValidatorFeeParameter,
+		AddPrimaryNetworkDelegatorFeeParameter,
+		MinValidatorStakeParameter,
+		MinDelegatorStakeParameter,
+		NameRegistrationFeeParameter,
+	}
+
+	errUnknownParameter = errors.New("unknown parameter")
+)
+
+// Parameter identifies a runtime parameter of the P-chain.
+type Parameter uint32
+
+func (p Parameter) String() string {
+	switch p {
+	case TxFeeParameter:
+		return "txFee"
+	case AddPrimaryNetworkValidatorFeeParameter:
+		return "addPrimaryNetworkValidatorFee"
+	case AddPrimaryNetworkDelegatorFeeParameter:
+		return "addPrimaryNetworkDelegatorFee"
+	case MinValidatorStakeParameter:
+		return "minValidatorStake"
+	case MinDelegatorStakeParameter:
+		return "minDelegatorStake"
+	case NameRegistrationFeeParameter:
+		return "nameRegistrationFee"
+	default:
+		return "unknown parameter"
+	}
+}
+
+// Verify returns nil if [p] may be changed by governance.
+func (p Parameter) Verify() error {
+	switch p {
+	case TxFeeParameter,
+		AddPrimaryNetworkValidatorFeeParameter,
+		AddPrimaryNetworkDelegatorFeeParameter,
+		MinValidatorStakeParameter,
+		MinDelegatorStakeParameter,
+		NameRegistrationFeeParameter:
+		return nil
+	default:
+		return fmt.Errorf("%w: %d", errUnknownParameter, p)
+	}
+}
+
+// IsStake returns true if [p] is a staking amount, which must not be zero.
+func (p Parameter) IsStake() bool {
+	return p == MinValidatorStakeParameter || p == MinDelegatorStakeParameter
+}
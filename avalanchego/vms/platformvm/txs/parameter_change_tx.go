@@ -0,0 +1,62 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+var (
+	_ UnsignedTx = (*ParameterChangeTx)(nil)
+
+	ErrZeroStakeParameter = errors.New("stake parameters can't be set to zero")
+)
+
+// ParameterChangeTx schedules a change of a runtime parameter. It must be
+// authorized by the governance owner configured on the network.
+type ParameterChangeTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// Parameter to change
+	Parameter Parameter `serialize:"true" json:"parameter"`
+	// New value of the parameter
+	Value uint64 `serialize:"true" json:"value"`
+	// Unix time at which the new value takes effect. Must be after the chain
+	// time when the tx is accepted.
+	ActivationTime uint64 `serialize:"true" json:"activationTime"`
+	// Proves that the issuer is authorized by the governance owner.
+	GovernanceAuth verify.Verifiable `serialize:"true" json:"governanceAuthorization"`
+}
+
+func (tx *ParameterChangeTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified:
+		// already passed syntactic verification
+		return nil
+	case tx.Parameter.IsStake() && tx.Value == 0:
+		return ErrZeroStakeParameter
+	}
+
+	if err := tx.Parameter.Verify(); err != nil {
+		return err
+	}
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := tx.GovernanceAuth.Verify(); err != nil {
+		return err
+	}
+
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *ParameterChangeTx) Visit(visitor Visitor) error {
+	return visitor.ParameterChangeTx(tx)
+}
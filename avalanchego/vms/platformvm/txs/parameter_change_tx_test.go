@@ -0,0 +1,119 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+var errInvalidGovernanceAuth = errors.New("invalid governance auth")
+
+func TestParameterChangeTxSyntacticVerify(t *testing.T) {
+	type test struct {
+		name        string
+		txFunc      func(*gomock.Controller) *ParameterChangeTx
+		expectedErr error
+	}
+
+	var (
+		networkID = uint32(1337)
+		chainID   = ids.GenerateTestID()
+	)
+
+	ctx := &snow.Context{
+		ChainID:   chainID,
+		NetworkID: networkID,
+	}
+
+	// A BaseTx that passes syntactic verification.
+	validBaseTx := BaseTx{
+		BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		},
+	}
+
+	tests := []test{
+		{
+			name: "nil tx",
+			txFunc: func(*gomock.Controller) *ParameterChangeTx {
+				return nil
+			},
+			expectedErr: ErrNilTx,
+		},
+		{
+			name: "unknown parameter",
+			txFunc: func(*gomock.Controller) *ParameterChangeTx {
+				return &ParameterChangeTx{
+					BaseTx:    validBaseTx,
+					Parameter: NameRegistrationFeeParameter + 1,
+					Value:     1,
+				}
+			},
+			expectedErr: errUnknownParameter,
+		},
+		{
+			name: "zero min validator stake",
+			txFunc: func(*gomock.Controller) *ParameterChangeTx {
+				return &ParameterChangeTx{
+					BaseTx:    validBaseTx,
+					Parameter: MinValidatorStakeParameter,
+				}
+			},
+			expectedErr: ErrZeroStakeParameter,
+		},
+		{
+			name: "invalid governanceAuth",
+			txFunc: func(ctrl *gomock.Controller) *ParameterChangeTx {
+				// This GovernanceAuth fails verification.
+				invalidGovernanceAuth := verify.NewMockVerifiable(ctrl)
+				invalidGovernanceAuth.EXPECT().Verify().Return(errInvalidGovernanceAuth)
+				return &ParameterChangeTx{
+					BaseTx:         validBaseTx,
+					Parameter:      TxFeeParameter,
+					GovernanceAuth: invalidGovernanceAuth,
+				}
+			},
+			expectedErr: errInvalidGovernanceAuth,
+		},
+		{
+			name: "passes verification",
+			txFunc: func(ctrl *gomock.Controller) *ParameterChangeTx {
+				// This GovernanceAuth passes verification.
+				validGovernanceAuth := verify.NewMockVerifiable(ctrl)
+				validGovernanceAuth.EXPECT().Verify().Return(nil)
+				return &ParameterChangeTx{
+					BaseTx:         validBaseTx,
+					Parameter:      TxFeeParameter,
+					GovernanceAuth: validGovernanceAuth,
+				}
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			tx := tt.txFunc(ctrl)
+			err := tx.SyntacticVerify(ctx)
+			require.ErrorIs(err, tt.expectedErr)
+			if tt.expectedErr != nil {
+				return
+			}
+			require.True(tx.SyntacticallyVerified)
+		})
+	}
+}
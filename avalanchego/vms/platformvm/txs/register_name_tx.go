@@ -0,0 +1,97 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+)
+
+// MaxRegisteredNameLen is the max length of a registered name
+const MaxRegisteredNameLen = 64
+
+var (
+	_ UnsignedTx = (*RegisterNameTx)(nil)
+
+	ErrInvalidName = errors.New("invalid name")
+)
+
+// RegisterNameTx registers [Name] for the primary network validator [NodeID].
+// The name refers to the validator and to the addresses of [Owner] until
+// [Expiry], which can't be after the end of the validation period.
+type RegisterNameTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// Name to register
+	Name string `serialize:"true" json:"name"`
+	// Node ID of the validator the name refers to
+	NodeID ids.NodeID `serialize:"true" json:"nodeID"`
+	// Who is authorized to update the name. The name refers to its addresses.
+	Owner fx.Owner `serialize:"true" json:"owner"`
+	// Unix time the name expires at
+	Expiry uint64 `serialize:"true" json:"expiry"`
+	// Proves that the issuer controls the validation rewards owner of the
+	// validator.
+	ValidatorAuth verify.Verifiable `serialize:"true" json:"validatorAuthorization"`
+}
+
+// InitCtx sets the FxID fields in the inputs and outputs of this
+// [RegisterNameTx]. Also sets the [ctx] to the given [vm.ctx] so that
+// the addresses can be json marshalled into human readable format
+func (tx *RegisterNameTx) InitCtx(ctx *snow.Context) {
+	tx.BaseTx.InitCtx(ctx)
+	tx.Owner.InitCtx(ctx)
+}
+
+func (tx *RegisterNameTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified:
+		// already passed syntactic verification
+		return nil
+	case tx.NodeID == ids.EmptyNodeID:
+		return errEmptyNodeID
+	}
+
+	if err := VerifyName(tx.Name); err != nil {
+		return err
+	}
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := verify.All(tx.Owner, tx.ValidatorAuth); err != nil {
+		return err
+	}
+
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *RegisterNameTx) Visit(visitor Visitor) error {
+	return visitor.RegisterNameTx(tx)
+}
+
+// VerifyName returns an error if [name] can't be registered. Names are made of
+// lowercase letters, digits and inner hyphens, so that they can't be confused
+// with one another when displayed.
+func VerifyName(name string) error {
+	if len(name) == 0 || len(name) > MaxRegisteredNameLen {
+		return fmt.Errorf("%w: length %d isn't in [1, %d]", ErrInvalidName, len(name), MaxRegisteredNameLen)
+	}
+	for i, c := range []byte(name) {
+		switch {
+		case 'a' <= c && c <= 'z', '0' <= c && c <= '9':
+		case c == '-' && i != 0 && i != len(name)-1:
+		default:
+			return fmt.Errorf("%w: %q has an invalid character at index %d", ErrInvalidName, name, i)
+		}
+	}
+	return nil
+}
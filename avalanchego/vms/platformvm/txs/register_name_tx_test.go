@@ -0,0 +1,168 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestVerifyName(t *testing.T) {
+	tests := []struct {
+		name        string
+		expectedErr error
+	}{
+		{
+			name:        "validator-1",
+			expectedErr: nil,
+		},
+		{
+			name:        strings.Repeat("a", MaxRegisteredNameLen),
+			expectedErr: nil,
+		},
+		{
+			name:        "",
+			expectedErr: ErrInvalidName,
+		},
+		{
+			name:        strings.Repeat("a", MaxRegisteredNameLen+1),
+			expectedErr: ErrInvalidName,
+		},
+		{
+			name:        "Validator",
+			expectedErr: ErrInvalidName,
+		},
+		{
+			name:        "-validator",
+			expectedErr: ErrInvalidName,
+		},
+		{
+			name:        "validator-",
+			expectedErr: ErrInvalidName,
+		},
+		{
+			name:        "my validator",
+			expectedErr: ErrInvalidName,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, VerifyName(tt.name), tt.expectedErr)
+		})
+	}
+}
+
+func TestRegisterNameTxSyntacticVerify(t *testing.T) {
+	type test struct {
+		name        string
+		txFunc      func(*gomock.Controller) *RegisterNameTx
+		expectedErr error
+	}
+
+	var (
+		networkID = uint32(1337)
+		chainID   = ids.GenerateTestID()
+		nodeID    = ids.GenerateTestNodeID()
+	)
+
+	ctx := &snow.Context{
+		ChainID:   chainID,
+		NetworkID: networkID,
+	}
+
+	// A BaseTx that passes syntactic verification.
+	validBaseTx := BaseTx{
+		BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		},
+	}
+
+	tests := []test{
+		{
+			name: "nil tx",
+			txFunc: func(*gomock.Controller) *RegisterNameTx {
+				return nil
+			},
+			expectedErr: ErrNilTx,
+		},
+		{
+			name: "empty nodeID",
+			txFunc: func(*gomock.Controller) *RegisterNameTx {
+				return &RegisterNameTx{
+					BaseTx: validBaseTx,
+					Name:   "validator",
+				}
+			},
+			expectedErr: errEmptyNodeID,
+		},
+		{
+			name: "invalid name",
+			txFunc: func(*gomock.Controller) *RegisterNameTx {
+				return &RegisterNameTx{
+					BaseTx: validBaseTx,
+					Name:   "Validator",
+					NodeID: nodeID,
+				}
+			},
+			expectedErr: ErrInvalidName,
+		},
+		{
+			name: "invalid validatorAuth",
+			txFunc: func(ctrl *gomock.Controller) *RegisterNameTx {
+				// This ValidatorAuth fails verification.
+				invalidValidatorAuth := verify.NewMockVerifiable(ctrl)
+				invalidValidatorAuth.EXPECT().Verify().Return(errInvalidValidatorAuth)
+				return &RegisterNameTx{
+					BaseTx:        validBaseTx,
+					Name:          "validator",
+					NodeID:        nodeID,
+					Owner:         &secp256k1fx.OutputOwners{},
+					ValidatorAuth: invalidValidatorAuth,
+				}
+			},
+			expectedErr: errInvalidValidatorAuth,
+		},
+		{
+			name: "passes verification",
+			txFunc: func(ctrl *gomock.Controller) *RegisterNameTx {
+				// This ValidatorAuth passes verification.
+				validValidatorAuth := verify.NewMockVerifiable(ctrl)
+				validValidatorAuth.EXPECT().Verify().Return(nil)
+				return &RegisterNameTx{
+					BaseTx:        validBaseTx,
+					Name:          "validator",
+					NodeID:        nodeID,
+					Owner:         &secp256k1fx.OutputOwners{},
+					ValidatorAuth: validValidatorAuth,
+				}
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			tx := tt.txFunc(ctrl)
+			err := tx.SyntacticVerify(ctx)
+			require.ErrorIs(err, tt.expectedErr)
+			if tt.expectedErr != nil {
+				return
+			}
+			require.True(tx.SyntacticallyVerified)
+		})
+	}
+}
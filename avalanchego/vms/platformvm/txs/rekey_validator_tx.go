@@ -0,0 +1,71 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+)
+
+var (
+	_ UnsignedTx = (*RekeyValidatorTx)(nil)
+
+	ErrRekeyToSameNodeID = errors.New("validator can't be re-keyed to its current nodeID")
+)
+
+// RekeyValidatorTx moves the stake of a current primary network validator,
+// along with its delegators, from [NodeID] to [NewNodeID] and replaces its BLS
+// key with the key of [Signer]. The validator keeps its staking period, rewards
+// and measured uptime.
+type RekeyValidatorTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// Node ID the validator is currently validating with
+	NodeID ids.NodeID `serialize:"true" json:"nodeID"`
+	// Node ID the validator will validate with from now on
+	NewNodeID ids.NodeID `serialize:"true" json:"newNodeID"`
+	// BLS key the validator will validate with from now on
+	Signer signer.Signer `serialize:"true" json:"signer"`
+	// Proves that the issuer controls the owner of the stake of the validator.
+	ValidatorAuth verify.Verifiable `serialize:"true" json:"validatorAuthorization"`
+}
+
+func (tx *RekeyValidatorTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified:
+		// already passed syntactic verification
+		return nil
+	case tx.NodeID == ids.EmptyNodeID || tx.NewNodeID == ids.EmptyNodeID:
+		return errEmptyNodeID
+	case tx.NodeID == tx.NewNodeID:
+		return ErrRekeyToSameNodeID
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := tx.ValidatorAuth.Verify(); err != nil {
+		return err
+	}
+	if err := tx.Signer.Verify(); err != nil {
+		return fmt.Errorf("%w: %w", errInvalidSigner, err)
+	}
+	if tx.Signer.Key() == nil {
+		return fmt.Errorf("%w: primary network validators must have a BLS key", errInvalidSigner)
+	}
+
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *RekeyValidatorTx) Visit(visitor Visitor) error {
+	return visitor.RekeyValidatorTx(tx)
+}
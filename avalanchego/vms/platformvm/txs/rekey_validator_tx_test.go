@@ -0,0 +1,173 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+)
+
+func TestRekeyValidatorTxSyntacticVerify(t *testing.T) {
+	type test struct {
+		name        string
+		txFunc      func(*gomock.Controller) *RekeyValidatorTx
+		expectedErr error
+	}
+
+	var (
+		networkID = uint32(1337)
+		chainID   = ids.GenerateTestID()
+		nodeID    = ids.GenerateTestNodeID()
+		newNodeID = ids.GenerateTestNodeID()
+	)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(t, err)
+	otherSK, err := bls.NewSecretKey()
+	require.NoError(t, err)
+
+	validSigner := signer.NewProofOfPossession(sk)
+	// This signer's proof of possession is made with another key.
+	invalidSigner := signer.NewProofOfPossession(sk)
+	invalidSigner.ProofOfPossession = signer.NewProofOfPossession(otherSK).ProofOfPossession
+
+	ctx := &snow.Context{
+		ChainID:   chainID,
+		NetworkID: networkID,
+	}
+
+	// A BaseTx that passes syntactic verification.
+	validBaseTx := BaseTx{
+		BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		},
+	}
+
+	tests := []test{
+		{
+			name: "nil tx",
+			txFunc: func(*gomock.Controller) *RekeyValidatorTx {
+				return nil
+			},
+			expectedErr: ErrNilTx,
+		},
+		{
+			name: "empty nodeID",
+			txFunc: func(*gomock.Controller) *RekeyValidatorTx {
+				return &RekeyValidatorTx{
+					BaseTx:    validBaseTx,
+					NewNodeID: newNodeID,
+				}
+			},
+			expectedErr: errEmptyNodeID,
+		},
+		{
+			name: "empty new nodeID",
+			txFunc: func(*gomock.Controller) *RekeyValidatorTx {
+				return &RekeyValidatorTx{
+					BaseTx: validBaseTx,
+					NodeID: nodeID,
+				}
+			},
+			expectedErr: errEmptyNodeID,
+		},
+		{
+			name: "same nodeID",
+			txFunc: func(*gomock.Controller) *RekeyValidatorTx {
+				return &RekeyValidatorTx{
+					BaseTx:    validBaseTx,
+					NodeID:    nodeID,
+					NewNodeID: nodeID,
+				}
+			},
+			expectedErr: ErrRekeyToSameNodeID,
+		},
+		{
+			name: "invalid validatorAuth",
+			txFunc: func(ctrl *gomock.Controller) *RekeyValidatorTx {
+				// This ValidatorAuth fails verification.
+				invalidValidatorAuth := verify.NewMockVerifiable(ctrl)
+				invalidValidatorAuth.EXPECT().Verify().Return(errInvalidValidatorAuth)
+				return &RekeyValidatorTx{
+					BaseTx:        validBaseTx,
+					NodeID:        nodeID,
+					NewNodeID:     newNodeID,
+					ValidatorAuth: invalidValidatorAuth,
+				}
+			},
+			expectedErr: errInvalidValidatorAuth,
+		},
+		{
+			name: "invalid proof of possession",
+			txFunc: func(ctrl *gomock.Controller) *RekeyValidatorTx {
+				validValidatorAuth := verify.NewMockVerifiable(ctrl)
+				validValidatorAuth.EXPECT().Verify().Return(nil)
+				return &RekeyValidatorTx{
+					BaseTx:        validBaseTx,
+					NodeID:        nodeID,
+					NewNodeID:     newNodeID,
+					Signer:        invalidSigner,
+					ValidatorAuth: validValidatorAuth,
+				}
+			},
+			expectedErr: errInvalidSigner,
+		},
+		{
+			name: "empty signer",
+			txFunc: func(ctrl *gomock.Controller) *RekeyValidatorTx {
+				validValidatorAuth := verify.NewMockVerifiable(ctrl)
+				validValidatorAuth.EXPECT().Verify().Return(nil)
+				return &RekeyValidatorTx{
+					BaseTx:        validBaseTx,
+					NodeID:        nodeID,
+					NewNodeID:     newNodeID,
+					Signer:        &signer.Empty{},
+					ValidatorAuth: validValidatorAuth,
+				}
+			},
+			expectedErr: errInvalidSigner,
+		},
+		{
+			name: "passes verification",
+			txFunc: func(ctrl *gomock.Controller) *RekeyValidatorTx {
+				// This ValidatorAuth passes verification.
+				validValidatorAuth := verify.NewMockVerifiable(ctrl)
+				validValidatorAuth.EXPECT().Verify().Return(nil)
+				return &RekeyValidatorTx{
+					BaseTx:        validBaseTx,
+					NodeID:        nodeID,
+					NewNodeID:     newNodeID,
+					Signer:        validSigner,
+					ValidatorAuth: validValidatorAuth,
+				}
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			tx := tt.txFunc(ctrl)
+			err := tx.SyntacticVerify(ctx)
+			require.ErrorIs(err, tt.expectedErr)
+			if tt.expectedErr != nil {
+				return
+			}
+			require.True(tx.SyntacticallyVerified)
+		})
+	}
+}
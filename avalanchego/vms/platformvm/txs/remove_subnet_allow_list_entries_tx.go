@@ -0,0 +1,55 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+var _ UnsignedTx = (*RemoveSubnetAllowListEntriesTx)(nil)
+
+// RemoveSubnetAllowListEntriesTx removes nodes from the allow list of a subnet.
+// Removed nodes that are already validating the subnet keep validating it.
+type RemoveSubnetAllowListEntriesTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// ID of the subnet this tx is modifying
+	Subnet ids.ID `serialize:"true" json:"subnetID"`
+	// Nodes to remove from the allow list, sorted and unique
+	NodeIDs []ids.NodeID `serialize:"true" json:"nodeIDs"`
+	// Proves that the issuer has the right to modify the allow list.
+	SubnetAuth verify.Verifiable `serialize:"true" json:"subnetAuthorization"`
+}
+
+func (tx *RemoveSubnetAllowListEntriesTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified:
+		// already passed syntactic verification
+		return nil
+	case tx.Subnet == constants.PrimaryNetworkID:
+		return ErrPrimaryNetworkAllowList
+	}
+
+	if err := verifyAllowListEntries(tx.NodeIDs); err != nil {
+		return err
+	}
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := tx.SubnetAuth.Verify(); err != nil {
+		return err
+	}
+
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *RemoveSubnetAllowListEntriesTx) Visit(visitor Visitor) error {
+	return visitor.RemoveSubnetAllowListEntriesTx(tx)
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+var (
+	_ UnsignedTx = (*SetSubnetValidatorWeightTx)(nil)
+
+	ErrSetPrimaryNetworkValidatorWeight = errors.New("can't set the weight of a primary network validator with SetSubnetValidatorWeightTx")
+)
+
+// SetSubnetValidatorWeightTx changes the weight of a current validator of a
+// permissioned subnet in place, without removing and re-adding the validator.
+type SetSubnetValidatorWeightTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// ID of the subnet the validator is validating
+	Subnet ids.ID `serialize:"true" json:"subnetID"`
+	// Node ID of the validator
+	NodeID ids.NodeID `serialize:"true" json:"nodeID"`
+	// New weight of the validator
+	Weight uint64 `serialize:"true" json:"weight"`
+	// Proves that the issuer has the right to modify the subnet's validators.
+	SubnetAuth verify.Verifiable `serialize:"true" json:"subnetAuthorization"`
+}
+
+func (tx *SetSubnetValidatorWeightTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified:
+		// already passed syntactic verification
+		return nil
+	case tx.Subnet == constants.PrimaryNetworkID:
+		return ErrSetPrimaryNetworkValidatorWeight
+	case tx.NodeID == ids.EmptyNodeID:
+		return errEmptyNodeID
+	case tx.Weight == 0:
+		return ErrWeightTooSmall
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := tx.SubnetAuth.Verify(); err != nil {
+		return err
+	}
+
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *SetSubnetValidatorWeightTx) Visit(visitor Visitor) error {
+	return visitor.SetSubnetValidatorWeightTx(tx)
+}
@@ -0,0 +1,136 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+func TestSetSubnetValidatorWeightTxSyntacticVerify(t *testing.T) {
+	type test struct {
+		name        string
+		txFunc      func(*gomock.Controller) *SetSubnetValidatorWeightTx
+		expectedErr error
+	}
+
+	var (
+		networkID = uint32(1337)
+		chainID   = ids.GenerateTestID()
+		subnetID  = ids.GenerateTestID()
+		nodeID    = ids.GenerateTestNodeID()
+	)
+
+	ctx := &snow.Context{
+		ChainID:   chainID,
+		NetworkID: networkID,
+	}
+
+	// A BaseTx that passes syntactic verification.
+	validBaseTx := BaseTx{
+		BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		},
+	}
+
+	tests := []test{
+		{
+			name: "nil tx",
+			txFunc: func(*gomock.Controller) *SetSubnetValidatorWeightTx {
+				return nil
+			},
+			expectedErr: ErrNilTx,
+		},
+		{
+			name: "primary network",
+			txFunc: func(*gomock.Controller) *SetSubnetValidatorWeightTx {
+				return &SetSubnetValidatorWeightTx{
+					BaseTx: validBaseTx,
+					Subnet: constants.PrimaryNetworkID,
+					NodeID: nodeID,
+					Weight: 1,
+				}
+			},
+			expectedErr: ErrSetPrimaryNetworkValidatorWeight,
+		},
+		{
+			name: "empty nodeID",
+			txFunc: func(*gomock.Controller) *SetSubnetValidatorWeightTx {
+				return &SetSubnetValidatorWeightTx{
+					BaseTx: validBaseTx,
+					Subnet: subnetID,
+					Weight: 1,
+				}
+			},
+			expectedErr: errEmptyNodeID,
+		},
+		{
+			name: "zero weight",
+			txFunc: func(*gomock.Controller) *SetSubnetValidatorWeightTx {
+				return &SetSubnetValidatorWeightTx{
+					BaseTx: validBaseTx,
+					Subnet: subnetID,
+					NodeID: nodeID,
+				}
+			},
+			expectedErr: ErrWeightTooSmall,
+		},
+		{
+			name: "invalid subnetAuth",
+			txFunc: func(ctrl *gomock.Controller) *SetSubnetValidatorWeightTx {
+				// This SubnetAuth fails verification.
+				invalidSubnetAuth := verify.NewMockVerifiable(ctrl)
+				invalidSubnetAuth.EXPECT().Verify().Return(errInvalidSubnetAuth)
+				return &SetSubnetValidatorWeightTx{
+					BaseTx:     validBaseTx,
+					Subnet:     subnetID,
+					NodeID:     nodeID,
+					Weight:     1,
+					SubnetAuth: invalidSubnetAuth,
+				}
+			},
+			expectedErr: errInvalidSubnetAuth,
+		},
+		{
+			name: "passes verification",
+			txFunc: func(ctrl *gomock.Controller) *SetSubnetValidatorWeightTx {
+				// This SubnetAuth passes verification.
+				validSubnetAuth := verify.NewMockVerifiable(ctrl)
+				validSubnetAuth.EXPECT().Verify().Return(nil)
+				return &SetSubnetValidatorWeightTx{
+					BaseTx:     validBaseTx,
+					Subnet:     subnetID,
+					NodeID:     nodeID,
+					Weight:     1,
+					SubnetAuth: validSubnetAuth,
+				}
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			tx := tt.txFunc(ctrl)
+			err := tx.SyntacticVerify(ctx)
+			require.ErrorIs(err, tt.expectedErr)
+			if tt.expectedErr != nil {
+				return
+			}
+			require.True(tx.SyntacticallyVerified)
+		})
+	}
+}
@@ -4,12 +4,23 @@
 package txs
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	ErrMultipleStakeOwners = errors.New("stake outputs have different owners")
+	ErrUnsignedStakeOwner  = errors.New("stake owner doesn't require any signatures")
+
+	errUnsupportedStakeOutput = errors.New("unsupported stake output")
 )
 
 // ValidatorTx defines the interface for a validator transaction that supports
@@ -58,3 +69,32 @@ type ScheduledStaker interface {
 	StartTime() time.Time
 	PendingPriority() Priority
 }
+
+// StakeOwner returns the owner that the stake of [staker] is returned to. An
+// error is returned if the stake outputs don't share a single owner, or if the
+// owner doesn't require any signatures, as the owner then can't authorize
+// changes to [staker].
+func StakeOwner(staker PermissionlessStaker) (*secp256k1fx.OutputOwners, error) {
+	var owner *secp256k1fx.OutputOwners
+	for _, out := range staker.Stake() {
+		stakeOut := out.Out
+		if lockedOut, ok := stakeOut.(*stakeable.LockOut); ok {
+			stakeOut = lockedOut.TransferableOut
+		}
+		transferOut, ok := stakeOut.(*secp256k1fx.TransferOutput)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T", errUnsupportedStakeOutput, stakeOut)
+		}
+
+		switch {
+		case owner == nil:
+			owner = &transferOut.OutputOwners
+		case !owner.Equals(&transferOut.OutputOwners):
+			return nil, ErrMultipleStakeOwners
+		}
+	}
+	if owner == nil || owner.Threshold == 0 {
+		return nil, ErrUnsignedStakeOwner
+	}
+	return owner, nil
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestStakeOwner(t *testing.T) {
+	var (
+		owner = secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+		}
+		otherOwner = secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+		}
+	)
+	newStakeOut := func(owner secp256k1fx.OutputOwners) *avax.TransferableOutput {
+		return &avax.TransferableOutput{
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          1,
+				OutputOwners: owner,
+			},
+		}
+	}
+	newLockedStakeOut := func(owner secp256k1fx.OutputOwners) *avax.TransferableOutput {
+		return &avax.TransferableOutput{
+			Out: &stakeable.LockOut{
+				Locktime: 1,
+				TransferableOut: &secp256k1fx.TransferOutput{
+					Amt:          1,
+					OutputOwners: owner,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		stakeOuts     []*avax.TransferableOutput
+		expectedErr   error
+		expectedOwner *secp256k1fx.OutputOwners
+	}{
+		{
+			name:          "single owner",
+			stakeOuts:     []*avax.TransferableOutput{newStakeOut(owner), newLockedStakeOut(owner)},
+			expectedOwner: &owner,
+		},
+		{
+			name:        "multiple owners",
+			stakeOuts:   []*avax.TransferableOutput{newStakeOut(owner), newStakeOut(otherOwner)},
+			expectedErr: ErrMultipleStakeOwners,
+		},
+		{
+			name:        "unsigned owner",
+			stakeOuts:   []*avax.TransferableOutput{newStakeOut(secp256k1fx.OutputOwners{})},
+			expectedErr: ErrUnsignedStakeOwner,
+		},
+		{
+			name:        "no stake",
+			expectedErr: ErrUnsignedStakeOwner,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			tx := &AddPermissionlessValidatorTx{StakeOuts: test.stakeOuts}
+			stakeOwner, err := StakeOwner(tx)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedOwner, stakeOwner)
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/network/p2p/gossip"
 	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
@@ -24,6 +25,7 @@ var (
 	ErrNilSignedTx = errors.New("nil signed tx is not valid")
 
 	errSignedTxNotInitialized = errors.New("signed tx was never initialized and is not valid")
+	errInvalidSignatureLength = errors.New("invalid signature length")
 )
 
 // Tx is a signed transaction
@@ -47,19 +49,33 @@ func NewSigned(
 	return res, res.Sign(c, signers)
 }
 
+// NewSignedWith returns [unsigned] signed by the keychain [signers].
+func NewSignedWith(
+	unsigned UnsignedTx,
+	c codec.Manager,
+	signers [][]keychain.Signer,
+) (*Tx, error) {
+	res := &Tx{Unsigned: unsigned}
+	return res, res.SignWith(c, signers)
+}
+
 func (tx *Tx) Initialize(c codec.Manager) error {
-	signedBytes, err := c.Marshal(CodecVersion, tx)
-	if err != nil {
-		return fmt.Errorf("couldn't marshal ProposalTx: %w", err)
-	}
+	return InitializeTxs(c, []*Tx{tx})
+}
 
-	unsignedBytesLen, err := c.Size(CodecVersion, &tx.Unsigned)
+// InitializeTxs initializes each of [txs]. The bytes of all [txs] are
+// marshalled into a pooled buffer and copied into a single allocation, which
+// is cheaper than initializing the txs one by one.
+func InitializeTxs(c codec.Manager, txs []*Tx) error {
+	signedBytes, unsignedBytesLen, err := marshalTxs(c, txs)
 	if err != nil {
-		return fmt.Errorf("couldn't calculate UnsignedTx marshal length: %w", err)
+		return fmt.Errorf("couldn't marshal tx: %w", err)
 	}
 
-	unsignedBytes := signedBytes[:unsignedBytesLen]
-	tx.SetBytes(unsignedBytes, signedBytes)
+	for i, tx := range txs {
+		unsignedBytes := signedBytes[i][:unsignedBytesLen[i]]
+		tx.SetBytes(unsignedBytes, signedBytes[i])
+	}
 	return nil
 }
 
@@ -132,6 +148,16 @@ func (tx *Tx) SyntacticVerify(ctx *snow.Context) error {
 // Note: We explicitly pass the codec in Sign since we may need to sign P-Chain
 // genesis txs whose length exceed the max length of txs.Codec.
 func (tx *Tx) Sign(c codec.Manager, signers [][]*secp256k1.PrivateKey) error {
+	return sign(tx, c, signers)
+}
+
+// SignWith signs this transaction with the provided keychain signers, which
+// may hold their keys outside of this process.
+func (tx *Tx) SignWith(c codec.Manager, signers [][]keychain.Signer) error {
+	return sign(tx, c, signers)
+}
+
+func sign[S keychain.Signer](tx *Tx, c codec.Manager, signers [][]S) error {
 	unsignedBytes, err := c.Marshal(CodecVersion, &tx.Unsigned)
 	if err != nil {
 		return fmt.Errorf("couldn't marshal UnsignedTx: %w", err)
@@ -148,6 +174,9 @@ func (tx *Tx) Sign(c codec.Manager, signers [][]*secp256k1.PrivateKey) error {
 			if err != nil {
 				return fmt.Errorf("problem generating credential: %w", err)
 			}
+			if len(sig) != secp256k1.SignatureLen {
+				return fmt.Errorf("%w: %d != %d", errInvalidSignatureLength, len(sig), secp256k1.SignatureLen)
+			}
 			copy(cred.Sigs[i][:], sig)
 		}
 		tx.Creds = append(tx.Creds, cred) // Attach credential
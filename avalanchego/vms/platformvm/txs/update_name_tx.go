@@ -0,0 +1,62 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+)
+
+var _ UnsignedTx = (*UpdateNameTx)(nil)
+
+// UpdateNameTx replaces the owner and the expiry of the registered [Name].
+// The name keeps referring to the validator it was registered for.
+type UpdateNameTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// Name to update
+	Name string `serialize:"true" json:"name"`
+	// New owner of the name
+	Owner fx.Owner `serialize:"true" json:"owner"`
+	// New unix time the name expires at
+	Expiry uint64 `serialize:"true" json:"expiry"`
+	// Proves that the issuer controls the current owner of the name
+	NameAuth verify.Verifiable `serialize:"true" json:"nameAuthorization"`
+}
+
+// InitCtx sets the FxID fields in the inputs and outputs of this
+// [UpdateNameTx]. Also sets the [ctx] to the given [vm.ctx] so that
+// the addresses can be json marshalled into human readable format
+func (tx *UpdateNameTx) InitCtx(ctx *snow.Context) {
+	tx.BaseTx.InitCtx(ctx)
+	tx.Owner.InitCtx(ctx)
+}
+
+func (tx *UpdateNameTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified:
+		// already passed syntactic verification
+		return nil
+	}
+
+	if err := VerifyName(tx.Name); err != nil {
+		return err
+	}
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := verify.All(tx.Owner, tx.NameAuth); err != nil {
+		return err
+	}
+
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *UpdateNameTx) Visit(visitor Visitor) error {
+	return visitor.UpdateNameTx(tx)
+}
@@ -20,4 +20,16 @@ type Visitor interface {
 	AddPermissionlessDelegatorTx(*AddPermissionlessDelegatorTx) error
 	TransferSubnetOwnershipTx(*TransferSubnetOwnershipTx) error
 	BaseTx(*BaseTx) error
+	AddSubnetAllowListEntriesTx(*AddSubnetAllowListEntriesTx) error
+	RemoveSubnetAllowListEntriesTx(*RemoveSubnetAllowListEntriesTx) error
+	AddCappedPermissionlessValidatorTx(*AddCappedPermissionlessValidatorTx) error
+	ExitValidatorTx(*ExitValidatorTx) error
+	SetSubnetValidatorWeightTx(*SetSubnetValidatorWeightTx) error
+	ParameterChangeTx(*ParameterChangeTx) error
+	RekeyValidatorTx(*RekeyValidatorTx) error
+	RegisterNameTx(*RegisterNameTx) error
+	UpdateNameTx(*UpdateNameTx) error
+	AddPermissionlessValidatorWithMetadataTx(*AddPermissionlessValidatorWithMetadataTx) error
+	ClaimRewardTx(*ClaimRewardTx) error
+	BindRewardAddressTx(*BindRewardAddressTx) error
 }
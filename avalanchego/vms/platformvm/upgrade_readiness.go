@@ -0,0 +1,118 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+var (
+	errOutdatedMajority = errors.New("the stake-weighted majority of the peers doesn't schedule the next upgrade")
+	errNewerMajority    = errors.New("the stake-weighted majority of the peers runs a newer version that may schedule upgrades this node doesn't")
+)
+
+// upgradeReadiness compares the fork schedule of this node with the versions
+// run by the connected primary network validators. The fork schedule isn't
+// exchanged with peers, so a peer is assumed to schedule the upgrades that
+// are compiled into its version: peers older than the minimum version that is
+// compatible after the next upgrade don't schedule it, and peers running a
+// newer minor version may schedule upgrades that this node doesn't.
+type upgradeReadiness struct {
+	// Name of the next upgrade scheduled by this node. Empty if every upgrade
+	// is activated.
+	NextUpgrade     string    `json:"nextUpgrade,omitempty"`
+	NextUpgradeTime time.Time `json:"nextUpgradeTime,omitempty"`
+	// Minimum version of the peers after the next upgrade
+	RequiredVersion string `json:"requiredVersion"`
+	// Stake of this node and of the connected validators
+	ConnectedWeight uint64 `json:"connectedWeight"`
+	// Stake of the connected validators that run a version older than
+	// [RequiredVersion]
+	OutdatedWeight uint64 `json:"outdatedWeight"`
+	// Stake of the connected validators that run a newer minor version than
+	// this node
+	NewerWeight uint64 `json:"newerWeight"`
+}
+
+// readiness returns the readiness of the validators in [vdrs] for the upgrade
+// [nextUpgrade] at [nextUpgradeTime]. [nodeID], this node, is counted as
+// ready.
+func (p *peerCompatibility) readiness(
+	vdrs map[ids.NodeID]*validators.GetValidatorOutput,
+	nodeID ids.NodeID,
+	nextUpgrade string,
+	nextUpgradeTime time.Time,
+	now time.Time,
+) upgradeReadiness {
+	if nextUpgrade == "" {
+		nextUpgradeTime = now
+	}
+	var (
+		current  = p.compatibility.Version()
+		required = p.minimumVersion(nextUpgradeTime)
+		r        = upgradeReadiness{
+			NextUpgrade:     nextUpgrade,
+			NextUpgradeTime: nextUpgradeTime,
+			RequiredVersion: required.String(),
+		}
+	)
+	if vdr, ok := vdrs[nodeID]; ok {
+		r.ConnectedWeight += vdr.Weight
+	}
+
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	for peerID, peerVersion := range p.versions {
+		vdr, ok := vdrs[peerID]
+		if !ok || peerID == nodeID {
+			continue
+		}
+		r.ConnectedWeight += vdr.Weight
+		if peerVersion.Before(required) {
+			r.OutdatedWeight += vdr.Weight
+		}
+		if isNewerMinor(peerVersion, current) {
+			r.NewerWeight += vdr.Weight
+		}
+	}
+	return r
+}
+
+// Verify returns an error if the stake-weighted majority of the connected
+// validators disagrees with the fork schedule of this node.
+func (r *upgradeReadiness) Verify() error {
+	switch {
+	case r.OutdatedWeight > r.ConnectedWeight/2:
+		return fmt.Errorf("%w: %d of %d stake runs a version before %s required by %s at %s",
+			errOutdatedMajority,
+			r.OutdatedWeight,
+			r.ConnectedWeight,
+			r.RequiredVersion,
+			r.NextUpgrade,
+			r.NextUpgradeTime,
+		)
+	case r.NewerWeight > r.ConnectedWeight/2:
+		return fmt.Errorf("%w: %d of %d stake",
+			errNewerMajority,
+			r.NewerWeight,
+			r.ConnectedWeight,
+		)
+	default:
+		return nil
+	}
+}
+
+func isNewerMinor(a, b *version.Application) bool {
+	if a.Major != b.Major {
+		return a.Major > b.Major
+	}
+	return a.Minor > b.Minor
+}
@@ -0,0 +1,97 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+func TestUpgradeReadiness(t *testing.T) {
+	var (
+		now     = time.Unix(1_700_000_000, 0)
+		nodeID  = ids.GenerateTestNodeID()
+		current = version.GetCompatibility(constants.UnitTestID).Version()
+		newer   = &version.Application{
+			Name:  current.Name,
+			Major: current.Major,
+			Minor: current.Minor + 1,
+		}
+		outdated = &version.Application{
+			Name: current.Name,
+		}
+	)
+
+	tests := []struct {
+		name                    string
+		peers                   map[*version.Application]uint64
+		expectedConnectedWeight uint64
+		expectedOutdatedWeight  uint64
+		expectedNewerWeight     uint64
+		expectedErr             error
+	}{
+		{
+			name:                    "no peers",
+			expectedConnectedWeight: 10,
+		},
+		{
+			name: "outdated minority",
+			peers: map[*version.Application]uint64{
+				current:  20,
+				outdated: 10,
+			},
+			expectedConnectedWeight: 40,
+			expectedOutdatedWeight:  10,
+		},
+		{
+			name: "outdated majority",
+			peers: map[*version.Application]uint64{
+				outdated: 30,
+			},
+			expectedConnectedWeight: 40,
+			expectedOutdatedWeight:  30,
+			expectedErr:             errOutdatedMajority,
+		},
+		{
+			name: "newer majority",
+			peers: map[*version.Application]uint64{
+				newer: 30,
+			},
+			expectedConnectedWeight: 40,
+			expectedNewerWeight:     30,
+			expectedErr:             errNewerMajority,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			vdrs := map[ids.NodeID]*validators.GetValidatorOutput{
+				nodeID: {NodeID: nodeID, Weight: 10},
+			}
+			p := newPeerCompatibility(constants.UnitTestID)
+			for peerVersion, weight := range test.peers {
+				peerID := ids.GenerateTestNodeID()
+				vdrs[peerID] = &validators.GetValidatorOutput{NodeID: peerID, Weight: weight}
+				_ = p.connect(peerID, peerVersion, now)
+			}
+
+			// Connected peers that aren't validators aren't weighted
+			require.NoError(p.connect(ids.GenerateTestNodeID(), current, now))
+
+			r := p.readiness(vdrs, nodeID, "durango", now.Add(time.Hour), now)
+			require.Equal(test.expectedConnectedWeight, r.ConnectedWeight)
+			require.Equal(test.expectedOutdatedWeight, r.OutdatedWeight)
+			require.Equal(test.expectedNewerWeight, r.NewerWeight)
+			require.ErrorIs(r.Verify(), test.expectedErr)
+		})
+	}
+}
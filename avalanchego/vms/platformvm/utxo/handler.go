@@ -11,10 +11,9 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
-	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/math"
-	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
@@ -44,7 +43,7 @@ var (
 type Spender interface {
 	// Spend the provided amount while deducting the provided fee.
 	// Arguments:
-	// - [keys] are the owners of the funds
+	// - [kc] signs for the owners of the funds
 	// - [amount] is the amount of funds that are trying to be staked
 	// - [fee] is the amount of AVAX that should be burned
 	// - [changeAddr] is the address that change, if there is any, is sent to
@@ -57,7 +56,7 @@ type Spender interface {
 	// - [signers] the proof of ownership of the funds being moved
 	Spend(
 		utxoReader avax.UTXOReader,
-		keys []*secp256k1.PrivateKey,
+		kc keychain.Keychain,
 		amount uint64,
 		fee uint64,
 		changeAddr ids.ShortID,
@@ -65,19 +64,19 @@ type Spender interface {
 		[]*avax.TransferableInput, // inputs
 		[]*avax.TransferableOutput, // returnedOutputs
 		[]*avax.TransferableOutput, // stakedOutputs
-		[][]*secp256k1.PrivateKey, // signers
+		[][]keychain.Signer, // signers
 		error,
 	)
 
-	// Authorize an operation on behalf of the named subnet with the provided
-	// keys.
+	// Authorize an operation on behalf of the named subnet with the signers
+	// of the provided keychain.
 	Authorize(
 		state state.Chain,
 		subnetID ids.ID,
-		keys []*secp256k1.PrivateKey,
+		kc keychain.Keychain,
 	) (
 		verify.Verifiable, // Input that names owners
-		[]*secp256k1.PrivateKey, // Keys that prove ownership
+		[]keychain.Signer, // Signers that prove ownership
 		error,
 	)
 }
@@ -148,7 +147,7 @@ type handler struct {
 
 func (h *handler) Spend(
 	utxoReader avax.UTXOReader,
-	keys []*secp256k1.PrivateKey,
+	kc keychain.Keychain,
 	amount uint64,
 	fee uint64,
 	changeAddr ids.ShortID,
@@ -156,27 +155,21 @@ func (h *handler) Spend(
 	[]*avax.TransferableInput, // inputs
 	[]*avax.TransferableOutput, // returnedOutputs
 	[]*avax.TransferableOutput, // stakedOutputs
-	[][]*secp256k1.PrivateKey, // signers
+	[][]keychain.Signer, // signers
 	error,
 ) {
-	addrs := set.NewSet[ids.ShortID](len(keys)) // The addresses controlled by [keys]
-	for _, key := range keys {
-		addrs.Add(key.PublicKey().Address())
-	}
-	utxos, err := avax.GetAllUTXOs(utxoReader, addrs) // The UTXOs controlled by [keys]
+	utxos, err := avax.GetAllUTXOs(utxoReader, kc.Addresses()) // The UTXOs controlled by [kc]
 	if err != nil {
 		return nil, nil, nil, nil, fmt.Errorf("couldn't get UTXOs: %w", err)
 	}
 
-	kc := secp256k1fx.NewKeychain(keys...) // Keychain consumes UTXOs and creates new ones
-
 	// Minimum time this transaction will be issued at
 	now := uint64(h.clk.Time().Unix())
 
 	ins := []*avax.TransferableInput{}
 	returnedOuts := []*avax.TransferableOutput{}
 	stakedOuts := []*avax.TransferableOutput{}
-	signers := [][]*secp256k1.PrivateKey{}
+	signers := [][]keychain.Signer{}
 
 	// Amount of AVAX that has been staked
 	amountStaked := uint64(0)
@@ -211,7 +204,7 @@ func (h *handler) Spend(
 			continue
 		}
 
-		inIntf, inSigners, err := kc.Spend(out.TransferableOut, now)
+		inIntf, inSigners, err := SpendOutput(kc, out.TransferableOut, now)
 		if err != nil {
 			// We couldn't spend the output, so move on to the next one
 			continue
@@ -304,7 +297,7 @@ func (h *handler) Spend(
 			out = inner.TransferableOut
 		}
 
-		inIntf, inSigners, err := kc.Spend(out, now)
+		inIntf, inSigners, err := SpendOutput(kc, out, now)
 		if err != nil {
 			// We couldn't spend this UTXO, so we skip to the next one
 			continue
@@ -393,10 +386,10 @@ func (h *handler) Spend(
 func (h *handler) Authorize(
 	state state.Chain,
 	subnetID ids.ID,
-	keys []*secp256k1.PrivateKey,
+	kc keychain.Keychain,
 ) (
 	verify.Verifiable, // Input that names owners
-	[]*secp256k1.PrivateKey, // Keys that prove ownership
+	[]keychain.Signer, // Signers that prove ownership
 	error,
 ) {
 	subnetOwner, err := state.GetSubnetOwner(subnetID)
@@ -408,20 +401,17 @@ func (h *handler) Authorize(
 		)
 	}
 
-	// Make sure the owners of the subnet match the provided keys
+	// Make sure the owners of the subnet match the provided keychain
 	owner, ok := subnetOwner.(*secp256k1fx.OutputOwners)
 	if !ok {
 		return nil, nil, fmt.Errorf("expected *secp256k1fx.OutputOwners but got %T", subnetOwner)
 	}
 
-	// Add the keys to a keychain
-	kc := secp256k1fx.NewKeychain(keys...)
-
 	// Make sure that the operation is valid after a minimum time
 	now := uint64(h.clk.Time().Unix())
 
 	// Attempt to prove ownership of the subnet
-	indices, signers, matches := kc.Match(owner, now)
+	indices, signers, matches := MatchOwners(kc, owner, now)
 	if !matches {
 		return nil, nil, errCantSign
 	}
@@ -0,0 +1,65 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utxo
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var errCantSpend = errors.New("unable to spend this UTXO")
+
+// SpendOutput attempts to create an input that spends [out] with the signers
+// of [kc].
+func SpendOutput(
+	kc keychain.Keychain,
+	out verify.Verifiable,
+	time uint64,
+) (verify.Verifiable, []keychain.Signer, error) {
+	switch out := out.(type) {
+	case *secp256k1fx.MintOutput:
+		if sigIndices, signers, able := MatchOwners(kc, &out.OutputOwners, time); able {
+			return &secp256k1fx.Input{
+				SigIndices: sigIndices,
+			}, signers, nil
+		}
+		return nil, nil, errCantSpend
+	case *secp256k1fx.TransferOutput:
+		if sigIndices, signers, able := MatchOwners(kc, &out.OutputOwners, time); able {
+			return &secp256k1fx.TransferInput{
+				Amt: out.Amt,
+				Input: secp256k1fx.Input{
+					SigIndices: sigIndices,
+				},
+			}, signers, nil
+		}
+		return nil, nil, errCantSpend
+	}
+	return nil, nil, fmt.Errorf("can't spend UTXO because it is unexpected type %T", out)
+}
+
+// MatchOwners attempts to match the addresses of [owners] with the signers of
+// [kc] up to the threshold of [owners].
+func MatchOwners(
+	kc keychain.Keychain,
+	owners *secp256k1fx.OutputOwners,
+	time uint64,
+) ([]uint32, []keychain.Signer, bool) {
+	if time < owners.Locktime {
+		return nil, nil, false
+	}
+	sigs := make([]uint32, 0, owners.Threshold)
+	signers := make([]keychain.Signer, 0, owners.Threshold)
+	for i := uint32(0); i < uint32(len(owners.Addrs)) && uint32(len(signers)) < owners.Threshold; i++ {
+		if signer, exists := kc.Get(owners.Addrs[i]); exists {
+			sigs = append(sigs, i)
+			signers = append(signers, signer)
+		}
+	}
+	return sigs, signers, uint32(len(signers)) == owners.Threshold
+}
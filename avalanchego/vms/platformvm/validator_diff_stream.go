@@ -0,0 +1,118 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+
+	avajson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+var _ http.Handler = (*validatorDiffStream)(nil)
+
+// APIValidatorWeightDiff is the API representation of the change of the
+// weight of a validator
+type APIValidatorWeightDiff struct {
+	NodeID   ids.NodeID     `json:"nodeID"`
+	Decrease bool           `json:"decrease"`
+	Amount   avajson.Uint64 `json:"amount"`
+}
+
+// APIValidatorPublicKeyDiff is the API representation of the change of the
+// public key of a validator
+type APIValidatorPublicKeyDiff struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	// PreviousPublicKey is the public key the validator had before the
+	// height. It is nil if the validator registered its public key at the
+	// height.
+	PreviousPublicKey *string `json:"previousPublicKey"`
+}
+
+// APIValidatorDiffs is the API representation of the changes of a validator
+// set that were applied at a height
+type APIValidatorDiffs struct {
+	SubnetID       ids.ID                      `json:"subnetID"`
+	Height         avajson.Uint64              `json:"height"`
+	WeightDiffs    []APIValidatorWeightDiff    `json:"weightDiffs"`
+	PublicKeyDiffs []APIValidatorPublicKeyDiff `json:"publicKeyDiffs"`
+}
+
+// validatorDiffStream streams the diffs of a validator set, one
+// [APIValidatorDiffs] per accepted height. The validator set is the one of the
+// subnet set by the subnetID query parameter, the primary network by default.
+type validatorDiffStream struct {
+	vm *VM
+}
+
+func (s *validatorDiffStream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	subnetID := constants.PrimaryNetworkID
+	if subnetIDStr := r.URL.Query().Get("subnetID"); subnetIDStr != "" {
+		var err error
+		subnetID, err = ids.FromString(subnetIDStr)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid subnetID: %s", err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	s.vm.streamHeights(w, r, "validatorDiffs", func(height uint64) (any, error) {
+		return s.getValidatorDiffs(subnetID, height)
+	})
+}
+
+func (s *validatorDiffStream) getValidatorDiffs(subnetID ids.ID, height uint64) (*APIValidatorDiffs, error) {
+	diffs, err := s.vm.state.GetValidatorDiffs(subnetID, height)
+	if err != nil {
+		return nil, err
+	}
+
+	apiDiffs := &APIValidatorDiffs{
+		SubnetID:       subnetID,
+		Height:         avajson.Uint64(height),
+		WeightDiffs:    make([]APIValidatorWeightDiff, 0, len(diffs.WeightDiffs)),
+		PublicKeyDiffs: make([]APIValidatorPublicKeyDiff, 0, len(diffs.PublicKeyDiffs)),
+	}
+	for nodeID, weightDiff := range diffs.WeightDiffs {
+		apiDiffs.WeightDiffs = append(apiDiffs.WeightDiffs, APIValidatorWeightDiff{
+			NodeID:   nodeID,
+			Decrease: weightDiff.Decrease,
+			Amount:   avajson.Uint64(weightDiff.Amount),
+		})
+	}
+	for nodeID, pkBytes := range diffs.PublicKeyDiffs {
+		pkDiff := APIValidatorPublicKeyDiff{
+			NodeID: nodeID,
+		}
+		if len(pkBytes) != 0 {
+			pk := bls.DeserializePublicKey(pkBytes)
+			if pk == nil {
+				return nil, fmt.Errorf("invalid public key diff of %s", nodeID)
+			}
+			pkStr, err := formatting.Encode(formatting.HexNC, bls.PublicKeyToBytes(pk))
+			if err != nil {
+				return nil, err
+			}
+			pkDiff.PreviousPublicKey = &pkStr
+		}
+		apiDiffs.PublicKeyDiffs = append(apiDiffs.PublicKeyDiffs, pkDiff)
+	}
+	utils.Sort(apiDiffs.WeightDiffs)
+	utils.Sort(apiDiffs.PublicKeyDiffs)
+	return apiDiffs, nil
+}
+
+func (d APIValidatorWeightDiff) Compare(other APIValidatorWeightDiff) int {
+	return d.NodeID.Compare(other.NodeID)
+}
+
+func (d APIValidatorPublicKeyDiff) Compare(other APIValidatorPublicKeyDiff) int {
+	return d.NodeID.Compare(other.NodeID)
+}
@@ -0,0 +1,49 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	avajson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+func TestValidatorDiffStream(t *testing.T) {
+	require := require.New(t)
+	vm, _, _ := defaultVM(t, latestFork)
+	stream := &validatorDiffStream{vm: vm}
+
+	// The genesis validators were added at height 0.
+	w := httptest.NewRecorder()
+	stream.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/validatorDiffs?startHeight=0&endHeight=0", nil))
+	require.Equal(http.StatusOK, w.Code)
+
+	decoder := json.NewDecoder(w.Body)
+	var diffs APIValidatorDiffs
+	require.NoError(decoder.Decode(&diffs))
+	require.False(decoder.More())
+
+	require.Zero(diffs.Height)
+	require.Len(diffs.WeightDiffs, len(genesisNodeIDs))
+	for _, weightDiff := range diffs.WeightDiffs {
+		require.Contains(genesisNodeIDs, weightDiff.NodeID)
+		require.False(weightDiff.Decrease)
+		require.Equal(avajson.Uint64(defaultWeight), weightDiff.Amount)
+	}
+	// The genesis validators didn't register public keys.
+	require.Empty(diffs.PublicKeyDiffs)
+
+	w = httptest.NewRecorder()
+	stream.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/validatorDiffs?startHeight=1&endHeight=0", nil))
+	require.Equal(http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	stream.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/validatorDiffs?subnetID=invalid", nil))
+	require.Equal(http.StatusBadRequest, w.Code)
+}
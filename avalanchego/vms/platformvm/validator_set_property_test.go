@@ -30,7 +30,6 @@ import (
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
-	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/utils/json"
@@ -43,6 +42,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	blockexecutor "github.com/ava-labs/avalanchego/vms/platformvm/block/executor"
 	txexecutor "github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
@@ -260,7 +260,7 @@ func addSubnetValidator(vm *VM, data *validatorInputData, subnetID ids.ID) (*sta
 		uint64(data.endTime.Unix()),
 		data.nodeID,
 		subnetID,
-		[]*secp256k1.PrivateKey{keys[0], keys[1]},
+		secp256k1fx.NewKeychain(keys[0], keys[1]),
 		addr,
 		nil,
 	)
@@ -286,7 +286,7 @@ func addPrimaryValidatorWithBLSKey(vm *VM, data *validatorInputData) (*state.Sta
 		signer.NewProofOfPossession(sk),
 		addr,
 		reward.PercentDenominator,
-		[]*secp256k1.PrivateKey{keys[0], keys[1]},
+		secp256k1fx.NewKeychain(keys[0], keys[1]),
 		addr,
 		nil,
 	)
@@ -681,7 +681,7 @@ func buildVM(t *testing.T) (*VM, ids.ID, error) {
 	testSubnet1, err = vm.txBuilder.NewCreateSubnetTx(
 		1, // threshold
 		[]ids.ShortID{keys[0].PublicKey().Address()},
-		[]*secp256k1.PrivateKey{keys[len(keys)-1]}, // pays tx fee
+		secp256k1fx.NewKeychain(keys[len(keys)-1]), // pays tx fee
 		keys[0].PublicKey().Address(),              // change addr
 		nil,
 	)
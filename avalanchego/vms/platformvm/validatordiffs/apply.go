@@ -0,0 +1,180 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validatordiffs
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/logging"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+// ApplyWeightDiffs iterates over the weight diffs of [subnetID] in [db] from
+// [startHeight] towards the genesis block until it has applied all of the
+// diffs up to and including [endHeight]. Applying the diffs modifies
+// [vdrs].
+//
+// It returns the lowest height from which the diffs were applied. If it is
+// greater than [endHeight], [db] doesn't contain the diffs of the heights in
+// [endHeight, returned height).
+//
+// Invariant: If attempting to generate the validator set for
+// [endHeight - 1], [vdrs] must initially contain the validator weights for
+// [startHeight].
+func ApplyWeightDiffs(
+	ctx context.Context,
+	log logging.Logger,
+	db database.Iteratee,
+	vdrs map[ids.NodeID]*validators.GetValidatorOutput,
+	startHeight uint64,
+	endHeight uint64,
+	subnetID ids.ID,
+) (uint64, error) {
+	diffIter := db.NewIteratorWithStartAndPrefix(
+		MarshalStartKey(subnetID, startHeight),
+		subnetID[:],
+	)
+	defer diffIter.Release()
+
+	prevHeight := startHeight + 1
+	for diffIter.Next() {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		_, parsedHeight, nodeID, err := UnmarshalKey(diffIter.Key())
+		if err != nil {
+			return 0, err
+		}
+
+		if parsedHeight > prevHeight {
+			log.Error("unexpected parsed height",
+				zap.Stringer("subnetID", subnetID),
+				zap.Uint64("parsedHeight", parsedHeight),
+				zap.Stringer("nodeID", nodeID),
+				zap.Uint64("prevHeight", prevHeight),
+				zap.Uint64("startHeight", startHeight),
+				zap.Uint64("endHeight", endHeight),
+			)
+		}
+
+		// If the parsedHeight is less than our target endHeight, then we have
+		// fully processed the diffs from startHeight through endHeight.
+		if parsedHeight < endHeight {
+			return endHeight, diffIter.Error()
+		}
+
+		prevHeight = parsedHeight
+
+		decrease, amount, err := UnmarshalWeightDiff(diffIter.Value())
+		if err != nil {
+			return 0, err
+		}
+
+		if err := ApplyWeightDiff(vdrs, nodeID, decrease, amount); err != nil {
+			return 0, err
+		}
+	}
+	return prevHeight, diffIter.Error()
+}
+
+// ApplyWeightDiff reverts the change of the weight of [nodeID] by [amount]
+// that happened at a height, so that [vdrs] holds the weights from before that
+// height.
+func ApplyWeightDiff(
+	vdrs map[ids.NodeID]*validators.GetValidatorOutput,
+	nodeID ids.NodeID,
+	decrease bool,
+	amount uint64,
+) error {
+	vdr, ok := vdrs[nodeID]
+	if !ok {
+		// This node isn't in the current validator set.
+		vdr = &validators.GetValidatorOutput{
+			NodeID: nodeID,
+		}
+		vdrs[nodeID] = vdr
+	}
+
+	// The weight of this node changed at this block.
+	var err error
+	if decrease {
+		// The validator's weight was decreased at this block, so in the
+		// prior block it was higher.
+		vdr.Weight, err = safemath.Add64(vdr.Weight, amount)
+	} else {
+		// The validator's weight was increased at this block, so in the
+		// prior block it was lower.
+		vdr.Weight, err = safemath.Sub(vdr.Weight, amount)
+	}
+	if err != nil {
+		return err
+	}
+
+	if vdr.Weight == 0 {
+		// The validator's weight was 0 before this block so they weren't in the
+		// validator set.
+		delete(vdrs, nodeID)
+	}
+	return nil
+}
+
+// ApplyPublicKeyDiffs iterates over the public key diffs in [db] from
+// [startHeight] towards the genesis block until it has applied all of the
+// diffs up to and including [endHeight]. Applying the diffs modifies [vdrs].
+//
+// Invariant: If attempting to generate the validator set for
+// [endHeight - 1], [vdrs] must initially contain the validator weights for
+// [startHeight].
+func ApplyPublicKeyDiffs(
+	ctx context.Context,
+	db database.Iteratee,
+	vdrs map[ids.NodeID]*validators.GetValidatorOutput,
+	startHeight uint64,
+	endHeight uint64,
+) error {
+	diffIter := db.NewIteratorWithStartAndPrefix(
+		MarshalStartKey(constants.PrimaryNetworkID, startHeight),
+		constants.PrimaryNetworkID[:],
+	)
+	defer diffIter.Release()
+
+	for diffIter.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		_, parsedHeight, nodeID, err := UnmarshalKey(diffIter.Key())
+		if err != nil {
+			return err
+		}
+		// If the parsedHeight is less than our target endHeight, then we have
+		// fully processed the diffs from startHeight through endHeight.
+		if parsedHeight < endHeight {
+			break
+		}
+
+		vdr, ok := vdrs[nodeID]
+		if !ok {
+			continue
+		}
+
+		pkBytes := diffIter.Value()
+		if len(pkBytes) == 0 {
+			vdr.PublicKey = nil
+			continue
+		}
+
+		vdr.PublicKey = bls.DeserializePublicKey(pkBytes)
+	}
+	return diffIter.Error()
+}
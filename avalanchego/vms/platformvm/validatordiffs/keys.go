@@ -0,0 +1,101 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validatordiffs
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+const (
+	// startKey = [subnetID] + [inverseHeight]
+	startKeyLength = ids.IDLen + database.Uint64Size
+	// key = [subnetID] + [inverseHeight] + [nodeID]
+	keyLength = startKeyLength + ids.NodeIDLen
+	// keyNodeIDOffset = [subnetIDLen] + [inverseHeightLen]
+	keyNodeIDOffset = ids.IDLen + database.Uint64Size
+
+	// weightValue = [isNegative] + [weight]
+	weightValueLength = database.BoolSize + database.Uint64Size
+)
+
+var (
+	ErrUnexpectedKeyLength         = fmt.Errorf("expected diff key length %d", keyLength)
+	ErrUnexpectedWeightValueLength = fmt.Errorf("expected weight value length %d", weightValueLength)
+)
+
+// MarshalStartKey is used to determine the starting key when iterating.
+//
+// Invariant: the result is a prefix of [MarshalKey] when called with the same
+// arguments.
+func MarshalStartKey(subnetID ids.ID, height uint64) []byte {
+	key := make([]byte, startKeyLength)
+	copy(key, subnetID[:])
+	packIterableHeight(key[ids.IDLen:], height)
+	return key
+}
+
+// MarshalKey returns the key of the diff of [nodeID] in [subnetID] at
+// [height].
+func MarshalKey(subnetID ids.ID, height uint64, nodeID ids.NodeID) []byte {
+	key := make([]byte, keyLength)
+	copy(key, subnetID[:])
+	packIterableHeight(key[ids.IDLen:], height)
+	copy(key[keyNodeIDOffset:], nodeID.Bytes())
+	return key
+}
+
+// UnmarshalKey returns the subnetID, height and nodeID of a diff key.
+func UnmarshalKey(key []byte) (ids.ID, uint64, ids.NodeID, error) {
+	if len(key) != keyLength {
+		return ids.Empty, 0, ids.EmptyNodeID, ErrUnexpectedKeyLength
+	}
+	var (
+		subnetID ids.ID
+		nodeID   ids.NodeID
+	)
+	copy(subnetID[:], key)
+	height := unpackIterableHeight(key[ids.IDLen:])
+	copy(nodeID[:], key[keyNodeIDOffset:])
+	return subnetID, height, nodeID, nil
+}
+
+// MarshalWeightDiff returns the value of a weight diff that decreased, or
+// increased, the weight of a validator by [amount].
+func MarshalWeightDiff(decrease bool, amount uint64) []byte {
+	value := make([]byte, weightValueLength)
+	if decrease {
+		value[0] = database.BoolTrue
+	}
+	binary.BigEndian.PutUint64(value[database.BoolSize:], amount)
+	return value
+}
+
+// UnmarshalWeightDiff returns whether the weight diff [value] decreased the
+// weight of a validator and by how much the weight changed.
+func UnmarshalWeightDiff(value []byte) (bool, uint64, error) {
+	if len(value) != weightValueLength {
+		return false, 0, ErrUnexpectedWeightValueLength
+	}
+	return value[0] == database.BoolTrue, binary.BigEndian.Uint64(value[database.BoolSize:]), nil
+}
+
+// Note: [height] is encoded as a bit flipped big endian number so that
+// iterating lexicographically results in iterating in decreasing heights.
+//
+// Invariant: [key] has sufficient length
+func packIterableHeight(key []byte, height uint64) {
+	binary.BigEndian.PutUint64(key, ^height)
+}
+
+// Because we bit flip the height when constructing the key, we must remember to
+// bip flip again here.
+//
+// Invariant: [key] has sufficient length
+func unpackIterableHeight(key []byte) uint64 {
+	return ^binary.BigEndian.Uint64(key)
+}
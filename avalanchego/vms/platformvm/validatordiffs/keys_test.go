@@ -1,7 +1,7 @@
 // Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
 // See the file LICENSE for licensing terms.
 
-package state
+package validatordiffs
 
 import (
 	"testing"
@@ -13,7 +13,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 )
 
-func FuzzMarshalDiffKey(f *testing.F) {
+func FuzzMarshalKey(f *testing.F) {
 	f.Fuzz(func(t *testing.T, data []byte) {
 		require := require.New(t)
 
@@ -25,8 +25,8 @@ func FuzzMarshalDiffKey(f *testing.F) {
 		fz := fuzzer.NewFuzzer(data)
 		fz.Fill(&subnetID, &height, &nodeID)
 
-		key := marshalDiffKey(subnetID, height, nodeID)
-		parsedSubnetID, parsedHeight, parsedNodeID, err := unmarshalDiffKey(key)
+		key := MarshalKey(subnetID, height, nodeID)
+		parsedSubnetID, parsedHeight, parsedNodeID, err := UnmarshalKey(key)
 		require.NoError(err)
 		require.Equal(subnetID, parsedSubnetID)
 		require.Equal(height, parsedHeight)
@@ -34,17 +34,17 @@ func FuzzMarshalDiffKey(f *testing.F) {
 	})
 }
 
-func FuzzUnmarshalDiffKey(f *testing.F) {
+func FuzzUnmarshalKey(f *testing.F) {
 	f.Fuzz(func(t *testing.T, key []byte) {
 		require := require.New(t)
 
-		subnetID, height, nodeID, err := unmarshalDiffKey(key)
+		subnetID, height, nodeID, err := UnmarshalKey(key)
 		if err != nil {
-			require.ErrorIs(err, errUnexpectedDiffKeyLength)
+			require.ErrorIs(err, ErrUnexpectedKeyLength)
 			return
 		}
 
-		formattedKey := marshalDiffKey(subnetID, height, nodeID)
+		formattedKey := MarshalKey(subnetID, height, nodeID)
 		require.Equal(key, formattedKey)
 	})
 }
@@ -60,13 +60,13 @@ func TestDiffIteration(t *testing.T) {
 	nodeID0 := ids.BuildTestNodeID([]byte{0x00})
 	nodeID1 := ids.BuildTestNodeID([]byte{0x01})
 
-	subnetID0Height0NodeID0 := marshalDiffKey(subnetID0, 0, nodeID0)
-	subnetID0Height1NodeID0 := marshalDiffKey(subnetID0, 1, nodeID0)
-	subnetID0Height1NodeID1 := marshalDiffKey(subnetID0, 1, nodeID1)
+	subnetID0Height0NodeID0 := MarshalKey(subnetID0, 0, nodeID0)
+	subnetID0Height1NodeID0 := MarshalKey(subnetID0, 1, nodeID0)
+	subnetID0Height1NodeID1 := MarshalKey(subnetID0, 1, nodeID1)
 
-	subnetID1Height0NodeID0 := marshalDiffKey(subnetID1, 0, nodeID0)
-	subnetID1Height1NodeID0 := marshalDiffKey(subnetID1, 1, nodeID0)
-	subnetID1Height1NodeID1 := marshalDiffKey(subnetID1, 1, nodeID1)
+	subnetID1Height0NodeID0 := MarshalKey(subnetID1, 0, nodeID0)
+	subnetID1Height1NodeID0 := MarshalKey(subnetID1, 1, nodeID0)
+	subnetID1Height1NodeID1 := MarshalKey(subnetID1, 1, nodeID1)
 
 	require.NoError(db.Put(subnetID0Height0NodeID0, nil))
 	require.NoError(db.Put(subnetID0Height1NodeID0, nil))
@@ -76,7 +76,7 @@ func TestDiffIteration(t *testing.T) {
 	require.NoError(db.Put(subnetID1Height1NodeID1, nil))
 
 	{
-		it := db.NewIteratorWithStartAndPrefix(marshalStartDiffKey(subnetID0, 0), subnetID0[:])
+		it := db.NewIteratorWithStartAndPrefix(MarshalStartKey(subnetID0, 0), subnetID0[:])
 		defer it.Release()
 
 		expectedKeys := [][]byte{
@@ -91,7 +91,7 @@ func TestDiffIteration(t *testing.T) {
 	}
 
 	{
-		it := db.NewIteratorWithStartAndPrefix(marshalStartDiffKey(subnetID0, 1), subnetID0[:])
+		it := db.NewIteratorWithStartAndPrefix(MarshalStartKey(subnetID0, 1), subnetID0[:])
 		defer it.Release()
 
 		expectedKeys := [][]byte{
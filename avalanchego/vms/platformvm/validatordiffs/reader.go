@@ -0,0 +1,113 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package validatordiffs encodes the validator weight and public key diffs
+// written by the P-chain state at every height, and applies them to rebuild
+// the validator sets of past heights.
+//
+// A [Reader] only requires the P-chain database, so historical validator sets
+// can be computed offline, for example from a database restored from a backup:
+//
+//	db := memdb.New()
+//	metadata, _, err := backup.Restore(f, db)
+//	...
+//	r := validatordiffs.NewReader(logging.NoLog{}, prefixdb.New(state.ValidatorsPrefix, db))
+//	vdrs, err := r.GetValidatorSet(ctx, subnetID, metadata.Height, targetHeight, subnetVdrs, primaryVdrs)
+package validatordiffs
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+var (
+	// WeightDiffsPrefix prefixes the weight diffs in the validators database
+	WeightDiffsPrefix = []byte("flatValidatorDiffs")
+	// PublicKeyDiffsPrefix prefixes the public key diffs in the validators
+	// database
+	PublicKeyDiffsPrefix = []byte("flatPublicKeyDiffs")
+)
+
+// Reader rebuilds past validator sets from the diffs in a validators database.
+//
+// Only the diffs recorded by the flat diff indices are read, so heights
+// accepted before those indices were populated can't be rebuilt.
+type Reader struct {
+	log            logging.Logger
+	weightDiffs    database.Iteratee
+	publicKeyDiffs database.Iteratee
+}
+
+// NewReader returns a Reader of the diffs in [validatorsDB], the validators
+// database of the P-chain state.
+func NewReader(log logging.Logger, validatorsDB database.Database) *Reader {
+	return &Reader{
+		log:            log,
+		weightDiffs:    prefixdb.New(WeightDiffsPrefix, validatorsDB),
+		publicKeyDiffs: prefixdb.New(PublicKeyDiffsPrefix, validatorsDB),
+	}
+}
+
+// GetValidatorSet returns the validator set of [subnetID] at [targetHeight].
+//
+// [subnetVdrs] must be the validator set of [subnetID] at [height] and, if
+// [subnetID] isn't the Primary Network, [primaryVdrs] must be the Primary
+// Network validator set at [height], which provides the public keys of the
+// subnet validators. The provided validator sets aren't modified.
+func (r *Reader) GetValidatorSet(
+	ctx context.Context,
+	subnetID ids.ID,
+	height uint64,
+	targetHeight uint64,
+	subnetVdrs map[ids.NodeID]*validators.GetValidatorOutput,
+	primaryVdrs map[ids.NodeID]*validators.GetValidatorOutput,
+) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	if height < targetHeight {
+		return nil, database.ErrNotFound
+	}
+
+	vdrs := make(map[ids.NodeID]*validators.GetValidatorOutput, len(subnetVdrs))
+	for nodeID, vdr := range subnetVdrs {
+		vdrCopy := *vdr
+		if subnetID != constants.PrimaryNetworkID {
+			vdrCopy.PublicKey = nil
+			if primaryVdr, ok := primaryVdrs[nodeID]; ok {
+				vdrCopy.PublicKey = primaryVdr.PublicKey
+			}
+		}
+		vdrs[nodeID] = &vdrCopy
+	}
+
+	// Note: Since we are attempting to generate the validator set at
+	// [targetHeight], we want to apply the diffs from
+	// (targetHeight, height]. Because the diffs are applied inclusively, we
+	// apply diffs in [targetHeight + 1, height].
+	lastDiffHeight := targetHeight + 1
+	_, err := ApplyWeightDiffs(
+		ctx,
+		r.log,
+		r.weightDiffs,
+		vdrs,
+		height,
+		lastDiffHeight,
+		subnetID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ApplyPublicKeyDiffs(
+		ctx,
+		r.publicKeyDiffs,
+		vdrs,
+		height,
+		lastDiffHeight,
+	)
+	return vdrs, err
+}
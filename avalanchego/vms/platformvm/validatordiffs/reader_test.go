@@ -0,0 +1,141 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validatordiffs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestReaderGetValidatorSet(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		validatorsDB   = memdb.New()
+		weightDiffs    = prefixdb.New(WeightDiffsPrefix, validatorsDB)
+		publicKeyDiffs = prefixdb.New(PublicKeyDiffsPrefix, validatorsDB)
+
+		subnetID = ids.GenerateTestID()
+		nodeID0  = ids.GenerateTestNodeID()
+		nodeID1  = ids.GenerateTestNodeID()
+	)
+	sk0, err := bls.NewSecretKey()
+	require.NoError(err)
+	pk0 := bls.PublicFromSecretKey(sk0)
+	sk1, err := bls.NewSecretKey()
+	require.NoError(err)
+	pk1 := bls.PublicFromSecretKey(sk1)
+
+	// Height 1: nodeID0 joins the Primary Network with pk0
+	require.NoError(weightDiffs.Put(
+		MarshalKey(constants.PrimaryNetworkID, 1, nodeID0),
+		MarshalWeightDiff(false, 10),
+	))
+	require.NoError(publicKeyDiffs.Put(
+		MarshalKey(constants.PrimaryNetworkID, 1, nodeID0),
+		nil,
+	))
+	// Height 2: nodeID1 joins the Primary Network and the subnet
+	require.NoError(weightDiffs.Put(
+		MarshalKey(constants.PrimaryNetworkID, 2, nodeID1),
+		MarshalWeightDiff(false, 20),
+	))
+	require.NoError(publicKeyDiffs.Put(
+		MarshalKey(constants.PrimaryNetworkID, 2, nodeID1),
+		nil,
+	))
+	require.NoError(weightDiffs.Put(
+		MarshalKey(subnetID, 2, nodeID1),
+		MarshalWeightDiff(false, 5),
+	))
+	// Height 3: nodeID0 gets a delegation
+	require.NoError(weightDiffs.Put(
+		MarshalKey(constants.PrimaryNetworkID, 3, nodeID0),
+		MarshalWeightDiff(false, 3),
+	))
+
+	var (
+		primaryVdrs = map[ids.NodeID]*validators.GetValidatorOutput{
+			nodeID0: {
+				NodeID:    nodeID0,
+				PublicKey: pk0,
+				Weight:    13,
+			},
+			nodeID1: {
+				NodeID:    nodeID1,
+				PublicKey: pk1,
+				Weight:    20,
+			},
+		}
+		subnetVdrs = map[ids.NodeID]*validators.GetValidatorOutput{
+			nodeID1: {
+				NodeID: nodeID1,
+				Weight: 5,
+			},
+		}
+		r   = NewReader(logging.NoLog{}, validatorsDB)
+		ctx = context.Background()
+	)
+
+	vdrs, err := r.GetValidatorSet(ctx, constants.PrimaryNetworkID, 3, 2, primaryVdrs, nil)
+	require.NoError(err)
+	require.Equal(map[ids.NodeID]*validators.GetValidatorOutput{
+		nodeID0: {
+			NodeID:    nodeID0,
+			PublicKey: pk0,
+			Weight:    10,
+		},
+		nodeID1: {
+			NodeID:    nodeID1,
+			PublicKey: pk1,
+			Weight:    20,
+		},
+	}, vdrs)
+
+	vdrs, err = r.GetValidatorSet(ctx, constants.PrimaryNetworkID, 3, 1, primaryVdrs, nil)
+	require.NoError(err)
+	require.Equal(map[ids.NodeID]*validators.GetValidatorOutput{
+		nodeID0: {
+			NodeID:    nodeID0,
+			PublicKey: pk0,
+			Weight:    10,
+		},
+	}, vdrs)
+
+	vdrs, err = r.GetValidatorSet(ctx, constants.PrimaryNetworkID, 3, 0, primaryVdrs, nil)
+	require.NoError(err)
+	require.Empty(vdrs)
+
+	vdrs, err = r.GetValidatorSet(ctx, subnetID, 3, 2, subnetVdrs, primaryVdrs)
+	require.NoError(err)
+	require.Equal(map[ids.NodeID]*validators.GetValidatorOutput{
+		nodeID1: {
+			NodeID:    nodeID1,
+			PublicKey: pk1,
+			Weight:    5,
+		},
+	}, vdrs)
+
+	vdrs, err = r.GetValidatorSet(ctx, subnetID, 3, 1, subnetVdrs, primaryVdrs)
+	require.NoError(err)
+	require.Empty(vdrs)
+
+	// The provided validator sets are left untouched
+	require.Equal(uint64(13), primaryVdrs[nodeID0].Weight)
+	require.Nil(subnetVdrs[nodeID1].PublicKey)
+
+	_, err = r.GetValidatorSet(ctx, constants.PrimaryNetworkID, 3, 4, primaryVdrs, nil)
+	require.ErrorIs(err, database.ErrNotFound)
+}
@@ -82,22 +82,35 @@ type State interface {
 		startHeight uint64,
 		endHeight uint64,
 	) error
+
+	// GetValidatorSetCheckpoint returns the validator set of [subnetID] at the
+	// lowest checkpointed height that is greater than or equal to [height],
+	// along with that height. If no such checkpoint exists,
+	// [database.ErrNotFound] is returned.
+	GetValidatorSetCheckpoint(
+		subnetID ids.ID,
+		height uint64,
+	) (uint64, map[ids.NodeID]*validators.GetValidatorOutput, error)
 }
 
+// NewManager returns a validator manager. If [recentSets] is non-nil, it is
+// used to serve the validator sets that were recently generated.
 func NewManager(
 	log logging.Logger,
 	cfg config.Config,
 	state State,
 	metrics metrics.Metrics,
 	clk *mockable.Clock,
+	recentSets *RecentSetStore,
 ) Manager {
 	return &manager{
-		log:     log,
-		cfg:     cfg,
-		state:   state,
-		metrics: metrics,
-		clk:     clk,
-		caches:  make(map[ids.ID]cache.Cacher[uint64, map[ids.NodeID]*validators.GetValidatorOutput]),
+		log:        log,
+		cfg:        cfg,
+		state:      state,
+		metrics:    metrics,
+		clk:        clk,
+		recentSets: recentSets,
+		caches:     make(map[ids.ID]cache.Cacher[uint64, map[ids.NodeID]*validators.GetValidatorOutput]),
 		recentlyAccepted: window.New[ids.ID](
 			window.Config{
 				Clock:   clk,
@@ -118,6 +131,10 @@ type manager struct {
 	metrics metrics.Metrics
 	clk     *mockable.Clock
 
+	// Optional store of serialized validator sets shared by all tracked
+	// subnets.
+	recentSets *RecentSetStore
+
 	// Maps caches for each subnet that is currently tracked.
 	// Key: Subnet ID
 	// Value: cache mapping height -> validator set map
@@ -193,18 +210,27 @@ func (m *manager) GetValidatorSet(
 		return validatorSet, nil
 	}
 
+	useRecentSets := m.recentSets != nil && m.isTracked(subnetID)
+	if useRecentSets {
+		if validatorSet, ok := m.recentSets.Get(subnetID, targetHeight); ok {
+			validatorSetsCache.Put(targetHeight, validatorSet)
+			m.metrics.IncValidatorSetsCached()
+			return validatorSet, nil
+		}
+	}
+
 	// get the start time to track metrics
 	startTime := m.clk.Time()
 
 	var (
-		validatorSet  map[ids.NodeID]*validators.GetValidatorOutput
-		currentHeight uint64
-		err           error
+		validatorSet map[ids.NodeID]*validators.GetValidatorOutput
+		startHeight  uint64
+		err          error
 	)
 	if subnetID == constants.PrimaryNetworkID {
-		validatorSet, currentHeight, err = m.makePrimaryNetworkValidatorSet(ctx, targetHeight)
+		validatorSet, startHeight, err = m.makePrimaryNetworkValidatorSet(ctx, targetHeight)
 	} else {
-		validatorSet, currentHeight, err = m.makeSubnetValidatorSet(ctx, targetHeight, subnetID)
+		validatorSet, startHeight, err = m.makeSubnetValidatorSet(ctx, targetHeight, subnetID)
 	}
 	if err != nil {
 		return nil, err
@@ -212,17 +238,20 @@ func (m *manager) GetValidatorSet(
 
 	// cache the validator set
 	validatorSetsCache.Put(targetHeight, validatorSet)
+	if useRecentSets {
+		m.recentSets.Put(subnetID, targetHeight, validatorSet)
+	}
 
 	duration := m.clk.Time().Sub(startTime)
 	m.metrics.IncValidatorSetsCreated()
 	m.metrics.AddValidatorSetsDuration(duration)
-	m.metrics.AddValidatorSetsHeightDiff(currentHeight - targetHeight)
+	m.metrics.AddValidatorSetsHeightDiff(subnetID, startHeight-targetHeight)
 	return validatorSet, nil
 }
 
 func (m *manager) getValidatorSetCache(subnetID ids.ID) cache.Cacher[uint64, map[ids.NodeID]*validators.GetValidatorOutput] {
 	// Only cache tracked subnets
-	if subnetID != constants.PrimaryNetworkID && !m.cfg.TrackedSubnets.Contains(subnetID) {
+	if !m.isTracked(subnetID) {
 		return &cache.Empty[uint64, map[ids.NodeID]*validators.GetValidatorOutput]{}
 	}
 
@@ -238,6 +267,10 @@ func (m *manager) getValidatorSetCache(subnetID ids.ID) cache.Cacher[uint64, map
 	return validatorSetsCache
 }
 
+func (m *manager) isTracked(subnetID ids.ID) bool {
+	return subnetID == constants.PrimaryNetworkID || m.cfg.TrackedSubnets.Contains(subnetID)
+}
+
 func (m *manager) makePrimaryNetworkValidatorSet(
 	ctx context.Context,
 	targetHeight uint64,
@@ -250,17 +283,27 @@ func (m *manager) makePrimaryNetworkValidatorSet(
 		return nil, 0, database.ErrNotFound
 	}
 
+	validatorSet, startHeight, err := m.getClosestValidatorSet(
+		targetHeight,
+		constants.PrimaryNetworkID,
+		validatorSet,
+		currentHeight,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	// Rebuild primary network validators at [targetHeight]
 	//
 	// Note: Since we are attempting to generate the validator set at
 	// [targetHeight], we want to apply the diffs from
-	// (targetHeight, currentHeight]. Because the state interface is implemented
-	// to be inclusive, we apply diffs in [targetHeight + 1, currentHeight].
+	// (targetHeight, startHeight]. Because the state interface is implemented
+	// to be inclusive, we apply diffs in [targetHeight + 1, startHeight].
 	lastDiffHeight := targetHeight + 1
 	err = m.state.ApplyValidatorWeightDiffs(
 		ctx,
 		validatorSet,
-		currentHeight,
+		startHeight,
 		lastDiffHeight,
 		constants.PlatformChainID,
 	)
@@ -271,10 +314,10 @@ func (m *manager) makePrimaryNetworkValidatorSet(
 	err = m.state.ApplyValidatorPublicKeyDiffs(
 		ctx,
 		validatorSet,
-		currentHeight,
+		startHeight,
 		lastDiffHeight,
 	)
-	return validatorSet, currentHeight, err
+	return validatorSet, startHeight, err
 }
 
 func (m *manager) getCurrentPrimaryValidatorSet(
@@ -298,17 +341,28 @@ func (m *manager) makeSubnetValidatorSet(
 		return nil, 0, database.ErrNotFound
 	}
 
+	subnetValidatorSet, primaryValidatorSet, startHeight, err := m.getClosestValidatorSets(
+		targetHeight,
+		subnetID,
+		subnetValidatorSet,
+		primaryValidatorSet,
+		currentHeight,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	// Rebuild subnet validators at [targetHeight]
 	//
 	// Note: Since we are attempting to generate the validator set at
 	// [targetHeight], we want to apply the diffs from
-	// (targetHeight, currentHeight]. Because the state interface is implemented
-	// to be inclusive, we apply diffs in [targetHeight + 1, currentHeight].
+	// (targetHeight, startHeight]. Because the state interface is implemented
+	// to be inclusive, we apply diffs in [targetHeight + 1, startHeight].
 	lastDiffHeight := targetHeight + 1
 	err = m.state.ApplyValidatorWeightDiffs(
 		ctx,
 		subnetValidatorSet,
-		currentHeight,
+		startHeight,
 		lastDiffHeight,
 		subnetID,
 	)
@@ -317,10 +371,10 @@ func (m *manager) makeSubnetValidatorSet(
 	}
 
 	// Update the subnet validator set to include the public keys at
-	// [currentHeight]. When we apply the public key diffs, we will convert
+	// [startHeight]. When we apply the public key diffs, we will convert
 	// these keys to represent the public keys at [targetHeight]. If the subnet
-	// validator is not currently a primary network validator, it doesn't have a
-	// key at [currentHeight].
+	// validator is not a primary network validator at [startHeight], it
+	// doesn't have a key at [startHeight].
 	for nodeID, vdr := range subnetValidatorSet {
 		if primaryVdr, ok := primaryValidatorSet[nodeID]; ok {
 			vdr.PublicKey = primaryVdr.PublicKey
@@ -332,10 +386,69 @@ func (m *manager) makeSubnetValidatorSet(
 	err = m.state.ApplyValidatorPublicKeyDiffs(
 		ctx,
 		subnetValidatorSet,
-		currentHeight,
+		startHeight,
 		lastDiffHeight,
 	)
-	return subnetValidatorSet, currentHeight, err
+	return subnetValidatorSet, startHeight, err
+}
+
+// getClosestValidatorSet returns the validator set, and its height, that
+// requires the fewest diffs to be applied to generate the validator set at
+// [targetHeight]. If there is no checkpoint between [targetHeight] and
+// [currentHeight], the current validator set is returned.
+func (m *manager) getClosestValidatorSet(
+	targetHeight uint64,
+	subnetID ids.ID,
+	currentValidatorSet map[ids.NodeID]*validators.GetValidatorOutput,
+	currentHeight uint64,
+) (map[ids.NodeID]*validators.GetValidatorOutput, uint64, error) {
+	checkpointHeight, checkpoint, err := m.state.GetValidatorSetCheckpoint(subnetID, targetHeight)
+	switch {
+	case err == database.ErrNotFound:
+		return currentValidatorSet, currentHeight, nil
+	case err != nil:
+		return nil, 0, err
+	case checkpointHeight >= currentHeight:
+		return currentValidatorSet, currentHeight, nil
+	default:
+		return checkpoint, checkpointHeight, nil
+	}
+}
+
+// getClosestValidatorSets is the subnet equivalent of getClosestValidatorSet.
+// The returned Primary Network validator set is at the same height as the
+// returned subnet validator set.
+func (m *manager) getClosestValidatorSets(
+	targetHeight uint64,
+	subnetID ids.ID,
+	currentSubnetValidatorSet map[ids.NodeID]*validators.GetValidatorOutput,
+	currentPrimaryValidatorSet map[ids.NodeID]*validators.GetValidatorOutput,
+	currentHeight uint64,
+) (map[ids.NodeID]*validators.GetValidatorOutput, map[ids.NodeID]*validators.GetValidatorOutput, uint64, error) {
+	subnetValidatorSet, startHeight, err := m.getClosestValidatorSet(
+		targetHeight,
+		subnetID,
+		currentSubnetValidatorSet,
+		currentHeight,
+	)
+	if err != nil || startHeight == currentHeight {
+		return subnetValidatorSet, currentPrimaryValidatorSet, startHeight, err
+	}
+
+	// Checkpoints of all subnets are written at the same heights, so the
+	// Primary Network checkpoint should always exist.
+	primaryHeight, primaryValidatorSet, err := m.state.GetValidatorSetCheckpoint(
+		constants.PrimaryNetworkID,
+		startHeight,
+	)
+	switch {
+	case err == database.ErrNotFound || (err == nil && primaryHeight != startHeight):
+		return currentSubnetValidatorSet, currentPrimaryValidatorSet, currentHeight, nil
+	case err != nil:
+		return nil, nil, 0, err
+	default:
+		return subnetValidatorSet, primaryValidatorSet, startHeight, nil
+	}
 }
 
 func (m *manager) getCurrentValidatorSets(
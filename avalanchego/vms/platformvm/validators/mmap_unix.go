@@ -0,0 +1,26 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build !windows
+// +build !windows
+
+package validators
+
+import (
+	"os"
+	"syscall"
+)
+
+func mmap(file *os.File, size int) ([]byte, error) {
+	return syscall.Mmap(
+		int(file.Fd()),
+		0,
+		size,
+		syscall.PROT_READ|syscall.PROT_WRITE,
+		syscall.MAP_SHARED,
+	)
+}
+
+func munmap(data []byte) error {
+	return syscall.Munmap(data)
+}
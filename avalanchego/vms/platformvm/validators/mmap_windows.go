@@ -0,0 +1,19 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build windows
+// +build windows
+
+package validators
+
+import "os"
+
+// mmap falls back to an in-memory buffer on windows. The file is still created
+// so that the on-disk layout matches other platforms.
+func mmap(_ *os.File, size int) ([]byte, error) {
+	return make([]byte, size), nil
+}
+
+func munmap([]byte) error {
+	return nil
+}
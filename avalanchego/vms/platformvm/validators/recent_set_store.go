@@ -0,0 +1,267 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validators
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"os"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/perms"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	// RecentSetStoreSlotSize is the maximum size of a serialized validator
+	// set, including its header, that can be held by a [RecentSetStore].
+	RecentSetStoreSlotSize = 256 * 1024
+
+	// slotHeader = [subnetID] + [height] + [sequence] + [payloadLength] + [checksum]
+	slotHeaderLength   = ids.IDLen + wrappers.LongLen + wrappers.LongLen + wrappers.IntLen + wrappers.IntLen
+	slotHeightOffset   = ids.IDLen
+	slotSequenceOffset = slotHeightOffset + wrappers.LongLen
+	slotLengthOffset   = slotSequenceOffset + wrappers.LongLen
+	slotChecksumOffset = slotLengthOffset + wrappers.IntLen
+
+	// validator = [nodeID] + [weight] + [publicKey length] + [publicKey]
+	maxPublicKeyLength = bls.PublicKeyLen * 2
+)
+
+var errInvalidSlotCount = errors.New("number of slots must be positive")
+
+type recentSetKey struct {
+	subnetID ids.ID
+	height   uint64
+}
+
+// RecentSetStore is a fixed capacity ring of serialized validator sets that is
+// backed by a memory mapped file.
+//
+// Because the validator set at a given height never changes, entries are never
+// invalidated; they are only overwritten by newer entries once the ring wraps
+// around. The contents of the file are kept across restarts, so that the sets
+// generated before a restart don't need to be regenerated. The store is safe
+// for concurrent use and does not require the P-chain's context lock to be
+// held.
+type RecentSetStore struct {
+	lock  sync.RWMutex
+	file  *os.File
+	data  []byte
+	slots map[recentSetKey]int
+	// keys[i] is the key currently held in slot i, if used[i] is true
+	keys []recentSetKey
+	used []bool
+	next int
+	// sequence of the most recently written slot
+	sequence uint64
+}
+
+// NewRecentSetStore opens the store at [path] that holds up to [numSlots]
+// validator sets. The validator sets previously written to [path] are served
+// by the returned store.
+func NewRecentSetStore(path string, numSlots int) (*RecentSetStore, error) {
+	if numSlots <= 0 {
+		return nil, errInvalidSlotCount
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, perms.ReadWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	// If [numSlots] changed since the last run, slots are either dropped from
+	// or added to the end of the file. The remaining slots are still valid.
+	size := numSlots * RecentSetStoreSlotSize
+	if err := file.Truncate(int64(size)); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	data, err := mmap(file, size)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	s := &RecentSetStore{
+		file:  file,
+		data:  data,
+		slots: make(map[recentSetKey]int, numSlots),
+		keys:  make([]recentSetKey, numSlots),
+		used:  make([]bool, numSlots),
+	}
+	s.load()
+	return s, nil
+}
+
+// load indexes the slots that were written by a previous run. Slots whose
+// checksum doesn't match their contents, such as slots that were being written
+// when the node stopped, are ignored.
+func (s *RecentSetStore) load() {
+	for slotIndex := range s.keys {
+		slot := s.slot(slotIndex)
+		sequence := binary.BigEndian.Uint64(slot[slotSequenceOffset:])
+		if sequence == 0 {
+			continue
+		}
+		length := binary.BigEndian.Uint32(slot[slotLengthOffset:])
+		if int(length) > RecentSetStoreSlotSize-slotHeaderLength {
+			continue
+		}
+		checksum := binary.BigEndian.Uint32(slot[slotChecksumOffset:])
+		if checksum != slotChecksum(slot, length) {
+			continue
+		}
+
+		var key recentSetKey
+		copy(key.subnetID[:], slot)
+		key.height = binary.BigEndian.Uint64(slot[slotHeightOffset:])
+		if _, ok := s.slots[key]; ok {
+			continue
+		}
+
+		s.slots[key] = slotIndex
+		s.keys[slotIndex] = key
+		s.used[slotIndex] = true
+		if sequence > s.sequence {
+			s.sequence = sequence
+			s.next = (slotIndex + 1) % len(s.keys)
+		}
+	}
+}
+
+// Get returns the validator set of [subnetID] at [height] if it is held by the
+// store. The returned map is owned by the caller.
+func (s *RecentSetStore) Get(subnetID ids.ID, height uint64) (map[ids.NodeID]*validators.GetValidatorOutput, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	slotIndex, ok := s.slots[recentSetKey{
+		subnetID: subnetID,
+		height:   height,
+	}]
+	if !ok {
+		return nil, false
+	}
+
+	slot := s.slot(slotIndex)
+	length := binary.BigEndian.Uint32(slot[slotLengthOffset:])
+	vdrs, err := unpackValidatorSet(slot[slotHeaderLength : slotHeaderLength+int(length)])
+	return vdrs, err == nil
+}
+
+// Put adds the validator set of [subnetID] at [height] to the store, evicting
+// the oldest entry if the store is full. Validator sets that are too large to
+// fit into a slot are not stored.
+func (s *RecentSetStore) Put(
+	subnetID ids.ID,
+	height uint64,
+	vdrs map[ids.NodeID]*validators.GetValidatorOutput,
+) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := recentSetKey{
+		subnetID: subnetID,
+		height:   height,
+	}
+	if _, ok := s.slots[key]; ok {
+		return
+	}
+
+	slotIndex := s.next
+	if s.used[slotIndex] {
+		delete(s.slots, s.keys[slotIndex])
+		s.used[slotIndex] = false
+	}
+
+	// Mark the slot as empty before overwriting it, so that a partially
+	// written slot is never loaded after a restart.
+	slot := s.slot(slotIndex)
+	binary.BigEndian.PutUint64(slot[slotSequenceOffset:], 0)
+
+	p := wrappers.Packer{
+		MaxSize: RecentSetStoreSlotSize - slotHeaderLength,
+		Bytes:   slot[slotHeaderLength:slotHeaderLength],
+	}
+	packValidatorSet(&p, vdrs)
+	if p.Errored() {
+		// The slot was left unreferenced, so it's fine that it may now contain
+		// a partially written validator set.
+		return
+	}
+
+	length := uint32(p.Offset)
+	s.sequence++
+	copy(slot, subnetID[:])
+	binary.BigEndian.PutUint64(slot[slotHeightOffset:], height)
+	binary.BigEndian.PutUint32(slot[slotLengthOffset:], length)
+	binary.BigEndian.PutUint32(slot[slotChecksumOffset:], slotChecksum(slot, length))
+	binary.BigEndian.PutUint64(slot[slotSequenceOffset:], s.sequence)
+
+	s.slots[key] = slotIndex
+	s.keys[slotIndex] = key
+	s.used[slotIndex] = true
+	s.next = (slotIndex + 1) % len(s.keys)
+}
+
+// Close unmaps and closes the backing file.
+func (s *RecentSetStore) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.slots = nil
+	return utils.Err(
+		munmap(s.data),
+		s.file.Close(),
+	)
+}
+
+func (s *RecentSetStore) slot(index int) []byte {
+	start := index * RecentSetStoreSlotSize
+	return s.data[start : start+RecentSetStoreSlotSize : start+RecentSetStoreSlotSize]
+}
+
+// slotChecksum returns the checksum of the key and the payload of [slot].
+func slotChecksum(slot []byte, length uint32) uint32 {
+	checksum := crc32.NewIEEE()
+	_, _ = checksum.Write(slot[:slotSequenceOffset])
+	_, _ = checksum.Write(slot[slotHeaderLength : slotHeaderLength+int(length)])
+	return checksum.Sum32()
+}
+
+func packValidatorSet(p *wrappers.Packer, vdrs map[ids.NodeID]*validators.GetValidatorOutput) {
+	p.PackInt(uint32(len(vdrs)))
+	for nodeID, vdr := range vdrs {
+		p.PackFixedBytes(nodeID.Bytes())
+		p.PackLong(vdr.Weight)
+		if vdr.PublicKey == nil {
+			p.PackBytes(nil)
+		} else {
+			p.PackBytes(bls.SerializePublicKey(vdr.PublicKey))
+		}
+	}
+}
+
+func unpackValidatorSet(b []byte) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	p := wrappers.Packer{Bytes: b}
+	numValidators := p.UnpackInt()
+	vdrs := make(map[ids.NodeID]*validators.GetValidatorOutput, numValidators)
+	for i := uint32(0); i < numValidators && !p.Errored(); i++ {
+		vdr := &validators.GetValidatorOutput{}
+		copy(vdr.NodeID[:], p.UnpackFixedBytes(ids.NodeIDLen))
+		vdr.Weight = p.UnpackLong()
+		if pkBytes := p.UnpackLimitedBytes(maxPublicKeyLength); len(pkBytes) != 0 {
+			vdr.PublicKey = bls.DeserializePublicKey(pkBytes)
+		}
+		vdrs[vdr.NodeID] = vdr
+	}
+	return vdrs, p.Err
+}
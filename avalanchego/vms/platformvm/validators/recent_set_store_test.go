@@ -0,0 +1,187 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validators
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+)
+
+func newTestRecentSetStore(t *testing.T, numSlots int) *RecentSetStore {
+	require := require.New(t)
+
+	s, err := NewRecentSetStore(filepath.Join(t.TempDir(), "sets"), numSlots)
+	require.NoError(err)
+	t.Cleanup(func() {
+		require.NoError(s.Close())
+	})
+	return s
+}
+
+func TestRecentSetStoreGetPut(t *testing.T) {
+	require := require.New(t)
+
+	s := newTestRecentSetStore(t, 2)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	var (
+		subnetID = ids.GenerateTestID()
+		nodeID0  = ids.GenerateTestNodeID()
+		nodeID1  = ids.GenerateTestNodeID()
+		vdrs     = map[ids.NodeID]*validators.GetValidatorOutput{
+			nodeID0: {
+				NodeID:    nodeID0,
+				PublicKey: bls.PublicFromSecretKey(sk),
+				Weight:    1,
+			},
+			nodeID1: {
+				NodeID: nodeID1,
+				Weight: 2,
+			},
+		}
+	)
+
+	_, ok := s.Get(subnetID, 1)
+	require.False(ok)
+
+	s.Put(subnetID, 1, vdrs)
+
+	gotVdrs, ok := s.Get(subnetID, 1)
+	require.True(ok)
+	require.Equal(vdrs, gotVdrs)
+
+	// The store is keyed by both the subnetID and the height.
+	_, ok = s.Get(subnetID, 2)
+	require.False(ok)
+	_, ok = s.Get(ids.GenerateTestID(), 1)
+	require.False(ok)
+}
+
+func TestRecentSetStoreEviction(t *testing.T) {
+	require := require.New(t)
+
+	s := newTestRecentSetStore(t, 2)
+
+	var (
+		subnetID = ids.GenerateTestID()
+		nodeID   = ids.GenerateTestNodeID()
+	)
+	for height := uint64(1); height <= 3; height++ {
+		s.Put(subnetID, height, map[ids.NodeID]*validators.GetValidatorOutput{
+			nodeID: {
+				NodeID: nodeID,
+				Weight: height,
+			},
+		})
+	}
+
+	_, ok := s.Get(subnetID, 1)
+	require.False(ok)
+
+	for height := uint64(2); height <= 3; height++ {
+		vdrs, ok := s.Get(subnetID, height)
+		require.True(ok)
+		require.Equal(height, vdrs[nodeID].Weight)
+	}
+}
+
+func TestRecentSetStoreOversizedSet(t *testing.T) {
+	require := require.New(t)
+
+	s := newTestRecentSetStore(t, 1)
+
+	subnetID := ids.GenerateTestID()
+	s.Put(subnetID, 1, map[ids.NodeID]*validators.GetValidatorOutput{})
+
+	vdrs := make(map[ids.NodeID]*validators.GetValidatorOutput)
+	for len(vdrs)*(ids.NodeIDLen+8) < RecentSetStoreSlotSize {
+		nodeID := ids.GenerateTestNodeID()
+		vdrs[nodeID] = &validators.GetValidatorOutput{
+			NodeID: nodeID,
+			Weight: 1,
+		}
+	}
+	s.Put(subnetID, 2, vdrs)
+
+	// The oversized set must not be served, and the set that previously
+	// occupied the slot has been evicted.
+	_, ok := s.Get(subnetID, 2)
+	require.False(ok)
+	_, ok = s.Get(subnetID, 1)
+	require.False(ok)
+
+	// The slot must still be usable.
+	s.Put(subnetID, 3, map[ids.NodeID]*validators.GetValidatorOutput{})
+	gotVdrs, ok := s.Get(subnetID, 3)
+	require.True(ok)
+	require.Empty(gotVdrs)
+}
+
+func TestRecentSetStoreReopen(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "sets")
+	s, err := NewRecentSetStore(path, 2)
+	require.NoError(err)
+
+	var (
+		subnetID = ids.GenerateTestID()
+		nodeID   = ids.GenerateTestNodeID()
+	)
+	putHeight := func(s *RecentSetStore, height uint64) {
+		s.Put(subnetID, height, map[ids.NodeID]*validators.GetValidatorOutput{
+			nodeID: {
+				NodeID: nodeID,
+				Weight: height,
+			},
+		})
+	}
+	for height := uint64(1); height <= 3; height++ {
+		putHeight(s, height)
+	}
+	require.NoError(s.Close())
+
+	// The sets written before the restart are still served.
+	s, err = NewRecentSetStore(path, 2)
+	require.NoError(err)
+	for height := uint64(2); height <= 3; height++ {
+		vdrs, ok := s.Get(subnetID, height)
+		require.True(ok)
+		require.Equal(height, vdrs[nodeID].Weight)
+	}
+
+	// The oldest set is still the next one to be evicted.
+	putHeight(s, 4)
+	_, ok := s.Get(subnetID, 2)
+	require.False(ok)
+	_, ok = s.Get(subnetID, 3)
+	require.True(ok)
+
+	// Corrupt the payload of the slot holding height 4.
+	slot := s.slot(s.slots[recentSetKey{subnetID: subnetID, height: 4}])
+	slot[slotHeaderLength]++
+	require.NoError(s.Close())
+
+	// Corrupted slots are ignored.
+	s, err = NewRecentSetStore(path, 2)
+	require.NoError(err)
+	_, ok = s.Get(subnetID, 4)
+	require.False(ok)
+	_, ok = s.Get(subnetID, 3)
+	require.True(ok)
+	require.NoError(s.Close())
+}
+
+func TestNewRecentSetStoreInvalidSize(t *testing.T) {
+	_, err := NewRecentSetStore(filepath.Join(t.TempDir(), "sets"), 0)
+	require.ErrorIs(t, err, errInvalidSlotCount)
+}
@@ -0,0 +1,92 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validatorset
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+var _ p2p.Handler = (*Handler)(nil)
+
+// State returns the canonical validator sets that are served.
+type State interface {
+	GetCanonicalValidatorSet(
+		ctx context.Context,
+		height uint64,
+		subnetID ids.ID,
+	) ([]*warp.Validator, uint64, error)
+}
+
+// Handler serves the canonical validator sets of subnets to any peer, so that
+// nodes that don't sync the P-chain can verify warp messages. Each response is
+// signed with the BLS key of this node.
+type Handler struct {
+	p2p.NoOpHandler
+
+	networkID uint32
+	chainID   ids.ID
+	log       logging.Logger
+	signer    warp.Signer
+	state     State
+}
+
+func NewHandler(
+	networkID uint32,
+	chainID ids.ID,
+	log logging.Logger,
+	signer warp.Signer,
+	state State,
+) *Handler {
+	return &Handler{
+		networkID: networkID,
+		chainID:   chainID,
+		log:       log,
+		signer:    signer,
+		state:     state,
+	}
+}
+
+func (h *Handler) AppRequest(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	_ time.Time,
+	requestBytes []byte,
+) ([]byte, error) {
+	request := &Request{}
+	if _, err := Codec.Unmarshal(requestBytes, request); err != nil {
+		h.log.Debug("dropping invalid validator set request",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	vdrs, totalWeight, err := h.state.GetCanonicalValidatorSet(ctx, request.Height, request.SubnetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get validator set of subnet %s at height %d: %w", request.SubnetID, request.Height, err)
+	}
+
+	response := &Response{
+		Set: *NewSet(request.Height, request.SubnetID, vdrs, totalWeight),
+	}
+	msg, err := response.Set.UnsignedMessage(h.networkID, h.chainID)
+	if err != nil {
+		return nil, err
+	}
+	sigBytes, err := h.signer.Sign(msg)
+	if err != nil {
+		return nil, err
+	}
+	copy(response.Signature[:], sigBytes)
+	return Codec.Marshal(CodecVersion, response)
+}
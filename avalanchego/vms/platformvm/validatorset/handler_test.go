@@ -0,0 +1,101 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validatorset
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+const networkID = constants.UnitTestID
+
+var errUnknownHeight = errors.New("unknown height")
+
+type testState map[uint64][]*warp.Validator
+
+func (s testState) GetCanonicalValidatorSet(_ context.Context, height uint64, _ ids.ID) ([]*warp.Validator, uint64, error) {
+	vdrs, ok := s[height]
+	if !ok {
+		return nil, 0, errUnknownHeight
+	}
+	totalWeight, err := warp.SumWeight(vdrs)
+	return vdrs, totalWeight, err
+}
+
+func TestHandler(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		chainID  = constants.PlatformChainID
+		subnetID = ids.GenerateTestID()
+		vdrs     = make([]*warp.Validator, 2)
+	)
+	for i := range vdrs {
+		sk, err := bls.NewSecretKey()
+		require.NoError(err)
+		pk := bls.PublicFromSecretKey(sk)
+		vdrs[i] = &warp.Validator{
+			PublicKey:      pk,
+			PublicKeyBytes: bls.PublicKeyToBytes(pk),
+			Weight:         uint64(i + 1),
+			NodeIDs:        []ids.NodeID{ids.GenerateTestNodeID()},
+		}
+	}
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	h := NewHandler(
+		networkID,
+		chainID,
+		logging.NoLog{},
+		warp.NewSigner(sk, networkID, chainID),
+		testState{10: vdrs},
+	)
+
+	ctx := context.Background()
+	requestBytes, err := Codec.Marshal(CodecVersion, &Request{
+		Height:   10,
+		SubnetID: subnetID,
+	})
+	require.NoError(err)
+	responseBytes, err := h.AppRequest(ctx, ids.GenerateTestNodeID(), time.Time{}, requestBytes)
+	require.NoError(err)
+
+	response, err := ParseResponse(responseBytes)
+	require.NoError(err)
+	require.Equal(uint64(10), response.Set.Height)
+	require.Equal(subnetID, response.Set.SubnetID)
+	require.Equal(uint64(3), response.Set.TotalWeight)
+	require.Len(response.Set.Validators, len(vdrs))
+	for i, vdr := range vdrs {
+		require.Equal(vdr.PublicKeyBytes, response.Set.Validators[i].PublicKey[:])
+		require.Equal(vdr.Weight, response.Set.Validators[i].Weight)
+		require.Equal(vdr.NodeIDs, response.Set.Validators[i].NodeIDs)
+	}
+	require.NoError(response.Verify(networkID, chainID, bls.PublicFromSecretKey(sk)))
+
+	// The signature doesn't vouch for a modified set
+	response.Set.TotalWeight++
+	err = response.Verify(networkID, chainID, bls.PublicFromSecretKey(sk))
+	require.ErrorIs(err, errInvalidSignature)
+
+	// Sets that can't be retrieved aren't served
+	requestBytes, err = Codec.Marshal(CodecVersion, &Request{
+		Height:   11,
+		SubnetID: subnetID,
+	})
+	require.NoError(err)
+	_, err = h.AppRequest(ctx, ids.GenerateTestNodeID(), time.Time{}, requestBytes)
+	require.ErrorIs(err, errUnknownHeight)
+}
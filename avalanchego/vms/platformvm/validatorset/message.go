@@ -0,0 +1,108 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validatorset
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+)
+
+var errInvalidSignature = errors.New("invalid signature")
+
+// Request is sent to a validator to request the canonical validator set of
+// [SubnetID] at [Height].
+type Request struct {
+	Height   uint64 `serialize:"true"`
+	SubnetID ids.ID `serialize:"true"`
+}
+
+// Validator is a validator of a canonical validator set.
+type Validator struct {
+	// Compressed BLS public key of the validator
+	PublicKey [bls.PublicKeyLen]byte `serialize:"true"`
+	Weight    uint64                 `serialize:"true"`
+	// NodeIDs that registered [PublicKey]
+	NodeIDs []ids.NodeID `serialize:"true"`
+}
+
+// Set is the validator set of [SubnetID] at [Height], in the order that the
+// signers of warp messages are indexed in.
+type Set struct {
+	Height   uint64 `serialize:"true"`
+	SubnetID ids.ID `serialize:"true"`
+	// Validators with a BLS key, sorted by their uncompressed keys
+	Validators []Validator `serialize:"true"`
+	// Weight of all the validators of the subnet, including the validators
+	// without a BLS key
+	TotalWeight uint64 `serialize:"true"`
+}
+
+// NewSet returns the set of [vdrs], as returned by
+// [warp.GetCanonicalValidatorSet].
+func NewSet(height uint64, subnetID ids.ID, vdrs []*warp.Validator, totalWeight uint64) *Set {
+	s := &Set{
+		Height:      height,
+		SubnetID:    subnetID,
+		Validators:  make([]Validator, len(vdrs)),
+		TotalWeight: totalWeight,
+	}
+	for i, vdr := range vdrs {
+		s.Validators[i] = Validator{
+			Weight:  vdr.Weight,
+			NodeIDs: vdr.NodeIDs,
+		}
+		copy(s.Validators[i].PublicKey[:], bls.PublicKeyToBytes(vdr.PublicKey))
+	}
+	return s
+}
+
+// UnsignedMessage returns the warp message, sent by [chainID], that a
+// validator signs to vouch for the set. Its payload is the hash of the set.
+func (s *Set) UnsignedMessage(networkID uint32, chainID ids.ID) (*warp.UnsignedMessage, error) {
+	bytes, err := Codec.Marshal(CodecVersion, s)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := payload.NewHash(hashing.ComputeHash256Array(bytes))
+	if err != nil {
+		return nil, err
+	}
+	return warp.NewUnsignedMessage(networkID, chainID, hash.Bytes())
+}
+
+// Response is the reply to a [Request]. [Set] is signed with the BLS key of the
+// responding validator.
+type Response struct {
+	Set       Set                    `serialize:"true"`
+	Signature [bls.SignatureLen]byte `serialize:"true"`
+}
+
+// ParseResponse parses the bytes of a [Response].
+func ParseResponse(bytes []byte) (*Response, error) {
+	r := &Response{}
+	_, err := Codec.Unmarshal(bytes, r)
+	return r, err
+}
+
+// Verify that [r.Set] was signed by [publicKey], the key of the validator that
+// the request was sent to.
+func (r *Response) Verify(networkID uint32, chainID ids.ID, publicKey *bls.PublicKey) error {
+	msg, err := r.Set.UnsignedMessage(networkID, chainID)
+	if err != nil {
+		return err
+	}
+	sig, err := bls.SignatureFromBytes(r.Signature[:])
+	if err != nil {
+		return err
+	}
+	if !bls.Verify(publicKey, sig, msg.Bytes()) {
+		return errInvalidSignature
+	}
+	return nil
+}
@@ -9,6 +9,9 @@ import (
 	"fmt"
 	"math"
 	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/gorilla/rpc/v2"
@@ -16,10 +19,13 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/codec/linearcodec"
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
@@ -29,12 +35,16 @@ import (
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/perms"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
-	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/alerts"
+	"github.com/ava-labs/avalanchego/vms/platformvm/attestation"
+	"github.com/ava-labs/avalanchego/vms/platformvm/autoimport"
 	"github.com/ava-labs/avalanchego/vms/platformvm/config"
 	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/heartbeat"
 	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
 	"github.com/ava-labs/avalanchego/vms/platformvm/network"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
@@ -42,6 +52,8 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/mempool"
 	"github.com/ava-labs/avalanchego/vms/platformvm/utxo"
+	"github.com/ava-labs/avalanchego/vms/platformvm/validatorset"
+	"github.com/ava-labs/avalanchego/vms/platformvm/watchlist"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	snowmanblock "github.com/ava-labs/avalanchego/snow/engine/snowman/block"
@@ -52,10 +64,25 @@ import (
 	pvalidators "github.com/ava-labs/avalanchego/vms/platformvm/validators"
 )
 
+const recentValidatorSetsFileName = "recent_validator_sets"
+
+var (
+	droppedTxIndexPrefix   = []byte("droppedTxIndex")
+	stateAttestationPrefix = []byte("stateAttestation")
+	peerBansPrefix         = []byte("peerBans")
+	addressQuotasPrefix    = []byte("addressQuotas")
+
+	errInvalidFeeTreasuryPercentage = fmt.Errorf("fee treasury percentage must be at most %d", reward.PercentDenominator)
+	errMissingCheckpointBlockID     = errors.New("trusted checkpoint height is set without a block ID")
+	errInvalidMaxBlockComplexity    = fmt.Errorf("max block complexity must be 0 or at least %d", txs.StakerMutationComplexity)
+	errInvalidSubnetFeeAsset        = errors.New("subnet fee asset must not be AVAX")
+)
+
 var (
 	_ snowmanblock.ChainVM       = (*VM)(nil)
 	_ secp256k1fx.VM             = (*VM)(nil)
 	_ validators.State           = (*VM)(nil)
+	_ validators.CachedState     = (*VM)(nil)
 	_ validators.SubnetConnector = (*VM)(nil)
 )
 
@@ -65,10 +92,15 @@ type VM struct {
 	network.Network
 	validators.State
 
+	// Optional local policy applied to the txs issued into the mempool
+	AdmissionPolicy network.AdmissionPolicy
+
 	metrics            metrics.Metrics
 	atomicUtxosManager avax.AtomicUTXOManager
 
-	// Used to get time. Useful for faking time during tests.
+	// Used to get time. Useful for faking time during tests. Every subsystem
+	// of the VM reads the time from this clock, so that faking it moves them
+	// all together.
 	clock mockable.Clock
 
 	uptimeManager uptime.Manager
@@ -77,8 +109,52 @@ type VM struct {
 	ctx *snow.Context
 	db  database.Database
 
+	// Genesis bytes the chain was initialized with
+	genesisBytes []byte
+
+	execConfig *config.ExecutionConfig
+
 	state state.State
 
+	// Optional memory mapped store of recently generated validator sets
+	recentValidatorSets *pvalidators.RecentSetStore
+
+	// Optional persistent history of the reasons txs were dropped for
+	droppedTxIndex *mempool.DroppedTxIndex
+	// Optional reservations of the delegation capacity of validators
+	delegationReservations *mempool.Reservations
+
+	compactionScheduler *state.CompactionScheduler
+
+	// Addresses whose rewards are reported as they are paid out
+	rewardWatchlist *watchlist.Watchlist
+
+	// Certificates of the state attested by the validators
+	stateCertificates *attestation.Store
+	// Optional attester that signs the state at the attested heights
+	stateAttester *attestation.Attester
+	// Peers banned for gossiping invalid txs
+	peerBans *network.PeerBans
+	// Quotas of the txs issued through the API by each address
+	issueTxAddressQuotas *addressQuotas
+	// Peers whose messages are dropped for running an incompatible version
+	peerCompatibility *peerCompatibility
+	// Time the compatible peers connected
+	peerConnections *peerConnections
+	// Optional tracker of the heartbeats of the validators
+	heartbeats *heartbeat.Tracker
+	// Optional importer of the atomic UTXOs owned by keys of the node
+	autoImporter *autoimport.Importer
+	// Alert rules evaluated on every health check
+	alertEvaluator *alerts.Evaluator
+
+	// Keys that can authorize blocks to be force accepted through the admin
+	// API. Nil if recovery is disabled.
+	recoveryKeys *recoveryKeys
+
+	// Canonical validator sets served to signature aggregators
+	canonicalValidatorSets cache.Cacher[canonicalValidatorSetKey, *canonicalValidatorSet]
+
 	fx            fx.Fx
 	codecRegistry codec.Registry
 
@@ -92,6 +168,8 @@ type VM struct {
 	onShutdownCtx context.Context
 	// Call [onShutdownCtxCancel] to cancel [onShutdownCtx] during Shutdown()
 	onShutdownCtxCancel context.CancelFunc
+	// Tracks the goroutines started with [runUntilShutdown]
+	awaitShutdown sync.WaitGroup
 
 	// TODO: Remove after v1.11.x is activated
 	pruned utils.Atomic[bool]
@@ -112,11 +190,42 @@ func (vm *VM) Initialize(
 ) error {
 	chainCtx.Log.Verbo("initializing platform chain")
 
+	if vm.FeeTreasuryPercentage > reward.PercentDenominator {
+		return fmt.Errorf("%w: %d", errInvalidFeeTreasuryPercentage, vm.FeeTreasuryPercentage)
+	}
+	// Blocks must have room for a RewardValidatorTx, otherwise stakers could
+	// never be removed.
+	if vm.MaxBlockComplexity != 0 && vm.MaxBlockComplexity < txs.StakerMutationComplexity {
+		return fmt.Errorf("%w: %d", errInvalidMaxBlockComplexity, vm.MaxBlockComplexity)
+	}
+	for subnetID, feeAsset := range vm.SubnetFeeAssets {
+		if feeAsset.AssetID == chainCtx.AVAXAssetID || feeAsset.AssetID == ids.Empty {
+			return fmt.Errorf("%w: subnet %s", errInvalidSubnetFeeAsset, subnetID)
+		}
+	}
+
 	execConfig, err := config.GetExecutionConfig(configBytes)
 	if err != nil {
 		return err
 	}
 	chainCtx.Log.Info("using VM execution config", zap.Reflect("config", execConfig))
+	if execConfig.TrustedCheckpointHeight != 0 && execConfig.TrustedCheckpointBlockID == ids.Empty {
+		return errMissingCheckpointBlockID
+	}
+	vm.execConfig = execConfig
+
+	if execConfig.Profile != "" {
+		overrides, err := applyProfile(&vm.Config, execConfig.Profile, chainCtx.NetworkID)
+		if err != nil {
+			return err
+		}
+		if len(overrides) > 0 {
+			chainCtx.Log.Warn("profile overrode node parameters",
+				zap.String("profile", execConfig.Profile),
+				zap.Strings("parameters", overrides),
+			)
+		}
+	}
 
 	registerer := prometheus.NewRegistry()
 	if err := chainCtx.Metrics.Register(registerer); err != nil {
@@ -124,13 +233,20 @@ func (vm *VM) Initialize(
 	}
 
 	// Initialize metrics as soon as possible
-	vm.metrics, err = metrics.New("", registerer)
+	vm.metrics, err = metrics.New("", registerer, execConfig.MaxSubnetMetricLabels)
 	if err != nil {
 		return fmt.Errorf("failed to initialize metrics: %w", err)
 	}
 
 	vm.ctx = chainCtx
 	vm.db = db
+	vm.genesisBytes = genesisBytes
+
+	if execConfig.RestoreBackupPath != "" {
+		if err := vm.restoreBackup(execConfig.RestoreBackupPath); err != nil {
+			return fmt.Errorf("failed to restore backup %q: %w", execConfig.RestoreBackupPath, err)
+		}
+	}
 
 	// Note: this codec is never used to serialize anything
 	vm.codecRegistry = linearcodec.NewDefault(time.Time{})
@@ -155,11 +271,26 @@ func (vm *VM) Initialize(
 		return err
 	}
 
-	validatorManager := pvalidators.NewManager(chainCtx.Log, vm.Config, vm.state, vm.metrics, &vm.clock)
+	if execConfig.RecentValidatorSetsStoreSize > 0 {
+		if err := os.MkdirAll(chainCtx.ChainDataDir, perms.ReadWriteExecute); err != nil {
+			return fmt.Errorf("failed to create chain data directory: %w", err)
+		}
+		vm.recentValidatorSets, err = pvalidators.NewRecentSetStore(
+			filepath.Join(chainCtx.ChainDataDir, recentValidatorSetsFileName),
+			execConfig.RecentValidatorSetsStoreSize,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create recent validator sets store: %w", err)
+		}
+	}
+
+	validatorManager := pvalidators.NewManager(chainCtx.Log, vm.Config, vm.state, vm.metrics, &vm.clock, vm.recentValidatorSets)
 	vm.State = validatorManager
 	vm.atomicUtxosManager = avax.NewAtomicUTXOManager(chainCtx.SharedMemory, txs.Codec)
 	utxoHandler := utxo.NewHandler(vm.ctx, &vm.clock, vm.fx)
 	vm.uptimeManager = uptime.NewManager(vm.state, &vm.clock)
+	vm.peerCompatibility = newPeerCompatibility(chainCtx.NetworkID)
+	vm.peerConnections = newPeerConnections()
 	vm.UptimeLockedCalculator.SetCalculator(&vm.bootstrapped, &chainCtx.Lock, vm.uptimeManager)
 
 	vm.txBuilder = txbuilder.New(
@@ -183,20 +314,149 @@ func (vm *VM) Initialize(
 		Bootstrapped: &vm.bootstrapped,
 	}
 
-	mempool, err := mempool.New("mempool", registerer, toEngine)
+	deniedAddrs, err := avax.ParseServiceAddresses(
+		avax.NewAddressManager(chainCtx),
+		execConfig.DeniedAddresses,
+	)
+	if err != nil {
+		return fmt.Errorf("invalid denied addresses: %w", err)
+	}
+	if deniedAddrs.Len() > 0 {
+		txExecutorBackend.Stages = append(txExecutorBackend.Stages, txexecutor.NewAddressScreeningStage(deniedAddrs))
+	}
+	stages, err := txexecutor.GetStages(execConfig.TxStages)
+	if err != nil {
+		return fmt.Errorf("invalid tx stages: %w", err)
+	}
+	txExecutorBackend.Stages = append(txExecutorBackend.Stages, stages...)
+
+	if execConfig.DroppedTxIndexSize > 0 {
+		vm.droppedTxIndex, err = mempool.NewDroppedTxIndex(
+			prefixdb.New(droppedTxIndexPrefix, vm.db),
+			execConfig.DroppedTxIndexSize,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create dropped tx index: %w", err)
+		}
+	}
+
+	watchedAddrs, err := avax.ParseServiceAddresses(
+		avax.NewAddressManager(chainCtx),
+		execConfig.RewardWatchlist,
+	)
+	if err != nil {
+		return fmt.Errorf("invalid reward watchlist: %w", err)
+	}
+	vm.rewardWatchlist = watchlist.New(execConfig.RewardWatchlistSize, watchedAddrs.List())
+	vm.rewardWatchlist.RegisterHandler(func(accrual watchlist.Accrual) {
+		chainCtx.Log.Info("reward paid to watched address",
+			zap.Stringer("address", accrual.Address),
+			zap.Stringer("stakerTxID", accrual.StakerTxID),
+			zap.Stringer("nodeID", accrual.NodeID),
+			zap.Stringer("utxoID", accrual.UTXOID),
+			zap.Uint64("amount", accrual.Amount),
+		)
+	})
+
+	vm.recoveryKeys, err = newRecoveryKeys(
+		avax.NewAddressManager(chainCtx),
+		execConfig.RecoveryKeys,
+		execConfig.RecoveryThreshold,
+	)
+	if err != nil {
+		return fmt.Errorf("invalid recovery keys: %w", err)
+	}
+
+	vm.canonicalValidatorSets = &cache.LRU[canonicalValidatorSetKey, *canonicalValidatorSet]{
+		Size: canonicalValidatorSetsCacheSize,
+	}
+
+	vm.stateCertificates = attestation.NewStore(prefixdb.New(stateAttestationPrefix, vm.db))
+	if execConfig.StateAttestationInterval > 0 {
+		vm.stateAttester = attestation.New(
+			attestation.Config{
+				Interval:  execConfig.StateAttestationInterval,
+				NetworkID: chainCtx.NetworkID,
+				ChainID:   chainCtx.ChainID,
+				NodeID:    chainCtx.NodeID,
+			},
+			chainCtx.Log,
+			chainCtx.WarpSigner,
+			validators.NewLockedState(&chainCtx.Lock, validatorManager),
+			vm.stateCertificates,
+		)
+	}
+
+	mpool, err := mempool.New("mempool", registerer, toEngine)
 	if err != nil {
 		return fmt.Errorf("failed to create mempool: %w", err)
 	}
+	if vm.droppedTxIndex != nil {
+		mpool = mempool.NewDroppedTxRecorder(mpool, vm.droppedTxIndex, chainCtx.Log)
+	}
 
 	vm.manager = blockexecutor.NewManager(
-		mempool,
+		mpool,
 		vm.metrics,
 		vm.state,
 		txExecutorBackend,
 		validatorManager,
+		execConfig.BootstrapCommitInterval,
+		blockexecutor.Checkpoint{
+			Height:  execConfig.TrustedCheckpointHeight,
+			BlockID: execConfig.TrustedCheckpointBlockID,
+		},
+		blockexecutor.InvariantChecks{
+			Interval: execConfig.InvariantCheckInterval,
+			Fatal:    execConfig.InvariantViolationsFatal,
+		},
+		execConfig.ShadowExecutionEnabled,
+		vm.rewardWatchlist,
+		vm.stateAttester,
+		execConfig.MaxProcessingBlocks,
 	)
 
-	txVerifier := network.NewLockedTxVerifier(&txExecutorBackend.Ctx.Lock, vm.manager)
+	txTypeVerifier, err := network.NewTxTypeVerifier(execConfig.DisabledTxTypes, vm.manager)
+	if err != nil {
+		return fmt.Errorf("invalid disabled tx types: %w", err)
+	}
+	var lockedTxVerifier network.TxVerifier = txTypeVerifier
+	if execConfig.DelegationReservationDuration > 0 {
+		vm.delegationReservations = mempool.NewReservations(&vm.clock, execConfig.DelegationReservationDuration)
+		lockedTxVerifier = &reservationTxVerifier{
+			vm:         vm,
+			txVerifier: txTypeVerifier,
+		}
+	}
+	if execConfig.ReadReplicaPrimaryURI != "" {
+		lockedTxVerifier = readReplicaTxVerifier{}
+	}
+	lockedTxVerifier = &invalidTxVerifier{
+		ctx:        txExecutorBackend.Ctx,
+		txVerifier: lockedTxVerifier,
+	}
+	txVerifier := network.NewPolicyTxVerifier(
+		vm.AdmissionPolicy,
+		network.NewLockedTxVerifier(&txExecutorBackend.Ctx.Lock, lockedTxVerifier),
+	)
+	vm.peerBans, err = network.NewPeerBans(
+		chainCtx.Log,
+		prefixdb.New(peerBansPrefix, vm.db),
+		&vm.clock,
+		execConfig.Network,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to load peer bans: %w", err)
+	}
+	vm.issueTxAddressQuotas, err = newAddressQuotas(
+		prefixdb.New(addressQuotasPrefix, vm.db),
+		execConfig.IssueTxAddressQuota,
+		execConfig.IssueTxAddressQuotaPeriod,
+		&vm.clock,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to load address quotas: %w", err)
+	}
 	vm.Network, err = network.New(
 		chainCtx.Log,
 		chainCtx.NodeID,
@@ -206,8 +466,13 @@ func (vm *VM) Initialize(
 			validatorManager,
 		),
 		txVerifier,
-		mempool,
+		&rejectionMeter{
+			Mempool: mpool,
+			metrics: vm.metrics,
+		},
 		txExecutorBackend.Config.PartialSyncPrimaryNetwork,
+		txExecutorBackend.Config.TrackedSubnets,
+		vm.peerBans,
 		appSender,
 		registerer,
 		execConfig.Network,
@@ -217,16 +482,128 @@ func (vm *VM) Initialize(
 	}
 
 	vm.onShutdownCtx, vm.onShutdownCtxCancel = context.WithCancel(context.Background())
-	// TODO: Wait for this goroutine to exit during Shutdown once the platformvm
-	// has better control of the context lock.
-	go vm.Network.Gossip(vm.onShutdownCtx)
+	vm.runUntilShutdown(vm.Network.Gossip)
+
+	if vm.stateAttester != nil {
+		lastAccepted, err := vm.manager.GetStatelessBlock(vm.manager.LastAccepted())
+		if err != nil {
+			return fmt.Errorf("failed to fetch last accepted block: %w", err)
+		}
+		vm.stateAttester.SetLastAccepted(lastAccepted.Height())
+
+		if err := vm.Network.AddHandler(network.StateAttestationHandlerID, vm.stateAttester); err != nil {
+			return fmt.Errorf("failed to register state attestation handler: %w", err)
+		}
+		client := vm.Network.NewClient(network.StateAttestationHandlerID)
+		vm.runUntilShutdown(func(ctx context.Context) {
+			vm.stateAttester.Run(ctx, client)
+		})
+	}
+
+	if execConfig.HeartbeatInterval > 0 {
+		vm.heartbeats = heartbeat.New(
+			heartbeat.Config{
+				Interval:       execConfig.HeartbeatInterval,
+				NetworkID:      chainCtx.NetworkID,
+				ChainID:        chainCtx.ChainID,
+				NodeID:         chainCtx.NodeID,
+				Version:        version.Current.String(),
+				TrackedSubnets: txExecutorBackend.Config.TrackedSubnets,
+			},
+			chainCtx.Log,
+			&vm.clock,
+			chainCtx.WarpSigner,
+			vm.Validators,
+			validators.NewLockedState(&chainCtx.Lock, validatorManager),
+		)
+		if err := vm.Network.AddHandler(network.HeartbeatHandlerID, vm.heartbeats); err != nil {
+			return fmt.Errorf("failed to register heartbeat handler: %w", err)
+		}
+		client := vm.Network.NewClient(network.HeartbeatHandlerID)
+		vm.runUntilShutdown(func(ctx context.Context) {
+			vm.heartbeats.Run(ctx, client)
+		})
+	}
+
+	validatorSetHandler := p2p.NewThrottlerHandler(
+		validatorset.NewHandler(
+			chainCtx.NetworkID,
+			chainCtx.ChainID,
+			chainCtx.Log,
+			chainCtx.WarpSigner,
+			lockedCanonicalValidators{vm: vm},
+		),
+		p2p.NewSlidingWindowThrottler(validatorSetRequestThrottlingPeriod, validatorSetRequestThrottlingLimit),
+		chainCtx.Log,
+	)
+	if err := vm.Network.AddHandler(network.ValidatorSetHandlerID, validatorSetHandler); err != nil {
+		return fmt.Errorf("failed to register validator set handler: %w", err)
+	}
 
 	vm.Builder = blockbuilder.New(
-		mempool,
+		mpool,
 		txExecutorBackend,
 		vm.manager,
 	)
 
+	if execConfig.AutoImportInterval > 0 {
+		vm.autoImporter = autoimport.New(
+			autoimport.Config{
+				Interval:          execConfig.AutoImportInterval,
+				MaxTxsPerInterval: execConfig.AutoImportMaxTxsPerInterval,
+				SourceChainIDs:    []ids.ID{chainCtx.XChainID, chainCtx.CChainID},
+				LogSize:           execConfig.AutoImportLogSize,
+			},
+			chainCtx.Log,
+			&vm.clock,
+			&chainCtx.Lock,
+			vm.txBuilder,
+			vm.issueTx,
+		)
+		vm.runUntilShutdown(vm.autoImporter.Run)
+	}
+
+	var minConnectedPercentage float64
+	if execConfig.AlertLowConnectivityEnabled {
+		minConnectedPercentage = 100 * vm.UptimePercentage
+	}
+	vm.alertEvaluator, err = alerts.New(
+		alerts.Config{
+			ValidatorExpiryWindow:  execConfig.AlertValidatorExpiryWindow,
+			MinConnectedPercentage: minConnectedPercentage,
+			MaxMempoolAge:          execConfig.AlertMaxMempoolAge,
+		},
+		registerer,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to initialize alerts: %w", err)
+	}
+	vm.alertEvaluator.RegisterHandler(func(event alerts.Event) {
+		if event.Resolved {
+			chainCtx.Log.Info("alert resolved",
+				zap.String("rule", event.Rule),
+			)
+			return
+		}
+		chainCtx.Log.Warn("alert firing",
+			zap.String("rule", event.Rule),
+			zap.String("message", event.Message),
+		)
+	})
+	if len(execConfig.AlertWebhookURLs) > 0 {
+		dispatcher := alerts.NewDispatcher(
+			chainCtx.Log,
+			&http.Client{},
+			execConfig.AlertWebhookURLs,
+		)
+		vm.alertEvaluator.RegisterHandler(dispatcher.Handle)
+		vm.runUntilShutdown(dispatcher.Run)
+	}
+
+	if notifier, ok := chainCtx.SharedMemory.(atomic.Notifier); ok {
+		notifier.RegisterPutHandler(vm.onImportableUTXOs)
+	}
+
 	// Create all of the chains that the database says exist
 	if err := vm.initBlockchains(); err != nil {
 		return fmt.Errorf(
@@ -243,9 +620,49 @@ func (vm *VM) Initialize(
 		return err
 	}
 
-	// Incrementing [awaitShutdown] would cause a deadlock since
-	// [periodicallyPruneMempool] grabs the context lock.
-	go vm.periodicallyPruneMempool(execConfig.MempoolPruneFrequency)
+	vm.runUntilShutdown(func(ctx context.Context) {
+		vm.periodicallyPruneMempool(ctx, execConfig.MempoolPruneFrequency)
+	})
+
+	vm.compactionScheduler, err = state.NewCompactionScheduler(
+		chainCtx.Log,
+		&vm.clock,
+		vm.state,
+		execConfig.CompactionWindowStart,
+		execConfig.CompactionWindowLength,
+		registerer,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to initialize compaction scheduler: %w", err)
+	}
+	vm.runUntilShutdown(vm.compactionScheduler.Dispatch)
+
+	if execConfig.ReadReplicaPrimaryURI != "" {
+		replica := &readReplica{
+			vm:         vm,
+			primaryURI: execConfig.ReadReplicaPrimaryURI,
+			client:     &http.Client{},
+		}
+		vm.runUntilShutdown(replica.Run)
+	}
+
+	shouldIndexCheckpoints, err := vm.state.ShouldIndexValidatorSetCheckpoints()
+	if err != nil {
+		return fmt.Errorf(
+			"failed to check if validator set checkpoints should be indexed: %w",
+			err,
+		)
+	}
+	if shouldIndexCheckpoints {
+		vm.runUntilShutdown(func(ctx context.Context) {
+			err := vm.state.IndexValidatorSetCheckpoints(ctx, &vm.ctx.Lock, vm.ctx.Log)
+			if err != nil {
+				vm.ctx.Log.Error("validator set checkpoint indexing failed",
+					zap.Error(err),
+				)
+			}
+		})
+	}
 
 	shouldPrune, err := vm.state.ShouldPrune()
 	if err != nil {
@@ -274,13 +691,24 @@ func (vm *VM) Initialize(
 	return nil
 }
 
-func (vm *VM) periodicallyPruneMempool(frequency time.Duration) {
+// runUntilShutdown runs [f] in a goroutine that Shutdown waits for. [f] must
+// return once its context is cancelled.
+func (vm *VM) runUntilShutdown(f func(context.Context)) {
+	vm.awaitShutdown.Add(1)
+	go func() {
+		defer vm.awaitShutdown.Done()
+
+		f(vm.onShutdownCtx)
+	}()
+}
+
+func (vm *VM) periodicallyPruneMempool(ctx context.Context, frequency time.Duration) {
 	ticker := time.NewTicker(frequency)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-vm.onShutdownCtx.Done():
+		case <-ctx.Done():
 			return
 		case <-ticker.C:
 			if err := vm.pruneMempool(); err != nil {
@@ -400,6 +828,12 @@ func (vm *VM) onNormalOperationsStarted() error {
 		return err
 	}
 
+	// Read replicas only accept the blocks of their primary node, so they
+	// never build blocks.
+	if vm.execConfig.ReadReplicaPrimaryURI != "" {
+		return nil
+	}
+
 	// Start the block builder
 	vm.Builder.StartBlockTimer()
 	return nil
@@ -416,6 +850,22 @@ func (vm *VM) SetState(_ context.Context, state snow.State) error {
 	}
 }
 
+// onImportableUTXOs is called by shared memory after [sourceChainID] exported
+// UTXOs to this chain.
+//
+// Invariant: The context lock must not be grabbed here, as the exporting chain
+// may be holding its own context lock.
+func (vm *VM) onImportableUTXOs(sourceChainID ids.ID, elems []*atomic.Element) {
+	vm.ctx.Log.Debug("received importable UTXOs",
+		zap.Stringer("sourceChainID", sourceChainID),
+		zap.Int("numUTXOs", len(elems)),
+	)
+	vm.metrics.AddImportableUTXOs(sourceChainID, len(elems))
+	if vm.autoImporter != nil {
+		vm.autoImporter.Notify(sourceChainID)
+	}
+}
+
 // Shutdown this blockchain
 func (vm *VM) Shutdown(context.Context) error {
 	if vm.db == nil {
@@ -425,6 +875,12 @@ func (vm *VM) Shutdown(context.Context) error {
 	vm.onShutdownCtxCancel()
 	vm.Builder.ShutdownBlockTimer()
 
+	// Shutdown is called with the context lock held, which the goroutines may
+	// be waiting for before they can notice that they should exit.
+	vm.ctx.Lock.Unlock()
+	vm.awaitShutdown.Wait()
+	vm.ctx.Lock.Lock()
+
 	if vm.bootstrapped.Get() {
 		primaryVdrIDs := vm.Validators.GetValidatorIDs(constants.PrimaryNetworkID)
 		if err := vm.uptimeManager.StopTracking(primaryVdrIDs, constants.PrimaryNetworkID); err != nil {
@@ -437,8 +893,15 @@ func (vm *VM) Shutdown(context.Context) error {
 				return err
 			}
 		}
+	}
+
+	// Blocks accepted during bootstrapping may not have been committed yet.
+	if err := vm.state.Commit(); err != nil {
+		return err
+	}
 
-		if err := vm.state.Commit(); err != nil {
+	if vm.recentValidatorSets != nil {
+		if err := vm.recentValidatorSets.Close(); err != nil {
 			return err
 		}
 	}
@@ -450,13 +913,7 @@ func (vm *VM) Shutdown(context.Context) error {
 }
 
 func (vm *VM) ParseBlock(_ context.Context, b []byte) (snowman.Block, error) {
-	// Note: blocks to be parsed are not verified, so we must used blocks.Codec
-	// rather than blocks.GenesisCodec
-	statelessBlk, err := block.Parse(block.Codec, b)
-	if err != nil {
-		return nil, err
-	}
-	return vm.manager.NewBlock(statelessBlk), nil
+	return vm.manager.ParseBlock(b)
 }
 
 func (vm *VM) GetBlock(_ context.Context, blkID ids.ID) (snowman.Block, error) {
@@ -489,28 +946,70 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]http.Handler, error) {
 	server.RegisterCodec(json.NewCodec(), "application/json;charset=UTF-8")
 	server.RegisterInterceptFunc(vm.metrics.InterceptRequest)
 	server.RegisterAfterFunc(vm.metrics.AfterRequest)
+	addrManager := avax.NewAddressManager(vm.ctx)
+	allowLists, err := newAddressAllowLists(addrManager, vm.execConfig.APIAddressAllowLists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the API address allow lists: %w", err)
+	}
 	service := &Service{
 		vm:          vm,
-		addrManager: avax.NewAddressManager(vm.ctx),
+		addrManager: addrManager,
 		stakerAttributesCache: &cache.LRU[ids.ID, *stakerAttributes]{
 			Size: stakerAttributesCacheSize,
 		},
+		addressAllowLists: allowLists,
+		issueTxQuotas: newIPQuotas(
+			vm.execConfig.IssueTxIPQuota,
+			vm.execConfig.IssueTxIPQuotaPeriod,
+			&vm.clock,
+		),
+	}
+	if err := server.RegisterService(service, "platform"); err != nil {
+		return nil, err
+	}
+
+	handlers := map[string]http.Handler{
+		"":                server,
+		"/validatorDiffs": &validatorDiffStream{vm: vm},
+		"/acceptedBlocks": &acceptedBlockStream{vm: vm},
+	}
+	if !vm.execConfig.AdminAPIEnabled {
+		return handlers, nil
 	}
-	err := server.RegisterService(service, "platform")
-	return map[string]http.Handler{
-		"": server,
-	}, err
+
+	adminServer := rpc.NewServer()
+	adminServer.RegisterCodec(json.NewCodec(), "application/json")
+	adminServer.RegisterCodec(json.NewCodec(), "application/json;charset=UTF-8")
+	adminServer.RegisterInterceptFunc(vm.metrics.InterceptRequest)
+	adminServer.RegisterAfterFunc(vm.metrics.AfterRequest)
+	err = adminServer.RegisterService(&AdminService{vm: vm}, "platformAdmin")
+	handlers["/admin"] = adminServer
+	return handlers, err
 }
 
-func (vm *VM) Connected(_ context.Context, nodeID ids.NodeID, _ *version.Application) error {
+func (vm *VM) Connected(_ context.Context, nodeID ids.NodeID, nodeVersion *version.Application) error {
+	if err := vm.peerCompatibility.connect(nodeID, nodeVersion, vm.clock.Time()); err != nil {
+		// Returning the error would shut down the chain, so the peer is only
+		// excluded from the uptimes and its messages are dropped.
+		vm.ctx.Log.Warn("ignoring incompatible peer",
+			zap.Error(err),
+		)
+		return nil
+	}
+	vm.peerConnections.connect(nodeID, vm.clock.Time())
 	return vm.uptimeManager.Connect(nodeID, constants.PrimaryNetworkID)
 }
 
 func (vm *VM) ConnectedSubnet(_ context.Context, nodeID ids.NodeID, subnetID ids.ID) error {
+	if vm.peerCompatibility.isIncompatible(nodeID) {
+		return nil
+	}
 	return vm.uptimeManager.Connect(nodeID, subnetID)
 }
 
 func (vm *VM) Disconnected(_ context.Context, nodeID ids.NodeID) error {
+	vm.peerCompatibility.disconnect(nodeID)
+	vm.peerConnections.disconnect(nodeID)
 	if err := vm.uptimeManager.Disconnect(nodeID); err != nil {
 		return err
 	}
@@ -541,6 +1040,15 @@ func (vm *VM) GetBlockIDAtHeight(_ context.Context, height uint64) (ids.ID, erro
 	return vm.state.GetBlockIDAtHeight(height)
 }
 
+// GetCachedValidatorSet only reads the store of recently generated validator
+// sets, so it doesn't require the context lock to be held.
+func (vm *VM) GetCachedValidatorSet(height uint64, subnetID ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, bool) {
+	if vm.recentValidatorSets == nil {
+		return nil, false
+	}
+	return vm.recentValidatorSets.Get(subnetID, height)
+}
+
 func (vm *VM) issueTx(ctx context.Context, tx *txs.Tx) error {
 	err := vm.Network.IssueTx(ctx, tx)
 	if err != nil && !errors.Is(err, mempool.ErrDuplicateTx) {
@@ -69,7 +69,7 @@ func TestAddDelegatorTxOverDelegatedRegression(t *testing.T) {
 		nodeID,
 		changeAddr,
 		reward.PercentDenominator,
-		[]*secp256k1.PrivateKey{keys[0]},
+		secp256k1fx.NewKeychain(keys[0]),
 		changeAddr,
 		nil,
 	)
@@ -104,7 +104,7 @@ func TestAddDelegatorTxOverDelegatedRegression(t *testing.T) {
 		uint64(firstDelegatorEndTime.Unix()),
 		nodeID,
 		changeAddr,
-		[]*secp256k1.PrivateKey{keys[0], keys[1]},
+		secp256k1fx.NewKeychain(keys[0], keys[1]),
 		changeAddr,
 		nil,
 	)
@@ -141,7 +141,7 @@ func TestAddDelegatorTxOverDelegatedRegression(t *testing.T) {
 		uint64(secondDelegatorEndTime.Unix()),
 		nodeID,
 		changeAddr,
-		[]*secp256k1.PrivateKey{keys[0], keys[1], keys[3]},
+		secp256k1fx.NewKeychain(keys[0], keys[1], keys[3]),
 		changeAddr,
 		nil,
 	)
@@ -168,7 +168,7 @@ func TestAddDelegatorTxOverDelegatedRegression(t *testing.T) {
 		uint64(thirdDelegatorEndTime.Unix()),
 		nodeID,
 		changeAddr,
-		[]*secp256k1.PrivateKey{keys[0], keys[1], keys[4]},
+		secp256k1fx.NewKeychain(keys[0], keys[1], keys[4]),
 		changeAddr,
 		nil,
 	)
@@ -241,7 +241,7 @@ func TestAddDelegatorTxHeapCorruption(t *testing.T) {
 				nodeID,
 				id,
 				reward.PercentDenominator,
-				[]*secp256k1.PrivateKey{keys[0], keys[1]},
+				secp256k1fx.NewKeychain(keys[0], keys[1]),
 				changeAddr,
 				nil,
 			)
@@ -266,7 +266,7 @@ func TestAddDelegatorTxHeapCorruption(t *testing.T) {
 				uint64(delegator1EndTime.Unix()),
 				nodeID,
 				keys[0].PublicKey().Address(),
-				[]*secp256k1.PrivateKey{keys[0], keys[1]},
+				secp256k1fx.NewKeychain(keys[0], keys[1]),
 				changeAddr,
 				nil,
 			)
@@ -291,7 +291,7 @@ func TestAddDelegatorTxHeapCorruption(t *testing.T) {
 				uint64(delegator2EndTime.Unix()),
 				nodeID,
 				keys[0].PublicKey().Address(),
-				[]*secp256k1.PrivateKey{keys[0], keys[1]},
+				secp256k1fx.NewKeychain(keys[0], keys[1]),
 				changeAddr,
 				nil,
 			)
@@ -316,7 +316,7 @@ func TestAddDelegatorTxHeapCorruption(t *testing.T) {
 				uint64(delegator3EndTime.Unix()),
 				nodeID,
 				keys[0].PublicKey().Address(),
-				[]*secp256k1.PrivateKey{keys[0], keys[1]},
+				secp256k1fx.NewKeychain(keys[0], keys[1]),
 				changeAddr,
 				nil,
 			)
@@ -341,7 +341,7 @@ func TestAddDelegatorTxHeapCorruption(t *testing.T) {
 				uint64(delegator4EndTime.Unix()),
 				nodeID,
 				keys[0].PublicKey().Address(),
-				[]*secp256k1.PrivateKey{keys[0], keys[1]},
+				secp256k1fx.NewKeychain(keys[0], keys[1]),
 				changeAddr,
 				nil,
 			)
@@ -419,7 +419,7 @@ func TestUnverifiedParentPanicRegression(t *testing.T) {
 	addSubnetTx0, err := vm.txBuilder.NewCreateSubnetTx(
 		1,
 		[]ids.ShortID{addr0},
-		[]*secp256k1.PrivateKey{key0},
+		secp256k1fx.NewKeychain(key0),
 		addr0,
 		nil,
 	)
@@ -428,7 +428,7 @@ func TestUnverifiedParentPanicRegression(t *testing.T) {
 	addSubnetTx1, err := vm.txBuilder.NewCreateSubnetTx(
 		1,
 		[]ids.ShortID{addr1},
-		[]*secp256k1.PrivateKey{key1},
+		secp256k1fx.NewKeychain(key1),
 		addr1,
 		nil,
 	)
@@ -437,7 +437,7 @@ func TestUnverifiedParentPanicRegression(t *testing.T) {
 	addSubnetTx2, err := vm.txBuilder.NewCreateSubnetTx(
 		1,
 		[]ids.ShortID{addr1},
-		[]*secp256k1.PrivateKey{key1},
+		secp256k1fx.NewKeychain(key1),
 		addr0,
 		nil,
 	)
@@ -511,7 +511,7 @@ func TestRejectedStateRegressionInvalidValidatorTimestamp(t *testing.T) {
 		nodeID,
 		ids.GenerateTestShortID(),
 		reward.PercentDenominator,
-		[]*secp256k1.PrivateKey{keys[0]},
+		secp256k1fx.NewKeychain(keys[0]),
 		ids.ShortEmpty,
 		nil,
 	)
@@ -720,7 +720,7 @@ func TestRejectedStateRegressionInvalidValidatorReward(t *testing.T) {
 		nodeID0,
 		ids.GenerateTestShortID(),
 		reward.PercentDenominator,
-		[]*secp256k1.PrivateKey{keys[0]},
+		secp256k1fx.NewKeychain(keys[0]),
 		ids.ShortEmpty,
 		nil,
 	)
@@ -893,7 +893,7 @@ func TestRejectedStateRegressionInvalidValidatorReward(t *testing.T) {
 		nodeID1,
 		ids.GenerateTestShortID(),
 		reward.PercentDenominator,
-		[]*secp256k1.PrivateKey{keys[1]},
+		secp256k1fx.NewKeychain(keys[1]),
 		ids.ShortEmpty,
 		nil,
 	)
@@ -1049,7 +1049,7 @@ func TestValidatorSetAtCacheOverwriteRegression(t *testing.T) {
 		extraNodeID,
 		ids.GenerateTestShortID(),
 		reward.PercentDenominator,
-		[]*secp256k1.PrivateKey{keys[0]},
+		secp256k1fx.NewKeychain(keys[0]),
 		ids.GenerateTestShortID(),
 		nil,
 	)
@@ -1171,7 +1171,7 @@ func TestAddDelegatorTxAddBeforeRemove(t *testing.T) {
 		nodeID,
 		id,
 		reward.PercentDenominator,
-		[]*secp256k1.PrivateKey{keys[0], keys[1]},
+		secp256k1fx.NewKeychain(keys[0], keys[1]),
 		changeAddr,
 		nil,
 	)
@@ -1196,7 +1196,7 @@ func TestAddDelegatorTxAddBeforeRemove(t *testing.T) {
 		uint64(delegator1EndTime.Unix()),
 		nodeID,
 		keys[0].PublicKey().Address(),
-		[]*secp256k1.PrivateKey{keys[0], keys[1]},
+		secp256k1fx.NewKeychain(keys[0], keys[1]),
 		changeAddr,
 		nil,
 	)
@@ -1221,7 +1221,7 @@ func TestAddDelegatorTxAddBeforeRemove(t *testing.T) {
 		uint64(delegator2EndTime.Unix()),
 		nodeID,
 		keys[0].PublicKey().Address(),
-		[]*secp256k1.PrivateKey{keys[0], keys[1]},
+		secp256k1fx.NewKeychain(keys[0], keys[1]),
 		changeAddr,
 		nil,
 	)
@@ -1259,7 +1259,7 @@ func TestRemovePermissionedValidatorDuringPendingToCurrentTransitionNotTracked(t
 		nodeID,
 		id,
 		reward.PercentDenominator,
-		[]*secp256k1.PrivateKey{keys[0], keys[1]},
+		secp256k1fx.NewKeychain(keys[0], keys[1]),
 		changeAddr,
 		nil,
 	)
@@ -1279,7 +1279,7 @@ func TestRemovePermissionedValidatorDuringPendingToCurrentTransitionNotTracked(t
 	createSubnetTx, err := vm.txBuilder.NewCreateSubnetTx(
 		1,
 		[]ids.ShortID{changeAddr},
-		[]*secp256k1.PrivateKey{keys[0], keys[1]},
+		secp256k1fx.NewKeychain(keys[0], keys[1]),
 		changeAddr,
 		nil,
 	)
@@ -1302,7 +1302,7 @@ func TestRemovePermissionedValidatorDuringPendingToCurrentTransitionNotTracked(t
 		uint64(validatorEndTime.Unix()),
 		nodeID,
 		createSubnetTx.ID(),
-		[]*secp256k1.PrivateKey{keys[0], keys[1]},
+		secp256k1fx.NewKeychain(keys[0], keys[1]),
 		changeAddr,
 		nil,
 	)
@@ -1330,7 +1330,7 @@ func TestRemovePermissionedValidatorDuringPendingToCurrentTransitionNotTracked(t
 	removeSubnetValidatorTx, err := vm.txBuilder.NewRemoveSubnetValidatorTx(
 		nodeID,
 		createSubnetTx.ID(),
-		[]*secp256k1.PrivateKey{keys[0], keys[1]},
+		secp256k1fx.NewKeychain(keys[0], keys[1]),
 		changeAddr,
 		nil,
 	)
@@ -1384,7 +1384,7 @@ func TestRemovePermissionedValidatorDuringPendingToCurrentTransitionTracked(t *t
 		nodeID,
 		id,
 		reward.PercentDenominator,
-		[]*secp256k1.PrivateKey{keys[0], keys[1]},
+		secp256k1fx.NewKeychain(keys[0], keys[1]),
 		changeAddr,
 		nil,
 	)
@@ -1404,7 +1404,7 @@ func TestRemovePermissionedValidatorDuringPendingToCurrentTransitionTracked(t *t
 	createSubnetTx, err := vm.txBuilder.NewCreateSubnetTx(
 		1,
 		[]ids.ShortID{changeAddr},
-		[]*secp256k1.PrivateKey{keys[0], keys[1]},
+		secp256k1fx.NewKeychain(keys[0], keys[1]),
 		changeAddr,
 		nil,
 	)
@@ -1427,7 +1427,7 @@ func TestRemovePermissionedValidatorDuringPendingToCurrentTransitionTracked(t *t
 		uint64(validatorEndTime.Unix()),
 		nodeID,
 		createSubnetTx.ID(),
-		[]*secp256k1.PrivateKey{keys[0], keys[1]},
+		secp256k1fx.NewKeychain(keys[0], keys[1]),
 		changeAddr,
 		nil,
 	)
@@ -1447,7 +1447,7 @@ func TestRemovePermissionedValidatorDuringPendingToCurrentTransitionTracked(t *t
 	removeSubnetValidatorTx, err := vm.txBuilder.NewRemoveSubnetValidatorTx(
 		nodeID,
 		createSubnetTx.ID(),
-		[]*secp256k1.PrivateKey{keys[0], keys[1]},
+		secp256k1fx.NewKeychain(keys[0], keys[1]),
 		changeAddr,
 		nil,
 	)
@@ -1512,7 +1512,7 @@ func TestSubnetValidatorBLSKeyDiffAfterExpiry(t *testing.T) {
 		signer.NewProofOfPossession(sk1),
 		addr, // reward address
 		reward.PercentDenominator,
-		keys,
+		secp256k1fx.NewKeychain(keys...),
 		addr, // change address
 		nil,
 	)
@@ -1543,7 +1543,7 @@ func TestSubnetValidatorBLSKeyDiffAfterExpiry(t *testing.T) {
 		uint64(subnetEndTime.Unix()),   // end time
 		nodeID,                         // Node ID
 		subnetID,
-		[]*secp256k1.PrivateKey{keys[0], keys[1]},
+		secp256k1fx.NewKeychain(keys[0], keys[1]),
 		addr,
 		nil,
 	)
@@ -1618,7 +1618,7 @@ func TestSubnetValidatorBLSKeyDiffAfterExpiry(t *testing.T) {
 		signer.NewProofOfPossession(sk2),
 		addr, // reward address
 		reward.PercentDenominator,
-		keys,
+		secp256k1fx.NewKeychain(keys...),
 		addr, // change address
 		nil,
 	)
@@ -1726,7 +1726,7 @@ func TestPrimaryNetworkValidatorPopulatedToEmptyBLSKeyDiff(t *testing.T) {
 		nodeID,
 		addr,
 		reward.PercentDenominator,
-		[]*secp256k1.PrivateKey{keys[0]},
+		secp256k1fx.NewKeychain(keys[0]),
 		addr,
 		nil,
 	)
@@ -1788,7 +1788,7 @@ func TestPrimaryNetworkValidatorPopulatedToEmptyBLSKeyDiff(t *testing.T) {
 		signer.NewProofOfPossession(sk2),
 		addr, // reward address
 		reward.PercentDenominator,
-		keys,
+		secp256k1fx.NewKeychain(keys...),
 		addr, // change address
 		nil,
 	)
@@ -1859,7 +1859,7 @@ func TestSubnetValidatorPopulatedToEmptyBLSKeyDiff(t *testing.T) {
 		nodeID,
 		addr,
 		reward.PercentDenominator,
-		[]*secp256k1.PrivateKey{keys[0]},
+		secp256k1fx.NewKeychain(keys[0]),
 		addr,
 		nil,
 	)
@@ -1889,7 +1889,7 @@ func TestSubnetValidatorPopulatedToEmptyBLSKeyDiff(t *testing.T) {
 		uint64(subnetEndTime.Unix()),   // end time
 		nodeID,                         // Node ID
 		subnetID,
-		[]*secp256k1.PrivateKey{keys[0], keys[1]},
+		secp256k1fx.NewKeychain(keys[0], keys[1]),
 		addr,
 		nil,
 	)
@@ -1963,7 +1963,7 @@ func TestSubnetValidatorPopulatedToEmptyBLSKeyDiff(t *testing.T) {
 		signer.NewProofOfPossession(sk2),
 		addr, // reward address
 		reward.PercentDenominator,
-		keys,
+		secp256k1fx.NewKeychain(keys...),
 		addr, // change address
 		nil,
 	)
@@ -2041,7 +2041,7 @@ func TestSubnetValidatorSetAfterPrimaryNetworkValidatorRemoval(t *testing.T) {
 		nodeID,
 		addr,
 		reward.PercentDenominator,
-		[]*secp256k1.PrivateKey{keys[0]},
+		secp256k1fx.NewKeychain(keys[0]),
 		addr,
 		nil,
 	)
@@ -2068,7 +2068,7 @@ func TestSubnetValidatorSetAfterPrimaryNetworkValidatorRemoval(t *testing.T) {
 		uint64(subnetEndTime.Unix()),   // end time
 		nodeID,                         // Node ID
 		subnetID,
-		[]*secp256k1.PrivateKey{keys[0], keys[1]},
+		secp256k1fx.NewKeychain(keys[0], keys[1]),
 		addr,
 		nil,
 	)
@@ -55,8 +55,10 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/api"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/offline"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
@@ -234,25 +236,33 @@ func defaultVM(t *testing.T, f fork) (*VM, database.Database, *mutableSharedMemo
 	}
 
 	vm := &VM{Config: config.Config{
-		Chains:                 chains.TestManager,
-		UptimeLockedCalculator: uptime.NewLockedCalculator(),
-		SybilProtectionEnabled: true,
-		Validators:             validators.NewManager(),
-		TxFee:                  defaultTxFee,
-		CreateSubnetTxFee:      100 * defaultTxFee,
-		TransformSubnetTxFee:   100 * defaultTxFee,
-		CreateBlockchainTxFee:  100 * defaultTxFee,
-		MinValidatorStake:      defaultMinValidatorStake,
-		MaxValidatorStake:      defaultMaxValidatorStake,
-		MinDelegatorStake:      defaultMinDelegatorStake,
-		MinStakeDuration:       defaultMinStakingDuration,
-		MaxStakeDuration:       defaultMaxStakingDuration,
-		RewardConfig:           defaultRewardConfig,
-		ApricotPhase3Time:      apricotPhase3Time,
-		ApricotPhase5Time:      apricotPhase5Time,
-		BanffTime:              banffTime,
-		CortinaTime:            cortinaTime,
-		DurangoTime:            durangoTime,
+		Chains:                    chains.TestManager,
+		UptimeLockedCalculator:    uptime.NewLockedCalculator(),
+		SybilProtectionEnabled:    true,
+		Validators:                validators.NewManager(),
+		TxFee:                     defaultTxFee,
+		CreateSubnetTxFee:         100 * defaultTxFee,
+		TransformSubnetTxFee:      100 * defaultTxFee,
+		CreateBlockchainTxFee:     100 * defaultTxFee,
+		MinValidatorStake:         defaultMinValidatorStake,
+		MaxValidatorStake:         defaultMaxValidatorStake,
+		MinDelegatorStake:         defaultMinDelegatorStake,
+		MinStakeDuration:          defaultMinStakingDuration,
+		MaxStakeDuration:          defaultMaxStakingDuration,
+		RewardConfig:              defaultRewardConfig,
+		ApricotPhase3Time:         apricotPhase3Time,
+		ApricotPhase5Time:         apricotPhase5Time,
+		BanffTime:                 banffTime,
+		CortinaTime:               cortinaTime,
+		DurangoTime:               durangoTime,
+		SubnetAllowListTime:       durangoTime,
+		CappedDelegationTime:      durangoTime,
+		ExitValidatorTime:         durangoTime,
+		SubnetValidatorWeightTime: durangoTime,
+		ParameterChangeTime:       durangoTime,
+		RekeyValidatorTime:        durangoTime,
+		NameRegistryTime:          durangoTime,
+		ValidatorMetadataTime:     durangoTime,
 	}}
 
 	db := memdb.New()
@@ -278,7 +288,12 @@ func defaultVM(t *testing.T, f fork) (*VM, database.Database, *mutableSharedMemo
 		return nil
 	}
 
-	dynamicConfigBytes := []byte(`{"network":{"max-validator-set-staleness":0}}`)
+	// The invariants of the state are verified after every accepted block
+	dynamicConfigBytes := []byte(`{
+		"network": {"max-validator-set-staleness": 0},
+		"invariant-check-interval": 1,
+		"invariant-violations-fatal": true
+	}`)
 	require.NoError(vm.Initialize(
 		context.Background(),
 		ctx,
@@ -304,7 +319,7 @@ func defaultVM(t *testing.T, f fork) (*VM, database.Database, *mutableSharedMemo
 		2, // threshold; 2 sigs from keys[0], keys[1], keys[2] needed to add validator to this subnet
 		// control keys are keys[0], keys[1], keys[2]
 		[]ids.ShortID{keys[0].PublicKey().Address(), keys[1].PublicKey().Address(), keys[2].PublicKey().Address()},
-		[]*secp256k1.PrivateKey{keys[0]}, // pays tx fee
+		secp256k1fx.NewKeychain(keys[0]), // pays tx fee
 		keys[0].PublicKey().Address(),    // change addr
 		nil,
 	)
@@ -407,7 +422,7 @@ func TestAddValidatorCommit(t *testing.T) {
 		signer.NewProofOfPossession(sk),
 		rewardAddress,
 		reward.PercentDenominator,
-		[]*secp256k1.PrivateKey{keys[0]},
+		secp256k1fx.NewKeychain(keys[0]),
 		ids.ShortEmpty, // change addr
 		nil,
 	)
@@ -452,7 +467,7 @@ func TestInvalidAddValidatorCommit(t *testing.T) {
 		nodeID,
 		ids.GenerateTestShortID(),
 		reward.PercentDenominator,
-		[]*secp256k1.PrivateKey{keys[0]},
+		secp256k1fx.NewKeychain(keys[0]),
 		ids.ShortEmpty, // change addr
 		nil,
 	)
@@ -506,7 +521,7 @@ func TestAddValidatorReject(t *testing.T) {
 		nodeID,
 		rewardAddress,
 		reward.PercentDenominator,
-		[]*secp256k1.PrivateKey{keys[0]},
+		secp256k1fx.NewKeychain(keys[0]),
 		ids.ShortEmpty, // change addr
 		nil,
 	)
@@ -555,7 +570,7 @@ func TestAddValidatorInvalidNotReissued(t *testing.T) {
 		signer.NewProofOfPossession(sk),
 		ids.GenerateTestShortID(),
 		reward.PercentDenominator,
-		[]*secp256k1.PrivateKey{keys[0]},
+		secp256k1fx.NewKeychain(keys[0]),
 		ids.ShortEmpty, // change addr
 		nil,
 	)
@@ -590,7 +605,7 @@ func TestAddSubnetValidatorAccept(t *testing.T) {
 		uint64(endTime.Unix()),
 		nodeID,
 		testSubnet1.ID(),
-		[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
 		ids.ShortEmpty, // change addr
 		nil,
 	)
@@ -638,7 +653,7 @@ func TestAddSubnetValidatorReject(t *testing.T) {
 		uint64(endTime.Unix()),
 		nodeID,
 		testSubnet1.ID(),
-		[]*secp256k1.PrivateKey{testSubnet1ControlKeys[1], testSubnet1ControlKeys[2]},
+		secp256k1fx.NewKeychain(testSubnet1ControlKeys[1], testSubnet1ControlKeys[2]),
 		ids.ShortEmpty, // change addr
 		nil,
 	)
@@ -729,6 +744,21 @@ func TestRewardValidatorAccept(t *testing.T) {
 	valTx, _ := tx.Unsigned.(*txs.AddValidatorTx)
 	_, err = vm.state.GetCurrentValidator(constants.PrimaryNetworkID, valTx.NodeID())
 	require.ErrorIs(err, database.ErrNotFound)
+
+	// Verify that the decision was recorded in the reward history
+	receipts, err := vm.state.GetRewardReceipts(valTx.NodeID(), 0, commit.Height())
+	require.NoError(err)
+	require.Len(receipts, 1)
+	receipt := receipts[0]
+	require.Equal(tx.ID(), receipt.StakerTxID)
+	require.Equal(constants.PrimaryNetworkID, receipt.SubnetID)
+	require.Equal(commit.Height(), receipt.Height)
+	require.Equal(uint64(timestamp.Unix()), receipt.Timestamp)
+	require.True(receipt.Rewarded)
+	require.Equal(state.RewardCauseUptimeSufficient, receipt.Cause)
+	require.Equal(receipt.PotentialReward, receipt.Reward)
+
+	requireConformance(t, vm)
 }
 
 // Test case where primary network validator not rewarded
@@ -799,6 +829,15 @@ func TestRewardValidatorReject(t *testing.T) {
 	valTx, _ := tx.Unsigned.(*txs.AddValidatorTx)
 	_, err = vm.state.GetCurrentValidator(constants.PrimaryNetworkID, valTx.NodeID())
 	require.ErrorIs(err, database.ErrNotFound)
+
+	// Verify that the aborted reward was recorded in the reward history
+	receipts, err := vm.state.GetRewardReceipts(valTx.NodeID(), 0, abort.Height())
+	require.NoError(err)
+	require.Len(receipts, 1)
+	require.False(receipts[0].Rewarded)
+	// This node preferred to reward the validator.
+	require.Equal(state.RewardCauseNetworkDecision, receipts[0].Cause)
+	require.Zero(receipts[0].Reward)
 }
 
 // Ensure BuildBlock errors when there is no block to build
@@ -825,7 +864,7 @@ func TestCreateChain(t *testing.T) {
 		ids.ID{'t', 'e', 's', 't', 'v', 'm'},
 		nil,
 		"name",
-		[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
 		ids.ShortEmpty, // change addr
 		nil,
 	)
@@ -863,6 +902,71 @@ func TestCreateChain(t *testing.T) {
 // 1) Create a subnet
 // 2) Add a validator to the subnet's current validator set
 // 3) Advance timestamp to validator's end time (removing validator from current)
+func TestOfflineVerifier(t *testing.T) {
+	require := require.New(t)
+	vm, _, _ := defaultVM(t, latestFork)
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	verifier, err := offline.New(&vm.Config, vm.ctx)
+	require.NoError(err)
+
+	tx, err := vm.txBuilder.NewCreateChainTx(
+		testSubnet1.ID(),
+		nil,
+		ids.ID{'t', 'e', 's', 't', 'v', 'm'},
+		nil,
+		"name",
+		secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
+		ids.ShortEmpty, // change addr
+		nil,
+	)
+	require.NoError(err)
+
+	parsedTx, err := offline.ParseTx(tx.Bytes())
+	require.NoError(err)
+
+	txDiff, err := verifier.VerifyTx(vm.state, parsedTx)
+	require.NoError(err)
+	_, txStatus, err := txDiff.GetTx(tx.ID())
+	require.NoError(err)
+	require.Equal(status.Committed, txStatus)
+
+	// The provided state must not be modified
+	_, _, err = vm.state.GetTx(tx.ID())
+	require.ErrorIs(err, database.ErrNotFound)
+
+	vm.ctx.Lock.Unlock()
+	require.NoError(vm.issueTx(context.Background(), tx))
+	vm.ctx.Lock.Lock()
+
+	blk, err := vm.Builder.BuildBlock(context.Background())
+	require.NoError(err)
+
+	parent, err := vm.state.GetStatelessBlock(vm.state.GetLastAccepted())
+	require.NoError(err)
+
+	parsedBlk, err := offline.ParseBlock(blk.Bytes())
+	require.NoError(err)
+
+	blkDiff, err := verifier.VerifyBlock(vm.state, parent, parsedBlk)
+	require.NoError(err)
+	_, txStatus, err = blkDiff.GetTx(tx.ID())
+	require.NoError(err)
+	require.Equal(status.Committed, txStatus)
+
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(blk.Accept(context.Background()))
+
+	// The block isn't a child of the new last accepted block
+	_, err = verifier.VerifyBlock(vm.state, parsedBlk, parsedBlk)
+	require.ErrorIs(err, offline.ErrWrongParent)
+
+	// The UTXOs consumed by the tx have been spent
+	_, err = verifier.VerifyTx(vm.state, parsedTx)
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
 func TestCreateSubnet(t *testing.T) {
 	require := require.New(t)
 	vm, _, _ := defaultVM(t, latestFork)
@@ -876,7 +980,7 @@ func TestCreateSubnet(t *testing.T) {
 			keys[0].PublicKey().Address(),
 			keys[1].PublicKey().Address(),
 		},
-		[]*secp256k1.PrivateKey{keys[0]}, // payer
+		secp256k1fx.NewKeychain(keys[0]), // payer
 		keys[0].PublicKey().Address(),    // change addr
 		nil,
 	)
@@ -920,7 +1024,7 @@ func TestCreateSubnet(t *testing.T) {
 		uint64(endTime.Unix()),
 		nodeID,
 		createSubnetTx.ID(),
-		[]*secp256k1.PrivateKey{keys[0]},
+		secp256k1fx.NewKeychain(keys[0]),
 		ids.ShortEmpty, // change addr
 		nil,
 	)
@@ -984,7 +1088,7 @@ func TestAtomicImport(t *testing.T) {
 	_, err := vm.txBuilder.NewImportTx(
 		vm.ctx.XChainID,
 		recipientKey.PublicKey().Address(),
-		[]*secp256k1.PrivateKey{keys[0]},
+		secp256k1fx.NewKeychain(keys[0]),
 		ids.ShortEmpty, // change addr
 		nil,
 	)
@@ -1024,7 +1128,7 @@ func TestAtomicImport(t *testing.T) {
 	tx, err := vm.txBuilder.NewImportTx(
 		vm.ctx.XChainID,
 		recipientKey.PublicKey().Address(),
-		[]*secp256k1.PrivateKey{recipientKey},
+		secp256k1fx.NewKeychain(recipientKey),
 		ids.ShortEmpty, // change addr
 		nil,
 	)
@@ -2023,7 +2127,7 @@ func TestRemovePermissionedValidatorDuringAddPending(t *testing.T) {
 		signer.NewProofOfPossession(sk),
 		id,
 		reward.PercentDenominator,
-		[]*secp256k1.PrivateKey{keys[0]},
+		secp256k1fx.NewKeychain(keys[0]),
 		keys[0].Address(),
 		nil,
 	)
@@ -2043,7 +2147,7 @@ func TestRemovePermissionedValidatorDuringAddPending(t *testing.T) {
 	createSubnetTx, err := vm.txBuilder.NewCreateSubnetTx(
 		1,
 		[]ids.ShortID{id},
-		[]*secp256k1.PrivateKey{keys[0]},
+		secp256k1fx.NewKeychain(keys[0]),
 		keys[0].Address(),
 		nil,
 	)
@@ -2066,7 +2170,7 @@ func TestRemovePermissionedValidatorDuringAddPending(t *testing.T) {
 		uint64(validatorEndTime.Unix()),
 		nodeID,
 		createSubnetTx.ID(),
-		[]*secp256k1.PrivateKey{key, keys[1]},
+		secp256k1fx.NewKeychain(key, keys[1]),
 		keys[1].Address(),
 		nil,
 	)
@@ -2075,7 +2179,7 @@ func TestRemovePermissionedValidatorDuringAddPending(t *testing.T) {
 	removeSubnetValidatorTx, err := vm.txBuilder.NewRemoveSubnetValidatorTx(
 		nodeID,
 		createSubnetTx.ID(),
-		[]*secp256k1.PrivateKey{key, keys[2]},
+		secp256k1fx.NewKeychain(key, keys[2]),
 		keys[2].Address(),
 		nil,
 	)
@@ -2113,7 +2217,7 @@ func TestTransferSubnetOwnershipTx(t *testing.T) {
 	createSubnetTx, err := vm.txBuilder.NewCreateSubnetTx(
 		1,
 		[]ids.ShortID{keys[0].PublicKey().Address()},
-		[]*secp256k1.PrivateKey{keys[0]},
+		secp256k1fx.NewKeychain(keys[0]),
 		keys[0].Address(),
 		nil,
 	)
@@ -2149,7 +2253,7 @@ func TestTransferSubnetOwnershipTx(t *testing.T) {
 		subnetID,
 		1,
 		[]ids.ShortID{keys[1].PublicKey().Address()},
-		[]*secp256k1.PrivateKey{keys[0]},
+		secp256k1fx.NewKeychain(keys[0]),
 		ids.ShortEmpty, // change addr
 		nil,
 	)
@@ -2181,6 +2285,467 @@ func TestTransferSubnetOwnershipTx(t *testing.T) {
 	require.Equal(expectedOwner, subnetOwner)
 }
 
+func TestSubnetAllowList(t *testing.T) {
+	require := require.New(t)
+	vm, _, _ := defaultVM(t, latestFork)
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	acceptTx := func(tx *txs.Tx) {
+		vm.ctx.Lock.Unlock()
+		require.NoError(vm.issueTx(context.Background(), tx))
+		vm.ctx.Lock.Lock()
+
+		blk, err := vm.Builder.BuildBlock(context.Background())
+		require.NoError(err)
+		require.Contains(blk.(*blockexecutor.Block).Block.Txs(), tx)
+		require.NoError(blk.Verify(context.Background()))
+		require.NoError(blk.Accept(context.Background()))
+		require.NoError(vm.SetPreference(context.Background(), vm.manager.LastAccepted()))
+	}
+
+	// Create a subnet owned by keys[0]
+	createSubnetTx, err := vm.txBuilder.NewCreateSubnetTx(
+		1,
+		[]ids.ShortID{keys[0].PublicKey().Address()},
+		secp256k1fx.NewKeychain(keys[0]),
+		keys[0].Address(),
+		nil,
+	)
+	require.NoError(err)
+	subnetID := createSubnetTx.ID()
+	acceptTx(createSubnetTx)
+
+	nodeID := genesisNodeIDs[0]
+	addEntriesTx, err := vm.txBuilder.NewAddSubnetAllowListEntriesTx(
+		subnetID,
+		[]ids.NodeID{nodeID},
+		defaultWeight,
+		&secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{keys[1].Address()},
+		},
+		secp256k1fx.NewKeychain(keys[0]),
+		keys[0].Address(),
+		nil,
+	)
+	require.NoError(err)
+	acceptTx(addEntriesTx)
+
+	allowListTxID, err := vm.state.GetSubnetAllowListEntry(subnetID, nodeID)
+	require.NoError(err)
+	require.Equal(addEntriesTx.ID(), allowListTxID)
+
+	// keys[1] doesn't control the subnet, but it owns the allow list entry
+	startTime := vm.clock.Time().Add(txexecutor.SyncBound).Add(1 * time.Second)
+	endTime := startTime.Add(defaultMinStakingDuration)
+	addValidatorTx, err := vm.txBuilder.NewAddSubnetValidatorTx(
+		defaultWeight,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		nodeID,
+		subnetID,
+		secp256k1fx.NewKeychain(keys[1]),
+		keys[1].Address(),
+		nil,
+	)
+	require.NoError(err)
+	acceptTx(addValidatorTx)
+
+	_, err = vm.state.GetCurrentValidator(subnetID, nodeID)
+	require.NoError(err)
+
+	removeEntriesTx, err := vm.txBuilder.NewRemoveSubnetAllowListEntriesTx(
+		subnetID,
+		[]ids.NodeID{nodeID},
+		secp256k1fx.NewKeychain(keys[0]),
+		keys[0].Address(),
+		nil,
+	)
+	require.NoError(err)
+	acceptTx(removeEntriesTx)
+
+	_, err = vm.state.GetSubnetAllowListEntry(subnetID, nodeID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// Removing the node from the allow list doesn't remove the validator
+	_, err = vm.state.GetCurrentValidator(subnetID, nodeID)
+	require.NoError(err)
+}
+
+func TestDelegatorStakeCap(t *testing.T) {
+	require := require.New(t)
+	vm, _, _ := defaultVM(t, latestFork)
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	issueAndAccept := func(tx *txs.Tx) {
+		vm.ctx.Lock.Unlock()
+		require.NoError(vm.issueTx(context.Background(), tx))
+		vm.ctx.Lock.Lock()
+		require.NoError(buildAndAcceptStandardBlock(vm))
+	}
+
+	var (
+		nodeID             = ids.GenerateTestNodeID()
+		startTime          = vm.clock.Time().Add(txexecutor.SyncBound).Add(time.Second)
+		validatorEndTime   = startTime.Add(2 * defaultMinStakingDuration)
+		delegatorEndTime   = startTime.Add(defaultMinStakingDuration)
+		maxDelegatorStake  = vm.MinDelegatorStake + 1
+		rewardAddress      = keys[1].Address()
+		otherRewardAddress = keys[2].Address()
+	)
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	newValidatorTx := func(rewardAddress ids.ShortID) *txs.Tx {
+		tx, err := vm.txBuilder.NewAddCappedPermissionlessValidatorTx(
+			vm.MinValidatorStake,
+			uint64(startTime.Unix()),
+			uint64(validatorEndTime.Unix()),
+			nodeID,
+			signer.NewProofOfPossession(sk),
+			rewardAddress,
+			reward.PercentDenominator,
+			maxDelegatorStake,
+			secp256k1fx.NewKeychain(keys[0]),
+			keys[0].Address(), // change address
+			nil,
+		)
+		require.NoError(err)
+		return tx
+	}
+
+	// Capped validators can't be added before the upgrade activates
+	vm.CappedDelegationTime = time.Time{}
+	vm.ctx.Lock.Unlock()
+	err = vm.issueTx(context.Background(), newValidatorTx(keys[4].Address()))
+	vm.ctx.Lock.Lock()
+	require.ErrorIs(err, txexecutor.ErrCappedDelegationNotActive)
+	vm.CappedDelegationTime = vm.DurangoTime
+
+	validatorTx := newValidatorTx(keys[0].Address())
+	issueAndAccept(validatorTx)
+
+	newDelegatorTx := func(rewardAddress ids.ShortID, endTime time.Time) *txs.Tx {
+		tx, err := vm.txBuilder.NewAddPermissionlessDelegatorTx(
+			vm.MinDelegatorStake,
+			uint64(startTime.Unix()),
+			uint64(endTime.Unix()),
+			nodeID,
+			rewardAddress,
+			secp256k1fx.NewKeychain(keys[3]),
+			keys[3].Address(), // change address
+			nil,
+		)
+		require.NoError(err)
+		return tx
+	}
+
+	issueAndAccept(newDelegatorTx(rewardAddress, delegatorEndTime))
+
+	rewardsOwnerID, err := txs.RewardsOwnerID(&secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{rewardAddress},
+	})
+	require.NoError(err)
+	delegatedStake, err := vm.state.GetDelegatedStake(validatorTx.ID(), rewardsOwnerID)
+	require.NoError(err)
+	require.Equal(vm.MinDelegatorStake, delegatedStake)
+
+	// A second delegation rewarded to the same address exceeds the cap
+	vm.ctx.Lock.Unlock()
+	err = vm.issueTx(context.Background(), newDelegatorTx(rewardAddress, delegatorEndTime))
+	vm.ctx.Lock.Lock()
+	require.ErrorIs(err, txexecutor.ErrDelegatorStakeCapExceeded)
+
+	// Delegations rewarded to other addresses are capped separately
+	issueAndAccept(newDelegatorTx(otherRewardAddress, delegatorEndTime))
+
+	// Removing the delegators releases their stake from the cap
+	vm.clock.Set(delegatorEndTime)
+	for i := 0; i < 2; i++ {
+		blk, err := vm.Builder.BuildBlock(context.Background())
+		require.NoError(err)
+		require.NoError(blk.Verify(context.Background()))
+		options, err := blk.(smcon.OracleBlock).Options(context.Background())
+		require.NoError(err)
+		commit := options[0].(*blockexecutor.Block)
+		require.NoError(commit.Verify(context.Background()))
+		require.NoError(blk.Accept(context.Background()))
+		require.NoError(commit.Accept(context.Background()))
+		require.NoError(vm.SetPreference(context.Background(), vm.manager.LastAccepted()))
+	}
+	delegatedStake, err = vm.state.GetDelegatedStake(validatorTx.ID(), rewardsOwnerID)
+	require.NoError(err)
+	require.Zero(delegatedStake)
+
+	issueAndAccept(newDelegatorTx(rewardAddress, validatorEndTime))
+}
+
+func TestAddPermissionlessValidatorWithMetadataTx(t *testing.T) {
+	require := require.New(t)
+	vm, _, _ := defaultVM(t, latestFork)
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	var (
+		nodeID    = ids.GenerateTestNodeID()
+		startTime = vm.clock.Time().Add(txexecutor.SyncBound).Add(time.Second)
+		endTime   = startTime.Add(defaultMinStakingDuration)
+	)
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	newValidatorTx := func(changeAddr ids.ShortID) *txs.Tx {
+		tx, err := vm.txBuilder.NewAddPermissionlessValidatorWithMetadataTx(
+			vm.MinValidatorStake,
+			uint64(startTime.Unix()),
+			uint64(endTime.Unix()),
+			nodeID,
+			signer.NewProofOfPossession(sk),
+			keys[0].Address(), // reward address
+			reward.PercentDenominator,
+			"https://example.com/metadata.json",
+			ids.GenerateTestID(),
+			secp256k1fx.NewKeychain(keys[0]),
+			changeAddr,
+			nil,
+		)
+		require.NoError(err)
+		return tx
+	}
+
+	// Validators can't be added with metadata before the upgrade activates
+	vm.ValidatorMetadataTime = time.Time{}
+	vm.ctx.Lock.Unlock()
+	err = vm.issueTx(context.Background(), newValidatorTx(keys[1].Address()))
+	vm.ctx.Lock.Lock()
+	require.ErrorIs(err, txexecutor.ErrValidatorMetadataNotActive)
+	vm.ValidatorMetadataTime = vm.DurangoTime
+
+	vm.ctx.Lock.Unlock()
+	require.NoError(vm.issueTx(context.Background(), newValidatorTx(keys[0].Address())))
+	vm.ctx.Lock.Lock()
+	require.NoError(buildAndAcceptStandardBlock(vm))
+
+	_, err = vm.state.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+	require.NoError(err)
+}
+
+func TestExitValidatorTx(t *testing.T) {
+	require := require.New(t)
+	vm, _, _ := defaultVM(t, latestFork)
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	issueAndAccept := func(tx *txs.Tx) {
+		vm.ctx.Lock.Unlock()
+		require.NoError(vm.issueTx(context.Background(), tx))
+		vm.ctx.Lock.Lock()
+		require.NoError(buildAndAcceptStandardBlock(vm))
+	}
+
+	var (
+		nodeID    = ids.GenerateTestNodeID()
+		startTime = vm.clock.Time().Add(txexecutor.SyncBound).Add(time.Second)
+		endTime   = startTime.Add(defaultMinStakingDuration)
+	)
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	validatorTx, err := vm.txBuilder.NewAddPermissionlessValidatorTx(
+		vm.MinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		nodeID,
+		signer.NewProofOfPossession(sk),
+		keys[1].Address(), // reward address
+		reward.PercentDenominator,
+		secp256k1fx.NewKeychain(keys[0]),
+		keys[0].Address(), // change address
+		nil,
+	)
+	require.NoError(err)
+	issueAndAccept(validatorTx)
+
+	delegatorTx, err := vm.txBuilder.NewAddPermissionlessDelegatorTx(
+		vm.MinDelegatorStake,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		nodeID,
+		keys[2].Address(), // reward address
+		secp256k1fx.NewKeychain(keys[3]),
+		keys[3].Address(), // change address
+		nil,
+	)
+	require.NoError(err)
+	issueAndAccept(delegatorTx)
+
+	validator, err := vm.state.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+	require.NoError(err)
+	delegatorIterator, err := vm.state.GetCurrentDelegatorIterator(constants.PrimaryNetworkID, nodeID)
+	require.NoError(err)
+	require.True(delegatorIterator.Next())
+	delegator := delegatorIterator.Value()
+	delegatorIterator.Release()
+	supply, err := vm.state.GetCurrentSupply(constants.PrimaryNetworkID)
+	require.NoError(err)
+
+	// Only the owner of the stake can remove the validator
+	_, err = vm.txBuilder.NewExitValidatorTx(
+		constants.PrimaryNetworkID,
+		nodeID,
+		secp256k1fx.NewKeychain(keys[1]),
+		keys[1].Address(), // change address
+		nil,
+	)
+	require.ErrorIs(err, txbuilder.ErrCantSignStakeOwner)
+
+	// Validators can't exit before the upgrade activates
+	exitTx, err := vm.txBuilder.NewExitValidatorTx(
+		constants.PrimaryNetworkID,
+		nodeID,
+		secp256k1fx.NewKeychain(keys[0]),
+		keys[1].Address(), // change address
+		nil,
+	)
+	require.NoError(err)
+	vm.ExitValidatorTime = time.Time{}
+	vm.ctx.Lock.Unlock()
+	err = vm.issueTx(context.Background(), exitTx)
+	vm.ctx.Lock.Lock()
+	require.ErrorIs(err, txexecutor.ErrExitValidatorNotActive)
+	vm.ExitValidatorTime = vm.DurangoTime
+
+	exitTx, err = vm.txBuilder.NewExitValidatorTx(
+		constants.PrimaryNetworkID,
+		nodeID,
+		secp256k1fx.NewKeychain(keys[0]),
+		keys[0].Address(), // change address
+		nil,
+	)
+	require.NoError(err)
+	issueAndAccept(exitTx)
+
+	_, err = vm.state.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+	require.ErrorIs(err, database.ErrNotFound)
+	delegatorIterator, err = vm.state.GetCurrentDelegatorIterator(constants.PrimaryNetworkID, nodeID)
+	require.NoError(err)
+	require.False(delegatorIterator.Next())
+	delegatorIterator.Release()
+
+	// The stake is returned and the potential rewards are forfeited
+	for _, stakerTx := range []*txs.Tx{validatorTx, delegatorTx} {
+		uStakerTx := stakerTx.Unsigned.(txs.PermissionlessStaker)
+		stakeUTXOID := avax.UTXOID{
+			TxID:        stakerTx.ID(),
+			OutputIndex: uint32(len(uStakerTx.Outputs())),
+		}
+		_, err := vm.state.GetUTXO(stakeUTXOID.InputID())
+		require.NoError(err)
+	}
+	newSupply, err := vm.state.GetCurrentSupply(constants.PrimaryNetworkID)
+	require.NoError(err)
+	require.Equal(supply-validator.PotentialReward-delegator.PotentialReward, newSupply)
+
+	// The delegator reports the tx that released it
+	service := &Service{vm: vm}
+	vm.ctx.Lock.Unlock()
+	var response GetTxStatusResponse
+	err = service.GetTxStatus(nil, &GetTxStatusArgs{TxID: delegatorTx.ID()}, &response)
+	vm.ctx.Lock.Lock()
+	require.NoError(err)
+	require.Equal(status.Committed, response.Status)
+	require.NotNil(response.Exit)
+	require.Equal(exitTx.ID(), response.Exit.TxID)
+	require.Equal(json.Uint64(vm.MinDelegatorStake), response.Exit.Refunded)
+	require.Equal(json.Uint64(delegator.PotentialReward), response.Exit.ForfeitedReward)
+
+	report := requireConformance(t, vm)
+	require.Equal(uint64(2), report.numStakerExits)
+}
+
+func TestSetSubnetValidatorWeightTx(t *testing.T) {
+	require := require.New(t)
+	vm, _, _ := defaultVM(t, latestFork)
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	issueAndAccept := func(tx *txs.Tx) {
+		vm.ctx.Lock.Unlock()
+		require.NoError(vm.issueTx(context.Background(), tx))
+		vm.ctx.Lock.Lock()
+		require.NoError(buildAndAcceptStandardBlock(vm))
+	}
+
+	var (
+		subnetID  = testSubnet1.ID()
+		nodeID    = genesisNodeIDs[0]
+		startTime = vm.clock.Time().Add(txexecutor.SyncBound).Add(time.Second)
+		endTime   = startTime.Add(defaultMinStakingDuration)
+	)
+	addSubnetValidatorTx, err := vm.txBuilder.NewAddSubnetValidatorTx(
+		1,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		nodeID,
+		subnetID,
+		secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
+		keys[0].Address(), // change address
+		nil,
+	)
+	require.NoError(err)
+	issueAndAccept(addSubnetValidatorTx)
+
+	_, err = vm.state.GetCurrentValidator(subnetID, nodeID)
+	require.NoError(err)
+	require.Equal(uint64(1), vm.Validators.GetWeight(subnetID, nodeID))
+
+	// Weights can't be set before the upgrade activates
+	setWeightTx, err := vm.txBuilder.NewSetSubnetValidatorWeightTx(
+		nodeID,
+		subnetID,
+		5,
+		secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
+		keys[1].Address(), // change address
+		nil,
+	)
+	require.NoError(err)
+	vm.SubnetValidatorWeightTime = time.Time{}
+	vm.ctx.Lock.Unlock()
+	err = vm.issueTx(context.Background(), setWeightTx)
+	vm.ctx.Lock.Lock()
+	require.ErrorIs(err, txexecutor.ErrSubnetValidatorWeightNotActive)
+	vm.SubnetValidatorWeightTime = vm.DurangoTime
+
+	setWeightTx, err = vm.txBuilder.NewSetSubnetValidatorWeightTx(
+		nodeID,
+		subnetID,
+		5,
+		secp256k1fx.NewKeychain(testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]),
+		keys[0].Address(), // change address
+		nil,
+	)
+	require.NoError(err)
+	issueAndAccept(setWeightTx)
+
+	// The validator keeps the tx that added it and only its weight changes
+	validator, err := vm.state.GetCurrentValidator(subnetID, nodeID)
+	require.NoError(err)
+	require.Equal(addSubnetValidatorTx.ID(), validator.TxID)
+	require.Equal(uint64(5), validator.Weight)
+	require.Equal(uint64(5), vm.Validators.GetWeight(subnetID, nodeID))
+
+	// The validator set at the height the validator was added still reports
+	// the prior weight
+	height, err := vm.GetCurrentHeight(context.Background())
+	require.NoError(err)
+	vdrs, err := vm.GetValidatorSet(context.Background(), height-1, subnetID)
+	require.NoError(err)
+	require.Equal(uint64(1), vdrs[nodeID].Weight)
+}
+
 func TestBaseTx(t *testing.T) {
 	require := require.New(t)
 	vm, _, _ := defaultVM(t, latestFork)
@@ -2198,7 +2763,7 @@ func TestBaseTx(t *testing.T) {
 				keys[1].Address(),
 			},
 		},
-		[]*secp256k1.PrivateKey{keys[0]},
+		secp256k1fx.NewKeychain(keys[0]),
 		changeAddr,
 		nil,
 	)
@@ -2274,7 +2839,7 @@ func TestPruneMempool(t *testing.T) {
 				keys[1].Address(),
 			},
 		},
-		[]*secp256k1.PrivateKey{keys[0]},
+		secp256k1fx.NewKeychain(keys[0]),
 		changeAddr,
 		nil,
 	)
@@ -2306,7 +2871,7 @@ func TestPruneMempool(t *testing.T) {
 		signer.NewProofOfPossession(sk),
 		keys[2].Address(),
 		20000,
-		[]*secp256k1.PrivateKey{keys[1]},
+		secp256k1fx.NewKeychain(keys[1]),
 		ids.ShortEmpty,
 		nil,
 	)
@@ -2337,3 +2902,94 @@ func TestPruneMempool(t *testing.T) {
 	_, ok = vm.Builder.Get(baseTxID)
 	require.True(ok)
 }
+
+func TestFeeTreasury(t *testing.T) {
+	require := require.New(t)
+	vm, _, _ := defaultVM(t, latestFork)
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	treasuryAddress := ids.GenerateTestShortID()
+	vm.FeeTreasuryAddress = treasuryAddress
+	vm.FeeTreasuryPercentage = reward.PercentDenominator / 4
+
+	createSubnetTx, err := vm.txBuilder.NewCreateSubnetTx(
+		1,
+		[]ids.ShortID{keys[0].PublicKey().Address()},
+		secp256k1fx.NewKeychain(keys[0]),
+		keys[0].Address(),
+		nil,
+	)
+	require.NoError(err)
+
+	vm.ctx.Lock.Unlock()
+	require.NoError(vm.issueTx(context.Background(), createSubnetTx))
+	vm.ctx.Lock.Lock()
+	require.NoError(buildAndAcceptStandardBlock(vm))
+
+	expectedRedirected := vm.CreateSubnetTxFee / 4
+	treasuryUTXOID := avax.UTXOID{
+		TxID:        createSubnetTx.ID(),
+		OutputIndex: txexecutor.FeeTreasuryOutputIndex,
+	}
+	utxo, err := vm.state.GetUTXO(treasuryUTXOID.InputID())
+	require.NoError(err)
+	require.Equal(vm.ctx.AVAXAssetID, utxo.AssetID())
+	out := utxo.Out.(*secp256k1fx.TransferOutput)
+	require.Equal(expectedRedirected, out.Amount())
+	require.Equal([]ids.ShortID{treasuryAddress}, out.Addrs)
+
+	lastAccepted, err := vm.manager.GetStatelessBlock(vm.manager.LastAccepted())
+	require.NoError(err)
+	height := json.Uint64(lastAccepted.Height())
+
+	service := &Service{vm: vm}
+	vm.ctx.Lock.Unlock()
+	reply := GetFeesBurnedReply{}
+	err = service.GetFeesBurned(nil, &GetFeesBurnedArgs{
+		StartHeight: height,
+		EndHeight:   height,
+	}, &reply)
+	vm.ctx.Lock.Lock()
+	require.NoError(err)
+	require.Equal(GetFeesBurnedReply{
+		Blocks: []BlockFees{{
+			Height:     height,
+			BlockID:    lastAccepted.ID(),
+			Burned:     json.Uint64(vm.CreateSubnetTxFee - expectedRedirected),
+			Redirected: json.Uint64(expectedRedirected),
+		}},
+		Burned:     json.Uint64(vm.CreateSubnetTxFee - expectedRedirected),
+		Redirected: json.Uint64(expectedRedirected),
+	}, reply)
+}
+
+func TestIncompatiblePeer(t *testing.T) {
+	require := require.New(t)
+	vm, _, _ := defaultVM(t, latestFork)
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	var (
+		compatibleNodeID   = ids.GenerateTestNodeID()
+		incompatibleNodeID = ids.GenerateTestNodeID()
+		oldVersion         = &version.Application{
+			Name:  version.Client,
+			Major: version.PrevMinimumCompatibleVersion.Major,
+			Minor: version.PrevMinimumCompatibleVersion.Minor - 1,
+		}
+	)
+	require.NoError(vm.Connected(context.Background(), compatibleNodeID, version.CurrentApp))
+	require.NoError(vm.Connected(context.Background(), incompatibleNodeID, oldVersion))
+	require.NoError(vm.ConnectedSubnet(context.Background(), incompatibleNodeID, testSubnet1.ID()))
+
+	// Incompatible peers are excluded from the uptimes
+	require.False(vm.peerCompatibility.isIncompatible(compatibleNodeID))
+	require.True(vm.uptimeManager.IsConnected(compatibleNodeID, constants.PrimaryNetworkID))
+	require.True(vm.peerCompatibility.isIncompatible(incompatibleNodeID))
+	require.False(vm.uptimeManager.IsConnected(incompatibleNodeID, constants.PrimaryNetworkID))
+	require.False(vm.uptimeManager.IsConnected(incompatibleNodeID, testSubnet1.ID()))
+
+	require.NoError(vm.Disconnected(context.Background(), incompatibleNodeID))
+	require.False(vm.peerCompatibility.isIncompatible(incompatibleNodeID))
+}
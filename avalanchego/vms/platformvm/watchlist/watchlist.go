@@ -0,0 +1,168 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package watchlist
+
+import (
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// Reward describes the staker whose reward was paid out by an accepted block.
+type Reward struct {
+	BlockID    ids.ID
+	Height     uint64
+	StakerTxID ids.ID
+	NodeID     ids.NodeID
+	SubnetID   ids.ID
+}
+
+// Accrual is a reward output that was created for a watched address.
+type Accrual struct {
+	// Index of the accrual. Accruals are indexed in the order they were
+	// recorded, starting from 0.
+	Index uint64 `json:"index"`
+
+	BlockID    ids.ID      `json:"blockID"`
+	Height     uint64      `json:"height"`
+	StakerTxID ids.ID      `json:"stakerTxID"`
+	NodeID     ids.NodeID  `json:"nodeID"`
+	SubnetID   ids.ID      `json:"subnetID"`
+	UTXOID     ids.ID      `json:"utxoID"`
+	Address    ids.ShortID `json:"address"`
+	AssetID    ids.ID      `json:"assetID"`
+	Amount     uint64      `json:"amount"`
+}
+
+// Handler is called with every accrual that is recorded. It is called
+// synchronously while the block that paid out the reward is being accepted, so
+// it must not block.
+type Handler func(Accrual)
+
+// Watchlist records the reward outputs that are created for a set of watched
+// addresses, so that reward owners don't have to poll for new UTXOs.
+//
+// The most recent accruals are kept in memory and are lost on restart.
+type Watchlist struct {
+	lock sync.RWMutex
+
+	addrs    set.Set[ids.ShortID]
+	handlers []Handler
+
+	// accruals is a ring buffer of the most recent accruals. The accrual with
+	// index i is at position i % len(accruals).
+	accruals  []Accrual
+	nextIndex uint64
+}
+
+// New returns a watchlist of [addrs] that remembers the [maxAccruals] most
+// recent accruals.
+func New(maxAccruals int, addrs []ids.ShortID) *Watchlist {
+	return &Watchlist{
+		addrs:    set.Of(addrs...),
+		accruals: make([]Accrual, max(maxAccruals, 1)),
+	}
+}
+
+// Watch adds [addrs] to the watchlist.
+func (w *Watchlist) Watch(addrs ...ids.ShortID) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.addrs.Add(addrs...)
+}
+
+// Unwatch removes [addrs] from the watchlist.
+func (w *Watchlist) Unwatch(addrs ...ids.ShortID) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.addrs.Remove(addrs...)
+}
+
+// Addresses returns the watched addresses.
+func (w *Watchlist) Addresses() []ids.ShortID {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+
+	return w.addrs.List()
+}
+
+// RegisterHandler registers [handler] to be called with every accrual that is
+// recorded after the handler was registered.
+func (w *Watchlist) RegisterHandler(handler Handler) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.handlers = append(w.handlers, handler)
+}
+
+// Record the outputs of [utxos], which were created by [reward], that are
+// owned by a watched address.
+func (w *Watchlist) Record(reward Reward, utxos []*avax.UTXO) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.addrs.Len() == 0 {
+		return
+	}
+
+	for _, utxo := range utxos {
+		out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+		if !ok {
+			continue
+		}
+		for _, addr := range out.Addrs {
+			if !w.addrs.Contains(addr) {
+				continue
+			}
+
+			accrual := Accrual{
+				Index:      w.nextIndex,
+				BlockID:    reward.BlockID,
+				Height:     reward.Height,
+				StakerTxID: reward.StakerTxID,
+				NodeID:     reward.NodeID,
+				SubnetID:   reward.SubnetID,
+				UTXOID:     utxo.InputID(),
+				Address:    addr,
+				AssetID:    utxo.AssetID(),
+				Amount:     out.Amt,
+			}
+			w.accruals[w.nextIndex%uint64(len(w.accruals))] = accrual
+			w.nextIndex++
+
+			for _, handler := range w.handlers {
+				handler(accrual)
+			}
+		}
+	}
+}
+
+// Accruals returns up to [limit] of the remembered accruals, starting at
+// [startIndex], along with the index of the next accrual to request.
+//
+// If accruals starting at [startIndex] have already been forgotten, the
+// returned accruals start with the oldest remembered accrual.
+func (w *Watchlist) Accruals(startIndex uint64, limit int) ([]Accrual, uint64) {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+
+	numRemembered := min(w.nextIndex, uint64(len(w.accruals)))
+	startIndex = max(startIndex, w.nextIndex-numRemembered)
+	if startIndex >= w.nextIndex {
+		return nil, w.nextIndex
+	}
+
+	numAccruals := min(w.nextIndex-startIndex, uint64(max(limit, 0)))
+	accruals := make([]Accrual, numAccruals)
+	for i := range accruals {
+		index := startIndex + uint64(i)
+		accruals[i] = w.accruals[index%uint64(len(w.accruals))]
+	}
+	return accruals, startIndex + numAccruals
+}
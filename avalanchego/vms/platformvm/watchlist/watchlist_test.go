@@ -0,0 +1,129 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package watchlist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func newRewardUTXO(outputIndex uint32, amount uint64, addrs ...ids.ShortID) *avax.UTXO {
+	return &avax.UTXO{
+		UTXOID: avax.UTXOID{OutputIndex: outputIndex},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: amount,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     addrs,
+			},
+		},
+	}
+}
+
+func TestWatchlistRecord(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		watchedAddr   = ids.ShortID{1}
+		unwatchedAddr = ids.ShortID{2}
+		reward        = Reward{
+			BlockID:    ids.ID{3},
+			Height:     4,
+			StakerTxID: ids.ID{5},
+			NodeID:     ids.NodeID{6},
+		}
+		w        = New(10, nil)
+		notified []Accrual
+	)
+	w.RegisterHandler(func(accrual Accrual) {
+		notified = append(notified, accrual)
+	})
+
+	// Nothing is recorded before an address is watched.
+	w.Record(reward, []*avax.UTXO{newRewardUTXO(0, 1, watchedAddr)})
+	accruals, nextIndex := w.Accruals(0, 10)
+	require.Empty(accruals)
+	require.Zero(nextIndex)
+
+	w.Watch(watchedAddr, unwatchedAddr)
+	w.Unwatch(unwatchedAddr)
+	require.Equal([]ids.ShortID{watchedAddr}, w.Addresses())
+
+	utxos := []*avax.UTXO{
+		newRewardUTXO(0, 1, watchedAddr),
+		newRewardUTXO(1, 2, unwatchedAddr),
+	}
+	w.Record(reward, utxos)
+
+	expected := []Accrual{{
+		Index:      0,
+		BlockID:    reward.BlockID,
+		Height:     reward.Height,
+		StakerTxID: reward.StakerTxID,
+		NodeID:     reward.NodeID,
+		UTXOID:     utxos[0].InputID(),
+		Address:    watchedAddr,
+		Amount:     1,
+	}}
+	accruals, nextIndex = w.Accruals(0, 10)
+	require.Equal(expected, accruals)
+	require.Equal(uint64(1), nextIndex)
+	require.Equal(expected, notified)
+}
+
+func TestWatchlistAccruals(t *testing.T) {
+	addr := ids.ShortID{1}
+	w := New(3, []ids.ShortID{addr})
+	for i := uint32(0); i < 5; i++ {
+		w.Record(Reward{}, []*avax.UTXO{newRewardUTXO(i, uint64(i), addr)})
+	}
+
+	tests := []struct {
+		name              string
+		startIndex        uint64
+		limit             int
+		expectedAmounts   []uint64
+		expectedNextIndex uint64
+	}{
+		{
+			name:              "forgotten accruals are skipped",
+			startIndex:        0,
+			limit:             10,
+			expectedAmounts:   []uint64{2, 3, 4},
+			expectedNextIndex: 5,
+		},
+		{
+			name:              "limited",
+			startIndex:        3,
+			limit:             1,
+			expectedAmounts:   []uint64{3},
+			expectedNextIndex: 4,
+		},
+		{
+			name:              "up to date",
+			startIndex:        5,
+			limit:             10,
+			expectedAmounts:   nil,
+			expectedNextIndex: 5,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			accruals, nextIndex := w.Accruals(test.startIndex, test.limit)
+			var amounts []uint64
+			for _, accrual := range accruals {
+				amounts = append(amounts, accrual.Amount)
+			}
+			require.Equal(test.expectedAmounts, amounts)
+			require.Equal(test.expectedNextIndex, nextIndex)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	stdcontext "context"
 )
@@ -69,6 +70,14 @@ func (b *backendVisitor) BaseTx(tx *txs.BaseTx) error {
 	return b.baseTx(tx)
 }
 
+func (b *backendVisitor) AddSubnetAllowListEntriesTx(tx *txs.AddSubnetAllowListEntriesTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) RemoveSubnetAllowListEntriesTx(tx *txs.RemoveSubnetAllowListEntriesTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
 func (b *backendVisitor) ImportTx(tx *txs.ImportTx) error {
 	err := b.b.removeUTXOs(
 		b.ctx,
@@ -110,6 +119,65 @@ func (b *backendVisitor) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessV
 	return b.baseTx(&tx.BaseTx)
 }
 
+func (b *backendVisitor) AddCappedPermissionlessValidatorTx(tx *txs.AddCappedPermissionlessValidatorTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) ExitValidatorTx(tx *txs.ExitValidatorTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) SetSubnetValidatorWeightTx(tx *txs.SetSubnetValidatorWeightTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) ParameterChangeTx(tx *txs.ParameterChangeTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) RekeyValidatorTx(tx *txs.RekeyValidatorTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) RegisterNameTx(tx *txs.RegisterNameTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) UpdateNameTx(tx *txs.UpdateNameTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) AddPermissionlessValidatorWithMetadataTx(tx *txs.AddPermissionlessValidatorWithMetadataTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
+// ClaimRewardTx also tracks the UTXO that pays out the claimed rewards, which
+// isn't part of the outputs of the tx.
+func (b *backendVisitor) ClaimRewardTx(tx *txs.ClaimRewardTx) error {
+	if err := b.baseTx(&tx.BaseTx); err != nil {
+		return err
+	}
+	to, ok := tx.To.(*secp256k1fx.OutputOwners)
+	if !ok {
+		return errUnknownOwnerType
+	}
+	return b.b.AddUTXO(b.ctx, constants.PlatformChainID, &avax.UTXO{
+		UTXOID: avax.UTXOID{
+			TxID:        b.txID,
+			OutputIndex: uint32(len(tx.Outs)),
+		},
+		Asset: avax.Asset{ID: tx.AssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt:          tx.Amount,
+			OutputOwners: *to,
+		},
+	})
+}
+
+func (b *backendVisitor) BindRewardAddressTx(tx *txs.BindRewardAddressTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
 func (b *backendVisitor) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
 	return b.baseTx(&tx.BaseTx)
 }
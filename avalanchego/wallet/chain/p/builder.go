@@ -1039,6 +1039,10 @@ func (b *builder) spend(
 		}
 	}
 
+	if options.UTXOSelection() == common.UTXOSelectionMinimal {
+		utxos = prioritizeUTXOs(utxos, amountsToBurn, amountsToStake, addrs, minIssuanceTime)
+	}
+
 	// Iterate over the unlocked UTXOs
 	for _, utxo := range utxos {
 		assetID := utxo.AssetID()
@@ -1146,6 +1150,66 @@ func (b *builder) spend(
 	return inputs, changeOutputs, stakeOutputs, nil
 }
 
+// prioritizeUTXOs reorders [utxos] so that the unlocked UTXOs picked by
+// [common.SelectAmounts] to cover the remaining amounts to burn and stake of
+// each asset are spent first. The other UTXOs follow in their original order.
+func prioritizeUTXOs(
+	utxos []*avax.UTXO,
+	amountsToBurn map[ids.ID]uint64,
+	amountsToStake map[ids.ID]uint64,
+	addrs set.Set[ids.ShortID],
+	minIssuanceTime uint64,
+) []*avax.UTXO {
+	var (
+		candidates = make(map[ids.ID][]int)
+		amounts    = make(map[ids.ID][]uint64)
+	)
+	for i, utxo := range utxos {
+		outIntf := utxo.Out
+		if lockedOut, ok := outIntf.(*stakeable.LockOut); ok {
+			if lockedOut.Locktime > minIssuanceTime {
+				continue
+			}
+			outIntf = lockedOut.TransferableOut
+		}
+		out, ok := outIntf.(*secp256k1fx.TransferOutput)
+		if !ok {
+			continue
+		}
+		if _, ok := common.MatchOwners(&out.OutputOwners, addrs, minIssuanceTime); !ok {
+			continue
+		}
+
+		assetID := utxo.AssetID()
+		candidates[assetID] = append(candidates[assetID], i)
+		amounts[assetID] = append(amounts[assetID], out.Amt)
+	}
+
+	prioritized := make([]*avax.UTXO, 0, len(utxos))
+	selected := set.NewSet[int](len(utxos))
+	for assetID, assetCandidates := range candidates {
+		target, err := math.Add64(amountsToBurn[assetID], amountsToStake[assetID])
+		if err != nil {
+			// The amounts can't be covered, so spend reports the shortfall
+			continue
+		}
+		for _, k := range common.SelectAmounts(amounts[assetID], target) {
+			selected.Add(assetCandidates[k])
+		}
+	}
+	for i, utxo := range utxos {
+		if selected.Contains(i) {
+			prioritized = append(prioritized, utxo)
+		}
+	}
+	for i, utxo := range utxos {
+		if !selected.Contains(i) {
+			prioritized = append(prioritized, utxo)
+		}
+	}
+	return prioritized
+}
+
 func (b *builder) authorizeSubnet(subnetID ids.ID, options *common.Options) (*secp256k1fx.Input, error) {
 	ownerIntf, err := b.backend.GetSubnetOwner(options.Context(), subnetID)
 	if err != nil {
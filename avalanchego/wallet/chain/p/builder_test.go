@@ -93,6 +93,112 @@ func TestBaseTx(t *testing.T) {
 	require.Equal(outputsToMove[0], outs[1])
 }
 
+func TestBaseTxMinimalUTXOSelection(t *testing.T) {
+	var (
+		require = require.New(t)
+
+		// backend
+		utxosKey   = testKeys[1]
+		utxos      = append(makeTestUTXOs(utxosKey), makeSmallTestUTXOs(utxosKey, 1_000)...)
+		chainUTXOs = common.NewDeterministicChainUTXOs(require, map[ids.ID][]*avax.UTXO{
+			constants.PlatformChainID: utxos,
+		})
+		backend = NewBackend(testCtx, chainUTXOs, nil)
+
+		// builder
+		utxoAddr = utxosKey.Address()
+		builder  = NewBuilder(set.Of(utxoAddr), backend)
+
+		// data to build the transaction
+		outputsToMove = []*avax.TransferableOutput{{
+			Asset: avax.Asset{ID: avaxAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 7 * units.Avax,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{utxoAddr},
+				},
+			},
+		}}
+	)
+
+	inOrderTx, err := builder.NewBaseTx(outputsToMove)
+	require.NoError(err)
+
+	utx, err := builder.NewBaseTx(
+		outputsToMove,
+		common.WithUTXOSelection(common.UTXOSelectionMinimal),
+	)
+	require.NoError(err)
+
+	// the large UTXO covers the outputs and the fee by itself
+	ins := utx.Ins
+	outs := utx.Outs
+	require.Len(ins, 1)
+	require.Len(outs, 2)
+	require.Greater(len(inOrderTx.Ins), len(ins))
+
+	expectedConsumed := testCtx.CreateSubnetTxFee() + outputsToMove[0].Out.Amount()
+	consumed := ins[0].In.Amount() - outs[0].Out.Amount()
+	require.Equal(expectedConsumed, consumed)
+	require.Equal(outputsToMove[0], outs[1])
+}
+
+func BenchmarkBaseTxUTXOSelection(b *testing.B) {
+	var (
+		require = require.New(b)
+
+		utxosKey   = testKeys[1]
+		utxos      = append(makeTestUTXOs(utxosKey), makeSmallTestUTXOs(utxosKey, 5_000)...)
+		chainUTXOs = common.NewDeterministicChainUTXOs(require, map[ids.ID][]*avax.UTXO{
+			constants.PlatformChainID: utxos,
+		})
+		backend = NewBackend(testCtx, chainUTXOs, nil)
+
+		utxoAddr = utxosKey.Address()
+		builder  = NewBuilder(set.Of(utxoAddr), backend)
+
+		outputsToMove = []*avax.TransferableOutput{{
+			Asset: avax.Asset{ID: avaxAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 12 * units.Avax,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{utxoAddr},
+				},
+			},
+		}}
+	)
+
+	benchmarks := []struct {
+		name      string
+		selection common.UTXOSelection
+	}{
+		{
+			name:      "in order",
+			selection: common.UTXOSelectionInOrder,
+		},
+		{
+			name:      "minimal",
+			selection: common.UTXOSelectionMinimal,
+		},
+	}
+	for _, benchmark := range benchmarks {
+		b.Run(benchmark.name, func(b *testing.B) {
+			var numIns int
+			for i := 0; i < b.N; i++ {
+				utx, err := builder.NewBaseTx(
+					outputsToMove,
+					common.WithUTXOSelection(benchmark.selection),
+				)
+				require.NoError(err)
+				numIns = len(utx.Ins)
+			}
+			b.ReportMetric(float64(numIns), "inputs")
+		})
+	}
+}
+
 func TestAddSubnetValidatorTx(t *testing.T) {
 	var (
 		require = require.New(t)
@@ -656,6 +762,32 @@ func TestAddPermissionlessDelegatorTx(t *testing.T) {
 	require.Equal(expectedConsumed, consumed)
 }
 
+// makeSmallTestUTXOs returns [count] unlocked UTXOs of a few MilliAvax each, as
+// held by an address that received many small payments.
+func makeSmallTestUTXOs(utxosKey *secp256k1.PrivateKey, count int) []*avax.UTXO {
+	const utxosOffset uint64 = 4048
+
+	utxosAddr := utxosKey.Address()
+	utxos := make([]*avax.UTXO, count)
+	for i := range utxos {
+		utxos[i] = &avax.UTXO{
+			UTXOID: avax.UTXOID{
+				TxID:        ids.Empty.Prefix(utxosOffset + uint64(i)),
+				OutputIndex: uint32(i),
+			},
+			Asset: avax.Asset{ID: avaxAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: uint64(1+i%10) * units.MilliAvax,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{utxosAddr},
+				},
+			},
+		}
+	}
+	return utxos
+}
+
 func makeTestUTXOs(utxosKey *secp256k1.PrivateKey) []*avax.UTXO {
 	// Note: we avoid ids.GenerateTestNodeID here to make sure that UTXO IDs won't change
 	// run by run. This simplifies checking what utxos are included in the built txs.
@@ -15,6 +15,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
 	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
@@ -30,6 +31,7 @@ var (
 	errUnknownCredentialType = errors.New("unknown credential type")
 	errUnknownOutputType     = errors.New("unknown output type")
 	errUnknownSubnetAuthType = errors.New("unknown subnet auth type")
+	errUnknownOwnerAuthType  = errors.New("unknown owner auth type")
 	errInvalidUTXOSigIndex   = errors.New("invalid UTXO signature index")
 
 	emptySig [secp256k1.SignatureLen]byte
@@ -51,6 +53,35 @@ func (*signerVisitor) RewardValidatorTx(*txs.RewardValidatorTx) error {
 	return errUnsupportedTxType
 }
 
+// ExitValidatorTx isn't supported because the wallet doesn't track the stake
+// owners of the validators that authorize it.
+func (*signerVisitor) ExitValidatorTx(*txs.ExitValidatorTx) error {
+	return errUnsupportedTxType
+}
+
+// ParameterChangeTx isn't supported because the wallet doesn't know the
+// governance owner that authorizes it.
+func (*signerVisitor) ParameterChangeTx(*txs.ParameterChangeTx) error {
+	return errUnsupportedTxType
+}
+
+// RekeyValidatorTx isn't supported for the same reason as ExitValidatorTx.
+func (*signerVisitor) RekeyValidatorTx(*txs.RekeyValidatorTx) error {
+	return errUnsupportedTxType
+}
+
+// RegisterNameTx isn't supported because the wallet doesn't track the
+// validation rewards owners that authorize it.
+func (*signerVisitor) RegisterNameTx(*txs.RegisterNameTx) error {
+	return errUnsupportedTxType
+}
+
+// UpdateNameTx isn't supported because the wallet doesn't track the owners of
+// the names that authorize it.
+func (*signerVisitor) UpdateNameTx(*txs.UpdateNameTx) error {
+	return errUnsupportedTxType
+}
+
 func (s *signerVisitor) BaseTx(tx *txs.BaseTx) error {
 	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
 	if err != nil {
@@ -143,6 +174,19 @@ func (s *signerVisitor) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidatorTx)
 	return sign(s.tx, true, txSigners)
 }
 
+func (s *signerVisitor) SetSubnetValidatorWeightTx(tx *txs.SetSubnetValidatorWeightTx) error {
+	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	subnetAuthSigners, err := s.getSubnetSigners(tx.Subnet, tx.SubnetAuth)
+	if err != nil {
+		return err
+	}
+	txSigners = append(txSigners, subnetAuthSigners)
+	return sign(s.tx, true, txSigners)
+}
+
 func (s *signerVisitor) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershipTx) error {
 	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
 	if err != nil {
@@ -156,6 +200,32 @@ func (s *signerVisitor) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershi
 	return sign(s.tx, true, txSigners)
 }
 
+func (s *signerVisitor) AddSubnetAllowListEntriesTx(tx *txs.AddSubnetAllowListEntriesTx) error {
+	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	subnetAuthSigners, err := s.getSubnetSigners(tx.Subnet, tx.SubnetAuth)
+	if err != nil {
+		return err
+	}
+	txSigners = append(txSigners, subnetAuthSigners)
+	return sign(s.tx, true, txSigners)
+}
+
+func (s *signerVisitor) RemoveSubnetAllowListEntriesTx(tx *txs.RemoveSubnetAllowListEntriesTx) error {
+	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	subnetAuthSigners, err := s.getSubnetSigners(tx.Subnet, tx.SubnetAuth)
+	if err != nil {
+		return err
+	}
+	txSigners = append(txSigners, subnetAuthSigners)
+	return sign(s.tx, true, txSigners)
+}
+
 func (s *signerVisitor) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
 	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
 	if err != nil {
@@ -177,6 +247,56 @@ func (s *signerVisitor) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessVa
 	return sign(s.tx, true, txSigners)
 }
 
+func (s *signerVisitor) AddCappedPermissionlessValidatorTx(tx *txs.AddCappedPermissionlessValidatorTx) error {
+	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	return sign(s.tx, true, txSigners)
+}
+
+func (s *signerVisitor) AddPermissionlessValidatorWithMetadataTx(tx *txs.AddPermissionlessValidatorWithMetadataTx) error {
+	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	return sign(s.tx, true, txSigners)
+}
+
+func (s *signerVisitor) ClaimRewardTx(tx *txs.ClaimRewardTx) error {
+	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	ownerInput, ok := tx.OwnerAuth.(*secp256k1fx.Input)
+	if !ok {
+		return errUnknownOwnerAuthType
+	}
+	ownerSigners, err := s.getOwnerSigners(ownerInput, tx.Owner)
+	if err != nil {
+		return err
+	}
+	txSigners = append(txSigners, ownerSigners)
+	return sign(s.tx, true, txSigners)
+}
+
+func (s *signerVisitor) BindRewardAddressTx(tx *txs.BindRewardAddressTx) error {
+	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	ownerInput, ok := tx.OwnerAuth.(*secp256k1fx.Input)
+	if !ok {
+		return errUnknownOwnerAuthType
+	}
+	ownerSigners, err := s.getOwnerSigners(ownerInput, tx.Owner)
+	if err != nil {
+		return err
+	}
+	txSigners = append(txSigners, ownerSigners)
+	return sign(s.tx, true, txSigners)
+}
+
 func (s *signerVisitor) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
 	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
 	if err != nil {
@@ -254,13 +374,19 @@ func (s *signerVisitor) getSubnetSigners(subnetID ids.ID, subnetAuth verify.Veri
 			err,
 		)
 	}
+	return s.getOwnerSigners(subnetInput, ownerIntf)
+}
+
+// getOwnerSigners returns the keys that sign [input] to prove control of
+// [ownerIntf]. Keys that aren't in the keychain are left nil.
+func (s *signerVisitor) getOwnerSigners(input *secp256k1fx.Input, ownerIntf fx.Owner) ([]keychain.Signer, error) {
 	owner, ok := ownerIntf.(*secp256k1fx.OutputOwners)
 	if !ok {
 		return nil, errUnknownOwnerType
 	}
 
-	authSigners := make([]keychain.Signer, len(subnetInput.SigIndices))
-	for sigIndex, addrIndex := range subnetInput.SigIndices {
+	authSigners := make([]keychain.Signer, len(input.SigIndices))
+	for sigIndex, addrIndex := range input.SigIndices {
 		if addrIndex >= uint32(len(owner.Addrs)) {
 			return nil, errInvalidUTXOSigIndex
 		}
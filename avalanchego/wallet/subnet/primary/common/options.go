@@ -43,6 +43,8 @@ type Options struct {
 
 	memo []byte
 
+	utxoSelection UTXOSelection
+
 	assumeDecided bool
 
 	pollFrequencySet bool
@@ -121,6 +123,10 @@ func (o *Options) Memo() []byte {
 	return o.memo
 }
 
+func (o *Options) UTXOSelection() UTXOSelection {
+	return o.utxoSelection
+}
+
 func (o *Options) AssumeDecided() bool {
 	return o.assumeDecided
 }
@@ -187,6 +193,12 @@ func WithMemo(memo []byte) Option {
 	}
 }
 
+func WithUTXOSelection(selection UTXOSelection) Option {
+	return func(o *Options) {
+		o.utxoSelection = selection
+	}
+}
+
 func WithAssumeDecided() Option {
 	return func(o *Options) {
 		o.assumeDecided = true
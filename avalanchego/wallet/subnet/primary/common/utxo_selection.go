@@ -0,0 +1,158 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"math"
+	"slices"
+	"sort"
+)
+
+const (
+	// UTXOSelectionInOrder spends the UTXOs in the order they are provided
+	// until enough value has been consumed.
+	UTXOSelectionInOrder UTXOSelection = iota
+	// UTXOSelectionMinimal spends the fewest UTXOs that cover the amount
+	// exactly, so that no change is returned. If no such UTXOs are found, it
+	// spends the fewest UTXOs that cover the amount with the least change.
+	UTXOSelectionMinimal
+)
+
+// maxSelectionTries bounds the number of branches explored while searching for
+// UTXOs that cover an amount exactly.
+const maxSelectionTries = 100_000
+
+// UTXOSelection is the strategy used to pick the UTXOs a tx spends
+type UTXOSelection byte
+
+// SelectAmounts returns the indices, in increasing order, of the [amounts] to
+// spend to cover [target] with as few inputs and as little change as possible.
+//
+// A branch and bound search looks for the fewest amounts that sum to [target]
+// exactly. If none is found within [maxSelectionTries] branches, the largest
+// amounts are selected, with the last one replaced by the smallest amount that
+// still covers [target].
+//
+// Nil is returned if [amounts] can't cover [target].
+func SelectAmounts(amounts []uint64, target uint64) []int {
+	if target == 0 {
+		return nil
+	}
+
+	order := make([]int, len(amounts))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return amounts[order[i]] > amounts[order[j]]
+	})
+
+	// remaining[i] is the sum of the amounts at order[i:], saturated at
+	// [math.MaxUint64].
+	remaining := make([]uint64, len(order)+1)
+	for i := len(order) - 1; i >= 0; i-- {
+		remaining[i] = remaining[i+1] + amounts[order[i]]
+		if remaining[i] < remaining[i+1] {
+			remaining[i] = math.MaxUint64
+		}
+	}
+	if remaining[0] < target {
+		return nil
+	}
+
+	s := &exactSearch{
+		amounts:   amounts,
+		order:     order,
+		remaining: remaining,
+		target:    target,
+	}
+	s.search(0, 0)
+
+	selected := s.best
+	if selected == nil {
+		selected = selectLargestFirst(amounts, order, target)
+	}
+	slices.Sort(selected)
+	return selected
+}
+
+// exactSearch finds the fewest amounts that sum to [target]
+type exactSearch struct {
+	amounts   []uint64
+	order     []int
+	remaining []uint64
+	target    uint64
+
+	tries   int
+	current []int
+	best    []int
+}
+
+// search explores the selections that include the amounts of [s.current] and
+// any of the amounts at [s.order[pos:]]. [sum] is the sum of [s.current].
+func (s *exactSearch) search(pos int, sum uint64) {
+	if s.tries >= maxSelectionTries {
+		return
+	}
+	s.tries++
+
+	if sum == s.target {
+		if s.best == nil || len(s.current) < len(s.best) {
+			s.best = slices.Clone(s.current)
+		}
+		return
+	}
+
+	needed := s.target - sum
+	switch {
+	case pos == len(s.order):
+		return
+	case s.best != nil && len(s.current)+1 >= len(s.best):
+		// Adding another amount can't beat the best selection
+		return
+	case s.remaining[pos] < needed:
+		// The remaining amounts can't cover the target
+		return
+	}
+
+	amount := s.amounts[s.order[pos]]
+	if amount <= needed {
+		s.current = append(s.current, s.order[pos])
+		s.search(pos+1, sum+amount)
+		s.current = s.current[:len(s.current)-1]
+	}
+
+	// Excluding an amount and including an equal one later would explore
+	// the same sums again, so equal amounts are skipped together.
+	next := pos + 1
+	for next < len(s.order) && s.amounts[s.order[next]] == amount {
+		next++
+	}
+	s.search(next, sum)
+}
+
+// selectLargestFirst returns the fewest amounts that cover [target]. [order]
+// must sort [amounts] in decreasing order and the amounts must cover [target].
+func selectLargestFirst(amounts []uint64, order []int, target uint64) []int {
+	var (
+		selected []int
+		sum      uint64
+	)
+	for pos, i := range order {
+		needed := target - sum
+		if amounts[i] < needed {
+			selected = append(selected, i)
+			sum += amounts[i]
+			continue
+		}
+
+		// Any amount that covers the rest of the target completes the
+		// selection, so the smallest one is spent to minimize the change.
+		last := pos + sort.Search(len(order)-pos, func(k int) bool {
+			return amounts[order[pos+k]] < needed
+		}) - 1
+		return append(selected, order[last])
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectAmounts(t *testing.T) {
+	tests := []struct {
+		name     string
+		amounts  []uint64
+		target   uint64
+		expected []int
+	}{
+		{
+			name:     "no target",
+			amounts:  []uint64{1, 2, 3},
+			target:   0,
+			expected: nil,
+		},
+		{
+			name:     "insufficient amounts",
+			amounts:  []uint64{1, 2, 3},
+			target:   7,
+			expected: nil,
+		},
+		{
+			name:     "single exact amount",
+			amounts:  []uint64{1, 5, 3, 2},
+			target:   3,
+			expected: []int{2},
+		},
+		{
+			name:     "fewest exact amounts",
+			amounts:  []uint64{1, 1, 1, 1, 4, 6},
+			target:   10,
+			expected: []int{4, 5},
+		},
+		{
+			name:     "exact match preferred over fewer inputs with change",
+			amounts:  []uint64{2, 3, 6},
+			target:   5,
+			expected: []int{0, 1},
+		},
+		{
+			name:     "smallest covering amount",
+			amounts:  []uint64{10, 20, 30},
+			target:   15,
+			expected: []int{1},
+		},
+		{
+			name:     "largest amounts then smallest covering amount",
+			amounts:  []uint64{50, 30, 3, 45},
+			target:   92,
+			expected: []int{0, 3},
+		},
+		{
+			name:     "saturated sums",
+			amounts:  []uint64{math.MaxUint64, math.MaxUint64, 1},
+			target:   math.MaxUint64,
+			expected: []int{0},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, SelectAmounts(test.amounts, test.target))
+		})
+	}
+}
+
+func TestSelectAmountsCoversTarget(t *testing.T) {
+	require := require.New(t)
+
+	r := rand.New(rand.NewSource(0)) //#nosec G404
+	for i := 0; i < 100; i++ {
+		amounts := make([]uint64, 1+r.Intn(200))
+		var total uint64
+		for j := range amounts {
+			amounts[j] = 1 + uint64(r.Intn(1_000_000))
+			total += amounts[j]
+		}
+		target := 1 + uint64(r.Int63n(int64(total)))
+
+		selected := SelectAmounts(amounts, target)
+		require.NotEmpty(selected)
+
+		var sum uint64
+		for _, k := range selected {
+			sum += amounts[k]
+		}
+		require.GreaterOrEqual(sum, target)
+	}
+}
+
+func BenchmarkSelectAmounts(b *testing.B) {
+	for _, numAmounts := range []int{1_000, 5_000, 20_000} {
+		r := rand.New(rand.NewSource(0)) //#nosec G404
+		amounts := make([]uint64, numAmounts)
+		var total uint64
+		for i := range amounts {
+			amounts[i] = 1 + uint64(r.Intn(1_000_000_000))
+			total += amounts[i]
+		}
+		target := total / 3
+
+		b.Run(fmt.Sprintf("%d amounts", numAmounts), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				SelectAmounts(amounts, target)
+			}
+		})
+	}
+}